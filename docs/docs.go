@@ -24,16 +24,16 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admins/me": {
+        "/adjustments/pending": {
             "get": {
-                "description": "Retrieves the profile information of the authenticated admin.",
+                "description": "Lists the adjustments waiting for the approval of the authenticated admin, oldest first, across the establishments that designated them adjustment approver.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Get Admin Profile",
+                "summary": "List Pending Adjustments",
                 "parameters": [
                     {
                         "type": "string",
@@ -47,7 +47,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.AdminResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AccountAdjustmentResponse"
+                            }
                         }
                     },
                     "401": {
@@ -69,19 +72,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates the profile information of the authenticated admin.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/adjustments/{id}/approve": {
+            "post": {
+                "description": "Approves an adjustment waiting for approval, applying it to the balance of its credit account. Only the adjustment approver of the account's establishment can approve it, and never their own adjustments.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Update Admin Profile",
+                "summary": "Approve Adjustment",
                 "parameters": [
                     {
                         "type": "string",
@@ -91,20 +93,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Updated admin data",
-                        "name": "admin",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "Adjustment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.AdminResponse"
+                            "$ref": "#/definitions/response.AccountAdjustmentResponse"
                         }
                     },
                     "400": {
@@ -125,6 +125,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -134,9 +146,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients": {
+        "/adjustments/{id}/reject": {
             "post": {
-                "description": "Creates a new client user with an associated credit account. Only Admins can create clients.",
+                "description": "Rejects an adjustment waiting for approval with a reason, leaving the balance alone. Only the adjustment approver of the account's establishment can reject it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -144,9 +156,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Create Client",
+                "summary": "Reject Adjustment",
                 "parameters": [
                     {
                         "type": "string",
@@ -156,20 +168,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Client data",
-                        "name": "client",
+                        "type": "integer",
+                        "description": "Adjustment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the rejection",
+                        "name": "rejection",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateClientRequest"
+                            "$ref": "#/definitions/request.RejectAccountAdjustmentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UserResponse"
+                            "$ref": "#/definitions/response.AccountAdjustmentResponse"
                         }
                     },
                     "400": {
@@ -178,12 +197,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -193,16 +230,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/me/account-statement": {
-            "get": {
-                "description": "Retrieves an account statement for the client within a specified date range.",
-                "produces": [
-                    "application/json"
-                ],
+        "/admins/impersonate/stop": {
+            "post": {
+                "description": "Stops an impersonation, after which its token is rejected right away. Called with the impersonation token it stops that impersonation; called with an admin's own token it stops every impersonation of the admin still going on.",
                 "tags": [
-                    "Clients"
+                    "Admins"
                 ],
-                "summary": "Get Client Account Statement",
+                "summary": "Stop Impersonation",
                 "parameters": [
                     {
                         "type": "string",
@@ -210,35 +244,20 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "endDate",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.AccountStatementResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -252,16 +271,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/me/account-statement/pdf": {
-            "get": {
-                "description": "Generates and downloads a PDF account statement for the client within a specified date range.",
+        "/admins/impersonate/{clientID}": {
+            "post": {
+                "description": "Issues a short-lived impersonation token to see the API exactly as a client of the admin's establishment (or the selected branch) sees it. The token is flagged as an impersonation, acts as the client's access token only on the read-only client endpoints (GET /clients/me/...) and only for the establishment it was started from, and can't be refreshed. Every request made with it is audited. Stop it with POST /admins/impersonate/stop. Only Admins can impersonate clients.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "application/pdf"
+                    "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Admins"
                 ],
-                "summary": "Get Client Account Statement (PDF)",
+                "summary": "Impersonate Client",
                 "parameters": [
                     {
                         "type": "string",
@@ -271,23 +293,33 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "endDate",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the impersonation",
+                        "name": "impersonation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ImpersonateClientRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/response.ImpersonationTokenResponse"
                         }
                     },
                     "400": {
@@ -302,6 +334,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -311,16 +355,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/me/account-summary": {
+        "/admins/impersonations": {
             "get": {
-                "description": "Retrieves a summary of the client's account, including transactions, payments, debts, and interest.",
+                "description": "Lists the latest impersonations of the authenticated admin, newest first, with the audit trail of every request made with each token, including the ones refused. Only Admins can see them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Admins"
                 ],
-                "summary": "Get Client Account Summary",
+                "summary": "List Impersonations",
                 "parameters": [
                     {
                         "type": "string",
@@ -334,17 +378,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.AccountSummaryResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ImpersonationResponse"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -358,19 +405,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/me/balance": {
+        "/admins/me": {
             "get": {
-                "description": "Gets the current balance of the authenticated client's credit account.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves the profile information of the authenticated admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Users"
                 ],
-                "summary": "Get Client Balance",
+                "summary": "Get Admin Profile",
                 "parameters": [
                     {
                         "type": "string",
@@ -384,17 +428,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.ClientBalanceResponse"
+                            "$ref": "#/definitions/response.AdminResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -406,11 +450,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/clients/me/credit-account": {
-            "get": {
-                "description": "Gets the credit account details of the authenticated client.",
+            },
+            "put": {
+                "description": "Updates the profile information of the authenticated admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -418,9 +460,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Users"
                 ],
-                "summary": "Get Client Credit Account",
+                "summary": "Update Admin Profile",
                 "parameters": [
                     {
                         "type": "string",
@@ -428,13 +470,22 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Updated admin data",
+                        "name": "admin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreditAccountResponse"
+                            "$ref": "#/definitions/response.AdminResponse"
                         }
                     },
                     "400": {
@@ -449,6 +500,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -458,19 +515,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/me/installments": {
+        "/admins/me/home": {
             "get": {
-                "description": "Gets the installments of the authenticated client's credit account.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets everything the dashboard of the admin app shows of the establishment in one call: whether it is active, inactive or suspended by the platform, the payments collected today in its time zone and what they add up to, how many transactions wait for their confirmation code, how many credit accounts are past their monthly due date with a balance, the 10 active products with the least stock at or below 5 and the last 10 entries of the activity of its credit accounts, with their clients.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Admins"
                 ],
-                "summary": "Get Client Installments",
+                "summary": "Get Admin Home",
                 "parameters": [
                     {
                         "type": "string",
@@ -478,26 +532,35 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.InstallmentResponse"
-                            }
+                            "$ref": "#/definitions/response.AdminHomeResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -511,19 +574,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/me/overdue-balance": {
+        "/attachments/{id}/download": {
             "get": {
-                "description": "Gets the overdue balance of the authenticated client's credit account.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Downloads an attached document. Available to the admins of its establishment and the client it belongs to.",
                 "produces": [
-                    "application/json"
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
                 ],
                 "tags": [
-                    "Clients"
+                    "Attachments"
                 ],
-                "summary": "Get Client Overdue Balance",
+                "summary": "Download Document",
                 "parameters": [
                     {
                         "type": "string",
@@ -531,16 +593,20 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    }
-                ],
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The document",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "number"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -555,6 +621,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -564,9 +636,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/me/password": {
-            "put": {
-                "description": "Updates the password for the authenticated client.",
+        "/clients": {
+            "post": {
+                "description": "Creates a new client user with an associated credit account. A client that already has an account in another establishment (same email and DNI) only gets a new credit account. A DNI or email of another user is rejected with 409 Conflict and a message naming it. With credit_template_id, the credit terms the request leaves out are taken from that credit template of the establishment. Only Admins can create clients.",
                 "consumes": [
                     "application/json"
                 ],
@@ -576,7 +648,7 @@ const docTemplate = `{
                 "tags": [
                     "Users"
                 ],
-                "summary": "Update Client Password",
+                "summary": "Create Client",
                 "parameters": [
                     {
                         "type": "string",
@@ -586,23 +658,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "New password data",
-                        "name": "newPassword",
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Client data",
+                        "name": "client",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                            "$ref": "#/definitions/request.CreateClientRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.UserResponse"
                         }
                     },
                     "400": {
@@ -611,8 +686,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "402": {
+                        "description": "Payment Required",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -622,23 +697,38 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/clients/me/transactions": {
+        "/clients/me/account-statement": {
             "get": {
-                "description": "Gets the transaction history of the authenticated client.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves an account statement for the client within a specified date range. Ranges longer than the role's limit are rejected with suggested smaller ranges.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Clients"
                 ],
-                "summary": "Get Client Transactions",
+                "summary": "Get Client Account Statement",
                 "parameters": [
                     {
                         "type": "string",
@@ -646,16 +736,37 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, ISO-8601 (2026-10-14 or 2026-10-14T09:30:00-05:00). Defaults to the longest range allowed for the caller's role",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, ISO-8601. Defaults to today",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Named range instead of startDate and endDate: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days",
+                        "name": "range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.TransactionResponse"
-                            }
+                            "$ref": "#/definitions/response.AccountStatementResponse"
                         }
                     },
                     "400": {
@@ -670,6 +781,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -679,16 +796,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/{clientID}/credit-account": {
+        "/clients/me/account-statement/pdf": {
             "get": {
-                "description": "Retrieves a credit account associated with a specific client.",
+                "description": "Generates and downloads a PDF account statement for the client within a specified date range, in the language of the Accept-Language header or else the establishment's. Ranges longer than the role's export limit are rejected with suggested smaller ranges. Long statements are better requested with POST /clients/me/account-statement/pdf/jobs, which renders them in the background.",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Get Credit Account by Client ID",
+                "summary": "Get Client Account Statement (PDF)",
                 "parameters": [
                     {
                         "type": "string",
@@ -697,19 +814,42 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Start date, ISO-8601 (2026-10-14 or 2026-10-14T09:30:00-05:00). Defaults to the longest range allowed for the caller's role",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, ISO-8601. Defaults to today",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Named range instead of startDate and endDate: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days",
+                        "name": "range",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Client ID",
-                        "name": "clientID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the PDF, es or en. Defaults to the establishment's",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreditAccountResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -718,6 +858,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -731,19 +883,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates credit account details of a client. Only Admins can update credit accounts.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/clients/me/account-statement/pdf/jobs": {
+            "post": {
+                "description": "Queues the rendering of the client's PDF account statement within a specified date range, in the language of the Accept-Language header or else the establishment's, and answers right away with the job. Follow it with GET /jobs/{id} and download the PDF from GET /jobs/{id}/result once it succeeded. Ranges longer than the role's export limit are rejected with suggested smaller ranges.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Update Client Credit Account",
+                "summary": "Request Client Account Statement (PDF) in the Background",
                 "parameters": [
                     {
                         "type": "string",
@@ -752,28 +903,42 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Start date, ISO-8601 (2026-10-14 or 2026-10-14T09:30:00-05:00). Defaults to the longest range allowed for the caller's role",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, ISO-8601. Defaults to today",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Named range instead of startDate and endDate: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days",
+                        "name": "range",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Client User ID",
-                        "name": "clientID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated credit account data",
-                        "name": "creditAccount",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateCreditAccountRequest"
-                        }
+                        "type": "string",
+                        "description": "Language of the PDF, es or en. Defaults to the establishment's",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/response.CreditAccountResponse"
+                            "$ref": "#/definitions/response.JobResponse"
                         }
                     },
                     "400": {
@@ -788,8 +953,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "402": {
+                        "description": "Payment Required",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -809,19 +974,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts": {
-            "post": {
-                "description": "Creates a new credit account for a client.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/clients/me/account-summary": {
+            "get": {
+                "description": "Retrieves a summary of the client's account, including transactions, payments, debts, and interest.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Create Credit Account",
+                "summary": "Get Client Account Summary",
                 "parameters": [
                     {
                         "type": "string",
@@ -831,20 +993,17 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Credit account data",
-                        "name": "creditAccount",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateCreditAccountRequest"
-                        }
+                        "type": "integer",
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreditAccountResponse"
+                            "$ref": "#/definitions/response.AccountSummaryResponse"
                         }
                     },
                     "400": {
@@ -859,8 +1018,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -874,16 +1033,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/debt-summary": {
+        "/clients/me/activity": {
             "get": {
-                "description": "Retrieves a summary of all client debts for an establishment. Only Admins can access this endpoint.",
+                "description": "Gets the activity feed of the authenticated client's credit account, newest first: purchases, payments, interest charges, late fees, credit limit changes, blocks and reversals. Each entry has an icon and title for the app to show. The maximum page size depends on the caller's role. The number of entries is sent in the X-Total-Count header.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Get Admin Debt Summary",
+                "summary": "Get Client Activity",
                 "parameters": [
                     {
                         "type": "string",
@@ -891,6 +1050,24 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (starts at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -899,10 +1076,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.AdminDebtSummary"
+                                "$ref": "#/definitions/response.ActivityResponse"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -915,6 +1098,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -924,16 +1113,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/overdue": {
+        "/clients/me/balance": {
             "get": {
-                "description": "Retrieves all overdue credit accounts for the authenticated admin\\'s establishment.",
+                "description": "Gets the current balance of the authenticated client's credit account, and what is left of its spending limit this week or month if it has one. Supports conditional requests for polling: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the balance is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Get Overdue Credit Accounts",
+                "summary": "Get Client Balance",
                 "parameters": [
                     {
                         "type": "string",
@@ -941,26 +1133,78 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.CreditAccountResponse"
+                            "$ref": "#/definitions/response.ClientBalanceResponse"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached response is current",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -974,16 +1218,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{creditAccountID}/apply-interest": {
-            "post": {
-                "description": "Applies interest to a specific credit account. Only Admins can apply interest.",
+        "/clients/me/credit-account": {
+            "get": {
+                "description": "Gets the credit account details of the authenticated client in one establishment. Supports conditional requests for polling: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the account is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Apply Interest to Account",
+                "summary": "Get Client Credit Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -994,19 +1241,58 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "creditAccountID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "$ref": "#/definitions/response.CreditAccountResponse"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached response is current",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
                             }
                         }
                     },
@@ -1022,12 +1308,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1043,16 +1323,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{creditAccountID}/apply-late-fee": {
-            "post": {
-                "description": "Applies a late fee to a specific credit account. Only Admins can apply late fees.",
+        "/clients/me/credit-accounts": {
+            "get": {
+                "description": "Lists the credit accounts of the authenticated client, one per establishment they buy on credit at.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Apply Late Fee to Account",
+                "summary": "List Client Credit Accounts",
                 "parameters": [
                     {
                         "type": "string",
@@ -1062,20 +1342,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "creditAccountID",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Comma-separated top-level fields to return of each item, e.g. id,client_id,current_balance. Defaults to all",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nested objects to embed in each item: client, establishment, both (default) or none with an empty value",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CreditAccountResponse"
                             }
                         }
                     },
@@ -1091,18 +1376,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1112,16 +1385,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{creditAccountID}/installments": {
+        "/clients/me/credit-agreement": {
             "get": {
-                "description": "Retrieves installments associated with a specific credit account.",
+                "description": "Returns the credit agreement of the authenticated client's account, generated when the account was opened with its terms: credit limit, interest rate, TCEA, due day and late fee. Purchases on the account are refused until the client accepts it. Accounts opened before agreements were introduced have none.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Clients"
                 ],
-                "summary": "Get Installments by Credit Account ID",
+                "summary": "Get Client Credit Agreement",
                 "parameters": [
                     {
                         "type": "string",
@@ -1132,20 +1405,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "creditAccountID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.InstallmentResponse"
-                            }
+                            "$ref": "#/definitions/response.CreditAgreementResponse"
                         }
                     },
                     "400": {
@@ -1154,6 +1423,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1163,16 +1450,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{creditAccountID}/installments/overdue": {
-            "get": {
-                "description": "Retrieves overdue installments for a specific credit account.",
+        "/clients/me/credit-agreement/accept": {
+            "post": {
+                "description": "Accepts the credit agreement of the authenticated client's account, recording the time, the IP address and user agent of the request and the SHA-256 hash of the agreement text. Purchases on the account are allowed from then on. Fails with 409 if it was already accepted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Clients"
                 ],
-                "summary": "Get Overdue Installments by Credit Account ID",
+                "summary": "Accept Client Credit Agreement",
                 "parameters": [
                     {
                         "type": "string",
@@ -1183,20 +1470,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "creditAccountID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.InstallmentResponse"
-                            }
+                            "$ref": "#/definitions/response.CreditAgreementResponse"
                         }
                     },
                     "400": {
@@ -1205,6 +1488,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1214,19 +1521,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{creditAccountID}/payments": {
-            "post": {
-                "description": "Processes a payment towards a client's credit account.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/clients/me/credit-agreement/pdf": {
+            "get": {
+                "description": "Downloads the credit agreement of the authenticated client's account. Once accepted, it carries the date and IP of the acceptance and the SHA-256 hash of the accepted text.",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Process Payment",
+                "summary": "Download Client Credit Agreement (PDF)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1237,29 +1541,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "creditAccountID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Payment details",
-                        "name": "payment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateTransactionRequest"
-                        }
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1280,6 +1571,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1289,19 +1586,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{creditAccountID}/purchases": {
-            "post": {
-                "description": "Processes a purchase on a client's credit account.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/clients/me/home": {
+            "get": {
+                "description": "Gets everything the home screen of the app shows of the authenticated client's credit account in one call: its available credit, when the next payment is due and how much it is, whether it is overdue, the last 5 movements of its activity feed and how many notifications about it the client hasn't read. Long-term accounts are due their earliest installment not paid in full, and short-term ones their balance on the monthly due date.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Process Purchase",
+                "summary": "Get Client Home",
                 "parameters": [
                     {
                         "type": "string",
@@ -1312,29 +1606,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "creditAccountID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Purchase details",
-                        "name": "purchase",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateTransactionRequest"
-                        }
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ClientHomeResponse"
                         }
                     },
                     "400": {
@@ -1355,6 +1636,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1364,9 +1651,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{creditAccountID}/transactions": {
+        "/clients/me/installments": {
             "get": {
-                "description": "Get all transactions for a specific credit account.",
+                "description": "Gets the installments of the authenticated client's credit account. Supports conditional requests for polling: send the ETag back in If-None-Match to get 304 Not Modified while no installment changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1374,9 +1661,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Clients"
                 ],
-                "summary": "Get Transaction by Credit Account ID",
+                "summary": "Get Client Installments",
                 "parameters": [
                     {
                         "type": "string",
@@ -1387,10 +1674,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "creditAccountID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1399,7 +1691,30 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.TransactionResponse"
+                                "$ref": "#/definitions/response.InstallmentResponse"
+                            }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached response is current",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
                             }
                         }
                     },
@@ -1415,8 +1730,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -1430,19 +1745,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/credit-accounts/{id}": {
+        "/clients/me/notifications": {
             "get": {
-                "description": "Gets a credit account by its ID.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
+                "description": "Lists the notifications sent to the authenticated client, newest first: payment reminders, confirmations, overdue notices, payment links and codes and bank transfer receipts, whether or not they reached the client by email or text. With establishment_id, only those about the client's account at that establishment; impersonating admins only see those of their establishment. The maximum page size depends on the caller's role. The number of notifications is sent in the X-Total-Count header.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Get Credit Account by ID",
+                "summary": "List Client Notifications",
                 "parameters": [
                     {
                         "type": "string",
@@ -1453,17 +1765,37 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only the notifications of the client's account at this establishment",
+                        "name": "establishment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only the notifications not read yet",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (starts at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreditAccountResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClientNotificationResponse"
+                            }
                         }
                     },
                     "400": {
@@ -1472,8 +1804,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -1485,19 +1823,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/clients/me/notifications/read": {
             "put": {
-                "description": "Updates a credit account by its ID. Only Admins can update credit accounts.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Marks every unread notification of the authenticated client read, and returns how many were.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Update Credit Account",
+                "summary": "Mark All Notifications Read",
                 "parameters": [
                     {
                         "type": "string",
@@ -1505,35 +1842,16 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Credit Account ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated credit account data",
-                        "name": "creditAccount",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateCreditAccountRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreditAccountResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
                         }
                     },
                     "401": {
@@ -1548,12 +1866,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1561,16 +1873,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a credit account by its ID. Only Admins can delete credit accounts.",
+            }
+        },
+        "/clients/me/notifications/{id}/read": {
+            "put": {
+                "description": "Marks one of the authenticated client's notifications read. Notifications read before keep when they were read.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Delete Credit Account",
+                "summary": "Mark Notification Read",
                 "parameters": [
                     {
                         "type": "string",
@@ -1581,15 +1895,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Credit Account ID",
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1624,9 +1944,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/establishments": {
-            "post": {
-                "description": "Creates a new establishment for the authenticated admin.",
+        "/clients/me/overdue-balance": {
+            "get": {
+                "description": "Gets the overdue balance of the authenticated client's credit account.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1634,9 +1954,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Establishments"
+                    "Clients"
                 ],
-                "summary": "Create Establishment",
+                "summary": "Get Client Overdue Balance",
                 "parameters": [
                     {
                         "type": "string",
@@ -1646,20 +1966,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Establishment data",
-                        "name": "establishment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateEstablishmentRequest"
-                        }
+                        "type": "integer",
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.EstablishmentResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number"
+                            }
                         }
                     },
                     "400": {
@@ -1674,6 +1994,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1683,16 +2009,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/establishments/me": {
-            "get": {
-                "description": "Gets the establishment details for the authenticated admin.",
+        "/clients/me/password": {
+            "put": {
+                "description": "Updates the password for the authenticated client.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Establishments"
+                    "Users"
                 ],
-                "summary": "Get Establishment",
+                "summary": "Update Client Password",
                 "parameters": [
                     {
                         "type": "string",
@@ -1700,37 +2029,51 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "New password data",
+                        "name": "newPassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.EstablishmentResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates the establishment details for the authenticated admin.",
+            }
+        },
+        "/clients/me/payoff": {
+            "post": {
+                "description": "Settles the authenticated client's account for the payoff_amount of a current quote, in a single transaction. Fails with 409 if the amount no longer matches the quote.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1738,9 +2081,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Establishments"
+                    "Clients"
                 ],
-                "summary": "Update Establishment",
+                "summary": "Pay Off Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -1750,20 +2093,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Updated establishment data",
-                        "name": "establishment",
+                        "description": "Quoted payoff amount",
+                        "name": "payoff",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateEstablishmentRequest"
+                            "$ref": "#/definitions/request.PayoffRequest"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.EstablishmentResponse"
+                            "$ref": "#/definitions/response.PayoffQuoteResponse"
                         }
                     },
                     "400": {
@@ -1784,6 +2133,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1793,16 +2148,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/establishments/{establishmentID}": {
+        "/clients/me/payoff-quote": {
             "get": {
-                "description": "Gets an establishment by its ID.",
+                "description": "Returns how much the authenticated client has to pay today to settle their account: outstanding balance plus interest accrued to date, minus the establishment's early-payment discount. The quote expires at the end of the day.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Establishments"
+                    "Clients"
                 ],
-                "summary": "Get Establishment by ID",
+                "summary": "Get Payoff Quote",
                 "parameters": [
                     {
                         "type": "string",
@@ -1813,17 +2168,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Establishment ID",
-                        "name": "establishmentID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.EstablishmentResponse"
+                            "$ref": "#/definitions/response.PayoffQuoteResponse"
                         }
                     },
                     "400": {
@@ -1832,6 +2186,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1847,19 +2207,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/establishments/{establishmentID}/clients": {
+        "/clients/me/statement-deliveries": {
             "get": {
-                "description": "Gets all clients associated with an establishment. Only Admins can access this endpoint.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists the statements emailed to the authenticated client, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Clients"
                 ],
-                "summary": "Get Clients by Establishment ID",
+                "summary": "List Client Sent Statements",
                 "parameters": [
                     {
                         "type": "string",
@@ -1867,13 +2224,6 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Establishment ID",
-                        "name": "establishmentID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1882,28 +2232,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.UserResponse"
+                                "$ref": "#/definitions/response.StatementDeliveryResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1913,16 +2251,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/establishments/{establishmentID}/credit-accounts": {
-            "get": {
-                "description": "Retrieves all credit accounts associated with an establishment. Only Admins can access this endpoint.",
+        "/clients/me/statement-emails": {
+            "put": {
+                "description": "Lets the authenticated client stop, or resume, receiving their monthly statement by email.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Credit Accounts"
+                    "Clients"
                 ],
-                "summary": "Get Credit Accounts by Establishment ID",
+                "summary": "Subscribe or Unsubscribe from Statement Emails",
                 "parameters": [
                     {
                         "type": "string",
@@ -1932,21 +2273,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Establishment ID",
-                        "name": "establishmentID",
-                        "in": "path",
-                        "required": true
+                        "description": "Statement email settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateStatementEmailsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.CreditAccountResponse"
-                            }
+                            "$ref": "#/definitions/response.StatementEmailSettingsResponse"
                         }
                     },
                     "400": {
@@ -1976,19 +2316,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/establishments/{establishmentID}/products": {
+        "/clients/me/statements": {
             "get": {
-                "description": "Gets all products associated with an establishment.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists the closed monthly statements of the authenticated client's credit account, newest first. Statements are frozen at each closing date and never change afterwards.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Clients"
                 ],
-                "summary": "Get Products by Establishment ID",
+                "summary": "List Client Statements",
                 "parameters": [
                     {
                         "type": "string",
@@ -1999,10 +2336,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Establishment ID",
-                        "name": "establishmentID",
-                        "in": "path",
-                        "required": true
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2011,7 +2347,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.ProductResponse"
+                                "$ref": "#/definitions/response.StatementPeriodResponse"
                             }
                         }
                     },
@@ -2021,6 +2357,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2030,9 +2384,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/installments": {
-            "post": {
-                "description": "Creates a new installment for a credit account. Only Admins can create installments.",
+        "/clients/me/transactions": {
+            "get": {
+                "description": "Gets the transaction history of the authenticated client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2040,9 +2394,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Clients"
                 ],
-                "summary": "Create Installment",
+                "summary": "Get Client Transactions",
                 "parameters": [
                     {
                         "type": "string",
@@ -2052,20 +2406,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Installment data",
-                        "name": "installment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateInstallmentRequest"
-                        }
+                        "type": "integer",
+                        "description": "Establishment of the credit account. Required when the client has accounts in several establishments",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.InstallmentResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TransactionResponse"
+                            }
                         }
                     },
                     "400": {
@@ -2080,8 +2434,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -2095,16 +2449,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/installments/{id}": {
+        "/clients/{clientID}/attachments": {
             "get": {
-                "description": "Gets an installment by its ID.",
+                "description": "Lists the documents of a client of the admin's establishment, those attached to their credit account included, newest first. Only Admins can list documents.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Attachments"
                 ],
-                "summary": "Get Installment by ID",
+                "summary": "List Client Documents",
                 "parameters": [
                     {
                         "type": "string",
@@ -2115,8 +2469,14 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Installment ID",
-                        "name": "id",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
                     }
@@ -2125,7 +2485,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.InstallmentResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AttachmentResponse"
+                            }
                         }
                     },
                     "400": {
@@ -2134,6 +2497,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2148,18 +2523,18 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Updates an existing installment. Only Admins can update installments.",
+            "post": {
+                "description": "Attaches a document, e.g. a scan of their DNI, to a client of the admin's establishment. PDF, JPEG and PNG files are accepted; they are scanned for viruses when a scanner is configured and kept for the retention period of their kind (10 years for credit agreements, 5 for the others). Only Admins can upload documents.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Attachments"
                 ],
-                "summary": "Update Installment",
+                "summary": "Upload Client Document",
                 "parameters": [
                     {
                         "type": "string",
@@ -2170,26 +2545,37 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Installment ID",
-                        "name": "id",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated installment details",
-                        "name": "installment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateInstallmentRequest"
-                        }
+                        "type": "file",
+                        "description": "Document",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "DNI_SCAN, CREDIT_AGREEMENT or OTHER",
+                        "name": "kind",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.InstallmentResponse"
+                            "$ref": "#/definitions/response.AttachmentResponse"
                         }
                     },
                     "400": {
@@ -2223,19 +2609,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an installment by its ID. Only Admins can delete installments.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/clients/{clientID}/credit-account": {
+            "get": {
+                "description": "Retrieves a client's credit account. Admins get the account in their establishment; clients can select the establishment when they hold accounts in several.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Credit Accounts"
                 ],
-                "summary": "Delete Installment",
+                "summary": "Get Credit Account by Client ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -2246,30 +2631,33 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Installment ID",
-                        "name": "id",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment of the credit account (clients only)",
+                        "name": "establishment_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.CreditAccountResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -2287,51 +2675,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Logs in a user with their email and password.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Login",
-                "parameters": [
-                    {
-                        "description": "User login credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.AuthResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/products": {
-            "post": {
-                "description": "Creates a new product for the authenticated admin\\'s establishment.",
+            },
+            "put": {
+                "description": "Updates credit account details of a client. Only Admins can update credit accounts. Send the version of the account last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the account as it is now.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2339,9 +2685,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Credit Accounts"
                 ],
-                "summary": "Create Product",
+                "summary": "Update Client Credit Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -2351,20 +2697,39 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Product data",
-                        "name": "product",
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client User ID",
+                        "name": "clientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated credit account data",
+                        "name": "creditAccount",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateProductRequest"
+                            "$ref": "#/definitions/request.UpdateCreditAccountRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.ProductResponse"
+                            "$ref": "#/definitions/response.CreditAccountResponse"
                         }
                     },
                     "400": {
@@ -2385,6 +2750,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2392,11 +2775,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products/{id}": {
-            "get": {
-                "description": "Gets a product by its ID.",
+            },
+            "patch": {
+                "description": "Changes only the credit account details of a client the request sets. Unlike PUT, zero and false values are set too, e.g. a grace period of 0 or unblocking with is_blocked false. Fields left out or null are kept. Only Admins can update credit accounts. Send the version of the account last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the account as it is now.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2404,9 +2785,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Credit Accounts"
                 ],
-                "summary": "Get Product by ID",
+                "summary": "Patch Client Credit Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -2417,17 +2798,38 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client User ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Credit account fields to change",
+                        "name": "creditAccount",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PatchCreditAccountRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.ProductResponse"
+                            "$ref": "#/definitions/response.CreditAccountResponse"
                         }
                     },
                     "400": {
@@ -2436,12 +2838,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2449,19 +2875,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing product. Only admins can update products.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/clients/{clientID}/notes": {
+            "get": {
+                "description": "Lists the notes the establishment has about a client of it, newest first, with their author. Clients never see them. Only Admins can see them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Clients"
                 ],
-                "summary": "Update Product",
+                "summary": "List Client Notes",
                 "parameters": [
                     {
                         "type": "string",
@@ -2472,26 +2897,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
                     },
                     {
-                        "description": "Updated product data",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateProductRequest"
-                        }
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.ProductResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClientNoteResponse"
+                            }
                         }
                     },
                     "400": {
@@ -2526,8 +2951,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes a product by its ID. Only Admins can delete products.",
+            "post": {
+                "description": "Adds a note about a client of the establishment, e.g. that they pay late or prefer being reached by WhatsApp, signed by the authenticated admin. Only Admins can write notes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2535,9 +2960,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Clients"
                 ],
-                "summary": "Delete Product",
+                "summary": "Create Client Note",
                 "parameters": [
                     {
                         "type": "string",
@@ -2548,15 +2973,33 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClientNoteRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClientNoteResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2591,9 +3034,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchases": {
-            "post": {
-                "description": "Processes a product purchase by a user.",
+        "/clients/{clientID}/notes/{noteID}": {
+            "put": {
+                "description": "Changes the text of a note about a client of the establishment. Only Admins can edit notes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2601,9 +3044,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchases"
+                    "Clients"
                 ],
-                "summary": "Create a Purchase",
+                "summary": "Update Client Note",
                 "parameters": [
                     {
                         "type": "string",
@@ -2613,23 +3056,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Purchase Data",
-                        "name": "purchase",
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "note",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreatePurchaseRequest"
+                            "$ref": "#/definitions/request.ClientNoteRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ClientNoteResponse"
                         }
                     },
                     "400": {
@@ -2650,6 +3110,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2657,35 +3123,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/refresh": {
-            "post": {
-                "description": "Refreshes the access token using a valid refresh token.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Deletes a note about a client of the establishment. Only Admins can delete notes.",
                 "tags": [
-                    "Authentication"
+                    "Clients"
                 ],
-                "summary": "Refresh Token",
+                "summary": "Delete Client Note",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer {refreshToken}",
+                        "description": "Bearer {token}",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.AuthResponse"
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "401": {
@@ -2693,46 +3174,21 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/register": {
-            "post": {
-                "description": "Registers a new admin user along with their establishment.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Register Admin",
-                "parameters": [
-                    {
-                        "description": "Admin and establishment registration data",
-                        "name": "registration",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/request.CreateAdminAndEstablishmentRequest"
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -2740,9 +3196,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/reset-password": {
-            "post": {
-                "description": "Resets the password for the authenticated user.",
+        "/clients/{clientID}/tags": {
+            "put": {
+                "description": "Sets the tags a client of the establishment has, replacing those they had; an empty list untags them. The tags must be tags of the establishment. Only Admins can tag clients.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2750,9 +3206,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Clients"
                 ],
-                "summary": "Reset Password",
+                "summary": "Tag Client",
                 "parameters": [
                     {
                         "type": "string",
@@ -2762,12 +3218,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Reset password request data",
-                        "name": "reset",
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "clientID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags of the client",
+                        "name": "tags",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                            "$ref": "#/definitions/request.SetClientTagsRequest"
                         }
                     }
                 ],
@@ -2775,9 +3244,9 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClientTagResponse"
                             }
                         }
                     },
@@ -2792,62 +3261,15 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/transactions": {
-            "post": {
-                "description": "Create a new transaction (purchase or payment).",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Transactions"
-                ],
-                "summary": "Create Transaction",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Transaction Data",
-                        "name": "transaction",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateTransactionRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/response.TransactionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -2861,9 +3283,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/{id}": {
-            "get": {
-                "description": "Get a transaction by its ID.",
+        "/collections/{clientID}/contact-log": {
+            "post": {
+                "description": "Records a call, visit or message to a client of the establishment to collect their debt, and its outcome: REACHED, NO_ANSWER, PROMISED_TO_PAY, REFUSED or WRONG_CONTACT. It is logged as contacted now unless contacted_at says when it happened. Only Admins can log contacts.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2871,9 +3293,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Establishments"
                 ],
-                "summary": "Get Transaction by ID",
+                "summary": "Log Collection Contact",
                 "parameters": [
                     {
                         "type": "string",
@@ -2884,81 +3306,32 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.TransactionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "Update a transaction by its ID. Only admins can update transactions.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Transactions"
-                ],
-                "summary": "Update Transaction",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
                     },
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Transaction Data",
-                        "name": "transaction",
+                        "description": "Type, outcome and notes of the contact",
+                        "name": "contact",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateTransactionRequest"
+                            "$ref": "#/definitions/request.LogCollectionContactRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.TransactionResponse"
+                            "$ref": "#/definitions/response.CollectionContactResponse"
                         }
                     },
                     "400": {
@@ -2992,9 +3365,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a transaction by its ID. Only admins can delete transactions.",
+            }
+        },
+        "/credit-accounts": {
+            "post": {
+                "description": "Creates a new credit account for a client. With credit_template_id, the terms the request leaves out are taken from that credit template of the establishment, and the account remembers it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3002,9 +3377,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Credit Accounts"
                 ],
-                "summary": "Delete Transaction",
+                "summary": "Create Credit Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -3015,17 +3390,25 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Credit account data",
+                        "name": "creditAccount",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCreditAccountRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.TransactionResponse"
+                            "$ref": "#/definitions/response.CreditAccountResponse"
                         }
                     },
                     "400": {
@@ -3040,6 +3423,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -3052,6 +3441,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3061,19 +3456,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/{id}/confirm": {
-            "post": {
-                "description": "Confirms a pending payment using a confirmation code. Only admins can confirm payments.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/credit-accounts/debt-summary": {
+            "get": {
+                "description": "Retrieves a summary of all client debts for an establishment. Only Admins can access this endpoint.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Credit Accounts"
                 ],
-                "summary": "Confirm Payment",
+                "summary": "Get Admin Debt Summary",
                 "parameters": [
                     {
                         "type": "string",
@@ -3084,40 +3476,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
                     },
                     {
-                        "description": "Confirmation code",
-                        "name": "confirmation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Comma-separated top-level fields to return of each item, e.g. client_id,client_name,current_balance. Defaults to all",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AdminDebtSummary"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3130,12 +3509,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3145,16 +3518,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/email-to-id": {
+        "/credit-accounts/overdue": {
             "get": {
-                "description": "Retrieves the ID of a user by their email address. This endpoint is typically for internal use or admin purposes.",
+                "description": "Retrieves all overdue credit accounts for the authenticated admin\\'s establishment.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Get User ID by Email",
+                "summary": "Get Overdue Credit Accounts",
                 "parameters": [
                     {
                         "type": "string",
@@ -3163,30 +3536,35 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
-                        "description": "User's email address",
-                        "name": "email",
-                        "in": "query",
-                        "required": true
+                        "description": "Comma-separated top-level fields to return of each item, e.g. id,client_id,current_balance. Defaults to all",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nested objects to embed in each item: client, establishment, both (default) or none with an empty value",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CreditAccountResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid email format",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3194,13 +3572,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden (only for admins)",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -3208,16 +3586,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/credit-accounts/{id}": {
             "get": {
-                "description": "Retrieves a user by their ID. Admins can retrieve any user, Clients can only retrieve themselves.",
+                "description": "Gets a credit account by its ID. Credit accounts of other establishments, or of other clients, are not found. Supports conditional requests: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the account is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Get User by ID",
+                "summary": "Get Credit Account by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -3228,17 +3609,60 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Credit Account ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UserResponse"
+                            "$ref": "#/definitions/response.CreditAccountResponse"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached response is current",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
+                            }
                         }
                     },
                     "400": {
@@ -3247,18 +3671,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3274,7 +3686,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates user details, including the URL of the profile photo.",
+                "description": "Updates a credit account by its ID. Only Admins can update credit accounts. Send the version of the account last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the account as it is now.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3282,9 +3694,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Update User",
+                "summary": "Update Credit Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -3295,26 +3707,32 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Credit Account ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated user data (including photo_url)",
-                        "name": "user",
+                        "description": "Updated credit account data",
+                        "name": "creditAccount",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequest"
+                            "$ref": "#/definitions/request.UpdateCreditAccountRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UserResponse"
+                            "$ref": "#/definitions/response.CreditAccountResponse"
                         }
                     },
                     "400": {
@@ -3341,6 +3759,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3350,17 +3780,77 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a user by their ID. Only Admins can delete users.",
-                "consumes": [
+                "description": "Deletes a credit account by its ID. Only Admins can delete credit accounts.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Delete Credit Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/adjustments": {
+            "get": {
+                "description": "Lists the manual adjustments of a credit account, newest first, including those waiting for approval or rejected. Only Admins of the account's establishment can list them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Delete User",
+                "summary": "List Account Adjustments",
                 "parameters": [
                     {
                         "type": "string",
@@ -3371,19 +3861,19 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Credit Account ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AccountAdjustmentResponse"
                             }
                         }
                     },
@@ -3418,21 +3908,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}/photo": {
+            },
             "post": {
-                "description": "Uploads a profile photo for a user.",
+                "description": "Adjusts the balance of a credit account by hand with a reason: an ADJUSTMENT_DEBIT charges the client, e.g. to correct an error, and an ADJUSTMENT_CREDIT (credit note) lowers what they owe, e.g. a goodwill credit. It is applied right away (201) unless its amount is above the adjustment threshold of the establishment, when it waits for the approval of the adjustment approver, a second admin the establishment designated in its settings (202). Only Admins of the account's establishment can adjust it.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Credit Accounts"
                 ],
-                "summary": "Upload User PhotoUrl",
+                "summary": "Create Account Adjustment",
                 "parameters": [
                     {
                         "type": "string",
@@ -3443,16 +3931,96 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Credit Account ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "User profile photo",
-                        "name": "photo",
-                        "in": "formData",
+                        "description": "Type, amount and reason",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAccountAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.AccountAdjustmentResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/response.AccountAdjustmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/agreement": {
+            "get": {
+                "description": "Returns the credit agreement of a credit account and whether the client accepted it. Only Admins of the account's establishment can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Get Credit Agreement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -3460,10 +4028,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.CreditAgreementResponse"
                         }
                     },
                     "400": {
@@ -3484,6 +4049,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3492,347 +4063,17991 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "enums.CreditType": {
-            "type": "string",
-            "enum": [
-                "SHORT_TERM",
-                "LONG_TERM"
-            ],
-            "x-enum-varnames": [
-                "ShortTerm",
-                "LongTerm"
-            ]
-        },
-        "enums.InstallmentStatus": {
-            "type": "string",
-            "enum": [
-                "PENDING",
-                "PAID",
-                "OVERDUE"
-            ],
-            "x-enum-varnames": [
-                "Pending",
-                "Paid",
-                "Overdue"
-            ]
-        },
-        "enums.InterestType": {
-            "type": "string",
-            "enum": [
-                "NOMINAL",
-                "EFFECTIVE"
-            ],
-            "x-enum-varnames": [
-                "Nominal",
-                "Effective"
-            ]
-        },
-        "enums.PaymentMethod": {
-            "type": "string",
-            "enum": [
-                "YAPE",
-                "PLIN",
-                "CASH"
-            ],
-            "x-enum-varnames": [
-                "YAPE",
-                "PLIN",
-                "CASH"
-            ]
         },
-        "enums.PaymentStatus": {
-            "type": "string",
-            "enum": [
+        "/credit-accounts/{id}/agreement/pdf": {
+            "get": {
+                "description": "Downloads the credit agreement of a credit account, with the client's acceptance once accepted. Only Admins of the account's establishment can download it.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Download Credit Agreement (PDF)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/apply-interest": {
+            "post": {
+                "description": "Applies interest to a specific credit account. Only Admins can apply interest.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Apply Interest to Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/apply-late-fee": {
+            "post": {
+                "description": "Applies a late fee to a specific credit account. Only Admins can apply late fees. The fee is charged on the overdue amount per the establishment's late fee mode: its percentage once per billing cycle (FLAT), its percentage for every day overdue (DAILY) or a fixed amount once per cycle (FIXED), up to the late fee cap if set. A cycle already charged its fee fails with 409 Conflict; in the DAILY mode, applying it again charges the days since. Late fees are suspended while the client has an active payment promise whose date hasn't passed. If payments or purchases keep changing the balance while the fee is applied, it fails with 409 Conflict too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Apply Late Fee to Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/attachments": {
+            "get": {
+                "description": "Lists the documents attached to a credit account of the admin's establishment, newest first. Only Admins can list documents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "List Credit Account Documents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AttachmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Attaches a document, e.g. the signed credit agreement, to a credit account of the admin's establishment. PDF, JPEG and PNG files are accepted; they are scanned for viruses when a scanner is configured and kept for the retention period of their kind (10 years for credit agreements, 5 for the others). Only Admins can upload documents.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Upload Credit Account Document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Document",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "DNI_SCAN, CREDIT_AGREEMENT or OTHER",
+                        "name": "kind",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/balance-history": {
+            "get": {
+                "description": "Lists the closing balance of a credit account for each day of the period, in the establishment's time zone, for balance-over-time charts. Balances are snapshotted nightly; when the period includes today, it ends with the current balance (current is true). Days before the first snapshot of the account are left out. Clients can see their own accounts and Admins those of their establishment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Get Credit Account Balance History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to last_30_days",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BalanceHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/block": {
+            "post": {
+                "description": "Blocks a credit account so it can't be used for purchases, recording the reason in its block history. Only Admins of the account's establishment can block it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Block Credit Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for blocking",
+                        "name": "block",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BlockCreditAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/close": {
+            "post": {
+                "description": "Closes a credit account when the client leaves. A closed account takes no more purchases, while its transactions, installments and statements stay available and payments are still accepted. Accounts that still owe answer 409 with a payoff_quote; sending its payoff_amount back pays the account off and closes it in one request. Written-off debt has to be recovered first. Clients close their own accounts; Admins close the accounts of their establishment. Only an Admin can reopen it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Close Credit Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional reason, and the quoted payoff amount of an account that still owes",
+                        "name": "close",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CloseCreditAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.AccountBalanceResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/events": {
+            "get": {
+                "description": "Lists the changes to the terms and status of a credit account, newest first: its credit limit or interest rate changing (LIMIT_CHANGED, RATE_CHANGED, with the old and new value), and it being BLOCKED, UNBLOCKED, CLOSED or REOPENED, with the reason and the admin who did it. Events are recorded with every change and never edited, so they settle what the terms of the account were at any time. Clients can see their own accounts and Admins those of their establishment. The maximum page size depends on the caller's role. The number of events is sent in the X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Get Credit Account Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (starts at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CreditAccountEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/installments": {
+            "get": {
+                "description": "Retrieves installments associated with a specific credit account. Only the admins of the establishment and the client holding the credit account can get them. Supports conditional requests: send the ETag back in If-None-Match to get 304 Not Modified while no installment changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Get Installments by Credit Account ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.InstallmentResponse"
+                            }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached response is current",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/installments/overdue": {
+            "get": {
+                "description": "Retrieves overdue installments for a specific credit account: those not paid in full by the end of their due date, marked overdue nightly. Only the admins of the establishment and the client holding the credit account can get them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Get Overdue Installments by Credit Account ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.InstallmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/payment-links": {
+            "get": {
+                "description": "Lists the payment links sent to the client of a credit account, newest first, with their status and the payment made through them. Only Admins of the account's establishment can list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "List Payment Links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PaymentLinkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Sends the client of a credit account a link to pay one of its unpaid installments, or an amount no larger than what the account owes, by Yape or Plin without logging in. The link goes to the client's verified email, and to their verified phone if the establishment texts notifications, so one of them must be verified. It lasts 24 hours unless expires_in_hours says otherwise, up to 72, and is marked VIEWED when opened, PAID once the payment made through it is confirmed and EXPIRED if not paid in time. Only Admins of the account's establishment can create them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Create Payment Link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Installment or amount to pay",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePaymentLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaymentLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/payment-links/{linkID}/qr": {
+            "get": {
+                "description": "Renders the URL of a payment link as a QR code, for the client to scan and pay from their phone. Only Admins of the account's establishment can get it.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Get Payment Link QR Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Payment Link ID",
+                        "name": "linkID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png (default) or svg",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pixels on each side, 64 to 1024. Defaults to 256",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/payment-promises": {
+            "get": {
+                "description": "Lists the promises to pay of a credit account, newest first, with whether they were kept. Only Admins of the account's establishment can list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "List Payment Promises",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PaymentPromiseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records a promise to pay (compromiso de pago) negotiated with the client of a credit account: the amount they will pay by a date. Late fees are suspended until the date passes, and the promise is then marked KEPT if the payments made since add up to the amount, or BROKEN otherwise. Broken promises lower the client's credit score. An account has one active promise at a time. Only Admins of the account's establishment can create them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Create Payment Promise",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promised amount and date",
+                        "name": "promise",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePaymentPromiseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaymentPromiseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/payments": {
+            "post": {
+                "description": "Processes a payment towards a client's credit account. CASH payments are collected in the open till session of the establishment, if any.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Process Payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment details",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/purchases": {
+            "post": {
+                "description": "Processes a purchase on a client's credit account. Purchases above the PIN threshold of the establishment need the client's purchase PIN in pin: a missing, wrong or unset PIN is a 403, and five wrong PINs in a row lock it for 30 minutes (429) unless the client resets it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Process Purchase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase details",
+                        "name": "purchase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/reopen": {
+            "post": {
+                "description": "Reopens a closed credit account for purchases, with the limit and rates it had. Only Admins of the account's establishment can reopen it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Reopen Credit Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for reopening",
+                        "name": "reopen",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReopenCreditAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/statements": {
+            "get": {
+                "description": "Lists the closed monthly statements of a credit account, newest first. Only Admins of the account's establishment can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "List Credit Account Statements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.StatementPeriodResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/transactions": {
+            "get": {
+                "description": "Get a page of transactions for a specific credit account, newest first. Only the admins of the establishment and the client holding the credit account can get them. The maximum page size depends on the caller's role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transaction by Credit Account ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (starts at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TransactionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/unblock": {
+            "post": {
+                "description": "Unblocks a credit account, recording the reason in its block history. Written-off accounts can't be unblocked. Only Admins of the account's establishment can unblock it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Unblock Credit Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for unblocking",
+                        "name": "unblock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BlockCreditAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-accounts/{id}/write-off": {
+            "post": {
+                "description": "Writes off the balance of a long-delinquent credit account as bad debt. The account is frozen (blocked, and it can't be unblocked), its balance moves to written_off_balance, and it is left out of the receivables reports and shown in the bad-debt report instead. Later payments on the account recover the written-off amount. Only Admins of the account's establishment can write it off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Write Off Credit Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the write-off",
+                        "name": "writeOff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.WriteOffCreditAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit-simulations": {
+            "post": {
+                "description": "Returns the installment schedule, total interest and TCEA of a prospective credit without saving anything. Long-term credits start with their grace months, which accrue no interest and push the first installment back. Only Admins can simulate credits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Simulations"
+                ],
+                "summary": "Simulate Credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Credit conditions",
+                        "name": "simulation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCreditSimulationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditSimulationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/csrf-token": {
+            "get": {
+                "description": "Returns the CSRF token of the current session, to be sent in the X-CSRF-Token header of state-changing requests authenticated by cookie.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get CSRF Token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments": {
+            "post": {
+                "description": "Creates a new establishment for the authenticated admin. A RUC another establishment already registered is rejected with 409 Conflict.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Create Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Establishment data",
+                        "name": "establishment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateEstablishmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me": {
+            "get": {
+                "description": "Gets the establishment details for the authenticated admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates the establishment details for the authenticated admin. Send the version of the establishment last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the establishment as it is now. A RUC another establishment already registered is rejected with 409 Conflict too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Update Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Updated establishment data",
+                        "name": "establishment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateEstablishmentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes only the establishment details the request sets for the authenticated admin. Unlike PUT, fields that don't change can be left out or null, and zero and false values are set too. Send the version of the establishment last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the establishment as it is now. A RUC another establishment already registered is rejected with 409 Conflict too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Patch Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Establishment fields to change",
+                        "name": "establishment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PatchEstablishmentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/accounting/accounts": {
+            "get": {
+                "description": "Gets the account of the establishment's chart of accounts each accounting event of the journal export is mapped to: RECEIVABLES (what clients owe), SALES, CASH (payments collected), INTEREST_INCOME, LATE_FEES and WRITE_OFFS, and the events not mapped yet. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounting"
+                ],
+                "summary": "Get Accounting Accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.AccountingAccountsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Maps accounting events to the accounts of the establishment's chart of accounts, e.g. {\"accounts\": {\"SALES\": \"7011\", \"CASH\": \"1011\"}}. Events left out keep their account. Every event must be mapped before the journal can be exported. Only Admins can change them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounting"
+                ],
+                "summary": "Update Accounting Accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Accounts by event",
+                        "name": "accounts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateAccountingAccountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.AccountingAccountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/accounting/export": {
+            "get": {
+                "description": "Downloads the journal entries of the movements of the credit accounts of the admin's establishment over a period, for its accounting system. Purchases and manual charges are debited to the receivables account and credited to sales, payments debited to cash, interest and late fees credited to their income accounts and write-offs debited to the write-off account; reversals and manual credits go the other way. The accounts are those each accounting event is mapped to at /establishments/me/accounting/accounts, and the journal can't be exported until every event is mapped. Use format=csv for a CSV with a debit and a credit line per entry, or format=ple for the Libro Diario (5.1) layout of SUNAT's PLE. Only Admins can export them.",
+                "produces": [
+                    "text/csv",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Accounting"
+                ],
+                "summary": "Export Journal Entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to month",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default) or ple",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/bank-transfers": {
+            "get": {
+                "description": "Lists the transfers notified by the bank that were applied to the credit accounts of the establishment, newest first, with the payment each one confirmed or was recorded as. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Transfers"
+                ],
+                "summary": "List Bank Transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.BankTransferResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/branches": {
+            "get": {
+                "description": "Lists the authenticated admin's main establishment followed by its branches. Only Admins can list branches.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "List Branches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.EstablishmentResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a branch of the authenticated admin's main establishment. The branch shares the main establishment's RUC and, unless given, its settings. Select a branch in other requests with the X-Branch-ID header. Only Admins can create branches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Create Branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Branch data",
+                        "name": "branch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateBranchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/cash-sales": {
+            "post": {
+                "description": "Records products the admin's establishment sold for cash, so they count in its sales reports. Only Admins can record cash sales.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Record a Cash Sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Products sold",
+                        "name": "sale",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCashSaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/categories": {
+            "get": {
+                "description": "Lists the product categories of the establishment by name. Establishments start with the default categories Grocery, FruitAndVeg, Meat, Poultry, Seafood, Bakery, Liquor and GeneralStore. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List Product Categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CategoryResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a product category to the establishment. Names are unique within the establishment, ignoring case. Only Admins can create them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create Product Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Category",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/categories/{id}": {
+            "put": {
+                "description": "Renames a product category of the establishment. Its products stay in it. Only Admins can rename them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Rename Product Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a product category of the establishment. Categories with products can't be deleted until their products are moved to another category. Only Admins can delete them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete Product Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/client-signups": {
+            "get": {
+                "description": "Lists the clients that signed up with the invite code of the establishment, oldest first. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "List Client Signups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list signups in this status: PENDING, APPROVED or REJECTED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClientSignupResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/client-signups/{id}/approve": {
+            "post": {
+                "description": "Approves a pending client signup, creating the client and opening their credit account with the given terms and its credit agreement. The client is notified. Only Admins can approve signups.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Approve Client Signup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client signup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Terms of the credit account",
+                        "name": "terms",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ApproveClientSignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClientSignupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/client-signups/{id}/reject": {
+            "post": {
+                "description": "Rejects a pending client signup. The client is notified with the reason. Only Admins can reject signups.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Reject Client Signup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client signup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the rejection",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RejectClientSignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClientSignupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/client-tags": {
+            "get": {
+                "description": "Lists the client tags of the establishment by name. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clients"
+                ],
+                "summary": "List Client Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClientTagResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a client tag, e.g. VIP or wholesale, to the establishment. Names are unique within the establishment, ignoring case. Only Admins can create them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clients"
+                ],
+                "summary": "Create Client Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClientTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClientTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/client-tags/{id}": {
+            "put": {
+                "description": "Renames a client tag of the establishment; its clients keep it. Names are unique within the establishment, ignoring case. Only Admins can rename them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clients"
+                ],
+                "summary": "Rename Client Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClientTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClientTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a client tag of the establishment, untagging the clients that had it. Only Admins can delete them.",
+                "tags": [
+                    "Clients"
+                ],
+                "summary": "Delete Client Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/clients/search": {
+            "get": {
+                "description": "Searches the clients of the establishment by name, DNI, email or phone, ordered by name, only those with every tag given when filtered by tag. Each client comes with the balance and overdue status of their credit account. An empty query lists every client. The maximum page size depends on the caller's role. Only Admins can search clients. The number of matches is sent in the X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Search Clients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text the client's name, DNI, email or phone contains",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Client tag name the clients have, repeated to require several",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (starts at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ClientSearchResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/collections": {
+            "get": {
+                "description": "Lists the overdue clients of the establishment in the order to collect from them: the most overdue first, then those owing the most, then those who broke the most payment promises. Each comes with their contact info and the date and outcome of their last collection contact. Written-off accounts are left out. Only Admins can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Collections Worklist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CollectionWorklistItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/credit-accounts/bulk-update": {
+            "post": {
+                "description": "Sets the terms of the request (credit limit, due day, interest rate and type, compounding period, grace period, late fee percentage and spending limit) on every credit account of the establishment the filter matches: by ID, the credit template they were opened with, credit and interest type, status and client tags. An empty filter matches every account. Each account is updated on its own and reported as UPDATE, UNCHANGED, SKIPPED (written off) or FAILED (e.g. changed by someone else meanwhile), with the terms changed from what to what. With preview nothing is changed and the report lists what the update would do; otherwise the update and each change are kept as its audit trail, see update_id. Only Admins can update credit terms.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Bulk Update Credit Terms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Filter, terms and reason",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BulkUpdateCreditAccountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BulkCreditTermUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/credit-accounts/bulk-updates": {
+            "get": {
+                "description": "Lists the bulk updates of credit terms of the establishment, newest first: who made them, why, their filter and terms, and how many accounts they matched, changed and failed to change. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "List Credit Term Updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CreditTermUpdateResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/credit-accounts/bulk-updates/{id}": {
+            "get": {
+                "description": "Retrieves a bulk update of credit terms of the establishment with every term it changed, by account, from what to what. Only Admins can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Get Credit Term Update",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit term update ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditTermUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/credit-templates": {
+            "get": {
+                "description": "Lists the credit templates of the establishment by name. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "List Credit Templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CreditTemplateResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a credit template to the establishment: a name and the credit limit, due day, interest rate and type, credit type and grace period credit accounts are opened with when they reference it. Names are unique within the establishment, ignoring case. Only Admins can create them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Create Credit Template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreditTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/credit-templates/{id}": {
+            "put": {
+                "description": "Changes the name and terms of a credit template of the establishment. Accounts already opened with it keep their terms. Only Admins can update them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Update Credit Template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreditTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreditTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a credit template of the establishment. Accounts opened with it keep their terms, but no longer point to it. Only Admins can delete them.",
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Delete Credit Template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Credit template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/deactivate": {
+            "post": {
+                "description": "Deactivates the authenticated admin's establishment, or the branch of X-Branch-ID. Until it is reactivated it takes no purchases, cash sales or new clients (403 with code establishment_inactive), its catalog is hidden and its invite code stops working, while its clients can still pay what they owe and see their accounts. Deactivating the main establishment deactivates its branches with it. Only Admins can deactivate establishments.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Deactivate Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/document-series": {
+            "get": {
+                "description": "Lists the fiscal document series of the establishment. New purchases and payments get the next number of the active receipt series, e.g. B001-000123. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "List Document Series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.DocumentSeriesResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a fiscal document series to the establishment. Receipt series codes start with B and invoice series codes with F, followed by three letters or digits. Creating an active series deactivates the active one of its type. Only Admins can create them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Create Document Series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Document series",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateDocumentSeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.DocumentSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/document-series/{id}": {
+            "put": {
+                "description": "Activates or deactivates a fiscal document series of the establishment, or moves its numbering forward. Activating a series deactivates the active one of its type. Numbers already issued can't be reused. Only Admins can change them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Update Document Series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Document series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes to the document series",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateDocumentSeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DocumentSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/export": {
+            "get": {
+                "description": "Exports the settings, product categories and products of the establishment as JSON, to set up another store with POST /establishments/me/import. Products are exported without their stock. Only Admins can export it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Export Establishment Configuration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentConfigResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/holidays": {
+            "get": {
+                "description": "Lists the holidays of the establishment by date. Due dates falling on a holiday or a Sunday move to the next business day, and installments are only overdue and reminded of by that day. Establishments start with the national holidays of Peru of the year they were created and the next. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "List Holidays",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year of the holidays. Defaults to every year",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.HolidayResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a holiday to the calendar of the establishment, e.g. a regional holiday. The installments still to be paid that are due on it move to the next business day. Only Admins can create them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Create Holiday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Holiday",
+                        "name": "holiday",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.HolidayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.HolidayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/holidays/national": {
+            "post": {
+                "description": "Adds the national holidays of Peru of a year to the calendar of the establishment, keeping the holidays it already has on those dates, and returns the holidays of that year. The installments still to be paid that are due on them move to the next business day. Only Admins can add them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Add National Holidays",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year of the holidays",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.HolidayResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/holidays/{id}": {
+            "put": {
+                "description": "Changes the date and name of a holiday of the establishment. The installments still to be paid that are due on its new date move to the next business day; those moved off its previous date stay where they are. Only Admins can change them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Update Holiday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Holiday",
+                        "name": "holiday",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.HolidayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.HolidayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a holiday of the establishment. Installments moved off it stay where they are. Only Admins can delete them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Delete Holiday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/import": {
+            "post": {
+                "description": "Imports a configuration exported by GET /establishments/me/export into the establishment. Its settings replace those it sets, but the adjustment approver. Its categories are created unless the establishment has them, matched by name ignoring case. Its products are matched with those of the establishment by SKU, or by barcode without one, or by name without either; new ones are created without stock, and those that differ from the existing ones are skipped (SKIP, the default), overwrite them (OVERWRITE) or fail the import (FAIL) as on_conflict says. The import is all or nothing, failing on any invalid item. With dry_run it changes nothing and reports what it would do with each item. Only Admins can import it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Import Establishment Configuration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Configuration and import options",
+                        "name": "import",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ImportEstablishmentConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/invite-code": {
+            "get": {
+                "description": "Returns the code clients self-register with at the admin's establishment, or the selected branch, generating it the first time. Share it, or the QR code of its signup URL, with prospective clients. Only Admins can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Invite Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.InviteCodeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Replaces the invite code of the admin's establishment, or the selected branch, with a new one. The previous code stops working right away; signups already made with it are kept. Only Admins can rotate it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Rotate Invite Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.InviteCodeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/invite-code/qr": {
+            "get": {
+                "description": "Renders the signup URL of the invite code of the admin's establishment, or the selected branch, as a QR code, for prospective clients to scan and sign up from their phone. Only Admins can get it.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Invite Code QR Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "png (default) or svg",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pixels on each side, 64 to 1024. Defaults to 256",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/plan": {
+            "get": {
+                "description": "Retrieves the plan of the establishment, which its branches are on too: the clients, products and branches it allows and the features it includes, with how much of each quota the establishment and its branches use. Quotas are soft: past the limit, what the establishment has keeps working but nothing new can be added until the plan is upgraded. Only Admins can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Establishment Plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentPlanResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/products/lookup": {
+            "get": {
+                "description": "Finds the product of the establishment with an EAN-13 barcode, for scanning products at the point of sale. Only Admins can look up products.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Look Up Product by Barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "EAN-13 barcode",
+                        "name": "barcode",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/purchase-approvals": {
+            "get": {
+                "description": "Lists the client purchases of the establishment that were above its approval threshold, oldest first. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "List Purchase Approvals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list approvals in this status: PENDING_APPROVAL, APPROVED, REJECTED or EXPIRED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PurchaseApprovalResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/purchase-approvals/{id}/approve": {
+            "post": {
+                "description": "Approves a purchase waiting for approval, charging it to the client's credit account if the account can still afford it. The client is notified. Only Admins can approve purchases.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Approve Purchase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Purchase approval ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PurchaseApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/purchase-approvals/{id}/reject": {
+            "post": {
+                "description": "Rejects a purchase waiting for approval. The client is notified with the reason. Only Admins can reject purchases.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Reject Purchase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Purchase approval ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the rejection",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RejectPurchaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PurchaseApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reactivate": {
+            "post": {
+                "description": "Reactivates the authenticated admin's deactivated establishment, or the branch of X-Branch-ID, so it takes purchases and new clients again. A branch stays inactive while its main establishment is deactivated. Only Admins can reactivate establishments.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Reactivate Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/bad-debt": {
+            "get": {
+                "description": "Lists the credit accounts of the admin's establishment written off as bad debt, newest first, with the amount written off, what later payments recovered and what is still outstanding. Written-off accounts are left out of the other receivables reports. Only Admins can see reports.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Bad-Debt Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BadDebtReportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/branches": {
+            "get": {
+                "description": "Puts the credit and cash sales, payments and outstanding debt of the admin's main establishment and each branch side by side, with totals across all of them. Only Admins can see reports.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Consolidated Branch Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to month",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BranchReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/cohorts": {
+            "get": {
+                "description": "Groups the clients of the admin's establishment by onboarding month and shows, for every due date since, how many paid on time, late or defaulted. Only Admins can see reports.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Client Cohort Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of onboarding months covered, current month included (1-24). Defaults to 12",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CohortReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/digest": {
+            "post": {
+                "description": "Emails the admin a digest of the reports of the establishment over a period, last week unless another is asked for: collections, new debt, overdue changes and top debtors, or the sections asked for, those of the establishment settings by default. The email is in HTML with the digest attached as a PDF, and is only sent to a verified email. Set preview to get the digest without emailing it. Digests are also emailed weekly or monthly as the digest_frequency setting says. Only Admins can ask for them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Send Report Digest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Period and sections of the digest",
+                        "name": "digest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SendReportDigestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ReportDigestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/digest-deliveries": {
+            "get": {
+                "description": "Lists the weekly and monthly report digests emailed to the admin of the establishment, newest first, with their status. A failed digest is retried on the next runs, up to 3 attempts, for two days after its period. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "List Report Digest Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ReportDigestDeliveryResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/forecast": {
+            "get": {
+                "description": "Forecasts the collections of the admin's establishment for each upcoming week or month, to plan inventory purchases. What falls due in a period is the pending installments of long-term accounts and the balance of short-term accounts on their next due date; each amount is weighted by how punctually its account paid installments before, or the establishment as a whole for accounts with a short history. Each period has what is scheduled and an optimistic/expected/pessimistic band (90th percentile, expected and 10th percentile). Past-due installments are reported apart, as overdue_balance, and written-off accounts are left out. Only Admins can see reports.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Collections Forecast Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "week (default, starting on Monday) or month",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of periods covered, the current one included: 1-26 weeks (defaults to 8) or 1-12 months (defaults to 3)",
+                        "name": "periods",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ForecastReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/products": {
+            "get": {
+                "description": "Shows units sold, revenue, credit vs cash split and stock turnover per product of the admin's establishment. Use format=csv to download it as CSV. Only Admins can see reports.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Product Performance Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to month",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/reports/receivables": {
+            "get": {
+                "description": "Adds up the closing balances of the credit accounts of the admin's establishment for each day of the period, in its time zone: balances owed, account credit in the clients' favor, their difference (receivables), written-off balances and how many accounts owed something. Days are included once their balances are snapshotted, nightly. Only Admins can see reports.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Receivables Trend Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to last_30_days",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ReceivablesTrendResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/settings": {
+            "get": {
+                "description": "Gets the business rules the establishment applies to its credit accounts: installments per long-term purchase, default interest rate, auto-block threshold and payment reminder schedule. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Establishment Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Changes the business rules of the establishment. Omitted fields keep their value. New installment counts and default rates only apply to purchases and accounts created afterwards. Clients are sent payment reminders on the days of reminder_schedule before or on their due dates, and overdue notices on those after them, e.g. [-7, -3, -1, 0, 3]; reminder_days_before N alone sets it to [-N]. With sms_notifications, payment reminders, confirmations, overdue notices and payment links are also texted to clients who verified their phone, from sms_sender if set. With digest_frequency WEEKLY or MONTHLY, the admin is emailed a digest of the reports after each week or month, with the digest_sections chosen or all of them. Manual adjustments above adjustment_threshold wait for the approval of adjustment_approver_id, an admin other than the establishment's. Only Admins can change them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Update Establishment Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Establishment settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateEstablishmentSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/sms-deliveries": {
+            "get": {
+                "description": "Lists the payment reminders, confirmations, overdue notices and payment links texted to the establishment's clients, newest first, with their delivery status as last reported by the SMS provider. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "List Texted Notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.SMSDeliveryResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/statement-deliveries": {
+            "get": {
+                "description": "Lists the statements emailed to the establishment's clients, newest first, including failed attempts. Only Admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statements"
+                ],
+                "summary": "List Sent Statements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.StatementDeliveryResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/statement-emails": {
+            "get": {
+                "description": "Tells whether the establishment emails its clients their statement at each closing date. Only Admins can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statements"
+                ],
+                "summary": "Get Statement Email Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.StatementEmailSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Turns the monthly statement emails of the establishment on or off. Statements are sent within a week of each account's closing date. Only Admins can change it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statements"
+                ],
+                "summary": "Update Statement Email Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Statement email settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateStatementEmailsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.StatementEmailSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/till-sessions": {
+            "get": {
+                "description": "Lists the till sessions of the establishment, newest first, with the cash expected and counted in each. Only Admins can list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "List Till Sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TillSessionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Opens the till of the establishment with the cash in the drawer. Until it is closed, CASH payments and cash sales recorded in the establishment are collected in it. An establishment has one open till session at a time. Only Admins can open the till.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Open Till Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Opening cash",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.OpenTillSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.TillSessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/till-sessions/current": {
+            "get": {
+                "description": "Reports on the open till session of the establishment: the cash payments and sales collected so far and the cash expected in the drawer. Only Admins can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Current Till Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TillSessionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/till-sessions/{id}": {
+            "get": {
+                "description": "Reports on a till session of the establishment: the opening cash, the cash payments and sales collected, the cash expected in the drawer against the cash counted on closing, the discrepancy and its notes. Only Admins can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Till Session Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Till session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TillSessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/till-sessions/{id}/close": {
+            "post": {
+                "description": "Closes the open till session with the cash counted in the drawer, freezing the cash expected in it. When the counted cash isn't the expected one, notes explaining the discrepancy are required. Only Admins can close the till.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Close Till Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Till session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counted cash and notes",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CloseTillSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TillSessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/me/transactions": {
+            "get": {
+                "description": "Search the transactions of the establishment's credit accounts, newest first by default. All filters are optional and are combined. The maximum page size depends on the caller's role. Only Admins can search. The number of matches is sent in the X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Search Establishment Transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client name, or the beginning of their DNI",
+                        "name": "client",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PURCHASE",
+                            "PAYMENT",
+                            "INTEREST",
+                            "LATE_FEE",
+                            "ADJUSTMENT_DEBIT",
+                            "ADJUSTMENT_CREDIT"
+                        ],
+                        "type": "string",
+                        "description": "Transaction type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "YAPE",
+                            "PLIN",
+                            "CASH",
+                            "BANK_TRANSFER"
+                        ],
+                        "type": "string",
+                        "description": "Payment method",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "SUCCESS",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Payment status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum amount",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum amount",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (2026-10-14) or instant (2026-10-14T09:30:00-05:00), ISO-8601. Dates and times without an offset are in the establishment's time zone",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (included) or instant, ISO-8601",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Named range instead of start_date and end_date: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days",
+                        "name": "range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Words to look for in the description",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "transaction_date",
+                            "amount"
+                        ],
+                        "type": "string",
+                        "default": "transaction_date",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (starts at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.EstablishmentTransactionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/{code}/client-signup": {
+            "post": {
+                "description": "Registers a client with the establishment of an invite code. The signup waits for an admin of the establishment, who is notified, to approve it and set the terms of the credit account; the client gets an email when it is reviewed and can log in from then on. Clients that already have an account in another establishment sign up with the same email and DNI and keep their password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clients"
+                ],
+                "summary": "Client Self-Signup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite code of the establishment",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Client data",
+                        "name": "signup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClientSignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClientSignupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/{establishmentID}": {
+            "get": {
+                "description": "Gets one of the authenticated admin's establishments, main or branch, by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Establishments"
+                ],
+                "summary": "Get Establishment by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "establishmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/{establishmentID}/catalog": {
+            "get": {
+                "description": "Gets a page of the active products of an establishment, by name, so clients can browse them before purchasing. Prices include tax, and instead of the stock each product says whether it is available and running out. No authentication is needed. Responses may be cached for a few minutes. The number of products is sent in the X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get Establishment Catalog",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "establishmentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list the products of this category",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (starts at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CatalogProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/{establishmentID}/clients": {
+            "get": {
+                "description": "Gets all clients associated with an establishment, only those with every tag given when filtered by tag. Only admins of the establishment can access this endpoint; other establishments are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Clients by Establishment ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "establishmentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Client tag name the clients have, repeated to require several",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.UserResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/{establishmentID}/credit-accounts": {
+            "get": {
+                "description": "Retrieves all credit accounts associated with an establishment. Only admins of the establishment can access this endpoint; other establishments are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit Accounts"
+                ],
+                "summary": "Get Credit Accounts by Establishment ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "establishmentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated top-level fields to return of each item, e.g. id,client_id,current_balance. Defaults to all",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nested objects to embed in each item: client, establishment, both (default) or none with an empty value",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CreditAccountResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/establishments/{establishmentID}/products": {
+            "get": {
+                "description": "Gets all products associated with an establishment, optionally only those of one of its categories. Supports conditional requests: send the ETag back in If-None-Match to get 304 Not Modified while no product changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get Products by Establishment ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "establishmentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list the products of this category",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ProductResponse"
+                            }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached response is current",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "description": "Opens a Server-Sent Events stream of account events such as payment.confirmed, purchase.created and account.blocked. Clients receive the events of their own credit accounts; admins those of every credit account in their establishments and branches. Each event is named after the event and carries a RealtimeEventResponse as data.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Stream Account Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RealtimeEventResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments": {
+            "post": {
+                "description": "Creates a new installment for a credit account. Only Admins can create installments, on credit accounts of their establishments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Create Installment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Installment data",
+                        "name": "installment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateInstallmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.InstallmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments/{id}": {
+            "get": {
+                "description": "Gets an installment by its ID. Only the admins of the establishment and the client holding the credit account can get it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Get Installment by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Installment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.InstallmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing installment. Only Admins can update installments, of the credit accounts of their establishments. Send the version of the installment last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the installment as it is now. The status follows the installment's payments and due date: PENDING, PARTIALLY_PAID once part of it is paid, OVERDUE if it isn't paid in full by the end of its due date and PAID once it is. It can only be set by hand to CANCELLED, while it is owed, and paid or cancelled installments can't be updated (409 Conflict).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Update Installment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Installment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated installment details",
+                        "name": "installment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateInstallmentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.InstallmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an installment by its ID. Only Admins can delete installments, of the credit accounts of their establishments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Delete Installment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Installment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments/{id}/reschedule": {
+            "post": {
+                "description": "Moves the due date of an unpaid installment, as agreed with the client, keeping the date it had in its reschedule history. The new date goes from tomorrow up to a month after the account's last unpaid installment, and each credit account has at most the reschedules its establishment allows (max_reschedules of its settings). Establishments with reschedule_interest add the account's interest for the days the installment is postponed, to the installment and the balance. An overdue installment moved to a later date is pending again. Only Admins can reschedule installments of their establishments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Reschedule Installment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Installment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New due date and reason",
+                        "name": "reschedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RescheduleInstallmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.InstallmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments/{id}/reschedules": {
+            "get": {
+                "description": "Lists the reschedules of an installment, oldest first, with the due date it had before each. Only Admins can see the reschedules of installments of their establishments.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Get Installment Reschedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Installment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.InstallmentRescheduleResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Returns the status of a background job requested by the authenticated user, e.g. a PDF statement. Jobs and their results are kept for a day after they finish.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get Job Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.JobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/result": {
+            "get": {
+                "description": "Downloads the file produced by a background job requested by the authenticated user. Answers 409 while the job is queued or running, or when it failed.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Download Job Result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job result",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Logs in a user with their email and password. With use_cookie the tokens are also stored in HttpOnly cookies and a CSRF token is returned, which must be sent in the X-CSRF-Token header of state-changing requests. Users with two-factor authentication get two_factor_required and a challenge_token to complete the login at /login/2fa instead of their tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.AuthResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/2fa": {
+            "post": {
+                "description": "Completes a login challenged for its second factor with a code of the user's authenticator app or one of their recovery codes. Users setting two-factor authentication up during the login send the first code of their app and also get their recovery codes. Five wrong codes in a row lock the second factor for 15 minutes with 429 Too Many Requests, after which the login has to be started again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Complete Two-Factor Login",
+                "parameters": [
+                    {
+                        "description": "Challenge token and code",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/2fa/setup": {
+            "post": {
+                "description": "Generates the two-factor secret of a user whose establishment requires two-factor authentication, when the login answered two_factor_setup_required. The login is then completed at /login/2fa with the first code of the app.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Set Up Two-Factor Authentication at Login",
+                "parameters": [
+                    {
+                        "description": "Challenge token",
+                        "name": "challenge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorChallengeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TwoFactorSetupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "description": "Ends the session of the refresh token, read from the refresh token cookie or the Authorization header, and clears the session cookies. Access tokens already issued last until they expire.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {refreshToken}",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/cache": {
+            "get": {
+                "description": "Returns hit/miss counters of the account summary and statement caches. Only admins can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Get Cache Metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/cache.Stats"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-links/{token}": {
+            "get": {
+                "description": "Shows the client what a payment link asks them to pay, and the payment they made through it if any. It needs no login: the token in the URL is signed by the API. The first view marks the link VIEWED.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment Links"
+                ],
+                "summary": "View Payment Link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of the link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaymentLinkPageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-links/{token}/pay": {
+            "post": {
+                "description": "Pays the amount of a payment link by Yape or Plin. The payment is made on the credit account, pending until an admin confirms it with the payment code in the response, and the link is marked PAID once it is. A link is only paid through once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment Links"
+                ],
+                "summary": "Pay Payment Link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of the link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment method",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PayPaymentLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/audit-log": {
+            "get": {
+                "description": "Lists the latest 500 requests made to the platform endpoints, newest first, including denied ones and this one. Each entry is recorded before the request is handled and completed with the status it was answered with. Only Super-admins can see it, and the request is audited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Get Platform Audit Log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User who made the requests",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (2026-10-14) or instant (2026-10-14T09:30:00-05:00), ISO-8601",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (included) or instant, ISO-8601",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PlatformAuditEntryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/establishments": {
+            "get": {
+                "description": "Lists the main establishments of the platform, oldest first, with their admin, plan and status, and how many branches and open credit accounts they have. Only Super-admins can list them, and the request is audited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "List Platform Establishments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name or RUC of the establishment, or name or email of its admin",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ACTIVE",
+                            "INACTIVE",
+                            "SUSPENDED"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PlatformEstablishmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/establishments/{id}/admin/reset-password": {
+            "post": {
+                "description": "Gives the admin of a main establishment a new temporary password and logs them out of every session. The password is emailed to the admin and never returned. Only Super-admins can reset admin passwords, and the request is audited.",
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Reset Admin Password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/establishments/{id}/plan": {
+            "put": {
+                "description": "Moves a main establishment, with its branches, to a plan. Moving to a smaller plan keeps what the establishment already has past the new limits, but nothing new can be added. Only Super-admins can assign plans, and the request is audited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Assign Plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Plan",
+                        "name": "plan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.EstablishmentPlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/establishments/{id}/reinstate": {
+            "post": {
+                "description": "Lifts the suspension of a main establishment, with its branches. Its admin can log in again. Only Super-admins can reinstate establishments, and the request is audited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Reinstate Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlatformEstablishmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/establishments/{id}/suspend": {
+            "post": {
+                "description": "Suspends a main establishment, with its branches, and logs its admin out of every session. Until it is reinstated, the admin can't log in and the establishment takes no purchases or new clients; its clients can still see their accounts and pay. Only Super-admins can suspend establishments, and the request is audited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Suspend Establishment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Establishment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason of the suspension",
+                        "name": "suspension",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SuspendEstablishmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlatformEstablishmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/metrics": {
+            "get": {
+                "description": "Adds up the establishments, users and credit accounts of the whole platform, with the purchases, payments and credit accounts opened over the last 30 days. Figures may lag a little behind. Only Super-admins can see them, and the request is audited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Get Platform Metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlatformMetricsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/platform/plans": {
+            "get": {
+                "description": "Lists the plans establishments can be on, from the smallest to the largest. A quota of 0 is unlimited, and one of -1 allows none. Only Super-admins can list them, and the request is audited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "List Plans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PlanResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "post": {
+                "description": "Creates a new product for the authenticated admin\\'s establishment. SKUs and EAN-13 barcodes are optional, and unique within the establishment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Product data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Gets a product by its ID. Supports conditional requests: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the product is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get Product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a cached response, answered with 304 Not Modified while it is current",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached response is current",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "private, no-cache: revalidate before reusing"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response, for If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the response last changed, for If-Modified-Since"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing product. An empty SKU or barcode removes it. Only admins of the product's establishment can update it; products of other establishments are not found. Send the version of the product last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the product as it is now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated product data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateProductRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a product by its ID. Only admins of the product's establishment can delete it; products of other establishments are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes only the product fields the request sets. Unlike PUT, zero and false values are set too, e.g. a stock of 0. Fields left out or null are kept. An empty SKU or barcode removes it. Only admins of the product's establishment can update it; products of other establishments are not found. Send the version of the product last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the product as it is now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Patch Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product fields to change",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PatchProductRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the change is based on, instead of version in the body",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/image": {
+            "post": {
+                "description": "Uploads an image for a product. The image is validated, resized to the standard sizes and set as the product image. Only admins of the product's establishment can upload it.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Upload Product Image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Product image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchases": {
+            "post": {
+                "description": "Processes a product purchase by a user. Purchases above the approval threshold of the establishment are not made yet: they wait for an admin's approval, which is returned with 202 Accepted, and don't affect the balance unless approved before they expire. Purchases are refused with 403 until the client accepts the credit agreement of the account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Create a Purchase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase Data",
+                        "name": "purchase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePurchaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/response.PurchaseApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchases/quote": {
+            "post": {
+                "description": "Prices a credit purchase before making it: line totals with their tax, the credit available before and after it, the installments a long-term purchase would be split into, and the conditions that would reject it (blocked account, over the credit limit, over the limit of high-risk clients). Nothing is saved. Only Clients can quote purchases.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Quote a Purchase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Products to buy",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PurchaseQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PurchaseQuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "Refreshes the access token using a valid refresh token, sent as a Bearer token or, for cookie sessions, in the refresh token cookie.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh Token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {refreshToken}",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.AuthResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Registers a new admin user along with their establishment. A DNI or email of another user, or the RUC of another establishment, is rejected with 409 Conflict and a message naming it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register Admin",
+                "parameters": [
+                    {
+                        "description": "Admin and establishment registration data",
+                        "name": "registration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAdminAndEstablishmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reset-password": {
+            "post": {
+                "description": "Resets the password for the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset Password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Reset password request data",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandbox/clock": {
+            "get": {
+                "description": "Returns the date the API currently uses for interest, late fees and overdue checks. Only available in the sandbox environment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Get Sandbox Clock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClockResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Moves the sandbox clock to the given date. Time keeps running from there until the clock is reset.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Simulate Date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Simulated date",
+                        "name": "clock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetSimulatedDateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Makes the sandbox clock follow the system time again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Reset Sandbox Clock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClockResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "post": {
+                "description": "Create a new transaction (purchase or payment). Clients make purchases on their own credit accounts, and admins register payments on the credit accounts of their establishments. Non-cash transactions stay pending until confirmed with the code sent to the client, on the channels listed in code_sent_to; they fail if not confirmed before payment_code_expires_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Create Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction Data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "description": "Get a transaction by its ID. Only the admins of the establishment and the client holding the credit account can get it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transaction by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a transaction by its ID. Only admins can update transactions, of the credit accounts of their establishments. Adjustments can't be updated, make an opposite adjustment instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Update Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction Data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a transaction by its ID. Only admins can delete transactions, of the credit accounts of their establishments. Adjustments can't be deleted, make an opposite adjustment instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Delete Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/allocation": {
+            "get": {
+                "description": "Returns the installments a payment paid, oldest due first, with what it paid of each and what was left of them, and the installments still left to pay after it with the next due date. Payments cover the unpaid installments of their credit account by due date; what exceeds them pays the rest of the balance. Later payments and installments are not counted. Only the admins of the establishment and the client holding the credit account can get it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Payment Allocation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID of the payment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaymentAllocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/confirm": {
+            "post": {
+                "description": "Confirms a pending payment using the confirmation code sent to the client. A code is only used once and fails the payment when it expires or after 5 wrong attempts. Only admins can confirm payments, of the credit accounts of their establishments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Confirm Payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Confirmation code",
+                        "name": "confirmation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/invoice": {
+            "get": {
+                "description": "Returns the electronic receipt of a credit purchase and the state of its submission to SUNAT: PENDING while it is being submitted, ACCEPTED, REJECTED with SUNAT's reason, or FAILED when it couldn't be submitted. Available to the admin of the establishment and the client who made the purchase.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Electronic Receipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID of the purchase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ElectronicInvoiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/invoice/pdf": {
+            "get": {
+                "description": "Downloads the printed representation of the electronic receipt of a credit purchase, with its tax breakdown and the hash of the signed XML. Available to the admin of the establishment and the client who made the purchase.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Download Electronic Receipt (PDF)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID of the purchase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/receipt/pdf": {
+            "get": {
+                "description": "Downloads the receipt of a payment, with the installments it paid and the schedule left to pay after it, in the language of Accept-Language or else of the establishment. Only the admins of the establishment and the client holding the credit account can get it.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Download Payment Receipt (PDF)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the PDF, es or en. Defaults to the establishment's",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID of the payment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/email-to-id": {
+            "get": {
+                "description": "Retrieves the ID of a user by their email address. This endpoint is typically for internal use or admin purposes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User ID by Email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User's email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (only for admins)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/disable": {
+            "post": {
+                "description": "Disables two-factor authentication for the authenticated user, confirmed with an authenticator or recovery code. Not allowed when an establishment of the user requires it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "Disable Two-Factor Authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Authenticator or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/recovery-codes": {
+            "post": {
+                "description": "Replaces the recovery codes of the authenticated user with new ones, confirmed with an authenticator or recovery code. The previous codes stop working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "Regenerate Recovery Codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Authenticator or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/setup": {
+            "post": {
+                "description": "Generates a new TOTP secret for the authenticated user. Show otpauth_url as a QR code to add it to an authenticator app, then confirm it at /users/me/2fa/verify. Two-factor authentication is not enabled until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "Set Up Two-Factor Authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TwoFactorSetupResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/verify": {
+            "post": {
+                "description": "Enables two-factor authentication with the first code of the authenticator app set up at /users/me/2fa/setup. Returns the recovery codes, which are only shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "Enable Two-Factor Authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Authenticator code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/purchase-pin": {
+            "get": {
+                "description": "Tells whether the authenticated client set a purchase PIN and whether it is locked. Admins need it to charge purchases above the PIN threshold of the establishment to the client's account. Only clients have a purchase PIN.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Purchase PIN",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PurchasePinResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Sets the purchase PIN of the authenticated client, 4 to 6 digits, confirmed with their password. Setting it again resets a forgotten PIN and lifts the 30-minute lockout that follows five wrong PINs in a row. Only clients have a purchase PIN.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set Purchase PIN",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Password and new PIN",
+                        "name": "pin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetPurchasePinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PurchasePinResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions": {
+            "get": {
+                "description": "Lists the active sessions of the authenticated user: the device, IP address and last activity of each login. The session of the request is flagged as current. Activity is recorded when the session logs in or refreshes its tokens.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "List Sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.SessionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions/{id}": {
+            "delete": {
+                "description": "Logs the authenticated user out of one of their sessions. Its refresh token stops working right away; access tokens already issued to it last until they expire.",
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Revoke Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/verification": {
+            "get": {
+                "description": "Tells which of the email and phone of the authenticated user are verified. Codes are sent to them when they are registered or changed; statements are only emailed to a verified email.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Contact Verification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ContactVerificationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/verification/{channel}/resend": {
+            "post": {
+                "description": "Sends a new code to the email or, by SMS, the phone of the authenticated user, replacing the previous one. Codes expire after 15 minutes or 5 wrong attempts, and a new one can be asked for once a minute.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Resend Verification Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "email",
+                            "phone"
+                        ],
+                        "type": "string",
+                        "description": "Channel to verify",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ContactVerificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/verification/{channel}/verify": {
+            "post": {
+                "description": "Verifies the email or phone of the authenticated user with the code last sent to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Verify Contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "email",
+                            "phone"
+                        ],
+                        "type": "string",
+                        "description": "Channel to verify",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Code sent to the channel",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ContactVerificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Retrieves a user by their ID. Admins can retrieve any user, Clients can only retrieve themselves. Admins retrieving a client of their establishment also get the tags and notes the establishment has about them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates user details, including the URL of the profile photo. Users can update themselves, and admins the clients holding a credit account in their establishment or its branches; other users are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated user data (including photo_url)",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a user by their ID. Only Admins can delete users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes only the user details the request sets, including the URL of the profile photo. Unlike PUT, blank values are set too. Fields left out or null are kept. Users can patch themselves, and admins the clients holding a credit account in their establishment or its branches; other users are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Patch User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User fields to change",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PatchUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/anonymize": {
+            "post": {
+                "description": "Irreversibly erases the personal data of a client: their name, DNI, email, phone, address, photo and documents are removed, along with their contact details in the notifications sent, their devices and their verification codes, and they are logged out for good. Their credit accounts, transactions and signed agreements are kept for the establishment's books, tied to the anonymized client, so they can only be anonymized once they owe nothing (409 Conflict otherwise). Clients can anonymize themselves; admins can anonymize a client whose every credit account is in one of their establishments.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Anonymize Client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/export": {
+            "get": {
+                "description": "Exports all the data held about a client: their profile, credit accounts with their agreements, transactions, installments, payment promises and activity, signups, documents, the notifications sent to them and the impersonations of their account. As json, or as zip for an archive of data.json with the files of their documents. Clients export their own data across every establishment, with their sessions; admins export the data of a client of their establishment (or of the branch of X-Branch-ID) concerning it.",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Export Client Data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Branch to act on. Defaults to the main establishment",
+                        "name": "X-Branch-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or zip. Defaults to json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserDataExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/photo": {
+            "post": {
+                "description": "Uploads a profile photo for a user. The image is validated, resized to the standard sizes and set as the user's photo.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Upload User PhotoUrl",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "User profile photo",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/bank/transfers": {
+            "post": {
+                "description": "Webhook the bank or payment aggregator notifies the transfers it received on. Each notification is signed in X-Bank-Signature with \"sha256=\" and the hex HMAC-SHA256, keyed with BANK_WEBHOOK_SECRET, of X-Bank-Timestamp, a dot and the body; notifications older than BANK_WEBHOOK_TOLERANCE are refused. A transfer whose reference is the confirmation code of a pending payment of the same amount confirms it, and one whose reference is CA- and the ID of a credit account is recorded as a payment on it; the client is emailed a receipt. Others are recorded unmatched. Notifications delivered again are answered with what was done with them the first time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Transfers"
+                ],
+                "summary": "Receive Bank Transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "When the notification was sent, in Unix seconds",
+                        "name": "X-Bank-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of the notification",
+                        "name": "X-Bank-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer received",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BankTransferNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BankTransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/sms/status": {
+            "post": {
+                "description": "Status callback of the texts sent through Twilio, which signs each report with X-Twilio-Signature. Set SMS_STATUS_CALLBACK_URL to this URL.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Receive SMS Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signature of the report",
+                        "name": "X-Twilio-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Twilio's SID of the message",
+                        "name": "MessageSid",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status of the message",
+                        "name": "MessageStatus",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the message wasn't delivered",
+                        "name": "ErrorCode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "cache.Stats": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer"
+                },
+                "hit_rate": {
+                    "description": "Hits / (Hits + Misses), 0 when unused",
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "enums.ActivityType": {
+            "type": "string",
+            "enum": [
+                "PURCHASE",
+                "PAYMENT",
+                "INTEREST_CHARGE",
+                "LATE_FEE",
+                "LIMIT_CHANGE",
+                "ACCOUNT_BLOCKED",
+                "ACCOUNT_UNBLOCKED",
+                "REVERSAL",
+                "WRITE_OFF",
+                "ACCOUNT_CLOSED",
+                "ACCOUNT_REOPENED",
+                "INSTALLMENT_RESCHEDULED",
+                "ADJUSTMENT"
+            ],
+            "x-enum-comments": {
+                "ActivityAdjustment": "An admin charged or credited the balance by hand",
+                "ActivityReversal": "A purchase or payment was deleted",
+                "ActivityWriteOff": "The balance was written off as bad debt"
+            },
+            "x-enum-varnames": [
+                "ActivityPurchase",
+                "ActivityPayment",
+                "ActivityInterestCharge",
+                "ActivityLateFee",
+                "ActivityLimitChange",
+                "ActivityAccountBlocked",
+                "ActivityAccountUnblocked",
+                "ActivityReversal",
+                "ActivityWriteOff",
+                "ActivityAccountClosed",
+                "ActivityAccountReopened",
+                "ActivityReschedule",
+                "ActivityAdjustment"
+            ]
+        },
+        "enums.AdjustmentStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING_APPROVAL",
+                "APPLIED",
+                "REJECTED"
+            ],
+            "x-enum-comments": {
+                "AdjustmentPending": "Above the adjustment threshold, waiting for the approver"
+            },
+            "x-enum-varnames": [
+                "AdjustmentPending",
+                "AdjustmentApplied",
+                "AdjustmentRejected"
+            ]
+        },
+        "enums.ApprovalStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING_APPROVAL",
+                "APPROVED",
+                "REJECTED",
+                "EXPIRED"
+            ],
+            "x-enum-comments": {
+                "ApprovalExpired": "Not decided within the approval days of the establishment"
+            },
+            "x-enum-varnames": [
+                "ApprovalPending",
+                "ApprovalApproved",
+                "ApprovalRejected",
+                "ApprovalExpired"
+            ]
+        },
+        "enums.AttachmentKind": {
+            "type": "string",
+            "enum": [
+                "DNI_SCAN",
+                "CREDIT_AGREEMENT",
+                "OTHER"
+            ],
+            "x-enum-comments": {
+                "AttachmentCreditAgreement": "Signed credit agreement"
+            },
+            "x-enum-varnames": [
+                "AttachmentDNIScan",
+                "AttachmentCreditAgreement",
+                "AttachmentOther"
+            ]
+        },
+        "enums.BankTransferStatus": {
+            "type": "string",
+            "enum": [
+                "CONFIRMED",
+                "RECORDED",
+                "UNMATCHED"
+            ],
+            "x-enum-comments": {
+                "BankTransferConfirmed": "Confirmed the pending payment whose code it referenced",
+                "BankTransferRecorded": "Recorded as a payment on the credit account it referenced",
+                "BankTransferUnmatched": "Referenced no pending payment or credit account, left for an admin"
+            },
+            "x-enum-varnames": [
+                "BankTransferConfirmed",
+                "BankTransferRecorded",
+                "BankTransferUnmatched"
+            ]
+        },
+        "enums.CollectionContactType": {
+            "type": "string",
+            "enum": [
+                "CALL",
+                "VISIT",
+                "MESSAGE"
+            ],
+            "x-enum-comments": {
+                "CollectionMessage": "SMS, WhatsApp or email"
+            },
+            "x-enum-varnames": [
+                "CollectionCall",
+                "CollectionVisit",
+                "CollectionMessage"
+            ]
+        },
+        "enums.CollectionOutcome": {
+            "type": "string",
+            "enum": [
+                "REACHED",
+                "NO_ANSWER",
+                "PROMISED_TO_PAY",
+                "REFUSED",
+                "WRONG_CONTACT"
+            ],
+            "x-enum-comments": {
+                "OutcomePromisedToPay": "Record the promise itself as a payment promise",
+                "OutcomeReached": "Spoke with the client without a commitment",
+                "OutcomeRefused": "The client refused to pay",
+                "OutcomeWrongContact": "The phone or address on file is no longer the client's"
+            },
+            "x-enum-varnames": [
+                "OutcomeReached",
+                "OutcomeNoAnswer",
+                "OutcomePromisedToPay",
+                "OutcomeRefused",
+                "OutcomeWrongContact"
+            ]
+        },
+        "enums.CompoundingPeriod": {
+            "type": "string",
+            "enum": [
+                "DAILY",
+                "MONTHLY",
+                "QUARTERLY"
+            ],
+            "x-enum-varnames": [
+                "Daily",
+                "Monthly",
+                "Quarterly"
+            ]
+        },
+        "enums.ContactChannel": {
+            "type": "string",
+            "enum": [
+                "email",
+                "phone"
+            ],
+            "x-enum-comments": {
+                "ContactPhone": "Verified by SMS"
+            },
+            "x-enum-varnames": [
+                "ContactEmail",
+                "ContactPhone"
+            ]
+        },
+        "enums.CreditAccountEventType": {
+            "type": "string",
+            "enum": [
+                "LIMIT_CHANGED",
+                "RATE_CHANGED",
+                "BLOCKED",
+                "UNBLOCKED",
+                "CLOSED",
+                "REOPENED"
+            ],
+            "x-enum-comments": {
+                "CreditAccountRateChanged": "The annual interest rate changed"
+            },
+            "x-enum-varnames": [
+                "CreditAccountLimitChanged",
+                "CreditAccountRateChanged",
+                "CreditAccountBlocked",
+                "CreditAccountUnblocked",
+                "CreditAccountClosed",
+                "CreditAccountReopened"
+            ]
+        },
+        "enums.CreditAccountStatus": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "CLOSED"
+            ],
+            "x-enum-comments": {
+                "AccountClosed": "Closed by the client or an admin once paid off, kept with its history"
+            },
+            "x-enum-varnames": [
+                "AccountActive",
+                "AccountClosed"
+            ]
+        },
+        "enums.CreditTermAction": {
+            "type": "string",
+            "enum": [
+                "UPDATE",
+                "UNCHANGED",
+                "SKIPPED",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "CreditTermFailed": "Couldn't be updated, see the reason",
+                "CreditTermSkipped": "Written off, its terms are frozen",
+                "CreditTermUnchanged": "The account already has the new terms"
+            },
+            "x-enum-varnames": [
+                "CreditTermUpdate",
+                "CreditTermUnchanged",
+                "CreditTermSkipped",
+                "CreditTermFailed"
+            ]
+        },
+        "enums.CreditType": {
+            "type": "string",
+            "enum": [
+                "SHORT_TERM",
+                "LONG_TERM"
+            ],
+            "x-enum-varnames": [
+                "ShortTerm",
+                "LongTerm"
+            ]
+        },
+        "enums.DigestFrequency": {
+            "type": "string",
+            "enum": [
+                "OFF",
+                "WEEKLY",
+                "MONTHLY"
+            ],
+            "x-enum-varnames": [
+                "DigestOff",
+                "DigestWeekly",
+                "DigestMonthly"
+            ]
+        },
+        "enums.DigestStatus": {
+            "type": "string",
+            "enum": [
+                "SENT",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "DigestSent",
+                "DigestFailed"
+            ]
+        },
+        "enums.DocumentType": {
+            "type": "string",
+            "enum": [
+                "RECEIPT",
+                "INVOICE"
+            ],
+            "x-enum-comments": {
+                "DocumentInvoice": "Factura, series starting with F",
+                "DocumentReceipt": "Boleta de venta, series starting with B"
+            },
+            "x-enum-varnames": [
+                "DocumentReceipt",
+                "DocumentInvoice"
+            ]
+        },
+        "enums.EstablishmentStatus": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "INACTIVE",
+                "SUSPENDED"
+            ],
+            "x-enum-comments": {
+                "EstablishmentInactive": "Deactivated by its admin, or a branch of a deactivated main establishment",
+                "EstablishmentSuspended": "Suspended by the platform, with its branches"
+            },
+            "x-enum-varnames": [
+                "EstablishmentActive",
+                "EstablishmentInactive",
+                "EstablishmentSuspended"
+            ]
+        },
+        "enums.ImportAction": {
+            "type": "string",
+            "enum": [
+                "CREATE",
+                "UPDATE",
+                "UNCHANGED",
+                "SKIPPED",
+                "CONFLICT",
+                "INVALID"
+            ],
+            "x-enum-comments": {
+                "ImportConflict": "Conflicts with an existing one, failing the import in the FAIL mode",
+                "ImportInvalid": "Can't be imported, see the reason",
+                "ImportSkipped": "Conflicts with an existing one, kept by the SKIP mode",
+                "ImportUnchanged": "The establishment already has it as imported"
+            },
+            "x-enum-varnames": [
+                "ImportCreate",
+                "ImportUpdate",
+                "ImportUnchanged",
+                "ImportSkipped",
+                "ImportConflict",
+                "ImportInvalid"
+            ]
+        },
+        "enums.ImportConflictMode": {
+            "type": "string",
+            "enum": [
+                "SKIP",
+                "OVERWRITE",
+                "FAIL"
+            ],
+            "x-enum-comments": {
+                "ImportFail": "Import nothing",
+                "ImportOverwrite": "Replace it with the imported one",
+                "ImportSkip": "Keep the existing product"
+            },
+            "x-enum-varnames": [
+                "ImportSkip",
+                "ImportOverwrite",
+                "ImportFail"
+            ]
+        },
+        "enums.InstallmentStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "PARTIALLY_PAID",
+                "PAID",
+                "OVERDUE",
+                "CANCELLED"
+            ],
+            "x-enum-comments": {
+                "Cancelled": "Forgiven by an admin, no longer owed",
+                "Overdue": "Not paid in full by the end of its due date",
+                "PartiallyPaid": "Part of it was paid and the rest isn't due yet"
+            },
+            "x-enum-varnames": [
+                "Pending",
+                "PartiallyPaid",
+                "Paid",
+                "Overdue",
+                "Cancelled"
+            ]
+        },
+        "enums.InterestType": {
+            "type": "string",
+            "enum": [
+                "NOMINAL",
+                "EFFECTIVE"
+            ],
+            "x-enum-varnames": [
+                "Nominal",
+                "Effective"
+            ]
+        },
+        "enums.InvoiceStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "ACCEPTED",
+                "REJECTED",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "InvoiceAccepted": "Accepted by SUNAT, possibly with observations",
+                "InvoiceFailed": "Given up on after failing to submit it too many times",
+                "InvoicePending": "Waiting to be submitted, or to be submitted again",
+                "InvoiceRejected": "Rejected by SUNAT, it must be corrected with a new document"
+            },
+            "x-enum-varnames": [
+                "InvoicePending",
+                "InvoiceAccepted",
+                "InvoiceRejected",
+                "InvoiceFailed"
+            ]
+        },
+        "enums.JobStatus": {
+            "type": "string",
+            "enum": [
+                "QUEUED",
+                "RUNNING",
+                "SUCCEEDED",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "JobQueued",
+                "JobRunning",
+                "JobSucceeded",
+                "JobFailed"
+            ]
+        },
+        "enums.NotificationKind": {
+            "type": "string",
+            "enum": [
+                "payment_reminder",
+                "payment_confirmation",
+                "overdue_notice",
+                "payment_link",
+                "payment_code",
+                "bank_transfer"
+            ],
+            "x-enum-varnames": [
+                "PaymentReminderNotification",
+                "PaymentConfirmationNotification",
+                "OverdueNotification",
+                "PaymentLinkNotification",
+                "PaymentCodeNotification",
+                "BankTransferNotification"
+            ]
+        },
+        "enums.PaymentLinkStatus": {
+            "type": "string",
+            "enum": [
+                "CREATED",
+                "VIEWED",
+                "PAID",
+                "EXPIRED"
+            ],
+            "x-enum-comments": {
+                "PaymentLinkExpired": "Not paid before it expired",
+                "PaymentLinkPaid": "The payment made through it was confirmed",
+                "PaymentLinkViewed": "The client opened it"
+            },
+            "x-enum-varnames": [
+                "PaymentLinkCreated",
+                "PaymentLinkViewed",
+                "PaymentLinkPaid",
+                "PaymentLinkExpired"
+            ]
+        },
+        "enums.PaymentMethod": {
+            "type": "string",
+            "enum": [
+                "YAPE",
+                "PLIN",
+                "CASH",
+                "BANK_TRANSFER"
+            ],
+            "x-enum-comments": {
+                "BANK_TRANSFER": "Notified by the bank through the bank webhook"
+            },
+            "x-enum-varnames": [
+                "YAPE",
+                "PLIN",
+                "CASH",
+                "BANK_TRANSFER"
+            ]
+        },
+        "enums.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "SUCCESS",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
                 "PENDING",
                 "SUCCESS",
                 "FAILED"
+            ]
+        },
+        "enums.Plan": {
+            "type": "string",
+            "enum": [
+                "FREE",
+                "STANDARD",
+                "PREMIUM"
+            ],
+            "x-enum-varnames": [
+                "PlanFree",
+                "PlanStandard",
+                "PlanPremium"
+            ]
+        },
+        "enums.PlanFeature": {
+            "type": "string",
+            "enum": [
+                "PDF_STATEMENTS",
+                "STATEMENT_EMAILS",
+                "REPORT_DIGESTS"
+            ],
+            "x-enum-comments": {
+                "FeaturePDFStatements": "Account statements downloaded as PDF",
+                "FeatureReportDigests": "Report digests emailed to admins",
+                "FeatureStatementEmails": "Monthly statements emailed to clients"
+            },
+            "x-enum-varnames": [
+                "FeaturePDFStatements",
+                "FeatureStatementEmails",
+                "FeatureReportDigests"
+            ]
+        },
+        "enums.PromiseStatus": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "KEPT",
+                "BROKEN"
+            ],
+            "x-enum-comments": {
+                "PromiseBroken": "The promised date passed without the promised amount paid",
+                "PromiseKept": "The promised amount was paid by the promised date"
+            },
+            "x-enum-varnames": [
+                "PromiseActive",
+                "PromiseKept",
+                "PromiseBroken"
+            ]
+        },
+        "enums.PurchaseBlocker": {
+            "type": "string",
+            "enum": [
+                "ACCOUNT_BLOCKED",
+                "ACCOUNT_CLOSED",
+                "ESTABLISHMENT_INACTIVE",
+                "AGREEMENT_NOT_ACCEPTED",
+                "CREDIT_LIMIT_EXCEEDED",
+                "HIGH_RISK_LIMIT_EXCEEDED",
+                "SPENDING_LIMIT_EXCEEDED"
+            ],
+            "x-enum-comments": {
+                "BlockAgreementPending": "The client hasn't accepted the credit agreement yet",
+                "BlockEstablishmentInactive": "The establishment, or the main establishment of the branch, was deactivated",
+                "BlockHighRiskLimit": "Over the purchase limit of high-risk clients",
+                "BlockSpendingLimit": "Over what the client may still spend this week or month"
+            },
+            "x-enum-varnames": [
+                "BlockAccountBlocked",
+                "BlockAccountClosed",
+                "BlockEstablishmentInactive",
+                "BlockAgreementPending",
+                "BlockCreditLimitExceeded",
+                "BlockHighRiskLimit",
+                "BlockSpendingLimit"
+            ]
+        },
+        "enums.Role": {
+            "type": "string",
+            "enum": [
+                "ADMIN",
+                "CLIENT",
+                "USER",
+                "SUPERADMIN"
+            ],
+            "x-enum-comments": {
+                "SUPERADMIN": "Operates the platform, across establishments"
+            },
+            "x-enum-varnames": [
+                "ADMIN",
+                "CLIENT",
+                "USER",
+                "SUPERADMIN"
+            ]
+        },
+        "enums.SMSStatus": {
+            "type": "string",
+            "enum": [
+                "QUEUED",
+                "SENT",
+                "DELIVERED",
+                "UNDELIVERED",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "SMSFailed": "Never sent",
+                "SMSQueued": "Accepted by the provider",
+                "SMSSent": "Handed to the carrier",
+                "SMSUndelivered": "Rejected by the carrier or the phone"
+            },
+            "x-enum-varnames": [
+                "SMSQueued",
+                "SMSSent",
+                "SMSDelivered",
+                "SMSUndelivered",
+                "SMSFailed"
+            ]
+        },
+        "enums.SignupStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "APPROVED",
+                "REJECTED"
+            ],
+            "x-enum-comments": {
+                "SignupApproved": "The client and their credit account were created"
+            },
+            "x-enum-varnames": [
+                "SignupPending",
+                "SignupApproved",
+                "SignupRejected"
+            ]
+        },
+        "enums.SpendingPeriod": {
+            "type": "string",
+            "enum": [
+                "WEEKLY",
+                "MONTHLY"
+            ],
+            "x-enum-comments": {
+                "SpendingMonthly": "Calendar months",
+                "SpendingWeekly": "Weeks start on Monday"
+            },
+            "x-enum-varnames": [
+                "SpendingWeekly",
+                "SpendingMonthly"
+            ]
+        },
+        "enums.StatementDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "SENT",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "StatementSent",
+                "StatementFailed"
+            ]
+        },
+        "enums.TransactionType": {
+            "type": "string",
+            "enum": [
+                "PURCHASE",
+                "PAYMENT",
+                "INTEREST",
+                "LATE_FEE",
+                "ADJUSTMENT_DEBIT",
+                "ADJUSTMENT_CREDIT"
+            ],
+            "x-enum-comments": {
+                "AdjustmentCredit": "Manual credit an admin gave, e.g. a correction or goodwill credit",
+                "AdjustmentDebit": "Manual charge an admin made, e.g. to correct an error",
+                "InterestCharge": "Interest accrued on the balance or charged on a postponed installment",
+                "LateFeeCharge": "Late fee charged on an overdue billing cycle"
+            },
+            "x-enum-varnames": [
+                "Purchase",
+                "Payment",
+                "InterestCharge",
+                "LateFeeCharge",
+                "AdjustmentDebit",
+                "AdjustmentCredit"
+            ]
+        },
+        "event.Name": {
+            "type": "string",
+            "enum": [
+                "transaction.created",
+                "transaction.updated",
+                "transaction.deleted",
+                "payment.confirmed",
+                "interest.accrued",
+                "late_fee.applied",
+                "credit_account.updated",
+                "credit_account.deleted",
+                "purchase.created",
+                "account.blocked",
+                "account.unblocked",
+                "account.written_off",
+                "account.closed",
+                "account.reopened",
+                "installment.rescheduled",
+                "credit_agreement.accepted",
+                "purchase.approval_requested",
+                "purchase.approved",
+                "purchase.rejected",
+                "purchase.approval_expired",
+                "payment_promise.created",
+                "payment_promise.kept",
+                "payment_promise.broken",
+                "payment_link.created",
+                "payment_link.paid",
+                "payment_link.expired",
+                "payment.failed"
+            ],
+            "x-enum-varnames": [
+                "TransactionCreated",
+                "TransactionUpdated",
+                "TransactionDeleted",
+                "PaymentConfirmed",
+                "InterestAccrued",
+                "LateFeeApplied",
+                "CreditAccountUpdated",
+                "CreditAccountDeleted",
+                "PurchaseCreated",
+                "AccountBlocked",
+                "AccountUnblocked",
+                "AccountWrittenOff",
+                "AccountClosed",
+                "AccountReopened",
+                "InstallmentRescheduled",
+                "CreditAgreementAccepted",
+                "PurchaseApprovalRequested",
+                "PurchaseApproved",
+                "PurchaseRejected",
+                "PurchaseApprovalExpired",
+                "PaymentPromiseCreated",
+                "PaymentPromiseKept",
+                "PaymentPromiseBroken",
+                "PaymentLinkCreated",
+                "PaymentLinkPaid",
+                "PaymentLinkExpired",
+                "PaymentFailed"
+            ]
+        },
+        "request.ApproveClientSignupRequest": {
+            "type": "object",
+            "required": [
+                "credit_limit",
+                "credit_type",
+                "interest_type",
+                "monthly_due_date"
+            ],
+            "properties": {
+                "compounding_period": {
+                    "description": "Optional, defaults to MONTHLY",
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "grace_period": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "description": "Optional, defaults to the establishment's default rate",
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "monthly_due_date": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                }
+            }
+        },
+        "request.AssignPlanRequest": {
+            "type": "object",
+            "required": [
+                "plan"
+            ],
+            "properties": {
+                "plan": {
+                    "enum": [
+                        "FREE",
+                        "STANDARD",
+                        "PREMIUM"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.Plan"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.BankTransferNotificationRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "id",
+                "reference",
+                "transferred_at"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "In soles",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "ID the bank gives the notification, the same on every delivery",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "payer_name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "reference": {
+                    "description": "Confirmation code of a pending payment, or CA-\u003ccredit account ID\u003e",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "transferred_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.BlockCreditAccountRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.BulkUpdateCreditAccountsRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/request.CreditAccountFilterRequest"
+                },
+                "preview": {
+                    "description": "List the accounts the update would change and how, without changing them",
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "terms": {
+                    "$ref": "#/definitions/request.CreditTermsRequest"
+                }
+            }
+        },
+        "request.CategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "request.ClientNoteRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "request.ClientSignupRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "dni",
+                "email",
+                "name",
+                "password",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "dni": {
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 8
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 9,
+                    "minLength": 9
+                }
+            }
+        },
+        "request.ClientTagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "request.CloseCreditAccountRequest": {
+            "type": "object",
+            "properties": {
+                "payoff_amount": {
+                    "description": "payoff_amount of a current quote, to settle and close an account that still owes",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.CloseTillSessionRequest": {
+            "type": "object",
+            "required": [
+                "counted_cash"
+            ],
+            "properties": {
+                "counted_cash": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "notes": {
+                    "description": "Explanation of the discrepancy, required when the counted cash isn't the expected one",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.ConfigCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "request.ConfigProductRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "barcode": {
+                    "description": "EAN-13",
+                    "type": "string"
+                },
+                "category": {
+                    "description": "Name of a category of the configuration or the establishment",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "request.CreateAccountAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "reason",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "type": {
+                    "enum": [
+                        "ADJUSTMENT_DEBIT",
+                        "ADJUSTMENT_CREDIT"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.TransactionType"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.CreateAdminAndEstablishmentRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "dni",
+                "email",
+                "establishment_address",
+                "establishment_name",
+                "establishment_phone",
+                "establishment_ruc",
+                "name",
+                "password",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "dni": {
+                    "description": "User fields",
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 8
+                },
+                "email": {
+                    "type": "string"
+                },
+                "establishment_address": {
+                    "type": "string"
+                },
+                "establishment_name": {
+                    "type": "string"
+                },
+                "establishment_phone": {
+                    "type": "string"
+                },
+                "establishment_ruc": {
+                    "description": "Establishment fields",
+                    "type": "string"
+                },
+                "establishment_timezone": {
+                    "description": "Optional, IANA name, defaults to America/Lima",
+                    "type": "string"
+                },
+                "late_fee_percentage": {
+                    "description": "Optional, can be set later",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 9,
+                    "minLength": 9
+                }
+            }
+        },
+        "request.CreateBranchRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "early_payment_discount_percentage": {
+                    "description": "Optional",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "late_fee_percentage": {
+                    "description": "Optional, defaults to the main establishment's",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Optional IANA name, defaults to the main establishment's",
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateCashSaleRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.PurchaseItemRequest"
+                    }
+                }
+            }
+        },
+        "request.CreateClientRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "dni",
+                "establishment_id",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "compounding_period": {
+                    "description": "Optional, defaults to MONTHLY",
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_template_id": {
+                    "description": "Optional, template of the establishment to take the credit terms from",
+                    "type": "integer"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "dni": {
+                    "type": "string",
+                    "maxLength": 8,
+                    "minLength": 8
+                },
+                "email": {
+                    "description": "Optional email",
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "grace_period": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "description": "Optional, defaults to the establishment's default rate",
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "monthly_due_date": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 9,
+                    "minLength": 9
+                }
+            }
+        },
+        "request.CreateCreditAccountRequest": {
+            "type": "object",
+            "required": [
+                "client_id"
+            ],
+            "properties": {
+                "client_id": {
+                    "type": "integer"
+                },
+                "compounding_period": {
+                    "description": "Optional, defaults to MONTHLY",
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_template_id": {
+                    "description": "Optional, template of the establishment to take the terms from",
+                    "type": "integer"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "grace_period": {
+                    "description": "Optional, for long-term credit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "description": "Optional, defaults to the establishment's default rate",
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "monthly_due_date": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                }
+            }
+        },
+        "request.CreateCreditSimulationRequest": {
+            "type": "object",
+            "required": [
+                "credit_type",
+                "interest_rate",
+                "interest_type",
+                "number_of_installments",
+                "principal"
+            ],
+            "properties": {
+                "compounding_period": {
+                    "description": "Optional, defaults to MONTHLY",
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "grace_period": {
+                    "description": "Optional, interest-free months before the first installment (for long-term credit)",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "description": "Annual interest rate (%)",
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "monthly_due_date": {
+                    "description": "Optional, defaults to today's day of the month",
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                },
+                "number_of_installments": {
+                    "type": "integer",
+                    "maximum": 360,
+                    "minimum": 1
+                },
+                "principal": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.CreateDocumentSeriesRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "document_type"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Number new transactions from this series, instead of the active one",
+                    "type": "boolean"
+                },
+                "code": {
+                    "description": "B or F and three letters or digits, e.g. B001",
+                    "type": "string"
+                },
+                "document_type": {
+                    "enum": [
+                        "RECEIPT",
+                        "INVOICE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.DocumentType"
+                        }
+                    ]
+                },
+                "next_number": {
+                    "description": "Number of the first document, 1 if omitted",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.CreateEstablishmentRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone",
+                "ruc"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "early_payment_discount_percentage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "ruc": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA name, defaults to America/Lima",
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateInstallmentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "credit_account_id",
+                "due_date"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreatePaymentLinkRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount to pay when no installment is given",
+                    "type": "number"
+                },
+                "expires_in_hours": {
+                    "description": "24 by default",
+                    "type": "integer",
+                    "maximum": 72,
+                    "minimum": 1
+                },
+                "installment_id": {
+                    "description": "Installment to pay, the link asks for its amount",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreatePaymentPromiseRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "date"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "description": "Day the client promises to pay by, ISO-8601, in the establishment's time zone",
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "description",
+                "establishment_id",
+                "name",
+                "price",
+                "stock"
+            ],
+            "properties": {
+                "barcode": {
+                    "description": "EAN-13",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.CreatePurchaseRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "credit_type",
+                "establishment_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Optional, products bought in quantity",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.PurchaseItemRequest"
+                    }
+                },
+                "product_ids": {
+                    "description": "One unit of each product",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.CreateTransactionRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "credit_account_id",
+                "payment_method",
+                "transaction_type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "Add PaymentMethod",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.PaymentMethod"
+                        }
+                    ]
+                },
+                "pin": {
+                    "description": "Client's purchase PIN, for purchases above the PIN threshold of the establishment",
+                    "type": "string"
+                },
+                "transaction_type": {
+                    "$ref": "#/definitions/enums.TransactionType"
+                }
+            }
+        },
+        "request.CreditAccountFilterRequest": {
+            "type": "object",
+            "properties": {
+                "credit_account_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "credit_template_id": {
+                    "description": "Accounts opened with the template",
+                    "type": "integer"
+                },
+                "credit_type": {
+                    "enum": [
+                        "SHORT_TERM",
+                        "LONG_TERM"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CreditType"
+                        }
+                    ]
+                },
+                "interest_type": {
+                    "enum": [
+                        "NOMINAL",
+                        "EFFECTIVE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.InterestType"
+                        }
+                    ]
+                },
+                "status": {
+                    "enum": [
+                        "ACTIVE",
+                        "CLOSED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CreditAccountStatus"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "Accounts of clients with every one of the tags",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.CreditTemplateRequest": {
+            "type": "object",
+            "required": [
+                "credit_limit",
+                "credit_type",
+                "interest_type",
+                "monthly_due_date",
+                "name"
+            ],
+            "properties": {
+                "compounding_period": {
+                    "description": "Optional, defaults to MONTHLY",
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_type": {
+                    "enum": [
+                        "SHORT_TERM",
+                        "LONG_TERM"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CreditType"
+                        }
+                    ]
+                },
+                "grace_period": {
+                    "description": "Optional, for long-term credit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "description": "Optional, accounts get the establishment's default rate without one",
+                    "type": "number"
+                },
+                "interest_type": {
+                    "enum": [
+                        "NOMINAL",
+                        "EFFECTIVE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.InterestType"
+                        }
+                    ]
+                },
+                "monthly_due_date": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "request.CreditTermsRequest": {
+            "type": "object",
+            "properties": {
+                "compounding_period": {
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "grace_period": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_type": {
+                    "enum": [
+                        "NOMINAL",
+                        "EFFECTIVE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.InterestType"
+                        }
+                    ]
+                },
+                "late_fee_percentage": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "monthly_due_date": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                },
+                "spending_limit": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "spending_limit_period": {
+                    "enum": [
+                        "WEEKLY",
+                        "MONTHLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.SpendingPeriod"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.EstablishmentConfigRequest": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "items": {
+                        "$ref": "#/definitions/request.ConfigCategoryRequest"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "maxItems": 5000,
+                    "items": {
+                        "$ref": "#/definitions/request.ConfigProductRequest"
+                    }
+                },
+                "settings": {
+                    "description": "The adjustment approver isn't imported, it is an admin of the exporting establishment",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/request.UpdateEstablishmentSettingsRequest"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.HolidayRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "name"
+            ],
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "request.ImpersonateClientRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.ImportEstablishmentConfigRequest": {
+            "type": "object",
+            "required": [
+                "config"
+            ],
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/request.EstablishmentConfigRequest"
+                },
+                "dry_run": {
+                    "description": "Only report what the import would do",
+                    "type": "boolean"
+                },
+                "on_conflict": {
+                    "description": "For products that exist and differ, SKIP if omitted",
+                    "type": "string",
+                    "enum": [
+                        "SKIP",
+                        "OVERWRITE",
+                        "FAIL"
+                    ]
+                }
+            }
+        },
+        "request.LogCollectionContactRequest": {
+            "type": "object",
+            "required": [
+                "outcome",
+                "type"
+            ],
+            "properties": {
+                "contacted_at": {
+                    "description": "RFC 3339, defaults to now",
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "outcome": {
+                    "enum": [
+                        "REACHED",
+                        "NO_ANSWER",
+                        "PROMISED_TO_PAY",
+                        "REFUSED",
+                        "WRONG_CONTACT"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CollectionOutcome"
+                        }
+                    ]
+                },
+                "type": {
+                    "enum": [
+                        "CALL",
+                        "VISIT",
+                        "MESSAGE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CollectionContactType"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "use_cookie": {
+                    "description": "Optional, also store the tokens in HttpOnly cookies (browser clients)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.OpenTillSessionRequest": {
+            "type": "object",
+            "required": [
+                "opening_cash"
+            ],
+            "properties": {
+                "opening_cash": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.PatchCreditAccountRequest": {
+            "type": "object",
+            "properties": {
+                "compounding_period": {
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_type": {
+                    "enum": [
+                        "SHORT_TERM",
+                        "LONG_TERM"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CreditType"
+                        }
+                    ]
+                },
+                "grace_period": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_type": {
+                    "enum": [
+                        "NOMINAL",
+                        "EFFECTIVE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.InterestType"
+                        }
+                    ]
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "late_fee_percentage": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "monthly_due_date": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                },
+                "spending_limit": {
+                    "description": "Most the client may spend per period, on top of the credit limit. 0 removes the limit",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "spending_limit_period": {
+                    "enum": [
+                        "WEEKLY",
+                        "MONTHLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.SpendingPeriod"
+                        }
+                    ]
+                },
+                "version": {
+                    "description": "Version of the account the change is based on, as last read. Required unless sent in If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.PatchEstablishmentRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "early_payment_discount_percentage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "late_fee_percentage": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "phone": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "ruc": {
+                    "description": "Ignored for branches",
+                    "type": "string",
+                    "minLength": 1
+                },
+                "timezone": {
+                    "description": "IANA name",
+                    "type": "string",
+                    "minLength": 1
+                },
+                "version": {
+                    "description": "Version of the establishment the change is based on, as last read. Required unless sent in If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.PatchProductRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "description": "EAN-13. Empty to remove it",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "description": "Empty to remove it",
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "version": {
+                    "description": "Version of the product the change is based on, as last read. Required unless sent in If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.PatchUserRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PayPaymentLinkRequest": {
+            "type": "object",
+            "required": [
+                "payment_method"
+            ],
+            "properties": {
+                "payment_method": {
+                    "enum": [
+                        "YAPE",
+                        "PLIN"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.PaymentMethod"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.PayoffRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "payoff_amount of the quote",
+                    "type": "number"
+                }
+            }
+        },
+        "request.PurchaseItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.PurchaseQuoteRequest": {
+            "type": "object",
+            "required": [
+                "credit_type",
+                "establishment_id"
+            ],
+            "properties": {
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Optional, products bought in quantity",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.PurchaseItemRequest"
+                    }
+                },
+                "product_ids": {
+                    "description": "One unit of each product",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.RejectAccountAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.RejectClientSignupRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.RejectPurchaseRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.ReopenCreditAccountRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.RescheduleInstallmentRequest": {
+            "type": "object",
+            "required": [
+                "due_date",
+                "reason"
+            ],
+            "properties": {
+                "due_date": {
+                    "description": "New due date, ISO-8601, in the establishment's time zone",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "request.SendReportDigestRequest": {
+            "type": "object",
+            "properties": {
+                "period": {
+                    "description": "Report period, as in the reports. Defaults to last_week",
+                    "type": "string"
+                },
+                "preview": {
+                    "description": "Only compile the digest, without emailing it",
+                    "type": "boolean"
+                },
+                "sections": {
+                    "description": "Defaults to those of the establishment settings",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.SetClientTagsRequest": {
+            "type": "object",
+            "properties": {
+                "tag_ids": {
+                    "description": "Empty to untag the client",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.SetPurchasePinRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "pin"
+            ],
+            "properties": {
+                "password": {
+                    "description": "Current password of the client",
+                    "type": "string"
+                },
+                "pin": {
+                    "description": "4 to 6 digits",
+                    "type": "string"
+                }
+            }
+        },
+        "request.SetSimulatedDateRequest": {
+            "type": "object",
+            "required": [
+                "now"
+            ],
+            "properties": {
+                "now": {
+                    "description": "RFC 3339 date-time the clock should report",
+                    "type": "string"
+                }
+            }
+        },
+        "request.SuspendEstablishmentRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.TwoFactorChallengeRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TwoFactorLoginRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "description": "Returned by the login",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Authenticator or recovery code",
+                    "type": "string"
+                },
+                "use_cookie": {
+                    "description": "Optional, also store the tokens in HttpOnly cookies (browser clients)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.UpdateAccountingAccountsRequest": {
+            "type": "object",
+            "required": [
+                "accounts"
+            ],
+            "properties": {
+                "accounts": {
+                    "description": "Account code by event, e.g. {\"SALES\": \"7011\"}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.UpdateCreditAccountRequest": {
+            "type": "object",
+            "properties": {
+                "compounding_period": {
+                    "enum": [
+                        "DAILY",
+                        "MONTHLY",
+                        "QUARTERLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CompoundingPeriod"
+                        }
+                    ]
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "grace_period": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "monthly_due_date": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                },
+                "spending_limit": {
+                    "description": "Most the client may spend per period, on top of the credit limit. 0 removes the limit",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "spending_limit_period": {
+                    "enum": [
+                        "WEEKLY",
+                        "MONTHLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.SpendingPeriod"
+                        }
+                    ]
+                },
+                "version": {
+                    "description": "Version of the account the change is based on, as last read. Required unless sent in If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateDocumentSeriesRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "next_number": {
+                    "description": "Can't go back to numbers already issued",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.UpdateEstablishmentRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone",
+                "ruc"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "early_payment_discount_percentage": {
+                    "description": "Optional",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "late_fee_percentage": {
+                    "description": "Optional",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "ruc": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Optional, IANA name",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version of the establishment the change is based on, as last read. Required unless sent in If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateEstablishmentSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "adjustment_approver_id": {
+                    "description": "Admin, other than the establishment's, who approves adjustments above the threshold, 0 for none",
+                    "type": "integer"
+                },
+                "adjustment_threshold": {
+                    "description": "Manual adjustments above it need the approver's approval, 0 to approve none",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "approval_expiry_days": {
+                    "description": "Days purchases wait for approval before they expire",
+                    "type": "integer",
+                    "maximum": 30,
+                    "minimum": 1
+                },
+                "approval_threshold": {
+                    "description": "Client purchases above it wait for an admin's approval, 0 to approve none",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "auto_block_days_overdue": {
+                    "description": "0 to never block automatically",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "default_interest_rate": {
+                    "description": "Annual rate (%), 0 to require a rate on every new account",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "digest_frequency": {
+                    "description": "Report digest emailed to the admin",
+                    "type": "string",
+                    "enum": [
+                        "OFF",
+                        "WEEKLY",
+                        "MONTHLY"
+                    ]
+                },
+                "digest_sections": {
+                    "description": "Empty for all of them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "high_risk_max_purchase": {
+                    "description": "0 to let high-risk clients buy up to their credit limit",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "high_risk_score": {
+                    "description": "Clients scoring below are flagged high risk",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "language": {
+                    "description": "Of emails, PDFs and API messages for requests without an Accept-Language header",
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en"
+                    ]
+                },
+                "late_fee_amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "late_fee_cap": {
+                    "description": "Most a billing cycle is charged in late fees, as a percentage of its overdue amount, 0 for no cap",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "late_fee_mode": {
+                    "description": "Late fees charged on overdue accounts. FLAT and DAILY charge the establishment's late fee percentage of the\noverdue amount, once per billing cycle or for every day overdue, FIXED charges LateFeeAmount once per cycle",
+                    "type": "string",
+                    "enum": [
+                        "FLAT",
+                        "DAILY",
+                        "FIXED"
+                    ]
+                },
+                "max_installments": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 1
+                },
+                "max_reschedules": {
+                    "description": "Installment reschedules a credit account may have, 0 to allow none",
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 0
+                },
+                "payment_code_minutes": {
+                    "description": "Minutes non-cash payments wait for their confirmation code before they expire",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 5
+                },
+                "pin_threshold": {
+                    "description": "Purchases admins charge above it need the client's purchase PIN, 0 to never ask",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "prices_exclude_tax": {
+                    "description": "Product prices are before tax, which is added when they are sold",
+                    "type": "boolean"
+                },
+                "reminder_days_before": {
+                    "description": "Only remind that many days before, 0 for no reminders. Ignored with reminder_schedule",
+                    "type": "integer",
+                    "maximum": 28,
+                    "minimum": 0
+                },
+                "reminder_schedule": {
+                    "description": "Days from their due date clients are sent payment reminders on, before it or on it, and overdue notices, after\nit, e.g. [-7, -3, -1, 0, 3]. Empty to send none",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "require_admin_two_factor": {
+                    "description": "Admins without two-factor authentication must set it up at their next login",
+                    "type": "boolean"
+                },
+                "reschedule_interest": {
+                    "description": "Charge postponed installments the account's interest for the extra days",
+                    "type": "boolean"
+                },
+                "sms_notifications": {
+                    "description": "Also text payment reminders, confirmations, overdue notices, payment links and confirmation codes to verified phones",
+                    "type": "boolean"
+                },
+                "sms_sender": {
+                    "description": "Number (E.164) or alphanumeric sender ID texts come from, empty for the API's",
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "tax_rate": {
+                    "description": "IGV rate (%). Exempt sales are not supported",
+                    "type": "number",
+                    "maximum": 100
+                }
+            }
+        },
+        "request.UpdateInstallmentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Only CANCELLED can be set by hand",
+                    "enum": [
+                        "PENDING",
+                        "PARTIALLY_PAID",
+                        "PAID",
+                        "OVERDUE",
+                        "CANCELLED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.InstallmentStatus"
+                        }
+                    ]
+                },
+                "version": {
+                    "description": "Version of the installment the change is based on, as last read. Required unless sent in If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateProductRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "description": "EAN-13. Empty to remove it",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "description": "Empty to remove it",
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "version": {
+                    "description": "Version of the product the change is based on, as last read. Required unless sent in If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateStatementEmailsRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
             ],
-            "x-enum-varnames": [
-                "PENDING",
-                "SUCCESS",
-                "FAILED"
-            ]
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.UpdateTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "transaction_type": {
+                    "$ref": "#/definitions/enums.TransactionType"
+                }
+            }
+        },
+        "request.UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Optional",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Optional",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "Optional",
+                    "type": "string"
+                },
+                "photo_url": {
+                    "description": "Optional",
+                    "type": "string"
+                }
+            }
+        },
+        "request.VerifyContactRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.WriteOffCreditAccountRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "response.AccountAdjustmentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by_id": {
+                    "type": "integer"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "rejection_reason": {
+                    "type": "string"
+                },
+                "requested_by_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.AdjustmentStatus"
+                },
+                "transaction_id": {
+                    "description": "Adjustment transaction, once applied",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/enums.TransactionType"
+                }
+            }
+        },
+        "response.AccountBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "payoff_quote": {
+                    "description": "Left out when only written-off debt is owed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.PayoffQuoteResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "response.AccountStatementResponse": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "description": "Manual adjustment debits less credits over the period",
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "interest_charged": {
+                    "description": "Interest charged over the period",
+                    "type": "number"
+                },
+                "late_fees_charged": {
+                    "description": "Late fees charged over the period",
+                    "type": "number"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "starting_balance": {
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TransactionResponse"
+                    }
+                }
+            }
+        },
+        "response.AccountSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "account_credit": {
+                    "type": "number"
+                },
+                "current_balance": {
+                    "type": "number"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "rates": {
+                    "$ref": "#/definitions/response.CreditRatesResponse"
+                },
+                "total_interest": {
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TransactionResponse"
+                    }
+                }
+            }
+        },
+        "response.AccountingAccountsResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Account code by event, empty for the events not mapped yet",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "unmapped": {
+                    "description": "Events without an account. The journal can't be exported until there are none",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.ActivityResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is the change to what the client owes: positive for charges, negative for payments",
+                    "type": "number"
+                },
+                "balance": {
+                    "description": "Balance and CreditLimit are the account's after the entry, omitted for entries older than the feed",
+                    "type": "number"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "\"debit\" for charges, \"credit\" for payments and \"none\" when no money moved",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "Name of the icon the app shows for the type, e.g. \"shopping-cart\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/enums.ActivityType"
+                }
+            }
+        },
+        "response.AdminActivityResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is the change to what the client owes: positive for charges, negative for payments",
+                    "type": "number"
+                },
+                "balance": {
+                    "description": "Balance and CreditLimit are the account's after the entry, omitted for entries older than the feed",
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "\"debit\" for charges, \"credit\" for payments and \"none\" when no money moved",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "Name of the icon the app shows for the type, e.g. \"shopping-cart\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/enums.ActivityType"
+                }
+            }
+        },
+        "response.AdminDebtSummary": {
+            "type": "object",
+            "properties": {
+                "broken_promises": {
+                    "description": "Promises to pay the client didn't keep",
+                    "type": "integer"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "credit_score": {
+                    "type": "integer"
+                },
+                "credit_type": {
+                    "type": "string"
+                },
+                "current_balance": {
+                    "type": "number"
+                },
+                "due_date": {
+                    "description": "For short-term or next installment",
+                    "type": "string"
+                },
+                "high_risk": {
+                    "type": "boolean"
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "number_of_installments": {
+                    "description": "Only for long-term",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.AdminHomeResponse": {
+            "type": "object",
+            "properties": {
+                "collected_today": {
+                    "description": "What those payments add up to",
+                    "type": "number"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "establishment_name": {
+                    "type": "string"
+                },
+                "low_stock_products": {
+                    "description": "Those running out first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LowStockProductResponse"
+                    }
+                },
+                "overdue_accounts": {
+                    "type": "integer"
+                },
+                "payments_today": {
+                    "description": "Payments collected since midnight, local time",
+                    "type": "integer"
+                },
+                "pending_confirmations": {
+                    "type": "integer"
+                },
+                "recent_activity": {
+                    "description": "Latest entries of the activity of all the credit accounts, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AdminActivityResponse"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.EstablishmentStatus"
+                },
+                "suspension_reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AdminResponse": {
+            "type": "object",
+            "properties": {
+                "establishment": {
+                    "$ref": "#/definitions/response.EstablishmentResponse"
+                },
+                "user": {
+                    "$ref": "#/definitions/response.UserResponse"
+                }
+            }
+        },
+        "response.AllocatedInstallmentResponse": {
+            "type": "object",
+            "properties": {
+                "allocated": {
+                    "description": "Paid of it by the payment",
+                    "type": "number"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "installment_id": {
+                    "type": "integer"
+                },
+                "outstanding": {
+                    "description": "Left to pay of it after the payment",
+                    "type": "number"
+                },
+                "paid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "integer"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/enums.AttachmentKind"
+                },
+                "retain_until": {
+                    "description": "Kept at least until then, deleted after",
+                    "type": "string"
+                },
+                "sha256": {
+                    "description": "Hex digest of the file",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "In bytes",
+                    "type": "integer"
+                },
+                "uploaded_by_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "challenge_token": {
+                    "type": "string"
+                },
+                "csrf_token": {
+                    "description": "Only set for cookie sessions",
+                    "type": "string"
+                },
+                "recovery_codes": {
+                    "description": "Issued when 2FA is set up during the login",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "two_factor_required": {
+                    "description": "Send an authenticator or recovery code to /login/2fa",
+                    "type": "boolean"
+                },
+                "two_factor_setup_required": {
+                    "description": "The establishment requires 2FA: set it up with /login/2fa/setup first",
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.BadDebtAccountResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "outstanding": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recovered": {
+                    "description": "Paid since",
+                    "type": "number"
+                },
+                "written_off": {
+                    "description": "Balance owed when written off",
+                    "type": "number"
+                },
+                "written_off_at": {
+                    "type": "string"
+                },
+                "written_off_by_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.BadDebtReportResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Newest write-off first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BadDebtAccountResponse"
+                    }
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "outstanding": {
+                    "type": "number"
+                },
+                "recovered": {
+                    "type": "number"
+                },
+                "written_off": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.BalanceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BalancePoint"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BalancePoint": {
+            "type": "object",
+            "properties": {
+                "account_credit": {
+                    "type": "number"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "current": {
+                    "description": "The balance as of now, the day isn't closed yet",
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "written_off_balance": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.BankTransferResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payer_name": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.BankTransferStatus"
+                },
+                "transaction_id": {
+                    "description": "Payment it confirmed or was recorded as",
+                    "type": "integer"
+                },
+                "transferred_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BranchReportResponse": {
+            "type": "object",
+            "properties": {
+                "branches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BranchSummaryResponse"
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/response.BranchSummaryResponse"
+                }
+            }
+        },
+        "response.BranchSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "description": "Manual adjustment debits less credits",
+                    "type": "number"
+                },
+                "cash_sales": {
+                    "type": "number"
+                },
+                "credit_accounts": {
+                    "type": "integer"
+                },
+                "credit_sales": {
+                    "type": "number"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "interest_and_fees": {
+                    "description": "Interest and late fees charged",
+                    "type": "number"
+                },
+                "is_main": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outstanding_balance": {
+                    "description": "Owed on the branch's credit accounts right now",
+                    "type": "number"
+                },
+                "overdue_accounts": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.BulkCreditTermUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CreditTermAccountResult"
+                    }
+                },
+                "preview": {
+                    "type": "boolean"
+                },
+                "summary": {
+                    "$ref": "#/definitions/response.CreditTermUpdateSummary"
+                },
+                "update_id": {
+                    "description": "Audit record of the update, nil in preview",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CatalogProductResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Name of the category",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "in_stock": {
+                    "type": "boolean"
+                },
+                "low_stock": {
+                    "description": "Only a few units left",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Including tax, as the client pays it",
+                    "type": "number"
+                }
+            }
+        },
+        "response.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ClientBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account_credit": {
+                    "description": "Overpaid amount applied to the next purchase",
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "current_balance": {
+                    "type": "number"
+                },
+                "spending_allowance": {
+                    "description": "What the client may still spend in the current period, only if the account has a spending limit",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.SpendingAllowanceResponse"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "description": "Last change of the credit account",
+                    "type": "string"
+                }
+            }
+        },
+        "response.ClientHomeResponse": {
+            "type": "object",
+            "properties": {
+                "available_credit": {
+                    "description": "0 while the account is blocked, closed or written off",
+                    "type": "number"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "current_balance": {
+                    "type": "number"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "establishment_name": {
+                    "type": "string"
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "next_due_amount": {
+                    "type": "number"
+                },
+                "next_due_date": {
+                    "description": "Omitted when nothing is owed",
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "recent_movements": {
+                    "description": "Latest entries of the activity feed, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ActivityResponse"
+                    }
+                },
+                "unread_notifications": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ClientNoteResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ClientNotificationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/enums.NotificationKind"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "read_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ClientSearchResponse": {
+            "type": "object",
+            "properties": {
+                "account_credit": {
+                    "description": "Overpaid amount applied to the next purchase",
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "current_balance": {
+                    "type": "number"
+                },
+                "days_overdue": {
+                    "description": "Days the oldest unpaid amount is past its due date",
+                    "type": "integer"
+                },
+                "dni": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "high_risk": {
+                    "type": "boolean"
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ClientSignupResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "description": "Opened once approved",
+                    "type": "integer"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by_id": {
+                    "type": "integer"
+                },
+                "dni": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.SignupStatus"
+                }
+            }
+        },
+        "response.ClientTagResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ClockResponse": {
+            "type": "object",
+            "properties": {
+                "now": {
+                    "type": "string"
+                },
+                "simulated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.CohortPeriodResponse": {
+            "type": "object",
+            "properties": {
+                "default_percentage": {
+                    "type": "number"
+                },
+                "defaulted": {
+                    "description": "No payment within the default threshold after the due date",
+                    "type": "integer"
+                },
+                "eligible": {
+                    "description": "Clients that owed something at the due date",
+                    "type": "integer"
+                },
+                "late": {
+                    "type": "integer"
+                },
+                "month": {
+                    "description": "YYYY-MM of the due date",
+                    "type": "string"
+                },
+                "months_since_onboarding": {
+                    "type": "integer"
+                },
+                "on_time": {
+                    "type": "integer"
+                },
+                "on_time_percentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.CohortReportResponse": {
+            "type": "object",
+            "properties": {
+                "cohorts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CohortResponse"
+                    }
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CohortResponse": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "integer"
+                },
+                "cohort": {
+                    "description": "Onboarding month, YYYY-MM",
+                    "type": "string"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CohortPeriodResponse"
+                    }
+                }
+            }
+        },
+        "response.CollectionContactResponse": {
+            "type": "object",
+            "properties": {
+                "admin_id": {
+                    "type": "integer"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "contacted_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/enums.CollectionOutcome"
+                },
+                "type": {
+                    "$ref": "#/definitions/enums.CollectionContactType"
+                }
+            }
+        },
+        "response.CollectionWorklistItem": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "Owed, net of the account credit",
+                    "type": "number"
+                },
+                "broken_promises": {
+                    "type": "integer"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "days_overdue": {
+                    "type": "integer"
+                },
+                "dni": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "last_contact_at": {
+                    "description": "Nil if the client was never contacted",
+                    "type": "string"
+                },
+                "last_contact_outcome": {
+                    "$ref": "#/definitions/enums.CollectionOutcome"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "Position in the worklist, from 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ConfigCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ConfigProductResponse": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category": {
+                    "description": "Name of the category",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ContactVerificationResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "email_verified": {
+                    "type": "boolean"
+                },
+                "email_verified_at": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "phone_verified": {
+                    "type": "boolean"
+                },
+                "phone_verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreditAccountBlockEventResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "automatic": {
+                    "type": "boolean"
+                },
+                "blocked": {
+                    "description": "false when the account was unblocked",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreditAccountEventResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "automatic": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_value": {
+                    "description": "Credit limit or interest rate after the change",
+                    "type": "number"
+                },
+                "old_value": {
+                    "description": "Credit limit or interest rate before the change",
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/enums.CreditAccountEventType"
+                }
+            }
+        },
+        "response.CreditAccountFilterResponse": {
+            "type": "object",
+            "properties": {
+                "credit_account_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "credit_template_id": {
+                    "type": "integer"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.CreditAccountStatus"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.CreditAccountResponse": {
+            "type": "object",
+            "properties": {
+                "account_credit": {
+                    "type": "number"
+                },
+                "block_history": {
+                    "description": "Newest first, only included for a single account",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CreditAccountBlockEventResponse"
+                    }
+                },
+                "client": {
+                    "description": "Left out of lists that don't include it, as is Establishment",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    ]
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "compounding_period": {
+                    "$ref": "#/definitions/enums.CompoundingPeriod"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_score": {
+                    "type": "integer"
+                },
+                "credit_template_id": {
+                    "description": "Template the account was opened with",
+                    "type": "integer"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "current_balance": {
+                    "type": "number"
+                },
+                "establishment": {
+                    "$ref": "#/definitions/response.EstablishmentResponse"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "grace_period": {
+                    "type": "integer"
+                },
+                "high_risk": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "last_interest_accrual_date": {
+                    "type": "string"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "monthly_due_date": {
+                    "type": "integer"
+                },
+                "rates": {
+                    "$ref": "#/definitions/response.CreditRatesResponse"
+                },
+                "spending_limit": {
+                    "description": "0 for no limit",
+                    "type": "number"
+                },
+                "spending_limit_period": {
+                    "$ref": "#/definitions/enums.SpendingPeriod"
+                },
+                "status": {
+                    "description": "ACTIVE or CLOSED",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.CreditAccountStatus"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Send it back to update the account",
+                    "type": "integer"
+                },
+                "written_off_at": {
+                    "type": "string"
+                },
+                "written_off_balance": {
+                    "description": "Written-off debt not recovered yet",
+                    "type": "number"
+                }
+            }
+        },
+        "response.CreditAgreementResponse": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "description": "Purchases are held until the client accepts it",
+                    "type": "boolean"
+                },
+                "accepted_at": {
+                    "type": "string"
+                },
+                "accepted_ip": {
+                    "type": "string"
+                },
+                "compounding_period": {
+                    "$ref": "#/definitions/enums.CompoundingPeriod"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "grace_period": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "monthly_due_date": {
+                    "type": "integer"
+                },
+                "tcea": {
+                    "type": "number"
+                },
+                "terms_hash": {
+                    "description": "SHA-256 of the accepted agreement text",
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreditRatesResponse": {
+            "type": "object",
+            "properties": {
+                "tcea": {
+                    "description": "Effective annual cost, including every charge paid by the client",
+                    "type": "number"
+                },
+                "tea": {
+                    "description": "Effective annual rate",
+                    "type": "number"
+                },
+                "tna": {
+                    "description": "Nominal annual rate",
+                    "type": "number"
+                }
+            }
+        },
+        "response.CreditSimulationResponse": {
+            "type": "object",
+            "properties": {
+                "compounding_period": {
+                    "$ref": "#/definitions/enums.CompoundingPeriod"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "effective_annual_rate": {
+                    "description": "TEA (%)",
+                    "type": "number"
+                },
+                "grace_period": {
+                    "type": "integer"
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "monthly_rate": {
+                    "description": "Periodic rate applied to each installment (%)",
+                    "type": "number"
+                },
+                "number_of_installments": {
+                    "type": "integer"
+                },
+                "principal": {
+                    "type": "number"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SimulatedInstallment"
+                    }
+                },
+                "tcea": {
+                    "description": "Effective annual cost of the cash flows (%)",
+                    "type": "number"
+                },
+                "total_interest": {
+                    "type": "number"
+                },
+                "total_payment": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.CreditTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "compounding_period": {
+                    "$ref": "#/definitions/enums.CompoundingPeriod"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "grace_period": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interest_rate": {
+                    "description": "0 for the establishment's default rate",
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "monthly_due_date": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreditTermAccountResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/enums.CreditTermAction"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CreditTermChangeResponse"
+                    }
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Why it was skipped or failed",
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreditTermChangeResponse": {
+            "type": "object",
+            "properties": {
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreditTermUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "admin_id": {
+                    "type": "integer"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CreditTermChangeResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "filter": {
+                    "$ref": "#/definitions/response.CreditAccountFilterResponse"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "terms": {
+                    "$ref": "#/definitions/response.CreditTermsResponse"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CreditTermUpdateSummary": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Changed, or to be changed in preview",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CreditTermsResponse": {
+            "type": "object",
+            "properties": {
+                "compounding_period": {
+                    "$ref": "#/definitions/enums.CompoundingPeriod"
+                },
+                "credit_limit": {
+                    "type": "number"
+                },
+                "grace_period": {
+                    "type": "integer"
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_type": {
+                    "$ref": "#/definitions/enums.InterestType"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "monthly_due_date": {
+                    "type": "integer"
+                },
+                "spending_limit": {
+                    "type": "number"
+                },
+                "spending_limit_period": {
+                    "$ref": "#/definitions/enums.SpendingPeriod"
+                }
+            }
+        },
+        "response.DigestCollectionsResponse": {
+            "type": "object",
+            "properties": {
+                "collected": {
+                    "description": "Confirmed payments",
+                    "type": "number"
+                },
+                "payments": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "description": "Payments waiting for confirmation",
+                    "type": "number"
+                }
+            }
+        },
+        "response.DigestDebtorResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.DigestNewDebtResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "charges": {
+                    "description": "Interest and late fees charged",
+                    "type": "number"
+                },
+                "new_accounts": {
+                    "description": "Credit accounts opened",
+                    "type": "integer"
+                },
+                "purchases": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.DigestOverdueResponse": {
+            "type": "object",
+            "properties": {
+                "newly_overdue": {
+                    "description": "Installments due in the period and still unpaid",
+                    "type": "integer"
+                },
+                "newly_overdue_amount": {
+                    "type": "number"
+                },
+                "overdue_accounts": {
+                    "description": "Accounts with installments overdue at the end of the period",
+                    "type": "integer"
+                },
+                "overdue_amount": {
+                    "type": "number"
+                },
+                "settled_overdue": {
+                    "description": "Installments overdue before the period and paid in it",
+                    "type": "integer"
+                },
+                "settled_overdue_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.DocumentSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "$ref": "#/definitions/enums.DocumentType"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_number": {
+                    "description": "0 if the series issued no documents yet",
+                    "type": "integer"
+                },
+                "next_document_number": {
+                    "description": "e.g. B001-000124",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ElectronicInvoiceResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "digest_value": {
+                    "description": "Hash of the signed XML, once signed",
+                    "type": "string"
+                },
+                "document_number": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "response_code": {
+                    "description": "Code SUNAT answered with, 0 when accepted",
+                    "type": "string"
+                },
+                "response_description": {
+                    "description": "With the observations of accepted receipts",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.InvoiceStatus"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.EstablishmentConfigResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ConfigCategoryResponse"
+                    }
+                },
+                "establishment_id": {
+                    "description": "The one exported",
+                    "type": "integer"
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "products": {
+                    "description": "Without their stock, which stays with the establishment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ConfigProductResponse"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/response.EstablishmentSettingsResponse"
+                }
+            }
+        },
+        "response.EstablishmentImportReport": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "False for dry runs",
+                    "type": "boolean"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ImportItemResult"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "on_conflict": {
+                    "$ref": "#/definitions/enums.ImportConflictMode"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ImportItemResult"
+                    }
+                },
+                "settings": {
+                    "description": "Nil without a settings section",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.ImportAction"
+                        }
+                    ]
+                },
+                "summary": {
+                    "$ref": "#/definitions/response.ImportSummary"
+                }
+            }
+        },
+        "response.EstablishmentPlanResponse": {
+            "type": "object",
+            "properties": {
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "plan": {
+                    "$ref": "#/definitions/response.PlanResponse"
+                },
+                "usage": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PlanUsageResponse"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.EstablishmentResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "admin": {
+                    "$ref": "#/definitions/response.UserResponse"
+                },
+                "admin_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "early_payment_discount_percentage": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "late_fee_percentage": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Set for branches",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "ruc": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Send it back to update the establishment",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.EstablishmentSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "adjustment_approver_id": {
+                    "type": "integer"
+                },
+                "adjustment_threshold": {
+                    "type": "number"
+                },
+                "approval_expiry_days": {
+                    "type": "integer"
+                },
+                "approval_threshold": {
+                    "type": "number"
+                },
+                "auto_block_days_overdue": {
+                    "type": "integer"
+                },
+                "default_interest_rate": {
+                    "type": "number"
+                },
+                "digest_frequency": {
+                    "description": "Report digest emailed to the admin",
+                    "type": "string"
+                },
+                "digest_sections": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "high_risk_max_purchase": {
+                    "type": "number"
+                },
+                "high_risk_score": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "late_fee_amount": {
+                    "type": "number"
+                },
+                "late_fee_cap": {
+                    "type": "number"
+                },
+                "late_fee_mode": {
+                    "type": "string"
+                },
+                "max_installments": {
+                    "type": "integer"
+                },
+                "max_reschedules": {
+                    "type": "integer"
+                },
+                "payment_code_minutes": {
+                    "type": "integer"
+                },
+                "pin_threshold": {
+                    "type": "number"
+                },
+                "prices_exclude_tax": {
+                    "type": "boolean"
+                },
+                "reminder_days_before": {
+                    "description": "Of the first reminder, 0 if none is sent before the due date",
+                    "type": "integer"
+                },
+                "reminder_schedule": {
+                    "description": "Days from their due date clients are sent payment reminders and overdue notices on",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "require_admin_two_factor": {
+                    "type": "boolean"
+                },
+                "reschedule_interest": {
+                    "type": "boolean"
+                },
+                "sms_notifications": {
+                    "type": "boolean"
+                },
+                "sms_sender": {
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.EstablishmentTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "client_dni": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "code_sent_to": {
+                    "description": "Channels the confirmation code reached the client on, for payments just made",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/enums.ContactChannel"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "document_number": {
+                    "description": "Receipt number, e.g. B001-000123",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payment_code": {
+                    "description": "Only to whoever made a payment through a payment link; it is sent to the client otherwise",
+                    "type": "string"
+                },
+                "payment_code_expires_at": {
+                    "description": "When the payment expires unless confirmed",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "Add PaymentMethod",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.PaymentMethod"
+                        }
+                    ]
+                },
+                "payment_status": {
+                    "description": "Add PaymentStatus",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.PaymentStatus"
+                        }
+                    ]
+                },
+                "tax_amount": {
+                    "type": "number"
+                },
+                "taxable_amount": {
+                    "description": "Tax breakdown of purchases of products, in statements",
+                    "type": "number"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "transaction_type": {
+                    "$ref": "#/definitions/enums.TransactionType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ForecastAmounts": {
+            "type": "object",
+            "properties": {
+                "expected": {
+                    "description": "Due amounts weighted by each account's punctuality",
+                    "type": "number"
+                },
+                "optimistic": {
+                    "description": "Collected in nine periods out of ten at most",
+                    "type": "number"
+                },
+                "pessimistic": {
+                    "description": "Collected in nine periods out of ten at least",
+                    "type": "number"
+                },
+                "scheduled": {
+                    "description": "Everything due in the period",
+                    "type": "number"
+                }
+            }
+        },
+        "response.ForecastPeriodResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Accounts with something due in the period",
+                    "type": "integer"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "expected": {
+                    "description": "Due amounts weighted by each account's punctuality",
+                    "type": "number"
+                },
+                "optimistic": {
+                    "description": "Collected in nine periods out of ten at most",
+                    "type": "number"
+                },
+                "pessimistic": {
+                    "description": "Collected in nine periods out of ten at least",
+                    "type": "number"
+                },
+                "scheduled": {
+                    "description": "Everything due in the period",
+                    "type": "number"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ForecastReportResponse": {
+            "type": "object",
+            "properties": {
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "granularity": {
+                    "description": "week or month",
+                    "type": "string"
+                },
+                "overdue_balance": {
+                    "description": "Past due and still unpaid, left out of the forecast; see the collections worklist",
+                    "type": "number"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ForecastPeriodResponse"
+                    }
+                },
+                "punctuality": {
+                    "description": "Share of the establishment's installments paid on time, assumed for accounts without a history of their own",
+                    "type": "number"
+                },
+                "total": {
+                    "$ref": "#/definitions/response.ForecastAmounts"
+                }
+            }
+        },
+        "response.HolidayResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "national": {
+                    "description": "One of the national holidays of Peru",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ImpersonationRequestResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ImpersonationRequestResponse"
+                    }
+                }
+            }
+        },
+        "response.ImpersonationTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "Only accepted on read-only client endpoints",
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "impersonation": {
+                    "type": "boolean"
+                },
+                "impersonation_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ImportItemResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/enums.ImportAction"
+                },
+                "existing_id": {
+                    "description": "Category or product of the establishment it matched",
+                    "type": "integer"
+                },
+                "index": {
+                    "description": "Position in its section of the configuration",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Of conflicts and invalid items",
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ImportSummary": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.InstallmentRescheduleResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "installment_id": {
+                    "type": "integer"
+                },
+                "interest": {
+                    "description": "Added to the installment for the days it was postponed",
+                    "type": "number"
+                },
+                "new_due_date": {
+                    "description": "YYYY-MM-DD in the establishment's time zone",
+                    "type": "string"
+                },
+                "original_due_date": {
+                    "description": "YYYY-MM-DD in the establishment's time zone",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "rescheduled_by_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.InstallmentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overdue_at": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.InstallmentStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Send it back to update the installment",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.InviteCodeResponse": {
+            "type": "object",
+            "properties": {
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "invite_code": {
+                    "type": "string"
+                },
+                "signup_path": {
+                    "description": "Endpoint clients sign up at, for a frontend to link to or encode as a QR code",
+                    "type": "string"
+                }
+            }
+        },
+        "response.JobResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.JobStatus"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.LowStockProductResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PaymentAllocationResponse": {
+            "type": "object",
+            "properties": {
+                "allocated_amount": {
+                    "description": "Paid of installments",
+                    "type": "number"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "document_number": {
+                    "type": "string"
+                },
+                "next_due_amount": {
+                    "type": "number"
+                },
+                "next_due_date": {
+                    "type": "string"
+                },
+                "paid_installments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AllocatedInstallmentResponse"
+                    }
+                },
+                "payment_method": {
+                    "$ref": "#/definitions/enums.PaymentMethod"
+                },
+                "payment_status": {
+                    "$ref": "#/definitions/enums.PaymentStatus"
+                },
+                "remaining_amount": {
+                    "description": "Left to pay of the installments after the payment",
+                    "type": "number"
+                },
+                "remaining_schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RemainingInstallmentResponse"
+                    }
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "unallocated_amount": {
+                    "description": "Paid of the rest of the balance",
+                    "type": "number"
+                }
+            }
         },
-        "enums.ProductCategory": {
-            "type": "string",
-            "enum": [
-                "Grocery",
-                "FruitAndVeg",
-                "Meat",
-                "Poultry",
-                "Seafood",
-                "Bakery",
-                "Liquor",
-                "GeneralStore"
-            ],
-            "x-enum-varnames": [
-                "ProductCategoryGrocery",
-                "ProductCategoryFruitAndVeg",
-                "ProductCategoryMeat",
-                "ProductCategoryPoultry",
-                "ProductCategorySeafood",
-                "ProductCategoryBakery",
-                "ProductCategoryLiquor",
-                "ProductCategoryGeneralStore"
-            ]
+        "response.PaymentLinkPageResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "establishment_name": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "installment_id": {
+                    "type": "integer"
+                },
+                "payment": {
+                    "description": "Made through the link, with the code to pay it with",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.TransactionResponse"
+                        }
+                    ]
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.PaymentLinkStatus"
+                }
+            }
         },
-        "enums.Role": {
-            "type": "string",
-            "enum": [
-                "ADMIN",
-                "CLIENT",
-                "USER"
-            ],
-            "x-enum-varnames": [
-                "ADMIN",
-                "CLIENT",
-                "USER"
-            ]
+        "response.PaymentLinkResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by_id": {
+                    "type": "integer"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "installment_id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "sent_to": {
+                    "description": "Channels that reached the client, when the link is created",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/enums.ContactChannel"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.PaymentLinkStatus"
+                },
+                "transaction_id": {
+                    "description": "Payment made through it",
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "viewed_at": {
+                    "type": "string"
+                }
+            }
         },
-        "enums.TransactionType": {
-            "type": "string",
-            "enum": [
-                "PURCHASE",
-                "PAYMENT"
-            ],
-            "x-enum-varnames": [
-                "Purchase",
-                "Payment"
-            ]
+        "response.PaymentPromiseResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by_id": {
+                    "type": "integer"
+                },
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paid": {
+                    "description": "Paid by the promised date, once the promise is kept or broken",
+                    "type": "number"
+                },
+                "promised_date": {
+                    "description": "YYYY-MM-DD in the establishment's time zone",
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.PromiseStatus"
+                }
+            }
         },
-        "request.CreateAdminAndEstablishmentRequest": {
+        "response.PaymentReminderResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "dni",
-                "email",
-                "establishment_address",
-                "establishment_name",
-                "establishment_phone",
-                "establishment_ruc",
-                "name",
-                "password",
-                "phone"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "minLength": 5
+                "amount": {
+                    "type": "number"
                 },
-                "dni": {
-                    "description": "User fields",
-                    "type": "string",
-                    "maxLength": 8,
-                    "minLength": 8
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "days_from_due": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
                 },
                 "email": {
                     "type": "string"
                 },
-                "establishment_address": {
+                "installment_id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/enums.NotificationKind"
+                },
+                "sent_at": {
                     "type": "string"
+                }
+            }
+        },
+        "response.PayoffQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "accrued_interest": {
+                    "description": "Interest accrued since the last accrual, to date",
+                    "type": "number"
                 },
-                "establishment_name": {
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "description": "Early-payment discount, not granted to overdue accounts",
+                    "type": "number"
+                },
+                "discount_percentage": {
+                    "type": "number"
+                },
+                "expires_at": {
                     "type": "string"
                 },
-                "establishment_phone": {
+                "outstanding_principal": {
+                    "type": "number"
+                },
+                "payoff_amount": {
+                    "type": "number"
+                },
+                "quoted_at": {
                     "type": "string"
+                }
+            }
+        },
+        "response.PlanResponse": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/enums.PlanFeature"
+                    }
                 },
-                "establishment_ruc": {
-                    "description": "Establishment fields",
+                "max_branches": {
+                    "type": "integer"
+                },
+                "max_clients": {
+                    "type": "integer"
+                },
+                "max_products": {
+                    "type": "integer"
+                },
+                "plan": {
+                    "$ref": "#/definitions/enums.Plan"
+                }
+            }
+        },
+        "response.PlanUsageResponse": {
+            "type": "object",
+            "properties": {
+                "at_limit": {
+                    "description": "No more can be added without an upgrade",
+                    "type": "boolean"
+                },
+                "max": {
+                    "description": "0 when unlimited, -1 when the plan allows none",
+                    "type": "integer"
+                },
+                "near_limit": {
+                    "description": "80% or more of the quota is used",
+                    "type": "boolean"
+                },
+                "quota": {
+                    "description": "clients, products or branches",
                     "type": "string"
                 },
-                "late_fee_percentage": {
-                    "description": "Optional, can be set later",
-                    "type": "number"
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PlatformAuditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
                 },
-                "name": {
+                "actor_role": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "completed_at": {
+                    "type": "string"
                 },
-                "phone": {
-                    "type": "string",
-                    "maxLength": 9,
-                    "minLength": 9
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "user_agent": {
+                    "type": "string"
                 }
             }
         },
-        "request.CreateClientRequest": {
+        "response.PlatformEstablishmentResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "credit_limit",
-                "credit_type",
-                "dni",
-                "establishment_id",
-                "interest_rate",
-                "interest_type",
-                "monthly_due_date",
-                "name",
-                "phone"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "minLength": 5
+                "admin_email": {
+                    "type": "string"
                 },
-                "credit_limit": {
-                    "type": "number"
+                "admin_id": {
+                    "type": "integer"
                 },
-                "credit_type": {
-                    "$ref": "#/definitions/enums.CreditType"
+                "admin_name": {
+                    "type": "string"
                 },
-                "dni": {
-                    "type": "string",
-                    "maxLength": 8,
-                    "minLength": 8
+                "branches": {
+                    "type": "integer"
                 },
-                "email": {
-                    "description": "Optional email",
+                "created_at": {
                     "type": "string"
                 },
-                "establishment_id": {
+                "id": {
                     "type": "integer"
                 },
-                "grace_period": {
-                    "type": "integer",
-                    "minimum": 0
+                "name": {
+                    "type": "string"
                 },
-                "interest_rate": {
-                    "type": "number"
+                "open_credit_accounts": {
+                    "type": "integer"
                 },
-                "interest_type": {
-                    "$ref": "#/definitions/enums.InterestType"
+                "plan": {
+                    "$ref": "#/definitions/enums.Plan"
                 },
-                "late_fee_percentage": {
-                    "type": "number"
+                "ruc": {
+                    "type": "string"
                 },
-                "monthly_due_date": {
-                    "type": "integer",
-                    "maximum": 31,
-                    "minimum": 1
+                "status": {
+                    "description": "ACTIVE, INACTIVE when its admin deactivated it, or SUSPENDED by the platform",
+                    "type": "string"
                 },
-                "name": {
+                "suspended_at": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string",
-                    "maxLength": 9,
-                    "minLength": 9
+                "suspension_reason": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CreateCreditAccountRequest": {
+        "response.PlatformMetricsResponse": {
             "type": "object",
-            "required": [
-                "client_id",
-                "credit_limit",
-                "credit_type",
-                "interest_rate",
-                "interest_type",
-                "monthly_due_date"
-            ],
             "properties": {
-                "client_id": {
+                "active_establishments": {
                     "type": "integer"
                 },
-                "credit_limit": {
+                "admins": {
+                    "type": "integer"
+                },
+                "branches": {
+                    "type": "integer"
+                },
+                "clients": {
+                    "type": "integer"
+                },
+                "establishments": {
+                    "description": "Main establishments",
+                    "type": "integer"
+                },
+                "establishments_by_plan": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "inactive_establishments": {
+                    "type": "integer"
+                },
+                "new_credit_accounts": {
+                    "type": "integer"
+                },
+                "open_credit_accounts": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "integer"
+                },
+                "payments_amount": {
                     "type": "number"
                 },
-                "credit_type": {
-                    "$ref": "#/definitions/enums.CreditType"
+                "period_days": {
+                    "type": "integer"
                 },
-                "grace_period": {
-                    "description": "Optional, for long-term credit",
-                    "type": "integer",
-                    "minimum": 0
+                "purchases": {
+                    "type": "integer"
                 },
-                "interest_rate": {
+                "purchases_amount": {
                     "type": "number"
                 },
-                "interest_type": {
-                    "$ref": "#/definitions/enums.InterestType"
+                "receivables": {
+                    "type": "number"
                 },
-                "monthly_due_date": {
-                    "type": "integer",
-                    "maximum": 31,
-                    "minimum": 1
+                "suspended_establishments": {
+                    "type": "integer"
+                },
+                "written_off_balance": {
+                    "type": "number"
                 }
             }
         },
-        "request.CreateEstablishmentRequest": {
+        "response.ProductPerformanceResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "name",
-                "phone",
-                "ruc"
-            ],
             "properties": {
-                "address": {
-                    "type": "string"
+                "cash_revenue": {
+                    "type": "number"
                 },
-                "image_url": {
+                "category": {
                     "type": "string"
                 },
-                "late_fee_percentage": {
+                "credit_percentage": {
+                    "description": "Share of the revenue sold on credit",
+                    "type": "number"
+                },
+                "credit_revenue": {
                     "type": "number"
                 },
                 "name": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string"
+                "product_id": {
+                    "type": "integer"
                 },
-                "ruc": {
-                    "type": "string"
+                "revenue": {
+                    "type": "number"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "stock_turnover": {
+                    "description": "Units sold per unit currently in stock",
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "taxable_revenue": {
+                    "description": "Revenue before tax",
+                    "type": "number"
+                },
+                "units_sold": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CreateInstallmentRequest": {
+        "response.ProductReportResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "credit_account_id",
-                "due_date"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "end_date": {
+                    "type": "string"
                 },
-                "credit_account_id": {
+                "establishment_id": {
                     "type": "integer"
                 },
-                "due_date": {
+                "period": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ProductPerformanceResponse"
+                    }
+                },
+                "start_date": {
                     "type": "string"
+                },
+                "total_revenue": {
+                    "type": "number"
+                },
+                "total_tax": {
+                    "type": "number"
+                },
+                "total_taxable": {
+                    "description": "Revenue before tax",
+                    "type": "number"
                 }
             }
         },
-        "request.CreateProductRequest": {
+        "response.ProductResponse": {
             "type": "object",
-            "required": [
-                "category",
-                "description",
-                "establishment_id",
-                "name",
-                "price",
-                "stock"
-            ],
             "properties": {
+                "barcode": {
+                    "type": "string"
+                },
                 "category": {
+                    "description": "Name of the category",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
+                "establishment": {
+                    "$ref": "#/definitions/response.EstablishmentResponse"
+                },
                 "establishment_id": {
                     "type": "integer"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "image_url": {
                     "type": "string"
                 },
@@ -3845,166 +22060,193 @@ const docTemplate = `{
                 "price": {
                     "type": "number"
                 },
+                "sku": {
+                    "type": "string"
+                },
                 "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Send it back to update the product",
+                    "type": "integer"
                 }
             }
         },
-        "request.CreatePurchaseRequest": {
+        "response.PurchaseApprovalItemResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "credit_type",
-                "establishment_id",
-                "product_ids"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "credit_type": {
-                    "$ref": "#/definitions/enums.CreditType"
-                },
-                "establishment_id": {
+                "product_id": {
                     "type": "integer"
                 },
-                "product_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CreateTransactionRequest": {
+        "response.PurchaseApprovalResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "credit_account_id",
-                "payment_method",
-                "transaction_type"
-            ],
             "properties": {
                 "amount": {
                     "type": "number"
                 },
+                "client_id": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "credit_account_id": {
                     "type": "integer"
                 },
-                "description": {
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
+                },
+                "decided_at": {
                     "type": "string"
                 },
-                "payment_method": {
-                    "description": "Add PaymentMethod",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/enums.PaymentMethod"
-                        }
-                    ]
+                "decided_by_id": {
+                    "type": "integer"
                 },
-                "transaction_type": {
-                    "$ref": "#/definitions/enums.TransactionType"
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PurchaseApprovalItemResponse"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.ApprovalStatus"
+                },
+                "transaction_id": {
+                    "description": "Purchase made once approved",
+                    "type": "integer"
                 }
             }
         },
-        "request.LoginRequest": {
+        "response.PurchaseBlockingCondition": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "code": {
+                    "$ref": "#/definitions/enums.PurchaseBlocker"
                 },
-                "password": {
+                "message": {
                     "type": "string"
                 }
             }
-        },
-        "request.ResetPasswordRequest": {
-            "type": "object",
-            "required": [
-                "current_password",
-                "new_password"
-            ],
+        },
+        "response.PurchasePinResponse": {
+            "type": "object",
             "properties": {
-                "current_password": {
+                "is_set": {
+                    "type": "boolean"
+                },
+                "locked_until": {
+                    "description": "Set while too many wrong PINs keep it locked",
                     "type": "string"
                 },
-                "new_password": {
-                    "type": "string",
-                    "minLength": 6
+                "updated_at": {
+                    "description": "When the PIN was last set",
+                    "type": "string"
                 }
             }
         },
-        "request.UpdateCreditAccountRequest": {
+        "response.PurchaseQuoteLineResponse": {
             "type": "object",
             "properties": {
-                "credit_limit": {
-                    "type": "number"
+                "name": {
+                    "type": "string"
                 },
-                "credit_type": {
-                    "$ref": "#/definitions/enums.CreditType"
+                "product_id": {
+                    "type": "integer"
                 },
-                "grace_period": {
-                    "type": "integer",
-                    "minimum": 0
+                "quantity": {
+                    "type": "integer"
                 },
-                "interest_rate": {
+                "tax_amount": {
                     "type": "number"
                 },
-                "interest_type": {
-                    "$ref": "#/definitions/enums.InterestType"
+                "tax_rate": {
+                    "type": "number"
                 },
-                "is_blocked": {
-                    "type": "boolean"
+                "taxable_amount": {
+                    "type": "number"
                 },
-                "late_fee_percentage": {
+                "total": {
                     "type": "number"
                 },
-                "monthly_due_date": {
-                    "type": "integer",
-                    "maximum": 31,
-                    "minimum": 1
+                "unit_price": {
+                    "type": "number"
                 }
             }
         },
-        "request.UpdateEstablishmentRequest": {
+        "response.PurchaseQuoteResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "name",
-                "phone",
-                "ruc"
-            ],
             "properties": {
-                "address": {
-                    "type": "string"
+                "available_credit": {
+                    "description": "Before the purchase",
+                    "type": "number"
                 },
-                "image_url": {
-                    "type": "string"
+                "available_credit_after": {
+                    "type": "number"
                 },
-                "is_active": {
+                "blocking_conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PurchaseBlockingCondition"
+                    }
+                },
+                "can_purchase": {
                     "type": "boolean"
                 },
-                "late_fee_percentage": {
-                    "description": "Optional",
-                    "type": "number"
+                "credit_account_id": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "credit_type": {
+                    "$ref": "#/definitions/enums.CreditType"
                 },
-                "phone": {
-                    "type": "string"
+                "installments": {
+                    "description": "Long-term purchases only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.QuotedInstallmentResponse"
+                    }
                 },
-                "ruc": {
-                    "type": "string"
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PurchaseQuoteLineResponse"
+                    }
+                },
+                "tax_amount": {
+                    "type": "number"
+                },
+                "taxable_amount": {
+                    "type": "number"
+                },
+                "total": {
+                    "description": "Amount to make the purchase for",
+                    "type": "number"
                 }
             }
         },
-        "request.UpdateInstallmentRequest": {
+        "response.QuotedInstallmentResponse": {
             "type": "object",
             "properties": {
                 "amount": {
@@ -4018,344 +22260,439 @@ const docTemplate = `{
                 }
             }
         },
-        "request.UpdateProductRequest": {
+        "response.RealtimeEventResponse": {
             "type": "object",
-            "required": [
-                "category"
-            ],
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/enums.ProductCategory"
+                "client_id": {
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string"
+                "credit_account_id": {
+                    "type": "integer"
                 },
-                "image_url": {
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "event": {
+                    "$ref": "#/definitions/event.Name"
+                },
+                "occurred_at": {
                     "type": "string"
+                }
+            }
+        },
+        "response.ReceivablesPoint": {
+            "type": "object",
+            "properties": {
+                "account_credit": {
+                    "description": "Overpayments in the clients' favor, added up",
+                    "type": "number"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "accounts_with_balance": {
+                    "type": "integer"
                 },
-                "name": {
+                "balance": {
+                    "description": "Balances owed, added up",
+                    "type": "number"
+                },
+                "date": {
                     "type": "string"
                 },
-                "price": {
+                "receivables": {
+                    "description": "Balance less account credit",
                     "type": "number"
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                "written_off_balance": {
+                    "type": "number"
                 }
             }
         },
-        "request.UpdateTransactionRequest": {
+        "response.ReceivablesTrendResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ReceivablesPoint"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.RemainingInstallmentResponse": {
             "type": "object",
             "properties": {
                 "amount": {
                     "type": "number"
                 },
-                "description": {
+                "due_date": {
                     "type": "string"
                 },
-                "transaction_type": {
-                    "$ref": "#/definitions/enums.TransactionType"
+                "installment_id": {
+                    "type": "integer"
+                },
+                "outstanding": {
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.InstallmentStatus"
                 }
             }
         },
-        "request.UpdateUserRequest": {
+        "response.ReportDigestDeliveryResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "Optional",
+                "attempts": {
+                    "type": "integer"
+                },
+                "email": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Optional",
+                "error": {
                     "type": "string"
                 },
-                "phone": {
-                    "description": "Optional",
+                "frequency": {
+                    "$ref": "#/definitions/enums.DigestFrequency"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "period_end": {
                     "type": "string"
                 },
-                "photo_url": {
-                    "description": "Optional",
+                "period_start": {
                     "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.DigestStatus"
                 }
             }
         },
-        "response.AccountStatementResponse": {
+        "response.ReportDigestResponse": {
             "type": "object",
             "properties": {
-                "client_id": {
-                    "type": "integer"
+                "collections": {
+                    "$ref": "#/definitions/response.DigestCollectionsResponse"
                 },
                 "end_date": {
                     "type": "string"
                 },
-                "start_date": {
+                "establishment_id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "starting_balance": {
-                    "type": "number"
+                "new_debt": {
+                    "$ref": "#/definitions/response.DigestNewDebtResponse"
                 },
-                "transactions": {
+                "overdue": {
+                    "$ref": "#/definitions/response.DigestOverdueResponse"
+                },
+                "sent_to": {
+                    "description": "Email the digest was sent to, empty for a preview",
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "top_debtors": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.TransactionResponse"
+                        "$ref": "#/definitions/response.DigestDebtorResponse"
                     }
                 }
             }
         },
-        "response.AccountSummaryResponse": {
+        "response.SMSDeliveryResponse": {
             "type": "object",
             "properties": {
-                "current_balance": {
-                    "type": "number"
+                "client_id": {
+                    "type": "integer"
                 },
-                "due_date": {
+                "credit_account_id": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "total_interest": {
-                    "type": "number"
+                "establishment_id": {
+                    "type": "integer"
                 },
-                "transactions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.TransactionResponse"
-                    }
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/enums.NotificationKind"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.SMSStatus"
+                },
+                "updated_at": {
+                    "description": "When the status was last reported",
+                    "type": "string"
                 }
             }
         },
-        "response.AdminDebtSummary": {
+        "response.SessionResponse": {
             "type": "object",
             "properties": {
-                "client_id": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "client_name": {
+                "current": {
+                    "description": "The session of the request",
+                    "type": "boolean"
+                },
+                "device": {
+                    "description": "Described from the user agent, e.g. \"Chrome on Windows\"",
                     "type": "string"
                 },
-                "credit_type": {
+                "expires_at": {
                     "type": "string"
                 },
-                "current_balance": {
-                    "type": "number"
+                "id": {
+                    "type": "integer"
                 },
-                "due_date": {
-                    "description": "For short-term or next installment",
+                "ip": {
                     "type": "string"
                 },
-                "interest_rate": {
-                    "type": "number"
+                "last_active_at": {
+                    "type": "string"
                 },
-                "number_of_installments": {
-                    "description": "Only for long-term",
-                    "type": "integer"
+                "user_agent": {
+                    "type": "string"
                 }
             }
         },
-        "response.AdminResponse": {
+        "response.SimulatedInstallment": {
             "type": "object",
             "properties": {
-                "establishment": {
-                    "$ref": "#/definitions/response.EstablishmentResponse"
+                "amortization": {
+                    "type": "number"
+                },
+                "closing_balance": {
+                    "type": "number"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "interest": {
+                    "type": "number"
+                },
+                "is_grace_period": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "opening_balance": {
+                    "type": "number"
                 },
-                "user": {
-                    "$ref": "#/definitions/response.UserResponse"
+                "payment": {
+                    "type": "number"
                 }
             }
         },
-        "response.AuthResponse": {
+        "response.SpendingAllowanceResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "limit": {
+                    "type": "number"
+                },
+                "period": {
+                    "$ref": "#/definitions/enums.SpendingPeriod"
+                },
+                "period_end": {
+                    "description": "When the allowance starts over",
                     "type": "string"
                 },
-                "refresh_token": {
+                "period_start": {
                     "type": "string"
-                }
-            }
-        },
-        "response.ClientBalanceResponse": {
-            "type": "object",
-            "properties": {
-                "client_id": {
-                    "type": "integer"
                 },
-                "current_balance": {
+                "remaining": {
+                    "type": "number"
+                },
+                "spent": {
                     "type": "number"
                 }
             }
         },
-        "response.CreditAccountResponse": {
+        "response.StatementDeliveryResponse": {
             "type": "object",
             "properties": {
-                "client": {
-                    "$ref": "#/definitions/response.UserResponse"
+                "attempts": {
+                    "type": "integer"
                 },
                 "client_id": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "credit_limit": {
-                    "type": "number"
-                },
-                "credit_type": {
-                    "$ref": "#/definitions/enums.CreditType"
+                "credit_account_id": {
+                    "type": "integer"
                 },
-                "current_balance": {
-                    "type": "number"
+                "email": {
+                    "type": "string"
                 },
-                "establishment": {
-                    "$ref": "#/definitions/response.EstablishmentResponse"
+                "error": {
+                    "type": "string"
                 },
                 "establishment_id": {
                     "type": "integer"
                 },
-                "grace_period": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "interest_rate": {
-                    "type": "number"
-                },
-                "interest_type": {
-                    "$ref": "#/definitions/enums.InterestType"
-                },
-                "is_blocked": {
-                    "type": "boolean"
-                },
-                "last_interest_accrual_date": {
+                "period_end": {
                     "type": "string"
                 },
-                "late_fee_percentage": {
-                    "type": "number"
-                },
-                "monthly_due_date": {
-                    "type": "integer"
+                "period_start": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "sent_at": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/enums.StatementDeliveryStatus"
                 }
             }
         },
-        "response.ErrorResponse": {
+        "response.StatementEmailSettingsResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 }
             }
         },
-        "response.EstablishmentResponse": {
+        "response.StatementPeriodResponse": {
             "type": "object",
             "properties": {
-                "address": {
+                "adjustments": {
+                    "description": "Manual adjustment debits less credits",
+                    "type": "number"
+                },
+                "closed_at": {
                     "type": "string"
                 },
-                "admin": {
-                    "$ref": "#/definitions/response.UserResponse"
+                "closing_balance": {
+                    "type": "number"
                 },
-                "admin_id": {
+                "credit_account_id": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "image_url": {
-                    "type": "string"
+                "interest_and_fees": {
+                    "type": "number"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "opening_balance": {
+                    "type": "number"
                 },
-                "late_fee_percentage": {
+                "payment_count": {
+                    "type": "integer"
+                },
+                "payments": {
                     "type": "number"
                 },
-                "name": {
+                "period_end": {
                     "type": "string"
                 },
-                "phone": {
+                "period_start": {
                     "type": "string"
                 },
-                "ruc": {
-                    "type": "string"
+                "purchase_count": {
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "purchases": {
+                    "type": "number"
                 }
             }
         },
-        "response.InstallmentResponse": {
+        "response.TillSessionResponse": {
             "type": "object",
             "properties": {
-                "amount": {
+                "cash_payments": {
                     "type": "number"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "credit_account_id": {
-                    "type": "integer"
+                "cash_sales": {
+                    "type": "number"
                 },
-                "due_date": {
+                "closed_at": {
                     "type": "string"
                 },
-                "id": {
+                "closed_by_id": {
                     "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/enums.InstallmentStatus"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "response.ProductResponse": {
-            "type": "object",
-            "properties": {
-                "category": {
-                    "$ref": "#/definitions/enums.ProductCategory"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "counted_cash": {
+                    "description": "Once closed",
+                    "type": "number"
                 },
-                "establishment": {
-                    "$ref": "#/definitions/response.EstablishmentResponse"
+                "discrepancy": {
+                    "description": "Counted less expected cash, once closed",
+                    "type": "number"
                 },
                 "establishment_id": {
                     "type": "integer"
                 },
+                "expected_cash": {
+                    "description": "Opening cash plus the cash payments and sales",
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "image_url": {
+                "notes": {
                     "type": "string"
                 },
-                "is_active": {
+                "open": {
                     "type": "boolean"
                 },
-                "name": {
+                "opened_at": {
                     "type": "string"
                 },
-                "price": {
+                "opened_by_id": {
+                    "type": "integer"
+                },
+                "opening_cash": {
                     "type": "number"
                 },
-                "stock": {
+                "payment_count": {
                     "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "payments": {
+                    "description": "Cash payments collected, in the session report",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TransactionResponse"
+                    }
                 }
             }
         },
@@ -4365,6 +22702,13 @@ const docTemplate = `{
                 "amount": {
                     "type": "number"
                 },
+                "code_sent_to": {
+                    "description": "Channels the confirmation code reached the client on, for payments just made",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/enums.ContactChannel"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -4374,11 +22718,19 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "document_number": {
+                    "description": "Receipt number, e.g. B001-000123",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "payment_code": {
-                    "description": "Add PaymentCode (if generated)",
+                    "description": "Only to whoever made a payment through a payment link; it is sent to the client otherwise",
+                    "type": "string"
+                },
+                "payment_code_expires_at": {
+                    "description": "When the payment expires unless confirmed",
                     "type": "string"
                 },
                 "payment_method": {
@@ -4397,6 +22749,13 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "tax_amount": {
+                    "type": "number"
+                },
+                "taxable_amount": {
+                    "description": "Tax breakdown of purchases of products, in statements",
+                    "type": "number"
+                },
                 "transaction_date": {
                     "type": "string"
                 },
@@ -4408,6 +22767,131 @@ const docTemplate = `{
                 }
             }
         },
+        "response.TwoFactorSetupResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_url": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.UserDataAccountResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ActivityResponse"
+                    }
+                },
+                "agreement": {
+                    "$ref": "#/definitions/response.CreditAgreementResponse"
+                },
+                "collection_contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CollectionContactResponse"
+                    }
+                },
+                "credit_account": {
+                    "$ref": "#/definitions/response.CreditAccountResponse"
+                },
+                "installments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.InstallmentResponse"
+                    }
+                },
+                "payment_promises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PaymentPromiseResponse"
+                    }
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TransactionResponse"
+                    }
+                }
+            }
+        },
+        "response.UserDataExportResponse": {
+            "type": "object",
+            "properties": {
+                "anonymized_at": {
+                    "type": "string"
+                },
+                "attachments": {
+                    "description": "Their files are only in the ZIP export",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AttachmentResponse"
+                    }
+                },
+                "client_signups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ClientSignupResponse"
+                    }
+                },
+                "contact_verification": {
+                    "$ref": "#/definitions/response.ContactVerificationResponse"
+                },
+                "credit_accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.UserDataAccountResponse"
+                    }
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "impersonations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ImpersonationResponse"
+                    }
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ClientNotificationResponse"
+                    }
+                },
+                "payment_reminders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PaymentReminderResponse"
+                    }
+                },
+                "sessions": {
+                    "description": "Only in the export of every establishment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SessionResponse"
+                    }
+                },
+                "sms_deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SMSDeliveryResponse"
+                    }
+                },
+                "statement_deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.StatementDeliveryResponse"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/response.UserResponse"
+                }
+            }
+        },
         "response.UserResponse": {
             "type": "object",
             "properties": {
@@ -4429,6 +22913,13 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "notes": {
+                    "description": "Only for admins, of a client of their establishment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ClientNoteResponse"
+                    }
+                },
                 "phone": {
                     "type": "string"
                 },
@@ -4438,10 +22929,26 @@ const docTemplate = `{
                 "rol": {
                     "$ref": "#/definitions/enums.Role"
                 },
+                "tags": {
+                    "description": "Only for admins, of a client of their establishment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ClientTagResponse"
+                    }
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
+        },
+        "response.VersionConflictResponse": {
+            "type": "object",
+            "properties": {
+                "current": {},
+                "error": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -4453,7 +22960,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "Final Assignment Finance API Rest",
-	Description:      "API for managing finances in small businesses.",
+	Description:      "API for managing finances in small businesses. Every endpoint is also served under /api/v2, which wraps responses in a {data, meta, links} envelope (response.ResponseV2) with links to the response itself and related resources, paginates lists (page and page_size query parameters, with links to the other pages) and returns errors as response.ErrorResponseV2 with a stable code.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
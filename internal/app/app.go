@@ -0,0 +1,107 @@
+// Package app builds the API out of its configuration and database: the repositories, the services
+// and the controllers, the background workers and jobs, and the HTTP routes. The API server is one
+// entrypoint using it; others, like workers or CLI commands, can build the same App and use only
+// the parts they need.
+package app
+
+import (
+	"ApiRestFinance/internal/config"
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/queue"
+	"ApiRestFinance/internal/realtime"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/sms"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/util"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// App is the API with all of its dependencies wired up.
+type App struct {
+	Config       *config.Config
+	Repositories *Repositories
+	Services     *Services
+
+	db               *gorm.DB
+	infra            *infrastructure
+	clock            util.Clock
+	simulatedClock   *util.SimulatedClock // Only in the sandbox environment
+	dbWatchdog       *database.Watchdog
+	jobQueue         *queue.WorkerPool
+	realtimeHub      *realtime.Hub
+	summaryCache     *service.AccountSummaryCache
+	smsStatusReports sms.StatusReports
+	tokenIssuer      *util.TokenIssuer
+	controllers      *controllers
+}
+
+// Build creates the App on an already migrated database. Upload limits are read from configWatcher,
+// so they follow its reloads. Nothing runs in the background until Start is called.
+func Build(cfg *config.Config, configWatcher *config.Watcher, db *gorm.DB) (*App, error) {
+	a := &App{Config: cfg, db: db}
+
+	// The sandbox environment lets admins move the clock to simulate future dates
+	a.clock = util.NewSystemClock()
+	if cfg.IsSandbox() {
+		a.simulatedClock = util.NewSimulatedClock()
+		a.clock = a.simulatedClock
+	}
+	util.UseTokenClock(a.clock)
+
+	// Watch the database connection so requests get a 503 instead of a 500 while it is down
+	dbWatchdog, err := database.NewWatchdog(db, 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database watchdog: %w", err)
+	}
+	a.dbWatchdog = dbWatchdog
+
+	// Keep each request within the establishments of its tenant, see scopedServices. The jobs, and
+	// the routes no tenant is behind, reach every tenant through a system session.
+	if err := tenant.Register(db); err != nil {
+		return nil, err
+	}
+	a.Repositories = newRepositories(tenant.System(db), a.clock)
+	if err := a.buildServices(configWatcher); err != nil {
+		return nil, err
+	}
+	a.controllers = a.newControllers(a.Services)
+	return a, nil
+}
+
+// scopedServices creates the services of a request on repositories scoped to its tenant, so whatever
+// a handler does, it only reaches the data of that tenant.
+func (a *App) scopedServices(scope tenant.Scope) *Services {
+	return a.newServices(newRepositories(tenant.Session(a.db, scope), a.clock), publisher{a.infra.eventBus})
+}
+
+// scopedControllers creates the controllers of a request on services scoped to its tenant.
+func (a *App) scopedControllers(scope tenant.Scope) *controllers {
+	return a.newControllers(a.scopedServices(scope))
+}
+
+// Start runs the database watchdog, the realtime hub and the job workers until ctx is done, and
+// the gRPC server when it's compiled in and configured.
+func (a *App) Start(ctx context.Context) {
+	a.dbWatchdog.Start(ctx)
+	a.realtimeHub.Start(ctx)
+
+	// Services registered their job handlers when they were built, so the workers can start
+	a.jobQueue.Start(ctx, a.Services.Job.RunJob)
+
+	// gRPC server for internal services, only compiled in with the grpc build tag
+	if startGRPCServer != nil && a.Config.GRPC.Address != "" {
+		go func() {
+			if err := startGRPCServer(a.Config, a.Services.CreditAccount, a.Services.Transaction, a.Services.User); err != nil {
+				log.Fatal("Error starting gRPC server: ", err)
+			}
+		}()
+	}
+}
+
+// startGRPCServer is set by grpc.go when building with the grpc build tag.
+var startGRPCServer func(cfg *config.Config, creditAccountService service.CreditAccountService, transactionService service.TransactionService, userService service.UserService) error
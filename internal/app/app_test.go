@@ -0,0 +1,115 @@
+package app
+
+import (
+	"ApiRestFinance/internal/config"
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/queue"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// query is a query run by a service, with the tenant it ran in, if any.
+type query struct {
+	sql    string
+	scope  *tenant.Scope
+	failed error
+}
+
+// newTestApp returns an App wired like Build on a database that only builds its statements, and the
+// queries run on it.
+func newTestApp(t *testing.T) (*App, *[]query) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=app_test sslmode=disable"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("error opening dry run database: %v", err)
+	}
+	if err := tenant.Register(db); err != nil {
+		t.Fatal(err)
+	}
+	var queries []query
+	err = db.Callback().Query().After("gorm:query").Register("test:record", func(db *gorm.DB) {
+		q := query{sql: db.Statement.SQL.String(), failed: db.Error}
+		if scope, ok := tenant.FromContext(db.Statement.Context); ok {
+			q.scope = &scope
+		}
+		queries = append(queries, q)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &App{Config: &config.Config{}, db: db, clock: util.NewFakeClock(time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC))}
+	a.infra = &infrastructure{eventBus: event.NewInMemoryBus(), jobQueue: queue.NewWorkerPool(1, 1)}
+	a.Repositories = newRepositories(tenant.System(db), a.clock)
+	a.Services = a.newServices(a.Repositories, a.infra.eventBus)
+	return a, &queries
+}
+
+func TestScopedServicesRunOnTheTenant(t *testing.T) {
+	a, queries := newTestApp(t)
+
+	scope := tenant.Scope{EstablishmentIDs: []uint{1}}
+	if _, err := a.scopedServices(scope).Product.GetProductByID(5); err != nil {
+		t.Fatalf("GetProductByID returned %v", err)
+	}
+	if len(*queries) == 0 || !strings.Contains((*queries)[0].sql, `"products"."establishment_id" = $2`) {
+		t.Fatalf("queries %v, want the product looked up in the establishment", *queries)
+	}
+	for _, q := range *queries {
+		if q.scope == nil || !reflect.DeepEqual(*q.scope, scope) {
+			t.Errorf("query %s of a request ran in %v, want its tenant", q.sql, q.scope)
+		}
+	}
+}
+
+func TestServicesOfTheAppRunOnTheSystemSession(t *testing.T) {
+	a, queries := newTestApp(t)
+
+	if _, err := a.Services.Product.GetProductByID(5); err != nil {
+		t.Fatalf("GetProductByID returned %v", err)
+	}
+	if len(*queries) == 0 {
+		t.Fatal("no query was run")
+	}
+	for _, q := range *queries {
+		if q.scope != nil || q.failed != nil {
+			t.Errorf("query %s ran in %v and returned %v, want it unscoped", q.sql, q.scope, q.failed)
+		}
+	}
+}
+
+func TestRepositoriesOnTheBareDatabaseRefuseTenantData(t *testing.T) {
+	a, _ := newTestApp(t)
+
+	if _, err := newRepositories(a.db, a.clock).Product.GetProductByID(5); !errors.Is(err, tenant.ErrNoTenant) {
+		t.Errorf("GetProductByID = %v, want %v", err, tenant.ErrNoTenant)
+	}
+}
+
+func TestScopedControllersServeEveryProtectedRoute(t *testing.T) {
+	a, _ := newTestApp(t)
+
+	startup, scoped := tenantRoutes(a.newControllers(a.Services)), tenantRoutes(a.scopedControllers(tenant.Scope{}))
+	if len(scoped) != len(startup) {
+		t.Fatalf("%d routes served per request, want the %d registered", len(scoped), len(startup))
+	}
+	for i := range startup {
+		if scoped[i].method != startup[i].method || scoped[i].path != startup[i].path {
+			t.Errorf("route %d is %s %s per request, registered as %s %s", i, scoped[i].method, scoped[i].path, startup[i].method, startup[i].path)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"ApiRestFinance/internal/controller"
+)
+
+// controllers are the handlers of the HTTP routes.
+type controllers struct {
+	auth                  *controller.AuthController
+	user                  *controller.UserController
+	establishment         *controller.EstablishmentController
+	product               *controller.ProductController
+	creditAccount         *controller.CreditAccountController
+	transaction           *controller.TransactionController
+	installment           *controller.InstallmentController
+	purchase              *controller.PurchaseController
+	report                *controller.ReportController
+	creditSimulation      *controller.CreditSimulationController
+	metrics               *controller.MetricsController
+	realtime              *controller.RealtimeController
+	statementDelivery     *controller.StatementDeliveryController
+	notification          *controller.NotificationController
+	statementPeriod       *controller.StatementPeriodController
+	establishmentSettings *controller.EstablishmentSettingsController
+	job                   *controller.JobController
+	twoFactor             *controller.TwoFactorController
+	purchasePin           *controller.PurchasePinController
+	contactVerification   *controller.ContactVerificationController
+	session               *controller.SessionController
+	impersonation         *controller.ImpersonationController
+	accountActivity       *controller.AccountActivityController
+	documentSeries        *controller.DocumentSeriesController
+	electronicInvoice     *controller.ElectronicInvoiceController
+	category              *controller.CategoryController
+	paymentPromise        *controller.PaymentPromiseController
+	paymentLink           *controller.PaymentLinkController
+	attachment            *controller.AttachmentController
+	privacy               *controller.PrivacyController
+	creditAgreement       *controller.CreditAgreementController
+	clientSignup          *controller.ClientSignupController
+	catalog               *controller.CatalogController
+	accountAdjustment     *controller.AccountAdjustmentController
+	tillSession           *controller.TillSessionController
+	collection            *controller.CollectionController
+	clientTag             *controller.ClientTagController
+	clientNote            *controller.ClientNoteController
+	establishmentConfig   *controller.EstablishmentConfigController
+	creditTemplate        *controller.CreditTemplateController
+	creditTermUpdate      *controller.CreditTermUpdateController
+	balanceHistory        *controller.BalanceHistoryController
+	plan                  *controller.PlanController
+	platform              *controller.PlatformController
+	bankTransfer          *controller.BankTransferController
+	accounting            *controller.AccountingController
+	clientHome            *controller.ClientHomeController
+	clientNotification    *controller.ClientNotificationController
+	adminHome             *controller.AdminHomeController
+	creditAccountEvent    *controller.CreditAccountEventController
+	holiday               *controller.HolidayController
+	sandbox               *controller.SandboxController // Only in the sandbox environment
+}
+
+// newControllers creates the controllers on the services of s.
+func (a *App) newControllers(s *Services) *controllers {
+	cfg := a.Config
+	summaryCache, realtimeHub, smsStatusReports := a.summaryCache, a.realtimeHub, a.smsStatusReports
+
+	c := &controllers{}
+	c.auth = controller.NewAuthController(s.Auth, cfg.JWT.Secret, cfg.IsProduction(), cfg.JWT.AccessTokenTTL, cfg.JWT.RefreshTokenTTL)
+	c.user = controller.NewUserController(s.User, s.Admin, s.CreditAccount, s.Establishment, s.ClientTag, s.ClientNote, s.Authorization)
+	c.establishment = controller.NewEstablishmentController(s.Establishment)
+	c.product = controller.NewProductController(s.Product, s.Establishment)
+	c.creditAccount = controller.NewCreditAccountController(s.CreditAccount, s.Establishment)
+	c.transaction = controller.NewTransactionController(s.Transaction, s.Authorization)
+	c.installment = controller.NewInstallmentController(s.Installment, s.Authorization)
+	c.purchase = controller.NewPurchaseController(s.Purchase)
+	c.report = controller.NewReportController(s.Report, s.ReportDigest)
+	c.creditSimulation = controller.NewCreditSimulationController(s.CreditSimulation)
+	c.metrics = controller.NewMetricsController(summaryCache)
+	c.realtime = controller.NewRealtimeController(realtimeHub, s.Establishment)
+	c.statementDelivery = controller.NewStatementDeliveryController(s.StatementDelivery)
+	c.notification = controller.NewNotificationController(s.NotificationDispatcher, smsStatusReports)
+	c.statementPeriod = controller.NewStatementPeriodController(s.StatementPeriod)
+	c.establishmentSettings = controller.NewEstablishmentSettingsController(s.EstablishmentSettings)
+	c.job = controller.NewJobController(s.Job)
+	c.twoFactor = controller.NewTwoFactorController(s.TwoFactor)
+	c.purchasePin = controller.NewPurchasePinController(s.PurchasePin)
+	c.contactVerification = controller.NewContactVerificationController(s.ContactVerification)
+	c.session = controller.NewSessionController(s.Session)
+	c.impersonation = controller.NewImpersonationController(s.Impersonation)
+	c.accountActivity = controller.NewAccountActivityController(s.AccountActivity)
+	c.documentSeries = controller.NewDocumentSeriesController(s.DocumentSeries)
+	c.electronicInvoice = controller.NewElectronicInvoiceController(s.Invoicing)
+	c.category = controller.NewCategoryController(s.Category)
+	c.paymentPromise = controller.NewPaymentPromiseController(s.PaymentPromise)
+	c.paymentLink = controller.NewPaymentLinkController(s.PaymentLink, cfg.PaymentLinkBaseURL)
+	c.attachment = controller.NewAttachmentController(s.Attachment)
+	c.privacy = controller.NewPrivacyController(s.Privacy)
+	c.creditAgreement = controller.NewCreditAgreementController(s.CreditAgreement)
+	c.clientSignup = controller.NewClientSignupController(s.ClientSignup)
+	c.catalog = controller.NewCatalogController(s.Catalog)
+	c.accountAdjustment = controller.NewAccountAdjustmentController(s.AccountAdjustment)
+	c.tillSession = controller.NewTillSessionController(s.TillSession)
+	c.collection = controller.NewCollectionController(s.Collection)
+	c.clientTag = controller.NewClientTagController(s.ClientTag)
+	c.clientNote = controller.NewClientNoteController(s.ClientNote)
+	c.establishmentConfig = controller.NewEstablishmentConfigController(s.EstablishmentConfig)
+	c.creditTemplate = controller.NewCreditTemplateController(s.CreditTemplate)
+	c.creditTermUpdate = controller.NewCreditTermUpdateController(s.CreditTermUpdate)
+	c.balanceHistory = controller.NewBalanceHistoryController(s.BalanceHistory)
+	c.plan = controller.NewPlanController(s.Plan)
+	c.platform = controller.NewPlatformController(s.Platform)
+	c.bankTransfer = controller.NewBankTransferController(s.BankTransfer, cfg.BankWebhook.Secret != "")
+	c.accounting = controller.NewAccountingController(s.Accounting)
+	c.clientHome = controller.NewClientHomeController(s.ClientHome)
+	c.clientNotification = controller.NewClientNotificationController(s.ClientNotification)
+	c.adminHome = controller.NewAdminHomeController(s.AdminHome)
+	c.creditAccountEvent = controller.NewCreditAccountEventController(s.CreditAccountEvent, s.Authorization)
+	c.holiday = controller.NewHolidayController(s.Holiday)
+	if a.simulatedClock != nil {
+		c.sandbox = controller.NewSandboxController(a.simulatedClock)
+	}
+	return c
+}
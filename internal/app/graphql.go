@@ -0,0 +1,11 @@
+//go:build graphql
+
+package app
+
+import (
+	"ApiRestFinance/internal/graph"
+)
+
+func init() {
+	newGraphQLHandler = graph.NewHandler
+}
@@ -0,0 +1,24 @@
+//go:build grpc
+
+package app
+
+import (
+	"ApiRestFinance/internal/config"
+	"ApiRestFinance/internal/grpcserver"
+	"ApiRestFinance/internal/service"
+	"log"
+)
+
+func init() {
+	startGRPCServer = func(cfg *config.Config, creditAccountService service.CreditAccountService, transactionService service.TransactionService, userService service.UserService) error {
+		server := grpcserver.NewServer(creditAccountService, transactionService, userService)
+		log.Printf("Starting gRPC server on %s...", cfg.GRPC.Address)
+		return server.ListenAndServe(grpcserver.Options{
+			Address:       cfg.GRPC.Address,
+			TLSCertFile:   cfg.GRPC.TLSCertFile,
+			TLSKeyFile:    cfg.GRPC.TLSKeyFile,
+			AllowInsecure: !cfg.IsProduction(),
+			AuthTokens:    cfg.GRPC.AuthTokens,
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"ApiRestFinance/internal/job"
+	"context"
+	"time"
+)
+
+// ScheduleJobs schedules the periodic jobs: cleanups, statements, reminders, scoring, balance snapshots
+// and the outbox relay. They run until ctx is done; Start must be called too, for the jobs they queue to run.
+func (a *App) ScheduleJobs(ctx context.Context) {
+	cfg, s := a.Config, a.Services
+
+	// Jobs whose task was lost, e.g. in a restart, are queued again
+	job.Every(ctx, "job requeue", time.Minute, s.Job.RequeueStaleJobs)
+	job.Daily(ctx, "job cleanup", 4*time.Hour, time.Local, s.Job.PurgeFinishedJobs)
+	job.Daily(ctx, "session cleanup", 4*time.Hour, time.Local, s.Session.PurgeEndedSessions)
+	job.Daily(ctx, "attachment retention", 4*time.Hour, time.Local, s.Attachment.PurgeExpiredAttachments)
+	job.Daily(ctx, "data retention", 4*time.Hour, time.Local, s.Privacy.PurgeExpiredData)
+
+	// Billing cycles are closed and their statements sent within a week of the closing date, so checking hourly is plenty
+	job.Every(ctx, "statement closing", time.Hour, s.StatementPeriod.CloseDueStatementPeriods)
+	job.Every(ctx, "statement emails", time.Hour, s.StatementDelivery.SendDueStatements)
+	job.Every(ctx, "report digests", time.Hour, s.ReportDigest.SendDueDigests)
+
+	// Rules configured in the establishment settings
+	job.Every(ctx, "overdue account blocking", time.Hour, s.CreditAccount.BlockOverdueAccounts)
+	job.Every(ctx, "payment reminders", time.Hour, s.PaymentReminder.SendReminders)
+	job.Every(ctx, "purchase approval expiry", time.Hour, s.Purchase.ExpirePurchaseApprovals)
+	job.Every(ctx, "payment promise resolution", time.Hour, s.PaymentPromise.ResolveDuePaymentPromises)
+	job.Every(ctx, "payment link expiry", time.Hour, s.PaymentLink.ExpirePaymentLinks)
+	job.Every(ctx, "pending payment expiry", time.Minute, s.Transaction.ExpirePendingPayments)
+
+	// Installments whose due date ended unpaid are marked overdue shortly after midnight
+	job.Daily(ctx, "installment overdue marking", 5*time.Minute, time.Local, s.Installment.MarkOverdueInstallments)
+
+	// Credit scores are recalculated nightly, while the stores are closed
+	job.Daily(ctx, "credit scoring", 3*time.Hour, time.Local, s.CreditScoring.ScoreAllAccounts)
+
+	// Balances are snapshotted shortly before midnight, as the close of the day, for balance history
+	job.Daily(ctx, "balance snapshots", 23*time.Hour+55*time.Minute, time.Local, s.BalanceHistory.SnapshotBalances)
+
+	// Outbox events are relayed within seconds and kept for a while after delivery
+	job.Every(ctx, "outbox relay", 5*time.Second, s.Outbox.RelayEvents)
+	job.Daily(ctx, "outbox cleanup", 4*time.Hour, time.Local, s.Outbox.PurgeDeliveredEvents)
+
+	// Receipts are queued by the outbox relay and submitted shortly after
+	if cfg.Invoicing.Endpoint != "" {
+		job.Every(ctx, "electronic invoicing", time.Minute, s.Invoicing.SubmitDueInvoices)
+	}
+}
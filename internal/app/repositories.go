@@ -0,0 +1,111 @@
+package app
+
+import (
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+
+	"gorm.io/gorm"
+)
+
+// Repositories are the repositories of every table, shared by the services.
+type Repositories struct {
+	User                  repository.UserRepository
+	Client                repository.ClientRepository
+	Establishment         repository.EstablishmentRepository
+	Product               repository.ProductRepository
+	CreditAccount         repository.CreditAccountRepository
+	Transaction           repository.TransactionRepository
+	Installment           repository.InstallmentRepository
+	PurchaseItem          repository.PurchaseItemRepository
+	StatementDelivery     repository.StatementDeliveryRepository
+	ReportDigest          repository.ReportDigestRepository
+	StatementPeriod       repository.StatementPeriodRepository
+	EstablishmentSettings repository.EstablishmentSettingsRepository
+	PaymentReminder       repository.PaymentReminderRepository
+	SMSDelivery           repository.SMSDeliveryRepository
+	Privacy               repository.PrivacyRepository
+	Outbox                repository.OutboxRepository
+	Job                   repository.JobRepository
+	TwoFactor             repository.TwoFactorRepository
+	PurchasePin           repository.PurchasePinRepository
+	ContactVerification   repository.ContactVerificationRepository
+	Session               repository.SessionRepository
+	AccountActivity       repository.AccountActivityRepository
+	PurchaseApproval      repository.PurchaseApprovalRepository
+	DocumentSeries        repository.DocumentSeriesRepository
+	ElectronicInvoice     repository.ElectronicInvoiceRepository
+	Category              repository.CategoryRepository
+	PaymentPromise        repository.PaymentPromiseRepository
+	Attachment            repository.AttachmentRepository
+	CreditAgreement       repository.CreditAgreementRepository
+	Impersonation         repository.ImpersonationRepository
+	ClientSignup          repository.ClientSignupRepository
+	AccountAdjustment     repository.AccountAdjustmentRepository
+	TillSession           repository.TillSessionRepository
+	CollectionContact     repository.CollectionContactRepository
+	ClientTag             repository.ClientTagRepository
+	ClientNote            repository.ClientNoteRepository
+	EstablishmentConfig   repository.EstablishmentConfigRepository
+	CreditTemplate        repository.CreditTemplateRepository
+	CreditTermUpdate      repository.CreditTermUpdateRepository
+	BalanceSnapshot       repository.BalanceSnapshotRepository
+	PaymentLink           repository.PaymentLinkRepository
+	Platform              repository.PlatformRepository
+	BankTransfer          repository.BankTransferRepository
+	PaymentAllocation     repository.PaymentAllocationRepository
+	ClientNotification    repository.ClientNotificationRepository
+	CreditAccountEvent    repository.CreditAccountEventRepository
+	Holiday               repository.HolidayRepository
+}
+
+func newRepositories(db *gorm.DB, clock util.Clock) *Repositories {
+	r := &Repositories{}
+	r.User = repository.NewUserRepository(db)
+	r.Client = repository.NewClientRepository(db)
+	r.Establishment = repository.NewEstablishmentRepository(db)
+	r.Product = repository.NewProductRepository(db)
+	r.CreditAccount = repository.NewCreditAccountRepository(db, r.User, clock)
+	r.Transaction = repository.NewTransactionRepository(db)
+	r.Installment = repository.NewInstallmentRepository(db, clock)
+	r.PurchaseItem = repository.NewPurchaseItemRepository(db)
+	r.StatementDelivery = repository.NewStatementDeliveryRepository(db)
+	r.ReportDigest = repository.NewReportDigestRepository(db)
+	r.StatementPeriod = repository.NewStatementPeriodRepository(db)
+	r.EstablishmentSettings = repository.NewEstablishmentSettingsRepository(db)
+	r.PaymentReminder = repository.NewPaymentReminderRepository(db)
+	r.SMSDelivery = repository.NewSMSDeliveryRepository(db)
+	r.Privacy = repository.NewPrivacyRepository(db)
+	r.Outbox = repository.NewOutboxRepository(db)
+	r.Job = repository.NewJobRepository(db)
+	r.TwoFactor = repository.NewTwoFactorRepository(db)
+	r.PurchasePin = repository.NewPurchasePinRepository(db)
+	r.ContactVerification = repository.NewContactVerificationRepository(db)
+	r.Session = repository.NewSessionRepository(db)
+	r.AccountActivity = repository.NewAccountActivityRepository(db)
+	r.PurchaseApproval = repository.NewPurchaseApprovalRepository(db)
+	r.DocumentSeries = repository.NewDocumentSeriesRepository(db)
+	r.ElectronicInvoice = repository.NewElectronicInvoiceRepository(db)
+	r.Category = repository.NewCategoryRepository(db)
+	r.PaymentPromise = repository.NewPaymentPromiseRepository(db)
+	r.Attachment = repository.NewAttachmentRepository(db)
+	r.CreditAgreement = repository.NewCreditAgreementRepository(db)
+	r.Impersonation = repository.NewImpersonationRepository(db)
+	r.ClientSignup = repository.NewClientSignupRepository(db)
+	r.AccountAdjustment = repository.NewAccountAdjustmentRepository(db)
+	r.TillSession = repository.NewTillSessionRepository(db)
+	r.CollectionContact = repository.NewCollectionContactRepository(db)
+	r.ClientTag = repository.NewClientTagRepository(db)
+	r.ClientNote = repository.NewClientNoteRepository(db)
+	r.EstablishmentConfig = repository.NewEstablishmentConfigRepository(db)
+	r.CreditTemplate = repository.NewCreditTemplateRepository(db)
+	r.CreditTermUpdate = repository.NewCreditTermUpdateRepository(db)
+	r.BalanceSnapshot = repository.NewBalanceSnapshotRepository(db)
+	r.PaymentLink = repository.NewPaymentLinkRepository(db)
+	r.Platform = repository.NewPlatformRepository(db)
+	r.BankTransfer = repository.NewBankTransferRepository(db)
+	r.PaymentAllocation = repository.NewPaymentAllocationRepository(db)
+	r.ClientNotification = repository.NewClientNotificationRepository(db)
+	r.CreditAccountEvent = repository.NewCreditAccountEventRepository(db)
+	r.Holiday = repository.NewHolidayRepository(db)
+	return r
+}
@@ -0,0 +1,390 @@
+package app
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/versioning"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// newGraphQLHandler is set by graphql.go when building with the graphql build tag.
+var newGraphQLHandler func(userService service.UserService, creditAccountService service.CreditAccountService, purchaseService service.PurchaseService, installmentService service.InstallmentService, transactionService service.TransactionService) gin.HandlerFunc
+
+// Router registers the routes of every API version, and of the GraphQL endpoint when it's compiled in.
+func (a *App) Router() *gin.Engine {
+	cfg, s, c := a.Config, a.Services, a.controllers
+
+	router := gin.Default()
+	gin.SetMode(gin.ReleaseMode)
+	router.Use(gin.Recovery())
+	router.Use(middleware.CorsMiddleware())
+
+	// Swagger documentation
+	url := ginSwagger.URL("/swagger/doc.json")
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
+
+	// Requests that don't ask for a language with Accept-Language are answered in the language of
+	// the establishment the user acts on, and responses show times in its time zone
+	requestUser := func(ctx *gin.Context) (uint, enums.Role, uint, uint) {
+		role, _ := ctx.Value("rol").(enums.Role)
+		establishmentID, _ := strconv.ParseUint(ctx.Query("establishment_id"), 10, 64)
+		return middleware.GetUserIDFromContext(ctx), role, middleware.GetBranchIDFromContext(ctx), uint(establishmentID)
+	}
+	userLanguage := func(ctx *gin.Context) i18n.Language {
+		return s.EstablishmentSettings.UserLanguage(requestUser(ctx))
+	}
+	userLocation := func(ctx *gin.Context) *time.Location {
+		return s.EstablishmentSettings.UserLocation(requestUser(ctx))
+	}
+
+	// Every API version serves the same routes and handlers; the version's middleware maps the
+	// responses to its contract, so /api/v1 clients keep working while /api/v2 evolves.
+	for _, version := range versioning.Versions {
+		// Public routes
+		publicRoutes := router.Group(version.BasePath(), versioning.Middleware(version), middleware.LanguageMiddleware(nil), middleware.DatabaseAvailabilityMiddleware(a.dbWatchdog))
+		{
+			publicRoutes.POST("/register", c.auth.RegisterAdmin)
+			publicRoutes.POST("/login", c.auth.Login)
+			publicRoutes.POST("/login/2fa", c.auth.CompleteTwoFactorLogin)
+			publicRoutes.POST("/login/2fa/setup", c.auth.SetupTwoFactorLogin)
+			publicRoutes.POST("/refresh", c.auth.RefreshToken)
+			publicRoutes.POST("/logout", c.auth.Logout)
+			// Client self-signup with an invite code, named like the establishment ID of the /establishments routes it shares a path with
+			publicRoutes.POST("/establishments/:establishmentID/client-signup", c.clientSignup.SignUpClient)
+			publicRoutes.GET("/establishments/:establishmentID/catalog", c.catalog.GetCatalog)
+			// Delivery status reports of the SMS provider, authenticated by its signature
+			publicRoutes.POST("/webhooks/sms/status", c.notification.ReceiveSMSStatus)
+			publicRoutes.POST("/webhooks/bank/transfers", c.bankTransfer.ReceiveBankTransfer)
+			// Payment links sent to clients, authenticated by their signed token
+			publicRoutes.GET("/payment-links/:token", c.paymentLink.ViewPaymentLink)
+			publicRoutes.POST("/payment-links/:token/pay", c.paymentLink.PayPaymentLink)
+		}
+
+		// Protected routes (require authentication). Cookie sessions must also send their CSRF token.
+		// Admins impersonating a client only reach its read-only endpoints, and every request is audited.
+		protectedRoutes := router.Group(version.BasePath(), versioning.Middleware(version), middleware.LanguageMiddleware(userLanguage), middleware.LocationMiddleware(userLocation), middleware.DatabaseAvailabilityMiddleware(a.dbWatchdog), middleware.AuthMiddleware(a.tokenIssuer, s.Impersonation), middleware.TenantMiddleware(s.Tenant), middleware.CSRFMiddleware(cfg.JWT.Secret), middleware.BranchMiddleware())
+		{
+			// Each request is served by controllers of its own, built on repositories scoped to its tenant
+			for i, r := range tenantRoutes(c) {
+				protectedRoutes.Handle(r.method, r.path, func(ctx *gin.Context) {
+					tenantRoutes(a.scopedControllers(middleware.GetTenantFromContext(ctx)))[i].handler(ctx)
+				})
+			}
+
+			// Platform routes, for super-admins only and audited. Super-admins act on every tenant, so their
+			// routes are served by the controllers of App, on its system session.
+			platformRoutes := protectedRoutes.Group("/platform", middleware.PlatformMiddleware(s.Platform))
+			{
+				platformRoutes.GET("/establishments", c.platform.GetEstablishments)
+				platformRoutes.POST("/establishments/:id/suspend", c.platform.SuspendEstablishment)
+				platformRoutes.POST("/establishments/:id/reinstate", c.platform.ReinstateEstablishment)
+				platformRoutes.POST("/establishments/:id/admin/reset-password", c.platform.ResetAdminPassword)
+				platformRoutes.PUT("/establishments/:id/plan", c.plan.AssignPlan)
+				platformRoutes.GET("/plans", c.plan.GetPlans)
+				platformRoutes.GET("/metrics", c.platform.GetMetrics)
+				platformRoutes.GET("/audit-log", c.platform.GetAuditLog)
+			}
+		}
+	}
+
+	// GraphQL endpoint for the mobile app, only compiled in with the graphql build tag. Like the
+	// protected routes, each request is resolved on services scoped to its tenant.
+	if newGraphQLHandler != nil {
+		router.POST("/graphql", middleware.DatabaseAvailabilityMiddleware(a.dbWatchdog), middleware.AuthMiddleware(a.tokenIssuer, nil), middleware.TenantMiddleware(s.Tenant), middleware.CSRFMiddleware(cfg.JWT.Secret), func(ctx *gin.Context) {
+			s := a.scopedServices(middleware.GetTenantFromContext(ctx))
+			newGraphQLHandler(s.User, s.CreditAccount, s.Purchase, s.Installment, s.Transaction)(ctx)
+		})
+	}
+
+	return router
+}
+
+// route is a protected route, served on the tenant of the request.
+type route struct {
+	method  string
+	path    string
+	handler gin.HandlerFunc
+}
+
+// tenantRoutes are the protected routes of every API version, served by the handlers of c.
+func tenantRoutes(c *controllers) []route {
+	routes := []route{
+		// CSRF token of the current session
+		{http.MethodGet, "/csrf-token", c.auth.GetCSRFToken},
+
+		// User routes
+		{http.MethodPost, "/clients", c.user.CreateClient},
+		{http.MethodGet, "/users/:id", c.user.GetUserByID},
+		{http.MethodPut, "/users/:id", c.user.UpdateUser},
+		{http.MethodPatch, "/users/:id", c.user.PatchUser},
+		{http.MethodDelete, "/users/:id", c.user.DeleteUser},
+		{http.MethodGet, "/admins/me", c.user.GetAdminProfile},
+		{http.MethodPut, "/admins/me", c.user.UpdateAdminProfile},
+		{http.MethodPost, "/admins/impersonate/stop", c.impersonation.StopImpersonation},
+		{http.MethodPost, "/admins/impersonate/:clientID", c.impersonation.StartImpersonation},
+		{http.MethodGet, "/admins/impersonations", c.impersonation.GetImpersonations},
+		{http.MethodGet, "/establishments/:establishmentID/clients", c.user.GetClientsByEstablishmentID},
+		{http.MethodGet, "/establishments/me/clients/search", c.user.SearchClients},
+		{http.MethodPost, "/users/:id/photo", c.user.UploadUserPhoto},
+		{http.MethodPut, "/users/:id/password", c.user.UpdatePassword},
+		{http.MethodGet, "/users/email-to-id", c.user.GetUserIDByEmail},
+		{http.MethodPost, "/users/:id/anonymize", c.privacy.AnonymizeUser},
+		{http.MethodGet, "/users/:id/export", c.privacy.ExportUserData},
+
+		// Establishment routes
+		{http.MethodGet, "/establishments/me", c.establishment.GetEstablishment},
+		{http.MethodPut, "/establishments/me", c.establishment.UpdateEstablishment},
+		{http.MethodPatch, "/establishments/me", c.establishment.PatchEstablishment},
+		{http.MethodGet, "/establishments/me/branches", c.establishment.GetBranches},
+		{http.MethodPost, "/establishments/me/branches", c.establishment.CreateBranch},
+		{http.MethodPost, "/establishments/me/deactivate", c.establishment.DeactivateEstablishment},
+		{http.MethodPost, "/establishments/me/reactivate", c.establishment.ReactivateEstablishment},
+		{http.MethodGet, "/establishments/me/settings", c.establishmentSettings.GetSettings},
+		{http.MethodPut, "/establishments/me/settings", c.establishmentSettings.UpdateSettings},
+		{http.MethodGet, "/establishments/me/document-series", c.documentSeries.GetDocumentSeries},
+		{http.MethodPost, "/establishments/me/document-series", c.documentSeries.CreateDocumentSeries},
+		{http.MethodPut, "/establishments/me/document-series/:id", c.documentSeries.UpdateDocumentSeries},
+		{http.MethodGet, "/establishments/:establishmentID", c.establishment.GetEstablishmentByID},
+
+		// Product routes
+		{http.MethodPost, "/products", c.product.CreateProduct},
+		{http.MethodGet, "/products/:id", c.product.GetProductByID},
+		{http.MethodGet, "/establishments/:establishmentID/products", c.product.GetAllProductsByEstablishmentID},
+		{http.MethodGet, "/establishments/me/products/lookup", c.product.LookupProduct},
+		{http.MethodPut, "/products/:id", c.product.UpdateProduct},
+		{http.MethodPatch, "/products/:id", c.product.PatchProduct},
+		{http.MethodDelete, "/products/:id", c.product.DeleteProduct},
+		{http.MethodPost, "/products/:id/image", c.product.UploadProductImage},
+		{http.MethodGet, "/establishments/me/categories", c.category.GetCategories},
+		{http.MethodPost, "/establishments/me/categories", c.category.CreateCategory},
+		{http.MethodPut, "/establishments/me/categories/:id", c.category.UpdateCategory},
+		{http.MethodDelete, "/establishments/me/categories/:id", c.category.DeleteCategory},
+		{http.MethodGet, "/establishments/me/holidays", c.holiday.GetHolidays},
+		{http.MethodPost, "/establishments/me/holidays", c.holiday.CreateHoliday},
+		{http.MethodPost, "/establishments/me/holidays/national", c.holiday.AddNationalHolidays},
+		{http.MethodPut, "/establishments/me/holidays/:id", c.holiday.UpdateHoliday},
+		{http.MethodDelete, "/establishments/me/holidays/:id", c.holiday.DeleteHoliday},
+
+		// Credit Account Routes
+		{http.MethodPost, "/credit-accounts", c.creditAccount.CreateCreditAccount},
+		{http.MethodGet, "/credit-accounts/:id", c.creditAccount.GetCreditAccountByID},
+		{http.MethodPut, "/clients/:clientID/credit-account", c.user.UpdateClientCreditAccount},
+		{http.MethodPatch, "/clients/:clientID/credit-account", c.user.PatchClientCreditAccount},
+		{http.MethodDelete, "/credit-accounts/:id", c.creditAccount.DeleteCreditAccount},
+		{http.MethodPost, "/credit-accounts/:id/block", c.creditAccount.BlockCreditAccount},
+		{http.MethodPost, "/credit-accounts/:id/unblock", c.creditAccount.UnblockCreditAccount},
+		{http.MethodPost, "/credit-accounts/:id/write-off", c.creditAccount.WriteOffCreditAccount},
+		{http.MethodPost, "/credit-accounts/:id/close", c.creditAccount.CloseCreditAccount},
+		{http.MethodPost, "/credit-accounts/:id/reopen", c.creditAccount.ReopenCreditAccount},
+		{http.MethodGet, "/credit-accounts/:id/events", c.creditAccountEvent.GetCreditAccountEvents},
+		{http.MethodGet, "/establishments/:establishmentID/credit-accounts", c.creditAccount.GetCreditAccountsByEstablishmentID},
+		{http.MethodGet, "/clients/:clientID/credit-account", c.creditAccount.GetCreditAccountByClientID},
+		{http.MethodPost, "/credit-accounts/:id/apply-interest", c.creditAccount.ApplyInterestToAccount},
+		{http.MethodPost, "/credit-accounts/:id/apply-late-fee", c.creditAccount.ApplyLateFeeToAccount},
+		{http.MethodGet, "/credit-accounts/overdue", c.creditAccount.GetOverdueCreditAccounts},
+		{http.MethodPost, "/credit-accounts/:id/purchases", c.creditAccount.ProcessPurchase},
+		{http.MethodPost, "/credit-accounts/:id/payments", c.creditAccount.ProcessPayment},
+		{http.MethodGet, "/credit-accounts/debt-summary", c.creditAccount.GetAdminDebtSummary},
+		{http.MethodPost, "/credit-accounts/:id/payment-promises", c.paymentPromise.CreatePaymentPromise},
+		{http.MethodGet, "/credit-accounts/:id/payment-promises", c.paymentPromise.GetPaymentPromises},
+		{http.MethodPost, "/credit-accounts/:id/payment-links", c.paymentLink.CreatePaymentLink},
+		{http.MethodGet, "/credit-accounts/:id/payment-links", c.paymentLink.GetPaymentLinks},
+		{http.MethodGet, "/credit-accounts/:id/payment-links/:linkID/qr", c.paymentLink.GetPaymentLinkQRCode},
+		{http.MethodPost, "/credit-accounts/:id/adjustments", c.accountAdjustment.CreateAdjustment},
+		{http.MethodGet, "/credit-accounts/:id/adjustments", c.accountAdjustment.GetAdjustments},
+		{http.MethodGet, "/adjustments/pending", c.accountAdjustment.GetPendingAdjustments},
+		{http.MethodPost, "/adjustments/:id/approve", c.accountAdjustment.ApproveAdjustment},
+		{http.MethodPost, "/adjustments/:id/reject", c.accountAdjustment.RejectAdjustment},
+		{http.MethodGet, "/credit-accounts/:id/agreement", c.creditAgreement.GetCreditAgreement},
+		{http.MethodGet, "/credit-accounts/:id/agreement/pdf", c.creditAgreement.GetCreditAgreementPDF},
+
+		// Attachment Routes
+		{http.MethodPost, "/clients/:clientID/attachments", c.attachment.UploadClientAttachment},
+		{http.MethodGet, "/clients/:clientID/attachments", c.attachment.GetClientAttachments},
+		{http.MethodPost, "/credit-accounts/:id/attachments", c.attachment.UploadCreditAccountAttachment},
+		{http.MethodGet, "/credit-accounts/:id/attachments", c.attachment.GetCreditAccountAttachments},
+		{http.MethodGet, "/attachments/:id/download", c.attachment.DownloadAttachment},
+
+		// Transaction Routes
+		{http.MethodPost, "/transactions", c.transaction.CreateTransaction},
+		{http.MethodGet, "/transactions/:id", c.transaction.GetTransactionByID},
+		{http.MethodPut, "/transactions/:id", c.transaction.UpdateTransaction},
+		{http.MethodDelete, "/transactions/:id", c.transaction.DeleteTransaction},
+		{http.MethodGet, "/credit-accounts/:id/transactions", c.transaction.GetTransactionsByCreditAccountID},
+		{http.MethodGet, "/establishments/me/transactions", c.transaction.SearchEstablishmentTransactions},
+		{http.MethodPost, "/transactions/:id/confirm", c.transaction.ConfirmPayment},
+		{http.MethodGet, "/transactions/:id/allocation", c.transaction.GetPaymentAllocation},
+		{http.MethodGet, "/transactions/:id/receipt/pdf", c.transaction.GetPaymentReceiptPDF},
+		{http.MethodGet, "/transactions/:id/invoice", c.electronicInvoice.GetInvoice},
+		{http.MethodGet, "/transactions/:id/invoice/pdf", c.electronicInvoice.GetInvoicePDF},
+
+		// Purchase Routes
+		{http.MethodPost, "/purchases", c.purchase.CreatePurchase},
+		{http.MethodPost, "/purchases/quote", c.purchase.QuotePurchase},
+		{http.MethodGet, "/clients/me/balance", c.purchase.GetClientBalance},
+		{http.MethodGet, "/clients/me/transactions", c.purchase.GetClientTransactions},
+		{http.MethodGet, "/clients/me/activity", c.accountActivity.GetClientActivity},
+		{http.MethodGet, "/clients/me/overdue-balance", c.purchase.GetClientOverdueBalance},
+		{http.MethodGet, "/clients/me/installments", c.purchase.GetClientInstallments},
+		{http.MethodGet, "/clients/me/credit-account", c.purchase.GetClientCreditAccount},
+		{http.MethodGet, "/clients/me/credit-accounts", c.purchase.GetClientCreditAccounts},
+		{http.MethodGet, "/clients/me/account-summary", c.purchase.GetClientAccountSummary},     // New endpoint
+		{http.MethodGet, "/clients/me/account-statement", c.purchase.GetClientAccountStatement}, // New endpoint
+		{http.MethodGet, "/clients/me/account-statement/pdf", c.purchase.GetClientAccountStatementPDF},
+		{http.MethodPost, "/clients/me/account-statement/pdf/jobs", c.purchase.CreateClientAccountStatementPDFJob},
+		{http.MethodGet, "/clients/me/payoff-quote", c.purchase.GetPayoffQuote},
+		{http.MethodPost, "/clients/me/payoff", c.purchase.PayOff},
+		{http.MethodGet, "/clients/me/credit-agreement", c.creditAgreement.GetClientCreditAgreement},
+		{http.MethodGet, "/clients/me/credit-agreement/pdf", c.creditAgreement.GetClientCreditAgreementPDF},
+		{http.MethodPost, "/clients/me/credit-agreement/accept", c.creditAgreement.AcceptClientCreditAgreement},
+		{http.MethodPost, "/establishments/me/cash-sales", c.purchase.RecordCashSale},
+		{http.MethodGet, "/establishments/me/purchase-approvals", c.purchase.GetPurchaseApprovals},
+		{http.MethodPost, "/establishments/me/purchase-approvals/:id/approve", c.purchase.ApprovePurchase},
+		{http.MethodPost, "/establishments/me/purchase-approvals/:id/reject", c.purchase.RejectPurchase},
+
+		// Client signup routes
+		{http.MethodGet, "/establishments/me/invite-code", c.clientSignup.GetInviteCode},
+		{http.MethodPost, "/establishments/me/invite-code", c.clientSignup.RotateInviteCode},
+		{http.MethodGet, "/establishments/me/invite-code/qr", c.clientSignup.GetInviteCodeQRCode},
+		{http.MethodGet, "/establishments/me/client-signups", c.clientSignup.GetClientSignups},
+		{http.MethodPost, "/establishments/me/client-signups/:id/approve", c.clientSignup.ApproveClientSignup},
+		{http.MethodPost, "/establishments/me/client-signups/:id/reject", c.clientSignup.RejectClientSignup},
+
+		// Till session routes
+		{http.MethodPost, "/establishments/me/till-sessions", c.tillSession.OpenTillSession},
+		{http.MethodGet, "/establishments/me/till-sessions", c.tillSession.GetTillSessions},
+		{http.MethodGet, "/establishments/me/till-sessions/current", c.tillSession.GetCurrentTillSession},
+		{http.MethodGet, "/establishments/me/till-sessions/:id", c.tillSession.GetTillSessionReport},
+		{http.MethodPost, "/establishments/me/till-sessions/:id/close", c.tillSession.CloseTillSession},
+
+		// Collection routes
+		{http.MethodGet, "/establishments/me/collections", c.collection.GetCollectionsWorklist},
+		{http.MethodPost, "/collections/:clientID/contact-log", c.collection.LogCollectionContact},
+
+		// Client tag and note routes
+		{http.MethodGet, "/establishments/me/client-tags", c.clientTag.GetClientTags},
+		{http.MethodPost, "/establishments/me/client-tags", c.clientTag.CreateClientTag},
+		{http.MethodPut, "/establishments/me/client-tags/:id", c.clientTag.UpdateClientTag},
+		{http.MethodDelete, "/establishments/me/client-tags/:id", c.clientTag.DeleteClientTag},
+		{http.MethodPut, "/clients/:clientID/tags", c.clientTag.SetClientTags},
+		{http.MethodGet, "/clients/:clientID/notes", c.clientNote.GetClientNotes},
+		{http.MethodPost, "/clients/:clientID/notes", c.clientNote.CreateClientNote},
+		{http.MethodPut, "/clients/:clientID/notes/:noteID", c.clientNote.UpdateClientNote},
+		{http.MethodDelete, "/clients/:clientID/notes/:noteID", c.clientNote.DeleteClientNote},
+
+		// Establishment configuration routes
+		{http.MethodGet, "/establishments/me/export", c.establishmentConfig.ExportConfig},
+		{http.MethodPost, "/establishments/me/import", c.establishmentConfig.ImportConfig},
+
+		// Credit template routes
+		{http.MethodGet, "/establishments/me/credit-templates", c.creditTemplate.GetCreditTemplates},
+		{http.MethodPost, "/establishments/me/credit-templates", c.creditTemplate.CreateCreditTemplate},
+		{http.MethodPut, "/establishments/me/credit-templates/:id", c.creditTemplate.UpdateCreditTemplate},
+		{http.MethodDelete, "/establishments/me/credit-templates/:id", c.creditTemplate.DeleteCreditTemplate},
+
+		// Bulk credit term update routes
+		{http.MethodPost, "/establishments/me/credit-accounts/bulk-update", c.creditTermUpdate.BulkUpdateCreditAccounts},
+		{http.MethodGet, "/establishments/me/credit-accounts/bulk-updates", c.creditTermUpdate.GetCreditTermUpdates},
+		{http.MethodGet, "/establishments/me/credit-accounts/bulk-updates/:id", c.creditTermUpdate.GetCreditTermUpdate},
+
+		// Balance history routes
+		{http.MethodGet, "/credit-accounts/:id/balance-history", c.balanceHistory.GetBalanceHistory},
+		{http.MethodGet, "/establishments/me/reports/receivables", c.balanceHistory.GetReceivablesTrend},
+
+		// Plan routes
+		{http.MethodGet, "/establishments/me/plan", c.plan.GetEstablishmentPlan},
+
+		// Statement email routes
+		{http.MethodGet, "/establishments/me/statement-emails", c.statementDelivery.GetStatementEmailSettings},
+		{http.MethodPut, "/establishments/me/statement-emails", c.statementDelivery.UpdateStatementEmailSettings},
+		{http.MethodGet, "/establishments/me/statement-deliveries", c.statementDelivery.GetEstablishmentStatementDeliveries},
+		{http.MethodPut, "/clients/me/statement-emails", c.statementDelivery.UpdateClientStatementEmails},
+		{http.MethodGet, "/clients/me/statement-deliveries", c.statementDelivery.GetClientStatementDeliveries},
+		{http.MethodGet, "/establishments/me/sms-deliveries", c.notification.GetSMSDeliveries},
+		{http.MethodGet, "/establishments/me/bank-transfers", c.bankTransfer.GetBankTransfers},
+
+		// Statement period Routes
+		{http.MethodGet, "/clients/me/statements", c.statementPeriod.GetClientStatements},
+		{http.MethodGet, "/credit-accounts/:id/statements", c.statementPeriod.GetCreditAccountStatements},
+
+		// Installment Routes
+		{http.MethodPost, "/installments", c.installment.CreateInstallment},
+		{http.MethodGet, "/installments/:id", c.installment.GetInstallmentByID},
+		{http.MethodPut, "/installments/:id", c.installment.UpdateInstallment},
+		{http.MethodDelete, "/installments/:id", c.installment.DeleteInstallment},
+		{http.MethodPost, "/installments/:id/reschedule", c.installment.RescheduleInstallment},
+		{http.MethodGet, "/installments/:id/reschedules", c.installment.GetInstallmentReschedules},
+		{http.MethodGet, "/credit-accounts/:id/installments", c.installment.GetInstallmentsByCreditAccountID},
+		{http.MethodGet, "/credit-accounts/:id/installments/overdue", c.installment.GetOverdueInstallments},
+
+		// Authentication route (reset password)
+		{http.MethodPost, "/reset-password", c.auth.ResetPassword},
+
+		// Report Routes
+		{http.MethodGet, "/establishments/me/reports/products", c.report.GetProductReport},
+		{http.MethodGet, "/establishments/me/reports/cohorts", c.report.GetCohortReport},
+		{http.MethodGet, "/establishments/me/reports/branches", c.report.GetBranchReport},
+		{http.MethodGet, "/establishments/me/reports/bad-debt", c.report.GetBadDebtReport},
+		{http.MethodGet, "/establishments/me/reports/forecast", c.report.GetForecastReport},
+		{http.MethodPost, "/establishments/me/reports/digest", c.report.SendReportDigest},
+		{http.MethodGet, "/establishments/me/reports/digest-deliveries", c.report.GetReportDigestDeliveries},
+
+		// Accounting Routes
+		{http.MethodGet, "/establishments/me/accounting/accounts", c.accounting.GetAccountingAccounts},
+		{http.MethodPut, "/establishments/me/accounting/accounts", c.accounting.UpdateAccountingAccounts},
+		{http.MethodGet, "/establishments/me/accounting/export", c.accounting.ExportJournal},
+
+		// Client App Routes
+		{http.MethodGet, "/clients/me/home", c.clientHome.GetClientHome},
+		{http.MethodGet, "/clients/me/notifications", c.clientNotification.GetClientNotifications},
+		{http.MethodPut, "/clients/me/notifications/read", c.clientNotification.MarkAllNotificationsRead},
+		{http.MethodPut, "/clients/me/notifications/:id/read", c.clientNotification.MarkNotificationRead},
+
+		// Admin App Routes
+		{http.MethodGet, "/admins/me/home", c.adminHome.GetAdminHome},
+
+		// Credit Simulation Routes
+		{http.MethodPost, "/credit-simulations", c.creditSimulation.SimulateCredit},
+
+		// Background job routes
+		{http.MethodGet, "/jobs/:id", c.job.GetJob},
+		{http.MethodGet, "/jobs/:id/result", c.job.GetJobResult},
+
+		// Two-factor authentication of the user's own account
+		{http.MethodPost, "/users/me/2fa/setup", c.twoFactor.SetupTwoFactor},
+		{http.MethodPost, "/users/me/2fa/verify", c.twoFactor.VerifyTwoFactor},
+		{http.MethodPost, "/users/me/2fa/disable", c.twoFactor.DisableTwoFactor},
+		{http.MethodPost, "/users/me/2fa/recovery-codes", c.twoFactor.RegenerateRecoveryCodes},
+		{http.MethodGet, "/users/me/purchase-pin", c.purchasePin.GetPurchasePin},
+		{http.MethodPut, "/users/me/purchase-pin", c.purchasePin.SetPurchasePin},
+		{http.MethodGet, "/users/me/verification", c.contactVerification.GetContactVerification},
+		{http.MethodPost, "/users/me/verification/:channel/resend", c.contactVerification.ResendVerificationCode},
+		{http.MethodPost, "/users/me/verification/:channel/verify", c.contactVerification.VerifyContact},
+
+		// Devices the user is logged in on
+		{http.MethodGet, "/users/me/sessions", c.session.GetSessions},
+		{http.MethodDelete, "/users/me/sessions/:id", c.session.RevokeSession},
+
+		// Realtime routes
+		{http.MethodGet, "/events/stream", c.realtime.StreamEvents},
+
+		// Metrics routes
+		{http.MethodGet, "/metrics/cache", c.metrics.GetCacheMetrics},
+	}
+
+	// Sandbox routes (only registered in the sandbox environment)
+	if c.sandbox != nil {
+		routes = append(routes, route{http.MethodGet, "/sandbox/clock", c.sandbox.GetClock})
+		routes = append(routes, route{http.MethodPut, "/sandbox/clock", c.sandbox.SetClock})
+		routes = append(routes, route{http.MethodDelete, "/sandbox/clock", c.sandbox.ResetClock})
+	}
+	return routes
+}
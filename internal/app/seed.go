@@ -0,0 +1,28 @@
+package app
+
+import (
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/util"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SeedDemoData fills an already migrated database with the demo establishments, products and
+// clients, and the given months of their history up to now. It runs in a transaction, so a seed that
+// fails leaves nothing behind. Meant for development and QA databases only.
+func SeedDemoData(db *gorm.DB, months int) (*service.DemoData, error) {
+	var data *service.DemoData
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// The history is recorded at the time it happened by moving the repositories' clock through it
+		clock := util.NewFakeClock(time.Now())
+		r := newRepositories(tenant.System(tx), clock)
+		demoDataService := service.NewDemoDataService(r.User, r.Establishment, r.Category, r.Product, r.CreditAccount, r.CreditAgreement, r.Installment, r.EstablishmentSettings, r.Holiday, clock)
+
+		var err error
+		data, err = demoDataService.SeedDemoData(months)
+		return err
+	})
+	return data, err
+}
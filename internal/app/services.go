@@ -0,0 +1,282 @@
+package app
+
+import (
+	"ApiRestFinance/internal/config"
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/invoicing"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/queue"
+	"ApiRestFinance/internal/realtime"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/sms"
+	"ApiRestFinance/internal/util"
+	"ApiRestFinance/internal/webhook"
+	"fmt"
+	"time"
+)
+
+// Services are the services of the API, for entrypoints that use them without going through HTTP.
+// Those of App run on a tenant.System session, as do the jobs; requests get services of their own.
+type Services struct {
+	Job                    service.JobService
+	TwoFactor              service.TwoFactorService
+	PurchasePin            service.PurchasePinService
+	EstablishmentSettings  service.EstablishmentSettingsService
+	ContactVerification    service.ContactVerificationService
+	Auth                   service.AuthService
+	Session                service.SessionService
+	Impersonation          service.ImpersonationService
+	AccountActivity        service.AccountActivityService
+	DocumentSeries         service.DocumentSeriesService
+	Category               service.CategoryService
+	User                   service.UserService
+	Admin                  service.AdminService
+	Establishment          service.EstablishmentService
+	Product                service.ProductService
+	CreditAccount          service.CreditAccountService
+	Transaction            service.TransactionService
+	Installment            service.InstallmentService
+	Report                 service.ReportService
+	ReportDigest           service.ReportDigestService
+	CreditSimulation       service.CreditSimulationService
+	NotificationDispatcher service.NotificationDispatcher
+	PaymentLink            service.PaymentLinkService
+	Purchase               service.PurchaseService
+	StatementDelivery      service.StatementDeliveryService
+	StatementPeriod        service.StatementPeriodService
+	PaymentReminder        service.PaymentReminderService
+	CreditScoring          service.CreditScoringService
+	Attachment             service.AttachmentService
+	Privacy                service.PrivacyService
+	PaymentPromise         service.PaymentPromiseService
+	CreditAgreement        service.CreditAgreementService
+	Catalog                service.CatalogService
+	ClientSignup           service.ClientSignupService
+	AccountAdjustment      service.AccountAdjustmentService
+	TillSession            service.TillSessionService
+	Collection             service.CollectionService
+	ClientTag              service.ClientTagService
+	ClientNote             service.ClientNoteService
+	EstablishmentConfig    service.EstablishmentConfigService
+	CreditTemplate         service.CreditTemplateService
+	CreditTermUpdate       service.CreditTermUpdateService
+	BalanceHistory         service.BalanceHistoryService
+	Plan                   service.PlanService
+	Platform               service.PlatformService
+	Tenant                 service.TenantService
+	Authorization          service.AuthorizationService
+	BankTransfer           service.BankTransferService
+	Accounting             service.AccountingService
+	ClientHome             service.ClientHomeService
+	ClientNotification     service.ClientNotificationService
+	AdminHome              service.AdminHomeService
+	CreditAccountEvent     service.CreditAccountEventService
+	Holiday                service.HolidayService
+	Invoicing              service.InvoicingService
+	Outbox                 service.OutboxService
+}
+
+// publisher is a bus that only publishes, for the services of requests: the events they publish are
+// handled by the services of App, which subscribed to the bus when they were created.
+type publisher struct{ event.Bus }
+
+func (publisher) Subscribe(event.Name, event.Handler) {}
+func (publisher) SubscribeAll(event.Handler)          {}
+
+// infrastructure are the senders, uploaders, queues and caches of the API, built once and shared by
+// the services of the jobs and those of every request.
+type infrastructure struct {
+	imageUploader    *service.ImageUploader
+	documentUploader *service.DocumentUploader
+	eventBus         event.Bus
+	summaryCache     *service.AccountSummaryCache
+	mailer           mail.Sender
+	texter           sms.Sender
+	eventPublishers  []service.EventPublisher
+	invoiceSigner    *invoicing.Signer
+	invoiceSender    service.InvoiceSender
+	jobQueue         *queue.WorkerPool
+	tokenIssuer      *util.TokenIssuer
+}
+
+// buildServices creates the senders, uploaders and queues of the API, and the services of the jobs
+// and other entrypoints on the repositories of a.Repositories.
+func (a *App) buildServices(configWatcher *config.Watcher) error {
+	cfg, r := a.Config, a.Repositories
+
+	// Uploaded images are only sent to a moderation provider when one is configured
+	imageModerator := service.NewNoopImageModerator()
+	if cfg.ImageModerationURL != "" {
+		imageModerator = service.NewHTTPImageModerator(cfg.ImageModerationURL)
+	}
+	imageUploader := service.NewImageUploader(imageModerator, func() service.ImageUploadSettings {
+		current := configWatcher.Config()
+		return service.ImageUploadSettings{
+			MaxSize:     current.Uploads.MaxImageSize,
+			MinSide:     current.Uploads.MinImageSide,
+			MaxSide:     current.Uploads.MaxImageSide,
+			StorageRoot: current.Storage.Root,
+		}
+	})
+
+	// Uploaded documents are only scanned for viruses when a scanner is configured
+	virusScanner := service.NewNoopVirusScanner()
+	if cfg.VirusScanURL != "" {
+		virusScanner = service.NewHTTPVirusScanner(cfg.VirusScanURL)
+	}
+	documentUploader := service.NewDocumentUploader(virusScanner, func() service.DocumentUploadSettings {
+		current := configWatcher.Config()
+		return service.DocumentUploadSettings{
+			MaxSize:     current.Uploads.MaxDocumentSize,
+			StorageRoot: current.Storage.Root,
+		}
+	})
+
+	// Domain events (transactions, accruals, ...) and the caches they invalidate
+	eventBus := event.NewInMemoryBus()
+	summaryCache := service.NewAccountSummaryCache(eventBus, 5*time.Minute)
+	realtimeHub := realtime.NewHub(eventBus, r.CreditAccount)
+
+	// Emails go through SMTP when it's configured and to the log otherwise
+	var mailer mail.Sender = mail.NewLogSender()
+	if cfg.SMTP.Host != "" {
+		mailer = mail.NewSMTPSender(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+	}
+
+	// Texts go through Twilio when it's configured and to the log otherwise
+	var texter sms.Sender = sms.NewLogSender()
+	var smsStatusReports sms.StatusReports
+	if cfg.SMS.TwilioAccountSID != "" {
+		twilio := sms.NewTwilioSender(cfg.SMS.TwilioAccountSID, cfg.SMS.TwilioAuthToken, cfg.SMS.From, cfg.SMS.StatusCallbackURL, cfg.SMS.Timeout)
+		texter, smsStatusReports = twilio, twilio
+	}
+
+	// Events written to the outbox are relayed to the configured webhooks
+	var eventPublishers []service.EventPublisher
+	if len(cfg.Webhooks.URLs) > 0 {
+		eventPublishers = append(eventPublishers, webhook.NewPublisher(cfg.Webhooks.URLs, cfg.Webhooks.Secret))
+	}
+
+	// Credit purchases are issued electronic receipts, submitted to SUNAT or an OSE, when it's configured
+	var invoiceSigner *invoicing.Signer
+	var invoiceSender service.InvoiceSender
+	if cfg.Invoicing.Endpoint != "" {
+		var err error
+		invoiceSigner, err = invoicing.LoadSigner(cfg.Invoicing.CertificateFile, cfg.Invoicing.KeyFile)
+		if err != nil {
+			return fmt.Errorf("error loading the electronic invoicing certificate: %w", err)
+		}
+		invoiceSender = invoicing.NewClient(cfg.Invoicing.Endpoint, cfg.Invoicing.Username, cfg.Invoicing.Password, cfg.Invoicing.Timeout)
+	}
+
+	// PDFs and emails are rendered and sent by background workers instead of during requests
+	jobQueue := queue.NewWorkerPool(cfg.Jobs.Workers, 1000)
+
+	// Access tokens are short-lived; clients renew them with the refresh token
+	tokenIssuer := util.NewTokenIssuer(util.TokenSettings{
+		Issuer:          cfg.JWT.Issuer,
+		Audience:        cfg.JWT.Audience,
+		KeyID:           cfg.JWT.KeyID,
+		Secret:          cfg.JWT.Secret,
+		PreviousKeys:    cfg.JWT.PreviousKeys,
+		AccessTokenTTL:  cfg.JWT.AccessTokenTTL,
+		RefreshTokenTTL: cfg.JWT.RefreshTokenTTL,
+	})
+
+	a.infra = &infrastructure{
+		imageUploader:    imageUploader,
+		documentUploader: documentUploader,
+		eventBus:         eventBus,
+		summaryCache:     summaryCache,
+		mailer:           mailer,
+		texter:           texter,
+		eventPublishers:  eventPublishers,
+		invoiceSigner:    invoiceSigner,
+		invoiceSender:    invoiceSender,
+		jobQueue:         jobQueue,
+		tokenIssuer:      tokenIssuer,
+	}
+	a.Services = a.newServices(r, eventBus)
+	a.jobQueue = jobQueue
+	a.realtimeHub = realtimeHub
+	a.summaryCache = summaryCache
+	a.smsStatusReports = smsStatusReports
+	a.tokenIssuer = tokenIssuer
+	return nil
+}
+
+// newServices creates the services on the repositories of r. Services subscribe to the events of bus
+// as they are created, so those of requests, which only publish, are given a publisher of the bus.
+func (a *App) newServices(r *Repositories, bus event.Bus) *Services {
+	cfg, clock, infra := a.Config, a.clock, a.infra
+	imageUploader, documentUploader, mailer, texter := infra.imageUploader, infra.documentUploader, infra.mailer, infra.texter
+	invoiceSigner, invoiceSender, tokenIssuer := infra.invoiceSigner, infra.invoiceSender, infra.tokenIssuer
+	eventBus, summaryCache, eventPublishers := bus, infra.summaryCache, append([]service.EventPublisher(nil), infra.eventPublishers...)
+
+	s := &Services{}
+	s.Job = service.NewJobService(r.Job, infra.jobQueue, clock)
+	s.TwoFactor = service.NewTwoFactorService(r.User, r.TwoFactor, clock)
+	s.PurchasePin = service.NewPurchasePinService(r.User, r.PurchasePin, clock)
+	s.EstablishmentSettings = service.NewEstablishmentSettingsService(r.EstablishmentSettings, r.Establishment, r.CreditAccount, r.User)
+	s.ContactVerification = service.NewContactVerificationService(r.User, r.ContactVerification, s.EstablishmentSettings, mailer, texter, clock)
+	s.Auth = service.NewAuthService(r.User, r.Establishment, r.Session, s.TwoFactor, s.ContactVerification, tokenIssuer, clock)
+	s.Session = service.NewSessionService(r.Session, clock)
+	s.Impersonation = service.NewImpersonationService(r.Impersonation, r.CreditAccount, r.Establishment, tokenIssuer, clock)
+	s.AccountActivity = service.NewAccountActivityService(r.AccountActivity, r.CreditAccount)
+	s.DocumentSeries = service.NewDocumentSeriesService(r.DocumentSeries, r.Establishment)
+	s.Category = service.NewCategoryService(r.Category, r.Establishment)
+	s.User = service.NewUserService(r.User, r.CreditAccount, r.EstablishmentSettings, r.CreditTemplate, r.Session, s.ContactVerification, clock, imageUploader)
+	s.Admin = service.NewAdminService(r.Establishment, r.User, s.ContactVerification)
+	s.Establishment = service.NewEstablishmentService(r.Establishment, r.User, imageUploader)
+	s.Product = service.NewProductService(r.Product, r.Category, r.Establishment, r.User, imageUploader)
+	s.CreditAccount = service.NewCreditAccountService(r.CreditAccount, r.Transaction, r.Installment, r.Client, r.Establishment, r.EstablishmentSettings, r.PaymentPromise, r.CreditTemplate, r.Holiday, s.PurchasePin, clock, eventBus)
+	s.Installment = service.NewInstallmentService(r.Installment, r.CreditAccount, r.Establishment, r.EstablishmentSettings, clock, eventBus)
+	s.Report = service.NewReportService(r.Establishment, r.PurchaseItem, r.CreditAccount, r.Transaction, r.Installment, r.Holiday, clock)
+	s.ReportDigest = service.NewReportDigestService(r.Establishment, r.User, r.EstablishmentSettings, r.CreditAccount, r.Transaction, r.Installment, r.ReportDigest, mailer, s.Job, clock)
+	s.CreditSimulation = service.NewCreditSimulationService(r.Establishment, r.Holiday, clock)
+	s.NotificationDispatcher = service.NewNotificationDispatcher(r.Establishment, r.CreditAccount, r.EstablishmentSettings, r.SMSDelivery, r.ClientNotification, mailer, texter, s.Job, clock, eventBus)
+	s.Transaction = service.NewTransactionService(r.Transaction, r.CreditAccount, r.Establishment, r.EstablishmentSettings, r.Installment, r.PaymentAllocation, s.NotificationDispatcher, clock, eventBus)
+	s.PaymentLink = service.NewPaymentLinkService(r.PaymentLink, r.CreditAccount, r.Installment, r.Establishment, r.EstablishmentSettings, s.NotificationDispatcher, clock, eventBus, cfg.JWT.Secret, cfg.PaymentLinkBaseURL)
+	s.Purchase = service.NewPurchaseService(r.User, r.Establishment, r.Product, r.CreditAccount, r.Transaction, r.Installment, r.PurchaseItem, r.EstablishmentSettings, r.PurchaseApproval, r.CreditAgreement, r.Holiday, mailer, clock, eventBus, summaryCache, s.Job, s.PaymentLink)
+	s.StatementDelivery = service.NewStatementDeliveryService(r.Establishment, r.CreditAccount, r.User, r.StatementDelivery, r.EstablishmentSettings, s.Purchase, mailer, s.Job, clock)
+	s.StatementPeriod = service.NewStatementPeriodService(r.StatementPeriod, r.CreditAccount, r.Transaction, r.Establishment, clock)
+	s.PaymentReminder = service.NewPaymentReminderService(r.EstablishmentSettings, r.CreditAccount, r.Installment, r.PaymentReminder, r.Holiday, s.NotificationDispatcher, clock)
+	s.CreditScoring = service.NewCreditScoringService(r.CreditAccount, r.Installment, r.EstablishmentSettings, r.PaymentPromise, r.Holiday, clock)
+	s.Attachment = service.NewAttachmentService(r.Attachment, r.CreditAccount, r.Establishment, documentUploader, clock)
+	s.Privacy = service.NewPrivacyService(r.Privacy, r.User, r.CreditAccount, r.Establishment, r.Attachment, documentUploader, imageUploader, service.RetentionPolicy{
+		Deliveries:     cfg.Retention.Deliveries,
+		Signups:        cfg.Retention.Signups,
+		Impersonations: cfg.Retention.Impersonations,
+		Verifications:  cfg.Retention.Verifications,
+	}, clock)
+	s.PaymentPromise = service.NewPaymentPromiseService(r.PaymentPromise, r.CreditAccount, r.Transaction, r.Establishment, clock, eventBus)
+	s.CreditAgreement = service.NewCreditAgreementService(r.CreditAgreement, r.CreditAccount, r.Establishment, clock, eventBus)
+	s.Catalog = service.NewCatalogService(r.Product, r.Establishment, r.EstablishmentSettings)
+	s.ClientSignup = service.NewClientSignupService(r.ClientSignup, r.Establishment, r.User, r.CreditAccount, r.EstablishmentSettings, s.ContactVerification, mailer, clock)
+	s.AccountAdjustment = service.NewAccountAdjustmentService(r.AccountAdjustment, r.CreditAccount, r.Establishment, r.EstablishmentSettings, clock, eventBus)
+	s.TillSession = service.NewTillSessionService(r.TillSession, r.Establishment, clock)
+	s.Collection = service.NewCollectionService(r.CollectionContact, r.CreditAccount, r.Installment, r.PaymentPromise, r.Establishment, r.Holiday, clock)
+	s.ClientTag = service.NewClientTagService(r.ClientTag, r.CreditAccount, r.Establishment, clock)
+	s.ClientNote = service.NewClientNoteService(r.ClientNote, r.CreditAccount, r.Establishment, clock)
+	s.EstablishmentConfig = service.NewEstablishmentConfigService(r.EstablishmentConfig, r.EstablishmentSettings, r.Category, r.Product, r.Establishment, clock)
+	s.CreditTemplate = service.NewCreditTemplateService(r.CreditTemplate, r.Establishment)
+	s.CreditTermUpdate = service.NewCreditTermUpdateService(r.CreditTermUpdate, r.CreditTemplate, r.Establishment, clock, eventBus)
+	s.BalanceHistory = service.NewBalanceHistoryService(r.BalanceSnapshot, r.CreditAccount, r.Establishment, clock)
+	s.Plan = service.NewPlanService(r.Establishment)
+	s.Platform = service.NewPlatformService(r.Platform, r.Establishment, r.User, r.Session, r.EstablishmentSettings, mailer, clock)
+	s.Tenant = service.NewTenantService(r.Establishment, r.CreditAccount)
+	s.Authorization = service.NewAuthorizationService(r.CreditAccount, r.Transaction, r.Installment)
+	s.BankTransfer = service.NewBankTransferService(r.BankTransfer, r.Transaction, r.CreditAccount, r.Establishment, s.NotificationDispatcher, clock, eventBus, cfg.BankWebhook.Secret, cfg.BankWebhook.Tolerance)
+	s.Accounting = service.NewAccountingService(r.Establishment, r.EstablishmentSettings, r.AccountActivity, clock)
+	s.ClientHome = service.NewClientHomeService(r.CreditAccount, r.AccountActivity, r.Holiday, clock)
+	s.ClientNotification = service.NewClientNotificationService(r.ClientNotification, clock)
+	s.AdminHome = service.NewAdminHomeService(r.Establishment, r.Product, r.AccountActivity, clock)
+	s.CreditAccountEvent = service.NewCreditAccountEventService(r.CreditAccountEvent)
+	s.Holiday = service.NewHolidayService(r.Holiday, r.Establishment)
+	s.Invoicing = service.NewInvoicingService(r.ElectronicInvoice, r.PurchaseItem, r.Establishment, r.EstablishmentSettings, invoiceSigner, invoiceSender, clock)
+	if cfg.Invoicing.Endpoint != "" {
+		eventPublishers = append(eventPublishers, s.Invoicing)
+	}
+	s.Outbox = service.NewOutboxService(r.Outbox, eventPublishers, clock)
+	return s
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats reports how effective a cache is.
+type Stats struct {
+	Hits    uint64  `json:"hits"`
+	Misses  uint64  `json:"misses"`
+	Entries int     `json:"entries"`
+	HitRate float64 `json:"hit_rate"` // Hits / (Hits + Misses), 0 when unused
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is a concurrency-safe in-memory cache with per-entry expiry.
+type Cache[V any] struct {
+	mu      sync.RWMutex
+	entries map[string]entry[V]
+	ttl     time.Duration
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+}
+
+// New creates a Cache whose entries expire after ttl.
+func New[V any](ttl time.Duration) *Cache[V] {
+	return &Cache[V]{entries: make(map[string]entry[V]), ttl: ttl}
+}
+
+// Get returns the value stored under key, if present and not expired.
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(e.expiresAt) {
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+	c.hits.Add(1)
+	return e.value, true
+}
+
+// Set stores value under key.
+func (c *Cache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry[V]{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// Delete removes key from the cache.
+func (c *Cache[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// DeletePrefix removes every key that starts with prefix.
+func (c *Cache[V]) DeletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Stats returns the hit/miss counters and current size of the cache.
+func (c *Cache[V]) Stats() Stats {
+	c.mu.RLock()
+	entries := len(c.entries)
+	c.mu.RUnlock()
+
+	stats := Stats{Hits: c.hits.Load(), Misses: c.misses.Load(), Entries: entries}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
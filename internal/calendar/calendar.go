@@ -0,0 +1,88 @@
+// Package calendar tells the business days due dates fall on from Sundays and the holidays of an
+// establishment, and knows the national holidays of Peru establishments start with.
+package calendar
+
+import "time"
+
+// dateLayout is how dates are compared: by their calendar date, wherever they are.
+const dateLayout = "2006-01-02"
+
+// Holiday is a day off of a year, by its date at midnight UTC.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// BusinessDays are the days due dates can fall on: every day but Sundays and holidays. The zero
+// value only leaves out Sundays.
+type BusinessDays struct {
+	holidays map[string]bool
+}
+
+// NewBusinessDays returns the business days around holidays. Only their calendar date counts, in
+// whatever location they are.
+func NewBusinessDays(holidays []time.Time) BusinessDays {
+	days := BusinessDays{holidays: make(map[string]bool, len(holidays))}
+	for _, holiday := range holidays {
+		days.holidays[holiday.Format(dateLayout)] = true
+	}
+	return days
+}
+
+// IsBusinessDay reports whether the calendar date of day, in its location, is a business day.
+func (b BusinessDays) IsBusinessDay(day time.Time) bool {
+	return day.Weekday() != time.Sunday && !b.holidays[day.Format(dateLayout)]
+}
+
+// Next returns day when it is a business day, or the first business day after it otherwise, at
+// the same time of day.
+func (b BusinessDays) Next(day time.Time) time.Time {
+	// Holidays are at most a few days in a row: give up after a month rather than loop forever
+	for i := 0; i < 31 && !b.IsBusinessDay(day); i++ {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// PeruvianHolidays returns the national holidays of Peru of a year, by date.
+func PeruvianHolidays(year int) []Holiday {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	easter := easterSunday(year)
+	return []Holiday{
+		{date(time.January, 1), "Año Nuevo"},
+		{easter.AddDate(0, 0, -3), "Jueves Santo"},
+		{easter.AddDate(0, 0, -2), "Viernes Santo"},
+		{date(time.May, 1), "Día del Trabajo"},
+		{date(time.June, 7), "Batalla de Arica y Día de la Bandera"},
+		{date(time.June, 29), "San Pedro y San Pablo"},
+		{date(time.July, 23), "Día de la Fuerza Aérea del Perú"},
+		{date(time.July, 28), "Fiestas Patrias"},
+		{date(time.July, 29), "Fiestas Patrias"},
+		{date(time.August, 6), "Batalla de Junín"},
+		{date(time.August, 30), "Santa Rosa de Lima"},
+		{date(time.October, 8), "Combate de Angamos"},
+		{date(time.November, 1), "Día de Todos los Santos"},
+		{date(time.December, 8), "Inmaculada Concepción"},
+		{date(time.December, 9), "Batalla de Ayacucho"},
+		{date(time.December, 25), "Navidad"},
+	}
+}
+
+// easterSunday returns the date of Easter Sunday of a year in the Gregorian calendar, at midnight
+// UTC, by the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,127 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func peruvianDays(year int) BusinessDays {
+	var holidays []time.Time
+	for _, holiday := range PeruvianHolidays(year) {
+		holidays = append(holidays, holiday.Date)
+	}
+	return NewBusinessDays(holidays)
+}
+
+func TestEasterSunday(t *testing.T) {
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{2000, date(2000, time.April, 23)},
+		{2008, date(2008, time.March, 23)},
+		{2019, date(2019, time.April, 21)},
+		{2024, date(2024, time.March, 31)},
+		{2025, date(2025, time.April, 20)},
+		{2026, date(2026, time.April, 5)},
+		{2038, date(2038, time.April, 25)}, // Latest possible date
+	}
+	for _, tt := range tests {
+		if got := easterSunday(tt.year); !got.Equal(tt.want) {
+			t.Errorf("easterSunday(%d) = %s, want %s", tt.year, got.Format(dateLayout), tt.want.Format(dateLayout))
+		}
+	}
+}
+
+func TestPeruvianHolidays(t *testing.T) {
+	holidays := PeruvianHolidays(2025)
+	if len(holidays) != 16 {
+		t.Fatalf("PeruvianHolidays(2025) has %d holidays, want 16", len(holidays))
+	}
+	names := make(map[string]string, len(holidays))
+	for _, holiday := range holidays {
+		names[holiday.Date.Format(dateLayout)] = holiday.Name
+	}
+	for day, want := range map[string]string{
+		"2025-01-01": "Año Nuevo",
+		"2025-04-17": "Jueves Santo",
+		"2025-04-18": "Viernes Santo",
+		"2025-07-28": "Fiestas Patrias",
+		"2025-12-25": "Navidad",
+	} {
+		if got := names[day]; got != want {
+			t.Errorf("holiday on %s = %q, want %q", day, got, want)
+		}
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	days := NewBusinessDays([]time.Time{date(2025, time.March, 12)})
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{"a weekday", date(2025, time.March, 10), true},
+		{"a Saturday", date(2025, time.March, 8), true},
+		{"a Sunday", date(2025, time.March, 9), false},
+		{"a holiday", date(2025, time.March, 12), false},
+		{"a holiday at another time of day", date(2025, time.March, 12).Add(18 * time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := days.IsBusinessDay(tt.day); got != tt.want {
+				t.Errorf("IsBusinessDay(%s) = %t, want %t", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBusinessDayUsesTheDayLocation(t *testing.T) {
+	// 02:00 UTC on Monday is still Sunday in Lima
+	lima := time.FixedZone("America/Lima", -5*60*60)
+	day := time.Date(2025, time.March, 10, 2, 0, 0, 0, time.UTC).In(lima)
+	if (BusinessDays{}).IsBusinessDay(day) {
+		t.Errorf("IsBusinessDay(%s) = true, want false on a Sunday in Lima", day)
+	}
+}
+
+func TestNext(t *testing.T) {
+	days := peruvianDays(2025)
+	tests := []struct {
+		name string
+		day  time.Time
+		want time.Time
+	}{
+		{"a business day is kept", date(2025, time.March, 10), date(2025, time.March, 10)},
+		{"a Sunday moves to Monday", date(2025, time.March, 9), date(2025, time.March, 10)},
+		{"a holiday moves to the next day", date(2025, time.May, 1), date(2025, time.May, 2)},
+		{"Holy Thursday skips Good Friday", date(2025, time.April, 17), date(2025, time.April, 19)},
+		{"a Sunday before Fiestas Patrias", date(2025, time.July, 27), date(2025, time.July, 30)},
+		{"a Sunday before two holidays in December", date(2025, time.December, 7), date(2025, time.December, 10)},
+		{"the time of day is kept", date(2025, time.March, 9).Add(15 * time.Hour), date(2025, time.March, 10).Add(15 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := days.Next(tt.day); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextGivesUp(t *testing.T) {
+	// A calendar with every day off can't loop forever
+	var holidays []time.Time
+	for day := date(2025, time.January, 1); day.Year() == 2025; day = day.AddDate(0, 0, 1) {
+		holidays = append(holidays, day)
+	}
+	start := date(2025, time.March, 1)
+	if got, want := NewBusinessDays(holidays).Next(start), start.AddDate(0, 0, 31); !got.Equal(want) {
+		t.Errorf("Next(%s) = %s, want %s", start, got, want)
+	}
+}
@@ -2,69 +2,265 @@ package config
 
 import (
 	"fmt"
-	"os"
+	"strings"
+	"time"
+)
 
-	"github.com/joho/godotenv"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
+// Environments the API can run in. Each one is a profile: its settings are read from its own files
+// on top of the shared ones, see Load.
+const (
+	Development = "development"
+	Test        = "test"
+	Production  = "production"
+	// Sandbox enables the simulated clock
+	Sandbox = "sandbox"
 )
 
-// Config struct to hold all configuration values
+// Config holds the settings of the API. It only describes them: connecting to the database and
+// the other services is left to the caller. The settings of Uploads can change while the API runs,
+// see Watcher; the others are read once at startup.
 type Config struct {
-	DB         *gorm.DB
-	JwtSecret  string
-	ServerPort string
-	ServerHost string
-}
-
-// LoadConfig loads configuration from environment variables or .env file
-func LoadConfig() (*Config, error) {
-	// Load environment variables from .env file
-	err := godotenv.Load(".env")
-	if err != nil {
-		fmt.Println("Error loading .env file, using system environment variables:", err)
-	}
+	// Environment is one of Development, Test, Production or Sandbox.
+	Environment string `yaml:"-"`
 
-	// Database configuration
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-	dbSSLMode := os.Getenv("DB_SSL_MODE")
-
-	// JWT secret key
-	jwtSecret := os.Getenv("JWT_SECRET")
-
-	// Server port
-	serverPort := os.Getenv("SERVER_PORT")
-	if serverPort == "" {
-		serverPort = "8080" // Default port
-	}
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	JWT       JWTConfig       `yaml:"jwt"`
+	SMTP      SMTPConfig      `yaml:"smtp"`
+	SMS       SMSConfig       `yaml:"sms"`
+	Storage   StorageConfig   `yaml:"storage"`
+	Uploads   UploadConfig    `yaml:"uploads"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Webhooks  WebhookConfig   `yaml:"webhooks"`
+	Jobs      JobConfig       `yaml:"jobs"`
+	Invoicing InvoicingConfig `yaml:"invoicing"`
+	Retention RetentionConfig `yaml:"retention"`
 
-	// Server host
-	serverHost := os.Getenv("SERVER_HOST")
-	if serverHost == "" {
-		serverHost = "localhost" // Default host
-	}
+	BankWebhook BankWebhookConfig `yaml:"bank_webhook"`
+
+	// ImageModerationURL is an optional endpoint uploaded images are checked against
+	ImageModerationURL string `yaml:"image_moderation_url"`
+	// VirusScanURL is an optional endpoint uploaded documents are scanned by
+	VirusScanURL string `yaml:"virus_scan_url"`
+	// PaymentLinkBaseURL is the page clients open their payment links on, followed by the link's
+	// token, e.g. https://pay.example.com/links/. Empty links to the API's own /payment-links/{token}.
+	// PDF statements only print a QR code to pay the balance when it is set.
+	PaymentLinkBaseURL string `yaml:"payment_link_base_url"`
+	// ReloadInterval is how often the profile files are read again to pick up changes to the
+	// reloadable settings. Zero only reloads them on SIGHUP.
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+}
+
+// ServerConfig is where the HTTP server listens and how long it waits for clients.
+type ServerConfig struct {
+	Host              string        `yaml:"host"`
+	Port              string        `yaml:"port"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	// WriteTimeout is disabled by default, as the event stream keeps its response open
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+}
+
+// DatabaseConfig is the PostgreSQL database and its read replicas.
+type DatabaseConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"ssl_mode"`
+	// ReplicaHosts are the read replicas reports and statements may read from, as host or
+	// host:port, sharing the primary's credentials. Empty reads everything from the primary.
+	ReplicaHosts []string `yaml:"replica_hosts"`
+	// ReplicaMaxLag is how far behind the primary a replica may be and still be read from
+	ReplicaMaxLag time.Duration `yaml:"replica_max_lag"`
+	// Connection pool limits. Zero keeps the database/sql defaults.
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
 
-	// Database connection string
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		dbHost, dbPort, dbUser, dbPass, dbName, dbSSLMode)
+// DSN returns the connection string of the primary database.
+func (c DatabaseConfig) DSN() string {
+	return c.dsn(c.Host, c.Port)
+}
 
-	// Connect to database
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("error connecting to database: %w", err)
+// ReplicaDSNs returns the connection strings of the read replicas.
+func (c DatabaseConfig) ReplicaDSNs() []string {
+	dsns := make([]string, 0, len(c.ReplicaHosts))
+	for _, address := range c.ReplicaHosts {
+		host, port, found := strings.Cut(address, ":")
+		if !found {
+			port = c.Port
+		}
+		dsns = append(dsns, c.dsn(host, port))
 	}
+	return dsns
+}
 
-	// Create Config struct
-	cfg := &Config{
-		DB:         db,
-		JwtSecret:  jwtSecret,
-		ServerPort: serverPort,
-		ServerHost: serverHost,
+func (c DatabaseConfig) dsn(host, port string) string {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s", host, port, c.User, c.Password, c.Name)
+	if c.SSLMode != "" {
+		dsn += " sslmode=" + c.SSLMode
 	}
+	return dsn
+}
+
+// JWTConfig is how the session tokens are signed and how long they last.
+type JWTConfig struct {
+	// Secret is the key new tokens are signed with, named KeyID in their kid header
+	Secret string `yaml:"secret"`
+	KeyID  string `yaml:"key_id"`
+	// PreviousKeys are the secrets of retired key IDs. Tokens signed with them stay valid until
+	// they expire; remove them once the refresh token TTL has passed since the rotation.
+	PreviousKeys    map[string]string `yaml:"previous_keys"`
+	Issuer          string            `yaml:"issuer"`
+	Audience        string            `yaml:"audience"`
+	AccessTokenTTL  time.Duration     `yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration     `yaml:"refresh_token_ttl"`
+}
+
+// SMTPConfig is the server emails are sent through. Without a host emails are only logged.
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// SMSConfig is the Twilio account texts are sent through. Without an account SID texts are only logged.
+type SMSConfig struct {
+	TwilioAccountSID string `yaml:"twilio_account_sid"`
+	TwilioAuthToken  string `yaml:"twilio_auth_token"`
+	// From is the number, alphanumeric sender ID or messaging service SID (MG...) texts are sent
+	// from, unless their establishment set its own
+	From string `yaml:"from"`
+	// StatusCallbackURL is the public URL of the API's /webhooks/sms/status, e.g.
+	// https://api.example.com/api/v1/webhooks/sms/status. Without it, texts are tracked until sent.
+	StatusCallbackURL string        `yaml:"status_callback_url"`
+	Timeout           time.Duration `yaml:"timeout"`
+}
+
+// StorageConfig is where uploaded files are kept.
+type StorageConfig struct {
+	// Root is the directory uploads are stored under. Empty stores them under the working directory.
+	Root string `yaml:"root"`
+}
+
+// UploadConfig limits the images and documents users upload. It can be reloaded while the API runs.
+type UploadConfig struct {
+	MaxImageSize    int64 `yaml:"max_image_size"`    // In bytes
+	MinImageSide    int   `yaml:"min_image_side"`    // In pixels
+	MaxImageSide    int   `yaml:"max_image_side"`    // In pixels
+	MaxDocumentSize int64 `yaml:"max_document_size"` // In bytes
+}
+
+// GRPCConfig is the gRPC server for internal services. An empty address disables it.
+type GRPCConfig struct {
+	Address     string `yaml:"address"`
+	TLSCertFile string `yaml:"tls_cert_file"`
+	TLSKeyFile  string `yaml:"tls_key_file"`
+	// AuthTokens are the tokens internal services authenticate to the gRPC server with
+	AuthTokens []string `yaml:"auth_tokens"`
+}
+
+// WebhookConfig is where the domain events relayed from the outbox are sent, signed with Secret if set.
+type WebhookConfig struct {
+	URLs   []string `yaml:"urls"`
+	Secret string   `yaml:"secret"`
+}
+
+// BankWebhookConfig is how the bank or payment aggregator notifying received transfers signs its
+// notifications. Without a secret, the bank webhook is disabled.
+type BankWebhookConfig struct {
+	Secret string `yaml:"secret"`
+	// Tolerance is how far the timestamp of a notification may be from the API's clock
+	Tolerance time.Duration `yaml:"tolerance"`
+}
+
+// JobConfig is how background jobs (PDFs, emails) are run.
+type JobConfig struct {
+	// Workers is how many jobs run at once
+	Workers int `yaml:"workers"`
+}
+
+// InvoicingConfig is the electronic invoicing service purchases are reported to, SUNAT or an OSE,
+// and the certificate documents are signed with. Without an endpoint, purchases are not invoiced.
+type InvoicingConfig struct {
+	Endpoint string `yaml:"endpoint"`
+	// Username is the RUC followed by the SOL user, e.g. 20123456789MODDATOS
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// CertificateFile and KeyFile are the PEM digital certificate and its RSA private key
+	CertificateFile string        `yaml:"certificate_file"`
+	KeyFile         string        `yaml:"key_file"`
+	Timeout         time.Duration `yaml:"timeout"`
+}
+
+// RetentionConfig is how long the records holding personal data are kept once they served their
+// purpose, before the retention job deletes them. Zero keeps them forever.
+type RetentionConfig struct {
+	// Deliveries are the logs of the statements, reminders, texts and digests sent
+	Deliveries time.Duration `yaml:"deliveries"`
+	// Signups are the self-registrations approved or rejected, counted from the decision
+	Signups time.Duration `yaml:"signups"`
+	// Impersonations are the audit trail of admins seeing the API as their clients
+	Impersonations time.Duration `yaml:"impersonations"`
+	// Verifications are the contact verification codes, counted from their expiry
+	Verifications time.Duration `yaml:"verifications"`
+}
 
-	return cfg, nil
+// IsProduction reports whether the API runs in production. Session cookies are then restricted to HTTPS.
+func (c *Config) IsProduction() bool {
+	return c.Environment == Production
+}
+
+// IsSandbox reports whether the API runs in the sandbox environment.
+func (c *Config) IsSandbox() bool {
+	return c.Environment == Sandbox
+}
+
+// defaults returns the settings used when neither a profile file nor the environment sets them.
+func defaults(environment string) Config {
+	return Config{
+		Environment: environment,
+		Server: ServerConfig{
+			Host:              "localhost",
+			Port:              "8080",
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       time.Minute,
+			IdleTimeout:       2 * time.Minute,
+		},
+		Database: DatabaseConfig{
+			Port:          "5432",
+			ReplicaMaxLag: 30 * time.Second,
+		},
+		JWT: JWTConfig{
+			KeyID:           "1",
+			Issuer:          "ApiRestFinance",
+			Audience:        "ApiRestFinance",
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 7 * 24 * time.Hour,
+		},
+		SMTP: SMTPConfig{Port: "587"}, // Submission port
+		SMS:  SMSConfig{Timeout: 10 * time.Second},
+		Uploads: UploadConfig{
+			MaxImageSize:    2 * 1024 * 1024, // 2MB
+			MinImageSide:    64,
+			MaxImageSide:    6000,
+			MaxDocumentSize: 10 * 1024 * 1024, // 10MB
+		},
+		Retention: RetentionConfig{
+			Deliveries:     365 * 24 * time.Hour,
+			Signups:        90 * 24 * time.Hour,
+			Impersonations: 2 * 365 * 24 * time.Hour,
+			Verifications:  30 * 24 * time.Hour,
+		},
+		Jobs:           JobConfig{Workers: 4},
+		Invoicing:      InvoicingConfig{Timeout: 30 * time.Second},
+		BankWebhook:    BankWebhookConfig{Tolerance: 5 * time.Minute},
+		ReloadInterval: 30 * time.Second,
+	}
 }
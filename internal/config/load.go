@@ -0,0 +1,290 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads the settings of the environment named by APP_ENV (development by default) and
+// validates them. Each setting is taken from the first of these that sets it:
+//
+//  1. the process environment
+//  2. .env.<environment>, e.g. .env.production
+//  3. .env
+//  4. the YAML profile: the file named by CONFIG_FILE, or config/<environment>.yaml if it exists
+//  5. the defaults
+//
+// The variables the environment and .env files can set are listed in envSource.apply. Missing
+// profile files are skipped, but files that can't be parsed are an error.
+func Load() (*Config, error) {
+	env, err := readEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	environment, err := parseEnvironment(env.get("APP_ENV"))
+	if err != nil {
+		return nil, err
+	}
+	if err := env.readDotenv(".env." + environment); err != nil {
+		return nil, err
+	}
+
+	cfg := defaults(environment)
+	if err := readYAML(&cfg, env.get("CONFIG_FILE")); err != nil {
+		return nil, err
+	}
+	if err := env.apply(&cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func parseEnvironment(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "dev", Development:
+		return Development, nil
+	case Test:
+		return Test, nil
+	case "prod", Production:
+		return Production, nil
+	case Sandbox:
+		return Sandbox, nil
+	default:
+		return "", fmt.Errorf("invalid APP_ENV %q: must be development, test, production or sandbox", value)
+	}
+}
+
+// readYAML reads the YAML profile into cfg. Unknown keys are an error, so typos don't go unnoticed.
+func readYAML(cfg *Config, path string) error {
+	required := path != ""
+	if !required {
+		path = filepath.Join("config", cfg.Environment+".yaml")
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// envSource looks variables up in the process environment and then in the .env files, most
+// specific first. Values that can't be parsed are collected, to be reported together.
+type envSource struct {
+	dotenv []map[string]string
+	errs   []error
+}
+
+func readEnv() (*envSource, error) {
+	env := &envSource{}
+	return env, env.readDotenv(".env")
+}
+
+// readDotenv adds a .env file as the most specific after the process environment.
+func (e *envSource) readDotenv(path string) error {
+	values, err := godotenv.Read(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	e.dotenv = append([]map[string]string{values}, e.dotenv...)
+	return nil
+}
+
+func (e *envSource) lookup(key string) (string, bool) {
+	if value, ok := os.LookupEnv(key); ok {
+		return value, true
+	}
+	for _, values := range e.dotenv {
+		if value, ok := values[key]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (e *envSource) get(key string) string {
+	value, _ := e.lookup(key)
+	return value
+}
+
+// apply overrides the settings of cfg with the variables that are set. Empty variables count as unset.
+func (e *envSource) apply(cfg *Config) error {
+	e.setString("SERVER_HOST", &cfg.Server.Host)
+	e.setString("SERVER_PORT", &cfg.Server.Port)
+	e.setString("PORT", &cfg.Server.Port) // Set by most hosting platforms
+	e.setDuration("SERVER_READ_HEADER_TIMEOUT", &cfg.Server.ReadHeaderTimeout)
+	e.setDuration("SERVER_READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	e.setDuration("SERVER_WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	e.setDuration("SERVER_IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+
+	e.setString("DB_HOST", &cfg.Database.Host)
+	e.setString("DB_PORT", &cfg.Database.Port)
+	e.setString("DB_USER", &cfg.Database.User)
+	e.setString("DB_PASSWORD", &cfg.Database.Password)
+	e.setString("DB_NAME", &cfg.Database.Name)
+	e.setString("DB_SSLMODE", &cfg.Database.SSLMode) // Older .env files spell it this way
+	e.setString("DB_SSL_MODE", &cfg.Database.SSLMode)
+	e.setList("DB_REPLICA_HOSTS", &cfg.Database.ReplicaHosts)
+	e.setDuration("DB_REPLICA_MAX_LAG", &cfg.Database.ReplicaMaxLag)
+	e.setInt("DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns)
+	e.setInt("DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns)
+	e.setDuration("DB_CONN_MAX_LIFETIME", &cfg.Database.ConnMaxLifetime)
+
+	e.setString("JWT_SECRET", &cfg.JWT.Secret)
+	e.setString("JWT_KEY_ID", &cfg.JWT.KeyID)
+	e.setMap("JWT_PREVIOUS_KEYS", &cfg.JWT.PreviousKeys)
+	e.setString("JWT_ISSUER", &cfg.JWT.Issuer)
+	e.setString("JWT_AUDIENCE", &cfg.JWT.Audience)
+	e.setDuration("JWT_ACCESS_TOKEN_TTL", &cfg.JWT.AccessTokenTTL)
+	e.setDuration("JWT_REFRESH_TOKEN_TTL", &cfg.JWT.RefreshTokenTTL)
+
+	e.setString("SMTP_HOST", &cfg.SMTP.Host)
+	e.setString("SMTP_PORT", &cfg.SMTP.Port)
+	e.setString("SMTP_USERNAME", &cfg.SMTP.Username)
+	e.setString("SMTP_PASSWORD", &cfg.SMTP.Password)
+	e.setString("SMTP_FROM", &cfg.SMTP.From)
+
+	e.setString("TWILIO_ACCOUNT_SID", &cfg.SMS.TwilioAccountSID)
+	e.setString("TWILIO_AUTH_TOKEN", &cfg.SMS.TwilioAuthToken)
+	e.setString("SMS_FROM", &cfg.SMS.From)
+	e.setString("SMS_STATUS_CALLBACK_URL", &cfg.SMS.StatusCallbackURL)
+	e.setDuration("SMS_TIMEOUT", &cfg.SMS.Timeout)
+
+	e.setString("STORAGE_ROOT", &cfg.Storage.Root)
+	e.setInt64("UPLOAD_MAX_IMAGE_SIZE", &cfg.Uploads.MaxImageSize)
+	e.setInt("UPLOAD_MIN_IMAGE_SIDE", &cfg.Uploads.MinImageSide)
+	e.setInt("UPLOAD_MAX_IMAGE_SIDE", &cfg.Uploads.MaxImageSide)
+	e.setInt64("UPLOAD_MAX_DOCUMENT_SIZE", &cfg.Uploads.MaxDocumentSize)
+
+	e.setString("GRPC_ADDRESS", &cfg.GRPC.Address)
+	e.setString("GRPC_TLS_CERT_FILE", &cfg.GRPC.TLSCertFile)
+	e.setString("GRPC_TLS_KEY_FILE", &cfg.GRPC.TLSKeyFile)
+	e.setList("GRPC_AUTH_TOKENS", &cfg.GRPC.AuthTokens)
+
+	e.setList("WEBHOOK_URLS", &cfg.Webhooks.URLs)
+	e.setString("WEBHOOK_SECRET", &cfg.Webhooks.Secret)
+
+	e.setString("BANK_WEBHOOK_SECRET", &cfg.BankWebhook.Secret)
+	e.setDuration("BANK_WEBHOOK_TOLERANCE", &cfg.BankWebhook.Tolerance)
+
+	e.setInt("JOB_WORKERS", &cfg.Jobs.Workers)
+
+	e.setString("INVOICING_ENDPOINT", &cfg.Invoicing.Endpoint)
+	e.setString("INVOICING_USERNAME", &cfg.Invoicing.Username)
+	e.setString("INVOICING_PASSWORD", &cfg.Invoicing.Password)
+	e.setString("INVOICING_CERTIFICATE_FILE", &cfg.Invoicing.CertificateFile)
+	e.setString("INVOICING_KEY_FILE", &cfg.Invoicing.KeyFile)
+	e.setDuration("INVOICING_TIMEOUT", &cfg.Invoicing.Timeout)
+
+	e.setDuration("RETENTION_DELIVERIES", &cfg.Retention.Deliveries)
+	e.setDuration("RETENTION_SIGNUPS", &cfg.Retention.Signups)
+	e.setDuration("RETENTION_IMPERSONATIONS", &cfg.Retention.Impersonations)
+	e.setDuration("RETENTION_VERIFICATIONS", &cfg.Retention.Verifications)
+
+	e.setString("IMAGE_MODERATION_URL", &cfg.ImageModerationURL)
+	e.setString("VIRUS_SCAN_URL", &cfg.VirusScanURL)
+	e.setString("PAYMENT_LINK_BASE_URL", &cfg.PaymentLinkBaseURL)
+	e.setDuration("CONFIG_RELOAD_INTERVAL", &cfg.ReloadInterval)
+
+	return errors.Join(e.errs...)
+}
+
+func (e *envSource) setString(key string, target *string) {
+	if value := e.get(key); value != "" {
+		*target = value
+	}
+}
+
+// setList reads a comma-separated list, ignoring blank items.
+func (e *envSource) setList(key string, target *[]string) {
+	value := e.get(key)
+	if value == "" {
+		return
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*target = items
+}
+
+// setMap reads a comma-separated list of key:value pairs.
+func (e *envSource) setMap(key string, target *map[string]string) {
+	var items []string
+	e.setList(key, &items)
+	if items == nil {
+		return
+	}
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		k, v, found := strings.Cut(item, ":")
+		if !found {
+			e.errs = append(e.errs, fmt.Errorf("invalid %s item %q: must be key:value", key, item))
+			return
+		}
+		values[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	*target = values
+}
+
+func (e *envSource) setInt(key string, target *int) {
+	if value := e.get(key); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("invalid %s %q: must be a whole number", key, value))
+			return
+		}
+		*target = n
+	}
+}
+
+func (e *envSource) setInt64(key string, target *int64) {
+	if value := e.get(key); value != "" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("invalid %s %q: must be a whole number", key, value))
+			return
+		}
+		*target = n
+	}
+}
+
+func (e *envSource) setDuration(key string, target *time.Duration) {
+	if value := e.get(key); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("invalid %s %q: must be a duration such as 30s or 15m", key, value))
+			return
+		}
+		*target = d
+	}
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Watcher serves the current settings, reloading them from the profile files and the environment
+// every ReloadInterval and on SIGHUP. Only the settings that are safe to change while the API runs
+// (Uploads) are taken from a reload; changes to the others are logged and wait for a restart. A
+// reload that fails to load or validate keeps the current settings.
+type Watcher struct {
+	current  atomic.Pointer[Config]
+	interval time.Duration
+}
+
+// NewWatcher creates a Watcher starting from cfg.
+func NewWatcher(cfg *Config) *Watcher {
+	w := &Watcher{interval: cfg.ReloadInterval}
+	w.current.Store(cfg)
+	return w
+}
+
+// Config returns the current settings. They must not be modified.
+func (w *Watcher) Config() *Config {
+	return w.current.Load()
+}
+
+// Start reloads the settings in the background until ctx is done.
+func (w *Watcher) Start(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	var tick <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		tick = ticker.C
+		go func() {
+			<-ctx.Done()
+			ticker.Stop()
+		}()
+	}
+
+	go func() {
+		defer signal.Stop(hangup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hangup:
+			case <-tick:
+			}
+			if err := w.Reload(); err != nil {
+				log.Println("error reloading configuration, keeping the current one:", err)
+			}
+		}
+	}()
+}
+
+// Reload loads the settings again and applies the reloadable ones.
+func (w *Watcher) Reload() error {
+	loaded, err := Load()
+	if err != nil {
+		return err
+	}
+
+	current := w.Config()
+	next := *current
+	next.Uploads = loaded.Uploads
+	if next.Uploads == current.Uploads {
+		return nil
+	}
+
+	log.Printf("configuration reloaded: uploads %+v", next.Uploads)
+	loaded.Uploads = current.Uploads
+	if !reflect.DeepEqual(*loaded, *current) {
+		log.Println("other configuration changes require a restart to take effect")
+	}
+	w.current.Store(&next)
+	return nil
+}
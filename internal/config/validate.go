@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// minProductionSecretLength is the shortest JWT secret accepted in production: 256 bits, the size of the HS256 key.
+const minProductionSecretLength = 32
+
+// ValidationError lists every problem found in the settings, so they can all be fixed at once.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks that the settings the API needs are set and consistent. It returns a
+// *ValidationError naming the variables to fix.
+func (c *Config) Validate() error {
+	var problems []string
+	problem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if !validPort(c.Server.Port) {
+		problem("SERVER_PORT %q is not a valid port", c.Server.Port)
+	}
+	if c.Server.ReadHeaderTimeout < 0 || c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 {
+		problem("server timeouts can't be negative")
+	}
+
+	for _, required := range []struct{ name, value string }{
+		{"DB_HOST", c.Database.Host},
+		{"DB_USER", c.Database.User},
+		{"DB_NAME", c.Database.Name},
+	} {
+		if required.value == "" {
+			problem("%s is required", required.name)
+		}
+	}
+	if !validPort(c.Database.Port) {
+		problem("DB_PORT %q is not a valid port", c.Database.Port)
+	}
+	if len(c.Database.ReplicaHosts) > 0 && c.Database.ReplicaMaxLag <= 0 {
+		problem("DB_REPLICA_MAX_LAG must be positive")
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 || c.Database.ConnMaxLifetime < 0 {
+		problem("database pool limits can't be negative")
+	}
+
+	switch {
+	case c.JWT.Secret == "":
+		problem("JWT_SECRET is required")
+	case c.IsProduction() && len(c.JWT.Secret) < minProductionSecretLength:
+		problem("JWT_SECRET must be at least %d characters long in production", minProductionSecretLength)
+	}
+	if c.JWT.AccessTokenTTL <= 0 || c.JWT.RefreshTokenTTL <= 0 {
+		problem("JWT_ACCESS_TOKEN_TTL and JWT_REFRESH_TOKEN_TTL must be positive")
+	} else if c.JWT.RefreshTokenTTL < c.JWT.AccessTokenTTL {
+		problem("JWT_REFRESH_TOKEN_TTL can't be shorter than JWT_ACCESS_TOKEN_TTL")
+	}
+	if c.JWT.KeyID == "" {
+		problem("JWT_KEY_ID is required")
+	}
+	for id, secret := range c.JWT.PreviousKeys {
+		switch {
+		case id == c.JWT.KeyID:
+			problem("JWT_PREVIOUS_KEYS can't reuse the current JWT_KEY_ID %q", id)
+		case id == "" || secret == "":
+			problem("JWT_PREVIOUS_KEYS entries need both a key ID and a secret")
+		}
+	}
+	if c.JWT.Issuer == "" || c.JWT.Audience == "" {
+		problem("JWT_ISSUER and JWT_AUDIENCE are required")
+	}
+
+	if c.SMTP.Host != "" {
+		if c.SMTP.From == "" {
+			problem("SMTP_FROM is required when SMTP_HOST is set")
+		}
+		if !validPort(c.SMTP.Port) {
+			problem("SMTP_PORT %q is not a valid port", c.SMTP.Port)
+		}
+	}
+
+	if c.SMS.TwilioAccountSID != "" {
+		if c.SMS.TwilioAuthToken == "" || c.SMS.From == "" {
+			problem("TWILIO_AUTH_TOKEN and SMS_FROM are required when TWILIO_ACCOUNT_SID is set")
+		}
+		if c.SMS.Timeout <= 0 {
+			problem("SMS_TIMEOUT must be positive")
+		}
+	}
+	if c.SMS.StatusCallbackURL != "" {
+		if u, err := url.Parse(c.SMS.StatusCallbackURL); err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			problem("SMS_STATUS_CALLBACK_URL %q is not an absolute URL", c.SMS.StatusCallbackURL)
+		}
+	}
+	if c.PaymentLinkBaseURL != "" {
+		if u, err := url.Parse(c.PaymentLinkBaseURL); err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			problem("PAYMENT_LINK_BASE_URL %q is not an absolute URL", c.PaymentLinkBaseURL)
+		}
+	}
+
+	if err := c.Uploads.validate(); err != nil {
+		problem("%s", err)
+	}
+
+	if (c.GRPC.TLSCertFile == "") != (c.GRPC.TLSKeyFile == "") {
+		problem("GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE must be set together")
+	}
+	if c.GRPC.Address != "" && c.IsProduction() && c.GRPC.TLSCertFile == "" {
+		problem("GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE are required to serve gRPC in production")
+	}
+
+	if c.Invoicing.Endpoint != "" {
+		for _, required := range []struct{ name, value string }{
+			{"INVOICING_USERNAME", c.Invoicing.Username},
+			{"INVOICING_PASSWORD", c.Invoicing.Password},
+			{"INVOICING_CERTIFICATE_FILE", c.Invoicing.CertificateFile},
+			{"INVOICING_KEY_FILE", c.Invoicing.KeyFile},
+		} {
+			if required.value == "" {
+				problem("%s is required when INVOICING_ENDPOINT is set", required.name)
+			}
+		}
+		if c.Invoicing.Timeout <= 0 {
+			problem("INVOICING_TIMEOUT must be positive")
+		}
+	}
+
+	if c.BankWebhook.Secret != "" && c.BankWebhook.Tolerance <= 0 {
+		problem("BANK_WEBHOOK_TOLERANCE must be positive")
+	}
+
+	if c.Retention.Deliveries < 0 || c.Retention.Signups < 0 || c.Retention.Impersonations < 0 || c.Retention.Verifications < 0 {
+		problem("retention periods can't be negative")
+	}
+
+	if c.Jobs.Workers < 1 {
+		problem("JOB_WORKERS must be at least 1")
+	}
+	if c.ReloadInterval < 0 {
+		problem("CONFIG_RELOAD_INTERVAL can't be negative")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (u UploadConfig) validate() error {
+	switch {
+	case u.MaxImageSize <= 0:
+		return fmt.Errorf("UPLOAD_MAX_IMAGE_SIZE must be positive")
+	case u.MinImageSide <= 0 || u.MaxImageSide < u.MinImageSide:
+		return fmt.Errorf("UPLOAD_MIN_IMAGE_SIDE must be positive and not above UPLOAD_MAX_IMAGE_SIDE")
+	case u.MaxDocumentSize <= 0:
+		return fmt.Errorf("UPLOAD_MAX_DOCUMENT_SIZE must be positive")
+	}
+	return nil
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
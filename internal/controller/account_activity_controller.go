@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountActivityController handles the activity feed of credit accounts.
+type AccountActivityController struct {
+	activityService service.AccountActivityService
+}
+
+// NewAccountActivityController creates a new instance of AccountActivityController.
+func NewAccountActivityController(activityService service.AccountActivityService) *AccountActivityController {
+	return &AccountActivityController{activityService: activityService}
+}
+
+// GetClientActivity godoc
+// @Summary      Get Client Activity
+// @Description  Gets the activity feed of the authenticated client's credit account, newest first: purchases, payments, interest charges, late fees, credit limit changes, blocks and reversals. Each entry has an icon and title for the app to show. The maximum page size depends on the caller's role. The number of entries is sent in the X-Total-Count header.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization     header      string  true  "Bearer {token}"
+// @Param        establishment_id  query       int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Param        page              query       int     false "Page number (starts at 1)"
+// @Param        page_size         query       int     false "Page size"
+// @Success      200  {array}   response.ActivityResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/activity [get]
+func (c *AccountActivityController) GetClientActivity(ctx *gin.Context) {
+	authUserRole := middleware.GetUserRoleFromContext(ctx)
+	if authUserRole != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can view their activity"})
+		return
+	}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page"})
+		return
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page_size"})
+		return
+	}
+	pageSize, err := service.QueryLimitsForRole(authUserRole).ResolvePageSize(requestedPageSize)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	activities, total, err := c.activityService.GetClientActivity(middleware.GetUserIDFromContext(ctx), establishmentID, page, pageSize)
+	if err != nil {
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	versioning.SetPaginationTotal(ctx, page, pageSize, total)
+	ctx.JSON(http.StatusOK, activities)
+}
@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountAdjustmentController handles the manual adjustments admins make to the balance of credit accounts.
+type AccountAdjustmentController struct {
+	adjustmentService service.AccountAdjustmentService
+}
+
+// NewAccountAdjustmentController creates a new instance of AccountAdjustmentController.
+func NewAccountAdjustmentController(adjustmentService service.AccountAdjustmentService) *AccountAdjustmentController {
+	return &AccountAdjustmentController{adjustmentService: adjustmentService}
+}
+
+// CreateAdjustment godoc
+// @Summary      Create Account Adjustment
+// @Description  Adjusts the balance of a credit account by hand with a reason: an ADJUSTMENT_DEBIT charges the client, e.g. to correct an error, and an ADJUSTMENT_CREDIT (credit note) lowers what they owe, e.g. a goodwill credit. It is applied right away (201) unless its amount is above the adjustment threshold of the establishment, when it waits for the approval of the adjustment approver, a second admin the establishment designated in its settings (202). Only Admins of the account's establishment can adjust it.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                   true  "Bearer {token}"
+// @Param        id             path        int                                      true  "Credit Account ID"
+// @Param        adjustment     body        request.CreateAccountAdjustmentRequest  true  "Type, amount and reason"
+// @Success      201  {object}  response.AccountAdjustmentResponse
+// @Success      202  {object}  response.AccountAdjustmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/adjustments [post]
+func (c *AccountAdjustmentController) CreateAdjustment(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can adjust credit accounts"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	var req request.CreateAccountAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	adjustment, err := c.adjustmentService.CreateAdjustment(middleware.GetUserIDFromContext(ctx), uint(id), req)
+	if err != nil {
+		respondAccountAdjustmentError(ctx, err)
+		return
+	}
+	if adjustment.Status == enums.AdjustmentPending {
+		ctx.JSON(http.StatusAccepted, adjustment)
+		return
+	}
+	ctx.JSON(http.StatusCreated, adjustment)
+}
+
+// GetAdjustments godoc
+// @Summary      List Account Adjustments
+// @Description  Lists the manual adjustments of a credit account, newest first, including those waiting for approval or rejected. Only Admins of the account's establishment can list them.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Success      200  {array}   response.AccountAdjustmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/adjustments [get]
+func (c *AccountAdjustmentController) GetAdjustments(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list adjustments"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	adjustments, err := c.adjustmentService.GetAdjustments(middleware.GetUserIDFromContext(ctx), uint(id))
+	if err != nil {
+		respondAccountAdjustmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, adjustments)
+}
+
+// GetPendingAdjustments godoc
+// @Summary      List Pending Adjustments
+// @Description  Lists the adjustments waiting for the approval of the authenticated admin, oldest first, across the establishments that designated them adjustment approver.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {array}   response.AccountAdjustmentResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /adjustments/pending [get]
+func (c *AccountAdjustmentController) GetPendingAdjustments(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins approve adjustments"})
+		return
+	}
+
+	adjustments, err := c.adjustmentService.GetPendingAdjustments(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		respondAccountAdjustmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, adjustments)
+}
+
+// ApproveAdjustment godoc
+// @Summary      Approve Adjustment
+// @Description  Approves an adjustment waiting for approval, applying it to the balance of its credit account. Only the adjustment approver of the account's establishment can approve it, and never their own adjustments.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Adjustment ID"
+// @Success      200  {object}  response.AccountAdjustmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /adjustments/{id}/approve [post]
+func (c *AccountAdjustmentController) ApproveAdjustment(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can approve adjustments"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid adjustment ID"})
+		return
+	}
+
+	adjustment, err := c.adjustmentService.ApproveAdjustment(middleware.GetUserIDFromContext(ctx), uint(id))
+	if err != nil {
+		respondAccountAdjustmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, adjustment)
+}
+
+// RejectAdjustment godoc
+// @Summary      Reject Adjustment
+// @Description  Rejects an adjustment waiting for approval with a reason, leaving the balance alone. Only the adjustment approver of the account's establishment can reject it.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                   true  "Bearer {token}"
+// @Param        id             path        int                                      true  "Adjustment ID"
+// @Param        rejection      body        request.RejectAccountAdjustmentRequest  true  "Reason for the rejection"
+// @Success      200  {object}  response.AccountAdjustmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /adjustments/{id}/reject [post]
+func (c *AccountAdjustmentController) RejectAdjustment(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can reject adjustments"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid adjustment ID"})
+		return
+	}
+	var req request.RejectAccountAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	adjustment, err := c.adjustmentService.RejectAdjustment(middleware.GetUserIDFromContext(ctx), uint(id), req.Reason)
+	if err != nil {
+		respondAccountAdjustmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, adjustment)
+}
+
+// respondAccountAdjustmentError writes the response for an error of an adjustment operation.
+func respondAccountAdjustmentError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrCreditAccountNotFound), errors.Is(err, service.ErrAccountAdjustmentNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrAdjustmentApproverRequired), errors.Is(err, service.ErrAdjustmentDecided):
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountingController exports the books of establishments for their accountants.
+type AccountingController struct {
+	accountingService service.AccountingService
+}
+
+// NewAccountingController creates a new instance of AccountingController.
+func NewAccountingController(accountingService service.AccountingService) *AccountingController {
+	return &AccountingController{accountingService: accountingService}
+}
+
+// GetAccountingAccounts godoc
+// @Summary      Get Accounting Accounts
+// @Description  Gets the account of the establishment's chart of accounts each accounting event of the journal export is mapped to: RECEIVABLES (what clients owe), SALES, CASH (payments collected), INTEREST_INCOME, LATE_FEES and WRITE_OFFS, and the events not mapped yet. Only Admins can see them.
+// @Tags         Accounting
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.AccountingAccountsResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/accounting/accounts [get]
+func (c *AccountingController) GetAccountingAccounts(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view accounting accounts"})
+		return
+	}
+
+	accounts, err := c.accountingService.GetAccountingAccounts(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, accounts)
+}
+
+// UpdateAccountingAccounts godoc
+// @Summary      Update Accounting Accounts
+// @Description  Maps accounting events to the accounts of the establishment's chart of accounts, e.g. {"accounts": {"SALES": "7011", "CASH": "1011"}}. Events left out keep their account. Every event must be mapped before the journal can be exported. Only Admins can change them.
+// @Tags         Accounting
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                   true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                      false "Branch to act on. Defaults to the main establishment"
+// @Param        accounts       body        request.UpdateAccountingAccountsRequest  true  "Accounts by event"
+// @Success      200  {object}  response.AccountingAccountsResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/accounting/accounts [put]
+func (c *AccountingController) UpdateAccountingAccounts(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can change accounting accounts"})
+		return
+	}
+
+	var req request.UpdateAccountingAccountsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	accounts, err := c.accountingService.UpdateAccountingAccounts(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, accounts)
+}
+
+// ExportJournal godoc
+// @Summary      Export Journal Entries
+// @Description  Downloads the journal entries of the movements of the credit accounts of the admin's establishment over a period, for its accounting system. Purchases and manual charges are debited to the receivables account and credited to sales, payments debited to cash, interest and late fees credited to their income accounts and write-offs debited to the write-off account; reversals and manual credits go the other way. The accounts are those each accounting event is mapped to at /establishments/me/accounting/accounts, and the journal can't be exported until every event is mapped. Use format=csv for a CSV with a debit and a credit line per entry, or format=ple for the Libro Diario (5.1) layout of SUNAT's PLE. Only Admins can export them.
+// @Tags         Accounting
+// @Produce      text/csv
+// @Produce      text/plain
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        period         query       string  false "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to month"
+// @Param        format         query       string  false "csv (default) or ple"
+// @Success      200  {file}    text/csv  "Journal entries"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/accounting/export [get]
+func (c *AccountingController) ExportJournal(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can export journal entries"})
+		return
+	}
+
+	adminID := middleware.GetUserIDFromContext(ctx)
+	branchID := middleware.GetBranchIDFromContext(ctx)
+	period := ctx.Query("period")
+
+	switch ctx.DefaultQuery("format", "csv") {
+	case "csv":
+		csvBytes, err := c.accountingService.ExportJournalCSV(adminID, branchID, period)
+		if err != nil {
+			respondAccountingError(ctx, err)
+			return
+		}
+		ctx.Header("Content-Disposition", "attachment; filename=journal_entries.csv")
+		ctx.Data(http.StatusOK, "text/csv", csvBytes)
+	case "ple":
+		pleBytes, fileName, err := c.accountingService.ExportJournalPLE(adminID, branchID, period)
+		if err != nil {
+			respondAccountingError(ctx, err)
+			return
+		}
+		ctx.Header("Content-Disposition", "attachment; filename="+fileName)
+		ctx.Data(http.StatusOK, "text/plain; charset=utf-8", pleBytes)
+	default:
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid format, use csv or ple"})
+	}
+}
+
+func respondAccountingError(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrAccountingEventsUnmapped) {
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	respondReportError(ctx, err)
+}
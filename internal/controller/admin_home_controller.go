@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHomeController serves the dashboard of the admin app.
+type AdminHomeController struct {
+	homeService service.AdminHomeService
+}
+
+// NewAdminHomeController creates a new instance of AdminHomeController.
+func NewAdminHomeController(homeService service.AdminHomeService) *AdminHomeController {
+	return &AdminHomeController{homeService: homeService}
+}
+
+// GetAdminHome godoc
+// @Summary      Get Admin Home
+// @Description  Gets everything the dashboard of the admin app shows of the establishment in one call: whether it is active, inactive or suspended by the platform, the payments collected today in its time zone and what they add up to, how many transactions wait for their confirmation code, how many credit accounts are past their monthly due date with a balance, the 10 active products with the least stock at or below 5 and the last 10 entries of the activity of its credit accounts, with their clients.
+// @Tags         Admins
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.AdminHomeResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admins/me/home [get]
+func (c *AdminHomeController) GetAdminHome(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view their home"})
+		return
+	}
+
+	home, err := c.homeService.GetAdminHome(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, home)
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentController handles the documents attached to clients and credit accounts.
+type AttachmentController struct {
+	attachmentService service.AttachmentService
+}
+
+// NewAttachmentController creates a new instance of AttachmentController.
+func NewAttachmentController(attachmentService service.AttachmentService) *AttachmentController {
+	return &AttachmentController{attachmentService: attachmentService}
+}
+
+// UploadClientAttachment godoc
+// @Summary      Upload Client Document
+// @Description  Attaches a document, e.g. a scan of their DNI, to a client of the admin's establishment. PDF, JPEG and PNG files are accepted; they are scanned for viruses when a scanner is configured and kept for the retention period of their kind (10 years for credit agreements, 5 for the others). Only Admins can upload documents.
+// @Tags         Attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int     true  "Client ID"
+// @Param        file           formData    file    true  "Document"
+// @Param        kind           formData    string  true  "DNI_SCAN, CREDIT_AGREEMENT or OTHER"
+// @Success      201  {object}  response.AttachmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/attachments [post]
+func (c *AttachmentController) UploadClientAttachment(ctx *gin.Context) {
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+	c.upload(ctx, func(adminID uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error) {
+		return c.attachmentService.UploadClientAttachment(adminID, middleware.GetBranchIDFromContext(ctx), uint(clientID), kind, file)
+	})
+}
+
+// UploadCreditAccountAttachment godoc
+// @Summary      Upload Credit Account Document
+// @Description  Attaches a document, e.g. the signed credit agreement, to a credit account of the admin's establishment. PDF, JPEG and PNG files are accepted; they are scanned for viruses when a scanner is configured and kept for the retention period of their kind (10 years for credit agreements, 5 for the others). Only Admins can upload documents.
+// @Tags         Attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Param        file           formData    file    true  "Document"
+// @Param        kind           formData    string  true  "DNI_SCAN, CREDIT_AGREEMENT or OTHER"
+// @Success      201  {object}  response.AttachmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/attachments [post]
+func (c *AttachmentController) UploadCreditAccountAttachment(ctx *gin.Context) {
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	c.upload(ctx, func(adminID uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error) {
+		return c.attachmentService.UploadCreditAccountAttachment(adminID, uint(creditAccountID), kind, file)
+	})
+}
+
+func (c *AttachmentController) upload(ctx *gin.Context, upload func(adminID uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error)) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can upload documents"})
+		return
+	}
+	var req request.UploadAttachmentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Error uploading file: " + err.Error()})
+		return
+	}
+
+	attachment, err := upload(middleware.GetUserIDFromContext(ctx), req.Kind, file)
+	if err != nil {
+		respondAttachmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+// GetClientAttachments godoc
+// @Summary      List Client Documents
+// @Description  Lists the documents of a client of the admin's establishment, those attached to their credit account included, newest first. Only Admins can list documents.
+// @Tags         Attachments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int     true  "Client ID"
+// @Success      200  {array}   response.AttachmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/attachments [get]
+func (c *AttachmentController) GetClientAttachments(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list documents"})
+		return
+	}
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+
+	attachments, err := c.attachmentService.GetClientAttachments(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(clientID))
+	if err != nil {
+		respondAttachmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+// GetCreditAccountAttachments godoc
+// @Summary      List Credit Account Documents
+// @Description  Lists the documents attached to a credit account of the admin's establishment, newest first. Only Admins can list documents.
+// @Tags         Attachments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Success      200  {array}   response.AttachmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/attachments [get]
+func (c *AttachmentController) GetCreditAccountAttachments(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list documents"})
+		return
+	}
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	attachments, err := c.attachmentService.GetCreditAccountAttachments(middleware.GetUserIDFromContext(ctx), uint(creditAccountID))
+	if err != nil {
+		respondAttachmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+// DownloadAttachment godoc
+// @Summary      Download Document
+// @Description  Downloads an attached document. Available to the admins of its establishment and the client it belongs to.
+// @Tags         Attachments
+// @Produce      application/pdf
+// @Produce      image/jpeg
+// @Produce      image/png
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Attachment ID"
+// @Success      200  {file}    file  "The document"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /attachments/{id}/download [get]
+func (c *AttachmentController) DownloadAttachment(ctx *gin.Context) {
+	attachmentID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid attachment ID"})
+		return
+	}
+
+	attachment, data, err := c.attachmentService.DownloadAttachment(middleware.GetUserIDFromContext(ctx), middleware.GetUserRoleFromContext(ctx), uint(attachmentID))
+	if err != nil {
+		respondAttachmentError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	ctx.Data(http.StatusOK, attachment.ContentType, data)
+}
+
+// respondAttachmentError writes the response for an error of an attachment operation.
+func respondAttachmentError(ctx *gin.Context, err error) {
+	var status int
+	switch {
+	case errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, service.ErrCreditAccountNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidAttachmentType), errors.Is(err, service.ErrInvalidAttachmentKind),
+		errors.Is(err, service.ErrFileSizeTooLarge), errors.Is(err, service.ErrAttachmentInfected):
+		status = http.StatusBadRequest
+	default:
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+}
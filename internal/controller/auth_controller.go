@@ -1,37 +1,57 @@
 package controller
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"ApiRestFinance/internal/middleware"
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/service"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 )
 
+// Cookie sessions keep their refresh token in a cookie that is only sent to the refresh and logout
+// endpoints, set once for each path.
+const refreshTokenCookie = "refresh_token"
+
+var refreshTokenCookiePaths = []string{"/api/v1/refresh", "/api/v1/logout"}
+
 // AuthController handles authentication-related endpoints.
 type AuthController struct {
-	authService service.AuthService
+	authService   service.AuthService
+	csrfSecret    string
+	secureCookies bool
+	// Cookies are kept as long as the token they hold; the CSRF cookie goes with the access token
+	accessCookieMaxAge  int
+	refreshCookieMaxAge int
 }
 
-// NewAuthController creates a new instance of AuthController.
-func NewAuthController(authService service.AuthService) *AuthController {
-	return &AuthController{authService: authService}
+// NewAuthController creates a new instance of AuthController. csrfSecret signs the CSRF tokens
+// of cookie sessions, secureCookies restricts session cookies to HTTPS and the TTLs are the
+// lifetimes of the access and refresh tokens.
+func NewAuthController(authService service.AuthService, csrfSecret string, secureCookies bool, accessTokenTTL, refreshTokenTTL time.Duration) *AuthController {
+	return &AuthController{
+		authService:         authService,
+		csrfSecret:          csrfSecret,
+		secureCookies:       secureCookies,
+		accessCookieMaxAge:  int(accessTokenTTL / time.Second),
+		refreshCookieMaxAge: int(refreshTokenTTL / time.Second),
+	}
 }
 
 // RegisterAdmin godoc
 // @Summary      Register Admin
-// @Description  Registers a new admin user along with their establishment.
+// @Description  Registers a new admin user along with their establishment. A DNI or email of another user, or the RUC of another establishment, is rejected with 409 Conflict and a message naming it.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
 // @Param        registration  body      request.CreateAdminAndEstablishmentRequest  true  "Admin and establishment registration data"
 // @Success      201  {object}  map[string]string
 // @Failure      400  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
 // @Router       /register [post]
 func (c *AuthController) RegisterAdmin(ctx *gin.Context) {
 	var req request.CreateAdminAndEstablishmentRequest
@@ -41,6 +61,10 @@ func (c *AuthController) RegisterAdmin(ctx *gin.Context) {
 	}
 
 	if err := c.authService.RegisterAdmin(&req); err != nil {
+		if uniqueConflict(err) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -50,7 +74,7 @@ func (c *AuthController) RegisterAdmin(ctx *gin.Context) {
 
 // Login godoc
 // @Summary      Login
-// @Description  Logs in a user with their email and password.
+// @Description  Logs in a user with their email and password. With use_cookie the tokens are also stored in HttpOnly cookies and a CSRF token is returned, which must be sent in the X-CSRF-Token header of state-changing requests. Users with two-factor authentication get two_factor_required and a challenge_token to complete the login at /login/2fa instead of their tokens.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
@@ -65,29 +89,108 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	authResponse, err := c.authService.Login(&req)
+	authResponse, err := c.authService.Login(&req, clientInfo(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	if req.UseCookie && !authResponse.TwoFactorRequired {
+		c.setSessionCookies(ctx, authResponse)
+	}
+
 	ctx.JSON(http.StatusOK, authResponse)
 }
 
+// CompleteTwoFactorLogin godoc
+// @Summary      Complete Two-Factor Login
+// @Description  Completes a login challenged for its second factor with a code of the user's authenticator app or one of their recovery codes. Users setting two-factor authentication up during the login send the first code of their app and also get their recovery codes. Five wrong codes in a row lock the second factor for 15 minutes with 429 Too Many Requests, after which the login has to be started again.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        login  body      request.TwoFactorLoginRequest  true  "Challenge token and code"
+// @Success      200  {object}  response.AuthResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      429  {object}  response.ErrorResponse
+// @Router       /login/2fa [post]
+func (c *AuthController) CompleteTwoFactorLogin(ctx *gin.Context) {
+	var req request.TwoFactorLoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	authResponse, err := c.authService.CompleteTwoFactorLogin(&req, clientInfo(ctx))
+	if err != nil {
+		status := twoFactorErrorStatus(err)
+		if status == http.StatusBadRequest {
+			status = http.StatusUnauthorized
+		}
+		ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if req.UseCookie {
+		c.setSessionCookies(ctx, authResponse)
+	}
+	ctx.JSON(http.StatusOK, authResponse)
+}
+
+// SetupTwoFactorLogin godoc
+// @Summary      Set Up Two-Factor Authentication at Login
+// @Description  Generates the two-factor secret of a user whose establishment requires two-factor authentication, when the login answered two_factor_setup_required. The login is then completed at /login/2fa with the first code of the app.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        challenge  body      request.TwoFactorChallengeRequest  true  "Challenge token"
+// @Success      200  {object}  response.TwoFactorSetupResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Router       /login/2fa/setup [post]
+func (c *AuthController) SetupTwoFactorLogin(ctx *gin.Context) {
+	var req request.TwoFactorChallengeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	setup, err := c.authService.SetupTwoFactorLogin(req.ChallengeToken)
+	if err != nil {
+		ctx.JSON(twoFactorErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, setup)
+}
+
 // RefreshToken godoc
 // @Summary      Refresh Token
-// @Description  Refreshes the access token using a valid refresh token.
+// @Description  Refreshes the access token using a valid refresh token, sent as a Bearer token or, for cookie sessions, in the refresh token cookie.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
-// @Param        Authorization  header      string  true  "Bearer {refreshToken}"
+// @Param        Authorization  header      string  false  "Bearer {refreshToken}"
 // @Success      200  {object}  response.AuthResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Router       /refresh [post]
 func (c *AuthController) RefreshToken(ctx *gin.Context) {
 	authHeader := ctx.GetHeader("Authorization")
 	if authHeader == "" {
-		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Authorization header missing"})
+		refreshToken, err := ctx.Cookie(refreshTokenCookie)
+		if err != nil || refreshToken == "" {
+			ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Authorization header missing"})
+			return
+		}
+
+		authResponse, err := c.authService.AttemptRefresh(refreshToken, clientInfo(ctx))
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.setSessionCookies(ctx, authResponse)
+		ctx.JSON(http.StatusOK, authResponse)
 		return
 	}
 
@@ -103,7 +206,7 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
-	authResponse, err := c.authService.AttemptRefresh(refreshToken)
+	authResponse, err := c.authService.AttemptRefresh(refreshToken, clientInfo(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: err.Error()})
 		return
@@ -131,32 +234,79 @@ func (c *AuthController) ResetPassword(ctx *gin.Context) {
 		return
 	}
 
-	claims, exists := ctx.Get("claims")
-	if !exists {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
 		ctx.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
 		return
 	}
 
-	claimsMap, ok := claims.(jwt.MapClaims)
-	if !ok {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Internal server error: invalid claims"})
+	err := c.authService.ResetPassword(&req, userID, middleware.GetSessionIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	userIDFloat, ok := claimsMap["user_id"].(float64)
-	if !ok {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Internal server error: missing user ID"})
-		return
+	ctx.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Ends the session of the refresh token, read from the refresh token cookie or the Authorization header, and clears the session cookies. Access tokens already issued last until they expire.
+// @Tags         Authentication
+// @Produce      json
+// @Param        Authorization  header      string  false  "Bearer {refreshToken}"
+// @Success      200  {object}  map[string]string
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /logout [post]
+func (c *AuthController) Logout(ctx *gin.Context) {
+	refreshToken, _ := ctx.Cookie(refreshTokenCookie)
+	if parts := strings.SplitN(ctx.GetHeader("Authorization"), " ", 2); len(parts) == 2 && parts[0] == "Bearer" {
+		refreshToken = parts[1]
+	}
+	if refreshToken != "" {
+		if err := c.authService.Logout(refreshToken); err != nil {
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 	}
 
-	userID := uint(userIDFloat)
-	fmt.Println("User ID: ", userID) // Debug: Print userID
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(middleware.AccessTokenCookie, "", -1, "/", "", c.secureCookies, true)
+	ctx.SetCookie(middleware.CSRFCookie, "", -1, "/", "", c.secureCookies, false)
+	for _, path := range refreshTokenCookiePaths {
+		ctx.SetCookie(refreshTokenCookie, "", -1, path, "", c.secureCookies, true)
+	}
 
-	err := c.authService.ResetPassword(&req, userID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
-		return
+	ctx.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// GetCSRFToken godoc
+// @Summary      Get CSRF Token
+// @Description  Returns the CSRF token of the current session, to be sent in the X-CSRF-Token header of state-changing requests authenticated by cookie.
+// @Tags         Authentication
+// @Produce      json
+// @Param        Authorization  header      string  false  "Bearer {token}"
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  response.ErrorResponse
+// @Router       /csrf-token [get]
+func (c *AuthController) GetCSRFToken(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"csrf_token": middleware.CSRFToken(ctx.GetString("session_token"), c.csrfSecret)})
+}
+
+// setSessionCookies stores the tokens of authResponse in cookies and adds the session's CSRF token to it.
+func (c *AuthController) setSessionCookies(ctx *gin.Context, authResponse *response.AuthResponse) {
+	authResponse.CSRFToken = middleware.CSRFToken(authResponse.AccessToken, c.csrfSecret)
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(middleware.AccessTokenCookie, authResponse.AccessToken, c.accessCookieMaxAge, "/", "", c.secureCookies, true)
+	for _, path := range refreshTokenCookiePaths {
+		ctx.SetCookie(refreshTokenCookie, authResponse.RefreshToken, c.refreshCookieMaxAge, path, "", c.secureCookies, true)
 	}
+	// Readable by scripts on purpose, so the portal can echo it back in the X-CSRF-Token header
+	ctx.SetCookie(middleware.CSRFCookie, authResponse.CSRFToken, c.accessCookieMaxAge, "/", "", c.secureCookies, false)
+}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+// clientInfo describes the device a request comes from, to be recorded in its session.
+func clientInfo(ctx *gin.Context) service.ClientInfo {
+	return service.ClientInfo{UserAgent: ctx.Request.UserAgent(), IP: ctx.ClientIP()}
 }
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// requestCaller returns the caller of the request, for service.AuthorizationService.
+func requestCaller(ctx *gin.Context) service.Caller {
+	return service.Caller{
+		UserID: middleware.GetUserIDFromContext(ctx),
+		Role:   middleware.GetUserRoleFromContext(ctx),
+		Tenant: middleware.GetTenantFromContext(ctx),
+	}
+}
+
+// respondUnauthorized answers the request and returns true when an authorization check failed: with
+// 404 and notFound when the record doesn't exist, and 403 when the caller may not access it.
+func respondUnauthorized(ctx *gin.Context, err error, notFound string) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: notFound})
+	case errors.Is(err, service.ErrAccessDenied):
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+	return true
+}
+
+// authorizeEstablishment answers with 404 and returns false unless establishmentID is an
+// establishment of the tenant of the request, for routes that list the records of an establishment.
+func authorizeEstablishment(ctx *gin.Context, establishmentID uint) bool {
+	if middleware.GetTenantFromContext(ctx).Allows(establishmentID) {
+		return true
+	}
+	ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Establishment not found"})
+	return false
+}
@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/testutil/fixture"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// reachedServices stand in for the services behind the authorized routes, recording whether a request
+// got past its authorization checks.
+type reachedServices struct {
+	service.TransactionService
+	service.InstallmentService
+	reached bool
+}
+
+func (s *reachedServices) CreateTransaction(req request.CreateTransactionRequest) (*response.TransactionResponse, error) {
+	s.reached = true
+	return &response.TransactionResponse{}, nil
+}
+
+func (s *reachedServices) GetTransactionByID(id uint) (*response.TransactionResponse, error) {
+	s.reached = true
+	return &response.TransactionResponse{}, nil
+}
+
+func (s *reachedServices) GetTransactionsByCreditAccountID(creditAccountID uint, page, pageSize int) ([]response.TransactionResponse, error) {
+	s.reached = true
+	return nil, nil
+}
+
+func (s *reachedServices) UpdateTransaction(id uint, req request.UpdateTransactionRequest) (*response.TransactionResponse, error) {
+	s.reached = true
+	return &response.TransactionResponse{}, nil
+}
+
+func (s *reachedServices) DeleteTransaction(id uint) error {
+	s.reached = true
+	return nil
+}
+
+func (s *reachedServices) ConfirmPayment(transactionID uint, confirmationCode string) error {
+	s.reached = true
+	return nil
+}
+
+func (s *reachedServices) GetPaymentAllocation(transactionID uint) (*response.PaymentAllocationResponse, error) {
+	s.reached = true
+	return &response.PaymentAllocationResponse{}, nil
+}
+
+func (s *reachedServices) GetPaymentReceiptPDF(transactionID uint, lang i18n.Language) ([]byte, error) {
+	s.reached = true
+	return []byte("%PDF"), nil
+}
+
+func (s *reachedServices) CreateInstallment(req request.CreateInstallmentRequest) (*response.InstallmentResponse, error) {
+	s.reached = true
+	return &response.InstallmentResponse{}, nil
+}
+
+func (s *reachedServices) GetInstallmentByID(id uint) (*response.InstallmentResponse, error) {
+	s.reached = true
+	return &response.InstallmentResponse{}, nil
+}
+
+func (s *reachedServices) GetInstallmentsByCreditAccountID(creditAccountID uint) ([]response.InstallmentResponse, error) {
+	s.reached = true
+	return nil, nil
+}
+
+func (s *reachedServices) UpdateInstallment(id uint, req request.UpdateInstallmentRequest) (*response.InstallmentResponse, error) {
+	s.reached = true
+	return &response.InstallmentResponse{}, nil
+}
+
+func (s *reachedServices) DeleteInstallment(id uint) error {
+	s.reached = true
+	return nil
+}
+
+func (s *reachedServices) GetOverdueInstallments(creditAccountID uint) ([]response.InstallmentResponse, error) {
+	s.reached = true
+	return nil, nil
+}
+
+func (s *reachedServices) RescheduleInstallment(adminID, installmentID uint, req request.RescheduleInstallmentRequest) (*response.InstallmentResponse, error) {
+	s.reached = true
+	return &response.InstallmentResponse{}, nil
+}
+
+func (s *reachedServices) GetInstallmentReschedules(adminID, installmentID uint) ([]response.InstallmentRescheduleResponse, error) {
+	s.reached = true
+	return nil, nil
+}
+
+func (s *reachedServices) GetCreditAccountEvents(creditAccountID uint, page, pageSize int) ([]response.CreditAccountEventResponse, int, error) {
+	s.reached = true
+	return nil, 0, nil
+}
+
+const (
+	authorizedTransactionID uint = 200
+	authorizedInstallmentID uint = 300
+	missingRecordID         uint = 999
+)
+
+// newAuthorizedRoutes registers the routes authorized by service.AuthorizationService as in
+// app/routes.go, over the credit account of fixture.CreditAccount, a transaction and an installment
+// on it. Other IDs are not found.
+func newAuthorizedRoutes(t *testing.T, as caller) (http.Handler, *reachedServices) {
+	ctrl := gomock.NewController(t)
+	account := fixture.CreditAccount().Build()
+	accounts := mocks.NewMockCreditAccountRepository(ctrl)
+	accounts.EXPECT().GetCreditAccountByID(gomock.Any()).DoAndReturn(func(id uint) (*entities.CreditAccount, error) {
+		if id != account.ID {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return account, nil
+	}).AnyTimes()
+	transactions := mocks.NewMockTransactionRepository(ctrl)
+	transactions.EXPECT().GetTransactionByID(gomock.Any()).DoAndReturn(func(id uint) (*entities.Transaction, error) {
+		if id != authorizedTransactionID {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return &entities.Transaction{Model: gorm.Model{ID: id}, CreditAccountID: account.ID}, nil
+	}).AnyTimes()
+	installments := mocks.NewMockInstallmentRepository(ctrl)
+	installments.EXPECT().GetInstallmentByID(gomock.Any()).DoAndReturn(func(id uint) (*entities.Installment, error) {
+		if id != authorizedInstallmentID {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return &entities.Installment{Model: gorm.Model{ID: id}, CreditAccountID: account.ID}, nil
+	}).AnyTimes()
+	authorization := service.NewAuthorizationService(accounts, transactions, installments)
+
+	services := &reachedServices{}
+	transaction := NewTransactionController(services, authorization)
+	installment := NewInstallmentController(services, authorization)
+	events := NewCreditAccountEventController(services, authorization)
+	router := newTestRouter(as)
+	router.POST("/transactions", transaction.CreateTransaction)
+	router.GET("/transactions/:id", transaction.GetTransactionByID)
+	router.PUT("/transactions/:id", transaction.UpdateTransaction)
+	router.DELETE("/transactions/:id", transaction.DeleteTransaction)
+	router.GET("/credit-accounts/:id/transactions", transaction.GetTransactionsByCreditAccountID)
+	router.POST("/transactions/:id/confirm", transaction.ConfirmPayment)
+	router.GET("/transactions/:id/allocation", transaction.GetPaymentAllocation)
+	router.GET("/transactions/:id/receipt/pdf", transaction.GetPaymentReceiptPDF)
+	router.POST("/installments", installment.CreateInstallment)
+	router.GET("/installments/:id", installment.GetInstallmentByID)
+	router.PUT("/installments/:id", installment.UpdateInstallment)
+	router.DELETE("/installments/:id", installment.DeleteInstallment)
+	router.POST("/installments/:id/reschedule", installment.RescheduleInstallment)
+	router.GET("/installments/:id/reschedules", installment.GetInstallmentReschedules)
+	router.GET("/credit-accounts/:id/installments", installment.GetInstallmentsByCreditAccountID)
+	router.GET("/credit-accounts/:id/installments/overdue", installment.GetOverdueInstallments)
+	router.GET("/credit-accounts/:id/events", events.GetCreditAccountEvents)
+	return router, services
+}
+
+// grantee is who a route lets through.
+type grantee int
+
+const (
+	toAdmins grantee = 1 << iota // Admins of the account's establishment
+	toHolder                     // The client holding the account
+)
+
+// authorizedRoute is a request to a route authorized by service.AuthorizationService, on the records
+// of newAuthorizedRoutes, with the callers it grants.
+type authorizedRoute struct {
+	method, path string
+	body         interface{}
+	grants       grantee
+}
+
+var authorizedRoutes = []authorizedRoute{
+	{http.MethodPost, "/transactions", gin.H{"credit_account_id": fixture.CreditAccountID, "transaction_type": enums.Purchase, "amount": 50, "payment_method": enums.CASH}, toHolder},
+	{http.MethodPost, "/transactions", gin.H{"credit_account_id": fixture.CreditAccountID, "transaction_type": enums.Payment, "amount": 50, "payment_method": enums.CASH}, toAdmins},
+	{http.MethodGet, "/transactions/200", nil, toAdmins | toHolder},
+	{http.MethodPut, "/transactions/200", gin.H{"amount": 60}, toAdmins},
+	{http.MethodDelete, "/transactions/200", nil, toAdmins},
+	{http.MethodGet, "/credit-accounts/100/transactions", nil, toAdmins | toHolder},
+	{http.MethodPost, "/transactions/200/confirm", gin.H{"confirmation_code": "123456"}, toAdmins},
+	{http.MethodGet, "/transactions/200/allocation", nil, toAdmins | toHolder},
+	{http.MethodGet, "/transactions/200/receipt/pdf", nil, toAdmins | toHolder},
+	{http.MethodPost, "/installments", gin.H{"credit_account_id": fixture.CreditAccountID, "due_date": fixture.Now, "amount": 100}, toAdmins},
+	{http.MethodGet, "/installments/300", nil, toAdmins | toHolder},
+	{http.MethodPut, "/installments/300", gin.H{"amount": 120, "version": 1}, toAdmins},
+	{http.MethodDelete, "/installments/300", nil, toAdmins},
+	{http.MethodPost, "/installments/300/reschedule", gin.H{"due_date": "2025-04-15", "reason": "Acuerdo con el cliente"}, toAdmins},
+	{http.MethodGet, "/installments/300/reschedules", nil, toAdmins},
+	{http.MethodGet, "/credit-accounts/100/installments", nil, toAdmins | toHolder},
+	{http.MethodGet, "/credit-accounts/100/installments/overdue", nil, toAdmins | toHolder},
+	{http.MethodGet, "/credit-accounts/100/events", nil, toAdmins | toHolder},
+}
+
+func TestAuthorizedRoutesByRole(t *testing.T) {
+	ownScope := tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID}}
+	callers := []struct {
+		name   string
+		as     caller
+		grants grantee // What the caller is to the account, 0 if nothing
+	}{
+		{"admin of the establishment", establishmentAdmin, toAdmins},
+		{"admin of another establishment", caller{userID: fixture.AdminID + 1, role: enums.ADMIN, scope: tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID + 1}}}, 0},
+		{"holder of the account", caller{userID: fixture.ClientID, role: enums.CLIENT, scope: tenant.Scope{EstablishmentIDs: ownScope.EstablishmentIDs, ClientID: fixture.ClientID}}, toHolder},
+		{"another client of the establishment", caller{userID: fixture.ClientID + 1, role: enums.CLIENT, scope: tenant.Scope{EstablishmentIDs: ownScope.EstablishmentIDs, ClientID: fixture.ClientID + 1}}, 0},
+		{"super-admin", caller{userID: 1, role: enums.SUPERADMIN}, 0},
+	}
+	for _, route := range authorizedRoutes {
+		for _, c := range callers {
+			t.Run(route.method+" "+route.path+" as "+c.name, func(t *testing.T) {
+				router, services := newAuthorizedRoutes(t, c.as)
+				recorder := serve(t, router, route.method, route.path, route.body)
+
+				granted := route.grants&c.grants != 0
+				if services.reached != granted {
+					t.Fatalf("reached the service = %t with %d %s, want %t", services.reached, recorder.Code, recorder.Body, granted)
+				}
+				if !granted && recorder.Code != http.StatusForbidden {
+					t.Errorf("denied with %d %s, want %d", recorder.Code, recorder.Body, http.StatusForbidden)
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizedRoutesOnMissingRecords(t *testing.T) {
+	tests := []struct {
+		method, path string
+		body         interface{}
+		want         int
+	}{
+		{http.MethodPost, "/transactions", gin.H{"credit_account_id": missingRecordID, "transaction_type": enums.Payment, "amount": 50, "payment_method": enums.CASH}, http.StatusBadRequest},
+		{http.MethodGet, "/transactions/999", nil, http.StatusNotFound},
+		{http.MethodDelete, "/transactions/999", nil, http.StatusNotFound},
+		{http.MethodGet, "/credit-accounts/999/transactions", nil, http.StatusNotFound},
+		{http.MethodPost, "/installments", gin.H{"credit_account_id": missingRecordID, "due_date": fixture.Now, "amount": 100}, http.StatusNotFound},
+		{http.MethodGet, "/installments/999", nil, http.StatusNotFound},
+		{http.MethodDelete, "/installments/999", nil, http.StatusNotFound},
+		{http.MethodGet, "/credit-accounts/999/installments", nil, http.StatusNotFound},
+		{http.MethodGet, "/credit-accounts/999/events", nil, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			router, services := newAuthorizedRoutes(t, establishmentAdmin)
+			recorder := serve(t, router, tt.method, tt.path, tt.body)
+			if recorder.Code != tt.want || services.reached {
+				t.Errorf("%s %s = %d %s, reached the service = %t, want %d", tt.method, tt.path, recorder.Code, recorder.Body, services.reached, tt.want)
+			}
+		})
+	}
+}
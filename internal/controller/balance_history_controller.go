@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BalanceHistoryController handles the balance of credit accounts over time.
+type BalanceHistoryController struct {
+	balanceHistoryService service.BalanceHistoryService
+}
+
+// NewBalanceHistoryController creates a new instance of BalanceHistoryController.
+func NewBalanceHistoryController(balanceHistoryService service.BalanceHistoryService) *BalanceHistoryController {
+	return &BalanceHistoryController{balanceHistoryService: balanceHistoryService}
+}
+
+// GetBalanceHistory godoc
+// @Summary      Get Credit Account Balance History
+// @Description  Lists the closing balance of a credit account for each day of the period, in the establishment's time zone, for balance-over-time charts. Balances are snapshotted nightly; when the period includes today, it ends with the current balance (current is true). Days before the first snapshot of the account are left out. Clients can see their own accounts and Admins those of their establishment.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Param        period         query       string  false "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to last_30_days"
+// @Success      200  {object}  response.BalanceHistoryResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/balance-history [get]
+func (c *BalanceHistoryController) GetBalanceHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	history, err := c.balanceHistoryService.GetBalanceHistory(middleware.GetUserIDFromContext(ctx), middleware.GetUserRoleFromContext(ctx), uint(id), ctx.Query("period"))
+	if err != nil {
+		if errors.Is(err, service.ErrCreditAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Credit account not found"})
+			return
+		}
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+// GetReceivablesTrend godoc
+// @Summary      Get Receivables Trend Report
+// @Description  Adds up the closing balances of the credit accounts of the admin's establishment for each day of the period, in its time zone: balances owed, account credit in the clients' favor, their difference (receivables), written-off balances and how many accounts owed something. Days are included once their balances are snapshotted, nightly. Only Admins can see reports.
+// @Tags         Reports
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        period         query       string  false "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to last_30_days"
+// @Success      200  {object}  response.ReceivablesTrendResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/receivables [get]
+func (c *BalanceHistoryController) GetReceivablesTrend(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view reports"})
+		return
+	}
+
+	trend, err := c.balanceHistoryService.GetReceivablesTrend(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), ctx.Query("period"))
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, trend)
+}
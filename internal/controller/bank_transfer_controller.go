@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxBankNotificationSize is the largest body of a bank notification read, in bytes.
+const maxBankNotificationSize = 64 * 1024
+
+// BankTransferController receives the transfers notified by the bank and shows admins those applied
+// to the credit accounts of their establishment.
+type BankTransferController struct {
+	bankTransferService service.BankTransferService
+	enabled             bool
+}
+
+// NewBankTransferController creates a new instance of BankTransferController. enabled is false when
+// no bank webhook secret is configured.
+func NewBankTransferController(bankTransferService service.BankTransferService, enabled bool) *BankTransferController {
+	return &BankTransferController{bankTransferService: bankTransferService, enabled: enabled}
+}
+
+// ReceiveBankTransfer godoc
+// @Summary      Receive Bank Transfer
+// @Description  Webhook the bank or payment aggregator notifies the transfers it received on. Each notification is signed in X-Bank-Signature with "sha256=" and the hex HMAC-SHA256, keyed with BANK_WEBHOOK_SECRET, of X-Bank-Timestamp, a dot and the body; notifications older than BANK_WEBHOOK_TOLERANCE are refused. A transfer whose reference is the confirmation code of a pending payment of the same amount confirms it, and one whose reference is CA- and the ID of a credit account is recorded as a payment on it; the client is emailed a receipt. Others are recorded unmatched. Notifications delivered again are answered with what was done with them the first time.
+// @Tags         Bank Transfers
+// @Accept       json
+// @Produce      json
+// @Param        X-Bank-Timestamp  header    string                                   true  "When the notification was sent, in Unix seconds"
+// @Param        X-Bank-Signature  header    string                                   true  "Signature of the notification"
+// @Param        transfer          body      request.BankTransferNotificationRequest  true  "Transfer received"
+// @Success      200  {object}  response.BankTransferResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /webhooks/bank/transfers [post]
+func (c *BankTransferController) ReceiveBankTransfer(ctx *gin.Context) {
+	if !c.enabled {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "No bank webhook is configured"})
+		return
+	}
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBankNotificationSize)
+	body, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	err = c.bankTransferService.VerifyNotification(ctx.GetHeader("X-Bank-Timestamp"), ctx.GetHeader("X-Bank-Signature"), body)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	var req request.BankTransferNotificationRequest
+	if err := binding.JSON.BindBody(body, &req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	transfer, err := c.bankTransferService.ReceiveBankTransfer(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, transfer)
+}
+
+// GetBankTransfers godoc
+// @Summary      List Bank Transfers
+// @Description  Lists the transfers notified by the bank that were applied to the credit accounts of the establishment, newest first, with the payment each one confirmed or was recorded as. Only Admins can see them.
+// @Tags         Bank Transfers
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.BankTransferResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/bank-transfers [get]
+func (c *BankTransferController) GetBankTransfers(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view bank transfers"})
+		return
+	}
+
+	transfers, err := c.bankTransferService.GetBankTransfers(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, transfers)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// catalogMaxAge is how long, in seconds, clients and proxies may cache a page of a catalog.
+const catalogMaxAge = 300
+
+// CatalogController handles the public catalog of establishments.
+type CatalogController struct {
+	catalogService service.CatalogService
+}
+
+// NewCatalogController creates a new instance of CatalogController.
+func NewCatalogController(catalogService service.CatalogService) *CatalogController {
+	return &CatalogController{catalogService: catalogService}
+}
+
+// GetCatalog godoc
+// @Summary      Get Establishment Catalog
+// @Description  Gets a page of the active products of an establishment, by name, so clients can browse them before purchasing. Prices include tax, and instead of the stock each product says whether it is available and running out. No authentication is needed. Responses may be cached for a few minutes. The number of products is sent in the X-Total-Count header.
+// @Tags         Products
+// @Produce      json
+// @Param        establishmentID  path      int  true  "Establishment ID"
+// @Param        category_id      query     int  false "Only list the products of this category"
+// @Param        page             query     int  false "Page number (starts at 1)"
+// @Param        page_size        query     int  false "Page size"
+// @Success      200  {array}   response.CatalogProductResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/{establishmentID}/catalog [get]
+func (c *CatalogController) GetCatalog(ctx *gin.Context) {
+	establishmentID, err := strconv.Atoi(ctx.Param("establishmentID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid establishment ID"})
+		return
+	}
+	categoryID, err := strconv.ParseUint(ctx.DefaultQuery("category_id", "0"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid category ID"})
+		return
+	}
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page"})
+		return
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page_size"})
+		return
+	}
+	// Anonymous callers get the limits of no role
+	pageSize, err := service.QueryLimitsForRole("").ResolvePageSize(requestedPageSize)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	catalog, total, err := c.catalogService.GetCatalog(uint(establishmentID), uint(categoryID), page, pageSize)
+	if err != nil {
+		if errors.Is(err, service.ErrEstablishmentNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", catalogMaxAge))
+	versioning.SetPaginationTotal(ctx, page, pageSize, total)
+	ctx.JSON(http.StatusOK, catalog)
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryController handles the categories establishments group their products in.
+type CategoryController struct {
+	categoryService service.CategoryService
+}
+
+// NewCategoryController creates a new instance of CategoryController.
+func NewCategoryController(categoryService service.CategoryService) *CategoryController {
+	return &CategoryController{categoryService: categoryService}
+}
+
+// GetCategories godoc
+// @Summary      List Product Categories
+// @Description  Lists the product categories of the establishment by name. Establishments start with the default categories Grocery, FruitAndVeg, Meat, Poultry, Seafood, Bakery, Liquor and GeneralStore. Only Admins can see them.
+// @Tags         Products
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.CategoryResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/categories [get]
+func (c *CategoryController) GetCategories(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view categories"})
+		return
+	}
+
+	categories, err := c.categoryService.GetCategories(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondCategoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, categories)
+}
+
+// CreateCategory godoc
+// @Summary      Create Product Category
+// @Description  Adds a product category to the establishment. Names are unique within the establishment, ignoring case. Only Admins can create them.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                   true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                      false "Branch to act on. Defaults to the main establishment"
+// @Param        category       body        request.CategoryRequest  true  "Category"
+// @Success      201  {object}  response.CategoryResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/categories [post]
+func (c *CategoryController) CreateCategory(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create categories"})
+		return
+	}
+
+	var req request.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	category, err := c.categoryService.CreateCategory(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondCategoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory godoc
+// @Summary      Rename Product Category
+// @Description  Renames a product category of the establishment. Its products stay in it. Only Admins can rename them.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                   true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                      false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                      true  "Category ID"
+// @Param        category       body        request.CategoryRequest  true  "Category"
+// @Success      200  {object}  response.CategoryResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/categories/{id} [put]
+func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can rename categories"})
+		return
+	}
+
+	categoryID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid category ID"})
+		return
+	}
+	var req request.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	category, err := c.categoryService.UpdateCategory(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(categoryID), req)
+	if err != nil {
+		respondCategoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory godoc
+// @Summary      Delete Product Category
+// @Description  Deletes a product category of the establishment. Categories with products can't be deleted until their products are moved to another category. Only Admins can delete them.
+// @Tags         Products
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int     true  "Category ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/categories/{id} [delete]
+func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete categories"})
+		return
+	}
+
+	categoryID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid category ID"})
+		return
+	}
+
+	if err := c.categoryService.DeleteCategory(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(categoryID)); err != nil {
+		respondCategoryError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// respondCategoryError writes the response of a failed category operation.
+func respondCategoryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCategoryName):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrCategoryNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrCategoryExists), errors.Is(err, service.ErrCategoryInUse):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
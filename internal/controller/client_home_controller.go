@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientHomeController serves the home screen of the client app.
+type ClientHomeController struct {
+	homeService service.ClientHomeService
+}
+
+// NewClientHomeController creates a new instance of ClientHomeController.
+func NewClientHomeController(homeService service.ClientHomeService) *ClientHomeController {
+	return &ClientHomeController{homeService: homeService}
+}
+
+// GetClientHome godoc
+// @Summary      Get Client Home
+// @Description  Gets everything the home screen of the app shows of the authenticated client's credit account in one call: its available credit, when the next payment is due and how much it is, whether it is overdue, the last 5 movements of its activity feed and how many notifications about it the client hasn't read. Long-term accounts are due their earliest installment not paid in full, and short-term ones their balance on the monthly due date.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization     header      string  true  "Bearer {token}"
+// @Param        establishment_id  query       int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Success      200  {object}  response.ClientHomeResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/home [get]
+func (c *ClientHomeController) GetClientHome(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can view their home"})
+		return
+	}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	home, err := c.homeService.GetClientHome(middleware.GetUserIDFromContext(ctx), establishmentID)
+	if err != nil {
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, home)
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientNoteController handles the notes admins write about the clients of their establishment.
+type ClientNoteController struct {
+	clientNoteService service.ClientNoteService
+}
+
+// NewClientNoteController creates a new instance of ClientNoteController.
+func NewClientNoteController(clientNoteService service.ClientNoteService) *ClientNoteController {
+	return &ClientNoteController{clientNoteService: clientNoteService}
+}
+
+// GetClientNotes godoc
+// @Summary      List Client Notes
+// @Description  Lists the notes the establishment has about a client of it, newest first, with their author. Clients never see them. Only Admins can see them.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int     true  "Client ID"
+// @Success      200  {array}   response.ClientNoteResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/notes [get]
+func (c *ClientNoteController) GetClientNotes(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view client notes"})
+		return
+	}
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+
+	notes, err := c.clientNoteService.GetClientNotes(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(clientID))
+	if err != nil {
+		respondClientNoteError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, notes)
+}
+
+// CreateClientNote godoc
+// @Summary      Create Client Note
+// @Description  Adds a note about a client of the establishment, e.g. that they pay late or prefer being reached by WhatsApp, signed by the authenticated admin. Only Admins can write notes.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                     true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                        false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int                        true  "Client ID"
+// @Param        note           body        request.ClientNoteRequest  true  "Note"
+// @Success      201  {object}  response.ClientNoteResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/notes [post]
+func (c *ClientNoteController) CreateClientNote(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can write client notes"})
+		return
+	}
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+	var req request.ClientNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	note, err := c.clientNoteService.CreateClientNote(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(clientID), req)
+	if err != nil {
+		respondClientNoteError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, note)
+}
+
+// UpdateClientNote godoc
+// @Summary      Update Client Note
+// @Description  Changes the text of a note about a client of the establishment. Only Admins can edit notes.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                     true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                        false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int                        true  "Client ID"
+// @Param        noteID         path        int                        true  "Note ID"
+// @Param        note           body        request.ClientNoteRequest  true  "Note"
+// @Success      200  {object}  response.ClientNoteResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/notes/{noteID} [put]
+func (c *ClientNoteController) UpdateClientNote(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can edit client notes"})
+		return
+	}
+	clientID, noteID, ok := clientNoteParams(ctx)
+	if !ok {
+		return
+	}
+	var req request.ClientNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	note, err := c.clientNoteService.UpdateClientNote(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), clientID, noteID, req)
+	if err != nil {
+		respondClientNoteError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, note)
+}
+
+// DeleteClientNote godoc
+// @Summary      Delete Client Note
+// @Description  Deletes a note about a client of the establishment. Only Admins can delete notes.
+// @Tags         Clients
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int     true  "Client ID"
+// @Param        noteID         path        int     true  "Note ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/notes/{noteID} [delete]
+func (c *ClientNoteController) DeleteClientNote(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete client notes"})
+		return
+	}
+	clientID, noteID, ok := clientNoteParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.clientNoteService.DeleteClientNote(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), clientID, noteID); err != nil {
+		respondClientNoteError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// clientNoteParams parses the client and note IDs of the path, responding 400 when one is invalid.
+func clientNoteParams(ctx *gin.Context) (uint, uint, bool) {
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return 0, 0, false
+	}
+	noteID, err := strconv.Atoi(ctx.Param("noteID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid note ID"})
+		return 0, 0, false
+	}
+	return uint(clientID), uint(noteID), true
+}
+
+// respondClientNoteError writes the response for an error of a client note operation.
+func respondClientNoteError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidClientNote):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrClientNoteNotFound), errors.Is(err, service.ErrClientNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientNotificationController lets clients read in the app the notifications they were sent.
+type ClientNotificationController struct {
+	notificationService service.ClientNotificationService
+}
+
+// NewClientNotificationController creates a new instance of ClientNotificationController.
+func NewClientNotificationController(notificationService service.ClientNotificationService) *ClientNotificationController {
+	return &ClientNotificationController{notificationService: notificationService}
+}
+
+// GetClientNotifications godoc
+// @Summary      List Client Notifications
+// @Description  Lists the notifications sent to the authenticated client, newest first: payment reminders, confirmations, overdue notices, payment links and codes and bank transfer receipts, whether or not they reached the client by email or text. With establishment_id, only those about the client's account at that establishment; impersonating admins only see those of their establishment. The maximum page size depends on the caller's role. The number of notifications is sent in the X-Total-Count header.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id query     int     false "Only the notifications of the client's account at this establishment"
+// @Param        unread         query       bool    false "Only the notifications not read yet"
+// @Param        page           query       int     false "Page number (starts at 1)"
+// @Param        page_size      query       int     false "Page size"
+// @Success      200  {array}   response.ClientNotificationResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/notifications [get]
+func (c *ClientNotificationController) GetClientNotifications(ctx *gin.Context) {
+	authUserRole := middleware.GetUserRoleFromContext(ctx)
+	if authUserRole != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can view their notifications"})
+		return
+	}
+
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+	unreadOnly, err := strconv.ParseBool(ctx.DefaultQuery("unread", "false"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid unread"})
+		return
+	}
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page"})
+		return
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page_size"})
+		return
+	}
+	pageSize, err := service.QueryLimitsForRole(authUserRole).ResolvePageSize(requestedPageSize)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	notifications, total, err := c.notificationService.GetClientNotifications(middleware.GetUserIDFromContext(ctx), establishmentID, unreadOnly, page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	versioning.SetPaginationTotal(ctx, page, pageSize, total)
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead godoc
+// @Summary      Mark Notification Read
+// @Description  Marks one of the authenticated client's notifications read. Notifications read before keep when they were read.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Notification ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/notifications/{id}/read [put]
+func (c *ClientNotificationController) MarkNotificationRead(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can read their notifications"})
+		return
+	}
+	notificationID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid notification ID"})
+		return
+	}
+
+	err = c.notificationService.MarkNotificationRead(middleware.GetUserIDFromContext(ctx), uint(notificationID))
+	if errors.Is(err, service.ErrNotificationNotFound) {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Notification marked read"})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Mark All Notifications Read
+// @Description  Marks every unread notification of the authenticated client read, and returns how many were.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  map[string]int
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/notifications/read [put]
+func (c *ClientNotificationController) MarkAllNotificationsRead(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can read their notifications"})
+		return
+	}
+
+	marked, err := c.notificationService.MarkAllNotificationsRead(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"marked": marked})
+}
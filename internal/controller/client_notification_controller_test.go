@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"net/http"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestClientNotificationsOfTheSelectedEstablishment(t *testing.T) {
+	client := caller{userID: fixture.ClientID, role: enums.CLIENT, scope: tenant.Scope{ClientID: fixture.ClientID}}
+	tests := []struct {
+		name                string
+		path                string
+		wantEstablishmentID uint // 0 when the query spans every establishment
+		want                int
+	}{
+		{"every establishment", "/clients/me/notifications", 0, http.StatusOK},
+		{"pinned to an establishment, as by an impersonation", "/clients/me/notifications?establishment_id=1", fixture.EstablishmentID, http.StatusOK},
+		{"invalid establishment", "/clients/me/notifications?establishment_id=abc", 0, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			notifications := mocks.NewMockClientNotificationRepository(ctrl)
+			if tt.want == http.StatusOK {
+				notifications.EXPECT().GetClientNotifications(fixture.ClientID, tt.wantEstablishmentID, false, 0, gomock.Any()).Return(nil, int64(0), nil)
+			}
+			c := NewClientNotificationController(service.NewClientNotificationService(notifications, util.NewFakeClock(fixture.Now)))
+			router := newTestRouter(client)
+			router.GET("/clients/me/notifications", c.GetClientNotifications)
+
+			if recorder := serve(t, router, http.MethodGet, tt.path, nil); recorder.Code != tt.want {
+				t.Errorf("GET %s = %d %s, want %d", tt.path, recorder.Code, recorder.Body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientSignupController handles clients registering themselves with an establishment's invite code,
+// and the admins reviewing those signups.
+type ClientSignupController struct {
+	clientSignupService service.ClientSignupService
+}
+
+// NewClientSignupController creates a new instance of ClientSignupController.
+func NewClientSignupController(clientSignupService service.ClientSignupService) *ClientSignupController {
+	return &ClientSignupController{clientSignupService: clientSignupService}
+}
+
+// GetInviteCode godoc
+// @Summary      Get Invite Code
+// @Description  Returns the code clients self-register with at the admin's establishment, or the selected branch, generating it the first time. Share it, or the QR code of its signup URL, with prospective clients. Only Admins can see it.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.InviteCodeResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/invite-code [get]
+func (c *ClientSignupController) GetInviteCode(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view the invite code"})
+		return
+	}
+
+	inviteCode, err := c.clientSignupService.GetInviteCode(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, inviteCode)
+}
+
+// GetInviteCodeQRCode godoc
+// @Summary      Get Invite Code QR Code
+// @Description  Renders the signup URL of the invite code of the admin's establishment, or the selected branch, as a QR code, for prospective clients to scan and sign up from their phone. Only Admins can get it.
+// @Tags         Establishments
+// @Produce      image/png
+// @Produce      image/svg+xml
+// @Param        Authorization  header      string  true   "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false  "Branch to act on. Defaults to the main establishment"
+// @Param        format         query       string  false  "png (default) or svg"
+// @Param        size           query       int     false  "Pixels on each side, 64 to 1024. Defaults to 256"
+// @Success      200  {file}    image/png  "QR code"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/invite-code/qr [get]
+func (c *ClientSignupController) GetInviteCodeQRCode(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view the invite code"})
+		return
+	}
+
+	inviteCode, err := c.clientSignupService.GetInviteCode(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	respondQRCode(ctx, requestBaseURL(ctx)+inviteCode.SignupPath)
+}
+
+// RotateInviteCode godoc
+// @Summary      Rotate Invite Code
+// @Description  Replaces the invite code of the admin's establishment, or the selected branch, with a new one. The previous code stops working right away; signups already made with it are kept. Only Admins can rotate it.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.InviteCodeResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/invite-code [post]
+func (c *ClientSignupController) RotateInviteCode(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can rotate the invite code"})
+		return
+	}
+
+	inviteCode, err := c.clientSignupService.RotateInviteCode(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, inviteCode)
+}
+
+// SignUpClient godoc
+// @Summary      Client Self-Signup
+// @Description  Registers a client with the establishment of an invite code. The signup waits for an admin of the establishment, who is notified, to approve it and set the terms of the credit account; the client gets an email when it is reviewed and can log in from then on. Clients that already have an account in another establishment sign up with the same email and DNI and keep their password.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        code    path      string                       true  "Invite code of the establishment"
+// @Param        signup  body      request.ClientSignupRequest  true  "Client data"
+// @Success      201  {object}  response.ClientSignupResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/{code}/client-signup [post]
+func (c *ClientSignupController) SignUpClient(ctx *gin.Context) {
+	var req request.ClientSignupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// The invite code takes the place of the establishment ID the other /establishments routes share
+	signup, err := c.clientSignupService.SignUpClient(ctx.Param("establishmentID"), req)
+	if err != nil {
+		respondClientSignupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, signup)
+}
+
+// GetClientSignups godoc
+// @Summary      List Client Signups
+// @Description  Lists the clients that signed up with the invite code of the establishment, oldest first. Only Admins can see them.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        status         query       string  false "Only list signups in this status: PENDING, APPROVED or REJECTED"
+// @Success      200  {array}   response.ClientSignupResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/client-signups [get]
+func (c *ClientSignupController) GetClientSignups(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view client signups"})
+		return
+	}
+
+	status := enums.SignupStatus(ctx.Query("status"))
+	switch status {
+	case "", enums.SignupPending, enums.SignupApproved, enums.SignupRejected:
+	default:
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid status"})
+		return
+	}
+
+	signups, err := c.clientSignupService.GetClientSignups(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), status)
+	if err != nil {
+		respondClientSignupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, signups)
+}
+
+// ApproveClientSignup godoc
+// @Summary      Approve Client Signup
+// @Description  Approves a pending client signup, creating the client and opening their credit account with the given terms and its credit agreement. The client is notified. Only Admins can approve signups.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                              true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                 false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                                 true  "Client signup ID"
+// @Param        terms          body        request.ApproveClientSignupRequest  true  "Terms of the credit account"
+// @Success      200  {object}  response.ClientSignupResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/client-signups/{id}/approve [post]
+func (c *ClientSignupController) ApproveClientSignup(ctx *gin.Context) {
+	signupID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client signup ID"})
+		return
+	}
+
+	var req request.ApproveClientSignupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can approve client signups"})
+		return
+	}
+
+	signup, err := c.clientSignupService.ApproveClientSignup(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(signupID), req)
+	if err != nil {
+		respondClientSignupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, signup)
+}
+
+// RejectClientSignup godoc
+// @Summary      Reject Client Signup
+// @Description  Rejects a pending client signup. The client is notified with the reason. Only Admins can reject signups.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                             true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                                true  "Client signup ID"
+// @Param        rejection      body        request.RejectClientSignupRequest  true  "Reason for the rejection"
+// @Success      200  {object}  response.ClientSignupResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/client-signups/{id}/reject [post]
+func (c *ClientSignupController) RejectClientSignup(ctx *gin.Context) {
+	signupID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client signup ID"})
+		return
+	}
+
+	var req request.RejectClientSignupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can reject client signups"})
+		return
+	}
+
+	signup, err := c.clientSignupService.RejectClientSignup(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(signupID), req.Reason)
+	if err != nil {
+		respondClientSignupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, signup)
+}
+
+// respondClientSignupError writes the response for an error of a client signup operation.
+func respondClientSignupError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInviteCodeNotFound), errors.Is(err, service.ErrClientSignupNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInterestRateRequired):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrClientSignupPending), errors.Is(err, service.ErrClientSignupDecided),
+		errors.Is(err, service.ErrCreditAccountExists), uniqueConflict(err):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientTagController handles the tags establishments label their clients with.
+type ClientTagController struct {
+	clientTagService service.ClientTagService
+}
+
+// NewClientTagController creates a new instance of ClientTagController.
+func NewClientTagController(clientTagService service.ClientTagService) *ClientTagController {
+	return &ClientTagController{clientTagService: clientTagService}
+}
+
+// GetClientTags godoc
+// @Summary      List Client Tags
+// @Description  Lists the client tags of the establishment by name. Only Admins can see them.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.ClientTagResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/client-tags [get]
+func (c *ClientTagController) GetClientTags(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view client tags"})
+		return
+	}
+
+	tags, err := c.clientTagService.GetClientTags(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondClientTagError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// CreateClientTag godoc
+// @Summary      Create Client Tag
+// @Description  Adds a client tag, e.g. VIP or wholesale, to the establishment. Names are unique within the establishment, ignoring case. Only Admins can create them.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                    true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                       false "Branch to act on. Defaults to the main establishment"
+// @Param        tag            body        request.ClientTagRequest  true  "Tag"
+// @Success      201  {object}  response.ClientTagResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/client-tags [post]
+func (c *ClientTagController) CreateClientTag(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create client tags"})
+		return
+	}
+
+	var req request.ClientTagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tag, err := c.clientTagService.CreateClientTag(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondClientTagError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, tag)
+}
+
+// UpdateClientTag godoc
+// @Summary      Rename Client Tag
+// @Description  Renames a client tag of the establishment; its clients keep it. Names are unique within the establishment, ignoring case. Only Admins can rename them.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                    true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                       false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                       true  "Client tag ID"
+// @Param        tag            body        request.ClientTagRequest  true  "Tag"
+// @Success      200  {object}  response.ClientTagResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/client-tags/{id} [put]
+func (c *ClientTagController) UpdateClientTag(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can rename client tags"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client tag ID"})
+		return
+	}
+	var req request.ClientTagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tag, err := c.clientTagService.UpdateClientTag(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(id), req)
+	if err != nil {
+		respondClientTagError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tag)
+}
+
+// DeleteClientTag godoc
+// @Summary      Delete Client Tag
+// @Description  Deletes a client tag of the establishment, untagging the clients that had it. Only Admins can delete them.
+// @Tags         Clients
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int     true  "Client tag ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/client-tags/{id} [delete]
+func (c *ClientTagController) DeleteClientTag(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete client tags"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client tag ID"})
+		return
+	}
+
+	if err := c.clientTagService.DeleteClientTag(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(id)); err != nil {
+		respondClientTagError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// SetClientTags godoc
+// @Summary      Tag Client
+// @Description  Sets the tags a client of the establishment has, replacing those they had; an empty list untags them. The tags must be tags of the establishment. Only Admins can tag clients.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                        true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                           false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int                           true  "Client ID"
+// @Param        tags           body        request.SetClientTagsRequest  true  "Tags of the client"
+// @Success      200  {array}   response.ClientTagResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/tags [put]
+func (c *ClientTagController) SetClientTags(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can tag clients"})
+		return
+	}
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+	var req request.SetClientTagsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tags, err := c.clientTagService.SetClientTags(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(clientID), req)
+	if err != nil {
+		respondClientTagError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// respondClientTagError writes the response for an error of a client tag operation.
+func respondClientTagError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidClientTagName):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrClientTagNotFound), errors.Is(err, service.ErrClientNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrClientTagExists):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CollectionController handles the collections worklist of overdue clients and its contact log.
+type CollectionController struct {
+	collectionService service.CollectionService
+}
+
+// NewCollectionController creates a new instance of CollectionController.
+func NewCollectionController(collectionService service.CollectionService) *CollectionController {
+	return &CollectionController{collectionService: collectionService}
+}
+
+// GetCollectionsWorklist godoc
+// @Summary      Get Collections Worklist
+// @Description  Lists the overdue clients of the establishment in the order to collect from them: the most overdue first, then those owing the most, then those who broke the most payment promises. Each comes with their contact info and the date and outcome of their last collection contact. Written-off accounts are left out. Only Admins can see it.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.CollectionWorklistItem
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/collections [get]
+func (c *CollectionController) GetCollectionsWorklist(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can see the collections worklist"})
+		return
+	}
+
+	worklist, err := c.collectionService.GetCollectionsWorklist(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, worklist)
+}
+
+// LogCollectionContact godoc
+// @Summary      Log Collection Contact
+// @Description  Records a call, visit or message to a client of the establishment to collect their debt, and its outcome: REACHED, NO_ANSWER, PROMISED_TO_PAY, REFUSED or WRONG_CONTACT. It is logged as contacted now unless contacted_at says when it happened. Only Admins can log contacts.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                               true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                  false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int                                  true  "Client ID"
+// @Param        contact        body        request.LogCollectionContactRequest  true  "Type, outcome and notes of the contact"
+// @Success      201  {object}  response.CollectionContactResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /collections/{clientID}/contact-log [post]
+func (c *CollectionController) LogCollectionContact(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can log collection contacts"})
+		return
+	}
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+	var req request.LogCollectionContactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	contact, err := c.collectionService.LogCollectionContact(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(clientID), req)
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, contact)
+}
+
+// respondCollectionError writes the response for an error of a collections operation.
+func respondCollectionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCreditAccountNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInvalidContactDate):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
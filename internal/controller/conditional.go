@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pollingCacheControl lets clients keep the responses they poll, but only after revalidating them
+// with If-None-Match or If-Modified-Since, as they are private to the user and change any time.
+const pollingCacheControl = "private, no-cache"
+
+// resourceVersion identifies a version of a resource shown in a response: its ID and UpdatedAt,
+// and whatever else it shows that can change without touching UpdatedAt (e.g. a category name).
+func resourceVersion(id uint, updatedAt time.Time, extra ...interface{}) string {
+	version := fmt.Sprintf("%d@%d", id, updatedAt.UnixNano())
+	for _, e := range extra {
+		version += fmt.Sprintf("/%v", e)
+	}
+	return version
+}
+
+// notModified sets the ETag, Last-Modified and Cache-Control headers of a response and answers
+// 304 Not Modified, returning true, when the request's If-None-Match or If-Modified-Since show the
+// client already has it. The weak ETag hashes the versions of the resources in the response (see
+// resourceVersion) with the API version, the request URI and the time zone timestamps are shown
+// in. lastModified is when the response last changed; lists pass the zero time and get no
+// Last-Modified, as removing an item doesn't change it. As in RFC 9110, If-Modified-Since is
+// ignored when If-None-Match is sent.
+func notModified(ctx *gin.Context, lastModified time.Time, versions ...string) bool {
+	location := ""
+	if loc := middleware.GetLocationFromContext(ctx); loc != nil {
+		location = loc.String()
+	}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s\n%d\n", versioning.FromContext(ctx), ctx.Request.URL.RequestURI(), location, len(versions))
+	for _, version := range versions {
+		fmt.Fprintln(hash, version)
+	}
+	etag := `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", pollingCacheControl)
+	if !lastModified.IsZero() {
+		ctx.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if match := ctx.GetHeader("If-None-Match"); match != "" {
+		if !etagMatches(match, etag) {
+			return false
+		}
+	} else if since, err := http.ParseTime(ctx.GetHeader("If-Modified-Since")); err != nil || lastModified.IsZero() || lastModified.Truncate(time.Second).After(since) {
+		return false
+	}
+	ctx.Status(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, comparing them weakly.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// requestVersion reads the version an update is based on from the If-Match header into version,
+// unless the body already sent it. If-Match carries the version as an entity tag, e.g. "3". It
+// answers the request and returns false when If-Match isn't a version.
+func requestVersion(ctx *gin.Context, version **uint) bool {
+	match := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if *version != nil || match == "" {
+		return true
+	}
+	n, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(match, "W/"), `"`), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid If-Match, send the version of the record, e.g. \"3\""})
+		return false
+	}
+	v := uint(n)
+	*version = &v
+	return true
+}
+
+// respondVersionError answers the errors of an update based on a version: 428 Precondition Required
+// when it didn't send one, and 409 Conflict with the record as it is now, read by current, when the
+// record moved past it. It returns false for other errors, which are left to the caller.
+func respondVersionError(ctx *gin.Context, err error, current func() (interface{}, error)) bool {
+	switch {
+	case errors.Is(err, service.ErrVersionRequired):
+		ctx.JSON(http.StatusPreconditionRequired, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrVersionConflict):
+		record, currentErr := current()
+		if currentErr != nil {
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: currentErr.Error()})
+			return true
+		}
+		ctx.JSON(http.StatusConflict, response.VersionConflictResponse{Error: err.Error(), Current: record})
+	default:
+		return false
+	}
+	return true
+}
+
+// latestChange returns the latest of times.
+func latestChange(times ...time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// creditAccountVersions returns the versions of a credit account response, which shows its client
+// and establishment too, and when the latest of them changed.
+func creditAccountVersions(account *response.CreditAccountResponse) ([]string, time.Time) {
+	versions := []string{resourceVersion(account.ID, account.UpdatedAt)}
+	lastModified := account.UpdatedAt
+	users := []*response.UserResponse{account.Client}
+	if account.Establishment != nil {
+		versions = append(versions, resourceVersion(account.Establishment.ID, account.Establishment.UpdatedAt))
+		lastModified = latestChange(lastModified, account.Establishment.UpdatedAt)
+		users = append(users, account.Establishment.Admin)
+	}
+	for _, user := range users {
+		if user != nil {
+			versions = append(versions, resourceVersion(user.ID, user.UpdatedAt))
+			lastModified = latestChange(lastModified, user.UpdatedAt)
+		}
+	}
+	return versions, lastModified
+}
+
+// installmentVersions returns the versions of a list of installments.
+func installmentVersions(installments []response.InstallmentResponse) []string {
+	versions := make([]string, 0, len(installments))
+	for _, installment := range installments {
+		versions = append(versions, resourceVersion(installment.ID, installment.UpdatedAt))
+	}
+	return versions
+}
+
+// productVersion returns the version of a product response, which shows the name of its category.
+func productVersion(product *response.ProductResponse) string {
+	return resourceVersion(product.ID, product.UpdatedAt, product.Category, product.Establishment.UpdatedAt.UnixNano())
+}
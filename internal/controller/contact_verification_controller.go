@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContactVerificationController lets users verify their own email and phone.
+type ContactVerificationController struct {
+	verificationService service.ContactVerificationService
+}
+
+// NewContactVerificationController creates a new instance of ContactVerificationController.
+func NewContactVerificationController(verificationService service.ContactVerificationService) *ContactVerificationController {
+	return &ContactVerificationController{verificationService: verificationService}
+}
+
+// GetContactVerification godoc
+// @Summary      Get Contact Verification
+// @Description  Tells which of the email and phone of the authenticated user are verified. Codes are sent to them when they are registered or changed; statements are only emailed to a verified email.
+// @Tags         Users
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.ContactVerificationResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/verification [get]
+func (c *ContactVerificationController) GetContactVerification(ctx *gin.Context) {
+	verification, err := c.verificationService.GetContactVerification(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, verification)
+}
+
+// ResendVerificationCode godoc
+// @Summary      Resend Verification Code
+// @Description  Sends a new code to the email or, by SMS, the phone of the authenticated user, replacing the previous one. Codes expire after 15 minutes or 5 wrong attempts, and a new one can be asked for once a minute.
+// @Tags         Users
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        channel        path        string  true  "Channel to verify" Enums(email, phone)
+// @Success      200  {object}  response.ContactVerificationResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      429  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/verification/{channel}/resend [post]
+func (c *ContactVerificationController) ResendVerificationCode(ctx *gin.Context) {
+	verification, err := c.verificationService.SendVerificationCode(middleware.GetUserIDFromContext(ctx), enums.ContactChannel(ctx.Param("channel")), middleware.GetLanguageFromContext(ctx))
+	if err != nil {
+		ctx.JSON(verificationErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, verification)
+}
+
+// VerifyContact godoc
+// @Summary      Verify Contact
+// @Description  Verifies the email or phone of the authenticated user with the code last sent to it.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                        true  "Bearer {token}"
+// @Param        channel        path        string                        true  "Channel to verify" Enums(email, phone)
+// @Param        code           body        request.VerifyContactRequest  true  "Code sent to the channel"
+// @Success      200  {object}  response.ContactVerificationResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      410  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/verification/{channel}/verify [post]
+func (c *ContactVerificationController) VerifyContact(ctx *gin.Context) {
+	var req request.VerifyContactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	verification, err := c.verificationService.VerifyContact(middleware.GetUserIDFromContext(ctx), enums.ContactChannel(ctx.Param("channel")), req.Code)
+	if err != nil {
+		ctx.JSON(verificationErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, verification)
+}
+
+// verificationErrorStatus maps the errors of contact verification to their HTTP status.
+func verificationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidContactChannel), errors.Is(err, service.ErrInvalidVerificationCode):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrContactAlreadyVerified), errors.Is(err, service.ErrNoPhone):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrVerificationCodeExpired):
+		return http.StatusGone
+	case errors.Is(err, service.ErrVerificationCodeTooSoon):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
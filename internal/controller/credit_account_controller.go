@@ -29,16 +29,20 @@ func NewCreditAccountController(creditAccountService service.CreditAccountServic
 
 // CreateCreditAccount godoc
 // @Summary      Create Credit Account
-// @Description  Creates a new credit account for a client.
+// @Description  Creates a new credit account for a client. With credit_template_id, the terms the request leaves out are taken from that credit template of the establishment, and the account remembers it.
 // @Tags         Credit Accounts
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        creditAccount  body      request.CreateCreditAccountRequest  true  "Credit account data"
 // @Success      201  {object}  response.CreditAccountResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /credit-accounts [post]
 func (c *CreditAccountController) CreateCreditAccount(ctx *gin.Context) {
@@ -56,7 +60,7 @@ func (c *CreditAccountController) CreateCreditAccount(ctx *gin.Context) {
 
 	userId := middleware.GetUserIDFromContext(ctx)
 
-	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId)
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId, middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
 		return
@@ -64,6 +68,26 @@ func (c *CreditAccountController) CreateCreditAccount(ctx *gin.Context) {
 
 	creditAccount, err := c.creditAccountService.CreateCreditAccount(req, establishment.ID)
 	if err != nil {
+		if errors.Is(err, service.ErrCreditAccountExists) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrInterestRateRequired) {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrCreditTemplateNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrEstablishmentInactive) {
+			ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrUpgradeRequired) {
+			ctx.JSON(http.StatusPaymentRequired, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -73,13 +97,19 @@ func (c *CreditAccountController) CreateCreditAccount(ctx *gin.Context) {
 
 // GetCreditAccountByID godoc
 // @Summary      Get Credit Account by ID
-// @Description  Gets a credit account by its ID.
+// @Description  Gets a credit account by its ID. Credit accounts of other establishments, or of other clients, are not found. Supports conditional requests: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the account is unchanged.
 // @Tags         Credit Accounts
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        id   path      int  true  "Credit Account ID"
+// @Param        If-None-Match  header      string  false "ETag of a cached response, answered with 304 Not Modified while it is current"
+// @Param        If-Modified-Since  header  string  false "Last-Modified of a cached response, answered with 304 Not Modified while it is current"
 // @Success      200  {object}  response.CreditAccountResponse
+// @Success      304  "The cached response is current"
+// @Header       200,304  {string}  ETag           "Version of the response, for If-None-Match"
+// @Header       200,304  {string}  Last-Modified  "When the response last changed, for If-Modified-Since"
+// @Header       200,304  {string}  Cache-Control  "private, no-cache: revalidate before reusing"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
@@ -101,16 +131,22 @@ func (c *CreditAccountController) GetCreditAccountByID(ctx *gin.Context) {
 		return
 	}
 
+	setCreditAccountLinks(ctx, creditAccount.ID)
+	if versions, lastModified := creditAccountVersions(creditAccount); notModified(ctx, lastModified, versions...) {
+		return
+	}
 	ctx.JSON(http.StatusOK, creditAccount)
 }
 
 // GetCreditAccountByClientID godoc
 // @Summary      Get Credit Account by Client ID
-// @Description  Retrieves a credit account associated with a specific client.
+// @Description  Retrieves a client's credit account. Admins get the account in their establishment; clients can select the establishment when they hold accounts in several.
 // @Tags         Credit Accounts
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        clientID path int true "Client ID"
+// @Param        establishment_id  query     int     false "Establishment of the credit account (clients only)"
 // @Success      200 {object}  response.CreditAccountResponse
 // @Failure      400 {object}  response.ErrorResponse
 // @Failure      404 {object}  response.ErrorResponse
@@ -123,41 +159,59 @@ func (c *CreditAccountController) GetCreditAccountByClientID(ctx *gin.Context) {
 		return
 	}
 
-	// Authorization: Admins can access any client's credit account, Clients can only access their own
+	// Authorization: Admins get the client's account in their own establishment, Clients can only
+	// access their own accounts and pick the establishment with establishment_id.
 	authUserID := middleware.GetUserIDFromContext(ctx)
 	authUserRole := middleware.GetUserRoleFromContext(ctx)
-	if authUserRole != enums.ADMIN && authUserID != uint(clientID) {
+	var establishmentID uint
+	if authUserRole == enums.ADMIN {
+		establishment, err := c.establishmentService.GetEstablishmentByAdminID(authUserID, middleware.GetBranchIDFromContext(ctx))
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		establishmentID = establishment.ID
+	} else if authUserID != uint(clientID) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Not authorized to access this credit account"})
 		return
+	} else {
+		var ok bool
+		if establishmentID, ok = establishmentSelector(ctx); !ok {
+			return
+		}
 	}
 
-	creditAccount, err := c.creditAccountService.GetCreditAccountByClientID(uint(clientID))
+	creditAccount, err := c.creditAccountService.GetCreditAccountByClientID(uint(clientID), establishmentID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, service.ErrCreditAccountNotFound) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Credit account not found for this client"})
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	setCreditAccountLinks(ctx, creditAccount.ID)
 	ctx.JSON(http.StatusOK, creditAccount)
 }
 
 // UpdateCreditAccount godoc
 // @Summary      Update Credit Account
-// @Description  Updates a credit account by its ID. Only Admins can update credit accounts.
+// @Description  Updates a credit account by its ID. Only Admins can update credit accounts. Send the version of the account last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the account as it is now.
 // @Tags         Credit Accounts
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        id     path      int                      true  "Credit Account ID"
 // @Param        creditAccount  body      request.UpdateCreditAccountRequest  true  "Updated credit account data"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
 // @Success      200     {object}  response.CreditAccountResponse
 // @Failure      400     {object}  response.ErrorResponse
 // @Failure      401     {object}  response.ErrorResponse
 // @Failure      403     {object}  response.ErrorResponse
 // @Failure      404     {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
 // @Failure      500     {object}  response.ErrorResponse
 // @Router       /credit-accounts/{id} [put]
 func (c *CreditAccountController) UpdateCreditAccount(ctx *gin.Context) {
@@ -172,6 +226,9 @@ func (c *CreditAccountController) UpdateCreditAccount(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
 
 	// Ensure the authenticated user is an ADMIN
 	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
@@ -181,6 +238,9 @@ func (c *CreditAccountController) UpdateCreditAccount(ctx *gin.Context) {
 
 	creditAccount, err := c.creditAccountService.UpdateCreditAccount(uint(id), req)
 	if err != nil {
+		if respondVersionError(ctx, err, func() (interface{}, error) { return c.creditAccountService.GetCreditAccountByID(uint(id)) }) {
+			return
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Credit account not found"})
 			return
@@ -192,6 +252,224 @@ func (c *CreditAccountController) UpdateCreditAccount(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, creditAccount)
 }
 
+// BlockCreditAccount godoc
+// @Summary      Block Credit Account
+// @Description  Blocks a credit account so it can't be used for purchases, recording the reason in its block history. Only Admins of the account's establishment can block it.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                             true  "Bearer {token}"
+// @Param        id             path        int                                true  "Credit Account ID"
+// @Param        block          body        request.BlockCreditAccountRequest  true  "Reason for blocking"
+// @Success      200  {object}  response.CreditAccountResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/block [post]
+func (c *CreditAccountController) BlockCreditAccount(ctx *gin.Context) {
+	c.changeBlock(ctx, c.creditAccountService.BlockCreditAccount)
+}
+
+// UnblockCreditAccount godoc
+// @Summary      Unblock Credit Account
+// @Description  Unblocks a credit account, recording the reason in its block history. Written-off accounts can't be unblocked. Only Admins of the account's establishment can unblock it.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                             true  "Bearer {token}"
+// @Param        id             path        int                                true  "Credit Account ID"
+// @Param        unblock        body        request.BlockCreditAccountRequest  true  "Reason for unblocking"
+// @Success      200  {object}  response.CreditAccountResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/unblock [post]
+func (c *CreditAccountController) UnblockCreditAccount(ctx *gin.Context) {
+	c.changeBlock(ctx, c.creditAccountService.UnblockCreditAccount)
+}
+
+func (c *CreditAccountController) changeBlock(ctx *gin.Context, change func(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error)) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can block or unblock credit accounts"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	var req request.BlockCreditAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	creditAccount, err := change(middleware.GetUserIDFromContext(ctx), uint(id), req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCreditAccountNotFound):
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCreditAccountAlreadyBlocked), errors.Is(err, service.ErrCreditAccountNotBlocked), errors.Is(err, service.ErrCreditAccountWrittenOff):
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, creditAccount)
+}
+
+// WriteOffCreditAccount godoc
+// @Summary      Write Off Credit Account
+// @Description  Writes off the balance of a long-delinquent credit account as bad debt. The account is frozen (blocked, and it can't be unblocked), its balance moves to written_off_balance, and it is left out of the receivables reports and shown in the bad-debt report instead. Later payments on the account recover the written-off amount. Only Admins of the account's establishment can write it off.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                true  "Bearer {token}"
+// @Param        id             path        int                                   true  "Credit Account ID"
+// @Param        writeOff       body        request.WriteOffCreditAccountRequest  true  "Reason for the write-off"
+// @Success      200  {object}  response.CreditAccountResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/write-off [post]
+func (c *CreditAccountController) WriteOffCreditAccount(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can write off credit accounts"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	var req request.WriteOffCreditAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	creditAccount, err := c.creditAccountService.WriteOffCreditAccount(middleware.GetUserIDFromContext(ctx), uint(id), req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCreditAccountNotFound):
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCreditAccountWrittenOff), errors.Is(err, service.ErrNothingToPayOff):
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, creditAccount)
+}
+
+// CloseCreditAccount godoc
+// @Summary      Close Credit Account
+// @Description  Closes a credit account when the client leaves. A closed account takes no more purchases, while its transactions, installments and statements stay available and payments are still accepted. Accounts that still owe answer 409 with a payoff_quote; sending its payoff_amount back pays the account off and closes it in one request. Written-off debt has to be recovered first. Clients close their own accounts; Admins close the accounts of their establishment. Only an Admin can reopen it.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                             true  "Bearer {token}"
+// @Param        id             path        int                                true  "Credit Account ID"
+// @Param        close          body        request.CloseCreditAccountRequest  true  "Optional reason, and the quoted payoff amount of an account that still owes"
+// @Success      200  {object}  response.CreditAccountResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.AccountBalanceResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/close [post]
+func (c *CreditAccountController) CloseCreditAccount(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	var req request.CloseCreditAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	creditAccount, err := c.creditAccountService.CloseCreditAccount(middleware.GetUserIDFromContext(ctx), middleware.GetUserRoleFromContext(ctx), uint(id), req)
+	if err != nil {
+		var balanceErr *service.AccountBalanceError
+		switch {
+		case errors.As(err, &balanceErr):
+			ctx.JSON(http.StatusConflict, response.AccountBalanceResponse{Error: err.Error(), PayoffQuote: balanceErr.Quote})
+		case errors.Is(err, service.ErrCreditAccountNotFound):
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCreditAccountAlreadyClosed), errors.Is(err, service.ErrPayoffQuoteChanged):
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, creditAccount)
+}
+
+// ReopenCreditAccount godoc
+// @Summary      Reopen Credit Account
+// @Description  Reopens a closed credit account for purchases, with the limit and rates it had. Only Admins of the account's establishment can reopen it.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                              true  "Bearer {token}"
+// @Param        id             path        int                                 true  "Credit Account ID"
+// @Param        reopen         body        request.ReopenCreditAccountRequest  true  "Reason for reopening"
+// @Success      200  {object}  response.CreditAccountResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/reopen [post]
+func (c *CreditAccountController) ReopenCreditAccount(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can reopen credit accounts"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	var req request.ReopenCreditAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	creditAccount, err := c.creditAccountService.ReopenCreditAccount(middleware.GetUserIDFromContext(ctx), uint(id), req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCreditAccountNotFound):
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCreditAccountNotClosed):
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrUpgradeRequired):
+			ctx.JSON(http.StatusPaymentRequired, response.ErrorResponse{Error: err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, creditAccount)
+}
+
 // DeleteCreditAccount godoc
 // @Summary      Delete Credit Account
 // @Description  Deletes a credit account by its ID. Only Admins can delete credit accounts.
@@ -233,15 +511,18 @@ func (c *CreditAccountController) DeleteCreditAccount(ctx *gin.Context) {
 
 // GetCreditAccountsByEstablishmentID godoc
 // @Summary      Get Credit Accounts by Establishment ID
-// @Description  Retrieves all credit accounts associated with an establishment. Only Admins can access this endpoint.
+// @Description  Retrieves all credit accounts associated with an establishment. Only admins of the establishment can access this endpoint; other establishments are not found.
 // @Tags         Credit Accounts
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        establishmentID path int true "Establishment ID"
+// @Param        fields         query       string  false "Comma-separated top-level fields to return of each item, e.g. id,client_id,current_balance. Defaults to all"
+// @Param        include        query       string  false "Nested objects to embed in each item: client, establishment, both (default) or none with an empty value"
 // @Success      200 {array} response.CreditAccountResponse
 // @Failure      400 {object} response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500 {object} response.ErrorResponse
 // @Router       /establishments/{establishmentID}/credit-accounts [get]
 func (c *CreditAccountController) GetCreditAccountsByEstablishmentID(ctx *gin.Context) {
@@ -256,14 +537,22 @@ func (c *CreditAccountController) GetCreditAccountsByEstablishmentID(ctx *gin.Co
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can access credit accounts"})
 		return
 	}
+	if !authorizeEstablishment(ctx, uint(establishmentID)) {
+		return
+	}
+
+	fields, ok := parseResponseFields(ctx, "client", "establishment")
+	if !ok {
+		return
+	}
 
-	creditAccounts, err := c.creditAccountService.GetCreditAccountsByEstablishmentID(uint(establishmentID))
+	creditAccounts, err := c.creditAccountService.GetCreditAccountsByEstablishmentID(uint(establishmentID), creditAccountIncludes(fields))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, creditAccounts)
+	fields.respond(ctx, http.StatusOK, creditAccounts)
 }
 
 // ApplyInterestToAccount godoc
@@ -272,16 +561,16 @@ func (c *CreditAccountController) GetCreditAccountsByEstablishmentID(ctx *gin.Co
 // @Tags         Credit Accounts
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        creditAccountID path int true "Credit Account ID"
+// @Param        id             path        int     true  "Credit Account ID"
 // @Success      200  {object}  map[string]string
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
-// @Router       /credit-accounts/{creditAccountID}/apply-interest [post]
+// @Router       /credit-accounts/{id}/apply-interest [post]
 func (c *CreditAccountController) ApplyInterestToAccount(ctx *gin.Context) {
-	creditAccountID, err := strconv.Atoi(ctx.Param("creditAccountID"))
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
 		return
@@ -307,20 +596,21 @@ func (c *CreditAccountController) ApplyInterestToAccount(ctx *gin.Context) {
 
 // ApplyLateFeeToAccount godoc
 // @Summary      Apply Late Fee to Account
-// @Description  Applies a late fee to a specific credit account. Only Admins can apply late fees.
+// @Description  Applies a late fee to a specific credit account. Only Admins can apply late fees. The fee is charged on the overdue amount per the establishment's late fee mode: its percentage once per billing cycle (FLAT), its percentage for every day overdue (DAILY) or a fixed amount once per cycle (FIXED), up to the late fee cap if set. A cycle already charged its fee fails with 409 Conflict; in the DAILY mode, applying it again charges the days since. Late fees are suspended while the client has an active payment promise whose date hasn't passed. If payments or purchases keep changing the balance while the fee is applied, it fails with 409 Conflict too.
 // @Tags         Credit Accounts
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        creditAccountID path int true "Credit Account ID"
+// @Param        id             path        int     true  "Credit Account ID"
 // @Success      200  {object}  map[string]string
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
-// @Router       /credit-accounts/{creditAccountID}/apply-late-fee [post]
+// @Router       /credit-accounts/{id}/apply-late-fee [post]
 func (c *CreditAccountController) ApplyLateFeeToAccount(ctx *gin.Context) {
-	creditAccountID, err := strconv.Atoi(ctx.Param("creditAccountID"))
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
 		return
@@ -337,6 +627,11 @@ func (c *CreditAccountController) ApplyLateFeeToAccount(ctx *gin.Context) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Credit account not found"})
 			return
 		}
+		if errors.Is(err, service.ErrLateFeesSuspended) || errors.Is(err, service.ErrLateFeeApplied) ||
+			errors.Is(err, service.ErrBalanceChanged) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -350,6 +645,9 @@ func (c *CreditAccountController) ApplyLateFeeToAccount(ctx *gin.Context) {
 // @Tags         Credit Accounts
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        fields         query       string  false "Comma-separated top-level fields to return of each item, e.g. id,client_id,current_balance. Defaults to all"
+// @Param        include        query       string  false "Nested objects to embed in each item: client, establishment, both (default) or none with an empty value"
 // @Success      200  {array}   response.CreditAccountResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
@@ -362,40 +660,45 @@ func (c *CreditAccountController) GetOverdueCreditAccounts(ctx *gin.Context) {
 		return
 	}
 
+	fields, ok := parseResponseFields(ctx, "client", "establishment")
+	if !ok {
+		return
+	}
 	userId := middleware.GetUserIDFromContext(ctx)
 
-	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId)
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId, middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	overdueAccounts, err := c.creditAccountService.GetOverdueCreditAccounts(establishment.ID)
+	overdueAccounts, err := c.creditAccountService.GetOverdueCreditAccounts(establishment.ID, creditAccountIncludes(fields))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, overdueAccounts)
+	fields.respond(ctx, http.StatusOK, overdueAccounts)
 }
 
 // ProcessPurchase godoc
 // @Summary      Process Purchase
-// @Description  Processes a purchase on a client's credit account.
+// @Description  Processes a purchase on a client's credit account. Purchases above the PIN threshold of the establishment need the client's purchase PIN in pin: a missing, wrong or unset PIN is a 403, and five wrong PINs in a row lock it for 30 minutes (429) unless the client resets it.
 // @Tags         Credit Accounts
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        creditAccountID path int true "Credit Account ID"
+// @Param        id             path        int     true  "Credit Account ID"
 // @Param        purchase        body      request.CreateTransactionRequest  true  "Purchase details"
 // @Success      201  {object}  map[string]string
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
+// @Failure      429  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
-// @Router       /credit-accounts/{creditAccountID}/purchases [post]
+// @Router       /credit-accounts/{id}/purchases [post]
 func (c *CreditAccountController) ProcessPurchase(ctx *gin.Context) {
-	creditAccountID, err := strconv.Atoi(ctx.Param("creditAccountID"))
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
 		return
@@ -421,8 +724,19 @@ func (c *CreditAccountController) ProcessPurchase(ctx *gin.Context) {
 
 	// Additional validation if needed...
 
-	err = c.creditAccountService.ProcessPurchase(uint(creditAccountID), req.Amount, req.Description)
+	err = c.creditAccountService.ProcessPurchase(uint(creditAccountID), req.Amount, req.Description, req.Pin)
 	if err != nil {
+		if errors.Is(err, service.ErrCreditAccountBlocked) || errors.Is(err, service.ErrHighRiskPurchaseLimit) ||
+			errors.Is(err, service.ErrAgreementNotAccepted) || errors.Is(err, service.ErrCreditAccountClosed) ||
+			errors.Is(err, service.ErrEstablishmentInactive) || errors.Is(err, service.ErrPurchasePinRequired) ||
+			errors.Is(err, service.ErrPurchasePinNotSet) || errors.Is(err, service.ErrInvalidPurchasePin) {
+			ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrPurchasePinLocked) {
+			ctx.JSON(http.StatusTooManyRequests, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		// Handle different error types appropriately (e.g., validation errors, insufficient credit, etc.)
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
@@ -433,21 +747,21 @@ func (c *CreditAccountController) ProcessPurchase(ctx *gin.Context) {
 
 // ProcessPayment godoc
 // @Summary      Process Payment
-// @Description  Processes a payment towards a client's credit account.
+// @Description  Processes a payment towards a client's credit account. CASH payments are collected in the open till session of the establishment, if any.
 // @Tags         Credit Accounts
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        creditAccountID path int true "Credit Account ID"
+// @Param        id             path        int     true  "Credit Account ID"
 // @Param        payment        body      request.CreateTransactionRequest  true  "Payment details"
 // @Success      201  {object}  map[string]string
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
-// @Router       /credit-accounts/{creditAccountID}/payments [post]
+// @Router       /credit-accounts/{id}/payments [post]
 func (c *CreditAccountController) ProcessPayment(ctx *gin.Context) {
-	creditAccountID, err := strconv.Atoi(ctx.Param("creditAccountID"))
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
 		return
@@ -473,7 +787,7 @@ func (c *CreditAccountController) ProcessPayment(ctx *gin.Context) {
 
 	// Additional validation if needed...
 
-	err = c.creditAccountService.ProcessPayment(uint(creditAccountID), req.Amount, req.Description)
+	err = c.creditAccountService.ProcessPayment(uint(creditAccountID), req.Amount, req.PaymentMethod, req.Description)
 	if err != nil {
 		// Handle different error types appropriately (e.g., validation errors, insufficient funds, etc.)
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
@@ -489,6 +803,8 @@ func (c *CreditAccountController) ProcessPayment(ctx *gin.Context) {
 // @Tags         Credit Accounts
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        fields         query       string  false "Comma-separated top-level fields to return of each item, e.g. client_id,client_name,current_balance. Defaults to all"
 // @Success      200  {array}  response.AdminDebtSummary
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
@@ -501,9 +817,10 @@ func (c *CreditAccountController) GetAdminDebtSummary(ctx *gin.Context) {
 		return
 	}
 
+	fields, _ := parseResponseFields(ctx)
 	userId := middleware.GetUserIDFromContext(ctx)
 
-	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId)
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId, middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
 		return
@@ -516,23 +833,27 @@ func (c *CreditAccountController) GetAdminDebtSummary(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, summary)
+	fields.respond(ctx, http.StatusOK, summary)
 }
 
 // UpdateCreditAccountByClientID godoc
 // @Summary      Update Credit Account by Client ID
-// @Description  Updates an existing credit account by client ID. Only Admins can update credit accounts.
+// @Description  Updates an existing credit account by client ID. Only Admins can update credit accounts. Send the version of the account last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the account as it is now.
 // @Tags         Credit Accounts
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string                        true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        clientID       path      int                        true  "Client User ID"
 // @Param        creditAccount  body      request.UpdateCreditAccountRequest  true  "Updated credit account data"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
 // @Success      200  {object}  response.CreditAccountResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/{clientID}/credit-account [put]
 func (c *CreditAccountController) UpdateCreditAccountByClientID(ctx *gin.Context) {
@@ -547,6 +868,9 @@ func (c *CreditAccountController) UpdateCreditAccountByClientID(ctx *gin.Context
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
 
 	// Ensure the authenticated user is an ADMIN
 	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
@@ -554,9 +878,22 @@ func (c *CreditAccountController) UpdateCreditAccountByClientID(ctx *gin.Context
 		return
 	}
 
-	creditAccountResponse, err := c.creditAccountService.UpdateCreditAccountByClientID(uint(clientID), req)
+	// Admins can only update the client's account in their own establishment
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	creditAccountResponse, err := c.creditAccountService.UpdateCreditAccountByClientID(uint(clientID), establishment.ID, req)
+	if err != nil {
+		current := func() (interface{}, error) {
+			return c.creditAccountService.GetCreditAccountByClientID(uint(clientID), establishment.ID)
+		}
+		if respondVersionError(ctx, err, current) {
+			return
+		}
+		if errors.Is(err, service.ErrCreditAccountNotFound) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Credit account not found for this client"})
 			return
 		}
@@ -566,3 +903,11 @@ func (c *CreditAccountController) UpdateCreditAccountByClientID(ctx *gin.Context
 
 	ctx.JSON(http.StatusOK, creditAccountResponse)
 }
+
+// creditAccountIncludes returns the nested objects credit account items embed for the fields of a request.
+func creditAccountIncludes(fields responseFields) service.CreditAccountIncludes {
+	return service.CreditAccountIncludes{
+		Client:        fields.includes("client"),
+		Establishment: fields.includes("establishment"),
+	}
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// creditAccountRepos are the repository mocks behind a credit account controller under test.
+type creditAccountRepos struct {
+	accounts *mocks.MockCreditAccountRepository
+	settings *mocks.MockEstablishmentSettingsRepository
+}
+
+func newTestCreditAccountController(t *testing.T) (*CreditAccountController, creditAccountRepos) {
+	ctrl := gomock.NewController(t)
+	repos := creditAccountRepos{
+		accounts: mocks.NewMockCreditAccountRepository(ctrl),
+		settings: mocks.NewMockEstablishmentSettingsRepository(ctrl),
+	}
+	creditAccountService := service.NewCreditAccountService(repos.accounts, mocks.NewMockTransactionRepository(ctrl), mocks.NewMockInstallmentRepository(ctrl),
+		mocks.NewMockClientRepository(ctrl), mocks.NewMockEstablishmentRepository(ctrl), repos.settings,
+		mocks.NewMockPaymentPromiseRepository(ctrl), mocks.NewMockCreditTemplateRepository(ctrl), mocks.NewMockHolidayRepository(ctrl),
+		nil, util.NewFakeClock(fixture.Now), event.NewInMemoryBus())
+	return NewCreditAccountController(creditAccountService, nil), repos
+}
+
+// creditAccountRoutes registers the credit account operations on their paths in app/routes.go.
+func creditAccountRoutes(c *CreditAccountController, as caller) http.Handler {
+	router := newTestRouter(as)
+	router.POST("/credit-accounts/:id/apply-interest", c.ApplyInterestToAccount)
+	router.POST("/credit-accounts/:id/apply-late-fee", c.ApplyLateFeeToAccount)
+	router.POST("/credit-accounts/:id/purchases", c.ProcessPurchase)
+	router.POST("/credit-accounts/:id/payments", c.ProcessPayment)
+	return router
+}
+
+var establishmentAdmin = caller{
+	userID: fixture.AdminID,
+	role:   enums.ADMIN,
+	scope:  tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID}},
+}
+
+func TestCreditAccountOperationsReadTheAccountFromThePath(t *testing.T) {
+	account := fixture.CreditAccount().Balance(300).Build()
+	purchase := map[string]interface{}{"credit_account_id": account.ID, "transaction_type": enums.Purchase, "amount": 50, "payment_method": enums.CASH}
+	payment := map[string]interface{}{"credit_account_id": account.ID, "transaction_type": enums.Payment, "amount": 50, "payment_method": enums.CASH}
+	tests := []struct {
+		name   string
+		path   string
+		body   interface{}
+		expect func(repos creditAccountRepos)
+		want   int
+	}{
+		{
+			name: "apply interest",
+			path: "/credit-accounts/100/apply-interest",
+			expect: func(repos creditAccountRepos) {
+				repos.accounts.EXPECT().GetCreditAccountByID(account.ID).Return(account, nil)
+				repos.accounts.EXPECT().ApplyInterest(account).Return(nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "apply a late fee to a missing account",
+			path: "/credit-accounts/100/apply-late-fee",
+			expect: func(repos creditAccountRepos) {
+				repos.accounts.EXPECT().GetCreditAccountByID(account.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "purchase",
+			path: "/credit-accounts/100/purchases",
+			body: purchase,
+			expect: func(repos creditAccountRepos) {
+				repos.accounts.EXPECT().GetCreditAccountByID(account.ID).Return(account, nil)
+				repos.settings.EXPECT().GetEstablishmentSettings(account.EstablishmentID).Return(fixture.Settings().Build(), nil)
+				repos.accounts.EXPECT().ProcessPurchase(account, 50.0, "").Return(nil)
+			},
+			want: http.StatusCreated,
+		},
+		{
+			name: "payment",
+			path: "/credit-accounts/100/payments",
+			body: payment,
+			expect: func(repos creditAccountRepos) {
+				repos.accounts.EXPECT().GetCreditAccountByID(account.ID).Return(account, nil)
+				repos.accounts.EXPECT().ProcessPayment(account, 50.0, enums.CASH, "").Return(nil)
+			},
+			want: http.StatusCreated,
+		},
+		{name: "apply interest to an invalid ID", path: "/credit-accounts/abc/apply-interest", want: http.StatusBadRequest},
+		{name: "apply a late fee to an invalid ID", path: "/credit-accounts/abc/apply-late-fee", want: http.StatusBadRequest},
+		{name: "purchase on an invalid ID", path: "/credit-accounts/abc/purchases", body: purchase, want: http.StatusBadRequest},
+		{name: "payment to an invalid ID", path: "/credit-accounts/abc/payments", body: payment, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, repos := newTestCreditAccountController(t)
+			if tt.expect != nil {
+				tt.expect(repos)
+			}
+			recorder := serve(t, creditAccountRoutes(c, establishmentAdmin), http.MethodPost, tt.path, tt.body)
+			if recorder.Code != tt.want {
+				t.Errorf("POST %s = %d %s, want %d", tt.path, recorder.Code, recorder.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreditAccountsAreListedForTheCallersEstablishmentsOnly(t *testing.T) {
+	tests := []struct {
+		name            string
+		establishmentID uint
+		want            int
+	}{
+		{"the establishment of the admin", fixture.EstablishmentID, http.StatusOK},
+		{"another establishment", fixture.EstablishmentID + 1, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, repos := newTestCreditAccountController(t)
+			if tt.want == http.StatusOK {
+				repos.accounts.EXPECT().GetCreditAccountsByEstablishmentID(tt.establishmentID).
+					Return([]entities.CreditAccount{*fixture.CreditAccount().Build()}, nil)
+			}
+			router := newTestRouter(establishmentAdmin)
+			router.GET("/establishments/:establishmentID/credit-accounts", c.GetCreditAccountsByEstablishmentID)
+
+			path := fmt.Sprintf("/establishments/%d/credit-accounts?include=", tt.establishmentID)
+			if recorder := serve(t, router, http.MethodGet, path, nil); recorder.Code != tt.want {
+				t.Errorf("GET %s = %d %s, want %d", path, recorder.Code, recorder.Body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreditAccountEventController handles the history of changes to the terms and status of credit accounts.
+type CreditAccountEventController struct {
+	eventService         service.CreditAccountEventService
+	authorizationService service.AuthorizationService
+}
+
+// NewCreditAccountEventController creates a new instance of CreditAccountEventController.
+func NewCreditAccountEventController(eventService service.CreditAccountEventService, authorizationService service.AuthorizationService) *CreditAccountEventController {
+	return &CreditAccountEventController{eventService: eventService, authorizationService: authorizationService}
+}
+
+// GetCreditAccountEvents godoc
+// @Summary      Get Credit Account Events
+// @Description  Lists the changes to the terms and status of a credit account, newest first: its credit limit or interest rate changing (LIMIT_CHANGED, RATE_CHANGED, with the old and new value), and it being BLOCKED, UNBLOCKED, CLOSED or REOPENED, with the reason and the admin who did it. Events are recorded with every change and never edited, so they settle what the terms of the account were at any time. Clients can see their own accounts and Admins those of their establishment. The maximum page size depends on the caller's role. The number of events is sent in the X-Total-Count header.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Param        page           query       int     false "Page number (starts at 1)"
+// @Param        page_size      query       int     false "Page size"
+// @Success      200  {array}   response.CreditAccountEventResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/events [get]
+func (c *CreditAccountEventController) GetCreditAccountEvents(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeCreditAccount(requestCaller(ctx), uint(id), service.HolderAccess), "Credit account not found") {
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page"})
+		return
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page_size"})
+		return
+	}
+	pageSize, err := service.QueryLimitsForRole(middleware.GetUserRoleFromContext(ctx)).ResolvePageSize(requestedPageSize)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	events, total, err := c.eventService.GetCreditAccountEvents(uint(id), page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	versioning.SetPaginationTotal(ctx, page, pageSize, total)
+	ctx.JSON(http.StatusOK, events)
+}
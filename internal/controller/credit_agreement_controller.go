@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreditAgreementController handles the credit agreements clients accept before purchasing on credit.
+type CreditAgreementController struct {
+	creditAgreementService service.CreditAgreementService
+}
+
+// NewCreditAgreementController creates a new instance of CreditAgreementController.
+func NewCreditAgreementController(creditAgreementService service.CreditAgreementService) *CreditAgreementController {
+	return &CreditAgreementController{creditAgreementService: creditAgreementService}
+}
+
+// GetClientCreditAgreement godoc
+// @Summary      Get Client Credit Agreement
+// @Description  Returns the credit agreement of the authenticated client's account, generated when the account was opened with its terms: credit limit, interest rate, TCEA, due day and late fee. Purchases on the account are refused until the client accepts it. Accounts opened before agreements were introduced have none.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Success      200  {object}  response.CreditAgreementResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/credit-agreement [get]
+func (c *CreditAgreementController) GetClientCreditAgreement(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can view their credit agreement"})
+		return
+	}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	agreement, err := c.creditAgreementService.GetClientCreditAgreement(middleware.GetUserIDFromContext(ctx), establishmentID)
+	if err != nil {
+		respondCreditAgreementError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, agreement)
+}
+
+// GetClientCreditAgreementPDF godoc
+// @Summary      Download Client Credit Agreement (PDF)
+// @Description  Downloads the credit agreement of the authenticated client's account. Once accepted, it carries the date and IP of the acceptance and the SHA-256 hash of the accepted text.
+// @Tags         Clients
+// @Produce      application/pdf
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Success      200  {file}    application/pdf  "PDF credit agreement"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/credit-agreement/pdf [get]
+func (c *CreditAgreementController) GetClientCreditAgreementPDF(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can download their credit agreement"})
+		return
+	}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	pdfBytes, err := c.creditAgreementService.GetClientCreditAgreementPDF(middleware.GetUserIDFromContext(ctx), establishmentID)
+	if err != nil {
+		respondCreditAgreementError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", "attachment; filename=credit_agreement.pdf")
+	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+// AcceptClientCreditAgreement godoc
+// @Summary      Accept Client Credit Agreement
+// @Description  Accepts the credit agreement of the authenticated client's account, recording the time, the IP address and user agent of the request and the SHA-256 hash of the agreement text. Purchases on the account are allowed from then on. Fails with 409 if it was already accepted.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Success      200  {object}  response.CreditAgreementResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/credit-agreement/accept [post]
+func (c *CreditAgreementController) AcceptClientCreditAgreement(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can accept their credit agreement"})
+		return
+	}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	agreement, err := c.creditAgreementService.AcceptClientCreditAgreement(middleware.GetUserIDFromContext(ctx), establishmentID, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err != nil {
+		respondCreditAgreementError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, agreement)
+}
+
+// GetCreditAgreement godoc
+// @Summary      Get Credit Agreement
+// @Description  Returns the credit agreement of a credit account and whether the client accepted it. Only Admins of the account's establishment can see it.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Success      200  {object}  response.CreditAgreementResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/agreement [get]
+func (c *CreditAgreementController) GetCreditAgreement(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view credit agreements"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	agreement, err := c.creditAgreementService.GetCreditAgreement(middleware.GetUserIDFromContext(ctx), uint(id))
+	if err != nil {
+		respondCreditAgreementError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, agreement)
+}
+
+// GetCreditAgreementPDF godoc
+// @Summary      Download Credit Agreement (PDF)
+// @Description  Downloads the credit agreement of a credit account, with the client's acceptance once accepted. Only Admins of the account's establishment can download it.
+// @Tags         Credit Accounts
+// @Produce      application/pdf
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Success      200  {file}    application/pdf  "PDF credit agreement"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/agreement/pdf [get]
+func (c *CreditAgreementController) GetCreditAgreementPDF(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can download credit agreements"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	pdfBytes, err := c.creditAgreementService.GetCreditAgreementPDF(middleware.GetUserIDFromContext(ctx), uint(id))
+	if err != nil {
+		respondCreditAgreementError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=credit_agreement_%d.pdf", id))
+	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+// respondCreditAgreementError writes the response for an error of a credit agreement operation.
+func respondCreditAgreementError(ctx *gin.Context, err error) {
+	status := clientAccountErrorStatus(err)
+	switch {
+	case errors.Is(err, service.ErrCreditAgreementNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrCreditAgreementAccepted):
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// CreditSimulationController handles API requests related to credit simulations.
+type CreditSimulationController struct {
+	creditSimulationService service.CreditSimulationService
+}
+
+// NewCreditSimulationController creates a new CreditSimulationController.
+func NewCreditSimulationController(creditSimulationService service.CreditSimulationService) *CreditSimulationController {
+	return &CreditSimulationController{creditSimulationService: creditSimulationService}
+}
+
+// SimulateCredit godoc
+// @Summary      Simulate Credit
+// @Description  Returns the installment schedule, total interest and TCEA of a prospective credit without saving anything. Long-term credits start with their grace months, which accrue no interest and push the first installment back. Only Admins can simulate credits.
+// @Tags         Credit Simulations
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        simulation  body      request.CreateCreditSimulationRequest  true  "Credit conditions"
+// @Success      200  {object}  response.CreditSimulationResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-simulations [post]
+func (c *CreditSimulationController) SimulateCredit(ctx *gin.Context) {
+	var req request.CreateCreditSimulationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Only admins can simulate credits
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can simulate credits"})
+		return
+	}
+
+	simulation, err := c.creditSimulationService.SimulateCredit(req, middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSimulation) {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, simulation)
+}
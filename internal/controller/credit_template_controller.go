@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreditTemplateController handles the credit templates establishments open credit accounts with.
+type CreditTemplateController struct {
+	creditTemplateService service.CreditTemplateService
+}
+
+// NewCreditTemplateController creates a new instance of CreditTemplateController.
+func NewCreditTemplateController(creditTemplateService service.CreditTemplateService) *CreditTemplateController {
+	return &CreditTemplateController{creditTemplateService: creditTemplateService}
+}
+
+// GetCreditTemplates godoc
+// @Summary      List Credit Templates
+// @Description  Lists the credit templates of the establishment by name. Only Admins can see them.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.CreditTemplateResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/credit-templates [get]
+func (c *CreditTemplateController) GetCreditTemplates(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view credit templates"})
+		return
+	}
+
+	templates, err := c.creditTemplateService.GetCreditTemplates(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondCreditTemplateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, templates)
+}
+
+// CreateCreditTemplate godoc
+// @Summary      Create Credit Template
+// @Description  Adds a credit template to the establishment: a name and the credit limit, due day, interest rate and type, credit type and grace period credit accounts are opened with when they reference it. Names are unique within the establishment, ignoring case. Only Admins can create them.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                         true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                            false "Branch to act on. Defaults to the main establishment"
+// @Param        template       body        request.CreditTemplateRequest  true  "Template"
+// @Success      201  {object}  response.CreditTemplateResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/credit-templates [post]
+func (c *CreditTemplateController) CreateCreditTemplate(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create credit templates"})
+		return
+	}
+
+	var req request.CreditTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	template, err := c.creditTemplateService.CreateCreditTemplate(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondCreditTemplateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, template)
+}
+
+// UpdateCreditTemplate godoc
+// @Summary      Update Credit Template
+// @Description  Changes the name and terms of a credit template of the establishment. Accounts already opened with it keep their terms. Only Admins can update them.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                         true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                            false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                            true  "Credit template ID"
+// @Param        template       body        request.CreditTemplateRequest  true  "Template"
+// @Success      200  {object}  response.CreditTemplateResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/credit-templates/{id} [put]
+func (c *CreditTemplateController) UpdateCreditTemplate(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can update credit templates"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit template ID"})
+		return
+	}
+	var req request.CreditTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	template, err := c.creditTemplateService.UpdateCreditTemplate(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(id), req)
+	if err != nil {
+		respondCreditTemplateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, template)
+}
+
+// DeleteCreditTemplate godoc
+// @Summary      Delete Credit Template
+// @Description  Deletes a credit template of the establishment. Accounts opened with it keep their terms, but no longer point to it. Only Admins can delete them.
+// @Tags         Credit Accounts
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int     true  "Credit template ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/credit-templates/{id} [delete]
+func (c *CreditTemplateController) DeleteCreditTemplate(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete credit templates"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit template ID"})
+		return
+	}
+
+	if err := c.creditTemplateService.DeleteCreditTemplate(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(id)); err != nil {
+		respondCreditTemplateError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// respondCreditTemplateError writes the response for an error of a credit template operation.
+func respondCreditTemplateError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCreditTemplateName):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrCreditTemplateNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrCreditTemplateExists):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreditTermUpdateController handles changing the terms of many credit accounts at once.
+type CreditTermUpdateController struct {
+	creditTermUpdateService service.CreditTermUpdateService
+}
+
+// NewCreditTermUpdateController creates a new instance of CreditTermUpdateController.
+func NewCreditTermUpdateController(creditTermUpdateService service.CreditTermUpdateService) *CreditTermUpdateController {
+	return &CreditTermUpdateController{creditTermUpdateService: creditTermUpdateService}
+}
+
+// BulkUpdateCreditAccounts godoc
+// @Summary      Bulk Update Credit Terms
+// @Description  Sets the terms of the request (credit limit, due day, interest rate and type, compounding period, grace period, late fee percentage and spending limit) on every credit account of the establishment the filter matches: by ID, the credit template they were opened with, credit and interest type, status and client tags. An empty filter matches every account. Each account is updated on its own and reported as UPDATE, UNCHANGED, SKIPPED (written off) or FAILED (e.g. changed by someone else meanwhile), with the terms changed from what to what. With preview nothing is changed and the report lists what the update would do; otherwise the update and each change are kept as its audit trail, see update_id. Only Admins can update credit terms.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                   true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                      false "Branch to act on. Defaults to the main establishment"
+// @Param        update         body        request.BulkUpdateCreditAccountsRequest  true  "Filter, terms and reason"
+// @Success      200  {object}  response.BulkCreditTermUpdateResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/credit-accounts/bulk-update [post]
+func (c *CreditTermUpdateController) BulkUpdateCreditAccounts(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can update credit terms"})
+		return
+	}
+
+	var req request.BulkUpdateCreditAccountsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	report, err := c.creditTermUpdateService.BulkUpdateCreditAccounts(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondCreditTermUpdateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetCreditTermUpdates godoc
+// @Summary      List Credit Term Updates
+// @Description  Lists the bulk updates of credit terms of the establishment, newest first: who made them, why, their filter and terms, and how many accounts they matched, changed and failed to change. Only Admins can see them.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.CreditTermUpdateResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/credit-accounts/bulk-updates [get]
+func (c *CreditTermUpdateController) GetCreditTermUpdates(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view credit term updates"})
+		return
+	}
+
+	updates, err := c.creditTermUpdateService.GetCreditTermUpdates(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondCreditTermUpdateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updates)
+}
+
+// GetCreditTermUpdate godoc
+// @Summary      Get Credit Term Update
+// @Description  Retrieves a bulk update of credit terms of the establishment with every term it changed, by account, from what to what. Only Admins can see it.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int     true  "Credit term update ID"
+// @Success      200  {object}  response.CreditTermUpdateResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/credit-accounts/bulk-updates/{id} [get]
+func (c *CreditTermUpdateController) GetCreditTermUpdate(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view credit term updates"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit term update ID"})
+		return
+	}
+
+	update, err := c.creditTermUpdateService.GetCreditTermUpdate(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(id))
+	if err != nil {
+		respondCreditTermUpdateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, update)
+}
+
+// respondCreditTermUpdateError writes the response for an error of a bulk update of credit terms.
+func respondCreditTermUpdateError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNoCreditTermChanges):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrCreditTemplateNotFound), errors.Is(err, service.ErrCreditTermUpdateNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
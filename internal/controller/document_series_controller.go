@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DocumentSeriesController handles the series establishments number their receipts and invoices from.
+type DocumentSeriesController struct {
+	seriesService service.DocumentSeriesService
+}
+
+// NewDocumentSeriesController creates a new instance of DocumentSeriesController.
+func NewDocumentSeriesController(seriesService service.DocumentSeriesService) *DocumentSeriesController {
+	return &DocumentSeriesController{seriesService: seriesService}
+}
+
+// GetDocumentSeries godoc
+// @Summary      List Document Series
+// @Description  Lists the fiscal document series of the establishment. New purchases and payments get the next number of the active receipt series, e.g. B001-000123. Only Admins can see them.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.DocumentSeriesResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/document-series [get]
+func (c *DocumentSeriesController) GetDocumentSeries(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view document series"})
+		return
+	}
+
+	series, err := c.seriesService.GetDocumentSeries(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondDocumentSeriesError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, series)
+}
+
+// CreateDocumentSeries godoc
+// @Summary      Create Document Series
+// @Description  Adds a fiscal document series to the establishment. Receipt series codes start with B and invoice series codes with F, followed by three letters or digits. Creating an active series deactivates the active one of its type. Only Admins can create them.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                   false "Branch to act on. Defaults to the main establishment"
+// @Param        series         body        request.CreateDocumentSeriesRequest   true  "Document series"
+// @Success      201  {object}  response.DocumentSeriesResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/document-series [post]
+func (c *DocumentSeriesController) CreateDocumentSeries(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create document series"})
+		return
+	}
+
+	var req request.CreateDocumentSeriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	series, err := c.seriesService.CreateDocumentSeries(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondDocumentSeriesError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, series)
+}
+
+// UpdateDocumentSeries godoc
+// @Summary      Update Document Series
+// @Description  Activates or deactivates a fiscal document series of the establishment, or moves its numbering forward. Activating a series deactivates the active one of its type. Numbers already issued can't be reused. Only Admins can change them.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                   false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                                   true  "Document series ID"
+// @Param        series         body        request.UpdateDocumentSeriesRequest   true  "Changes to the document series"
+// @Success      200  {object}  response.DocumentSeriesResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/document-series/{id} [put]
+func (c *DocumentSeriesController) UpdateDocumentSeries(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can change document series"})
+		return
+	}
+
+	seriesID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid document series ID"})
+		return
+	}
+	var req request.UpdateDocumentSeriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	series, err := c.seriesService.UpdateDocumentSeries(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(seriesID), req)
+	if err != nil {
+		respondDocumentSeriesError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, series)
+}
+
+// respondDocumentSeriesError writes the response of a failed document series operation.
+func respondDocumentSeriesError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidDocumentSeries):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDocumentSeriesNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDocumentSeriesExists), errors.Is(err, service.ErrDocumentNumberIssued):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
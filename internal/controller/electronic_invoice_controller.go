@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ElectronicInvoiceController exposes the electronic receipts issued for credit purchases.
+type ElectronicInvoiceController struct {
+	invoicingService service.InvoicingService
+}
+
+// NewElectronicInvoiceController creates a new instance of ElectronicInvoiceController.
+func NewElectronicInvoiceController(invoicingService service.InvoicingService) *ElectronicInvoiceController {
+	return &ElectronicInvoiceController{invoicingService: invoicingService}
+}
+
+// GetInvoice godoc
+// @Summary      Get Electronic Receipt
+// @Description  Returns the electronic receipt of a credit purchase and the state of its submission to SUNAT: PENDING while it is being submitted, ACCEPTED, REJECTED with SUNAT's reason, or FAILED when it couldn't be submitted. Available to the admin of the establishment and the client who made the purchase.
+// @Tags         Transactions
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Transaction ID of the purchase"
+// @Success      200  {object}  response.ElectronicInvoiceResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /transactions/{id}/invoice [get]
+func (c *ElectronicInvoiceController) GetInvoice(ctx *gin.Context) {
+	transactionID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid Transaction ID"})
+		return
+	}
+
+	invoice, err := c.invoicingService.GetInvoice(middleware.GetUserIDFromContext(ctx), middleware.GetUserRoleFromContext(ctx), uint(transactionID))
+	if err != nil {
+		respondInvoiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, invoice)
+}
+
+// GetInvoicePDF godoc
+// @Summary      Download Electronic Receipt (PDF)
+// @Description  Downloads the printed representation of the electronic receipt of a credit purchase, with its tax breakdown and the hash of the signed XML. Available to the admin of the establishment and the client who made the purchase.
+// @Tags         Transactions
+// @Produce      application/pdf
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Transaction ID of the purchase"
+// @Success      200  {file}    application/pdf  "PDF receipt"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /transactions/{id}/invoice/pdf [get]
+func (c *ElectronicInvoiceController) GetInvoicePDF(ctx *gin.Context) {
+	transactionID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid Transaction ID"})
+		return
+	}
+
+	pdfBytes, err := c.invoicingService.GetInvoicePDF(middleware.GetUserIDFromContext(ctx), middleware.GetUserRoleFromContext(ctx), uint(transactionID))
+	if err != nil {
+		respondInvoiceError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=receipt_%d.pdf", transactionID))
+	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+// respondInvoiceError writes the response for an error of an electronic receipt operation.
+func respondInvoiceError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, service.ErrInvoiceNotFound) {
+		status = http.StatusNotFound
+	}
+	ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+}
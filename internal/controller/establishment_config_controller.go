@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EstablishmentConfigController handles exporting the configuration of an establishment and importing it into another.
+type EstablishmentConfigController struct {
+	configService service.EstablishmentConfigService
+}
+
+// NewEstablishmentConfigController creates a new instance of EstablishmentConfigController.
+func NewEstablishmentConfigController(configService service.EstablishmentConfigService) *EstablishmentConfigController {
+	return &EstablishmentConfigController{configService: configService}
+}
+
+// ExportConfig godoc
+// @Summary      Export Establishment Configuration
+// @Description  Exports the settings, product categories and products of the establishment as JSON, to set up another store with POST /establishments/me/import. Products are exported without their stock. Only Admins can export it.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.EstablishmentConfigResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/export [get]
+func (c *EstablishmentConfigController) ExportConfig(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can export the establishment configuration"})
+		return
+	}
+
+	config, err := c.configService.ExportConfig(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentConfigError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, config)
+}
+
+// ImportConfig godoc
+// @Summary      Import Establishment Configuration
+// @Description  Imports a configuration exported by GET /establishments/me/export into the establishment. Its settings replace those it sets, but the adjustment approver. Its categories are created unless the establishment has them, matched by name ignoring case. Its products are matched with those of the establishment by SKU, or by barcode without one, or by name without either; new ones are created without stock, and those that differ from the existing ones are skipped (SKIP, the default), overwrite them (OVERWRITE) or fail the import (FAIL) as on_conflict says. The import is all or nothing, failing on any invalid item. With dry_run it changes nothing and reports what it would do with each item. Only Admins can import it.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                    true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                       false "Branch to act on. Defaults to the main establishment"
+// @Param        import         body        request.ImportEstablishmentConfigRequest  true  "Configuration and import options"
+// @Success      200  {object}  response.EstablishmentImportReport
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/import [post]
+func (c *EstablishmentConfigController) ImportConfig(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can import an establishment configuration"})
+		return
+	}
+	var req request.ImportEstablishmentConfigRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	report, err := c.configService.ImportConfig(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondEstablishmentConfigError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// respondEstablishmentConfigError writes the response for an error of an establishment configuration operation.
+func respondEstablishmentConfigError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidConfigImport):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrConfigImportConflict), errors.Is(err, service.ErrVersionConflict),
+		errors.Is(err, service.ErrCategoryExists), errors.Is(err, service.ErrSKUExists), errors.Is(err, service.ErrBarcodeExists):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
@@ -1,12 +1,14 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"ApiRestFinance/internal/middleware"
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -24,7 +26,7 @@ func NewEstablishmentController(establishmentService service.EstablishmentServic
 
 // CreateEstablishment godoc
 // @Summary      Create Establishment
-// @Description  Creates a new establishment for the authenticated admin.
+// @Description  Creates a new establishment for the authenticated admin. A RUC another establishment already registered is rejected with 409 Conflict.
 // @Tags         Establishments
 // @Accept       json
 // @Produce      json
@@ -33,6 +35,7 @@ func NewEstablishmentController(establishmentService service.EstablishmentServic
 // @Success      201  {object}  response.EstablishmentResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /establishments [post]
 func (c *EstablishmentController) CreateEstablishment(ctx *gin.Context) {
@@ -46,6 +49,10 @@ func (c *EstablishmentController) CreateEstablishment(ctx *gin.Context) {
 
 	establishment, err := c.establishmentService.CreateEstablishment(&req, adminID)
 	if err != nil {
+		if uniqueConflict(err) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -59,6 +66,7 @@ func (c *EstablishmentController) CreateEstablishment(ctx *gin.Context) {
 // @Tags         Establishments
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Success      200  {object}  response.EstablishmentResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
@@ -67,9 +75,9 @@ func (c *EstablishmentController) CreateEstablishment(ctx *gin.Context) {
 func (c *EstablishmentController) GetEstablishment(ctx *gin.Context) {
 	adminID := middleware.GetUserIDFromContext(ctx)
 
-	establishment, err := c.establishmentService.GetEstablishmentByAdminID(adminID)
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(adminID, middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		respondEstablishmentError(ctx, err)
 		return
 	}
 
@@ -78,16 +86,20 @@ func (c *EstablishmentController) GetEstablishment(ctx *gin.Context) {
 
 // UpdateEstablishment godoc
 // @Summary      Update Establishment
-// @Description  Updates the establishment details for the authenticated admin.
+// @Description  Updates the establishment details for the authenticated admin. Send the version of the establishment last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the establishment as it is now. A RUC another establishment already registered is rejected with 409 Conflict too.
 // @Tags         Establishments
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string                          true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        establishment  body      request.UpdateEstablishmentRequest  true  "Updated establishment data"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
 // @Success      200  {object}  response.EstablishmentResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /establishments/me [put]
 func (c *EstablishmentController) UpdateEstablishment(ctx *gin.Context) {
@@ -96,12 +108,68 @@ func (c *EstablishmentController) UpdateEstablishment(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
 
 	adminID := middleware.GetUserIDFromContext(ctx)
 
-	establishment, err := c.establishmentService.UpdateEstablishmentByAdminID(adminID, req)
+	branchID := middleware.GetBranchIDFromContext(ctx)
+	establishment, err := c.establishmentService.UpdateEstablishmentByAdminID(adminID, branchID, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		current := func() (interface{}, error) {
+			return c.establishmentService.GetEstablishmentByAdminID(adminID, branchID)
+		}
+		if respondVersionError(ctx, err, current) {
+			return
+		}
+		respondEstablishmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, establishment)
+}
+
+// PatchEstablishment godoc
+// @Summary      Patch Establishment
+// @Description  Changes only the establishment details the request sets for the authenticated admin. Unlike PUT, fields that don't change can be left out or null, and zero and false values are set too. Send the version of the establishment last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the establishment as it is now. A RUC another establishment already registered is rejected with 409 Conflict too.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                          true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        establishment  body      request.PatchEstablishmentRequest  true  "Establishment fields to change"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
+// @Success      200  {object}  response.EstablishmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me [patch]
+func (c *EstablishmentController) PatchEstablishment(ctx *gin.Context) {
+	var req request.PatchEstablishmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
+
+	adminID := middleware.GetUserIDFromContext(ctx)
+
+	branchID := middleware.GetBranchIDFromContext(ctx)
+	establishment, err := c.establishmentService.PatchEstablishmentByAdminID(adminID, branchID, req)
+	if err != nil {
+		current := func() (interface{}, error) {
+			return c.establishmentService.GetEstablishmentByAdminID(adminID, branchID)
+		}
+		if respondVersionError(ctx, err, current) {
+			return
+		}
+		respondEstablishmentError(ctx, err)
 		return
 	}
 
@@ -110,7 +178,7 @@ func (c *EstablishmentController) UpdateEstablishment(ctx *gin.Context) {
 
 // GetEstablishmentByID godoc
 // @Summary      Get Establishment by ID
-// @Description  Gets an establishment by its ID.
+// @Description  Gets one of the authenticated admin's establishments, main or branch, by its ID.
 // @Tags         Establishments
 // @Produce      json
 // @Param        Authorization  header      string                          true  "Bearer {token}"
@@ -127,17 +195,161 @@ func (c *EstablishmentController) GetEstablishmentByID(ctx *gin.Context) {
 		return
 	}
 
+	// Admins can only see their own establishments: the main one or any of its branches
 	adminID := middleware.GetUserIDFromContext(ctx)
-	establishment, err := c.establishmentService.GetEstablishmentByAdminID(adminID)
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(adminID, uint(id))
 	if err != nil {
+		if errors.Is(err, service.ErrBranchNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Establishment not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	if establishment.ID != uint(id) {
-		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Establishment not found"})
+	ctx.JSON(http.StatusOK, establishment)
+}
+
+// CreateBranch godoc
+// @Summary      Create Branch
+// @Description  Creates a branch of the authenticated admin's main establishment. The branch shares the main establishment's RUC and, unless given, its settings. Select a branch in other requests with the X-Branch-ID header. Only Admins can create branches.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                       true  "Bearer {token}"
+// @Param        branch         body      request.CreateBranchRequest  true  "Branch data"
+// @Success      201  {object}  response.EstablishmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/branches [post]
+func (c *EstablishmentController) CreateBranch(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create branches"})
 		return
 	}
 
+	var req request.CreateBranchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	branch, err := c.establishmentService.CreateBranch(middleware.GetUserIDFromContext(ctx), req)
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, branch)
+}
+
+// GetBranches godoc
+// @Summary      List Branches
+// @Description  Lists the authenticated admin's main establishment followed by its branches. Only Admins can list branches.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {array}   response.EstablishmentResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/branches [get]
+func (c *EstablishmentController) GetBranches(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list branches"})
+		return
+	}
+
+	branches, err := c.establishmentService.GetBranches(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, branches)
+}
+
+// DeactivateEstablishment godoc
+// @Summary      Deactivate Establishment
+// @Description  Deactivates the authenticated admin's establishment, or the branch of X-Branch-ID. Until it is reactivated it takes no purchases, cash sales or new clients (403 with code establishment_inactive), its catalog is hidden and its invite code stops working, while its clients can still pay what they owe and see their accounts. Deactivating the main establishment deactivates its branches with it. Only Admins can deactivate establishments.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.EstablishmentResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/deactivate [post]
+func (c *EstablishmentController) DeactivateEstablishment(ctx *gin.Context) {
+	c.setActive(ctx, c.establishmentService.DeactivateEstablishment)
+}
+
+// ReactivateEstablishment godoc
+// @Summary      Reactivate Establishment
+// @Description  Reactivates the authenticated admin's deactivated establishment, or the branch of X-Branch-ID, so it takes purchases and new clients again. A branch stays inactive while its main establishment is deactivated. Only Admins can reactivate establishments.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.EstablishmentResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reactivate [post]
+func (c *EstablishmentController) ReactivateEstablishment(ctx *gin.Context) {
+	c.setActive(ctx, c.establishmentService.ReactivateEstablishment)
+}
+
+func (c *EstablishmentController) setActive(ctx *gin.Context, change func(adminID, branchID uint) (*response.EstablishmentResponse, error)) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can deactivate or reactivate establishments"})
+		return
+	}
+
+	establishment, err := change(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		if errors.Is(err, service.ErrEstablishmentActive) || errors.Is(err, service.ErrEstablishmentDeactivated) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		respondEstablishmentError(ctx, err)
+		return
+	}
 	ctx.JSON(http.StatusOK, establishment)
 }
+
+// respondEstablishmentError answers 404 when the selected branch isn't one of the admin's, 403 when
+// the establishment is deactivated, and 409 when a DNI, email or RUC is taken (see uniqueConflict).
+func respondEstablishmentError(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrBranchNotFound) {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrEstablishmentInactive) {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrUpgradeRequired) {
+		ctx.JSON(http.StatusPaymentRequired, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if uniqueConflict(err) {
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+}
+
+// uniqueConflict reports whether err is a DNI, email or RUC another user or establishment already
+// has. The message names the field, so forms can point at it.
+func uniqueConflict(err error) bool {
+	return errors.Is(err, service.ErrDNIInUse) || errors.Is(err, service.ErrEmailInUse) || errors.Is(err, service.ErrRUCInUse)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EstablishmentSettingsController handles the business rules of establishments.
+type EstablishmentSettingsController struct {
+	settingsService service.EstablishmentSettingsService
+}
+
+// NewEstablishmentSettingsController creates a new instance of EstablishmentSettingsController.
+func NewEstablishmentSettingsController(settingsService service.EstablishmentSettingsService) *EstablishmentSettingsController {
+	return &EstablishmentSettingsController{settingsService: settingsService}
+}
+
+// GetSettings godoc
+// @Summary      Get Establishment Settings
+// @Description  Gets the business rules the establishment applies to its credit accounts: installments per long-term purchase, default interest rate, auto-block threshold and payment reminder schedule. Only Admins can see them.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.EstablishmentSettingsResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/settings [get]
+func (c *EstablishmentSettingsController) GetSettings(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view establishment settings"})
+		return
+	}
+
+	settings, err := c.settingsService.GetSettings(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings godoc
+// @Summary      Update Establishment Settings
+// @Description  Changes the business rules of the establishment. Omitted fields keep their value. New installment counts and default rates only apply to purchases and accounts created afterwards. Clients are sent payment reminders on the days of reminder_schedule before or on their due dates, and overdue notices on those after them, e.g. [-7, -3, -1, 0, 3]; reminder_days_before N alone sets it to [-N]. With sms_notifications, payment reminders, confirmations, overdue notices and payment links are also texted to clients who verified their phone, from sms_sender if set. With digest_frequency WEEKLY or MONTHLY, the admin is emailed a digest of the reports after each week or month, with the digest_sections chosen or all of them. Manual adjustments above adjustment_threshold wait for the approval of adjustment_approver_id, an admin other than the establishment's. Only Admins can change them.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                      true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                         false "Branch to act on. Defaults to the main establishment"
+// @Param        settings       body        request.UpdateEstablishmentSettingsRequest  true  "Establishment settings"
+// @Success      200  {object}  response.EstablishmentSettingsResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/settings [put]
+func (c *EstablishmentSettingsController) UpdateSettings(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can change establishment settings"})
+		return
+	}
+
+	var req request.UpdateEstablishmentSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	settings, err := c.settingsService.UpdateSettings(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if errors.Is(err, service.ErrInvalidAdjustmentApprover) {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settings)
+}
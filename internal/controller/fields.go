@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"ApiRestFinance/internal/model/dto/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseFields are the parts of a list response a request asked for: ?fields=id,current_balance
+// keeps only those top-level fields of each item, and ?include=client leaves out the nested objects
+// not listed. Without them responses are complete.
+type responseFields struct {
+	fields   []string        // Top-level fields to keep, in order, nil for all
+	included map[string]bool // Nested objects to embed, nil for all of them
+}
+
+// parseResponseFields reads the fields and include query parameters of a list whose items can
+// embed the nested objects named by nested; an empty include embeds none. It answers the request
+// and returns false when include names another one.
+func parseResponseFields(ctx *gin.Context, nested ...string) (responseFields, bool) {
+	var f responseFields
+	if fields := splitQueryList(ctx.Query("fields")); len(fields) > 0 {
+		f.fields = fields
+	}
+	if value, ok := ctx.GetQuery("include"); ok {
+		f.included = map[string]bool{}
+		for _, name := range splitQueryList(value) {
+			known := false
+			for _, n := range nested {
+				known = known || n == name
+			}
+			if !known {
+				ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid include " + name + ", use " + strings.Join(nested, ", ")})
+				return responseFields{}, false
+			}
+			f.included[name] = true
+		}
+	}
+	return f, true
+}
+
+// includes reports whether items embed the nested object name, which they do unless include leaves
+// it out or fields doesn't list it.
+func (f responseFields) includes(name string) bool {
+	if f.included != nil && !f.included[name] {
+		return false
+	}
+	if f.fields == nil {
+		return true
+	}
+	for _, field := range f.fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// respond writes data, a list or a single object, with only the fields asked for. Unknown fields
+// are ignored.
+func (f responseFields) respond(ctx *gin.Context, status int, data interface{}) {
+	if f.fields == nil {
+		ctx.JSON(status, data)
+		return
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		var item map[string]json.RawMessage
+		if err := json.Unmarshal(body, &item); err != nil {
+			ctx.Data(status, "application/json; charset=utf-8", body)
+			return
+		}
+		ctx.Data(status, "application/json; charset=utf-8", f.selectFields(item))
+		return
+	}
+
+	var out bytes.Buffer
+	out.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		out.Write(f.selectFields(item))
+	}
+	out.WriteByte(']')
+	ctx.Data(status, "application/json; charset=utf-8", out.Bytes())
+}
+
+// selectFields encodes the fields asked for of an item, in the order they were asked for.
+func (f responseFields) selectFields(item map[string]json.RawMessage) []byte {
+	var out bytes.Buffer
+	out.WriteByte('{')
+	written := 0
+	for _, field := range f.fields {
+		value, ok := item[field]
+		if !ok {
+			continue
+		}
+		if written > 0 {
+			out.WriteByte(',')
+		}
+		name, _ := json.Marshal(field)
+		out.Write(name)
+		out.WriteByte(':')
+		out.Write(value)
+		written++
+	}
+	out.WriteByte('}')
+	return out.Bytes()
+}
+
+// splitQueryList splits a comma-separated query parameter, skipping blanks and repeated names.
+func splitQueryList(value string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HolidayController handles the holiday calendars establishments count business days with.
+type HolidayController struct {
+	holidayService service.HolidayService
+}
+
+// NewHolidayController creates a new instance of HolidayController.
+func NewHolidayController(holidayService service.HolidayService) *HolidayController {
+	return &HolidayController{holidayService: holidayService}
+}
+
+// GetHolidays godoc
+// @Summary      List Holidays
+// @Description  Lists the holidays of the establishment by date. Due dates falling on a holiday or a Sunday move to the next business day, and installments are only overdue and reminded of by that day. Establishments start with the national holidays of Peru of the year they were created and the next. Only Admins can see them.
+// @Tags         Holidays
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        year           query       int     false "Year of the holidays. Defaults to every year"
+// @Success      200  {array}   response.HolidayResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/holidays [get]
+func (c *HolidayController) GetHolidays(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view holidays"})
+		return
+	}
+	year, err := strconv.Atoi(ctx.DefaultQuery("year", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid year"})
+		return
+	}
+
+	holidays, err := c.holidayService.GetHolidays(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), year)
+	if err != nil {
+		respondHolidayError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, holidays)
+}
+
+// CreateHoliday godoc
+// @Summary      Create Holiday
+// @Description  Adds a holiday to the calendar of the establishment, e.g. a regional holiday. The installments still to be paid that are due on it move to the next business day. Only Admins can create them.
+// @Tags         Holidays
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                     false "Branch to act on. Defaults to the main establishment"
+// @Param        holiday        body        request.HolidayRequest  true  "Holiday"
+// @Success      201  {object}  response.HolidayResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/holidays [post]
+func (c *HolidayController) CreateHoliday(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create holidays"})
+		return
+	}
+
+	var req request.HolidayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	holiday, err := c.holidayService.CreateHoliday(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondHolidayError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, holiday)
+}
+
+// AddNationalHolidays godoc
+// @Summary      Add National Holidays
+// @Description  Adds the national holidays of Peru of a year to the calendar of the establishment, keeping the holidays it already has on those dates, and returns the holidays of that year. The installments still to be paid that are due on them move to the next business day. Only Admins can add them.
+// @Tags         Holidays
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        year           query       int     true  "Year of the holidays"
+// @Success      200  {array}   response.HolidayResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/holidays/national [post]
+func (c *HolidayController) AddNationalHolidays(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can add holidays"})
+		return
+	}
+	year, err := strconv.Atoi(ctx.Query("year"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid year"})
+		return
+	}
+
+	holidays, err := c.holidayService.AddNationalHolidays(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), year)
+	if err != nil {
+		respondHolidayError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, holidays)
+}
+
+// UpdateHoliday godoc
+// @Summary      Update Holiday
+// @Description  Changes the date and name of a holiday of the establishment. The installments still to be paid that are due on its new date move to the next business day; those moved off its previous date stay where they are. Only Admins can change them.
+// @Tags         Holidays
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                     true  "Holiday ID"
+// @Param        holiday        body        request.HolidayRequest  true  "Holiday"
+// @Success      200  {object}  response.HolidayResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/holidays/{id} [put]
+func (c *HolidayController) UpdateHoliday(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can change holidays"})
+		return
+	}
+
+	holidayID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid holiday ID"})
+		return
+	}
+	var req request.HolidayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	holiday, err := c.holidayService.UpdateHoliday(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(holidayID), req)
+	if err != nil {
+		respondHolidayError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, holiday)
+}
+
+// DeleteHoliday godoc
+// @Summary      Delete Holiday
+// @Description  Deletes a holiday of the establishment. Installments moved off it stay where they are. Only Admins can delete them.
+// @Tags         Holidays
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int     true  "Holiday ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/holidays/{id} [delete]
+func (c *HolidayController) DeleteHoliday(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete holidays"})
+		return
+	}
+
+	holidayID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid holiday ID"})
+		return
+	}
+
+	if err := c.holidayService.DeleteHoliday(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(holidayID)); err != nil {
+		respondHolidayError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// respondHolidayError writes the response of a failed holiday operation.
+func respondHolidayError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidHolidayDate), errors.Is(err, service.ErrInvalidHolidayYear):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrHolidayNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrHolidayExists):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
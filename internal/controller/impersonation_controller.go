@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonationController lets admins see the API as one of their clients ("view as client").
+type ImpersonationController struct {
+	impersonationService service.ImpersonationService
+}
+
+// NewImpersonationController creates a new instance of ImpersonationController.
+func NewImpersonationController(impersonationService service.ImpersonationService) *ImpersonationController {
+	return &ImpersonationController{impersonationService: impersonationService}
+}
+
+// StartImpersonation godoc
+// @Summary      Impersonate Client
+// @Description  Issues a short-lived impersonation token to see the API exactly as a client of the admin's establishment (or the selected branch) sees it. The token is flagged as an impersonation, acts as the client's access token only on the read-only client endpoints (GET /clients/me/...) and only for the establishment it was started from, and can't be refreshed. Every request made with it is audited. Stop it with POST /admins/impersonate/stop. Only Admins can impersonate clients.
+// @Tags         Admins
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                            true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                               false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path        int                               true  "Client ID"
+// @Param        impersonation  body        request.ImpersonateClientRequest  true  "Reason for the impersonation"
+// @Success      201  {object}  response.ImpersonationTokenResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admins/impersonate/{clientID} [post]
+func (c *ImpersonationController) StartImpersonation(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can impersonate clients"})
+		return
+	}
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+	var req request.ImpersonateClientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	token, err := c.impersonationService.StartImpersonation(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(clientID), req.Reason, clientInfo(ctx))
+	if err != nil {
+		if errors.Is(err, service.ErrCreditAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, token)
+}
+
+// StopImpersonation godoc
+// @Summary      Stop Impersonation
+// @Description  Stops an impersonation, after which its token is rejected right away. Called with the impersonation token it stops that impersonation; called with an admin's own token it stops every impersonation of the admin still going on.
+// @Tags         Admins
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      204
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admins/impersonate/stop [post]
+func (c *ImpersonationController) StopImpersonation(ctx *gin.Context) {
+	var err error
+	if impersonationID := middleware.GetImpersonationIDFromContext(ctx); impersonationID != 0 {
+		err = c.impersonationService.StopImpersonation(impersonationID)
+	} else if middleware.GetUserRoleFromContext(ctx) == enums.ADMIN {
+		err = c.impersonationService.StopAdminImpersonations(middleware.GetUserIDFromContext(ctx))
+	} else {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can stop impersonations"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetImpersonations godoc
+// @Summary      List Impersonations
+// @Description  Lists the latest impersonations of the authenticated admin, newest first, with the audit trail of every request made with each token, including the ones refused. Only Admins can see them.
+// @Tags         Admins
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {array}   response.ImpersonationResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admins/impersonations [get]
+func (c *ImpersonationController) GetImpersonations(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list impersonations"})
+		return
+	}
+
+	impersonations, err := c.impersonationService.GetImpersonations(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, impersonations)
+}
@@ -2,31 +2,35 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"ApiRestFinance/internal/middleware"
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/versioning"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // InstallmentController handles API requests related to installments.
 type InstallmentController struct {
-	installmentService service.InstallmentService
+	installmentService   service.InstallmentService
+	authorizationService service.AuthorizationService
 }
 
 // NewInstallmentController creates a new InstallmentController.
-func NewInstallmentController(installmentService service.InstallmentService) *InstallmentController {
-	return &InstallmentController{installmentService: installmentService}
+func NewInstallmentController(installmentService service.InstallmentService, authorizationService service.AuthorizationService) *InstallmentController {
+	return &InstallmentController{installmentService: installmentService, authorizationService: authorizationService}
 }
 
 // CreateInstallment godoc
 // @Summary      Create Installment
-// @Description  Creates a new installment for a credit account. Only Admins can create installments.
+// @Description  Creates a new installment for a credit account. Only Admins can create installments, on credit accounts of their establishments.
 // @Tags         Installments
 // @Accept       json
 // @Produce      json
@@ -36,6 +40,7 @@ func NewInstallmentController(installmentService service.InstallmentService) *In
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /installments [post]
 func (c *InstallmentController) CreateInstallment(ctx *gin.Context) {
@@ -50,6 +55,9 @@ func (c *InstallmentController) CreateInstallment(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create installments"})
 		return
 	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeCreditAccount(requestCaller(ctx), req.CreditAccountID, service.AdminAccess), "Credit account not found") {
+		return
+	}
 
 	installment, err := c.installmentService.CreateInstallment(req)
 	if err != nil {
@@ -62,13 +70,15 @@ func (c *InstallmentController) CreateInstallment(ctx *gin.Context) {
 
 // GetInstallmentByID godoc
 // @Summary      Get Installment by ID
-// @Description  Gets an installment by its ID.
+// @Description  Gets an installment by its ID. Only the admins of the establishment and the client holding the credit account can get it.
 // @Tags         Installments
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        id   path      int  true  "Installment ID"
 // @Success      200  {object}  response.InstallmentResponse
 // @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /installments/{id} [get]
@@ -78,6 +88,9 @@ func (c *InstallmentController) GetInstallmentByID(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid installment ID"})
 		return
 	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeInstallment(requestCaller(ctx), uint(id), service.HolderAccess), "Installment not found") {
+		return
+	}
 
 	installment, err := c.installmentService.GetInstallmentByID(uint(id))
 	if err != nil {
@@ -89,29 +102,41 @@ func (c *InstallmentController) GetInstallmentByID(ctx *gin.Context) {
 		return
 	}
 
+	versioning.SetLinks(ctx, versioning.Links{
+		"credit_account": fmt.Sprintf("/credit-accounts/%d", installment.CreditAccountID),
+		"installments":   fmt.Sprintf("/credit-accounts/%d/installments", installment.CreditAccountID),
+	})
 	ctx.JSON(http.StatusOK, installment)
 }
 
 // GetInstallmentsByCreditAccountID godoc
 // @Summary      Get Installments by Credit Account ID
-// @Description  Retrieves installments associated with a specific credit account.
+// @Description  Retrieves installments associated with a specific credit account. Only the admins of the establishment and the client holding the credit account can get them. Supports conditional requests: send the ETag back in If-None-Match to get 304 Not Modified while no installment changed.
 // @Tags         Installments
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        creditAccountID   path      int  true  "Credit Account ID"
+// @Param        id   path      int  true  "Credit Account ID"
+// @Param        If-None-Match  header      string  false "ETag of a cached response, answered with 304 Not Modified while it is current"
 // @Success      200  {array}   response.InstallmentResponse
+// @Success      304  "The cached response is current"
+// @Header       200,304  {string}  ETag           "Version of the response, for If-None-Match"
+// @Header       200,304  {string}  Cache-Control  "private, no-cache: revalidate before reusing"
 // @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
-// @Router       /credit-accounts/{creditAccountID}/installments [get]
+// @Router       /credit-accounts/{id}/installments [get]
 func (c *InstallmentController) GetInstallmentsByCreditAccountID(ctx *gin.Context) {
-	creditAccountID, err := strconv.Atoi(ctx.Param("creditAccountID"))
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
 		return
 	}
 
-	// You might want to add authorization logic here to determine
-	// who can access installments for a credit account (admin, client, both?)
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeCreditAccount(requestCaller(ctx), uint(creditAccountID), service.HolderAccess), "Credit account not found") {
+		return
+	}
 
 	installments, err := c.installmentService.GetInstallmentsByCreditAccountID(uint(creditAccountID))
 	if err != nil {
@@ -119,23 +144,29 @@ func (c *InstallmentController) GetInstallmentsByCreditAccountID(ctx *gin.Contex
 		return
 	}
 
+	if notModified(ctx, time.Time{}, installmentVersions(installments)...) {
+		return
+	}
 	ctx.JSON(http.StatusOK, installments)
 }
 
 // UpdateInstallment godoc
 // @Summary      Update Installment
-// @Description  Updates an existing installment. Only Admins can update installments.
+// @Description  Updates an existing installment. Only Admins can update installments, of the credit accounts of their establishments. Send the version of the installment last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the installment as it is now. The status follows the installment's payments and due date: PENDING, PARTIALLY_PAID once part of it is paid, OVERDUE if it isn't paid in full by the end of its due date and PAID once it is. It can only be set by hand to CANCELLED, while it is owed, and paid or cancelled installments can't be updated (409 Conflict).
 // @Tags         Installments
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        id             path      int  true  "Installment ID"
 // @Param        installment     body      request.UpdateInstallmentRequest  true  "Updated installment details"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
 // @Success      200  {object}  response.InstallmentResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /installments/{id} [put]
 func (c *InstallmentController) UpdateInstallment(ctx *gin.Context) {
@@ -150,19 +181,33 @@ func (c *InstallmentController) UpdateInstallment(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
 
 	// Check user role - only admins can update
 	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can update installments"})
 		return
 	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeInstallment(requestCaller(ctx), uint(id), service.AdminAccess), "Installment not found") {
+		return
+	}
 
 	installment, err := c.installmentService.UpdateInstallment(uint(id), req)
 	if err != nil {
+		if respondVersionError(ctx, err, func() (interface{}, error) { return c.installmentService.GetInstallmentByID(uint(id)) }) {
+			return
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Installment not found"})
 			return
 		}
+		if errors.Is(err, service.ErrInstallmentPaid) || errors.Is(err, service.ErrInstallmentCancelled) ||
+			errors.Is(err, service.ErrInvalidInstallmentStatus) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -172,7 +217,7 @@ func (c *InstallmentController) UpdateInstallment(ctx *gin.Context) {
 
 // DeleteInstallment godoc
 // @Summary      Delete Installment
-// @Description  Deletes an installment by its ID. Only Admins can delete installments.
+// @Description  Deletes an installment by its ID. Only Admins can delete installments, of the credit accounts of their establishments.
 // @Tags         Installments
 // @Accept       json
 // @Produce      json
@@ -197,6 +242,9 @@ func (c *InstallmentController) DeleteInstallment(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete installments"})
 		return
 	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeInstallment(requestCaller(ctx), uint(id), service.AdminAccess), "Installment not found") {
+		return
+	}
 
 	if err := c.installmentService.DeleteInstallment(uint(id)); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -212,23 +260,28 @@ func (c *InstallmentController) DeleteInstallment(ctx *gin.Context) {
 
 // GetOverdueInstallments godoc
 // @Summary      Get Overdue Installments by Credit Account ID
-// @Description  Retrieves overdue installments for a specific credit account.
+// @Description  Retrieves overdue installments for a specific credit account: those not paid in full by the end of their due date, marked overdue nightly. Only the admins of the establishment and the client holding the credit account can get them.
 // @Tags         Installments
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        creditAccountID path int true "Credit Account ID"
+// @Param        id path int true "Credit Account ID"
 // @Success      200 {array} response.InstallmentResponse
 // @Failure      400 {object} response.ErrorResponse
+// @Failure      401 {object} response.ErrorResponse
+// @Failure      403 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
 // @Failure      500 {object} response.ErrorResponse
-// @Router       /credit-accounts/{creditAccountID}/installments/overdue [get]
+// @Router       /credit-accounts/{id}/installments/overdue [get]
 func (c *InstallmentController) GetOverdueInstallments(ctx *gin.Context) {
-	creditAccountID, err := strconv.Atoi(ctx.Param("creditAccountID"))
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
 		return
 	}
 
-	// You might want to add authorization logic here as well
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeCreditAccount(requestCaller(ctx), uint(creditAccountID), service.HolderAccess), "Credit account not found") {
+		return
+	}
 
 	overdueInstallments, err := c.installmentService.GetOverdueInstallments(uint(creditAccountID))
 	if err != nil {
@@ -238,3 +291,105 @@ func (c *InstallmentController) GetOverdueInstallments(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, overdueInstallments)
 }
+
+// RescheduleInstallment godoc
+// @Summary      Reschedule Installment
+// @Description  Moves the due date of an unpaid installment, as agreed with the client, keeping the date it had in its reschedule history. The new date goes from tomorrow up to a month after the account's last unpaid installment, and each credit account has at most the reschedules its establishment allows (max_reschedules of its settings). Establishments with reschedule_interest add the account's interest for the days the installment is postponed, to the installment and the balance. An overdue installment moved to a later date is pending again. Only Admins can reschedule installments of their establishments.
+// @Tags         Installments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path      int  true  "Installment ID"
+// @Param        reschedule     body      request.RescheduleInstallmentRequest  true  "New due date and reason"
+// @Success      200  {object}  response.InstallmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /installments/{id}/reschedule [post]
+func (c *InstallmentController) RescheduleInstallment(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid installment ID"})
+		return
+	}
+
+	var req request.RescheduleInstallmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can reschedule installments"})
+		return
+	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeInstallment(requestCaller(ctx), uint(id), service.AdminAccess), "Installment not found") {
+		return
+	}
+
+	installment, err := c.installmentService.RescheduleInstallment(middleware.GetUserIDFromContext(ctx), uint(id), req)
+	if err != nil {
+		if respondVersionError(ctx, err, func() (interface{}, error) { return c.installmentService.GetInstallmentByID(uint(id)) }) {
+			return
+		}
+		switch {
+		case errors.Is(err, service.ErrInstallmentNotFound):
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrInvalidRescheduleDate):
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrInstallmentPaid), errors.Is(err, service.ErrInstallmentCancelled), errors.Is(err, service.ErrRescheduleLimitReached),
+			errors.Is(err, service.ErrCreditAccountClosed), errors.Is(err, service.ErrCreditAccountWrittenOff):
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, installment)
+}
+
+// GetInstallmentReschedules godoc
+// @Summary      Get Installment Reschedules
+// @Description  Lists the reschedules of an installment, oldest first, with the due date it had before each. Only Admins can see the reschedules of installments of their establishments.
+// @Tags         Installments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path      int  true  "Installment ID"
+// @Success      200  {array}   response.InstallmentRescheduleResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /installments/{id}/reschedules [get]
+func (c *InstallmentController) GetInstallmentReschedules(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid installment ID"})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can see installment reschedules"})
+		return
+	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeInstallment(requestCaller(ctx), uint(id), service.AdminAccess), "Installment not found") {
+		return
+	}
+
+	reschedules, err := c.installmentService.GetInstallmentReschedules(middleware.GetUserIDFromContext(ctx), uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrInstallmentNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reschedules)
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobController lets users follow the background jobs they requested and download their results.
+type JobController struct {
+	jobService service.JobService
+}
+
+// NewJobController creates a new instance of JobController.
+func NewJobController(jobService service.JobService) *JobController {
+	return &JobController{jobService: jobService}
+}
+
+// GetJob godoc
+// @Summary      Get Job Status
+// @Description  Returns the status of a background job requested by the authenticated user, e.g. a PDF statement. Jobs and their results are kept for a day after they finish.
+// @Tags         Jobs
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Job ID"
+// @Success      200  {object}  response.JobResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /jobs/{id} [get]
+func (c *JobController) GetJob(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid job ID"})
+		return
+	}
+
+	job, err := c.jobService.GetJob(uint(id), middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(jobErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, job)
+}
+
+// GetJobResult godoc
+// @Summary      Download Job Result
+// @Description  Downloads the file produced by a background job requested by the authenticated user. Answers 409 while the job is queued or running, or when it failed.
+// @Tags         Jobs
+// @Produce      application/octet-stream
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Job ID"
+// @Success      200  {file}    file  "Job result"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /jobs/{id}/result [get]
+func (c *JobController) GetJobResult(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid job ID"})
+		return
+	}
+
+	result, err := c.jobService.GetJobResult(uint(id), middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(jobErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.Header("Content-Disposition", "attachment; filename="+result.Filename)
+	ctx.Data(http.StatusOK, result.ContentType, result.Data)
+}
+
+// jobErrorStatus maps errors from looking up a job to a status code.
+func jobErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrJobNotFound), errors.Is(err, service.ErrJobHasNoResult):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrJobNotFinished), errors.Is(err, service.ErrJobFailed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
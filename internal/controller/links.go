@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"fmt"
+
+	"ApiRestFinance/internal/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setCreditAccountLinks links the response to the resources of a credit account.
+func setCreditAccountLinks(ctx *gin.Context, creditAccountID uint) {
+	base := fmt.Sprintf("/credit-accounts/%d", creditAccountID)
+	versioning.SetLinks(ctx, versioning.Links{
+		"credit_account":   base,
+		"transactions":     base + "/transactions",
+		"installments":     base + "/installments",
+		"statements":       base + "/statements",
+		"payment_promises": base + "/payment-promises",
+		"payment_links":    base + "/payment-links",
+		"agreement":        base + "/agreement",
+		"attachments":      base + "/attachments",
+	})
+}
+
+// setClientAccountLinks links the response to the resources of the authenticated client's credit
+// account, in the establishment the request selected if any (see establishmentSelector).
+func setClientAccountLinks(ctx *gin.Context, establishmentID uint) {
+	selector := ""
+	if establishmentID != 0 {
+		selector = fmt.Sprintf("?establishment_id=%d", establishmentID)
+	}
+	versioning.SetLinks(ctx, versioning.Links{
+		"credit_account":    "/clients/me/credit-account" + selector,
+		"transactions":      "/clients/me/transactions" + selector,
+		"installments":      "/clients/me/installments" + selector,
+		"account_statement": "/clients/me/account-statement" + selector,
+		"statements":        "/clients/me/statements" + selector,
+		"agreement":         "/clients/me/credit-agreement" + selector,
+	})
+}
+
+// requestBaseURL returns the URL of the API version the request came to, on the host it came to,
+// for the URLs handed out to be opened outside the API.
+func requestBaseURL(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + ctx.Request.Host + versioning.FromContext(ctx).BasePath()
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsController exposes operational metrics.
+type MetricsController struct {
+	summaryCache *service.AccountSummaryCache
+}
+
+// NewMetricsController creates a new instance of MetricsController.
+func NewMetricsController(summaryCache *service.AccountSummaryCache) *MetricsController {
+	return &MetricsController{summaryCache: summaryCache}
+}
+
+// GetCacheMetrics godoc
+// @Summary      Get Cache Metrics
+// @Description  Returns hit/miss counters of the account summary and statement caches. Only admins can see them.
+// @Tags         Metrics
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  map[string]cache.Stats
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Router       /metrics/cache [get]
+func (c *MetricsController) GetCacheMetrics(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view metrics"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.summaryCache.Stats())
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/sms"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationController shows admins the notifications texted to their clients and receives the
+// delivery status reports of the SMS provider.
+type NotificationController struct {
+	dispatcher    service.NotificationDispatcher
+	statusReports sms.StatusReports
+}
+
+// NewNotificationController creates a new instance of NotificationController. statusReports is
+// nil when no SMS provider is configured.
+func NewNotificationController(dispatcher service.NotificationDispatcher, statusReports sms.StatusReports) *NotificationController {
+	return &NotificationController{dispatcher: dispatcher, statusReports: statusReports}
+}
+
+// GetSMSDeliveries godoc
+// @Summary      List Texted Notifications
+// @Description  Lists the payment reminders, confirmations, overdue notices and payment links texted to the establishment's clients, newest first, with their delivery status as last reported by the SMS provider. Only Admins can see them.
+// @Tags         Notifications
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.SMSDeliveryResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/sms-deliveries [get]
+func (c *NotificationController) GetSMSDeliveries(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view texted notifications"})
+		return
+	}
+
+	deliveries, err := c.dispatcher.GetSMSDeliveries(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// ReceiveSMSStatus godoc
+// @Summary      Receive SMS Status
+// @Description  Status callback of the texts sent through Twilio, which signs each report with X-Twilio-Signature. Set SMS_STATUS_CALLBACK_URL to this URL.
+// @Tags         Notifications
+// @Accept       x-www-form-urlencoded
+// @Param        X-Twilio-Signature  header    string  true   "Signature of the report"
+// @Param        MessageSid          formData  string  true   "Twilio's SID of the message"
+// @Param        MessageStatus       formData  string  true   "Status of the message"
+// @Param        ErrorCode           formData  string  false  "Why the message wasn't delivered"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /webhooks/sms/status [post]
+func (c *NotificationController) ReceiveSMSStatus(ctx *gin.Context) {
+	if c.statusReports == nil {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "No SMS provider is configured"})
+		return
+	}
+	if err := ctx.Request.ParseForm(); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	report, err := c.statusReports.ParseStatusReport(ctx.GetHeader("X-Twilio-Signature"), ctx.Request.PostForm)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := c.dispatcher.UpdateSMSStatus(*report); err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
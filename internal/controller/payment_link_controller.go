@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaymentLinkController handles the links admins send to clients to pay their credit accounts, and
+// the public pages clients open them on.
+type PaymentLinkController struct {
+	paymentLinkService service.PaymentLinkService
+	baseURL            string
+}
+
+// NewPaymentLinkController creates a new instance of PaymentLinkController. Link URLs are baseURL
+// followed by their token; an empty baseURL points them to the API's own /payment-links/{token} on
+// the host the request came to.
+func NewPaymentLinkController(paymentLinkService service.PaymentLinkService, baseURL string) *PaymentLinkController {
+	return &PaymentLinkController{paymentLinkService: paymentLinkService, baseURL: baseURL}
+}
+
+// CreatePaymentLink godoc
+// @Summary      Create Payment Link
+// @Description  Sends the client of a credit account a link to pay one of its unpaid installments, or an amount no larger than what the account owes, by Yape or Plin without logging in. The link goes to the client's verified email, and to their verified phone if the establishment texts notifications, so one of them must be verified. It lasts 24 hours unless expires_in_hours says otherwise, up to 72, and is marked VIEWED when opened, PAID once the payment made through it is confirmed and EXPIRED if not paid in time. Only Admins of the account's establishment can create them.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                            true  "Bearer {token}"
+// @Param        id             path        int                               true  "Credit Account ID"
+// @Param        link           body        request.CreatePaymentLinkRequest  true  "Installment or amount to pay"
+// @Success      201  {object}  response.PaymentLinkResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/payment-links [post]
+func (c *PaymentLinkController) CreatePaymentLink(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can send payment links"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	var req request.CreatePaymentLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	link, err := c.paymentLinkService.CreatePaymentLink(middleware.GetUserIDFromContext(ctx), uint(id), req, c.linkBaseURL(ctx))
+	if err != nil {
+		respondPaymentLinkError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, link)
+}
+
+// GetPaymentLinks godoc
+// @Summary      List Payment Links
+// @Description  Lists the payment links sent to the client of a credit account, newest first, with their status and the payment made through them. Only Admins of the account's establishment can list them.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Success      200  {array}   response.PaymentLinkResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/payment-links [get]
+func (c *PaymentLinkController) GetPaymentLinks(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list payment links"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	links, err := c.paymentLinkService.GetPaymentLinks(middleware.GetUserIDFromContext(ctx), uint(id), c.linkBaseURL(ctx))
+	if err != nil {
+		respondPaymentLinkError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, links)
+}
+
+// GetPaymentLinkQRCode godoc
+// @Summary      Get Payment Link QR Code
+// @Description  Renders the URL of a payment link as a QR code, for the client to scan and pay from their phone. Only Admins of the account's establishment can get it.
+// @Tags         Credit Accounts
+// @Produce      image/png
+// @Produce      image/svg+xml
+// @Param        Authorization  header      string  true   "Bearer {token}"
+// @Param        id             path        int     true   "Credit Account ID"
+// @Param        linkID         path        int     true   "Payment Link ID"
+// @Param        format         query       string  false  "png (default) or svg"
+// @Param        size           query       int     false  "Pixels on each side, 64 to 1024. Defaults to 256"
+// @Success      200  {file}    image/png  "QR code"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/payment-links/{linkID}/qr [get]
+func (c *PaymentLinkController) GetPaymentLinkQRCode(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can get payment link QR codes"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	linkID, err := strconv.Atoi(ctx.Param("linkID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid payment link ID"})
+		return
+	}
+
+	link, err := c.paymentLinkService.GetPaymentLink(middleware.GetUserIDFromContext(ctx), uint(id), uint(linkID), c.linkBaseURL(ctx))
+	if err != nil {
+		respondPaymentLinkError(ctx, err)
+		return
+	}
+	respondQRCode(ctx, link.URL)
+}
+
+// ViewPaymentLink godoc
+// @Summary      View Payment Link
+// @Description  Shows the client what a payment link asks them to pay, and the payment they made through it if any. It needs no login: the token in the URL is signed by the API. The first view marks the link VIEWED.
+// @Tags         Payment Links
+// @Produce      json
+// @Param        token  path      string  true  "Token of the link"
+// @Success      200  {object}  response.PaymentLinkPageResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /payment-links/{token} [get]
+func (c *PaymentLinkController) ViewPaymentLink(ctx *gin.Context) {
+	page, err := c.paymentLinkService.ViewPaymentLink(ctx.Param("token"))
+	if err != nil {
+		respondPaymentLinkError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, page)
+}
+
+// PayPaymentLink godoc
+// @Summary      Pay Payment Link
+// @Description  Pays the amount of a payment link by Yape or Plin. The payment is made on the credit account, pending until an admin confirms it with the payment code in the response, and the link is marked PAID once it is. A link is only paid through once.
+// @Tags         Payment Links
+// @Accept       json
+// @Produce      json
+// @Param        token    path      string                         true  "Token of the link"
+// @Param        payment  body      request.PayPaymentLinkRequest  true  "Payment method"
+// @Success      201  {object}  response.TransactionResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      410  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /payment-links/{token}/pay [post]
+func (c *PaymentLinkController) PayPaymentLink(ctx *gin.Context) {
+	var req request.PayPaymentLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	transaction, err := c.paymentLinkService.PayPaymentLink(ctx.Param("token"), req)
+	if err != nil {
+		respondPaymentLinkError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, transaction)
+}
+
+// linkBaseURL returns what the URLs of payment links start with, before their token.
+func (c *PaymentLinkController) linkBaseURL(ctx *gin.Context) string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+	return requestBaseURL(ctx) + "/payment-links/"
+}
+
+// respondPaymentLinkError writes the response for an error of a payment link operation.
+func respondPaymentLinkError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrCreditAccountNotFound), errors.Is(err, service.ErrInstallmentNotFound), errors.Is(err, service.ErrPaymentLinkNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidPaymentLinkAmount):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrNothingToPayOff), errors.Is(err, service.ErrInstallmentPaid), errors.Is(err, service.ErrInstallmentCancelled), errors.Is(err, service.ErrNoVerifiedContact), errors.Is(err, service.ErrPaymentLinkUsed):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrPaymentLinkExpired):
+		status = http.StatusGone
+	}
+	ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+}
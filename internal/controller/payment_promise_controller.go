@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaymentPromiseController handles the promises to pay negotiated with overdue clients.
+type PaymentPromiseController struct {
+	paymentPromiseService service.PaymentPromiseService
+}
+
+// NewPaymentPromiseController creates a new instance of PaymentPromiseController.
+func NewPaymentPromiseController(paymentPromiseService service.PaymentPromiseService) *PaymentPromiseController {
+	return &PaymentPromiseController{paymentPromiseService: paymentPromiseService}
+}
+
+// CreatePaymentPromise godoc
+// @Summary      Create Payment Promise
+// @Description  Records a promise to pay (compromiso de pago) negotiated with the client of a credit account: the amount they will pay by a date. Late fees are suspended until the date passes, and the promise is then marked KEPT if the payments made since add up to the amount, or BROKEN otherwise. Broken promises lower the client's credit score. An account has one active promise at a time. Only Admins of the account's establishment can create them.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                               true  "Bearer {token}"
+// @Param        id             path        int                                  true  "Credit Account ID"
+// @Param        promise        body        request.CreatePaymentPromiseRequest  true  "Promised amount and date"
+// @Success      201  {object}  response.PaymentPromiseResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/payment-promises [post]
+func (c *PaymentPromiseController) CreatePaymentPromise(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can record payment promises"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+	var req request.CreatePaymentPromiseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	promise, err := c.paymentPromiseService.CreatePaymentPromise(middleware.GetUserIDFromContext(ctx), uint(id), req)
+	if err != nil {
+		respondPaymentPromiseError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, promise)
+}
+
+// GetPaymentPromises godoc
+// @Summary      List Payment Promises
+// @Description  Lists the promises to pay of a credit account, newest first, with whether they were kept. Only Admins of the account's establishment can list them.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Success      200  {array}   response.PaymentPromiseResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/payment-promises [get]
+func (c *PaymentPromiseController) GetPaymentPromises(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list payment promises"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	promises, err := c.paymentPromiseService.GetPaymentPromises(middleware.GetUserIDFromContext(ctx), uint(id))
+	if err != nil {
+		respondPaymentPromiseError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, promises)
+}
+
+// respondPaymentPromiseError writes the response for an error of a payment promise operation.
+func respondPaymentPromiseError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrCreditAccountNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidPromiseDate):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrPaymentPromiseExists), errors.Is(err, service.ErrNothingToPayOff):
+		status = http.StatusConflict
+	}
+	ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+}
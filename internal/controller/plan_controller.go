@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlanController handles the plans establishments are on.
+type PlanController struct {
+	planService service.PlanService
+}
+
+// NewPlanController creates a new instance of PlanController.
+func NewPlanController(planService service.PlanService) *PlanController {
+	return &PlanController{planService: planService}
+}
+
+// GetEstablishmentPlan godoc
+// @Summary      Get Establishment Plan
+// @Description  Retrieves the plan of the establishment, which its branches are on too: the clients, products and branches it allows and the features it includes, with how much of each quota the establishment and its branches use. Quotas are soft: past the limit, what the establishment has keeps working but nothing new can be added until the plan is upgraded. Only Admins can see it.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.EstablishmentPlanResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/plan [get]
+func (c *PlanController) GetEstablishmentPlan(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view the establishment's plan"})
+		return
+	}
+
+	establishmentPlan, err := c.planService.GetEstablishmentPlan(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		respondPlanError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, establishmentPlan)
+}
+
+// GetPlans godoc
+// @Summary      List Plans
+// @Description  Lists the plans establishments can be on, from the smallest to the largest. A quota of 0 is unlimited, and one of -1 allows none. Only Super-admins can list them, and the request is audited.
+// @Tags         Platform
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {array}   response.PlanResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/plans [get]
+func (c *PlanController) GetPlans(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.planService.GetPlans())
+}
+
+// AssignPlan godoc
+// @Summary      Assign Plan
+// @Description  Moves a main establishment, with its branches, to a plan. Moving to a smaller plan keeps what the establishment already has past the new limits, but nothing new can be added. Only Super-admins can assign plans, and the request is audited.
+// @Tags         Platform
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                     true  "Bearer {token}"
+// @Param        id             path        int                        true  "Establishment ID"
+// @Param        plan           body        request.AssignPlanRequest  true  "Plan"
+// @Success      200  {object}  response.EstablishmentPlanResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/establishments/{id}/plan [put]
+func (c *PlanController) AssignPlan(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid establishment ID"})
+		return
+	}
+	var req request.AssignPlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	establishmentPlan, err := c.planService.AssignPlan(uint(id), req)
+	if err != nil {
+		respondPlanError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, establishmentPlan)
+}
+
+// respondPlanError maps the errors of the plan endpoints to their status.
+func respondPlanError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEstablishmentNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrPlanOfBranch):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
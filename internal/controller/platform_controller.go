@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlatformController handles what super-admins do across the establishments of the platform. Its
+// routes are guarded and audited by middleware.PlatformMiddleware.
+type PlatformController struct {
+	platformService service.PlatformService
+}
+
+// NewPlatformController creates a new instance of PlatformController.
+func NewPlatformController(platformService service.PlatformService) *PlatformController {
+	return &PlatformController{platformService: platformService}
+}
+
+// GetEstablishments godoc
+// @Summary      List Platform Establishments
+// @Description  Lists the main establishments of the platform, oldest first, with their admin, plan and status, and how many branches and open credit accounts they have. Only Super-admins can list them, and the request is audited.
+// @Tags         Platform
+// @Produce      json
+// @Param        Authorization  header      string  true   "Bearer {token}"
+// @Param        q              query       string  false  "Name or RUC of the establishment, or name or email of its admin"
+// @Param        status         query       string  false  "Status" Enums(ACTIVE, INACTIVE, SUSPENDED)
+// @Success      200  {array}   response.PlatformEstablishmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/establishments [get]
+func (c *PlatformController) GetEstablishments(ctx *gin.Context) {
+	var req request.SearchPlatformEstablishmentsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	establishments, err := c.platformService.GetEstablishments(req)
+	if err != nil {
+		respondPlatformError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, establishments)
+}
+
+// SuspendEstablishment godoc
+// @Summary      Suspend Establishment
+// @Description  Suspends a main establishment, with its branches, and logs its admin out of every session. Until it is reinstated, the admin can't log in and the establishment takes no purchases or new clients; its clients can still see their accounts and pay. Only Super-admins can suspend establishments, and the request is audited.
+// @Tags         Platform
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                               true  "Bearer {token}"
+// @Param        id             path        int                                  true  "Establishment ID"
+// @Param        suspension     body        request.SuspendEstablishmentRequest  true  "Reason of the suspension"
+// @Success      200  {object}  response.PlatformEstablishmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/establishments/{id}/suspend [post]
+func (c *PlatformController) SuspendEstablishment(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid establishment ID"})
+		return
+	}
+	var req request.SuspendEstablishmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	establishment, err := c.platformService.SuspendEstablishment(uint(id), req)
+	if err != nil {
+		respondPlatformError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, establishment)
+}
+
+// ReinstateEstablishment godoc
+// @Summary      Reinstate Establishment
+// @Description  Lifts the suspension of a main establishment, with its branches. Its admin can log in again. Only Super-admins can reinstate establishments, and the request is audited.
+// @Tags         Platform
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Establishment ID"
+// @Success      200  {object}  response.PlatformEstablishmentResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/establishments/{id}/reinstate [post]
+func (c *PlatformController) ReinstateEstablishment(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid establishment ID"})
+		return
+	}
+
+	establishment, err := c.platformService.ReinstateEstablishment(uint(id))
+	if err != nil {
+		respondPlatformError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, establishment)
+}
+
+// ResetAdminPassword godoc
+// @Summary      Reset Admin Password
+// @Description  Gives the admin of a main establishment a new temporary password and logs them out of every session. The password is emailed to the admin and never returned. Only Super-admins can reset admin passwords, and the request is audited.
+// @Tags         Platform
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Establishment ID"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/establishments/{id}/admin/reset-password [post]
+func (c *PlatformController) ResetAdminPassword(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid establishment ID"})
+		return
+	}
+
+	if err := c.platformService.ResetAdminPassword(uint(id)); err != nil {
+		respondPlatformError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetMetrics godoc
+// @Summary      Get Platform Metrics
+// @Description  Adds up the establishments, users and credit accounts of the whole platform, with the purchases, payments and credit accounts opened over the last 30 days. Figures may lag a little behind. Only Super-admins can see them, and the request is audited.
+// @Tags         Platform
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.PlatformMetricsResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/metrics [get]
+func (c *PlatformController) GetMetrics(ctx *gin.Context) {
+	metrics, err := c.platformService.GetMetrics()
+	if err != nil {
+		respondPlatformError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, metrics)
+}
+
+// GetAuditLog godoc
+// @Summary      Get Platform Audit Log
+// @Description  Lists the latest 500 requests made to the platform endpoints, newest first, including denied ones and this one. Each entry is recorded before the request is handled and completed with the status it was answered with. Only Super-admins can see it, and the request is audited.
+// @Tags         Platform
+// @Produce      json
+// @Param        Authorization  header      string  true   "Bearer {token}"
+// @Param        actor_id       query       int     false  "User who made the requests"
+// @Param        start_date     query       string  false  "First day (2026-10-14) or instant (2026-10-14T09:30:00-05:00), ISO-8601"
+// @Param        end_date       query       string  false  "Last day (included) or instant, ISO-8601"
+// @Success      200  {array}   response.PlatformAuditEntryResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Failure      503  {object}  response.ErrorResponse
+// @Router       /platform/audit-log [get]
+func (c *PlatformController) GetAuditLog(ctx *gin.Context) {
+	var req request.SearchPlatformAuditLogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	entries, err := c.platformService.GetAuditLog(req)
+	if err != nil {
+		respondPlatformError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// respondPlatformError maps the errors of the platform endpoints to their status.
+func respondPlatformError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEstablishmentNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrPlatformBranch), errors.Is(err, util.ErrInvalidDate), errors.Is(err, util.ErrInvalidDateRange):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrAlreadySuspended), errors.Is(err, service.ErrNotSuspended):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
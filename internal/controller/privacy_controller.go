@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyController handles the export and erasure of the personal data of clients.
+type PrivacyController struct {
+	privacyService service.PrivacyService
+}
+
+// NewPrivacyController creates a new instance of PrivacyController.
+func NewPrivacyController(privacyService service.PrivacyService) *PrivacyController {
+	return &PrivacyController{privacyService: privacyService}
+}
+
+// AnonymizeUser godoc
+// @Summary      Anonymize Client
+// @Description  Irreversibly erases the personal data of a client: their name, DNI, email, phone, address, photo and documents are removed, along with their contact details in the notifications sent, their devices and their verification codes, and they are logged out for good. Their credit accounts, transactions and signed agreements are kept for the establishment's books, tied to the anonymized client, so they can only be anonymized once they owe nothing (409 Conflict otherwise). Clients can anonymize themselves; admins can anonymize a client whose every credit account is in one of their establishments.
+// @Tags         Users
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "User ID"
+// @Success      200  {object}  response.UserResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/{id}/anonymize [post]
+func (c *PrivacyController) AnonymizeUser(ctx *gin.Context) {
+	targetID, ok := privacyTarget(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := c.privacyService.AnonymizeUser(middleware.GetUserIDFromContext(ctx), middleware.GetUserRoleFromContext(ctx), targetID)
+	if err != nil {
+		respondPrivacyError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+// ExportUserData godoc
+// @Summary      Export Client Data
+// @Description  Exports all the data held about a client: their profile, credit accounts with their agreements, transactions, installments, payment promises and activity, signups, documents, the notifications sent to them and the impersonations of their account. As json, or as zip for an archive of data.json with the files of their documents. Clients export their own data across every establishment, with their sessions; admins export the data of a client of their establishment (or of the branch of X-Branch-ID) concerning it.
+// @Tags         Users
+// @Produce      json
+// @Produce      application/zip
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int     true  "User ID"
+// @Param        format         query       string  false "json or zip. Defaults to json"
+// @Success      200  {object}  response.UserDataExportResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/{id}/export [get]
+func (c *PrivacyController) ExportUserData(ctx *gin.Context) {
+	targetID, ok := privacyTarget(ctx)
+	if !ok {
+		return
+	}
+	userID, role, branchID := middleware.GetUserIDFromContext(ctx), middleware.GetUserRoleFromContext(ctx), middleware.GetBranchIDFromContext(ctx)
+
+	switch ctx.DefaultQuery("format", "json") {
+	case "json":
+		export, err := c.privacyService.ExportUserData(userID, role, branchID, targetID)
+		if err != nil {
+			respondPrivacyError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, export)
+	case "zip":
+		archive, err := c.privacyService.ExportUserArchive(userID, role, branchID, targetID)
+		if err != nil {
+			respondPrivacyError(ctx, err)
+			return
+		}
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=user_%d_data.zip", targetID))
+		ctx.Data(http.StatusOK, "application/zip", archive)
+	default:
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid format, use json or zip"})
+	}
+}
+
+// privacyTarget parses the user a privacy request is about. Clients may only make them about
+// themselves; anyone else gets 403.
+func privacyTarget(ctx *gin.Context) (uint, bool) {
+	targetID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user ID"})
+		return 0, false
+	}
+	role := middleware.GetUserRoleFromContext(ctx)
+	if role != enums.ADMIN && (role != enums.CLIENT || middleware.GetUserIDFromContext(ctx) != uint(targetID)) {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Not authorized to access this user"})
+		return 0, false
+	}
+	return uint(targetID), true
+}
+
+// respondPrivacyError writes the response for an error of an export or anonymization.
+func respondPrivacyError(ctx *gin.Context, err error) {
+	var status int
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrUserNotClient):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrClientHasOtherAccounts), errors.Is(err, service.ErrClientOwesBalance),
+		errors.Is(err, service.ErrUserAnonymized):
+		status = http.StatusConflict
+	default:
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+}
@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"ApiRestFinance/internal/middleware"
 	"ApiRestFinance/internal/model/dto/request"
@@ -11,6 +13,7 @@ import (
 	"ApiRestFinance/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // ProductController handles product-related endpoints.
@@ -26,15 +29,17 @@ func NewProductController(productService service.ProductService, establishmentSe
 
 // CreateProduct godoc
 // @Summary      Create Product
-// @Description  Creates a new product for the authenticated admin\'s establishment.
+// @Description  Creates a new product for the authenticated admin\'s establishment. SKUs and EAN-13 barcodes are optional, and unique within the establishment.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string                  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        product        body      request.CreateProductRequest  true  "Product data"
 // @Success      201  {object}  response.ProductResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /products [post]
@@ -53,7 +58,7 @@ func (c *ProductController) CreateProduct(ctx *gin.Context) {
 
 	userId := middleware.GetUserIDFromContext(ctx)
 
-	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId)
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId, middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
 		return
@@ -66,7 +71,7 @@ func (c *ProductController) CreateProduct(ctx *gin.Context) {
 
 	product, err := c.productService.CreateProduct(req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(productErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -75,13 +80,19 @@ func (c *ProductController) CreateProduct(ctx *gin.Context) {
 
 // GetProductByID godoc
 // @Summary      Get Product by ID
-// @Description  Gets a product by its ID.
+// @Description  Gets a product by its ID. Supports conditional requests: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the product is unchanged.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        id             path      int  true  "Product ID"
+// @Param        If-None-Match  header      string  false "ETag of a cached response, answered with 304 Not Modified while it is current"
+// @Param        If-Modified-Since  header  string  false "Last-Modified of a cached response, answered with 304 Not Modified while it is current"
 // @Success      200  {object}  response.ProductResponse
+// @Success      304  "The cached response is current"
+// @Header       200,304  {string}  ETag           "Version of the response, for If-None-Match"
+// @Header       200,304  {string}  Last-Modified  "When the response last changed, for If-Modified-Since"
+// @Header       200,304  {string}  Cache-Control  "private, no-cache: revalidate before reusing"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
@@ -99,18 +110,27 @@ func (c *ProductController) GetProductByID(ctx *gin.Context) {
 		return
 	}
 
+	lastModified := latestChange(product.UpdatedAt, product.Establishment.UpdatedAt)
+	if notModified(ctx, lastModified, productVersion(product)) {
+		return
+	}
 	ctx.JSON(http.StatusOK, product)
 }
 
 // GetAllProductsByEstablishmentID godoc
 // @Summary      Get Products by Establishment ID
-// @Description  Gets all products associated with an establishment.
+// @Description  Gets all products associated with an establishment, optionally only those of one of its categories. Supports conditional requests: send the ETag back in If-None-Match to get 304 Not Modified while no product changed.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        establishmentID   path      int  true  "Establishment ID"
+// @Param        category_id    query     int  false "Only list the products of this category"
+// @Param        If-None-Match  header      string  false "ETag of a cached response, answered with 304 Not Modified while it is current"
 // @Success      200  {array}   response.ProductResponse
+// @Success      304  "The cached response is current"
+// @Header       200,304  {string}  ETag           "Version of the response, for If-None-Match"
+// @Header       200,304  {string}  Cache-Control  "private, no-cache: revalidate before reusing"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /establishments/{establishmentID}/products [get]
@@ -127,29 +147,78 @@ func (c *ProductController) GetAllProductsByEstablishmentID(ctx *gin.Context) {
 		return
 	}
 
-	products, err := c.productService.GetAllProductsByEstablishmentID(uint(establishmentID))
+	categoryID, err := strconv.ParseUint(ctx.DefaultQuery("category_id", "0"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid category ID"})
+		return
+	}
+
+	products, err := c.productService.GetAllProductsByEstablishmentID(uint(establishmentID), uint(categoryID))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	versions := make([]string, 0, len(products))
+	for i := range products {
+		versions = append(versions, productVersion(&products[i]))
+	}
+	if notModified(ctx, time.Time{}, versions...) {
+		return
+	}
 	ctx.JSON(http.StatusOK, products)
 }
 
+// LookupProduct godoc
+// @Summary      Look Up Product by Barcode
+// @Description  Finds the product of the establishment with an EAN-13 barcode, for scanning products at the point of sale. Only Admins can look up products.
+// @Tags         Products
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        barcode        query     string  true  "EAN-13 barcode"
+// @Success      200  {object}  response.ProductResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/products/lookup [get]
+func (c *ProductController) LookupProduct(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can look up products"})
+		return
+	}
+
+	product, err := c.productService.LookupProductByBarcode(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), ctx.Query("barcode"))
+	if errors.Is(err, service.ErrInvalidBarcode) || errors.Is(err, service.ErrProductNotFound) {
+		ctx.JSON(productErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, product)
+}
+
 // UpdateProduct godoc
 // @Summary      Update Product
-// @Description  Updates an existing product. Only admins can update products.
+// @Description  Updates an existing product. An empty SKU or barcode removes it. Only admins of the product's establishment can update it; products of other establishments are not found. Send the version of the product last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the product as it is now.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        id             path      int                      true  "Product ID"
 // @Param        product        body      request.UpdateProductRequest  true  "Updated product data"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
 // @Success      200  {object}  response.ProductResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /products/{id} [put]
 func (c *ProductController) UpdateProduct(ctx *gin.Context) {
@@ -164,25 +233,152 @@ func (c *ProductController) UpdateProduct(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
 
 	// Check user role - Only Admins can update products
 	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can update products"})
 		return
 	}
+	if !c.authorizeProduct(ctx, uint(productID)) {
+		return
+	}
 
 	updatedProduct, err := c.productService.UpdateProduct(uint(productID), req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		if respondVersionError(ctx, err, func() (interface{}, error) { return c.productService.GetProductByID(uint(productID)) }) {
+			return
+		}
+		ctx.JSON(productErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, updatedProduct)
 }
 
+// PatchProduct godoc
+// @Summary      Patch Product
+// @Description  Changes only the product fields the request sets. Unlike PUT, zero and false values are set too, e.g. a stock of 0. Fields left out or null are kept. An empty SKU or barcode removes it. Only admins of the product's establishment can update it; products of other establishments are not found. Send the version of the product last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the product as it is now.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path      int                      true  "Product ID"
+// @Param        product        body      request.PatchProductRequest  true  "Product fields to change"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
+// @Success      200  {object}  response.ProductResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /products/{id} [patch]
+func (c *ProductController) PatchProduct(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+
+	var req request.PatchProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
+
+	// Check user role - Only Admins can update products
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can update products"})
+		return
+	}
+	if !c.authorizeProduct(ctx, uint(productID)) {
+		return
+	}
+
+	updatedProduct, err := c.productService.PatchProduct(uint(productID), req)
+	if err != nil {
+		if respondVersionError(ctx, err, func() (interface{}, error) { return c.productService.GetProductByID(uint(productID)) }) {
+			return
+		}
+		ctx.JSON(productErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updatedProduct)
+}
+
+// UploadProductImage godoc
+// @Summary      Upload Product Image
+// @Description  Uploads an image for a product. The image is validated, resized to the standard sizes and set as the product image. Only admins of the product's establishment can upload it.
+// @Tags         Products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path      int  true  "Product ID"
+// @Param        image          formData      file  true  "Product image"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /products/{id}/image [post]
+func (c *ProductController) UploadProductImage(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid product ID"})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can upload product images"})
+		return
+	}
+
+	file, err := ctx.FormFile("image")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Error uploading file: " + err.Error()})
+		return
+	}
+
+	product, err := c.productService.GetProductByID(uint(productID))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Product not found"})
+		return
+	}
+
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil || establishment.ID != product.EstablishmentID {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Forbidden: product does not belong to your establishment"})
+		return
+	}
+
+	imageURL, err := c.productService.UploadProductImage(file, uint(productID))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidFileType) || errors.Is(err, service.ErrFileSizeTooLarge) ||
+			errors.Is(err, service.ErrInvalidImage) || errors.Is(err, service.ErrInvalidImageDimensions) ||
+			errors.Is(err, service.ErrImageRejected) {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error uploading image: " + err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"image_url": imageURL})
+}
+
 // DeleteProduct godoc
 // @Summary      Delete Product
-// @Description  Deletes a product by its ID. Only Admins can delete products.
+// @Description  Deletes a product by its ID. Only admins of the product's establishment can delete it; products of other establishments are not found.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
@@ -207,6 +403,9 @@ func (c *ProductController) DeleteProduct(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete products"})
 		return
 	}
+	if !c.authorizeProduct(ctx, uint(productID)) {
+		return
+	}
 
 	if err := c.productService.DeleteProduct(uint(productID)); err != nil {
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
@@ -215,3 +414,34 @@ func (c *ProductController) DeleteProduct(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent) // 204 No Content on successful deletion
 }
+
+// authorizeProduct answers with 404 and returns false unless the product is of an establishment of
+// the tenant of the request, so admins can't tell the products of other establishments exist.
+func (c *ProductController) authorizeProduct(ctx *gin.Context, productID uint) bool {
+	product, err := c.productService.GetProductByID(productID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, service.ErrProductNotFound) ||
+		(err == nil && !middleware.GetTenantFromContext(ctx).Allows(product.EstablishmentID)) {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Product not found"})
+		return false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return false
+	}
+	return true
+}
+
+// productErrorStatus maps the errors of creating, updating or looking up a product to their HTTP status.
+func productErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound), errors.Is(err, service.ErrInvalidBarcode):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrSKUExists), errors.Is(err, service.ErrBarcodeExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrUpgradeRequired):
+		return http.StatusPaymentRequired
+	}
+	return http.StatusInternalServerError
+}
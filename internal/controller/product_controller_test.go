@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/testutil/fixture"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// stubProductService holds products by ID and records which were changed, without storing changes.
+type stubProductService struct {
+	service.ProductService
+	products map[uint]response.ProductResponse
+	changed  []uint
+}
+
+func (s *stubProductService) GetProductByID(id uint) (*response.ProductResponse, error) {
+	product, ok := s.products[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &product, nil
+}
+
+func (s *stubProductService) UpdateProduct(id uint, req request.UpdateProductRequest) (*response.ProductResponse, error) {
+	return s.PatchProduct(id, req.Patch())
+}
+
+func (s *stubProductService) PatchProduct(id uint, req request.PatchProductRequest) (*response.ProductResponse, error) {
+	s.changed = append(s.changed, id)
+	return s.GetProductByID(id)
+}
+
+func (s *stubProductService) DeleteProduct(id uint) error {
+	s.changed = append(s.changed, id)
+	return nil
+}
+
+func TestProductChangesAreLimitedToTheCallersEstablishments(t *testing.T) {
+	const ownProductID, otherProductID, missingProductID uint = 1, 2, 3
+	client := caller{userID: fixture.ClientID, role: enums.CLIENT, scope: establishmentAdmin.scope}
+	tests := []struct {
+		name      string
+		as        caller
+		productID uint
+		want      int
+	}{
+		{"an admin changes a product of the establishment", establishmentAdmin, ownProductID, http.StatusOK},
+		{"an admin changes a product of another establishment", establishmentAdmin, otherProductID, http.StatusNotFound},
+		{"an admin changes a missing product", establishmentAdmin, missingProductID, http.StatusNotFound},
+		{"a client changes a product of the establishment", client, ownProductID, http.StatusForbidden},
+	}
+	for _, method := range []string{http.MethodPatch, http.MethodPut, http.MethodDelete} {
+		for _, tt := range tests {
+			t.Run(method+" "+tt.name, func(t *testing.T) {
+				products := &stubProductService{products: map[uint]response.ProductResponse{
+					ownProductID:   {ID: ownProductID, EstablishmentID: fixture.EstablishmentID},
+					otherProductID: {ID: otherProductID, EstablishmentID: fixture.EstablishmentID + 1},
+				}}
+				c := NewProductController(products, nil)
+				router := newTestRouter(tt.as)
+				router.PATCH("/products/:id", c.PatchProduct)
+				router.PUT("/products/:id", c.UpdateProduct)
+				router.DELETE("/products/:id", c.DeleteProduct)
+
+				path := fmt.Sprintf("/products/%d", tt.productID)
+				recorder := serve(t, router, method, path, map[string]interface{}{"name": "Arroz", "version": 1})
+				want := tt.want
+				if method == http.MethodDelete && want == http.StatusOK {
+					want = http.StatusNoContent
+				}
+				if recorder.Code != want {
+					t.Fatalf("%s %s = %d %s, want %d", method, path, recorder.Code, recorder.Body, want)
+				}
+				if changed := len(products.changed) > 0; changed != (tt.want == http.StatusOK) {
+					t.Errorf("product changed = %t with status %d", changed, recorder.Code)
+				}
+			})
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"ApiRestFinance/internal/middleware"
@@ -9,6 +11,7 @@ import (
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/util"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,16 +27,18 @@ func NewPurchaseController(purchaseService service.PurchaseService) *PurchaseCon
 
 // CreatePurchase godoc
 // @Summary      Create a Purchase
-// @Description  Processes a product purchase by a user.
+// @Description  Processes a product purchase by a user. Purchases above the approval threshold of the establishment are not made yet: they wait for an admin's approval, which is returned with 202 Accepted, and don't affect the balance unless approved before they expire. Purchases are refused with 403 until the client accepts the credit agreement of the account.
 // @Tags         Purchases
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        purchase         body      request.CreatePurchaseRequest  true  "Purchase Data"
 // @Success      201  {object}  map[string]string
+// @Success      202  {object}  response.PurchaseApprovalResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /purchases [post]
 func (c *PurchaseController) CreatePurchase(ctx *gin.Context) {
@@ -59,41 +64,260 @@ func (c *PurchaseController) CreatePurchase(ctx *gin.Context) {
 		return
 	}
 
-	err := c.purchaseService.ProcessPurchase(userID, req.EstablishmentID, req.ProductIDs, req.CreditType, req.Amount)
+	approval, err := c.purchaseService.ProcessPurchase(userID, req.EstablishmentID, req.LineItems(), req.CreditType, req.Amount)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if approval != nil {
+		ctx.JSON(http.StatusAccepted, approval)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, gin.H{"message": "Purchase created successfully"})
 }
 
+// GetPurchaseApprovals godoc
+// @Summary      List Purchase Approvals
+// @Description  Lists the client purchases of the establishment that were above its approval threshold, oldest first. Only Admins can see them.
+// @Tags         Purchases
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        status         query     string  false "Only list approvals in this status: PENDING_APPROVAL, APPROVED, REJECTED or EXPIRED"
+// @Success      200  {array}   response.PurchaseApprovalResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/purchase-approvals [get]
+func (c *PurchaseController) GetPurchaseApprovals(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view purchase approvals"})
+		return
+	}
+
+	status := enums.ApprovalStatus(ctx.Query("status"))
+	switch status {
+	case "", enums.ApprovalPending, enums.ApprovalApproved, enums.ApprovalRejected, enums.ApprovalExpired:
+	default:
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid status"})
+		return
+	}
+
+	approvals, err := c.purchaseService.GetPurchaseApprovals(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), status)
+	if err != nil {
+		respondPurchaseApprovalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, approvals)
+}
+
+// ApprovePurchase godoc
+// @Summary      Approve Purchase
+// @Description  Approves a purchase waiting for approval, charging it to the client's credit account if the account can still afford it. The client is notified. Only Admins can approve purchases.
+// @Tags         Purchases
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path      int     true  "Purchase approval ID"
+// @Success      200  {object}  response.PurchaseApprovalResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/purchase-approvals/{id}/approve [post]
+func (c *PurchaseController) ApprovePurchase(ctx *gin.Context) {
+	approvalID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid purchase approval ID"})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can approve purchases"})
+		return
+	}
+
+	approval, err := c.purchaseService.ApprovePurchase(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(approvalID))
+	if err != nil {
+		respondPurchaseApprovalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, approval)
+}
+
+// RejectPurchase godoc
+// @Summary      Reject Purchase
+// @Description  Rejects a purchase waiting for approval. The client is notified with the reason. Only Admins can reject purchases.
+// @Tags         Purchases
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path      int     true  "Purchase approval ID"
+// @Param        rejection      body      request.RejectPurchaseRequest  true  "Reason for the rejection"
+// @Success      200  {object}  response.PurchaseApprovalResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/purchase-approvals/{id}/reject [post]
+func (c *PurchaseController) RejectPurchase(ctx *gin.Context) {
+	approvalID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid purchase approval ID"})
+		return
+	}
+
+	var req request.RejectPurchaseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can reject purchases"})
+		return
+	}
+
+	approval, err := c.purchaseService.RejectPurchase(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(approvalID), req.Reason)
+	if err != nil {
+		respondPurchaseApprovalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, approval)
+}
+
+// QuotePurchase godoc
+// @Summary      Quote a Purchase
+// @Description  Prices a credit purchase before making it: line totals with their tax, the credit available before and after it, the installments a long-term purchase would be split into, and the conditions that would reject it (blocked account, over the credit limit, over the limit of high-risk clients). Nothing is saved. Only Clients can quote purchases.
+// @Tags         Purchases
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        quote          body      request.PurchaseQuoteRequest  true  "Products to buy"
+// @Success      200  {object}  response.PurchaseQuoteResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /purchases/quote [post]
+func (c *PurchaseController) QuotePurchase(ctx *gin.Context) {
+	var req request.PurchaseQuoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can quote purchases"})
+		return
+	}
+
+	if req.CreditType != enums.ShortTerm && req.CreditType != enums.LongTerm {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit type"})
+		return
+	}
+
+	quote, err := c.purchaseService.QuotePurchase(middleware.GetUserIDFromContext(ctx), req.EstablishmentID, req.LineItems(), req.CreditType)
+	if err != nil {
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, quote)
+}
+
+// RecordCashSale godoc
+// @Summary      Record a Cash Sale
+// @Description  Records products the admin's establishment sold for cash, so they count in its sales reports. Only Admins can record cash sales.
+// @Tags         Purchases
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        sale           body      request.CreateCashSaleRequest  true  "Products sold"
+// @Success      201  {object}  map[string]string
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/cash-sales [post]
+func (c *PurchaseController) RecordCashSale(ctx *gin.Context) {
+	var req request.CreateCashSaleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Only Admins can record cash sales
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can record cash sales"})
+		return
+	}
+
+	if err := c.purchaseService.RecordCashSale(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req.Items); err != nil {
+		if errors.Is(err, service.ErrEstablishmentInactive) {
+			ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Cash sale recorded successfully"})
+}
+
 // GetClientBalance godoc
 // @Summary      Get Client Balance
-// @Description  Gets the current balance of the authenticated client's credit account.
+// @Description  Gets the current balance of the authenticated client's credit account, and what is left of its spending limit this week or month if it has one. Supports conditional requests for polling: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the balance is unchanged.
 // @Tags         Clients
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Param        If-None-Match  header      string  false "ETag of a cached response, answered with 304 Not Modified while it is current"
+// @Param        If-Modified-Since  header  string  false "Last-Modified of a cached response, answered with 304 Not Modified while it is current"
 // @Success      200  {object}  response.ClientBalanceResponse
+// @Success      304  "The cached response is current"
+// @Header       200,304  {string}  ETag           "Version of the response, for If-None-Match"
+// @Header       200,304  {string}  Last-Modified  "When the response last changed, for If-Modified-Since"
+// @Header       200,304  {string}  Cache-Control  "private, no-cache: revalidate before reusing"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/balance [get]
 func (c *PurchaseController) GetClientBalance(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
 
-	balance, err := c.purchaseService.GetClientBalance(userID)
+	balance, err := c.purchaseService.GetClientBalance(userID, establishmentID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	resp := response.ClientBalanceResponse{
-		ClientID:       userID,
-		CurrentBalance: balance,
+	lastModified := balance.UpdatedAt
+	version := resourceVersion(balance.ClientID, balance.UpdatedAt)
+	if balance.SpendingAllowance != nil {
+		// The allowance starts over with each period without the account changing
+		lastModified = latestChange(lastModified, balance.SpendingAllowance.PeriodStart)
+		version = resourceVersion(balance.ClientID, balance.UpdatedAt, balance.SpendingAllowance.PeriodStart.UnixNano())
+	}
+	if notModified(ctx, lastModified, version) {
+		return
 	}
-	ctx.JSON(http.StatusOK, resp)
+	ctx.JSON(http.StatusOK, balance)
 }
 
 // GetClientTransactions godoc
@@ -103,17 +327,23 @@ func (c *PurchaseController) GetClientBalance(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
 // @Success      200  {array}   response.TransactionResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/transactions [get]
 func (c *PurchaseController) GetClientTransactions(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
 
-	transactions, err := c.purchaseService.GetClientTransactions(userID)
+	transactions, err := c.purchaseService.GetClientTransactions(userID, establishmentID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -127,17 +357,23 @@ func (c *PurchaseController) GetClientTransactions(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
 // @Success      200  {object}  map[string]float64
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/overdue-balance [get]
 func (c *PurchaseController) GetClientOverdueBalance(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
 
-	overdueBalance, err := c.purchaseService.GetClientOverdueBalance(userID)
+	overdueBalance, err := c.purchaseService.GetClientOverdueBalance(userID, establishmentID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -146,69 +382,142 @@ func (c *PurchaseController) GetClientOverdueBalance(ctx *gin.Context) {
 
 // GetClientInstallments godoc
 // @Summary      Get Client Installments
-// @Description  Gets the installments of the authenticated client's credit account.
+// @Description  Gets the installments of the authenticated client's credit account. Supports conditional requests for polling: send the ETag back in If-None-Match to get 304 Not Modified while no installment changed.
 // @Tags         Clients
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Param        If-None-Match  header      string  false "ETag of a cached response, answered with 304 Not Modified while it is current"
 // @Success      200  {array}   response.InstallmentResponse
+// @Success      304  "The cached response is current"
+// @Header       200,304  {string}  ETag           "Version of the response, for If-None-Match"
+// @Header       200,304  {string}  Cache-Control  "private, no-cache: revalidate before reusing"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/installments [get]
 func (c *PurchaseController) GetClientInstallments(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
 
-	installments, err := c.purchaseService.GetClientInstallments(userID)
+	installments, err := c.purchaseService.GetClientInstallments(userID, establishmentID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	if notModified(ctx, time.Time{}, installmentVersions(installments)...) {
+		return
+	}
 	ctx.JSON(http.StatusOK, installments)
 }
 
 // GetClientCreditAccount godoc
 // @Summary      Get Client Credit Account
-// @Description  Gets the credit account details of the authenticated client.
+// @Description  Gets the credit account details of the authenticated client in one establishment. Supports conditional requests for polling: send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while the account is unchanged.
 // @Tags         Clients
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Param        If-None-Match  header      string  false "ETag of a cached response, answered with 304 Not Modified while it is current"
+// @Param        If-Modified-Since  header  string  false "Last-Modified of a cached response, answered with 304 Not Modified while it is current"
 // @Success      200  {object}  response.CreditAccountResponse
+// @Success      304  "The cached response is current"
+// @Header       200,304  {string}  ETag           "Version of the response, for If-None-Match"
+// @Header       200,304  {string}  Last-Modified  "When the response last changed, for If-Modified-Since"
+// @Header       200,304  {string}  Cache-Control  "private, no-cache: revalidate before reusing"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/credit-account [get]
 func (c *PurchaseController) GetClientCreditAccount(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
 
-	creditAccount, err := c.purchaseService.GetClientCreditAccount(userID)
+	creditAccount, err := c.purchaseService.GetClientCreditAccount(userID, establishmentID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	setClientAccountLinks(ctx, establishmentID)
+	versions := []string{resourceVersion(creditAccount.ID, creditAccount.UpdatedAt)}
+	lastModified := creditAccount.UpdatedAt
+	if creditAccount.Client != nil {
+		versions = append(versions, resourceVersion(creditAccount.Client.ID, creditAccount.Client.UpdatedAt))
+		lastModified = latestChange(lastModified, creditAccount.Client.UpdatedAt)
+	}
+	if creditAccount.Establishment != nil {
+		versions = append(versions, resourceVersion(creditAccount.Establishment.ID, creditAccount.Establishment.UpdatedAt))
+		lastModified = latestChange(lastModified, creditAccount.Establishment.UpdatedAt)
+	}
+	if notModified(ctx, lastModified, versions...) {
+		return
+	}
 	ctx.JSON(http.StatusOK, creditAccount)
 }
 
+// GetClientCreditAccounts godoc
+// @Summary      List Client Credit Accounts
+// @Description  Lists the credit accounts of the authenticated client, one per establishment they buy on credit at.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        fields         query       string  false "Comma-separated top-level fields to return of each item, e.g. id,client_id,current_balance. Defaults to all"
+// @Param        include        query       string  false "Nested objects to embed in each item: client, establishment, both (default) or none with an empty value"
+// @Success      200  {array}   response.CreditAccountResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/credit-accounts [get]
+func (c *PurchaseController) GetClientCreditAccounts(ctx *gin.Context) {
+	fields, ok := parseResponseFields(ctx, "client", "establishment")
+	if !ok {
+		return
+	}
+
+	creditAccounts, err := c.purchaseService.GetClientCreditAccounts(middleware.GetUserIDFromContext(ctx), creditAccountIncludes(fields))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	fields.respond(ctx, http.StatusOK, creditAccounts)
+}
+
 // GetClientAccountSummary godoc
 // @Summary      Get Client Account Summary
 // @Description  Retrieves a summary of the client's account, including transactions, payments, debts, and interest.
 // @Tags         Clients
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
 // @Success      200  {object}  response.AccountSummaryResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/account-summary [get]
 func (c *PurchaseController) GetClientAccountSummary(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
 
-	summary, err := c.purchaseService.GetClientAccountSummary(userID)
+	summary, err := c.purchaseService.GetClientAccountSummary(userID, establishmentID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -217,44 +526,40 @@ func (c *PurchaseController) GetClientAccountSummary(ctx *gin.Context) {
 
 // GetClientAccountStatement godoc
 // @Summary      Get Client Account Statement
-// @Description  Retrieves an account statement for the client within a specified date range.
+// @Description  Retrieves an account statement for the client within a specified date range. Ranges longer than the role's limit are rejected with suggested smaller ranges.
 // @Tags         Clients
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        startDate      query       string  false "Start date (YYYY-MM-DD)"
-// @Param        endDate        query       string  false "End date (YYYY-MM-DD)"
+// @Param        startDate      query       string  false "Start date, ISO-8601 (2026-10-14 or 2026-10-14T09:30:00-05:00). Defaults to the longest range allowed for the caller's role"
+// @Param        endDate        query       string  false "End date, ISO-8601. Defaults to today"
+// @Param        range          query       string  false "Named range instead of startDate and endDate: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
 // @Success      200  {object}  response.AccountStatementResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/account-statement [get]
 func (c *PurchaseController) GetClientAccountStatement(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
-	startDateStr := ctx.Query("startDate")
-	endDateStr := ctx.Query("endDate")
-
-	var startDate, endDate time.Time
-	var err error
-
-	if startDateStr != "" {
-		startDate, err = time.Parse("2006-01-02", startDateStr)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid start date format"})
-			return
-		}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+	startDate, endDate, ok := statementDates(ctx)
+	if !ok {
+		return
 	}
 
-	if endDateStr != "" {
-		endDate, err = time.Parse("2006-01-02", endDateStr)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid end date format"})
-			return
-		}
+	startDate, endDate, err := service.QueryLimitsForRole(middleware.GetUserRoleFromContext(ctx)).ResolveStatementRange(startDate, endDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
 	}
 
-	statement, err := c.purchaseService.GetClientAccountStatement(userID, startDate, endDate)
+	statement, err := c.purchaseService.GetClientAccountStatement(userID, establishmentID, startDate, endDate)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -263,45 +568,37 @@ func (c *PurchaseController) GetClientAccountStatement(ctx *gin.Context) {
 
 // GetClientAccountStatementPDF godoc
 // @Summary      Get Client Account Statement (PDF)
-// @Description  Generates and downloads a PDF account statement for the client within a specified date range.
+// @Description  Generates and downloads a PDF account statement for the client within a specified date range, in the language of the Accept-Language header or else the establishment's. Ranges longer than the role's export limit are rejected with suggested smaller ranges. Long statements are better requested with POST /clients/me/account-statement/pdf/jobs, which renders them in the background.
 // @Tags         Clients
 // @Produce      application/pdf
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param        startDate      query       string  false "Start date (YYYY-MM-DD)"
-// @Param        endDate        query       string  false "End date (YYYY-MM-DD)"
+// @Param        startDate      query       string  false "Start date, ISO-8601 (2026-10-14 or 2026-10-14T09:30:00-05:00). Defaults to the longest range allowed for the caller's role"
+// @Param        endDate        query       string  false "End date, ISO-8601. Defaults to today"
+// @Param        range          query       string  false "Named range instead of startDate and endDate: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Param        Accept-Language  header    string  false "Language of the PDF, es or en. Defaults to the establishment's"
 // @Success      200  {file}   application/pdf  "PDF Account Statement"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/me/account-statement/pdf [get]
 func (c *PurchaseController) GetClientAccountStatementPDF(ctx *gin.Context) {
 	userID := middleware.GetUserIDFromContext(ctx)
-	startDateStr := ctx.Query("startDate")
-	endDateStr := ctx.Query("endDate")
-
-	var startDate, endDate time.Time
-	var err error
-
-	if startDateStr != "" {
-		startDate, err = time.Parse("2006-01-02", startDateStr)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid start date format"})
-			return
-		}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
 	}
-
-	if endDateStr != "" {
-		endDate, err = time.Parse("2006-01-02", endDateStr)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid end date format"})
-			return
-		}
+	startDate, endDate, ok := statementExportRange(ctx)
+	if !ok {
+		return
 	}
 
 	// Get the PDF data from the service
-	pdfBytes, err := c.purchaseService.GenerateClientAccountStatementPDF(userID, startDate, endDate)
+	pdfBytes, err := c.purchaseService.GenerateClientAccountStatementPDF(userID, establishmentID, startDate, endDate, middleware.GetLanguageFromContext(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error generating PDF: " + err.Error()})
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: "Error generating PDF: " + err.Error()})
 		return
 	}
 
@@ -310,3 +607,199 @@ func (c *PurchaseController) GetClientAccountStatementPDF(ctx *gin.Context) {
 	ctx.Header("Content-Disposition", "attachment; filename=account_statement.pdf")
 	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
 }
+
+// CreateClientAccountStatementPDFJob godoc
+// @Summary      Request Client Account Statement (PDF) in the Background
+// @Description  Queues the rendering of the client's PDF account statement within a specified date range, in the language of the Accept-Language header or else the establishment's, and answers right away with the job. Follow it with GET /jobs/{id} and download the PDF from GET /jobs/{id}/result once it succeeded. Ranges longer than the role's export limit are rejected with suggested smaller ranges.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        startDate      query       string  false "Start date, ISO-8601 (2026-10-14 or 2026-10-14T09:30:00-05:00). Defaults to the longest range allowed for the caller's role"
+// @Param        endDate        query       string  false "End date, ISO-8601. Defaults to today"
+// @Param        range          query       string  false "Named range instead of startDate and endDate: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Param        Accept-Language  header    string  false "Language of the PDF, es or en. Defaults to the establishment's"
+// @Success      202  {object}  response.JobResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/account-statement/pdf/jobs [post]
+func (c *PurchaseController) CreateClientAccountStatementPDFJob(ctx *gin.Context) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+	startDate, endDate, ok := statementExportRange(ctx)
+	if !ok {
+		return
+	}
+
+	job, err := c.purchaseService.EnqueueClientAccountStatementPDF(userID, establishmentID, startDate, endDate, middleware.GetLanguageFromContext(ctx))
+	if err != nil {
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusAccepted, job)
+}
+
+// statementExportRange reads the startDate and endDate query parameters of a statement export and
+// applies the export limit of the caller's role. It answers the request and returns false when they're invalid.
+func statementExportRange(ctx *gin.Context) (time.Time, time.Time, bool) {
+	startDate, endDate, ok := statementDates(ctx)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	startDate, endDate, err := service.QueryLimitsForRole(middleware.GetUserRoleFromContext(ctx)).ResolveExportRange(startDate, endDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return time.Time{}, time.Time{}, false
+	}
+	return startDate, endDate, true
+}
+
+// statementDates reads the startDate and endDate query parameters of a statement, or its named range,
+// as the calendar days they fall on; missing ones are zero. It answers the request and returns false
+// when they're invalid.
+func statementDates(ctx *gin.Context) (time.Time, time.Time, bool) {
+	startDate, endDate, err := util.ParseDateRange(ctx.Query("startDate"), ctx.Query("endDate"), ctx.Query("range"), time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return time.Time{}, time.Time{}, false
+	}
+	// Statements cover whole days in the establishment's time zone, see GetClientAccountStatement
+	if !startDate.IsZero() {
+		startDate = util.StartOfDayIn(startDate, startDate.Location())
+	}
+	if !endDate.IsZero() {
+		endDate = util.StartOfDayIn(endDate, endDate.Location())
+	}
+	return startDate, endDate, true
+}
+
+// GetPayoffQuote godoc
+// @Summary      Get Payoff Quote
+// @Description  Returns how much the authenticated client has to pay today to settle their account: outstanding balance plus interest accrued to date, minus the establishment's early-payment discount. The quote expires at the end of the day.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Success      200  {object}  response.PayoffQuoteResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/payoff-quote [get]
+func (c *PurchaseController) GetPayoffQuote(ctx *gin.Context) {
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	quote, err := c.purchaseService.GetPayoffQuote(middleware.GetUserIDFromContext(ctx), establishmentID)
+	if err != nil {
+		if errors.Is(err, service.ErrNothingToPayOff) {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quote)
+}
+
+// PayOff godoc
+// @Summary      Pay Off Account
+// @Description  Settles the authenticated client's account for the payoff_amount of a current quote, in a single transaction. Fails with 409 if the amount no longer matches the quote.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        payoff         body      request.PayoffRequest  true  "Quoted payoff amount"
+// @Param        establishment_id  query     int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Success      200  {object}  response.PayoffQuoteResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/payoff [post]
+func (c *PurchaseController) PayOff(ctx *gin.Context) {
+	var req request.PayoffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	quote, err := c.purchaseService.PayOff(middleware.GetUserIDFromContext(ctx), establishmentID, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNothingToPayOff):
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrPayoffQuoteChanged):
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quote)
+}
+
+// establishmentSelector reads the optional establishment_id query parameter that picks one of the
+// client's credit accounts. It answers 400 and returns false if the parameter is not a valid ID.
+func establishmentSelector(ctx *gin.Context) (uint, bool) {
+	value := ctx.Query("establishment_id")
+	if value == "" {
+		return 0, true
+	}
+	establishmentID, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || establishmentID == 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid establishment ID"})
+		return 0, false
+	}
+	return uint(establishmentID), true
+}
+
+// clientAccountErrorStatus maps errors from looking up a client's credit account to a status code.
+func clientAccountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrEstablishmentRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrCreditAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrHighRiskPurchaseLimit), errors.Is(err, service.ErrCreditAccountBlocked),
+		errors.Is(err, service.ErrSpendingLimitExceeded), errors.Is(err, service.ErrAgreementNotAccepted),
+		errors.Is(err, service.ErrCreditAccountClosed), errors.Is(err, service.ErrEstablishmentInactive):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrUpgradeRequired):
+		return http.StatusPaymentRequired
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondPurchaseApprovalError writes the response for an error of a purchase approval operation.
+func respondPurchaseApprovalError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPurchaseApprovalNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrCreditAccountBlocked), errors.Is(err, service.ErrHighRiskPurchaseLimit),
+		errors.Is(err, service.ErrAgreementNotAccepted), errors.Is(err, service.ErrCreditAccountClosed):
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrPurchaseApprovalDecided), errors.Is(err, service.ErrCreditLimitExceeded):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
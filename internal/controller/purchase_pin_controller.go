@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PurchasePinController lets clients manage the PIN they confirm purchases charged by admins with.
+type PurchasePinController struct {
+	pinService service.PurchasePinService
+}
+
+// NewPurchasePinController creates a new instance of PurchasePinController.
+func NewPurchasePinController(pinService service.PurchasePinService) *PurchasePinController {
+	return &PurchasePinController{pinService: pinService}
+}
+
+// GetPurchasePin godoc
+// @Summary      Get Purchase PIN
+// @Description  Tells whether the authenticated client set a purchase PIN and whether it is locked. Admins need it to charge purchases above the PIN threshold of the establishment to the client's account. Only clients have a purchase PIN.
+// @Tags         Users
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.PurchasePinResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/purchase-pin [get]
+func (c *PurchasePinController) GetPurchasePin(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients have a purchase PIN"})
+		return
+	}
+
+	pin, err := c.pinService.GetPurchasePin(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, pin)
+}
+
+// SetPurchasePin godoc
+// @Summary      Set Purchase PIN
+// @Description  Sets the purchase PIN of the authenticated client, 4 to 6 digits, confirmed with their password. Setting it again resets a forgotten PIN and lifts the 30-minute lockout that follows five wrong PINs in a row. Only clients have a purchase PIN.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        pin            body        request.SetPurchasePinRequest  true  "Password and new PIN"
+// @Success      200  {object}  response.PurchasePinResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/purchase-pin [put]
+func (c *PurchasePinController) SetPurchasePin(ctx *gin.Context) {
+	var req request.SetPurchasePinRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients have a purchase PIN"})
+		return
+	}
+
+	pin, err := c.pinService.SetPurchasePin(middleware.GetUserIDFromContext(ctx), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPurchasePinFormat) || errors.Is(err, service.ErrIncorrectPassword) {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, pin)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/qrcode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Sizes of the QR code images, in pixels.
+const (
+	defaultQRCodeSize = 256
+	minQRCodeSize     = 64
+	maxQRCodeSize     = 1024
+)
+
+// respondQRCode writes a QR code of content as the image the request asks for: PNG unless the
+// format query parameter is svg, of the pixels the size query parameter says on each side.
+func respondQRCode(ctx *gin.Context, content string) {
+	size := defaultQRCodeSize
+	if raw := ctx.Query("size"); raw != "" {
+		var err error
+		size, err = strconv.Atoi(raw)
+		if err != nil || size < minQRCodeSize || size > maxQRCodeSize {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid size, use 64 to 1024 pixels"})
+			return
+		}
+	}
+	format := ctx.DefaultQuery("format", "png")
+	if format != "png" && format != "svg" {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid format, use png or svg"})
+		return
+	}
+
+	code, err := qrcode.Encode(content)
+	if errors.Is(err, qrcode.ErrTooLong) {
+		ctx.JSON(http.StatusUnprocessableEntity, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if format == "svg" {
+		ctx.Data(http.StatusOK, "image/svg+xml", code.SVG(size))
+		return
+	}
+	// PNGs have whole pixels per module, so they are as close to size as that allows
+	image, err := code.PNG(max(1, size/(code.Size()+2*qrcode.QuietZone)))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.Data(http.StatusOK, "image/png", image)
+}
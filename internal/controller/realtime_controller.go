@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/realtime"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// keepAliveInterval is how often an idle stream gets a comment, so proxies don't close it.
+const keepAliveInterval = 25 * time.Second
+
+// RealtimeController streams account events to connected apps.
+type RealtimeController struct {
+	hub                  *realtime.Hub
+	establishmentService service.EstablishmentService
+}
+
+// NewRealtimeController creates a new instance of RealtimeController.
+func NewRealtimeController(hub *realtime.Hub, establishmentService service.EstablishmentService) *RealtimeController {
+	return &RealtimeController{hub: hub, establishmentService: establishmentService}
+}
+
+// StreamEvents godoc
+// @Summary      Stream Account Events
+// @Description  Opens a Server-Sent Events stream of account events such as payment.confirmed, purchase.created and account.blocked. Clients receive the events of their own credit accounts; admins those of every credit account in their establishments and branches. Each event is named after the event and carries a RealtimeEventResponse as data.
+// @Tags         Realtime
+// @Produce      text/event-stream
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.RealtimeEventResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /events/stream [get]
+func (c *RealtimeController) StreamEvents(ctx *gin.Context) {
+	var subscription *realtime.Subscription
+	if middleware.GetUserRoleFromContext(ctx) == enums.ADMIN {
+		establishments, err := c.establishmentService.GetBranches(middleware.GetUserIDFromContext(ctx))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		establishmentIDs := make([]uint, 0, len(establishments))
+		for _, establishment := range establishments {
+			establishmentIDs = append(establishmentIDs, establishment.ID)
+		}
+		subscription = c.hub.SubscribeEstablishments(establishmentIDs)
+	} else {
+		subscription = c.hub.SubscribeClient(middleware.GetUserIDFromContext(ctx))
+	}
+	defer subscription.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+	ctx.Status(http.StatusOK)
+	_, _ = ctx.Writer.WriteString(": connected\n\n")
+	ctx.Writer.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case evt, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			ctx.SSEvent(string(evt.Event), evt)
+		case <-keepAlive.C:
+			_, _ = ctx.Writer.WriteString(": keep-alive\n\n")
+		}
+		ctx.Writer.Flush()
+	}
+}
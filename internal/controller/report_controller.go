@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ReportController handles API requests for establishment reports and their digests.
+type ReportController struct {
+	reportService       service.ReportService
+	reportDigestService service.ReportDigestService
+}
+
+// NewReportController creates a new ReportController.
+func NewReportController(reportService service.ReportService, reportDigestService service.ReportDigestService) *ReportController {
+	return &ReportController{reportService: reportService, reportDigestService: reportDigestService}
+}
+
+// GetProductReport godoc
+// @Summary      Get Product Performance Report
+// @Description  Shows units sold, revenue, credit vs cash split and stock turnover per product of the admin's establishment. Use format=csv to download it as CSV. Only Admins can see reports.
+// @Tags         Reports
+// @Produce      json
+// @Produce      text/csv
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        period         query       string  false "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to month"
+// @Param        format         query       string  false "json (default) or csv"
+// @Success      200  {object}  response.ProductReportResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/products [get]
+func (c *ReportController) GetProductReport(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view reports"})
+		return
+	}
+
+	adminID := middleware.GetUserIDFromContext(ctx)
+	branchID := middleware.GetBranchIDFromContext(ctx)
+	period := ctx.Query("period")
+
+	switch ctx.DefaultQuery("format", "json") {
+	case "json":
+		report, err := c.reportService.GetProductReport(adminID, branchID, period)
+		if err != nil {
+			respondReportError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, report)
+	case "csv":
+		csvBytes, err := c.reportService.ExportProductReportCSV(adminID, branchID, period)
+		if err != nil {
+			respondReportError(ctx, err)
+			return
+		}
+		ctx.Header("Content-Disposition", "attachment; filename=product_report.csv")
+		ctx.Data(http.StatusOK, "text/csv", csvBytes)
+	default:
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid format, use json or csv"})
+	}
+}
+
+// GetCohortReport godoc
+// @Summary      Get Client Cohort Report
+// @Description  Groups the clients of the admin's establishment by onboarding month and shows, for every due date since, how many paid on time, late or defaulted. Only Admins can see reports.
+// @Tags         Reports
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        months         query       int     false "Number of onboarding months covered, current month included (1-24). Defaults to 12"
+// @Success      200  {object}  response.CohortReportResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/cohorts [get]
+func (c *ReportController) GetCohortReport(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view reports"})
+		return
+	}
+
+	var months int
+	if monthsStr := ctx.Query("months"); monthsStr != "" {
+		var err error
+		months, err = strconv.Atoi(monthsStr)
+		if err != nil || months < 1 {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid months"})
+			return
+		}
+	}
+
+	report, err := c.reportService.GetCohortReport(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), months)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetBranchReport godoc
+// @Summary      Get Consolidated Branch Report
+// @Description  Puts the credit and cash sales, payments and outstanding debt of the admin's main establishment and each branch side by side, with totals across all of them. Only Admins can see reports.
+// @Tags         Reports
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        period         query       string  false "week, month, quarter, year (current period to date), a named range (today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_N_days), YYYY-MM or YYYY. Defaults to month"
+// @Success      200  {object}  response.BranchReportResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/branches [get]
+func (c *ReportController) GetBranchReport(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view reports"})
+		return
+	}
+
+	report, err := c.reportService.GetBranchReport(middleware.GetUserIDFromContext(ctx), ctx.Query("period"))
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetBadDebtReport godoc
+// @Summary      Get Bad-Debt Report
+// @Description  Lists the credit accounts of the admin's establishment written off as bad debt, newest first, with the amount written off, what later payments recovered and what is still outstanding. Written-off accounts are left out of the other receivables reports. Only Admins can see reports.
+// @Tags         Reports
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.BadDebtReportResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/bad-debt [get]
+func (c *ReportController) GetBadDebtReport(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view reports"})
+		return
+	}
+
+	report, err := c.reportService.GetBadDebtReport(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetForecastReport godoc
+// @Summary      Get Collections Forecast Report
+// @Description  Forecasts the collections of the admin's establishment for each upcoming week or month, to plan inventory purchases. What falls due in a period is the pending installments of long-term accounts and the balance of short-term accounts on their next due date; each amount is weighted by how punctually its account paid installments before, or the establishment as a whole for accounts with a short history. Each period has what is scheduled and an optimistic/expected/pessimistic band (90th percentile, expected and 10th percentile). Past-due installments are reported apart, as overdue_balance, and written-off accounts are left out. Only Admins can see reports.
+// @Tags         Reports
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        granularity    query       string  false "week (default, starting on Monday) or month"
+// @Param        periods        query       int     false "Number of periods covered, the current one included: 1-26 weeks (defaults to 8) or 1-12 months (defaults to 3)"
+// @Success      200  {object}  response.ForecastReportResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/forecast [get]
+func (c *ReportController) GetForecastReport(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view reports"})
+		return
+	}
+
+	var periods int
+	if periodsStr := ctx.Query("periods"); periodsStr != "" {
+		var err error
+		periods, err = strconv.Atoi(periodsStr)
+		if err != nil || periods < 1 {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid periods"})
+			return
+		}
+	}
+
+	report, err := c.reportService.GetForecastReport(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), ctx.Query("granularity"), periods)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// SendReportDigest godoc
+// @Summary      Send Report Digest
+// @Description  Emails the admin a digest of the reports of the establishment over a period, last week unless another is asked for: collections, new debt, overdue changes and top debtors, or the sections asked for, those of the establishment settings by default. The email is in HTML with the digest attached as a PDF, and is only sent to a verified email. Set preview to get the digest without emailing it. Digests are also emailed weekly or monthly as the digest_frequency setting says. Only Admins can ask for them.
+// @Tags         Reports
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                           true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                              false "Branch to act on. Defaults to the main establishment"
+// @Param        digest         body        request.SendReportDigestRequest  true  "Period and sections of the digest"
+// @Success      200  {object}  response.ReportDigestResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/digest [post]
+func (c *ReportController) SendReportDigest(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can ask for report digests"})
+		return
+	}
+	var req request.SendReportDigestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	digest, err := c.reportDigestService.SendDigest(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, digest)
+}
+
+// GetReportDigestDeliveries godoc
+// @Summary      List Report Digest Deliveries
+// @Description  Lists the weekly and monthly report digests emailed to the admin of the establishment, newest first, with their status. A failed digest is retried on the next runs, up to 3 attempts, for two days after its period. Only Admins can see them.
+// @Tags         Reports
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.ReportDigestDeliveryResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/reports/digest-deliveries [get]
+func (c *ReportController) GetReportDigestDeliveries(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view report digests"})
+		return
+	}
+
+	deliveries, err := c.reportDigestService.GetDigestDeliveries(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+func respondReportError(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidReportPeriod) {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrBranchNotFound) {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrEmailNotVerified) {
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrUpgradeRequired) {
+		ctx.JSON(http.StatusPaymentRequired, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/tenant"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// caller is who a test request is authenticated as, as AuthMiddleware and TenantMiddleware leave it
+// in the context.
+type caller struct {
+	userID uint
+	role   enums.Role
+	scope  tenant.Scope
+}
+
+// newTestRouter returns a router whose requests are authenticated as caller.
+func newTestRouter(as caller) *gin.Engine {
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("user_id", as.userID)
+		ctx.Set("rol", as.role)
+		ctx.Set("tenant", as.scope)
+		ctx.Next()
+	})
+	return router
+}
+
+// serve sends a request with body encoded as JSON, if not nil, and returns the recorded response.
+func serve(t *testing.T, router http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("error encoding request body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SandboxController exposes sandbox-only tools such as the simulated clock.
+type SandboxController struct {
+	clock *util.SimulatedClock
+}
+
+// NewSandboxController creates a new instance of SandboxController.
+func NewSandboxController(clock *util.SimulatedClock) *SandboxController {
+	return &SandboxController{clock: clock}
+}
+
+// GetClock godoc
+// @Summary      Get Sandbox Clock
+// @Description  Returns the date the API currently uses for interest, late fees and overdue checks. Only available in the sandbox environment.
+// @Tags         Sandbox
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.ClockResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Router       /sandbox/clock [get]
+func (c *SandboxController) GetClock(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can use the sandbox clock"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.clockResponse())
+}
+
+// SetClock godoc
+// @Summary      Simulate Date
+// @Description  Moves the sandbox clock to the given date. Time keeps running from there until the clock is reset.
+// @Tags         Sandbox
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        clock  body      request.SetSimulatedDateRequest  true  "Simulated date"
+// @Success      200  {object}  response.ClockResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Router       /sandbox/clock [put]
+func (c *SandboxController) SetClock(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can use the sandbox clock"})
+		return
+	}
+
+	var req request.SetSimulatedDateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.clock.SetNow(req.Now)
+	ctx.JSON(http.StatusOK, c.clockResponse())
+}
+
+// ResetClock godoc
+// @Summary      Reset Sandbox Clock
+// @Description  Makes the sandbox clock follow the system time again.
+// @Tags         Sandbox
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.ClockResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Router       /sandbox/clock [delete]
+func (c *SandboxController) ResetClock(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can use the sandbox clock"})
+		return
+	}
+
+	c.clock.Reset()
+	ctx.JSON(http.StatusOK, c.clockResponse())
+}
+
+func (c *SandboxController) clockResponse() response.ClockResponse {
+	return response.ClockResponse{Now: c.clock.Now(), Simulated: c.clock.IsSimulated()}
+}
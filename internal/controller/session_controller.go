@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionController lets users see the devices they are logged in on and log them out.
+type SessionController struct {
+	sessionService service.SessionService
+}
+
+// NewSessionController creates a new instance of SessionController.
+func NewSessionController(sessionService service.SessionService) *SessionController {
+	return &SessionController{sessionService: sessionService}
+}
+
+// GetSessions godoc
+// @Summary      List Sessions
+// @Description  Lists the active sessions of the authenticated user: the device, IP address and last activity of each login. The session of the request is flagged as current. Activity is recorded when the session logs in or refreshes its tokens.
+// @Tags         Sessions
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {array}   response.SessionResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/sessions [get]
+func (c *SessionController) GetSessions(ctx *gin.Context) {
+	sessions, err := c.sessionService.GetSessions(middleware.GetUserIDFromContext(ctx), middleware.GetSessionIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary      Revoke Session
+// @Description  Logs the authenticated user out of one of their sessions. Its refresh token stops working right away; access tokens already issued to it last until they expire.
+// @Tags         Sessions
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Session ID"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/sessions/{id} [delete]
+func (c *SessionController) RevokeSession(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid session ID"})
+		return
+	}
+
+	if err := c.sessionService.RevokeSession(middleware.GetUserIDFromContext(ctx), uint(id)); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrSessionNotFound) {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatementDeliveryController handles the monthly statement emails.
+type StatementDeliveryController struct {
+	statementDeliveryService service.StatementDeliveryService
+}
+
+// NewStatementDeliveryController creates a new instance of StatementDeliveryController.
+func NewStatementDeliveryController(statementDeliveryService service.StatementDeliveryService) *StatementDeliveryController {
+	return &StatementDeliveryController{statementDeliveryService: statementDeliveryService}
+}
+
+// GetStatementEmailSettings godoc
+// @Summary      Get Statement Email Settings
+// @Description  Tells whether the establishment emails its clients their statement at each closing date. Only Admins can see it.
+// @Tags         Statements
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.StatementEmailSettingsResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/statement-emails [get]
+func (c *StatementDeliveryController) GetStatementEmailSettings(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view statement email settings"})
+		return
+	}
+
+	settings, err := c.statementDeliveryService.GetStatementEmailSettings(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateStatementEmailSettings godoc
+// @Summary      Update Statement Email Settings
+// @Description  Turns the monthly statement emails of the establishment on or off. Statements are sent within a week of each account's closing date. Only Admins can change it.
+// @Tags         Statements
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                                   false "Branch to act on. Defaults to the main establishment"
+// @Param        settings       body        request.UpdateStatementEmailsRequest  true  "Statement email settings"
+// @Success      200  {object}  response.StatementEmailSettingsResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/statement-emails [put]
+func (c *StatementDeliveryController) UpdateStatementEmailSettings(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can change statement email settings"})
+		return
+	}
+
+	var req request.UpdateStatementEmailsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	settings, err := c.statementDeliveryService.UpdateStatementEmailSettings(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), *req.Enabled)
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// GetEstablishmentStatementDeliveries godoc
+// @Summary      List Sent Statements
+// @Description  Lists the statements emailed to the establishment's clients, newest first, including failed attempts. Only Admins can see them.
+// @Tags         Statements
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.StatementDeliveryResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/statement-deliveries [get]
+func (c *StatementDeliveryController) GetEstablishmentStatementDeliveries(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view sent statements"})
+		return
+	}
+
+	deliveries, err := c.statementDeliveryService.GetEstablishmentDeliveries(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// UpdateClientStatementEmails godoc
+// @Summary      Subscribe or Unsubscribe from Statement Emails
+// @Description  Lets the authenticated client stop, or resume, receiving their monthly statement by email.
+// @Tags         Clients
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                                true  "Bearer {token}"
+// @Param        settings       body        request.UpdateStatementEmailsRequest  true  "Statement email settings"
+// @Success      200  {object}  response.StatementEmailSettingsResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/statement-emails [put]
+func (c *StatementDeliveryController) UpdateClientStatementEmails(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can change their statement emails"})
+		return
+	}
+
+	var req request.UpdateStatementEmailsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	settings, err := c.statementDeliveryService.UpdateClientStatementEmails(middleware.GetUserIDFromContext(ctx), *req.Enabled)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// GetClientStatementDeliveries godoc
+// @Summary      List Client Sent Statements
+// @Description  Lists the statements emailed to the authenticated client, newest first.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {array}   response.StatementDeliveryResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/statement-deliveries [get]
+func (c *StatementDeliveryController) GetClientStatementDeliveries(ctx *gin.Context) {
+	deliveries, err := c.statementDeliveryService.GetClientDeliveries(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, deliveries)
+}
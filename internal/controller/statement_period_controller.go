@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatementPeriodController handles the closed monthly statements of credit accounts.
+type StatementPeriodController struct {
+	statementPeriodService service.StatementPeriodService
+}
+
+// NewStatementPeriodController creates a new instance of StatementPeriodController.
+func NewStatementPeriodController(statementPeriodService service.StatementPeriodService) *StatementPeriodController {
+	return &StatementPeriodController{statementPeriodService: statementPeriodService}
+}
+
+// GetClientStatements godoc
+// @Summary      List Client Statements
+// @Description  Lists the closed monthly statements of the authenticated client's credit account, newest first. Statements are frozen at each closing date and never change afterwards.
+// @Tags         Clients
+// @Produce      json
+// @Param        Authorization     header      string  true  "Bearer {token}"
+// @Param        establishment_id  query       int     false "Establishment of the credit account. Required when the client has accounts in several establishments"
+// @Success      200  {array}   response.StatementPeriodResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/me/statements [get]
+func (c *StatementPeriodController) GetClientStatements(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.CLIENT {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only clients can view their statements"})
+		return
+	}
+	establishmentID, ok := establishmentSelector(ctx)
+	if !ok {
+		return
+	}
+
+	statements, err := c.statementPeriodService.GetClientStatements(middleware.GetUserIDFromContext(ctx), establishmentID)
+	if err != nil {
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, statements)
+}
+
+// GetCreditAccountStatements godoc
+// @Summary      List Credit Account Statements
+// @Description  Lists the closed monthly statements of a credit account, newest first. Only Admins of the account's establishment can see them.
+// @Tags         Credit Accounts
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Credit Account ID"
+// @Success      200  {array}   response.StatementPeriodResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /credit-accounts/{id}/statements [get]
+func (c *StatementPeriodController) GetCreditAccountStatements(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can view credit account statements"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid credit account ID"})
+		return
+	}
+
+	statements, err := c.statementPeriodService.GetCreditAccountStatements(middleware.GetUserIDFromContext(ctx), uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrCreditAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Credit account not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, statements)
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TillSessionController handles the till sessions admins reconcile the cash of their establishment with.
+type TillSessionController struct {
+	tillSessionService service.TillSessionService
+}
+
+// NewTillSessionController creates a new instance of TillSessionController.
+func NewTillSessionController(tillSessionService service.TillSessionService) *TillSessionController {
+	return &TillSessionController{tillSessionService: tillSessionService}
+}
+
+// OpenTillSession godoc
+// @Summary      Open Till Session
+// @Description  Opens the till of the establishment with the cash in the drawer. Until it is closed, CASH payments and cash sales recorded in the establishment are collected in it. An establishment has one open till session at a time. Only Admins can open the till.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                          true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                             false "Branch to act on. Defaults to the main establishment"
+// @Param        session        body        request.OpenTillSessionRequest  true  "Opening cash"
+// @Success      201  {object}  response.TillSessionResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/till-sessions [post]
+func (c *TillSessionController) OpenTillSession(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can open the till"})
+		return
+	}
+	var req request.OpenTillSessionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	session, err := c.tillSessionService.OpenTillSession(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req)
+	if err != nil {
+		respondTillSessionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, session)
+}
+
+// GetTillSessions godoc
+// @Summary      List Till Sessions
+// @Description  Lists the till sessions of the establishment, newest first, with the cash expected and counted in each. Only Admins can list them.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {array}   response.TillSessionResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/till-sessions [get]
+func (c *TillSessionController) GetTillSessions(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can list till sessions"})
+		return
+	}
+
+	sessions, err := c.tillSessionService.GetTillSessions(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondTillSessionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// GetCurrentTillSession godoc
+// @Summary      Get Current Till Session
+// @Description  Reports on the open till session of the establishment: the cash payments and sales collected so far and the cash expected in the drawer. Only Admins can see it.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Success      200  {object}  response.TillSessionResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/till-sessions/current [get]
+func (c *TillSessionController) GetCurrentTillSession(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can see till sessions"})
+		return
+	}
+
+	session, err := c.tillSessionService.GetCurrentTillSession(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		respondTillSessionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, session)
+}
+
+// GetTillSessionReport godoc
+// @Summary      Get Till Session Report
+// @Description  Reports on a till session of the establishment: the opening cash, the cash payments and sales collected, the cash expected in the drawer against the cash counted on closing, the discrepancy and its notes. Only Admins can see it.
+// @Tags         Establishments
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int     true  "Till session ID"
+// @Success      200  {object}  response.TillSessionResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/till-sessions/{id} [get]
+func (c *TillSessionController) GetTillSessionReport(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can see till sessions"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid till session ID"})
+		return
+	}
+
+	session, err := c.tillSessionService.GetTillSessionReport(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(id))
+	if err != nil {
+		respondTillSessionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, session)
+}
+
+// CloseTillSession godoc
+// @Summary      Close Till Session
+// @Description  Closes the open till session with the cash counted in the drawer, freezing the cash expected in it. When the counted cash isn't the expected one, notes explaining the discrepancy are required. Only Admins can close the till.
+// @Tags         Establishments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                           true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int                              false "Branch to act on. Defaults to the main establishment"
+// @Param        id             path        int                              true  "Till session ID"
+// @Param        session        body        request.CloseTillSessionRequest  true  "Counted cash and notes"
+// @Success      200  {object}  response.TillSessionResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/till-sessions/{id}/close [post]
+func (c *TillSessionController) CloseTillSession(ctx *gin.Context) {
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can close the till"})
+		return
+	}
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid till session ID"})
+		return
+	}
+	var req request.CloseTillSessionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	session, err := c.tillSessionService.CloseTillSession(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), uint(id), req)
+	if err != nil {
+		respondTillSessionError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, session)
+}
+
+// respondTillSessionError writes the response for an error of a till session operation.
+func respondTillSessionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTillSessionNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDiscrepancyNotesRequired):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrTillSessionOpen), errors.Is(err, service.ErrTillSessionClosed):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		respondEstablishmentError(ctx, err)
+	}
+}
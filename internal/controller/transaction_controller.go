@@ -2,6 +2,7 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -10,6 +11,8 @@ import (
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/util"
+	"ApiRestFinance/internal/versioning"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -17,19 +20,21 @@ import (
 
 // TransactionController handles API requests related to transactions.
 type TransactionController struct {
-	transactionService service.TransactionService
+	transactionService   service.TransactionService
+	authorizationService service.AuthorizationService
 }
 
 // NewTransactionController creates a new instance of TransactionController.
-func NewTransactionController(transactionService service.TransactionService) *TransactionController {
+func NewTransactionController(transactionService service.TransactionService, authorizationService service.AuthorizationService) *TransactionController {
 	return &TransactionController{
-		transactionService: transactionService,
+		transactionService:   transactionService,
+		authorizationService: authorizationService,
 	}
 }
 
 // CreateTransaction godoc
 // @Summary      Create Transaction
-// @Description  Create a new transaction (purchase or payment).
+// @Description  Create a new transaction (purchase or payment). Clients make purchases on their own credit accounts, and admins register payments on the credit accounts of their establishments. Non-cash transactions stay pending until confirmed with the code sent to the client, on the channels listed in code_sent_to; they fail if not confirmed before payment_code_expires_at.
 // @Tags         Transactions
 // @Accept  json
 // @Produce  json
@@ -64,6 +69,18 @@ func (c *TransactionController) CreateTransaction(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can create payment transactions"})
 		return
 	}
+	access := service.HolderAccess
+	if req.TransactionType == enums.Payment {
+		access = service.AdminAccess
+	}
+	err := c.authorizationService.AuthorizeCreditAccount(requestCaller(ctx), req.CreditAccountID, access)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: service.ErrCreditAccountNotFound.Error()})
+		return
+	}
+	if respondUnauthorized(ctx, err, "") {
+		return
+	}
 
 	resp, err := c.transactionService.CreateTransaction(req)
 	if err != nil {
@@ -79,7 +96,7 @@ func (c *TransactionController) CreateTransaction(ctx *gin.Context) {
 
 // GetTransactionByID godoc
 // @Summary Get Transaction by ID
-// @Description Get a transaction by its ID.
+// @Description Get a transaction by its ID. Only the admins of the establishment and the client holding the credit account can get it.
 // @Tags Transactions
 // @Accept  json
 // @Produce  json
@@ -87,6 +104,8 @@ func (c *TransactionController) CreateTransaction(ctx *gin.Context) {
 // @Param id path int true "Transaction ID"
 // @Success 200 {object} response.TransactionResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /transactions/{id} [get]
@@ -97,6 +116,11 @@ func (c *TransactionController) GetTransactionByID(ctx *gin.Context) {
 		return
 	}
 
+	// Only the admins of the establishment and the client holding the credit account can access it
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeTransaction(requestCaller(ctx), uint(transactionID), service.HolderAccess), "Transaction not found") {
+		return
+	}
+
 	resp, err := c.transactionService.GetTransactionByID(uint(transactionID))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -107,47 +131,57 @@ func (c *TransactionController) GetTransactionByID(ctx *gin.Context) {
 		return
 	}
 
-	// Authorization: Only the admin or the client associated with the transaction can access it
-	authUserID := middleware.GetUserIDFromContext(ctx)
-	authUserRole := middleware.GetUserRoleFromContext(ctx)
-	if authUserRole != enums.ADMIN && resp.CreditAccountID != authUserID { // Assuming CreditAccountID is the Client User ID
-		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Forbidden: Not authorized to access this transaction"})
-		return
-	}
-
+	versioning.SetLinks(ctx, versioning.Links{"credit_account": fmt.Sprintf("/credit-accounts/%d", resp.CreditAccountID)})
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // GetTransactionsByCreditAccountID godoc
 // @Summary Get Transaction by Credit Account ID
-// @Description Get all transactions for a specific credit account.
+// @Description Get a page of transactions for a specific credit account, newest first. Only the admins of the establishment and the client holding the credit account can get them. The maximum page size depends on the caller's role.
 // @Tags Transactions
 // @Accept  json
 // @Produce  json
 // @Param        Authorization  header      string  true  "Bearer {token}"
-// @Param creditAccountID path int true "Credit Account ID"
+// @Param id path int true "Credit Account ID"
+// @Param page query int false "Page number (starts at 1)"
+// @Param page_size query int false "Page size"
 // @Success 200 {array} response.TransactionResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
-// @Router /credit-accounts/{creditAccountID}/transactions [get]
+// @Router /credit-accounts/{id}/transactions [get]
 func (c *TransactionController) GetTransactionsByCreditAccountID(ctx *gin.Context) {
-	creditAccountID, err := strconv.Atoi(ctx.Param("creditAccountID"))
+	creditAccountID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid Credit Account ID"})
 		return
 	}
 
-	// Authorization: Only the admin or the client associated with the credit account can access its transactions
-	authUserID := middleware.GetUserIDFromContext(ctx)
+	// Only the admins of the establishment and the client holding the credit account can access its transactions
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeCreditAccount(requestCaller(ctx), uint(creditAccountID), service.HolderAccess), "Credit Account not found") {
+		return
+	}
 	authUserRole := middleware.GetUserRoleFromContext(ctx)
-	if authUserRole != enums.ADMIN && uint(creditAccountID) != authUserID {
-		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Forbidden: Not authorized to access transactions for this credit account"})
+
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page"})
+		return
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page_size"})
+		return
+	}
+	pageSize, err := service.QueryLimitsForRole(authUserRole).ResolvePageSize(requestedPageSize)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	resp, err := c.transactionService.GetTransactionsByCreditAccountID(uint(creditAccountID))
+	resp, err := c.transactionService.GetTransactionsByCreditAccountID(uint(creditAccountID), page, pageSize)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Credit Account not found"})
@@ -156,12 +190,82 @@ func (c *TransactionController) GetTransactionsByCreditAccountID(ctx *gin.Contex
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	versioning.SetPagination(ctx, page, pageSize)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SearchEstablishmentTransactions godoc
+// @Summary Search Establishment Transactions
+// @Description Search the transactions of the establishment's credit accounts, newest first by default. All filters are optional and are combined. The maximum page size depends on the caller's role. Only Admins can search. The number of matches is sent in the X-Total-Count header.
+// @Tags Transactions
+// @Produce  json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param client query string false "Client name, or the beginning of their DNI"
+// @Param type query string false "Transaction type" Enums(PURCHASE, PAYMENT, INTEREST, LATE_FEE, ADJUSTMENT_DEBIT, ADJUSTMENT_CREDIT)
+// @Param payment_method query string false "Payment method" Enums(YAPE, PLIN, CASH, BANK_TRANSFER)
+// @Param status query string false "Payment status" Enums(PENDING, SUCCESS, FAILED)
+// @Param min_amount query number false "Minimum amount"
+// @Param max_amount query number false "Maximum amount"
+// @Param start_date query string false "First day (2026-10-14) or instant (2026-10-14T09:30:00-05:00), ISO-8601. Dates and times without an offset are in the establishment's time zone"
+// @Param end_date query string false "Last day (included) or instant, ISO-8601"
+// @Param range query string false "Named range instead of start_date and end_date: today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days"
+// @Param q query string false "Words to look for in the description"
+// @Param sort query string false "Field to sort by" Enums(transaction_date, amount) default(transaction_date)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(desc)
+// @Param page query int false "Page number (starts at 1)"
+// @Param page_size query int false "Page size"
+// @Success 200 {array} response.EstablishmentTransactionResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /establishments/me/transactions [get]
+func (c *TransactionController) SearchEstablishmentTransactions(ctx *gin.Context) {
+	authUserRole := middleware.GetUserRoleFromContext(ctx)
+	if authUserRole != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can search establishment transactions"})
+		return
+	}
+
+	var req request.SearchTransactionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page"})
+		return
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page_size"})
+		return
+	}
+	pageSize, err := service.QueryLimitsForRole(authUserRole).ResolvePageSize(requestedPageSize)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp, total, err := c.transactionService.SearchEstablishmentTransactions(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), req, page, pageSize)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidDate) || errors.Is(err, util.ErrInvalidDateRange) {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	versioning.SetPaginationTotal(ctx, page, pageSize, total)
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // UpdateTransaction godoc
 // @Summary Update Transaction
-// @Description Update a transaction by its ID. Only admins can update transactions.
+// @Description Update a transaction by its ID. Only admins can update transactions, of the credit accounts of their establishments. Adjustments can't be updated, make an opposite adjustment instead.
 // @Tags Transactions
 // @Accept  json
 // @Produce  json
@@ -173,6 +277,7 @@ func (c *TransactionController) GetTransactionsByCreditAccountID(ctx *gin.Contex
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /transactions/{id} [put]
 func (c *TransactionController) UpdateTransaction(ctx *gin.Context) {
@@ -193,6 +298,9 @@ func (c *TransactionController) UpdateTransaction(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can update transactions"})
 		return
 	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeTransaction(requestCaller(ctx), uint(transactionID), service.AdminAccess), "Transaction not found") {
+		return
+	}
 
 	resp, err := c.transactionService.UpdateTransaction(uint(transactionID), req)
 	if err != nil {
@@ -200,6 +308,10 @@ func (c *TransactionController) UpdateTransaction(ctx *gin.Context) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Transaction not found"})
 			return
 		}
+		if errors.Is(err, service.ErrAdjustmentTransaction) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -208,7 +320,7 @@ func (c *TransactionController) UpdateTransaction(ctx *gin.Context) {
 
 // DeleteTransaction godoc
 // @Summary Delete Transaction
-// @Description Delete a transaction by its ID. Only admins can delete transactions.
+// @Description Delete a transaction by its ID. Only admins can delete transactions, of the credit accounts of their establishments. Adjustments can't be deleted, make an opposite adjustment instead.
 // @Tags Transactions
 // @Accept  json
 // @Produce  json
@@ -219,6 +331,7 @@ func (c *TransactionController) UpdateTransaction(ctx *gin.Context) {
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /transactions/{id} [delete]
 func (c *TransactionController) DeleteTransaction(ctx *gin.Context) {
@@ -233,12 +346,19 @@ func (c *TransactionController) DeleteTransaction(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can delete transactions"})
 		return
 	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeTransaction(requestCaller(ctx), uint(transactionID), service.AdminAccess), "Transaction not found") {
+		return
+	}
 
 	if err := c.transactionService.DeleteTransaction(uint(transactionID)); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Transaction not found"})
 			return
 		}
+		if errors.Is(err, service.ErrAdjustmentTransaction) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -247,7 +367,7 @@ func (c *TransactionController) DeleteTransaction(ctx *gin.Context) {
 
 // ConfirmPayment godoc
 // @Summary      Confirm Payment
-// @Description  Confirms a pending payment using a confirmation code. Only admins can confirm payments.
+// @Description  Confirms a pending payment using the confirmation code sent to the client. A code is only used once and fails the payment when it expires or after 5 wrong attempts. Only admins can confirm payments, of the credit accounts of their establishments.
 // @Tags         Transactions
 // @Accept       json
 // @Produce      json
@@ -259,6 +379,7 @@ func (c *TransactionController) DeleteTransaction(ctx *gin.Context) {
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /transactions/{id}/confirm [post]
 func (c *TransactionController) ConfirmPayment(ctx *gin.Context) {
@@ -285,11 +406,102 @@ func (c *TransactionController) ConfirmPayment(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can confirm payments"})
 		return
 	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeTransaction(requestCaller(ctx), uint(transactionID), service.AdminAccess), "Transaction not found") {
+		return
+	}
 
-	if err := c.transactionService.ConfirmPayment(uint(transactionID), confirmationCode); err != nil {
+	err = c.transactionService.ConfirmPayment(uint(transactionID), confirmationCode)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Transaction not found"})
+		return
+	case errors.Is(err, service.ErrInvalidPaymentCode):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	case errors.Is(err, service.ErrPaymentNotPending), errors.Is(err, service.ErrPaymentCodeExpired), errors.Is(err, service.ErrPaymentCodeAttempts):
+		ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Payment confirmed successfully"})
 }
+
+// GetPaymentAllocation godoc
+// @Summary      Get Payment Allocation
+// @Description  Returns the installments a payment paid, oldest due first, with what it paid of each and what was left of them, and the installments still left to pay after it with the next due date. Payments cover the unpaid installments of their credit account by due date; what exceeds them pays the rest of the balance. Later payments and installments are not counted. Only the admins of the establishment and the client holding the credit account can get it.
+// @Tags         Transactions
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        id             path        int     true  "Transaction ID of the payment"
+// @Success      200  {object}  response.PaymentAllocationResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /transactions/{id}/allocation [get]
+func (c *TransactionController) GetPaymentAllocation(ctx *gin.Context) {
+	transactionID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid Transaction ID"})
+		return
+	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeTransaction(requestCaller(ctx), uint(transactionID), service.HolderAccess), "Transaction not found") {
+		return
+	}
+
+	allocation, err := c.transactionService.GetPaymentAllocation(uint(transactionID))
+	if err != nil {
+		respondPaymentAllocationError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, allocation)
+}
+
+// GetPaymentReceiptPDF godoc
+// @Summary      Download Payment Receipt (PDF)
+// @Description  Downloads the receipt of a payment, with the installments it paid and the schedule left to pay after it, in the language of Accept-Language or else of the establishment. Only the admins of the establishment and the client holding the credit account can get it.
+// @Tags         Transactions
+// @Produce      application/pdf
+// @Param        Authorization    header      string  true  "Bearer {token}"
+// @Param        Accept-Language  header      string  false "Language of the PDF, es or en. Defaults to the establishment's"
+// @Param        id               path        int     true  "Transaction ID of the payment"
+// @Success      200  {file}    application/pdf  "PDF receipt"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /transactions/{id}/receipt/pdf [get]
+func (c *TransactionController) GetPaymentReceiptPDF(ctx *gin.Context) {
+	transactionID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid Transaction ID"})
+		return
+	}
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeTransaction(requestCaller(ctx), uint(transactionID), service.HolderAccess), "Transaction not found") {
+		return
+	}
+
+	pdfBytes, err := c.transactionService.GetPaymentReceiptPDF(uint(transactionID), middleware.GetLanguageFromContext(ctx))
+	if err != nil {
+		respondPaymentAllocationError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=payment_receipt_%d.pdf", transactionID))
+	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+func respondPaymentAllocationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Transaction not found"})
+	case errors.Is(err, service.ErrNotPayment):
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
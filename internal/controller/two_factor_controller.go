@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorController lets users manage the two-factor authentication of their own account.
+type TwoFactorController struct {
+	twoFactorService service.TwoFactorService
+}
+
+// NewTwoFactorController creates a new instance of TwoFactorController.
+func NewTwoFactorController(twoFactorService service.TwoFactorService) *TwoFactorController {
+	return &TwoFactorController{twoFactorService: twoFactorService}
+}
+
+// SetupTwoFactor godoc
+// @Summary      Set Up Two-Factor Authentication
+// @Description  Generates a new TOTP secret for the authenticated user. Show otpauth_url as a QR code to add it to an authenticator app, then confirm it at /users/me/2fa/verify. Two-factor authentication is not enabled until then.
+// @Tags         Two-Factor Authentication
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Success      200  {object}  response.TwoFactorSetupResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/2fa/setup [post]
+func (c *TwoFactorController) SetupTwoFactor(ctx *gin.Context) {
+	setup, err := c.twoFactorService.SetupTwoFactor(middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(twoFactorErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, setup)
+}
+
+// VerifyTwoFactor godoc
+// @Summary      Enable Two-Factor Authentication
+// @Description  Enables two-factor authentication with the first code of the authenticator app set up at /users/me/2fa/setup. Returns the recovery codes, which are only shown once.
+// @Tags         Two-Factor Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        code           body        request.TwoFactorCodeRequest  true  "Authenticator code"
+// @Success      200  {object}  response.RecoveryCodesResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/2fa/verify [post]
+func (c *TwoFactorController) VerifyTwoFactor(ctx *gin.Context) {
+	var req request.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	codes, err := c.twoFactorService.EnableTwoFactor(middleware.GetUserIDFromContext(ctx), req.Code)
+	if err != nil {
+		ctx.JSON(twoFactorErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, codes)
+}
+
+// DisableTwoFactor godoc
+// @Summary      Disable Two-Factor Authentication
+// @Description  Disables two-factor authentication for the authenticated user, confirmed with an authenticator or recovery code. Not allowed when an establishment of the user requires it.
+// @Tags         Two-Factor Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        code           body        request.TwoFactorCodeRequest  true  "Authenticator or recovery code"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      429  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/2fa/disable [post]
+func (c *TwoFactorController) DisableTwoFactor(ctx *gin.Context) {
+	var req request.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := c.twoFactorService.DisableTwoFactor(middleware.GetUserIDFromContext(ctx), req.Code); err != nil {
+		ctx.JSON(twoFactorErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate Recovery Codes
+// @Description  Replaces the recovery codes of the authenticated user with new ones, confirmed with an authenticator or recovery code. The previous codes stop working.
+// @Tags         Two-Factor Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        code           body        request.TwoFactorCodeRequest  true  "Authenticator or recovery code"
+// @Success      200  {object}  response.RecoveryCodesResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      429  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/me/2fa/recovery-codes [post]
+func (c *TwoFactorController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	var req request.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	codes, err := c.twoFactorService.RegenerateRecoveryCodes(middleware.GetUserIDFromContext(ctx), req.Code)
+	if err != nil {
+		ctx.JSON(twoFactorErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, codes)
+}
+
+// twoFactorErrorStatus maps the errors of two-factor operations to their HTTP status.
+func twoFactorErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactorCode):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrInvalidTwoFactorChallenge):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrTwoFactorLocked):
+		return http.StatusTooManyRequests
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled), errors.Is(err, service.ErrTwoFactorNotSetUp),
+		errors.Is(err, service.ErrTwoFactorNotEnabled), errors.Is(err, service.ErrTwoFactorRequired):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
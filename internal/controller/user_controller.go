@@ -13,6 +13,8 @@ import (
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/util"
+	"ApiRestFinance/internal/versioning"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,24 +25,31 @@ type UserController struct {
 	adminService         service.AdminService
 	creditAccountService service.CreditAccountService
 	establishmentService service.EstablishmentService
+	clientTagService     service.ClientTagService
+	clientNoteService    service.ClientNoteService
+	authorizationService service.AuthorizationService
 }
 
 // NewUserController creates a new instance of UserController.
-func NewUserController(userService service.UserService, adminService service.AdminService, creditAccountService service.CreditAccountService, establishmentService service.EstablishmentService) *UserController {
-	return &UserController{userService: userService, adminService: adminService, creditAccountService: creditAccountService, establishmentService: establishmentService}
+func NewUserController(userService service.UserService, adminService service.AdminService, creditAccountService service.CreditAccountService, establishmentService service.EstablishmentService, clientTagService service.ClientTagService, clientNoteService service.ClientNoteService, authorizationService service.AuthorizationService) *UserController {
+	return &UserController{userService: userService, adminService: adminService, creditAccountService: creditAccountService, establishmentService: establishmentService, clientTagService: clientTagService, clientNoteService: clientNoteService, authorizationService: authorizationService}
 }
 
 // CreateClient godoc
 // @Summary      Create Client
-// @Description  Creates a new client user with an associated credit account. Only Admins can create clients.
+// @Description  Creates a new client user with an associated credit account. A client that already has an account in another establishment (same email and DNI) only gets a new credit account. A DNI or email of another user is rejected with 409 Conflict and a message naming it. With credit_template_id, the credit terms the request leaves out are taken from that credit template of the establishment. Only Admins can create clients.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string                  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        client         body      request.CreateClientRequest  true  "Client data"
 // @Success      201  {object}  response.UserResponse
 // @Failure      400  {object}  response.ErrorResponse
+// @Failure      402  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients [post]
 func (c *UserController) CreateClient(ctx *gin.Context) {
@@ -58,7 +67,7 @@ func (c *UserController) CreateClient(ctx *gin.Context) {
 
 	userId := middleware.GetUserIDFromContext(ctx)
 
-	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId)
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(userId, middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
 		return
@@ -67,6 +76,26 @@ func (c *UserController) CreateClient(ctx *gin.Context) {
 
 	userResponse, err := c.userService.CreateClient(req)
 	if err != nil {
+		if errors.Is(err, service.ErrCreditAccountExists) || uniqueConflict(err) {
+			ctx.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrInterestRateRequired) {
+			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrCreditTemplateNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrEstablishmentInactive) {
+			ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrUpgradeRequired) {
+			ctx.JSON(http.StatusPaymentRequired, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -102,7 +131,7 @@ func (c *UserController) UpdatePassword(ctx *gin.Context) {
 
 	userID := middleware.GetUserIDFromContext(ctx)
 
-	err := c.userService.UpdatePassword(userID, req.NewPassword)
+	err := c.userService.UpdatePassword(userID, middleware.GetSessionIDFromContext(ctx), req.NewPassword)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
@@ -113,10 +142,11 @@ func (c *UserController) UpdatePassword(ctx *gin.Context) {
 
 // GetUserByID godoc
 // @Summary      Get User by ID
-// @Description  Retrieves a user by their ID. Admins can retrieve any user, Clients can only retrieve themselves.
+// @Description  Retrieves a user by their ID. Admins can retrieve any user, Clients can only retrieve themselves. Admins retrieving a client of their establishment also get the tags and notes the establishment has about them.
 // @Tags         Users
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        id             path      int  true  "User ID"
 // @Success      200  {object}  response.UserResponse
 // @Failure      400  {object}  response.ErrorResponse
@@ -147,6 +177,24 @@ func (c *UserController) GetUserByID(ctx *gin.Context) {
 		return
 	}
 
+	// Tags and notes are internal to the establishment, and only there for clients of it
+	if authUserRole == enums.ADMIN && userResponse.Rol == enums.CLIENT {
+		branchID := middleware.GetBranchIDFromContext(ctx)
+		tags, err := c.clientTagService.GetTagsOfClient(authUserID, branchID, userResponse.ID)
+		if err != nil && !errors.Is(err, service.ErrClientNotFound) {
+			respondEstablishmentError(ctx, err)
+			return
+		}
+		if err == nil {
+			notes, err := c.clientNoteService.GetClientNotes(authUserID, branchID, userResponse.ID)
+			if err != nil {
+				respondEstablishmentError(ctx, err)
+				return
+			}
+			userResponse.Tags, userResponse.Notes = tags, notes
+		}
+	}
+
 	ctx.JSON(http.StatusOK, userResponse)
 }
 
@@ -255,18 +303,22 @@ func (c *UserController) UpdateAdminProfile(ctx *gin.Context) {
 
 // UpdateClientCreditAccount godoc
 // @Summary      Update Client Credit Account
-// @Description  Updates credit account details of a client. Only Admins can update credit accounts.
+// @Description  Updates credit account details of a client. Only Admins can update credit accounts. Send the version of the account last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the account as it is now.
 // @Tags         Credit Accounts
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string                        true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
 // @Param        clientID       path      int                        true  "Client User ID"
 // @Param        creditAccount  body      request.UpdateCreditAccountRequest  true  "Updated credit account data"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
 // @Success      200  {object}  response.CreditAccountResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /clients/{clientID}/credit-account [put]
 func (c *UserController) UpdateClientCreditAccount(ctx *gin.Context) {
@@ -281,6 +333,9 @@ func (c *UserController) UpdateClientCreditAccount(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
 
 	// Ensure the authenticated user is an ADMIN
 	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
@@ -288,9 +343,86 @@ func (c *UserController) UpdateClientCreditAccount(ctx *gin.Context) {
 		return
 	}
 
-	creditAccountResponse, err := c.creditAccountService.UpdateCreditAccountByClientID(uint(clientID), req)
+	// Admins can only update the client's account in their own establishment
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	creditAccountResponse, err := c.creditAccountService.UpdateCreditAccountByClientID(uint(clientID), establishment.ID, req)
+	if err != nil {
+		current := func() (interface{}, error) {
+			return c.creditAccountService.GetCreditAccountByClientID(uint(clientID), establishment.ID)
+		}
+		if respondVersionError(ctx, err, current) {
+			return
+		}
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, creditAccountResponse)
+}
+
+// PatchClientCreditAccount godoc
+// @Summary      Patch Client Credit Account
+// @Description  Changes only the credit account details of a client the request sets. Unlike PUT, zero and false values are set too, e.g. a grace period of 0 or unblocking with is_blocked false. Fields left out or null are kept. Only Admins can update credit accounts. Send the version of the account last read, in the body or in If-Match: if it changed since, the update fails with 409 Conflict and the account as it is now.
+// @Tags         Credit Accounts
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                        true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        clientID       path      int                        true  "Client User ID"
+// @Param        creditAccount  body      request.PatchCreditAccountRequest  true  "Credit account fields to change"
+// @Param        If-Match       header      string  false "Version the change is based on, instead of version in the body"
+// @Success      200  {object}  response.CreditAccountResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.VersionConflictResponse
+// @Failure      428  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /clients/{clientID}/credit-account [patch]
+func (c *UserController) PatchClientCreditAccount(ctx *gin.Context) {
+	clientID, err := strconv.Atoi(ctx.Param("clientID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid client ID"})
+		return
+	}
+
+	var req request.PatchCreditAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !requestVersion(ctx, &req.Version) {
+		return
+	}
+
+	// Ensure the authenticated user is an ADMIN
+	if middleware.GetUserRoleFromContext(ctx) != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can update credit accounts"})
+		return
+	}
+
+	// Admins can only update the client's account in their own establishment
+	establishment, err := c.establishmentService.GetEstablishmentByAdminID(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	creditAccountResponse, err := c.creditAccountService.PatchCreditAccountByClientID(uint(clientID), establishment.ID, req)
+	if err != nil {
+		current := func() (interface{}, error) {
+			return c.creditAccountService.GetCreditAccountByClientID(uint(clientID), establishment.ID)
+		}
+		if respondVersionError(ctx, err, current) {
+			return
+		}
+		ctx.JSON(clientAccountErrorStatus(err), response.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -299,16 +431,18 @@ func (c *UserController) UpdateClientCreditAccount(ctx *gin.Context) {
 
 // GetClientsByEstablishmentID godoc
 // @Summary      Get Clients by Establishment ID
-// @Description  Gets all clients associated with an establishment. Only Admins can access this endpoint.
+// @Description  Gets all clients associated with an establishment, only those with every tag given when filtered by tag. Only admins of the establishment can access this endpoint; other establishments are not found.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header      string  true  "Bearer {token}"
 // @Param        establishmentID   path      int  true  "Establishment ID"
+// @Param        tag            query       []string  false "Client tag name the clients have, repeated to require several" collectionFormat(multi)
 // @Success      200  {array}   response.UserResponse
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      401  {object}  response.ErrorResponse
 // @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /establishments/{establishmentID}/clients [get]
 func (c *UserController) GetClientsByEstablishmentID(ctx *gin.Context) {
@@ -324,8 +458,11 @@ func (c *UserController) GetClientsByEstablishmentID(ctx *gin.Context) {
 		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can access clients"})
 		return
 	}
+	if !authorizeEstablishment(ctx, uint(establishmentID)) {
+		return
+	}
 
-	clients, err := c.userService.GetClientsByEstablishmentID(uint(establishmentID))
+	clients, err := c.userService.GetClientsByEstablishmentID(uint(establishmentID), queryTags(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
@@ -340,9 +477,70 @@ func (c *UserController) GetClientsByEstablishmentID(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, userResponses)
 }
 
+// SearchClients godoc
+// @Summary      Search Clients
+// @Description  Searches the clients of the establishment by name, DNI, email or phone, ordered by name, only those with every tag given when filtered by tag. Each client comes with the balance and overdue status of their credit account. An empty query lists every client. The maximum page size depends on the caller's role. Only Admins can search clients. The number of matches is sent in the X-Total-Count header.
+// @Tags         Users
+// @Produce      json
+// @Param        Authorization  header      string  true  "Bearer {token}"
+// @Param        X-Branch-ID    header      int     false "Branch to act on. Defaults to the main establishment"
+// @Param        q              query       string  false "Text the client's name, DNI, email or phone contains"
+// @Param        tag            query       []string  false "Client tag name the clients have, repeated to require several" collectionFormat(multi)
+// @Param        page           query       int     false "Page number (starts at 1)"
+// @Param        page_size      query       int     false "Page size"
+// @Success      200  {array}   response.ClientSearchResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /establishments/me/clients/search [get]
+func (c *UserController) SearchClients(ctx *gin.Context) {
+	authUserRole := middleware.GetUserRoleFromContext(ctx)
+	if authUserRole != enums.ADMIN {
+		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only admins can search clients"})
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page"})
+		return
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid page_size"})
+		return
+	}
+	pageSize, err := service.QueryLimitsForRole(authUserRole).ResolvePageSize(requestedPageSize)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	clients, total, err := c.creditAccountService.SearchEstablishmentClients(middleware.GetUserIDFromContext(ctx), middleware.GetBranchIDFromContext(ctx), ctx.Query("q"), queryTags(ctx), page, pageSize)
+	if err != nil {
+		respondEstablishmentError(ctx, err)
+		return
+	}
+	versioning.SetPaginationTotal(ctx, page, pageSize, total)
+	ctx.JSON(http.StatusOK, clients)
+}
+
+// queryTags returns the client tag names of the tag query parameters, ignoring blank ones.
+func queryTags(ctx *gin.Context) []string {
+	var tags []string
+	for _, tag := range ctx.QueryArray("tag") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // UploadUserPhoto godoc
 // @Summary      Upload User PhotoUrl
-// @Description  Uploads a profile photo for a user.
+// @Description  Uploads a profile photo for a user. The image is validated, resized to the standard sizes and set as the user's photo.
 // @Tags         Users
 // @Accept       multipart/form-data
 // @Produce      json
@@ -383,7 +581,9 @@ func (c *UserController) UploadUserPhoto(ctx *gin.Context) {
 	photoURL, err := c.userService.UploadUserPhoto(file, uint(userID))
 	if err != nil {
 		// Handle errors (file type, size, storage errors)
-		if errors.Is(err, service.ErrInvalidFileType) || errors.Is(err, service.ErrFileSizeTooLarge) {
+		if errors.Is(err, service.ErrInvalidFileType) || errors.Is(err, service.ErrFileSizeTooLarge) ||
+			errors.Is(err, service.ErrInvalidImage) || errors.Is(err, service.ErrInvalidImageDimensions) ||
+			errors.Is(err, service.ErrImageRejected) {
 			ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error uploading photo: " + err.Error()})
@@ -396,7 +596,7 @@ func (c *UserController) UploadUserPhoto(ctx *gin.Context) {
 
 // UpdateUser godoc
 // @Summary      Update User
-// @Description  Updates user details, including the URL of the profile photo.
+// @Description  Updates user details, including the URL of the profile photo. Users can update themselves, and admins the clients holding a credit account in their establishment or its branches; other users are not found.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
@@ -423,13 +623,8 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
-	// Authorization Logic:
-	authUserID := middleware.GetUserIDFromContext(ctx)
-	authUserRole := middleware.GetUserRoleFromContext(ctx)
-
-	// Allow admins to update any user, but clients can only update themselves
-	if authUserRole != enums.ADMIN && authUserID != uint(userID) {
-		ctx.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Forbidden: You are not authorized to update this user"})
+	// Users update themselves, and admins the clients of their establishment
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeUserChange(requestCaller(ctx), uint(userID)), "User not found") {
 		return
 	}
 
@@ -447,6 +642,54 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, userResponse)
 }
 
+// PatchUser godoc
+// @Summary      Patch User
+// @Description  Changes only the user details the request sets, including the URL of the profile photo. Unlike PUT, blank values are set too. Fields left out or null are kept. Users can patch themselves, and admins the clients holding a credit account in their establishment or its branches; other users are not found.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header      string                  true  "Bearer {token}"
+// @Param        id             path      int                      true  "User ID"
+// @Param        user           body      request.PatchUserRequest  true  "User fields to change"
+// @Success      200  {object}  response.UserResponse
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      401  {object}  response.ErrorResponse
+// @Failure      403  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /users/{id} [patch]
+func (c *UserController) PatchUser(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	var req request.PatchUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Users update themselves, and admins the clients of their establishment
+	if respondUnauthorized(ctx, c.authorizationService.AuthorizeUserChange(requestCaller(ctx), uint(userID)), "User not found") {
+		return
+	}
+
+	// Update user using the service (which handles photo uploads)
+	userResponse, err := c.userService.PatchUser(uint(userID), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, response.ErrorResponse{Error: "User not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating user: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userResponse)
+}
+
 // GetUserIDByEmail godoc
 // @Summary      Get User ID by Email
 // @Description  Retrieves the ID of a user by their email address. This endpoint is typically for internal use or admin purposes.
@@ -505,7 +748,7 @@ func _NewUserResponse(user *entities.User) *response.UserResponse {
 		Name:      user.Name,
 		Address:   user.Address,
 		Phone:     user.Phone,
-		PhotoUrl:  user.PhotoUrl,
+		PhotoUrl:  util.UserPhotoURL(user.PhotoUrl, user.Name),
 		Rol:       user.Rol,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
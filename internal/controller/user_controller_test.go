@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/testutil/fixture"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// stubUserService changes users without storing them.
+type stubUserService struct {
+	service.UserService
+	changed []uint
+}
+
+func (s *stubUserService) PatchUser(userID uint, req request.PatchUserRequest) (*response.UserResponse, error) {
+	s.changed = append(s.changed, userID)
+	return &response.UserResponse{ID: userID}, nil
+}
+
+func (s *stubUserService) UpdateUser(userID uint, req request.UpdateUserRequest) (*response.UserResponse, error) {
+	return s.PatchUser(userID, req.Patch())
+}
+
+func (s *stubUserService) GetClientsByEstablishmentID(establishmentID uint, tags []string) ([]entities.User, error) {
+	return []entities.User{*fixture.Client().Build()}, nil
+}
+
+func TestClientsAreListedForTheCallersEstablishmentsOnly(t *testing.T) {
+	tests := []struct {
+		name            string
+		establishmentID uint
+		want            int
+	}{
+		{"the establishment of the admin", fixture.EstablishmentID, http.StatusOK},
+		{"another establishment", fixture.EstablishmentID + 1, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewUserController(&stubUserService{}, nil, nil, nil, nil, nil, nil)
+			router := newTestRouter(establishmentAdmin)
+			router.GET("/establishments/:establishmentID/clients", c.GetClientsByEstablishmentID)
+
+			path := fmt.Sprintf("/establishments/%d/clients", tt.establishmentID)
+			if recorder := serve(t, router, http.MethodGet, path, nil); recorder.Code != tt.want {
+				t.Errorf("GET %s = %d %s, want %d", path, recorder.Code, recorder.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserChangesAreLimitedToTheCallersClients(t *testing.T) {
+	const otherEstablishmentID, branchID uint = 2, 3
+	admin := caller{userID: fixture.AdminID, role: enums.ADMIN, scope: tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID, branchID}}}
+	client := caller{userID: fixture.ClientID, role: enums.CLIENT, scope: tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID}, ClientID: fixture.ClientID}}
+	tests := []struct {
+		name             string
+		as               caller
+		userID           uint
+		establishmentIDs []uint // Where the user holds credit accounts, nil if not looked up
+		want             int
+	}{
+		{"an admin changes a client of the establishment", admin, fixture.ClientID, []uint{fixture.EstablishmentID}, http.StatusOK},
+		{"an admin changes a client of a branch", admin, fixture.ClientID, []uint{otherEstablishmentID, branchID}, http.StatusOK},
+		{"an admin changes a client of another establishment", admin, fixture.ClientID, []uint{otherEstablishmentID}, http.StatusNotFound},
+		{"an admin changes a user without credit accounts", admin, 30, []uint{}, http.StatusNotFound},
+		{"an admin changes themselves", admin, fixture.AdminID, nil, http.StatusOK},
+		{"a client changes themselves", client, fixture.ClientID, nil, http.StatusOK},
+		{"a client changes another user", client, 30, nil, http.StatusForbidden},
+	}
+	for _, method := range []string{http.MethodPatch, http.MethodPut} {
+		for _, tt := range tests {
+			t.Run(method+" "+tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				accounts := mocks.NewMockCreditAccountRepository(ctrl)
+				if tt.establishmentIDs != nil {
+					accounts.EXPECT().GetClientEstablishmentIDs(tt.userID).Return(tt.establishmentIDs, nil)
+				}
+				users := &stubUserService{}
+				authorization := service.NewAuthorizationService(accounts, mocks.NewMockTransactionRepository(ctrl), mocks.NewMockInstallmentRepository(ctrl))
+				c := NewUserController(users, nil, nil, nil, nil, nil, authorization)
+				router := newTestRouter(tt.as)
+				router.PATCH("/users/:id", c.PatchUser)
+				router.PUT("/users/:id", c.UpdateUser)
+
+				path := fmt.Sprintf("/users/%d", tt.userID)
+				recorder := serve(t, router, method, path, map[string]string{"name": "Nuevo Nombre"})
+				if recorder.Code != tt.want {
+					t.Fatalf("%s %s = %d %s, want %d", method, path, recorder.Code, recorder.Body, tt.want)
+				}
+				if changed := len(users.changed) > 0; changed != (tt.want == http.StatusOK) {
+					t.Errorf("user changed = %t with status %d", changed, recorder.Code)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PostgreSQL error codes that are worth retrying.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	adminShutdown        = "57P01"
+	cannotConnectNow     = "57P03"
+)
+
+const uniqueViolation = "23505"
+
+// IsTransient reports whether err is a database error that may succeed when the whole
+// operation is retried: serialization failures, deadlocks, a server that is restarting, or a
+// connection that broke before the statement was sent.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case serializationFailure, deadlockDetected, adminShutdown, cannotConnectNow:
+			return true
+		}
+		// Class 08: connection exceptions
+		return strings.HasPrefix(pgErr.Code, "08")
+	}
+
+	// A connection that fails after the statement was sent is not retried: it may have been applied
+	return errors.Is(err, driver.ErrBadConn) || pgconn.SafeToRetry(err)
+}
+
+// UniqueViolation reports whether err is a write rejected by a unique constraint or index, and
+// returns its name.
+func UniqueViolation(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return pgErr.ConstraintName, true
+	}
+	return "", false
+}
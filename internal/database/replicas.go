@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	readReplicaKey = "database:read_replica"
+
+	// asOfMargin covers the time between a row's timestamp being taken and its transaction committing,
+	// so a replica that replayed past a moment also has the rows dated before it.
+	asOfMargin = time.Minute
+)
+
+// ReadReplica marks the queries of db as safe to run on a read replica that lags behind the primary
+// by up to the configured maximum. Queries in a transaction always run on the primary, and so does
+// everything when no replica is configured or none is available.
+func ReadReplica(db *gorm.DB) *gorm.DB {
+	return db.Set(readReplicaKey, time.Time{})
+}
+
+// ReadReplicaAsOf is ReadReplica for queries that only look at rows dated before asOf, e.g. a period
+// of a statement. They only run on a replica that has replayed everything committed up to then, so
+// they see the same rows as on the primary. A zero asOf keeps the queries on the primary.
+func ReadReplicaAsOf(db *gorm.DB, asOf time.Time) *gorm.DB {
+	if asOf.IsZero() {
+		return db
+	}
+	return db.Set(readReplicaKey, asOf)
+}
+
+// Replicas routes the queries marked with ReadReplica to read replicas of the primary database. It
+// checks how far behind each replica is every interval and stops using the ones that fall behind by
+// more than maxLag or stop answering until they catch up again.
+type Replicas struct {
+	replicas []*replica
+	maxLag   time.Duration
+	interval time.Duration
+	next     atomic.Uint32
+}
+
+type replica struct {
+	db *sql.DB
+
+	mu        sync.RWMutex
+	available bool
+	caughtUp  time.Time // Everything committed on the primary until then has been replayed
+}
+
+// UseReplicas connects to the replicas behind dsns and registers them on db.
+func UseReplicas(db *gorm.DB, dsns []string, maxLag, interval time.Duration) (*Replicas, error) {
+	r := &Replicas{maxLag: maxLag, interval: interval}
+	for _, dsn := range dsns {
+		replicaDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to read replica: %w", err)
+		}
+		sqlDB, err := replicaDB.DB()
+		if err != nil {
+			return nil, err
+		}
+		r.replicas = append(r.replicas, &replica{db: sqlDB})
+	}
+
+	if err := db.Callback().Query().Before("gorm:query").Register(readReplicaKey, r.route); err != nil {
+		return nil, err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register(readReplicaKey, r.route); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Start checks the replicas right away and then every interval in the background until ctx is done.
+// Replicas aren't used before their first check.
+func (r *Replicas) Start(ctx context.Context) {
+	r.checkAll(ctx)
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.checkAll(ctx)
+			}
+		}
+	}()
+}
+
+func (r *Replicas) checkAll(ctx context.Context) {
+	for _, replica := range r.replicas {
+		replica.check(ctx, r.maxLag)
+	}
+}
+
+// route moves a query marked with ReadReplica to an up-to-date enough replica.
+func (r *Replicas) route(tx *gorm.DB) {
+	value, ok := tx.Get(readReplicaKey)
+	if !ok || tx.Error != nil {
+		return
+	}
+	if _, inTransaction := tx.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return
+	}
+	asOf, _ := value.(time.Time)
+	if replica := r.pick(asOf); replica != nil {
+		tx.Statement.ConnPool = replica.db
+	}
+}
+
+// pick returns the next replica in turn that can serve a query as of asOf, nil if none can.
+func (r *Replicas) pick(asOf time.Time) *replica {
+	start := int(r.next.Add(1))
+	for i := range r.replicas {
+		replica := r.replicas[(start+i)%len(r.replicas)]
+		if replica.canServe(asOf) {
+			return replica
+		}
+	}
+	return nil
+}
+
+func (rp *replica) canServe(asOf time.Time) bool {
+	rp.mu.RLock()
+	defer rp.mu.RUnlock()
+	return rp.available && (asOf.IsZero() || rp.caughtUp.After(asOf.Add(asOfMargin)))
+}
+
+// check asks the replica how much it has replayed and only keeps it available if it answers and lags
+// by at most maxLag. An idle replica that received nothing new is caught up even though its last
+// replayed transaction is old.
+func (rp *replica) check(ctx context.Context, maxLag time.Duration) {
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	var inRecovery, idle sql.NullBool
+	var lastReplay sql.NullTime
+	err := rp.db.QueryRowContext(pingCtx,
+		"SELECT pg_is_in_recovery(), pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn(), pg_last_xact_replay_timestamp()").
+		Scan(&inRecovery, &idle, &lastReplay)
+
+	now := time.Now()
+	caughtUp := now
+	if err == nil && inRecovery.Bool && !idle.Bool {
+		caughtUp = lastReplay.Time
+	}
+	lag := now.Sub(caughtUp)
+	available := err == nil && lag <= maxLag
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	switch {
+	case available && !rp.available:
+		log.Println("read replica available")
+	case err != nil && rp.available:
+		log.Println("read replica unavailable:", err)
+	case !available && rp.available:
+		log.Printf("read replica is %s behind, using the primary", lag.Round(time.Second))
+	}
+	rp.available = available
+	rp.caughtUp = caughtUp
+}
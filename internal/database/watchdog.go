@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	pingTimeout       = 2 * time.Second
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Watchdog keeps track of whether the database is reachable. It pings the database every interval
+// and, once a ping fails, keeps trying to reconnect with exponential backoff until it answers again.
+// Broken connections are dropped by the pool, so a successful ping means a fresh connection could be made.
+type Watchdog struct {
+	db       *sql.DB
+	interval time.Duration
+
+	mu        sync.RWMutex
+	available bool
+	delay     time.Duration
+	nextCheck time.Time
+}
+
+// NewWatchdog creates a Watchdog for the connection pool behind db. It assumes the database
+// is available until a check says otherwise.
+func NewWatchdog(db *gorm.DB, interval time.Duration) (*Watchdog, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return &Watchdog{db: sqlDB, interval: interval, available: true}, nil
+}
+
+// Start runs the health checks in the background until ctx is done.
+func (w *Watchdog) Start(ctx context.Context) {
+	go func() {
+		for {
+			w.Check(ctx)
+
+			timer := time.NewTimer(w.waitTime())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// Check pings the database right away, updates the availability and reports whether it answered.
+func (w *Watchdog) Check(ctx context.Context) bool {
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	err := w.db.PingContext(pingCtx)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	if err == nil {
+		if !w.available {
+			log.Println("database connection restored")
+		}
+		w.available = true
+		w.delay = 0
+		w.nextCheck = now.Add(w.interval)
+		return true
+	}
+
+	if w.available {
+		log.Println("database unavailable:", err)
+		w.delay = minReconnectDelay
+	} else {
+		w.delay = min(2*w.delay, maxReconnectDelay)
+	}
+	w.available = false
+	w.nextCheck = now.Add(w.delay)
+	return false
+}
+
+// Available reports whether the last check found the database reachable.
+func (w *Watchdog) Available() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.available
+}
+
+// RetryAfter returns how long clients should wait before retrying while the database is
+// unavailable: the time until the next reconnection attempt, at least one second.
+func (w *Watchdog) RetryAfter() time.Duration {
+	return max(w.waitTime(), time.Second)
+}
+
+func (w *Watchdog) waitTime() time.Duration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return max(time.Until(w.nextCheck), 0)
+}
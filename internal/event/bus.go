@@ -0,0 +1,110 @@
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+// Name identifies a kind of domain event.
+type Name string
+
+const (
+	TransactionCreated   Name = "transaction.created"
+	TransactionUpdated   Name = "transaction.updated"
+	TransactionDeleted   Name = "transaction.deleted"
+	PaymentConfirmed     Name = "payment.confirmed"
+	InterestAccrued      Name = "interest.accrued"
+	LateFeeApplied       Name = "late_fee.applied"
+	CreditAccountUpdated Name = "credit_account.updated"
+	CreditAccountDeleted Name = "credit_account.deleted"
+	PurchaseCreated      Name = "purchase.created"
+	AccountBlocked       Name = "account.blocked"
+	AccountUnblocked     Name = "account.unblocked"
+	AccountWrittenOff    Name = "account.written_off"
+	AccountClosed        Name = "account.closed"
+	AccountReopened      Name = "account.reopened"
+
+	// An admin moved the due date of an installment, having agreed it with the client
+	InstallmentRescheduled Name = "installment.rescheduled"
+
+	// The client accepts the credit agreement of a new account before making purchases
+	CreditAgreementAccepted Name = "credit_agreement.accepted"
+
+	// A client purchase above the approval threshold waits for an admin, who approves (making the
+	// purchase) or rejects it, unless it expires first
+	PurchaseApprovalRequested Name = "purchase.approval_requested"
+	PurchaseApproved          Name = "purchase.approved"
+	PurchaseRejected          Name = "purchase.rejected"
+	PurchaseApprovalExpired   Name = "purchase.approval_expired"
+
+	// A promise to pay suspends late fees until its date, then it is kept or broken
+	PaymentPromiseCreated Name = "payment_promise.created"
+	PaymentPromiseKept    Name = "payment_promise.kept"
+	PaymentPromiseBroken  Name = "payment_promise.broken"
+
+	// A payment link is paid once the payment made through it is confirmed, unless it expires first
+	PaymentLinkCreated Name = "payment_link.created"
+	PaymentLinkPaid    Name = "payment_link.paid"
+	PaymentLinkExpired Name = "payment_link.expired"
+
+	// A non-cash payment fails unless an admin confirms it, before it expires, with the code sent to the client
+	PaymentFailed Name = "payment.failed"
+)
+
+// Event is something that happened to a credit account.
+type Event struct {
+	Name            Name
+	CreditAccountID uint
+	OccurredAt      time.Time
+	Payload         any // Optional event-specific data
+}
+
+// Handler reacts to a published event.
+type Handler func(evt Event)
+
+// Bus delivers published events to the handlers subscribed to them.
+type Bus interface {
+	Publish(evt Event)
+	Subscribe(name Name, handler Handler)
+	SubscribeAll(handler Handler)
+}
+
+type inMemoryBus struct {
+	mu       sync.RWMutex
+	handlers map[Name][]Handler
+	all      []Handler
+}
+
+// NewInMemoryBus creates a Bus that calls handlers synchronously in the publishing goroutine.
+func NewInMemoryBus() Bus {
+	return &inMemoryBus{handlers: make(map[Name][]Handler)}
+}
+
+// Publish delivers evt to every handler subscribed to its name and to every catch-all handler.
+func (b *inMemoryBus) Publish(evt Event) {
+	if evt.OccurredAt.IsZero() {
+		evt.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[evt.Name]...), b.all...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(evt)
+	}
+}
+
+// Subscribe registers handler for events with the given name.
+func (b *inMemoryBus) Subscribe(name Name, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// SubscribeAll registers handler for every event.
+func (b *inMemoryBus) SubscribeAll(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, handler)
+}
@@ -0,0 +1,46 @@
+package finance
+
+import (
+	"ApiRestFinance/internal/interest"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+)
+
+// longTermInstallments is the number of installments long-term purchases are split into.
+const longTermInstallments = 12
+
+// AccountRates returns the TNA, TEA and TCEA a credit account's configuration implies, the TNA
+// being capitalized per the account's compounding period. The TCEA is measured on a unit purchase
+// repaid the way the account repays: in one month for short-term credit, and in equal installments
+// starting once the interest-free grace months are over for long-term credit.
+func AccountRates(account entities.CreditAccount) (Rates, error) {
+	annualRate := account.InterestRate / 100
+	monthlyRate, err := interest.RateForMonths(annualRate, account.InterestType, account.CompoundingPeriod, 1)
+	if err != nil {
+		return Rates{}, err
+	}
+
+	var rates Rates
+	if rates.TNA, err = interest.NominalAnnualRate(annualRate, account.InterestType, account.CompoundingPeriod); err != nil {
+		return Rates{}, err
+	}
+	if rates.TEA, err = interest.EffectiveAnnualRate(annualRate, account.InterestType, account.CompoundingPeriod); err != nil {
+		return Rates{}, err
+	}
+
+	cashFlows := []float64{-1}
+	if account.CreditType == enums.LongTerm {
+		for i := 0; i < account.GracePeriod; i++ {
+			cashFlows = append(cashFlows, 0)
+		}
+		payment := FrenchPayment(1, monthlyRate, longTermInstallments)
+		for i := 0; i < longTermInstallments; i++ {
+			cashFlows = append(cashFlows, payment)
+		}
+	} else {
+		cashFlows = append(cashFlows, 1+monthlyRate)
+	}
+	rates.TCEA = TCEA(cashFlows)
+
+	return rates, nil
+}
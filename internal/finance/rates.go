@@ -0,0 +1,67 @@
+// Package finance holds the repayment and cost-of-credit calculations used to disclose
+// TNA (tasa nominal anual), TEA (tasa efectiva anual) and TCEA (tasa de costo efectivo anual).
+// Rate conversions live in the interest package. All rates are expressed as decimals (0.24 for 24%).
+package finance
+
+import (
+	"math"
+)
+
+// MonthsPerYear is the number of monthly periods accounts are billed in per year.
+const MonthsPerYear = 12
+
+// Rates are the annual rates disclosed for a credit.
+type Rates struct {
+	TNA  float64
+	TEA  float64
+	TCEA float64
+}
+
+// AnnualizePeriodicRate returns the effective annual rate equivalent to rate compounded periodsPerYear times.
+func AnnualizePeriodicRate(rate float64, periodsPerYear int) float64 {
+	return math.Pow(1+rate, float64(periodsPerYear)) - 1
+}
+
+// FrenchPayment returns the constant installment that repays principal in n periods at rate.
+func FrenchPayment(principal, rate float64, n int) float64 {
+	if rate == 0 {
+		return principal / float64(n)
+	}
+	return principal * rate / (1 - math.Pow(1+rate, -float64(n)))
+}
+
+// InternalRateOfReturn finds the periodic rate at which cashFlows (one per period, the first being
+// the disbursement as a negative amount) have a net present value of zero. It uses bisection, which
+// converges for the single sign change of a loan's cash flows.
+func InternalRateOfReturn(cashFlows []float64) float64 {
+	npv := func(rate float64) float64 {
+		var total float64
+		for i, flow := range cashFlows {
+			total += flow / math.Pow(1+rate, float64(i))
+		}
+		return total
+	}
+
+	if npv(0) <= 0 {
+		return 0
+	}
+	low, high := 0.0, 1.0
+	for npv(high) > 0 {
+		high *= 2
+	}
+	for i := 0; i < 200 && high-low > 1e-12; i++ {
+		mid := (low + high) / 2
+		if npv(mid) > 0 {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// TCEA returns the effective annual cost of monthly cash flows: every payment the client makes,
+// interest and charges alike, measured against the amount disbursed.
+func TCEA(monthlyCashFlows []float64) float64 {
+	return AnnualizePeriodicRate(InternalRateOfReturn(monthlyCashFlows), MonthsPerYear)
+}
@@ -0,0 +1,132 @@
+package finance
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"math"
+	"testing"
+)
+
+func approx(a, b, tolerance float64) bool {
+	return math.Abs(a-b) < tolerance
+}
+
+func TestFrenchPayment(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal float64
+		rate      float64
+		n         int
+		want      float64
+	}{
+		{"1% monthly over a year", 1000, 0.01, 12, 88.8487886783416},
+		{"a single period", 1000, 0.01, 1, 1010},
+		{"no interest", 1200, 0, 12, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FrenchPayment(tt.principal, tt.rate, tt.n); !approx(got, tt.want, 1e-9) {
+				t.Errorf("FrenchPayment(%v, %v, %d) = %v, want %v", tt.principal, tt.rate, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInternalRateOfReturn(t *testing.T) {
+	payment := FrenchPayment(1000, 0.01, 12)
+	loan := []float64{-1000}
+	for i := 0; i < 12; i++ {
+		loan = append(loan, payment)
+	}
+
+	tests := []struct {
+		name      string
+		cashFlows []float64
+		want      float64
+	}{
+		{"French loan", loan, 0.01},
+		{"one period", []float64{-100, 110}, 0.1},
+		{"repaid without interest", []float64{-100, 50, 50}, 0},
+		{"repaid less than disbursed", []float64{-100, 90}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InternalRateOfReturn(tt.cashFlows); !approx(got, tt.want, 1e-9) {
+				t.Errorf("InternalRateOfReturn(%v) = %v, want %v", tt.cashFlows, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTCEA(t *testing.T) {
+	if got, want := TCEA([]float64{-1, 1.01}), math.Pow(1.01, 12)-1; !approx(got, want, 1e-9) {
+		t.Errorf("TCEA of 1%% a month = %v, want %v", got, want)
+	}
+}
+
+func TestAnnualizePeriodicRate(t *testing.T) {
+	if got, want := AnnualizePeriodicRate(0.03, 4), 0.12550881; !approx(got, want, 1e-9) {
+		t.Errorf("AnnualizePeriodicRate(0.03, 4) = %v, want %v", got, want)
+	}
+}
+
+func TestAccountRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		account entities.CreditAccount
+		tna     float64
+		tea     float64
+		tcea    float64
+	}{
+		{
+			name:    "short-term effective rate",
+			account: entities.CreditAccount{InterestRate: 24, InterestType: enums.Effective, CompoundingPeriod: enums.Monthly, CreditType: enums.ShortTerm},
+			tna:     0.21705098980212867,
+			tea:     0.24,
+			tcea:    0.24,
+		},
+		{
+			name:    "short-term nominal rate",
+			account: entities.CreditAccount{InterestRate: 12, InterestType: enums.Nominal, CompoundingPeriod: enums.Monthly, CreditType: enums.ShortTerm},
+			tna:     0.12,
+			tea:     0.12682503013196977,
+			tcea:    0.12682503013196977,
+		},
+		{
+			name:    "long-term without grace",
+			account: entities.CreditAccount{InterestRate: 24, InterestType: enums.Effective, CompoundingPeriod: enums.Monthly, CreditType: enums.LongTerm},
+			tna:     0.21705098980212867,
+			tea:     0.24,
+			tcea:    0.24,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := AccountRates(tt.account)
+			if err != nil {
+				t.Fatalf("AccountRates returned %v", err)
+			}
+			if !approx(rates.TNA, tt.tna, 1e-9) || !approx(rates.TEA, tt.tea, 1e-9) || !approx(rates.TCEA, tt.tcea, 1e-6) {
+				t.Errorf("AccountRates = %+v, want TNA %v, TEA %v, TCEA %v", rates, tt.tna, tt.tea, tt.tcea)
+			}
+		})
+	}
+}
+
+func TestAccountRatesGracePeriodLowersTCEA(t *testing.T) {
+	// Grace months are interest-free, so the client pays the same installments later
+	account := entities.CreditAccount{InterestRate: 24, InterestType: enums.Effective, CompoundingPeriod: enums.Monthly, CreditType: enums.LongTerm, GracePeriod: 2}
+	rates, err := AccountRates(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates.TCEA >= rates.TEA || rates.TCEA <= 0 {
+		t.Errorf("TCEA with 2 grace months = %v, want between 0 and the TEA %v", rates.TCEA, rates.TEA)
+	}
+}
+
+func TestAccountRatesInvalidInterestType(t *testing.T) {
+	if _, err := AccountRates(entities.CreditAccount{InterestRate: 24, InterestType: "SIMPLE"}); err == nil {
+		t.Error("AccountRates accepted an invalid interest type")
+	}
+}
@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+)
+
+// batchWait is how long a loader collects keys before querying. Resolvers of sibling fields
+// run concurrently, so this is enough for all the accounts of a response to end up in one batch.
+const batchWait = 2 * time.Millisecond
+
+// loader batches the lookups of one kind made while resolving a request into a single fetch and
+// caches the results for the rest of the request. Keys missing from a fetch resolve to the zero value.
+type loader[V any] struct {
+	fetch func(keys []uint) (map[uint]V, error)
+
+	mu      sync.Mutex
+	pending *batch[V]
+	cache   map[uint]*batch[V]
+}
+
+type batch[V any] struct {
+	keys    []uint
+	done    chan struct{}
+	results map[uint]V
+	err     error
+}
+
+func newLoader[V any](fetch func(keys []uint) (map[uint]V, error)) *loader[V] {
+	return &loader[V]{fetch: fetch, cache: make(map[uint]*batch[V])}
+}
+
+// Load returns the value for key, waiting for the batch it is fetched in.
+func (l *loader[V]) Load(key uint) (V, error) {
+	l.mu.Lock()
+	b, ok := l.cache[key]
+	if !ok {
+		if l.pending == nil {
+			l.pending = &batch[V]{done: make(chan struct{})}
+			time.AfterFunc(batchWait, l.dispatch)
+		}
+		b = l.pending
+		b.keys = append(b.keys, key)
+		l.cache[key] = b
+	}
+	l.mu.Unlock()
+
+	<-b.done
+	return b.results[key], b.err
+}
+
+func (l *loader[V]) dispatch() {
+	l.mu.Lock()
+	b := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	b.results, b.err = l.fetch(b.keys)
+	close(b.done)
+}
+
+// loaders holds the per-request loaders of the fields that would otherwise run one query per credit account.
+type loaders struct {
+	installments *loader[[]response.InstallmentResponse]
+	transactions *loader[[]response.TransactionResponse]
+}
+
+func newLoaders(installmentService service.InstallmentService, transactionService service.TransactionService) *loaders {
+	return &loaders{
+		installments: newLoader(installmentService.GetInstallmentsByCreditAccountIDs),
+		transactions: newLoader(transactionService.GetTransactionsByCreditAccountIDs),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
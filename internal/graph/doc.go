@@ -0,0 +1,12 @@
+// Package graph implements the /graphql endpoint with gqlgen. The executable schema in generated.go
+// is generated from schema.graphqls; the endpoint is only served when building with the graphql
+// build tag:
+//
+//	go build -tags graphql
+//
+// Regenerate the schema after changing it, which keeps the resolvers in schema.resolvers.go:
+//
+//	go generate ./internal/graph
+package graph
+
+//go:generate go run github.com/99designs/gqlgen generate --config gqlgen.yml
@@ -0,0 +1,31 @@
+//go:build graphql
+
+package graph
+
+import (
+	"context"
+
+	"ApiRestFinance/internal/middleware"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// NewHandler returns the gin handler of the /graphql endpoint. It must run after the auth middleware.
+func NewHandler(userService service.UserService, creditAccountService service.CreditAccountService, purchaseService service.PurchaseService, installmentService service.InstallmentService, transactionService service.TransactionService) gin.HandlerFunc {
+	server := handler.NewDefaultServer(NewExecutableSchema(Config{Resolvers: &Resolver{
+		userService:          userService,
+		creditAccountService: creditAccountService,
+		purchaseService:      purchaseService,
+	}}))
+
+	return func(c *gin.Context) {
+		ctx := withLoaders(c.Request.Context(), newLoaders(installmentService, transactionService))
+		if middleware.GetUserRoleFromContext(c) == enums.CLIENT {
+			ctx = context.WithValue(ctx, clientKey{}, middleware.GetUserIDFromContext(c))
+		}
+		server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	}
+}
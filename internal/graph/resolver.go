@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"ApiRestFinance/internal/service"
+)
+
+var errClientsOnly = errors.New("only clients can use the GraphQL API")
+
+type clientKey struct{}
+
+// Resolver resolves the schema with the services the REST API uses.
+type Resolver struct {
+	userService          service.UserService
+	creditAccountService service.CreditAccountService
+	purchaseService      service.PurchaseService
+}
+
+// clientFromContext returns the ID of the authenticated client.
+func clientFromContext(ctx context.Context) (uint, error) {
+	clientID, ok := ctx.Value(clientKey{}).(uint)
+	if !ok {
+		return 0, errClientsOnly
+	}
+	return clientID, nil
+}
@@ -0,0 +1,119 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/service"
+	"context"
+)
+
+// CreditAccounts is the resolver for the creditAccounts field.
+func (r *clientResolver) CreditAccounts(ctx context.Context, obj *response.UserResponse) ([]response.CreditAccountResponse, error) {
+	return r.purchaseService.GetClientCreditAccounts(obj.ID, service.AllCreditAccountIncludes)
+}
+
+// InterestType is the resolver for the interestType field.
+func (r *creditAccountResolver) InterestType(ctx context.Context, obj *response.CreditAccountResponse) (string, error) {
+	return string(obj.InterestType), nil
+}
+
+// CreditType is the resolver for the creditType field.
+func (r *creditAccountResolver) CreditType(ctx context.Context, obj *response.CreditAccountResponse) (string, error) {
+	return string(obj.CreditType), nil
+}
+
+// Installments is the resolver for the installments field.
+func (r *creditAccountResolver) Installments(ctx context.Context, obj *response.CreditAccountResponse, status *string) ([]response.InstallmentResponse, error) {
+	// Installments of all the accounts in a response are loaded together
+	installments, err := loadersFromContext(ctx).installments.Load(obj.ID)
+	if err != nil || status == nil {
+		return installments, err
+	}
+
+	filtered := make([]response.InstallmentResponse, 0, len(installments))
+	for _, installment := range installments {
+		if string(installment.Status) == *status {
+			filtered = append(filtered, installment)
+		}
+	}
+	return filtered, nil
+}
+
+// Transactions is the resolver for the transactions field.
+func (r *creditAccountResolver) Transactions(ctx context.Context, obj *response.CreditAccountResponse, first *int) ([]response.TransactionResponse, error) {
+	// Transactions of all the accounts in a response are loaded together
+	transactions, err := loadersFromContext(ctx).transactions.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if first != nil && *first >= 0 && *first < len(transactions) {
+		transactions = transactions[:*first]
+	}
+	return transactions, nil
+}
+
+// Status is the resolver for the status field.
+func (r *installmentResolver) Status(ctx context.Context, obj *response.InstallmentResponse) (string, error) {
+	return string(obj.Status), nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*response.UserResponse, error) {
+	clientID, err := clientFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.userService.GetUserByID(clientID)
+}
+
+// CreditAccount is the resolver for the creditAccount field.
+func (r *queryResolver) CreditAccount(ctx context.Context, establishmentID *uint) (*response.CreditAccountResponse, error) {
+	clientID, err := clientFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var establishment uint
+	if establishmentID != nil {
+		establishment = *establishmentID
+	}
+	return r.creditAccountService.GetCreditAccountByClientID(clientID, establishment)
+}
+
+// TransactionType is the resolver for the transactionType field.
+func (r *transactionResolver) TransactionType(ctx context.Context, obj *response.TransactionResponse) (string, error) {
+	return string(obj.TransactionType), nil
+}
+
+// PaymentMethod is the resolver for the paymentMethod field.
+func (r *transactionResolver) PaymentMethod(ctx context.Context, obj *response.TransactionResponse) (string, error) {
+	return string(obj.PaymentMethod), nil
+}
+
+// PaymentStatus is the resolver for the paymentStatus field.
+func (r *transactionResolver) PaymentStatus(ctx context.Context, obj *response.TransactionResponse) (string, error) {
+	return string(obj.PaymentStatus), nil
+}
+
+// Client returns ClientResolver implementation.
+func (r *Resolver) Client() ClientResolver { return &clientResolver{r} }
+
+// CreditAccount returns CreditAccountResolver implementation.
+func (r *Resolver) CreditAccount() CreditAccountResolver { return &creditAccountResolver{r} }
+
+// Installment returns InstallmentResolver implementation.
+func (r *Resolver) Installment() InstallmentResolver { return &installmentResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Transaction returns TransactionResolver implementation.
+func (r *Resolver) Transaction() TransactionResolver { return &transactionResolver{r} }
+
+type clientResolver struct{ *Resolver }
+type creditAccountResolver struct{ *Resolver }
+type installmentResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type transactionResolver struct{ *Resolver }
@@ -0,0 +1,7 @@
+//go:build tools
+
+package graph
+
+// Keeps gqlgen and the dependencies of its generator in go.mod, so go generate runs the version
+// the generated code was made with.
+import _ "github.com/99designs/gqlgen"
@@ -0,0 +1,15 @@
+//go:build grpc
+
+// Package grpcserver serves the read-only FinanceQueryService defined in
+// proto/finance/v1/finance.proto to internal services such as analytics. It is only compiled
+// with the grpc build tag:
+//
+//	go build -tags grpc
+//
+// The financev1 package is generated from the proto file with protoc, protoc-gen-go v1.34.1 and
+// protoc-gen-go-grpc v1.4.0. Regenerate it after changing the proto file:
+//
+//	go generate -tags grpc ./internal/grpcserver
+package grpcserver
+
+//go:generate protoc -I ../../proto --go_out=. --go_opt=module=ApiRestFinance/internal/grpcserver --go-grpc_out=. --go-grpc_opt=module=ApiRestFinance/internal/grpcserver finance/v1/finance.proto
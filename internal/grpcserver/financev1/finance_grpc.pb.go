@@ -0,0 +1,270 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.1
+// source: finance/v1/finance.proto
+
+package financev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	FinanceQueryService_GetCreditAccount_FullMethodName   = "/finance.v1.FinanceQueryService/GetCreditAccount"
+	FinanceQueryService_ListCreditAccounts_FullMethodName = "/finance.v1.FinanceQueryService/ListCreditAccounts"
+	FinanceQueryService_ListTransactions_FullMethodName   = "/finance.v1.FinanceQueryService/ListTransactions"
+	FinanceQueryService_GetClient_FullMethodName          = "/finance.v1.FinanceQueryService/GetClient"
+	FinanceQueryService_ListClients_FullMethodName        = "/finance.v1.FinanceQueryService/ListClients"
+)
+
+// FinanceQueryServiceClient is the client API for FinanceQueryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FinanceQueryService gives internal services read-only access to credit accounts,
+// their transactions and clients. Every call needs an "authorization: Bearer <token>"
+// metadata entry with one of the tokens in GRPC_AUTH_TOKENS.
+type FinanceQueryServiceClient interface {
+	GetCreditAccount(ctx context.Context, in *GetCreditAccountRequest, opts ...grpc.CallOption) (*CreditAccount, error)
+	ListCreditAccounts(ctx context.Context, in *ListCreditAccountsRequest, opts ...grpc.CallOption) (*ListCreditAccountsResponse, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	GetClient(ctx context.Context, in *GetClientRequest, opts ...grpc.CallOption) (*Client, error)
+	ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error)
+}
+
+type financeQueryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFinanceQueryServiceClient(cc grpc.ClientConnInterface) FinanceQueryServiceClient {
+	return &financeQueryServiceClient{cc}
+}
+
+func (c *financeQueryServiceClient) GetCreditAccount(ctx context.Context, in *GetCreditAccountRequest, opts ...grpc.CallOption) (*CreditAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreditAccount)
+	err := c.cc.Invoke(ctx, FinanceQueryService_GetCreditAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeQueryServiceClient) ListCreditAccounts(ctx context.Context, in *ListCreditAccountsRequest, opts ...grpc.CallOption) (*ListCreditAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCreditAccountsResponse)
+	err := c.cc.Invoke(ctx, FinanceQueryService_ListCreditAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeQueryServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, FinanceQueryService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeQueryServiceClient) GetClient(ctx context.Context, in *GetClientRequest, opts ...grpc.CallOption) (*Client, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Client)
+	err := c.cc.Invoke(ctx, FinanceQueryService_GetClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeQueryServiceClient) ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClientsResponse)
+	err := c.cc.Invoke(ctx, FinanceQueryService_ListClients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FinanceQueryServiceServer is the server API for FinanceQueryService service.
+// All implementations must embed UnimplementedFinanceQueryServiceServer
+// for forward compatibility
+//
+// FinanceQueryService gives internal services read-only access to credit accounts,
+// their transactions and clients. Every call needs an "authorization: Bearer <token>"
+// metadata entry with one of the tokens in GRPC_AUTH_TOKENS.
+type FinanceQueryServiceServer interface {
+	GetCreditAccount(context.Context, *GetCreditAccountRequest) (*CreditAccount, error)
+	ListCreditAccounts(context.Context, *ListCreditAccountsRequest) (*ListCreditAccountsResponse, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	GetClient(context.Context, *GetClientRequest) (*Client, error)
+	ListClients(context.Context, *ListClientsRequest) (*ListClientsResponse, error)
+	mustEmbedUnimplementedFinanceQueryServiceServer()
+}
+
+// UnimplementedFinanceQueryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFinanceQueryServiceServer struct {
+}
+
+func (UnimplementedFinanceQueryServiceServer) GetCreditAccount(context.Context, *GetCreditAccountRequest) (*CreditAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCreditAccount not implemented")
+}
+func (UnimplementedFinanceQueryServiceServer) ListCreditAccounts(context.Context, *ListCreditAccountsRequest) (*ListCreditAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCreditAccounts not implemented")
+}
+func (UnimplementedFinanceQueryServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedFinanceQueryServiceServer) GetClient(context.Context, *GetClientRequest) (*Client, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClient not implemented")
+}
+func (UnimplementedFinanceQueryServiceServer) ListClients(context.Context, *ListClientsRequest) (*ListClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClients not implemented")
+}
+func (UnimplementedFinanceQueryServiceServer) mustEmbedUnimplementedFinanceQueryServiceServer() {}
+
+// UnsafeFinanceQueryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FinanceQueryServiceServer will
+// result in compilation errors.
+type UnsafeFinanceQueryServiceServer interface {
+	mustEmbedUnimplementedFinanceQueryServiceServer()
+}
+
+func RegisterFinanceQueryServiceServer(s grpc.ServiceRegistrar, srv FinanceQueryServiceServer) {
+	s.RegisterService(&FinanceQueryService_ServiceDesc, srv)
+}
+
+func _FinanceQueryService_GetCreditAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCreditAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceQueryServiceServer).GetCreditAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceQueryService_GetCreditAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceQueryServiceServer).GetCreditAccount(ctx, req.(*GetCreditAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceQueryService_ListCreditAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCreditAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceQueryServiceServer).ListCreditAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceQueryService_ListCreditAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceQueryServiceServer).ListCreditAccounts(ctx, req.(*ListCreditAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceQueryService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceQueryServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceQueryService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceQueryServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceQueryService_GetClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceQueryServiceServer).GetClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceQueryService_GetClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceQueryServiceServer).GetClient(ctx, req.(*GetClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceQueryService_ListClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceQueryServiceServer).ListClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceQueryService_ListClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceQueryServiceServer).ListClients(ctx, req.(*ListClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FinanceQueryService_ServiceDesc is the grpc.ServiceDesc for FinanceQueryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FinanceQueryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "finance.v1.FinanceQueryService",
+	HandlerType: (*FinanceQueryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCreditAccount",
+			Handler:    _FinanceQueryService_GetCreditAccount_Handler,
+		},
+		{
+			MethodName: "ListCreditAccounts",
+			Handler:    _FinanceQueryService_ListCreditAccounts_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _FinanceQueryService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "GetClient",
+			Handler:    _FinanceQueryService_GetClient_Handler,
+		},
+		{
+			MethodName: "ListClients",
+			Handler:    _FinanceQueryService_ListClients_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "finance/v1/finance.proto",
+}
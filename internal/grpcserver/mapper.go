@@ -0,0 +1,68 @@
+//go:build grpc
+
+package grpcserver
+
+import (
+	"ApiRestFinance/internal/grpcserver/financev1"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func creditAccountToProto(account *response.CreditAccountResponse) *financev1.CreditAccount {
+	return &financev1.CreditAccount{
+		Id:                uint32(account.ID),
+		ClientId:          uint32(account.ClientID),
+		EstablishmentId:   uint32(account.EstablishmentID),
+		CreditLimit:       account.CreditLimit,
+		CurrentBalance:    account.CurrentBalance,
+		AccountCredit:     account.AccountCredit,
+		MonthlyDueDate:    int32(account.MonthlyDueDate),
+		InterestRate:      account.InterestRate,
+		InterestType:      string(account.InterestType),
+		CreditType:        string(account.CreditType),
+		GracePeriod:       int32(account.GracePeriod),
+		IsBlocked:         account.IsBlocked,
+		LateFeePercentage: account.LateFeePercentage,
+		CreatedAt:         timestamppb.New(account.CreatedAt),
+		UpdatedAt:         timestamppb.New(account.UpdatedAt),
+	}
+}
+
+func transactionToProto(transaction *response.TransactionResponse) *financev1.Transaction {
+	return &financev1.Transaction{
+		Id:              uint32(transaction.ID),
+		CreditAccountId: uint32(transaction.CreditAccountID),
+		TransactionType: string(transaction.TransactionType),
+		Amount:          transaction.Amount,
+		Description:     transaction.Description,
+		TransactionDate: timestamppb.New(transaction.TransactionDate),
+		PaymentMethod:   string(transaction.PaymentMethod),
+		PaymentStatus:   string(transaction.PaymentStatus),
+	}
+}
+
+func userToProto(user *response.UserResponse) *financev1.Client {
+	return &financev1.Client{
+		Id:        uint32(user.ID),
+		Dni:       user.DNI,
+		Email:     user.Email,
+		Name:      user.Name,
+		Phone:     user.Phone,
+		Address:   user.Address,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+}
+
+func clientToProto(user *entities.User) *financev1.Client {
+	return &financev1.Client{
+		Id:        uint32(user.ID),
+		Dni:       user.DNI,
+		Email:     user.Email,
+		Name:      user.Name,
+		Phone:     user.Phone,
+		Address:   user.Address,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+}
@@ -0,0 +1,159 @@
+//go:build grpc
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"ApiRestFinance/internal/grpcserver/financev1"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Options configure the gRPC server. Without a certificate the server only starts when
+// AllowInsecure is set, which is meant for local development.
+type Options struct {
+	Address       string
+	TLSCertFile   string
+	TLSKeyFile    string
+	AllowInsecure bool
+	AuthTokens    []string
+}
+
+// Server implements FinanceQueryService on top of the same services the REST API uses.
+type Server struct {
+	financev1.UnimplementedFinanceQueryServiceServer
+	creditAccountService service.CreditAccountService
+	transactionService   service.TransactionService
+	userService          service.UserService
+}
+
+// NewServer creates a new instance of Server.
+func NewServer(creditAccountService service.CreditAccountService, transactionService service.TransactionService, userService service.UserService) *Server {
+	return &Server{
+		creditAccountService: creditAccountService,
+		transactionService:   transactionService,
+		userService:          userService,
+	}
+}
+
+// ListenAndServe serves the queries on opts.Address until the listener fails.
+func (s *Server) ListenAndServe(opts Options) error {
+	if len(opts.AuthTokens) == 0 {
+		return errors.New("grpc server needs at least one auth token")
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryAuthInterceptor(opts.AuthTokens)),
+		grpc.ChainStreamInterceptor(streamAuthInterceptor(opts.AuthTokens)),
+	}
+	switch {
+	case opts.TLSCertFile != "" && opts.TLSKeyFile != "":
+		creds, err := credentials.NewServerTLSFromFile(opts.TLSCertFile, opts.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("error loading grpc TLS certificate: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	case !opts.AllowInsecure:
+		return errors.New("grpc server needs a TLS certificate and key")
+	}
+
+	listener, err := net.Listen("tcp", opts.Address)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", opts.Address, err)
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	financev1.RegisterFinanceQueryServiceServer(grpcServer, s)
+	return grpcServer.Serve(listener)
+}
+
+// GetCreditAccount returns a credit account by ID.
+func (s *Server) GetCreditAccount(_ context.Context, req *financev1.GetCreditAccountRequest) (*financev1.CreditAccount, error) {
+	account, err := s.creditAccountService.GetCreditAccountByID(uint(req.GetId()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return creditAccountToProto(account), nil
+}
+
+// ListCreditAccounts returns the credit accounts of an establishment.
+func (s *Server) ListCreditAccounts(_ context.Context, req *financev1.ListCreditAccountsRequest) (*financev1.ListCreditAccountsResponse, error) {
+	accounts, err := s.creditAccountService.GetCreditAccountsByEstablishmentID(uint(req.GetEstablishmentId()), service.AllCreditAccountIncludes)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &financev1.ListCreditAccountsResponse{}
+	for i := range accounts {
+		resp.CreditAccounts = append(resp.CreditAccounts, creditAccountToProto(&accounts[i]))
+	}
+	return resp, nil
+}
+
+// ListTransactions returns a page of a credit account's transactions, newest first.
+func (s *Server) ListTransactions(_ context.Context, req *financev1.ListTransactionsRequest) (*financev1.ListTransactionsResponse, error) {
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+	// Internal services get the same page sizes as admins
+	pageSize, err := service.QueryLimitsForRole(enums.ADMIN).ResolvePageSize(int(req.GetPageSize()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	transactions, err := s.transactionService.GetTransactionsByCreditAccountID(uint(req.GetCreditAccountId()), page, pageSize)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &financev1.ListTransactionsResponse{}
+	for i := range transactions {
+		resp.Transactions = append(resp.Transactions, transactionToProto(&transactions[i]))
+	}
+	return resp, nil
+}
+
+// GetClient returns a client by user ID.
+func (s *Server) GetClient(_ context.Context, req *financev1.GetClientRequest) (*financev1.Client, error) {
+	user, err := s.userService.GetUserByID(uint(req.GetId()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if user.Rol != enums.CLIENT {
+		return nil, status.Error(codes.NotFound, "client not found")
+	}
+	return userToProto(user), nil
+}
+
+// ListClients returns the clients of an establishment.
+func (s *Server) ListClients(_ context.Context, req *financev1.ListClientsRequest) (*financev1.ListClientsResponse, error) {
+	clients, err := s.userService.GetClientsByEstablishmentID(uint(req.GetEstablishmentId()), nil)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &financev1.ListClientsResponse{}
+	for i := range clients {
+		resp.Clients = append(resp.Clients, clientToProto(&clients[i]))
+	}
+	return resp, nil
+}
+
+// toStatusError maps service errors to gRPC status codes, hiding the details of unexpected ones.
+func toStatusError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, service.ErrCreditAccountNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,101 @@
+// Package i18n translates the texts the API shows to people: error messages, emails and PDFs. Only
+// Spanish and English are supported; English is the language the code is written in.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language is a two-letter ISO 639-1 language code.
+type Language string
+
+const (
+	Spanish Language = "es"
+	English Language = "en"
+)
+
+// Default is the language of establishments that didn't choose one, as their clients speak Spanish.
+const Default = Spanish
+
+// Parse returns the supported language of a language tag such as es-PE, ignoring the region.
+func Parse(tag string) (Language, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	switch lang := Language(tag); lang {
+	case Spanish, English:
+		return lang, true
+	}
+	return "", false
+}
+
+// FromAcceptLanguage returns the supported language an Accept-Language header prefers, if any.
+func FromAcceptLanguage(header string) (Language, bool) {
+	type candidate struct {
+		lang    Language
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		lang, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{lang, quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].lang, true
+}
+
+// Lookup returns the text of key in a language, without falling back to another one.
+func Lookup(lang Language, key string) (string, bool) {
+	text, ok := catalogs[lang][key]
+	return text, ok
+}
+
+// T returns the text of key in a language, formatted with args as by fmt.Sprintf. Keys missing in
+// the language fall back to English, and unknown keys to the key itself.
+func T(lang Language, key string, args ...interface{}) string {
+	text, ok := Lookup(lang, key)
+	if !ok {
+		if text, ok = Lookup(English, key); !ok {
+			text = key
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+var catalogs = map[Language]map[string]string{
+	Spanish: spanish,
+	English: english,
+}
+
+// Label returns the text of an enum value, e.g. Label(Spanish, "transaction_type", "PAYMENT"), or
+// the value itself when it has none.
+func Label(lang Language, kind, value string) string {
+	if text, ok := Lookup(lang, kind+"."+value); ok {
+		return text
+	}
+	return value
+}
@@ -0,0 +1,114 @@
+package i18n
+
+// english holds the texts of emails, text messages and PDFs. Error messages are written in English
+// already, so only their Spanish translations are kept.
+var english = map[string]string{
+	"mail.greeting": "Hello %s,",
+
+	"mail.purchase_approval.subject": "%s: purchase of %.2f awaits your approval",
+	"mail.purchase_approval.body":    "%s made a purchase of %.2f that needs your approval. It expires on %s if it isn't approved or rejected.",
+	"mail.purchase_approved.subject": "Your purchase of %.2f was approved",
+	"mail.purchase_approved.body":    "Your purchase of %.2f was approved. It was charged to your credit account.",
+	"mail.purchase_rejected.subject": "Your purchase of %.2f was rejected",
+	"mail.purchase_rejected.body":    "Your purchase of %.2f was rejected. Reason: %s",
+	"mail.purchase_expired.subject":  "Your purchase of %.2f expired",
+	"mail.purchase_expired.body":     "Your purchase of %.2f expired. It wasn't approved in time and was not charged to your credit account.",
+	"mail.statement.subject":         "%s: account statement to %s",
+	"mail.statement.body":            "Attached is the statement of your credit account at %s for the period from %s to %s.\n\nYou can stop receiving these emails from the app.",
+	"mail.payment_reminder.subject":  "%[2]s: payment due on %[3]s",
+	"mail.payment_reminder.body":     "This is a reminder that %.2f of your credit account at %s is due on %s.",
+	"mail.overdue_notice.subject":    "%[2]s: payment overdue since %[3]s",
+	"mail.overdue_notice.body":       "%.2f of your credit account at %s was due on %s and is still unpaid. Please pay it as soon as possible.",
+	"mail.client_signup.subject":     "%s: %s signed up as a client",
+	"mail.client_signup.body":        "%s (DNI %s) signed up as a client of %s with your invite code. Review the signup and set the terms of their credit account to activate it.",
+	"mail.signup_received.subject":   "Your signup with %s was received",
+	"mail.signup_received.body":      "We received your signup with %s. You will get another email once it is reviewed.",
+	"mail.signup_approved.subject":   "Your credit account is active",
+	"mail.signup_approved.body":      "Your signup was approved and your credit account is active, with a credit limit of %.2f due on day %d of each month. Log in to accept your credit agreement before your first purchase.",
+	"mail.signup_rejected.subject":   "Your signup was rejected",
+	"mail.signup_rejected.body":      "Your signup was rejected. Reason: %s",
+
+	"mail.contact_verification.subject": "Your verification code is %s",
+	"mail.contact_verification.body":    "Enter the code %s in the app to verify this email. It expires in %d minutes. If you didn't ask for it, you can ignore this email.",
+	"sms.contact_verification":          "%s is your verification code. It expires in %d minutes.",
+
+	"sms.payment_reminder":     "%[2]s: %.2[1]f of your credit account is due on %[3]s.",
+	"sms.payment_confirmation": "%s: we received your payment. Your balance is now %.2f.",
+	"sms.overdue_notice":       "%[2]s: %.2[1]f of your credit account was due on %[3]s and is still unpaid. Please pay it as soon as possible.",
+
+	"mail.payment_link.subject": "%[2]s: pay %.2[1]f online",
+	"mail.payment_link.body":    "%[2]s sent you a link to pay %.2[1]f of your credit account: %[3]s\n\nThe link expires on %[4]s.",
+	"sms.payment_link":          "%[2]s: pay %.2[1]f of your credit account at %[3]s before %[4]s.",
+
+	"mail.payment_code.subject": "%[2]s: code %[3]s confirms your payment",
+	"mail.payment_code.body":    "%[2]s registered your payment of %.2[1]f. Give them the code %[3]s to confirm it before %[4]s, or it will fail and the amount will be owed again. If you didn't make this payment, don't share the code.",
+	"sms.payment_code":          "%[2]s: give the code %[3]s to confirm your payment of %.2[1]f before %[4]s. Don't share it if you didn't pay.",
+
+	"mail.bank_transfer.subject": "%[2]s: we received your transfer of %.2[1]f",
+	"mail.bank_transfer.body":    "%[2]s received your bank transfer of %.2[1]f and applied it to your credit account. Your balance is now %.2[3]f.",
+
+	"mail.admin_password_reset.subject": "%s: your password was reset",
+	"mail.admin_password_reset.body":    "Support reset the password of your admin account at %s and logged you out of every session. Your temporary password is %s\n\nLog in with it and change it right away.",
+
+	"mail.report_digest.subject":   "%s: report digest from %s to %s",
+	"mail.report_digest.body":      "Here is the report digest of %s for the period from %s to %s. It is also attached as a PDF.",
+	"digest.title":                 "Report digest of %s",
+	"digest.period":                "From %s to %s",
+	"digest.collections":           "Collections",
+	"digest.collections.payments":  "Payments: %d",
+	"digest.collections.collected": "Collected: %.2f",
+	"digest.collections.pending":   "Awaiting confirmation: %.2f",
+	"digest.new_debt":              "New debt",
+	"digest.new_debt.purchases":    "Credit purchases: %d, for %.2f",
+	"digest.new_debt.accounts":     "Credit accounts opened: %d",
+	"digest.new_debt.charges":      "Interest and late fees charged: %.2f",
+	"digest.overdue":               "Overdue",
+	"digest.overdue.accounts":      "Accounts overdue: %d, owing %.2f",
+	"digest.overdue.new":           "Installments fallen overdue: %d, for %.2f",
+	"digest.overdue.settled":       "Overdue installments paid: %d, for %.2f",
+	"digest.top_debtors":           "Top debtors",
+	"digest.top_debtors.client":    "Client",
+	"digest.top_debtors.balance":   "Balance",
+	"digest.top_debtors.overdue":   "Overdue",
+	"digest.top_debtors.none":      "No account owes anything.",
+	"digest.yes":                   "Yes",
+	"digest.no":                    "No",
+
+	"pdf.statement.title":             "Account Statement - Client ID: %d",
+	"pdf.statement.start_date":        "Start Date: %s",
+	"pdf.statement.end_date":          "End Date: %s",
+	"pdf.statement.starting_balance":  "Starting Balance: %.2f",
+	"pdf.statement.ending_balance":    "Ending Balance: %.2f",
+	"pdf.statement.taxable_purchases": "Taxable Purchases: %.2f",
+	"pdf.statement.interest_charged":  "Interest Charged: %.2f",
+	"pdf.statement.late_fees_charged": "Late Fees Charged: %.2f",
+	"pdf.statement.adjustments":       "Adjustments: %.2f",
+	"pdf.statement.date":              "Date",
+	"pdf.statement.receipt":           "Receipt",
+	"pdf.statement.description":       "Description",
+	"pdf.statement.type":              "Type",
+	"pdf.statement.payment_method":    "Payment Method",
+	"pdf.statement.amount":            "Amount",
+	"pdf.statement.status":            "Status",
+	"pdf.statement.scan_to_pay":       "Scan to pay your balance of %.2f",
+
+	"pdf.receipt.title":                "Payment Receipt",
+	"pdf.receipt.client":               "Client: %s",
+	"pdf.receipt.number":               "Receipt: %s",
+	"pdf.receipt.date":                 "Date: %s",
+	"pdf.receipt.payment_method":       "Payment Method: %s",
+	"pdf.receipt.status":               "Status: %s",
+	"pdf.receipt.amount":               "Amount Paid: %.2f",
+	"pdf.receipt.paid_installments":    "Installments Paid",
+	"pdf.receipt.no_installments_paid": "The payment went to no installment.",
+	"pdf.receipt.due_date":             "Due Date",
+	"pdf.receipt.installment":          "Installment",
+	"pdf.receipt.paid":                 "Paid",
+	"pdf.receipt.outstanding":          "Outstanding",
+	"pdf.receipt.installment_status":   "Status",
+	"pdf.receipt.unallocated":          "Paid of the rest of the balance: %.2f",
+	"pdf.receipt.remaining_schedule":   "Remaining Schedule",
+	"pdf.receipt.no_installments_left": "No installments left to pay.",
+	"pdf.receipt.remaining_amount":     "Left to Pay: %.2f",
+	"pdf.receipt.next_due":             "Next Installment: %.2f due %s",
+}
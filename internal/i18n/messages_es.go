@@ -0,0 +1,301 @@
+package i18n
+
+// spanish holds the Spanish texts: the translations of the error messages clients are expected to
+// handle, by their v2 code, of binding errors, and of emails and PDFs.
+var spanish = map[string]string{
+	"error.credit_account_not_found":       "cuenta de crédito no encontrada",
+	"error.credit_account_exists":          "el cliente ya tiene una cuenta de crédito en este establecimiento",
+	"error.establishment_required":         "el cliente tiene cuentas de crédito en varios establecimientos, se debe seleccionar uno",
+	"error.invalid_transaction_type":       "tipo de transacción inválido",
+	"error.insufficient_balance":           "saldo insuficiente",
+	"error.invalid_file_type":              "tipo de archivo inválido. Solo se permiten imágenes",
+	"error.file_too_large":                 "el archivo es demasiado grande",
+	"error.query_limit_exceeded":           "se superó el límite de la consulta",
+	"error.invalid_image":                  "el archivo no es una imagen válida",
+	"error.invalid_image_dimensions":       "dimensiones de imagen inválidas",
+	"error.image_rejected":                 "imagen rechazada por moderación",
+	"error.invalid_simulation":             "simulación de crédito inválida",
+	"error.invalid_report_period":          "periodo de reporte inválido",
+	"error.nothing_to_pay_off":             "la cuenta de crédito no tiene saldo pendiente",
+	"error.payoff_quote_changed":           "el monto de cancelación cambió, solicita una nueva cotización",
+	"error.branch_not_found":               "sucursal no encontrada",
+	"error.establishment_not_found":        "establecimiento no encontrado",
+	"error.interest_rate_required":         "la tasa de interés es obligatoria, el establecimiento no tiene una tasa por defecto",
+	"error.high_risk_purchase_limit":       "la compra supera el límite para clientes de alto riesgo",
+	"error.credit_account_blocked":         "la cuenta de crédito está bloqueada, no se puede procesar la compra",
+	"error.credit_account_already_blocked": "la cuenta de crédito ya está bloqueada",
+	"error.credit_account_not_blocked":     "la cuenta de crédito no está bloqueada",
+	"error.job_not_found":                  "tarea no encontrada",
+	"error.job_not_finished":               "la tarea aún no ha terminado",
+	"error.job_failed":                     "la tarea falló",
+	"error.job_has_no_result":              "la tarea no tiene un resultado para descargar",
+	"error.two_factor_already_enabled":     "la autenticación de dos factores ya está activada",
+	"error.two_factor_not_set_up":          "la autenticación de dos factores no ha sido configurada",
+	"error.two_factor_not_enabled":         "la autenticación de dos factores no está activada",
+	"error.two_factor_required":            "el establecimiento exige autenticación de dos factores",
+	"error.invalid_two_factor_code":        "código de dos factores inválido",
+	"error.invalid_two_factor_challenge":   "el inicio de sesión es inválido o expiró, vuelve a iniciar sesión",
+	"error.two_factor_locked":              "la autenticación de dos factores está bloqueada por demasiados códigos incorrectos, inténtalo más tarde",
+	"error.session_not_found":              "sesión no encontrada",
+	"error.document_series_not_found":      "serie de comprobantes no encontrada",
+	"error.document_series_exists":         "el establecimiento ya tiene una serie de comprobantes con ese código",
+	"error.invalid_document_series":        "código de serie inválido, las series de boletas empiezan con B y las de facturas con F, seguidas de tres letras o dígitos",
+	"error.document_number_issued":         "la serie ya emitió ese número, solo puede avanzar",
+	"error.invoice_not_found":              "comprobante electrónico no encontrado",
+	"error.category_not_found":             "categoría no encontrada",
+	"error.category_exists":                "el establecimiento ya tiene una categoría con ese nombre",
+	"error.category_in_use":                "la categoría aún tiene productos, muévelos primero a otra categoría",
+	"error.invalid_category_name":          "el nombre de la categoría no puede estar vacío",
+	"error.product_not_found":              "producto no encontrado",
+	"error.invalid_barcode":                "código de barras inválido, debe ser un código EAN-13 de 13 dígitos con un dígito de control válido",
+	"error.sku_exists":                     "el establecimiento ya tiene un producto con ese SKU",
+	"error.barcode_exists":                 "el establecimiento ya tiene un producto con ese código de barras",
+	"error.credit_limit_exceeded":          "el monto de la compra supera el límite de crédito",
+	"error.spending_limit_exceeded":        "la compra supera el límite de gasto del periodo",
+	"error.purchase_approval_not_found":    "aprobación de compra no encontrada",
+	"error.purchase_approval_decided":      "la aprobación de compra ya fue resuelta",
+	"error.payment_promise_exists":         "la cuenta de crédito ya tiene una promesa de pago activa",
+	"error.invalid_promise_date":           "fecha de promesa inválida, debe ser una fecha ISO-8601 desde hoy",
+	"error.late_fees_suspended":            "las moras están suspendidas por una promesa de pago activa",
+	"error.credit_account_written_off":     "la cuenta de crédito fue castigada",
+	"error.attachment_not_found":           "documento no encontrado",
+	"error.invalid_attachment_type":        "tipo de archivo inválido. Solo se permiten documentos PDF, JPEG y PNG",
+	"error.invalid_attachment_kind":        "tipo de documento inválido",
+	"error.attachment_infected":            "documento rechazado por el antivirus",
+	"error.credit_agreement_not_found":     "contrato de crédito no encontrado",
+	"error.credit_agreement_accepted":      "el contrato de crédito ya fue aceptado",
+	"error.credit_agreement_not_accepted":  "el contrato de crédito no fue aceptado, no se puede procesar la compra",
+	"error.email_in_use":                   "el email ya está en uso",
+	"error.dni_in_use":                     "el DNI ya está en uso",
+	"error.ruc_in_use":                     "el RUC ya está registrado por otro establecimiento",
+	"error.invite_code_not_found":          "código de invitación no encontrado",
+	"error.client_signup_not_found":        "registro de cliente no encontrado",
+	"error.client_signup_pending":          "ya hay un registro con ese DNI esperando aprobación",
+	"error.client_signup_decided":          "el registro de cliente ya fue resuelto",
+	"error.balance_changed":                "el saldo de la cuenta de crédito cambió",
+	"error.invalid_date":                   "las fechas deben ser ISO-8601, p. ej. 2026-10-14 o 2026-10-14T09:30:00-05:00",
+	"error.invalid_date_range":             "rango de fechas inválido, usa fechas de inicio y fin o uno de today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year o last_N_days",
+	"error.version_required":               "envía la versión del registro en la que se basa el cambio, en el cuerpo o en If-Match",
+	"error.version_conflict":               "alguien más cambió el registro desde que lo leíste, vuelve a cargarlo e inténtalo de nuevo",
+	"error.invalid_contact_channel":        "canal inválido, usa email o phone",
+	"error.contact_already_verified":       "ese contacto ya está verificado",
+	"error.no_phone":                       "el usuario no tiene un teléfono que verificar",
+	"error.verification_code_too_soon":     "se envió un código de verificación hace menos de un minuto, espera antes de pedir otro",
+	"error.invalid_verification_code":      "código de verificación inválido",
+	"error.verification_code_expired":      "el código de verificación expiró o se ingresó mal demasiadas veces, pide uno nuevo",
+	"error.installment_not_found":          "cuota no encontrada",
+	"error.installment_paid":               "la cuota ya está pagada",
+	"error.installment_cancelled":          "la cuota fue anulada",
+	"error.invalid_payment_link_amount":    "envía la cuota a pagar o un monto que no supere lo que debe la cuenta",
+	"error.no_verified_contact":            "el cliente no tiene un email o teléfono verificado al que enviar el enlace",
+	"error.payment_link_not_found":         "enlace de pago no encontrado",
+	"error.payment_link_expired":           "el enlace de pago expiró, pide uno nuevo al establecimiento",
+	"error.payment_link_used":              "el enlace de pago ya fue usado",
+	"error.email_not_verified":             "verifica tu email para recibir el resumen de reportes",
+	"error.user_not_found":                 "usuario no encontrado",
+	"error.user_not_client":                "solo se pueden exportar o anonimizar los datos de clientes",
+	"error.client_has_other_accounts":      "el cliente tiene cuentas de crédito en otros establecimientos, solo el propio cliente puede borrar sus datos",
+	"error.client_owes_balance":            "el cliente aún debe en una cuenta de crédito, sáldala antes de borrar sus datos",
+	"error.user_anonymized":                "el usuario ya fue anonimizado",
+	"error.credit_account_closed":          "la cuenta de crédito está cerrada, no se puede procesar la compra",
+	"error.credit_account_not_closed":      "la cuenta de crédito no está cerrada",
+	"error.credit_account_already_closed":  "la cuenta de crédito ya está cerrada",
+	"error.credit_account_has_balance":     "la cuenta de crédito aún tiene deuda, págala para cerrarla",
+	"error.establishment_inactive":         "el establecimiento está desactivado, no acepta compras ni clientes nuevos",
+	"error.establishment_active":           "el establecimiento ya está activo",
+	"error.establishment_deactivated":      "el establecimiento ya está desactivado",
+	"error.incorrect_password":             "la contraseña actual es incorrecta",
+	"error.invalid_purchase_pin_format":    "el PIN de compras debe tener de 4 a 6 dígitos",
+	"error.purchase_pin_required":          "las compras de este monto necesitan el PIN de compras del cliente",
+	"error.purchase_pin_not_set":           "el cliente no ha configurado un PIN de compras, debe hacerlo antes de compras de este monto",
+	"error.invalid_purchase_pin":           "PIN de compras inválido",
+	"error.purchase_pin_locked":            "el PIN de compras está bloqueado por demasiados intentos fallidos, inténtelo más tarde o pida al cliente que lo restablezca",
+	"error.invalid_reschedule_date":        "fecha de vencimiento inválida, las cuotas se mueven a un día desde mañana hasta un mes después de la última cuota de la cuenta",
+	"error.reschedule_limit_reached":       "la cuenta de crédito agotó las reprogramaciones de cuotas que permite el establecimiento",
+	"error.late_fee_applied":               "ya se cobró la mora de este ciclo de facturación",
+	"error.account_adjustment_not_found":   "ajuste no encontrado",
+	"error.adjustment_approver_required":   "los ajustes sobre el umbral necesitan un aprobador, designa uno en la configuración del establecimiento",
+	"error.invalid_adjustment_approver":    "el aprobador de ajustes debe ser un administrador distinto al del establecimiento",
+	"error.adjustment_decided":             "el ajuste ya fue aprobado o rechazado",
+	"error.adjustment_transaction":         "los ajustes no se pueden modificar ni eliminar, registra un ajuste contrario",
+	"error.till_session_not_found":         "sesión de caja no encontrada",
+	"error.till_session_open":              "el establecimiento ya tiene una caja abierta, ciérrala primero",
+	"error.till_session_closed":            "la sesión de caja ya está cerrada",
+	"error.discrepancy_notes_required":     "el efectivo contado no coincide con el esperado, explica la diferencia en las notas",
+	"error.invalid_contact_date":           "fecha de contacto inválida, no se pueden registrar contactos por adelantado",
+	"error.client_not_found":               "cliente no encontrado",
+	"error.client_tag_not_found":           "etiqueta de cliente no encontrada",
+	"error.client_tag_exists":              "el establecimiento ya tiene una etiqueta de cliente con ese nombre",
+	"error.invalid_client_tag_name":        "el nombre de la etiqueta no puede estar vacío",
+	"error.client_note_not_found":          "nota de cliente no encontrada",
+	"error.invalid_client_note":            "la nota del cliente no puede estar vacía",
+	"error.invalid_config_import":          "la configuración tiene elementos que no se pueden importar, simule la importación para verlos",
+	"error.config_import_conflict":         "la configuración tiene productos distintos a los del establecimiento, simule la importación para verlos o importe con otro modo on_conflict",
+	"error.credit_template_not_found":      "plantilla de crédito no encontrada",
+	"error.credit_template_exists":         "el establecimiento ya tiene una plantilla de crédito con ese nombre",
+	"error.invalid_credit_template_name":   "el nombre de la plantilla de crédito no puede estar vacío",
+	"error.no_credit_term_changes":         "la actualización no cambia ninguna condición de crédito",
+	"error.credit_term_update_not_found":   "actualización de condiciones de crédito no encontrada",
+	"error.upgrade_required":               "el plan del establecimiento no lo permite, se requiere mejorar el plan",
+	"error.plan_of_branch":                 "las sucursales tienen el plan de su establecimiento principal",
+	"error.establishment_suspended":        "el establecimiento está suspendido por la plataforma, contacta a soporte",
+	"error.already_suspended":              "el establecimiento ya está suspendido",
+	"error.not_suspended":                  "el establecimiento no está suspendido",
+	"error.platform_branch":                "las sucursales se gestionan con su establecimiento principal",
+	"error.access_denied":                  "no está autorizado a acceder a este registro",
+	"error.payment_not_pending":            "la transacción no puede confirmarse",
+	"error.invalid_payment_code":           "código de confirmación inválido",
+	"error.payment_code_expired":           "el código de confirmación expiró, el pago falló",
+	"error.payment_code_attempts":          "demasiados códigos de confirmación incorrectos, el pago falló",
+	"error.invalid_bank_signature":         "firma de la notificación bancaria no válida",
+	"error.stale_bank_notification":        "la fecha de la notificación bancaria está fuera del margen permitido",
+	"error.accounting_events_unmapped":     "asigna una cuenta a cada evento contable antes de exportar el libro diario",
+	"error.not_payment":                    "la transacción no es un pago",
+	"error.notification_not_found":         "notificación no encontrada",
+	"error.invalid_installment_status":     "el estado de una cuota sigue a sus pagos y su vencimiento, solo puede cambiarse a mano a CANCELLED mientras se deba",
+	"error.holiday_not_found":              "feriado no encontrado",
+	"error.holiday_exists":                 "el establecimiento ya tiene un feriado en esa fecha",
+	"error.invalid_holiday_date":           "las fechas de los feriados deben ser AAAA-MM-DD",
+	"error.invalid_holiday_year":           "el año debe estar entre 2000 y 2100",
+
+	"validation.empty_body": "el cuerpo de la solicitud está vacío",
+	"validation.type":       "el campo %s tiene un tipo inválido",
+	"validation.invalid":    "el campo %s no es válido",
+	"validation.required":   "el campo %s es obligatorio",
+	"validation.email":      "el campo %s debe ser un email válido",
+	"validation.min":        "el campo %s es demasiado corto o pequeño",
+	"validation.max":        "el campo %s es demasiado largo o grande",
+	"validation.len":        "el campo %s no tiene la longitud requerida",
+	"validation.gt":         "el campo %s debe ser mayor",
+	"validation.gte":        "el campo %s debe ser mayor",
+	"validation.lt":         "el campo %s debe ser menor",
+	"validation.lte":        "el campo %s debe ser menor",
+	"validation.oneof":      "el campo %s no tiene uno de los valores permitidos",
+	"validation.numeric":    "el campo %s debe ser numérico",
+	"validation.dive":       "el campo %s tiene elementos inválidos",
+
+	"mail.greeting": "Hola %s,",
+
+	"mail.purchase_approval.subject": "%s: una compra de %.2f espera tu aprobación",
+	"mail.purchase_approval.body":    "%s hizo una compra de %.2f que necesita tu aprobación. Expira el %s si no es aprobada o rechazada.",
+	"mail.purchase_approved.subject": "Tu compra de %.2f fue aprobada",
+	"mail.purchase_approved.body":    "Tu compra de %.2f fue aprobada. Se cargó a tu cuenta de crédito.",
+	"mail.purchase_rejected.subject": "Tu compra de %.2f fue rechazada",
+	"mail.purchase_rejected.body":    "Tu compra de %.2f fue rechazada. Motivo: %s",
+	"mail.purchase_expired.subject":  "Tu compra de %.2f expiró",
+	"mail.purchase_expired.body":     "Tu compra de %.2f expiró. No fue aprobada a tiempo y no se cargó a tu cuenta de crédito.",
+	"mail.statement.subject":         "%s: estado de cuenta al %s",
+	"mail.statement.body":            "Adjuntamos el estado de tu cuenta de crédito en %s del periodo del %s al %s.\n\nPuedes dejar de recibir estos emails desde la app.",
+	"mail.payment_reminder.subject":  "%[2]s: pago con vencimiento el %[3]s",
+	"mail.payment_reminder.body":     "Te recordamos que %.2f de tu cuenta de crédito en %s vence el %s.",
+	"mail.overdue_notice.subject":    "%[2]s: pago vencido el %[3]s",
+	"mail.overdue_notice.body":       "%.2f de tu cuenta de crédito en %s venció el %s y sigue sin pagarse. Págalo lo antes posible.",
+	"mail.client_signup.subject":     "%s: %s se registró como cliente",
+	"mail.client_signup.body":        "%s (DNI %s) se registró como cliente de %s con tu código de invitación. Revisa el registro y define las condiciones de su cuenta de crédito para activarla.",
+	"mail.signup_received.subject":   "Recibimos tu registro en %s",
+	"mail.signup_received.body":      "Recibimos tu registro en %s. Te enviaremos otro email cuando sea revisado.",
+	"mail.signup_approved.subject":   "Tu cuenta de crédito está activa",
+	"mail.signup_approved.body":      "Tu registro fue aprobado y tu cuenta de crédito está activa, con un límite de crédito de %.2f y vencimiento el día %d de cada mes. Inicia sesión para aceptar tu contrato de crédito antes de tu primera compra.",
+	"mail.signup_rejected.subject":   "Tu registro fue rechazado",
+	"mail.signup_rejected.body":      "Tu registro fue rechazado. Motivo: %s",
+
+	"mail.contact_verification.subject": "Tu código de verificación es %s",
+	"mail.contact_verification.body":    "Ingresa el código %s en la aplicación para verificar este email. Vence en %d minutos. Si no lo solicitaste, puedes ignorar este email.",
+	"sms.contact_verification":          "%s es tu código de verificación. Vence en %d minutos.",
+
+	"sms.payment_reminder":     "%[2]s: %.2[1]f de tu cuenta de crédito vence el %[3]s.",
+	"sms.payment_confirmation": "%s: recibimos tu pago. Tu saldo ahora es %.2f.",
+	"sms.overdue_notice":       "%[2]s: %.2[1]f de tu cuenta de crédito venció el %[3]s y sigue sin pagarse. Págalo lo antes posible.",
+
+	"mail.payment_link.subject": "%[2]s: paga %.2[1]f en línea",
+	"mail.payment_link.body":    "%[2]s te envió un enlace para pagar %.2[1]f de tu cuenta de crédito: %[3]s\n\nEl enlace vence el %[4]s.",
+	"sms.payment_link":          "%[2]s: paga %.2[1]f de tu cuenta de crédito en %[3]s antes del %[4]s.",
+
+	"mail.payment_code.subject": "%[2]s: el código %[3]s confirma tu pago",
+	"mail.payment_code.body":    "%[2]s registró tu pago de %.2[1]f. Dales el código %[3]s para confirmarlo antes de las %[4]s, o el pago fallará y volverás a deber el monto. Si no hiciste este pago, no compartas el código.",
+	"sms.payment_code":          "%[2]s: da el código %[3]s para confirmar tu pago de %.2[1]f antes de las %[4]s. No lo compartas si no pagaste.",
+
+	"mail.bank_transfer.subject": "%[2]s: recibimos tu transferencia de %.2[1]f",
+	"mail.bank_transfer.body":    "%[2]s recibió tu transferencia bancaria de %.2[1]f y la aplicó a tu cuenta de crédito. Tu saldo ahora es %.2[3]f.",
+
+	"mail.admin_password_reset.subject": "%s: tu contraseña fue restablecida",
+	"mail.admin_password_reset.body":    "Soporte restableció la contraseña de tu cuenta de administrador en %s y cerró todas tus sesiones. Tu contraseña temporal es %s\n\nInicia sesión con ella y cámbiala de inmediato.",
+
+	"mail.report_digest.subject":   "%s: resumen de reportes del %s al %s",
+	"mail.report_digest.body":      "Este es el resumen de reportes de %s del periodo del %s al %s. También va adjunto en PDF.",
+	"digest.title":                 "Resumen de reportes de %s",
+	"digest.period":                "Del %s al %s",
+	"digest.collections":           "Cobranzas",
+	"digest.collections.payments":  "Pagos: %d",
+	"digest.collections.collected": "Cobrado: %.2f",
+	"digest.collections.pending":   "Por confirmar: %.2f",
+	"digest.new_debt":              "Nueva deuda",
+	"digest.new_debt.purchases":    "Compras al crédito: %d, por %.2f",
+	"digest.new_debt.accounts":     "Cuentas de crédito abiertas: %d",
+	"digest.new_debt.charges":      "Intereses y moras cobrados: %.2f",
+	"digest.overdue":               "Morosidad",
+	"digest.overdue.accounts":      "Cuentas vencidas: %d, que deben %.2f",
+	"digest.overdue.new":           "Cuotas que vencieron: %d, por %.2f",
+	"digest.overdue.settled":       "Cuotas vencidas pagadas: %d, por %.2f",
+	"digest.top_debtors":           "Mayores deudores",
+	"digest.top_debtors.client":    "Cliente",
+	"digest.top_debtors.balance":   "Saldo",
+	"digest.top_debtors.overdue":   "Vencida",
+	"digest.top_debtors.none":      "Ninguna cuenta tiene saldo pendiente.",
+	"digest.yes":                   "Sí",
+	"digest.no":                    "No",
+
+	"pdf.statement.title":             "Estado de Cuenta - Cliente ID: %d",
+	"pdf.statement.start_date":        "Fecha de inicio: %s",
+	"pdf.statement.end_date":          "Fecha de fin: %s",
+	"pdf.statement.starting_balance":  "Saldo inicial: %.2f",
+	"pdf.statement.ending_balance":    "Saldo final: %.2f",
+	"pdf.statement.taxable_purchases": "Compras gravadas: %.2f",
+	"pdf.statement.interest_charged":  "Intereses cobrados: %.2f",
+	"pdf.statement.late_fees_charged": "Moras cobradas: %.2f",
+	"pdf.statement.adjustments":       "Ajustes: %.2f",
+	"pdf.statement.date":              "Fecha",
+	"pdf.statement.receipt":           "Comprobante",
+	"pdf.statement.description":       "Descripción",
+	"pdf.statement.type":              "Tipo",
+	"pdf.statement.payment_method":    "Medio de pago",
+	"pdf.statement.amount":            "Monto",
+	"pdf.statement.status":            "Estado",
+	"pdf.statement.scan_to_pay":       "Escanea para pagar tu saldo de %.2f",
+
+	"pdf.receipt.title":                "Constancia de pago",
+	"pdf.receipt.client":               "Cliente: %s",
+	"pdf.receipt.number":               "Comprobante: %s",
+	"pdf.receipt.date":                 "Fecha: %s",
+	"pdf.receipt.payment_method":       "Medio de pago: %s",
+	"pdf.receipt.status":               "Estado: %s",
+	"pdf.receipt.amount":               "Monto pagado: %.2f",
+	"pdf.receipt.paid_installments":    "Cuotas pagadas",
+	"pdf.receipt.no_installments_paid": "El pago no se aplicó a ninguna cuota.",
+	"pdf.receipt.due_date":             "Vencimiento",
+	"pdf.receipt.installment":          "Cuota",
+	"pdf.receipt.paid":                 "Pagado",
+	"pdf.receipt.outstanding":          "Pendiente",
+	"pdf.receipt.installment_status":   "Estado",
+	"pdf.receipt.unallocated":          "Aplicado al resto del saldo: %.2f",
+	"pdf.receipt.remaining_schedule":   "Cronograma pendiente",
+	"pdf.receipt.no_installments_left": "No quedan cuotas por pagar.",
+	"pdf.receipt.remaining_amount":     "Por pagar: %.2f",
+	"pdf.receipt.next_due":             "Próxima cuota: %.2f, vence el %s",
+
+	"transaction_type.PURCHASE":          "Compra",
+	"transaction_type.PAYMENT":           "Pago",
+	"transaction_type.INTEREST":          "Interés",
+	"transaction_type.LATE_FEE":          "Mora",
+	"transaction_type.ADJUSTMENT_DEBIT":  "Ajuste (cargo)",
+	"transaction_type.ADJUSTMENT_CREDIT": "Nota de crédito",
+	"payment_status.PENDING":             "Pendiente",
+	"payment_status.SUCCESS":             "Exitoso",
+	"payment_status.FAILED":              "Fallido",
+	"payment_method.CASH":                "Efectivo",
+	"installment_status.PENDING":         "Pendiente",
+	"installment_status.PARTIALLY_PAID":  "Pagada en parte",
+	"installment_status.PAID":            "Pagada",
+	"installment_status.OVERDUE":         "Vencida",
+	"installment_status.CANCELLED":       "Anulada",
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// fieldErrorPattern matches a failed rule in the messages of binding errors, one per line
+	fieldErrorPattern = regexp.MustCompile(`^Key: '[^']*' Error:Field validation for '([^']+)' failed on the '([^']+)' tag$`)
+	// typeErrorPattern matches a JSON value of the wrong type
+	typeErrorPattern = regexp.MustCompile(`^json: cannot unmarshal \S+ into Go struct field \S*?\.?([^.\s]+) of type \S+$`)
+)
+
+// TranslateValidation translates the message of a request binding error. It reports false for other
+// messages, and for English, the language of the originals.
+func TranslateValidation(lang Language, message string) (string, bool) {
+	if lang == English {
+		return "", false
+	}
+	if message == "EOF" {
+		return T(lang, "validation.empty_body"), true
+	}
+	if match := typeErrorPattern.FindStringSubmatch(message); match != nil {
+		return T(lang, "validation.type", match[1]), true
+	}
+
+	lines := strings.Split(message, "\n")
+	translated := make([]string, len(lines))
+	for i, line := range lines {
+		match := fieldErrorPattern.FindStringSubmatch(line)
+		if match == nil {
+			return "", false
+		}
+		key := "validation." + match[2]
+		if _, ok := Lookup(lang, key); !ok {
+			key = "validation.invalid"
+		}
+		translated[i] = T(lang, key, snakeCase(match[1]))
+	}
+	return strings.Join(translated, "\n"), true
+}
+
+// snakeCase turns a struct field name into its JSON name, e.g. CreditLimit into credit_limit.
+func snakeCase(field string) string {
+	var b strings.Builder
+	runes := []rune(field)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Acronyms stay together: DNI is dni, ClientID is client_id
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
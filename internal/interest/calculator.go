@@ -0,0 +1,96 @@
+// Package interest converts annual rates between their nominal and effective forms and computes
+// the interest accrued over a number of days or months.
+//
+// A nominal rate (TNA) is capitalized a number of times per year given by the account's compounding
+// period; an effective rate (TEA) already includes its capitalization. Both are turned into the
+// equivalent TEA first and then prorated with compound interest, so accruing twice over half the
+// time yields the same amount as accruing once over the whole period. Rates are decimals (0.24 for 24%).
+package interest
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"fmt"
+	"math"
+)
+
+// DaysPerYear is the day count used to prorate annual rates.
+const DaysPerYear = 365
+
+// PeriodsPerYear returns how many times a year a nominal rate is capitalized. Accounts created
+// before compounding periods existed have none and capitalize monthly.
+func PeriodsPerYear(period enums.CompoundingPeriod) int {
+	switch period {
+	case enums.Daily:
+		return DaysPerYear
+	case enums.Quarterly:
+		return 4
+	default:
+		return 12
+	}
+}
+
+// EffectiveAnnualRate returns the TEA of an annual rate of the given type.
+func EffectiveAnnualRate(annualRate float64, interestType enums.InterestType, period enums.CompoundingPeriod) (float64, error) {
+	switch interestType {
+	case enums.Nominal:
+		m := float64(PeriodsPerYear(period))
+		return math.Pow(1+annualRate/m, m) - 1, nil
+	case enums.Effective:
+		return annualRate, nil
+	}
+	return 0, fmt.Errorf("invalid interest type: %s", interestType)
+}
+
+// NominalAnnualRate returns the TNA, capitalized per period, of an annual rate of the given type.
+func NominalAnnualRate(annualRate float64, interestType enums.InterestType, period enums.CompoundingPeriod) (float64, error) {
+	switch interestType {
+	case enums.Nominal:
+		return annualRate, nil
+	case enums.Effective:
+		m := float64(PeriodsPerYear(period))
+		return m * (math.Pow(1+annualRate, 1/m) - 1), nil
+	}
+	return 0, fmt.Errorf("invalid interest type: %s", interestType)
+}
+
+// RateForDays returns the rate that accrues over the given number of days.
+func RateForDays(annualRate float64, interestType enums.InterestType, period enums.CompoundingPeriod, days int) (float64, error) {
+	tea, err := EffectiveAnnualRate(annualRate, interestType, period)
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(1+tea, float64(days)/DaysPerYear) - 1, nil
+}
+
+// RateForMonths returns the rate that accrues over the given number of months.
+func RateForMonths(annualRate float64, interestType enums.InterestType, period enums.CompoundingPeriod, months int) (float64, error) {
+	tea, err := EffectiveAnnualRate(annualRate, interestType, period)
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(1+tea, float64(months)/12) - 1, nil
+}
+
+// ForDays returns the interest principal accrues over the given number of days.
+func ForDays(principal, annualRate float64, interestType enums.InterestType, period enums.CompoundingPeriod, days int) (float64, error) {
+	if days <= 0 {
+		return 0, nil
+	}
+	rate, err := RateForDays(annualRate, interestType, period, days)
+	if err != nil {
+		return 0, err
+	}
+	return principal * rate, nil
+}
+
+// ForMonths returns the interest principal accrues over the given number of months.
+func ForMonths(principal, annualRate float64, interestType enums.InterestType, period enums.CompoundingPeriod, months int) (float64, error) {
+	if months <= 0 {
+		return 0, nil
+	}
+	rate, err := RateForMonths(annualRate, interestType, period, months)
+	if err != nil {
+		return 0, err
+	}
+	return principal * rate, nil
+}
@@ -0,0 +1,187 @@
+package interest
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"math"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < tolerance
+}
+
+func TestPeriodsPerYear(t *testing.T) {
+	tests := []struct {
+		period enums.CompoundingPeriod
+		want   int
+	}{
+		{enums.Daily, 365},
+		{enums.Monthly, 12},
+		{enums.Quarterly, 4},
+		{"", 12}, // Accounts created before compounding periods existed
+	}
+	for _, tt := range tests {
+		if got := PeriodsPerYear(tt.period); got != tt.want {
+			t.Errorf("PeriodsPerYear(%q) = %d, want %d", tt.period, got, tt.want)
+		}
+	}
+}
+
+func TestEffectiveAnnualRate(t *testing.T) {
+	tests := []struct {
+		name         string
+		rate         float64
+		interestType enums.InterestType
+		period       enums.CompoundingPeriod
+		want         float64
+	}{
+		{"effective is kept", 0.24, enums.Effective, enums.Monthly, 0.24},
+		{"effective ignores the period", 0.24, enums.Effective, enums.Daily, 0.24},
+		{"nominal monthly", 0.12, enums.Nominal, enums.Monthly, 0.12682503013196977},
+		{"nominal quarterly", 0.12, enums.Nominal, enums.Quarterly, 0.12550881000000014},
+		{"nominal daily", 0.365, enums.Nominal, enums.Daily, 0.4402513134295205},
+		{"zero rate", 0, enums.Nominal, enums.Monthly, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EffectiveAnnualRate(tt.rate, tt.interestType, tt.period)
+			if err != nil {
+				t.Fatalf("EffectiveAnnualRate returned %v", err)
+			}
+			if !approx(got, tt.want) {
+				t.Errorf("EffectiveAnnualRate(%v, %s, %s) = %v, want %v", tt.rate, tt.interestType, tt.period, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNominalAnnualRate(t *testing.T) {
+	tests := []struct {
+		name         string
+		rate         float64
+		interestType enums.InterestType
+		period       enums.CompoundingPeriod
+		want         float64
+	}{
+		{"nominal is kept", 0.12, enums.Nominal, enums.Monthly, 0.12},
+		{"effective monthly", 0.24, enums.Effective, enums.Monthly, 0.21705098980212867},
+		{"effective quarterly", 0.24, enums.Effective, enums.Quarterly, 0.22100058766355435},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NominalAnnualRate(tt.rate, tt.interestType, tt.period)
+			if err != nil {
+				t.Fatalf("NominalAnnualRate returned %v", err)
+			}
+			if !approx(got, tt.want) {
+				t.Errorf("NominalAnnualRate(%v, %s, %s) = %v, want %v", tt.rate, tt.interestType, tt.period, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNominalAndEffectiveRoundTrip(t *testing.T) {
+	for _, period := range []enums.CompoundingPeriod{enums.Daily, enums.Monthly, enums.Quarterly} {
+		tna, err := NominalAnnualRate(0.3, enums.Effective, period)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tea, err := EffectiveAnnualRate(tna, enums.Nominal, period)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !approx(tea, 0.3) {
+			t.Errorf("TEA of the TNA of 30%% compounded %s = %v, want 0.3", period, tea)
+		}
+	}
+}
+
+func TestInvalidInterestType(t *testing.T) {
+	if _, err := EffectiveAnnualRate(0.24, "SIMPLE", enums.Monthly); err == nil {
+		t.Error("EffectiveAnnualRate accepted an invalid interest type")
+	}
+	if _, err := NominalAnnualRate(0.24, "SIMPLE", enums.Monthly); err == nil {
+		t.Error("NominalAnnualRate accepted an invalid interest type")
+	}
+	if _, err := ForDays(1000, 0.24, "SIMPLE", enums.Monthly, 30); err == nil {
+		t.Error("ForDays accepted an invalid interest type")
+	}
+	if _, err := ForMonths(1000, 0.24, "SIMPLE", enums.Monthly, 1); err == nil {
+		t.Error("ForMonths accepted an invalid interest type")
+	}
+}
+
+func TestForDays(t *testing.T) {
+	tests := []struct {
+		name         string
+		principal    float64
+		rate         float64
+		interestType enums.InterestType
+		days         int
+		want         float64
+	}{
+		{"a whole year accrues the TEA", 1000, 0.24, enums.Effective, 365, 240},
+		{"30 days", 1000, 0.24, enums.Effective, 30, 17.8376106371132},
+		{"nominal over a year accrues its TEA", 1000, 0.12, enums.Nominal, 365, 126.82503013196977},
+		{"no days", 1000, 0.24, enums.Effective, 0, 0},
+		{"negative days", 1000, 0.24, enums.Effective, -5, 0},
+		{"no principal", 0, 0.24, enums.Effective, 30, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ForDays(tt.principal, tt.rate, tt.interestType, enums.Monthly, tt.days)
+			if err != nil {
+				t.Fatalf("ForDays returned %v", err)
+			}
+			if !approx(got, tt.want) {
+				t.Errorf("ForDays(%v, %v, %s, %d) = %v, want %v", tt.principal, tt.rate, tt.interestType, tt.days, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForMonths(t *testing.T) {
+	tests := []struct {
+		name   string
+		months int
+		want   float64
+	}{
+		{"one month", 1, 18.087582483510722},
+		{"six months", 6, 113.55287256600444},
+		{"a year accrues the TEA", 12, 240},
+		{"no months", 0, 0},
+		{"negative months", -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ForMonths(1000, 0.24, enums.Effective, enums.Monthly, tt.months)
+			if err != nil {
+				t.Fatalf("ForMonths returned %v", err)
+			}
+			if !approx(got, tt.want) {
+				t.Errorf("ForMonths(1000, 0.24, %d) = %v, want %v", tt.months, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccrualIsAdditive(t *testing.T) {
+	// Accruing over 10 days and then 20 more on the new balance yields the same as 30 days at once
+	first, err := ForDays(1000, 0.24, enums.Effective, enums.Monthly, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ForDays(1000+first, 0.24, enums.Effective, enums.Monthly, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	whole, err := ForDays(1000, 0.24, enums.Effective, enums.Monthly, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !approx(first+second, whole) {
+		t.Errorf("10 + 20 days accrued %v, 30 days accrued %v", first+second, whole)
+	}
+}
@@ -0,0 +1,205 @@
+// Package invoicing builds, signs and submits the electronic invoices and receipts of Peruvian
+// electronic invoicing (SUNAT's UBL 2.1 format) to SUNAT or an OSE.
+package invoicing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Document types of SUNAT's catalog 01.
+const (
+	Invoice = "01" // Factura
+	Receipt = "03" // Boleta de venta
+)
+
+// Identity document types of SUNAT's catalog 06.
+const (
+	NoDocument = "0"
+	DNI        = "1"
+	RUC        = "6"
+)
+
+// Namespaces of the UBL 2.1 invoice and the XML signature.
+const (
+	nsInvoice   = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	nsCAC       = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	nsCBC       = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+	nsExtension = "urn:oasis:names:specification:ubl:schema:xsd:CommonExtensionComponents-2"
+	nsSignature = "http://www.w3.org/2000/09/xmldsig#"
+)
+
+// rootNamespaces are declared on the invoice element, and so are in scope everywhere in it.
+var rootNamespaces = []attr{
+	{"xmlns", nsInvoice},
+	{"xmlns:cac", nsCAC},
+	{"xmlns:cbc", nsCBC},
+	{"xmlns:ds", nsSignature},
+	{"xmlns:ext", nsExtension},
+}
+
+// signatureID names the signature of the document, referenced from its cac:Signature.
+const signatureID = "SignatureSP"
+
+// Document is a sale to be reported as an electronic invoice or receipt.
+type Document struct {
+	Type     string    // Invoice or Receipt
+	Number   string    // Series and correlative, e.g. B001-000123
+	IssuedAt time.Time // In Peru's time zone
+	Currency string    // ISO 4217 code, e.g. PEN
+	Issuer   Party
+	Customer Party
+	Lines    []Line
+}
+
+// Party is the issuer or the customer of a document.
+type Party struct {
+	IDType  string // NoDocument, DNI or RUC
+	ID      string
+	Name    string
+	Address string
+}
+
+// Line is an item sold, with its tax breakdown as charged.
+type Line struct {
+	Description string
+	Quantity    float64
+	TaxRate     float64 // IGV rate (%), e.g. 18
+	Amounts     Amounts
+}
+
+// Amounts is the tax breakdown of a line or a document.
+type Amounts struct {
+	Taxable float64 // Without the IGV
+	Tax     float64
+	Total   float64
+}
+
+// UnitPrice is the price of one unit of the line, including the IGV.
+func (l Line) UnitPrice() float64 {
+	return round(l.Amounts.Total / l.Quantity)
+}
+
+// Amounts adds up the breakdowns of the lines of the document.
+func (d *Document) Amounts() Amounts {
+	var amounts Amounts
+	for _, line := range d.Lines {
+		lineAmounts := line.Amounts
+		amounts.Taxable += lineAmounts.Taxable
+		amounts.Tax += lineAmounts.Tax
+		amounts.Total += lineAmounts.Total
+	}
+	return Amounts{Taxable: round(amounts.Taxable), Tax: round(amounts.Tax), Total: round(amounts.Total)}
+}
+
+// FileName is the name SUNAT expects for the files of the document, without extension: the RUC of
+// the issuer, the document type and its number.
+func (d *Document) FileName() string {
+	return d.Issuer.ID + "-" + d.Type + "-" + d.Number
+}
+
+// element builds the unsigned UBL invoice, with an empty extension for the signature.
+func (d *Document) element() *element {
+	amounts := d.Amounts()
+	invoice := el("Invoice").with(rootNamespaces...)
+	invoice.add(
+		el("ext:UBLExtensions", el("ext:UBLExtension", el("ext:ExtensionContent"))),
+		text("cbc:UBLVersionID", "2.1"),
+		text("cbc:CustomizationID", "2.0"),
+		text("cbc:ID", d.Number),
+		text("cbc:IssueDate", d.IssuedAt.Format("2006-01-02")),
+		text("cbc:IssueTime", d.IssuedAt.Format("15:04:05")),
+		text("cbc:InvoiceTypeCode", d.Type, attr{"listID", "0101"}), // Domestic sale
+		text("cbc:Note", AmountInWords(amounts.Total, d.Currency), attr{"languageLocaleID", "1000"}),
+		text("cbc:DocumentCurrencyCode", d.Currency),
+		el("cac:Signature",
+			text("cbc:ID", signatureID),
+			el("cac:SignatoryParty",
+				el("cac:PartyIdentification", text("cbc:ID", d.Issuer.ID)),
+				el("cac:PartyName", text("cbc:Name", d.Issuer.Name)),
+			),
+			el("cac:DigitalSignatureAttachment", el("cac:ExternalReference", text("cbc:URI", "#"+signatureID))),
+		),
+		el("cac:AccountingSupplierParty", d.Issuer.element(true)),
+		el("cac:AccountingCustomerParty", d.Customer.element(false)),
+		d.taxTotal(amounts, nil),
+		el("cac:LegalMonetaryTotal",
+			d.amount("cbc:LineExtensionAmount", amounts.Taxable),
+			d.amount("cbc:TaxInclusiveAmount", amounts.Total),
+			d.amount("cbc:PayableAmount", amounts.Total),
+		),
+	)
+	for i := range d.Lines {
+		line := &d.Lines[i]
+		invoice.add(el("cac:InvoiceLine",
+			text("cbc:ID", strconv.Itoa(i+1)),
+			text("cbc:InvoicedQuantity", formatQuantity(line.Quantity), attr{"unitCode", "NIU"}), // Units
+			d.amount("cbc:LineExtensionAmount", line.Amounts.Taxable),
+			el("cac:PricingReference", el("cac:AlternativeConditionPrice",
+				d.amount("cbc:PriceAmount", line.UnitPrice()),
+				text("cbc:PriceTypeCode", "01"), // Unit price including taxes
+			)),
+			d.taxTotal(line.Amounts, line),
+			el("cac:Item", text("cbc:Description", line.Description)),
+			el("cac:Price", d.amount("cbc:PriceAmount", round(line.Amounts.Taxable/line.Quantity))),
+		))
+	}
+	return invoice
+}
+
+func (p Party) element(issuer bool) *element {
+	legalEntity := el("cac:PartyLegalEntity", text("cbc:RegistrationName", p.Name))
+	if issuer {
+		legalEntity.add(el("cac:RegistrationAddress",
+			text("cbc:AddressTypeCode", "0000"), // Main address registered with SUNAT
+			el("cac:AddressLine", text("cbc:Line", p.Address)),
+		))
+	}
+	id := p.ID
+	if id == "" {
+		id = "-"
+	}
+	return el("cac:Party",
+		el("cac:PartyIdentification", text("cbc:ID", id, attr{"schemeID", p.IDType})),
+		legalEntity,
+	)
+}
+
+// taxTotal is the IGV of amounts, those of the whole document or of line. The subtotal of a line
+// also states its rate and that it is taxed.
+func (d *Document) taxTotal(amounts Amounts, line *Line) *element {
+	category := el("cac:TaxCategory")
+	if line != nil {
+		category.add(
+			text("cbc:Percent", strconv.FormatFloat(line.TaxRate, 'f', -1, 64)),
+			text("cbc:TaxExemptionReasonCode", "10"), // Taxed, onerous operation
+		)
+	}
+	category.add(el("cac:TaxScheme",
+		text("cbc:ID", "1000"),
+		text("cbc:Name", "IGV"),
+		text("cbc:TaxTypeCode", "VAT"),
+	))
+	return el("cac:TaxTotal",
+		d.amount("cbc:TaxAmount", amounts.Tax),
+		el("cac:TaxSubtotal",
+			d.amount("cbc:TaxableAmount", amounts.Taxable),
+			d.amount("cbc:TaxAmount", amounts.Tax),
+			category,
+		),
+	)
+}
+
+func (d *Document) amount(name string, value float64) *element {
+	return text(name, fmt.Sprintf("%.2f", value), attr{"currencyID", d.Currency})
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,119 @@
+package invoicing
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Algorithms of the enveloped XML signature (XMLDSig) documents are signed with.
+const (
+	algorithmC14N      = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	algorithmRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algorithmEnveloped = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	algorithmSHA256    = "http://www.w3.org/2001/04/xmlenc#sha256"
+)
+
+// Signer signs documents with the digital certificate of the issuer, as SUNAT requires.
+type Signer struct {
+	certificate *x509.Certificate
+	key         *rsa.PrivateKey
+}
+
+// LoadSigner reads a PEM certificate and its RSA private key, in PKCS #1 or PKCS #8 form.
+func LoadSigner(certificateFile, keyFile string) (*Signer, error) {
+	certificatePEM, err := os.ReadFile(certificateFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading invoicing certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading invoicing key: %w", err)
+	}
+	return NewSigner(certificatePEM, keyPEM)
+}
+
+// NewSigner creates a Signer from a PEM certificate and its RSA private key.
+func NewSigner(certificatePEM, keyPEM []byte) (*Signer, error) {
+	block, _ := pem.Decode(certificatePEM)
+	if block == nil {
+		return nil, errors.New("invoicing certificate is not PEM encoded")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoicing certificate: %w", err)
+	}
+
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("invoicing key is not PEM encoded")
+	}
+	key, err := parseRSAKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if !key.PublicKey.Equal(certificate.PublicKey) {
+		return nil, errors.New("invoicing key does not match the certificate")
+	}
+	return &Signer{certificate: certificate, key: key}, nil
+}
+
+func parseRSAKey(der []byte) (*rsa.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoicing key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invoicing key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// Sign builds the UBL XML of doc with its enveloped signature in the UBL extension. It also returns
+// the digest of the document, the hash printed on its PDF representation.
+func (s *Signer) Sign(doc *Document) ([]byte, string, error) {
+	invoice := doc.element()
+
+	// The enveloped-signature transform leaves the extension empty, as it is now
+	sum := sha256.Sum256([]byte(invoice.String()))
+	digest := base64.StdEncoding.EncodeToString(sum[:])
+
+	signedInfo := el("ds:SignedInfo",
+		el("ds:CanonicalizationMethod").with(attr{"Algorithm", algorithmC14N}),
+		el("ds:SignatureMethod").with(attr{"Algorithm", algorithmRSASHA256}),
+		el("ds:Reference",
+			el("ds:Transforms", el("ds:Transform").with(attr{"Algorithm", algorithmEnveloped})),
+			el("ds:DigestMethod").with(attr{"Algorithm", algorithmSHA256}),
+			text("ds:DigestValue", digest),
+		).with(attr{"URI", ""}),
+	)
+	// Canonicalized on its own, the signed info declares the namespaces in scope from the root
+	var canonical strings.Builder
+	signedInfo.render(&canonical, rootNamespaces)
+	hashed := sha256.Sum256([]byte(canonical.String()))
+	signatureValue, err := rsa.SignPKCS1v15(nil, s.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return nil, "", fmt.Errorf("error signing document: %w", err)
+	}
+
+	invoice.find("ext:ExtensionContent").add(el("ds:Signature",
+		signedInfo,
+		text("ds:SignatureValue", base64.StdEncoding.EncodeToString(signatureValue)),
+		el("ds:KeyInfo", el("ds:X509Data",
+			text("ds:X509Certificate", base64.StdEncoding.EncodeToString(s.certificate.Raw)),
+		)),
+	).with(attr{"Id", signatureID}))
+
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + invoice.String()), digest, nil
+}
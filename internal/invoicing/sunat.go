@@ -0,0 +1,179 @@
+package invoicing
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client submits documents to the sendBill operation of SUNAT's billing web service, or of an OSE
+// exposing the same service.
+type Client struct {
+	endpoint string
+	username string // RUC followed by the SOL user, e.g. 20123456789MODDATOS
+	password string
+	http     *http.Client
+}
+
+// NewClient creates a Client for the service at endpoint.
+func NewClient(endpoint, username, password string, timeout time.Duration) *Client {
+	return &Client{endpoint: endpoint, username: username, password: password, http: &http.Client{Timeout: timeout}}
+}
+
+// CDR is the receipt record (constancia de recepción) SUNAT answers a submitted document with.
+type CDR struct {
+	ResponseCode string
+	Description  string
+	Notes        []string // Observations on accepted documents
+	Data         []byte   // The zipped, signed CDR as received
+}
+
+// Accepted reports whether the document was accepted, possibly with observations.
+func (c *CDR) Accepted() bool {
+	code, err := strconv.Atoi(c.ResponseCode)
+	return err == nil && (code == 0 || code >= 4000)
+}
+
+// Fault is an error SUNAT answered a submission with instead of a CDR.
+type Fault struct {
+	Code    string
+	Message string
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("SUNAT answered with fault %s: %s", f.Code, f.Message)
+}
+
+// Rejected reports whether the document itself was rejected (codes 2000 to 3999), so submitting it
+// again is pointless. Other faults are errors of the submission or the service.
+func (f *Fault) Rejected() bool {
+	code, err := strconv.Atoi(f.Code)
+	return err == nil && code >= 2000 && code < 4000
+}
+
+// SendBill submits the signed XML of a document, zipped under fileName as SUNAT requires, and returns
+// its CDR. A rejection or an error of the service is returned as a *Fault.
+func (c *Client) SendBill(fileName string, signedXML []byte) (*CDR, error) {
+	content, err := zipFile(fileName+".xml", signedXML)
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope bytes.Buffer
+	envelope.WriteString(`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ser="http://service.sunat.gob.pe" ` +
+		`xmlns:wsse="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd"><soapenv:Header><wsse:Security><wsse:UsernameToken>`)
+	envelope.WriteString("<wsse:Username>" + escapeText(c.username) + "</wsse:Username><wsse:Password>" + escapeText(c.password) + "</wsse:Password>")
+	envelope.WriteString("</wsse:UsernameToken></wsse:Security></soapenv:Header><soapenv:Body><ser:sendBill>")
+	envelope.WriteString("<fileName>" + escapeText(fileName) + ".zip</fileName><contentFile>" + base64.StdEncoding.EncodeToString(content) + "</contentFile>")
+	envelope.WriteString("</ser:sendBill></soapenv:Body></soapenv:Envelope>")
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, &envelope)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SUNAT request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", "urn:sendBill")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error submitting to SUNAT: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error reading SUNAT response: %w", err)
+	}
+
+	// Faults come with a 500 status, so the body is read whatever the status
+	var answer struct {
+		Body struct {
+			Fault *struct {
+				Code   string `xml:"faultcode"`
+				String string `xml:"faultstring"`
+			} `xml:"Fault"`
+			SendBill *struct {
+				ApplicationResponse string `xml:"applicationResponse"`
+			} `xml:"sendBillResponse"`
+		} `xml:"Body"`
+	}
+	if err := xml.Unmarshal(body, &answer); err != nil {
+		return nil, fmt.Errorf("SUNAT answered %s with an invalid response: %w", resp.Status, err)
+	}
+	switch {
+	case answer.Body.Fault != nil:
+		// Codes come as e.g. "soap-env:Client.2335"
+		code := answer.Body.Fault.Code
+		code = code[strings.LastIndexAny(code, ".:")+1:]
+		return nil, &Fault{Code: code, Message: strings.TrimSpace(answer.Body.Fault.String)}
+	case answer.Body.SendBill == nil:
+		return nil, fmt.Errorf("SUNAT answered %s without a CDR", resp.Status)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(answer.Body.SendBill.ApplicationResponse))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CDR encoding: %w", err)
+	}
+	return ParseCDR(data)
+}
+
+// ParseCDR reads the response code and description of a zipped CDR.
+func ParseCDR(data []byte) (*CDR, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CDR archive: %w", err)
+	}
+	for _, file := range archive.File {
+		if !strings.HasSuffix(strings.ToLower(file.Name), ".xml") {
+			continue // The archive may hold an empty directory entry
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("error reading CDR: %w", err)
+		}
+		var response struct {
+			Notes            []string `xml:"Note"`
+			DocumentResponse struct {
+				Response struct {
+					ResponseCode string `xml:"ResponseCode"`
+					Description  string `xml:"Description"`
+				} `xml:"Response"`
+			} `xml:"DocumentResponse"`
+		}
+		err = xml.NewDecoder(reader).Decode(&response)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CDR: %w", err)
+		}
+		return &CDR{
+			ResponseCode: strings.TrimSpace(response.DocumentResponse.Response.ResponseCode),
+			Description:  strings.TrimSpace(response.DocumentResponse.Response.Description),
+			Notes:        response.Notes,
+			Data:         data,
+		}, nil
+	}
+	return nil, errors.New("CDR archive has no XML document")
+}
+
+func zipFile(name string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	file, err := archive.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("error zipping document: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		return nil, fmt.Errorf("error zipping document: %w", err)
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("error zipping document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
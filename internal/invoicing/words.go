@@ -0,0 +1,94 @@
+package invoicing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// currencyNames are the plural names of the currencies, as written in the amount in words.
+var currencyNames = map[string]string{
+	"PEN": "SOLES",
+	"USD": "DÓLARES AMERICANOS",
+}
+
+var (
+	unitWords = []string{"", "UNO", "DOS", "TRES", "CUATRO", "CINCO", "SEIS", "SIETE", "OCHO", "NUEVE",
+		"DIEZ", "ONCE", "DOCE", "TRECE", "CATORCE", "QUINCE", "DIECISÉIS", "DIECISIETE", "DIECIOCHO", "DIECINUEVE",
+		"VEINTE", "VEINTIUNO", "VEINTIDÓS", "VEINTITRÉS", "VEINTICUATRO", "VEINTICINCO", "VEINTISÉIS", "VEINTISIETE", "VEINTIOCHO", "VEINTINUEVE"}
+	tensWords     = []string{"", "", "", "TREINTA", "CUARENTA", "CINCUENTA", "SESENTA", "SETENTA", "OCHENTA", "NOVENTA"}
+	hundredsWords = []string{"", "CIENTO", "DOSCIENTOS", "TRESCIENTOS", "CUATROCIENTOS", "QUINIENTOS", "SEISCIENTOS", "SETECIENTOS", "OCHOCIENTOS", "NOVECIENTOS"}
+)
+
+// AmountInWords writes an amount as the legend of SUNAT's catalog 52, code 1000, e.g.
+// "SON CIENTO VEINTITRÉS CON 45/100 SOLES".
+func AmountInWords(amount float64, currency string) string {
+	cents := int64(math.Round(amount * 100))
+	integer, fraction := cents/100, cents%100
+	name := currencyNames[currency]
+	if name == "" {
+		name = currency
+	}
+	return fmt.Sprintf("SON %s CON %02d/100 %s", numberInWords(integer), fraction, name)
+}
+
+// numberInWords writes a non-negative integer below a trillion in Spanish.
+func numberInWords(n int64) string {
+	if n == 0 {
+		return "CERO"
+	}
+	var parts []string
+	if millions := n / 1_000_000; millions > 0 {
+		if millions == 1 {
+			parts = append(parts, "UN MILLÓN")
+		} else {
+			parts = append(parts, apocope(belowThousand(millions%1000, millions/1000))+" MILLONES")
+		}
+	}
+	if thousands := n / 1000 % 1000; thousands == 1 {
+		parts = append(parts, "MIL")
+	} else if thousands > 1 {
+		parts = append(parts, apocope(belowThousand(thousands, 0))+" MIL")
+	}
+	if rest := n % 1000; rest > 0 {
+		parts = append(parts, belowThousand(rest, 0))
+	}
+	return strings.Join(parts, " ")
+}
+
+// belowThousand writes n, below a thousand, preceded by thousands thousands (for the millions).
+func belowThousand(n, thousands int64) string {
+	var parts []string
+	if thousands == 1 {
+		parts = append(parts, "MIL")
+	} else if thousands > 1 {
+		parts = append(parts, apocope(belowThousand(thousands, 0))+" MIL")
+	}
+	if n == 100 {
+		return strings.Join(append(parts, "CIEN"), " ")
+	}
+	if hundreds := n / 100; hundreds > 0 {
+		parts = append(parts, hundredsWords[hundreds])
+	}
+	switch rest := n % 100; {
+	case rest == 0:
+	case rest < 30:
+		parts = append(parts, unitWords[rest])
+	case rest%10 == 0:
+		parts = append(parts, tensWords[rest/10])
+	default:
+		parts = append(parts, tensWords[rest/10]+" Y "+unitWords[rest%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+// apocope shortens a trailing "uno" before "mil" or "millones": veintiún mil, treinta y un millones.
+func apocope(words string) string {
+	switch {
+	case strings.HasSuffix(words, "VEINTIUNO"):
+		return strings.TrimSuffix(words, "VEINTIUNO") + "VEINTIÚN"
+	case strings.HasSuffix(words, "UNO"):
+		return strings.TrimSuffix(words, "UNO") + "UN"
+	}
+	return words
+}
@@ -0,0 +1,110 @@
+package invoicing
+
+import (
+	"sort"
+	"strings"
+)
+
+// element is an XML element, rendered in canonical form (Canonical XML 1.0, without comments) so the
+// bytes that are signed are the bytes that are sent: no XML declaration or whitespace between
+// elements, start and end tags for empty elements and attributes in canonical order. Namespaces are
+// declared on the root element only.
+type element struct {
+	name     string
+	attrs    []attr
+	text     string
+	children []*element
+}
+
+type attr struct {
+	name  string
+	value string
+}
+
+// el creates an element with the given children.
+func el(name string, children ...*element) *element {
+	return &element{name: name, children: children}
+}
+
+// text creates an element with text content.
+func text(name, value string, attrs ...attr) *element {
+	return &element{name: name, text: value, attrs: attrs}
+}
+
+// with adds attributes to e.
+func (e *element) with(attrs ...attr) *element {
+	e.attrs = append(e.attrs, attrs...)
+	return e
+}
+
+// add appends children to e.
+func (e *element) add(children ...*element) *element {
+	e.children = append(e.children, children...)
+	return e
+}
+
+// find returns the first descendant of e named name, depth first, or nil.
+func (e *element) find(name string) *element {
+	for _, child := range e.children {
+		if child.name == name {
+			return child
+		}
+		if found := child.find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func (e *element) String() string {
+	var b strings.Builder
+	e.render(&b, nil)
+	return b.String()
+}
+
+// render writes e, declaring inherited on it as well: the namespaces in scope that canonicalizing
+// e on its own, as a signed subset of the document, renders.
+func (e *element) render(b *strings.Builder, inherited []attr) {
+	b.WriteString("<" + e.name)
+	for _, a := range canonicalAttrs(append(append([]attr(nil), inherited...), e.attrs...)) {
+		b.WriteString(" " + a.name + `="` + escapeAttr(a.value) + `"`)
+	}
+	b.WriteString(">")
+	b.WriteString(escapeText(e.text))
+	for _, child := range e.children {
+		child.render(b, nil)
+	}
+	b.WriteString("</" + e.name + ">")
+}
+
+// canonicalAttrs sorts namespace declarations first, the default one before the prefixed ones by
+// prefix, and then the other attributes, which are all unqualified, by name.
+func canonicalAttrs(attrs []attr) []attr {
+	sort.SliceStable(attrs, func(i, j int) bool {
+		ri, rj := attrRank(attrs[i].name), attrRank(attrs[j].name)
+		if ri != rj {
+			return ri < rj
+		}
+		return attrs[i].name < attrs[j].name
+	})
+	return attrs
+}
+
+func attrRank(name string) int {
+	switch {
+	case name == "xmlns":
+		return 0
+	case strings.HasPrefix(name, "xmlns:"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(s string) string { return textEscaper.Replace(s) }
+func escapeAttr(s string) string { return attrEscaper.Replace(s) }
@@ -0,0 +1,56 @@
+// Package job runs background work on a schedule.
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs fn right away and then every interval in the background until ctx is done.
+// Errors are logged and don't stop the schedule.
+func Every(ctx context.Context, name string, interval time.Duration, fn func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := fn(); err != nil {
+				log.Printf("job %s failed: %v", name, err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Daily runs fn in the background every day at the given time of day in loc, e.g. 3*time.Hour
+// for 03:00, until ctx is done. Errors are logged and don't stop the schedule.
+func Daily(ctx context.Context, name string, at time.Duration, loc *time.Location, fn func() error) {
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(nextRun(time.Now().In(loc), at)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if err := fn(); err != nil {
+				log.Printf("job %s failed: %v", name, err)
+			}
+		}
+	}()
+}
+
+// nextRun returns the first time after now that is at into a day.
+func nextRun(now time.Time, at time.Duration) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	next := midnight.Add(at)
+	if !next.After(now) {
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Add(at)
+	}
+	return next
+}
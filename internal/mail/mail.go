@@ -0,0 +1,181 @@
+// Package mail sends emails, over SMTP or to the log in development.
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+)
+
+// Attachment is a file attached to a Message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Message is a plain text email, with an HTML version of its body if HTML is set.
+type Message struct {
+	To          string
+	Subject     string
+	Body        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(msg Message) error
+}
+
+type smtpSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender creates a Sender that delivers through an SMTP server. Username may be empty
+// for servers that don't require authentication.
+func NewSMTPSender(host, port, username, password, from string) Sender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpSender{addr: host + ":" + port, auth: auth, from: from}
+}
+
+// Send delivers msg as a multipart/mixed email.
+func (s *smtpSender) Send(msg Message) error {
+	data, err := encode(s.from, msg)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, data); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	return nil
+}
+
+type logSender struct{}
+
+// NewLogSender creates a Sender that only logs the messages, for environments without an SMTP server.
+func NewLogSender() Sender {
+	return logSender{}
+}
+
+func (logSender) Send(msg Message) error {
+	log.Printf("email to %s: %q (%d attachments)", msg.To, msg.Subject, len(msg.Attachments))
+	return nil
+}
+
+func encode(from string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	if err := writeBody(writer, msg); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range msg.Attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := base64.NewEncoder(base64.StdEncoding, &lineWriter{w: part})
+		if _, err := encoder.Write(attachment.Data); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBody writes the body of msg to writer, as plain text or, with an HTML version, as a
+// multipart/alternative part clients show the version they prefer of.
+func writeBody(writer *multipart.Writer, msg Message) error {
+	plain := textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}}
+	if msg.HTML == "" {
+		body, err := writer.CreatePart(plain)
+		if err != nil {
+			return err
+		}
+		_, err = body.Write([]byte(msg.Body))
+		return err
+	}
+
+	var buf bytes.Buffer
+	alternative := multipart.NewWriter(&buf)
+	for _, version := range []struct {
+		header textproto.MIMEHeader
+		body   string
+	}{
+		{plain, msg.Body},
+		{textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}}, msg.HTML},
+	} {
+		part, err := alternative.CreatePart(version.header)
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write([]byte(version.body)); err != nil {
+			return err
+		}
+	}
+	if err := alternative.Close(); err != nil {
+		return err
+	}
+
+	body, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"multipart/alternative; boundary=" + alternative.Boundary()}})
+	if err != nil {
+		return err
+	}
+	_, err = body.Write(buf.Bytes())
+	return err
+}
+
+// lineWriter breaks base64 output into the 76 character lines email requires.
+type lineWriter struct {
+	w      io.Writer
+	column int
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(76-l.column, len(p))
+		if _, err := l.w.Write(p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		l.column += n
+		p = p[n:]
+		if l.column == 76 {
+			if _, err := l.w.Write([]byte("\r\n")); err != nil {
+				return written, err
+			}
+			l.column = 0
+		}
+	}
+	return written, nil
+}
@@ -2,74 +2,57 @@ package middleware
 
 import (
 	"ApiRestFinance/internal/model/entities/enums"
-	"fmt"
+	"ApiRestFinance/internal/util"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 )
 
-// AuthMiddleware is a JWT authentication middleware for Gin
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// AuthMiddleware is a JWT authentication middleware for Gin. The access token is read from the
+// Authorization header or, for browser clients, from the access token cookie, and its claims are
+// stored in the context as *util.TokenClaims. Refresh tokens are rejected. Impersonation tokens are
+// accepted as the client's access token on the endpoints they are restricted to, checked and audited by
+// impersonation; they are rejected when it is nil.
+func AuthMiddleware(tokens *util.TokenIssuer, impersonation ImpersonationGuard) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		var tokenString string
+		authMethod := "bearer"
+
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		if authHeader != "" {
+			// Check if the token is in the format "Bearer {token}"
+			tokenParts := strings.Split(authHeader, " ")
+			if len(tokenParts) != 2 || strings.ToLower(tokenParts[0]) != "bearer" {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
+				return
+			}
+			tokenString = tokenParts[1]
+		} else if cookie, err := c.Cookie(AccessTokenCookie); err == nil && cookie != "" {
+			tokenString = cookie
+			authMethod = "cookie"
+		} else {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
 			return
 		}
 
-		// Check if the token is in the format "Bearer {token}"
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || strings.ToLower(tokenParts[0]) != "bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
-			return
+		claims, err := tokens.Validate(tokenString, util.AccessToken)
+		if err != nil && impersonation != nil {
+			claims, err = tokens.Validate(tokenString, util.ImpersonationToken)
 		}
-
-		tokenString := tokenParts[1]
-
-		// Parse and validate the JWT token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Verify signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			return
 		}
-
-		// Extract claims and set them in the context
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Unable to extract claims"})
-			return
-		}
 		c.Set("claims", claims)
-
-		// Extract user ID from claims
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Unable to extract user ID"})
+		c.Set("auth_method", authMethod)
+		c.Set("session_token", tokenString)
+		c.Set("user_id", claims.UserID)
+		c.Set("rol", enums.Role(claims.Role))
+		if claims.Type == util.ImpersonationToken {
+			impersonate(c, impersonation, claims)
 			return
 		}
-		userIDUint := uint(userID)
-		c.Set("user_id", userIDUint)
-		// Extract user role from claims
-		rol := claims["rol"].(string)
-
-		if rol == "" {
-			fmt.Println("Rol is empty")
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Unable to extract user role"})
-			return
-		}
-
-		role := enums.Role(rol)
-
-		c.Set("rol", role)
 		c.Next()
 
 	}
@@ -88,6 +71,15 @@ func GetUserIDFromContext(ctx *gin.Context) uint {
 	return userIDUint
 }
 
+// GetSessionIDFromContext returns the session of the request's access token, or 0 if it has none.
+func GetSessionIDFromContext(ctx *gin.Context) uint {
+	claims, ok := ctx.Value("claims").(*util.TokenClaims)
+	if !ok {
+		return 0
+	}
+	return claims.SessionID
+}
+
 func GetUserRoleFromContext(c *gin.Context) enums.Role {
 	value, exists := c.Get("rol")
 	if !exists {
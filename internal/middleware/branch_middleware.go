@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BranchHeader selects which of an admin's establishments a request acts on. Without it requests
+// act on the admin's main establishment.
+const BranchHeader = "X-Branch-ID"
+
+// BranchMiddleware reads the branch selected with the X-Branch-ID header into the context. Whether
+// the branch belongs to the admin is checked when it is looked up.
+func BranchMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := c.GetHeader(BranchHeader)
+		if value == "" {
+			c.Next()
+			return
+		}
+
+		branchID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || branchID == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid " + BranchHeader + " header"})
+			return
+		}
+		c.Set("branch_id", uint(branchID))
+		c.Next()
+	}
+}
+
+// GetBranchIDFromContext returns the branch selected for the request, or 0 for the main establishment.
+func GetBranchIDFromContext(ctx *gin.Context) uint {
+	branchID, _ := ctx.Get("branch_id")
+	id, _ := branchID.(uint)
+	return id
+}
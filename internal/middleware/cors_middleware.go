@@ -10,8 +10,9 @@ func CorsMiddleware() gin.HandlerFunc {
 	// Configure CORS middleware
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type", "Accept"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "Accept", "X-CSRF-Token", "X-Branch-ID"},
+		ExposedHeaders:   []string{"API-Version", "Retry-After", "X-Total-Count"},
 		AllowCredentials: true,
 	})
 
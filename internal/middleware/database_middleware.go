@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"ApiRestFinance/internal/database"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DatabaseAvailabilityMiddleware answers 503 Service Unavailable with a Retry-After header while
+// the database is down, instead of letting every request fail with a 500. A handler that fails with
+// a 500 triggers an immediate health check, so the first request to hit an outage is reported as a 503 too.
+func DatabaseAvailabilityMiddleware(watchdog *database.Watchdog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !watchdog.Available() {
+			setRetryAfter(c.Writer.Header(), watchdog)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Service temporarily unavailable, please retry later"})
+			return
+		}
+
+		c.Writer = &degradedResponseWriter{ResponseWriter: c.Writer, c: c, watchdog: watchdog}
+		c.Next()
+	}
+}
+
+// degradedResponseWriter turns a 500 into a 503 when it was caused by the database going away.
+type degradedResponseWriter struct {
+	gin.ResponseWriter
+	c        *gin.Context
+	watchdog *database.Watchdog
+}
+
+func (w *degradedResponseWriter) WriteHeader(code int) {
+	if code == http.StatusInternalServerError && !w.Written() && !w.watchdog.Check(w.c.Request.Context()) {
+		setRetryAfter(w.Header(), w.watchdog)
+		code = http.StatusServiceUnavailable
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func setRetryAfter(header http.Header, watchdog *database.Watchdog) {
+	seconds := int(math.Ceil(watchdog.RetryAfter().Seconds()))
+	header.Set("Retry-After", strconv.Itoa(seconds))
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ApiRestFinance/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonationGuard checks that impersonations are still going on and audits the requests made
+// with their tokens.
+type ImpersonationGuard interface {
+	ImpersonationActive(impersonationID uint) bool
+	AuditImpersonatedRequest(impersonationID uint, method, path string, status int, ip string)
+}
+
+// unscopedClientRoutes are the client endpoints that span every establishment of the client, so they
+// would show an impersonating admin the accounts the client holds elsewhere.
+var unscopedClientRoutes = map[string]bool{
+	"credit-accounts":      true,
+	"statement-deliveries": true,
+}
+
+// impersonate authorizes and audits a request made with an impersonation token. Those tokens are only
+// accepted on the read-only client endpoints, which always act on the impersonating admin's
+// establishment, and to stop the impersonation.
+func impersonate(c *gin.Context, guard ImpersonationGuard, claims *util.TokenClaims) {
+	path := c.Request.URL.RequestURI() // As requested, before the establishment is pinned
+	defer func() {
+		guard.AuditImpersonatedRequest(claims.ImpersonationID, c.Request.Method, path, c.Writer.Status(), c.ClientIP())
+	}()
+
+	if !guard.ImpersonationActive(claims.ImpersonationID) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Impersonation ended or expired"})
+		return
+	}
+	if !impersonationAllowed(c) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Impersonation tokens only give read-only access to client endpoints"})
+		return
+	}
+
+	// Pin the request to the establishment the client is being seen from
+	establishment := strconv.FormatUint(uint64(claims.EstablishmentID), 10)
+	query := c.Request.URL.Query()
+	if selected := query.Get("establishment_id"); selected != "" && selected != establishment {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Impersonation is limited to the establishment it was started from"})
+		return
+	}
+	query.Set("establishment_id", establishment)
+	c.Request.URL.RawQuery = query.Encode()
+	c.Next()
+}
+
+// impersonationAllowed reports whether an impersonation token may be used for the request.
+func impersonationAllowed(c *gin.Context) bool {
+	route := c.FullPath()
+	if c.Request.Method == http.MethodPost && strings.HasSuffix(route, "/admins/impersonate/stop") {
+		return true
+	}
+	if c.Request.Method != http.MethodGet {
+		return false
+	}
+	_, endpoint, ok := strings.Cut(route, "/clients/me/")
+	return ok && !unscopedClientRoutes[endpoint]
+}
+
+// GetImpersonatorIDFromContext returns the admin impersonating the client the request acts as, or 0
+// if it isn't made with an impersonation token.
+func GetImpersonatorIDFromContext(ctx *gin.Context) uint {
+	claims, ok := ctx.Value("claims").(*util.TokenClaims)
+	if !ok {
+		return 0
+	}
+	return claims.ImpersonatorID
+}
+
+// GetImpersonationIDFromContext returns the impersonation of the request's token, or 0 if it has none.
+func GetImpersonationIDFromContext(ctx *gin.Context) uint {
+	claims, ok := ctx.Value("claims").(*util.TokenClaims)
+	if !ok {
+		return 0
+	}
+	return claims.ImpersonationID
+}
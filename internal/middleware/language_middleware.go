@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"ApiRestFinance/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageResolver returns the language to answer a request in when it doesn't ask for one.
+type LanguageResolver func(c *gin.Context) i18n.Language
+
+// LanguageMiddleware picks the language of the request from its Accept-Language header. Requests
+// without a supported one are answered in the language defaultLanguage returns, looked up only when
+// something has to be translated; without defaultLanguage they get i18n.Default.
+func LanguageMiddleware(defaultLanguage LanguageResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if lang, ok := i18n.FromAcceptLanguage(c.GetHeader("Accept-Language")); ok {
+			c.Set("language", lang)
+		} else if defaultLanguage != nil {
+			c.Set("language_resolver", defaultLanguage)
+		}
+		c.Next()
+	}
+}
+
+// GetLanguageFromContext returns the language to answer the request in, i18n.Default outside the
+// LanguageMiddleware.
+func GetLanguageFromContext(c *gin.Context) i18n.Language {
+	if value, exists := c.Get("language"); exists {
+		if lang, ok := value.(i18n.Language); ok {
+			return lang
+		}
+	}
+	lang := i18n.Default
+	if value, exists := c.Get("language_resolver"); exists {
+		if resolve, ok := value.(LanguageResolver); ok {
+			lang = resolve(c)
+		}
+	}
+	c.Set("language", lang)
+	return lang
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocationResolver returns the time zone to show the times of a response in, nil if it has none.
+type LocationResolver func(c *gin.Context) *time.Location
+
+// LocationMiddleware lets the response of a request show its times in the time zone userLocation
+// returns, looked up only when a response has times to show.
+func LocationMiddleware(userLocation LocationResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("location_resolver", userLocation)
+		c.Next()
+	}
+}
+
+// GetLocationFromContext returns the time zone to show the times of the response in, nil outside the
+// LocationMiddleware or when the request has none.
+func GetLocationFromContext(c *gin.Context) *time.Location {
+	if value, exists := c.Get("location"); exists {
+		loc, _ := value.(*time.Location)
+		return loc
+	}
+	var loc *time.Location
+	if value, exists := c.Get("location_resolver"); exists {
+		if resolve, ok := value.(LocationResolver); ok && resolve != nil {
+			loc = resolve(c)
+		}
+	}
+	c.Set("location", loc)
+	return loc
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"ApiRestFinance/internal/model/entities/enums"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlatformAuditor records the audit entries of the requests made to the platform endpoints.
+type PlatformAuditor interface {
+	StartPlatformRequest(actorID uint, role, method, route, path, ip, userAgent string) (uint, error)
+	CompletePlatformRequest(entryID uint, status int)
+}
+
+// PlatformMiddleware guards the platform endpoints, which only super-admins may use. Every request is
+// audited, denied ones too: the entry is recorded before the request is handled, which is refused if
+// it can't be, and completed with the status the request was answered with. It must run after
+// AuthMiddleware.
+func PlatformMiddleware(auditor PlatformAuditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := GetUserRoleFromContext(c)
+		entryID, err := auditor.StartPlatformRequest(GetUserIDFromContext(c), string(role), c.Request.Method,
+			c.FullPath(), c.Request.URL.RequestURI(), c.ClientIP(), c.Request.UserAgent())
+		if err != nil {
+			log.Printf("Refusing platform request %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Platform requests can't be audited right now, try again later"})
+			return
+		}
+		defer func() {
+			auditor.CompletePlatformRequest(entryID, c.Writer.Status())
+		}()
+
+		if role != enums.SUPERADMIN || GetImpersonationIDFromContext(c) != 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Only super-admins can use the platform endpoints"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantResolver resolves the tenant a user acts in.
+type TenantResolver interface {
+	ResolveTenant(userID uint, role enums.Role, impersonatedEstablishmentID uint) (tenant.Scope, error)
+}
+
+// TenantMiddleware resolves, once per request, the tenant the user acts in and stores it in the
+// context, for the handlers to run the request on tenant-scoped repositories. It must run after
+// AuthMiddleware.
+func TenantMiddleware(resolver TenantResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var impersonatedEstablishmentID uint
+		if claims, ok := c.Value("claims").(*util.TokenClaims); ok && claims.Type == util.ImpersonationToken {
+			impersonatedEstablishmentID = claims.EstablishmentID
+		}
+
+		scope, err := resolver.ResolveTenant(GetUserIDFromContext(c), GetUserRoleFromContext(c), impersonatedEstablishmentID)
+		if err != nil {
+			log.Printf("Error resolving the tenant of user %d: %v", GetUserIDFromContext(c), err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Could not resolve the establishments of the request"})
+			return
+		}
+		c.Set("tenant", scope)
+		c.Next()
+	}
+}
+
+// GetTenantFromContext returns the tenant of the request. Without TenantMiddleware it is the zero
+// Scope, which reaches no tenant data.
+func GetTenantFromContext(ctx *gin.Context) tenant.Scope {
+	scope, _ := ctx.Value("tenant").(tenant.Scope)
+	return scope
+}
@@ -0,0 +1,154 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrIrreversible is returned when rolling back a migration that can't be undone.
+var ErrIrreversible = errors.New("migration cannot be rolled back")
+
+// Migration is one versioned change to the database schema. IDs sort in the order migrations are
+// applied, so they start with the date they were written.
+type Migration struct {
+	ID       string
+	Migrate  func(tx *gorm.DB) error
+	Rollback func(tx *gorm.DB) error // nil when the migration can't be undone
+}
+
+// schemaMigration records a migration applied to the database.
+type schemaMigration struct {
+	ID        string    `gorm:"primaryKey;size:255"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies and rolls back a list of migrations, keeping track of the applied ones in the
+// schema_migrations table. Each migration runs in its own transaction, so a failing migration leaves
+// the schema as the previous one left it.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New creates a Migrator for migrations, which must be listed in the order they are applied.
+func New(db *gorm.DB, migrations []Migration) (*Migrator, error) {
+	seen := make(map[string]bool, len(migrations))
+	for _, migration := range migrations {
+		if migration.ID == "" || migration.Migrate == nil {
+			return nil, fmt.Errorf("migration %q is incomplete", migration.ID)
+		}
+		if seen[migration.ID] {
+			return nil, fmt.Errorf("duplicate migration %q", migration.ID)
+		}
+		seen[migration.ID] = true
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrate applies the migrations that haven't been applied yet.
+func (m *Migrator) Migrate() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if applied[migration.ID] {
+			continue
+		}
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Migrate(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{ID: migration.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error applying migration %s: %w", migration.ID, err)
+		}
+		log.Println("applied migration", migration.ID)
+	}
+	return nil
+}
+
+// RollbackLast rolls back the last applied migration. It does nothing when none is applied.
+func (m *Migrator) RollbackLast() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if applied[m.migrations[i].ID] {
+			return m.rollback(m.migrations[i])
+		}
+	}
+	return nil
+}
+
+// RollbackTo rolls back every migration applied after the one with the given ID, newest first,
+// leaving the schema as it was right after that migration.
+func (m *Migrator) RollbackTo(id string) error {
+	target := -1
+	for i, migration := range m.migrations {
+		if migration.ID == id {
+			target = i
+		}
+	}
+	if target < 0 {
+		return fmt.Errorf("unknown migration %q", id)
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	for i := len(m.migrations) - 1; i > target; i-- {
+		if !applied[m.migrations[i].ID] {
+			continue
+		}
+		if err := m.rollback(m.migrations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) rollback(migration Migration) error {
+	if migration.Rollback == nil {
+		return fmt.Errorf("error rolling back migration %s: %w", migration.ID, ErrIrreversible)
+	}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Rollback(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{ID: migration.ID}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error rolling back migration %s: %w", migration.ID, err)
+	}
+	log.Println("rolled back migration", migration.ID)
+	return nil
+}
+
+// applied returns the IDs of the migrations applied to the database, creating the table that tracks them if needed.
+func (m *Migrator) applied() (map[string]bool, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("error creating schema_migrations: %w", err)
+	}
+	var ids []string
+	if err := m.db.Model(&schemaMigration{}).Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("error retrieving applied migrations: %w", err)
+	}
+	applied := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		applied[id] = true
+	}
+	return applied, nil
+}
@@ -0,0 +1,1019 @@
+package migration
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// All returns the migrations of the API's schema in the order they are applied. New migrations go
+// at the end; applied migrations must never be edited.
+func All() []Migration {
+	return []Migration{
+		{
+			// The schema as AutoMigrate left it before migrations were versioned. It is a no-op on
+			// databases created back then and creates the tables on new ones.
+			ID: "202610140001_initial_schema",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(initialSchema()...)
+			},
+			Rollback: func(tx *gorm.DB) error {
+				tables := initialSchema()
+				for i := len(tables) - 1; i >= 0; i-- {
+					if err := tx.Migrator().DropTable(tables[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// Branches share their main establishment's RUC, so the RUC is now only unique among main
+			// establishments. Restoring the index would fail as soon as a branch exists.
+			ID: "202610140002_drop_establishments_ruc_index",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.Exec("DROP INDEX IF EXISTS idx_establishments_ruc").Error
+			},
+		},
+		{
+			// Composite indexes for the queries that filter accounts of an establishment by due date,
+			// list the transactions of an account by date and look up installments by due date.
+			ID: "202610140003_composite_indexes",
+			Migrate: func(tx *gorm.DB) error {
+				return createIndexes(tx, compositeIndexes)
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropIndexes(tx, compositeIndexes)
+			},
+		},
+		{
+			// Expression index behind the transaction search's description filter
+			ID: "202610140004_transaction_description_index",
+			Migrate: func(tx *gorm.DB) error {
+				return createIndexes(tx, descriptionIndexes)
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropIndexes(tx, descriptionIndexes)
+			},
+		},
+		{
+			// Trigram indexes behind the client search's ILIKE filters. The search still works without
+			// them, just slower, so a database user that can't create extensions is not fatal.
+			ID: "202610140005_client_search_indexes",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.SavePoint("pg_trgm").Error; err != nil {
+					return err
+				}
+				if err := tx.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+					log.Println("pg_trgm is not available, client search will not be indexed:", err)
+					return tx.RollbackTo("pg_trgm").Error
+				}
+				return createIndexes(tx, clientSearchIndexes)
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropIndexes(tx, clientSearchIndexes)
+			},
+		},
+		{
+			ID: "202610140006_outbox_events",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.OutboxEvent{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.OutboxEvent{})
+			},
+		},
+		{
+			ID: "202610140007_jobs",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Job{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.Job{})
+			},
+		},
+		{
+			// AutoMigrate only adds the missing columns and the recovery codes table
+			ID: "202610140008_two_factor_authentication",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.User{}, &entities.EstablishmentSettings{}, &entities.TwoFactorRecoveryCode{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.TwoFactorRecoveryCode{}); err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.User{}, "TwoFactorSecret", "TwoFactorEnabled", "TwoFactorLastStep"); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "RequireAdminTwoFactor")
+			},
+		},
+		{
+			ID: "202610140009_sessions",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Session{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.Session{})
+			},
+		},
+		{
+			// Backfills the ledger with the existing transactions and block history. Their balance and
+			// credit limit at the time are unknown, so they are left empty.
+			ID: "202610140010_account_activities",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.AccountActivity{}); err != nil {
+					return err
+				}
+				err := tx.Exec(`INSERT INTO account_activities
+					(credit_account_id, type, amount, description, transaction_id, occurred_at, created_at)
+					SELECT credit_account_id, transaction_type,
+						CASE WHEN transaction_type = 'PAYMENT' THEN -amount ELSE amount END,
+						description, id, transaction_date, NOW()
+					FROM transactions WHERE deleted_at IS NULL`).Error
+				if err != nil {
+					return err
+				}
+				return tx.Exec(`INSERT INTO account_activities
+					(credit_account_id, type, amount, description, occurred_at, created_at)
+					SELECT credit_account_id,
+						CASE WHEN blocked THEN 'ACCOUNT_BLOCKED' ELSE 'ACCOUNT_UNBLOCKED' END,
+						0, reason, created_at, NOW()
+					FROM credit_account_block_events`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.AccountActivity{})
+			},
+		},
+		{
+			ID: "202610140011_document_series",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.DocumentSeries{}, &entities.Transaction{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.DocumentSeries{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.Transaction{}, "DocumentNumber")
+			},
+		},
+		{
+			ID: "202610140012_electronic_invoices",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.ElectronicInvoice{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.ElectronicInvoice{})
+			},
+		},
+		{
+			ID: "202610140013_purchase_taxes",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.PurchaseItem{}); err != nil {
+					return err
+				}
+				// Prices always included the IGV until now
+				return tx.Exec(`UPDATE purchase_items SET tax_rate = 18,
+					taxable_amount = ROUND((total / 1.18)::numeric, 2),
+					tax_amount = ROUND(total::numeric, 2) - ROUND((total / 1.18)::numeric, 2)`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := dropColumns(tx, &entities.PurchaseItem{}, "TaxRate", "TaxableAmount", "TaxAmount"); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "TaxRate", "PricesExcludeTax")
+			},
+		},
+		{
+			// Every establishment gets the categories of the former enum, plus any other a product
+			// was in, and products move to the category of their former value.
+			ID: "202610140014_product_categories",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.Category{}, &entities.Product{}); err != nil {
+					return err
+				}
+				for _, name := range enums.DefaultProductCategories {
+					err := tx.Exec(`INSERT INTO categories (establishment_id, name, created_at, updated_at)
+						SELECT id, ?, NOW(), NOW() FROM establishments
+						ON CONFLICT DO NOTHING`, string(name)).Error
+					if err != nil {
+						return err
+					}
+				}
+				if !tx.Migrator().HasColumn("products", "category") {
+					return nil
+				}
+				err := tx.Exec(`INSERT INTO categories (establishment_id, name, created_at, updated_at)
+					SELECT DISTINCT establishment_id, category, NOW(), NOW() FROM products
+					ON CONFLICT DO NOTHING`).Error
+				if err != nil {
+					return err
+				}
+				err = tx.Exec(`UPDATE products SET category_id = categories.id FROM categories
+					WHERE categories.establishment_id = products.establishment_id AND categories.name = products.category`).Error
+				if err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn("products", "category")
+			},
+			Rollback: func(tx *gorm.DB) error {
+				err := tx.Exec(fmt.Sprintf(`ALTER TABLE products ADD COLUMN IF NOT EXISTS category text NOT NULL DEFAULT '%s'`, enums.ProductCategoryGeneralStore)).Error
+				if err != nil {
+					return err
+				}
+				err = tx.Exec(`UPDATE products SET category = categories.name FROM categories WHERE categories.id = products.category_id`).Error
+				if err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.Product{}, "CategoryID"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&entities.Category{})
+			},
+		},
+		{
+			ID: "202610140015_product_codes",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Product{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.Product{}, "SKU", "Barcode")
+			},
+		},
+		{
+			ID: "202610140016_purchase_approvals",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.PurchaseApproval{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.PurchaseApproval{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "ApprovalThreshold", "ApprovalExpiryDays")
+			},
+		},
+		{
+			ID: "202610140017_spending_limits",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CreditAccount{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.CreditAccount{}, "SpendingLimit", "SpendingLimitPeriod")
+			},
+		},
+		{
+			ID: "202610140018_payment_promises",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.PaymentPromise{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.PaymentPromise{})
+			},
+		},
+		{
+			ID: "202610140019_write_offs",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CreditAccount{}, &entities.CreditAccountWriteOff{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.CreditAccountWriteOff{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.CreditAccount{}, "WrittenOffBalance", "WrittenOffAt")
+			},
+		},
+		{
+			ID: "202610140020_attachments",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Attachment{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.Attachment{})
+			},
+		},
+		{
+			ID: "202610140021_credit_agreements",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CreditAgreement{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.CreditAgreement{})
+			},
+		},
+		{
+			ID: "202610140022_impersonations",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Impersonation{}, &entities.ImpersonationRequest{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.ImpersonationRequest{}, &entities.Impersonation{})
+			},
+		},
+		{
+			ID: "202610140023_client_signups",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Establishment{}, &entities.ClientSignup{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.ClientSignup{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.Establishment{}, "InviteCode")
+			},
+		},
+		{
+			ID: "202610140024_establishment_language",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "Language")
+			},
+		},
+		{
+			ID: "202610140025_record_versions",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CreditAccount{}, &entities.Product{}, &entities.Establishment{}, &entities.Installment{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for _, model := range []interface{}{&entities.CreditAccount{}, &entities.Product{}, &entities.Establishment{}, &entities.Installment{}} {
+					if err := dropColumns(tx, model, "Version"); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// The API answers duplicate DNIs, emails and RUCs with 409 Conflict when these indexes reject
+			// them, so they must exist. Emails are also unique ignoring case, as a@x.com and A@x.com
+			// reach the same inbox. The migration fails, naming the duplicate, if users already share
+			// one of them; merge those by hand first.
+			ID: "202610140026_unique_constraints",
+			Migrate: func(tx *gorm.DB) error {
+				return createUniqueIndexes(tx, uniqueIndexes)
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropIndexes(tx, uniqueIndexes[len(uniqueIndexes)-1:])
+			},
+		},
+		{
+			// Statements are only emailed to verified emails. The emails of existing users are taken as
+			// verified, as they have been receiving them; their phones start unverified.
+			ID: "202610140027_contact_verification",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.User{}, &entities.ContactVerification{}); err != nil {
+					return err
+				}
+				return tx.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL").Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.ContactVerification{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.User{}, "EmailVerifiedAt", "PhoneVerifiedAt")
+			},
+		},
+		{
+			// Overdue notices are recorded with the payment reminders, so the unique index of their due
+			// dates is recreated with the kind
+			ID: "202610140028_sms_notifications",
+			Migrate: func(tx *gorm.DB) error {
+				if err := dropIndexes(tx, paymentReminderIndexes); err != nil {
+					return err
+				}
+				return tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.PaymentReminder{}, &entities.SMSDelivery{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.SMSDelivery{}); err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.EstablishmentSettings{}, "SMSNotifications", "SMSSender"); err != nil {
+					return err
+				}
+				if err := tx.Exec("DELETE FROM payment_reminders WHERE kind <> ?", enums.PaymentReminderNotification).Error; err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.PaymentReminder{}, "Kind"); err != nil {
+					return err
+				}
+				return createUniqueIndexes(tx, paymentReminderIndexes)
+			},
+		},
+		{
+			ID: "202610140029_payment_links",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.PaymentLink{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.PaymentLink{})
+			},
+		},
+		{
+			ID: "202610140030_report_digests",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.ReportDigest{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.ReportDigest{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "DigestFrequency", "DigestSections")
+			},
+		},
+		{
+			ID: "202610140031_user_anonymization",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.User{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.User{}, "AnonymizedAt")
+			},
+		},
+		{
+			ID: "202610140032_credit_account_closure",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CreditAccount{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.CreditAccount{}, "Status", "ClosedAt")
+			},
+		},
+		{
+			ID: "202610140033_purchase_pins",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.PurchasePin{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.PurchasePin{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "PinThreshold")
+			},
+		},
+		{
+			ID: "202610140034_installment_reschedules",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.InstallmentReschedule{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.InstallmentReschedule{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "MaxReschedules", "RescheduleInterest")
+			},
+		},
+		{
+			ID: "202610140035_late_fee_modes",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.LateFee{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.LateFee{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "LateFeeMode", "LateFeeAmount", "LateFeeCap")
+			},
+		},
+		{
+			// Backfills the transactions of the interest and late fees charged before they had any, from
+			// their ledger entries, and links the entries to them.
+			ID: "202610140036_charge_transactions",
+			Migrate: func(tx *gorm.DB) error {
+				err := tx.Exec(`INSERT INTO transactions
+					(created_at, updated_at, credit_account_id, transaction_type, amount, description,
+						transaction_date, payment_method, payment_status)
+					SELECT NOW(), NOW(), credit_account_id,
+						CASE WHEN type = 'INTEREST_CHARGE' THEN 'INTEREST' ELSE 'LATE_FEE' END,
+						amount, description, occurred_at, '', 'SUCCESS'
+					FROM account_activities
+					WHERE type IN ('INTEREST_CHARGE', 'LATE_FEE') AND transaction_id IS NULL`).Error
+				if err != nil {
+					return err
+				}
+				return tx.Exec(`UPDATE account_activities SET transaction_id = transactions.id
+					FROM transactions
+					WHERE account_activities.type IN ('INTEREST_CHARGE', 'LATE_FEE')
+						AND account_activities.transaction_id IS NULL
+						AND transactions.credit_account_id = account_activities.credit_account_id
+						AND transactions.transaction_type = CASE WHEN account_activities.type = 'INTEREST_CHARGE' THEN 'INTEREST' ELSE 'LATE_FEE' END
+						AND transactions.transaction_date = account_activities.occurred_at
+						AND transactions.amount = account_activities.amount`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				err := tx.Exec(`UPDATE account_activities SET transaction_id = NULL
+					WHERE type IN ('INTEREST_CHARGE', 'LATE_FEE')`).Error
+				if err != nil {
+					return err
+				}
+				return tx.Exec(`DELETE FROM transactions WHERE transaction_type IN ('INTEREST', 'LATE_FEE')`).Error
+			},
+		},
+		{
+			ID: "202610140037_account_adjustments",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.AccountAdjustment{}, &entities.StatementPeriod{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.AccountAdjustment{}); err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.StatementPeriod{}, "Adjustments"); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "AdjustmentThreshold", "AdjustmentApproverID")
+			},
+		},
+		{
+			ID: "202610140038_till_sessions",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.TillSession{}, &entities.Transaction{}, &entities.PurchaseItem{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := dropColumns(tx, &entities.Transaction{}, "TillSessionID"); err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.PurchaseItem{}, "TillSessionID"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&entities.TillSession{})
+			},
+		},
+		{
+			ID: "202610140039_collection_contacts",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CollectionContact{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.CollectionContact{})
+			},
+		},
+		{
+			ID: "202610140040_client_notes_and_tags",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.ClientTag{}, &entities.ClientTagAssignment{}, &entities.ClientNote{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.ClientNote{}, &entities.ClientTagAssignment{}, &entities.ClientTag{})
+			},
+		},
+		{
+			ID: "202610140041_credit_templates",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CreditTemplate{}, &entities.CreditAccount{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&entities.CreditAccount{}, "CreditTemplateID"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&entities.CreditTemplate{})
+			},
+		},
+		{
+			ID: "202610140042_credit_term_updates",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.CreditTermUpdate{}, &entities.CreditTermChange{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.CreditTermChange{}, &entities.CreditTermUpdate{})
+			},
+		},
+		{
+			ID: "202610140043_balance_snapshots",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.BalanceSnapshot{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.BalanceSnapshot{})
+			},
+		},
+		{
+			ID: "202610140044_establishment_plans",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.Establishment{}); err != nil {
+					return err
+				}
+				// Establishments from before plans keep every feature and have no limits
+				return tx.Exec("UPDATE establishments SET plan = ?", enums.PlanPremium).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.Establishment{}, "Plan")
+			},
+		},
+		{
+			ID: "202610140045_platform_suspensions_and_audit",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Establishment{}, &entities.PlatformAuditEntry{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entities.PlatformAuditEntry{}); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.Establishment{}, "SuspendedAt", "SuspensionReason")
+			},
+		},
+		{
+			ID: "202610140046_payment_code_expiry",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.Transaction{}, &entities.EstablishmentSettings{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := dropColumns(tx, &entities.Transaction{}, "PaymentCodeExpiresAt", "PaymentCodeAttempts"); err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "PaymentCodeMinutes")
+			},
+		},
+		{
+			ID: "202610140047_bank_transfers",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.BankTransfer{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.BankTransfer{})
+			},
+		},
+		{
+			ID: "202610140048_accounting_accounts",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.EstablishmentSettings{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "ReceivablesAccount", "SalesAccount", "CashAccount", "InterestAccount", "LateFeeAccount", "WriteOffAccount")
+			},
+		},
+		{
+			// New establishments map their accounting events themselves; those that could already
+			// export keep the accounts of the PCGE they had
+			ID: "202610140049_accounting_event_mapping",
+			Migrate: func(tx *gorm.DB) error {
+				for column := range pcgeAccounts {
+					if err := setColumnDefault(tx, "establishment_settings", column, ""); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for column, account := range pcgeAccounts {
+					if err := setColumnDefault(tx, "establishment_settings", column, account); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// Payments made before are allocated as if each had covered the installments of its
+			// account by due date, after the payments made before it
+			ID: "202610140050_payment_allocations",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.PaymentAllocation{}); err != nil {
+					return err
+				}
+				return tx.Exec(`WITH due AS (
+						SELECT id, credit_account_id, amount,
+							SUM(amount) OVER (PARTITION BY credit_account_id ORDER BY due_date, id) - amount AS covered_from
+						FROM installments WHERE deleted_at IS NULL
+					), paid AS (
+						SELECT id, credit_account_id, amount, transaction_date,
+							SUM(amount) OVER (PARTITION BY credit_account_id ORDER BY transaction_date, id) - amount AS covered_from
+						FROM transactions
+						WHERE deleted_at IS NULL AND transaction_type = ? AND payment_status <> ?
+					), allocated AS (
+						SELECT paid.id AS transaction_id, due.id AS installment_id, paid.credit_account_id, paid.transaction_date,
+							LEAST(due.covered_from + due.amount, paid.covered_from + paid.amount) - GREATEST(due.covered_from, paid.covered_from) AS amount
+						FROM paid JOIN due ON due.credit_account_id = paid.credit_account_id
+					)
+					INSERT INTO payment_allocations (transaction_id, installment_id, credit_account_id, amount, created_at)
+					SELECT transaction_id, installment_id, credit_account_id, ROUND(amount::numeric, 2), transaction_date
+					FROM allocated WHERE amount >= 0.005`, enums.Payment, enums.FAILED).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.PaymentAllocation{})
+			},
+		},
+		{
+			ID: "202610140051_client_notifications",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.ClientNotification{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.ClientNotification{})
+			},
+		},
+		{
+			// Installments were only marked paid by hand or on payoff: they move to the status what
+			// was allocated to them and their due date put them in
+			ID: "202610140052_installment_status_timestamps",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.Installment{}); err != nil {
+					return err
+				}
+				err := tx.Exec(`UPDATE installments SET paid_at = COALESCE(
+						(SELECT MAX(created_at) FROM payment_allocations WHERE payment_allocations.installment_id = installments.id),
+						installments.updated_at)
+					WHERE status = ? AND paid_at IS NULL`, enums.Paid).Error
+				if err != nil {
+					return err
+				}
+				return tx.Exec(`WITH allocated AS (
+						SELECT installments.id, COALESCE(SUM(payment_allocations.amount), 0) AS amount,
+							COALESCE(MAX(payment_allocations.created_at), installments.updated_at) AS paid_at
+						FROM installments LEFT JOIN payment_allocations ON payment_allocations.installment_id = installments.id
+						WHERE installments.status <> ? AND installments.deleted_at IS NULL
+						GROUP BY installments.id
+					)
+					UPDATE installments SET
+						status = CASE
+							WHEN installments.amount - allocated.amount < 0.005 THEN ?
+							WHEN installments.due_date + INTERVAL '1 day' <= NOW() THEN ?
+							WHEN allocated.amount >= 0.005 THEN ?
+							ELSE ? END,
+						paid_at = CASE WHEN installments.amount - allocated.amount < 0.005 THEN allocated.paid_at END,
+						overdue_at = CASE WHEN installments.amount - allocated.amount >= 0.005 AND installments.due_date + INTERVAL '1 day' <= NOW()
+							THEN installments.due_date + INTERVAL '1 day' END
+					FROM allocated WHERE allocated.id = installments.id`,
+					enums.Paid, enums.Paid, enums.Overdue, enums.PartiallyPaid, enums.Pending).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				err := tx.Exec("UPDATE installments SET status = CASE WHEN status = ? THEN ? ELSE ? END WHERE status IN ?",
+					enums.Cancelled, enums.Paid, enums.Pending, []enums.InstallmentStatus{enums.PartiallyPaid, enums.Cancelled}).Error
+				if err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.Installment{}, "overdue_at", "paid_at", "cancelled_at")
+			},
+		},
+		{
+			// Backfills the history with the block history and the limit changes, closures and
+			// reopenings of the ledger. The ledger kept no reason for limit changes nor who closed or
+			// reopened accounts, and interest rate changes weren't recorded before
+			ID: "202610140053_credit_account_events",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.CreditAccountEvent{}); err != nil {
+					return err
+				}
+				err := tx.Exec(`INSERT INTO credit_account_events
+					(credit_account_id, type, reason, automatic, actor_id, created_at)
+					SELECT credit_account_id, CASE WHEN blocked THEN ? ELSE ? END, reason, automatic, actor_id, created_at
+					FROM credit_account_block_events`, enums.CreditAccountBlocked, enums.CreditAccountUnblocked).Error
+				if err != nil {
+					return err
+				}
+				return tx.Exec(`INSERT INTO credit_account_events
+					(credit_account_id, type, old_value, new_value, reason, created_at)
+					SELECT credit_account_id,
+						CASE type WHEN ? THEN ? WHEN ? THEN ? ELSE ? END,
+						CASE WHEN type = ? THEN substring(description from 'from ([-0-9.]+) to')::double precision END,
+						CASE WHEN type = ? THEN substring(description from 'to ([-0-9.]+)$')::double precision END,
+						CASE WHEN type = ? THEN '' ELSE COALESCE(description, '') END,
+						occurred_at
+					FROM account_activities
+					WHERE type IN (?, ?)
+						OR (type = ? AND description ~ '^Credit limit changed from [-0-9.]+ to [-0-9.]+$')`,
+					enums.ActivityAccountClosed, enums.CreditAccountClosed, enums.ActivityAccountReopened, enums.CreditAccountReopened, enums.CreditAccountLimitChanged,
+					enums.ActivityLimitChange, enums.ActivityLimitChange, enums.ActivityLimitChange,
+					enums.ActivityAccountClosed, enums.ActivityAccountReopened, enums.ActivityLimitChange).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.CreditAccountEvent{})
+			},
+		},
+		{
+			// Establishments get the national holidays of this year and the next, as new ones do, and
+			// the installments still to be paid that fall on a Sunday or one of them move to the next
+			// business day, a day at a time
+			ID: "202610140054_holidays",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entities.Holiday{}); err != nil {
+					return err
+				}
+				var establishmentIDs []uint
+				if err := tx.Model(&entities.Establishment{}).Pluck("id", &establishmentIDs).Error; err != nil {
+					return err
+				}
+				year := time.Now().Year()
+				var holidays []entities.Holiday
+				for _, establishmentID := range establishmentIDs {
+					for _, holiday := range append(calendar.PeruvianHolidays(year), calendar.PeruvianHolidays(year+1)...) {
+						holidays = append(holidays, entities.Holiday{EstablishmentID: establishmentID, Date: holiday.Date, Name: holiday.Name, National: true})
+					}
+				}
+				if len(holidays) > 0 {
+					if err := tx.CreateInBatches(&holidays, 500).Error; err != nil {
+						return err
+					}
+				}
+				for i := 0; i < 31; i++ {
+					result := tx.Exec(`UPDATE installments SET due_date = installments.due_date + INTERVAL '1 day', version = installments.version + 1
+						FROM credit_accounts JOIN establishments ON establishments.id = credit_accounts.establishment_id
+						WHERE credit_accounts.id = installments.credit_account_id
+							AND installments.status IN ?
+							AND (EXTRACT(DOW FROM installments.due_date AT TIME ZONE establishments.timezone) = 0
+								OR EXISTS (SELECT 1 FROM holidays WHERE holidays.establishment_id = establishments.id
+									AND holidays.date = (installments.due_date AT TIME ZONE establishments.timezone)::date))`,
+						[]enums.InstallmentStatus{enums.Pending, enums.PartiallyPaid})
+					if result.Error != nil || result.RowsAffected == 0 {
+						return result.Error
+					}
+				}
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entities.Holiday{})
+			},
+		},
+		{
+			// The reminder days become a schedule starting on them, with the overdue notices of the
+			// establishments that text their notifications a day after the due date, as they were sent.
+			// Reminders sent until now are recorded on those days, for the whole due date
+			ID: "202610140055_reminder_schedules",
+			Migrate: func(tx *gorm.DB) error {
+				if err := dropIndexes(tx, paymentReminderIndexes); err != nil {
+					return err
+				}
+				if err := tx.AutoMigrate(&entities.EstablishmentSettings{}, &entities.PaymentReminder{}); err != nil {
+					return err
+				}
+				if !tx.Migrator().HasColumn(&entities.EstablishmentSettings{}, "reminder_days_before") {
+					return nil
+				}
+				err := tx.Exec(`UPDATE establishment_settings SET reminder_schedule = '-' || reminder_days_before
+					WHERE reminder_days_before > 0`).Error
+				if err != nil {
+					return err
+				}
+				err = tx.Exec(`UPDATE establishment_settings
+					SET reminder_schedule = CASE WHEN reminder_schedule = '' THEN '1' ELSE reminder_schedule || ',1' END
+					WHERE sms_notifications`).Error
+				if err != nil {
+					return err
+				}
+				err = tx.Exec(`UPDATE payment_reminders SET days_from_due = -establishment_settings.reminder_days_before
+					FROM credit_accounts JOIN establishment_settings ON establishment_settings.establishment_id = credit_accounts.establishment_id
+					WHERE credit_accounts.id = payment_reminders.credit_account_id AND payment_reminders.kind = ?`,
+					enums.PaymentReminderNotification).Error
+				if err != nil {
+					return err
+				}
+				if err := tx.Exec("UPDATE payment_reminders SET days_from_due = 1 WHERE kind = ?", enums.OverdueNotification).Error; err != nil {
+					return err
+				}
+				return dropColumns(tx, &entities.EstablishmentSettings{}, "reminder_days_before")
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Exec("ALTER TABLE establishment_settings ADD COLUMN IF NOT EXISTS reminder_days_before bigint NOT NULL DEFAULT 0").Error; err != nil {
+					return err
+				}
+				err := tx.Exec(`UPDATE establishment_settings SET reminder_days_before = -split_part(reminder_schedule, ',', 1)::bigint
+					WHERE reminder_schedule LIKE '-%'`).Error
+				if err != nil {
+					return err
+				}
+				err = tx.Exec(`DELETE FROM payment_reminders USING payment_reminders kept
+					WHERE kept.credit_account_id = payment_reminders.credit_account_id AND kept.due_date = payment_reminders.due_date
+						AND kept.kind = payment_reminders.kind AND kept.id < payment_reminders.id`).Error
+				if err != nil {
+					return err
+				}
+				if err := dropIndexes(tx, paymentReminderIndexes); err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.PaymentReminder{}, "InstallmentID", "DaysFromDue"); err != nil {
+					return err
+				}
+				if err := dropColumns(tx, &entities.EstablishmentSettings{}, "ReminderSchedule"); err != nil {
+					return err
+				}
+				return createUniqueIndexes(tx, []index{{"idx_payment_reminders_due", "payment_reminders", "(credit_account_id, due_date, kind)"}})
+			},
+		},
+		{
+			ID: "202610140056_two_factor_lockout",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entities.User{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return dropColumns(tx, &entities.User{}, "TwoFactorFailedAttempts", "TwoFactorLockedUntil")
+			},
+		},
+	}
+}
+
+// initialSchema lists the entities of the first migration, parents before the tables referencing them.
+func initialSchema() []interface{} {
+	return []interface{}{
+		&entities.User{},
+		&entities.Establishment{},
+		&entities.Product{},
+		&entities.CreditAccount{},
+		&entities.Transaction{},
+		&entities.Installment{},
+		&entities.PurchaseItem{},
+		&entities.StatementDelivery{},
+		&entities.StatementPeriod{},
+		&entities.EstablishmentSettings{},
+		&entities.PaymentReminder{},
+		&entities.CreditAccountBlockEvent{},
+	}
+}
+
+// index is an index a migration creates, by name, table and indexed expression.
+type index struct {
+	name       string
+	table      string
+	definition string
+}
+
+var compositeIndexes = []index{
+	{"idx_credit_accounts_establishment_due", "credit_accounts", "(establishment_id, monthly_due_date)"},
+	{"idx_transactions_account_date", "transactions", "(credit_account_id, transaction_date)"},
+	{"idx_installments_account_due", "installments", "(credit_account_id, due_date)"},
+	{"idx_installments_status_due", "installments", "(status, due_date)"},
+}
+
+var descriptionIndexes = []index{
+	{"idx_transactions_description_fts", "transactions", "USING gin (to_tsvector('simple', coalesce(description, '')))"},
+}
+
+var clientSearchIndexes = []index{
+	{"idx_users_name_trgm", "users", "USING gin (name gin_trgm_ops)"},
+	{"idx_users_dni_trgm", "users", "USING gin (dni gin_trgm_ops)"},
+	{"idx_users_email_trgm", "users", "USING gin (email gin_trgm_ops)"},
+	{"idx_users_phone_trgm", "users", "USING gin (phone gin_trgm_ops)"},
+}
+
+// uniqueIndexes are the unique indexes of 202610140026_unique_constraints. Only the last one is
+// new: the others were created from the entities' tags and are kept on rollback.
+var uniqueIndexes = []index{
+	{"idx_users_dni", "users", "(dni)"},
+	{"idx_users_email", "users", "(email)"},
+	{"idx_establishments_main_ruc", "establishments", "(ruc) WHERE parent_id IS NULL"},
+	{"idx_users_email_lower", "users", "(lower(email))"},
+}
+
+// paymentReminderIndexes is the unique index of the due dates reminded before
+// 202610140028_sms_notifications added their kind to it.
+var paymentReminderIndexes = []index{
+	{"idx_payment_reminders_due", "payment_reminders", "(credit_account_id, due_date)"},
+}
+
+// pcgeAccounts are the defaults of the account columns of establishment_settings before
+// 202610140049_accounting_event_mapping, from the PCGE.
+var pcgeAccounts = map[string]string{
+	"receivables_account": "1212",
+	"sales_account":       "7011",
+	"cash_account":        "1011",
+	"interest_account":    "7722",
+	"late_fee_account":    "7599",
+	"write_off_account":   "6841",
+}
+
+func createIndexes(tx *gorm.DB, indexes []index) error {
+	for _, idx := range indexes {
+		if err := tx.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s %s", idx.name, idx.table, idx.definition)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createUniqueIndexes(tx *gorm.DB, indexes []index) error {
+	for _, idx := range indexes {
+		if err := tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s %s", idx.name, idx.table, idx.definition)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropIndexes(tx *gorm.DB, indexes []index) error {
+	for _, idx := range indexes {
+		if err := tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", idx.name)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setColumnDefault(tx *gorm.DB, table, column, value string) error {
+	return tx.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT '%s'", table, column, value)).Error
+}
+
+func dropColumns(tx *gorm.DB, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		if !tx.Migrator().HasColumn(model, field) {
+			continue
+		}
+		if err := tx.Migrator().DropColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
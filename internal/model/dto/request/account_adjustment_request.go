@@ -0,0 +1,15 @@
+package request
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// CreateAccountAdjustmentRequest is a manual charge or credit an admin makes to the balance of a credit account
+type CreateAccountAdjustmentRequest struct {
+	Type   enums.TransactionType `json:"type" binding:"required,oneof=ADJUSTMENT_DEBIT ADJUSTMENT_CREDIT"`
+	Amount float64               `json:"amount" binding:"required,gt=0"`
+	Reason string                `json:"reason" binding:"required,max=500"`
+}
+
+// RejectAccountAdjustmentRequest holds the reason the approver rejects an adjustment waiting for approval
+type RejectAccountAdjustmentRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,8 @@
+package request
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// AssignPlanRequest moves a main establishment, with its branches, to a plan.
+type AssignPlanRequest struct {
+	Plan enums.Plan `json:"plan" binding:"required,oneof=FREE STANDARD PREMIUM"`
+}
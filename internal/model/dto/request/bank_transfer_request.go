@@ -0,0 +1,12 @@
+package request
+
+import "time"
+
+// BankTransferNotificationRequest holds a transfer the bank notifies it received
+type BankTransferNotificationRequest struct {
+	ID            string    `json:"id" binding:"required,max=100"`        // ID the bank gives the notification, the same on every delivery
+	Amount        float64   `json:"amount" binding:"required,gt=0"`       // In soles
+	Reference     string    `json:"reference" binding:"required,max=100"` // Confirmation code of a pending payment, or CA-<credit account ID>
+	PayerName     string    `json:"payer_name" binding:"max=200"`
+	TransferredAt time.Time `json:"transferred_at" binding:"required"`
+}
@@ -0,0 +1,6 @@
+package request
+
+// BlockCreditAccountRequest explains why a credit account is being blocked or unblocked.
+type BlockCreditAccountRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,46 @@
+package request
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// BulkUpdateCreditAccountsRequest changes the terms of the credit accounts of the establishment the
+// filter matches, or lists what it would change in preview
+type BulkUpdateCreditAccountsRequest struct {
+	Filter CreditAccountFilterRequest `json:"filter"`
+	Terms  CreditTermsRequest         `json:"terms"`
+	Reason string                     `json:"reason" binding:"required,max=500"`
+	// List the accounts the update would change and how, without changing them
+	Preview bool `json:"preview"`
+}
+
+// CreditAccountFilterRequest selects the credit accounts that match every field it sets; an empty
+// filter selects every account of the establishment
+type CreditAccountFilterRequest struct {
+	CreditAccountIDs []uint                     `json:"credit_account_ids" binding:"omitempty,max=1000"`
+	CreditTemplateID *uint                      `json:"credit_template_id"` // Accounts opened with the template
+	CreditType       *enums.CreditType          `json:"credit_type" binding:"omitempty,oneof=SHORT_TERM LONG_TERM"`
+	InterestType     *enums.InterestType        `json:"interest_type" binding:"omitempty,oneof=NOMINAL EFFECTIVE"`
+	Status           *enums.CreditAccountStatus `json:"status" binding:"omitempty,oneof=ACTIVE CLOSED"`
+	// Accounts of clients with every one of the tags
+	Tags []string `json:"tags" binding:"omitempty,max=10,dive,max=50"`
+}
+
+// CreditTermsRequest holds the terms a bulk update sets; terms left out or null are kept. The credit
+// type isn't among them, as changing it changes how the balance is repaid
+type CreditTermsRequest struct {
+	CreditLimit         *float64                 `json:"credit_limit" binding:"omitempty,gt=0"`
+	MonthlyDueDate      *int                     `json:"monthly_due_date" binding:"omitempty,min=1,max=31"`
+	InterestRate        *float64                 `json:"interest_rate" binding:"omitempty,gt=0.0"`
+	InterestType        *enums.InterestType      `json:"interest_type" binding:"omitempty,oneof=NOMINAL EFFECTIVE"`
+	CompoundingPeriod   *enums.CompoundingPeriod `json:"compounding_period" binding:"omitempty,oneof=DAILY MONTHLY QUARTERLY"`
+	GracePeriod         *int                     `json:"grace_period" binding:"omitempty,min=0"`
+	LateFeePercentage   *float64                 `json:"late_fee_percentage" binding:"omitempty,min=0"`
+	SpendingLimit       *float64                 `json:"spending_limit" binding:"omitempty,min=0"`
+	SpendingLimitPeriod *enums.SpendingPeriod    `json:"spending_limit_period" binding:"omitempty,oneof=WEEKLY MONTHLY"`
+}
+
+// Empty reports whether the request sets no term.
+func (r CreditTermsRequest) Empty() bool {
+	return r.CreditLimit == nil && r.MonthlyDueDate == nil && r.InterestRate == nil && r.InterestType == nil &&
+		r.CompoundingPeriod == nil && r.GracePeriod == nil && r.LateFeePercentage == nil &&
+		r.SpendingLimit == nil && r.SpendingLimitPeriod == nil
+}
@@ -0,0 +1,6 @@
+package request
+
+// CategoryRequest names a product category of an establishment.
+type CategoryRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
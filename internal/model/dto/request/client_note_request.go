@@ -0,0 +1,6 @@
+package request
+
+// ClientNoteRequest is the text of a note about a client.
+type ClientNoteRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
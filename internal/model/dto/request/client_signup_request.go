@@ -0,0 +1,32 @@
+package request
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+)
+
+// ClientSignupRequest represents a client registering themselves with an establishment's invite code.
+type ClientSignupRequest struct {
+	DNI      string `json:"dni" binding:"required,min=8,max=8"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	Name     string `json:"name" binding:"required"`
+	Address  string `json:"address" binding:"required,min=5"`
+	Phone    string `json:"phone" binding:"required,min=9,max=9"`
+}
+
+// ApproveClientSignupRequest holds the terms of the credit account opened when an admin approves a client signup.
+type ApproveClientSignupRequest struct {
+	CreditLimit       float64                 `json:"credit_limit" binding:"required,gt=0"`
+	MonthlyDueDate    int                     `json:"monthly_due_date" binding:"required,min=1,max=31"`
+	InterestRate      float64                 `json:"interest_rate" binding:"omitempty,gt=0.0"` // Optional, defaults to the establishment's default rate
+	InterestType      enums.InterestType      `json:"interest_type" binding:"required"`
+	CompoundingPeriod enums.CompoundingPeriod `json:"compounding_period" binding:"omitempty,oneof=DAILY MONTHLY QUARTERLY"` // Optional, defaults to MONTHLY
+	CreditType        enums.CreditType        `json:"credit_type" binding:"required"`
+	GracePeriod       int                     `json:"grace_period" binding:"omitempty,min=0"`
+	LateFeePercentage float64                 `json:"late_fee_percentage" binding:"omitempty"`
+}
+
+// RejectClientSignupRequest holds the reason an admin rejects a client signup.
+type RejectClientSignupRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,11 @@
+package request
+
+// ClientTagRequest names a client tag of an establishment.
+type ClientTagRequest struct {
+	Name string `json:"name" binding:"required,max=30"`
+}
+
+// SetClientTagsRequest holds the tags a client has in the establishment, replacing those they had.
+type SetClientTagsRequest struct {
+	TagIDs []uint `json:"tag_ids" binding:"max=20"` // Empty to untag the client
+}
@@ -0,0 +1,7 @@
+package request
+
+// CloseCreditAccountRequest closes a credit account, paying it off first when it still owes.
+type CloseCreditAccountRequest struct {
+	Reason       string  `json:"reason" binding:"max=500"`
+	PayoffAmount float64 `json:"payoff_amount" binding:"gte=0"` // payoff_amount of a current quote, to settle and close an account that still owes
+}
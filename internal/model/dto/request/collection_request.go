@@ -0,0 +1,14 @@
+package request
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// LogCollectionContactRequest records a call, visit or message to an overdue client and its outcome
+type LogCollectionContactRequest struct {
+	Type        enums.CollectionContactType `json:"type" binding:"required,oneof=CALL VISIT MESSAGE"`
+	Outcome     enums.CollectionOutcome     `json:"outcome" binding:"required,oneof=REACHED NO_ANSWER PROMISED_TO_PAY REFUSED WRONG_CONTACT"`
+	Notes       string                      `json:"notes" binding:"max=1000"`
+	ContactedAt *time.Time                  `json:"contacted_at"` // RFC 3339, defaults to now
+}
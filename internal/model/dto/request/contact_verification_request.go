@@ -0,0 +1,6 @@
+package request
+
+// VerifyContactRequest confirms an email or phone with the code sent to it.
+type VerifyContactRequest struct {
+	Code string `json:"code" binding:"required"`
+}
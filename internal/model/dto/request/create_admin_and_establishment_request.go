@@ -9,9 +9,10 @@ type CreateAdminAndEstablishmentRequest struct {
 	Address  string `json:"address" binding:"required,min=5"`
 	Phone    string `json:"phone" binding:"required,min=9,max=9"`
 	// Establishment fields
-	EstablishmentRUC     string  `json:"establishment_ruc" binding:"required"`
-	EstablishmentName    string  `json:"establishment_name" binding:"required"`
-	EstablishmentPhone   string  `json:"establishment_phone" binding:"required"`
-	EstablishmentAddress string  `json:"establishment_address" binding:"required"`
-	LateFeePercentage    float64 `json:"late_fee_percentage" binding:"omitempty"` // Optional, can be set later
+	EstablishmentRUC      string  `json:"establishment_ruc" binding:"required"`
+	EstablishmentName     string  `json:"establishment_name" binding:"required"`
+	EstablishmentPhone    string  `json:"establishment_phone" binding:"required"`
+	EstablishmentAddress  string  `json:"establishment_address" binding:"required"`
+	LateFeePercentage     float64 `json:"late_fee_percentage" binding:"omitempty"`    // Optional, can be set later
+	EstablishmentTimezone string  `json:"establishment_timezone" binding:"omitempty"` // Optional, IANA name, defaults to America/Lima
 }
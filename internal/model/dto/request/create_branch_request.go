@@ -0,0 +1,12 @@
+package request
+
+// CreateBranchRequest holds the data of a new branch. Branches share the RUC of their main establishment.
+type CreateBranchRequest struct {
+	Name                           string  `json:"name" binding:"required"`
+	Phone                          string  `json:"phone" binding:"required"`
+	Address                        string  `json:"address" binding:"required"`
+	ImageUrl                       string  `json:"image_url" binding:"omitempty"`
+	LateFeePercentage              float64 `json:"late_fee_percentage" binding:"omitempty"`                             // Optional, defaults to the main establishment's
+	Timezone                       string  `json:"timezone" binding:"omitempty"`                                        // Optional IANA name, defaults to the main establishment's
+	EarlyPaymentDiscountPercentage float64 `json:"early_payment_discount_percentage" binding:"omitempty,min=0,max=100"` // Optional
+}
@@ -0,0 +1,6 @@
+package request
+
+// CreateCashSaleRequest holds the products of a sale paid in cash at the establishment
+type CreateCashSaleRequest struct {
+	Items []PurchaseItemRequest `json:"items" binding:"required,min=1,dive"`
+}
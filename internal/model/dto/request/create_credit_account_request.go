@@ -4,12 +4,15 @@ import (
 	"ApiRestFinance/internal/model/entities/enums"
 )
 
+// CreateCreditAccountRequest opens a credit account. With a credit template, the terms it omits are taken from the template.
 type CreateCreditAccountRequest struct {
-	ClientID       uint               `json:"client_id" binding:"required"`
-	CreditLimit    float64            `json:"credit_limit" binding:"required,gt=0.0"`
-	MonthlyDueDate int                `json:"monthly_due_date" binding:"required,min=1,max=31"`
-	InterestRate   float64            `json:"interest_rate" binding:"required,gt=0.0"`
-	InterestType   enums.InterestType `json:"interest_type" binding:"required"`
-	CreditType     enums.CreditType   `json:"credit_type" binding:"required"`
-	GracePeriod    int                `json:"grace_period" binding:"omitempty,min=0"` // Optional, for long-term credit
+	ClientID          uint                    `json:"client_id" binding:"required"`
+	CreditTemplateID  *uint                   `json:"credit_template_id"` // Optional, template of the establishment to take the terms from
+	CreditLimit       float64                 `json:"credit_limit" binding:"required_without=CreditTemplateID,omitempty,gt=0.0"`
+	MonthlyDueDate    int                     `json:"monthly_due_date" binding:"required_without=CreditTemplateID,omitempty,min=1,max=31"`
+	InterestRate      float64                 `json:"interest_rate" binding:"omitempty,gt=0.0"` // Optional, defaults to the establishment's default rate
+	InterestType      enums.InterestType      `json:"interest_type" binding:"required_without=CreditTemplateID"`
+	CompoundingPeriod enums.CompoundingPeriod `json:"compounding_period" binding:"omitempty,oneof=DAILY MONTHLY QUARTERLY"` // Optional, defaults to MONTHLY
+	CreditType        enums.CreditType        `json:"credit_type" binding:"required_without=CreditTemplateID"`
+	GracePeriod       int                     `json:"grace_period" binding:"omitempty,min=0"` // Optional, for long-term credit
 }
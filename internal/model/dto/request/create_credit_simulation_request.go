@@ -0,0 +1,16 @@
+package request
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+)
+
+type CreateCreditSimulationRequest struct {
+	Principal            float64                 `json:"principal" binding:"required,gt=0.0"`
+	InterestRate         float64                 `json:"interest_rate" binding:"required,gt=0.0"` // Annual interest rate (%)
+	InterestType         enums.InterestType      `json:"interest_type" binding:"required"`
+	CompoundingPeriod    enums.CompoundingPeriod `json:"compounding_period" binding:"omitempty,oneof=DAILY MONTHLY QUARTERLY"` // Optional, defaults to MONTHLY
+	CreditType           enums.CreditType        `json:"credit_type" binding:"required"`
+	NumberOfInstallments int                     `json:"number_of_installments" binding:"required,min=1,max=360"`
+	MonthlyDueDate       int                     `json:"monthly_due_date" binding:"omitempty,min=1,max=31"` // Optional, defaults to today's day of the month
+	GracePeriod          int                     `json:"grace_period" binding:"omitempty,min=0"`            // Optional, interest-free months before the first installment (for long-term credit)
+}
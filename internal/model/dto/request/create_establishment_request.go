@@ -1,10 +1,12 @@
 package request
 
 type CreateEstablishmentRequest struct {
-	RUC               string  `json:"ruc" binding:"required"`
-	Name              string  `json:"name" binding:"required"`
-	Phone             string  `json:"phone" binding:"required"`
-	Address           string  `json:"address" binding:"required"`
-	ImageUrl          string  `json:"image_url" binding:"omitempty"`
-	LateFeePercentage float64 `json:"late_fee_percentage" binding:"omitempty"`
+	RUC                            string  `json:"ruc" binding:"required"`
+	Name                           string  `json:"name" binding:"required"`
+	Phone                          string  `json:"phone" binding:"required"`
+	Address                        string  `json:"address" binding:"required"`
+	ImageUrl                       string  `json:"image_url" binding:"omitempty"`
+	LateFeePercentage              float64 `json:"late_fee_percentage" binding:"omitempty"`
+	Timezone                       string  `json:"timezone" binding:"omitempty"` // IANA name, defaults to America/Lima
+	EarlyPaymentDiscountPercentage float64 `json:"early_payment_discount_percentage" binding:"omitempty,min=0,max=100"`
 }
@@ -3,7 +3,9 @@ package request
 type CreateProductRequest struct {
 	EstablishmentID uint    `json:"establishment_id" binding:"required"`
 	Name            string  `json:"name" binding:"required"`
-	Category        string  `json:"category" binding:"required"`
+	SKU             string  `json:"sku" binding:"omitempty,max=64"`
+	Barcode         string  `json:"barcode" binding:"omitempty"` // EAN-13
+	CategoryID      uint    `json:"category_id" binding:"required"`
 	Description     string  `json:"description" binding:"required"`
 	Price           float64 `json:"price" binding:"required,gt=0.0"`
 	Stock           int     `json:"stock" binding:"required,gte=0"`
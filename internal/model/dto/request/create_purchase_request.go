@@ -4,8 +4,41 @@ import "ApiRestFinance/internal/model/entities/enums"
 
 // CreatePurchaseRequest holds the data to create a purchase
 type CreatePurchaseRequest struct {
-	EstablishmentID uint             `json:"establishment_id" binding:"required"`
-	ProductIDs      []uint           `json:"product_ids" binding:"required"`
-	CreditType      enums.CreditType `json:"credit_type" binding:"required"`
-	Amount          float64          `json:"amount" binding:"required"`
+	EstablishmentID uint                  `json:"establishment_id" binding:"required"`
+	ProductIDs      []uint                `json:"product_ids" binding:"required_without=Items"` // One unit of each product
+	Items           []PurchaseItemRequest `json:"items" binding:"omitempty,dive"`               // Optional, products bought in quantity
+	CreditType      enums.CreditType      `json:"credit_type" binding:"required"`
+	Amount          float64               `json:"amount" binding:"required"`
+}
+
+// PurchaseQuoteRequest holds the products of a credit purchase to quote before making it
+type PurchaseQuoteRequest struct {
+	EstablishmentID uint                  `json:"establishment_id" binding:"required"`
+	ProductIDs      []uint                `json:"product_ids" binding:"required_without=Items"` // One unit of each product
+	Items           []PurchaseItemRequest `json:"items" binding:"omitempty,dive"`               // Optional, products bought in quantity
+	CreditType      enums.CreditType      `json:"credit_type" binding:"required"`
+}
+
+// PurchaseItemRequest is a product line of a purchase or sale
+type PurchaseItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	Quantity  int  `json:"quantity" binding:"required,min=1"`
+}
+
+// LineItems returns every product of the purchase as a line item, counting each of ProductIDs once.
+func (r CreatePurchaseRequest) LineItems() []PurchaseItemRequest {
+	return lineItems(r.ProductIDs, r.Items)
+}
+
+// LineItems returns every product of the quote as a line item, counting each of ProductIDs once.
+func (r PurchaseQuoteRequest) LineItems() []PurchaseItemRequest {
+	return lineItems(r.ProductIDs, r.Items)
+}
+
+func lineItems(productIDs []uint, lines []PurchaseItemRequest) []PurchaseItemRequest {
+	items := make([]PurchaseItemRequest, 0, len(productIDs)+len(lines))
+	for _, productID := range productIDs {
+		items = append(items, PurchaseItemRequest{ProductID: productID, Quantity: 1})
+	}
+	return append(items, lines...)
 }
@@ -10,4 +10,5 @@ type CreateTransactionRequest struct {
 	Amount          float64               `json:"amount" binding:"required,gt=0.0"`
 	Description     string                `json:"description" binding:"omitempty"`
 	PaymentMethod   enums.PaymentMethod   `json:"payment_method" binding:"required"` // Add PaymentMethod
+	Pin             string                `json:"pin"`                               // Client's purchase PIN, for purchases above the PIN threshold of the establishment
 }
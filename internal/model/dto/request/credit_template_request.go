@@ -0,0 +1,15 @@
+package request
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// CreditTemplateRequest sets the name and credit terms of a credit template.
+type CreditTemplateRequest struct {
+	Name              string                  `json:"name" binding:"required,max=50"`
+	CreditLimit       float64                 `json:"credit_limit" binding:"required,gt=0.0"`
+	MonthlyDueDate    int                     `json:"monthly_due_date" binding:"required,min=1,max=31"`
+	InterestRate      float64                 `json:"interest_rate" binding:"omitempty,gt=0.0"` // Optional, accounts get the establishment's default rate without one
+	InterestType      enums.InterestType      `json:"interest_type" binding:"required,oneof=NOMINAL EFFECTIVE"`
+	CompoundingPeriod enums.CompoundingPeriod `json:"compounding_period" binding:"omitempty,oneof=DAILY MONTHLY QUARTERLY"` // Optional, defaults to MONTHLY
+	CreditType        enums.CreditType        `json:"credit_type" binding:"required,oneof=SHORT_TERM LONG_TERM"`
+	GracePeriod       int                     `json:"grace_period" binding:"omitempty,min=0"` // Optional, for long-term credit
+}
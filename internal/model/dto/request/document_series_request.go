@@ -0,0 +1,17 @@
+package request
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// CreateDocumentSeriesRequest adds a fiscal document series to an establishment.
+type CreateDocumentSeriesRequest struct {
+	DocumentType enums.DocumentType `json:"document_type" binding:"required,oneof=RECEIPT INVOICE"`
+	Code         string             `json:"code" binding:"required,len=4"`         // B or F and three letters or digits, e.g. B001
+	NextNumber   *int64             `json:"next_number" binding:"omitempty,min=1"` // Number of the first document, 1 if omitted
+	Active       bool               `json:"active"`                                // Number new transactions from this series, instead of the active one
+}
+
+// UpdateDocumentSeriesRequest changes a fiscal document series. Omitted fields are left unchanged.
+type UpdateDocumentSeriesRequest struct {
+	NextNumber *int64 `json:"next_number" binding:"omitempty,min=1"` // Can't go back to numbers already issued
+	Active     *bool  `json:"active"`
+}
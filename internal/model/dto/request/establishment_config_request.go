@@ -0,0 +1,33 @@
+package request
+
+// ImportEstablishmentConfigRequest imports the configuration exported from an establishment into another.
+type ImportEstablishmentConfigRequest struct {
+	Config     EstablishmentConfigRequest `json:"config" binding:"required"`
+	OnConflict string                     `json:"on_conflict" binding:"omitempty,oneof=SKIP OVERWRITE FAIL"` // For products that exist and differ, SKIP if omitted
+	DryRun     bool                       `json:"dry_run"`                                                   // Only report what the import would do
+}
+
+// EstablishmentConfigRequest is the configuration GET /establishments/me/export exports. Omitted sections are left unchanged.
+type EstablishmentConfigRequest struct {
+	Settings   *UpdateEstablishmentSettingsRequest `json:"settings"` // The adjustment approver isn't imported, it is an admin of the exporting establishment
+	Categories []ConfigCategoryRequest             `json:"categories" binding:"max=500,dive"`
+	Products   []ConfigProductRequest              `json:"products" binding:"max=5000,dive"`
+}
+
+// ConfigCategoryRequest is a product category of an imported configuration, matched by name, ignoring case.
+type ConfigCategoryRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+// ConfigProductRequest is a product of an imported configuration. It is matched with the products of the
+// establishment by SKU, or by barcode without one, or by name, ignoring case, without either.
+type ConfigProductRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	SKU         string  `json:"sku" binding:"omitempty,max=64"`
+	Barcode     string  `json:"barcode" binding:"omitempty"` // EAN-13
+	Category    string  `json:"category" binding:"required"` // Name of a category of the configuration or the establishment
+	Description string  `json:"description"`
+	Price       float64 `json:"price" binding:"required,gt=0.0"`
+	ImageUrl    string  `json:"image_url"`
+	IsActive    bool    `json:"is_active"`
+}
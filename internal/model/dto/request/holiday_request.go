@@ -0,0 +1,7 @@
+package request
+
+// HolidayRequest is a holiday of the calendar of an establishment.
+type HolidayRequest struct {
+	Date string `json:"date" binding:"required"` // YYYY-MM-DD
+	Name string `json:"name" binding:"required,max=100"`
+}
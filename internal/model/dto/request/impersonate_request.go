@@ -0,0 +1,6 @@
+package request
+
+// ImpersonateClientRequest explains why an admin needs to see the API as one of their clients.
+type ImpersonateClientRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
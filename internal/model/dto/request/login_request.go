@@ -1,6 +1,7 @@
 package request
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required"`
+	UseCookie bool   `json:"use_cookie"` // Optional, also store the tokens in HttpOnly cookies (browser clients)
 }
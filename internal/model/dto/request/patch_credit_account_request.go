@@ -0,0 +1,25 @@
+package request
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+)
+
+// PatchCreditAccountRequest changes the fields of a credit account it sets; fields left out or null
+// are kept. Unlike UpdateCreditAccountRequest, zero and false values are set too, e.g.
+// "grace_period": 0 or "is_blocked": false.
+type PatchCreditAccountRequest struct {
+	CreditLimit       *float64                 `json:"credit_limit" binding:"omitempty,gt=0"`
+	MonthlyDueDate    *int                     `json:"monthly_due_date" binding:"omitempty,min=1,max=31"`
+	InterestRate      *float64                 `json:"interest_rate" binding:"omitempty,gt=0.0"`
+	InterestType      *enums.InterestType      `json:"interest_type" binding:"omitempty,oneof=NOMINAL EFFECTIVE"`
+	CompoundingPeriod *enums.CompoundingPeriod `json:"compounding_period" binding:"omitempty,oneof=DAILY MONTHLY QUARTERLY"`
+	CreditType        *enums.CreditType        `json:"credit_type" binding:"omitempty,oneof=SHORT_TERM LONG_TERM"`
+	GracePeriod       *int                     `json:"grace_period" binding:"omitempty,min=0"`
+	IsBlocked         *bool                    `json:"is_blocked"`
+	LateFeePercentage *float64                 `json:"late_fee_percentage" binding:"omitempty,min=0"`
+	// Most the client may spend per period, on top of the credit limit. 0 removes the limit
+	SpendingLimit       *float64              `json:"spending_limit" binding:"omitempty,min=0"`
+	SpendingLimitPeriod *enums.SpendingPeriod `json:"spending_limit_period" binding:"omitempty,oneof=WEEKLY MONTHLY"`
+	// Version of the account the change is based on, as last read. Required unless sent in If-Match
+	Version *uint `json:"version"`
+}
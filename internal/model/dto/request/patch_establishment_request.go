@@ -0,0 +1,17 @@
+package request
+
+// PatchEstablishmentRequest changes the fields of an establishment it sets; fields left out or null
+// are kept, so unlike UpdateEstablishmentRequest it doesn't need the ones that don't change.
+type PatchEstablishmentRequest struct {
+	RUC                            *string  `json:"ruc" binding:"omitempty,min=1"` // Ignored for branches
+	Name                           *string  `json:"name" binding:"omitempty,min=1"`
+	Phone                          *string  `json:"phone" binding:"omitempty,min=1"`
+	Address                        *string  `json:"address" binding:"omitempty,min=1"`
+	ImageUrl                       *string  `json:"image_url"`
+	IsActive                       *bool    `json:"is_active"`
+	LateFeePercentage              *float64 `json:"late_fee_percentage" binding:"omitempty,min=0"`
+	Timezone                       *string  `json:"timezone" binding:"omitempty,min=1"` // IANA name
+	EarlyPaymentDiscountPercentage *float64 `json:"early_payment_discount_percentage" binding:"omitempty,min=0,max=100"`
+	// Version of the establishment the change is based on, as last read. Required unless sent in If-Match
+	Version *uint `json:"version"`
+}
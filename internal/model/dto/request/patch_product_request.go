@@ -0,0 +1,17 @@
+package request
+
+// PatchProductRequest changes the fields of a product it sets; fields left out or null are kept.
+// Unlike UpdateProductRequest, zero values are set too, e.g. "stock": 0.
+type PatchProductRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,min=1"`
+	CategoryID  *uint    `json:"category_id"`
+	SKU         *string  `json:"sku" binding:"omitempty,max=64"` // Empty to remove it
+	Barcode     *string  `json:"barcode"`                        // EAN-13. Empty to remove it
+	Description *string  `json:"description"`
+	Price       *float64 `json:"price" binding:"omitempty,gt=0.0"`
+	Stock       *int     `json:"stock" binding:"omitempty,gte=0"`
+	ImageUrl    *string  `json:"image_url"`
+	IsActive    *bool    `json:"is_active"`
+	// Version of the product the change is based on, as last read. Required unless sent in If-Match
+	Version *uint `json:"version"`
+}
@@ -0,0 +1,9 @@
+package request
+
+// PatchUserRequest changes the fields of a user it sets; fields left out or null are kept.
+type PatchUserRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1"`
+	Address  *string `json:"address"`
+	Phone    *string `json:"phone"`
+	PhotoUrl *string `json:"photo_url"`
+}
@@ -0,0 +1,15 @@
+package request
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// CreatePaymentLinkRequest holds what a payment link asks the client to pay
+type CreatePaymentLinkRequest struct {
+	InstallmentID  *uint   `json:"installment_id"`                                    // Installment to pay, the link asks for its amount
+	Amount         float64 `json:"amount" binding:"omitempty,gt=0"`                   // Amount to pay when no installment is given
+	ExpiresInHours int     `json:"expires_in_hours" binding:"omitempty,min=1,max=72"` // 24 by default
+}
+
+// PayPaymentLinkRequest holds how the client pays through a payment link
+type PayPaymentLinkRequest struct {
+	PaymentMethod enums.PaymentMethod `json:"payment_method" binding:"required,oneof=YAPE PLIN"`
+}
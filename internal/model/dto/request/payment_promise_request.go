@@ -0,0 +1,7 @@
+package request
+
+// CreatePaymentPromiseRequest holds the promise to pay negotiated with a client
+type CreatePaymentPromiseRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Date   string  `json:"date" binding:"required"` // Day the client promises to pay by, ISO-8601, in the establishment's time zone
+}
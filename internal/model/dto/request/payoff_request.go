@@ -0,0 +1,6 @@
+package request
+
+// PayoffRequest confirms the amount of a payoff quote the client wants to pay
+type PayoffRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"` // payoff_amount of the quote
+}
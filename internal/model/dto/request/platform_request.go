@@ -0,0 +1,21 @@
+package request
+
+// SearchPlatformEstablishmentsRequest holds the query parameters of the establishments listed to
+// super-admins. Every filter is optional.
+type SearchPlatformEstablishmentsRequest struct {
+	Query  string `form:"q"` // Name or RUC of the establishment, or name or email of its admin
+	Status string `form:"status" binding:"omitempty,oneof=ACTIVE INACTIVE SUSPENDED"`
+}
+
+// SuspendEstablishmentRequest suspends a main establishment, with its branches.
+type SuspendEstablishmentRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// SearchPlatformAuditLogRequest holds the query parameters of the platform audit log. Every filter is
+// optional.
+type SearchPlatformAuditLogRequest struct {
+	ActorID   uint   `form:"actor_id"`
+	StartDate string `form:"start_date"` // ISO-8601 date or date and time
+	EndDate   string `form:"end_date"`   // ISO-8601, inclusive
+}
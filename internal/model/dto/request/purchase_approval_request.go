@@ -0,0 +1,6 @@
+package request
+
+// RejectPurchaseRequest holds the reason an admin rejects a purchase waiting for approval
+type RejectPurchaseRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// SetPurchasePinRequest sets or resets the PIN a client confirms purchases charged by admins with.
+type SetPurchasePinRequest struct {
+	Password string `json:"password" binding:"required"` // Current password of the client
+	Pin      string `json:"pin" binding:"required"`      // 4 to 6 digits
+}
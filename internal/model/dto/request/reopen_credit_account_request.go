@@ -0,0 +1,6 @@
+package request
+
+// ReopenCreditAccountRequest explains why an admin reopens a closed credit account.
+type ReopenCreditAccountRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// RescheduleInstallmentRequest moves the due date of an installment, as agreed with the client
+type RescheduleInstallmentRequest struct {
+	DueDate string `json:"due_date" binding:"required"` // New due date, ISO-8601, in the establishment's time zone
+	Reason  string `json:"reason" binding:"required"`
+}
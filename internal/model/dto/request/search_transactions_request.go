@@ -0,0 +1,22 @@
+package request
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+)
+
+// SearchTransactionsRequest holds the query parameters of the establishment transaction search.
+// Every filter is optional.
+type SearchTransactionsRequest struct {
+	Client          string                `form:"client"` // Client name, or the beginning of their DNI
+	TransactionType enums.TransactionType `form:"type" binding:"omitempty,oneof=PURCHASE PAYMENT INTEREST LATE_FEE ADJUSTMENT_DEBIT ADJUSTMENT_CREDIT"`
+	PaymentMethod   enums.PaymentMethod   `form:"payment_method" binding:"omitempty,oneof=YAPE PLIN CASH BANK_TRANSFER"`
+	PaymentStatus   enums.PaymentStatus   `form:"status" binding:"omitempty,oneof=PENDING SUCCESS FAILED"`
+	MinAmount       float64               `form:"min_amount" binding:"omitempty,gte=0"`
+	MaxAmount       float64               `form:"max_amount" binding:"omitempty,gtefield=MinAmount"`
+	StartDate       string                `form:"start_date"` // ISO-8601 date or date and time
+	EndDate         string                `form:"end_date"`   // ISO-8601, inclusive
+	Range           string                `form:"range"`      // Named range instead of StartDate and EndDate, see util.NamedDateRange
+	Query           string                `form:"q"`          // Words to look for in the description
+	Sort            string                `form:"sort" binding:"omitempty,oneof=transaction_date amount"`
+	Order           string                `form:"order" binding:"omitempty,oneof=asc desc"`
+}
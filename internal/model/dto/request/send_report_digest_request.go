@@ -0,0 +1,8 @@
+package request
+
+// SendReportDigestRequest asks for the report digest of an establishment over a period, emailed to its admin.
+type SendReportDigestRequest struct {
+	Period   string   `json:"period"`                                                                           // Report period, as in the reports. Defaults to last_week
+	Sections []string `json:"sections" binding:"omitempty,dive,oneof=collections new_debt overdue top_debtors"` // Defaults to those of the establishment settings
+	Preview  bool     `json:"preview"`                                                                          // Only compile the digest, without emailing it
+}
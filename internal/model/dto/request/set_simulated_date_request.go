@@ -0,0 +1,7 @@
+package request
+
+import "time"
+
+type SetSimulatedDateRequest struct {
+	Now time.Time `json:"now" binding:"required"` // RFC 3339 date-time the clock should report
+}
@@ -0,0 +1,12 @@
+package request
+
+// OpenTillSessionRequest holds the cash in the drawer when an admin opens the till
+type OpenTillSessionRequest struct {
+	OpeningCash *float64 `json:"opening_cash" binding:"required,min=0"`
+}
+
+// CloseTillSessionRequest holds the cash an admin counted in the drawer when closing the till
+type CloseTillSessionRequest struct {
+	CountedCash *float64 `json:"counted_cash" binding:"required,min=0"`
+	Notes       string   `json:"notes" binding:"max=500"` // Explanation of the discrepancy, required when the counted cash isn't the expected one
+}
@@ -0,0 +1,19 @@
+package request
+
+// TwoFactorCodeRequest confirms an operation with a code of the user's authenticator app or, where
+// accepted, one of their recovery codes.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// TwoFactorLoginRequest completes a login that needs a second factor.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"` // Returned by the login
+	Code           string `json:"code" binding:"required"`            // Authenticator or recovery code
+	UseCookie      bool   `json:"use_cookie"`                         // Optional, also store the tokens in HttpOnly cookies (browser clients)
+}
+
+// TwoFactorChallengeRequest identifies a login waiting for its second factor.
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// UpdateAccountingAccountsRequest maps accounting events to the accounts of the establishment's chart of
+// accounts. Events left out keep their account.
+type UpdateAccountingAccountsRequest struct {
+	Accounts map[string]string `json:"accounts" binding:"required,min=1,dive,keys,oneof=RECEIVABLES SALES CASH INTEREST_INCOME LATE_FEES WRITE_OFFS,endkeys,required,max=20,alphanum"` // Account code by event, e.g. {"SALES": "7011"}
+}
@@ -5,12 +5,54 @@ import (
 )
 
 type UpdateCreditAccountRequest struct {
-	CreditLimit       float64            `json:"credit_limit" binding:"omitempty,gt=0"`
-	MonthlyDueDate    int                `json:"monthly_due_date" binding:"omitempty,min=1,max=31"`
-	InterestRate      float64            `json:"interest_rate" binding:"omitempty,gt=0.0"`
-	InterestType      enums.InterestType `json:"interest_type" binding:"omitempty"`
-	CreditType        enums.CreditType   `json:"credit_type" binding:"omitempty"`
-	GracePeriod       int                `json:"grace_period" binding:"omitempty,min=0"`
-	IsBlocked         bool               `json:"is_blocked"`
-	LateFeePercentage float64            `json:"late_fee_percentage" binding:"omitempty"`
+	CreditLimit       float64                 `json:"credit_limit" binding:"omitempty,gt=0"`
+	MonthlyDueDate    int                     `json:"monthly_due_date" binding:"omitempty,min=1,max=31"`
+	InterestRate      float64                 `json:"interest_rate" binding:"omitempty,gt=0.0"`
+	InterestType      enums.InterestType      `json:"interest_type" binding:"omitempty"`
+	CompoundingPeriod enums.CompoundingPeriod `json:"compounding_period" binding:"omitempty,oneof=DAILY MONTHLY QUARTERLY"`
+	CreditType        enums.CreditType        `json:"credit_type" binding:"omitempty"`
+	GracePeriod       int                     `json:"grace_period" binding:"omitempty,min=0"`
+	IsBlocked         bool                    `json:"is_blocked"`
+	LateFeePercentage float64                 `json:"late_fee_percentage" binding:"omitempty"`
+	// Most the client may spend per period, on top of the credit limit. 0 removes the limit
+	SpendingLimit       *float64             `json:"spending_limit" binding:"omitempty,min=0"`
+	SpendingLimitPeriod enums.SpendingPeriod `json:"spending_limit_period" binding:"omitempty,oneof=WEEKLY MONTHLY"`
+	// Version of the account the change is based on, as last read. Required unless sent in If-Match
+	Version *uint `json:"version"`
+}
+
+// Patch returns the changes of the update, which keeps the fields left at 0 or blank, except for
+// the grace period and block, always set, and a negative late fee, which is kept.
+func (r UpdateCreditAccountRequest) Patch() PatchCreditAccountRequest {
+	patch := PatchCreditAccountRequest{
+		GracePeriod:   &r.GracePeriod,
+		IsBlocked:     &r.IsBlocked,
+		SpendingLimit: r.SpendingLimit,
+		Version:       r.Version,
+	}
+	if r.CreditLimit > 0 {
+		patch.CreditLimit = &r.CreditLimit
+	}
+	if r.MonthlyDueDate > 0 {
+		patch.MonthlyDueDate = &r.MonthlyDueDate
+	}
+	if r.InterestRate > 0 {
+		patch.InterestRate = &r.InterestRate
+	}
+	if r.InterestType != "" {
+		patch.InterestType = &r.InterestType
+	}
+	if r.CompoundingPeriod != "" {
+		patch.CompoundingPeriod = &r.CompoundingPeriod
+	}
+	if r.CreditType != "" {
+		patch.CreditType = &r.CreditType
+	}
+	if r.LateFeePercentage >= 0 {
+		patch.LateFeePercentage = &r.LateFeePercentage
+	}
+	if r.SpendingLimitPeriod != "" {
+		patch.SpendingLimitPeriod = &r.SpendingLimitPeriod
+	}
+	return patch
 }
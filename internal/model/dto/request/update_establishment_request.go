@@ -1,11 +1,34 @@
 package request
 
 type UpdateEstablishmentRequest struct {
-	RUC               string  `json:"ruc" binding:"required"`
-	Name              string  `json:"name" binding:"required"`
-	Phone             string  `json:"phone" binding:"required"`
-	Address           string  `json:"address" binding:"required"`
-	ImageUrl          string  `json:"image_url" binding:"omitempty"`
-	IsActive          bool    `json:"is_active"`
-	LateFeePercentage float64 `json:"late_fee_percentage" binding:"omitempty"` // Optional
+	RUC                            string  `json:"ruc" binding:"required"`
+	Name                           string  `json:"name" binding:"required"`
+	Phone                          string  `json:"phone" binding:"required"`
+	Address                        string  `json:"address" binding:"required"`
+	ImageUrl                       string  `json:"image_url" binding:"omitempty"`
+	IsActive                       bool    `json:"is_active"`
+	LateFeePercentage              float64 `json:"late_fee_percentage" binding:"omitempty"`                             // Optional
+	Timezone                       string  `json:"timezone" binding:"omitempty"`                                        // Optional, IANA name
+	EarlyPaymentDiscountPercentage float64 `json:"early_payment_discount_percentage" binding:"omitempty,min=0,max=100"` // Optional
+	// Version of the establishment the change is based on, as last read. Required unless sent in If-Match
+	Version *uint `json:"version"`
+}
+
+// Patch returns the changes of the update, which sets every field but a blank time zone.
+func (r UpdateEstablishmentRequest) Patch() PatchEstablishmentRequest {
+	patch := PatchEstablishmentRequest{
+		RUC:                            &r.RUC,
+		Name:                           &r.Name,
+		Phone:                          &r.Phone,
+		Address:                        &r.Address,
+		ImageUrl:                       &r.ImageUrl,
+		IsActive:                       &r.IsActive,
+		LateFeePercentage:              &r.LateFeePercentage,
+		EarlyPaymentDiscountPercentage: &r.EarlyPaymentDiscountPercentage,
+		Version:                        r.Version,
+	}
+	if r.Timezone != "" {
+		patch.Timezone = &r.Timezone
+	}
+	return patch
 }
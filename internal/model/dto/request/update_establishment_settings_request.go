@@ -0,0 +1,39 @@
+package request
+
+// UpdateEstablishmentSettingsRequest changes the business rules of an establishment. Omitted fields are left unchanged.
+type UpdateEstablishmentSettingsRequest struct {
+	MaxInstallments       *int     `json:"max_installments" binding:"omitempty,min=1,max=60"`
+	DefaultInterestRate   *float64 `json:"default_interest_rate" binding:"omitempty,min=0"`         // Annual rate (%), 0 to require a rate on every new account
+	AutoBlockDaysOverdue  *int     `json:"auto_block_days_overdue" binding:"omitempty,min=0"`       // 0 to never block automatically
+	ReminderDaysBefore    *int     `json:"reminder_days_before" binding:"omitempty,min=0,max=28"`   // Only remind that many days before, 0 for no reminders. Ignored with reminder_schedule
+	HighRiskScore         *int     `json:"high_risk_score" binding:"omitempty,min=0,max=1000"`      // Clients scoring below are flagged high risk
+	HighRiskMaxPurchase   *float64 `json:"high_risk_max_purchase" binding:"omitempty,min=0"`        // 0 to let high-risk clients buy up to their credit limit
+	RequireAdminTwoFactor *bool    `json:"require_admin_two_factor"`                                // Admins without two-factor authentication must set it up at their next login
+	TaxRate               *float64 `json:"tax_rate" binding:"omitempty,gt=0,max=100"`               // IGV rate (%). Exempt sales are not supported
+	PricesExcludeTax      *bool    `json:"prices_exclude_tax"`                                      // Product prices are before tax, which is added when they are sold
+	ApprovalThreshold     *float64 `json:"approval_threshold" binding:"omitempty,min=0"`            // Client purchases above it wait for an admin's approval, 0 to approve none
+	ApprovalExpiryDays    *int     `json:"approval_expiry_days" binding:"omitempty,min=1,max=30"`   // Days purchases wait for approval before they expire
+	PinThreshold          *float64 `json:"pin_threshold" binding:"omitempty,min=0"`                 // Purchases admins charge above it need the client's purchase PIN, 0 to never ask
+	PaymentCodeMinutes    *int     `json:"payment_code_minutes" binding:"omitempty,min=5,max=1440"` // Minutes non-cash payments wait for their confirmation code before they expire
+	MaxReschedules        *int     `json:"max_reschedules" binding:"omitempty,min=0,max=24"`        // Installment reschedules a credit account may have, 0 to allow none
+	RescheduleInterest    *bool    `json:"reschedule_interest"`                                     // Charge postponed installments the account's interest for the extra days
+	AdjustmentThreshold   *float64 `json:"adjustment_threshold" binding:"omitempty,min=0"`          // Manual adjustments above it need the approver's approval, 0 to approve none
+	AdjustmentApproverID  *uint    `json:"adjustment_approver_id"`                                  // Admin, other than the establishment's, who approves adjustments above the threshold, 0 for none
+	Language              *string  `json:"language" binding:"omitempty,oneof=es en"`                // Of emails, PDFs and API messages for requests without an Accept-Language header
+	SMSNotifications      *bool    `json:"sms_notifications"`                                       // Also text payment reminders, confirmations, overdue notices, payment links and confirmation codes to verified phones
+	SMSSender             *string  `json:"sms_sender" binding:"omitempty,max=16"`                   // Number (E.164) or alphanumeric sender ID texts come from, empty for the API's
+
+	// Late fees charged on overdue accounts. FLAT and DAILY charge the establishment's late fee percentage of the
+	// overdue amount, once per billing cycle or for every day overdue, FIXED charges LateFeeAmount once per cycle
+	LateFeeMode   *string  `json:"late_fee_mode" binding:"omitempty,oneof=FLAT DAILY FIXED"`
+	LateFeeAmount *float64 `json:"late_fee_amount" binding:"omitempty,min=0"`
+	LateFeeCap    *float64 `json:"late_fee_cap" binding:"omitempty,min=0"` // Most a billing cycle is charged in late fees, as a percentage of its overdue amount, 0 for no cap
+
+	// Days from their due date clients are sent payment reminders on, before it or on it, and overdue notices, after
+	// it, e.g. [-7, -3, -1, 0, 3]. Empty to send none
+	ReminderSchedule []int `json:"reminder_schedule" binding:"omitempty,max=10,dive,min=-28,max=28"`
+
+	// Report digest emailed to the admin
+	DigestFrequency *string  `json:"digest_frequency" binding:"omitempty,oneof=OFF WEEKLY MONTHLY"`
+	DigestSections  []string `json:"digest_sections" binding:"omitempty,dive,oneof=collections new_debt overdue top_debtors"` // Empty for all of them
+}
@@ -9,5 +9,7 @@ import (
 type UpdateInstallmentRequest struct {
 	DueDate time.Time               `json:"due_date" binding:"omitempty"`
 	Amount  float64                 `json:"amount" binding:"omitempty,gt=0.0"`
-	Status  enums.InstallmentStatus `json:"status" binding:"omitempty"`
+	Status  enums.InstallmentStatus `json:"status" binding:"omitempty,oneof=PENDING PARTIALLY_PAID PAID OVERDUE CANCELLED"` // Only CANCELLED can be set by hand
+	// Version of the installment the change is based on, as last read. Required unless sent in If-Match
+	Version *uint `json:"version"`
 }
@@ -1,13 +1,41 @@
 package request
 
-import "ApiRestFinance/internal/model/entities/enums"
-
 type UpdateProductRequest struct {
-	Name        string                `json:"name" binding:"omitempty"`
-	Category    enums.ProductCategory `json:"category" binding:"required"`
-	Description string                `json:"description" binding:"omitempty"`
-	Price       float64               `json:"price" binding:"omitempty,gt=0.0"`
-	Stock       int                   `json:"stock" binding:"omitempty,gte=0"`
-	ImageUrl    string                `json:"image_url" binding:"omitempty"`
-	IsActive    bool                  `json:"is_active"`
+	Name        string  `json:"name" binding:"omitempty"`
+	CategoryID  *uint   `json:"category_id"`
+	SKU         *string `json:"sku" binding:"omitempty,max=64"` // Empty to remove it
+	Barcode     *string `json:"barcode"`                        // EAN-13. Empty to remove it
+	Description string  `json:"description" binding:"omitempty"`
+	Price       float64 `json:"price" binding:"omitempty,gt=0.0"`
+	Stock       int     `json:"stock" binding:"omitempty,gte=0"`
+	ImageUrl    string  `json:"image_url" binding:"omitempty"`
+	IsActive    bool    `json:"is_active"`
+	// Version of the product the change is based on, as last read. Required unless sent in If-Match
+	Version *uint `json:"version"`
+}
+
+// Patch returns the changes of the update, which keeps the name, description and image left blank
+// and the price left at 0.
+func (r UpdateProductRequest) Patch() PatchProductRequest {
+	patch := PatchProductRequest{
+		CategoryID: r.CategoryID,
+		SKU:        r.SKU,
+		Barcode:    r.Barcode,
+		Stock:      &r.Stock,
+		IsActive:   &r.IsActive,
+		Version:    r.Version,
+	}
+	if r.Name != "" {
+		patch.Name = &r.Name
+	}
+	if r.Description != "" {
+		patch.Description = &r.Description
+	}
+	if r.Price > 0 {
+		patch.Price = &r.Price
+	}
+	if r.ImageUrl != "" {
+		patch.ImageUrl = &r.ImageUrl
+	}
+	return patch
 }
@@ -0,0 +1,6 @@
+package request
+
+// UpdateStatementEmailsRequest turns the monthly statement emails on or off.
+type UpdateStatementEmailsRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
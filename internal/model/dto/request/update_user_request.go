@@ -6,3 +6,21 @@ type UpdateUserRequest struct {
 	Phone    string `json:"phone" binding:"omitempty"`     // Optional
 	PhotoUrl string `json:"photo_url" binding:"omitempty"` // Optional
 }
+
+// Patch returns the changes of the update, which keeps the fields left blank.
+func (r UpdateUserRequest) Patch() PatchUserRequest {
+	var patch PatchUserRequest
+	if r.Name != "" {
+		patch.Name = &r.Name
+	}
+	if r.Address != "" {
+		patch.Address = &r.Address
+	}
+	if r.Phone != "" {
+		patch.Phone = &r.Phone
+	}
+	if r.PhotoUrl != "" {
+		patch.PhotoUrl = &r.PhotoUrl
+	}
+	return patch
+}
@@ -0,0 +1,8 @@
+package request
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// UploadAttachmentRequest holds the form fields sent along with an uploaded document.
+type UploadAttachmentRequest struct {
+	Kind enums.AttachmentKind `form:"kind" binding:"required,oneof=DNI_SCAN CREDIT_AGREEMENT OTHER"`
+}
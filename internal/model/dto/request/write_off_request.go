@@ -0,0 +1,6 @@
+package request
+
+// WriteOffCreditAccountRequest explains why the balance of a credit account is written off as bad debt.
+type WriteOffCreditAccountRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,24 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ActivityResponse is an entry of the activity feed of a credit account.
+type ActivityResponse struct {
+	ID              uint               `json:"id"`
+	CreditAccountID uint               `json:"credit_account_id"`
+	Type            enums.ActivityType `json:"type"`
+	Icon            string             `json:"icon"` // Name of the icon the app shows for the type, e.g. "shopping-cart"
+	Title           string             `json:"title"`
+	Description     string             `json:"description"`
+	// Amount is the change to what the client owes: positive for charges, negative for payments
+	Amount    float64 `json:"amount"`
+	Direction string  `json:"direction"` // "debit" for charges, "credit" for payments and "none" when no money moved
+	// Balance and CreditLimit are the account's after the entry, omitted for entries older than the feed
+	Balance       *float64  `json:"balance,omitempty"`
+	CreditLimit   *float64  `json:"credit_limit,omitempty"`
+	TransactionID *uint     `json:"transaction_id,omitempty"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
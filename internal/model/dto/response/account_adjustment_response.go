@@ -0,0 +1,24 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// AccountAdjustmentResponse is a manual adjustment of the balance of a credit account, and the
+// decision on it when it needed approval.
+type AccountAdjustmentResponse struct {
+	ID              uint                   `json:"id"`
+	CreditAccountID uint                   `json:"credit_account_id"`
+	EstablishmentID uint                   `json:"establishment_id"`
+	Type            enums.TransactionType  `json:"type"`
+	Amount          float64                `json:"amount"`
+	Reason          string                 `json:"reason"`
+	Status          enums.AdjustmentStatus `json:"status"`
+	RequestedByID   uint                   `json:"requested_by_id"`
+	DecidedByID     *uint                  `json:"decided_by_id,omitempty"`
+	DecidedAt       *time.Time             `json:"decided_at,omitempty"`
+	RejectionReason string                 `json:"rejection_reason,omitempty"`
+	TransactionID   *uint                  `json:"transaction_id,omitempty"` // Adjustment transaction, once applied
+	CreatedAt       time.Time              `json:"created_at"`
+}
@@ -8,5 +8,8 @@ type AccountStatementResponse struct {
     StartDate       time.Time             `json:"start_date"`
     EndDate         time.Time             `json:"end_date"`
     StartingBalance float64               `json:"starting_balance"`
+    InterestCharged float64               `json:"interest_charged"`  // Interest charged over the period
+    LateFeesCharged float64               `json:"late_fees_charged"` // Late fees charged over the period
+    Adjustments     float64               `json:"adjustments"`       // Manual adjustment debits less credits over the period
     Transactions    []TransactionResponse `json:"transactions"`
 }
\ No newline at end of file
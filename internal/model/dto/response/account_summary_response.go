@@ -5,7 +5,9 @@ import "time"
 // AccountSummaryResponse represents a summary of a client's account.
 type AccountSummaryResponse struct {
 	CurrentBalance float64               `json:"current_balance"`
+	AccountCredit  float64               `json:"account_credit"`
 	DueDate        time.Time             `json:"due_date"`
 	TotalInterest  float64               `json:"total_interest"`
+	Rates          *CreditRatesResponse  `json:"rates"`
 	Transactions   []TransactionResponse `json:"transactions"`
 }
@@ -0,0 +1,9 @@
+package response
+
+// AccountingAccountsResponse is the account of the chart of accounts of an establishment each
+// accounting event is exported to.
+type AccountingAccountsResponse struct {
+	EstablishmentID uint              `json:"establishment_id"`
+	Accounts        map[string]string `json:"accounts"` // Account code by event, empty for the events not mapped yet
+	Unmapped        []string          `json:"unmapped"` // Events without an account. The journal can't be exported until there are none
+}
@@ -10,4 +10,7 @@ type AdminDebtSummary struct {
 	NumberOfDues   int       `json:"number_of_installments"` // Only for long-term
 	CurrentBalance float64   `json:"current_balance"`
 	DueDate        time.Time `json:"due_date"` // For short-term or next installment
+	CreditScore    *int      `json:"credit_score"`
+	HighRisk       bool      `json:"high_risk"`
+	BrokenPromises int       `json:"broken_promises"` // Promises to pay the client didn't keep
 }
@@ -0,0 +1,32 @@
+package response
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// AdminHomeResponse is what the dashboard of the admin app shows of an establishment.
+type AdminHomeResponse struct {
+	EstablishmentID      uint                      `json:"establishment_id"`
+	EstablishmentName    string                    `json:"establishment_name"`
+	Status               enums.EstablishmentStatus `json:"status"`
+	SuspensionReason     string                    `json:"suspension_reason,omitempty"`
+	PaymentsToday        int                       `json:"payments_today"`  // Payments collected since midnight, local time
+	CollectedToday       float64                   `json:"collected_today"` // What those payments add up to
+	PendingConfirmations int                       `json:"pending_confirmations"`
+	OverdueAccounts      int                       `json:"overdue_accounts"`
+	LowStockProducts     []LowStockProductResponse `json:"low_stock_products"` // Those running out first
+	RecentActivity       []AdminActivityResponse   `json:"recent_activity"`    // Latest entries of the activity of all the credit accounts, newest first
+}
+
+// LowStockProductResponse is an active product that is running out or out of stock.
+type LowStockProductResponse struct {
+	ID    uint    `json:"id"`
+	Name  string  `json:"name"`
+	SKU   *string `json:"sku,omitempty"`
+	Stock int     `json:"stock"`
+}
+
+// AdminActivityResponse is an entry of the activity feed of a credit account, with its client.
+type AdminActivityResponse struct {
+	ActivityResponse
+	ClientID   uint   `json:"client_id"`
+	ClientName string `json:"client_name"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// AttachmentResponse is a document attached to a client or one of their credit accounts.
+type AttachmentResponse struct {
+	ID              uint                 `json:"id"`
+	ClientID        uint                 `json:"client_id"`
+	CreditAccountID *uint                `json:"credit_account_id,omitempty"`
+	Kind            enums.AttachmentKind `json:"kind"`
+	FileName        string               `json:"file_name"`
+	ContentType     string               `json:"content_type"`
+	Size            int64                `json:"size"`   // In bytes
+	SHA256          string               `json:"sha256"` // Hex digest of the file
+	UploadedByID    uint                 `json:"uploaded_by_id"`
+	RetainUntil     time.Time            `json:"retain_until"` // Kept at least until then, deleted after
+	CreatedAt       time.Time            `json:"created_at"`
+}
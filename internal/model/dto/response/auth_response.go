@@ -1,6 +1,14 @@
 package response
 
+// AuthResponse holds the tokens of a new session. When a second factor is needed, it holds a
+// challenge token to complete the login with instead.
 type AuthResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	CSRFToken    string `json:"csrf_token,omitempty"` // Only set for cookie sessions
+
+	TwoFactorRequired      bool     `json:"two_factor_required,omitempty"`       // Send an authenticator or recovery code to /login/2fa
+	TwoFactorSetupRequired bool     `json:"two_factor_setup_required,omitempty"` // The establishment requires 2FA: set it up with /login/2fa/setup first
+	ChallengeToken         string   `json:"challenge_token,omitempty"`
+	RecoveryCodes          []string `json:"recovery_codes,omitempty"` // Issued when 2FA is set up during the login
 }
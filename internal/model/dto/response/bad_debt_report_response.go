@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+// BadDebtReportResponse lists the credit accounts of an establishment written off as bad debt and how
+// much of it was recovered.
+type BadDebtReportResponse struct {
+	EstablishmentID uint                     `json:"establishment_id"`
+	Accounts        []BadDebtAccountResponse `json:"accounts"` // Newest write-off first
+	WrittenOff      float64                  `json:"written_off"`
+	Recovered       float64                  `json:"recovered"`
+	Outstanding     float64                  `json:"outstanding"`
+}
+
+// BadDebtAccountResponse is a credit account written off as bad debt.
+type BadDebtAccountResponse struct {
+	CreditAccountID uint      `json:"credit_account_id"`
+	ClientID        uint      `json:"client_id"`
+	ClientName      string    `json:"client_name"`
+	WrittenOffAt    time.Time `json:"written_off_at"`
+	Reason          string    `json:"reason"`
+	WrittenOffByID  uint      `json:"written_off_by_id"`
+	WrittenOff      float64   `json:"written_off"` // Balance owed when written off
+	Recovered       float64   `json:"recovered"`   // Paid since
+	Outstanding     float64   `json:"outstanding"`
+}
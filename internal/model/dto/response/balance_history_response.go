@@ -0,0 +1,39 @@
+package response
+
+import "time"
+
+// BalanceHistoryResponse is the balance of a credit account day by day, for balance-over-time charts.
+type BalanceHistoryResponse struct {
+	CreditAccountID uint           `json:"credit_account_id"`
+	StartDate       time.Time      `json:"start_date"`
+	EndDate         time.Time      `json:"end_date"`
+	Points          []BalancePoint `json:"points"`
+}
+
+// BalancePoint is the balance of a credit account at the close of a day.
+type BalancePoint struct {
+	Date              time.Time `json:"date"`
+	Balance           float64   `json:"balance"`
+	AccountCredit     float64   `json:"account_credit"`
+	WrittenOffBalance float64   `json:"written_off_balance"`
+	CreditLimit       float64   `json:"credit_limit"`
+	Current           bool      `json:"current"` // The balance as of now, the day isn't closed yet
+}
+
+// ReceivablesTrendResponse is what the clients of an establishment owed day by day.
+type ReceivablesTrendResponse struct {
+	EstablishmentID uint               `json:"establishment_id"`
+	StartDate       time.Time          `json:"start_date"`
+	EndDate         time.Time          `json:"end_date"`
+	Points          []ReceivablesPoint `json:"points"`
+}
+
+// ReceivablesPoint is what the clients of an establishment owed at the close of a day.
+type ReceivablesPoint struct {
+	Date                time.Time `json:"date"`
+	Balance             float64   `json:"balance"`        // Balances owed, added up
+	AccountCredit       float64   `json:"account_credit"` // Overpayments in the clients' favor, added up
+	Receivables         float64   `json:"receivables"`    // Balance less account credit
+	WrittenOffBalance   float64   `json:"written_off_balance"`
+	AccountsWithBalance int       `json:"accounts_with_balance"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// BankTransferResponse is a transfer notified by the bank and what was done with it.
+type BankTransferResponse struct {
+	ID              uint                     `json:"id"`
+	ProviderID      string                   `json:"provider_id"`
+	Reference       string                   `json:"reference"`
+	Amount          float64                  `json:"amount"`
+	PayerName       string                   `json:"payer_name,omitempty"`
+	Status          enums.BankTransferStatus `json:"status"`
+	Detail          string                   `json:"detail,omitempty"`
+	CreditAccountID *uint                    `json:"credit_account_id,omitempty"`
+	TransactionID   *uint                    `json:"transaction_id,omitempty"` // Payment it confirmed or was recorded as
+	TransferredAt   time.Time                `json:"transferred_at"`
+	ReceivedAt      time.Time                `json:"received_at"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+// BranchReportResponse consolidates the figures of an admin's main establishment and its branches over a period.
+type BranchReportResponse struct {
+	Period    string                  `json:"period"`
+	StartDate time.Time               `json:"start_date"`
+	EndDate   time.Time               `json:"end_date"`
+	Branches  []BranchSummaryResponse `json:"branches"`
+	Totals    BranchSummaryResponse   `json:"totals"`
+}
+
+type BranchSummaryResponse struct {
+	EstablishmentID    uint    `json:"establishment_id,omitempty"`
+	Name               string  `json:"name,omitempty"`
+	IsMain             bool    `json:"is_main"`
+	CreditSales        float64 `json:"credit_sales"`
+	CashSales          float64 `json:"cash_sales"`
+	Payments           float64 `json:"payments"`
+	InterestAndFees    float64 `json:"interest_and_fees"`   // Interest and late fees charged
+	Adjustments        float64 `json:"adjustments"`         // Manual adjustment debits less credits
+	OutstandingBalance float64 `json:"outstanding_balance"` // Owed on the branch's credit accounts right now
+	CreditAccounts     int     `json:"credit_accounts"`
+	OverdueAccounts    int     `json:"overdue_accounts"`
+}
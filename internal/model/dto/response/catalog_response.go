@@ -0,0 +1,15 @@
+package response
+
+// CatalogProductResponse is a product of an establishment's public catalog. The stock itself isn't
+// shown, only whether it can be bought.
+type CatalogProductResponse struct {
+	ID          uint    `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	CategoryID  *uint   `json:"category_id"`
+	Category    string  `json:"category"` // Name of the category
+	Price       float64 `json:"price"`    // Including tax, as the client pays it
+	ImageUrl    string  `json:"image_url"`
+	InStock     bool    `json:"in_stock"`
+	LowStock    bool    `json:"low_stock"` // Only a few units left
+}
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// CategoryResponse is a product category of an establishment.
+type CategoryResponse struct {
+	ID              uint      `json:"id"`
+	EstablishmentID uint      `json:"establishment_id"`
+	Name            string    `json:"name"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
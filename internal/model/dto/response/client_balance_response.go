@@ -1,7 +1,26 @@
 package response
 
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
 // ClientBalanceResponse represents the response for the client's balance
 type ClientBalanceResponse struct {
 	ClientID       uint    `json:"client_id"`
 	CurrentBalance float64 `json:"current_balance"`
+	AccountCredit  float64 `json:"account_credit"` // Overpaid amount applied to the next purchase
+	// What the client may still spend in the current period, only if the account has a spending limit
+	SpendingAllowance *SpendingAllowanceResponse `json:"spending_allowance,omitempty"`
+	UpdatedAt         time.Time                  `json:"updated_at"` // Last change of the credit account
+}
+
+// SpendingAllowanceResponse is how much of the spending limit of a credit account is left this period.
+type SpendingAllowanceResponse struct {
+	Limit       float64              `json:"limit"`
+	Period      enums.SpendingPeriod `json:"period"`
+	PeriodStart time.Time            `json:"period_start"`
+	PeriodEnd   time.Time            `json:"period_end"` // When the allowance starts over
+	Spent       float64              `json:"spent"`
+	Remaining   float64              `json:"remaining"`
 }
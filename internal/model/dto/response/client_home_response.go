@@ -0,0 +1,19 @@
+package response
+
+import "time"
+
+// ClientHomeResponse is what the home screen of the app shows of a client's credit account.
+type ClientHomeResponse struct {
+	CreditAccountID     uint               `json:"credit_account_id"`
+	EstablishmentID     uint               `json:"establishment_id"`
+	EstablishmentName   string             `json:"establishment_name"`
+	CreditLimit         float64            `json:"credit_limit"`
+	CurrentBalance      float64            `json:"current_balance"`
+	AvailableCredit     float64            `json:"available_credit"` // 0 while the account is blocked, closed or written off
+	IsBlocked           bool               `json:"is_blocked"`
+	NextDueDate         *time.Time         `json:"next_due_date,omitempty"` // Omitted when nothing is owed
+	NextDueAmount       float64            `json:"next_due_amount"`
+	Overdue             bool               `json:"overdue"`
+	RecentMovements     []ActivityResponse `json:"recent_movements"` // Latest entries of the activity feed, newest first
+	UnreadNotifications int                `json:"unread_notifications"`
+}
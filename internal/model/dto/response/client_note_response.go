@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+// ClientNoteResponse is a note an admin wrote about a client.
+type ClientNoteResponse struct {
+	ID              uint      `json:"id"`
+	EstablishmentID uint      `json:"establishment_id"`
+	ClientID        uint      `json:"client_id"`
+	AuthorID        uint      `json:"author_id"`
+	AuthorName      string    `json:"author_name"`
+	Body            string    `json:"body"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ClientNotificationResponse is a notification sent to a client, as kept for them to read in the app.
+type ClientNotificationResponse struct {
+	ID              uint                   `json:"id"`
+	EstablishmentID uint                   `json:"establishment_id"`
+	CreditAccountID uint                   `json:"credit_account_id"`
+	Kind            enums.NotificationKind `json:"kind"`
+	Message         string                 `json:"message"`
+	Read            bool                   `json:"read"`
+	ReadAt          *time.Time             `json:"read_at,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package response
+
+// ClientSearchResponse is a client found by the establishment client search, with the state of their
+// credit account in the establishment.
+type ClientSearchResponse struct {
+	ClientID        uint    `json:"client_id"`
+	Name            string  `json:"name"`
+	DNI             string  `json:"dni"`
+	Email           string  `json:"email"`
+	Phone           string  `json:"phone"`
+	PhotoUrl        string  `json:"photo_url"`
+	CreditAccountID uint    `json:"credit_account_id"`
+	CurrentBalance  float64 `json:"current_balance"`
+	AccountCredit   float64 `json:"account_credit"` // Overpaid amount applied to the next purchase
+	CreditLimit     float64 `json:"credit_limit"`
+	IsBlocked       bool    `json:"is_blocked"`
+	HighRisk        bool    `json:"high_risk"`
+	Overdue         bool    `json:"overdue"`
+	DaysOverdue     int     `json:"days_overdue"` // Days the oldest unpaid amount is past its due date
+}
@@ -0,0 +1,30 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ClientSignupResponse is a client's self-registration with an establishment, and the decision on it.
+type ClientSignupResponse struct {
+	ID              uint               `json:"id"`
+	EstablishmentID uint               `json:"establishment_id"`
+	DNI             string             `json:"dni"`
+	Email           string             `json:"email"`
+	Name            string             `json:"name"`
+	Address         string             `json:"address"`
+	Phone           string             `json:"phone"`
+	Status          enums.SignupStatus `json:"status"`
+	DecidedByID     *uint              `json:"decided_by_id,omitempty"`
+	DecidedAt       *time.Time         `json:"decided_at,omitempty"`
+	Reason          string             `json:"reason,omitempty"`
+	CreditAccountID *uint              `json:"credit_account_id,omitempty"` // Opened once approved
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// InviteCodeResponse is the code clients self-register with at an establishment.
+type InviteCodeResponse struct {
+	EstablishmentID uint   `json:"establishment_id"`
+	InviteCode      string `json:"invite_code"`
+	SignupPath      string `json:"signup_path"` // Endpoint clients sign up at, for a frontend to link to or encode as a QR code
+}
@@ -0,0 +1,8 @@
+package response
+
+import "time"
+
+type ClockResponse struct {
+	Now       time.Time `json:"now"`
+	Simulated bool      `json:"simulated"`
+}
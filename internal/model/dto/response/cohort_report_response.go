@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+// CohortReportResponse shows how clients onboarded in each month repaid in the months that followed.
+type CohortReportResponse struct {
+	EstablishmentID uint             `json:"establishment_id"`
+	GeneratedAt     time.Time        `json:"generated_at"`
+	Cohorts         []CohortResponse `json:"cohorts"`
+}
+
+type CohortResponse struct {
+	Cohort  string                 `json:"cohort"` // Onboarding month, YYYY-MM
+	Clients int                    `json:"clients"`
+	Periods []CohortPeriodResponse `json:"periods"`
+}
+
+// CohortPeriodResponse is the repayment performance of a cohort at one due date after onboarding.
+type CohortPeriodResponse struct {
+	MonthsSinceOnboarding int     `json:"months_since_onboarding"`
+	Month                 string  `json:"month"`    // YYYY-MM of the due date
+	Eligible              int     `json:"eligible"` // Clients that owed something at the due date
+	OnTime                int     `json:"on_time"`
+	Late                  int     `json:"late"`
+	Defaulted             int     `json:"defaulted"` // No payment within the default threshold after the due date
+	OnTimePercentage      float64 `json:"on_time_percentage"`
+	DefaultPercentage     float64 `json:"default_percentage"`
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CollectionWorklistItem is an overdue client in the collections worklist of an establishment, with
+// what the collector needs to reach them.
+type CollectionWorklistItem struct {
+	Priority           int                      `json:"priority"` // Position in the worklist, from 1
+	ClientID           uint                     `json:"client_id"`
+	CreditAccountID    uint                     `json:"credit_account_id"`
+	Name               string                   `json:"name"`
+	DNI                string                   `json:"dni"`
+	Phone              string                   `json:"phone"`
+	Email              string                   `json:"email"`
+	Address            string                   `json:"address"`
+	DaysOverdue        int                      `json:"days_overdue"`
+	Balance            float64                  `json:"balance"` // Owed, net of the account credit
+	BrokenPromises     int                      `json:"broken_promises"`
+	IsBlocked          bool                     `json:"is_blocked"`
+	LastContactAt      *time.Time               `json:"last_contact_at"` // Nil if the client was never contacted
+	LastContactOutcome *enums.CollectionOutcome `json:"last_contact_outcome,omitempty"`
+}
+
+// CollectionContactResponse is a collection contact made to a client.
+type CollectionContactResponse struct {
+	ID              uint                        `json:"id"`
+	ClientID        uint                        `json:"client_id"`
+	CreditAccountID uint                        `json:"credit_account_id"`
+	AdminID         uint                        `json:"admin_id"`
+	Type            enums.CollectionContactType `json:"type"`
+	Outcome         enums.CollectionOutcome     `json:"outcome"`
+	Notes           string                      `json:"notes"`
+	ContactedAt     time.Time                   `json:"contacted_at"`
+}
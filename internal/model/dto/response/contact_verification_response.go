@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+// ContactVerificationResponse tells which contact details of a user are verified. Statements are
+// only emailed to a verified email.
+type ContactVerificationResponse struct {
+	Email           string     `json:"email"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Phone           string     `json:"phone"`
+	PhoneVerified   bool       `json:"phone_verified"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+}
@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// CreditAccountBlockEventResponse is an entry of the block history of a credit account.
+type CreditAccountBlockEventResponse struct {
+	Blocked   bool      `json:"blocked"` // false when the account was unblocked
+	Reason    string    `json:"reason"`
+	Automatic bool      `json:"automatic"`
+	ActorID   *uint     `json:"actor_id,omitempty"`
+	ActorName string    `json:"actor_name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CreditAccountEventResponse is a change to the terms or status of a credit account.
+type CreditAccountEventResponse struct {
+	ID              uint                         `json:"id"`
+	CreditAccountID uint                         `json:"credit_account_id"`
+	Type            enums.CreditAccountEventType `json:"type"`
+	OldValue        *float64                     `json:"old_value,omitempty"` // Credit limit or interest rate before the change
+	NewValue        *float64                     `json:"new_value,omitempty"` // Credit limit or interest rate after the change
+	Description     string                       `json:"description"`
+	Reason          string                       `json:"reason"`
+	Automatic       bool                         `json:"automatic"`
+	ActorID         *uint                        `json:"actor_id,omitempty"`
+	ActorName       string                       `json:"actor_name,omitempty"`
+	CreatedAt       time.Time                    `json:"created_at"`
+}
@@ -8,19 +8,33 @@ import (
 type CreditAccountResponse struct {
 	ID                      uint                 `json:"id"`
 	ClientID                uint                 `json:"client_id"`
-	Client                  *UserResponse       `json:"client"`
+	Client                  *UserResponse       `json:"client,omitempty"` // Left out of lists that don't include it, as is Establishment
 	EstablishmentID         uint                 `json:"establishment_id"`
-	Establishment           *EstablishmentResponse `json:"establishment"`
+	Establishment           *EstablishmentResponse `json:"establishment,omitempty"`
 	CreditLimit             float64              `json:"credit_limit"`
 	CurrentBalance          float64              `json:"current_balance"`
+	AccountCredit           float64              `json:"account_credit"`
 	MonthlyDueDate          int                  `json:"monthly_due_date"`
 	InterestRate            float64              `json:"interest_rate"`
 	InterestType            enums.InterestType   `json:"interest_type"`
+	CompoundingPeriod       enums.CompoundingPeriod `json:"compounding_period"`
 	CreditType              enums.CreditType     `json:"credit_type"`
 	GracePeriod             int                  `json:"grace_period"` 
 	IsBlocked               bool                 `json:"is_blocked"`
+	CreditScore             *int                 `json:"credit_score"`
+	HighRisk                bool                 `json:"high_risk"`
 	LastInterestAccrualDate time.Time            `json:"last_interest_accrual_date"`
 	LateFeePercentage       float64            `json:"late_fee_percentage"`
+	SpendingLimit           float64              `json:"spending_limit"` // 0 for no limit
+	SpendingLimitPeriod     enums.SpendingPeriod `json:"spending_limit_period"`
+	WrittenOffBalance       float64              `json:"written_off_balance"` // Written-off debt not recovered yet
+	WrittenOffAt            *time.Time           `json:"written_off_at,omitempty"`
+	Status                  enums.CreditAccountStatus `json:"status"` // ACTIVE or CLOSED
+	ClosedAt                *time.Time           `json:"closed_at,omitempty"`
+	CreditTemplateID        *uint                `json:"credit_template_id"` // Template the account was opened with
+	Rates                   *CreditRatesResponse `json:"rates"`
+	Version                 uint                 `json:"version"` // Send it back to update the account
+	BlockHistory            []CreditAccountBlockEventResponse `json:"block_history,omitempty"` // Newest first, only included for a single account
 	CreatedAt               time.Time            `json:"created_at"`
 	UpdatedAt               time.Time            `json:"updated_at"`
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CreditAgreementResponse is the credit agreement of a credit account, with its terms and whether the
+// client accepted it.
+type CreditAgreementResponse struct {
+	ID                uint                    `json:"id"`
+	CreditAccountID   uint                    `json:"credit_account_id"`
+	CreditLimit       float64                 `json:"credit_limit"`
+	InterestRate      float64                 `json:"interest_rate"`
+	InterestType      enums.InterestType      `json:"interest_type"`
+	CompoundingPeriod enums.CompoundingPeriod `json:"compounding_period"`
+	TCEA              float64                 `json:"tcea"`
+	CreditType        enums.CreditType        `json:"credit_type"`
+	MonthlyDueDate    int                     `json:"monthly_due_date"`
+	GracePeriod       int                     `json:"grace_period"`
+	LateFeePercentage float64                 `json:"late_fee_percentage"`
+	Accepted          bool                    `json:"accepted"` // Purchases are held until the client accepts it
+	AcceptedAt        *time.Time              `json:"accepted_at,omitempty"`
+	AcceptedIP        string                  `json:"accepted_ip,omitempty"`
+	TermsHash         string                  `json:"terms_hash,omitempty"` // SHA-256 of the accepted agreement text
+	CreatedAt         time.Time               `json:"created_at"`
+}
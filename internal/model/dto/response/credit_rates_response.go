@@ -0,0 +1,8 @@
+package response
+
+// CreditRatesResponse discloses the annual rates of a credit, as percentages.
+type CreditRatesResponse struct {
+	TNA  float64 `json:"tna"`  // Nominal annual rate
+	TEA  float64 `json:"tea"`  // Effective annual rate
+	TCEA float64 `json:"tcea"` // Effective annual cost, including every charge paid by the client
+}
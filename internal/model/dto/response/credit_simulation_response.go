@@ -0,0 +1,33 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+type SimulatedInstallment struct {
+	Number         int       `json:"number"`
+	DueDate        time.Time `json:"due_date"`
+	OpeningBalance float64   `json:"opening_balance"`
+	Interest       float64   `json:"interest"`
+	Amortization   float64   `json:"amortization"`
+	Payment        float64   `json:"payment"`
+	ClosingBalance float64   `json:"closing_balance"`
+	IsGracePeriod  bool      `json:"is_grace_period"`
+}
+
+type CreditSimulationResponse struct {
+	Principal            float64                 `json:"principal"`
+	InterestRate         float64                 `json:"interest_rate"`
+	InterestType         enums.InterestType      `json:"interest_type"`
+	CompoundingPeriod    enums.CompoundingPeriod `json:"compounding_period"`
+	CreditType           enums.CreditType        `json:"credit_type"`
+	NumberOfInstallments int                     `json:"number_of_installments"`
+	GracePeriod          int                     `json:"grace_period"`
+	MonthlyRate          float64                 `json:"monthly_rate"`          // Periodic rate applied to each installment (%)
+	EffectiveAnnualRate  float64                 `json:"effective_annual_rate"` // TEA (%)
+	TCEA                 float64                 `json:"tcea"`                  // Effective annual cost of the cash flows (%)
+	TotalInterest        float64                 `json:"total_interest"`
+	TotalPayment         float64                 `json:"total_payment"`
+	Schedule             []SimulatedInstallment  `json:"schedule"`
+}
@@ -0,0 +1,22 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CreditTemplateResponse is a set of credit terms an establishment opens credit accounts with.
+type CreditTemplateResponse struct {
+	ID                uint                    `json:"id"`
+	EstablishmentID   uint                    `json:"establishment_id"`
+	Name              string                  `json:"name"`
+	CreditLimit       float64                 `json:"credit_limit"`
+	MonthlyDueDate    int                     `json:"monthly_due_date"`
+	InterestRate      float64                 `json:"interest_rate"` // 0 for the establishment's default rate
+	InterestType      enums.InterestType      `json:"interest_type"`
+	CompoundingPeriod enums.CompoundingPeriod `json:"compounding_period"`
+	CreditType        enums.CreditType        `json:"credit_type"`
+	GracePeriod       int                     `json:"grace_period"`
+	CreatedAt         time.Time               `json:"created_at"`
+	UpdatedAt         time.Time               `json:"updated_at"`
+}
@@ -0,0 +1,81 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// BulkCreditTermUpdateResponse reports what a bulk update of credit terms did, or would do in preview,
+// with each credit account its filter matched.
+type BulkCreditTermUpdateResponse struct {
+	UpdateID *uint                     `json:"update_id"` // Audit record of the update, nil in preview
+	Preview  bool                      `json:"preview"`
+	Accounts []CreditTermAccountResult `json:"accounts"`
+	Summary  CreditTermUpdateSummary   `json:"summary"`
+}
+
+// CreditTermAccountResult is what a bulk update of credit terms does, or would do, with an account.
+type CreditTermAccountResult struct {
+	CreditAccountID uint                       `json:"credit_account_id"`
+	ClientID        uint                       `json:"client_id"`
+	ClientName      string                     `json:"client_name"`
+	Action          enums.CreditTermAction     `json:"action"`
+	Changes         []CreditTermChangeResponse `json:"changes"`
+	Reason          string                     `json:"reason,omitempty"` // Why it was skipped or failed
+}
+
+// CreditTermUpdateSummary counts the accounts of a bulk update of credit terms by what it did with them.
+type CreditTermUpdateSummary struct {
+	Matched   int `json:"matched"`
+	Updated   int `json:"updated"` // Changed, or to be changed in preview
+	Unchanged int `json:"unchanged"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+}
+
+// CreditTermChangeResponse is a term of a credit account changed by a bulk update.
+type CreditTermChangeResponse struct {
+	CreditAccountID uint   `json:"credit_account_id"`
+	Field           string `json:"field"`
+	OldValue        string `json:"old_value"`
+	NewValue        string `json:"new_value"`
+}
+
+// CreditTermUpdateResponse is the audit record of a bulk update of credit terms. Changes are only
+// included when a single update is retrieved.
+type CreditTermUpdateResponse struct {
+	ID              uint                        `json:"id"`
+	EstablishmentID uint                        `json:"establishment_id"`
+	AdminID         uint                        `json:"admin_id"`
+	Reason          string                      `json:"reason"`
+	Filter          CreditAccountFilterResponse `json:"filter"`
+	Terms           CreditTermsResponse         `json:"terms"`
+	Matched         int                         `json:"matched"`
+	Updated         int                         `json:"updated"`
+	Failed          int                         `json:"failed"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	Changes         []CreditTermChangeResponse  `json:"changes,omitempty"`
+}
+
+// CreditAccountFilterResponse is the filter of the credit accounts a bulk update applied to.
+type CreditAccountFilterResponse struct {
+	CreditAccountIDs []uint                     `json:"credit_account_ids,omitempty"`
+	CreditTemplateID *uint                      `json:"credit_template_id,omitempty"`
+	CreditType       *enums.CreditType          `json:"credit_type,omitempty"`
+	InterestType     *enums.InterestType        `json:"interest_type,omitempty"`
+	Status           *enums.CreditAccountStatus `json:"status,omitempty"`
+	Tags             []string                   `json:"tags,omitempty"`
+}
+
+// CreditTermsResponse holds the terms a bulk update set.
+type CreditTermsResponse struct {
+	CreditLimit         *float64                 `json:"credit_limit,omitempty"`
+	MonthlyDueDate      *int                     `json:"monthly_due_date,omitempty"`
+	InterestRate        *float64                 `json:"interest_rate,omitempty"`
+	InterestType        *enums.InterestType      `json:"interest_type,omitempty"`
+	CompoundingPeriod   *enums.CompoundingPeriod `json:"compounding_period,omitempty"`
+	GracePeriod         *int                     `json:"grace_period,omitempty"`
+	LateFeePercentage   *float64                 `json:"late_fee_percentage,omitempty"`
+	SpendingLimit       *float64                 `json:"spending_limit,omitempty"`
+	SpendingLimitPeriod *enums.SpendingPeriod    `json:"spending_limit_period,omitempty"`
+}
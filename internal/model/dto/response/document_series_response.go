@@ -0,0 +1,19 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// DocumentSeriesResponse is a fiscal document series of an establishment.
+type DocumentSeriesResponse struct {
+	ID                 uint               `json:"id"`
+	EstablishmentID    uint               `json:"establishment_id"`
+	DocumentType       enums.DocumentType `json:"document_type"`
+	Code               string             `json:"code"`
+	LastNumber         int64              `json:"last_number"`          // 0 if the series issued no documents yet
+	NextDocumentNumber string             `json:"next_document_number"` // e.g. B001-000124
+	Active             bool               `json:"active"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
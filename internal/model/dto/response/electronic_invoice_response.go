@@ -0,0 +1,21 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ElectronicInvoiceResponse is the electronic receipt of a purchase and the state of its submission
+// to SUNAT.
+type ElectronicInvoiceResponse struct {
+	TransactionID       uint                `json:"transaction_id"`
+	DocumentNumber      string              `json:"document_number"`
+	Status              enums.InvoiceStatus `json:"status"`
+	ResponseCode        string              `json:"response_code,omitempty"`        // Code SUNAT answered with, 0 when accepted
+	ResponseDescription string              `json:"response_description,omitempty"` // With the observations of accepted receipts
+	DigestValue         string              `json:"digest_value,omitempty"`         // Hash of the signed XML, once signed
+	Attempts            int                 `json:"attempts"`
+	LastError           string              `json:"last_error,omitempty"`
+	SubmittedAt         *time.Time          `json:"submitted_at,omitempty"`
+	CreatedAt           time.Time           `json:"created_at"`
+}
@@ -3,3 +3,10 @@ package response
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// VersionConflictResponse is the error of an update based on an outdated version of a record, with
+// the record as it is now.
+type VersionConflictResponse struct {
+	Error   string      `json:"error"`
+	Current interface{} `json:"current"`
+}
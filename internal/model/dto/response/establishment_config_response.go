@@ -0,0 +1,64 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// EstablishmentConfigResponse is the configuration of an establishment, as imported into another by
+// POST /establishments/me/import.
+type EstablishmentConfigResponse struct {
+	EstablishmentID uint                          `json:"establishment_id"` // The one exported
+	ExportedAt      time.Time                     `json:"exported_at"`
+	Settings        EstablishmentSettingsResponse `json:"settings"`
+	Categories      []ConfigCategoryResponse      `json:"categories"`
+	Products        []ConfigProductResponse       `json:"products"` // Without their stock, which stays with the establishment
+}
+
+// ConfigCategoryResponse is a product category of an exported configuration.
+type ConfigCategoryResponse struct {
+	Name string `json:"name"`
+}
+
+// ConfigProductResponse is a product of an exported configuration.
+type ConfigProductResponse struct {
+	Name        string  `json:"name"`
+	SKU         string  `json:"sku,omitempty"`
+	Barcode     string  `json:"barcode,omitempty"`
+	Category    string  `json:"category"` // Name of the category
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	ImageUrl    string  `json:"image_url"`
+	IsActive    bool    `json:"is_active"`
+}
+
+// EstablishmentImportReport tells what importing a configuration did, or would do in a dry run, with each of its items.
+type EstablishmentImportReport struct {
+	DryRun     bool                     `json:"dry_run"`
+	Applied    bool                     `json:"applied"` // False for dry runs
+	OnConflict enums.ImportConflictMode `json:"on_conflict"`
+	Settings   *enums.ImportAction      `json:"settings"` // Nil without a settings section
+	Categories []ImportItemResult       `json:"categories"`
+	Products   []ImportItemResult       `json:"products"`
+	Summary    ImportSummary            `json:"summary"`
+}
+
+// ImportItemResult is what importing a configuration does with a category or product of it.
+type ImportItemResult struct {
+	Index      int                `json:"index"` // Position in its section of the configuration
+	Name       string             `json:"name"`
+	SKU        string             `json:"sku,omitempty"`
+	Action     enums.ImportAction `json:"action"`
+	ExistingID *uint              `json:"existing_id,omitempty"` // Category or product of the establishment it matched
+	Reason     string             `json:"reason,omitempty"`      // Of conflicts and invalid items
+}
+
+// ImportSummary counts the categories and products of an import by action.
+type ImportSummary struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Skipped   int `json:"skipped"`
+	Conflicts int `json:"conflicts"`
+	Invalid   int `json:"invalid"`
+}
@@ -5,16 +5,20 @@ import (
 )
 
 type EstablishmentResponse struct {
-	ID                uint          `json:"id"`
-	RUC               string        `json:"ruc"`
-	Name              string        `json:"name"`
-	Phone             string        `json:"phone"`
-	Address           string        `json:"address"`
-	ImageUrl          string        `json:"image_url"`
-	Admin             *UserResponse `json:"admin"`
-	AdminID           uint          `json:"admin_id"`
-	LateFeePercentage float64       `json:"late_fee_percentage"`
-	IsActive          bool          `json:"is_active"`
-	CreatedAt         time.Time     `json:"created_at"`
-	UpdatedAt         time.Time     `json:"updated_at"`
+	ID                             uint          `json:"id"`
+	RUC                            string        `json:"ruc"`
+	Name                           string        `json:"name"`
+	Phone                          string        `json:"phone"`
+	Address                        string        `json:"address"`
+	ImageUrl                       string        `json:"image_url"`
+	Admin                          *UserResponse `json:"admin"`
+	AdminID                        uint          `json:"admin_id"`
+	ParentID                       *uint         `json:"parent_id,omitempty"` // Set for branches
+	LateFeePercentage              float64       `json:"late_fee_percentage"`
+	Timezone                       string        `json:"timezone"`
+	EarlyPaymentDiscountPercentage float64       `json:"early_payment_discount_percentage"`
+	IsActive                       bool          `json:"is_active"`
+	Version                        uint          `json:"version"` // Send it back to update the establishment
+	CreatedAt                      time.Time     `json:"created_at"`
+	UpdatedAt                      time.Time     `json:"updated_at"`
 }
@@ -0,0 +1,36 @@
+package response
+
+// EstablishmentSettingsResponse is the set of business rules an establishment applies to its credit accounts.
+type EstablishmentSettingsResponse struct {
+	EstablishmentID       uint    `json:"establishment_id"`
+	MaxInstallments       int     `json:"max_installments"`
+	DefaultInterestRate   float64 `json:"default_interest_rate"`
+	AutoBlockDaysOverdue  int     `json:"auto_block_days_overdue"`
+	ReminderDaysBefore    int     `json:"reminder_days_before"` // Of the first reminder, 0 if none is sent before the due date
+	HighRiskScore         int     `json:"high_risk_score"`
+	HighRiskMaxPurchase   float64 `json:"high_risk_max_purchase"`
+	RequireAdminTwoFactor bool    `json:"require_admin_two_factor"`
+	TaxRate               float64 `json:"tax_rate"`
+	PricesExcludeTax      bool    `json:"prices_exclude_tax"`
+	ApprovalThreshold     float64 `json:"approval_threshold"`
+	ApprovalExpiryDays    int     `json:"approval_expiry_days"`
+	PinThreshold          float64 `json:"pin_threshold"`
+	PaymentCodeMinutes    int     `json:"payment_code_minutes"`
+	MaxReschedules        int     `json:"max_reschedules"`
+	RescheduleInterest    bool    `json:"reschedule_interest"`
+	LateFeeMode           string  `json:"late_fee_mode"`
+	LateFeeAmount         float64 `json:"late_fee_amount"`
+	LateFeeCap            float64 `json:"late_fee_cap"`
+	AdjustmentThreshold   float64 `json:"adjustment_threshold"`
+	AdjustmentApproverID  uint    `json:"adjustment_approver_id"`
+	Language              string  `json:"language"`
+	SMSNotifications      bool    `json:"sms_notifications"`
+	SMSSender             string  `json:"sms_sender"`
+
+	// Days from their due date clients are sent payment reminders and overdue notices on
+	ReminderSchedule []int `json:"reminder_schedule"`
+
+	// Report digest emailed to the admin
+	DigestFrequency string   `json:"digest_frequency"`
+	DigestSections  []string `json:"digest_sections"`
+}
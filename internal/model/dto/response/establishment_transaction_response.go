@@ -0,0 +1,10 @@
+package response
+
+// EstablishmentTransactionResponse is a transaction found by the establishment transaction search,
+// with the client it belongs to.
+type EstablishmentTransactionResponse struct {
+	TransactionResponse
+	ClientID   uint   `json:"client_id"`
+	ClientName string `json:"client_name"`
+	ClientDNI  string `json:"client_dni"`
+}
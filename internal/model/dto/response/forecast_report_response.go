@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+// ForecastReportResponse is the collections an establishment can expect in each upcoming week or month,
+// from the installment schedule and due balances of its credit accounts and how punctually they paid.
+type ForecastReportResponse struct {
+	EstablishmentID uint                     `json:"establishment_id"`
+	Granularity     string                   `json:"granularity"` // week or month
+	GeneratedAt     time.Time                `json:"generated_at"`
+	Punctuality     float64                  `json:"punctuality"`     // Share of the establishment's installments paid on time, assumed for accounts without a history of their own
+	OverdueBalance  float64                  `json:"overdue_balance"` // Past due and still unpaid, left out of the forecast; see the collections worklist
+	Periods         []ForecastPeriodResponse `json:"periods"`
+	Total           ForecastAmounts          `json:"total"`
+}
+
+// ForecastPeriodResponse is the collections expected in a week or month.
+type ForecastPeriodResponse struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Accounts int       `json:"accounts"` // Accounts with something due in the period
+	ForecastAmounts
+}
+
+// ForecastAmounts is what is due in a period and the band of what is likely to be collected of it.
+type ForecastAmounts struct {
+	Scheduled   float64 `json:"scheduled"`   // Everything due in the period
+	Optimistic  float64 `json:"optimistic"`  // Collected in nine periods out of ten at most
+	Expected    float64 `json:"expected"`    // Due amounts weighted by each account's punctuality
+	Pessimistic float64 `json:"pessimistic"` // Collected in nine periods out of ten at least
+}
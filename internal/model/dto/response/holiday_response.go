@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+// HolidayResponse is a holiday of the calendar of an establishment.
+type HolidayResponse struct {
+	ID              uint      `json:"id"`
+	EstablishmentID uint      `json:"establishment_id"`
+	Date            string    `json:"date"` // YYYY-MM-DD
+	Name            string    `json:"name"`
+	National        bool      `json:"national"` // One of the national holidays of Peru
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package response
+
+import "time"
+
+// ImpersonationTokenResponse is the token an admin sees the API as one of their clients with.
+type ImpersonationTokenResponse struct {
+	ImpersonationID uint      `json:"impersonation_id"`
+	ClientID        uint      `json:"client_id"`
+	EstablishmentID uint      `json:"establishment_id"`
+	AccessToken     string    `json:"access_token"` // Only accepted on read-only client endpoints
+	Impersonation   bool      `json:"impersonation"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+// ImpersonationResponse is an impersonation of a client by an admin, with the requests made in it.
+type ImpersonationResponse struct {
+	ID              uint                           `json:"id"`
+	ClientID        uint                           `json:"client_id"`
+	EstablishmentID uint                           `json:"establishment_id"`
+	Reason          string                         `json:"reason"`
+	IP              string                         `json:"ip"`
+	Active          bool                           `json:"active"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	ExpiresAt       time.Time                      `json:"expires_at"`
+	EndedAt         *time.Time                     `json:"ended_at,omitempty"`
+	Requests        []ImpersonationRequestResponse `json:"requests"`
+}
+
+// ImpersonationRequestResponse is a request made with an impersonation token.
+type ImpersonationRequestResponse struct {
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
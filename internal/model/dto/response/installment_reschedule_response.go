@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+// InstallmentRescheduleResponse is a due date of an installment an admin moved.
+type InstallmentRescheduleResponse struct {
+	ID              uint      `json:"id"`
+	InstallmentID   uint      `json:"installment_id"`
+	CreditAccountID uint      `json:"credit_account_id"`
+	OriginalDueDate string    `json:"original_due_date"` // YYYY-MM-DD in the establishment's time zone
+	NewDueDate      string    `json:"new_due_date"`      // YYYY-MM-DD in the establishment's time zone
+	Interest        float64   `json:"interest"`          // Added to the installment for the days it was postponed
+	Reason          string    `json:"reason"`
+	RescheduledByID uint      `json:"rescheduled_by_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
@@ -11,6 +11,10 @@ type InstallmentResponse struct {
 	DueDate         time.Time               `json:"due_date"`
 	Amount          float64                 `json:"amount"`
 	Status          enums.InstallmentStatus `json:"status"`
+	OverdueAt       *time.Time              `json:"overdue_at,omitempty"`
+	PaidAt          *time.Time              `json:"paid_at,omitempty"`
+	CancelledAt     *time.Time              `json:"cancelled_at,omitempty"`
+	Version         uint                    `json:"version"` // Send it back to update the installment
 	CreatedAt       time.Time               `json:"created_at"`
 	UpdatedAt       time.Time               `json:"updated_at"`
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// JobResponse is the status of a background job. Once it succeeded, its result is downloaded from /jobs/{id}/result.
+type JobResponse struct {
+	ID         uint            `json:"id"`
+	Type       string          `json:"type"`
+	Status     enums.JobStatus `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	Attempts   int             `json:"attempts"`
+	CreatedAt  time.Time       `json:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
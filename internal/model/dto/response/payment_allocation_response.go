@@ -0,0 +1,44 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PaymentAllocationResponse is what a payment paid of the installments of its credit account, and
+// what was left of them to pay after it.
+type PaymentAllocationResponse struct {
+	TransactionID     uint                           `json:"transaction_id"`
+	CreditAccountID   uint                           `json:"credit_account_id"`
+	DocumentNumber    string                         `json:"document_number,omitempty"`
+	TransactionDate   time.Time                      `json:"transaction_date"`
+	PaymentMethod     enums.PaymentMethod            `json:"payment_method"`
+	PaymentStatus     enums.PaymentStatus            `json:"payment_status"`
+	Amount            float64                        `json:"amount"`
+	AllocatedAmount   float64                        `json:"allocated_amount"`   // Paid of installments
+	UnallocatedAmount float64                        `json:"unallocated_amount"` // Paid of the rest of the balance
+	PaidInstallments  []AllocatedInstallmentResponse `json:"paid_installments"`
+	RemainingSchedule []RemainingInstallmentResponse `json:"remaining_schedule"`
+	RemainingAmount   float64                        `json:"remaining_amount"` // Left to pay of the installments after the payment
+	NextDueDate       *time.Time                     `json:"next_due_date,omitempty"`
+	NextDueAmount     float64                        `json:"next_due_amount,omitempty"`
+}
+
+// AllocatedInstallmentResponse is an installment a payment went to.
+type AllocatedInstallmentResponse struct {
+	InstallmentID uint      `json:"installment_id"`
+	DueDate       time.Time `json:"due_date"`
+	Amount        float64   `json:"amount"`
+	Allocated     float64   `json:"allocated"`   // Paid of it by the payment
+	Outstanding   float64   `json:"outstanding"` // Left to pay of it after the payment
+	Paid          bool      `json:"paid"`
+}
+
+// RemainingInstallmentResponse is an installment that was still owed after a payment.
+type RemainingInstallmentResponse struct {
+	InstallmentID uint                    `json:"installment_id"`
+	DueDate       time.Time               `json:"due_date"`
+	Amount        float64                 `json:"amount"`
+	Outstanding   float64                 `json:"outstanding"`
+	Status        enums.InstallmentStatus `json:"status"`
+}
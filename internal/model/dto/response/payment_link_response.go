@@ -0,0 +1,35 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PaymentLinkResponse is a link sent to a client to pay their credit account, and what became of it.
+type PaymentLinkResponse struct {
+	ID              uint                    `json:"id"`
+	CreditAccountID uint                    `json:"credit_account_id"`
+	ClientID        uint                    `json:"client_id"`
+	InstallmentID   *uint                   `json:"installment_id,omitempty"`
+	Amount          float64                 `json:"amount"`
+	Status          enums.PaymentLinkStatus `json:"status"`
+	URL             string                  `json:"url"`
+	ExpiresAt       time.Time               `json:"expires_at"`
+	ViewedAt        *time.Time              `json:"viewed_at,omitempty"`
+	TransactionID   *uint                   `json:"transaction_id,omitempty"` // Payment made through it
+	PaidAt          *time.Time              `json:"paid_at,omitempty"`
+	CreatedByID     uint                    `json:"created_by_id"`
+	CreatedAt       time.Time               `json:"created_at"`
+	SentTo          []enums.ContactChannel  `json:"sent_to,omitempty"` // Channels that reached the client, when the link is created
+}
+
+// PaymentLinkPageResponse is what the client sees when opening a payment link.
+type PaymentLinkPageResponse struct {
+	EstablishmentName string                  `json:"establishment_name"`
+	ClientName        string                  `json:"client_name"`
+	InstallmentID     *uint                   `json:"installment_id,omitempty"`
+	Amount            float64                 `json:"amount"`
+	Status            enums.PaymentLinkStatus `json:"status"`
+	ExpiresAt         time.Time               `json:"expires_at"`
+	Payment           *TransactionResponse    `json:"payment,omitempty"` // Made through the link, with the code to pay it with
+}
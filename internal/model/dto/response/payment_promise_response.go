@@ -0,0 +1,19 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PaymentPromiseResponse is a promise to pay negotiated with a client and whether it was kept.
+type PaymentPromiseResponse struct {
+	ID              uint                `json:"id"`
+	CreditAccountID uint                `json:"credit_account_id"`
+	Amount          float64             `json:"amount"`
+	PromisedDate    string              `json:"promised_date"` // YYYY-MM-DD in the establishment's time zone
+	Status          enums.PromiseStatus `json:"status"`
+	Paid            float64             `json:"paid"` // Paid by the promised date, once the promise is kept or broken
+	CreatedByID     uint                `json:"created_by_id"`
+	ResolvedAt      *time.Time          `json:"resolved_at,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+}
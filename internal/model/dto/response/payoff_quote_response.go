@@ -0,0 +1,22 @@
+package response
+
+import "time"
+
+// PayoffQuoteResponse is the amount that settles a credit account in full, valid until ExpiresAt.
+type PayoffQuoteResponse struct {
+	CreditAccountID      uint      `json:"credit_account_id"`
+	OutstandingPrincipal float64   `json:"outstanding_principal"`
+	AccruedInterest      float64   `json:"accrued_interest"` // Interest accrued since the last accrual, to date
+	DiscountPercentage   float64   `json:"discount_percentage"`
+	Discount             float64   `json:"discount"` // Early-payment discount, not granted to overdue accounts
+	PayoffAmount         float64   `json:"payoff_amount"`
+	QuotedAt             time.Time `json:"quoted_at"`
+	ExpiresAt            time.Time `json:"expires_at"`
+}
+
+// AccountBalanceResponse is the error of closing a credit account that still owes, with the quote
+// to pay it off, which settles and closes it when sent back as payoff_amount.
+type AccountBalanceResponse struct {
+	Error       string               `json:"error"`
+	PayoffQuote *PayoffQuoteResponse `json:"payoff_quote,omitempty"` // Left out when only written-off debt is owed
+}
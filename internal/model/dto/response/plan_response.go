@@ -0,0 +1,32 @@
+package response
+
+import "ApiRestFinance/internal/model/entities/enums"
+
+// PlanResponse is what a plan allows. A quota of 0 is unlimited, and one of -1 allows none.
+type PlanResponse struct {
+	Plan        enums.Plan          `json:"plan"`
+	MaxClients  int                 `json:"max_clients"`
+	MaxProducts int                 `json:"max_products"`
+	MaxBranches int                 `json:"max_branches"`
+	Features    []enums.PlanFeature `json:"features"`
+}
+
+// EstablishmentPlanResponse is the plan of a main establishment and how much of its quotas the
+// establishment and its branches use.
+type EstablishmentPlanResponse struct {
+	EstablishmentID uint                `json:"establishment_id"`
+	Name            string              `json:"name"`
+	Plan            PlanResponse        `json:"plan"`
+	Usage           []PlanUsageResponse `json:"usage"`
+	Version         uint                `json:"version"`
+}
+
+// PlanUsageResponse is how much of a quota of its plan an establishment uses. It can be over the
+// limit after moving to a smaller plan: what it has keeps working, but nothing new can be added.
+type PlanUsageResponse struct {
+	Quota     string `json:"quota"` // clients, products or branches
+	Used      int    `json:"used"`
+	Max       int    `json:"max"`        // 0 when unlimited, -1 when the plan allows none
+	NearLimit bool   `json:"near_limit"` // 80% or more of the quota is used
+	AtLimit   bool   `json:"at_limit"`   // No more can be added without an upgrade
+}
@@ -0,0 +1,63 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PlatformEstablishmentResponse is a main establishment as super-admins see it, with its branches.
+type PlatformEstablishmentResponse struct {
+	ID                 uint       `json:"id"`
+	Name               string     `json:"name"`
+	RUC                string     `json:"ruc"`
+	Status             string     `json:"status"` // ACTIVE, INACTIVE when its admin deactivated it, or SUSPENDED by the platform
+	Plan               enums.Plan `json:"plan"`
+	AdminID            uint       `json:"admin_id"`
+	AdminName          string     `json:"admin_name"`
+	AdminEmail         string     `json:"admin_email"`
+	Branches           int        `json:"branches"`
+	OpenCreditAccounts int        `json:"open_credit_accounts"`
+	SuspendedAt        *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason   string     `json:"suspension_reason,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	Version            uint       `json:"version"`
+}
+
+// PlatformMetricsResponse are the figures across every establishment of the platform. Activity is
+// over the last PeriodDays days.
+type PlatformMetricsResponse struct {
+	Establishments          int                `json:"establishments"` // Main establishments
+	ActiveEstablishments    int                `json:"active_establishments"`
+	InactiveEstablishments  int                `json:"inactive_establishments"`
+	SuspendedEstablishments int                `json:"suspended_establishments"`
+	Branches                int                `json:"branches"`
+	EstablishmentsByPlan    map[enums.Plan]int `json:"establishments_by_plan"`
+	Admins                  int                `json:"admins"`
+	Clients                 int                `json:"clients"`
+	OpenCreditAccounts      int                `json:"open_credit_accounts"`
+	Receivables             float64            `json:"receivables"`
+	WrittenOffBalance       float64            `json:"written_off_balance"`
+	PeriodDays              int                `json:"period_days"`
+	Purchases               int                `json:"purchases"`
+	PurchasesAmount         float64            `json:"purchases_amount"`
+	Payments                int                `json:"payments"`
+	PaymentsAmount          float64            `json:"payments_amount"`
+	NewCreditAccounts       int                `json:"new_credit_accounts"`
+	GeneratedAt             time.Time          `json:"generated_at"`
+}
+
+// PlatformAuditEntryResponse is a request made to the platform endpoints. Status is 0 while the
+// request is being handled, or if it never completed.
+type PlatformAuditEntryResponse struct {
+	ID          uint       `json:"id"`
+	ActorID     uint       `json:"actor_id"`
+	ActorRole   string     `json:"actor_role"`
+	Method      string     `json:"method"`
+	Route       string     `json:"route"`
+	Path        string     `json:"path"`
+	Status      int        `json:"status"`
+	IP          string     `json:"ip"`
+	UserAgent   string     `json:"user_agent"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+// ProductReportResponse shows how each product of an establishment sold over a period.
+type ProductReportResponse struct {
+	EstablishmentID uint                         `json:"establishment_id"`
+	Period          string                       `json:"period"`
+	StartDate       time.Time                    `json:"start_date"`
+	EndDate         time.Time                    `json:"end_date"`
+	TotalRevenue    float64                      `json:"total_revenue"`
+	TotalTaxable    float64                      `json:"total_taxable"` // Revenue before tax
+	TotalTax        float64                      `json:"total_tax"`
+	Products        []ProductPerformanceResponse `json:"products"`
+}
+
+type ProductPerformanceResponse struct {
+	ProductID        uint    `json:"product_id"`
+	Name             string  `json:"name"`
+	Category         string  `json:"category"`
+	UnitsSold        int     `json:"units_sold"`
+	Revenue          float64 `json:"revenue"`
+	CreditRevenue    float64 `json:"credit_revenue"`
+	CashRevenue      float64 `json:"cash_revenue"`
+	TaxableRevenue   float64 `json:"taxable_revenue"` // Revenue before tax
+	Tax              float64 `json:"tax"`
+	CreditPercentage float64 `json:"credit_percentage"` // Share of the revenue sold on credit
+	Stock            int     `json:"stock"`
+	StockTurnover    float64 `json:"stock_turnover"` // Units sold per unit currently in stock
+}
@@ -1,21 +1,22 @@
 package response
 
-import (
-	"ApiRestFinance/internal/model/entities/enums"
-	"time"
-)
+import "time"
 
 type ProductResponse struct {
 	ID            uint              `json:"id"`
 	EstablishmentID uint              `json:"establishment_id"`
 	Establishment   EstablishmentResponse `json:"establishment"`
 	Name          string            `json:"name"`
+	SKU           *string           `json:"sku"`
+	Barcode       *string           `json:"barcode"`
 	Description   string            `json:"description"`
-	Category      enums.ProductCategory `json:"category"`
+	CategoryID    *uint             `json:"category_id"`
+	Category      string            `json:"category"` // Name of the category
 	Price         float64           `json:"price"`
 	Stock         int               `json:"stock"`
 	ImageUrl      string            `json:"image_url"`
 	IsActive      bool              `json:"is_active"`
+	Version       uint              `json:"version"` // Send it back to update the product
 	CreatedAt     time.Time         `json:"created_at"`
 	UpdatedAt     time.Time         `json:"updated_at"`
 }
\ No newline at end of file
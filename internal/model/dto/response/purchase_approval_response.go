@@ -0,0 +1,32 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PurchaseApprovalResponse is a client purchase above the approval threshold of the establishment,
+// and the decision on it.
+type PurchaseApprovalResponse struct {
+	ID              uint                           `json:"id"`
+	CreditAccountID uint                           `json:"credit_account_id"`
+	EstablishmentID uint                           `json:"establishment_id"`
+	ClientID        uint                           `json:"client_id"`
+	ClientName      string                         `json:"client_name"`
+	Amount          float64                        `json:"amount"`
+	CreditType      enums.CreditType               `json:"credit_type"`
+	Items           []PurchaseApprovalItemResponse `json:"items"`
+	Status          enums.ApprovalStatus           `json:"status"`
+	ExpiresAt       time.Time                      `json:"expires_at"`
+	DecidedByID     *uint                          `json:"decided_by_id,omitempty"`
+	DecidedAt       *time.Time                     `json:"decided_at,omitempty"`
+	Reason          string                         `json:"reason,omitempty"`
+	TransactionID   *uint                          `json:"transaction_id,omitempty"` // Purchase made once approved
+	CreatedAt       time.Time                      `json:"created_at"`
+}
+
+// PurchaseApprovalItemResponse is a product line of a purchase waiting for approval.
+type PurchaseApprovalItemResponse struct {
+	ProductID uint `json:"product_id"`
+	Quantity  int  `json:"quantity"`
+}
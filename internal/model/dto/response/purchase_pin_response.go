@@ -0,0 +1,11 @@
+package response
+
+import "time"
+
+// PurchasePinResponse tells whether a client set a purchase PIN and whether it is locked. The PIN
+// itself is never returned.
+type PurchasePinResponse struct {
+	IsSet       bool       `json:"is_set"`
+	LockedUntil *time.Time `json:"locked_until"` // Set while too many wrong PINs keep it locked
+	UpdatedAt   *time.Time `json:"updated_at"`   // When the PIN was last set
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PurchaseQuoteResponse is what a credit purchase would cost the client and do to their account.
+type PurchaseQuoteResponse struct {
+	CreditAccountID      uint                        `json:"credit_account_id"`
+	CreditType           enums.CreditType            `json:"credit_type"`
+	Lines                []PurchaseQuoteLineResponse `json:"lines"`
+	TaxableAmount        float64                     `json:"taxable_amount"`
+	TaxAmount            float64                     `json:"tax_amount"`
+	Total                float64                     `json:"total"`            // Amount to make the purchase for
+	AvailableCredit      float64                     `json:"available_credit"` // Before the purchase
+	AvailableCreditAfter float64                     `json:"available_credit_after"`
+	Installments         []QuotedInstallmentResponse `json:"installments,omitempty"` // Long-term purchases only
+	CanPurchase          bool                        `json:"can_purchase"`
+	BlockingConditions   []PurchaseBlockingCondition `json:"blocking_conditions"`
+}
+
+// PurchaseQuoteLineResponse is a product line of a purchase quote.
+type PurchaseQuoteLineResponse struct {
+	ProductID     uint    `json:"product_id"`
+	Name          string  `json:"name"`
+	Quantity      int     `json:"quantity"`
+	UnitPrice     float64 `json:"unit_price"`
+	TaxRate       float64 `json:"tax_rate"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+	Total         float64 `json:"total"`
+}
+
+// QuotedInstallmentResponse is an installment a long-term purchase would be split into. Installments
+// the account credit would cover are already PAID.
+type QuotedInstallmentResponse struct {
+	DueDate time.Time               `json:"due_date"`
+	Amount  float64                 `json:"amount"`
+	Status  enums.InstallmentStatus `json:"status"`
+}
+
+// PurchaseBlockingCondition is a reason the purchase would be rejected.
+type PurchaseBlockingCondition struct {
+	Code    enums.PurchaseBlocker `json:"code"`
+	Message string                `json:"message"`
+}
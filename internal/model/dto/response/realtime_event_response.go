@@ -0,0 +1,15 @@
+package response
+
+import (
+	"ApiRestFinance/internal/event"
+	"time"
+)
+
+// RealtimeEventResponse is the data of an event sent on the realtime event stream.
+type RealtimeEventResponse struct {
+	Event           event.Name `json:"event"`
+	CreditAccountID uint       `json:"credit_account_id"`
+	ClientID        uint       `json:"client_id"`
+	EstablishmentID uint       `json:"establishment_id"`
+	OccurredAt      time.Time  `json:"occurred_at"`
+}
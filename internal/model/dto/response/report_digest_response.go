@@ -0,0 +1,67 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ReportDigestResponse sums up the activity of an establishment over a period, as emailed to its admin.
+// Only the sections asked for are set.
+type ReportDigestResponse struct {
+	EstablishmentID uint                       `json:"establishment_id"`
+	Name            string                     `json:"name"`
+	StartDate       time.Time                  `json:"start_date"`
+	EndDate         time.Time                  `json:"end_date"`
+	Collections     *DigestCollectionsResponse `json:"collections,omitempty"`
+	NewDebt         *DigestNewDebtResponse     `json:"new_debt,omitempty"`
+	Overdue         *DigestOverdueResponse     `json:"overdue,omitempty"`
+	TopDebtors      []DigestDebtorResponse     `json:"top_debtors,omitempty"`
+	SentTo          string                     `json:"sent_to,omitempty"` // Email the digest was sent to, empty for a preview
+}
+
+// DigestCollectionsResponse is what the clients of an establishment paid over a period.
+type DigestCollectionsResponse struct {
+	Payments  int     `json:"payments"`
+	Collected float64 `json:"collected"` // Confirmed payments
+	Pending   float64 `json:"pending"`   // Payments waiting for confirmation
+}
+
+// DigestNewDebtResponse is what the clients of an establishment bought on credit over a period.
+type DigestNewDebtResponse struct {
+	Purchases   int     `json:"purchases"`
+	Amount      float64 `json:"amount"`
+	NewAccounts int     `json:"new_accounts"` // Credit accounts opened
+	Charges     float64 `json:"charges"`      // Interest and late fees charged
+}
+
+// DigestOverdueResponse is how the overdue installments of an establishment changed over a period.
+type DigestOverdueResponse struct {
+	OverdueAccounts      int     `json:"overdue_accounts"` // Accounts with installments overdue at the end of the period
+	OverdueAmount        float64 `json:"overdue_amount"`
+	NewlyOverdue         int     `json:"newly_overdue"` // Installments due in the period and still unpaid
+	NewlyOverdueAmount   float64 `json:"newly_overdue_amount"`
+	SettledOverdue       int     `json:"settled_overdue"` // Installments overdue before the period and paid in it
+	SettledOverdueAmount float64 `json:"settled_overdue_amount"`
+}
+
+// DigestDebtorResponse is one of the credit accounts of an establishment owing the most.
+type DigestDebtorResponse struct {
+	CreditAccountID uint    `json:"credit_account_id"`
+	ClientID        uint    `json:"client_id"`
+	ClientName      string  `json:"client_name"`
+	Balance         float64 `json:"balance"`
+	Overdue         bool    `json:"overdue"`
+}
+
+// ReportDigestDeliveryResponse is the emailing of a scheduled report digest.
+type ReportDigestDeliveryResponse struct {
+	ID          uint                  `json:"id"`
+	Frequency   enums.DigestFrequency `json:"frequency"`
+	PeriodStart time.Time             `json:"period_start"`
+	PeriodEnd   time.Time             `json:"period_end"`
+	Email       string                `json:"email"`
+	Status      enums.DigestStatus    `json:"status"`
+	Attempts    int                   `json:"attempts"`
+	Error       string                `json:"error,omitempty"`
+	SentAt      *time.Time            `json:"sent_at,omitempty"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+// SessionResponse is a device the user is logged in on.
+type SessionResponse struct {
+	ID           uint      `json:"id"`
+	Device       string    `json:"device"` // Described from the user agent, e.g. "Chrome on Windows"
+	UserAgent    string    `json:"user_agent"`
+	IP           string    `json:"ip"`
+	Current      bool      `json:"current"` // The session of the request
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// SMSDeliveryResponse is one notification texted, or attempted to be, to a client.
+type SMSDeliveryResponse struct {
+	ID              uint                   `json:"id"`
+	CreditAccountID uint                   `json:"credit_account_id"`
+	ClientID        uint                   `json:"client_id"`
+	EstablishmentID uint                   `json:"establishment_id"`
+	Kind            enums.NotificationKind `json:"kind"`
+	Phone           string                 `json:"phone"`
+	Status          enums.SMSStatus        `json:"status"`
+	Error           string                 `json:"error,omitempty"`
+	SentAt          time.Time              `json:"sent_at"`
+	UpdatedAt       time.Time              `json:"updated_at"` // When the status was last reported
+}
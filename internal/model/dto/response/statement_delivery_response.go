@@ -0,0 +1,26 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// StatementEmailSettingsResponse tells whether monthly statements are emailed.
+type StatementEmailSettingsResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// StatementDeliveryResponse is one monthly statement emailed, or attempted to be, to a client.
+type StatementDeliveryResponse struct {
+	ID              uint                          `json:"id"`
+	CreditAccountID uint                          `json:"credit_account_id"`
+	ClientID        uint                          `json:"client_id"`
+	EstablishmentID uint                          `json:"establishment_id"`
+	PeriodStart     time.Time                     `json:"period_start"`
+	PeriodEnd       time.Time                     `json:"period_end"`
+	Email           string                        `json:"email"`
+	Status          enums.StatementDeliveryStatus `json:"status"`
+	Attempts        int                           `json:"attempts"`
+	Error           string                        `json:"error,omitempty"`
+	SentAt          *time.Time                    `json:"sent_at,omitempty"`
+}
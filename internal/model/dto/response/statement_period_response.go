@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+// StatementPeriodResponse is a closed billing cycle of a credit account, as frozen at its closing date.
+type StatementPeriodResponse struct {
+	ID              uint      `json:"id"`
+	CreditAccountID uint      `json:"credit_account_id"`
+	PeriodStart     time.Time `json:"period_start"`
+	PeriodEnd       time.Time `json:"period_end"`
+	OpeningBalance  float64   `json:"opening_balance"`
+	Purchases       float64   `json:"purchases"`
+	Payments        float64   `json:"payments"`
+	InterestAndFees float64   `json:"interest_and_fees"`
+	Adjustments     float64   `json:"adjustments"` // Manual adjustment debits less credits
+	ClosingBalance  float64   `json:"closing_balance"`
+	PurchaseCount   int       `json:"purchase_count"`
+	PaymentCount    int       `json:"payment_count"`
+	ClosedAt        time.Time `json:"closed_at"`
+}
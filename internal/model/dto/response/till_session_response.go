@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+// TillSessionResponse is a till session of an establishment, with the cash expected in the drawer.
+// While it is open, the figures are those collected so far.
+type TillSessionResponse struct {
+	ID              uint                  `json:"id"`
+	EstablishmentID uint                  `json:"establishment_id"`
+	Open            bool                  `json:"open"`
+	OpenedByID      uint                  `json:"opened_by_id"`
+	OpenedAt        time.Time             `json:"opened_at"`
+	ClosedByID      *uint                 `json:"closed_by_id,omitempty"`
+	ClosedAt        *time.Time            `json:"closed_at,omitempty"`
+	OpeningCash     float64               `json:"opening_cash"`
+	CashPayments    float64               `json:"cash_payments"`
+	PaymentCount    int                   `json:"payment_count"`
+	CashSales       float64               `json:"cash_sales"`
+	ExpectedCash    float64               `json:"expected_cash"`          // Opening cash plus the cash payments and sales
+	CountedCash     *float64              `json:"counted_cash,omitempty"` // Once closed
+	Discrepancy     *float64              `json:"discrepancy,omitempty"`  // Counted less expected cash, once closed
+	Notes           string                `json:"notes,omitempty"`
+	Payments        []TransactionResponse `json:"payments,omitempty"` // Cash payments collected, in the session report
+}
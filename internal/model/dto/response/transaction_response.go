@@ -13,8 +13,13 @@ type TransactionResponse struct {
 	Description     string                `json:"description"`
 	TransactionDate time.Time             `json:"transaction_date"`
 	PaymentMethod    enums.PaymentMethod   `json:"payment_method"` // Add PaymentMethod
-	PaymentCode      string                `json:"payment_code"`   // Add PaymentCode (if generated)
+	PaymentCode      string                `json:"payment_code"`   // Only to whoever made a payment through a payment link; it is sent to the client otherwise
+	PaymentCodeExpiresAt *time.Time        `json:"payment_code_expires_at,omitempty"` // When the payment expires unless confirmed
+	CodeSentTo       []enums.ContactChannel `json:"code_sent_to,omitempty"` // Channels the confirmation code reached the client on, for payments just made
 	PaymentStatus    enums.PaymentStatus   `json:"payment_status"` // Add PaymentStatus
+	DocumentNumber   string                `json:"document_number,omitempty"` // Receipt number, e.g. B001-000123
+	TaxableAmount    *float64              `json:"taxable_amount,omitempty"`  // Tax breakdown of purchases of products, in statements
+	TaxAmount        *float64              `json:"tax_amount,omitempty"`
 	CreatedAt       time.Time             `json:"created_at"`
 	UpdatedAt       time.Time             `json:"updated_at"`
 }
\ No newline at end of file
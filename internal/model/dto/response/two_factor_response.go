@@ -0,0 +1,14 @@
+package response
+
+// TwoFactorSetupResponse is the secret to add to an authenticator app. OtpauthURL is meant to be
+// shown as a QR code; Secret is for typing it in by hand.
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURL string `json:"otpauth_url"`
+}
+
+// RecoveryCodesResponse lists new recovery codes. They are only shown once, each can be used a
+// single time instead of an authenticator code.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
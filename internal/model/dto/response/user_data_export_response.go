@@ -0,0 +1,47 @@
+package response
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// UserDataExportResponse is all the data held about a client, as exported on their request. Admins
+// only export what concerns their establishment.
+type UserDataExportResponse struct {
+	ExportedAt          time.Time                    `json:"exported_at"`
+	User                UserResponse                 `json:"user"`
+	Contact             ContactVerificationResponse  `json:"contact_verification"`
+	AnonymizedAt        *time.Time                   `json:"anonymized_at,omitempty"`
+	CreditAccounts      []UserDataAccountResponse    `json:"credit_accounts"`
+	ClientSignups       []ClientSignupResponse       `json:"client_signups"`
+	Attachments         []AttachmentResponse         `json:"attachments"` // Their files are only in the ZIP export
+	StatementDeliveries []StatementDeliveryResponse  `json:"statement_deliveries"`
+	PaymentReminders    []PaymentReminderResponse    `json:"payment_reminders"`
+	SMSDeliveries       []SMSDeliveryResponse        `json:"sms_deliveries"`
+	Notifications       []ClientNotificationResponse `json:"notifications"`
+	Impersonations      []ImpersonationResponse      `json:"impersonations"`
+	Sessions            []SessionResponse            `json:"sessions,omitempty"` // Only in the export of every establishment
+}
+
+// UserDataAccountResponse is a credit account of the client with its whole history.
+type UserDataAccountResponse struct {
+	CreditAccount      CreditAccountResponse       `json:"credit_account"`
+	Agreement          *CreditAgreementResponse    `json:"agreement,omitempty"`
+	Transactions       []TransactionResponse       `json:"transactions"`
+	Installments       []InstallmentResponse       `json:"installments"`
+	PaymentPromises    []PaymentPromiseResponse    `json:"payment_promises"`
+	CollectionContacts []CollectionContactResponse `json:"collection_contacts"`
+	Activity           []ActivityResponse          `json:"activity"`
+}
+
+// PaymentReminderResponse is a payment reminder or overdue notice emailed to a client.
+type PaymentReminderResponse struct {
+	CreditAccountID uint                   `json:"credit_account_id"`
+	Kind            enums.NotificationKind `json:"kind"`
+	DueDate         time.Time              `json:"due_date"`
+	InstallmentID   uint                   `json:"installment_id,omitempty"`
+	DaysFromDue     int                    `json:"days_from_due"`
+	Email           string                 `json:"email"`
+	Amount          float64                `json:"amount"`
+	SentAt          time.Time              `json:"sent_at"`
+}
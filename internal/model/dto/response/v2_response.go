@@ -0,0 +1,36 @@
+package response
+
+// ErrorResponseV2 is the error body of the /api/v2 endpoints.
+type ErrorResponseV2 struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail carries a stable, machine readable code along with the human readable message.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ResponseV2 is the envelope the /api/v2 endpoints wrap their successful responses in. Links hold
+// the URL of the response itself ("self"), of the other pages of lists (first, prev, next and
+// last) and of related resources, e.g. the transactions of a credit account.
+type ResponseV2 struct {
+	Data  interface{}       `json:"data"`
+	Meta  *MetaV2           `json:"meta,omitempty"`
+	Links map[string]string `json:"links"`
+}
+
+// MetaV2 describes the data of a ResponseV2.
+type MetaV2 struct {
+	Pagination *PaginationMeta `json:"pagination,omitempty"` // Lists only
+}
+
+// PaginationMeta describes the page of a list returned in a ResponseV2. TotalItems and TotalPages are
+// left out when the whole list isn't known, e.g. for lists paginated in the database.
+type PaginationMeta struct {
+	Page       int  `json:"page"`
+	PageSize   int  `json:"page_size"`
+	TotalItems *int `json:"total_items,omitempty"`
+	TotalPages *int `json:"total_pages,omitempty"`
+	HasMore    bool `json:"has_more"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// AccountActivity is an entry of the ledger of a credit account: everything that changed what the
+// client owes or can spend. It is written in the same database transaction as the change.
+type AccountActivity struct {
+	ID              uint               `gorm:"primarykey"`
+	CreditAccountID uint               `gorm:"not null;index:idx_account_activities_account_occurred,priority:1"`
+	Type            enums.ActivityType `gorm:"type:text;not null"`
+	// Amount is the change to what the client owes: positive for charges, negative for payments
+	// and 0 for changes that move no money
+	Amount      float64 `gorm:"not null;default:0"`
+	Description string  `gorm:"type:text"`
+	// Balance and CreditLimit are the account's after the change. They are nil for the entries
+	// backfilled from the history recorded before the ledger existed.
+	Balance       *float64
+	CreditLimit   *float64
+	TransactionID *uint     // Purchase, payment, interest or late fee behind the entry
+	OccurredAt    time.Time `gorm:"not null;index:idx_account_activities_account_occurred,priority:2"`
+	CreatedAt     time.Time `gorm:"not null"`
+}
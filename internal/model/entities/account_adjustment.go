@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// AccountAdjustment is a manual charge or credit an admin made to the balance of a credit account.
+// Adjustments above the adjustment threshold of the establishment only change the balance once the
+// approver the establishment designated approves them.
+type AccountAdjustment struct {
+	ID              uint                   `gorm:"primarykey"`
+	CreditAccountID uint                   `gorm:"index;not null"`
+	EstablishmentID uint                   `gorm:"index;not null"`
+	Type            enums.TransactionType  `gorm:"type:text;not null"` // ADJUSTMENT_DEBIT or ADJUSTMENT_CREDIT
+	Amount          float64                `gorm:"not null"`
+	Reason          string                 `gorm:"type:text;not null"`
+	Status          enums.AdjustmentStatus `gorm:"type:text;not null;index"`
+	RequestedByID   uint                   `gorm:"not null"` // Admin who made it
+	DecidedByID     *uint                  // Approver who approved or rejected it, nil if it needed no approval
+	DecidedAt       *time.Time
+	RejectionReason string    `gorm:"type:text"`
+	TransactionID   *uint     // Adjustment transaction, once applied
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// Attachment is a document uploaded for a client of an establishment, e.g. a DNI scan, or for one of
+// their credit accounts, e.g. the signed agreement. The file is kept under the storage root until
+// RetainUntil.
+type Attachment struct {
+	ID              uint                 `gorm:"primarykey"`
+	EstablishmentID uint                 `gorm:"index:idx_attachments_establishment_client;not null"`
+	ClientID        uint                 `gorm:"index:idx_attachments_establishment_client;not null"`
+	CreditAccountID *uint                `gorm:"index"` // Nil for documents of the client rather than of an account
+	Kind            enums.AttachmentKind `gorm:"type:text;not null"`
+	FileName        string               `gorm:"not null"` // As uploaded
+	ContentType     string               `gorm:"not null"`
+	Size            int64                `gorm:"not null"`           // In bytes
+	SHA256          string               `gorm:"size:64;not null"`   // Hex digest of the file
+	Path            string               `gorm:"type:text;not null"` // Where the file is stored
+	UploadedByID    uint                 `gorm:"not null"`           // Admin who uploaded it
+	RetainUntil     time.Time            `gorm:"not null;index"`     // Kept at least until then, purged after
+	CreatedAt       time.Time            `gorm:"not null"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// BalanceSnapshot is the balance of a credit account at the close of a day, recorded nightly so balance
+// history doesn't have to be replayed from the transactions.
+type BalanceSnapshot struct {
+	ID                uint      `gorm:"primarykey"`
+	CreditAccountID   uint      `gorm:"not null;uniqueIndex:idx_balance_snapshots_account_date"`
+	EstablishmentID   uint      `gorm:"not null;index:idx_balance_snapshots_establishment_date"`
+	Date              time.Time `gorm:"not null;uniqueIndex:idx_balance_snapshots_account_date;index:idx_balance_snapshots_establishment_date"` // Start of the day in the establishment's time zone
+	Balance           float64   `gorm:"not null"`                                                                                               // Balance owed at the close of the day
+	AccountCredit     float64   `gorm:"not null;default:0"`
+	WrittenOffBalance float64   `gorm:"not null;default:0"`
+	CreditLimit       float64   `gorm:"not null"`
+	CreatedAt         time.Time `gorm:"not null"`
+}
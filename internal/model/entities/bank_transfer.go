@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// BankTransfer is a transfer the bank notified through the bank webhook, and what was done with it.
+// Notifications are told apart by the ID the bank gave them, so one delivered again is only applied
+// once.
+type BankTransfer struct {
+	ID              uint                     `gorm:"primarykey"`
+	ProviderID      string                   `gorm:"uniqueIndex:idx_bank_transfers_provider_id;not null"` // ID the bank gave the notification
+	Reference       string                   `gorm:"not null"`                                            // As the payer wrote it
+	Amount          float64                  `gorm:"not null"`
+	PayerName       string                   `gorm:"not null;default:''"`
+	Status          enums.BankTransferStatus `gorm:"type:text;not null"`
+	Detail          string                   `gorm:"not null;default:''"` // Why an unmatched transfer wasn't applied
+	EstablishmentID *uint                    `gorm:"index"`               // Of the credit account it was applied to, nil if unmatched
+	CreditAccountID *uint                    `gorm:"index"`
+	TransactionID   *uint                    // Payment it confirmed or was recorded as
+	TransferredAt   time.Time                `gorm:"not null"` // When the bank says the money was received
+	CreatedAt       time.Time                `gorm:"index;not null"`
+}
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// Category groups the products of an establishment. Establishments start with the categories of
+// enums.DefaultProductCategories.
+type Category struct {
+	ID              uint      `gorm:"primarykey"`
+	EstablishmentID uint      `gorm:"not null;uniqueIndex:idx_categories_establishment_name,priority:1"`
+	Name            string    `gorm:"not null;uniqueIndex:idx_categories_establishment_name,priority:2"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
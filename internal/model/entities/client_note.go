@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// ClientNote is a note an admin wrote about a client of their establishment, e.g. that they pay late
+// or prefer being reached by WhatsApp. Clients never see them.
+type ClientNote struct {
+	ID              uint      `gorm:"primarykey"`
+	EstablishmentID uint      `gorm:"not null;index:idx_client_notes_client,priority:1"`
+	ClientID        uint      `gorm:"not null;index:idx_client_notes_client,priority:2"`
+	AuthorID        uint      `gorm:"not null"` // Admin who wrote it
+	Author          *User     `gorm:"foreignKey:AuthorID;references:ID"`
+	Body            string    `gorm:"type:text;not null"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ClientNotification is a notification sent to a client, kept for them to read in the app whether or
+// not it reached them by email or text.
+type ClientNotification struct {
+	ID              uint                   `gorm:"primarykey"`
+	ClientID        uint                   `gorm:"not null;index:idx_client_notifications_client_created,priority:1"`
+	EstablishmentID uint                   `gorm:"index;not null"`
+	CreditAccountID uint                   `gorm:"index;not null"`
+	Kind            enums.NotificationKind `gorm:"type:text;not null"`
+	Message         string                 `gorm:"type:text;not null"`
+	ReadAt          *time.Time             // nil until the client reads it
+	CreatedAt       time.Time              `gorm:"not null;index:idx_client_notifications_client_created,priority:2"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ClientSignup is a client's self-registration with an establishment, made with its invite code. The
+// client can't buy on credit until an admin approves it, setting the terms of their credit account.
+type ClientSignup struct {
+	ID              uint               `gorm:"primarykey"`
+	EstablishmentID uint               `gorm:"index;not null"`
+	Establishment   *Establishment     `gorm:"foreignKey:EstablishmentID;references:ID"`
+	DNI             string             `gorm:"not null"`
+	Email           string             `gorm:"not null"`
+	Name            string             `gorm:"not null"`
+	Address         string             `gorm:"not null"`
+	Phone           string             `gorm:"not null"`
+	Password        string             `gorm:"not null"` // Hashed, becomes the password of the client once approved
+	Status          enums.SignupStatus `gorm:"type:text;not null;index"`
+	DecidedByID     *uint              // Admin who approved or rejected it
+	DecidedAt       *time.Time
+	Reason          string         `gorm:"type:text"` // Given by the admin who rejected it
+	CreditAccountID *uint          // Credit account opened, once approved
+	CreditAccount   *CreditAccount `gorm:"foreignKey:CreditAccountID;references:ID"`
+	CreatedAt       time.Time      `gorm:"not null"`
+	UpdatedAt       time.Time      `gorm:"not null"`
+}
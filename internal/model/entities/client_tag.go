@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// ClientTag is a label an establishment gives its clients, e.g. VIP or wholesale, to find them by.
+type ClientTag struct {
+	ID              uint      `gorm:"primarykey"`
+	EstablishmentID uint      `gorm:"not null;uniqueIndex:idx_client_tags_establishment_name,priority:1"`
+	Name            string    `gorm:"not null;uniqueIndex:idx_client_tags_establishment_name,priority:2"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+// ClientTagAssignment tags a client with a tag of an establishment they are a client of.
+type ClientTagAssignment struct {
+	ClientTagID uint      `gorm:"primaryKey"`
+	ClientID    uint      `gorm:"primaryKey;index"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
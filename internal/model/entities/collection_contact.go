@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CollectionContact is a call, visit or message an admin made to an overdue client to collect their
+// debt, and how it went.
+type CollectionContact struct {
+	ID              uint                        `gorm:"primarykey"`
+	CreditAccountID uint                        `gorm:"index:idx_collection_contacts_account,priority:1;not null"`
+	EstablishmentID uint                        `gorm:"index;not null"`
+	ClientID        uint                        `gorm:"not null"`
+	AdminID         uint                        `gorm:"not null"` // Admin who made the contact
+	Type            enums.CollectionContactType `gorm:"type:text;not null"`
+	Outcome         enums.CollectionOutcome     `gorm:"type:text;not null"`
+	Notes           string                      `gorm:"type:text"`
+	ContactedAt     time.Time                   `gorm:"not null;index:idx_collection_contacts_account,priority:2"`
+	CreatedAt       time.Time                   `gorm:"not null"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ContactVerification is the code sent to the email or phone of a user to confirm it is theirs. A
+// user has at most one per channel, replaced by each new code. Only its SHA-256 hash is stored.
+type ContactVerification struct {
+	ID          uint                 `gorm:"primarykey"`
+	UserID      uint                 `gorm:"not null;uniqueIndex:idx_contact_verifications_user_channel"`
+	Channel     enums.ContactChannel `gorm:"type:text;not null;uniqueIndex:idx_contact_verifications_user_channel"`
+	Destination string               `gorm:"not null"` // Email or phone the code was sent to
+	CodeHash    string               `gorm:"not null"`
+	Attempts    int                  `gorm:"not null;default:0"` // Wrong codes entered
+	ExpiresAt   time.Time            `gorm:"not null"`
+	SentAt      time.Time            `gorm:"not null"` // When the code was last sent, for the resend cooldown
+	CreatedAt   time.Time            `gorm:"not null"`
+}
@@ -8,20 +8,33 @@ import (
 
 type CreditAccount struct {
 	gorm.Model
-	ClientID                uint               `gorm:"index;not null"`
+	ClientID                uint               `gorm:"index;uniqueIndex:idx_client_establishment;not null"` // A client holds at most one account per establishment
 	Client                  *User            `gorm:"foreignKey:ClientID;references:ID"` // Client this account belongs to
-	EstablishmentID         uint               `gorm:"index;not null"`
+	EstablishmentID         uint               `gorm:"index;uniqueIndex:idx_client_establishment;not null"`
 	Establishment           *Establishment     `gorm:"foreignKey:EstablishmentID;references:ID"`
 	CreditLimit             float64            `gorm:"not null"`
 	CurrentBalance          float64            `gorm:"not null"` // Current balance owed
+	AccountCredit           float64            `gorm:"not null;default:0"` // Overpaid amount in the client's favor, applied to the next purchase
 	MonthlyDueDate          int                `gorm:"not null"` // Day of the month (1-31) when payment is due
 	InterestRate            float64            `gorm:"not null"` // Annual interest rate
 	InterestType            enums.InterestType `gorm:"not null"` // NOMINAL or EFFECTIVE
+	CompoundingPeriod       enums.CompoundingPeriod `gorm:"not null;default:'MONTHLY'"` // How often a NOMINAL rate is capitalized: DAILY, MONTHLY or QUARTERLY
 	CreditType              enums.CreditType   `gorm:"not null"` // SHORT_TERM or LONG_TERM
 	GracePeriod             int                `gorm:"default:0"` // Grace period in months (for LONG_TERM credit)
 	IsBlocked               bool               `gorm:"default:false"`
+	CreditScore             *int               // Internal credit score (0-1000), nil until the account is first scored
+	HighRisk                bool               `gorm:"not null;default:false"` // Score below the establishment's high-risk threshold
+	ScoredAt                *time.Time         // When the credit score was last calculated
 	LastInterestAccrualDate time.Time          `gorm:"not null"` // Date when interest was last applied
 	LateFeePercentage       float64            `gorm:"not null"` // Percentage for late fee calculation
+	SpendingLimit           float64              `gorm:"not null;default:0"` // Most the client may spend per period, 0 for no limit beyond the credit limit
+	SpendingLimitPeriod     enums.SpendingPeriod `gorm:"type:text;not null;default:'MONTHLY'"` // WEEKLY or MONTHLY
+	WrittenOffBalance       float64              `gorm:"not null;default:0"` // Written-off debt not recovered yet, kept out of the balance
+	WrittenOffAt            *time.Time           // When the balance was written off as bad debt, which freezes the account
+	Status                  enums.CreditAccountStatus `gorm:"type:text;not null;default:'ACTIVE'"` // ACTIVE, or CLOSED to purchases
+	ClosedAt                *time.Time                // When the account was closed, nil while it's active
+	CreditTemplateID        *uint                `gorm:"index"` // Template the account was opened with, nil if none or if it was deleted
+	Version                 uint                 `gorm:"not null;default:1"` // Incremented by each edit, for optimistic locking
 	CreatedAt               time.Time          `gorm:"not null"`
 	UpdatedAt               time.Time          `gorm:"not null"`
 }
\ No newline at end of file
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// CreditAccountBlockEvent records a credit account being blocked or unblocked.
+type CreditAccountBlockEvent struct {
+	ID              uint      `gorm:"primarykey"`
+	CreditAccountID uint      `gorm:"index;not null"`
+	Blocked         bool      `gorm:"not null"` // Whether the account was blocked or unblocked
+	Reason          string    `gorm:"type:text;not null"`
+	Automatic       bool      `gorm:"not null;default:false"` // Done by the system rather than an admin
+	ActorID         *uint     // Admin who blocked or unblocked the account, nil when automatic
+	Actor           *User     `gorm:"foreignKey:ActorID;references:ID"`
+	CreatedAt       time.Time `gorm:"not null"`
+}
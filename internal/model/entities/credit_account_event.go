@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CreditAccountEvent records a change to the terms or status of a credit account: its credit limit
+// or interest rate changing, or it being blocked, unblocked, closed or reopened. It is written in the
+// same database transaction as the change and never updated.
+type CreditAccountEvent struct {
+	ID              uint                         `gorm:"primarykey"`
+	CreditAccountID uint                         `gorm:"not null;index:idx_credit_account_events_account_created,priority:1"`
+	Type            enums.CreditAccountEventType `gorm:"type:text;not null"`
+	// OldValue and NewValue are the credit limit or interest rate before and after the change, and
+	// nil for the other types
+	OldValue  *float64
+	NewValue  *float64
+	Reason    string    `gorm:"type:text;not null"`
+	Automatic bool      `gorm:"not null;default:false"` // Done by the system rather than an admin
+	ActorID   *uint     // Admin who made the change, nil when automatic or not known
+	Actor     *User     `gorm:"foreignKey:ActorID;references:ID"`
+	CreatedAt time.Time `gorm:"not null;index:idx_credit_account_events_account_created,priority:2"`
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// CreditAccountWriteOff records the balance of a credit account written off as bad debt. The written-off
+// amount is kept out of the account balance, in its WrittenOffBalance, until payments recover it.
+type CreditAccountWriteOff struct {
+	ID              uint           `gorm:"primarykey"`
+	CreditAccountID uint           `gorm:"uniqueIndex;not null"` // An account is written off once
+	CreditAccount   *CreditAccount `gorm:"foreignKey:CreditAccountID;references:ID"`
+	EstablishmentID uint           `gorm:"index;not null"`
+	Amount          float64        `gorm:"not null"` // Balance owed when written off
+	Reason          string         `gorm:"type:text;not null"`
+	WrittenOffByID  uint           `gorm:"not null"` // Admin who wrote it off
+	CreatedAt       time.Time      `gorm:"not null"`
+}
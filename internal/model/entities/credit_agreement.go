@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CreditAgreement is the credit agreement of a credit account, with the terms the account was opened
+// with. The client has to accept it before making purchases; the acceptance records when, from where
+// and the hash of the text accepted.
+type CreditAgreement struct {
+	ID                uint                    `gorm:"primarykey"`
+	CreditAccountID   uint                    `gorm:"uniqueIndex;not null"` // An account has one agreement
+	CreditAccount     *CreditAccount          `gorm:"foreignKey:CreditAccountID;references:ID"`
+	CreditLimit       float64                 `gorm:"not null"`
+	InterestRate      float64                 `gorm:"not null"` // Annual interest rate
+	InterestType      enums.InterestType      `gorm:"type:text;not null"`
+	CompoundingPeriod enums.CompoundingPeriod `gorm:"type:text;not null"`
+	TCEA              float64                 `gorm:"not null"` // Total annual cost of credit, as a percentage
+	CreditType        enums.CreditType        `gorm:"type:text;not null"`
+	MonthlyDueDate    int                     `gorm:"not null"` // Day of the month when payment is due
+	GracePeriod       int                     `gorm:"not null;default:0"`
+	LateFeePercentage float64                 `gorm:"not null"`
+	AcceptedAt        *time.Time              `gorm:"index"` // Nil until the client accepts it
+	AcceptedIP        string                  `gorm:"type:text"`
+	AcceptedUserAgent string                  `gorm:"type:text"`
+	TermsHash         string                  `gorm:"type:text"` // SHA-256 of the agreement text the client accepted
+	CreatedAt         time.Time               `gorm:"not null"`
+	UpdatedAt         time.Time               `gorm:"not null"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// CreditTemplate is a set of credit terms an establishment opens credit accounts with, so admins
+// don't enter the same terms for each client. Accounts keep their terms when the template changes.
+type CreditTemplate struct {
+	ID                uint                    `gorm:"primarykey"`
+	EstablishmentID   uint                    `gorm:"not null;uniqueIndex:idx_credit_templates_establishment_name,priority:1"`
+	Name              string                  `gorm:"not null;uniqueIndex:idx_credit_templates_establishment_name,priority:2"`
+	CreditLimit       float64                 `gorm:"not null"`
+	MonthlyDueDate    int                     `gorm:"not null"`                   // Day of the month (1-31) when payment is due
+	InterestRate      float64                 `gorm:"not null;default:0"`         // Annual rate, 0 for the establishment's default rate
+	InterestType      enums.InterestType      `gorm:"not null"`                   // NOMINAL or EFFECTIVE
+	CompoundingPeriod enums.CompoundingPeriod `gorm:"not null;default:'MONTHLY'"` // How often a NOMINAL rate is capitalized
+	CreditType        enums.CreditType        `gorm:"not null"`                   // SHORT_TERM or LONG_TERM
+	GracePeriod       int                     `gorm:"not null;default:0"`         // Grace period in months (for LONG_TERM credit)
+	CreatedAt         time.Time               `gorm:"not null"`
+	UpdatedAt         time.Time               `gorm:"not null"`
+}
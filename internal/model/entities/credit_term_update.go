@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// CreditTermUpdate is a change an admin made to the terms of a set of credit accounts of an
+// establishment at once. Its changes record what it changed in each account.
+type CreditTermUpdate struct {
+	ID              uint               `gorm:"primarykey"`
+	EstablishmentID uint               `gorm:"index;not null"`
+	AdminID         uint               `gorm:"not null"`
+	Reason          string             `gorm:"type:text;not null"`
+	Filter          string             `gorm:"type:text;not null"` // Filter of the accounts it applied to, as JSON
+	Terms           string             `gorm:"type:text;not null"` // Terms it set, as JSON
+	Matched         int                `gorm:"not null"`           // Accounts the filter matched
+	Updated         int                `gorm:"not null"`           // Accounts it changed
+	Failed          int                `gorm:"not null"`           // Accounts it couldn't change
+	Changes         []CreditTermChange `gorm:"foreignKey:CreditTermUpdateID;references:ID"`
+	CreatedAt       time.Time          `gorm:"not null"`
+}
+
+// CreditTermChange records a term of a credit account a bulk update changed, from what to what.
+type CreditTermChange struct {
+	ID                 uint      `gorm:"primarykey"`
+	CreditTermUpdateID uint      `gorm:"index;not null"`
+	CreditAccountID    uint      `gorm:"index;not null"`
+	Field              string    `gorm:"type:text;not null"` // JSON name of the term, e.g. interest_rate
+	OldValue           string    `gorm:"type:text;not null"`
+	NewValue           string    `gorm:"type:text;not null"`
+	CreatedAt          time.Time `gorm:"not null"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// DocumentSeries is a series of sequentially numbered fiscal documents of an establishment, such as
+// B001 for receipts B001-000001, B001-000002 and so on. Each establishment numbers its transactions
+// from its active series of each document type.
+type DocumentSeries struct {
+	ID              uint               `gorm:"primarykey"`
+	EstablishmentID uint               `gorm:"not null;uniqueIndex:idx_document_series_establishment_code,priority:1;uniqueIndex:idx_document_series_active,priority:1,where:active"`
+	DocumentType    enums.DocumentType `gorm:"type:text;not null;uniqueIndex:idx_document_series_active,priority:2,where:active"`
+	Code            string             `gorm:"size:4;not null;uniqueIndex:idx_document_series_establishment_code,priority:2"` // e.g. B001
+	LastNumber      int64              `gorm:"not null;default:0"`                                                            // Last number issued, 0 if none
+	Active          bool               `gorm:"not null;default:false"`                                                        // Series new transactions are numbered from
+	CreatedAt       time.Time          `gorm:"not null"`
+	UpdatedAt       time.Time          `gorm:"not null"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// ElectronicInvoice is the electronic receipt of a purchase and its submission to SUNAT. The signed
+// XML is built on the first attempt and sent as is on the next ones.
+type ElectronicInvoice struct {
+	ID              uint                `gorm:"primarykey"`
+	TransactionID   uint                `gorm:"uniqueIndex;not null"`
+	Transaction     *Transaction        `gorm:"foreignKey:TransactionID;references:ID"`
+	EstablishmentID uint                `gorm:"index;not null"`
+	DocumentNumber  string              `gorm:"not null"` // Number of the transaction, e.g. B001-000123
+	Status          enums.InvoiceStatus `gorm:"type:text;not null;default:PENDING"`
+	SignedXML       string              `gorm:"type:text"`
+	DigestValue     string              // Hash of the signed XML, printed on the PDF
+	// CDR is the zipped receipt record SUNAT answered with, and ResponseCode and ResponseDescription
+	// its result, or those of the fault it rejected the document with
+	CDR                 []byte
+	ResponseCode        string
+	ResponseDescription string     `gorm:"type:text"`
+	Attempts            int        `gorm:"not null;default:0"`
+	NextAttemptAt       time.Time  `gorm:"not null;index:idx_electronic_invoices_pending,where:status = 'PENDING'"`
+	LastError           string     `gorm:"type:text"`
+	SubmittedAt         *time.Time // When SUNAT accepted or rejected it
+	CreatedAt           time.Time  `gorm:"not null"`
+	UpdatedAt           time.Time  `gorm:"not null"`
+}
@@ -0,0 +1,17 @@
+package enums
+
+// AccountingEvent is what the journal entries of the accounting export debit or credit, which each
+// establishment maps to an account of its chart of accounts.
+type AccountingEvent string
+
+const (
+	AccountingReceivables    AccountingEvent = "RECEIVABLES"     // What clients owe, on the other side of every entry
+	AccountingSales          AccountingEvent = "SALES"           // Purchases and manual charges
+	AccountingCash           AccountingEvent = "CASH"            // Payments collected
+	AccountingInterestIncome AccountingEvent = "INTEREST_INCOME" // Interest charged
+	AccountingLateFees       AccountingEvent = "LATE_FEES"       // Late fees charged
+	AccountingWriteOffs      AccountingEvent = "WRITE_OFFS"      // Balances written off as bad debt
+)
+
+// AccountingEvents are the accounting events, all of which must be mapped before the journal is exported.
+var AccountingEvents = []AccountingEvent{AccountingReceivables, AccountingSales, AccountingCash, AccountingInterestIncome, AccountingLateFees, AccountingWriteOffs}
@@ -0,0 +1,19 @@
+package enums
+
+type ActivityType string
+
+const (
+	ActivityPurchase         ActivityType = "PURCHASE"
+	ActivityPayment          ActivityType = "PAYMENT"
+	ActivityInterestCharge   ActivityType = "INTEREST_CHARGE"
+	ActivityLateFee          ActivityType = "LATE_FEE"
+	ActivityLimitChange      ActivityType = "LIMIT_CHANGE"
+	ActivityAccountBlocked   ActivityType = "ACCOUNT_BLOCKED"
+	ActivityAccountUnblocked ActivityType = "ACCOUNT_UNBLOCKED"
+	ActivityReversal         ActivityType = "REVERSAL"  // A purchase or payment was deleted
+	ActivityWriteOff         ActivityType = "WRITE_OFF" // The balance was written off as bad debt
+	ActivityAccountClosed    ActivityType = "ACCOUNT_CLOSED"
+	ActivityAccountReopened  ActivityType = "ACCOUNT_REOPENED"
+	ActivityReschedule       ActivityType = "INSTALLMENT_RESCHEDULED"
+	ActivityAdjustment       ActivityType = "ADJUSTMENT" // An admin charged or credited the balance by hand
+)
@@ -0,0 +1,10 @@
+package enums
+
+// AdjustmentStatus is the state of a manual adjustment of the balance of a credit account.
+type AdjustmentStatus string
+
+const (
+	AdjustmentPending  AdjustmentStatus = "PENDING_APPROVAL" // Above the adjustment threshold, waiting for the approver
+	AdjustmentApplied  AdjustmentStatus = "APPLIED"
+	AdjustmentRejected AdjustmentStatus = "REJECTED"
+)
@@ -0,0 +1,11 @@
+package enums
+
+// ApprovalStatus is the state of a purchase that needs an admin's approval.
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "PENDING_APPROVAL"
+	ApprovalApproved ApprovalStatus = "APPROVED"
+	ApprovalRejected ApprovalStatus = "REJECTED"
+	ApprovalExpired  ApprovalStatus = "EXPIRED" // Not decided within the approval days of the establishment
+)
@@ -0,0 +1,10 @@
+package enums
+
+// AttachmentKind is what a document attached to a client or credit account is.
+type AttachmentKind string
+
+const (
+	AttachmentDNIScan         AttachmentKind = "DNI_SCAN"
+	AttachmentCreditAgreement AttachmentKind = "CREDIT_AGREEMENT" // Signed credit agreement
+	AttachmentOther           AttachmentKind = "OTHER"
+)
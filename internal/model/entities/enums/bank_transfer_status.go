@@ -0,0 +1,10 @@
+package enums
+
+// BankTransferStatus is what was done with a transfer notified through the bank webhook.
+type BankTransferStatus string
+
+const (
+	BankTransferConfirmed BankTransferStatus = "CONFIRMED" // Confirmed the pending payment whose code it referenced
+	BankTransferRecorded  BankTransferStatus = "RECORDED"  // Recorded as a payment on the credit account it referenced
+	BankTransferUnmatched BankTransferStatus = "UNMATCHED" // Referenced no pending payment or credit account, left for an admin
+)
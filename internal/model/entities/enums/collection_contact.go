@@ -0,0 +1,21 @@
+package enums
+
+// CollectionContactType is how an admin got in touch with an overdue client to collect.
+type CollectionContactType string
+
+const (
+	CollectionCall    CollectionContactType = "CALL"
+	CollectionVisit   CollectionContactType = "VISIT"
+	CollectionMessage CollectionContactType = "MESSAGE" // SMS, WhatsApp or email
+)
+
+// CollectionOutcome is what came of a collection contact.
+type CollectionOutcome string
+
+const (
+	OutcomeReached       CollectionOutcome = "REACHED" // Spoke with the client without a commitment
+	OutcomeNoAnswer      CollectionOutcome = "NO_ANSWER"
+	OutcomePromisedToPay CollectionOutcome = "PROMISED_TO_PAY" // Record the promise itself as a payment promise
+	OutcomeRefused       CollectionOutcome = "REFUSED"         // The client refused to pay
+	OutcomeWrongContact  CollectionOutcome = "WRONG_CONTACT"   // The phone or address on file is no longer the client's
+)
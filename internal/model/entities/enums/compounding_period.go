@@ -0,0 +1,9 @@
+package enums
+
+type CompoundingPeriod string
+
+const (
+	Daily     CompoundingPeriod = "DAILY"
+	Monthly   CompoundingPeriod = "MONTHLY"
+	Quarterly CompoundingPeriod = "QUARTERLY"
+)
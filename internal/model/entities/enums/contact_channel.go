@@ -0,0 +1,9 @@
+package enums
+
+// ContactChannel is a way of reaching a user that has to be verified before it is used.
+type ContactChannel string
+
+const (
+	ContactEmail ContactChannel = "email"
+	ContactPhone ContactChannel = "phone" // Verified by SMS
+)
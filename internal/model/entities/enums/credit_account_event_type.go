@@ -0,0 +1,12 @@
+package enums
+
+type CreditAccountEventType string
+
+const (
+	CreditAccountLimitChanged CreditAccountEventType = "LIMIT_CHANGED"
+	CreditAccountRateChanged  CreditAccountEventType = "RATE_CHANGED" // The annual interest rate changed
+	CreditAccountBlocked      CreditAccountEventType = "BLOCKED"
+	CreditAccountUnblocked    CreditAccountEventType = "UNBLOCKED"
+	CreditAccountClosed       CreditAccountEventType = "CLOSED"
+	CreditAccountReopened     CreditAccountEventType = "REOPENED"
+)
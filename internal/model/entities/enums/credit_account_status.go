@@ -0,0 +1,9 @@
+package enums
+
+// CreditAccountStatus is whether a credit account is open for purchases.
+type CreditAccountStatus string
+
+const (
+	AccountActive CreditAccountStatus = "ACTIVE"
+	AccountClosed CreditAccountStatus = "CLOSED" // Closed by the client or an admin once paid off, kept with its history
+)
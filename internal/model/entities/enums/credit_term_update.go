@@ -0,0 +1,12 @@
+package enums
+
+// CreditTermAction is what a bulk update of credit terms does, or would do in preview, with an account
+// it matched.
+type CreditTermAction string
+
+const (
+	CreditTermUpdate    CreditTermAction = "UPDATE"
+	CreditTermUnchanged CreditTermAction = "UNCHANGED" // The account already has the new terms
+	CreditTermSkipped   CreditTermAction = "SKIPPED"   // Written off, its terms are frozen
+	CreditTermFailed    CreditTermAction = "FAILED"    // Couldn't be updated, see the reason
+)
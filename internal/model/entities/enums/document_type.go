@@ -0,0 +1,9 @@
+package enums
+
+// DocumentType is the kind of fiscal document a series numbers.
+type DocumentType string
+
+const (
+	DocumentReceipt DocumentType = "RECEIPT" // Boleta de venta, series starting with B
+	DocumentInvoice DocumentType = "INVOICE" // Factura, series starting with F
+)
@@ -0,0 +1,23 @@
+package enums
+
+// ImportConflictMode is what importing an establishment configuration does with the products the
+// establishment already has that differ from the imported ones.
+type ImportConflictMode string
+
+const (
+	ImportSkip      ImportConflictMode = "SKIP"      // Keep the existing product
+	ImportOverwrite ImportConflictMode = "OVERWRITE" // Replace it with the imported one
+	ImportFail      ImportConflictMode = "FAIL"      // Import nothing
+)
+
+// ImportAction is what importing an establishment configuration does, or would do, with an item of it.
+type ImportAction string
+
+const (
+	ImportCreate    ImportAction = "CREATE"
+	ImportUpdate    ImportAction = "UPDATE"
+	ImportUnchanged ImportAction = "UNCHANGED" // The establishment already has it as imported
+	ImportSkipped   ImportAction = "SKIPPED"   // Conflicts with an existing one, kept by the SKIP mode
+	ImportConflict  ImportAction = "CONFLICT"  // Conflicts with an existing one, failing the import in the FAIL mode
+	ImportInvalid   ImportAction = "INVALID"   // Can't be imported, see the reason
+)
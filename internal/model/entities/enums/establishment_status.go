@@ -0,0 +1,10 @@
+package enums
+
+// EstablishmentStatus is whether an establishment takes purchases and new clients.
+type EstablishmentStatus string
+
+const (
+	EstablishmentActive    EstablishmentStatus = "ACTIVE"
+	EstablishmentInactive  EstablishmentStatus = "INACTIVE"  // Deactivated by its admin, or a branch of a deactivated main establishment
+	EstablishmentSuspended EstablishmentStatus = "SUSPENDED" // Suspended by the platform, with its branches
+)
@@ -1,9 +1,18 @@
 package enums
 
+// InstallmentStatus is where an installment is in paying it off. Payments and the passing of its due
+// date move it between them, except to Cancelled, which only an admin does.
 type InstallmentStatus string
 
 const (
-	Pending InstallmentStatus = "PENDING"
-	Paid    InstallmentStatus = "PAID"
-	Overdue InstallmentStatus = "OVERDUE"
+	Pending       InstallmentStatus = "PENDING"
+	PartiallyPaid InstallmentStatus = "PARTIALLY_PAID" // Part of it was paid and the rest isn't due yet
+	Paid          InstallmentStatus = "PAID"
+	Overdue       InstallmentStatus = "OVERDUE"   // Not paid in full by the end of its due date
+	Cancelled     InstallmentStatus = "CANCELLED" // Forgiven by an admin, no longer owed
 )
+
+// IsOpen reports whether installments in the status are still owed.
+func (s InstallmentStatus) IsOpen() bool {
+	return s == Pending || s == PartiallyPaid || s == Overdue
+}
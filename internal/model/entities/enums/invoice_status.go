@@ -0,0 +1,11 @@
+package enums
+
+// InvoiceStatus is where an electronic invoice is in its submission to SUNAT.
+type InvoiceStatus string
+
+const (
+	InvoicePending  InvoiceStatus = "PENDING"  // Waiting to be submitted, or to be submitted again
+	InvoiceAccepted InvoiceStatus = "ACCEPTED" // Accepted by SUNAT, possibly with observations
+	InvoiceRejected InvoiceStatus = "REJECTED" // Rejected by SUNAT, it must be corrected with a new document
+	InvoiceFailed   InvoiceStatus = "FAILED"   // Given up on after failing to submit it too many times
+)
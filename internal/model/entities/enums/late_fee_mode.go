@@ -0,0 +1,10 @@
+package enums
+
+// LateFeeMode is how an establishment charges late fees on overdue credit accounts.
+type LateFeeMode string
+
+const (
+	LateFeeFlat  LateFeeMode = "FLAT"  // The late fee percentage of the overdue amount, once per billing cycle
+	LateFeeDaily LateFeeMode = "DAILY" // The late fee percentage of the overdue amount for every day it is overdue
+	LateFeeFixed LateFeeMode = "FIXED" // A fixed amount, once per billing cycle
+)
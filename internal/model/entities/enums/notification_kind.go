@@ -0,0 +1,13 @@
+package enums
+
+// NotificationKind is what a notification sent to a client is about.
+type NotificationKind string
+
+const (
+	PaymentReminderNotification     NotificationKind = "payment_reminder"
+	PaymentConfirmationNotification NotificationKind = "payment_confirmation"
+	OverdueNotification             NotificationKind = "overdue_notice"
+	PaymentLinkNotification         NotificationKind = "payment_link"
+	PaymentCodeNotification         NotificationKind = "payment_code"
+	BankTransferNotification        NotificationKind = "bank_transfer"
+)
@@ -0,0 +1,11 @@
+package enums
+
+// PaymentLinkStatus is the state of a link sent to a client to pay their credit account.
+type PaymentLinkStatus string
+
+const (
+	PaymentLinkCreated PaymentLinkStatus = "CREATED"
+	PaymentLinkViewed  PaymentLinkStatus = "VIEWED"  // The client opened it
+	PaymentLinkPaid    PaymentLinkStatus = "PAID"    // The payment made through it was confirmed
+	PaymentLinkExpired PaymentLinkStatus = "EXPIRED" // Not paid before it expired
+)
@@ -6,4 +6,5 @@ const (
 	YAPE  PaymentMethod = "YAPE"
 	PLIN  PaymentMethod = "PLIN"
 	CASH  PaymentMethod = "CASH"
+	BANK_TRANSFER PaymentMethod = "BANK_TRANSFER" // Notified by the bank through the bank webhook
 )
\ No newline at end of file
@@ -0,0 +1,19 @@
+package enums
+
+// Plan is what an establishment subscribes to, setting its limits and the features it has.
+type Plan string
+
+const (
+	PlanFree     Plan = "FREE"
+	PlanStandard Plan = "STANDARD"
+	PlanPremium  Plan = "PREMIUM"
+)
+
+// PlanFeature is a feature only some plans include.
+type PlanFeature string
+
+const (
+	FeaturePDFStatements   PlanFeature = "PDF_STATEMENTS"   // Account statements downloaded as PDF
+	FeatureStatementEmails PlanFeature = "STATEMENT_EMAILS" // Monthly statements emailed to clients
+	FeatureReportDigests   PlanFeature = "REPORT_DIGESTS"   // Report digests emailed to admins
+)
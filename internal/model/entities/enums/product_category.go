@@ -1,5 +1,7 @@
 package enums
 
+// ProductCategory names one of the categories establishments start with. They can rename them,
+// delete them or define their own.
 type ProductCategory string
 
 const (
@@ -12,3 +14,15 @@ const (
 	ProductCategoryLiquor       ProductCategory = "Liquor"
 	ProductCategoryGeneralStore ProductCategory = "GeneralStore"
 )
+
+// DefaultProductCategories are the categories new establishments are created with.
+var DefaultProductCategories = []ProductCategory{
+	ProductCategoryGrocery,
+	ProductCategoryFruitAndVeg,
+	ProductCategoryMeat,
+	ProductCategoryPoultry,
+	ProductCategorySeafood,
+	ProductCategoryBakery,
+	ProductCategoryLiquor,
+	ProductCategoryGeneralStore,
+}
@@ -0,0 +1,10 @@
+package enums
+
+// PromiseStatus is the state of a promise to pay negotiated with a client.
+type PromiseStatus string
+
+const (
+	PromiseActive PromiseStatus = "ACTIVE"
+	PromiseKept   PromiseStatus = "KEPT"   // The promised amount was paid by the promised date
+	PromiseBroken PromiseStatus = "BROKEN" // The promised date passed without the promised amount paid
+)
@@ -0,0 +1,14 @@
+package enums
+
+// PurchaseBlocker is a condition that keeps a credit purchase from going through.
+type PurchaseBlocker string
+
+const (
+	BlockAccountBlocked        PurchaseBlocker = "ACCOUNT_BLOCKED"
+	BlockAccountClosed         PurchaseBlocker = "ACCOUNT_CLOSED"
+	BlockEstablishmentInactive PurchaseBlocker = "ESTABLISHMENT_INACTIVE" // The establishment, or the main establishment of the branch, was deactivated
+	BlockAgreementPending      PurchaseBlocker = "AGREEMENT_NOT_ACCEPTED" // The client hasn't accepted the credit agreement yet
+	BlockCreditLimitExceeded   PurchaseBlocker = "CREDIT_LIMIT_EXCEEDED"
+	BlockHighRiskLimit         PurchaseBlocker = "HIGH_RISK_LIMIT_EXCEEDED" // Over the purchase limit of high-risk clients
+	BlockSpendingLimit         PurchaseBlocker = "SPENDING_LIMIT_EXCEEDED"  // Over what the client may still spend this week or month
+)
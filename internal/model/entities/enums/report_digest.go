@@ -0,0 +1,30 @@
+package enums
+
+// DigestFrequency is how often an establishment's admin is emailed its report digest.
+type DigestFrequency string
+
+const (
+	DigestOff     DigestFrequency = "OFF"
+	DigestWeekly  DigestFrequency = "WEEKLY"
+	DigestMonthly DigestFrequency = "MONTHLY"
+)
+
+// DigestSection is a part of the report digest admins can leave out.
+type DigestSection string
+
+const (
+	DigestCollections DigestSection = "collections" // Payments collected
+	DigestNewDebt     DigestSection = "new_debt"    // Credit purchases and new accounts
+	DigestOverdue     DigestSection = "overdue"     // Overdue accounts and the installments that fell overdue
+	DigestTopDebtors  DigestSection = "top_debtors" // Accounts owing the most
+)
+
+// DigestSections are the sections of a digest, in the order they are shown.
+var DigestSections = []DigestSection{DigestCollections, DigestNewDebt, DigestOverdue, DigestTopDebtors}
+
+type DigestStatus string
+
+const (
+	DigestSent   DigestStatus = "SENT"
+	DigestFailed DigestStatus = "FAILED"
+)
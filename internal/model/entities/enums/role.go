@@ -3,7 +3,8 @@ package enums
 type Role string
 
 const (
-	ADMIN  Role = "ADMIN"
-	CLIENT Role = "CLIENT"
-	USER   Role = "USER"
+	ADMIN      Role = "ADMIN"
+	CLIENT     Role = "CLIENT"
+	USER       Role = "USER"
+	SUPERADMIN Role = "SUPERADMIN" // Operates the platform, across establishments
 )
@@ -0,0 +1,10 @@
+package enums
+
+// SignupStatus is the state of a client's self-registration with an establishment.
+type SignupStatus string
+
+const (
+	SignupPending  SignupStatus = "PENDING"
+	SignupApproved SignupStatus = "APPROVED" // The client and their credit account were created
+	SignupRejected SignupStatus = "REJECTED"
+)
@@ -0,0 +1,12 @@
+package enums
+
+// SMSStatus is how far a text message got to its recipient, as last reported by the SMS provider.
+type SMSStatus string
+
+const (
+	SMSQueued      SMSStatus = "QUEUED" // Accepted by the provider
+	SMSSent        SMSStatus = "SENT"   // Handed to the carrier
+	SMSDelivered   SMSStatus = "DELIVERED"
+	SMSUndelivered SMSStatus = "UNDELIVERED" // Rejected by the carrier or the phone
+	SMSFailed      SMSStatus = "FAILED"      // Never sent
+)
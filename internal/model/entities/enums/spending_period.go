@@ -0,0 +1,9 @@
+package enums
+
+// SpendingPeriod is how often the spending limit of a credit account starts over.
+type SpendingPeriod string
+
+const (
+	SpendingWeekly  SpendingPeriod = "WEEKLY"  // Weeks start on Monday
+	SpendingMonthly SpendingPeriod = "MONTHLY" // Calendar months
+)
@@ -0,0 +1,8 @@
+package enums
+
+type StatementDeliveryStatus string
+
+const (
+	StatementSent   StatementDeliveryStatus = "SENT"
+	StatementFailed StatementDeliveryStatus = "FAILED"
+)
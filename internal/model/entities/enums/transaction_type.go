@@ -5,4 +5,27 @@ type TransactionType string
 const (
 	Purchase            TransactionType = "PURCHASE"
 	Payment             TransactionType = "PAYMENT"
+	InterestCharge      TransactionType = "INTEREST"          // Interest accrued on the balance or charged on a postponed installment
+	LateFeeCharge       TransactionType = "LATE_FEE"          // Late fee charged on an overdue billing cycle
+	AdjustmentDebit     TransactionType = "ADJUSTMENT_DEBIT"  // Manual charge an admin made, e.g. to correct an error
+	AdjustmentCredit    TransactionType = "ADJUSTMENT_CREDIT" // Manual credit an admin gave, e.g. a correction or goodwill credit
 )
+
+// IsCharge reports whether transactions of the type add to what the client owes, as payments subtract from it.
+func (t TransactionType) IsCharge() bool {
+	switch t {
+	case Purchase, InterestCharge, LateFeeCharge, AdjustmentDebit:
+		return true
+	}
+	return false
+}
+
+// IsCredit reports whether transactions of the type subtract from what the client owes.
+func (t TransactionType) IsCredit() bool {
+	return t == Payment || t == AdjustmentCredit
+}
+
+// IsAdjustment reports whether the type is a manual adjustment, which only an adjustment can make.
+func (t TransactionType) IsAdjustment() bool {
+	return t == AdjustmentDebit || t == AdjustmentCredit
+}
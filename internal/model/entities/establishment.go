@@ -1,21 +1,31 @@
 package entities
 
 import (
+	"ApiRestFinance/internal/model/entities/enums"
 	"gorm.io/gorm"
 	"time"
 )
 
 type Establishment struct {
 	gorm.Model
-	RUC               string `gorm:"uniqueIndex;not null"`
-	Name              string `gorm:"not null"`
-	Phone             string `gorm:"not null"`
-	Address           string `gorm:"not null"`
-	ImageUrl          string `gorm:"default:'https://st2.depositphotos.com/47577860/46265/v/450/depositphotos_462652902-stock-illustration-building-business-company-icon.jpg'"`
-	AdminID           uint
-	Admin             *User     `gorm:"foreignKey:AdminID;references:ID"`
-	IsActive          bool      `gorm:"not null"`
-	LateFeePercentage float64   `gorm:"null"` // Added Late Fee Percentage
-	CreatedAt         time.Time `gorm:"not null"`
-	UpdatedAt         time.Time `gorm:"not null"`
+	RUC                            string `gorm:"uniqueIndex:idx_establishments_main_ruc,where:parent_id IS NULL;not null"` // Branches share the RUC of their main establishment
+	Name                           string `gorm:"not null"`
+	Phone                          string `gorm:"not null"`
+	Address                        string `gorm:"not null"`
+	ImageUrl                       string `gorm:"default:'https://st2.depositphotos.com/47577860/46265/v/450/depositphotos_462652902-stock-illustration-building-business-company-icon.jpg'"`
+	AdminID                        uint
+	ParentID                       *uint      `gorm:"index"` // Main establishment of a branch, nil for a main establishment
+	Admin                          *User      `gorm:"foreignKey:AdminID;references:ID"`
+	IsActive                       bool       `gorm:"not null"`
+	LateFeePercentage              float64    `gorm:"null"`                              // Added Late Fee Percentage
+	Timezone                       string     `gorm:"not null;default:'America/Lima'"`   // IANA time zone used for due dates and reports
+	EarlyPaymentDiscountPercentage float64    `gorm:"default:0"`                         // Discount on the outstanding balance when a client pays off early
+	StatementEmailsEnabled         bool       `gorm:"not null;default:false"`            // Email clients their monthly statement at the closing date
+	InviteCode                     *string    `gorm:"uniqueIndex"`                       // Code clients self-register with, nil until an admin generates one
+	Plan                           enums.Plan `gorm:"type:text;not null;default:'FREE'"` // Branches are on the plan of their main establishment
+	SuspendedAt                    *time.Time // Set while the platform suspends a main establishment, with its branches
+	SuspensionReason               string     `gorm:"type:text;not null;default:''"`
+	Version                        uint       `gorm:"not null;default:1"` // Incremented by each edit, for optimistic locking
+	CreatedAt                      time.Time  `gorm:"not null"`
+	UpdatedAt                      time.Time  `gorm:"not null"`
 }
@@ -0,0 +1,43 @@
+package entities
+
+import "time"
+
+// EstablishmentSettings holds the business rules an establishment, main or branch, applies to its credit accounts.
+// Establishments without a row use the defaults of repository.DefaultEstablishmentSettings.
+type EstablishmentSettings struct {
+	ID                    uint      `gorm:"primarykey"`
+	EstablishmentID       uint      `gorm:"uniqueIndex;not null"`
+	MaxInstallments       int       `gorm:"not null;default:12"`    // Installments long-term purchases are split into
+	DefaultInterestRate   float64   `gorm:"not null;default:0"`     // Annual rate (%) of new accounts that don't set one, 0 for none
+	AutoBlockDaysOverdue  int       `gorm:"not null;default:0"`     // Days overdue after which accounts are blocked, 0 to never block
+	ReminderSchedule      string    `gorm:"not null;default:''"`    // Comma-separated days from the due date reminders, and overdue notices after it, are sent on, empty for none
+	HighRiskScore         int       `gorm:"not null;default:400"`   // Credit score below which clients are flagged high risk
+	HighRiskMaxPurchase   float64   `gorm:"not null;default:0"`     // Largest purchase a high-risk client may make, 0 for no cap
+	RequireAdminTwoFactor bool      `gorm:"not null;default:false"` // The establishment's admin must log in with two-factor authentication
+	TaxRate               float64   `gorm:"not null;default:18"`    // IGV rate (%) charged on sales
+	PricesExcludeTax      bool      `gorm:"not null;default:false"` // Product prices are before tax, which is added when they are sold
+	ApprovalThreshold     float64   `gorm:"not null;default:0"`     // Client purchases above it wait for an admin's approval, 0 to approve none
+	ApprovalExpiryDays    int       `gorm:"not null;default:3"`     // Days a purchase waits for approval before it expires
+	PinThreshold          float64   `gorm:"not null;default:0"`     // Purchases admins charge above it need the client's purchase PIN, 0 to never ask
+	PaymentCodeMinutes    int       `gorm:"not null;default:30"`    // Minutes a non-cash payment waits for its confirmation code before it expires
+	MaxReschedules        int       `gorm:"not null;default:3"`     // Installment reschedules a credit account may have, 0 to allow none
+	RescheduleInterest    bool      `gorm:"not null;default:false"` // Postponed installments are charged the account's interest for the extra days
+	LateFeeMode           string    `gorm:"not null;default:FLAT"`  // How late fees are charged, see enums.LateFeeMode
+	LateFeeAmount         float64   `gorm:"not null;default:0"`     // Fee charged per billing cycle in the FIXED mode
+	LateFeeCap            float64   `gorm:"not null;default:0"`     // Most a billing cycle is charged in late fees, as a percentage of its overdue amount, 0 for no cap
+	AdjustmentThreshold   float64   `gorm:"not null;default:0"`     // Manual adjustments above it need the approver's approval, 0 to approve none
+	AdjustmentApproverID  uint      `gorm:"not null;default:0"`     // Admin, other than the establishment's, who approves adjustments above the threshold
+	Language              string    `gorm:"not null;default:'es'"`  // Language of emails, PDFs and API messages for requests that don't ask for one
+	SMSNotifications      bool      `gorm:"not null;default:false"` // Payment reminders, confirmations, overdue notices, payment links and confirmation codes are also texted to verified phones
+	SMSSender             string    `gorm:"not null;default:''"`    // Number or sender ID texts come from, empty for the API's
+	DigestFrequency       string    `gorm:"not null;default:'OFF'"` // How often the admin is emailed a digest of the reports, see enums.DigestFrequency
+	DigestSections        string    `gorm:"not null;default:''"`    // Comma-separated sections of the digest, empty for all of them
+	ReceivablesAccount    string    `gorm:"not null;default:''"`    // Accounts of the accounting events of the journal export, empty until mapped, see enums.AccountingEvent
+	SalesAccount          string    `gorm:"not null;default:''"`
+	CashAccount           string    `gorm:"not null;default:''"`
+	InterestAccount       string    `gorm:"not null;default:''"`
+	LateFeeAccount        string    `gorm:"not null;default:''"`
+	WriteOffAccount       string    `gorm:"not null;default:''"`
+	CreatedAt             time.Time `gorm:"not null"`
+	UpdatedAt             time.Time `gorm:"not null"`
+}
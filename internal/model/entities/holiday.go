@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// Holiday is a day an establishment doesn't do business on. Due dates falling on a holiday or a
+// Sunday move to the next business day.
+type Holiday struct {
+	ID              uint      `gorm:"primarykey"`
+	EstablishmentID uint      `gorm:"not null;uniqueIndex:idx_holidays_establishment_date,priority:1"`
+	Date            time.Time `gorm:"type:date;not null;uniqueIndex:idx_holidays_establishment_date,priority:2"` // At midnight UTC
+	Name            string    `gorm:"type:text;not null"`
+	National        bool      `gorm:"not null;default:false"` // Added from the national holidays of Peru
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// Impersonation is an admin seeing the API as one of the clients of their establishment, with a
+// short-lived token restricted to read-only client endpoints. Every request made with it is audited.
+type Impersonation struct {
+	ID              uint                   `gorm:"primarykey"`
+	AdminID         uint                   `gorm:"index;not null"`
+	ClientID        uint                   `gorm:"index;not null"`
+	EstablishmentID uint                   `gorm:"not null"` // Establishment whose account of the client is seen
+	Reason          string                 `gorm:"type:text;not null;default:''"`
+	IP              string                 `gorm:"not null;default:''"` // Of the admin starting it
+	UserAgent       string                 `gorm:"not null;default:''"`
+	ExpiresAt       time.Time              `gorm:"not null"`
+	EndedAt         *time.Time             // When the admin stopped it, nil otherwise
+	CreatedAt       time.Time              `gorm:"not null"`
+	Requests        []ImpersonationRequest `gorm:"foreignKey:ImpersonationID"`
+}
+
+// ImpersonationRequest is the audit entry of a request made with an impersonation token, allowed or not.
+type ImpersonationRequest struct {
+	ID              uint      `gorm:"primarykey"`
+	ImpersonationID uint      `gorm:"index;not null"`
+	Method          string    `gorm:"not null"`
+	Path            string    `gorm:"type:text;not null"` // With its query string
+	Status          int       `gorm:"not null"`
+	IP              string    `gorm:"not null;default:''"`
+	CreatedAt       time.Time `gorm:"not null"`
+}
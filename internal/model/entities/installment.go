@@ -12,5 +12,9 @@ type Installment struct {
 	CreditAccount   *CreditAccount           `gorm:"foreignKey:CreditAccountID;references:ID"`
 	DueDate         time.Time               `gorm:"not null"` // Due date of the installment
 	Amount          float64                 `gorm:"not null"`
-	Status          enums.InstallmentStatus `gorm:"not null;default:PENDING"` // PENDING, PAID, OVERDUE
+	Status          enums.InstallmentStatus `gorm:"not null;default:PENDING"` // PENDING, PARTIALLY_PAID, PAID, OVERDUE or CANCELLED
+	OverdueAt       *time.Time              // When its due date ended unpaid, nil if it hasn't or it was rescheduled since
+	PaidAt          *time.Time              // When it was paid in full
+	CancelledAt     *time.Time
+	Version         uint                    `gorm:"not null;default:1"`       // Incremented by each edit, for optimistic locking
 }
\ No newline at end of file
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// InstallmentReschedule is a due date of an installment an admin moved, having agreed it with the
+// client. The installment keeps the new date and its reschedules the dates it had before.
+type InstallmentReschedule struct {
+	ID              uint      `gorm:"primarykey"`
+	InstallmentID   uint      `gorm:"index;not null"`
+	CreditAccountID uint      `gorm:"index;not null"` // Reschedules are limited per account
+	OriginalDueDate time.Time `gorm:"not null"`       // Due date of the installment before this reschedule
+	NewDueDate      time.Time `gorm:"not null"`
+	Interest        float64   `gorm:"not null;default:0"` // Added to the installment for the days it was postponed, if the establishment charges it
+	Reason          string    `gorm:"type:text;not null"`
+	RescheduledByID uint      `gorm:"not null"` // Admin who agreed it
+	CreatedAt       time.Time `gorm:"not null"`
+}
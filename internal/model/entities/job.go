@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// Job is work run in the background, such as rendering a PDF or sending an email, along with its
+// outcome. Jobs requested by a user can be followed and downloaded by them; the ones the API
+// schedules itself have no owner.
+type Job struct {
+	ID      uint            `gorm:"primarykey"`
+	Type    string          `gorm:"not null"`
+	OwnerID uint            `gorm:"index;not null;default:0"` // 0 for jobs the API schedules itself
+	Status  enums.JobStatus `gorm:"not null;index"`
+	// DedupKey keeps the same work from being queued twice: only one unfinished job may have a given key
+	DedupKey          *string `gorm:"uniqueIndex:idx_jobs_pending_dedup_key,where:finished_at IS NULL"`
+	Payload           string  `gorm:"type:jsonb;not null;default:'{}'"` // Job-specific input
+	Result            []byte  `gorm:"type:bytea"`
+	ResultContentType string
+	ResultFilename    string
+	Error             string `gorm:"type:text"`
+	Attempts          int    `gorm:"not null;default:0"`
+	StartedAt         *time.Time
+	FinishedAt        *time.Time
+	CreatedAt         time.Time `gorm:"not null"`
+	UpdatedAt         time.Time `gorm:"not null"`
+}
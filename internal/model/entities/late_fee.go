@@ -5,10 +5,14 @@ import (
     "time"
 )
 
+// LateFee is a late fee charged to a credit account. A billing cycle is charged at most its fee, whatever
+// the number of times late fees are applied to it.
 type LateFee struct {
     gorm.Model
     CreditAccountID uint       `gorm:"index;not null"`
     CreditAccount   CreditAccount `gorm:"foreignKey:CreditAccountID;references:ID"`
     Amount          float64    `gorm:"not null"`       // Amount of the late fee
     AppliedDate     time.Time  `gorm:"not null"`      // Date when the late fee was applied
-}
\ No newline at end of file
+    CycleDueDate    time.Time  `gorm:"not null;index"` // Due date of the billing cycle the fee is charged for
+    DaysOverdue     int        `gorm:"not null"`       // Days the cycle was overdue when the fee was applied
+}
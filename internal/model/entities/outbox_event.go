@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// OutboxEvent is a domain event waiting to be relayed to webhooks. It is written in the same database
+// transaction as the change it describes, so an event is only lost if the change is lost too.
+type OutboxEvent struct {
+	ID              uint       `gorm:"primarykey"`
+	Name            string     `gorm:"not null"`
+	CreditAccountID uint       `gorm:"index;not null"`
+	Payload         string     `gorm:"type:jsonb;not null;default:'{}'"` // Event-specific data
+	OccurredAt      time.Time  `gorm:"not null"`
+	DeliveredAt     *time.Time // nil until every publisher accepted the event
+	Attempts        int        `gorm:"not null;default:0"`
+	NextAttemptAt   time.Time  `gorm:"not null;index:idx_outbox_events_pending,where:delivered_at IS NULL"`
+	LastError       string     `gorm:"type:text"`
+	CreatedAt       time.Time  `gorm:"not null"`
+}
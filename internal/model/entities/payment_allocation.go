@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// PaymentAllocation is the part of a payment that went to an installment. Payments cover the unpaid
+// installments of their credit account by due date, and whatever exceeds them pays the rest of the
+// balance. The allocations of a payment are deleted if it is deleted or fails.
+type PaymentAllocation struct {
+	ID              uint      `gorm:"primarykey"`
+	TransactionID   uint      `gorm:"index;not null"` // Payment
+	InstallmentID   uint      `gorm:"index;not null"`
+	CreditAccountID uint      `gorm:"index;not null"`
+	Amount          float64   `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"not null"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PaymentLink is a link an admin sent to the client of a credit account to pay an amount, one of the
+// account's installments or one the admin chose, or the link printed as a QR code on the account's
+// statements to pay its balance. Paying through it makes a pending payment on the
+// account, and the link is marked paid once that payment is confirmed.
+type PaymentLink struct {
+	ID              uint                    `gorm:"primarykey"`
+	CreditAccountID uint                    `gorm:"index;not null"`
+	CreditAccount   *CreditAccount          `gorm:"foreignKey:CreditAccountID;references:ID"`
+	EstablishmentID uint                    `gorm:"index;not null"`
+	InstallmentID   *uint                   // Installment the amount was taken from, if any
+	Amount          float64                 `gorm:"not null"`
+	Status          enums.PaymentLinkStatus `gorm:"type:text;not null"`
+	ExpiresAt       time.Time               `gorm:"not null;index:idx_payment_links_open,where:status IN ('CREATED','VIEWED')"`
+	ViewedAt        *time.Time              // First time the client opened it
+	TransactionID   *uint                   // Payment made through it
+	Transaction     *Transaction            `gorm:"foreignKey:TransactionID;references:ID"`
+	PaidAt          *time.Time
+	CreatedByID     uint      `gorm:"not null"` // Admin who sent it, 0 for the links printed on statements
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
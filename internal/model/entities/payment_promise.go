@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PaymentPromise is a promise to pay negotiated by an admin with an overdue client. Late fees are
+// suspended while it is active, and it is marked kept or broken once its date passes.
+type PaymentPromise struct {
+	ID              uint                `gorm:"primarykey"`
+	CreditAccountID uint                `gorm:"index;not null;uniqueIndex:idx_payment_promises_account_active,where:status = 'ACTIVE'"` // One active promise per account
+	CreditAccount   *CreditAccount      `gorm:"foreignKey:CreditAccountID;references:ID"`
+	Amount          float64             `gorm:"not null"`
+	PromisedDate    time.Time           `gorm:"not null;index:idx_payment_promises_active,where:status = 'ACTIVE'"` // Start of the promised day in the establishment's time zone
+	Status          enums.PromiseStatus `gorm:"type:text;not null"`
+	Paid            float64             `gorm:"not null;default:0"` // Paid from the promise up to the promised date, once resolved
+	CreatedByID     uint                `gorm:"not null"`           // Admin who negotiated it
+	ResolvedAt      *time.Time
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
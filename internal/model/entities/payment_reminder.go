@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PaymentReminder records the reminder sent to a client ahead of one of their account's due dates,
+// or the overdue notice sent after it, on a day of the reminder schedule of its establishment. Long-term
+// accounts have one per installment reminded of. Email is empty for those that were only texted.
+type PaymentReminder struct {
+	ID              uint                   `gorm:"primarykey"`
+	CreditAccountID uint                   `gorm:"not null;uniqueIndex:idx_payment_reminders_due"`
+	DueDate         time.Time              `gorm:"not null;uniqueIndex:idx_payment_reminders_due"`
+	Kind            enums.NotificationKind `gorm:"type:text;not null;default:'payment_reminder';uniqueIndex:idx_payment_reminders_due"`
+	InstallmentID   uint                   `gorm:"not null;default:0;uniqueIndex:idx_payment_reminders_due"` // 0 for the monthly due date of short-term accounts
+	DaysFromDue     int                    `gorm:"not null;default:0;uniqueIndex:idx_payment_reminders_due"` // Day of the schedule it was sent on, negative before the due date
+	Email           string                 `gorm:"not null"`
+	Amount          float64                `gorm:"not null"`
+	SentAt          time.Time              `gorm:"not null"`
+}
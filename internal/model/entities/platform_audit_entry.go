@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// PlatformAuditEntry is the audit entry of a request to the platform endpoints, allowed or not. It is
+// recorded before the request is handled, which doesn't happen if it can't be, and completed with
+// the status it was answered with.
+type PlatformAuditEntry struct {
+	ID          uint       `gorm:"primarykey"`
+	ActorID     uint       `gorm:"index;not null"` // User making the request, of any role
+	ActorRole   string     `gorm:"not null"`
+	Method      string     `gorm:"not null"`
+	Route       string     `gorm:"not null"`           // Route pattern, e.g. /api/v1/platform/establishments/:id/suspend
+	Path        string     `gorm:"type:text;not null"` // As requested, with its query string
+	Status      int        `gorm:"not null;default:0"` // 0 while the request is being handled
+	IP          string     `gorm:"not null;default:''"`
+	UserAgent   string     `gorm:"not null;default:''"`
+	CreatedAt   time.Time  `gorm:"index;not null"`
+	CompletedAt *time.Time // When the request was answered
+}
@@ -1,7 +1,6 @@
 package entities
 
 import (
-	"ApiRestFinance/internal/model/entities/enums"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,15 +8,19 @@ import (
 
 type Product struct {
 	gorm.Model
-	EstablishmentID uint       `gorm:"not null"`
+	EstablishmentID uint       `gorm:"not null;uniqueIndex:idx_products_establishment_sku,priority:1,where:deleted_at IS NULL;uniqueIndex:idx_products_establishment_barcode,priority:1,where:deleted_at IS NULL"`
 	Establishment   Establishment `gorm:"foreignKey:EstablishmentID;references:ID"`
 	Name          string  `gorm:"not null"`
-	Category      enums.ProductCategory `gorm:"not null"`
+	SKU           *string   `gorm:"uniqueIndex:idx_products_establishment_sku,priority:2"`     // Unique among the products of the establishment
+	Barcode       *string   `gorm:"uniqueIndex:idx_products_establishment_barcode,priority:2"` // EAN-13, unique among the products of the establishment
+	CategoryID    *uint     `gorm:"index"` // Only deleted products may lose their category, if it is deleted too
+	Category      *Category `gorm:"foreignKey:CategoryID;references:ID"`
 	Description   string  `gorm:"not null"`
 	Price         float64 `gorm:"not null"`
 	Stock         int     `gorm:"not null"`
 	ImageUrl      string  `gorm:"default:'https://rahulindesign.websites.co.in/twenty-nineteen/img/defaults/product-default.png'"`
 	IsActive      bool    `gorm:"not null"`
+	Version       uint    `gorm:"not null;default:1"` // Incremented by each edit, for optimistic locking
 	CreatedAt     time.Time `gorm:"not null"`
 	UpdatedAt     time.Time `gorm:"not null"`
 }
\ No newline at end of file
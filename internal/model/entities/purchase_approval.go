@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// PurchaseApproval is a purchase a client made above the approval threshold of the establishment. It
+// doesn't affect the balance of the account unless an admin approves it before it expires.
+type PurchaseApproval struct {
+	ID              uint                 `gorm:"primarykey"`
+	CreditAccountID uint                 `gorm:"index;not null"`
+	CreditAccount   *CreditAccount       `gorm:"foreignKey:CreditAccountID;references:ID"`
+	EstablishmentID uint                 `gorm:"index;not null"`
+	Amount          float64              `gorm:"not null"`
+	CreditType      enums.CreditType     `gorm:"type:text;not null"`
+	Items           string               `gorm:"type:text;not null"` // JSON product lines of the purchase, priced when it is approved
+	Status          enums.ApprovalStatus `gorm:"type:text;not null;index"`
+	ExpiresAt       time.Time            `gorm:"not null;index:idx_purchase_approvals_pending,where:status = 'PENDING_APPROVAL'"`
+	DecidedByID     *uint                // Admin who approved or rejected it
+	DecidedAt       *time.Time
+	Reason          string       `gorm:"type:text"` // Given by the admin who rejected it
+	TransactionID   *uint        // Purchase transaction, once approved
+	Transaction     *Transaction `gorm:"foreignKey:TransactionID;references:ID"`
+	CreatedAt       time.Time    `gorm:"not null"`
+	UpdatedAt       time.Time    `gorm:"not null"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// PurchaseItem is one product line of a sale, bought either on credit or paid in cash.
+type PurchaseItem struct {
+	gorm.Model
+	EstablishmentID uint         `gorm:"index;not null"`
+	TransactionID   *uint        `gorm:"index"` // Purchase transaction of credit sales, nil for cash sales
+	Transaction     *Transaction `gorm:"foreignKey:TransactionID;references:ID"`
+	ProductID       uint         `gorm:"index;not null"`
+	Product         *Product     `gorm:"foreignKey:ProductID;references:ID"`
+	Quantity        int          `gorm:"not null"`
+	UnitPrice       float64      `gorm:"not null"`           // Product price at the time of the sale, before tax if the establishment prices exclude it
+	Total           float64      `gorm:"not null"`           // Amount charged, tax included
+	TaxRate         float64      `gorm:"not null;default:0"` // Rate (%) the item was taxed at
+	TaxableAmount   float64      `gorm:"not null;default:0"`
+	TaxAmount       float64      `gorm:"not null;default:0"`
+	IsCredit        bool         `gorm:"not null"`
+	SoldAt          time.Time    `gorm:"index;not null"`
+	TillSessionID   *uint        `gorm:"index"` // Till session a cash sale was collected in, nil if none was open
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// PurchasePin is the PIN a client confirms the purchases admins charge to their credit accounts
+// with, above the PIN threshold of the establishment. Only its bcrypt hash is stored.
+type PurchasePin struct {
+	ID             uint       `gorm:"primarykey"`
+	UserID         uint       `gorm:"uniqueIndex;not null"`
+	PinHash        string     `gorm:"not null"`
+	FailedAttempts int        `gorm:"not null;default:0"` // Wrong PINs entered since the last right one or lockout
+	LockedUntil    *time.Time // Set after too many wrong PINs, no PIN is accepted until then
+	CreatedAt      time.Time  `gorm:"not null"`
+	UpdatedAt      time.Time  `gorm:"not null"` // When the PIN was last set
+}
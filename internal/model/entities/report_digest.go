@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportDigest records the emailing of the scheduled report digest of an establishment for a period.
+type ReportDigest struct {
+	gorm.Model
+	EstablishmentID uint                  `gorm:"not null;uniqueIndex:idx_report_digests_period"`
+	Frequency       enums.DigestFrequency `gorm:"type:text;not null;uniqueIndex:idx_report_digests_period"`
+	PeriodStart     time.Time             `gorm:"not null;uniqueIndex:idx_report_digests_period"`
+	PeriodEnd       time.Time             `gorm:"not null"`
+	Email           string                `gorm:"not null"`
+	Status          enums.DigestStatus    `gorm:"type:text;not null"`
+	Attempts        int                   `gorm:"not null;default:0"`
+	Error           string                // Last sending error of a failed digest
+	SentAt          *time.Time
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// Session is a login of a user on a device. It lasts as long as its refresh token is renewed, until
+// it expires or is revoked.
+type Session struct {
+	ID             uint       `gorm:"primarykey"`
+	UserID         uint       `gorm:"index;not null"`
+	RefreshTokenID string     `gorm:"not null"` // ID (jti) of the latest refresh token, the only one accepted
+	UserAgent      string     `gorm:"not null;default:''"`
+	IP             string     `gorm:"not null;default:''"` // Of the last login or refresh
+	LastActiveAt   time.Time  `gorm:"not null"`            // Last login or refresh
+	ExpiresAt      time.Time  `gorm:"index;not null"`
+	RevokedAt      *time.Time // Nil while the session can be refreshed
+	CreatedAt      time.Time  `gorm:"not null"`
+}
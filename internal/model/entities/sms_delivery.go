@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+)
+
+// SMSDelivery records a notification texted to the client of a credit account and how far it got,
+// as last reported by the SMS provider.
+type SMSDelivery struct {
+	ID              uint                   `gorm:"primarykey"`
+	EstablishmentID uint                   `gorm:"index;not null"`
+	ClientID        uint                   `gorm:"index;not null"`
+	CreditAccountID uint                   `gorm:"index;not null"`
+	Kind            enums.NotificationKind `gorm:"type:text;not null"`
+	Phone           string                 `gorm:"not null"`
+	ProviderID      string                 `gorm:"index"` // ID the provider gave the message, which its status reports refer to
+	Status          enums.SMSStatus        `gorm:"type:text;not null"`
+	Error           string                 // Sending error, or the provider's error code of undelivered messages
+	SentAt          time.Time              `gorm:"not null"`
+	UpdatedAt       time.Time              `gorm:"not null"`
+}
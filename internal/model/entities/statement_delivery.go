@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatementDelivery records the emailing of a credit account's monthly statement.
+type StatementDelivery struct {
+	gorm.Model
+	CreditAccountID uint                          `gorm:"not null;uniqueIndex:idx_statement_deliveries_period"`
+	ClientID        uint                          `gorm:"index;not null"`
+	EstablishmentID uint                          `gorm:"index;not null"`
+	PeriodStart     time.Time                     `gorm:"not null"`
+	PeriodEnd       time.Time                     `gorm:"not null;uniqueIndex:idx_statement_deliveries_period"` // Closing date of the statement
+	Email           string                        `gorm:"not null"`
+	Status          enums.StatementDeliveryStatus `gorm:"type:text;not null"`
+	Attempts        int                           `gorm:"not null;default:0"`
+	Error           string                        // Last sending error of a failed delivery
+	SentAt          *time.Time
+}
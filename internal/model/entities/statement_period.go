@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatementPeriod freezes the figures of a credit account's billing cycle when it closes, so
+// statements don't change when transactions are edited afterwards. Periods are never updated.
+type StatementPeriod struct {
+	gorm.Model
+	CreditAccountID uint      `gorm:"not null;uniqueIndex:idx_statement_periods_account_end"`
+	PeriodStart     time.Time `gorm:"not null"`
+	PeriodEnd       time.Time `gorm:"not null;uniqueIndex:idx_statement_periods_account_end"` // Closing date, excluded from the period
+	OpeningBalance  float64   `gorm:"not null"`
+	Purchases       float64   `gorm:"not null"`
+	Payments        float64   `gorm:"not null"`
+	InterestAndFees float64   `gorm:"not null"`           // Interest and late fees
+	Adjustments     float64   `gorm:"not null;default:0"` // Manual adjustment debits less credits
+	ClosingBalance  float64   `gorm:"not null"`           // Balance owed minus account credit, negative when the client is in credit
+	PurchaseCount   int       `gorm:"not null"`
+	PaymentCount    int       `gorm:"not null"`
+	ClosedAt        time.Time `gorm:"not null"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// TillSession is a shift of the cash register of an establishment, from an admin opening it with the
+// cash in the drawer to closing it with the cash counted. Cash payments and cash sales recorded while
+// it is open are collected in it, so the cash counted can be reconciled with them.
+type TillSession struct {
+	ID              uint       `gorm:"primarykey"`
+	EstablishmentID uint       `gorm:"not null;index;uniqueIndex:idx_till_sessions_establishment_open,where:closed_at IS NULL"` // One open session per establishment
+	OpenedByID      uint       `gorm:"not null"`
+	OpenedAt        time.Time  `gorm:"not null"`
+	OpeningCash     float64    `gorm:"not null"` // Cash in the drawer when opened
+	ClosedByID      *uint      // Admin who closed it, nil while open
+	ClosedAt        *time.Time `gorm:"index"`
+	CashPayments    float64    `gorm:"not null;default:0"` // Frozen on closing, like the figures below, so later edits of the transactions don't change them
+	PaymentCount    int        `gorm:"not null;default:0"`
+	CashSales       float64    `gorm:"not null;default:0"`
+	ExpectedCash    float64    `gorm:"not null;default:0"` // Opening cash plus the cash payments and sales
+	CountedCash     float64    `gorm:"not null;default:0"`
+	Notes           string     `gorm:"type:text"` // Explanation of the difference between the counted and expected cash
+	CreatedAt       time.Time  `gorm:"not null"`
+	UpdatedAt       time.Time  `gorm:"not null"`
+}
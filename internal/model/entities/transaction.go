@@ -14,8 +14,12 @@ type Transaction struct {
 	Amount           float64               `gorm:"not null"`
 	Description      string                `gorm:"type:text"`      // Optional description
 	TransactionDate  time.Time             `gorm:"not null"`      // Date of the transaction
-	PaymentMethod    enums.PaymentMethod   `gorm:"not null"`      // YAP, PLIN, CASH
+	PaymentMethod    enums.PaymentMethod   `gorm:"not null"`      // YAP, PLIN, CASH, BANK_TRANSFER
 	PaymentCode      string                `gorm:"default:null"`  // Code generated for client confirmation
 	ConfirmationCode string                `gorm:"default:null"`  // Code provided by admin for confirmation
+	PaymentCodeExpiresAt *time.Time        // When a pending payment expires unconfirmed, nil for payments made before codes expired
+	PaymentCodeAttempts  int               `gorm:"not null;default:0"` // Wrong confirmation codes tried on the pending payment
 	PaymentStatus    enums.PaymentStatus   `gorm:"default:PENDING"` // PENDING, SUCCESS, FAILED
+	DocumentNumber   string                `gorm:"default:null;index"` // Receipt number, e.g. B001-000123. Empty when the establishment has no active series
+	TillSessionID    *uint                 `gorm:"index"` // Till session a cash payment was collected in, nil if none was open
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// TwoFactorRecoveryCode is a single-use code that replaces the TOTP code of a user who lost their
+// authenticator. Only its SHA-256 hash is stored.
+type TwoFactorRecoveryCode struct {
+	ID        uint       `gorm:"primarykey"`
+	UserID    uint       `gorm:"index;not null"`
+	CodeHash  string     `gorm:"uniqueIndex;not null"`
+	UsedAt    *time.Time // Nil until the code is used
+	CreatedAt time.Time  `gorm:"not null"`
+}
@@ -15,7 +15,16 @@ type User struct {
 	Address   string     `gorm:"not null"`
 	Phone     string     `gorm:"not null"`
 	PhotoUrl  string     `gorm:"default:'https://cdn.pixabay.com/photo/2015/10/05/22/37/blank-profile-picture-973460_1280.png'"`
-	Rol       enums.Role `gorm:"type:text;not null"` // ADMIN or CLIENT
+	Rol       enums.Role `gorm:"type:text;not null"` // ADMIN, CLIENT or SUPERADMIN
+	StatementEmailsOptOut bool `gorm:"not null;default:false"` // Client unsubscribed from statement emails
+	TwoFactorSecret   string `gorm:"not null;default:''"`    // Base32 TOTP secret, set up but not in use until TwoFactorEnabled
+	TwoFactorEnabled  bool   `gorm:"not null;default:false"` // Logins need a TOTP or recovery code after the password
+	TwoFactorLastStep int64  `gorm:"not null;default:0"`     // TOTP period of the last code accepted, so codes can't be replayed
+	TwoFactorFailedAttempts int        `gorm:"not null;default:0"` // Codes entered since the last right one or lockout
+	TwoFactorLockedUntil    *time.Time // Set after too many wrong codes, no code is accepted until then
+	EmailVerifiedAt *time.Time // Nil until the user confirms Email with the code sent to it
+	PhoneVerifiedAt *time.Time // Nil until the user confirms Phone with the code sent by SMS
+	AnonymizedAt    *time.Time // Set once the user's personal data was erased, which can't be undone
 	CreatedAt time.Time  `gorm:"not null"`
 	UpdatedAt time.Time  `gorm:"not null"`
 }
\ No newline at end of file
@@ -0,0 +1,97 @@
+// Package plan defines the plans establishments subscribe to: how many clients, products and
+// branches each of them allows and which features it includes. Quotas are soft: they stop new items
+// from being added past the limit, while what an establishment already has keeps working.
+package plan
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"errors"
+	"fmt"
+)
+
+// ErrUpgradeRequired is returned when the plan of an establishment doesn't allow what was attempted.
+var ErrUpgradeRequired = errors.New("upgrade required")
+
+// Quota is something plans limit the number of.
+type Quota string
+
+const (
+	Clients  Quota = "clients"  // Credit accounts not closed
+	Products Quota = "products" // Products in the catalogs
+	Branches Quota = "branches" // Branches besides the main establishment
+)
+
+// Quotas are the quotas of a plan, in the order they are shown.
+var Quotas = []Quota{Clients, Products, Branches}
+
+// Limits are what a plan allows. A quota of 0 is unlimited, and one of -1 allows none.
+type Limits struct {
+	Plan        enums.Plan
+	MaxClients  int
+	MaxProducts int
+	MaxBranches int
+	Features    []enums.PlanFeature
+}
+
+// Plans are the plans establishments can be on, from the smallest to the largest.
+var Plans = []Limits{
+	{Plan: enums.PlanFree, MaxClients: 50, MaxProducts: 200, MaxBranches: -1},
+	{Plan: enums.PlanStandard, MaxClients: 500, MaxProducts: 2000, MaxBranches: 2,
+		Features: []enums.PlanFeature{enums.FeaturePDFStatements, enums.FeatureStatementEmails}},
+	{Plan: enums.PlanPremium,
+		Features: []enums.PlanFeature{enums.FeaturePDFStatements, enums.FeatureStatementEmails, enums.FeatureReportDigests}},
+}
+
+// Of returns the limits of a plan. Establishments on an unknown plan get those of the smallest one.
+func Of(plan enums.Plan) Limits {
+	for _, limits := range Plans {
+		if limits.Plan == plan {
+			return limits
+		}
+	}
+	return Plans[0]
+}
+
+// Max returns how many of quota the plan allows, 0 for unlimited and -1 for none.
+func (l Limits) Max(quota Quota) int {
+	switch quota {
+	case Clients:
+		return l.MaxClients
+	case Products:
+		return l.MaxProducts
+	case Branches:
+		return l.MaxBranches
+	}
+	return 0
+}
+
+// Allows reports whether the plan includes a feature.
+func (l Limits) Allows(feature enums.PlanFeature) bool {
+	for _, f := range l.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckQuota fails with ErrUpgradeRequired if adding items of quota to the used ones goes past what
+// the plan allows.
+func (l Limits) CheckQuota(quota Quota, used, adding int) error {
+	max := l.Max(quota)
+	switch {
+	case max < 0 && adding > 0:
+		return fmt.Errorf("the %s plan doesn't include %s: %w", l.Plan, quota, ErrUpgradeRequired)
+	case max > 0 && used+adding > max:
+		return fmt.Errorf("the %s plan allows up to %d %s: %w", l.Plan, max, quota, ErrUpgradeRequired)
+	}
+	return nil
+}
+
+// CheckFeature fails with ErrUpgradeRequired unless the plan includes a feature.
+func (l Limits) CheckFeature(feature enums.PlanFeature) error {
+	if !l.Allows(feature) {
+		return fmt.Errorf("the %s plan doesn't include %s: %w", l.Plan, feature, ErrUpgradeRequired)
+	}
+	return nil
+}
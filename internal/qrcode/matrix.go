@@ -0,0 +1,276 @@
+package qrcode
+
+// builder lays out the modules of a code: its function patterns, which are fixed, and its data,
+// which is masked.
+type builder struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// newCode returns the code of version number with its function patterns drawn and the areas of its
+// format and version information reserved.
+func newCode(number int, v version) *builder {
+	b := &builder{size: 17 + 4*number}
+	b.modules = make([][]bool, b.size)
+	b.isFunction = make([][]bool, b.size)
+	for i := range b.modules {
+		b.modules[i] = make([]bool, b.size)
+		b.isFunction[i] = make([]bool, b.size)
+	}
+
+	// Timing patterns, partly covered by the finders below
+	for i := 0; i < b.size; i++ {
+		b.setFunction(6, i, i%2 == 0)
+		b.setFunction(i, 6, i%2 == 0)
+	}
+	b.drawFinder(3, 3)
+	b.drawFinder(b.size-4, 3)
+	b.drawFinder(3, b.size-4)
+
+	last := len(v.alignment) - 1
+	for i, x := range v.alignment {
+		for j, y := range v.alignment {
+			// The corners with finders have no alignment pattern
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			b.drawAlignment(x, y)
+		}
+	}
+
+	b.drawFormat(0)
+	b.drawVersion(number)
+	return b
+}
+
+func (b *builder) setFunction(x, y int, dark bool) {
+	b.modules[y][x] = dark
+	b.isFunction[y][x] = true
+}
+
+// drawFinder draws a finder pattern centered on x, y, with its separator.
+func (b *builder) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= b.size || yy < 0 || yy >= b.size {
+				continue
+			}
+			distance := max(abs(dx), abs(dy))
+			b.setFunction(xx, yy, distance != 2 && distance != 4)
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centered on x, y.
+func (b *builder) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			b.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat draws both copies of the format information of level M with mask, and the dark
+// module next to them.
+func (b *builder) drawFormat(mask int) {
+	data := mask // Level M is 00
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		b.setFunction(8, i, bit(i))
+	}
+	b.setFunction(8, 7, bit(6))
+	b.setFunction(8, 8, bit(7))
+	b.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		b.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		b.setFunction(b.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		b.setFunction(8, b.size-15+i, bit(i))
+	}
+	b.setFunction(8, b.size-8, true)
+}
+
+// drawVersion draws both copies of the version information, which versions 7 and up carry.
+func (b *builder) drawVersion(number int) {
+	if number < 7 {
+		return
+	}
+	remainder := number
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	bits := number<<12 | remainder
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 == 1
+		a, c := b.size-11+i%3, i/3
+		b.setFunction(a, c, dark)
+		b.setFunction(c, a, dark)
+	}
+}
+
+// placeData fills the modules that aren't part of a function pattern with codewords, in the zigzag
+// of two-module columns from the bottom right corner. Modules left over stay light.
+func (b *builder) placeData(codewords []byte) {
+	i := 0
+	for right := b.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// The vertical timing pattern is skipped
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < b.size; vert++ {
+			y := vert
+			if upward {
+				y = b.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if b.isFunction[y][x] || i >= 8*len(codewords) {
+					continue
+				}
+				b.modules[y][x] = codewords[i/8]>>(7-i%8)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// masks invert the data modules at column x, row y when they return true.
+var masks = []func(x, y int) bool{
+	func(x, y int) bool { return (x+y)%2 == 0 },
+	func(x, y int) bool { return y%2 == 0 },
+	func(x, y int) bool { return x%3 == 0 },
+	func(x, y int) bool { return (x+y)%3 == 0 },
+	func(x, y int) bool { return (x/3+y/2)%2 == 0 },
+	func(x, y int) bool { return x*y%2+x*y%3 == 0 },
+	func(x, y int) bool { return (x*y%2+x*y%3)%2 == 0 },
+	func(x, y int) bool { return ((x+y)%2+x*y%3)%2 == 0 },
+}
+
+// applyMask inverts the data modules mask selects. Applying it twice undoes it.
+func (b *builder) applyMask(mask int) {
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if !b.isFunction[y][x] && masks[mask](x, y) {
+				b.modules[y][x] = !b.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask whose code scores the lowest penalty, the easiest to scan.
+func (b *builder) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := range masks {
+		b.applyMask(mask)
+		b.drawFormat(mask)
+		if penalty := b.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		b.applyMask(mask)
+	}
+	b.applyMask(best)
+	b.drawFormat(best)
+}
+
+// penalty scores the features that make a code hard to scan: long runs and blocks of one color,
+// patterns that look like finders and an unbalanced share of dark modules.
+func (b *builder) penalty() int {
+	penalty := 0
+	finderLike := []bool{true, false, true, true, true, false, true}
+	for i := 0; i < b.size; i++ {
+		row := make([]bool, b.size)
+		column := make([]bool, b.size)
+		for j := 0; j < b.size; j++ {
+			row[j], column[j] = b.modules[i][j], b.modules[j][i]
+		}
+		for _, line := range [][]bool{row, column} {
+			penalty += runPenalty(line)
+			penalty += 40 * finderLikeCount(line, finderLike)
+		}
+	}
+
+	dark := 0
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if b.modules[y][x] {
+				dark++
+			}
+			if x+1 < b.size && y+1 < b.size {
+				color := b.modules[y][x]
+				if b.modules[y][x+1] == color && b.modules[y+1][x] == color && b.modules[y+1][x+1] == color {
+					penalty += 3
+				}
+			}
+		}
+	}
+	percent := dark * 100 / (b.size * b.size)
+	penalty += 10 * (abs(percent-50) / 5)
+	return penalty
+}
+
+// runPenalty scores the runs of five or more modules of one color in a line.
+func runPenalty(line []bool) int {
+	penalty, run := 0, 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			penalty += 3 + run - 5
+		}
+		run = 1
+	}
+	return penalty
+}
+
+// finderLikeCount counts the 1:1:3:1:1 patterns in a line with four light modules on either side,
+// outside the line counting as light.
+func finderLikeCount(line, pattern []bool) int {
+	dark := func(i int) bool { return i >= 0 && i < len(line) && line[i] }
+	count := 0
+	for start := 0; start+len(pattern) <= len(line); start++ {
+		matches := true
+		for k, want := range pattern {
+			if line[start+k] != want {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		lightBefore, lightAfter := true, true
+		for k := 1; k <= 4; k++ {
+			lightBefore = lightBefore && !dark(start-k)
+			lightAfter = lightAfter && !dark(start+len(pattern)-1+k)
+		}
+		if lightBefore {
+			count++
+		}
+		if lightAfter {
+			count++
+		}
+	}
+	return count
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
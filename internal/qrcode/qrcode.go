@@ -0,0 +1,188 @@
+// Package qrcode encodes text as QR codes (ISO/IEC 18004) and renders them as PNG or SVG images.
+// Codes use byte mode and error correction level M, recovering from about 15% of damage, in
+// versions 1 to 10: up to 213 bytes, plenty for the URLs the API encodes.
+package qrcode
+
+import (
+	"errors"
+)
+
+// ErrTooLong is returned for content that doesn't fit in the largest supported version.
+var ErrTooLong = errors.New("content too long for a QR code")
+
+// Code is the grid of modules of a QR code, without its quiet zone.
+type Code struct {
+	size    int
+	modules [][]bool // Dark modules, by row then column
+}
+
+// version describes the codewords of a version at error correction level M. Its data codewords are
+// split in blocks of shortBlocks first, then blocks one codeword longer, each followed by ecPerBlock
+// error correction codewords.
+type version struct {
+	ecPerBlock  int
+	shortBlocks int
+	shortData   int // Data codewords of the short blocks
+	longBlocks  int
+	alignment   []int // Centers of the alignment patterns, on both axes
+}
+
+// versions are the supported versions at level M, by number minus one.
+var versions = []version{
+	{10, 1, 16, 0, nil},
+	{16, 1, 28, 0, []int{6, 18}},
+	{26, 1, 44, 0, []int{6, 22}},
+	{18, 2, 32, 0, []int{6, 26}},
+	{24, 2, 43, 0, []int{6, 30}},
+	{16, 4, 27, 0, []int{6, 34}},
+	{18, 4, 31, 0, []int{6, 22, 38}},
+	{22, 2, 38, 2, []int{6, 24, 42}},
+	{22, 3, 36, 2, []int{6, 26, 46}},
+	{26, 4, 43, 1, []int{6, 28, 50}},
+}
+
+func (v version) dataCodewords() int {
+	return v.shortBlocks*v.shortData + v.longBlocks*(v.shortData+1)
+}
+
+// Encode returns the QR code of content, in the smallest version it fits in.
+func Encode(content string) (*Code, error) {
+	data := []byte(content)
+	for i, v := range versions {
+		number := i + 1
+		countBits := 8
+		if number >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) > 8*v.dataCodewords() {
+			continue
+		}
+
+		bits := &bitBuffer{}
+		bits.append(0b0100, 4) // Byte mode
+		bits.append(len(data), countBits)
+		for _, b := range data {
+			bits.append(int(b), 8)
+		}
+		codewords := bits.codewords(v.dataCodewords())
+
+		c := newCode(number, v)
+		c.placeData(interleave(codewords, v))
+		c.applyBestMask()
+		return &Code{size: c.size, modules: c.modules}, nil
+	}
+	return nil, ErrTooLong
+}
+
+// Size returns the number of modules on each side of the code.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark reports whether the module at column x, row y is dark.
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// bitBuffer accumulates the bits of the data of a code, most significant first.
+type bitBuffer struct {
+	bits []bool
+}
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		b.bits = append(b.bits, value>>i&1 == 1)
+	}
+}
+
+// codewords terminates and pads the bits to capacity codewords.
+func (b *bitBuffer) codewords(capacity int) []byte {
+	terminator := min(4, 8*capacity-len(b.bits))
+	b.append(0, terminator)
+	b.append(0, (8-len(b.bits)%8)%8)
+	for pad := 0xEC; len(b.bits) < 8*capacity; pad ^= 0xEC ^ 0x11 {
+		b.append(pad, 8)
+	}
+
+	codewords := make([]byte, capacity)
+	for i, bit := range b.bits {
+		if bit {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return codewords
+}
+
+// interleave splits the data codewords in the blocks of v, adds their error correction and
+// interleaves them in the order they are placed in the code.
+func interleave(data []byte, v version) []byte {
+	divisor := reedSolomonDivisor(v.ecPerBlock)
+	var blocks, ecBlocks [][]byte
+	for i, offset := 0, 0; i < v.shortBlocks+v.longBlocks; i++ {
+		length := v.shortData
+		if i >= v.shortBlocks {
+			length++
+		}
+		block := data[offset : offset+length]
+		offset += length
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+	}
+
+	var result []byte
+	for i := 0; i <= v.shortData; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, ec := range ecBlocks {
+			result = append(result, ec[i])
+		}
+	}
+	return result
+}
+
+// reedSolomonDivisor returns the generator polynomial of degree error correction codewords,
+// highest coefficient first without the leading 1.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of data.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
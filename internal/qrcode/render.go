@@ -0,0 +1,61 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// QuietZone is the light margin around a code scanners need, in modules.
+const QuietZone = 4
+
+// PNG renders the code as a black and white PNG image, with scale pixels per module and its quiet zone.
+func (c *Code) PNG(scale int) ([]byte, error) {
+	side := (c.size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				offset := img.PixOffset((x+QuietZone)*scale, (y+QuietZone)*scale+dy)
+				for dx := 0; dx < scale; dx++ {
+					img.Pix[offset+dx] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders the code as an SVG image of side pixels, with its quiet zone. The image is vector, so
+// it scales to any size.
+func (c *Code) SVG(side int) []byte {
+	total := c.size + 2*QuietZone
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, side, side, total, total)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, total, total)
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			// Runs of dark modules in a row are drawn as one rectangle
+			if !c.modules[y][x] || (x > 0 && c.modules[y][x-1]) {
+				continue
+			}
+			run := 1
+			for x+run < c.size && c.modules[y][x+run] {
+				run++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", x+QuietZone, y+QuietZone, run, run)
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes()
+}
@@ -0,0 +1,78 @@
+// Package queue hands background work over to workers, so HTTP requests don't wait for it.
+package queue
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// ErrQueueFull is returned when a task is enqueued while every slot of the queue is taken.
+var ErrQueueFull = errors.New("queue is full")
+
+// Task asks a worker to run a job. The job itself is stored in the database, so a task only
+// carries what is needed to find and dispatch it.
+type Task struct {
+	JobID uint   `json:"job_id"`
+	Type  string `json:"type"`
+}
+
+// Handler runs a task delivered by a Driver.
+type Handler func(task Task)
+
+// Driver carries tasks from the API to the workers that run them. WorkerPool runs them in this
+// process; a broker such as RabbitMQ or SQS can be used instead by implementing Driver, so the
+// workers can run in separate processes. Tasks may be delivered more than once.
+type Driver interface {
+	Enqueue(task Task) error
+	// Start delivers the tasks to handle in the background until ctx is done.
+	Start(ctx context.Context, handle Handler)
+}
+
+// WorkerPool is a Driver that runs tasks in a fixed number of goroutines. Tasks waiting in the
+// pool are lost if the process stops, so whoever enqueues them must be able to enqueue them again.
+type WorkerPool struct {
+	tasks   chan Task
+	workers int
+}
+
+// NewWorkerPool creates a WorkerPool with the given number of workers that holds up to capacity
+// tasks waiting for one.
+func NewWorkerPool(workers, capacity int) *WorkerPool {
+	return &WorkerPool{tasks: make(chan Task, capacity), workers: max(workers, 1)}
+}
+
+// Enqueue adds a task to the pool without waiting, failing with ErrQueueFull when it is full.
+func (p *WorkerPool) Enqueue(task Task) error {
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Start runs the workers until ctx is done. A task that panics is logged and doesn't stop its worker.
+func (p *WorkerPool) Start(ctx context.Context, handle Handler) {
+	for i := 0; i < p.workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case task := <-p.tasks:
+					run(handle, task)
+				}
+			}
+		}()
+	}
+}
+
+func run(handle Handler, task Task) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("task %s for job %d panicked: %v", task.Type, task.JobID, r)
+		}
+	}()
+	handle(task)
+}
@@ -0,0 +1,164 @@
+package realtime
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/repository"
+)
+
+const (
+	queueSize      = 256
+	subscriberSize = 16
+)
+
+// Hub fans account events out to the connected clients and admins they concern. A client gets
+// the events of their own credit accounts, an admin those of the accounts in their establishments.
+type Hub struct {
+	creditAccountRepo repository.CreditAccountRepository
+	queue             chan event.Event
+
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	owners      map[uint]owner // Credit account ID to its client and establishment, so deleted accounts are still routed
+}
+
+type owner struct {
+	clientID        uint
+	establishmentID uint
+}
+
+// Subscription receives the events of a client or of a set of establishments until it is closed.
+type Subscription struct {
+	hub              *Hub
+	events           chan response.RealtimeEventResponse
+	clientID         uint
+	establishmentIDs map[uint]bool
+}
+
+// NewHub creates a Hub fed by the events published on bus.
+func NewHub(bus event.Bus, creditAccountRepo repository.CreditAccountRepository) *Hub {
+	h := &Hub{
+		creditAccountRepo: creditAccountRepo,
+		queue:             make(chan event.Event, queueSize),
+		subscribers:       make(map[*Subscription]struct{}),
+		owners:            make(map[uint]owner),
+	}
+	// Handlers run in the publishing request, so events are only queued here and delivered by Start
+	bus.SubscribeAll(func(evt event.Event) {
+		select {
+		case h.queue <- evt:
+		default:
+			log.Println("realtime queue full, dropping event", evt.Name)
+		}
+	})
+	return h
+}
+
+// Start delivers the queued events in the background until ctx is done.
+func (h *Hub) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt := <-h.queue:
+				h.deliver(evt)
+			}
+		}
+	}()
+}
+
+// SubscribeClient subscribes to the events of a client's credit accounts.
+func (h *Hub) SubscribeClient(clientID uint) *Subscription {
+	return h.subscribe(&Subscription{clientID: clientID})
+}
+
+// SubscribeEstablishments subscribes to the events of the credit accounts in the given establishments.
+func (h *Hub) SubscribeEstablishments(establishmentIDs []uint) *Subscription {
+	ids := make(map[uint]bool, len(establishmentIDs))
+	for _, id := range establishmentIDs {
+		ids[id] = true
+	}
+	return h.subscribe(&Subscription{establishmentIDs: ids})
+}
+
+func (h *Hub) subscribe(sub *Subscription) *Subscription {
+	sub.hub = h
+	sub.events = make(chan response.RealtimeEventResponse, subscriberSize)
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// Events returns the channel the subscription's events arrive on. It is closed by Close.
+func (s *Subscription) Events() <-chan response.RealtimeEventResponse {
+	return s.events
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subscribers[s]; ok {
+		delete(s.hub.subscribers, s)
+		close(s.events)
+	}
+}
+
+func (s *Subscription) wants(o owner) bool {
+	if s.clientID != 0 {
+		return s.clientID == o.clientID
+	}
+	return s.establishmentIDs[o.establishmentID]
+}
+
+func (h *Hub) deliver(evt event.Event) {
+	o, ok := h.owner(evt.CreditAccountID)
+	if !ok {
+		return
+	}
+	msg := response.RealtimeEventResponse{
+		Event:           evt.Name,
+		CreditAccountID: evt.CreditAccountID,
+		ClientID:        o.clientID,
+		EstablishmentID: o.establishmentID,
+		OccurredAt:      evt.OccurredAt,
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.subscribers {
+		if !sub.wants(o) {
+			continue
+		}
+		// A subscriber that doesn't keep up misses events rather than holding up everyone else
+		select {
+		case sub.events <- msg:
+		default:
+		}
+	}
+}
+
+func (h *Hub) owner(creditAccountID uint) (owner, bool) {
+	h.mu.RLock()
+	o, ok := h.owners[creditAccountID]
+	h.mu.RUnlock()
+	if ok {
+		return o, true
+	}
+
+	account, err := h.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if err != nil {
+		return owner{}, false
+	}
+	o = owner{clientID: account.ClientID, establishmentID: account.EstablishmentID}
+	h.mu.Lock()
+	h.owners[creditAccountID] = o
+	h.mu.Unlock()
+	return o, true
+}
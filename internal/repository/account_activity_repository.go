@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AccountActivityRepository reads the activity ledger of credit accounts. Entries are written by
+// the repository methods that make the changes they describe, in the same transaction.
+type AccountActivityRepository interface {
+	GetActivitiesByCreditAccountID(creditAccountID uint, offset, limit int) ([]entities.AccountActivity, int64, error)
+	GetRecentActivities(creditAccountID uint, limit int) ([]entities.AccountActivity, error)
+	GetRecentEstablishmentActivities(establishmentID uint, limit int) ([]EstablishmentActivity, error)
+	GetEstablishmentMovements(establishmentID uint, startDate, endDate time.Time) ([]AccountMovement, error)
+}
+
+// AccountMovement is a ledger entry that moved money, with the transaction behind it. The
+// transaction fields are empty for entries without one, such as write-offs.
+type AccountMovement struct {
+	entities.AccountActivity
+	TransactionType enums.TransactionType
+	PaymentMethod   enums.PaymentMethod
+	DocumentNumber  string
+}
+
+// EstablishmentActivity is a ledger entry of a credit account of an establishment, with the client
+// who holds the account.
+type EstablishmentActivity struct {
+	entities.AccountActivity
+	ClientID   uint
+	ClientName string
+}
+
+type accountActivityRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountActivityRepository creates a new AccountActivityRepository instance.
+func NewAccountActivityRepository(db *gorm.DB) AccountActivityRepository {
+	return &accountActivityRepository{db: db}
+}
+
+// GetActivitiesByCreditAccountID retrieves a page of the activity of a credit account, newest first,
+// and the number of entries across all pages.
+func (r *accountActivityRepository) GetActivitiesByCreditAccountID(creditAccountID uint, offset, limit int) ([]entities.AccountActivity, int64, error) {
+	query := r.db.Model(&entities.AccountActivity{}).Where("credit_account_id = ?", creditAccountID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var activities []entities.AccountActivity
+	err := query.Order("occurred_at DESC, id DESC").Offset(offset).Limit(limit).Find(&activities).Error
+	return activities, total, err
+}
+
+// GetRecentActivities retrieves the latest limit entries of the activity of a credit account, newest
+// first, without counting them.
+func (r *accountActivityRepository) GetRecentActivities(creditAccountID uint, limit int) ([]entities.AccountActivity, error) {
+	var activities []entities.AccountActivity
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Order("occurred_at DESC, id DESC").Limit(limit).Find(&activities).Error
+	return activities, err
+}
+
+// GetRecentEstablishmentActivities retrieves the latest limit entries of the activity of the credit
+// accounts of an establishment, newest first.
+func (r *accountActivityRepository) GetRecentEstablishmentActivities(establishmentID uint, limit int) ([]EstablishmentActivity, error) {
+	var activities []EstablishmentActivity
+	err := r.db.Table("account_activities").
+		Select("account_activities.*, credit_accounts.client_id, users.name AS client_name").
+		Joins("JOIN credit_accounts ON credit_accounts.id = account_activities.credit_account_id").
+		Joins("JOIN users ON users.id = credit_accounts.client_id").
+		Where("credit_accounts.establishment_id = ?", establishmentID).
+		Order("account_activities.occurred_at DESC, account_activities.id DESC").
+		Limit(limit).
+		Scan(&activities).Error
+	return activities, err
+}
+
+// GetEstablishmentMovements retrieves the ledger entries of the credit accounts of an establishment that
+// moved money between two dates, oldest first. Reversals keep the type of the deleted transaction.
+func (r *accountActivityRepository) GetEstablishmentMovements(establishmentID uint, startDate, endDate time.Time) ([]AccountMovement, error) {
+	var movements []AccountMovement
+	err := database.ReadReplica(r.db).Table("account_activities").
+		Select(`account_activities.*,
+			COALESCE(transactions.transaction_type, '') AS transaction_type,
+			COALESCE(transactions.payment_method, '') AS payment_method,
+			COALESCE(transactions.document_number, '') AS document_number`).
+		Joins("JOIN credit_accounts ON credit_accounts.id = account_activities.credit_account_id").
+		Joins("LEFT JOIN transactions ON transactions.id = account_activities.transaction_id").
+		Where("credit_accounts.establishment_id = ? AND account_activities.amount <> 0", establishmentID).
+		Where("account_activities.occurred_at BETWEEN ? AND ?", startDate, endDate).
+		Order("account_activities.occurred_at, account_activities.id").
+		Scan(&movements).Error
+	return movements, err
+}
+
+// recordActivity adds an entry to the ledger of creditAccount as part of tx, snapshotting the
+// account's balance and credit limit after the change.
+func recordActivity(tx *gorm.DB, creditAccount *entities.CreditAccount, activityType enums.ActivityType, amount float64, description string, transactionID *uint, occurredAt time.Time) error {
+	balance, creditLimit := creditAccount.CurrentBalance, creditAccount.CreditLimit
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	return tx.Create(&entities.AccountActivity{
+		CreditAccountID: creditAccount.ID,
+		Type:            activityType,
+		Amount:          amount,
+		Description:     description,
+		Balance:         &balance,
+		CreditLimit:     &creditLimit,
+		TransactionID:   transactionID,
+		OccurredAt:      occurredAt,
+		CreatedAt:       time.Now(),
+	}).Error
+}
+
+// recordTransactionActivity adds the ledger entry of a purchase, payment, interest or late fee. deleted
+// records the reversal of a deleted transaction instead.
+func recordTransactionActivity(tx *gorm.DB, transaction *entities.Transaction, creditAccount *entities.CreditAccount, deleted bool) error {
+	activityType, amount := enums.ActivityPurchase, transaction.Amount
+	switch transaction.TransactionType {
+	case enums.Purchase:
+	case enums.Payment:
+		activityType, amount = enums.ActivityPayment, -transaction.Amount
+	case enums.InterestCharge:
+		activityType = enums.ActivityInterestCharge
+	case enums.LateFeeCharge:
+		activityType = enums.ActivityLateFee
+	case enums.AdjustmentDebit:
+		activityType = enums.ActivityAdjustment
+	case enums.AdjustmentCredit:
+		activityType, amount = enums.ActivityAdjustment, -transaction.Amount
+	default:
+		return fmt.Errorf("invalid transaction type %q", transaction.TransactionType)
+	}
+	occurredAt := transaction.TransactionDate
+	if deleted {
+		activityType, amount, occurredAt = enums.ActivityReversal, -amount, time.Now()
+	}
+	return recordActivity(tx, creditAccount, activityType, amount, transaction.Description, &transaction.ID, occurredAt)
+}
+
+// recordBlockActivity adds the ledger entry of a block history entry, once the account was blocked or unblocked in tx.
+func recordBlockActivity(tx *gorm.DB, blockEvent *entities.CreditAccountBlockEvent) error {
+	var creditAccount entities.CreditAccount
+	if err := tx.First(&creditAccount, blockEvent.CreditAccountID).Error; err != nil {
+		return err
+	}
+	activityType := enums.ActivityAccountUnblocked
+	if blockEvent.Blocked {
+		activityType = enums.ActivityAccountBlocked
+	}
+	return recordActivity(tx, &creditAccount, activityType, 0, blockEvent.Reason, nil, blockEvent.CreatedAt)
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAdjustmentDecided is returned when an adjustment waiting for approval was already approved or rejected.
+var ErrAdjustmentDecided = errors.New("adjustment was already decided")
+
+// AccountAdjustmentRepository defines operations for managing the manual adjustments admins make to
+// the balance of credit accounts.
+type AccountAdjustmentRepository interface {
+	CreateAccountAdjustment(adjustment *entities.AccountAdjustment) error
+	GetAccountAdjustmentByID(adjustmentID uint) (*entities.AccountAdjustment, error)
+	GetAccountAdjustmentsByCreditAccountID(creditAccountID uint) ([]entities.AccountAdjustment, error)
+	GetPendingAccountAdjustments(establishmentIDs []uint) ([]entities.AccountAdjustment, error)
+	ApplyAccountAdjustment(adjustment *entities.AccountAdjustment) error
+	RejectAccountAdjustment(adjustment *entities.AccountAdjustment) (bool, error)
+}
+
+type accountAdjustmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountAdjustmentRepository creates a new AccountAdjustmentRepository instance.
+func NewAccountAdjustmentRepository(db *gorm.DB) AccountAdjustmentRepository {
+	return &accountAdjustmentRepository{db: db}
+}
+
+// CreateAccountAdjustment creates a new adjustment in the database, without changing the balance.
+func (r *accountAdjustmentRepository) CreateAccountAdjustment(adjustment *entities.AccountAdjustment) error {
+	return r.db.Omit(clause.Associations).Create(adjustment).Error
+}
+
+// GetAccountAdjustmentByID retrieves an adjustment by its ID.
+func (r *accountAdjustmentRepository) GetAccountAdjustmentByID(adjustmentID uint) (*entities.AccountAdjustment, error) {
+	var adjustment entities.AccountAdjustment
+	err := r.db.First(&adjustment, adjustmentID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+// GetAccountAdjustmentsByCreditAccountID retrieves the adjustments of a credit account, newest first.
+func (r *accountAdjustmentRepository) GetAccountAdjustmentsByCreditAccountID(creditAccountID uint) ([]entities.AccountAdjustment, error) {
+	var adjustments []entities.AccountAdjustment
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Order("created_at DESC, id DESC").Find(&adjustments).Error
+	return adjustments, err
+}
+
+// GetPendingAccountAdjustments retrieves the adjustments waiting for approval in some establishments,
+// oldest first.
+func (r *accountAdjustmentRepository) GetPendingAccountAdjustments(establishmentIDs []uint) ([]entities.AccountAdjustment, error) {
+	var adjustments []entities.AccountAdjustment
+	if len(establishmentIDs) == 0 {
+		return adjustments, nil
+	}
+	err := r.db.Where("establishment_id IN ? AND status = ?", establishmentIDs, enums.AdjustmentPending).
+		Order("created_at, id").Find(&adjustments).Error
+	return adjustments, err
+}
+
+// ApplyAccountAdjustment applies an adjustment to the balance of its credit account in a single
+// transaction, adding its transaction and ledger entry. A new adjustment, one without an ID, is created
+// applied; an existing one must still be waiting for approval, else it returns ErrAdjustmentDecided.
+func (r *accountAdjustmentRepository) ApplyAccountAdjustment(adjustment *entities.AccountAdjustment) error {
+	original := *adjustment
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*adjustment = original
+		if adjustment.ID != 0 {
+			var pending entities.AccountAdjustment
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&pending, adjustment.ID).Error; err != nil {
+				return err
+			}
+			if pending.Status != enums.AdjustmentPending {
+				return ErrAdjustmentDecided
+			}
+		}
+
+		var creditAccount entities.CreditAccount
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&creditAccount, adjustment.CreditAccountID).Error; err != nil {
+			return err
+		}
+		transaction := entities.Transaction{
+			CreditAccountID: creditAccount.ID,
+			TransactionType: adjustment.Type,
+			Amount:          adjustment.Amount,
+			Description:     adjustment.Reason,
+			TransactionDate: adjustment.UpdatedAt,
+			PaymentStatus:   enums.SUCCESS,
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return fmt.Errorf("error creating adjustment transaction: %w", err)
+		}
+
+		wasBlocked := creditAccount.IsBlocked
+		switch adjustment.Type {
+		case enums.AdjustmentDebit:
+			chargeAccount(&creditAccount, adjustment.Amount)
+		case enums.AdjustmentCredit:
+			payAccount(&creditAccount, adjustment.Amount)
+		default:
+			return fmt.Errorf("invalid adjustment type %q", adjustment.Type)
+		}
+		if err := saveAccountBalance(tx, &creditAccount, wasBlocked); err != nil {
+			return err
+		}
+		if err := recordTransactionActivity(tx, &transaction, &creditAccount, false); err != nil {
+			return err
+		}
+		if err := enqueueTransactionEvent(tx, event.TransactionCreated, &transaction, &creditAccount); err != nil {
+			return err
+		}
+
+		adjustment.Status, adjustment.TransactionID = enums.AdjustmentApplied, &transaction.ID
+		if err := tx.Omit(clause.Associations).Save(adjustment).Error; err != nil {
+			return fmt.Errorf("error saving adjustment: %w", err)
+		}
+		return nil
+	})
+}
+
+// RejectAccountAdjustment saves the rejection of an adjustment waiting for approval. It reports false
+// if the adjustment was decided meanwhile.
+func (r *accountAdjustmentRepository) RejectAccountAdjustment(adjustment *entities.AccountAdjustment) (bool, error) {
+	result := r.db.Model(&entities.AccountAdjustment{}).
+		Where("id = ? AND status = ?", adjustment.ID, enums.AdjustmentPending).
+		Updates(map[string]interface{}{
+			"status":           enums.AdjustmentRejected,
+			"decided_by_id":    adjustment.DecidedByID,
+			"decided_at":       adjustment.DecidedAt,
+			"rejection_reason": adjustment.RejectionReason,
+			"updated_at":       adjustment.UpdatedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
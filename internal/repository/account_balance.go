@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// paidOffReason is the block history reason of accounts unblocked by paying what they owed.
+const paidOffReason = "Balance paid off"
+
+// chargeAccount adds amount to what the client owes, using up their account credit first.
+// Reversing a payment is a charge too.
+func chargeAccount(creditAccount *entities.CreditAccount, amount float64) {
+	fromCredit := math.Min(creditAccount.AccountCredit, amount)
+	creditAccount.AccountCredit -= fromCredit
+	creditAccount.CurrentBalance += amount - fromCredit
+}
+
+// payAccount subtracts amount from what the client owes, then recovers written-off debt. Whatever
+// exceeds both is kept as account credit. Reversing a purchase is a payment too.
+func payAccount(creditAccount *entities.CreditAccount, amount float64) {
+	toBalance := math.Min(math.Max(creditAccount.CurrentBalance, 0), amount)
+	creditAccount.CurrentBalance -= toBalance
+	recovered := math.Min(creditAccount.WrittenOffBalance, amount-toBalance)
+	creditAccount.WrittenOffBalance -= recovered
+	creditAccount.AccountCredit += amount - toBalance - recovered
+
+	// Unblock the account once nothing is owed, unless it was written off
+	if creditAccount.IsBlocked && creditAccount.CurrentBalance <= 0 && creditAccount.WrittenOffAt == nil {
+		creditAccount.IsBlocked = false
+	}
+}
+
+// saveAccountBalance saves a credit account after its balance changed and, if paying it unblocked
+// the account, records the unblock in its block history, its activity, its events and the outbox.
+func saveAccountBalance(tx *gorm.DB, creditAccount *entities.CreditAccount, wasBlocked bool) error {
+	if err := tx.Save(creditAccount).Error; err != nil {
+		return err
+	}
+	if !wasBlocked || creditAccount.IsBlocked {
+		return nil
+	}
+	unblock := entities.CreditAccountBlockEvent{
+		CreditAccountID: creditAccount.ID,
+		Blocked:         false,
+		Reason:          paidOffReason,
+		Automatic:       true,
+	}
+	return recordBlock(tx, &unblock)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository defines operations for managing the documents attached to clients and credit accounts.
+type AttachmentRepository interface {
+	CreateAttachment(attachment *entities.Attachment) error
+	GetAttachmentByID(attachmentID uint) (*entities.Attachment, error)
+	GetClientAttachments(establishmentID, clientID uint) ([]entities.Attachment, error)
+	GetCreditAccountAttachments(creditAccountID uint) ([]entities.Attachment, error)
+	GetExpiredAttachments(now time.Time, limit int) ([]entities.Attachment, error)
+	DeleteAttachment(attachmentID uint) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository creates a new AttachmentRepository instance.
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// CreateAttachment creates a new attachment in the database.
+func (r *attachmentRepository) CreateAttachment(attachment *entities.Attachment) error {
+	return r.db.Create(attachment).Error
+}
+
+// GetAttachmentByID retrieves an attachment by its ID.
+func (r *attachmentRepository) GetAttachmentByID(attachmentID uint) (*entities.Attachment, error) {
+	var attachment entities.Attachment
+	if err := r.db.First(&attachment, attachmentID).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetClientAttachments retrieves every attachment of a client in an establishment, those of their
+// credit account included, newest first.
+func (r *attachmentRepository) GetClientAttachments(establishmentID, clientID uint) ([]entities.Attachment, error) {
+	var attachments []entities.Attachment
+	err := r.db.Where("establishment_id = ? AND client_id = ?", establishmentID, clientID).
+		Order("created_at DESC, id DESC").Find(&attachments).Error
+	return attachments, err
+}
+
+// GetCreditAccountAttachments retrieves the attachments of a credit account, newest first.
+func (r *attachmentRepository) GetCreditAccountAttachments(creditAccountID uint) ([]entities.Attachment, error) {
+	var attachments []entities.Attachment
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Order("created_at DESC, id DESC").Find(&attachments).Error
+	return attachments, err
+}
+
+// GetExpiredAttachments retrieves up to limit attachments whose retention ended by now, oldest first.
+func (r *attachmentRepository) GetExpiredAttachments(now time.Time, limit int) ([]entities.Attachment, error) {
+	var attachments []entities.Attachment
+	err := r.db.Where("retain_until <= ?", now).Order("retain_until, id").Limit(limit).Find(&attachments).Error
+	return attachments, err
+}
+
+// DeleteAttachment deletes an attachment from the database.
+func (r *attachmentRepository) DeleteAttachment(attachmentID uint) error {
+	return r.db.Delete(&entities.Attachment{}, attachmentID).Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReceivablesDay adds up the balance snapshots of the credit accounts of an establishment on a day.
+type ReceivablesDay struct {
+	Date                time.Time
+	Balance             float64
+	AccountCredit       float64
+	WrittenOffBalance   float64
+	AccountsWithBalance int
+}
+
+// BalanceSnapshotRepository defines operations for managing the daily balance snapshots of credit accounts.
+type BalanceSnapshotRepository interface {
+	SaveBalanceSnapshots(snapshots []entities.BalanceSnapshot) error
+	GetBalanceSnapshots(creditAccountID uint, startDate, endDate time.Time) ([]entities.BalanceSnapshot, error)
+	GetReceivablesByDay(establishmentID uint, startDate, endDate time.Time) ([]ReceivablesDay, error)
+}
+
+type balanceSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewBalanceSnapshotRepository creates a new BalanceSnapshotRepository instance.
+func NewBalanceSnapshotRepository(db *gorm.DB) BalanceSnapshotRepository {
+	return &balanceSnapshotRepository{db: db}
+}
+
+// SaveBalanceSnapshots saves the snapshots in batches. A snapshot of an account on a day it already has
+// one replaces it, so taking the snapshots of a day again keeps the latest balances.
+func (r *balanceSnapshotRepository) SaveBalanceSnapshots(snapshots []entities.BalanceSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "credit_account_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"balance", "account_credit", "written_off_balance", "credit_limit", "created_at"}),
+	}).CreateInBatches(snapshots, 500).Error
+}
+
+// GetBalanceSnapshots retrieves the snapshots of a credit account on the days between startDate and
+// endDate, oldest first. It may read from a lagging replica.
+func (r *balanceSnapshotRepository) GetBalanceSnapshots(creditAccountID uint, startDate, endDate time.Time) ([]entities.BalanceSnapshot, error) {
+	var snapshots []entities.BalanceSnapshot
+	err := database.ReadReplica(r.db).Where("credit_account_id = ? AND date BETWEEN ? AND ?", creditAccountID, startDate, endDate).
+		Order("date").Find(&snapshots).Error
+	return snapshots, err
+}
+
+// GetReceivablesByDay adds up the snapshots of the credit accounts of an establishment by day, for the
+// days between startDate and endDate, oldest first. It may read from a lagging replica.
+func (r *balanceSnapshotRepository) GetReceivablesByDay(establishmentID uint, startDate, endDate time.Time) ([]ReceivablesDay, error) {
+	var days []ReceivablesDay
+	err := database.ReadReplica(r.db).Model(&entities.BalanceSnapshot{}).
+		Select(`date, SUM(balance) AS balance, SUM(account_credit) AS account_credit,
+			SUM(written_off_balance) AS written_off_balance,
+			SUM(CASE WHEN balance > 0 THEN 1 ELSE 0 END) AS accounts_with_balance`).
+		Where("establishment_id = ? AND date BETWEEN ? AND ?", establishmentID, startDate, endDate).
+		Group("date").Order("date").
+		Scan(&days).Error
+	return days, err
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BankTransferRepository defines operations for managing the transfers notified by the bank.
+type BankTransferRepository interface {
+	CreateBankTransfer(transfer *entities.BankTransfer) error
+	ConfirmBankTransfer(transfer *entities.BankTransfer, now time.Time) error
+	RecordBankTransfer(transfer *entities.BankTransfer, transaction *entities.Transaction) error
+	GetBankTransferByProviderID(providerID string) (*entities.BankTransfer, error)
+	GetBankTransfersByEstablishmentID(establishmentID uint) ([]entities.BankTransfer, error)
+}
+
+// ErrBankTransferReceived is returned when recording a transfer whose notification was already received.
+var ErrBankTransferReceived = errors.New("bank transfer was already received")
+
+type bankTransferRepository struct {
+	db *gorm.DB
+}
+
+// NewBankTransferRepository creates a new BankTransferRepository instance.
+func NewBankTransferRepository(db *gorm.DB) BankTransferRepository {
+	return &bankTransferRepository{db: db}
+}
+
+// CreateBankTransfer records a transfer that wasn't applied to any payment.
+func (r *bankTransferRepository) CreateBankTransfer(transfer *entities.BankTransfer) error {
+	return uniqueError(r.db.Create(transfer).Error)
+}
+
+// ConfirmBankTransfer confirms the pending payment of transfer.TransactionID with its own code and
+// records the transfer, in one transaction. It fails with ErrPaymentNotPending, recording nothing,
+// when the payment isn't pending anymore, expired or is of another amount.
+func (r *bankTransferRepository) ConfirmBankTransfer(transfer *entities.BankTransfer, now time.Time) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		var transaction entities.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, *transfer.TransactionID).Error; err != nil {
+			return err
+		}
+		expired := transaction.PaymentCodeExpiresAt != nil && !now.Before(*transaction.PaymentCodeExpiresAt)
+		if transaction.PaymentStatus != enums.PENDING || expired || math.Abs(transaction.Amount-transfer.Amount) > 0.005 {
+			return ErrPaymentNotPending
+		}
+
+		transaction.PaymentStatus = enums.SUCCESS
+		transaction.ConfirmationCode = transaction.PaymentCode
+		err := tx.Model(&transaction).Updates(map[string]interface{}{"payment_status": enums.SUCCESS, "confirmation_code": transaction.PaymentCode}).Error
+		if err != nil {
+			return err
+		}
+		var creditAccount entities.CreditAccount
+		if err := tx.First(&creditAccount, transaction.CreditAccountID).Error; err != nil {
+			return err
+		}
+		if err := enqueueTransactionEvent(tx, event.PaymentConfirmed, &transaction, &creditAccount); err != nil {
+			return err
+		}
+		return uniqueError(tx.Create(transfer).Error)
+	})
+}
+
+// RecordBankTransfer records a transfer as a confirmed payment on transfer.CreditAccountID, updating
+// the account's balance, in one transaction. transaction is the payment, which is created.
+func (r *bankTransferRepository) RecordBankTransfer(transfer *entities.BankTransfer, transaction *entities.Transaction) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		var creditAccount entities.CreditAccount
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&creditAccount, *transfer.CreditAccountID).Error; err != nil {
+			return err
+		}
+		wasBlocked := creditAccount.IsBlocked
+
+		documentNumber, err := nextDocumentNumber(tx, creditAccount.EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error numbering receipt: %w", err)
+		}
+		transaction.ID = 0
+		transaction.CreditAccountID = creditAccount.ID
+		transaction.DocumentNumber = documentNumber
+		if err := tx.Create(transaction).Error; err != nil {
+			return fmt.Errorf("error creating transaction: %w", err)
+		}
+		if err := allocatePayment(tx, transaction); err != nil {
+			return fmt.Errorf("error allocating payment to installments: %w", err)
+		}
+		payAccount(&creditAccount, transaction.Amount)
+		if err := saveAccountBalance(tx, &creditAccount, wasBlocked); err != nil {
+			return fmt.Errorf("error updating credit account balance: %w", err)
+		}
+		if err := recordTransactionActivity(tx, transaction, &creditAccount, false); err != nil {
+			return err
+		}
+		if err := enqueueTransactionEvent(tx, event.TransactionCreated, transaction, &creditAccount); err != nil {
+			return err
+		}
+
+		transfer.TransactionID = &transaction.ID
+		return uniqueError(tx.Create(transfer).Error)
+	})
+}
+
+// GetBankTransferByProviderID retrieves the transfer of a notification by the ID the bank gave it.
+func (r *bankTransferRepository) GetBankTransferByProviderID(providerID string) (*entities.BankTransfer, error) {
+	var transfer entities.BankTransfer
+	if err := r.db.Where("provider_id = ?", providerID).First(&transfer).Error; err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// GetBankTransfersByEstablishmentID retrieves the transfers applied to the credit accounts of an
+// establishment, newest first.
+func (r *bankTransferRepository) GetBankTransfersByEstablishmentID(establishmentID uint) ([]entities.BankTransfer, error) {
+	var transfers []entities.BankTransfer
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("created_at DESC, id DESC").Find(&transfers).Error
+	return transfers, err
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CategoryRepository defines operations for managing the product categories of establishments.
+type CategoryRepository interface {
+	GetCategoriesByEstablishmentID(establishmentID uint) ([]entities.Category, error)
+	GetCategoryByID(categoryID uint) (*entities.Category, error)
+	CreateCategory(category *entities.Category) error
+	UpdateCategory(category *entities.Category) error
+	DeleteCategory(categoryID uint) (bool, error)
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository creates a new CategoryRepository instance.
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+// GetCategoriesByEstablishmentID retrieves the categories of an establishment by name.
+func (r *categoryRepository) GetCategoriesByEstablishmentID(establishmentID uint) ([]entities.Category, error) {
+	var categories []entities.Category
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("name").Find(&categories).Error
+	return categories, err
+}
+
+// GetCategoryByID retrieves a category by its ID.
+func (r *categoryRepository) GetCategoryByID(categoryID uint) (*entities.Category, error) {
+	var category entities.Category
+	if err := r.db.First(&category, categoryID).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// CreateCategory creates a category.
+func (r *categoryRepository) CreateCategory(category *entities.Category) error {
+	return uniqueError(r.db.Create(category).Error)
+}
+
+// UpdateCategory renames a category.
+func (r *categoryRepository) UpdateCategory(category *entities.Category) error {
+	return uniqueError(r.db.Model(category).Select("name", "updated_at").Updates(category).Error)
+}
+
+// DeleteCategory deletes a category, unless products still belong to it: it reports false, without
+// changes, if they do. Deleted products of the category are left without one.
+func (r *categoryRepository) DeleteCategory(categoryID uint) (bool, error) {
+	deleted := false
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		var category entities.Category
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&category, categoryID).Error; err != nil {
+			return err
+		}
+		var products int64
+		if err := tx.Model(&entities.Product{}).Where("category_id = ?", categoryID).Count(&products).Error; err != nil {
+			return err
+		}
+		if products > 0 {
+			return nil
+		}
+
+		err := tx.Unscoped().Model(&entities.Product{}).Where("category_id = ?", categoryID).Update("category_id", nil).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&category).Error; err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+// createDefaultCategories adds the default categories to a new establishment as part of tx.
+func createDefaultCategories(tx *gorm.DB, establishmentID uint) error {
+	categories := make([]entities.Category, len(enums.DefaultProductCategories))
+	for i, name := range enums.DefaultProductCategories {
+		categories[i] = entities.Category{EstablishmentID: establishmentID, Name: string(name)}
+	}
+	return tx.Create(&categories).Error
+}
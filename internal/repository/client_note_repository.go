@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClientNoteRepository defines operations for managing the notes admins write about their clients.
+type ClientNoteRepository interface {
+	CreateClientNote(note *entities.ClientNote) error
+	GetClientNoteByID(noteID uint) (*entities.ClientNote, error)
+	GetClientNotes(establishmentID, clientID uint) ([]entities.ClientNote, error)
+	UpdateClientNote(note *entities.ClientNote) error
+	DeleteClientNote(noteID uint) error
+}
+
+type clientNoteRepository struct {
+	db *gorm.DB
+}
+
+// NewClientNoteRepository creates a new ClientNoteRepository instance.
+func NewClientNoteRepository(db *gorm.DB) ClientNoteRepository {
+	return &clientNoteRepository{db: db}
+}
+
+// CreateClientNote creates a new client note in the database.
+func (r *clientNoteRepository) CreateClientNote(note *entities.ClientNote) error {
+	return r.db.Omit(clause.Associations).Create(note).Error
+}
+
+// GetClientNoteByID retrieves a client note by its ID, with its author.
+func (r *clientNoteRepository) GetClientNoteByID(noteID uint) (*entities.ClientNote, error) {
+	var note entities.ClientNote
+	if err := r.db.Preload("Author").First(&note, noteID).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// GetClientNotes retrieves the notes an establishment has about a client, newest first, with their authors.
+func (r *clientNoteRepository) GetClientNotes(establishmentID, clientID uint) ([]entities.ClientNote, error) {
+	var notes []entities.ClientNote
+	err := r.db.Preload("Author").Where("establishment_id = ? AND client_id = ?", establishmentID, clientID).
+		Order("created_at DESC, id DESC").Find(&notes).Error
+	return notes, err
+}
+
+// UpdateClientNote changes the text of a client note.
+func (r *clientNoteRepository) UpdateClientNote(note *entities.ClientNote) error {
+	return r.db.Model(note).Select("body", "updated_at").Updates(note).Error
+}
+
+// DeleteClientNote deletes a client note.
+func (r *clientNoteRepository) DeleteClientNote(noteID uint) error {
+	return r.db.Delete(&entities.ClientNote{}, noteID).Error
+}
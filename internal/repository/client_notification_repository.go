@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClientNotificationRepository defines operations for managing the notifications kept for clients.
+type ClientNotificationRepository interface {
+	CreateClientNotification(notification *entities.ClientNotification) error
+	GetClientNotifications(clientID, establishmentID uint, unreadOnly bool, offset, limit int) ([]entities.ClientNotification, int64, error)
+	MarkClientNotificationRead(clientID, notificationID uint, now time.Time) error
+	MarkClientNotificationsRead(clientID uint, now time.Time) (int64, error)
+}
+
+type clientNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewClientNotificationRepository creates a new ClientNotificationRepository instance.
+func NewClientNotificationRepository(db *gorm.DB) ClientNotificationRepository {
+	return &clientNotificationRepository{db: db}
+}
+
+// CreateClientNotification keeps a notification sent to a client.
+func (r *clientNotificationRepository) CreateClientNotification(notification *entities.ClientNotification) error {
+	return r.db.Create(notification).Error
+}
+
+// GetClientNotifications retrieves a page of the notifications of a client, newest first, and the
+// number of them across all pages. A non-zero establishmentID keeps only those about the client's
+// account at that establishment, and unreadOnly leaves out those already read.
+func (r *clientNotificationRepository) GetClientNotifications(clientID, establishmentID uint, unreadOnly bool, offset, limit int) ([]entities.ClientNotification, int64, error) {
+	query := r.db.Model(&entities.ClientNotification{}).Where("client_id = ?", clientID)
+	if establishmentID != 0 {
+		query = query.Where("establishment_id = ?", establishmentID)
+	}
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []entities.ClientNotification
+	err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&notifications).Error
+	return notifications, total, err
+}
+
+// MarkClientNotificationRead marks a notification of a client read at now. Notifications read before
+// keep when they were read. It fails with gorm.ErrRecordNotFound if the client has no such notification.
+func (r *clientNotificationRepository) MarkClientNotificationRead(clientID, notificationID uint, now time.Time) error {
+	var notification entities.ClientNotification
+	if err := r.db.Where("id = ? AND client_id = ?", notificationID, clientID).First(&notification).Error; err != nil {
+		return err
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+	return r.db.Model(&notification).Update("read_at", now).Error
+}
+
+// MarkClientNotificationsRead marks every unread notification of a client read at now, and returns
+// how many were.
+func (r *clientNotificationRepository) MarkClientNotificationsRead(clientID uint, now time.Time) (int64, error) {
+	result := r.db.Model(&entities.ClientNotification{}).Where("client_id = ? AND read_at IS NULL", clientID).Update("read_at", now)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/testutil/dbtest"
+	"ApiRestFinance/internal/testutil/fixture"
+	"testing"
+)
+
+func TestClientNotificationRepositoryGetClientNotificationsOfAnEstablishment(t *testing.T) {
+	db := dbtest.Open(t)
+	const otherEstablishmentID, otherAccountID uint = 2, 101
+	seedAccount(t, db, fixture.CreditAccount().Build())
+	other := fixture.Establishment().ID(otherEstablishmentID).RUC("20999999991").Build()
+	dbtest.Create(t, db, other, fixture.CreditAccount().ID(otherAccountID).Establishment(other).Build(),
+		&entities.ClientNotification{ClientID: fixture.ClientID, EstablishmentID: fixture.EstablishmentID, CreditAccountID: fixture.CreditAccountID, Kind: enums.PaymentReminderNotification, Message: "Recordatorio", CreatedAt: fixture.Now},
+		&entities.ClientNotification{ClientID: fixture.ClientID, EstablishmentID: otherEstablishmentID, CreditAccountID: otherAccountID, Kind: enums.PaymentReminderNotification, Message: "Recordatorio", CreatedAt: fixture.Now},
+	)
+	repo := repository.NewClientNotificationRepository(db)
+
+	tests := []struct {
+		name            string
+		establishmentID uint
+		want            int64
+	}{
+		{"every establishment", 0, 2},
+		{"one establishment", fixture.EstablishmentID, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifications, total, err := repo.GetClientNotifications(fixture.ClientID, tt.establishmentID, false, 0, 10)
+			if err != nil {
+				t.Fatalf("GetClientNotifications returned %v", err)
+			}
+			if total != tt.want || int64(len(notifications)) != tt.want {
+				t.Errorf("got %d notifications of %d, want %d", len(notifications), total, tt.want)
+			}
+			for _, notification := range notifications {
+				if tt.establishmentID != 0 && notification.EstablishmentID != tt.establishmentID {
+					t.Errorf("notification of establishment %d listed", notification.EstablishmentID)
+				}
+			}
+		})
+	}
+}
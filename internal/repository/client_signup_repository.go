@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/plan"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSignupDecided is returned when a client signup was already approved or rejected.
+var ErrSignupDecided = errors.New("client signup was already decided")
+
+// ClientSignupRepository defines operations for managing the self-registrations of clients waiting
+// for an admin's approval.
+type ClientSignupRepository interface {
+	CreateClientSignup(signup *entities.ClientSignup) error
+	GetClientSignupByID(signupID uint) (*entities.ClientSignup, error)
+	GetPendingClientSignup(establishmentID uint, dni string) (*entities.ClientSignup, error)
+	GetClientSignupsByEstablishmentID(establishmentID uint, status enums.SignupStatus) ([]entities.ClientSignup, error)
+	ApproveClientSignup(signup *entities.ClientSignup, user *entities.User, creditAccount *entities.CreditAccount) error
+	RejectClientSignup(signup *entities.ClientSignup) (bool, error)
+}
+
+type clientSignupRepository struct {
+	db *gorm.DB
+}
+
+// NewClientSignupRepository creates a new ClientSignupRepository instance.
+func NewClientSignupRepository(db *gorm.DB) ClientSignupRepository {
+	return &clientSignupRepository{db: db}
+}
+
+// CreateClientSignup creates a new client signup in the database.
+func (r *clientSignupRepository) CreateClientSignup(signup *entities.ClientSignup) error {
+	return r.db.Omit(clause.Associations).Create(signup).Error
+}
+
+// GetClientSignupByID retrieves a client signup by its ID.
+func (r *clientSignupRepository) GetClientSignupByID(signupID uint) (*entities.ClientSignup, error) {
+	var signup entities.ClientSignup
+	err := r.db.First(&signup, signupID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &signup, nil
+}
+
+// GetPendingClientSignup retrieves the signup still waiting for approval of a DNI in an establishment.
+func (r *clientSignupRepository) GetPendingClientSignup(establishmentID uint, dni string) (*entities.ClientSignup, error) {
+	var signup entities.ClientSignup
+	err := r.db.Where("establishment_id = ? AND dni = ? AND status = ?", establishmentID, dni, enums.SignupPending).First(&signup).Error
+	if err != nil {
+		return nil, err
+	}
+	return &signup, nil
+}
+
+// GetClientSignupsByEstablishmentID retrieves the client signups of an establishment, oldest first,
+// only those in a status unless status is empty.
+func (r *clientSignupRepository) GetClientSignupsByEstablishmentID(establishmentID uint, status enums.SignupStatus) ([]entities.ClientSignup, error) {
+	query := r.db.Where("establishment_id = ?", establishmentID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var signups []entities.ClientSignup
+	err := query.Order("created_at, id").Find(&signups).Error
+	return signups, err
+}
+
+// ApproveClientSignup approves a pending client signup and opens the client's credit account, with
+// its credit agreement, in a single transaction. The client user is created too unless user is nil,
+// when creditAccount.ClientID is an existing client. It returns ErrSignupDecided if the signup was
+// decided meanwhile, and plan.ErrUpgradeRequired if the establishment's plan allows no more clients.
+func (r *clientSignupRepository) ApproveClientSignup(signup *entities.ClientSignup, user *entities.User, creditAccount *entities.CreditAccount) error {
+	return uniqueError(inTransaction(r.db, func(tx *gorm.DB) error {
+		if err := checkEstablishmentActive(tx, creditAccount.EstablishmentID); err != nil {
+			return err
+		}
+		if err := checkPlanQuota(tx, creditAccount.EstablishmentID, plan.Clients, 1); err != nil {
+			return err
+		}
+		if user != nil {
+			user.ID = 0
+			if err := tx.Create(user).Error; err != nil {
+				return fmt.Errorf("error creating user: %w", err)
+			}
+			creditAccount.ClientID = user.ID
+		}
+		creditAccount.ID = 0
+		if err := tx.Omit(clause.Associations).Create(creditAccount).Error; err != nil {
+			return fmt.Errorf("error creating credit account: %w", err)
+		}
+		if err := createCreditAgreement(tx, creditAccount, *signup.DecidedAt); err != nil {
+			return err
+		}
+
+		result := tx.Model(&entities.ClientSignup{}).
+			Where("id = ? AND status = ?", signup.ID, enums.SignupPending).
+			Updates(map[string]interface{}{
+				"status":            enums.SignupApproved,
+				"decided_by_id":     signup.DecidedByID,
+				"decided_at":        signup.DecidedAt,
+				"credit_account_id": creditAccount.ID,
+				"updated_at":        signup.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrSignupDecided
+		}
+		signup.Status, signup.CreditAccountID = enums.SignupApproved, &creditAccount.ID
+		return nil
+	}))
+}
+
+// RejectClientSignup saves the rejection of a pending client signup. It reports false if the signup
+// was decided meanwhile.
+func (r *clientSignupRepository) RejectClientSignup(signup *entities.ClientSignup) (bool, error) {
+	result := r.db.Model(&entities.ClientSignup{}).
+		Where("id = ? AND status = ?", signup.ID, enums.SignupPending).
+		Updates(map[string]interface{}{
+			"status":        enums.SignupRejected,
+			"decided_by_id": signup.DecidedByID,
+			"decided_at":    signup.DecidedAt,
+			"reason":        signup.Reason,
+			"updated_at":    signup.UpdatedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
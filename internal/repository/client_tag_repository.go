@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClientTagRepository defines operations for managing the client tags of establishments and the
+// clients tagged with them.
+type ClientTagRepository interface {
+	GetClientTagsByEstablishmentID(establishmentID uint) ([]entities.ClientTag, error)
+	GetClientTagByID(tagID uint) (*entities.ClientTag, error)
+	CreateClientTag(tag *entities.ClientTag) error
+	UpdateClientTag(tag *entities.ClientTag) error
+	DeleteClientTag(tagID uint) error
+	GetTagsOfClient(establishmentID, clientID uint) ([]entities.ClientTag, error)
+	SetClientTags(establishmentID, clientID uint, tagIDs []uint, now time.Time) error
+}
+
+type clientTagRepository struct {
+	db *gorm.DB
+}
+
+// NewClientTagRepository creates a new ClientTagRepository instance.
+func NewClientTagRepository(db *gorm.DB) ClientTagRepository {
+	return &clientTagRepository{db: db}
+}
+
+// GetClientTagsByEstablishmentID retrieves the client tags of an establishment by name.
+func (r *clientTagRepository) GetClientTagsByEstablishmentID(establishmentID uint) ([]entities.ClientTag, error) {
+	var tags []entities.ClientTag
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("name").Find(&tags).Error
+	return tags, err
+}
+
+// GetClientTagByID retrieves a client tag by its ID.
+func (r *clientTagRepository) GetClientTagByID(tagID uint) (*entities.ClientTag, error) {
+	var tag entities.ClientTag
+	if err := r.db.First(&tag, tagID).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// CreateClientTag creates a client tag.
+func (r *clientTagRepository) CreateClientTag(tag *entities.ClientTag) error {
+	return uniqueError(r.db.Create(tag).Error)
+}
+
+// UpdateClientTag renames a client tag.
+func (r *clientTagRepository) UpdateClientTag(tag *entities.ClientTag) error {
+	return uniqueError(r.db.Model(tag).Select("name", "updated_at").Updates(tag).Error)
+}
+
+// DeleteClientTag deletes a client tag, untagging the clients that had it.
+func (r *clientTagRepository) DeleteClientTag(tagID uint) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		if err := tx.Where("client_tag_id = ?", tagID).Delete(&entities.ClientTagAssignment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.ClientTag{}, tagID).Error
+	})
+}
+
+// GetTagsOfClient retrieves the tags of an establishment a client has, by name.
+func (r *clientTagRepository) GetTagsOfClient(establishmentID, clientID uint) ([]entities.ClientTag, error) {
+	var tags []entities.ClientTag
+	err := r.db.Joins("JOIN client_tag_assignments ON client_tag_assignments.client_tag_id = client_tags.id").
+		Where("client_tags.establishment_id = ? AND client_tag_assignments.client_id = ?", establishmentID, clientID).
+		Order("client_tags.name").
+		Find(&tags).Error
+	return tags, err
+}
+
+// SetClientTags replaces the tags of an establishment a client has with tagIDs, which must be tags of
+// the establishment. Tags of other establishments the client has are left alone.
+func (r *clientTagRepository) SetClientTags(establishmentID, clientID uint, tagIDs []uint, now time.Time) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		establishmentTags := tx.Model(&entities.ClientTag{}).Select("id").Where("establishment_id = ?", establishmentID)
+		err := tx.Where("client_id = ? AND client_tag_id IN (?)", clientID, establishmentTags).
+			Delete(&entities.ClientTagAssignment{}).Error
+		if err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+		assignments := make([]entities.ClientTagAssignment, len(tagIDs))
+		for i, tagID := range tagIDs {
+			assignments[i] = entities.ClientTagAssignment{ClientTagID: tagID, ClientID: clientID, CreatedAt: now}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&assignments).Error
+	})
+}
+
+// withClientTags limits query to the clients, in clientColumn, that have every one of tags in the
+// establishment of establishmentColumn. Tag names are matched ignoring case; no tags match every client.
+func withClientTags(query *gorm.DB, clientColumn, establishmentColumn string, tags []string) *gorm.DB {
+	for _, tag := range tags {
+		query = query.Where("EXISTS (SELECT 1 FROM client_tag_assignments JOIN client_tags ON client_tags.id = client_tag_assignments.client_tag_id"+
+			" WHERE client_tag_assignments.client_id = "+clientColumn+" AND client_tags.establishment_id = "+establishmentColumn+
+			" AND lower(client_tags.name) = lower(?))", tag)
+	}
+	return query
+}
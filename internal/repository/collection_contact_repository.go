@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CollectionContactRepository defines operations for managing the contact log of collections.
+type CollectionContactRepository interface {
+	CreateCollectionContact(contact *entities.CollectionContact) error
+	GetLastCollectionContacts(creditAccountIDs []uint) (map[uint]entities.CollectionContact, error)
+}
+
+type collectionContactRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionContactRepository creates a new CollectionContactRepository instance.
+func NewCollectionContactRepository(db *gorm.DB) CollectionContactRepository {
+	return &collectionContactRepository{db: db}
+}
+
+// CreateCollectionContact creates a new collection contact in the database.
+func (r *collectionContactRepository) CreateCollectionContact(contact *entities.CollectionContact) error {
+	return r.db.Omit(clause.Associations).Create(contact).Error
+}
+
+// GetLastCollectionContacts retrieves the latest collection contact of each of the credit accounts,
+// keyed by credit account ID. Accounts never contacted are left out.
+func (r *collectionContactRepository) GetLastCollectionContacts(creditAccountIDs []uint) (map[uint]entities.CollectionContact, error) {
+	lastContacts := make(map[uint]entities.CollectionContact, len(creditAccountIDs))
+	if len(creditAccountIDs) == 0 {
+		return lastContacts, nil
+	}
+	var contacts []entities.CollectionContact
+	err := r.db.Select("DISTINCT ON (credit_account_id) *").
+		Where("credit_account_id IN ?", creditAccountIDs).
+		Order("credit_account_id, contacted_at DESC, id DESC").
+		Find(&contacts).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, contact := range contacts {
+		lastContacts[contact.CreditAccountID] = contact
+	}
+	return lastContacts, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/database"
+	"errors"
+)
+
+// Errors of writes that would give a record a value another record already has, where it has to
+// be unique.
+var (
+	ErrDNIInUse             = errors.New("DNI already in use")
+	ErrEmailInUse           = errors.New("email already in use")
+	ErrRUCInUse             = errors.New("RUC already registered by another establishment")
+	ErrCreditAccountExists  = errors.New("client already has a credit account in this establishment")
+	ErrSKUExists            = errors.New("the establishment already has a product with that SKU")
+	ErrBarcodeExists        = errors.New("the establishment already has a product with that barcode")
+	ErrCategoryExists       = errors.New("the establishment already has a category with that name")
+	ErrClientTagExists      = errors.New("the establishment already has a client tag with that name")
+	ErrDocumentSeriesExists = errors.New("the establishment already has a document series with that code")
+	ErrCreditTemplateExists = errors.New("the establishment already has a credit template with that name")
+	ErrHolidayExists        = errors.New("the establishment already has a holiday on that date")
+)
+
+// uniqueIndexErrors gives the unique indexes of the schema the error of the field they keep unique.
+var uniqueIndexErrors = map[string]error{
+	"idx_users_dni":                           ErrDNIInUse,
+	"idx_users_email":                         ErrEmailInUse,
+	"idx_users_email_lower":                   ErrEmailInUse,
+	"idx_establishments_main_ruc":             ErrRUCInUse,
+	"idx_client_establishment":                ErrCreditAccountExists,
+	"idx_products_establishment_sku":          ErrSKUExists,
+	"idx_products_establishment_barcode":      ErrBarcodeExists,
+	"idx_categories_establishment_name":       ErrCategoryExists,
+	"idx_client_tags_establishment_name":      ErrClientTagExists,
+	"idx_document_series_establishment_code":  ErrDocumentSeriesExists,
+	"idx_till_sessions_establishment_open":    ErrTillSessionOpen,
+	"idx_credit_templates_establishment_name": ErrCreditTemplateExists,
+	"idx_bank_transfers_provider_id":          ErrBankTransferReceived,
+	"idx_holidays_establishment_date":         ErrHolidayExists,
+}
+
+// uniqueError replaces err, when it is a write rejected by one of the unique indexes of
+// uniqueIndexErrors, by the error of its field. The services check most of them first, but only
+// the index settles two requests racing for the same value. Other errors are returned unchanged.
+func uniqueError(err error) error {
+	if index, ok := database.UniqueViolation(err); ok {
+		if fieldErr, ok := uniqueIndexErrors[index]; ok {
+			return fieldErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ContactVerificationRepository defines operations for managing the codes that verify the email
+// and phone of users.
+type ContactVerificationRepository interface {
+	SaveContactVerification(verification *entities.ContactVerification) error
+	GetContactVerification(userID uint, channel enums.ContactChannel) (*entities.ContactVerification, error)
+	AddFailedAttempt(verificationID uint) error
+	ConfirmContact(verification *entities.ContactVerification, now time.Time) (bool, error)
+}
+
+type contactVerificationRepository struct {
+	db *gorm.DB
+}
+
+// NewContactVerificationRepository creates a new ContactVerificationRepository instance.
+func NewContactVerificationRepository(db *gorm.DB) ContactVerificationRepository {
+	return &contactVerificationRepository{db: db}
+}
+
+// SaveContactVerification stores a new code for a channel of a user, replacing the previous one
+// and its failed attempts.
+func (r *contactVerificationRepository) SaveContactVerification(verification *entities.ContactVerification) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"destination", "code_hash", "attempts", "expires_at", "sent_at", "created_at"}),
+	}).Create(verification).Error
+}
+
+// GetContactVerification retrieves the code last sent to a channel of a user.
+func (r *contactVerificationRepository) GetContactVerification(userID uint, channel enums.ContactChannel) (*entities.ContactVerification, error) {
+	var verification entities.ContactVerification
+	err := r.db.Where("user_id = ? AND channel = ?", userID, channel).First(&verification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// AddFailedAttempt counts a wrong code entered for a verification.
+func (r *contactVerificationRepository) AddFailedAttempt(verificationID uint) error {
+	return r.db.Model(&entities.ContactVerification{}).Where("id = ?", verificationID).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// ConfirmContact marks the email or phone a verification code was sent to as verified at now and
+// deletes the code. It reports false, only deleting the code, if the user changed that email or
+// phone since the code was sent.
+func (r *contactVerificationRepository) ConfirmContact(verification *entities.ContactVerification, now time.Time) (bool, error) {
+	column, verifiedColumn := "email", "email_verified_at"
+	if verification.Channel == enums.ContactPhone {
+		column, verifiedColumn = "phone", "phone_verified_at"
+	}
+	confirmed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.User{}).
+			Where("id = ? AND "+column+" = ?", verification.UserID, verification.Destination).
+			Update(verifiedColumn, now)
+		if result.Error != nil {
+			return result.Error
+		}
+		confirmed = result.RowsAffected == 1
+		return tx.Delete(&entities.ContactVerification{}, verification.ID).Error
+	})
+	return confirmed, err
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// accountUpdateReason is the event reason of the terms changed through a credit account update.
+const accountUpdateReason = "Changed in a credit account update"
+
+// CreditAccountEventRepository reads the history of changes to the terms and status of credit
+// accounts. Events are written by the repository methods that make the changes, in the same
+// transaction.
+type CreditAccountEventRepository interface {
+	GetCreditAccountEvents(creditAccountID uint, offset, limit int) ([]entities.CreditAccountEvent, int64, error)
+}
+
+type creditAccountEventRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditAccountEventRepository creates a new CreditAccountEventRepository instance.
+func NewCreditAccountEventRepository(db *gorm.DB) CreditAccountEventRepository {
+	return &creditAccountEventRepository{db: db}
+}
+
+// GetCreditAccountEvents retrieves a page of the history of a credit account, newest first, with the
+// admins who made the changes, and the number of events across all pages.
+func (r *creditAccountEventRepository) GetCreditAccountEvents(creditAccountID uint, offset, limit int) ([]entities.CreditAccountEvent, int64, error) {
+	query := r.db.Model(&entities.CreditAccountEvent{}).Where("credit_account_id = ?", creditAccountID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []entities.CreditAccountEvent
+	err := query.Preload("Actor").Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&events).Error
+	return events, total, err
+}
+
+// recordAccountEvent adds an event to the history of a credit account, once the change was made in tx.
+func recordAccountEvent(tx *gorm.DB, accountEvent *entities.CreditAccountEvent) error {
+	if accountEvent.CreatedAt.IsZero() {
+		accountEvent.CreatedAt = time.Now()
+	}
+	return tx.Omit(clause.Associations).Create(accountEvent).Error
+}
+
+// recordTermChanges adds an event for the credit limit and the interest rate of a credit account that
+// changed from those of previous, once it was saved in tx.
+func recordTermChanges(tx *gorm.DB, previous, creditAccount *entities.CreditAccount, reason string, actorID *uint, now time.Time) error {
+	changes := []struct {
+		eventType          enums.CreditAccountEventType
+		oldValue, newValue float64
+	}{
+		{enums.CreditAccountLimitChanged, previous.CreditLimit, creditAccount.CreditLimit},
+		{enums.CreditAccountRateChanged, previous.InterestRate, creditAccount.InterestRate},
+	}
+	for _, change := range changes {
+		if change.oldValue == change.newValue {
+			continue
+		}
+		oldValue, newValue := change.oldValue, change.newValue
+		err := recordAccountEvent(tx, &entities.CreditAccountEvent{
+			CreditAccountID: creditAccount.ID,
+			Type:            change.eventType,
+			OldValue:        &oldValue,
+			NewValue:        &newValue,
+			Reason:          reason,
+			ActorID:         actorID,
+			CreatedAt:       now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordBlock records a credit account being blocked or unblocked, as blockEvent says, once it was in
+// tx: it creates blockEvent in the block history and adds it to the account's activity, its events and
+// the outbox.
+func recordBlock(tx *gorm.DB, blockEvent *entities.CreditAccountBlockEvent) error {
+	if err := tx.Create(blockEvent).Error; err != nil {
+		return err
+	}
+	if err := recordBlockActivity(tx, blockEvent); err != nil {
+		return err
+	}
+	eventType := enums.CreditAccountUnblocked
+	if blockEvent.Blocked {
+		eventType = enums.CreditAccountBlocked
+	}
+	err := recordAccountEvent(tx, &entities.CreditAccountEvent{
+		CreditAccountID: blockEvent.CreditAccountID,
+		Type:            eventType,
+		Reason:          blockEvent.Reason,
+		Automatic:       blockEvent.Automatic,
+		ActorID:         blockEvent.ActorID,
+		CreatedAt:       blockEvent.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return enqueueBlockEvent(tx, blockEvent)
+}
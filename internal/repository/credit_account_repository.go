@@ -1,8 +1,13 @@
 package repository
 
 import (
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/interest"
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/plan"
+	"ApiRestFinance/internal/util"
 	"errors"
 	"fmt"
 	"math"
@@ -16,33 +21,95 @@ import (
 type CreditAccountRepository interface {
 	CreateCreditAccount(creditAccount *entities.CreditAccount) error
 	GetCreditAccountByID(creditAccountID uint) (*entities.CreditAccount, error)
-	GetCreditAccountByClientID(clientID uint) (*entities.CreditAccount, error)
+	GetCreditAccountsByClientID(clientID uint) ([]entities.CreditAccount, error)
+	GetCreditAccountByClientAndEstablishmentID(clientID, establishmentID uint) (*entities.CreditAccount, error)
 	UpdateCreditAccount(creditAccount *entities.CreditAccount) error
+	SetCreditAccountBlocked(creditAccountID uint, event *entities.CreditAccountBlockEvent) (bool, error)
+	GetBlockHistory(creditAccountID uint) ([]entities.CreditAccountBlockEvent, error)
+	UpdateCreditScore(creditAccountID uint, score int, highRisk bool, scoredAt time.Time) error
 	DeleteCreditAccount(creditAccountID uint) error
 	GetCreditAccountsByEstablishmentID(establishmentID uint) ([]entities.CreditAccount, error)
+	SearchCreditAccounts(establishmentID uint, query string, tags []string, offset, limit int) ([]entities.CreditAccount, int64, error)
+	GetAllCreditAccounts() ([]entities.CreditAccount, error)
 	ApplyInterest(creditAccount *entities.CreditAccount) error
-	ApplyLateFee(creditAccount *entities.CreditAccount, daysOverdue int) error
-	GetOverdueCreditAccounts(establishmentID uint) ([]entities.CreditAccount, error)
+	ApplyLateFee(creditAccount *entities.CreditAccount, lateFee *entities.LateFee) error
+	GetOwingCreditAccounts(establishmentID uint) ([]entities.CreditAccount, error)
 	ProcessPurchase(creditAccount *entities.CreditAccount, amount float64, description string) error
-	ProcessPayment(creditAccount *entities.CreditAccount, amount float64, description string) error
+	ProcessPayment(creditAccount *entities.CreditAccount, amount float64, paymentMethod enums.PaymentMethod, description string) error
 	CreateClientAndCreditAccount(user *entities.User, creditAccount *entities.CreditAccount) error
 	DeleteClientAndCreditAccount(userID uint) error
-	ProcessPurchaseTransaction(creditAccount *entities.CreditAccount, amount float64, description string) error
+	ProcessPurchaseTransaction(creditAccount *entities.CreditAccount, amount float64, description string, items []entities.PurchaseItem) error
+	ApprovePurchaseTransaction(approval *entities.PurchaseApproval, adminID uint, description string, items []entities.PurchaseItem) error
+	SettleCreditAccount(creditAccount *entities.CreditAccount, expectedBalance, payoffAmount float64, description string) error
+	WriteOffCreditAccount(creditAccount *entities.CreditAccount, writeOff *entities.CreditAccountWriteOff) error
+	GetWriteOffsByEstablishmentID(establishmentID uint) ([]entities.CreditAccountWriteOff, error)
+	CloseCreditAccount(creditAccount *entities.CreditAccount, reason string, actorID uint) error
+	ReopenCreditAccount(creditAccount *entities.CreditAccount, reason string, actorID uint) error
+	GetClientEstablishmentIDs(clientID uint) ([]uint, error)
+	GetClientHomeAccounts(clientID, establishmentID uint) ([]ClientHomeAccount, error)
 }
 
+// ClientHomeAccount is a client's credit account with what the home screen of the app shows of it
+// besides its balance: its establishment, the earliest of its installments not paid in full and
+// the client's unread notifications about it.
+type ClientHomeAccount struct {
+	entities.CreditAccount
+	EstablishmentName     string
+	EstablishmentTimezone string
+	NextDueDate           *time.Time // nil when no installment is owed
+	NextDueAmount         float64    // Left to pay of that installment
+	UnreadNotifications   int64
+}
+
+// ErrBalanceChanged is returned when an account's balance changed between quoting and settling it.
+var ErrBalanceChanged = errors.New("credit account balance changed")
+
+// ErrCreditAccountBlocked is returned when a purchase is attempted on a blocked credit account.
+var ErrCreditAccountBlocked = errors.New("credit account is blocked, cannot process purchase")
+
+// ErrApprovalDecided is returned when a purchase approval was already approved, rejected or expired.
+var ErrApprovalDecided = errors.New("purchase approval was already decided")
+
+// ErrAccountWrittenOff is returned when writing off a credit account that was already written off.
+var ErrAccountWrittenOff = errors.New("credit account was written off")
+
+// ErrAccountClosed is returned when a purchase is attempted on a closed credit account, or when
+// closing one again.
+var ErrAccountClosed = errors.New("credit account is closed, cannot process purchase")
+
+// ErrAccountNotClosed is returned when reopening a credit account that is not closed.
+var ErrAccountNotClosed = errors.New("credit account is not closed")
+
+// ErrLateFeeApplied is returned when a billing cycle was already charged all the late fees it owes.
+var ErrLateFeeApplied = errors.New("the billing cycle was already charged its late fee")
+
 type creditAccountRepository struct {
 	db       *gorm.DB
 	userRepo UserRepository
+	clock    util.Clock
 }
 
 // NewCreditAccountRepository creates a new CreditAccountRepository instance.
-func NewCreditAccountRepository(db *gorm.DB, userRepo UserRepository) CreditAccountRepository {
-	return &creditAccountRepository{db: db, userRepo: userRepo}
+func NewCreditAccountRepository(db *gorm.DB, userRepo UserRepository, clock util.Clock) CreditAccountRepository {
+	return &creditAccountRepository{db: db, userRepo: userRepo, clock: clock}
 }
 
-// CreateCreditAccount creates a new credit account in the database.
+// CreateCreditAccount creates a new credit account in the database, with its credit agreement. It
+// fails with ErrEstablishmentInactive if the establishment is deactivated, and with
+// plan.ErrUpgradeRequired if its plan allows no more clients.
 func (r *creditAccountRepository) CreateCreditAccount(creditAccount *entities.CreditAccount) error {
-	return r.db.Create(creditAccount).Error
+	return uniqueError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkEstablishmentActive(tx, creditAccount.EstablishmentID); err != nil {
+			return err
+		}
+		if err := checkPlanQuota(tx, creditAccount.EstablishmentID, plan.Clients, 1); err != nil {
+			return err
+		}
+		if err := tx.Create(creditAccount).Error; err != nil {
+			return err
+		}
+		return createCreditAgreement(tx, creditAccount, r.clock.Now())
+	}))
 }
 
 // GetCreditAccountByID retrieves a credit account by its ID, including the Establishment.
@@ -55,19 +122,134 @@ func (r *creditAccountRepository) GetCreditAccountByID(creditAccountID uint) (*e
 	return &creditAccount, nil
 }
 
-// GetCreditAccountByClientID retrieves a credit account by its client ID.
-func (r *creditAccountRepository) GetCreditAccountByClientID(clientID uint) (*entities.CreditAccount, error) {
+// GetClientEstablishmentIDs retrieves the establishments where a client holds credit accounts.
+func (r *creditAccountRepository) GetClientEstablishmentIDs(clientID uint) ([]uint, error) {
+	var establishmentIDs []uint
+	err := r.db.Model(&entities.CreditAccount{}).Where("client_id = ?", clientID).Order("establishment_id").Pluck("establishment_id", &establishmentIDs).Error
+	return establishmentIDs, err
+}
+
+// GetClientHomeAccounts retrieves the credit accounts a client holds, or the one in establishmentID
+// unless it is 0, for the home screen of the app, in one query.
+func (r *creditAccountRepository) GetClientHomeAccounts(clientID, establishmentID uint) ([]ClientHomeAccount, error) {
+	query := r.db.Model(&entities.CreditAccount{}).
+		Select(`credit_accounts.*,
+			establishments.name AS establishment_name,
+			establishments.timezone AS establishment_timezone,
+			next_installment.due_date AS next_due_date,
+			COALESCE(next_installment.outstanding, 0) AS next_due_amount,
+			(SELECT COUNT(*) FROM client_notifications
+				WHERE client_notifications.credit_account_id = credit_accounts.id AND client_notifications.read_at IS NULL) AS unread_notifications`).
+		Joins("JOIN establishments ON establishments.id = credit_accounts.establishment_id").
+		Joins(`LEFT JOIN LATERAL (
+			SELECT installments.due_date, installments.amount - COALESCE(SUM(payment_allocations.amount), 0) AS outstanding
+			FROM installments LEFT JOIN payment_allocations ON payment_allocations.installment_id = installments.id
+			WHERE installments.credit_account_id = credit_accounts.id AND installments.deleted_at IS NULL AND installments.status IN ?
+			GROUP BY installments.id
+			HAVING installments.amount - COALESCE(SUM(payment_allocations.amount), 0) >= 0.005
+			ORDER BY installments.due_date, installments.id
+			LIMIT 1
+		) next_installment ON true`, openInstallmentStatuses).
+		Where("credit_accounts.client_id = ?", clientID)
+	if establishmentID != 0 {
+		query = query.Where("credit_accounts.establishment_id = ?", establishmentID)
+	}
+
+	var accounts []ClientHomeAccount
+	err := query.Order("credit_accounts.id").Scan(&accounts).Error
+	return accounts, err
+}
+
+// GetCreditAccountsByClientID retrieves the credit accounts a client holds, one per establishment.
+func (r *creditAccountRepository) GetCreditAccountsByClientID(clientID uint) ([]entities.CreditAccount, error) {
+	var creditAccounts []entities.CreditAccount
+	err := r.db.Where("client_id = ?", clientID).Preload("Client").Preload("Establishment").Order("id").Find(&creditAccounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return creditAccounts, nil
+}
+
+// GetCreditAccountByClientAndEstablishmentID retrieves a client's credit account in an establishment.
+func (r *creditAccountRepository) GetCreditAccountByClientAndEstablishmentID(clientID, establishmentID uint) (*entities.CreditAccount, error) {
 	var creditAccount entities.CreditAccount
-	err := r.db.Where("client_id = ?", clientID).Preload("Client").Preload("Establishment").First(&creditAccount).Error
+	err := r.db.Where("client_id = ? AND establishment_id = ?", clientID, establishmentID).
+		Preload("Client").Preload("Establishment").First(&creditAccount).Error
 	if err != nil {
 		return nil, err
 	}
 	return &creditAccount, nil
 }
 
-// UpdateCreditAccount updates an existing credit account in the database.
+// UpdateCreditAccount updates an existing credit account in the database, recording a change of its
+// credit limit in its activity and of its credit limit or interest rate in its events. It fails with
+// ErrVersionConflict if the account changed since it was read at creditAccount.Version, and moves it
+// to the next version otherwise.
 func (r *creditAccountRepository) UpdateCreditAccount(creditAccount *entities.CreditAccount) error {
-	return r.db.Save(creditAccount).Error
+	version := creditAccount.Version
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		creditAccount.Version = version
+		return saveCreditAccount(tx, creditAccount, accountUpdateReason, nil, r.clock.Now())
+	})
+}
+
+// saveCreditAccount saves a credit account if it still is at the version it was read at (see
+// saveVersion), adding a ledger entry at now when its credit limit changed and an event, for reason
+// and by actorID, for each of its credit limit and interest rate that changed.
+func saveCreditAccount(tx *gorm.DB, creditAccount *entities.CreditAccount, reason string, actorID *uint, now time.Time) error {
+	var previous entities.CreditAccount
+	err := tx.Model(&entities.CreditAccount{}).Select("credit_limit", "interest_rate").
+		Where("id = ?", creditAccount.ID).Take(&previous).Error
+	if err != nil {
+		return err
+	}
+	if err := saveVersion(tx, creditAccount, &creditAccount.Version); err != nil {
+		return err
+	}
+	if err := recordTermChanges(tx, &previous, creditAccount, reason, actorID, now); err != nil {
+		return err
+	}
+	if previous.CreditLimit == creditAccount.CreditLimit {
+		return nil
+	}
+	description := fmt.Sprintf("Credit limit changed from %.2f to %.2f", previous.CreditLimit, creditAccount.CreditLimit)
+	return recordActivity(tx, creditAccount, enums.ActivityLimitChange, 0, description, nil, now)
+}
+
+// SetCreditAccountBlocked blocks or unblocks a credit account, as blockEvent says, and records blockEvent in
+// its block history and events, moving the account to its next version. It reports whether the account changed:
+// blocking a blocked account records nothing.
+func (r *creditAccountRepository) SetCreditAccountBlocked(creditAccountID uint, blockEvent *entities.CreditAccountBlockEvent) (bool, error) {
+	changed := false
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		result := tx.Model(&entities.CreditAccount{}).
+			Where("id = ? AND is_blocked = ?", creditAccountID, !blockEvent.Blocked).
+			Updates(map[string]interface{}{"is_blocked": blockEvent.Blocked, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		changed = result.RowsAffected > 0
+		if !changed {
+			return nil
+		}
+		blockEvent.ID = 0
+		blockEvent.CreditAccountID = creditAccountID
+		return recordBlock(tx, blockEvent)
+	})
+	return changed, err
+}
+
+// GetBlockHistory retrieves the times a credit account was blocked or unblocked, newest first.
+func (r *creditAccountRepository) GetBlockHistory(creditAccountID uint) ([]entities.CreditAccountBlockEvent, error) {
+	var events []entities.CreditAccountBlockEvent
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Preload("Actor").Order("created_at DESC, id DESC").Find(&events).Error
+	return events, err
+}
+
+// UpdateCreditScore stores the latest credit score of a credit account without touching its other fields.
+func (r *creditAccountRepository) UpdateCreditScore(creditAccountID uint, score int, highRisk bool, scoredAt time.Time) error {
+	return r.db.Model(&entities.CreditAccount{}).Where("id = ?", creditAccountID).
+		Updates(map[string]interface{}{"credit_score": score, "high_risk": highRisk, "scored_at": scoredAt}).Error
 }
 
 // DeleteCreditAccount deletes a credit account from the database.
@@ -85,50 +267,209 @@ func (r *creditAccountRepository) GetCreditAccountsByEstablishmentID(establishme
 	return creditAccounts, nil
 }
 
-// ApplyInterest calculates and applies interest to a credit account.
+// SearchCreditAccounts retrieves one page of the credit accounts of an establishment whose client's name,
+// DNI, email or phone contains query, ordered by client name, along with the number of matches across all
+// pages. An empty query matches every account. The ILIKE filters can use the trigram indexes on users. With
+// tags, only the accounts of clients with every one of them in the establishment match.
+func (r *creditAccountRepository) SearchCreditAccounts(establishmentID uint, query string, tags []string, offset, limit int) ([]entities.CreditAccount, int64, error) {
+	search := database.ReadReplica(r.db).Model(&entities.CreditAccount{}).
+		Joins("JOIN users ON users.id = credit_accounts.client_id AND users.deleted_at IS NULL").
+		Where("credit_accounts.establishment_id = ?", establishmentID)
+	if query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		search = search.Where("users.name ILIKE ? OR users.dni ILIKE ? OR users.email ILIKE ? OR users.phone ILIKE ?", pattern, pattern, pattern, pattern)
+	}
+	search = withClientTags(search, "credit_accounts.client_id", "credit_accounts.establishment_id", tags)
+
+	var total int64
+	if err := search.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var creditAccounts []entities.CreditAccount
+	err := search.Preload("Client").Preload("Establishment").
+		Order("users.name").
+		Order("credit_accounts.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&creditAccounts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return creditAccounts, total, nil
+}
+
+// GetAllCreditAccounts retrieves the credit accounts of every establishment, for jobs that go over all of them.
+func (r *creditAccountRepository) GetAllCreditAccounts() ([]entities.CreditAccount, error) {
+	var creditAccounts []entities.CreditAccount
+	err := r.db.Preload("Establishment").Order("id").Find(&creditAccounts).Error
+	return creditAccounts, err
+}
+
+// ApplyInterest calculates and applies interest to a credit account, once a month. Long-term
+// purchases still in their grace period don't accrue interest.
 func (r *creditAccountRepository) ApplyInterest(creditAccount *entities.CreditAccount) error {
-	if creditAccount.CurrentBalance == 0 ||
-		time.Now().Before(creditAccount.LastInterestAccrualDate.AddDate(0, 1, 0)) {
+	if !interestDue(creditAccount, r.clock.Now()) {
 		return nil
 	}
 
-	interest := calculateInterest(*creditAccount)
-	creditAccount.CurrentBalance += interest
-	creditAccount.LastInterestAccrualDate = time.Now()
+	original := *creditAccount
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*creditAccount = original
+		// Retrieve the credit account for update, locking the row, and check again: interest may
+		// have been applied since it was read, e.g. by the job and an admin at the same time
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(creditAccount, creditAccount.ID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account for interest: %w", err)
+		}
+		now := r.clock.Now()
+		if !interestDue(creditAccount, now) {
+			return nil
+		}
+		chargeable, err := balanceOutsideGrace(tx, creditAccount, now)
+		if err != nil {
+			return err
+		}
+
+		// Interest accrues once a month on the balance
+		var accrued float64
+		if chargeable > 0 {
+			accrued, err = interest.ForMonths(chargeable, creditAccount.InterestRate/100,
+				creditAccount.InterestType, creditAccount.CompoundingPeriod, 1)
+			if err != nil {
+				return err
+			}
+		}
+		creditAccount.CurrentBalance += accrued
+		creditAccount.LastInterestAccrualDate = now
+		if err := tx.Save(creditAccount).Error; err != nil {
+			return err
+		}
+		if accrued == 0 {
+			return nil // The whole balance is in its grace period
+		}
+		return recordCharge(tx, creditAccount, enums.InterestCharge, accrued, "Monthly interest",
+			creditAccount.LastInterestAccrualDate)
+	})
+}
 
-	return r.db.Save(creditAccount).Error
+// interestDue reports whether a credit account owes a balance that last accrued interest a month or
+// more before now.
+func interestDue(creditAccount *entities.CreditAccount, now time.Time) bool {
+	return creditAccount.CurrentBalance != 0 && !now.Before(creditAccount.LastInterestAccrualDate.AddDate(0, 1, 0))
 }
 
-// ApplyLateFee applies late fee to a credit account.
-func (r *creditAccountRepository) ApplyLateFee(creditAccount *entities.CreditAccount, daysOverdue int) error {
-	if daysOverdue <= 0 {
-		return nil
+// ApplyLateFee charges a credit account the late fee its overdue billing cycle owes, lateFee.Amount being
+// the cycle's fee as of now. What the cycle was already charged is deducted, so that applying late fees
+// again only charges what accrued since; it returns ErrLateFeeApplied if nothing did. Long-term purchases
+// still in their grace period aren't overdue, so they are never charged. The fee is worked out from the
+// account as read, so it fails with ErrBalanceChanged if a payment or purchase changed what it owes since.
+func (r *creditAccountRepository) ApplyLateFee(creditAccount *entities.CreditAccount, lateFee *entities.LateFee) error {
+	original, owed := *creditAccount, lateFee.Amount
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*creditAccount = original
+		// Retrieve the credit account for update, locking the row
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(creditAccount, creditAccount.ID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account for late fee: %w", err)
+		}
+		if math.Abs(creditAccount.CurrentBalance-original.CurrentBalance) > 0.005 ||
+			math.Abs(creditAccount.AccountCredit-original.AccountCredit) > 0.005 {
+			return ErrBalanceChanged
+		}
+
+		var charged float64
+		err := tx.Model(&entities.LateFee{}).
+			Where("credit_account_id = ? AND cycle_due_date = ?", creditAccount.ID, lateFee.CycleDueDate).
+			Select("COALESCE(SUM(amount), 0)").
+			Scan(&charged).Error
+		if err != nil {
+			return err
+		}
+		amount := math.Round((owed-charged)*100) / 100
+		if amount < 0.01 {
+			return ErrLateFeeApplied
+		}
+
+		lateFee.ID, lateFee.CreditAccountID, lateFee.Amount = 0, creditAccount.ID, amount
+		if err := tx.Omit(clause.Associations).Create(lateFee).Error; err != nil {
+			return fmt.Errorf("error recording late fee: %w", err)
+		}
+		creditAccount.CurrentBalance += amount
+		if err := tx.Save(creditAccount).Error; err != nil {
+			return err
+		}
+		description := fmt.Sprintf("Late fee, %d days overdue", lateFee.DaysOverdue)
+		return recordCharge(tx, creditAccount, enums.LateFeeCharge, amount, description, lateFee.AppliedDate)
+	})
+}
+
+// recordCharge adds the transaction of an interest or late fee charge of amount, with its ledger entry,
+// once it was added to the balance of the credit account in tx. Unlike purchases, charges get no receipt.
+func recordCharge(tx *gorm.DB, creditAccount *entities.CreditAccount, transactionType enums.TransactionType, amount float64, description string, at time.Time) error {
+	transaction := entities.Transaction{
+		CreditAccountID: creditAccount.ID,
+		TransactionType: transactionType,
+		Amount:          amount,
+		Description:     description,
+		TransactionDate: at,
+		PaymentStatus:   enums.SUCCESS,
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return fmt.Errorf("error creating charge transaction: %w", err)
 	}
+	return recordTransactionActivity(tx, &transaction, creditAccount, false)
+}
 
-	lateFee := creditAccount.CurrentBalance * (creditAccount.Establishment.LateFeePercentage / 100)
-	creditAccount.CurrentBalance += lateFee
+// balanceOutsideGrace returns the part of a credit account's balance interest is charged on: all of it,
+// except for long-term credit, where purchases made within the last GracePeriod months are still in
+// their grace period.
+func balanceOutsideGrace(tx *gorm.DB, creditAccount *entities.CreditAccount, now time.Time) (float64, error) {
+	if creditAccount.CreditType != enums.LongTerm || creditAccount.GracePeriod <= 0 {
+		return creditAccount.CurrentBalance, nil
+	}
 
-	return r.db.Save(creditAccount).Error
+	var inGrace float64
+	err := tx.Model(&entities.Transaction{}).
+		Where("credit_account_id = ? AND transaction_type = ? AND transaction_date > ?",
+			creditAccount.ID, enums.Purchase, now.AddDate(0, -creditAccount.GracePeriod, 0)).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&inGrace).Error
+	if err != nil {
+		return 0, fmt.Errorf("error summing purchases in grace period: %w", err)
+	}
+	return max(creditAccount.CurrentBalance-inGrace, 0), nil
 }
 
-// GetOverdueCreditAccounts gets all overdue credit accounts for an establishment.
-func (r *creditAccountRepository) GetOverdueCreditAccounts(establishmentID uint) ([]entities.CreditAccount, error) {
-	today := time.Now()
-	var overdueAccounts []entities.CreditAccount
-	err := r.db.Preload("Client").Preload("Establishment").Where("establishment_id = ? AND monthly_due_date < ? AND current_balance > 0", establishmentID, today.Day()).Find(&overdueAccounts).Error
+// GetOwingCreditAccounts gets all credit accounts of an establishment whose balance exceeds their
+// credit, with their client and establishment. Which of them are overdue depends on the business
+// days of the establishment, so it is up to the caller.
+func (r *creditAccountRepository) GetOwingCreditAccounts(establishmentID uint) ([]entities.CreditAccount, error) {
+	var owingAccounts []entities.CreditAccount
+	err := r.db.Preload("Client").Preload("Establishment").Where("establishment_id = ? AND current_balance > account_credit", establishmentID).Find(&owingAccounts).Error
 	if err != nil {
 		return nil, err
 	}
-	return overdueAccounts, nil
+	return owingAccounts, nil
 }
 
 func (r *creditAccountRepository) ProcessPurchase(creditAccount *entities.CreditAccount, amount float64, description string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	original := *creditAccount
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*creditAccount = original
+
 		if creditAccount.IsBlocked {
-			return errors.New("credit account is blocked, cannot process purchase")
+			return ErrCreditAccountBlocked
+		}
+		if creditAccount.Status == enums.AccountClosed {
+			return ErrAccountClosed
+		}
+		if err := checkAgreementAccepted(tx, creditAccount.ID); err != nil {
+			return err
+		}
+		if err := checkEstablishmentActive(tx, creditAccount.EstablishmentID); err != nil {
+			return err
 		}
 
-		if creditAccount.CurrentBalance+amount > creditAccount.CreditLimit {
+		if creditAccount.CurrentBalance+amount-creditAccount.AccountCredit > creditAccount.CreditLimit {
 			return errors.New("purchase exceeds credit limit")
 		}
 
@@ -138,82 +479,146 @@ func (r *creditAccountRepository) ProcessPurchase(creditAccount *entities.Credit
 			TransactionType: enums.Purchase,
 			Amount:          amount,
 			Description:     description,
-			TransactionDate: time.Now(),
+			TransactionDate: r.clock.Now(),
 		}
+		documentNumber, err := nextDocumentNumber(tx, creditAccount.EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error numbering receipt: %w", err)
+		}
+		transaction.DocumentNumber = documentNumber
 		if err := tx.Create(&transaction).Error; err != nil {
 			return fmt.Errorf("error creating purchase transaction: %w", err)
 		}
 
 		// Update the credit account balance
-		creditAccount.CurrentBalance += amount
+		chargeAccount(creditAccount, amount)
 		if err := tx.Save(creditAccount).Error; err != nil {
 			return fmt.Errorf("error updating credit account balance: %w", err)
 		}
 
-		return nil
+		if err := recordTransactionActivity(tx, &transaction, creditAccount, false); err != nil {
+			return err
+		}
+		return enqueueTransactionEvent(tx, event.PurchaseCreated, &transaction, creditAccount)
 	})
 }
 
-func (r *creditAccountRepository) ProcessPayment(creditAccount *entities.CreditAccount, amount float64, description string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+// ProcessPayment records a payment the establishment collected. Cash payments are collected in its
+// open till session, if any.
+func (r *creditAccountRepository) ProcessPayment(creditAccount *entities.CreditAccount, amount float64, paymentMethod enums.PaymentMethod, description string) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
 		// Retrieve the credit account for update, locking the row
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(creditAccount, creditAccount.ID).Error; err != nil {
 			return fmt.Errorf("error retrieving credit account for payment: %w", err)
 		}
 
-		if amount > creditAccount.CurrentBalance {
-			return fmt.Errorf("payment amount exceeds current balance: %.2f", creditAccount.CurrentBalance)
-		}
-
 		transaction := entities.Transaction{
 			CreditAccountID: creditAccount.ID,
 			TransactionType: enums.Payment,
 			Amount:          amount,
 			Description:     description,
-			TransactionDate: time.Now(),
+			TransactionDate: r.clock.Now(),
+			PaymentMethod:   paymentMethod,
+		}
+		documentNumber, err := nextDocumentNumber(tx, creditAccount.EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error numbering receipt: %w", err)
+		}
+		transaction.DocumentNumber = documentNumber
+		if err := assignTillSession(tx, &transaction, creditAccount.EstablishmentID); err != nil {
+			return err
 		}
 		if err := tx.Create(&transaction).Error; err != nil {
 			return fmt.Errorf("error creating payment transaction: %w", err)
 		}
 
-		creditAccount.CurrentBalance -= amount
-		if err := tx.Save(creditAccount).Error; err != nil {
-			return fmt.Errorf("error updating credit account balance: %w", err)
+		if err := allocatePayment(tx, &transaction); err != nil {
+			return fmt.Errorf("error allocating payment to installments: %w", err)
 		}
 
-		if creditAccount.IsBlocked && creditAccount.CurrentBalance <= 0 {
-			creditAccount.IsBlocked = false
-			if err := tx.Save(creditAccount).Error; err != nil {
-				return fmt.Errorf("error unblocking credit account: %w", err)
-			}
+		// Anything paid beyond the balance is kept as account credit
+		wasBlocked := creditAccount.IsBlocked
+		payAccount(creditAccount, amount)
+		if err := saveAccountBalance(tx, creditAccount, wasBlocked); err != nil {
+			return fmt.Errorf("error updating credit account balance: %w", err)
 		}
 
-		return nil
+		if err := recordTransactionActivity(tx, &transaction, creditAccount, false); err != nil {
+			return err
+		}
+		return enqueueTransactionEvent(tx, event.TransactionCreated, &transaction, creditAccount)
 	})
 }
 
-// calculateInterest calculates the interest for a credit account based on its type and interest type
-func calculateInterest(creditAccount entities.CreditAccount) float64 {
-	var interest float64
-	principal := creditAccount.CurrentBalance
-	annualRate := creditAccount.InterestRate / 100
-	timeInYears := 1.0 / 12.0
+// SettleCreditAccount pays off a credit account in full for payoffAmount: it records the payment,
+// clears the balance, marks the pending installments as paid and unblocks the account. It fails
+// with ErrBalanceChanged if the balance no longer is expectedBalance, so a stale quote is never settled.
+func (r *creditAccountRepository) SettleCreditAccount(creditAccount *entities.CreditAccount, expectedBalance, payoffAmount float64, description string) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		// Retrieve the credit account for update, locking the row
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(creditAccount, creditAccount.ID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account for payoff: %w", err)
+		}
+		if math.Abs(creditAccount.CurrentBalance-expectedBalance) > 0.005 {
+			return ErrBalanceChanged
+		}
 
-	if creditAccount.InterestType == enums.Nominal {
-		interest = principal * annualRate * timeInYears
-	} else if creditAccount.InterestType == enums.Effective {
-		interest = principal * (math.Pow(1+annualRate, timeInYears) - 1)
-	}
-	return interest
+		now := r.clock.Now()
+		transaction := entities.Transaction{
+			CreditAccountID: creditAccount.ID,
+			TransactionType: enums.Payment,
+			Amount:          payoffAmount,
+			Description:     description,
+			TransactionDate: now,
+		}
+		documentNumber, err := nextDocumentNumber(tx, creditAccount.EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error numbering receipt: %w", err)
+		}
+		transaction.DocumentNumber = documentNumber
+		if err := tx.Create(&transaction).Error; err != nil {
+			return fmt.Errorf("error creating payoff transaction: %w", err)
+		}
+
+		if err := allocatePayment(tx, &transaction); err != nil {
+			return fmt.Errorf("error allocating payoff to installments: %w", err)
+		}
+		if err := tx.Model(&entities.Installment{}).
+			Where("credit_account_id = ? AND status IN ?", creditAccount.ID, openInstallmentStatuses).
+			Updates(map[string]interface{}{"status": enums.Paid, "paid_at": now, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return fmt.Errorf("error settling installments: %w", err)
+		}
+
+		wasBlocked := creditAccount.IsBlocked
+		creditAccount.CurrentBalance = 0
+		creditAccount.LastInterestAccrualDate = now
+		creditAccount.IsBlocked = false
+		if err := saveAccountBalance(tx, creditAccount, wasBlocked); err != nil {
+			return fmt.Errorf("error updating credit account balance: %w", err)
+		}
+
+		if err := recordTransactionActivity(tx, &transaction, creditAccount, false); err != nil {
+			return err
+		}
+		return enqueueTransactionEvent(tx, event.PaymentConfirmed, &transaction, creditAccount)
+	})
 }
 
 func (r *creditAccountRepository) DeleteCreditAccountInTransaction(tx *gorm.DB, creditAccountID uint) error {
 	return tx.Delete(&entities.CreditAccount{}, creditAccountID).Error
 }
 
-// CreateClientAndCreditAccount creates a new client user and their credit account in a transaction.
+// CreateClientAndCreditAccount creates a new client user and their credit account, with its credit
+// agreement, in a transaction. It fails with ErrEstablishmentInactive if the establishment is
+// deactivated, and with plan.ErrUpgradeRequired if its plan allows no more clients.
 func (r *creditAccountRepository) CreateClientAndCreditAccount(user *entities.User, creditAccount *entities.CreditAccount) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	return uniqueError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkEstablishmentActive(tx, creditAccount.EstablishmentID); err != nil {
+			return err
+		}
+		if err := checkPlanQuota(tx, creditAccount.EstablishmentID, plan.Clients, 1); err != nil {
+			return err
+		}
 		if err := tx.Create(user).Error; err != nil {
 			return fmt.Errorf("error creating user: %w", err)
 		}
@@ -223,27 +628,20 @@ func (r *creditAccountRepository) CreateClientAndCreditAccount(user *entities.Us
 			return fmt.Errorf("error creating credit account: %w", err)
 		}
 
-		return nil
-	})
+		return createCreditAgreement(tx, creditAccount, r.clock.Now())
+	}))
 }
 
+// DeleteClientAndCreditAccount deletes a client user together with all of their credit accounts.
 func (r *creditAccountRepository) DeleteClientAndCreditAccount(userID uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// 1. Get the CreditAccount ID
-		creditAccount, err := r.GetCreditAccountByClientID(userID)
-		if err != nil {
-			return fmt.Errorf("error retrieving credit account: %w", err)
-		}
-
-		// 2. Delete the Credit Account
-		if err := r.DeleteCreditAccountInTransaction(tx, creditAccount.ID); err != nil {
-			return fmt.Errorf("error deleting credit account: %w", err)
+		// 1. Delete the client's credit accounts, in every establishment
+		if err := tx.Where("client_id = ?", userID).Delete(&entities.CreditAccount{}).Error; err != nil {
+			return fmt.Errorf("error deleting credit accounts: %w", err)
 		}
 
-		// 3. Delete the User
-		// You can access the userRepo from here if you pass it during initialization
-		// For example, if your creditAccountRepository has a userRepo field:
-		if err := r.userRepo.DeleteUser(userID); err != nil {
+		// 2. Delete the User
+		if err := tx.Delete(&entities.User{}, userID).Error; err != nil {
 			return fmt.Errorf("error deleting user: %w", err)
 		}
 
@@ -251,35 +649,232 @@ func (r *creditAccountRepository) DeleteClientAndCreditAccount(userID uint) erro
 	})
 }
 
-// ProcessPurchaseTransaction handles the purchase logic within a transaction.
-func (r *creditAccountRepository) ProcessPurchaseTransaction(creditAccount *entities.CreditAccount, amount float64, description string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if creditAccount.IsBlocked {
-			return errors.New("credit account is blocked, cannot process purchase")
+// ProcessPurchaseTransaction handles the purchase logic within a transaction, recording the
+// purchased items (if any) against the purchase transaction.
+func (r *creditAccountRepository) ProcessPurchaseTransaction(creditAccount *entities.CreditAccount, amount float64, description string, items []entities.PurchaseItem) error {
+	original := *creditAccount
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*creditAccount = original
+		_, err := r.purchase(tx, creditAccount, amount, description, items)
+		return err
+	})
+}
+
+// ApprovePurchaseTransaction makes the purchase of a pending approval on its credit account and marks
+// it approved by adminID, unless it was decided meanwhile.
+func (r *creditAccountRepository) ApprovePurchaseTransaction(approval *entities.PurchaseApproval, adminID uint, description string, items []entities.PurchaseItem) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(approval, approval.ID).Error; err != nil {
+			return err
+		}
+		if approval.Status != enums.ApprovalPending {
+			return ErrApprovalDecided
 		}
 
-		if creditAccount.CurrentBalance+amount > creditAccount.CreditLimit {
-			return errors.New("purchase exceeds credit limit")
+		var creditAccount entities.CreditAccount
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&creditAccount, approval.CreditAccountID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account: %w", err)
+		}
+		transaction, err := r.purchase(tx, &creditAccount, approval.Amount, description, items)
+		if err != nil {
+			return err
 		}
 
-		// Create the purchase transaction
-		transaction := entities.Transaction{
+		now := r.clock.Now()
+		approval.Status, approval.DecidedByID, approval.DecidedAt, approval.TransactionID = enums.ApprovalApproved, &adminID, &now, &transaction.ID
+		return tx.Select("status", "decided_by_id", "decided_at", "transaction_id", "updated_at").Save(approval).Error
+	})
+}
+
+// purchase charges a purchase of amount to creditAccount as part of tx, with its product lines.
+func (r *creditAccountRepository) purchase(tx *gorm.DB, creditAccount *entities.CreditAccount, amount float64, description string, items []entities.PurchaseItem) (*entities.Transaction, error) {
+	if creditAccount.IsBlocked {
+		return nil, ErrCreditAccountBlocked
+	}
+	if creditAccount.Status == enums.AccountClosed {
+		return nil, ErrAccountClosed
+	}
+	if err := checkAgreementAccepted(tx, creditAccount.ID); err != nil {
+		return nil, err
+	}
+	if err := checkEstablishmentActive(tx, creditAccount.EstablishmentID); err != nil {
+		return nil, err
+	}
+
+	if creditAccount.CurrentBalance+amount-creditAccount.AccountCredit > creditAccount.CreditLimit {
+		return nil, errors.New("purchase exceeds credit limit")
+	}
+
+	// Create the purchase transaction
+	transaction := entities.Transaction{
+		CreditAccountID: creditAccount.ID,
+		TransactionType: enums.Purchase,
+		Amount:          amount,
+		Description:     description,
+		TransactionDate: r.clock.Now(),
+	}
+	documentNumber, err := nextDocumentNumber(tx, creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error numbering receipt: %w", err)
+	}
+	transaction.DocumentNumber = documentNumber
+	if err := tx.Create(&transaction).Error; err != nil {
+		return nil, fmt.Errorf("error creating purchase transaction: %w", err)
+	}
+
+	for i := range items {
+		items[i].TransactionID = &transaction.ID
+	}
+	if len(items) > 0 {
+		if err := tx.Create(&items).Error; err != nil {
+			return nil, fmt.Errorf("error creating purchase items: %w", err)
+		}
+	}
+
+	// Update the credit account's current balance
+	chargeAccount(creditAccount, amount)
+	if err := tx.Save(creditAccount).Error; err != nil {
+		return nil, fmt.Errorf("error updating credit account balance: %w", err)
+	}
+
+	if err := recordTransactionActivity(tx, &transaction, creditAccount, false); err != nil {
+		return nil, err
+	}
+	return &transaction, enqueueTransactionEvent(tx, event.PurchaseCreated, &transaction, creditAccount)
+}
+
+// WriteOffCreditAccount writes off the balance of a credit account as bad debt: the balance moves to
+// the account's WrittenOffBalance, where payments recover it, and the account is blocked for good. It
+// fails with ErrAccountWrittenOff if it already was written off and with ErrBalanceChanged if it no
+// longer owes anything.
+func (r *creditAccountRepository) WriteOffCreditAccount(creditAccount *entities.CreditAccount, writeOff *entities.CreditAccountWriteOff) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		// Retrieve the credit account for update, locking the row
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(creditAccount, creditAccount.ID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account for write-off: %w", err)
+		}
+		if creditAccount.WrittenOffAt != nil {
+			return ErrAccountWrittenOff
+		}
+		if creditAccount.CurrentBalance <= 0 {
+			return ErrBalanceChanged
+		}
+
+		now := r.clock.Now()
+		wasBlocked := creditAccount.IsBlocked
+		writeOff.ID = 0
+		writeOff.CreditAccountID = creditAccount.ID
+		writeOff.EstablishmentID = creditAccount.EstablishmentID
+		writeOff.Amount = creditAccount.CurrentBalance
+		writeOff.CreatedAt = now
+		creditAccount.WrittenOffBalance += creditAccount.CurrentBalance
+		creditAccount.CurrentBalance = 0
+		creditAccount.WrittenOffAt = &now
+		creditAccount.IsBlocked = true
+		if err := tx.Omit(clause.Associations).Save(creditAccount).Error; err != nil {
+			return fmt.Errorf("error updating credit account: %w", err)
+		}
+		if err := tx.Omit(clause.Associations).Create(writeOff).Error; err != nil {
+			return fmt.Errorf("error creating write-off: %w", err)
+		}
+
+		description := fmt.Sprintf("Written off: %s", writeOff.Reason)
+		if err := recordActivity(tx, creditAccount, enums.ActivityWriteOff, -writeOff.Amount, description, nil, now); err != nil {
+			return err
+		}
+		if err := enqueueEvent(tx, event.AccountWrittenOff, creditAccount.ID, now, writeOffPayload{
+			Amount:  writeOff.Amount,
+			Reason:  writeOff.Reason,
+			ActorID: writeOff.WrittenOffByID,
+		}); err != nil {
+			return err
+		}
+		if wasBlocked {
+			return nil
+		}
+
+		block := entities.CreditAccountBlockEvent{
 			CreditAccountID: creditAccount.ID,
-			TransactionType: enums.Purchase,
-			Amount:          amount,
-			Description:     description,
-			TransactionDate: time.Now(),
+			Blocked:         true,
+			Reason:          description,
+			ActorID:         &writeOff.WrittenOffByID,
+			CreatedAt:       now,
 		}
-		if err := tx.Create(&transaction).Error; err != nil {
-			return fmt.Errorf("error creating purchase transaction: %w", err)
+		return recordBlock(tx, &block)
+	})
+}
+
+// GetWriteOffsByEstablishmentID retrieves the write-offs of an establishment with their credit account
+// and client, newest first.
+func (r *creditAccountRepository) GetWriteOffsByEstablishmentID(establishmentID uint) ([]entities.CreditAccountWriteOff, error) {
+	var writeOffs []entities.CreditAccountWriteOff
+	err := r.db.Preload("CreditAccount.Client").Where("establishment_id = ?", establishmentID).
+		Order("created_at DESC, id DESC").Find(&writeOffs).Error
+	return writeOffs, err
+}
+
+// CloseCreditAccount closes a credit account to purchases, keeping its history. It fails with
+// ErrAccountClosed if it already was closed and with ErrBalanceChanged if it still owes anything,
+// written-off debt included.
+func (r *creditAccountRepository) CloseCreditAccount(creditAccount *entities.CreditAccount, reason string, actorID uint) error {
+	return r.setAccountStatus(creditAccount, enums.AccountClosed, reason, actorID)
+}
+
+// ReopenCreditAccount opens a closed credit account to purchases again. It fails with
+// ErrAccountNotClosed if it isn't closed, and with plan.ErrUpgradeRequired if the establishment's
+// plan allows no more clients.
+func (r *creditAccountRepository) ReopenCreditAccount(creditAccount *entities.CreditAccount, reason string, actorID uint) error {
+	return r.setAccountStatus(creditAccount, enums.AccountActive, reason, actorID)
+}
+
+// setAccountStatus closes or reopens a credit account, recording it in the account's activity, its
+// events and the outbox.
+func (r *creditAccountRepository) setAccountStatus(creditAccount *entities.CreditAccount, status enums.CreditAccountStatus, reason string, actorID uint) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		// Retrieve the credit account for update, locking the row
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(creditAccount, creditAccount.ID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account: %w", err)
+		}
+		closed := creditAccount.Status == enums.AccountClosed
+		switch {
+		case status == enums.AccountClosed && closed:
+			return ErrAccountClosed
+		case status == enums.AccountClosed && (creditAccount.CurrentBalance > 0.005 || creditAccount.WrittenOffBalance > 0.005):
+			return ErrBalanceChanged
+		case status == enums.AccountActive && !closed:
+			return ErrAccountNotClosed
+		}
+		if status == enums.AccountActive {
+			if err := checkPlanQuota(tx, creditAccount.EstablishmentID, plan.Clients, 1); err != nil {
+				return err
+			}
 		}
 
-		// Update the credit account's current balance
-		creditAccount.CurrentBalance += amount
-		if err := tx.Save(creditAccount).Error; err != nil {
-			return fmt.Errorf("error updating credit account balance: %w", err)
+		now := r.clock.Now()
+		activityType, eventType, name := enums.ActivityAccountReopened, enums.CreditAccountReopened, event.AccountReopened
+		creditAccount.Status, creditAccount.ClosedAt = status, nil
+		if status == enums.AccountClosed {
+			activityType, eventType, name = enums.ActivityAccountClosed, enums.CreditAccountClosed, event.AccountClosed
+			creditAccount.ClosedAt = &now
+		}
+		creditAccount.Version++
+		if err := tx.Omit(clause.Associations).Save(creditAccount).Error; err != nil {
+			return fmt.Errorf("error updating credit account: %w", err)
 		}
 
-		return nil
+		if err := recordActivity(tx, creditAccount, activityType, 0, reason, nil, now); err != nil {
+			return err
+		}
+		err := recordAccountEvent(tx, &entities.CreditAccountEvent{
+			CreditAccountID: creditAccount.ID,
+			Type:            eventType,
+			Reason:          reason,
+			ActorID:         &actorID,
+			CreatedAt:       now,
+		})
+		if err != nil {
+			return err
+		}
+		return enqueueEvent(tx, name, creditAccount.ID, now, closurePayload{Reason: reason, ActorID: actorID})
 	})
 }
@@ -0,0 +1,358 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/testutil/dbtest"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// seedAccount creates the establishment, its admin and the client of account, and then account.
+func seedAccount(t *testing.T, db *gorm.DB, account *entities.CreditAccount) {
+	t.Helper()
+	dbtest.Create(t, db, fixture.Admin().Build(), fixture.Establishment().Build(), fixture.Client().Build(), account)
+}
+
+func newTestCreditAccountRepository(db *gorm.DB) repository.CreditAccountRepository {
+	return repository.NewCreditAccountRepository(db, repository.NewUserRepository(db), util.NewFakeClock(fixture.Now))
+}
+
+func reloadAccount(t *testing.T, db *gorm.DB, id uint) entities.CreditAccount {
+	t.Helper()
+	var account entities.CreditAccount
+	if err := db.First(&account, id).Error; err != nil {
+		t.Fatalf("error reloading credit account: %v", err)
+	}
+	return account
+}
+
+func transactionsOf(t *testing.T, db *gorm.DB, accountID uint, transactionType enums.TransactionType) []entities.Transaction {
+	t.Helper()
+	var transactions []entities.Transaction
+	err := db.Where("credit_account_id = ? AND transaction_type = ?", accountID, transactionType).Order("id").Find(&transactions).Error
+	if err != nil {
+		t.Fatalf("error listing transactions: %v", err)
+	}
+	return transactions
+}
+
+func TestCreditAccountRepositoryProcessPurchase(t *testing.T) {
+	tests := []struct {
+		name        string
+		account     *entities.CreditAccount
+		amount      float64
+		wantErr     bool
+		wantBalance float64
+		wantCredit  float64
+	}{
+		{"charged to the balance", fixture.CreditAccount().Balance(100).Build(), 200, false, 300, 0},
+		{"up to the credit limit", fixture.CreditAccount().Balance(800).Build(), 200, false, 1000, 0},
+		{"over the credit limit", fixture.CreditAccount().Balance(900).Build(), 200, true, 900, 0},
+		{"account credit is used first", fixture.CreditAccount().AccountCredit(50).Build(), 200, false, 150, 0},
+		{"blocked account", fixture.CreditAccount().Blocked().Build(), 200, true, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.Open(t)
+			seedAccount(t, db, tt.account)
+			repo := newTestCreditAccountRepository(db)
+
+			err := repo.ProcessPurchase(tt.account, tt.amount, "Compra")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessPurchase returned %v, want error %t", err, tt.wantErr)
+			}
+			saved := reloadAccount(t, db, tt.account.ID)
+			if math.Abs(saved.CurrentBalance-tt.wantBalance) > 0.005 || math.Abs(saved.AccountCredit-tt.wantCredit) > 0.005 {
+				t.Errorf("balance %.2f and credit %.2f, want %.2f and %.2f", saved.CurrentBalance, saved.AccountCredit, tt.wantBalance, tt.wantCredit)
+			}
+			purchases := transactionsOf(t, db, tt.account.ID, enums.Purchase)
+			if tt.wantErr {
+				if len(purchases) != 0 {
+					t.Errorf("a failed purchase recorded %d transactions", len(purchases))
+				}
+				return
+			}
+			if len(purchases) != 1 || purchases[0].Amount != tt.amount || !purchases[0].TransactionDate.Equal(fixture.Now) {
+				t.Errorf("recorded purchases %+v, want one of %.2f at %s", purchases, tt.amount, fixture.Now)
+			}
+		})
+	}
+}
+
+func TestCreditAccountRepositoryProcessPayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		account     *entities.CreditAccount
+		amount      float64
+		wantBalance float64
+		wantCredit  float64
+		wantBlocked bool
+	}{
+		{"part of the balance", fixture.CreditAccount().Balance(300).Build(), 100, 200, 0, false},
+		{"overpaid into account credit", fixture.CreditAccount().Balance(300).Build(), 400, 0, 100, false},
+		{"paying off unblocks", fixture.CreditAccount().Balance(300).Blocked().Build(), 300, 0, 0, false},
+		{"paying part stays blocked", fixture.CreditAccount().Balance(300).Blocked().Build(), 100, 200, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.Open(t)
+			seedAccount(t, db, tt.account)
+			repo := newTestCreditAccountRepository(db)
+
+			if err := repo.ProcessPayment(tt.account, tt.amount, enums.CASH, "Pago"); err != nil {
+				t.Fatalf("ProcessPayment returned %v", err)
+			}
+			saved := reloadAccount(t, db, tt.account.ID)
+			if math.Abs(saved.CurrentBalance-tt.wantBalance) > 0.005 || math.Abs(saved.AccountCredit-tt.wantCredit) > 0.005 || saved.IsBlocked != tt.wantBlocked {
+				t.Errorf("balance %.2f, credit %.2f, blocked %t, want %.2f, %.2f, %t",
+					saved.CurrentBalance, saved.AccountCredit, saved.IsBlocked, tt.wantBalance, tt.wantCredit, tt.wantBlocked)
+			}
+			if payments := transactionsOf(t, db, tt.account.ID, enums.Payment); len(payments) != 1 || payments[0].Amount != tt.amount {
+				t.Errorf("recorded payments %+v, want one of %.2f", payments, tt.amount)
+			}
+		})
+	}
+}
+
+func TestCreditAccountRepositoryApplyInterest(t *testing.T) {
+	tests := []struct {
+		name        string
+		account     *entities.CreditAccount
+		wantAccrued float64
+	}{
+		{"a month after the last accrual", fixture.CreditAccount().Balance(1000).LastAccrual(fixture.Now.AddDate(0, -1, 0)).Build(), 18.087582483510722},
+		{"less than a month after", fixture.CreditAccount().Balance(1000).LastAccrual(fixture.Now.AddDate(0, 0, -20)).Build(), 0},
+		{"no balance", fixture.CreditAccount().LastAccrual(fixture.Now.AddDate(0, -1, 0)).Build(), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.Open(t)
+			seedAccount(t, db, tt.account)
+			repo := newTestCreditAccountRepository(db)
+			before := tt.account.CurrentBalance
+
+			if err := repo.ApplyInterest(tt.account); err != nil {
+				t.Fatalf("ApplyInterest returned %v", err)
+			}
+			saved := reloadAccount(t, db, tt.account.ID)
+			if accrued := saved.CurrentBalance - before; math.Abs(accrued-tt.wantAccrued) > 1e-6 {
+				t.Errorf("accrued %v, want %v", accrued, tt.wantAccrued)
+			}
+			charges := transactionsOf(t, db, tt.account.ID, enums.InterestCharge)
+			if (len(charges) == 1) != (tt.wantAccrued > 0) || len(charges) > 1 {
+				t.Errorf("recorded %d interest charges for %v accrued", len(charges), tt.wantAccrued)
+			}
+		})
+	}
+}
+
+func TestCreditAccountRepositoryApplyInterestConcurrently(t *testing.T) {
+	db := dbtest.Shared(t)
+	account := fixture.CreditAccount().Balance(1000).LastAccrual(fixture.Now.AddDate(0, -1, 0)).Build()
+	seedAccount(t, db, account)
+	repo := newTestCreditAccountRepository(db)
+
+	// Both callers read the account before either applied interest, as the job and an admin might
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		stale := *account
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.ApplyInterest(&stale)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("ApplyInterest returned %v", err)
+		}
+	}
+
+	if charges := transactionsOf(t, db, account.ID, enums.InterestCharge); len(charges) != 1 {
+		t.Errorf("recorded %d interest charges, want 1", len(charges))
+	}
+	if saved := reloadAccount(t, db, account.ID); math.Abs(saved.CurrentBalance-1018.087582483510722) > 1e-6 {
+		t.Errorf("balance %v, want interest accrued once", saved.CurrentBalance)
+	}
+}
+
+func TestCreditAccountRepositoryOnATenantSession(t *testing.T) {
+	db := dbtest.Open(t)
+	account := fixture.CreditAccount().Build()
+	seedAccount(t, db, account)
+
+	own := newTestCreditAccountRepository(tenant.Session(db, tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID}}))
+	if _, err := own.GetCreditAccountByID(account.ID); err != nil {
+		t.Errorf("account of the tenant not found: %v", err)
+	}
+	other := newTestCreditAccountRepository(tenant.Session(db, tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID + 1}}))
+	if _, err := other.GetCreditAccountByID(account.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("account of another tenant returned %v, want gorm.ErrRecordNotFound", err)
+	}
+	unscoped := newTestCreditAccountRepository(db.WithContext(context.Background()))
+	if _, err := unscoped.GetCreditAccountByID(account.ID); !errors.Is(err, tenant.ErrNoTenant) {
+		t.Errorf("account read without a tenant returned %v, want %v", err, tenant.ErrNoTenant)
+	}
+}
+
+func TestCreditAccountRepositoryApplyLateFeeAfterAPayment(t *testing.T) {
+	db := dbtest.Open(t)
+	account := fixture.CreditAccount().Balance(300).Build()
+	seedAccount(t, db, account)
+	repo := newTestCreditAccountRepository(db)
+
+	// The fee was worked out from the account as read before the client paid
+	stale := *account
+	if err := repo.ProcessPayment(account, 200, enums.CASH, "Pago"); err != nil {
+		t.Fatalf("ProcessPayment returned %v", err)
+	}
+	lateFee := &entities.LateFee{Amount: 15, AppliedDate: fixture.Now, CycleDueDate: fixture.Now.AddDate(0, 0, -5), DaysOverdue: 5}
+	if err := repo.ApplyLateFee(&stale, lateFee); !errors.Is(err, repository.ErrBalanceChanged) {
+		t.Fatalf("ApplyLateFee on a stale account returned %v, want repository.ErrBalanceChanged", err)
+	}
+	if saved := reloadAccount(t, db, account.ID); math.Abs(saved.CurrentBalance-100) > 0.005 {
+		t.Errorf("balance %.2f, want the 100 left after the payment", saved.CurrentBalance)
+	}
+	if charges := transactionsOf(t, db, account.ID, enums.LateFeeCharge); len(charges) != 0 {
+		t.Errorf("recorded %d late fee charges on a stale account", len(charges))
+	}
+}
+
+func TestCreditAccountRepositoryApplyLateFeeWithPaymentsConcurrently(t *testing.T) {
+	db := dbtest.Shared(t)
+	account := fixture.CreditAccount().Balance(300).Build()
+	seedAccount(t, db, account)
+	repo := newTestCreditAccountRepository(db)
+
+	// Every caller read the account before any of them wrote it
+	const payments = 3
+	var wg sync.WaitGroup
+	errs := make([]error, payments+1)
+	for i := range errs {
+		stale := *account
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i == payments {
+				lateFee := &entities.LateFee{Amount: 15, AppliedDate: fixture.Now, CycleDueDate: fixture.Now.AddDate(0, 0, -5), DaysOverdue: 5}
+				errs[i] = repo.ApplyLateFee(&stale, lateFee)
+				return
+			}
+			errs[i] = repo.ProcessPayment(&stale, 50, enums.CASH, "Pago")
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs[:payments] {
+		if err != nil {
+			t.Fatalf("payment %d returned %v", i, err)
+		}
+	}
+	lateFeeErr := errs[payments]
+	if lateFeeErr != nil && !errors.Is(lateFeeErr, repository.ErrBalanceChanged) {
+		t.Fatalf("ApplyLateFee returned %v", lateFeeErr)
+	}
+
+	// No payment is lost, whether the fee was charged before them or refused after
+	want := 300 - payments*50.0
+	if lateFeeErr == nil {
+		want += 15
+	}
+	if saved := reloadAccount(t, db, account.ID); math.Abs(saved.CurrentBalance-want) > 0.005 {
+		t.Errorf("balance %.2f, want %.2f", saved.CurrentBalance, want)
+	}
+}
+
+func TestCreditAccountRepositoryGetOwingCreditAccounts(t *testing.T) {
+	db := dbtest.Open(t)
+	seedAccount(t, db, fixture.CreditAccount().Balance(100).Build())
+	covered, noBalance := fixture.Client().ID(21).DNI("12345679").Build(), fixture.Client().ID(22).DNI("12345680").Build()
+	dbtest.Create(t, db, covered, noBalance,
+		fixture.CreditAccount().ID(101).Client(covered).Balance(100).AccountCredit(100).Build(),
+		fixture.CreditAccount().ID(102).Client(noBalance).Build(),
+	)
+	repo := newTestCreditAccountRepository(db)
+
+	accounts, err := repo.GetOwingCreditAccounts(fixture.EstablishmentID)
+	if err != nil {
+		t.Fatalf("GetOwingCreditAccounts returned %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].ID != fixture.CreditAccountID {
+		t.Fatalf("got %d accounts, want only account %d", len(accounts), fixture.CreditAccountID)
+	}
+	if accounts[0].Client == nil || accounts[0].Establishment == nil {
+		t.Errorf("client %v and establishment %v loaded, want both", accounts[0].Client, accounts[0].Establishment)
+	}
+}
+
+func TestCreditAccountRepositoryProcessPaymentAllocation(t *testing.T) {
+	loc := util.LoadLocation("America/Lima")
+	dueDates := []time.Time{ // Of three installments of 100, after fixture.Now
+		time.Date(2025, time.March, 15, 0, 0, 0, 0, loc),
+		time.Date(2025, time.April, 15, 0, 0, 0, 0, loc),
+		time.Date(2025, time.May, 15, 0, 0, 0, 0, loc),
+	}
+	tests := []struct {
+		name      string
+		payments  []float64
+		want      []float64 // Allocated by the last payment to each installment by due date, 0 if none
+		wantState []enums.InstallmentStatus
+	}{
+		{"one installment", []float64{100}, []float64{100, 0, 0}, []enums.InstallmentStatus{enums.Paid, enums.Pending, enums.Pending}},
+		{"part of one", []float64{40}, []float64{40, 0, 0}, []enums.InstallmentStatus{enums.PartiallyPaid, enums.Pending, enums.Pending}},
+		{"into the next one", []float64{150}, []float64{100, 50, 0}, []enums.InstallmentStatus{enums.Paid, enums.PartiallyPaid, enums.Pending}},
+		{"the rest of one paid before", []float64{50, 100}, []float64{50, 50, 0}, []enums.InstallmentStatus{enums.Paid, enums.PartiallyPaid, enums.Pending}},
+		{"beyond every installment", []float64{350}, []float64{100, 100, 100}, []enums.InstallmentStatus{enums.Paid, enums.Paid, enums.Paid}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.Open(t)
+			account := fixture.CreditAccount().LongTerm(0).Balance(300).Build()
+			seedAccount(t, db, account)
+			installments := make([]*entities.Installment, len(dueDates))
+			for i := len(dueDates) - 1; i >= 0; i-- { // Latest first, so IDs don't follow due dates
+				installments[i] = fixture.Installment(100, dueDates[i])
+				dbtest.Create(t, db, installments[i])
+			}
+			repo := newTestCreditAccountRepository(db)
+
+			for _, amount := range tt.payments {
+				if err := repo.ProcessPayment(account, amount, enums.CASH, "Pago"); err != nil {
+					t.Fatalf("ProcessPayment of %.2f returned %v", amount, err)
+				}
+			}
+			payments := transactionsOf(t, db, account.ID, enums.Payment)
+			var allocations []entities.PaymentAllocation
+			if err := db.Where("transaction_id = ?", payments[len(payments)-1].ID).Find(&allocations).Error; err != nil {
+				t.Fatalf("error listing allocations: %v", err)
+			}
+			allocated := make(map[uint]float64)
+			for _, allocation := range allocations {
+				allocated[allocation.InstallmentID] += allocation.Amount
+			}
+			for i, installment := range installments {
+				var saved entities.Installment
+				if err := db.First(&saved, installment.ID).Error; err != nil {
+					t.Fatalf("error reloading installment: %v", err)
+				}
+				if math.Abs(allocated[installment.ID]-tt.want[i]) > 0.005 || saved.Status != tt.wantState[i] {
+					t.Errorf("installment %d got %.2f and is %s, want %.2f and %s", i+1, allocated[installment.ID], saved.Status, tt.want[i], tt.wantState[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/finance"
+	"ApiRestFinance/internal/model/entities"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAgreementNotAccepted is returned when a purchase is attempted before the client accepted the
+// credit agreement of the account.
+var ErrAgreementNotAccepted = errors.New("credit agreement not accepted, cannot process purchase")
+
+// ErrAgreementAccepted is returned when accepting a credit agreement that was already accepted.
+var ErrAgreementAccepted = errors.New("credit agreement was already accepted")
+
+// CreditAgreementRepository defines operations for managing the credit agreements of credit accounts,
+// which are created together with the account, see CreditAccountRepository.CreateCreditAccount.
+type CreditAgreementRepository interface {
+	GetCreditAgreementByCreditAccountID(creditAccountID uint) (*entities.CreditAgreement, error)
+	AcceptCreditAgreement(agreement *entities.CreditAgreement) error
+}
+
+type creditAgreementRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditAgreementRepository creates a new CreditAgreementRepository instance.
+func NewCreditAgreementRepository(db *gorm.DB) CreditAgreementRepository {
+	return &creditAgreementRepository{db: db}
+}
+
+// GetCreditAgreementByCreditAccountID retrieves the credit agreement of a credit account.
+func (r *creditAgreementRepository) GetCreditAgreementByCreditAccountID(creditAccountID uint) (*entities.CreditAgreement, error) {
+	var agreement entities.CreditAgreement
+	err := r.db.Where("credit_account_id = ?", creditAccountID).First(&agreement).Error
+	if err != nil {
+		return nil, err
+	}
+	return &agreement, nil
+}
+
+// AcceptCreditAgreement saves the acceptance of a credit agreement, which allows purchases on its
+// account. It fails with ErrAgreementAccepted if the agreement was accepted meanwhile.
+func (r *creditAgreementRepository) AcceptCreditAgreement(agreement *entities.CreditAgreement) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		result := tx.Model(&entities.CreditAgreement{}).
+			Where("id = ? AND accepted_at IS NULL", agreement.ID).
+			Updates(map[string]interface{}{
+				"accepted_at":         agreement.AcceptedAt,
+				"accepted_ip":         agreement.AcceptedIP,
+				"accepted_user_agent": agreement.AcceptedUserAgent,
+				"terms_hash":          agreement.TermsHash,
+				"updated_at":          agreement.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrAgreementAccepted
+		}
+		return enqueueEvent(tx, event.CreditAgreementAccepted, agreement.CreditAccountID, *agreement.AcceptedAt, agreementPayload{
+			AgreementID: agreement.ID,
+			TermsHash:   agreement.TermsHash,
+		})
+	})
+}
+
+// createCreditAgreement creates the credit agreement of a new credit account as part of tx, with the
+// terms the account is opened with.
+func createCreditAgreement(tx *gorm.DB, creditAccount *entities.CreditAccount, now time.Time) error {
+	tcea := 0.0
+	if rates, err := finance.AccountRates(*creditAccount); err == nil {
+		tcea = rates.TCEA * 100
+	}
+	agreement := entities.CreditAgreement{
+		CreditAccountID:   creditAccount.ID,
+		CreditLimit:       creditAccount.CreditLimit,
+		InterestRate:      creditAccount.InterestRate,
+		InterestType:      creditAccount.InterestType,
+		CompoundingPeriod: creditAccount.CompoundingPeriod,
+		TCEA:              tcea,
+		CreditType:        creditAccount.CreditType,
+		MonthlyDueDate:    creditAccount.MonthlyDueDate,
+		GracePeriod:       creditAccount.GracePeriod,
+		LateFeePercentage: creditAccount.LateFeePercentage,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := tx.Omit(clause.Associations).Create(&agreement).Error; err != nil {
+		return fmt.Errorf("error creating credit agreement: %w", err)
+	}
+	return nil
+}
+
+// checkAgreementAccepted fails with ErrAgreementNotAccepted if the credit agreement of an account is
+// pending acceptance. Accounts opened before agreements were introduced have none and aren't held.
+func checkAgreementAccepted(tx *gorm.DB, creditAccountID uint) error {
+	var pending int64
+	err := tx.Model(&entities.CreditAgreement{}).
+		Where("credit_account_id = ? AND accepted_at IS NULL", creditAccountID).Count(&pending).Error
+	if err != nil {
+		return fmt.Errorf("error retrieving credit agreement: %w", err)
+	}
+	if pending > 0 {
+		return ErrAgreementNotAccepted
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+
+	"gorm.io/gorm"
+)
+
+// CreditTemplateRepository defines operations for managing the credit templates of establishments.
+type CreditTemplateRepository interface {
+	GetCreditTemplatesByEstablishmentID(establishmentID uint) ([]entities.CreditTemplate, error)
+	GetCreditTemplateByID(templateID uint) (*entities.CreditTemplate, error)
+	CreateCreditTemplate(template *entities.CreditTemplate) error
+	UpdateCreditTemplate(template *entities.CreditTemplate) error
+	DeleteCreditTemplate(templateID uint) error
+}
+
+type creditTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditTemplateRepository creates a new CreditTemplateRepository instance.
+func NewCreditTemplateRepository(db *gorm.DB) CreditTemplateRepository {
+	return &creditTemplateRepository{db: db}
+}
+
+// GetCreditTemplatesByEstablishmentID retrieves the credit templates of an establishment by name.
+func (r *creditTemplateRepository) GetCreditTemplatesByEstablishmentID(establishmentID uint) ([]entities.CreditTemplate, error) {
+	var templates []entities.CreditTemplate
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("name").Find(&templates).Error
+	return templates, err
+}
+
+// GetCreditTemplateByID retrieves a credit template by its ID.
+func (r *creditTemplateRepository) GetCreditTemplateByID(templateID uint) (*entities.CreditTemplate, error) {
+	var template entities.CreditTemplate
+	if err := r.db.First(&template, templateID).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// CreateCreditTemplate creates a credit template.
+func (r *creditTemplateRepository) CreateCreditTemplate(template *entities.CreditTemplate) error {
+	return uniqueError(r.db.Create(template).Error)
+}
+
+// UpdateCreditTemplate changes the name and terms of a credit template.
+func (r *creditTemplateRepository) UpdateCreditTemplate(template *entities.CreditTemplate) error {
+	return uniqueError(r.db.Model(template).Select("*").Omit("id", "establishment_id", "created_at").Updates(template).Error)
+}
+
+// DeleteCreditTemplate deletes a credit template. The accounts opened with it keep their terms, but
+// no longer point to it.
+func (r *creditTemplateRepository) DeleteCreditTemplate(templateID uint) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&entities.CreditAccount{}).Where("credit_template_id = ?", templateID).
+			Updates(map[string]interface{}{"credit_template_id": nil, "version": gorm.Expr("version + 1")}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&entities.CreditTemplate{}, templateID).Error
+	})
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreditAccountFilter selects the credit accounts of an establishment that match every field it sets.
+type CreditAccountFilter struct {
+	CreditAccountIDs []uint
+	CreditTemplateID *uint
+	CreditType       *enums.CreditType
+	InterestType     *enums.InterestType
+	Status           *enums.CreditAccountStatus
+	Tags             []string // Accounts of clients with every one of them, ignoring case
+}
+
+// CreditTermUpdateRepository defines operations for changing the terms of many credit accounts at once,
+// and for the audit trail of those changes.
+type CreditTermUpdateRepository interface {
+	GetFilteredCreditAccounts(establishmentID uint, filter CreditAccountFilter) ([]entities.CreditAccount, error)
+	CreateCreditTermUpdate(update *entities.CreditTermUpdate) error
+	SaveCreditTermUpdate(update *entities.CreditTermUpdate) error
+	ApplyCreditTermChanges(creditAccount *entities.CreditAccount, update *entities.CreditTermUpdate, changes []entities.CreditTermChange) error
+	GetCreditTermUpdatesByEstablishmentID(establishmentID uint) ([]entities.CreditTermUpdate, error)
+	GetCreditTermUpdateByID(updateID uint) (*entities.CreditTermUpdate, error)
+}
+
+type creditTermUpdateRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditTermUpdateRepository creates a new CreditTermUpdateRepository instance.
+func NewCreditTermUpdateRepository(db *gorm.DB) CreditTermUpdateRepository {
+	return &creditTermUpdateRepository{db: db}
+}
+
+// GetFilteredCreditAccounts retrieves the credit accounts of an establishment the filter matches, with
+// their clients, ordered by ID.
+func (r *creditTermUpdateRepository) GetFilteredCreditAccounts(establishmentID uint, filter CreditAccountFilter) ([]entities.CreditAccount, error) {
+	query := r.db.Preload("Client").Where("credit_accounts.establishment_id = ?", establishmentID)
+	if len(filter.CreditAccountIDs) > 0 {
+		query = query.Where("credit_accounts.id IN ?", filter.CreditAccountIDs)
+	}
+	if filter.CreditTemplateID != nil {
+		query = query.Where("credit_accounts.credit_template_id = ?", *filter.CreditTemplateID)
+	}
+	if filter.CreditType != nil {
+		query = query.Where("credit_accounts.credit_type = ?", *filter.CreditType)
+	}
+	if filter.InterestType != nil {
+		query = query.Where("credit_accounts.interest_type = ?", *filter.InterestType)
+	}
+	if filter.Status != nil {
+		query = query.Where("credit_accounts.status = ?", *filter.Status)
+	}
+	query = withClientTags(query, "credit_accounts.client_id", "credit_accounts.establishment_id", filter.Tags)
+
+	var creditAccounts []entities.CreditAccount
+	err := query.Order("credit_accounts.id").Find(&creditAccounts).Error
+	return creditAccounts, err
+}
+
+// CreateCreditTermUpdate creates the audit record of a bulk update, without its changes.
+func (r *creditTermUpdateRepository) CreateCreditTermUpdate(update *entities.CreditTermUpdate) error {
+	return r.db.Omit(clause.Associations).Create(update).Error
+}
+
+// SaveCreditTermUpdate saves the counts of a bulk update once it's done.
+func (r *creditTermUpdateRepository) SaveCreditTermUpdate(update *entities.CreditTermUpdate) error {
+	return r.db.Omit(clause.Associations).Save(update).Error
+}
+
+// ApplyCreditTermChanges saves a credit account with the new terms of a bulk update and records the
+// changes in a single transaction, with the update's reason and admin in the account's events. It
+// fails with ErrVersionConflict if the account changed since it was read at creditAccount.Version, and
+// moves it to the next version otherwise.
+func (r *creditTermUpdateRepository) ApplyCreditTermChanges(creditAccount *entities.CreditAccount, update *entities.CreditTermUpdate, changes []entities.CreditTermChange) error {
+	version := creditAccount.Version
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		creditAccount.Version = version
+		if err := saveCreditAccount(tx, creditAccount, update.Reason, &update.AdminID, update.CreatedAt); err != nil {
+			return err
+		}
+		return tx.Create(&changes).Error
+	})
+}
+
+// GetCreditTermUpdatesByEstablishmentID retrieves the bulk updates of an establishment, newest first,
+// without their changes.
+func (r *creditTermUpdateRepository) GetCreditTermUpdatesByEstablishmentID(establishmentID uint) ([]entities.CreditTermUpdate, error) {
+	var updates []entities.CreditTermUpdate
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("created_at DESC, id DESC").Find(&updates).Error
+	return updates, err
+}
+
+// GetCreditTermUpdateByID retrieves a bulk update by its ID, with its changes by account.
+func (r *creditTermUpdateRepository) GetCreditTermUpdateByID(updateID uint) (*entities.CreditTermUpdate, error) {
+	var update entities.CreditTermUpdate
+	err := r.db.Preload("Changes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("credit_account_id, id")
+	}).First(&update, updateID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/util"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentSeriesRepository defines operations for managing the fiscal document series of establishments.
+type DocumentSeriesRepository interface {
+	GetDocumentSeriesByEstablishmentID(establishmentID uint) ([]entities.DocumentSeries, error)
+	GetDocumentSeriesByID(seriesID uint) (*entities.DocumentSeries, error)
+	CreateDocumentSeries(series *entities.DocumentSeries) error
+	UpdateDocumentSeries(series *entities.DocumentSeries, lastNumber *int64) (bool, error)
+}
+
+type documentSeriesRepository struct {
+	db *gorm.DB
+}
+
+// NewDocumentSeriesRepository creates a new DocumentSeriesRepository instance.
+func NewDocumentSeriesRepository(db *gorm.DB) DocumentSeriesRepository {
+	return &documentSeriesRepository{db: db}
+}
+
+// GetDocumentSeriesByEstablishmentID retrieves the series of an establishment, by type and code.
+func (r *documentSeriesRepository) GetDocumentSeriesByEstablishmentID(establishmentID uint) ([]entities.DocumentSeries, error) {
+	var series []entities.DocumentSeries
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("document_type, code").Find(&series).Error
+	return series, err
+}
+
+// GetDocumentSeriesByID retrieves a series by its ID.
+func (r *documentSeriesRepository) GetDocumentSeriesByID(seriesID uint) (*entities.DocumentSeries, error) {
+	var series entities.DocumentSeries
+	if err := r.db.First(&series, seriesID).Error; err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// CreateDocumentSeries creates a series. An active series replaces the active one of its type.
+func (r *documentSeriesRepository) CreateDocumentSeries(series *entities.DocumentSeries) error {
+	return uniqueError(inTransaction(r.db, func(tx *gorm.DB) error {
+		series.ID = 0
+		if series.Active {
+			if err := deactivateDocumentSeries(tx, series); err != nil {
+				return err
+			}
+		}
+		return tx.Create(series).Error
+	}))
+}
+
+// UpdateDocumentSeries stores whether a series is active, replacing the active one of its type, and
+// reloads it. lastNumber, if set, moves the numbering of the series: it reports false, without changes,
+// if the series already issued numbers past lastNumber, since going back would number two documents
+// the same.
+func (r *documentSeriesRepository) UpdateDocumentSeries(series *entities.DocumentSeries, lastNumber *int64) (bool, error) {
+	updated := false
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		updated = false
+		if lastNumber != nil {
+			result := tx.Model(series).Where("last_number <= ?", *lastNumber).Update("last_number", *lastNumber)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+		}
+		if series.Active {
+			if err := deactivateDocumentSeries(tx, series); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(series).Update("active", series.Active).Error; err != nil {
+			return err
+		}
+		updated = true
+		return tx.First(series, series.ID).Error
+	})
+	return updated, err
+}
+
+// deactivateDocumentSeries deactivates the other series of the establishment and type of series.
+func deactivateDocumentSeries(tx *gorm.DB, series *entities.DocumentSeries) error {
+	return tx.Model(&entities.DocumentSeries{}).
+		Where("establishment_id = ? AND document_type = ? AND active AND id <> ?", series.EstablishmentID, series.DocumentType, series.ID).
+		Update("active", false).Error
+}
+
+// nextDocumentNumber issues the next number of the active receipt series of an establishment as part
+// of tx. The series row stays locked until tx ends, so concurrent transactions get consecutive numbers,
+// and a rolled back tx leaves no gap. It returns "" when the establishment has no active series.
+func nextDocumentNumber(tx *gorm.DB, establishmentID uint) (string, error) {
+	var series entities.DocumentSeries
+	result := tx.Model(&series).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "code"}, {Name: "last_number"}}}).
+		Where("establishment_id = ? AND document_type = ? AND active", establishmentID, enums.DocumentReceipt).
+		Update("last_number", gorm.Expr("last_number + 1"))
+	if result.Error != nil || result.RowsAffected == 0 {
+		return "", result.Error
+	}
+	return util.FormatDocumentNumber(series.Code, series.LastNumber), nil
+}
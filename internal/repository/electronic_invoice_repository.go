@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ElectronicInvoiceRepository defines operations for managing the electronic invoices of purchases.
+type ElectronicInvoiceRepository interface {
+	CreateInvoiceForTransaction(transactionID uint, now time.Time) (bool, error)
+	// ProcessDueInvoices locks up to limit pending invoices that are due for submission and calls
+	// process with them, saving the submission state process leaves them in. Invoices locked by
+	// another instance are skipped.
+	ProcessDueInvoices(now time.Time, limit int, process func(invoices []entities.ElectronicInvoice)) (int, error)
+	GetInvoiceByTransactionID(transactionID uint) (*entities.ElectronicInvoice, error)
+}
+
+type electronicInvoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewElectronicInvoiceRepository creates a new ElectronicInvoiceRepository instance.
+func NewElectronicInvoiceRepository(db *gorm.DB) ElectronicInvoiceRepository {
+	return &electronicInvoiceRepository{db: db}
+}
+
+// CreateInvoiceForTransaction queues the electronic invoice of a purchase, due now. It reports false
+// if the transaction is not a numbered purchase, or already has an invoice.
+func (r *electronicInvoiceRepository) CreateInvoiceForTransaction(transactionID uint, now time.Time) (bool, error) {
+	result := r.db.Exec(`INSERT INTO electronic_invoices
+		(transaction_id, establishment_id, document_number, status, attempts, next_attempt_at, created_at, updated_at)
+		SELECT transactions.id, credit_accounts.establishment_id, transactions.document_number, ?, 0, ?, ?, ?
+		FROM transactions JOIN credit_accounts ON credit_accounts.id = transactions.credit_account_id
+		WHERE transactions.id = ? AND transactions.transaction_type = ? AND transactions.document_number IS NOT NULL
+		ON CONFLICT (transaction_id) DO NOTHING`,
+		enums.InvoicePending, now, now, now, transactionID, enums.Purchase)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *electronicInvoiceRepository) ProcessDueInvoices(now time.Time, limit int, process func(invoices []entities.ElectronicInvoice)) (int, error) {
+	count := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var invoices []entities.ElectronicInvoice
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Transaction", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Preload("Transaction.CreditAccount.Client").
+			Preload("Transaction.CreditAccount.Establishment").
+			Where("status = ? AND next_attempt_at <= ?", enums.InvoicePending, now).
+			Order("id").
+			Limit(limit).
+			Find(&invoices).Error
+		if err != nil {
+			return err
+		}
+		count = len(invoices)
+		if count == 0 {
+			return nil
+		}
+
+		process(invoices)
+		for i := range invoices {
+			err := tx.Model(&invoices[i]).
+				Select("status", "signed_xml", "digest_value", "cdr", "response_code", "response_description",
+					"attempts", "next_attempt_at", "last_error", "submitted_at").
+				Updates(&invoices[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// GetInvoiceByTransactionID retrieves the electronic invoice of a purchase, with the purchase, its
+// account, client and establishment.
+func (r *electronicInvoiceRepository) GetInvoiceByTransactionID(transactionID uint) (*entities.ElectronicInvoice, error) {
+	var invoice entities.ElectronicInvoice
+	err := r.db.Preload("Transaction", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Transaction.CreditAccount.Client").
+		Preload("Transaction.CreditAccount.Establishment").
+		Where("transaction_id = ?", transactionID).
+		First(&invoice).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/plan"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EstablishmentImport is what importing a configuration changes in an establishment.
+type EstablishmentImport struct {
+	EstablishmentID uint
+	Settings        *entities.EstablishmentSettings // Nil to keep them
+	Categories      []entities.Category             // To create
+	Products        []ImportedProduct
+}
+
+// ImportedProduct is a product to create, or to update if it has an ID, in the category of the
+// establishment named CategoryName.
+type ImportedProduct struct {
+	Product      entities.Product
+	CategoryName string
+}
+
+// EstablishmentConfigRepository defines operations for importing configurations into establishments.
+type EstablishmentConfigRepository interface {
+	ImportEstablishmentConfig(establishmentImport *EstablishmentImport) error
+}
+
+type establishmentConfigRepository struct {
+	db *gorm.DB
+}
+
+// NewEstablishmentConfigRepository creates a new EstablishmentConfigRepository instance.
+func NewEstablishmentConfigRepository(db *gorm.DB) EstablishmentConfigRepository {
+	return &establishmentConfigRepository{db: db}
+}
+
+// ImportEstablishmentConfig saves the settings, creates the categories and creates or updates the
+// products of an import, all or none of them. Products are updated from the version they were read
+// at, failing with ErrVersionConflict if they changed since. It fails with plan.ErrUpgradeRequired
+// if the establishment's plan doesn't allow the products it creates.
+func (r *establishmentConfigRepository) ImportEstablishmentConfig(establishmentImport *EstablishmentImport) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		created := 0
+		for _, product := range establishmentImport.Products {
+			if product.Product.ID == 0 {
+				created++
+			}
+		}
+		if err := checkPlanQuota(tx, establishmentImport.EstablishmentID, plan.Products, created); err != nil {
+			return err
+		}
+		if establishmentImport.Settings != nil {
+			if err := saveEstablishmentSettings(tx, establishmentImport.Settings); err != nil {
+				return fmt.Errorf("error saving establishment settings: %w", err)
+			}
+		}
+		for i := range establishmentImport.Categories {
+			if err := uniqueError(tx.Create(&establishmentImport.Categories[i]).Error); err != nil {
+				return fmt.Errorf("error creating category %q: %w", establishmentImport.Categories[i].Name, err)
+			}
+		}
+
+		var categories []entities.Category
+		if err := tx.Where("establishment_id = ?", establishmentImport.EstablishmentID).Find(&categories).Error; err != nil {
+			return fmt.Errorf("error retrieving categories: %w", err)
+		}
+		categoryIDs := make(map[string]uint, len(categories))
+		for _, category := range categories {
+			categoryIDs[strings.ToLower(category.Name)] = category.ID
+		}
+
+		for i := range establishmentImport.Products {
+			product := &establishmentImport.Products[i].Product
+			categoryID, ok := categoryIDs[strings.ToLower(establishmentImport.Products[i].CategoryName)]
+			if !ok {
+				return fmt.Errorf("category %q of product %q not found", establishmentImport.Products[i].CategoryName, product.Name)
+			}
+			product.CategoryID, product.Category = &categoryID, nil
+
+			var err error
+			if product.ID == 0 {
+				err = uniqueError(tx.Omit(clause.Associations).Create(product).Error)
+			} else {
+				err = saveVersion(tx, product, &product.Version)
+			}
+			if err != nil {
+				return fmt.Errorf("error importing product %q: %w", product.Name, err)
+			}
+		}
+		return nil
+	})
+}
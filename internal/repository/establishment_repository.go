@@ -2,7 +2,11 @@ package repository
 
 import (
 	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/plan"
+	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,11 +18,38 @@ type EstablishmentRepository interface {
 	UpdateEstablishment(establishment *entities.Establishment) error
 	DeleteEstablishment(establishmentID uint) error
 	GetEstablishmentByAdminID(adminID uint) (*entities.Establishment, error)
+	GetEstablishmentsByAdminID(adminID uint) ([]entities.Establishment, error)
+	GetAdminEstablishment(adminID, establishmentID uint) (*entities.Establishment, error)
+	GetEstablishmentsWithStatementEmails() ([]entities.Establishment, error)
+	GetEstablishmentByInviteCode(code string) (*entities.Establishment, error)
+	UpdateInviteCode(establishmentID uint, code string) error
 	CreateEstablishmentInTransaction(tx *gorm.DB, establishment *entities.Establishment) error
 	CreateAdminAndEstablishment(user *entities.User, establishment *entities.Establishment) error
 	GetAdminByUserID(userID uint) (*entities.User, error)
+	IsEstablishmentActive(establishmentID uint) (bool, error)
+	SetEstablishmentActive(establishmentID uint, active bool) (bool, error)
+	SetEstablishmentPlan(establishmentID uint, p enums.Plan) error
+	SetEstablishmentSuspended(establishmentID uint, suspendedAt *time.Time, reason string) (bool, error)
+	GetPlanUsage(establishmentID uint) (map[plan.Quota]int, error)
+	GetEstablishmentSummary(establishmentID uint, dayStart, now time.Time, dueDay int) (*EstablishmentSummary, error)
 }
 
+// EstablishmentSummary is what the admin home shows of an establishment. IsActive, SuspendedAt and
+// SuspensionReason are those of the main establishment for a branch that is active itself.
+type EstablishmentSummary struct {
+	IsActive             bool
+	SuspendedAt          *time.Time
+	SuspensionReason     string
+	PaymentsToday        int64
+	CollectedToday       float64
+	PendingConfirmations int64 // Pending transactions not expired yet
+	OverdueAccounts      int64
+}
+
+// ErrEstablishmentInactive is returned when a purchase or a new client is attempted at a deactivated
+// establishment, or at a branch of one.
+var ErrEstablishmentInactive = errors.New("establishment is inactive, it takes no purchases or new clients")
+
 type establishmentRepository struct {
 	db *gorm.DB
 }
@@ -28,9 +59,18 @@ func NewEstablishmentRepository(db *gorm.DB) EstablishmentRepository {
 	return &establishmentRepository{db: db}
 }
 
-// CreateEstablishment creates a new establishment in the database.
+// CreateEstablishment creates a new establishment in the database, with the default product
+// categories and national holidays. A branch fails with plan.ErrUpgradeRequired if its main
+// establishment's plan allows no more branches.
 func (r *establishmentRepository) CreateEstablishment(establishment *entities.Establishment) error {
-	return r.db.Create(establishment).Error
+	return uniqueError(r.db.Transaction(func(tx *gorm.DB) error {
+		if establishment.ParentID != nil {
+			if err := checkPlanQuota(tx, *establishment.ParentID, plan.Branches, 1); err != nil {
+				return err
+			}
+		}
+		return r.CreateEstablishmentInTransaction(tx, establishment)
+	}))
 }
 
 // GetEstablishmentByID retrieves an establishment by its ID.
@@ -52,9 +92,11 @@ func (r *establishmentRepository) GetEstablishmentByUserID(userID uint) (*entiti
 	return &establishment, nil
 }
 
-// UpdateEstablishment updates an existing establishment in the database.
+// UpdateEstablishment updates an existing establishment in the database. It fails with
+// ErrVersionConflict if the establishment changed since it was read at establishment.Version, and
+// moves it to the next version otherwise.
 func (r *establishmentRepository) UpdateEstablishment(establishment *entities.Establishment) error {
-	return r.db.Save(establishment).Error
+	return saveVersion(r.db, establishment, &establishment.Version)
 }
 
 // DeleteEstablishment deletes an establishment from the database.
@@ -62,33 +104,85 @@ func (r *establishmentRepository) DeleteEstablishment(establishmentID uint) erro
 	return r.db.Delete(&entities.Establishment{}, establishmentID).Error
 }
 
-// GetEstablishmentByAdminID retrieves the establishment associated with a specific admin.
+// GetEstablishmentByAdminID retrieves the main establishment of a specific admin, not one of its branches.
 func (r *establishmentRepository) GetEstablishmentByAdminID(adminID uint) (*entities.Establishment, error) {
 	var establishment entities.Establishment
-	err := r.db.Where("admin_id = ?", adminID).First(&establishment).Error
+	err := r.db.Where("admin_id = ? AND parent_id IS NULL", adminID).First(&establishment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &establishment, nil
+}
+
+// GetEstablishmentsWithStatementEmails retrieves the active establishments that email their clients' statements.
+func (r *establishmentRepository) GetEstablishmentsWithStatementEmails() ([]entities.Establishment, error) {
+	var establishments []entities.Establishment
+	err := r.db.Where("statement_emails_enabled AND is_active").Find(&establishments).Error
+	return establishments, err
+}
+
+// GetEstablishmentByInviteCode retrieves the establishment, main or branch, with an invite code,
+// whether it is active or not.
+func (r *establishmentRepository) GetEstablishmentByInviteCode(code string) (*entities.Establishment, error) {
+	var establishment entities.Establishment
+	err := r.db.Where("invite_code = ?", code).First(&establishment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &establishment, nil
+}
+
+// UpdateInviteCode replaces the invite code of an establishment, so the previous one stops working.
+func (r *establishmentRepository) UpdateInviteCode(establishmentID uint, code string) error {
+	return r.db.Model(&entities.Establishment{}).Where("id = ?", establishmentID).Update("invite_code", code).Error
+}
+
+// GetEstablishmentsByAdminID retrieves the main establishment of an admin followed by its branches.
+func (r *establishmentRepository) GetEstablishmentsByAdminID(adminID uint) ([]entities.Establishment, error) {
+	var establishments []entities.Establishment
+	err := r.db.Where("admin_id = ?", adminID).Order("parent_id IS NOT NULL, id").Find(&establishments).Error
+	if err != nil {
+		return nil, err
+	}
+	return establishments, nil
+}
+
+// GetAdminEstablishment retrieves an establishment, main or branch, only if it belongs to the admin.
+func (r *establishmentRepository) GetAdminEstablishment(adminID, establishmentID uint) (*entities.Establishment, error) {
+	var establishment entities.Establishment
+	err := r.db.Where("id = ? AND admin_id = ?", establishmentID, adminID).First(&establishment).Error
 	if err != nil {
 		return nil, err
 	}
 	return &establishment, nil
 }
 
+// CreateEstablishmentInTransaction creates an establishment, with the default product categories and
+// the national holidays of the year it was created and the next, as part of tx.
 func (r *establishmentRepository) CreateEstablishmentInTransaction(tx *gorm.DB, establishment *entities.Establishment) error {
-	return tx.Create(establishment).Error
+	if err := tx.Create(establishment).Error; err != nil {
+		return err
+	}
+	if err := createDefaultCategories(tx, establishment.ID); err != nil {
+		return err
+	}
+	year := establishment.CreatedAt.Year()
+	return createDefaultHolidays(tx, establishment.ID, year, year+1)
 }
 
 func (r *establishmentRepository) CreateAdminAndEstablishment(user *entities.User, establishment *entities.Establishment) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	return uniqueError(r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(user).Error; err != nil {
 			return fmt.Errorf("error creating user: %w", err)
 		}
 
 		establishment.AdminID = user.ID
-		if err := tx.Create(establishment).Error; err != nil {
+		if err := r.CreateEstablishmentInTransaction(tx, establishment); err != nil {
 			return fmt.Errorf("error creating establishment: %w", err)
 		}
 
 		return nil
-	})
+	}))
 }
 
 func (r *establishmentRepository) GetAdminByUserID(userID uint) (*entities.User, error) {
@@ -99,3 +193,155 @@ func (r *establishmentRepository) GetAdminByUserID(userID uint) (*entities.User,
 	}
 	return &admin, nil
 }
+
+// IsEstablishmentActive reports whether an establishment takes purchases and new clients: it is
+// active and not suspended and, for a branch, neither is its main establishment.
+func (r *establishmentRepository) IsEstablishmentActive(establishmentID uint) (bool, error) {
+	return establishmentActive(r.db, establishmentID)
+}
+
+// SetEstablishmentActive deactivates or reactivates an establishment, moving it to the next version.
+// Establishments already in the requested state are left untouched, and it reports whether the
+// establishment changed.
+func (r *establishmentRepository) SetEstablishmentActive(establishmentID uint, active bool) (bool, error) {
+	result := r.db.Model(&entities.Establishment{}).
+		Where("id = ? AND is_active = ?", establishmentID, !active).
+		Updates(map[string]interface{}{"is_active": active, "version": gorm.Expr("version + 1")})
+	return result.RowsAffected > 0, result.Error
+}
+
+// SetEstablishmentPlan moves a main establishment to a plan, and to its next version.
+func (r *establishmentRepository) SetEstablishmentPlan(establishmentID uint, p enums.Plan) error {
+	return r.db.Model(&entities.Establishment{}).
+		Where("id = ? AND parent_id IS NULL", establishmentID).
+		Updates(map[string]interface{}{"plan": p, "version": gorm.Expr("version + 1")}).Error
+}
+
+// SetEstablishmentSuspended suspends a main establishment, with its branches, or reinstates it with a
+// nil suspendedAt, moving it to the next version. Establishments already in the requested state are
+// left untouched, and it reports whether the establishment changed.
+func (r *establishmentRepository) SetEstablishmentSuspended(establishmentID uint, suspendedAt *time.Time, reason string) (bool, error) {
+	query := r.db.Model(&entities.Establishment{}).Where("id = ? AND parent_id IS NULL", establishmentID)
+	if suspendedAt != nil {
+		query = query.Where("suspended_at IS NULL")
+	} else {
+		query = query.Where("suspended_at IS NOT NULL")
+	}
+	result := query.Updates(map[string]interface{}{"suspended_at": suspendedAt, "suspension_reason": reason, "version": gorm.Expr("version + 1")})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetPlanUsage counts, for each quota of the plans, what a main establishment and its branches have.
+func (r *establishmentRepository) GetPlanUsage(establishmentID uint) (map[plan.Quota]int, error) {
+	usage := make(map[plan.Quota]int, len(plan.Quotas))
+	for _, quota := range plan.Quotas {
+		used, err := planUsage(r.db, establishmentID, quota)
+		if err != nil {
+			return nil, err
+		}
+		usage[quota] = used
+	}
+	return usage, nil
+}
+
+// GetEstablishmentSummary counts, in one query, the payments an establishment collected since
+// dayStart and what they add up to, its pending transactions not expired at now and its credit
+// accounts past their monthly due date, dueDay being the local day of the month.
+func (r *establishmentRepository) GetEstablishmentSummary(establishmentID uint, dayStart, now time.Time, dueDay int) (*EstablishmentSummary, error) {
+	var summary EstablishmentSummary
+	result := r.db.Table("establishments").
+		Select(`establishments.is_active AND COALESCE(parents.is_active, true) AS is_active,
+			COALESCE(parents.suspended_at, establishments.suspended_at) AS suspended_at,
+			CASE WHEN parents.suspended_at IS NOT NULL THEN parents.suspension_reason ELSE establishments.suspension_reason END AS suspension_reason,
+			collections.payments AS payments_today,
+			collections.collected AS collected_today,
+			(SELECT COUNT(*) FROM transactions JOIN credit_accounts ON credit_accounts.id = transactions.credit_account_id
+				WHERE credit_accounts.establishment_id = establishments.id AND transactions.deleted_at IS NULL
+				AND transactions.payment_status = ? AND (transactions.payment_code_expires_at IS NULL OR transactions.payment_code_expires_at > ?)) AS pending_confirmations,
+			(SELECT COUNT(*) FROM credit_accounts
+				WHERE credit_accounts.establishment_id = establishments.id AND credit_accounts.deleted_at IS NULL
+				AND credit_accounts.monthly_due_date < ? AND credit_accounts.current_balance > 0) AS overdue_accounts`,
+			enums.PENDING, now, dueDay).
+		Joins("LEFT JOIN establishments parents ON parents.id = establishments.parent_id AND parents.deleted_at IS NULL").
+		Joins(`CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS payments, COALESCE(SUM(transactions.amount), 0) AS collected
+			FROM transactions JOIN credit_accounts ON credit_accounts.id = transactions.credit_account_id
+			WHERE credit_accounts.establishment_id = establishments.id AND transactions.deleted_at IS NULL
+			AND transactions.transaction_type = ? AND transactions.payment_status = ? AND transactions.transaction_date BETWEEN ? AND ?
+		) collections`, enums.Payment, enums.SUCCESS, dayStart, now).
+		Where("establishments.id = ? AND establishments.deleted_at IS NULL", establishmentID).
+		Scan(&summary)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &summary, nil
+}
+
+// planUsage counts, as part of tx, what a main establishment and its branches have of a quota.
+func planUsage(tx *gorm.DB, mainID uint, quota plan.Quota) (int, error) {
+	establishments := tx.Model(&entities.Establishment{}).Select("id").Where("id = ? OR parent_id = ?", mainID, mainID)
+	var count int64
+	var err error
+	switch quota {
+	case plan.Clients:
+		err = tx.Model(&entities.CreditAccount{}).Where("establishment_id IN (?) AND status <> ?", establishments, enums.AccountClosed).Count(&count).Error
+	case plan.Products:
+		err = tx.Model(&entities.Product{}).Where("establishment_id IN (?)", establishments).Count(&count).Error
+	case plan.Branches:
+		err = tx.Model(&entities.Establishment{}).Where("parent_id = ?", mainID).Count(&count).Error
+	}
+	return int(count), err
+}
+
+// checkPlanQuota fails with plan.ErrUpgradeRequired if adding items of quota to an establishment, or
+// to a branch, goes past what the plan of its main establishment allows, as part of tx.
+func checkPlanQuota(tx *gorm.DB, establishmentID uint, quota plan.Quota, adding int) error {
+	var establishment entities.Establishment
+	if err := tx.Select("id", "parent_id", "plan").First(&establishment, establishmentID).Error; err != nil {
+		return fmt.Errorf("error checking plan: %w", err)
+	}
+	if establishment.ParentID != nil {
+		var main entities.Establishment
+		if err := tx.Select("id", "plan").First(&main, *establishment.ParentID).Error; err != nil {
+			return fmt.Errorf("error checking plan: %w", err)
+		}
+		establishment = main
+	}
+
+	limits := plan.Of(establishment.Plan)
+	if limits.Max(quota) == 0 {
+		return nil
+	}
+	used, err := planUsage(tx, establishment.ID, quota)
+	if err != nil {
+		return fmt.Errorf("error checking plan: %w", err)
+	}
+	return limits.CheckQuota(quota, used, adding)
+}
+
+// establishmentActive reports, as part of tx, whether an establishment and the main establishment of
+// a branch are active and not suspended by the platform.
+func establishmentActive(tx *gorm.DB, establishmentID uint) (bool, error) {
+	var count int64
+	err := tx.Model(&entities.Establishment{}).
+		Joins("LEFT JOIN establishments parents ON parents.id = establishments.parent_id AND parents.deleted_at IS NULL").
+		Where("establishments.id = ? AND establishments.is_active AND establishments.suspended_at IS NULL AND (parents.id IS NULL OR (parents.is_active AND parents.suspended_at IS NULL))", establishmentID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// checkEstablishmentActive fails with ErrEstablishmentInactive if an establishment, or the main
+// establishment of a branch, is deactivated.
+func checkEstablishmentActive(tx *gorm.DB, establishmentID uint) error {
+	active, err := establishmentActive(tx, establishmentID)
+	if err != nil {
+		return fmt.Errorf("error checking establishment: %w", err)
+	}
+	if !active {
+		return ErrEstablishmentInactive
+	}
+	return nil
+}
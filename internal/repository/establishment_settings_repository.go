@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Rules of establishments that didn't configure otherwise.
+const (
+	DefaultMaxInstallments = 12 // Installments long-term purchases are split into
+	DefaultHighRiskScore   = 400
+	DefaultTaxRate         = 18 // IGV, including the municipal promotion tax
+	DefaultApprovalExpiry  = 3  // Days purchases wait for approval
+	DefaultMaxReschedules  = 3  // Installment reschedules per credit account
+	DefaultPaymentCodeTTL  = 30 // Minutes non-cash payments wait for their confirmation code
+)
+
+// DefaultEstablishmentSettings returns the rules of an establishment that never changed its settings.
+func DefaultEstablishmentSettings(establishmentID uint) *entities.EstablishmentSettings {
+	return &entities.EstablishmentSettings{
+		EstablishmentID:    establishmentID,
+		MaxInstallments:    DefaultMaxInstallments,
+		HighRiskScore:      DefaultHighRiskScore,
+		TaxRate:            DefaultTaxRate,
+		ApprovalExpiryDays: DefaultApprovalExpiry,
+		MaxReschedules:     DefaultMaxReschedules,
+		PaymentCodeMinutes: DefaultPaymentCodeTTL,
+		LateFeeMode:        string(enums.LateFeeFlat),
+		Language:           string(i18n.Default),
+		DigestFrequency:    string(enums.DigestOff),
+	}
+}
+
+// EstablishmentSettingsRepository defines operations for managing EstablishmentSettings entities.
+type EstablishmentSettingsRepository interface {
+	GetEstablishmentSettings(establishmentID uint) (*entities.EstablishmentSettings, error)
+	SaveEstablishmentSettings(settings *entities.EstablishmentSettings) error
+	GetEstablishmentSettingsWithAutoBlock() ([]entities.EstablishmentSettings, error)
+	GetEstablishmentSettingsWithReminders() ([]entities.EstablishmentSettings, error)
+	GetEstablishmentSettingsWithDigests() ([]entities.EstablishmentSettings, error)
+	GetEstablishmentSettingsByAdjustmentApprover(approverID uint) ([]entities.EstablishmentSettings, error)
+}
+
+type establishmentSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewEstablishmentSettingsRepository creates a new EstablishmentSettingsRepository instance.
+func NewEstablishmentSettingsRepository(db *gorm.DB) EstablishmentSettingsRepository {
+	return &establishmentSettingsRepository{db: db}
+}
+
+// GetEstablishmentSettings retrieves the settings of an establishment, or the defaults if it has none.
+func (r *establishmentSettingsRepository) GetEstablishmentSettings(establishmentID uint) (*entities.EstablishmentSettings, error) {
+	var settings entities.EstablishmentSettings
+	err := r.db.Where("establishment_id = ?", establishmentID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultEstablishmentSettings(establishmentID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveEstablishmentSettings creates or replaces the settings of an establishment.
+func (r *establishmentSettingsRepository) SaveEstablishmentSettings(settings *entities.EstablishmentSettings) error {
+	return saveEstablishmentSettings(r.db, settings)
+}
+
+func saveEstablishmentSettings(db *gorm.DB, settings *entities.EstablishmentSettings) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "establishment_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_installments", "default_interest_rate", "auto_block_days_overdue", "reminder_schedule", "high_risk_score", "high_risk_max_purchase", "require_admin_two_factor", "tax_rate", "prices_exclude_tax", "approval_threshold", "approval_expiry_days", "pin_threshold", "max_reschedules", "reschedule_interest", "late_fee_mode", "late_fee_amount", "late_fee_cap", "adjustment_threshold", "adjustment_approver_id", "language", "sms_notifications", "sms_sender", "digest_frequency", "digest_sections", "updated_at"}),
+	}).Create(settings).Error
+}
+
+// GetEstablishmentSettingsWithAutoBlock retrieves the settings of the establishments that block overdue accounts.
+func (r *establishmentSettingsRepository) GetEstablishmentSettingsWithAutoBlock() ([]entities.EstablishmentSettings, error) {
+	var settings []entities.EstablishmentSettings
+	err := r.db.Where("auto_block_days_overdue > 0").Order("establishment_id").Find(&settings).Error
+	return settings, err
+}
+
+// GetEstablishmentSettingsWithReminders retrieves the settings of the establishments that send payment reminders
+// or overdue notices.
+func (r *establishmentSettingsRepository) GetEstablishmentSettingsWithReminders() ([]entities.EstablishmentSettings, error) {
+	var settings []entities.EstablishmentSettings
+	err := r.db.Where("reminder_schedule <> ''").Order("establishment_id").Find(&settings).Error
+	return settings, err
+}
+
+// GetEstablishmentSettingsWithDigests retrieves the settings of the establishments that email their admin a report digest.
+func (r *establishmentSettingsRepository) GetEstablishmentSettingsWithDigests() ([]entities.EstablishmentSettings, error) {
+	var settings []entities.EstablishmentSettings
+	err := r.db.Where("digest_frequency <> ?", enums.DigestOff).Order("establishment_id").Find(&settings).Error
+	return settings, err
+}
+
+// GetEstablishmentSettingsByAdjustmentApprover retrieves the settings of the establishments a user
+// approves the adjustments of.
+func (r *establishmentSettingsRepository) GetEstablishmentSettingsByAdjustmentApprover(approverID uint) ([]entities.EstablishmentSettings, error) {
+	var settings []entities.EstablishmentSettings
+	err := r.db.Where("adjustment_approver_id = ?", approverID).Order("establishment_id").Find(&settings).Error
+	return settings, err
+}
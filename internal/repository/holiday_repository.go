@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HolidayRepository defines operations for managing the holiday calendars of establishments.
+type HolidayRepository interface {
+	GetHolidaysByEstablishmentID(establishmentID uint, year int) ([]entities.Holiday, error)
+	GetHolidayByID(holidayID uint) (*entities.Holiday, error)
+	CreateHoliday(holiday *entities.Holiday, shift DueDateShift) error
+	UpdateHoliday(holiday *entities.Holiday, shift DueDateShift) error
+	DeleteHoliday(holidayID uint) error
+	CreateHolidays(holidays []entities.Holiday, shifts []DueDateShift) error
+}
+
+// DueDateShift moves the installments still to be paid of the credit accounts of an establishment
+// that are due from From until To, excluded, to DueDate: those of a day that became a holiday, to
+// the next business day.
+type DueDateShift struct {
+	From    time.Time
+	To      time.Time
+	DueDate time.Time
+}
+
+type holidayRepository struct {
+	db *gorm.DB
+}
+
+// NewHolidayRepository creates a new HolidayRepository instance.
+func NewHolidayRepository(db *gorm.DB) HolidayRepository {
+	return &holidayRepository{db: db}
+}
+
+// GetHolidaysByEstablishmentID retrieves the holidays of an establishment in a year, or in every year
+// when it is 0, by date.
+func (r *holidayRepository) GetHolidaysByEstablishmentID(establishmentID uint, year int) ([]entities.Holiday, error) {
+	query := r.db.Where("establishment_id = ?", establishmentID)
+	if year != 0 {
+		query = query.Where("date >= ? AND date < ?",
+			time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+	}
+	var holidays []entities.Holiday
+	err := query.Order("date").Find(&holidays).Error
+	return holidays, err
+}
+
+// GetHolidayByID retrieves a holiday by its ID.
+func (r *holidayRepository) GetHolidayByID(holidayID uint) (*entities.Holiday, error) {
+	var holiday entities.Holiday
+	if err := r.db.First(&holiday, holidayID).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// CreateHoliday adds a holiday to the calendar of its establishment and moves the installments due
+// on it as shift says, in one transaction.
+func (r *holidayRepository) CreateHoliday(holiday *entities.Holiday, shift DueDateShift) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		if err := uniqueError(tx.Create(holiday).Error); err != nil {
+			return err
+		}
+		return shiftDueDates(tx, holiday.EstablishmentID, shift)
+	})
+}
+
+// UpdateHoliday changes the date and name of a holiday and moves the installments due on its new
+// date as shift says, in one transaction. Installments moved off its previous date stay where they are.
+func (r *holidayRepository) UpdateHoliday(holiday *entities.Holiday, shift DueDateShift) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		err := tx.Model(holiday).Select("date", "name", "national", "updated_at").Updates(holiday).Error
+		if err := uniqueError(err); err != nil {
+			return err
+		}
+		return shiftDueDates(tx, holiday.EstablishmentID, shift)
+	})
+}
+
+// DeleteHoliday deletes a holiday. Installments that were moved off it stay where they are.
+func (r *holidayRepository) DeleteHoliday(holidayID uint) error {
+	result := r.db.Delete(&entities.Holiday{}, holidayID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateHolidays adds the holidays to the calendars of their establishments, skipping the dates they
+// already have a holiday on, and moves the installments due on them as shifts say, in one transaction.
+func (r *holidayRepository) CreateHolidays(holidays []entities.Holiday, shifts []DueDateShift) error {
+	if len(holidays) == 0 {
+		return nil
+	}
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&holidays).Error; err != nil {
+			return err
+		}
+		for _, shift := range shifts {
+			if err := shiftDueDates(tx, holidays[0].EstablishmentID, shift); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// shiftDueDates moves the installments of the credit accounts of an establishment as shift says, in tx.
+// Overdue installments are left overdue on the date they were due.
+func shiftDueDates(tx *gorm.DB, establishmentID uint, shift DueDateShift) error {
+	return tx.Model(&entities.Installment{}).
+		Where("status IN ? AND due_date >= ? AND due_date < ?", []enums.InstallmentStatus{enums.Pending, enums.PartiallyPaid}, shift.From, shift.To).
+		Where("credit_account_id IN (?)", tx.Model(&entities.CreditAccount{}).Select("id").Where("establishment_id = ?", establishmentID)).
+		Updates(map[string]interface{}{"due_date": shift.DueDate, "version": gorm.Expr("version + 1")}).Error
+}
+
+// createDefaultHolidays adds the national holidays of Peru of the given years to a new establishment
+// as part of tx.
+func createDefaultHolidays(tx *gorm.DB, establishmentID uint, years ...int) error {
+	var holidays []entities.Holiday
+	for _, year := range years {
+		for _, holiday := range calendar.PeruvianHolidays(year) {
+			holidays = append(holidays, entities.Holiday{
+				EstablishmentID: establishmentID,
+				Date:            holiday.Date,
+				Name:            holiday.Name,
+				National:        true,
+			})
+		}
+	}
+	return tx.Create(&holidays).Error
+}
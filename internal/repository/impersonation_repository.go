@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// impersonationHistoryLimit is how many of an admin's latest impersonations are listed.
+const impersonationHistoryLimit = 50
+
+// ImpersonationRepository defines operations for managing admin impersonations of clients and their
+// audit trail.
+type ImpersonationRepository interface {
+	CreateImpersonation(impersonation *entities.Impersonation) error
+	GetImpersonationByID(impersonationID uint) (*entities.Impersonation, error)
+	GetImpersonationsByAdminID(adminID uint) ([]entities.Impersonation, error)
+	EndImpersonation(impersonationID uint, now time.Time) (bool, error)
+	EndAdminImpersonations(adminID uint, now time.Time) error
+	CreateImpersonationRequest(request *entities.ImpersonationRequest) error
+}
+
+type impersonationRepository struct {
+	db *gorm.DB
+}
+
+// NewImpersonationRepository creates a new ImpersonationRepository instance.
+func NewImpersonationRepository(db *gorm.DB) ImpersonationRepository {
+	return &impersonationRepository{db: db}
+}
+
+// CreateImpersonation creates a new impersonation in the database.
+func (r *impersonationRepository) CreateImpersonation(impersonation *entities.Impersonation) error {
+	return r.db.Omit(clause.Associations).Create(impersonation).Error
+}
+
+// GetImpersonationByID retrieves an impersonation by its ID.
+func (r *impersonationRepository) GetImpersonationByID(impersonationID uint) (*entities.Impersonation, error) {
+	var impersonation entities.Impersonation
+	if err := r.db.First(&impersonation, impersonationID).Error; err != nil {
+		return nil, err
+	}
+	return &impersonation, nil
+}
+
+// GetImpersonationsByAdminID retrieves the latest impersonations of an admin, newest first, with the
+// requests made in each in order.
+func (r *impersonationRepository) GetImpersonationsByAdminID(adminID uint) ([]entities.Impersonation, error) {
+	var impersonations []entities.Impersonation
+	err := r.db.Where("admin_id = ?", adminID).
+		Preload("Requests", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		Order("created_at DESC, id DESC").Limit(impersonationHistoryLimit).Find(&impersonations).Error
+	return impersonations, err
+}
+
+// EndImpersonation stops an impersonation that is still going on. It reports false if it had already
+// ended or expired.
+func (r *impersonationRepository) EndImpersonation(impersonationID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&entities.Impersonation{}).
+		Where("id = ? AND ended_at IS NULL AND expires_at > ?", impersonationID, now).
+		Update("ended_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+// EndAdminImpersonations stops every impersonation of an admin still going on.
+func (r *impersonationRepository) EndAdminImpersonations(adminID uint, now time.Time) error {
+	return r.db.Model(&entities.Impersonation{}).
+		Where("admin_id = ? AND ended_at IS NULL AND expires_at > ?", adminID, now).
+		Update("ended_at", now).Error
+}
+
+// CreateImpersonationRequest records a request made with an impersonation token.
+func (r *impersonationRepository) CreateImpersonationRequest(request *entities.ImpersonationRequest) error {
+	return r.db.Create(request).Error
+}
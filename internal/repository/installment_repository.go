@@ -1,11 +1,26 @@
 package repository
 
 import (
+	"ApiRestFinance/internal/event"
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInstallmentPaid is returned when rescheduling an installment that is already paid.
+	ErrInstallmentPaid = errors.New("installment is already paid")
+	// ErrInstallmentCancelled is returned when changing an installment that was cancelled.
+	ErrInstallmentCancelled = errors.New("installment was cancelled")
+	// ErrRescheduleLimitReached is returned when rescheduling an installment of a credit account that
+	// used up the reschedules its establishment allows.
+	ErrRescheduleLimitReached = errors.New("the credit account used up the installment reschedules the establishment allows")
 )
 
 // InstallmentRepository defines operations for managing Installment entities.
@@ -13,18 +28,26 @@ type InstallmentRepository interface {
 	CreateInstallments(installments []entities.Installment) error // Batch create for efficiency
 	GetInstallmentByID(installmentID uint) (*entities.Installment, error)
 	GetInstallmentsByCreditAccountID(creditAccountID uint) ([]entities.Installment, error)
+	GetInstallmentsByCreditAccountIDs(creditAccountIDs []uint) ([]entities.Installment, error)
 	UpdateInstallment(installment *entities.Installment) error
 	DeleteInstallment(installmentID uint) error
 	GetOverdueInstallments(creditAccountID uint) ([]entities.Installment, error)
+	MarkOverdueInstallments(now time.Time) ([]entities.Installment, error)
+	RescheduleInstallment(installment *entities.Installment, reschedule *entities.InstallmentReschedule, maxReschedules int) error
+	GetInstallmentReschedules(installmentID uint) ([]entities.InstallmentReschedule, error)
 }
 
+// openInstallmentStatuses are the statuses of the installments still owed.
+var openInstallmentStatuses = []enums.InstallmentStatus{enums.Pending, enums.PartiallyPaid, enums.Overdue}
+
 type installmentRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	clock util.Clock
 }
 
 // NewInstallmentRepository creates a new InstallmentRepository instance.
-func NewInstallmentRepository(db *gorm.DB) InstallmentRepository {
-	return &installmentRepository{db: db}
+func NewInstallmentRepository(db *gorm.DB, clock util.Clock) InstallmentRepository {
+	return &installmentRepository{db: db, clock: clock}
 }
 
 // CreateInstallments creates multiple installments in a single database transaction.
@@ -52,9 +75,27 @@ func (r *installmentRepository) GetInstallmentsByCreditAccountID(creditAccountID
 	return installments, nil
 }
 
-// UpdateInstallment updates an existing installment in the database.
+// GetInstallmentsByCreditAccountIDs retrieves the installments of several credit accounts in one query, ordered by due date.
+func (r *installmentRepository) GetInstallmentsByCreditAccountIDs(creditAccountIDs []uint) ([]entities.Installment, error) {
+	var installments []entities.Installment
+	err := r.db.Where("credit_account_id IN ?", creditAccountIDs).Order("due_date").Find(&installments).Error
+	return installments, err
+}
+
+// UpdateInstallment updates an existing installment in the database. It fails with ErrVersionConflict
+// if the installment changed since it was read at installment.Version, and moves it to the next
+// version otherwise. Unless it is cancelled, the installment then moves to the status its payments
+// and due date put it in, and is read back.
 func (r *installmentRepository) UpdateInstallment(installment *entities.Installment) error {
-	return r.db.Save(installment).Error
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		if err := saveVersion(tx, installment, &installment.Version); err != nil {
+			return err
+		}
+		if err := refreshInstallmentStatuses(tx, installment.CreditAccountID, []uint{installment.ID}, r.clock.Now()); err != nil {
+			return fmt.Errorf("error updating installment status: %w", err)
+		}
+		return tx.First(installment, installment.ID).Error
+	})
 }
 
 // DeleteInstallment deletes an installment from the database.
@@ -65,9 +106,162 @@ func (r *installmentRepository) DeleteInstallment(installmentID uint) error {
 // GetOverdueInstallments retrieves overdue installments for a credit account.
 func (r *installmentRepository) GetOverdueInstallments(creditAccountID uint) ([]entities.Installment, error) {
 	var overdueInstallments []entities.Installment
-	err := r.db.Where("credit_account_id = ? AND due_date < ? AND status = ?", creditAccountID, time.Now(), enums.Overdue).Find(&overdueInstallments).Error
+	err := r.db.Where("credit_account_id = ? AND due_date < ? AND status = ?", creditAccountID, r.clock.Now(), enums.Overdue).Find(&overdueInstallments).Error
 	if err != nil {
 		return nil, err
 	}
 	return overdueInstallments, nil
-}
\ No newline at end of file
+}
+
+// MarkOverdueInstallments marks overdue the installments still owed whose due date ended by now,
+// moving them to their next version, and returns them.
+func (r *installmentRepository) MarkOverdueInstallments(now time.Time) ([]entities.Installment, error) {
+	var installments []entities.Installment
+	err := r.db.Model(&installments).Clauses(clause.Returning{}).
+		Where("status IN ? AND due_date <= ?", []enums.InstallmentStatus{enums.Pending, enums.PartiallyPaid}, now.AddDate(0, 0, -1)).
+		Updates(map[string]interface{}{"status": enums.Overdue, "overdue_at": now, "version": gorm.Expr("version + 1")}).Error
+	return installments, err
+}
+
+// RescheduleInstallment moves an installment, read at installment.Version, to reschedule.NewDueDate,
+// adding reschedule.Interest to it and to the balance of its credit account, and keeps the date it
+// had in the reschedule history. An overdue installment moved to a later date is no longer overdue. It
+// fails with ErrVersionConflict if the installment changed since it was read, with
+// ErrInstallmentPaid if it was paid, with ErrInstallmentCancelled if it was cancelled and with
+// ErrRescheduleLimitReached if the account already has maxReschedules reschedules.
+func (r *installmentRepository) RescheduleInstallment(installment *entities.Installment, reschedule *entities.InstallmentReschedule, maxReschedules int) error {
+	version := installment.Version
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		// Lock the account first, so reschedules of its installments are counted one at a time
+		var creditAccount entities.CreditAccount
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&creditAccount, installment.CreditAccountID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account: %w", err)
+		}
+		var count int64
+		if err := tx.Model(&entities.InstallmentReschedule{}).Where("credit_account_id = ?", creditAccount.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("error counting reschedules: %w", err)
+		}
+		if count >= int64(maxReschedules) {
+			return ErrRescheduleLimitReached
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(installment, installment.ID).Error; err != nil {
+			return fmt.Errorf("error retrieving installment: %w", err)
+		}
+		if installment.Version != version {
+			return ErrVersionConflict
+		}
+		switch installment.Status {
+		case enums.Paid:
+			return ErrInstallmentPaid
+		case enums.Cancelled:
+			return ErrInstallmentCancelled
+		}
+
+		now := r.clock.Now()
+		reschedule.InstallmentID, reschedule.CreditAccountID = installment.ID, creditAccount.ID
+		reschedule.OriginalDueDate, reschedule.CreatedAt = installment.DueDate, now
+		if err := tx.Create(reschedule).Error; err != nil {
+			return fmt.Errorf("error recording reschedule: %w", err)
+		}
+
+		installment.DueDate = reschedule.NewDueDate
+		installment.Amount += reschedule.Interest
+		installment.Version++
+		if err := tx.Omit(clause.Associations).Save(installment).Error; err != nil {
+			return fmt.Errorf("error updating installment: %w", err)
+		}
+		if err := refreshInstallmentStatuses(tx, creditAccount.ID, []uint{installment.ID}, now); err != nil {
+			return fmt.Errorf("error updating installment status: %w", err)
+		}
+		if err := tx.First(installment, installment.ID).Error; err != nil {
+			return fmt.Errorf("error retrieving installment: %w", err)
+		}
+
+		if reschedule.Interest > 0 {
+			creditAccount.CurrentBalance += reschedule.Interest
+			creditAccount.Version++
+			if err := tx.Omit(clause.Associations).Save(&creditAccount).Error; err != nil {
+				return fmt.Errorf("error updating credit account balance: %w", err)
+			}
+			description := fmt.Sprintf("Interest for rescheduling installment %d", installment.ID)
+			if err := recordCharge(tx, &creditAccount, enums.InterestCharge, reschedule.Interest, description, now); err != nil {
+				return err
+			}
+		}
+		if err := recordActivity(tx, &creditAccount, enums.ActivityReschedule, 0, reschedule.Reason, nil, now); err != nil {
+			return err
+		}
+		return enqueueEvent(tx, event.InstallmentRescheduled, creditAccount.ID, now, reschedulePayload{
+			InstallmentID:   installment.ID,
+			OriginalDueDate: reschedule.OriginalDueDate,
+			NewDueDate:      reschedule.NewDueDate,
+			Interest:        reschedule.Interest,
+			Reason:          reschedule.Reason,
+			ActorID:         reschedule.RescheduledByID,
+		})
+	})
+}
+
+// GetInstallmentReschedules retrieves the reschedule history of an installment, oldest first.
+func (r *installmentRepository) GetInstallmentReschedules(installmentID uint) ([]entities.InstallmentReschedule, error) {
+	var reschedules []entities.InstallmentReschedule
+	err := r.db.Where("installment_id = ?", installmentID).Order("created_at, id").Find(&reschedules).Error
+	return reschedules, err
+}
+
+// installmentStatus returns the status an installment of which allocated was paid is in at now.
+// Installments are overdue once their due date ended, until they are paid in full.
+func installmentStatus(installment *entities.Installment, allocated float64, now time.Time) enums.InstallmentStatus {
+	switch {
+	case installment.Amount-allocated < 0.005:
+		return enums.Paid
+	case !now.Before(installment.DueDate.AddDate(0, 0, 1)):
+		return enums.Overdue
+	case allocated >= 0.005:
+		return enums.PartiallyPaid
+	}
+	return enums.Pending
+}
+
+// refreshInstallmentStatuses moves installments of a credit account, as part of tx, to the status
+// what was allocated to them and their due date put them in at now, stamping when they went overdue
+// and when they were paid. Cancelled installments are left as they are.
+func refreshInstallmentStatuses(tx *gorm.DB, creditAccountID uint, installmentIDs []uint, now time.Time) error {
+	if len(installmentIDs) == 0 {
+		return nil
+	}
+	var installments []entities.Installment
+	err := tx.Where("id IN ? AND status <> ?", installmentIDs, enums.Cancelled).Find(&installments).Error
+	if err != nil || len(installments) == 0 {
+		return err
+	}
+	allocated, err := allocatedAmounts(tx, creditAccountID, 0)
+	if err != nil {
+		return err
+	}
+
+	for i := range installments {
+		installment := &installments[i]
+		status := installmentStatus(installment, allocated[installment.ID], now)
+		if status == installment.Status {
+			continue
+		}
+		updates := map[string]interface{}{"status": status, "version": gorm.Expr("version + 1")}
+		switch status {
+		case enums.Paid:
+			updates["paid_at"] = now
+		case enums.Overdue:
+			updates["paid_at"] = nil
+			if installment.OverdueAt == nil {
+				updates["overdue_at"] = now
+			}
+		default:
+			updates["paid_at"], updates["overdue_at"] = nil, nil
+		}
+		if err := tx.Model(installment).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
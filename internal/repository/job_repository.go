@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobRepository stores the background jobs and their outcome.
+type JobRepository interface {
+	// CreateJob stores a new job. When the job has a dedup key and an unfinished job with the same
+	// key exists, nothing is stored, job is filled with the existing one and false is returned.
+	CreateJob(job *entities.Job) (bool, error)
+	GetJobByID(id uint) (*entities.Job, error)
+	// ClaimJob marks a queued job as running and returns it, or returns nil when the job isn't
+	// queued anymore, e.g. because another worker claimed it first.
+	ClaimJob(id uint, now time.Time) (*entities.Job, error)
+	FinishJob(job *entities.Job) error
+	// GetStaleJobs retrieves the jobs still queued since before queuedBefore and the ones running
+	// since before runningBefore, whose worker probably stopped.
+	GetStaleJobs(queuedBefore, runningBefore time.Time) ([]entities.Job, error)
+	RequeueJob(id uint) error
+	DeleteFinishedJobs(before time.Time) (int64, error)
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new JobRepository instance.
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) CreateJob(job *entities.Job) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(job)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+	err := r.db.Where("dedup_key = ? AND finished_at IS NULL", job.DedupKey).First(job).Error
+	return false, err
+}
+
+func (r *jobRepository) GetJobByID(id uint) (*entities.Job, error) {
+	var job entities.Job
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) ClaimJob(id uint, now time.Time) (*entities.Job, error) {
+	result := r.db.Model(&entities.Job{}).
+		Where("id = ? AND status = ?", id, enums.JobQueued).
+		Updates(map[string]interface{}{
+			"status":     enums.JobRunning,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"started_at": now,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return r.GetJobByID(id)
+}
+
+func (r *jobRepository) FinishJob(job *entities.Job) error {
+	return r.db.Model(job).
+		Select("status", "result", "result_content_type", "result_filename", "error", "finished_at").
+		Updates(job).Error
+}
+
+func (r *jobRepository) GetStaleJobs(queuedBefore, runningBefore time.Time) ([]entities.Job, error) {
+	var jobs []entities.Job
+	err := r.db.Omit("result").
+		Where("(status = ? AND updated_at < ?) OR (status = ? AND started_at < ?)", enums.JobQueued, queuedBefore, enums.JobRunning, runningBefore).
+		Order("id").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// RequeueJob puts a running job back in the queue.
+func (r *jobRepository) RequeueJob(id uint) error {
+	return r.db.Model(&entities.Job{}).
+		Where("id = ? AND status = ?", id, enums.JobRunning).
+		Update("status", enums.JobQueued).Error
+}
+
+// DeleteFinishedJobs deletes the jobs that finished before the given time, along with their results.
+func (r *jobRepository) DeleteFinishedJobs(before time.Time) (int64, error) {
+	result := r.db.Where("finished_at < ?", before).Delete(&entities.Job{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,53 @@
+// Package mocks has gomock mocks of the repository interfaces, so services can be unit tested
+// without a database. Regenerate them after changing a repository interface:
+//
+//	go generate ./internal/repository/mocks
+package mocks
+
+//go:generate go run go.uber.org/mock/mockgen -source=../account_activity_repository.go -destination=account_activity_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../account_adjustment_repository.go -destination=account_adjustment_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../attachment_repository.go -destination=attachment_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../balance_snapshot_repository.go -destination=balance_snapshot_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../bank_transfer_repository.go -destination=bank_transfer_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../category_repository.go -destination=category_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../client_note_repository.go -destination=client_note_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../client_notification_repository.go -destination=client_notification_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../client_repository.go -destination=client_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../client_signup_repository.go -destination=client_signup_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../client_tag_repository.go -destination=client_tag_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../collection_contact_repository.go -destination=collection_contact_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../contact_verification_repository.go -destination=contact_verification_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../credit_account_event_repository.go -destination=credit_account_event_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../credit_account_repository.go -destination=credit_account_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../credit_agreement_repository.go -destination=credit_agreement_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../credit_template_repository.go -destination=credit_template_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../credit_term_update_repository.go -destination=credit_term_update_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../document_series_repository.go -destination=document_series_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../electronic_invoice_repository.go -destination=electronic_invoice_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../establishment_config_repository.go -destination=establishment_config_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../establishment_repository.go -destination=establishment_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../establishment_settings_repository.go -destination=establishment_settings_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../holiday_repository.go -destination=holiday_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../impersonation_repository.go -destination=impersonation_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../installment_repository.go -destination=installment_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../job_repository.go -destination=job_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../outbox_repository.go -destination=outbox_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../payment_allocation_repository.go -destination=payment_allocation_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../payment_link_repository.go -destination=payment_link_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../payment_promise_repository.go -destination=payment_promise_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../payment_reminder_repository.go -destination=payment_reminder_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../platform_repository.go -destination=platform_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../privacy_repository.go -destination=privacy_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../product_repository.go -destination=product_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../purchase_approval_repository.go -destination=purchase_approval_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../purchase_item_repository.go -destination=purchase_item_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../purchase_pin_repository.go -destination=purchase_pin_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../report_digest_repository.go -destination=report_digest_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../session_repository.go -destination=session_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../sms_delivery_repository.go -destination=sms_delivery_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../statement_delivery_repository.go -destination=statement_delivery_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../statement_period_repository.go -destination=statement_period_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../till_session_repository.go -destination=till_session_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../transaction_repository.go -destination=transaction_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../two_factor_repository.go -destination=two_factor_repository.go -package=mocks
+//go:generate go run go.uber.org/mock/mockgen -source=../user_repository.go -destination=user_repository.go -package=mocks
@@ -0,0 +1,6 @@
+//go:build tools
+
+package mocks
+
+// Keeps mockgen in go.mod, so go generate runs the version the mocks were made with.
+import _ "go.uber.org/mock/mockgen"
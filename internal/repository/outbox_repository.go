@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/entities"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository reads and settles the events waiting in the outbox. Events are written by the
+// repository methods that make the changes they describe, in the same transaction.
+type OutboxRepository interface {
+	// RelayPendingEvents locks up to limit events that are due for delivery and calls relay with them
+	// in a transaction, saving the delivery state relay leaves them in. Events locked by another
+	// relay are skipped, so several API instances can relay at once.
+	RelayPendingEvents(now time.Time, maxAttempts, limit int, relay func(events []entities.OutboxEvent)) (int, error)
+	DeleteDeliveredEvents(before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new OutboxRepository instance.
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) RelayPendingEvents(now time.Time, maxAttempts, limit int, relay func(events []entities.OutboxEvent)) (int, error) {
+	count := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var events []entities.OutboxEvent
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("delivered_at IS NULL AND attempts < ? AND next_attempt_at <= ?", maxAttempts, now).
+			Order("id").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+		count = len(events)
+		if count == 0 {
+			return nil
+		}
+
+		relay(events)
+		for i := range events {
+			err := tx.Model(&events[i]).Select("delivered_at", "attempts", "next_attempt_at", "last_error").Updates(&events[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// DeleteDeliveredEvents deletes the events delivered before the given time.
+func (r *outboxRepository) DeleteDeliveredEvents(before time.Time) (int64, error) {
+	result := r.db.Where("delivered_at < ?", before).Delete(&entities.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// enqueueEvent writes an event to the outbox as part of tx, to be relayed once tx commits.
+func enqueueEvent(tx *gorm.DB, name event.Name, creditAccountID uint, occurredAt time.Time, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding %s event: %w", name, err)
+	}
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	return tx.Create(&entities.OutboxEvent{
+		Name:            string(name),
+		CreditAccountID: creditAccountID,
+		Payload:         string(data),
+		OccurredAt:      occurredAt,
+		NextAttemptAt:   time.Now(),
+	}).Error
+}
+
+// transactionPayload is the data of the events about a transaction.
+type transactionPayload struct {
+	TransactionID   uint    `json:"transaction_id"`
+	TransactionType string  `json:"transaction_type"`
+	Amount          float64 `json:"amount"`
+	PaymentMethod   string  `json:"payment_method,omitempty"`
+	PaymentStatus   string  `json:"payment_status,omitempty"`
+	CurrentBalance  float64 `json:"current_balance"`
+}
+
+// enqueueTransactionEvent writes an event about transaction, which left creditAccount with its current balance.
+func enqueueTransactionEvent(tx *gorm.DB, name event.Name, transaction *entities.Transaction, creditAccount *entities.CreditAccount) error {
+	return enqueueEvent(tx, name, creditAccount.ID, transaction.TransactionDate, transactionPayload{
+		TransactionID:   transaction.ID,
+		TransactionType: string(transaction.TransactionType),
+		Amount:          transaction.Amount,
+		PaymentMethod:   string(transaction.PaymentMethod),
+		PaymentStatus:   string(transaction.PaymentStatus),
+		CurrentBalance:  creditAccount.CurrentBalance,
+	})
+}
+
+// blockPayload is the data of the events about a credit account being blocked or unblocked.
+type blockPayload struct {
+	Reason    string `json:"reason"`
+	Automatic bool   `json:"automatic"`
+	ActorID   *uint  `json:"actor_id,omitempty"`
+}
+
+// writeOffPayload is the data of the event about a credit account being written off.
+type writeOffPayload struct {
+	Amount  float64 `json:"amount"`
+	Reason  string  `json:"reason"`
+	ActorID uint    `json:"actor_id"`
+}
+
+// closurePayload is the data of the events about a credit account being closed or reopened.
+type closurePayload struct {
+	Reason  string `json:"reason"`
+	ActorID uint   `json:"actor_id"`
+}
+
+// reschedulePayload is the data of the event about an installment being rescheduled.
+type reschedulePayload struct {
+	InstallmentID   uint      `json:"installment_id"`
+	OriginalDueDate time.Time `json:"original_due_date"`
+	NewDueDate      time.Time `json:"new_due_date"`
+	Interest        float64   `json:"interest"`
+	Reason          string    `json:"reason"`
+	ActorID         uint      `json:"actor_id"`
+}
+
+// agreementPayload is the data of the event of a credit agreement being accepted.
+type agreementPayload struct {
+	AgreementID uint   `json:"agreement_id"`
+	TermsHash   string `json:"terms_hash"`
+}
+
+// enqueueBlockEvent writes the event matching a block history entry.
+func enqueueBlockEvent(tx *gorm.DB, blockEvent *entities.CreditAccountBlockEvent) error {
+	name := event.AccountUnblocked
+	if blockEvent.Blocked {
+		name = event.AccountBlocked
+	}
+	return enqueueEvent(tx, name, blockEvent.CreditAccountID, blockEvent.CreatedAt, blockPayload{
+		Reason:    blockEvent.Reason,
+		Automatic: blockEvent.Automatic,
+		ActorID:   blockEvent.ActorID,
+	})
+}
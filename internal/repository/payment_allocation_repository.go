@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PaymentAllocationRepository reads which installments payments went to. Allocations are written by
+// the repository methods that record and reverse payments, in the same transaction.
+type PaymentAllocationRepository interface {
+	GetAllocationsByTransactionID(transactionID uint) ([]entities.PaymentAllocation, error)
+	GetAllocatedAmounts(creditAccountID, upToTransactionID uint) (map[uint]float64, error)
+}
+
+type paymentAllocationRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentAllocationRepository creates a new PaymentAllocationRepository instance.
+func NewPaymentAllocationRepository(db *gorm.DB) PaymentAllocationRepository {
+	return &paymentAllocationRepository{db: db}
+}
+
+// GetAllocationsByTransactionID retrieves the allocations of a payment, in the order the installments
+// were covered.
+func (r *paymentAllocationRepository) GetAllocationsByTransactionID(transactionID uint) ([]entities.PaymentAllocation, error) {
+	var allocations []entities.PaymentAllocation
+	err := r.db.Where("transaction_id = ?", transactionID).Order("id").Find(&allocations).Error
+	return allocations, err
+}
+
+// GetAllocatedAmounts adds up what the payments of a credit account up to upToTransactionID, included,
+// paid of each of its installments, by installment ID. 0 includes every payment.
+func (r *paymentAllocationRepository) GetAllocatedAmounts(creditAccountID, upToTransactionID uint) (map[uint]float64, error) {
+	return allocatedAmounts(r.db, creditAccountID, upToTransactionID)
+}
+
+func allocatedAmounts(db *gorm.DB, creditAccountID, upToTransactionID uint) (map[uint]float64, error) {
+	query := db.Model(&entities.PaymentAllocation{}).
+		Select("installment_id, SUM(amount) AS amount").
+		Where("credit_account_id = ?", creditAccountID)
+	if upToTransactionID > 0 {
+		query = query.Where("transaction_id <= ?", upToTransactionID)
+	}
+	var rows []struct {
+		InstallmentID uint
+		Amount        float64
+	}
+	if err := query.Group("installment_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	allocated := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		allocated[row.InstallmentID] = row.Amount
+	}
+	return allocated, nil
+}
+
+// allocatePayment allocates a payment, as part of tx, to the unpaid installments of its credit
+// account by due date, each up to what is left of it, and moves them to the status it puts them in.
+func allocatePayment(tx *gorm.DB, transaction *entities.Transaction) error {
+	var installments []entities.Installment
+	err := tx.Where("credit_account_id = ? AND status IN ?", transaction.CreditAccountID, openInstallmentStatuses).
+		Order("due_date, id").Find(&installments).Error
+	if err != nil || len(installments) == 0 {
+		return err
+	}
+	allocated, err := allocatedAmounts(tx, transaction.CreditAccountID, 0)
+	if err != nil {
+		return err
+	}
+
+	remaining := transaction.Amount
+	var allocatedTo []uint
+	for _, installment := range installments {
+		outstanding := installment.Amount - allocated[installment.ID]
+		if outstanding < 0.005 {
+			continue
+		}
+		amount := math.Round(math.Min(remaining, outstanding)*100) / 100
+		err := tx.Create(&entities.PaymentAllocation{
+			TransactionID:   transaction.ID,
+			InstallmentID:   installment.ID,
+			CreditAccountID: transaction.CreditAccountID,
+			Amount:          amount,
+			CreatedAt:       time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		allocatedTo = append(allocatedTo, installment.ID)
+		if remaining -= amount; remaining < 0.005 {
+			break
+		}
+	}
+	return refreshInstallmentStatuses(tx, transaction.CreditAccountID, allocatedTo, transaction.TransactionDate)
+}
+
+// releasePaymentAllocations deletes the allocations of a payment that was deleted or failed, as part
+// of tx, so what it covered is owed again, and moves the installments back to the status they are
+// in without it.
+func releasePaymentAllocations(tx *gorm.DB, transaction *entities.Transaction) error {
+	var installmentIDs []uint
+	err := tx.Model(&entities.PaymentAllocation{}).Where("transaction_id = ?", transaction.ID).Pluck("installment_id", &installmentIDs).Error
+	if err != nil || len(installmentIDs) == 0 {
+		return err
+	}
+	if err := tx.Where("transaction_id = ?", transaction.ID).Delete(&entities.PaymentAllocation{}).Error; err != nil {
+		return err
+	}
+	return refreshInstallmentStatuses(tx, transaction.CreditAccountID, installmentIDs, time.Now())
+}
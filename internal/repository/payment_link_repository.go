@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentLinkRepository defines operations for managing the payment links sent to clients.
+type PaymentLinkRepository interface {
+	CreatePaymentLink(link *entities.PaymentLink) error
+	GetPaymentLinkByID(linkID uint) (*entities.PaymentLink, error)
+	GetPaymentLinksByCreditAccountID(creditAccountID uint) ([]entities.PaymentLink, error)
+	GetOpenStatementPaymentLink(creditAccountID uint, amount float64, until time.Time) (*entities.PaymentLink, error)
+	MarkPaymentLinkViewed(linkID uint, now time.Time) error
+	PayPaymentLink(link *entities.PaymentLink, transaction *entities.Transaction) error
+	MarkPaymentLinksPaid(creditAccountID uint, now time.Time) ([]entities.PaymentLink, error)
+	ExpirePaymentLinks(now time.Time) ([]entities.PaymentLink, error)
+}
+
+// ErrPaymentLinkUsed is returned when paying through a link that was already paid through or expired.
+var ErrPaymentLinkUsed = errors.New("payment link was already used")
+
+// openPaymentLinkStatuses are the statuses of the links that can still be paid through.
+var openPaymentLinkStatuses = []enums.PaymentLinkStatus{enums.PaymentLinkCreated, enums.PaymentLinkViewed}
+
+type paymentLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentLinkRepository creates a new PaymentLinkRepository instance.
+func NewPaymentLinkRepository(db *gorm.DB) PaymentLinkRepository {
+	return &paymentLinkRepository{db: db}
+}
+
+// CreatePaymentLink creates a new payment link in the database.
+func (r *paymentLinkRepository) CreatePaymentLink(link *entities.PaymentLink) error {
+	return r.db.Omit(clause.Associations).Create(link).Error
+}
+
+// GetPaymentLinkByID retrieves a payment link with its payment and its credit account, client and
+// establishment.
+func (r *paymentLinkRepository) GetPaymentLinkByID(linkID uint) (*entities.PaymentLink, error) {
+	var link entities.PaymentLink
+	err := r.db.Preload("CreditAccount.Client").Preload("CreditAccount.Establishment").Preload("Transaction").First(&link, linkID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetPaymentLinksByCreditAccountID retrieves the payment links of a credit account, newest first.
+func (r *paymentLinkRepository) GetPaymentLinksByCreditAccountID(creditAccountID uint) ([]entities.PaymentLink, error) {
+	var links []entities.PaymentLink
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Order("created_at DESC, id DESC").Find(&links).Error
+	return links, err
+}
+
+// GetOpenStatementPaymentLink retrieves the link printed on the statements of a credit account for
+// amount that can still be paid through until then, the one lasting longest if several can.
+func (r *paymentLinkRepository) GetOpenStatementPaymentLink(creditAccountID uint, amount float64, until time.Time) (*entities.PaymentLink, error) {
+	var link entities.PaymentLink
+	err := r.db.Where("credit_account_id = ? AND created_by_id = 0 AND amount = ? AND transaction_id IS NULL AND status IN ? AND expires_at > ?", creditAccountID, amount, openPaymentLinkStatuses, until).
+		Order("expires_at DESC").First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// MarkPaymentLinkViewed records the first time the client opened a payment link. Later views, and
+// views of links paid or expired meanwhile, leave it as it is.
+func (r *paymentLinkRepository) MarkPaymentLinkViewed(linkID uint, now time.Time) error {
+	return r.db.Model(&entities.PaymentLink{}).
+		Where("id = ? AND status = ?", linkID, enums.PaymentLinkCreated).
+		Updates(map[string]interface{}{"status": enums.PaymentLinkViewed, "viewed_at": now, "updated_at": now}).Error
+}
+
+// PayPaymentLink makes the payment of a link on its credit account and ties it to the link, unless
+// the link was paid through or expired meanwhile. The payment stays pending until it is confirmed,
+// see MarkPaymentLinksPaid.
+func (r *paymentLinkRepository) PayPaymentLink(link *entities.PaymentLink, transaction *entities.Transaction) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		var locked entities.PaymentLink
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, link.ID).Error; err != nil {
+			return err
+		}
+		if locked.TransactionID != nil || (locked.Status != enums.PaymentLinkCreated && locked.Status != enums.PaymentLinkViewed) {
+			return ErrPaymentLinkUsed
+		}
+
+		var creditAccount entities.CreditAccount
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&creditAccount, locked.CreditAccountID).Error; err != nil {
+			return fmt.Errorf("error retrieving credit account: %w", err)
+		}
+		wasBlocked := creditAccount.IsBlocked
+
+		documentNumber, err := nextDocumentNumber(tx, creditAccount.EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error numbering receipt: %w", err)
+		}
+		transaction.ID = 0
+		transaction.CreditAccountID = creditAccount.ID
+		transaction.DocumentNumber = documentNumber
+		if err := tx.Create(transaction).Error; err != nil {
+			return fmt.Errorf("error creating transaction: %w", err)
+		}
+		if err := allocatePayment(tx, transaction); err != nil {
+			return fmt.Errorf("error allocating payment to installments: %w", err)
+		}
+		payAccount(&creditAccount, transaction.Amount)
+		if err := saveAccountBalance(tx, &creditAccount, wasBlocked); err != nil {
+			return fmt.Errorf("error updating credit account balance: %w", err)
+		}
+		if err := recordTransactionActivity(tx, transaction, &creditAccount, false); err != nil {
+			return err
+		}
+		if err := enqueueTransactionEvent(tx, event.TransactionCreated, transaction, &creditAccount); err != nil {
+			return err
+		}
+
+		link.TransactionID, link.UpdatedAt = &transaction.ID, transaction.TransactionDate
+		return tx.Model(&entities.PaymentLink{}).Where("id = ?", link.ID).
+			Updates(map[string]interface{}{"transaction_id": transaction.ID, "updated_at": link.UpdatedAt}).Error
+	})
+}
+
+// MarkPaymentLinksPaid marks paid the links of a credit account whose payment was confirmed, and
+// returns them.
+func (r *paymentLinkRepository) MarkPaymentLinksPaid(creditAccountID uint, now time.Time) ([]entities.PaymentLink, error) {
+	confirmed := r.db.Model(&entities.Transaction{}).Select("id").Where("payment_status = ?", enums.SUCCESS)
+	return r.updateOpenLinks(map[string]interface{}{"status": enums.PaymentLinkPaid, "paid_at": now, "updated_at": now}, func(query *gorm.DB) *gorm.DB {
+		return query.Where("credit_account_id = ? AND transaction_id IN (?)", creditAccountID, confirmed)
+	})
+}
+
+// ExpirePaymentLinks marks expired the links past their expiry that weren't paid through, and
+// returns them. Links whose payment waits for confirmation are left alone, those whose payment
+// failed expire as well.
+func (r *paymentLinkRepository) ExpirePaymentLinks(now time.Time) ([]entities.PaymentLink, error) {
+	failed := r.db.Model(&entities.Transaction{}).Select("id").Where("payment_status = ?", enums.FAILED)
+	return r.updateOpenLinks(map[string]interface{}{"status": enums.PaymentLinkExpired, "updated_at": now}, func(query *gorm.DB) *gorm.DB {
+		return query.Where("(transaction_id IS NULL OR transaction_id IN (?)) AND expires_at <= ?", failed, now)
+	})
+}
+
+// updateOpenLinks applies updates to the links that can still be paid through and match filter,
+// skipping those being paid, and returns them.
+func (r *paymentLinkRepository) updateOpenLinks(updates map[string]interface{}, filter func(query *gorm.DB) *gorm.DB) ([]entities.PaymentLink, error) {
+	var ids []uint
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		ids = nil
+		query := tx.Model(&entities.PaymentLink{}).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ?", openPaymentLinkStatuses)
+		if err := filter(query).Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&entities.PaymentLink{}).Where("id IN ?", ids).Updates(updates).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var links []entities.PaymentLink
+	err = r.db.Order("id").Find(&links, ids).Error
+	return links, err
+}
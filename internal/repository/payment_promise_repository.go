@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentPromiseRepository defines operations for managing the promises to pay of credit accounts.
+type PaymentPromiseRepository interface {
+	CreatePaymentPromise(promise *entities.PaymentPromise) error
+	GetPaymentPromisesByCreditAccountID(creditAccountID uint) ([]entities.PaymentPromise, error)
+	GetActivePaymentPromise(creditAccountID uint) (*entities.PaymentPromise, error)
+	GetDuePaymentPromises(now time.Time) ([]entities.PaymentPromise, error)
+	ResolvePaymentPromise(promise *entities.PaymentPromise) (bool, error)
+	CountBrokenPaymentPromises(creditAccountID uint) (int64, error)
+	CountBrokenPaymentPromisesByCreditAccountIDs(creditAccountIDs []uint) (map[uint]int, error)
+}
+
+type paymentPromiseRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentPromiseRepository creates a new PaymentPromiseRepository instance.
+func NewPaymentPromiseRepository(db *gorm.DB) PaymentPromiseRepository {
+	return &paymentPromiseRepository{db: db}
+}
+
+// CreatePaymentPromise creates a new payment promise in the database.
+func (r *paymentPromiseRepository) CreatePaymentPromise(promise *entities.PaymentPromise) error {
+	return r.db.Omit(clause.Associations).Create(promise).Error
+}
+
+// GetPaymentPromisesByCreditAccountID retrieves the payment promises of a credit account, newest first.
+func (r *paymentPromiseRepository) GetPaymentPromisesByCreditAccountID(creditAccountID uint) ([]entities.PaymentPromise, error) {
+	var promises []entities.PaymentPromise
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Order("created_at DESC, id DESC").Find(&promises).Error
+	return promises, err
+}
+
+// GetActivePaymentPromise retrieves the active payment promise of a credit account, gorm.ErrRecordNotFound
+// if it has none.
+func (r *paymentPromiseRepository) GetActivePaymentPromise(creditAccountID uint) (*entities.PaymentPromise, error) {
+	var promise entities.PaymentPromise
+	err := r.db.Where("credit_account_id = ? AND status = ?", creditAccountID, enums.PromiseActive).First(&promise).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promise, nil
+}
+
+// GetDuePaymentPromises retrieves the active payment promises whose promised day is over.
+func (r *paymentPromiseRepository) GetDuePaymentPromises(now time.Time) ([]entities.PaymentPromise, error) {
+	var promises []entities.PaymentPromise
+	err := r.db.Where("status = ? AND promised_date + interval '1 day' <= ?", enums.PromiseActive, now).
+		Order("promised_date, id").Find(&promises).Error
+	return promises, err
+}
+
+// ResolvePaymentPromise saves whether an active payment promise was kept or broken. It reports false
+// if the promise was resolved meanwhile.
+func (r *paymentPromiseRepository) ResolvePaymentPromise(promise *entities.PaymentPromise) (bool, error) {
+	result := r.db.Model(&entities.PaymentPromise{}).
+		Where("id = ? AND status = ?", promise.ID, enums.PromiseActive).
+		Updates(map[string]interface{}{
+			"status":      promise.Status,
+			"paid":        promise.Paid,
+			"resolved_at": promise.ResolvedAt,
+			"updated_at":  promise.UpdatedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// CountBrokenPaymentPromises counts the payment promises a credit account broke.
+func (r *paymentPromiseRepository) CountBrokenPaymentPromises(creditAccountID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.PaymentPromise{}).
+		Where("credit_account_id = ? AND status = ?", creditAccountID, enums.PromiseBroken).Count(&count).Error
+	return count, err
+}
+
+// CountBrokenPaymentPromisesByCreditAccountIDs counts the payment promises each of the credit accounts
+// broke, keyed by credit account ID. Accounts that broke none are left out.
+func (r *paymentPromiseRepository) CountBrokenPaymentPromisesByCreditAccountIDs(creditAccountIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int, len(creditAccountIDs))
+	if len(creditAccountIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		CreditAccountID uint
+		Count           int
+	}
+	err := r.db.Model(&entities.PaymentPromise{}).
+		Select("credit_account_id, COUNT(*) AS count").
+		Where("credit_account_id IN ? AND status = ?", creditAccountIDs, enums.PromiseBroken).
+		Group("credit_account_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.CreditAccountID] = row.Count
+	}
+	return counts, nil
+}
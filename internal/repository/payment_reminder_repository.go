@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentReminderRepository defines operations for managing PaymentReminder entities.
+type PaymentReminderRepository interface {
+	GetReminders(creditAccountID uint, dueDate time.Time, daysFromDue int) ([]entities.PaymentReminder, error)
+	CreateReminders(reminders []entities.PaymentReminder) error
+}
+
+type paymentReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentReminderRepository creates a new PaymentReminderRepository instance.
+func NewPaymentReminderRepository(db *gorm.DB) PaymentReminderRepository {
+	return &paymentReminderRepository{db: db}
+}
+
+// GetReminders retrieves the reminders and overdue notices already sent for the credit account's due
+// date on a day of the reminder schedule.
+func (r *paymentReminderRepository) GetReminders(creditAccountID uint, dueDate time.Time, daysFromDue int) ([]entities.PaymentReminder, error) {
+	var reminders []entities.PaymentReminder
+	err := r.db.Where("credit_account_id = ? AND due_date = ? AND days_from_due = ?", creditAccountID, dueDate, daysFromDue).Find(&reminders).Error
+	return reminders, err
+}
+
+// CreateReminders records the installments, or monthly due date, a reminder was sent for. Recording
+// the same one twice is a no-op.
+func (r *paymentReminderRepository) CreateReminders(reminders []entities.PaymentReminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reminders).Error
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxPlatformAuditEntries caps how many audit entries are listed at once.
+const maxPlatformAuditEntries = 500
+
+// PlatformEstablishment is a main establishment as the platform lists it, with its admin and how
+// many branches and open credit accounts it has.
+type PlatformEstablishment struct {
+	entities.Establishment
+	Branches int
+	Clients  int
+}
+
+// PlatformAuditFilter narrows the audit entries listed, with an inclusive EndDate. Zero fields don't filter.
+type PlatformAuditFilter struct {
+	ActorID   uint
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// PlatformMetrics are the figures across every establishment of the platform.
+type PlatformMetrics struct {
+	Establishments          int
+	ActiveEstablishments    int
+	SuspendedEstablishments int
+	Branches                int
+	EstablishmentsByPlan    map[enums.Plan]int
+	Admins                  int
+	Clients                 int
+	OpenCreditAccounts      int
+	Receivables             float64
+	WrittenOffBalance       float64
+	Purchases               int // Since the start of the metrics' period
+	PurchasesAmount         float64
+	Payments                int
+	PaymentsAmount          float64
+	NewCreditAccounts       int
+}
+
+// PlatformRepository defines the operations of the platform, across establishments.
+type PlatformRepository interface {
+	CreatePlatformAuditEntry(entry *entities.PlatformAuditEntry) error
+	CompletePlatformAuditEntry(entryID uint, status int, completedAt time.Time) error
+	GetPlatformAuditEntries(filter PlatformAuditFilter) ([]entities.PlatformAuditEntry, error)
+	GetPlatformEstablishments(search string) ([]PlatformEstablishment, error)
+	GetPlatformEstablishment(establishmentID uint) (*PlatformEstablishment, error)
+	GetPlatformMetrics(since time.Time) (*PlatformMetrics, error)
+}
+
+type platformRepository struct {
+	db *gorm.DB
+}
+
+// NewPlatformRepository creates a new PlatformRepository instance.
+func NewPlatformRepository(db *gorm.DB) PlatformRepository {
+	return &platformRepository{db: db}
+}
+
+// CreatePlatformAuditEntry records a request to the platform endpoints before it is handled.
+func (r *platformRepository) CreatePlatformAuditEntry(entry *entities.PlatformAuditEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// CompletePlatformAuditEntry records the status a request to the platform endpoints was answered with.
+// Entries are completed once and never changed afterwards.
+func (r *platformRepository) CompletePlatformAuditEntry(entryID uint, status int, completedAt time.Time) error {
+	return r.db.Model(&entities.PlatformAuditEntry{}).
+		Where("id = ? AND completed_at IS NULL", entryID).
+		Updates(map[string]interface{}{"status": status, "completed_at": completedAt}).Error
+}
+
+// GetPlatformAuditEntries retrieves the latest audit entries matching filter, newest first.
+func (r *platformRepository) GetPlatformAuditEntries(filter PlatformAuditFilter) ([]entities.PlatformAuditEntry, error) {
+	query := r.db.Model(&entities.PlatformAuditEntry{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if !filter.StartDate.IsZero() {
+		query = query.Where("created_at >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("created_at <= ?", filter.EndDate)
+	}
+	var entries []entities.PlatformAuditEntry
+	err := query.Order("created_at DESC, id DESC").Limit(maxPlatformAuditEntries).Find(&entries).Error
+	return entries, err
+}
+
+// GetPlatformEstablishments retrieves the main establishments, with their admins, oldest first. A
+// search matches the name or RUC of the establishment, or the name or email of its admin.
+func (r *platformRepository) GetPlatformEstablishments(search string) ([]PlatformEstablishment, error) {
+	query := r.platformEstablishments()
+	if search != "" {
+		pattern := "%" + escapeLike(search) + "%"
+		query = query.Joins("LEFT JOIN users admins ON admins.id = establishments.admin_id").
+			Where("establishments.name ILIKE ? OR establishments.ruc LIKE ? OR admins.name ILIKE ? OR admins.email ILIKE ?", pattern, pattern, pattern, pattern)
+	}
+	return r.findPlatformEstablishments(query.Order("establishments.id"))
+}
+
+// GetPlatformEstablishment retrieves a main establishment, with its admin. It fails with
+// gorm.ErrRecordNotFound for branches too.
+func (r *platformRepository) GetPlatformEstablishment(establishmentID uint) (*PlatformEstablishment, error) {
+	establishments, err := r.findPlatformEstablishments(r.platformEstablishments().Where("establishments.id = ?", establishmentID))
+	if err != nil {
+		return nil, err
+	}
+	if len(establishments) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &establishments[0], nil
+}
+
+// platformEstablishments selects the main establishments, with how many branches and open credit
+// accounts they have.
+func (r *platformRepository) platformEstablishments() *gorm.DB {
+	return r.db.Model(&entities.Establishment{}).
+		Select(`establishments.*,
+			(SELECT COUNT(*) FROM establishments branches WHERE branches.parent_id = establishments.id AND branches.deleted_at IS NULL) AS branches,
+			(SELECT COUNT(*) FROM credit_accounts WHERE credit_accounts.deleted_at IS NULL AND credit_accounts.status <> ?
+				AND credit_accounts.establishment_id IN (SELECT id FROM establishments members WHERE (members.id = establishments.id OR members.parent_id = establishments.id) AND members.deleted_at IS NULL)) AS clients`, enums.AccountClosed).
+		Where("establishments.parent_id IS NULL")
+}
+
+// findPlatformEstablishments runs a query of platformEstablishments and loads the admins of the
+// establishments found.
+func (r *platformRepository) findPlatformEstablishments(query *gorm.DB) ([]PlatformEstablishment, error) {
+	var establishments []PlatformEstablishment
+	if err := query.Scan(&establishments).Error; err != nil {
+		return nil, err
+	}
+
+	adminIDs := make([]uint, len(establishments))
+	for i := range establishments {
+		adminIDs[i] = establishments[i].AdminID
+	}
+	var admins []entities.User
+	if len(adminIDs) > 0 {
+		if err := r.db.Where("id IN ?", adminIDs).Find(&admins).Error; err != nil {
+			return nil, err
+		}
+	}
+	adminsByID := make(map[uint]*entities.User, len(admins))
+	for i := range admins {
+		adminsByID[admins[i].ID] = &admins[i]
+	}
+	for i := range establishments {
+		establishments[i].Admin = adminsByID[establishments[i].AdminID]
+	}
+	return establishments, nil
+}
+
+// GetPlatformMetrics adds up the establishments, users and credit accounts of the platform, and the
+// purchases, payments and credit accounts opened since a time. It may read from a lagging replica.
+func (r *platformRepository) GetPlatformMetrics(since time.Time) (*PlatformMetrics, error) {
+	db := database.ReadReplica(r.db)
+	metrics := &PlatformMetrics{EstablishmentsByPlan: make(map[enums.Plan]int)}
+
+	var establishments []struct {
+		Plan      enums.Plan
+		IsActive  bool
+		Suspended bool
+		Count     int
+	}
+	err := db.Model(&entities.Establishment{}).
+		Select("plan, is_active, suspended_at IS NOT NULL AS suspended, COUNT(*) AS count").
+		Where("parent_id IS NULL").
+		Group("plan, is_active, suspended_at IS NOT NULL").
+		Scan(&establishments).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range establishments {
+		metrics.Establishments += group.Count
+		metrics.EstablishmentsByPlan[group.Plan] += group.Count
+		if group.Suspended {
+			metrics.SuspendedEstablishments += group.Count
+		} else if group.IsActive {
+			metrics.ActiveEstablishments += group.Count
+		}
+	}
+
+	var count int64
+	if err := db.Model(&entities.Establishment{}).Where("parent_id IS NOT NULL").Count(&count).Error; err != nil {
+		return nil, err
+	}
+	metrics.Branches = int(count)
+	if err := db.Model(&entities.User{}).Where("rol = ?", enums.ADMIN).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	metrics.Admins = int(count)
+	if err := db.Model(&entities.User{}).Where("rol = ? AND anonymized_at IS NULL", enums.CLIENT).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	metrics.Clients = int(count)
+
+	var accounts struct {
+		Open              int
+		Receivables       float64
+		WrittenOffBalance float64
+		Opened            int
+	}
+	err = db.Model(&entities.CreditAccount{}).
+		Select(`SUM(CASE WHEN status <> ? THEN 1 ELSE 0 END) AS open,
+			COALESCE(SUM(CASE WHEN written_off_at IS NULL THEN current_balance - account_credit ELSE 0 END), 0) AS receivables,
+			COALESCE(SUM(written_off_balance), 0) AS written_off_balance,
+			SUM(CASE WHEN created_at >= ? THEN 1 ELSE 0 END) AS opened`, enums.AccountClosed, since).
+		Scan(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	metrics.OpenCreditAccounts = accounts.Open
+	metrics.Receivables = accounts.Receivables
+	metrics.WrittenOffBalance = accounts.WrittenOffBalance
+	metrics.NewCreditAccounts = accounts.Opened
+
+	var transactions []struct {
+		TransactionType enums.TransactionType
+		Count           int
+		Amount          float64
+	}
+	err = db.Model(&entities.Transaction{}).
+		Select("transaction_type, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Where("transaction_date >= ? AND transaction_type IN ? AND payment_status <> ?", since, []enums.TransactionType{enums.Purchase, enums.Payment}, enums.FAILED).
+		Group("transaction_type").
+		Scan(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range transactions {
+		if group.TransactionType == enums.Purchase {
+			metrics.Purchases, metrics.PurchasesAmount = group.Count, group.Amount
+		} else {
+			metrics.Payments, metrics.PaymentsAmount = group.Count, group.Amount
+		}
+	}
+	return metrics, nil
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PrivacyRepository defines operations for exporting, erasing and expiring the personal data held
+// about users.
+type PrivacyRepository interface {
+	GetUserData(userID, establishmentID uint) (*UserData, error)
+	AnonymizeUser(userID uint, anonymized *entities.User, now time.Time) error
+	DeleteDeliveryLogs(before time.Time) (int64, error)
+	DeleteDecidedSignups(before time.Time) (int64, error)
+	DeleteImpersonations(before time.Time) (int64, error)
+	DeleteContactVerifications(before time.Time) (int64, error)
+}
+
+// ErrUserAnonymized is returned when anonymizing a user whose personal data was already erased.
+var ErrUserAnonymized = errors.New("user was already anonymized")
+
+// UserData is every record held about a user, as gathered for an export.
+type UserData struct {
+	User                *entities.User
+	CreditAccounts      []entities.CreditAccount
+	Agreements          []entities.CreditAgreement
+	Transactions        []entities.Transaction
+	Installments        []entities.Installment
+	PaymentPromises     []entities.PaymentPromise
+	CollectionContacts  []entities.CollectionContact
+	Activities          []entities.AccountActivity
+	Attachments         []entities.Attachment
+	Signups             []entities.ClientSignup
+	StatementDeliveries []entities.StatementDelivery
+	SMSDeliveries       []entities.SMSDelivery
+	Notifications       []entities.ClientNotification
+	PaymentReminders    []entities.PaymentReminder
+	Impersonations      []entities.Impersonation
+	Sessions            []entities.Session // Only gathered across every establishment
+}
+
+type privacyRepository struct {
+	db *gorm.DB
+}
+
+// NewPrivacyRepository creates a new PrivacyRepository instance.
+func NewPrivacyRepository(db *gorm.DB) PrivacyRepository {
+	return &privacyRepository{db: db}
+}
+
+// GetUserData gathers the records of a user in an establishment, or in every establishment and
+// those of the user themselves, like their sessions, when establishmentID is 0.
+func (r *privacyRepository) GetUserData(userID, establishmentID uint) (*UserData, error) {
+	var user entities.User
+	if err := r.db.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	data := UserData{User: &user}
+
+	// scoped limits the records of a table with an establishment_id column to the establishment
+	scoped := func(query *gorm.DB) *gorm.DB {
+		if establishmentID != 0 {
+			return query.Where("establishment_id = ?", establishmentID)
+		}
+		return query
+	}
+	if err := scoped(r.db.Where("client_id = ?", userID)).Order("id").Find(&data.CreditAccounts).Error; err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+	accountIDs := make([]uint, 0, len(data.CreditAccounts))
+	for _, creditAccount := range data.CreditAccounts {
+		accountIDs = append(accountIDs, creditAccount.ID)
+	}
+
+	byAccount := []struct {
+		name   string
+		target interface{}
+		order  string
+	}{
+		{"credit agreements", &data.Agreements, "id"},
+		{"transactions", &data.Transactions, "transaction_date, id"},
+		{"installments", &data.Installments, "due_date, id"},
+		{"payment promises", &data.PaymentPromises, "created_at, id"},
+		{"collection contacts", &data.CollectionContacts, "contacted_at, id"},
+		{"account activity", &data.Activities, "occurred_at, id"},
+		{"payment reminders", &data.PaymentReminders, "sent_at, id"},
+	}
+	for _, records := range byAccount {
+		if err := r.db.Where("credit_account_id IN ?", accountIDs).Order(records.order).Find(records.target).Error; err != nil {
+			return nil, fmt.Errorf("error retrieving %s: %w", records.name, err)
+		}
+	}
+
+	byClient := []struct {
+		name   string
+		target interface{}
+		order  string
+	}{
+		{"attachments", &data.Attachments, "created_at, id"},
+		{"statement deliveries", &data.StatementDeliveries, "period_end, id"},
+		{"SMS deliveries", &data.SMSDeliveries, "sent_at, id"},
+		{"client notifications", &data.Notifications, "created_at, id"},
+	}
+	for _, records := range byClient {
+		if err := scoped(r.db.Where("client_id = ?", userID)).Order(records.order).Find(records.target).Error; err != nil {
+			return nil, fmt.Errorf("error retrieving %s: %w", records.name, err)
+		}
+	}
+	impersonations := scoped(r.db.Where("client_id = ?", userID)).
+		Preload("Requests", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") })
+	if err := impersonations.Order("created_at, id").Find(&data.Impersonations).Error; err != nil {
+		return nil, fmt.Errorf("error retrieving impersonations: %w", err)
+	}
+
+	// Signups are matched by the account they opened, or by the DNI and email of the user for those
+	// rejected or still pending
+	signups := scoped(r.db.Where("credit_account_id IN ? OR dni = ? OR lower(email) = lower(?)", accountIDs, data.User.DNI, data.User.Email))
+	if err := signups.Order("created_at, id").Find(&data.Signups).Error; err != nil {
+		return nil, fmt.Errorf("error retrieving client signups: %w", err)
+	}
+
+	if establishmentID == 0 {
+		if err := r.db.Where("user_id = ?", userID).Order("created_at, id").Find(&data.Sessions).Error; err != nil {
+			return nil, fmt.Errorf("error retrieving sessions: %w", err)
+		}
+	}
+	return &data, nil
+}
+
+// AnonymizeUser replaces the personal fields of a user with those of anonymized, logs them out and
+// erases their personal data from the records kept about them: contact details in deliveries and
+// signups, devices and IPs of their sessions and agreement acceptances, verification and recovery
+// codes, the notes admins wrote about them. Their credit accounts and transactions are kept, tied to the anonymized user.
+func (r *privacyRepository) AnonymizeUser(userID uint, anonymized *entities.User, now time.Time) error {
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		var user entities.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return err
+		}
+		if user.AnonymizedAt != nil {
+			return ErrUserAnonymized
+		}
+
+		err := tx.Model(&entities.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"dni":                  anonymized.DNI,
+			"email":                anonymized.Email,
+			"password":             "",
+			"name":                 anonymized.Name,
+			"address":              "",
+			"phone":                "",
+			"photo_url":            anonymized.PhotoUrl,
+			"two_factor_secret":    "",
+			"two_factor_enabled":   false,
+			"two_factor_last_step": 0,
+			"email_verified_at":    nil,
+			"phone_verified_at":    nil,
+			"anonymized_at":        now,
+			"updated_at":           now,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("error anonymizing user: %w", err)
+		}
+
+		accounts := tx.Model(&entities.CreditAccount{}).Select("id").Where("client_id = ?", userID)
+		scrubs := []struct {
+			name    string
+			query   *gorm.DB
+			updates map[string]interface{}
+		}{
+			{"sessions", tx.Model(&entities.Session{}).Where("user_id = ?", userID),
+				map[string]interface{}{"user_agent": "", "ip": "", "revoked_at": gorm.Expr("COALESCE(revoked_at, ?)", now)}},
+			{"client signups", tx.Model(&entities.ClientSignup{}).Where("credit_account_id IN (?) OR dni = ? OR lower(email) = lower(?)", accounts, user.DNI, user.Email),
+				map[string]interface{}{"dni": anonymized.DNI, "email": anonymized.Email, "name": anonymized.Name, "address": "", "phone": "", "password": "", "updated_at": now}},
+			{"credit agreements", tx.Model(&entities.CreditAgreement{}).Where("credit_account_id IN (?)", accounts),
+				map[string]interface{}{"accepted_ip": "", "accepted_user_agent": ""}},
+			{"statement deliveries", tx.Unscoped().Model(&entities.StatementDelivery{}).Where("client_id = ?", userID),
+				map[string]interface{}{"email": ""}},
+			{"payment reminders", tx.Model(&entities.PaymentReminder{}).Where("credit_account_id IN (?)", accounts),
+				map[string]interface{}{"email": ""}},
+			{"collection contacts", tx.Model(&entities.CollectionContact{}).Where("credit_account_id IN (?)", accounts),
+				map[string]interface{}{"notes": ""}},
+			{"SMS deliveries", tx.Model(&entities.SMSDelivery{}).Where("client_id = ?", userID),
+				map[string]interface{}{"phone": ""}},
+		}
+		for _, scrub := range scrubs {
+			if err := scrub.query.Updates(scrub.updates).Error; err != nil {
+				return fmt.Errorf("error anonymizing %s: %w", scrub.name, err)
+			}
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&entities.ContactVerification{}).Error; err != nil {
+			return fmt.Errorf("error deleting contact verifications: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&entities.TwoFactorRecoveryCode{}).Error; err != nil {
+			return fmt.Errorf("error deleting recovery codes: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&entities.PurchasePin{}).Error; err != nil {
+			return fmt.Errorf("error deleting purchase PIN: %w", err)
+		}
+		if err := tx.Where("client_id = ?", userID).Delete(&entities.ClientNote{}).Error; err != nil {
+			return fmt.Errorf("error deleting client notes: %w", err)
+		}
+		if err := tx.Where("client_id = ?", userID).Delete(&entities.ClientNotification{}).Error; err != nil {
+			return fmt.Errorf("error deleting client notifications: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteDeliveryLogs deletes the statement deliveries, payment reminders, SMS deliveries, client
+// notifications and report digests sent, or last attempted, before the given time.
+func (r *privacyRepository) DeleteDeliveryLogs(before time.Time) (int64, error) {
+	var deleted int64
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		deleted = 0
+		logs := []struct {
+			name  string
+			query *gorm.DB
+			model interface{}
+		}{
+			{"statement deliveries", tx.Unscoped().Where("updated_at < ?", before), &entities.StatementDelivery{}},
+			{"payment reminders", tx.Where("sent_at < ?", before), &entities.PaymentReminder{}},
+			{"SMS deliveries", tx.Where("updated_at < ?", before), &entities.SMSDelivery{}},
+			{"client notifications", tx.Where("created_at < ?", before), &entities.ClientNotification{}},
+			{"report digests", tx.Unscoped().Where("updated_at < ?", before), &entities.ReportDigest{}},
+		}
+		for _, records := range logs {
+			result := records.query.Delete(records.model)
+			if result.Error != nil {
+				return fmt.Errorf("error deleting %s: %w", records.name, result.Error)
+			}
+			deleted += result.RowsAffected
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// DeleteDecidedSignups deletes the client signups approved or rejected before the given time.
+// Pending signups are kept until an admin decides on them.
+func (r *privacyRepository) DeleteDecidedSignups(before time.Time) (int64, error) {
+	result := r.db.Where("status <> ? AND decided_at < ?", enums.SignupPending, before).Delete(&entities.ClientSignup{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteImpersonations deletes the impersonations that ended or expired before the given time,
+// along with the requests made in them.
+func (r *privacyRepository) DeleteImpersonations(before time.Time) (int64, error) {
+	var deleted int64
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		ended := tx.Model(&entities.Impersonation{}).Select("id").Where("COALESCE(ended_at, expires_at) < ?", before)
+		if err := tx.Where("impersonation_id IN (?)", ended).Delete(&entities.ImpersonationRequest{}).Error; err != nil {
+			return fmt.Errorf("error deleting impersonation requests: %w", err)
+		}
+		result := tx.Where("COALESCE(ended_at, expires_at) < ?", before).Delete(&entities.Impersonation{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
+
+// DeleteContactVerifications deletes the verification codes that expired before the given time.
+func (r *privacyRepository) DeleteContactVerifications(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&entities.ContactVerification{})
+	return result.RowsAffected, result.Error
+}
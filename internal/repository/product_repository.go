@@ -2,6 +2,7 @@ package repository
 
 import (
 	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/plan"
 	"gorm.io/gorm"
 )
 
@@ -9,7 +10,11 @@ import (
 type ProductRepository interface {
 	CreateProduct(product *entities.Product) error
 	GetProductByID(productID uint) (*entities.Product, error)
-	GetAllProductsByEstablishmentID(establishmentID uint) ([]entities.Product, error)
+	GetAllProductsByEstablishmentID(establishmentID, categoryID uint) ([]entities.Product, error)
+	GetCatalogProducts(establishmentID, categoryID uint, offset, limit int) ([]entities.Product, int64, error)
+	GetProductByBarcode(establishmentID uint, barcode string) (*entities.Product, error)
+	GetLowStockProducts(establishmentID uint, threshold, limit int) ([]entities.Product, error)
+	GetProductsByCodes(establishmentID uint, sku, barcode *string) ([]entities.Product, error)
 	UpdateProduct(product *entities.Product) error
 	DeleteProduct(productID uint) error
 }
@@ -23,34 +28,91 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 	return &productRepository{db: db}
 }
 
-// CreateProduct creates a new product in the database.
+// CreateProduct creates a new product in the database. It fails with plan.ErrUpgradeRequired if the
+// establishment's plan allows no more products.
 func (r *productRepository) CreateProduct(product *entities.Product) error {
-	return r.db.Create(product).Error
+	return uniqueError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkPlanQuota(tx, product.EstablishmentID, plan.Products, 1); err != nil {
+			return err
+		}
+		return tx.Create(product).Error
+	}))
 }
 
 // GetProductByID retrieves a product by its ID.
 func (r *productRepository) GetProductByID(productID uint) (*entities.Product, error) {
 	var product entities.Product
-	err := r.db.First(&product, productID).Error
+	err := r.db.Preload("Category").First(&product, productID).Error
 	if err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
-// GetAllProductsByEstablishmentID retrieves all products associated with a specific establishment.
-func (r *productRepository) GetAllProductsByEstablishmentID(establishmentID uint) ([]entities.Product, error) {
+// GetAllProductsByEstablishmentID retrieves all products associated with a specific establishment,
+// only those of a category unless categoryID is 0.
+func (r *productRepository) GetAllProductsByEstablishmentID(establishmentID, categoryID uint) ([]entities.Product, error) {
 	var products []entities.Product
-	err := r.db.Where("establishment_id = ?", establishmentID).Find(&products).Error
+	query := r.db.Preload("Category").Where("establishment_id = ?", establishmentID)
+	if categoryID != 0 {
+		query = query.Where("category_id = ?", categoryID)
+	}
+	err := query.Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
-// UpdateProduct updates an existing product in the database.
+// GetCatalogProducts retrieves a page of the active products of an establishment, by name, only those
+// of a category unless categoryID is 0, and the number of products across all pages.
+func (r *productRepository) GetCatalogProducts(establishmentID, categoryID uint, offset, limit int) ([]entities.Product, int64, error) {
+	query := r.db.Model(&entities.Product{}).Where("establishment_id = ? AND is_active", establishmentID)
+	if categoryID != 0 {
+		query = query.Where("category_id = ?", categoryID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var products []entities.Product
+	err := query.Preload("Category").Order("name, id").Offset(offset).Limit(limit).Find(&products).Error
+	return products, total, err
+}
+
+// GetLowStockProducts retrieves up to limit active products of an establishment with threshold or
+// fewer in stock, those running out first.
+func (r *productRepository) GetLowStockProducts(establishmentID uint, threshold, limit int) ([]entities.Product, error) {
+	var products []entities.Product
+	err := r.db.Where("establishment_id = ? AND is_active AND stock <= ?", establishmentID, threshold).
+		Order("stock, name, id").Limit(limit).Find(&products).Error
+	return products, err
+}
+
+// GetProductByBarcode retrieves the product of an establishment with a barcode.
+func (r *productRepository) GetProductByBarcode(establishmentID uint, barcode string) (*entities.Product, error) {
+	var product entities.Product
+	err := r.db.Preload("Category").Where("establishment_id = ? AND barcode = ?", establishmentID, barcode).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetProductsByCodes retrieves the products of an establishment with the SKU or the barcode. Nil
+// codes match no product.
+func (r *productRepository) GetProductsByCodes(establishmentID uint, sku, barcode *string) ([]entities.Product, error) {
+	var products []entities.Product
+	err := r.db.Where("establishment_id = ? AND (sku = ? OR barcode = ?)", establishmentID, sku, barcode).Find(&products).Error
+	return products, err
+}
+
+// UpdateProduct updates an existing product in the database. It fails with ErrVersionConflict if the
+// product changed since it was read at product.Version, and moves it to the next version otherwise.
 func (r *productRepository) UpdateProduct(product *entities.Product) error {
-	return r.db.Save(product).Error
+	return saveVersion(r.db, product, &product.Version)
 }
 
 // DeleteProduct deletes a product from the database.
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurchaseApprovalRepository defines operations for managing the purchases waiting for an admin's
+// approval. Approving one makes the purchase, see CreditAccountRepository.ApprovePurchaseTransaction.
+type PurchaseApprovalRepository interface {
+	CreatePurchaseApproval(approval *entities.PurchaseApproval) error
+	GetPurchaseApprovalByID(approvalID uint) (*entities.PurchaseApproval, error)
+	GetPurchaseApprovalsByEstablishmentID(establishmentID uint, status enums.ApprovalStatus) ([]entities.PurchaseApproval, error)
+	RejectPurchaseApproval(approval *entities.PurchaseApproval) (bool, error)
+	ExpirePurchaseApprovals(now time.Time) ([]entities.PurchaseApproval, error)
+}
+
+type purchaseApprovalRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseApprovalRepository creates a new PurchaseApprovalRepository instance.
+func NewPurchaseApprovalRepository(db *gorm.DB) PurchaseApprovalRepository {
+	return &purchaseApprovalRepository{db: db}
+}
+
+// CreatePurchaseApproval creates a new purchase approval in the database.
+func (r *purchaseApprovalRepository) CreatePurchaseApproval(approval *entities.PurchaseApproval) error {
+	return r.db.Omit(clause.Associations).Create(approval).Error
+}
+
+// GetPurchaseApprovalByID retrieves a purchase approval with its credit account and client.
+func (r *purchaseApprovalRepository) GetPurchaseApprovalByID(approvalID uint) (*entities.PurchaseApproval, error) {
+	var approval entities.PurchaseApproval
+	err := r.db.Preload("CreditAccount.Client").First(&approval, approvalID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// GetPurchaseApprovalsByEstablishmentID retrieves the purchase approvals of an establishment, oldest
+// first, only those in a status unless status is empty.
+func (r *purchaseApprovalRepository) GetPurchaseApprovalsByEstablishmentID(establishmentID uint, status enums.ApprovalStatus) ([]entities.PurchaseApproval, error) {
+	query := r.db.Preload("CreditAccount.Client").Where("establishment_id = ?", establishmentID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var approvals []entities.PurchaseApproval
+	err := query.Order("created_at, id").Find(&approvals).Error
+	return approvals, err
+}
+
+// RejectPurchaseApproval saves the rejection of a pending purchase approval. It reports false if the
+// approval was decided meanwhile.
+func (r *purchaseApprovalRepository) RejectPurchaseApproval(approval *entities.PurchaseApproval) (bool, error) {
+	result := r.db.Model(&entities.PurchaseApproval{}).
+		Where("id = ? AND status = ?", approval.ID, enums.ApprovalPending).
+		Updates(map[string]interface{}{
+			"status":        enums.ApprovalRejected,
+			"decided_by_id": approval.DecidedByID,
+			"decided_at":    approval.DecidedAt,
+			"reason":        approval.Reason,
+			"updated_at":    approval.UpdatedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ExpirePurchaseApprovals marks expired the pending purchase approvals past their expiry, and
+// returns them with their credit account and client. Approvals being approved are left alone.
+func (r *purchaseApprovalRepository) ExpirePurchaseApprovals(now time.Time) ([]entities.PurchaseApproval, error) {
+	var ids []uint
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		ids = nil
+		err := tx.Model(&entities.PurchaseApproval{}).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", enums.ApprovalPending, now).Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&entities.PurchaseApproval{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": enums.ApprovalExpired, "updated_at": now}).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var approvals []entities.PurchaseApproval
+	err = r.db.Preload("CreditAccount.Client").Order("id").Find(&approvals, ids).Error
+	return approvals, err
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductSales aggregates the sales of one product over a period.
+type ProductSales struct {
+	ProductID      uint
+	Name           string
+	Category       string
+	Stock          int
+	UnitsSold      int
+	Revenue        float64
+	CreditRevenue  float64
+	CashRevenue    float64
+	TaxableRevenue float64
+	TaxRevenue     float64
+}
+
+// PurchaseTax is the tax breakdown of the items of a credit purchase.
+type PurchaseTax struct {
+	TransactionID uint
+	TaxableAmount float64
+	TaxAmount     float64
+}
+
+// PurchaseItemRepository defines operations for managing PurchaseItem entities.
+type PurchaseItemRepository interface {
+	CreatePurchaseItems(items []entities.PurchaseItem) error
+	GetPurchaseItemsByTransactionID(transactionID uint) ([]entities.PurchaseItem, error)
+	GetPurchaseTaxes(transactionIDs []uint) ([]PurchaseTax, error)
+	GetProductSales(establishmentID uint, startDate, endDate time.Time) ([]ProductSales, error)
+}
+
+type purchaseItemRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseItemRepository creates a new PurchaseItemRepository instance.
+func NewPurchaseItemRepository(db *gorm.DB) PurchaseItemRepository {
+	return &purchaseItemRepository{db: db}
+}
+
+// CreatePurchaseItems creates the items of a sale in a single statement. Cash sales are collected in
+// the open till session of their establishment, if any.
+func (r *purchaseItemRepository) CreatePurchaseItems(items []entities.PurchaseItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if items[0].IsCredit {
+		return r.db.Create(&items).Error
+	}
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		sessionID, err := openTillSessionID(tx, items[0].EstablishmentID)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].TillSessionID = sessionID
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+// GetPurchaseItemsByTransactionID retrieves the items of a credit purchase with their products, even
+// the products deleted since.
+func (r *purchaseItemRepository) GetPurchaseItemsByTransactionID(transactionID uint) ([]entities.PurchaseItem, error) {
+	var items []entities.PurchaseItem
+	err := r.db.Preload("Product", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("transaction_id = ?", transactionID).Order("id").Find(&items).Error
+	return items, err
+}
+
+// GetPurchaseTaxes adds up the tax breakdown of the items of each of the given purchases. Purchases
+// without items are left out.
+func (r *purchaseItemRepository) GetPurchaseTaxes(transactionIDs []uint) ([]PurchaseTax, error) {
+	var taxes []PurchaseTax
+	if len(transactionIDs) == 0 {
+		return taxes, nil
+	}
+	err := r.db.Model(&entities.PurchaseItem{}).
+		Select("transaction_id, SUM(taxable_amount) AS taxable_amount, SUM(tax_amount) AS tax_amount").
+		Where("transaction_id IN ?", transactionIDs).
+		Group("transaction_id").
+		Scan(&taxes).Error
+	return taxes, err
+}
+
+// GetProductSales aggregates the items sold between startDate and endDate for every product of an
+// establishment, including products that sold nothing, ordered by revenue. It may read from a lagging replica.
+func (r *purchaseItemRepository) GetProductSales(establishmentID uint, startDate, endDate time.Time) ([]ProductSales, error) {
+	var sales []ProductSales
+	err := database.ReadReplica(r.db).Table("products").
+		Select(`products.id AS product_id, products.name, COALESCE(categories.name, '') AS category, products.stock,
+			COALESCE(SUM(purchase_items.quantity), 0) AS units_sold,
+			COALESCE(SUM(purchase_items.total), 0) AS revenue,
+			COALESCE(SUM(CASE WHEN purchase_items.is_credit THEN purchase_items.total ELSE 0 END), 0) AS credit_revenue,
+			COALESCE(SUM(CASE WHEN NOT purchase_items.is_credit THEN purchase_items.total ELSE 0 END), 0) AS cash_revenue,
+			COALESCE(SUM(purchase_items.taxable_amount), 0) AS taxable_revenue,
+			COALESCE(SUM(purchase_items.tax_amount), 0) AS tax_revenue`).
+		Joins(`LEFT JOIN purchase_items ON purchase_items.product_id = products.id
+			AND purchase_items.deleted_at IS NULL
+			AND purchase_items.sold_at BETWEEN ? AND ?`, startDate, endDate).
+		Joins("LEFT JOIN categories ON categories.id = products.category_id").
+		Where("products.establishment_id = ? AND products.deleted_at IS NULL", establishmentID).
+		Group("products.id, products.name, categories.name, products.stock").
+		Order("revenue DESC, products.name").
+		Scan(&sales).Error
+	if err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurchasePinRepository defines operations for managing the PINs clients confirm purchases with.
+type PurchasePinRepository interface {
+	SavePurchasePin(pin *entities.PurchasePin) error
+	GetPurchasePin(userID uint) (*entities.PurchasePin, error)
+	AddFailedPinAttempt(pinID uint, maxAttempts int, lockUntil time.Time) error
+	ClearFailedPinAttempts(pinID uint) error
+}
+
+type purchasePinRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchasePinRepository creates a new PurchasePinRepository instance.
+func NewPurchasePinRepository(db *gorm.DB) PurchasePinRepository {
+	return &purchasePinRepository{db: db}
+}
+
+// SavePurchasePin sets the PIN of a client, replacing the previous one and lifting its lockout.
+func (r *purchasePinRepository) SavePurchasePin(pin *entities.PurchasePin) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"pin_hash", "failed_attempts", "locked_until", "updated_at"}),
+	}).Create(pin).Error
+}
+
+// GetPurchasePin retrieves the PIN of a client.
+func (r *purchasePinRepository) GetPurchasePin(userID uint) (*entities.PurchasePin, error) {
+	var pin entities.PurchasePin
+	err := r.db.Where("user_id = ?", userID).First(&pin).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// AddFailedPinAttempt counts a wrong PIN entered. The one that reaches maxAttempts locks the PIN
+// until lockUntil and starts the count again.
+func (r *purchasePinRepository) AddFailedPinAttempt(pinID uint, maxAttempts int, lockUntil time.Time) error {
+	return r.db.Model(&entities.PurchasePin{}).Where("id = ?", pinID).Updates(map[string]interface{}{
+		"locked_until":    gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN ? ELSE locked_until END", maxAttempts, lockUntil),
+		"failed_attempts": gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN 0 ELSE failed_attempts + 1 END", maxAttempts),
+	}).Error
+}
+
+// ClearFailedPinAttempts forgets the wrong PINs entered before a right one.
+func (r *purchasePinRepository) ClearFailedPinAttempts(pinID uint) error {
+	return r.db.Model(&entities.PurchasePin{}).Where("id = ? AND failed_attempts > 0", pinID).
+		Update("failed_attempts", 0).Error
+}
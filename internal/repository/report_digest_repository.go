@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportDigestRepository defines operations for managing ReportDigest entities.
+type ReportDigestRepository interface {
+	GetDigest(establishmentID uint, frequency enums.DigestFrequency, periodStart time.Time) (*entities.ReportDigest, error)
+	SaveDigest(digest *entities.ReportDigest) error
+	GetDigestsByEstablishmentID(establishmentID uint) ([]entities.ReportDigest, error)
+}
+
+type reportDigestRepository struct {
+	db *gorm.DB
+}
+
+// NewReportDigestRepository creates a new ReportDigestRepository instance.
+func NewReportDigestRepository(db *gorm.DB) ReportDigestRepository {
+	return &reportDigestRepository{db: db}
+}
+
+// GetDigest retrieves the digest of an establishment at frequency for the period starting at periodStart.
+func (r *reportDigestRepository) GetDigest(establishmentID uint, frequency enums.DigestFrequency, periodStart time.Time) (*entities.ReportDigest, error) {
+	var digest entities.ReportDigest
+	err := r.db.Where("establishment_id = ? AND frequency = ? AND period_start = ?", establishmentID, frequency, periodStart).First(&digest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &digest, nil
+}
+
+// SaveDigest creates or updates a digest.
+func (r *reportDigestRepository) SaveDigest(digest *entities.ReportDigest) error {
+	return r.db.Save(digest).Error
+}
+
+// GetDigestsByEstablishmentID retrieves the digests of an establishment, newest first.
+func (r *reportDigestRepository) GetDigestsByEstablishmentID(establishmentID uint) ([]entities.ReportDigest, error) {
+	var digests []entities.ReportDigest
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("period_start DESC, id DESC").Find(&digests).Error
+	return digests, err
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/database"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxTransactionAttempts = 3
+	transactionRetryDelay  = 100 * time.Millisecond
+)
+
+// inTransaction runs fn in a database transaction and retries the whole transaction when it
+// fails with a transient error (see database.IsTransient). Since fn may run more than once, it
+// must undo any changes a failed attempt made to the entities it was given before using them.
+func inTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	delay := transactionRetryDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = db.Transaction(fn)
+		if attempt == maxTransactionAttempts || !database.IsTransient(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
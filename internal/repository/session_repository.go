@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SessionRepository defines operations for managing Session entities.
+type SessionRepository interface {
+	CreateSession(session *entities.Session) error
+	GetSessionByID(sessionID uint) (*entities.Session, error)
+	RotateSession(session *entities.Session, previousTokenID string) (bool, error)
+	GetActiveSessionsByUserID(userID uint, now time.Time) ([]entities.Session, error)
+	RevokeSession(userID, sessionID uint, now time.Time) (bool, error)
+	RevokeUserSessions(userID, exceptSessionID uint, now time.Time) error
+	DeleteEndedSessions(before time.Time) (int64, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new SessionRepository instance.
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// CreateSession creates a new session in the database.
+func (r *sessionRepository) CreateSession(session *entities.Session) error {
+	return r.db.Create(session).Error
+}
+
+// GetSessionByID retrieves a session by its ID.
+func (r *sessionRepository) GetSessionByID(sessionID uint) (*entities.Session, error) {
+	var session entities.Session
+	if err := r.db.First(&session, sessionID).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RotateSession saves the new refresh token ID, activity and expiry of a session, provided it is
+// not revoked and its refresh token is still previousTokenID. It reports false otherwise, so a
+// refresh token can only be exchanged once.
+func (r *sessionRepository) RotateSession(session *entities.Session, previousTokenID string) (bool, error) {
+	result := r.db.Model(&entities.Session{}).
+		Where("id = ? AND refresh_token_id = ? AND revoked_at IS NULL", session.ID, previousTokenID).
+		Updates(map[string]interface{}{
+			"refresh_token_id": session.RefreshTokenID,
+			"user_agent":       session.UserAgent,
+			"ip":               session.IP,
+			"last_active_at":   session.LastActiveAt,
+			"expires_at":       session.ExpiresAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetActiveSessionsByUserID retrieves the sessions of a user that are neither revoked nor expired, most recently active first.
+func (r *sessionRepository) GetActiveSessionsByUserID(userID uint, now time.Time) ([]entities.Session, error) {
+	var sessions []entities.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_active_at DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeSession revokes an active session of a user. It reports false if the user has no such session.
+func (r *sessionRepository) RevokeSession(userID, sessionID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&entities.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, userID, now).
+		Update("revoked_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+// RevokeUserSessions revokes every session of a user but exceptSessionID, which may be 0 to revoke them all.
+func (r *sessionRepository) RevokeUserSessions(userID, exceptSessionID uint, now time.Time) error {
+	return r.db.Model(&entities.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).
+		Update("revoked_at", now).Error
+}
+
+// DeleteEndedSessions deletes the sessions that expired or were revoked before the given time.
+func (r *sessionRepository) DeleteEndedSessions(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ? OR revoked_at < ?", before, before).Delete(&entities.Session{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SMSDeliveryRepository defines operations for managing SMSDelivery entities.
+type SMSDeliveryRepository interface {
+	CreateSMSDelivery(delivery *entities.SMSDelivery) error
+	UpdateSMSDelivery(delivery *entities.SMSDelivery) error
+	UpdateSMSDeliveryStatus(providerID string, status enums.SMSStatus, deliveryError string, now time.Time) error
+	GetSMSDeliveriesByEstablishmentID(establishmentID uint) ([]entities.SMSDelivery, error)
+}
+
+type smsDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewSMSDeliveryRepository creates a new SMSDeliveryRepository instance.
+func NewSMSDeliveryRepository(db *gorm.DB) SMSDeliveryRepository {
+	return &smsDeliveryRepository{db: db}
+}
+
+// CreateSMSDelivery records a text about to be sent.
+func (r *smsDeliveryRepository) CreateSMSDelivery(delivery *entities.SMSDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// UpdateSMSDelivery saves how sending a text went.
+func (r *smsDeliveryRepository) UpdateSMSDelivery(delivery *entities.SMSDelivery) error {
+	return r.db.Save(delivery).Error
+}
+
+// UpdateSMSDeliveryStatus records the status the provider reported for the text it gave providerID.
+// Texts already delivered, undelivered or failed keep their status, as reports may arrive out of order.
+func (r *smsDeliveryRepository) UpdateSMSDeliveryStatus(providerID string, status enums.SMSStatus, deliveryError string, now time.Time) error {
+	return r.db.Model(&entities.SMSDelivery{}).
+		Where("provider_id = ? AND status IN ?", providerID, []enums.SMSStatus{enums.SMSQueued, enums.SMSSent}).
+		Updates(map[string]interface{}{"status": status, "error": deliveryError, "updated_at": now}).Error
+}
+
+// GetSMSDeliveriesByEstablishmentID retrieves the texts of an establishment, newest first.
+func (r *smsDeliveryRepository) GetSMSDeliveriesByEstablishmentID(establishmentID uint) ([]entities.SMSDelivery, error) {
+	var deliveries []entities.SMSDelivery
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("sent_at DESC, id DESC").Find(&deliveries).Error
+	return deliveries, err
+}
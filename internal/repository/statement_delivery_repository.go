@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatementDeliveryRepository defines operations for managing StatementDelivery entities.
+type StatementDeliveryRepository interface {
+	GetDelivery(creditAccountID uint, periodEnd time.Time) (*entities.StatementDelivery, error)
+	SaveDelivery(delivery *entities.StatementDelivery) error
+	GetDeliveriesByEstablishmentID(establishmentID uint) ([]entities.StatementDelivery, error)
+	GetDeliveriesByClientID(clientID uint) ([]entities.StatementDelivery, error)
+}
+
+type statementDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewStatementDeliveryRepository creates a new StatementDeliveryRepository instance.
+func NewStatementDeliveryRepository(db *gorm.DB) StatementDeliveryRepository {
+	return &statementDeliveryRepository{db: db}
+}
+
+// GetDelivery retrieves the delivery of a credit account's statement closing at periodEnd.
+func (r *statementDeliveryRepository) GetDelivery(creditAccountID uint, periodEnd time.Time) (*entities.StatementDelivery, error) {
+	var delivery entities.StatementDelivery
+	err := r.db.Where("credit_account_id = ? AND period_end = ?", creditAccountID, periodEnd).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// SaveDelivery creates or updates a delivery.
+func (r *statementDeliveryRepository) SaveDelivery(delivery *entities.StatementDelivery) error {
+	return r.db.Save(delivery).Error
+}
+
+// GetDeliveriesByEstablishmentID retrieves the deliveries of an establishment, newest first.
+func (r *statementDeliveryRepository) GetDeliveriesByEstablishmentID(establishmentID uint) ([]entities.StatementDelivery, error) {
+	var deliveries []entities.StatementDelivery
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("period_end DESC, id DESC").Find(&deliveries).Error
+	return deliveries, err
+}
+
+// GetDeliveriesByClientID retrieves the deliveries of a client, newest first.
+func (r *statementDeliveryRepository) GetDeliveriesByClientID(clientID uint) ([]entities.StatementDelivery, error) {
+	var deliveries []entities.StatementDelivery
+	err := r.db.Where("client_id = ?", clientID).Order("period_end DESC, id DESC").Find(&deliveries).Error
+	return deliveries, err
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StatementPeriodRepository defines operations for managing StatementPeriod entities. Periods are
+// immutable, so there is no update or delete.
+type StatementPeriodRepository interface {
+	CreateStatementPeriod(period *entities.StatementPeriod) (bool, error)
+	GetLatestStatementPeriod(creditAccountID uint) (*entities.StatementPeriod, error)
+	GetStatementPeriodsByCreditAccountID(creditAccountID uint) ([]entities.StatementPeriod, error)
+}
+
+type statementPeriodRepository struct {
+	db *gorm.DB
+}
+
+// NewStatementPeriodRepository creates a new StatementPeriodRepository instance.
+func NewStatementPeriodRepository(db *gorm.DB) StatementPeriodRepository {
+	return &statementPeriodRepository{db: db}
+}
+
+// CreateStatementPeriod stores a closed period and reports whether it was created. A period that
+// was already closed, e.g. by another instance, is left untouched.
+func (r *statementPeriodRepository) CreateStatementPeriod(period *entities.StatementPeriod) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(period)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetLatestStatementPeriod retrieves the last closed period of a credit account.
+func (r *statementPeriodRepository) GetLatestStatementPeriod(creditAccountID uint) (*entities.StatementPeriod, error) {
+	var period entities.StatementPeriod
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Order("period_end DESC").First(&period).Error
+	if err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+// GetStatementPeriodsByCreditAccountID retrieves the closed periods of a credit account, newest first.
+func (r *statementPeriodRepository) GetStatementPeriodsByCreditAccountID(creditAccountID uint) ([]entities.StatementPeriod, error) {
+	var periods []entities.StatementPeriod
+	err := r.db.Where("credit_account_id = ?", creditAccountID).Order("period_end DESC").Find(&periods).Error
+	return periods, err
+}
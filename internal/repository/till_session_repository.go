@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"errors"
+	"fmt"
+	"math"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrTillSessionOpen is returned when a till session is opened while the establishment has one open.
+	ErrTillSessionOpen = errors.New("the establishment already has an open till session, close it first")
+	// ErrTillSessionClosed is returned when a till session that was already closed is closed.
+	ErrTillSessionClosed = errors.New("till session is already closed")
+	// ErrDiscrepancyNotesRequired is returned when a till session is closed with a counted cash other
+	// than the expected one and no notes explaining it.
+	ErrDiscrepancyNotesRequired = errors.New("the counted cash differs from the expected cash, explain the difference in the notes")
+)
+
+// TillSessionTotals adds up the cash collected in a till session.
+type TillSessionTotals struct {
+	CashPayments float64
+	PaymentCount int
+	CashSales    float64
+}
+
+// TillSessionRepository defines operations for managing the till sessions of establishments.
+type TillSessionRepository interface {
+	OpenTillSession(session *entities.TillSession) error
+	GetTillSessionByID(sessionID uint) (*entities.TillSession, error)
+	GetOpenTillSession(establishmentID uint) (*entities.TillSession, error)
+	GetTillSessionsByEstablishmentID(establishmentID uint) ([]entities.TillSession, error)
+	GetTillSessionTotals(sessionID uint) (TillSessionTotals, error)
+	GetTillSessionPayments(sessionID uint) ([]entities.Transaction, error)
+	CloseTillSession(session *entities.TillSession) error
+}
+
+type tillSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewTillSessionRepository creates a new TillSessionRepository instance.
+func NewTillSessionRepository(db *gorm.DB) TillSessionRepository {
+	return &tillSessionRepository{db: db}
+}
+
+// OpenTillSession creates a new open till session. It returns ErrTillSessionOpen if the establishment
+// already has one open.
+func (r *tillSessionRepository) OpenTillSession(session *entities.TillSession) error {
+	return uniqueError(r.db.Omit(clause.Associations).Create(session).Error)
+}
+
+// GetTillSessionByID retrieves a till session by its ID.
+func (r *tillSessionRepository) GetTillSessionByID(sessionID uint) (*entities.TillSession, error) {
+	var session entities.TillSession
+	err := r.db.First(&session, sessionID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetOpenTillSession retrieves the open till session of an establishment, gorm.ErrRecordNotFound if
+// it has none.
+func (r *tillSessionRepository) GetOpenTillSession(establishmentID uint) (*entities.TillSession, error) {
+	var session entities.TillSession
+	err := r.db.Where("establishment_id = ? AND closed_at IS NULL", establishmentID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetTillSessionsByEstablishmentID retrieves the till sessions of an establishment, newest first.
+func (r *tillSessionRepository) GetTillSessionsByEstablishmentID(establishmentID uint) ([]entities.TillSession, error) {
+	var sessions []entities.TillSession
+	err := r.db.Where("establishment_id = ?", establishmentID).Order("opened_at DESC, id DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+// GetTillSessionTotals sums the cash payments, leaving out failed ones, and the cash sales collected
+// in a till session.
+func (r *tillSessionRepository) GetTillSessionTotals(sessionID uint) (TillSessionTotals, error) {
+	return tillSessionTotals(r.db, sessionID)
+}
+
+// GetTillSessionPayments retrieves the cash payments collected in a till session, oldest first.
+func (r *tillSessionRepository) GetTillSessionPayments(sessionID uint) ([]entities.Transaction, error) {
+	var payments []entities.Transaction
+	err := r.db.Where("till_session_id = ?", sessionID).Order("transaction_date, id").Find(&payments).Error
+	return payments, err
+}
+
+// CloseTillSession closes an open till session with the cash counted in session.CountedCash, freezing
+// the cash collected in it. It returns ErrTillSessionClosed if it was closed meanwhile, and
+// ErrDiscrepancyNotesRequired if the counted cash differs from the expected one without session.Notes.
+func (r *tillSessionRepository) CloseTillSession(session *entities.TillSession) error {
+	original := *session
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*session = original
+		var locked entities.TillSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, session.ID).Error; err != nil {
+			return err
+		}
+		if locked.ClosedAt != nil {
+			return ErrTillSessionClosed
+		}
+
+		totals, err := tillSessionTotals(tx, session.ID)
+		if err != nil {
+			return err
+		}
+		expected := math.Round((locked.OpeningCash+totals.CashPayments+totals.CashSales)*100) / 100
+		if math.Abs(session.CountedCash-expected) >= 0.005 && session.Notes == "" {
+			return ErrDiscrepancyNotesRequired
+		}
+
+		session.CashPayments, session.PaymentCount, session.CashSales = totals.CashPayments, totals.PaymentCount, totals.CashSales
+		session.ExpectedCash = expected
+		return tx.Model(&entities.TillSession{}).Where("id = ?", session.ID).
+			Updates(map[string]interface{}{
+				"closed_by_id":  session.ClosedByID,
+				"closed_at":     session.ClosedAt,
+				"cash_payments": session.CashPayments,
+				"payment_count": session.PaymentCount,
+				"cash_sales":    session.CashSales,
+				"expected_cash": session.ExpectedCash,
+				"counted_cash":  session.CountedCash,
+				"notes":         session.Notes,
+				"updated_at":    session.UpdatedAt,
+			}).Error
+	})
+}
+
+func tillSessionTotals(db *gorm.DB, sessionID uint) (TillSessionTotals, error) {
+	var totals TillSessionTotals
+	err := db.Model(&entities.Transaction{}).
+		Select("COALESCE(SUM(amount), 0) AS cash_payments, COUNT(*) AS payment_count").
+		Where("till_session_id = ? AND payment_status <> ?", sessionID, enums.FAILED).
+		Scan(&totals).Error
+	if err != nil {
+		return TillSessionTotals{}, fmt.Errorf("error summing till session payments: %w", err)
+	}
+	err = db.Model(&entities.PurchaseItem{}).
+		Select("COALESCE(SUM(total), 0)").
+		Where("till_session_id = ?", sessionID).
+		Scan(&totals.CashSales).Error
+	if err != nil {
+		return TillSessionTotals{}, fmt.Errorf("error summing till session sales: %w", err)
+	}
+	return totals, nil
+}
+
+// openTillSessionID returns the ID of the open till session of an establishment, nil if it has none.
+// The session is locked for share until tx ends, so it can't be closed without what tx collects in it.
+func openTillSessionID(tx *gorm.DB, establishmentID uint) (*uint, error) {
+	var sessions []entities.TillSession
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Select("id").
+		Where("establishment_id = ? AND closed_at IS NULL", establishmentID).Limit(1).Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving open till session: %w", err)
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return &sessions[0].ID, nil
+}
+
+// assignTillSession adds a cash payment about to be created in tx to the open till session of the
+// establishment, if any. Other transactions are left alone.
+func assignTillSession(tx *gorm.DB, transaction *entities.Transaction, establishmentID uint) error {
+	if transaction.TransactionType != enums.Payment || transaction.PaymentMethod != enums.CASH {
+		return nil
+	}
+	sessionID, err := openTillSessionID(tx, establishmentID)
+	if err != nil {
+		return err
+	}
+	transaction.TillSessionID = sessionID
+	return nil
+}
@@ -1,20 +1,64 @@
 package repository
 
 import (
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/event"
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Errors of confirming pending payments with their code. The payment fails, and what it paid is
+// owed again, once its code expired or was mistyped too many times.
+var (
+	ErrPaymentNotPending   = errors.New("transaction cannot be confirmed")
+	ErrInvalidPaymentCode  = errors.New("invalid confirmation code")
+	ErrPaymentCodeExpired  = errors.New("confirmation code expired, the payment failed")
+	ErrPaymentCodeAttempts = errors.New("too many wrong confirmation codes, the payment failed")
+)
+
+// TransactionTotals adds up the purchases, payments, interest, late fees and adjustments of a credit account over a period.
+type TransactionTotals struct {
+	Purchases     float64
+	Payments      float64
+	Interest      float64
+	LateFees      float64
+	Adjustments   float64 // Adjustment debits less adjustment credits
+	PurchaseCount int
+	PaymentCount  int
+}
+
+// TransactionSearch narrows down the transactions of an establishment. Zero values leave a filter out.
+type TransactionSearch struct {
+	EstablishmentID uint
+	Client          string // Prefix of the client's DNI or part of their name
+	TransactionType enums.TransactionType
+	PaymentMethod   enums.PaymentMethod
+	PaymentStatus   enums.PaymentStatus
+	MinAmount       float64
+	MaxAmount       float64
+	StartDate       time.Time
+	EndDate         time.Time // Exclusive
+	Text            string    // Full-text search on the description
+	SortBy          string    // transaction_date or amount
+	Descending      bool
+	Offset          int
+	Limit           int
+}
+
 // TransactionRepository defines operations for managing Transaction entities.
 type TransactionRepository interface {
 	CreateTransaction(transaction *entities.Transaction, creditAccount *entities.CreditAccount) error
 	GetTransactionByID(transactionID uint) (*entities.Transaction, error)
 	GetTransactionsByCreditAccountID(creditAccountID uint) ([]entities.Transaction, error)
+	GetTransactionsByCreditAccountIDPaged(creditAccountID uint, offset, limit int) ([]entities.Transaction, error)
+	GetTransactionsByCreditAccountIDs(creditAccountIDs []uint) ([]entities.Transaction, error)
 	UpdateTransaction(transaction *entities.Transaction, creditAccount *entities.CreditAccount) error
 	DeleteTransaction(transactionID uint, creditAccount *entities.CreditAccount) error
 	CreateTransactionInTx(tx *gorm.DB, transaction *entities.Transaction) error
@@ -22,6 +66,12 @@ type TransactionRepository interface {
 	DeleteTransactionInTx(tx *gorm.DB, transactionID uint) error
 	GetTransactionsByCreditAccountIDAndDateRange(creditAccountID uint, startDate, endDate time.Time) ([]entities.Transaction, error)
 	GetBalanceBeforeDate(creditAccountID uint, beforeDate time.Time) (float64, error)
+	GetTransactionTotals(creditAccountID uint, startDate, endDate time.Time) (TransactionTotals, error)
+	GetTransactionsByEstablishmentID(establishmentID uint, startDate, endDate time.Time) ([]entities.Transaction, error)
+	SearchTransactions(search TransactionSearch) ([]entities.Transaction, int64, error)
+	ConfirmPendingPayment(transactionID uint, code string, maxAttempts int, now time.Time) (*entities.Transaction, error)
+	ExpirePendingPayments(now time.Time) ([]entities.Transaction, error)
+	GetPendingPaymentsByCode(code string, now time.Time) ([]entities.Transaction, error)
 }
 
 type transactionRepository struct {
@@ -35,47 +85,60 @@ func NewTransactionRepository(db *gorm.DB) TransactionRepository {
 
 // CreateTransaction creates a new transaction and updates the credit account balance in a transaction.
 func (r *transactionRepository) CreateTransaction(transaction *entities.Transaction, creditAccount *entities.CreditAccount) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	original := *creditAccount
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*creditAccount = original
+
+		documentNumber, err := nextDocumentNumber(tx, creditAccount.EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error numbering receipt: %w", err)
+		}
+		transaction.DocumentNumber = documentNumber
+		if err := assignTillSession(tx, transaction, creditAccount.EstablishmentID); err != nil {
+			return err
+		}
 		if err := tx.Create(transaction).Error; err != nil {
 			return fmt.Errorf("error creating transaction: %w", err)
 		}
 
-		// Update the credit account balance based on the transaction type
+		// Update the credit account balance based on the transaction type. Adjustments are made
+		// through AccountAdjustmentRepository, which enforces their approval
 		switch transaction.TransactionType {
-		case enums.Purchase:
-			creditAccount.CurrentBalance += transaction.Amount
+		case enums.Purchase, enums.InterestCharge, enums.LateFeeCharge:
+			chargeAccount(creditAccount, transaction.Amount)
 		case enums.Payment:
-			if transaction.Amount > creditAccount.CurrentBalance {
-				return fmt.Errorf("payment amount exceeds current balance: %.2f", creditAccount.CurrentBalance)
-			}
-			creditAccount.CurrentBalance -= transaction.Amount
-
-			// Unblock the account if it was blocked and the balance is zero or less
-			if creditAccount.IsBlocked && creditAccount.CurrentBalance <= 0 {
-				creditAccount.IsBlocked = false
+			payAccount(creditAccount, transaction.Amount)
+			if err := allocatePayment(tx, transaction); err != nil {
+				return fmt.Errorf("error allocating payment to installments: %w", err)
 			}
 		default:
 			return errors.New("invalid transaction type")
 		}
 
 		// Save the updated credit account
-		if err := tx.Save(creditAccount).Error; err != nil {
+		if err := saveAccountBalance(tx, creditAccount, original.IsBlocked); err != nil {
 			return fmt.Errorf("error updating credit account balance: %w", err)
 		}
 
-		return nil
+		if err := recordTransactionActivity(tx, transaction, creditAccount, false); err != nil {
+			return err
+		}
+		return enqueueTransactionEvent(tx, event.TransactionCreated, transaction, creditAccount)
 	})
 }
 
 // UpdateTransaction updates a transaction and adjusts the credit account balance in a transaction.
 func (r *transactionRepository) UpdateTransaction(transaction *entities.Transaction, creditAccount *entities.CreditAccount) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	original := *creditAccount
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*creditAccount = original
+
 		// Reverse the effect of the original transaction
 		switch transaction.TransactionType {
-		case enums.Purchase:
-			creditAccount.CurrentBalance -= transaction.Amount
+		case enums.Purchase, enums.InterestCharge, enums.LateFeeCharge:
+			payAccount(creditAccount, transaction.Amount)
 		case enums.Payment:
-			creditAccount.CurrentBalance += transaction.Amount
+			chargeAccount(creditAccount, transaction.Amount)
 		default:
 			return errors.New("invalid transaction type")
 		}
@@ -85,17 +148,10 @@ func (r *transactionRepository) UpdateTransaction(transaction *entities.Transact
 
 		// Apply the effect of the updated transaction
 		switch transaction.TransactionType {
-		case enums.Purchase:
-			creditAccount.CurrentBalance += transaction.Amount
+		case enums.Purchase, enums.InterestCharge, enums.LateFeeCharge:
+			chargeAccount(creditAccount, transaction.Amount)
 		case enums.Payment:
-			if transaction.Amount > creditAccount.CurrentBalance {
-				return fmt.Errorf("payment amount exceeds current balance: %.2f", creditAccount.CurrentBalance)
-			}
-			creditAccount.CurrentBalance -= transaction.Amount
-
-			if creditAccount.IsBlocked && creditAccount.CurrentBalance <= 0 {
-				creditAccount.IsBlocked = false
-			}
+			payAccount(creditAccount, transaction.Amount)
 		default:
 			return errors.New("invalid transaction type")
 		}
@@ -104,17 +160,20 @@ func (r *transactionRepository) UpdateTransaction(transaction *entities.Transact
 		if err := tx.Save(transaction).Error; err != nil {
 			return fmt.Errorf("error updating transaction: %w", err)
 		}
-		if err := tx.Save(creditAccount).Error; err != nil {
+		if err := saveAccountBalance(tx, creditAccount, original.IsBlocked); err != nil {
 			return fmt.Errorf("error updating credit account balance: %w", err)
 		}
 
-		return nil
+		return enqueueTransactionEvent(tx, event.TransactionUpdated, transaction, creditAccount)
 	})
 }
 
 // DeleteTransaction deletes a transaction and adjusts the credit account balance in a transaction.
 func (r *transactionRepository) DeleteTransaction(transactionID uint, creditAccount *entities.CreditAccount) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	original := *creditAccount
+	return inTransaction(r.db, func(tx *gorm.DB) error {
+		*creditAccount = original
+
 		// Retrieve the transaction for deletion
 		var transaction entities.Transaction
 		if err := tx.First(&transaction, transactionID).Error; err != nil {
@@ -123,10 +182,13 @@ func (r *transactionRepository) DeleteTransaction(transactionID uint, creditAcco
 
 		// Reverse the effect of the transaction on the credit account balance
 		switch transaction.TransactionType {
-		case enums.Purchase:
-			creditAccount.CurrentBalance -= transaction.Amount
+		case enums.Purchase, enums.InterestCharge, enums.LateFeeCharge:
+			payAccount(creditAccount, transaction.Amount)
 		case enums.Payment:
-			creditAccount.CurrentBalance += transaction.Amount
+			chargeAccount(creditAccount, transaction.Amount)
+			if err := releasePaymentAllocations(tx, &transaction); err != nil {
+				return fmt.Errorf("error releasing payment allocations: %w", err)
+			}
 		default:
 			return errors.New("invalid transaction type")
 		}
@@ -137,12 +199,133 @@ func (r *transactionRepository) DeleteTransaction(transactionID uint, creditAcco
 		}
 
 		// Save the updated credit account balance
-		if err := tx.Save(creditAccount).Error; err != nil {
+		if err := saveAccountBalance(tx, creditAccount, original.IsBlocked); err != nil {
 			return fmt.Errorf("error updating credit account balance: %w", err)
 		}
 
+		if err := recordTransactionActivity(tx, &transaction, creditAccount, true); err != nil {
+			return err
+		}
+		return enqueueTransactionEvent(tx, event.TransactionDeleted, &transaction, creditAccount)
+	})
+}
+
+// ConfirmPendingPayment confirms a pending non-cash payment with the code sent to the client. Codes
+// are used once: confirmed payments aren't pending anymore. A wrong code fails with
+// ErrInvalidPaymentCode and counts as an attempt; the payment fails at the maxAttempts-th, with
+// ErrPaymentCodeAttempts, or when tried after it expired, with ErrPaymentCodeExpired. The payment
+// is returned along with those errors.
+func (r *transactionRepository) ConfirmPendingPayment(transactionID uint, code string, maxAttempts int, now time.Time) (*entities.Transaction, error) {
+	var transaction entities.Transaction
+	var outcome error
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		outcome = nil
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, transactionID).Error; err != nil {
+			return err
+		}
+		if transaction.PaymentStatus != enums.PENDING || transaction.PaymentMethod == enums.CASH || transaction.PaymentCode == "" {
+			outcome = ErrPaymentNotPending
+			return nil
+		}
+
+		switch {
+		case transaction.PaymentCodeExpiresAt != nil && !now.Before(*transaction.PaymentCodeExpiresAt):
+			outcome = ErrPaymentCodeExpired
+			return failPendingPayment(tx, &transaction)
+		case subtle.ConstantTimeCompare([]byte(transaction.PaymentCode), []byte(code)) != 1:
+			transaction.PaymentCodeAttempts++
+			if transaction.PaymentCodeAttempts >= maxAttempts {
+				outcome = ErrPaymentCodeAttempts
+				return failPendingPayment(tx, &transaction)
+			}
+			outcome = ErrInvalidPaymentCode
+			return tx.Model(&transaction).Update("payment_code_attempts", transaction.PaymentCodeAttempts).Error
+		}
+
+		transaction.PaymentStatus = enums.SUCCESS
+		transaction.ConfirmationCode = code
+		err := tx.Model(&transaction).Updates(map[string]interface{}{"payment_status": enums.SUCCESS, "confirmation_code": code}).Error
+		if err != nil {
+			return err
+		}
+		var creditAccount entities.CreditAccount
+		if err := tx.First(&creditAccount, transaction.CreditAccountID).Error; err != nil {
+			return err
+		}
+		return enqueueTransactionEvent(tx, event.PaymentConfirmed, &transaction, &creditAccount)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, outcome
+}
+
+// ExpirePendingPayments fails the pending payments whose code expired unconfirmed, and returns them.
+// Payments being confirmed meanwhile are left for the next run.
+func (r *transactionRepository) ExpirePendingPayments(now time.Time) ([]entities.Transaction, error) {
+	var expired []entities.Transaction
+	err := inTransaction(r.db, func(tx *gorm.DB) error {
+		expired = nil
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("payment_status = ? AND payment_code_expires_at <= ?", enums.PENDING, now).
+			Order("id").Find(&expired).Error
+		if err != nil {
+			return err
+		}
+		for i := range expired {
+			if err := failPendingPayment(tx, &expired[i]); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// GetPendingPaymentsByCode retrieves the pending payments of every establishment whose confirmation
+// code is code and hasn't expired at now. Codes are short, so more than one may share it.
+func (r *transactionRepository) GetPendingPaymentsByCode(code string, now time.Time) ([]entities.Transaction, error) {
+	var transactions []entities.Transaction
+	err := r.db.Where("payment_status = ? AND payment_code = ? AND (payment_code_expires_at IS NULL OR payment_code_expires_at > ?)", enums.PENDING, code, now).
+		Order("id").Find(&transactions).Error
+	return transactions, err
+}
+
+// failPendingPayment fails a pending transaction in tx, reversing what it did to the balance of its
+// credit account when it was made.
+func failPendingPayment(tx *gorm.DB, transaction *entities.Transaction) error {
+	var creditAccount entities.CreditAccount
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&creditAccount, transaction.CreditAccountID).Error; err != nil {
+		return err
+	}
+	wasBlocked := creditAccount.IsBlocked
+	switch transaction.TransactionType {
+	case enums.Purchase:
+		payAccount(&creditAccount, transaction.Amount)
+	case enums.Payment:
+		chargeAccount(&creditAccount, transaction.Amount)
+		if err := releasePaymentAllocations(tx, transaction); err != nil {
+			return fmt.Errorf("error releasing payment allocations: %w", err)
+		}
+	default:
+		return errors.New("invalid transaction type")
+	}
+
+	transaction.PaymentStatus = enums.FAILED
+	err := tx.Model(transaction).Updates(map[string]interface{}{"payment_status": enums.FAILED, "payment_code_attempts": transaction.PaymentCodeAttempts}).Error
+	if err != nil {
+		return fmt.Errorf("error failing payment: %w", err)
+	}
+	if err := saveAccountBalance(tx, &creditAccount, wasBlocked); err != nil {
+		return fmt.Errorf("error updating credit account balance: %w", err)
+	}
+	if err := recordTransactionActivity(tx, transaction, &creditAccount, true); err != nil {
+		return err
+	}
+	return enqueueTransactionEvent(tx, event.PaymentFailed, transaction, &creditAccount)
 }
 
 // GetTransactionByID retrieves a transaction by its ID.
@@ -165,6 +348,26 @@ func (r *transactionRepository) GetTransactionsByCreditAccountID(creditAccountID
 	return transactions, nil
 }
 
+// GetTransactionsByCreditAccountIDPaged retrieves one page of transactions for a credit account, newest first.
+func (r *transactionRepository) GetTransactionsByCreditAccountIDPaged(creditAccountID uint, offset, limit int) ([]entities.Transaction, error) {
+	var transactions []entities.Transaction
+	err := r.db.Where("credit_account_id = ?", creditAccountID).
+		Order("transaction_date DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// GetTransactionsByCreditAccountIDs retrieves the transactions of several credit accounts in one query, newest first.
+func (r *transactionRepository) GetTransactionsByCreditAccountIDs(creditAccountIDs []uint) ([]entities.Transaction, error) {
+	var transactions []entities.Transaction
+	err := r.db.Where("credit_account_id IN ?", creditAccountIDs).
+		Order("transaction_date DESC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
 func (r *transactionRepository) CreateTransactionInTx(tx *gorm.DB, transaction *entities.Transaction) error {
 	return tx.Create(transaction).Error
 }
@@ -180,7 +383,8 @@ func (r *transactionRepository) DeleteTransactionInTx(tx *gorm.DB, transactionID
 // GetTransactionsByCreditAccountIDAndDateRange retrieves transactions for a credit account within a given date range.
 func (r *transactionRepository) GetTransactionsByCreditAccountIDAndDateRange(creditAccountID uint, startDate, endDate time.Time) ([]entities.Transaction, error) {
 	var transactions []entities.Transaction
-	db := r.db.Where("credit_account_id = ?", creditAccountID)
+	// A closed period reads the same on a replica that replayed past it
+	db := database.ReadReplicaAsOf(r.db, endDate).Where("credit_account_id = ?", creditAccountID)
 
 	if !startDate.IsZero() {
 		db = db.Where("transaction_date >= ?", startDate)
@@ -196,8 +400,9 @@ func (r *transactionRepository) GetTransactionsByCreditAccountIDAndDateRange(cre
 // GetBalanceBeforeDate retrieves the balance of a credit account before a specified date.
 func (r *transactionRepository) GetBalanceBeforeDate(creditAccountID uint, beforeDate time.Time) (float64, error) {
 	var balance float64
-	err := r.db.Model(&entities.Transaction{}).
-		Select("SUM(CASE WHEN transaction_type = ? THEN -amount ELSE amount END) as balance", enums.Payment).
+	err := database.ReadReplicaAsOf(r.db, beforeDate).Model(&entities.Transaction{}).
+		Select("SUM(CASE WHEN transaction_type IN ? THEN -amount ELSE amount END) as balance",
+			[]enums.TransactionType{enums.Payment, enums.AdjustmentCredit}).
 		Where("credit_account_id = ? AND transaction_date < ?", creditAccountID, beforeDate).
 		Scan(&balance).Error
 
@@ -207,3 +412,105 @@ func (r *transactionRepository) GetBalanceBeforeDate(creditAccountID uint, befor
 
 	return balance, nil
 }
+
+// GetTransactionTotals sums the transactions of a credit account made from startDate up to, but
+// not including, endDate. A zero endDate includes every transaction from startDate on.
+func (r *transactionRepository) GetTransactionTotals(creditAccountID uint, startDate, endDate time.Time) (TransactionTotals, error) {
+	var totals TransactionTotals
+	db := database.ReadReplicaAsOf(r.db, endDate).Model(&entities.Transaction{}).
+		Select(`COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount END), 0) AS purchases,
+			COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount END), 0) AS payments,
+			COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount END), 0) AS interest,
+			COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount END), 0) AS late_fees,
+			COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount WHEN transaction_type = ? THEN -amount END), 0) AS adjustments,
+			COUNT(CASE WHEN transaction_type = ? THEN 1 END) AS purchase_count,
+			COUNT(CASE WHEN transaction_type = ? THEN 1 END) AS payment_count`,
+			enums.Purchase, enums.Payment, enums.InterestCharge, enums.LateFeeCharge, enums.AdjustmentDebit, enums.AdjustmentCredit,
+			enums.Purchase, enums.Payment).
+		Where("credit_account_id = ? AND transaction_date >= ?", creditAccountID, startDate)
+	if !endDate.IsZero() {
+		db = db.Where("transaction_date < ?", endDate)
+	}
+	if err := db.Scan(&totals).Error; err != nil {
+		return TransactionTotals{}, fmt.Errorf("error getting transaction totals: %w", err)
+	}
+	return totals, nil
+}
+
+// GetTransactionsByEstablishmentID retrieves the transactions of every credit account of an establishment
+// made between startDate and endDate, ordered by date. It is meant for reports, which may read from a lagging replica.
+func (r *transactionRepository) GetTransactionsByEstablishmentID(establishmentID uint, startDate, endDate time.Time) ([]entities.Transaction, error) {
+	var transactions []entities.Transaction
+	err := database.ReadReplica(r.db).Joins("JOIN credit_accounts ON credit_accounts.id = transactions.credit_account_id").
+		Where("credit_accounts.establishment_id = ? AND transactions.transaction_date BETWEEN ? AND ?", establishmentID, startDate, endDate).
+		Order("transactions.transaction_date").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// SearchTransactions retrieves one page of the transactions of an establishment that match search,
+// with their credit account and client, along with the number of matches across all pages.
+func (r *transactionRepository) SearchTransactions(search TransactionSearch) ([]entities.Transaction, int64, error) {
+	query := database.ReadReplica(r.db).Model(&entities.Transaction{}).
+		Joins("JOIN credit_accounts ON credit_accounts.id = transactions.credit_account_id").
+		Where("credit_accounts.establishment_id = ?", search.EstablishmentID)
+
+	if search.Client != "" {
+		query = query.Joins("JOIN users ON users.id = credit_accounts.client_id").
+			Where("users.dni LIKE ? OR users.name ILIKE ?", escapeLike(search.Client)+"%", "%"+escapeLike(search.Client)+"%")
+	}
+	if search.TransactionType != "" {
+		query = query.Where("transactions.transaction_type = ?", search.TransactionType)
+	}
+	if search.PaymentMethod != "" {
+		query = query.Where("transactions.payment_method = ?", search.PaymentMethod)
+	}
+	if search.PaymentStatus != "" {
+		query = query.Where("transactions.payment_status = ?", search.PaymentStatus)
+	}
+	if search.MinAmount > 0 {
+		query = query.Where("transactions.amount >= ?", search.MinAmount)
+	}
+	if search.MaxAmount > 0 {
+		query = query.Where("transactions.amount <= ?", search.MaxAmount)
+	}
+	if !search.StartDate.IsZero() {
+		query = query.Where("transactions.transaction_date >= ?", search.StartDate)
+	}
+	if !search.EndDate.IsZero() {
+		query = query.Where("transactions.transaction_date < ?", search.EndDate)
+	}
+	if search.Text != "" {
+		// Matches the expression of idx_transactions_description_fts so the index can be used
+		query = query.Where("to_tsvector('simple', coalesce(transactions.description, '')) @@ plainto_tsquery('simple', ?)", search.Text)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	column := "transactions.transaction_date"
+	if search.SortBy == "amount" {
+		column = "transactions.amount"
+	}
+	direction := " ASC"
+	if search.Descending {
+		direction = " DESC"
+	}
+
+	var transactions []entities.Transaction
+	err := query.Preload("CreditAccount.Client").
+		Order(column + direction).
+		Order("transactions.id" + direction).
+		Offset(search.Offset).
+		Limit(search.Limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return transactions, total, nil
+}
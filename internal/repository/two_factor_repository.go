@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TwoFactorRepository defines operations for managing the two-factor authentication of users.
+type TwoFactorRepository interface {
+	SetTwoFactorSecret(userID uint, secret string) error
+	EnableTwoFactor(userID uint, step int64, recoveryCodeHashes []string) error
+	DisableTwoFactor(userID uint) error
+	AdvanceTwoFactorStep(userID uint, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID uint, recoveryCodeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string, now time.Time) (bool, error)
+	StartTwoFactorAttempt(userID uint, maxAttempts int, now, lockUntil time.Time) (bool, error)
+	ClearTwoFactorAttempts(userID uint) error
+	AdminRequiresTwoFactor(adminID uint) (bool, error)
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+// NewTwoFactorRepository creates a new TwoFactorRepository instance.
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+// SetTwoFactorSecret stores the secret a user is setting up. It is not used until EnableTwoFactor.
+func (r *twoFactorRepository) SetTwoFactorSecret(userID uint, secret string) error {
+	return r.db.Model(&entities.User{}).Where("id = ? AND NOT two_factor_enabled", userID).
+		Update("two_factor_secret", secret).Error
+}
+
+// EnableTwoFactor turns on two-factor authentication for a user with the secret set up, replacing
+// their recovery codes. step is the period of the code that confirmed the setup.
+func (r *twoFactorRepository) EnableTwoFactor(userID uint, step int64, recoveryCodeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_enabled":   true,
+			"two_factor_last_step": step,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+// DisableTwoFactor turns off two-factor authentication for a user and forgets their secret and recovery codes.
+func (r *twoFactorRepository) DisableTwoFactor(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_enabled":         false,
+			"two_factor_secret":          "",
+			"two_factor_last_step":       0,
+			"two_factor_failed_attempts": 0,
+			"two_factor_locked_until":    nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entities.TwoFactorRecoveryCode{}).Error
+	})
+}
+
+// AdvanceTwoFactorStep records step as the last TOTP period accepted for a user. It reports false,
+// without changes, if a code of that period or a later one was already accepted.
+func (r *twoFactorRepository) AdvanceTwoFactorStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&entities.User{}).Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReplaceRecoveryCodes deletes the recovery codes of a user and stores new ones.
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID uint, recoveryCodeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, recoveryCodeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&entities.TwoFactorRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	codes := make([]entities.TwoFactorRecoveryCode, len(recoveryCodeHashes))
+	for i, hash := range recoveryCodeHashes {
+		codes[i] = entities.TwoFactorRecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
+
+// UseRecoveryCode marks an unused recovery code of a user as used. It reports false if the user has
+// no such code or it was already used.
+func (r *twoFactorRepository) UseRecoveryCode(userID uint, codeHash string, now time.Time) (bool, error) {
+	result := r.db.Model(&entities.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+// StartTwoFactorAttempt counts a two-factor code a user entered, before it is checked, so that
+// concurrent guesses are counted too. The one that reaches maxAttempts locks their second factor
+// until lockUntil and starts the count again. It reports false, without counting, while it is locked.
+func (r *twoFactorRepository) StartTwoFactorAttempt(userID uint, maxAttempts int, now, lockUntil time.Time) (bool, error) {
+	result := r.db.Model(&entities.User{}).
+		Where("id = ? AND (two_factor_locked_until IS NULL OR two_factor_locked_until <= ?)", userID, now).
+		Updates(map[string]interface{}{
+			"two_factor_locked_until":    gorm.Expr("CASE WHEN two_factor_failed_attempts + 1 >= ? THEN ? ELSE two_factor_locked_until END", maxAttempts, lockUntil),
+			"two_factor_failed_attempts": gorm.Expr("CASE WHEN two_factor_failed_attempts + 1 >= ? THEN 0 ELSE two_factor_failed_attempts + 1 END", maxAttempts),
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ClearTwoFactorAttempts forgets the two-factor codes a user entered up to a right one, lifting the
+// lockout the right one may have reached.
+func (r *twoFactorRepository) ClearTwoFactorAttempts(userID uint) error {
+	return r.db.Model(&entities.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"two_factor_failed_attempts": 0,
+		"two_factor_locked_until":    nil,
+	}).Error
+}
+
+// AdminRequiresTwoFactor reports whether any establishment of an admin, main or branch, requires its
+// admin to use two-factor authentication.
+func (r *twoFactorRepository) AdminRequiresTwoFactor(adminID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.EstablishmentSettings{}).
+		Joins("JOIN establishments ON establishments.id = establishment_settings.establishment_id AND establishments.deleted_at IS NULL").
+		Where("establishments.admin_id = ? AND establishment_settings.require_admin_two_factor", adminID).
+		Count(&count).Error
+	return count > 0, err
+}
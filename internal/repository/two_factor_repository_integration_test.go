@@ -0,0 +1,107 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/testutil/dbtest"
+	"ApiRestFinance/internal/testutil/fixture"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	testMaxTwoFactorAttempts = 5
+	testTwoFactorLockout     = 15 * time.Minute
+)
+
+func reloadUser(t *testing.T, db *gorm.DB, id uint) *entities.User {
+	t.Helper()
+	var user entities.User
+	if err := db.First(&user, id).Error; err != nil {
+		t.Fatalf("error reloading user: %v", err)
+	}
+	return &user
+}
+
+func TestTwoFactorRepositoryStartTwoFactorAttemptLocksAfterTheLastAllowed(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.Create(t, db, fixture.Client().TwoFactor("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ").Build())
+	repo := repository.NewTwoFactorRepository(db)
+	lockUntil := fixture.Now.Add(testTwoFactorLockout)
+
+	for i := 1; i <= testMaxTwoFactorAttempts; i++ {
+		started, err := repo.StartTwoFactorAttempt(fixture.ClientID, testMaxTwoFactorAttempts, fixture.Now, lockUntil)
+		if err != nil || !started {
+			t.Fatalf("attempt %d: StartTwoFactorAttempt = %t, %v, want true", i, started, err)
+		}
+	}
+	user := reloadUser(t, db, fixture.ClientID)
+	if user.TwoFactorLockedUntil == nil || !user.TwoFactorLockedUntil.Equal(lockUntil) || user.TwoFactorFailedAttempts != 0 {
+		t.Fatalf("after %d attempts: locked until %v with %d attempts, want %v with 0", testMaxTwoFactorAttempts, user.TwoFactorLockedUntil, user.TwoFactorFailedAttempts, lockUntil)
+	}
+
+	started, err := repo.StartTwoFactorAttempt(fixture.ClientID, testMaxTwoFactorAttempts, lockUntil.Add(-time.Second), lockUntil.Add(testTwoFactorLockout))
+	if err != nil || started {
+		t.Errorf("attempt while locked: StartTwoFactorAttempt = %t, %v, want false", started, err)
+	}
+	if user := reloadUser(t, db, fixture.ClientID); user.TwoFactorFailedAttempts != 0 || !user.TwoFactorLockedUntil.Equal(lockUntil) {
+		t.Errorf("attempt while locked was counted: %d attempts, locked until %v", user.TwoFactorFailedAttempts, user.TwoFactorLockedUntil)
+	}
+
+	started, err = repo.StartTwoFactorAttempt(fixture.ClientID, testMaxTwoFactorAttempts, lockUntil, lockUntil.Add(testTwoFactorLockout))
+	if err != nil || !started {
+		t.Errorf("attempt once the lockout is over: StartTwoFactorAttempt = %t, %v, want true", started, err)
+	}
+}
+
+func TestTwoFactorRepositoryClearTwoFactorAttempts(t *testing.T) {
+	db := dbtest.Open(t)
+	lockedUntil := fixture.Now.Add(testTwoFactorLockout)
+	dbtest.Create(t, db, fixture.Client().TwoFactor("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ").With(func(u *entities.User) {
+		u.TwoFactorFailedAttempts = 3
+		u.TwoFactorLockedUntil = &lockedUntil
+	}).Build())
+	repo := repository.NewTwoFactorRepository(db)
+
+	if err := repo.ClearTwoFactorAttempts(fixture.ClientID); err != nil {
+		t.Fatalf("ClearTwoFactorAttempts returned %v", err)
+	}
+	if user := reloadUser(t, db, fixture.ClientID); user.TwoFactorFailedAttempts != 0 || user.TwoFactorLockedUntil != nil {
+		t.Errorf("%d attempts, locked until %v, want none", user.TwoFactorFailedAttempts, user.TwoFactorLockedUntil)
+	}
+}
+
+func TestTwoFactorRepositoryStartTwoFactorAttemptConcurrently(t *testing.T) {
+	db := dbtest.Shared(t)
+	dbtest.Create(t, db, fixture.Client().TwoFactor("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ").Build())
+	repo := repository.NewTwoFactorRepository(db)
+
+	const guesses = 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	started := 0
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.StartTwoFactorAttempt(fixture.ClientID, testMaxTwoFactorAttempts, fixture.Now, fixture.Now.Add(testTwoFactorLockout))
+			if err != nil {
+				t.Errorf("StartTwoFactorAttempt returned %v", err)
+			}
+			if ok {
+				mu.Lock()
+				started++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if started != testMaxTwoFactorAttempts {
+		t.Errorf("%d of %d concurrent guesses were let through, want %d", started, guesses, testMaxTwoFactorAttempts)
+	}
+}
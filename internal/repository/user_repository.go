@@ -20,7 +20,7 @@ type UserRepository interface {
 	DeleteClientInTransaction(tx *gorm.DB, clientID uint) error
 	GetClientByID(clientID uint) (*entities.Client, error)
 	UpdateClient(client *entities.Client) error
-	GetClientsByEstablishmentID(establishmentID uint) ([]entities.User, error)
+	GetClientsByEstablishmentID(establishmentID uint, tags []string) ([]entities.User, error)
 	UpdatePassword(userID uint, newPassword string) error
 	GetUserIDByEmail(email string) (uint, error)
 }
@@ -36,7 +36,7 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 
 // CreateUser creates a new user in the database.
 func (r *userRepository) CreateUser(user *entities.User) error {
-	return r.db.Create(user).Error
+	return uniqueError(r.db.Create(user).Error)
 }
 
 // GetUserIDByEmail implements the same method from the UserRepository interface.
@@ -68,7 +68,7 @@ func (r *userRepository) GetUserByID(userID uint) (*entities.User, error) {
 
 // UpdateUser updates an existing user in the database.
 func (r *userRepository) UpdateUser(user *entities.User) error {
-	return r.db.Save(user).Error
+	return uniqueError(r.db.Save(user).Error)
 }
 
 // DeleteUser deletes a user from the database.
@@ -77,7 +77,7 @@ func (r *userRepository) DeleteUser(userID uint) error {
 }
 
 func (r *userRepository) CreateUserInTransaction(tx *gorm.DB, user *entities.User) error {
-	return tx.Create(user).Error
+	return uniqueError(tx.Create(user).Error)
 }
 
 // CreateClientInTransaction creates a new client within a database transaction.
@@ -105,11 +105,13 @@ func (r *userRepository) UpdateClient(client *entities.Client) error {
 	return r.db.Save(client).Error
 }
 
-// GetClientsByEstablishmentID retrieves users with the CLIENT role associated with a given establishment ID.
-func (r *userRepository) GetClientsByEstablishmentID(establishmentID uint) ([]entities.User, error) {
+// GetClientsByEstablishmentID retrieves users with the CLIENT role associated with a given establishment ID,
+// only those with every one of tags in the establishment when there are any.
+func (r *userRepository) GetClientsByEstablishmentID(establishmentID uint, tags []string) ([]entities.User, error) {
 	var clients []entities.User
-	err := r.db.Joins("JOIN credit_accounts ON credit_accounts.client_id = users.id").
-		Where("credit_accounts.establishment_id = ? AND users.rol = ?", establishmentID, enums.CLIENT).
+	query := r.db.Joins("JOIN credit_accounts ON credit_accounts.client_id = users.id").
+		Where("credit_accounts.establishment_id = ? AND users.rol = ?", establishmentID, enums.CLIENT)
+	err := withClientTags(query, "users.id", "credit_accounts.establishment_id", tags).
 		Find(&clients).Error
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving clients: %w", err)
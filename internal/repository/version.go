@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict is returned when a record changed since it was read, so saving it would
+// overwrite someone else's change.
+var ErrVersionConflict = errors.New("the record was changed by someone else since it was read, reload it and try again")
+
+// saveVersion saves model like tx.Save, but only if its row still is at *version, the version it was
+// read at, and moves it to the next version. It fails with ErrVersionConflict when the row is at
+// another version by now, leaving *version as it was, and with the error of the field when a unique
+// index rejects the change (see uniqueError). Associations aren't saved.
+func saveVersion(tx *gorm.DB, model interface{}, version *uint) error {
+	read := *version
+	*version = read + 1
+	result := tx.Model(model).Where("version = ?", read).Select("*").Omit(clause.Associations).Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = read
+	}
+	return uniqueError(result.Error)
+}
@@ -0,0 +1,52 @@
+// Package scoring derives the internal credit score of an account from its repayment history.
+// Scores range from MinScore to MaxScore, higher meaning more creditworthy.
+package scoring
+
+import "math"
+
+const (
+	MinScore = 0
+	MaxScore = 1000
+)
+
+// Weights of each factor, adding up to MaxScore.
+const (
+	punctualityPoints = 500
+	utilizationPoints = 300
+	overduePoints     = 200
+)
+
+const (
+	// neutralPunctuality is assumed for accounts without installments due yet.
+	neutralPunctuality = 0.8
+	// Overdue points lost per unpaid installment past due, per day the oldest one is overdue and per
+	// promise to pay broken.
+	pointsPerOverdueInstallment = 50
+	pointsPerDayOverdue         = 2
+	pointsPerBrokenPromise      = 40
+)
+
+// History is what a score is derived from.
+type History struct {
+	InstallmentsDue     int     // Installments whose due date has passed
+	InstallmentsOnTime  int     // Installments due that were paid by their due date
+	OverdueInstallments int     // Installments past due that are still unpaid
+	DaysOverdue         int     // Days the oldest unpaid amount is overdue
+	Utilization         float64 // Balance owed over the credit limit
+	BrokenPromises      int     // Promises to pay that weren't kept
+}
+
+// Score returns the credit score of a history: half punctuality, 30% utilization of the credit
+// limit and 20% current overdue amounts and broken promises to pay.
+func Score(history History) int {
+	punctuality := neutralPunctuality
+	if history.InstallmentsDue > 0 {
+		punctuality = float64(history.InstallmentsOnTime) / float64(history.InstallmentsDue)
+	}
+	utilization := math.Min(math.Max(history.Utilization, 0), 1)
+	overdue := overduePoints - pointsPerOverdueInstallment*history.OverdueInstallments - pointsPerDayOverdue*history.DaysOverdue -
+		pointsPerBrokenPromise*history.BrokenPromises
+
+	score := punctualityPoints*punctuality + utilizationPoints*(1-utilization) + float64(max(overdue, 0))
+	return min(max(int(math.Round(score)), MinScore), MaxScore)
+}
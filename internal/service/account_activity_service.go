@@ -0,0 +1,91 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"fmt"
+)
+
+// activityPresentation is how the app shows the entries of an activity type.
+type activityPresentation struct {
+	icon  string
+	title string
+}
+
+var activityPresentations = map[enums.ActivityType]activityPresentation{
+	enums.ActivityPurchase:         {icon: "shopping-cart", title: "Purchase"},
+	enums.ActivityPayment:          {icon: "payment", title: "Payment"},
+	enums.ActivityInterestCharge:   {icon: "percent", title: "Interest charged"},
+	enums.ActivityLateFee:          {icon: "alert", title: "Late fee"},
+	enums.ActivityLimitChange:      {icon: "credit-limit", title: "Credit limit changed"},
+	enums.ActivityAccountBlocked:   {icon: "lock", title: "Account blocked"},
+	enums.ActivityAccountUnblocked: {icon: "unlock", title: "Account unblocked"},
+	enums.ActivityReversal:         {icon: "undo", title: "Reversal"},
+	enums.ActivityWriteOff:         {icon: "write-off", title: "Balance written off"},
+	enums.ActivityAccountClosed:    {icon: "archive", title: "Account closed"},
+	enums.ActivityAccountReopened:  {icon: "refresh", title: "Account reopened"},
+	enums.ActivityReschedule:       {icon: "calendar", title: "Installment rescheduled"},
+	enums.ActivityAdjustment:       {icon: "edit", title: "Balance adjusted"},
+}
+
+// AccountActivityService builds the activity feed of credit accounts from their ledger.
+type AccountActivityService interface {
+	GetClientActivity(clientID, establishmentID uint, page, pageSize int) ([]response.ActivityResponse, int, error)
+}
+
+type accountActivityService struct {
+	activityRepo      repository.AccountActivityRepository
+	creditAccountRepo repository.CreditAccountRepository
+}
+
+// NewAccountActivityService creates a new instance of AccountActivityService.
+func NewAccountActivityService(activityRepo repository.AccountActivityRepository, creditAccountRepo repository.CreditAccountRepository) AccountActivityService {
+	return &accountActivityService{activityRepo: activityRepo, creditAccountRepo: creditAccountRepo}
+}
+
+// GetClientActivity retrieves a page of the activity of a client's credit account, newest first, and
+// the number of entries across all pages.
+func (s *accountActivityService) GetClientActivity(clientID, establishmentID uint, page, pageSize int) ([]response.ActivityResponse, int, error) {
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	activities, total, err := s.activityRepo.GetActivitiesByCreditAccountID(creditAccount.ID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error retrieving account activity: %w", err)
+	}
+
+	activityResponses := make([]response.ActivityResponse, len(activities))
+	for i := range activities {
+		activityResponses[i] = activityToResponse(&activities[i])
+	}
+	return activityResponses, int(total), nil
+}
+
+func activityToResponse(activity *entities.AccountActivity) response.ActivityResponse {
+	presentation := activityPresentations[activity.Type]
+	direction := "none"
+	switch {
+	case activity.Amount > 0:
+		direction = "debit"
+	case activity.Amount < 0:
+		direction = "credit"
+	}
+	return response.ActivityResponse{
+		ID:              activity.ID,
+		CreditAccountID: activity.CreditAccountID,
+		Type:            activity.Type,
+		Icon:            presentation.icon,
+		Title:           presentation.title,
+		Description:     activity.Description,
+		Amount:          activity.Amount,
+		Direction:       direction,
+		Balance:         activity.Balance,
+		CreditLimit:     activity.CreditLimit,
+		TransactionID:   activity.TransactionID,
+		OccurredAt:      activity.OccurredAt,
+	}
+}
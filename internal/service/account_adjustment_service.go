@@ -0,0 +1,224 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// AccountAdjustmentService lets admins correct the balance of credit accounts with manual debits and
+// credits. Adjustments above the adjustment threshold of the establishment wait for the approval of
+// the approver it designated, a second admin.
+type AccountAdjustmentService interface {
+	CreateAdjustment(adminID, creditAccountID uint, req request.CreateAccountAdjustmentRequest) (*response.AccountAdjustmentResponse, error)
+	GetAdjustments(adminID, creditAccountID uint) ([]response.AccountAdjustmentResponse, error)
+	GetPendingAdjustments(approverID uint) ([]response.AccountAdjustmentResponse, error)
+	ApproveAdjustment(approverID, adjustmentID uint) (*response.AccountAdjustmentResponse, error)
+	RejectAdjustment(approverID, adjustmentID uint, reason string) (*response.AccountAdjustmentResponse, error)
+}
+
+type accountAdjustmentService struct {
+	adjustmentRepo    repository.AccountAdjustmentRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	clock             util.Clock
+	bus               event.Bus
+}
+
+// NewAccountAdjustmentService creates a new instance of AccountAdjustmentService.
+func NewAccountAdjustmentService(adjustmentRepo repository.AccountAdjustmentRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository, clock util.Clock, bus event.Bus) AccountAdjustmentService {
+	return &accountAdjustmentService{
+		adjustmentRepo:    adjustmentRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		settingsRepo:      settingsRepo,
+		clock:             clock,
+		bus:               bus,
+	}
+}
+
+// CreateAdjustment adjusts the balance of a credit account in one of the admin's establishments. It
+// is applied right away unless its amount is above the adjustment threshold of the establishment, when
+// it waits for the approver's approval instead.
+func (s *accountAdjustmentService) CreateAdjustment(adminID, creditAccountID uint, req request.CreateAccountAdjustmentRequest) (*response.AccountAdjustmentResponse, error) {
+	creditAccount, err := s.findCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	now := s.clock.Now()
+	adjustment := entities.AccountAdjustment{
+		CreditAccountID: creditAccount.ID,
+		EstablishmentID: creditAccount.EstablishmentID,
+		Type:            req.Type,
+		Amount:          roundCurrency(req.Amount),
+		Reason:          strings.TrimSpace(req.Reason),
+		RequestedByID:   adminID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if settings.AdjustmentThreshold > 0 && adjustment.Amount > settings.AdjustmentThreshold {
+		if settings.AdjustmentApproverID == 0 {
+			return nil, ErrAdjustmentApproverRequired
+		}
+		adjustment.Status = enums.AdjustmentPending
+		if err := s.adjustmentRepo.CreateAccountAdjustment(&adjustment); err != nil {
+			return nil, fmt.Errorf("error creating adjustment: %w", err)
+		}
+		return accountAdjustmentToResponse(&adjustment), nil
+	}
+
+	if err := s.adjustmentRepo.ApplyAccountAdjustment(&adjustment); err != nil {
+		return nil, fmt.Errorf("error applying adjustment: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.TransactionCreated, creditAccount.ID)
+	return accountAdjustmentToResponse(&adjustment), nil
+}
+
+// GetAdjustments lists the adjustments of a credit account in one of the admin's establishments, newest first.
+func (s *accountAdjustmentService) GetAdjustments(adminID, creditAccountID uint) ([]response.AccountAdjustmentResponse, error) {
+	creditAccount, err := s.findCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	adjustments, err := s.adjustmentRepo.GetAccountAdjustmentsByCreditAccountID(creditAccount.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving adjustments: %w", err)
+	}
+	return accountAdjustmentsToResponse(adjustments), nil
+}
+
+// GetPendingAdjustments lists the adjustments waiting for the approval of an approver, oldest first.
+func (s *accountAdjustmentService) GetPendingAdjustments(approverID uint) ([]response.AccountAdjustmentResponse, error) {
+	settings, err := s.settingsRepo.GetEstablishmentSettingsByAdjustmentApprover(approverID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	establishmentIDs := make([]uint, 0, len(settings))
+	for _, setting := range settings {
+		establishmentIDs = append(establishmentIDs, setting.EstablishmentID)
+	}
+	adjustments, err := s.adjustmentRepo.GetPendingAccountAdjustments(establishmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving adjustments: %w", err)
+	}
+	return accountAdjustmentsToResponse(adjustments), nil
+}
+
+// ApproveAdjustment approves an adjustment waiting for the approver's approval, applying it to the
+// balance of its credit account.
+func (s *accountAdjustmentService) ApproveAdjustment(approverID, adjustmentID uint) (*response.AccountAdjustmentResponse, error) {
+	adjustment, err := s.findPendingAdjustment(approverID, adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	adjustment.DecidedByID, adjustment.DecidedAt, adjustment.UpdatedAt = &approverID, &now, now
+	if err := s.adjustmentRepo.ApplyAccountAdjustment(adjustment); err != nil {
+		if errors.Is(err, repository.ErrAdjustmentDecided) {
+			return nil, ErrAdjustmentDecided
+		}
+		return nil, fmt.Errorf("error applying adjustment: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.TransactionCreated, adjustment.CreditAccountID)
+	return accountAdjustmentToResponse(adjustment), nil
+}
+
+// RejectAdjustment rejects an adjustment waiting for the approver's approval, leaving the balance alone.
+func (s *accountAdjustmentService) RejectAdjustment(approverID, adjustmentID uint, reason string) (*response.AccountAdjustmentResponse, error) {
+	adjustment, err := s.findPendingAdjustment(approverID, adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	adjustment.Status, adjustment.DecidedByID, adjustment.DecidedAt = enums.AdjustmentRejected, &approverID, &now
+	adjustment.RejectionReason, adjustment.UpdatedAt = strings.TrimSpace(reason), now
+	rejected, err := s.adjustmentRepo.RejectAccountAdjustment(adjustment)
+	if err != nil {
+		return nil, fmt.Errorf("error rejecting adjustment: %w", err)
+	}
+	if !rejected {
+		return nil, ErrAdjustmentDecided
+	}
+	return accountAdjustmentToResponse(adjustment), nil
+}
+
+// findCreditAccount retrieves a credit account of one of the admin's establishments.
+func (s *accountAdjustmentService) findCreditAccount(adminID, creditAccountID uint) (*entities.CreditAccount, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
+	return creditAccount, nil
+}
+
+// findPendingAdjustment retrieves an adjustment waiting for approval in an establishment the user
+// approves the adjustments of. Nobody approves their own adjustments.
+func (s *accountAdjustmentService) findPendingAdjustment(approverID, adjustmentID uint) (*entities.AccountAdjustment, error) {
+	adjustment, err := s.adjustmentRepo.GetAccountAdjustmentByID(adjustmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAccountAdjustmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving adjustment: %w", err)
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(adjustment.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	if settings.AdjustmentApproverID != approverID || adjustment.RequestedByID == approverID {
+		return nil, ErrAccountAdjustmentNotFound
+	}
+	if adjustment.Status != enums.AdjustmentPending {
+		return nil, ErrAdjustmentDecided
+	}
+	return adjustment, nil
+}
+
+func accountAdjustmentsToResponse(adjustments []entities.AccountAdjustment) []response.AccountAdjustmentResponse {
+	responses := make([]response.AccountAdjustmentResponse, 0, len(adjustments))
+	for i := range adjustments {
+		responses = append(responses, *accountAdjustmentToResponse(&adjustments[i]))
+	}
+	return responses
+}
+
+func accountAdjustmentToResponse(adjustment *entities.AccountAdjustment) *response.AccountAdjustmentResponse {
+	return &response.AccountAdjustmentResponse{
+		ID:              adjustment.ID,
+		CreditAccountID: adjustment.CreditAccountID,
+		EstablishmentID: adjustment.EstablishmentID,
+		Type:            adjustment.Type,
+		Amount:          adjustment.Amount,
+		Reason:          adjustment.Reason,
+		Status:          adjustment.Status,
+		RequestedByID:   adjustment.RequestedByID,
+		DecidedByID:     adjustment.DecidedByID,
+		DecidedAt:       adjustment.DecidedAt,
+		RejectionReason: adjustment.RejectionReason,
+		TransactionID:   adjustment.TransactionID,
+		CreatedAt:       adjustment.CreatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"ApiRestFinance/internal/cache"
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"time"
+)
+
+// AccountSummaryCache keeps computed account summaries and statements per credit
+// account. Entries of an account are dropped whenever an event for it is published,
+// and expire after the TTL as a safety net.
+type AccountSummaryCache struct {
+	summaries  *cache.Cache[*response.AccountSummaryResponse]
+	statements *cache.Cache[*response.AccountStatementResponse]
+}
+
+// NewAccountSummaryCache creates an AccountSummaryCache that invalidates itself from bus.
+func NewAccountSummaryCache(bus event.Bus, ttl time.Duration) *AccountSummaryCache {
+	c := &AccountSummaryCache{
+		summaries:  cache.New[*response.AccountSummaryResponse](ttl),
+		statements: cache.New[*response.AccountStatementResponse](ttl),
+	}
+	bus.SubscribeAll(func(evt event.Event) {
+		c.Invalidate(evt.CreditAccountID)
+	})
+	return c
+}
+
+// Invalidate drops every cached summary and statement of a credit account.
+func (c *AccountSummaryCache) Invalidate(creditAccountID uint) {
+	prefix := accountCachePrefix(creditAccountID)
+	c.summaries.DeletePrefix(prefix)
+	c.statements.DeletePrefix(prefix)
+}
+
+// Stats returns the hit/miss metrics of the summary and statement caches.
+func (c *AccountSummaryCache) Stats() map[string]cache.Stats {
+	return map[string]cache.Stats{
+		"account_summary":   c.summaries.Stats(),
+		"account_statement": c.statements.Stats(),
+	}
+}
+
+func accountCachePrefix(creditAccountID uint) string {
+	return fmt.Sprintf("account:%d:", creditAccountID)
+}
+
+// summaryCacheKey keys a summary by the local day it was computed on, since the due date depends on it.
+func summaryCacheKey(creditAccountID uint, day time.Time) string {
+	return accountCachePrefix(creditAccountID) + day.Format("2006-01-02")
+}
+
+// statementCacheKey keys a statement by its period.
+func statementCacheKey(creditAccountID uint, startDate, endDate time.Time) string {
+	return accountCachePrefix(creditAccountID) + startDate.Format(time.RFC3339) + "/" + endDate.Format(time.RFC3339)
+}
+
+// publishAccountEvent publishes an event about a credit account on bus.
+func publishAccountEvent(bus event.Bus, clock util.Clock, name event.Name, creditAccountID uint) {
+	bus.Publish(event.Event{Name: name, CreditAccountID: creditAccountID, OccurredAt: clock.Now()})
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"ApiRestFinance/internal/invoicing"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AccountingService exports the movements of the credit accounts of an establishment as the journal
+// entries of its books, for its accountant to import, in the accounts the admin maps each accounting
+// event to.
+type AccountingService interface {
+	GetAccountingAccounts(adminID, branchID uint) (*response.AccountingAccountsResponse, error)
+	UpdateAccountingAccounts(adminID, branchID uint, req request.UpdateAccountingAccountsRequest) (*response.AccountingAccountsResponse, error)
+	ExportJournalCSV(adminID, branchID uint, period string) ([]byte, error)
+	ExportJournalPLE(adminID, branchID uint, period string) ([]byte, string, error)
+}
+
+type accountingService struct {
+	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	activityRepo      repository.AccountActivityRepository
+	clock             util.Clock
+}
+
+// NewAccountingService creates a new instance of AccountingService.
+func NewAccountingService(establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository, activityRepo repository.AccountActivityRepository, clock util.Clock) AccountingService {
+	return &accountingService{
+		establishmentRepo: establishmentRepo,
+		settingsRepo:      settingsRepo,
+		activityRepo:      activityRepo,
+		clock:             clock,
+	}
+}
+
+// GetAccountingAccounts returns the account each accounting event of the admin's establishment is
+// mapped to.
+func (s *accountingService) GetAccountingAccounts(adminID, branchID uint) (*response.AccountingAccountsResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	return accountingAccountsToResponse(settings), nil
+}
+
+// UpdateAccountingAccounts maps the accounting events of the request to their accounts. The other
+// events keep theirs.
+func (s *accountingService) UpdateAccountingAccounts(adminID, branchID uint, req request.UpdateAccountingAccountsRequest) (*response.AccountingAccountsResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	accounts := eventAccounts(settings)
+	for event, account := range req.Accounts {
+		*accounts[enums.AccountingEvent(event)] = strings.TrimSpace(account)
+	}
+	if err := s.settingsRepo.SaveEstablishmentSettings(settings); err != nil {
+		return nil, fmt.Errorf("error updating establishment settings: %w", err)
+	}
+	return accountingAccountsToResponse(settings), nil
+}
+
+// journal is the journal of the movements of an establishment over a period.
+type journal struct {
+	Establishment *entities.Establishment
+	StartDate     time.Time
+	Entries       []journalEntry
+}
+
+// journalEntry is the entry of a ledger movement: Amount is debited to one account and credited to
+// the other.
+type journalEntry struct {
+	Number          int
+	Date            time.Time
+	CreditAccountID uint
+	DocumentNumber  string
+	Description     string
+	DebitAccount    string
+	CreditAccount   string
+	Amount          float64
+}
+
+// ExportJournalCSV returns the journal entries of the admin's establishment over period as a CSV
+// file, with a debit and a credit line per entry.
+func (s *accountingService) ExportJournalCSV(adminID, branchID uint, period string) ([]byte, error) {
+	journal, err := s.loadJournal(adminID, branchID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"entry", "date", "document_number", "credit_account_id", "description", "account", "debit", "credit"})
+	for _, entry := range journal.Entries {
+		amount := strconv.FormatFloat(entry.Amount, 'f', 2, 64)
+		prefix := []string{
+			strconv.Itoa(entry.Number),
+			entry.Date.Format("2006-01-02"),
+			entry.DocumentNumber,
+			strconv.FormatUint(uint64(entry.CreditAccountID), 10),
+			entry.Description,
+		}
+		_ = writer.Write(append(prefix, entry.DebitAccount, amount, "0.00"))
+		_ = writer.Write(append(prefix, entry.CreditAccount, "0.00", amount))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("error writing CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportJournalPLE returns the journal entries of the admin's establishment over period in the layout
+// of the Libro Diario (format 5.1) of SUNAT's Programa de Libros Electrónicos, along with the name
+// SUNAT expects the file to have. Lines are in soles and fields are separated by pipes.
+func (s *accountingService) ExportJournalPLE(adminID, branchID uint, period string) ([]byte, string, error) {
+	journal, err := s.loadJournal(adminID, branchID, period)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range journal.Entries {
+		documentType, series, number := pleDocument(entry.DocumentNumber)
+		amount := strconv.FormatFloat(entry.Amount, 'f', 2, 64)
+		date := entry.Date.Format("02/01/2006")
+		for i, account := range []string{entry.DebitAccount, entry.CreditAccount} {
+			debit, credit := amount, "0.00"
+			if i == 1 {
+				debit, credit = credit, debit
+			}
+			fields := []string{
+				entry.Date.Format("200601") + "00",
+				strconv.Itoa(entry.Number),
+				fmt.Sprintf("M%d", i+1),
+				account,
+				"", "",
+				"PEN",
+				"", "",
+				documentType, series, number,
+				date, "", date,
+				pleText(entry.Description),
+				"",
+				debit, credit,
+				"",
+				"1",
+			}
+			buf.WriteString(strings.Join(fields, "|") + "|\r\n")
+		}
+	}
+
+	hasEntries := "0"
+	if len(journal.Entries) > 0 {
+		hasEntries = "1"
+	}
+	// LE, the RUC, the period (AAAAMM00), the book (050100), 00, an operating company, whether the
+	// book has entries, in soles and generated by the PLE
+	fileName := fmt.Sprintf("LE%s%s00050100001%s11.txt", journal.Establishment.RUC, journal.StartDate.Format("200601"), hasEntries)
+	return buf.Bytes(), fileName, nil
+}
+
+// loadJournal returns the journal entries of the movements of the credit accounts of the admin's
+// establishment over period, in the accounts its accounting events are mapped to. Each movement is
+// debited to the receivables account and credited to the account of its event when it adds to what
+// the client owes, and the other way round when it subtracts from it. It fails with
+// ErrAccountingEventsUnmapped while an event has no account.
+func (s *accountingService) loadJournal(adminID, branchID uint, period string) (*journal, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	if period == "" {
+		period = DefaultReportPeriod
+	}
+	loc := establishmentLocation(establishment)
+	startDate, endDate, err := reportPeriodRange(period, s.clock.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	if unmapped := unmappedEvents(settings); len(unmapped) > 0 {
+		return nil, fmt.Errorf("no account for %s: %w", strings.Join(unmapped, ", "), ErrAccountingEventsUnmapped)
+	}
+	movements, err := s.activityRepo.GetEstablishmentMovements(establishment.ID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account movements: %w", err)
+	}
+
+	accounts := eventAccounts(settings)
+	entries := make([]journalEntry, 0, len(movements))
+	for _, movement := range movements {
+		amount := roundCurrency(math.Abs(movement.Amount))
+		if amount == 0 {
+			continue
+		}
+		entry := journalEntry{
+			Number:          len(entries) + 1,
+			Date:            movement.OccurredAt.In(loc),
+			CreditAccountID: movement.CreditAccountID,
+			DocumentNumber:  movement.DocumentNumber,
+			Description:     journalDescription(movement),
+			DebitAccount:    *accounts[enums.AccountingReceivables],
+			CreditAccount:   *accounts[movementEvent(movement)],
+			Amount:          amount,
+		}
+		if movement.Amount < 0 {
+			entry.DebitAccount, entry.CreditAccount = entry.CreditAccount, entry.DebitAccount
+		}
+		entries = append(entries, entry)
+	}
+	return &journal{Establishment: establishment, StartDate: startDate, Entries: entries}, nil
+}
+
+// movementEvent returns the accounting event of a movement, on the other side of the entry from the
+// receivables. Reversals are of the event of the deleted transaction.
+func movementEvent(movement repository.AccountMovement) enums.AccountingEvent {
+	if movement.Type == enums.ActivityWriteOff {
+		return enums.AccountingWriteOffs
+	}
+	switch movement.TransactionType {
+	case enums.Payment:
+		return enums.AccountingCash
+	case enums.InterestCharge:
+		return enums.AccountingInterestIncome
+	case enums.LateFeeCharge:
+		return enums.AccountingLateFees
+	}
+	return enums.AccountingSales
+}
+
+// eventAccounts returns the settings field with the account of each accounting event.
+func eventAccounts(settings *entities.EstablishmentSettings) map[enums.AccountingEvent]*string {
+	return map[enums.AccountingEvent]*string{
+		enums.AccountingReceivables:    &settings.ReceivablesAccount,
+		enums.AccountingSales:          &settings.SalesAccount,
+		enums.AccountingCash:           &settings.CashAccount,
+		enums.AccountingInterestIncome: &settings.InterestAccount,
+		enums.AccountingLateFees:       &settings.LateFeeAccount,
+		enums.AccountingWriteOffs:      &settings.WriteOffAccount,
+	}
+}
+
+// unmappedEvents returns the accounting events settings has no account for, in the order of
+// enums.AccountingEvents.
+func unmappedEvents(settings *entities.EstablishmentSettings) []string {
+	accounts := eventAccounts(settings)
+	unmapped := []string{}
+	for _, event := range enums.AccountingEvents {
+		if *accounts[event] == "" {
+			unmapped = append(unmapped, string(event))
+		}
+	}
+	return unmapped
+}
+
+func accountingAccountsToResponse(settings *entities.EstablishmentSettings) *response.AccountingAccountsResponse {
+	accounts := make(map[string]string, len(enums.AccountingEvents))
+	for event, account := range eventAccounts(settings) {
+		accounts[string(event)] = *account
+	}
+	return &response.AccountingAccountsResponse{
+		EstablishmentID: settings.EstablishmentID,
+		Accounts:        accounts,
+		Unmapped:        unmappedEvents(settings),
+	}
+}
+
+// journalDescription returns the description (glosa) of the entry of a movement.
+func journalDescription(movement repository.AccountMovement) string {
+	description := strings.TrimSpace(movement.Description)
+	if description == "" {
+		return fmt.Sprintf("%s credit account %d", movement.Type, movement.CreditAccountID)
+	}
+	return fmt.Sprintf("%s credit account %d: %s", movement.Type, movement.CreditAccountID, description)
+}
+
+// pleDocument splits a document number such as B001-000123 into the type of SUNAT's catalog 10, the
+// series and the number the PLE asks for. Movements without a document are of type 00, others.
+func pleDocument(documentNumber string) (documentType, series, number string) {
+	series, number, found := strings.Cut(documentNumber, "-")
+	if !found {
+		return "00", "", ""
+	}
+	switch {
+	case strings.HasPrefix(series, "F"):
+		return invoicing.Invoice, series, number
+	case strings.HasPrefix(series, "B"):
+		return invoicing.Receipt, series, number
+	}
+	return "00", series, number
+}
+
+// pleText removes from a text the pipes and line breaks that would break the fields of a PLE line,
+// and cuts it to the 200 characters of the glosa.
+func pleText(text string) string {
+	text = strings.Join(strings.FieldsFunc(text, func(r rune) bool {
+		return r == '|' || r == '\r' || r == '\n'
+	}), " ")
+	if runes := []rune(text); len(runes) > 200 {
+		text = string(runes[:200])
+	}
+	return text
+}
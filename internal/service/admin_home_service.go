@@ -0,0 +1,89 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"fmt"
+)
+
+const (
+	// adminHomeMovements is how many entries of the activity feed the admin dashboard shows.
+	adminHomeMovements = 10
+	// adminHomeLowStock is how many products running out the admin dashboard lists.
+	adminHomeLowStock = 10
+)
+
+// AdminHomeService composes the dashboard of the admin app, which would otherwise take the
+// establishment, payments, overdue accounts, products and activity endpoints.
+type AdminHomeService interface {
+	GetAdminHome(adminID, branchID uint) (*response.AdminHomeResponse, error)
+}
+
+type adminHomeService struct {
+	establishmentRepo repository.EstablishmentRepository
+	productRepo       repository.ProductRepository
+	activityRepo      repository.AccountActivityRepository
+	clock             util.Clock
+}
+
+// NewAdminHomeService creates a new instance of AdminHomeService.
+func NewAdminHomeService(establishmentRepo repository.EstablishmentRepository, productRepo repository.ProductRepository, activityRepo repository.AccountActivityRepository, clock util.Clock) AdminHomeService {
+	return &adminHomeService{establishmentRepo: establishmentRepo, productRepo: productRepo, activityRepo: activityRepo, clock: clock}
+}
+
+// GetAdminHome returns the dashboard of the admin's establishment, or of one of its branches: its
+// status, the payments collected today, the transactions waiting for confirmation, the accounts
+// overdue, the products running out and the latest activity of its credit accounts.
+func (s *adminHomeService) GetAdminHome(adminID, branchID uint) (*response.AdminHomeResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	localNow := now.In(establishmentLocation(establishment))
+
+	summary, err := s.establishmentRepo.GetEstablishmentSummary(establishment.ID, util.StartOfDayIn(localNow, localNow.Location()), now, localNow.Day())
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing establishment: %w", err)
+	}
+	products, err := s.productRepo.GetLowStockProducts(establishment.ID, lowStockThreshold, adminHomeLowStock)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving products: %w", err)
+	}
+	activities, err := s.activityRepo.GetRecentEstablishmentActivities(establishment.ID, adminHomeMovements)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account activity: %w", err)
+	}
+
+	home := &response.AdminHomeResponse{
+		EstablishmentID:      establishment.ID,
+		EstablishmentName:    establishment.Name,
+		Status:               enums.EstablishmentActive,
+		PaymentsToday:        int(summary.PaymentsToday),
+		CollectedToday:       roundCurrency(summary.CollectedToday),
+		PendingConfirmations: int(summary.PendingConfirmations),
+		OverdueAccounts:      int(summary.OverdueAccounts),
+		LowStockProducts:     make([]response.LowStockProductResponse, len(products)),
+		RecentActivity:       make([]response.AdminActivityResponse, len(activities)),
+	}
+	switch {
+	case summary.SuspendedAt != nil:
+		home.Status = enums.EstablishmentSuspended
+		home.SuspensionReason = summary.SuspensionReason
+	case !summary.IsActive:
+		home.Status = enums.EstablishmentInactive
+	}
+	for i, product := range products {
+		home.LowStockProducts[i] = response.LowStockProductResponse{ID: product.ID, Name: product.Name, SKU: product.SKU, Stock: product.Stock}
+	}
+	for i := range activities {
+		home.RecentActivity[i] = response.AdminActivityResponse{
+			ActivityResponse: activityToResponse(&activities[i].AccountActivity),
+			ClientID:         activities[i].ClientID,
+			ClientName:       activities[i].ClientName,
+		}
+	}
+	return home, nil
+}
@@ -18,13 +18,14 @@ type AdminService interface {
 }
 
 type adminService struct {
-	establishmentRepo repository.EstablishmentRepository
-	userRepo          repository.UserRepository
+	establishmentRepo   repository.EstablishmentRepository
+	userRepo            repository.UserRepository
+	verificationService ContactVerificationService
 }
 
-// NewAdminService creates a new instance of adminService.
-func NewAdminService(establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository) AdminService {
-	return &adminService{establishmentRepo: establishmentRepo, userRepo: userRepo}
+// NewAdminService creates a new instance of adminService. A changed phone is verified by verificationService.
+func NewAdminService(establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository, verificationService ContactVerificationService) AdminService {
+	return &adminService{establishmentRepo: establishmentRepo, userRepo: userRepo, verificationService: verificationService}
 }
 
 // GetAdminByUserID retrieves admin details by user ID.
@@ -69,8 +70,10 @@ func (s *adminService) UpdateAdmin(userID uint, req request.UpdateUserRequest) (
 	if req.Address != "" {
 		user.Address = req.Address
 	}
-	if req.Phone != "" {
+	phoneChanged := req.Phone != "" && req.Phone != user.Phone
+	if phoneChanged {
 		user.Phone = req.Phone
+		user.PhoneVerifiedAt = nil
 	}
 	if req.PhotoUrl != "" {
 		user.PhotoUrl = req.PhotoUrl
@@ -79,6 +82,9 @@ func (s *adminService) UpdateAdmin(userID uint, req request.UpdateUserRequest) (
 	if err := s.userRepo.UpdateUser(user); err != nil {
 		return nil, fmt.Errorf("error updating user: %w", err)
 	}
+	if phoneChanged {
+		s.verificationService.StartVerification(user, enums.ContactPhone)
+	}
 
 	// Retrieve the updated establishment (for the response)
 	establishment, err := s.establishmentRepo.GetEstablishmentByAdminID(userID)
@@ -98,17 +104,21 @@ func establishmentToResponse(establishment *entities.Establishment, admin *respo
 		return nil
 	}
 	return &response.EstablishmentResponse{
-		ID:                establishment.ID,
-		RUC:               establishment.RUC,
-		Name:              establishment.Name,
-		Phone:             establishment.Phone,
-		Address:           establishment.Address,
-		ImageUrl:          establishment.ImageUrl,
-		LateFeePercentage: establishment.LateFeePercentage,
-		IsActive:          establishment.IsActive,
-		CreatedAt:         establishment.CreatedAt,
-		UpdatedAt:         establishment.UpdatedAt,
-		AdminID:           establishment.AdminID,
-		Admin:             admin,
+		ID:                             establishment.ID,
+		RUC:                            establishment.RUC,
+		Name:                           establishment.Name,
+		Phone:                          establishment.Phone,
+		Address:                        establishment.Address,
+		ImageUrl:                       establishment.ImageUrl,
+		LateFeePercentage:              establishment.LateFeePercentage,
+		Timezone:                       establishment.Timezone,
+		EarlyPaymentDiscountPercentage: establishment.EarlyPaymentDiscountPercentage,
+		IsActive:                       establishment.IsActive,
+		Version:                        establishment.Version,
+		CreatedAt:                      establishment.CreatedAt,
+		UpdatedAt:                      establishment.UpdatedAt,
+		AdminID:                        establishment.AdminID,
+		ParentID:                       establishment.ParentID,
+		Admin:                          admin,
 	}
 }
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime/multipart"
+
+	"gorm.io/gorm"
+)
+
+// attachmentRetentionYears is how long documents of each kind are kept after being uploaded. Signed
+// agreements are kept as long as commercial records must be.
+var attachmentRetentionYears = map[enums.AttachmentKind]int{
+	enums.AttachmentDNIScan:         5,
+	enums.AttachmentCreditAgreement: 10,
+	enums.AttachmentOther:           5,
+}
+
+// attachmentPurgeBatch is how many expired attachments are deleted per query.
+const attachmentPurgeBatch = 500
+
+// AttachmentService handles the documents attached to clients and credit accounts.
+type AttachmentService interface {
+	UploadClientAttachment(adminID, branchID, clientID uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error)
+	UploadCreditAccountAttachment(adminID, creditAccountID uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error)
+	GetClientAttachments(adminID, branchID, clientID uint) ([]response.AttachmentResponse, error)
+	GetCreditAccountAttachments(adminID, creditAccountID uint) ([]response.AttachmentResponse, error)
+	DownloadAttachment(userID uint, role enums.Role, attachmentID uint) (*entities.Attachment, []byte, error)
+	PurgeExpiredAttachments() error
+}
+
+type attachmentService struct {
+	attachmentRepo    repository.AttachmentRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	uploader          *DocumentUploader
+	clock             util.Clock
+}
+
+// NewAttachmentService creates a new instance of AttachmentService.
+func NewAttachmentService(attachmentRepo repository.AttachmentRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, uploader *DocumentUploader, clock util.Clock) AttachmentService {
+	return &attachmentService{
+		attachmentRepo:    attachmentRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		uploader:          uploader,
+		clock:             clock,
+	}
+}
+
+// UploadClientAttachment attaches a document to a client of the admin's establishment (or of branch
+// branchID), i.e. a client with a credit account there.
+func (s *attachmentService) UploadClientAttachment(adminID, branchID, clientID uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error) {
+	creditAccount, err := s.clientCreditAccount(adminID, branchID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return s.upload(adminID, creditAccount, nil, kind, file)
+}
+
+// UploadCreditAccountAttachment attaches a document to a credit account of one of the admin's establishments.
+func (s *attachmentService) UploadCreditAccountAttachment(adminID, creditAccountID uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.upload(adminID, creditAccount, &creditAccount.ID, kind, file)
+}
+
+// GetClientAttachments lists the documents of a client of the admin's establishment, those attached
+// to their credit account included, newest first.
+func (s *attachmentService) GetClientAttachments(adminID, branchID, clientID uint) ([]response.AttachmentResponse, error) {
+	creditAccount, err := s.clientCreditAccount(adminID, branchID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepo.GetClientAttachments(creditAccount.EstablishmentID, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving attachments: %w", err)
+	}
+	return attachmentsToResponse(attachments), nil
+}
+
+// GetCreditAccountAttachments lists the documents of a credit account of one of the admin's
+// establishments, newest first.
+func (s *attachmentService) GetCreditAccountAttachments(adminID, creditAccountID uint) ([]response.AttachmentResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepo.GetCreditAccountAttachments(creditAccount.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving attachments: %w", err)
+	}
+	return attachmentsToResponse(attachments), nil
+}
+
+// DownloadAttachment returns an attachment and its contents to an admin of its establishment or to
+// the client it belongs to. Anyone else gets ErrAttachmentNotFound.
+func (s *attachmentService) DownloadAttachment(userID uint, role enums.Role, attachmentID uint) (*entities.Attachment, []byte, error) {
+	attachment, err := s.attachmentRepo.GetAttachmentByID(attachmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving attachment: %w", err)
+	}
+
+	switch role {
+	case enums.ADMIN:
+		if _, err := s.establishmentRepo.GetAdminEstablishment(userID, attachment.EstablishmentID); err != nil {
+			return nil, nil, ErrAttachmentNotFound
+		}
+	case enums.CLIENT:
+		if attachment.ClientID != userID {
+			return nil, nil, ErrAttachmentNotFound
+		}
+	default:
+		return nil, nil, ErrAttachmentNotFound
+	}
+
+	data, err := s.uploader.read(attachment.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, data, nil
+}
+
+// PurgeExpiredAttachments deletes the attachments whose retention ended, file and record.
+func (s *attachmentService) PurgeExpiredAttachments() error {
+	now := s.clock.Now()
+	failed := 0
+	for {
+		attachments, err := s.attachmentRepo.GetExpiredAttachments(now, attachmentPurgeBatch)
+		if err != nil {
+			return fmt.Errorf("error retrieving expired attachments: %w", err)
+		}
+		deleted := 0
+		for _, attachment := range attachments {
+			if err := s.uploader.remove(attachment.Path); err != nil {
+				failed++
+				continue
+			}
+			if err := s.attachmentRepo.DeleteAttachment(attachment.ID); err != nil {
+				failed++
+				continue
+			}
+			deleted++
+		}
+		if len(attachments) < attachmentPurgeBatch || deleted == 0 {
+			break
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d expired attachments could not be deleted", failed)
+	}
+	return nil
+}
+
+// upload stores a document of the client of creditAccount, attached to the account itself when
+// creditAccountID is set.
+func (s *attachmentService) upload(adminID uint, creditAccount *entities.CreditAccount, creditAccountID *uint, kind enums.AttachmentKind, file *multipart.FileHeader) (*response.AttachmentResponse, error) {
+	years, ok := attachmentRetentionYears[kind]
+	if !ok {
+		return nil, ErrInvalidAttachmentKind
+	}
+
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return nil, fmt.Errorf("error naming attachment: %w", err)
+	}
+	dir := fmt.Sprintf("attachments/%d/%d", creditAccount.EstablishmentID, creditAccount.ClientID)
+	stored, err := s.uploader.save(file, dir, hex.EncodeToString(name))
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	attachment := entities.Attachment{
+		EstablishmentID: creditAccount.EstablishmentID,
+		ClientID:        creditAccount.ClientID,
+		CreditAccountID: creditAccountID,
+		Kind:            kind,
+		FileName:        file.Filename,
+		ContentType:     stored.ContentType,
+		Size:            stored.Size,
+		SHA256:          stored.SHA256,
+		Path:            stored.Path,
+		UploadedByID:    adminID,
+		RetainUntil:     now.AddDate(years, 0, 0),
+		CreatedAt:       now,
+	}
+	if err := s.attachmentRepo.CreateAttachment(&attachment); err != nil {
+		_ = s.uploader.remove(stored.Path)
+		return nil, fmt.Errorf("error saving attachment: %w", err)
+	}
+	return attachmentToResponse(&attachment), nil
+}
+
+// clientCreditAccount retrieves the credit account a client holds in the admin's establishment (or
+// branch branchID), which makes them a client of it.
+func (s *attachmentService) clientCreditAccount(adminID, branchID, clientID uint) (*entities.CreditAccount, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(clientID, establishment.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	return creditAccount, nil
+}
+
+// adminCreditAccount retrieves a credit account of one of the admin's establishments.
+func (s *attachmentService) adminCreditAccount(adminID, creditAccountID uint) (*entities.CreditAccount, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
+	return creditAccount, nil
+}
+
+func attachmentsToResponse(attachments []entities.Attachment) []response.AttachmentResponse {
+	attachmentResponses := make([]response.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		attachmentResponses = append(attachmentResponses, *attachmentToResponse(&attachments[i]))
+	}
+	return attachmentResponses
+}
+
+func attachmentToResponse(attachment *entities.Attachment) *response.AttachmentResponse {
+	return &response.AttachmentResponse{
+		ID:              attachment.ID,
+		ClientID:        attachment.ClientID,
+		CreditAccountID: attachment.CreditAccountID,
+		Kind:            attachment.Kind,
+		FileName:        attachment.FileName,
+		ContentType:     attachment.ContentType,
+		Size:            attachment.Size,
+		SHA256:          attachment.SHA256,
+		UploadedByID:    attachment.UploadedByID,
+		RetainUntil:     attachment.RetainUntil,
+		CreatedAt:       attachment.CreatedAt,
+	}
+}
@@ -9,31 +9,40 @@ import (
 	"ApiRestFinance/internal/util"
 	"errors"
 	"fmt"
-	"time"
 
-	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthService handles authentication and user-related operations.
 type AuthService interface {
 	RegisterAdmin(req *request.CreateAdminAndEstablishmentRequest) error
-	Login(req *request.LoginRequest) (*response.AuthResponse, error)
-	AttemptRefresh(accessToken string) (*response.AuthResponse, error)
-	ValidateToken(tokenString string) (jwt.MapClaims, error)
-	ResetPassword(req *request.ResetPasswordRequest, userID uint) error
+	Login(req *request.LoginRequest, client ClientInfo) (*response.AuthResponse, error)
+	CompleteTwoFactorLogin(req *request.TwoFactorLoginRequest, client ClientInfo) (*response.AuthResponse, error)
+	SetupTwoFactorLogin(challengeToken string) (*response.TwoFactorSetupResponse, error)
+	AttemptRefresh(refreshToken string, client ClientInfo) (*response.AuthResponse, error)
+	Logout(refreshToken string) error
+	ValidateToken(tokenString string) (*util.TokenClaims, error)
+	ResetPassword(req *request.ResetPasswordRequest, userID, sessionID uint) error
 }
 
-type authService struct {
-	userRepo          repository.UserRepository
-	establishmentRepo repository.EstablishmentRepository
+// errInvalidRefreshToken is returned for refresh tokens that are invalid, expired, already exchanged
+// or of a revoked session.
+var errInvalidRefreshToken = errors.New("refresh token invalid or expired, login again")
 
-	jwtSecret string
+type authService struct {
+	userRepo            repository.UserRepository
+	establishmentRepo   repository.EstablishmentRepository
+	sessionRepo         repository.SessionRepository
+	twoFactorService    TwoFactorService
+	verificationService ContactVerificationService
+	tokens              *util.TokenIssuer
+	clock               util.Clock
 }
 
-// NewAuthService creates a new instance of authService.
-func NewAuthService(userRepo repository.UserRepository, establishmentRepo repository.EstablishmentRepository, jwtSecret string) AuthService {
-	return &authService{userRepo: userRepo, establishmentRepo: establishmentRepo, jwtSecret: jwtSecret}
+// NewAuthService creates a new instance of authService. Session tokens are issued and validated by
+// tokens, and the contact details of new admins are verified by verificationService.
+func NewAuthService(userRepo repository.UserRepository, establishmentRepo repository.EstablishmentRepository, sessionRepo repository.SessionRepository, twoFactorService TwoFactorService, verificationService ContactVerificationService, tokens *util.TokenIssuer, clock util.Clock) AuthService {
+	return &authService{userRepo: userRepo, establishmentRepo: establishmentRepo, sessionRepo: sessionRepo, twoFactorService: twoFactorService, verificationService: verificationService, tokens: tokens, clock: clock}
 }
 
 // RegisterAdmin registers a new admin user along with their establishment.
@@ -41,7 +50,7 @@ func (s *authService) RegisterAdmin(req *request.CreateAdminAndEstablishmentRequ
 	// Check if the email is already in use
 	_, err := s.userRepo.GetUserByEmail(req.Email)
 	if err == nil {
-		return errors.New("email already in use")
+		return ErrEmailInUse
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -58,8 +67,16 @@ func (s *authService) RegisterAdmin(req *request.CreateAdminAndEstablishmentRequ
 		Address:   req.Address,
 		Phone:     req.Phone,
 		Rol:       enums.ADMIN,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: s.clock.Now(),
+		UpdatedAt: s.clock.Now(),
+	}
+
+	timezone := req.EstablishmentTimezone
+	if timezone == "" {
+		timezone = util.DefaultTimezone
+	}
+	if err := util.ValidateTimezone(timezone); err != nil {
+		return err
 	}
 
 	// Create the Establishment entity
@@ -70,20 +87,25 @@ func (s *authService) RegisterAdmin(req *request.CreateAdminAndEstablishmentRequ
 		Address:           req.EstablishmentAddress,
 		ImageUrl:          "",
 		LateFeePercentage: req.LateFeePercentage,
+		Timezone:          timezone,
 		IsActive:          true,
-		CreatedAt:         time.Now(),
-		UpdatedAt:         time.Now(),
+		Plan:              enums.PlanFree,
+		CreatedAt:         s.clock.Now(),
+		UpdatedAt:         s.clock.Now(),
 	}
 
 	if err := s.establishmentRepo.CreateAdminAndEstablishment(user, establishment); err != nil {
 		return fmt.Errorf("error registering admin and establishment: %w", err)
 	}
+	s.verificationService.StartVerification(user, enums.ContactEmail, enums.ContactPhone)
 
 	return nil
 }
 
-// Login authenticates a user with email and password.
-func (s *authService) Login(req *request.LoginRequest) (*response.AuthResponse, error) {
+// Login authenticates a user with email and password and starts a session on the client's device.
+// Users with two-factor authentication, or whose establishment requires it, get a challenge to
+// complete with CompleteTwoFactorLogin instead of their tokens.
+func (s *authService) Login(req *request.LoginRequest, client ClientInfo) (*response.AuthResponse, error) {
 	user, err := s.userRepo.GetUserByEmail(req.Email)
 	if err != nil {
 		return nil, errors.New("invalid credentials")
@@ -93,84 +115,222 @@ func (s *authService) Login(req *request.LoginRequest) (*response.AuthResponse,
 		return nil, errors.New("invalid credentials")
 	}
 
-	accessToken, err := util.GenerateAccessToken(user.ID, string(user.Rol), s.jwtSecret)
+	if user.TwoFactorEnabled {
+		return s.twoFactorChallenge(user, false)
+	}
+	required, err := s.twoFactorService.TwoFactorRequired(user)
 	if err != nil {
 		return nil, err
 	}
+	if required {
+		return s.twoFactorChallenge(user, true)
+	}
+	return s.startSession(user, client)
+}
 
-	refreshToken, err := util.GenerateRefreshToken(user.ID, string(user.Rol), s.jwtSecret)
+// CompleteTwoFactorLogin issues the tokens of a login challenged for its second factor. Users setting
+// two-factor authentication up during the login confirm it with the code and get their recovery codes.
+func (s *authService) CompleteTwoFactorLogin(req *request.TwoFactorLoginRequest, client ClientInfo) (*response.AuthResponse, error) {
+	user, err := s.challengedUser(req.ChallengeToken)
 	if err != nil {
 		return nil, err
 	}
 
-	authResponse := &response.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	if user.TwoFactorEnabled {
+		if err := s.twoFactorService.VerifyTwoFactor(user, req.Code); err != nil {
+			return nil, err
+		}
+		return s.startSession(user, client)
 	}
 
+	recoveryCodes, err := s.twoFactorService.EnableTwoFactor(user.ID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	authResponse, err := s.startSession(user, client)
+	if err != nil {
+		return nil, err
+	}
+	authResponse.RecoveryCodes = recoveryCodes.RecoveryCodes
 	return authResponse, nil
 }
 
-// AttemptRefresh attempts to refresh the access token using the refresh token.
-func (s *authService) AttemptRefresh(accessToken string) (*response.AuthResponse, error) {
-	token, err := util.ValidateToken(accessToken, s.jwtSecret)
+// SetupTwoFactorLogin generates the two-factor secret of a user who must set it up to log in.
+func (s *authService) SetupTwoFactorLogin(challengeToken string) (*response.TwoFactorSetupResponse, error) {
+	user, err := s.challengedUser(challengeToken)
 	if err != nil {
-		return nil, errors.New("access token invalid")
+		return nil, err
 	}
+	return s.twoFactorService.SetupTwoFactor(user.ID)
+}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return nil, errors.New("access token invalid")
+// AttemptRefresh exchanges the latest refresh token of an active session for new access and refresh
+// tokens. The user is read again, so the new tokens carry their current role and establishment.
+func (s *authService) AttemptRefresh(refreshToken string, client ClientInfo) (*response.AuthResponse, error) {
+	claims, err := s.tokens.Validate(refreshToken, util.RefreshToken)
+	if err != nil {
+		return nil, errInvalidRefreshToken
 	}
 
-	exp, ok := claims["exp"].(float64)
-	if !ok {
-		return nil, errors.New("access token invalid")
+	now := s.clock.Now()
+	session, err := s.sessionRepo.GetSessionByID(claims.SessionID)
+	if err != nil || session.UserID != claims.UserID || session.RevokedAt != nil ||
+		!now.Before(session.ExpiresAt) || session.RefreshTokenID != claims.ID {
+		return nil, errInvalidRefreshToken
 	}
 
-	expirationTime := time.Unix(int64(exp), 0)
-	if time.Since(expirationTime) > 5*time.Minute {
-		return nil, errors.New("token expired, login again")
+	user, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, errInvalidRefreshToken
+	}
+	if err := s.checkNotSuspended(user); err != nil {
+		return nil, err
 	}
 
-	userIDFloat, ok := claims["user_id"].(float64)
-	if !ok {
-		return nil, errors.New("access token invalid")
+	tokenID, err := util.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+	session.RefreshTokenID = tokenID
+	session.UserAgent = client.UserAgent
+	session.IP = client.IP
+	session.LastActiveAt = now
+	session.ExpiresAt = now.Add(s.tokens.RefreshTokenTTL())
+	rotated, err := s.sessionRepo.RotateSession(session, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing session: %w", err)
 	}
+	if !rotated {
+		return nil, errInvalidRefreshToken
+	}
+
+	return s.issueTokens(user, session)
+}
+
+// Logout revokes the session of a refresh token. Invalid tokens have no session to revoke and are ignored.
+func (s *authService) Logout(refreshToken string) error {
+	claims, err := s.tokens.Validate(refreshToken, util.RefreshToken)
+	if err != nil {
+		return nil
+	}
+	if _, err := s.sessionRepo.RevokeSession(claims.UserID, claims.SessionID, s.clock.Now()); err != nil {
+		return fmt.Errorf("error revoking session: %w", err)
+	}
+	return nil
+}
 
-	userID := uint(userIDFloat)
+// ValidateToken validates an access token and returns its claims.
+func (s *authService) ValidateToken(tokenString string) (*util.TokenClaims, error) {
+	return s.tokens.Validate(tokenString, util.AccessToken)
+}
 
-	userRol := claims["rol"].(string)
+// twoFactorChallenge answers a login that needs a second factor, or for setupRequired, needs two-factor
+// authentication to be set up first.
+func (s *authService) twoFactorChallenge(user *entities.User, setupRequired bool) (*response.AuthResponse, error) {
+	challengeToken, err := s.tokens.Issue(util.TwoFactorChallenge, util.TokenClaims{UserID: user.ID, Role: string(user.Rol)}, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &response.AuthResponse{
+		TwoFactorRequired:      true,
+		TwoFactorSetupRequired: setupRequired,
+		ChallengeToken:         challengeToken,
+	}, nil
+}
+
+// challengedUser returns the user of a valid login challenge. Challenges issued before a lockout of
+// the user's second factor ended are no longer valid, so the password has to be entered again.
+func (s *authService) challengedUser(challengeToken string) (*entities.User, error) {
+	claims, err := s.tokens.Validate(challengeToken, util.TwoFactorChallenge)
+	if err != nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	user, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	if twoFactorLocked(user, s.clock.Now()) {
+		return nil, ErrTwoFactorLocked
+	}
+	if user.TwoFactorLockedUntil != nil && claims.IssuedAt != nil && claims.IssuedAt.Before(*user.TwoFactorLockedUntil) {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	return user, nil
+}
 
-	newAccessToken, err := util.GenerateAccessToken(userID, userRol, s.jwtSecret)
+// startSession starts a new session for user on the client's device and issues its tokens.
+func (s *authService) startSession(user *entities.User, client ClientInfo) (*response.AuthResponse, error) {
+	if err := s.checkNotSuspended(user); err != nil {
+		return nil, err
+	}
+	tokenID, err := util.NewTokenID()
 	if err != nil {
 		return nil, err
 	}
 
-	authResponse := &response.AuthResponse{
-		AccessToken:  newAccessToken,
-		RefreshToken: newAccessToken,
+	now := s.clock.Now()
+	session := &entities.Session{
+		UserID:         user.ID,
+		RefreshTokenID: tokenID,
+		UserAgent:      client.UserAgent,
+		IP:             client.IP,
+		LastActiveAt:   now,
+		ExpiresAt:      now.Add(s.tokens.RefreshTokenTTL()),
+		CreatedAt:      now,
 	}
-	return authResponse, nil
+	if err := s.sessionRepo.CreateSession(session); err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+	return s.issueTokens(user, session)
 }
 
-// ValidateToken validates a JWT token.
-func (s *authService) ValidateToken(tokenString string) (jwt.MapClaims, error) {
-	token, err := util.ValidateToken(tokenString, s.jwtSecret)
+// checkNotSuspended fails with ErrEstablishmentSuspended for the admin of an establishment the
+// platform suspended, who can't log in or refresh their tokens until it is reinstated.
+func (s *authService) checkNotSuspended(user *entities.User) error {
+	if user.Rol != enums.ADMIN {
+		return nil
+	}
+	establishment, err := s.establishmentRepo.GetEstablishmentByAdminID(user.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	if establishment.SuspendedAt != nil {
+		return ErrEstablishmentSuspended
+	}
+	return nil
+}
+
+// issueTokens issues a new pair of tokens for user in session. Admin tokens carry their main establishment.
+func (s *authService) issueTokens(user *entities.User, session *entities.Session) (*response.AuthResponse, error) {
+	claims := util.TokenClaims{UserID: user.ID, Role: string(user.Rol), SessionID: session.ID}
+	if user.Rol == enums.ADMIN {
+		establishment, err := s.establishmentRepo.GetEstablishmentByAdminID(user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving establishment: %w", err)
+		}
+		claims.EstablishmentID = establishment.ID
+	}
+
+	now := s.clock.Now()
+	accessToken, err := s.tokens.Issue(util.AccessToken, claims, now)
 	if err != nil {
 		return nil, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return nil, errors.New("invalid JWT token")
+	claims.ID = session.RefreshTokenID
+	refreshToken, err := s.tokens.Issue(util.RefreshToken, claims, now)
+	if err != nil {
+		return nil, err
 	}
 
-	return claims, nil
+	return &response.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
-// ResetPassword resets the password for a user.
-func (s *authService) ResetPassword(req *request.ResetPasswordRequest, userID uint) error {
+// ResetPassword resets the password for a user and logs them out of their other sessions, keeping sessionID.
+func (s *authService) ResetPassword(req *request.ResetPasswordRequest, userID, sessionID uint) error {
 	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
 		return errors.New("user not found")
@@ -187,5 +347,11 @@ func (s *authService) ResetPassword(req *request.ResetPasswordRequest, userID ui
 
 	user.Password = string(newPasswordHash)
 
-	return s.userRepo.UpdateUser(user)
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.RevokeUserSessions(user.ID, sessionID, s.clock.Now()); err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+	return nil
 }
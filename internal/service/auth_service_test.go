@@ -0,0 +1,60 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthServiceCompleteTwoFactorLoginAfterALockout(t *testing.T) {
+	// Tokens are validated against the system time, so the clock can't be fixture.Now
+	now := time.Now().Truncate(time.Second)
+	at := func(d time.Duration) *time.Time {
+		until := now.Add(d)
+		return &until
+	}
+	tests := []struct {
+		name        string
+		issuedAt    time.Time
+		lockedUntil *time.Time
+		want        error
+	}{
+		{"never locked", now.Add(-time.Minute), nil, ErrInvalidTwoFactorCode},
+		{"locked", now.Add(-time.Minute), at(time.Minute), ErrTwoFactorLocked},
+		{"challenge issued before the lockout ended", now.Add(-2 * time.Minute), at(-time.Minute), ErrInvalidTwoFactorChallenge},
+		{"challenge issued after the lockout ended", now.Add(-time.Minute), at(-2 * time.Minute), ErrInvalidTwoFactorCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			users := mocks.NewMockUserRepository(ctrl)
+			twoFactor := mocks.NewMockTwoFactorRepository(ctrl)
+			clock := util.NewFakeClock(now)
+			tokens := util.NewTokenIssuer(util.TokenSettings{Issuer: "test", Audience: "test", KeyID: "test", Secret: "test-secret", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour})
+			user := fixture.Client().TwoFactor(testTOTPSecret).Build()
+			user.TwoFactorLockedUntil = tt.lockedUntil
+			users.EXPECT().GetUserByID(fixture.ClientID).Return(user, nil)
+			if errors.Is(tt.want, ErrInvalidTwoFactorCode) {
+				// The challenge is accepted, so the wrong code is counted and checked
+				twoFactor.EXPECT().StartTwoFactorAttempt(fixture.ClientID, maxTwoFactorAttempts, now, now.Add(twoFactorLockout)).Return(true, nil)
+				twoFactor.EXPECT().UseRecoveryCode(fixture.ClientID, gomock.Any(), now).Return(false, nil)
+			}
+			challenge, err := tokens.Issue(util.TwoFactorChallenge, util.TokenClaims{UserID: fixture.ClientID, Role: string(user.Rol)}, tt.issuedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := NewAuthService(users, nil, nil, NewTwoFactorService(users, twoFactor, clock), nil, tokens, clock)
+
+			_, err = s.CompleteTwoFactorLogin(&request.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000"}, ClientInfo{})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("CompleteTwoFactorLogin = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+// Access is what a caller wants to do with a credit account or the records of one.
+type Access int
+
+const (
+	// HolderAccess is granted to the admins of the account's establishment and to the client holding it.
+	HolderAccess Access = iota
+	// AdminAccess is only granted to the admins of the account's establishment.
+	AdminAccess
+)
+
+// Caller is the user a request is made by, with the tenant it acts in.
+type Caller struct {
+	UserID uint
+	Role   enums.Role
+	Tenant tenant.Scope
+}
+
+// AuthorizationService decides whether callers may access credit accounts, transactions and
+// installments, from the establishment and client that own them, and whether they may change users.
+// Records that don't exist fail with
+// gorm.ErrRecordNotFound, and those the caller may not access with ErrAccessDenied.
+type AuthorizationService interface {
+	AuthorizeCreditAccount(caller Caller, creditAccountID uint, access Access) error
+	AuthorizeTransaction(caller Caller, transactionID uint, access Access) error
+	AuthorizeInstallment(caller Caller, installmentID uint, access Access) error
+	AuthorizeUserChange(caller Caller, userID uint) error
+}
+
+type authorizationService struct {
+	creditAccountRepo repository.CreditAccountRepository
+	transactionRepo   repository.TransactionRepository
+	installmentRepo   repository.InstallmentRepository
+}
+
+// NewAuthorizationService creates a new instance of AuthorizationService.
+func NewAuthorizationService(creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository) AuthorizationService {
+	return &authorizationService{creditAccountRepo: creditAccountRepo, transactionRepo: transactionRepo, installmentRepo: installmentRepo}
+}
+
+// AuthorizeCreditAccount checks that caller may access a credit account.
+func (s *authorizationService) AuthorizeCreditAccount(caller Caller, creditAccountID uint, access Access) error {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if err != nil {
+		return err
+	}
+	return authorizeOwner(caller, creditAccount, access)
+}
+
+// AuthorizeTransaction checks that caller may access a transaction, as the credit account it was
+// made on.
+func (s *authorizationService) AuthorizeTransaction(caller Caller, transactionID uint, access Access) error {
+	transaction, err := s.transactionRepo.GetTransactionByID(transactionID)
+	if err != nil {
+		return err
+	}
+	return s.AuthorizeCreditAccount(caller, transaction.CreditAccountID, access)
+}
+
+// AuthorizeInstallment checks that caller may access an installment, as the credit account it is due on.
+func (s *authorizationService) AuthorizeInstallment(caller Caller, installmentID uint, access Access) error {
+	installment, err := s.installmentRepo.GetInstallmentByID(installmentID)
+	if err != nil {
+		return err
+	}
+	return s.AuthorizeCreditAccount(caller, installment.CreditAccountID, access)
+}
+
+// AuthorizeUserChange checks that caller may change the details of a user: their own, or, for admins,
+// those of the clients holding a credit account in an establishment of their tenant. Other users fail
+// with gorm.ErrRecordNotFound for admins, so they can't tell users of other establishments exist.
+func (s *authorizationService) AuthorizeUserChange(caller Caller, userID uint) error {
+	if caller.UserID == userID {
+		return nil
+	}
+	if caller.Role != enums.ADMIN {
+		return ErrAccessDenied
+	}
+	establishmentIDs, err := s.creditAccountRepo.GetClientEstablishmentIDs(userID)
+	if err != nil {
+		return err
+	}
+	for _, establishmentID := range establishmentIDs {
+		if caller.Tenant.Allows(establishmentID) {
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+// authorizeOwner checks that caller may access a credit account. Admins reach the accounts of the
+// establishments of their tenant; clients only their own accounts there, and never with AdminAccess.
+// Any other role, like super-admins, reaches none.
+func authorizeOwner(caller Caller, creditAccount *entities.CreditAccount, access Access) error {
+	if !caller.Tenant.Allows(creditAccount.EstablishmentID) {
+		return ErrAccessDenied
+	}
+	switch caller.Role {
+	case enums.ADMIN:
+		return nil
+	case enums.CLIENT:
+		if access == HolderAccess && creditAccount.ClientID == caller.UserID {
+			return nil
+		}
+	}
+	return ErrAccessDenied
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/tenant"
+	"ApiRestFinance/internal/testutil/fixture"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestAuthorizeCreditAccount(t *testing.T) {
+	own := tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID}}
+	admin := Caller{UserID: fixture.AdminID, Role: enums.ADMIN, Tenant: own}
+	holder := Caller{UserID: fixture.ClientID, Role: enums.CLIENT, Tenant: tenant.Scope{EstablishmentIDs: own.EstablishmentIDs, ClientID: fixture.ClientID}}
+	tests := []struct {
+		name      string
+		caller    Caller
+		accountID uint
+		access    Access
+		want      error
+	}{
+		{"admin of the establishment", admin, fixture.CreditAccountID, AdminAccess, nil},
+		{"admin of another establishment", Caller{UserID: fixture.AdminID, Role: enums.ADMIN, Tenant: tenant.Scope{EstablishmentIDs: []uint{fixture.EstablishmentID + 1}}}, fixture.CreditAccountID, HolderAccess, ErrAccessDenied},
+		{"holder", holder, fixture.CreditAccountID, HolderAccess, nil},
+		{"holder asking for admin access", holder, fixture.CreditAccountID, AdminAccess, ErrAccessDenied},
+		{"another client", Caller{UserID: fixture.ClientID + 1, Role: enums.CLIENT, Tenant: own}, fixture.CreditAccountID, HolderAccess, ErrAccessDenied},
+		{"user role", Caller{UserID: fixture.ClientID, Role: enums.USER, Tenant: own}, fixture.CreditAccountID, HolderAccess, ErrAccessDenied},
+		{"super-admin", Caller{UserID: 1, Role: enums.SUPERADMIN}, fixture.CreditAccountID, HolderAccess, ErrAccessDenied},
+		{"missing account", admin, fixture.CreditAccountID + 1, HolderAccess, gorm.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			accounts := mocks.NewMockCreditAccountRepository(ctrl)
+			if tt.accountID == fixture.CreditAccountID {
+				accounts.EXPECT().GetCreditAccountByID(tt.accountID).Return(fixture.CreditAccount().Build(), nil)
+			} else {
+				accounts.EXPECT().GetCreditAccountByID(tt.accountID).Return(nil, gorm.ErrRecordNotFound)
+			}
+			s := NewAuthorizationService(accounts, mocks.NewMockTransactionRepository(ctrl), mocks.NewMockInstallmentRepository(ctrl))
+
+			if err := s.AuthorizeCreditAccount(tt.caller, tt.accountID, tt.access); !errors.Is(err, tt.want) {
+				t.Errorf("AuthorizeCreditAccount = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
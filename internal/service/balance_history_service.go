@@ -0,0 +1,184 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// DefaultBalanceHistoryPeriod is the period balance history and receivables trends cover when none is requested.
+const DefaultBalanceHistoryPeriod = "last_30_days"
+
+// BalanceHistoryService serves the balance of credit accounts over time from their daily balance
+// snapshots, and takes those snapshots.
+type BalanceHistoryService interface {
+	SnapshotBalances() error
+	GetBalanceHistory(userID uint, role enums.Role, creditAccountID uint, period string) (*response.BalanceHistoryResponse, error)
+	GetReceivablesTrend(adminID, branchID uint, period string) (*response.ReceivablesTrendResponse, error)
+}
+
+type balanceHistoryService struct {
+	snapshotRepo      repository.BalanceSnapshotRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+}
+
+// NewBalanceHistoryService creates a new instance of BalanceHistoryService.
+func NewBalanceHistoryService(snapshotRepo repository.BalanceSnapshotRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock) BalanceHistoryService {
+	return &balanceHistoryService{
+		snapshotRepo:      snapshotRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		clock:             clock,
+	}
+}
+
+// SnapshotBalances records the balance of every credit account as the close of the current day in
+// the time zone of its establishment. It runs nightly, shortly before midnight; running it again on
+// the same day replaces the snapshots with the latest balances.
+func (s *balanceHistoryService) SnapshotBalances() error {
+	accounts, err := s.creditAccountRepo.GetAllCreditAccounts()
+	if err != nil {
+		return fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+
+	now := s.clock.Now()
+	snapshots := make([]entities.BalanceSnapshot, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+		snapshots[i] = entities.BalanceSnapshot{
+			CreditAccountID:   account.ID,
+			EstablishmentID:   account.EstablishmentID,
+			Date:              util.StartOfDayIn(now, accountLocation(account)),
+			Balance:           account.CurrentBalance,
+			AccountCredit:     account.AccountCredit,
+			WrittenOffBalance: account.WrittenOffBalance,
+			CreditLimit:       account.CreditLimit,
+			CreatedAt:         now,
+		}
+	}
+	if err := s.snapshotRepo.SaveBalanceSnapshots(snapshots); err != nil {
+		return fmt.Errorf("error saving balance snapshots: %w", err)
+	}
+	return nil
+}
+
+// GetBalanceHistory retrieves the closing balance of each day of the period of a credit account, in
+// the time zone of its establishment, ending with its current balance when the period includes today.
+// Clients see their own accounts and admins those of their establishments. Days before the account's
+// first snapshot are left out.
+func (s *balanceHistoryService) GetBalanceHistory(userID uint, role enums.Role, creditAccountID uint, period string) (*response.BalanceHistoryResponse, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	switch role {
+	case enums.CLIENT:
+		if creditAccount.ClientID != userID {
+			return nil, ErrCreditAccountNotFound
+		}
+	case enums.ADMIN:
+		if _, err := s.establishmentRepo.GetAdminEstablishment(userID, creditAccount.EstablishmentID); err != nil {
+			return nil, ErrCreditAccountNotFound
+		}
+	default:
+		return nil, ErrCreditAccountNotFound
+	}
+
+	if period == "" {
+		period = DefaultBalanceHistoryPeriod
+	}
+	now := s.clock.Now().In(accountLocation(creditAccount))
+	startDate, endDate, err := reportPeriodRange(period, now)
+	if err != nil {
+		return nil, err
+	}
+	startDate = util.StartOfDayIn(startDate, now.Location())
+	snapshots, err := s.snapshotRepo.GetBalanceSnapshots(creditAccount.ID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving balance snapshots: %w", err)
+	}
+
+	today := util.StartOfDayIn(now, now.Location())
+	history := &response.BalanceHistoryResponse{
+		CreditAccountID: creditAccount.ID,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Points:          make([]response.BalancePoint, 0, len(snapshots)+1),
+	}
+	for _, snapshot := range snapshots {
+		// Today's snapshot is superseded by the current balance below
+		if !snapshot.Date.Before(today) {
+			continue
+		}
+		history.Points = append(history.Points, response.BalancePoint{
+			Date:              snapshot.Date,
+			Balance:           snapshot.Balance,
+			AccountCredit:     snapshot.AccountCredit,
+			WrittenOffBalance: snapshot.WrittenOffBalance,
+			CreditLimit:       snapshot.CreditLimit,
+		})
+	}
+	if !endDate.Before(today) {
+		history.Points = append(history.Points, response.BalancePoint{
+			Date:              today,
+			Balance:           creditAccount.CurrentBalance,
+			AccountCredit:     creditAccount.AccountCredit,
+			WrittenOffBalance: creditAccount.WrittenOffBalance,
+			CreditLimit:       creditAccount.CreditLimit,
+			Current:           true,
+		})
+	}
+	return history, nil
+}
+
+// GetReceivablesTrend adds up the closing balances of the credit accounts of the admin's establishment,
+// or the selected branch, for each day of the period in its time zone. Days are included once their
+// snapshots are taken, nightly.
+func (s *balanceHistoryService) GetReceivablesTrend(adminID, branchID uint, period string) (*response.ReceivablesTrendResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if period == "" {
+		period = DefaultBalanceHistoryPeriod
+	}
+	now := s.clock.Now().In(establishmentLocation(establishment))
+	startDate, endDate, err := reportPeriodRange(period, now)
+	if err != nil {
+		return nil, err
+	}
+	startDate = util.StartOfDayIn(startDate, now.Location())
+	days, err := s.snapshotRepo.GetReceivablesByDay(establishment.ID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving balance snapshots: %w", err)
+	}
+
+	trend := &response.ReceivablesTrendResponse{
+		EstablishmentID: establishment.ID,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Points:          make([]response.ReceivablesPoint, len(days)),
+	}
+	for i, day := range days {
+		trend.Points[i] = response.ReceivablesPoint{
+			Date:                day.Date.In(now.Location()),
+			Balance:             roundCurrency(day.Balance),
+			AccountCredit:       roundCurrency(day.AccountCredit),
+			Receivables:         roundCurrency(day.Balance - day.AccountCredit),
+			WrittenOffBalance:   roundCurrency(day.WrittenOffBalance),
+			AccountsWithBalance: day.AccountsWithBalance,
+		}
+	}
+	return trend, nil
+}
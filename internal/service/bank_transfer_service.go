@@ -0,0 +1,278 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// accountReferencePrefix starts the references of the transfers made to a credit account, followed
+// by its ID, e.g. CA-42.
+const accountReferencePrefix = "CA-"
+
+// BankTransferService applies the transfers the bank notifies through the bank webhook. A transfer
+// whose reference is the confirmation code of a pending payment of the same amount confirms it, and
+// one referencing a credit account is recorded as a payment on it; any other is kept unmatched. The
+// client is emailed a receipt of the transfers applied, and texted the confirmation of the payment
+// where their establishment texts notifications.
+type BankTransferService interface {
+	VerifyNotification(timestamp, signature string, body []byte) error
+	ReceiveBankTransfer(req request.BankTransferNotificationRequest) (*response.BankTransferResponse, error)
+	GetBankTransfers(adminID, branchID uint) ([]response.BankTransferResponse, error)
+}
+
+type bankTransferService struct {
+	transferRepo      repository.BankTransferRepository
+	transactionRepo   repository.TransactionRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	dispatcher        NotificationDispatcher
+	clock             util.Clock
+	bus               event.Bus
+	secret            []byte
+	tolerance         time.Duration
+}
+
+// NewBankTransferService creates a new instance of BankTransferService. Notifications are signed by
+// the bank with secret, and their timestamp may be up to tolerance away from the clock.
+func NewBankTransferService(transferRepo repository.BankTransferRepository, transactionRepo repository.TransactionRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, dispatcher NotificationDispatcher, clock util.Clock, bus event.Bus, secret string, tolerance time.Duration) BankTransferService {
+	return &bankTransferService{
+		transferRepo:      transferRepo,
+		transactionRepo:   transactionRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		dispatcher:        dispatcher,
+		clock:             clock,
+		bus:               bus,
+		secret:            []byte(secret),
+		tolerance:         tolerance,
+	}
+}
+
+// VerifyNotification checks that the body of a notification was signed by the bank: signature must
+// be "sha256=" followed by the hex HMAC-SHA256, keyed with the secret, of timestamp, a dot and body.
+// The timestamp, in Unix seconds, must be within the tolerance of the clock, so a notification
+// captured on its way can't be replayed later; within it, notifications received before are told
+// apart by their ID.
+func (s *bankTransferService) VerifyNotification(timestamp, signature string, body []byte) error {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if len(s.secret) == 0 || !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidBankSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrStaleBankNotification
+	}
+	if age := s.clock.Now().Sub(time.Unix(seconds, 0)); age > s.tolerance || age < -s.tolerance {
+		return ErrStaleBankNotification
+	}
+	return nil
+}
+
+// ReceiveBankTransfer applies a transfer the bank notified and returns what was done with it. A
+// notification received before is answered with what was done then, and not applied again.
+func (s *bankTransferService) ReceiveBankTransfer(req request.BankTransferNotificationRequest) (*response.BankTransferResponse, error) {
+	received, err := s.transferRepo.GetBankTransferByProviderID(req.ID)
+	if err == nil {
+		return bankTransferToResponse(received), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error retrieving bank transfer: %w", err)
+	}
+
+	now := s.clock.Now()
+	transfer := entities.BankTransfer{
+		ProviderID:    req.ID,
+		Reference:     strings.TrimSpace(req.Reference),
+		Amount:        roundCurrency(req.Amount),
+		PayerName:     strings.TrimSpace(req.PayerName),
+		TransferredAt: req.TransferredAt,
+		CreatedAt:     now,
+	}
+	err = s.apply(&transfer, now)
+	if errors.Is(err, repository.ErrBankTransferReceived) {
+		// Delivered again while it was being applied
+		received, err := s.transferRepo.GetBankTransferByProviderID(req.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving bank transfer: %w", err)
+		}
+		return bankTransferToResponse(received), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.CreditAccountID != nil {
+		if transfer.Status == enums.BankTransferRecorded {
+			publishAccountEvent(s.bus, s.clock, event.TransactionCreated, *transfer.CreditAccountID)
+		}
+		publishAccountEvent(s.bus, s.clock, event.PaymentConfirmed, *transfer.CreditAccountID)
+		s.sendReceipt(&transfer)
+	}
+	return bankTransferToResponse(&transfer), nil
+}
+
+// apply confirms the pending payment transfer references or records it as a payment on the credit
+// account it references, and records the transfer. Transfers that can't be applied are recorded
+// unmatched, with why in their Detail.
+func (s *bankTransferService) apply(transfer *entities.BankTransfer, now time.Time) error {
+	reference := strings.ToUpper(transfer.Reference)
+
+	payments, err := s.transactionRepo.GetPendingPaymentsByCode(reference, now)
+	if err != nil {
+		return fmt.Errorf("error retrieving pending payments: %w", err)
+	}
+	var matching []entities.Transaction
+	for _, payment := range payments {
+		if math.Abs(payment.Amount-transfer.Amount) <= 0.005 {
+			matching = append(matching, payment)
+		}
+	}
+	switch {
+	case len(matching) == 1:
+		err := s.confirm(transfer, &matching[0], now)
+		if !errors.Is(err, repository.ErrPaymentNotPending) {
+			return err
+		}
+		transfer.Detail = "the pending payment it references was confirmed or failed meanwhile"
+	case len(matching) > 1:
+		transfer.Detail = "more than one pending payment has its code and amount"
+	case strings.HasPrefix(reference, accountReferencePrefix):
+		id, err := strconv.ParseUint(strings.TrimPrefix(reference, accountReferencePrefix), 10, 64)
+		if err != nil {
+			transfer.Detail = "the credit account it references is not valid"
+			break
+		}
+		creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(uint(id))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			transfer.Detail = "the credit account it references doesn't exist"
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error retrieving credit account: %w", err)
+		}
+		return s.record(transfer, creditAccount, now)
+	default:
+		transfer.Detail = "its reference matches no pending payment or credit account"
+	}
+
+	transfer.Status = enums.BankTransferUnmatched
+	transfer.EstablishmentID, transfer.CreditAccountID, transfer.TransactionID = nil, nil, nil
+	if err := s.transferRepo.CreateBankTransfer(transfer); err != nil {
+		return fmt.Errorf("error recording bank transfer: %w", err)
+	}
+	return nil
+}
+
+// confirm confirms the pending payment transfer pays.
+func (s *bankTransferService) confirm(transfer *entities.BankTransfer, payment *entities.Transaction, now time.Time) error {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(payment.CreditAccountID)
+	if err != nil {
+		return fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	transfer.Status = enums.BankTransferConfirmed
+	transfer.EstablishmentID, transfer.CreditAccountID, transfer.TransactionID = &creditAccount.EstablishmentID, &creditAccount.ID, &payment.ID
+	if err := s.transferRepo.ConfirmBankTransfer(transfer, now); err != nil {
+		if errors.Is(err, repository.ErrPaymentNotPending) || errors.Is(err, repository.ErrBankTransferReceived) {
+			return err
+		}
+		return fmt.Errorf("error confirming payment: %w", err)
+	}
+	return nil
+}
+
+// record records transfer as a confirmed payment on creditAccount.
+func (s *bankTransferService) record(transfer *entities.BankTransfer, creditAccount *entities.CreditAccount, now time.Time) error {
+	transfer.Status = enums.BankTransferRecorded
+	transfer.EstablishmentID, transfer.CreditAccountID = &creditAccount.EstablishmentID, &creditAccount.ID
+	transaction := entities.Transaction{
+		TransactionType: enums.Payment,
+		Amount:          transfer.Amount,
+		Description:     fmt.Sprintf("Bank transfer %s", transfer.ProviderID),
+		TransactionDate: now,
+		PaymentMethod:   enums.BANK_TRANSFER,
+		PaymentStatus:   enums.SUCCESS,
+	}
+	if err := s.transferRepo.RecordBankTransfer(transfer, &transaction); err != nil {
+		if errors.Is(err, repository.ErrBankTransferReceived) {
+			return err
+		}
+		return fmt.Errorf("error recording payment: %w", err)
+	}
+	return nil
+}
+
+// sendReceipt emails the client the receipt of a transfer applied to their credit account. Failing
+// to send it doesn't undo the payment.
+func (s *bankTransferService) sendReceipt(transfer *entities.BankTransfer) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(*transfer.CreditAccountID)
+	if err == nil {
+		establishmentName := ""
+		if creditAccount.Establishment != nil {
+			establishmentName = creditAccount.Establishment.Name
+		}
+		_, err = s.dispatcher.Dispatch(Notification{
+			Kind:    enums.BankTransferNotification,
+			Account: creditAccount,
+			Args:    []interface{}{transfer.Amount, establishmentName, roundCurrency(creditAccount.CurrentBalance - creditAccount.AccountCredit)},
+		})
+	}
+	if err != nil {
+		log.Printf("receipt of bank transfer %s could not be sent: %v", transfer.ProviderID, err)
+	}
+}
+
+// GetBankTransfers lists the transfers applied to the credit accounts of the admin's establishment,
+// newest first.
+func (s *bankTransferService) GetBankTransfers(adminID, branchID uint) ([]response.BankTransferResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := s.transferRepo.GetBankTransfersByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bank transfers: %w", err)
+	}
+
+	responses := make([]response.BankTransferResponse, 0, len(transfers))
+	for i := range transfers {
+		responses = append(responses, *bankTransferToResponse(&transfers[i]))
+	}
+	return responses, nil
+}
+
+func bankTransferToResponse(transfer *entities.BankTransfer) *response.BankTransferResponse {
+	return &response.BankTransferResponse{
+		ID:              transfer.ID,
+		ProviderID:      transfer.ProviderID,
+		Reference:       transfer.Reference,
+		Amount:          transfer.Amount,
+		PayerName:       transfer.PayerName,
+		Status:          transfer.Status,
+		Detail:          transfer.Detail,
+		CreditAccountID: transfer.CreditAccountID,
+		TransactionID:   transfer.TransactionID,
+		TransferredAt:   transfer.TransferredAt,
+		ReceivedAt:      transfer.CreatedAt,
+	}
+}
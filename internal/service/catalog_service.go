@@ -0,0 +1,67 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/repository"
+	"fmt"
+)
+
+// lowStockThreshold is the stock at or below which catalog products are flagged as running out.
+const lowStockThreshold = 5
+
+// CatalogService serves the public catalog clients browse before purchasing.
+type CatalogService interface {
+	GetCatalog(establishmentID, categoryID uint, page, pageSize int) ([]response.CatalogProductResponse, int, error)
+}
+
+type catalogService struct {
+	productRepo       repository.ProductRepository
+	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+}
+
+// NewCatalogService creates a new instance of CatalogService.
+func NewCatalogService(productRepo repository.ProductRepository, establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository) CatalogService {
+	return &catalogService{productRepo: productRepo, establishmentRepo: establishmentRepo, settingsRepo: settingsRepo}
+}
+
+// GetCatalog retrieves a page of the active products of an active establishment, by name, with the
+// price the client pays for them, and the number of products across all pages.
+func (s *catalogService) GetCatalog(establishmentID, categoryID uint, page, pageSize int) ([]response.CatalogProductResponse, int, error) {
+	// The catalog of a deactivated establishment, or of a branch of one, is hidden
+	active, err := s.establishmentRepo.IsEstablishmentActive(establishmentID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	if !active {
+		return nil, 0, ErrEstablishmentNotFound
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishmentID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	products, total, err := s.productRepo.GetCatalogProducts(establishmentID, categoryID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error retrieving products: %w", err)
+	}
+
+	catalog := make([]response.CatalogProductResponse, len(products))
+	for i, product := range products {
+		_, _, price := taxBreakdown(product.Price, settings.TaxRate, !settings.PricesExcludeTax)
+		catalog[i] = response.CatalogProductResponse{
+			ID:          product.ID,
+			Name:        product.Name,
+			Description: product.Description,
+			CategoryID:  product.CategoryID,
+			Price:       price,
+			ImageUrl:    product.ImageUrl,
+			InStock:     product.Stock > 0,
+			LowStock:    product.Stock > 0 && product.Stock <= lowStockThreshold,
+		}
+		if product.Category != nil {
+			catalog[i].Category = product.Category.Name
+		}
+	}
+	return catalog, int(total), nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// CategoryService handles the categories establishments group their products in.
+type CategoryService interface {
+	GetCategories(adminID, branchID uint) ([]response.CategoryResponse, error)
+	CreateCategory(adminID, branchID uint, req request.CategoryRequest) (*response.CategoryResponse, error)
+	UpdateCategory(adminID, branchID, categoryID uint, req request.CategoryRequest) (*response.CategoryResponse, error)
+	DeleteCategory(adminID, branchID, categoryID uint) error
+}
+
+type categoryService struct {
+	categoryRepo      repository.CategoryRepository
+	establishmentRepo repository.EstablishmentRepository
+}
+
+// NewCategoryService creates a new instance of CategoryService.
+func NewCategoryService(categoryRepo repository.CategoryRepository, establishmentRepo repository.EstablishmentRepository) CategoryService {
+	return &categoryService{categoryRepo: categoryRepo, establishmentRepo: establishmentRepo}
+}
+
+// GetCategories retrieves the categories of the admin's establishment.
+func (s *categoryService) GetCategories(adminID, branchID uint) ([]response.CategoryResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.GetCategoriesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving categories: %w", err)
+	}
+
+	categoryResponses := make([]response.CategoryResponse, len(categories))
+	for i := range categories {
+		categoryResponses[i] = *categoryToResponse(&categories[i])
+	}
+	return categoryResponses, nil
+}
+
+// CreateCategory adds a category to the admin's establishment.
+func (s *categoryService) CreateCategory(adminID, branchID uint, req request.CategoryRequest) (*response.CategoryResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkNameAvailable(establishment.ID, 0, name); err != nil {
+		return nil, err
+	}
+
+	category := entities.Category{EstablishmentID: establishment.ID, Name: name}
+	if err := s.categoryRepo.CreateCategory(&category); err != nil {
+		return nil, fmt.Errorf("error creating category: %w", err)
+	}
+	return categoryToResponse(&category), nil
+}
+
+// UpdateCategory renames a category of the admin's establishment.
+func (s *categoryService) UpdateCategory(adminID, branchID, categoryID uint, req request.CategoryRequest) (*response.CategoryResponse, error) {
+	category, err := s.findCategory(adminID, branchID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkNameAvailable(category.EstablishmentID, category.ID, name); err != nil {
+		return nil, err
+	}
+
+	category.Name = name
+	if err := s.categoryRepo.UpdateCategory(category); err != nil {
+		return nil, fmt.Errorf("error updating category: %w", err)
+	}
+	return categoryToResponse(category), nil
+}
+
+// DeleteCategory deletes a category of the admin's establishment that has no products left.
+func (s *categoryService) DeleteCategory(adminID, branchID, categoryID uint) error {
+	category, err := s.findCategory(adminID, branchID, categoryID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.categoryRepo.DeleteCategory(category.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCategoryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting category: %w", err)
+	}
+	if !deleted {
+		return ErrCategoryInUse
+	}
+	return nil
+}
+
+// findCategory retrieves a category of the admin's establishment.
+func (s *categoryService) findCategory(adminID, branchID, categoryID uint) (*entities.Category, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.categoryRepo.GetCategoryByID(categoryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && category.EstablishmentID != establishment.ID) {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving category: %w", err)
+	}
+	return category, nil
+}
+
+// checkNameAvailable rejects a blank name, or one another category of the establishment than the one
+// being renamed already has. Names are compared ignoring case.
+func (s *categoryService) checkNameAvailable(establishmentID, categoryID uint, name string) error {
+	if name == "" {
+		return ErrInvalidCategoryName
+	}
+	categories, err := s.categoryRepo.GetCategoriesByEstablishmentID(establishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving categories: %w", err)
+	}
+	for _, category := range categories {
+		if category.ID != categoryID && strings.EqualFold(category.Name, name) {
+			return ErrCategoryExists
+		}
+	}
+	return nil
+}
+
+func categoryToResponse(category *entities.Category) *response.CategoryResponse {
+	return &response.CategoryResponse{
+		ID:              category.ID,
+		EstablishmentID: category.EstablishmentID,
+		Name:            category.Name,
+		CreatedAt:       category.CreatedAt,
+		UpdatedAt:       category.UpdatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"time"
+)
+
+// clientHomeMovements is how many entries of the activity feed the home screen shows.
+const clientHomeMovements = 5
+
+// ClientHomeService composes the home screen of the client app, which would otherwise take the
+// balance, overdue balance, installments, credit account and activity endpoints.
+type ClientHomeService interface {
+	GetClientHome(clientID, establishmentID uint) (*response.ClientHomeResponse, error)
+}
+
+type clientHomeService struct {
+	creditAccountRepo repository.CreditAccountRepository
+	activityRepo      repository.AccountActivityRepository
+	holidayRepo       repository.HolidayRepository
+	clock             util.Clock
+}
+
+// NewClientHomeService creates a new instance of ClientHomeService.
+func NewClientHomeService(creditAccountRepo repository.CreditAccountRepository, activityRepo repository.AccountActivityRepository, holidayRepo repository.HolidayRepository, clock util.Clock) ClientHomeService {
+	return &clientHomeService{creditAccountRepo: creditAccountRepo, activityRepo: activityRepo, holidayRepo: holidayRepo, clock: clock}
+}
+
+// GetClientHome returns the home screen of the client's credit account in establishmentID, which
+// may be 0 when the client has a single account, in two queries: the account with its next
+// installment and unread notifications, and its latest movements, plus the holidays of its
+// establishment for short-term accounts.
+func (s *clientHomeService) GetClientHome(clientID, establishmentID uint) (*response.ClientHomeResponse, error) {
+	accounts, err := s.creditAccountRepo.GetClientHomeAccounts(clientID, establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	switch {
+	case len(accounts) == 0:
+		return nil, ErrCreditAccountNotFound
+	case len(accounts) > 1:
+		return nil, ErrEstablishmentRequired
+	}
+	account := &accounts[0]
+	creditAccount := &account.CreditAccount
+	creditAccount.Establishment = &entities.Establishment{Name: account.EstablishmentName, Timezone: account.EstablishmentTimezone}
+
+	activities, err := s.activityRepo.GetRecentActivities(creditAccount.ID, clientHomeMovements)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account activity: %w", err)
+	}
+
+	home := &response.ClientHomeResponse{
+		CreditAccountID:     creditAccount.ID,
+		EstablishmentID:     creditAccount.EstablishmentID,
+		EstablishmentName:   account.EstablishmentName,
+		CreditLimit:         creditAccount.CreditLimit,
+		CurrentBalance:      creditAccount.CurrentBalance,
+		IsBlocked:           creditAccount.IsBlocked,
+		RecentMovements:     make([]response.ActivityResponse, len(activities)),
+		UnreadNotifications: int(account.UnreadNotifications),
+	}
+	if !creditAccount.IsBlocked && creditAccount.Status != enums.AccountClosed && creditAccount.WrittenOffAt == nil {
+		home.AvailableCredit = roundCurrency(max(creditAccount.CreditLimit-creditAccount.CurrentBalance+creditAccount.AccountCredit, 0))
+	}
+	var days calendar.BusinessDays
+	if creditAccount.CreditType != enums.LongTerm {
+		if days, err = businessDays(s.holidayRepo, creditAccount.EstablishmentID); err != nil {
+			return nil, err
+		}
+	}
+	home.NextDueDate, home.NextDueAmount, home.Overdue = nextPayment(account, days, s.clock.Now())
+	for i := range activities {
+		home.RecentMovements[i] = activityToResponse(&activities[i])
+	}
+	return home, nil
+}
+
+// nextPayment returns when the client of a credit account has to pay next and how much, and whether
+// that is already overdue. Long-term accounts are due their earliest installment not paid in full,
+// and short-term ones what they owe on their monthly due date, or the next business day after it.
+func nextPayment(account *repository.ClientHomeAccount, days calendar.BusinessDays, now time.Time) (*time.Time, float64, bool) {
+	creditAccount := &account.CreditAccount
+	if creditAccount.CreditType == enums.LongTerm {
+		if account.NextDueDate == nil {
+			return nil, 0, false
+		}
+		return account.NextDueDate, roundCurrency(account.NextDueAmount), account.NextDueDate.Before(now)
+	}
+
+	owed := roundCurrency(creditAccount.CurrentBalance - creditAccount.AccountCredit)
+	if owed <= 0 {
+		return nil, 0, false
+	}
+	// Until this month's due date has passed it is the next one as well
+	loc := accountLocation(creditAccount)
+	dueDate := days.Next(util.CurrentDueDate(now.In(loc), creditAccount.MonthlyDueDate, loc))
+	return &dueDate, owed, isAccountOverdue(*creditAccount, days, now)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ClientNoteService handles the notes admins write about the clients of their establishment.
+type ClientNoteService interface {
+	GetClientNotes(adminID, branchID, clientID uint) ([]response.ClientNoteResponse, error)
+	CreateClientNote(adminID, branchID, clientID uint, req request.ClientNoteRequest) (*response.ClientNoteResponse, error)
+	UpdateClientNote(adminID, branchID, clientID, noteID uint, req request.ClientNoteRequest) (*response.ClientNoteResponse, error)
+	DeleteClientNote(adminID, branchID, clientID, noteID uint) error
+}
+
+type clientNoteService struct {
+	noteRepo          repository.ClientNoteRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+}
+
+// NewClientNoteService creates a new instance of ClientNoteService.
+func NewClientNoteService(noteRepo repository.ClientNoteRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock) ClientNoteService {
+	return &clientNoteService{noteRepo: noteRepo, creditAccountRepo: creditAccountRepo, establishmentRepo: establishmentRepo, clock: clock}
+}
+
+// GetClientNotes retrieves the notes the admin's establishment has about a client of it, newest first.
+func (s *clientNoteService) GetClientNotes(adminID, branchID, clientID uint) ([]response.ClientNoteResponse, error) {
+	establishment, err := establishmentClient(s.establishmentRepo, s.creditAccountRepo, adminID, branchID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := s.noteRepo.GetClientNotes(establishment.ID, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client notes: %w", err)
+	}
+
+	noteResponses := make([]response.ClientNoteResponse, len(notes))
+	for i := range notes {
+		noteResponses[i] = *clientNoteToResponse(&notes[i])
+	}
+	return noteResponses, nil
+}
+
+// CreateClientNote adds a note of the admin about a client of their establishment.
+func (s *clientNoteService) CreateClientNote(adminID, branchID, clientID uint, req request.ClientNoteRequest) (*response.ClientNoteResponse, error) {
+	establishment, err := establishmentClient(s.establishmentRepo, s.creditAccountRepo, adminID, branchID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, ErrInvalidClientNote
+	}
+
+	now := s.clock.Now()
+	note := entities.ClientNote{
+		EstablishmentID: establishment.ID,
+		ClientID:        clientID,
+		AuthorID:        adminID,
+		Body:            body,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.noteRepo.CreateClientNote(&note); err != nil {
+		return nil, fmt.Errorf("error creating client note: %w", err)
+	}
+	created, err := s.noteRepo.GetClientNoteByID(note.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client note: %w", err)
+	}
+	return clientNoteToResponse(created), nil
+}
+
+// UpdateClientNote changes the text of a note about a client of the admin's establishment.
+func (s *clientNoteService) UpdateClientNote(adminID, branchID, clientID, noteID uint, req request.ClientNoteRequest) (*response.ClientNoteResponse, error) {
+	note, err := s.findClientNote(adminID, branchID, clientID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, ErrInvalidClientNote
+	}
+
+	note.Body, note.UpdatedAt = body, s.clock.Now()
+	if err := s.noteRepo.UpdateClientNote(note); err != nil {
+		return nil, fmt.Errorf("error updating client note: %w", err)
+	}
+	return clientNoteToResponse(note), nil
+}
+
+// DeleteClientNote deletes a note about a client of the admin's establishment.
+func (s *clientNoteService) DeleteClientNote(adminID, branchID, clientID, noteID uint) error {
+	note, err := s.findClientNote(adminID, branchID, clientID, noteID)
+	if err != nil {
+		return err
+	}
+	if err := s.noteRepo.DeleteClientNote(note.ID); err != nil {
+		return fmt.Errorf("error deleting client note: %w", err)
+	}
+	return nil
+}
+
+// findClientNote retrieves a note the admin's establishment has about a client of it.
+func (s *clientNoteService) findClientNote(adminID, branchID, clientID, noteID uint) (*entities.ClientNote, error) {
+	establishment, err := establishmentClient(s.establishmentRepo, s.creditAccountRepo, adminID, branchID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	note, err := s.noteRepo.GetClientNoteByID(noteID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (note.EstablishmentID != establishment.ID || note.ClientID != clientID)) {
+		return nil, ErrClientNoteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client note: %w", err)
+	}
+	return note, nil
+}
+
+func clientNoteToResponse(note *entities.ClientNote) *response.ClientNoteResponse {
+	noteResponse := &response.ClientNoteResponse{
+		ID:              note.ID,
+		EstablishmentID: note.EstablishmentID,
+		ClientID:        note.ClientID,
+		AuthorID:        note.AuthorID,
+		Body:            note.Body,
+		CreatedAt:       note.CreatedAt,
+		UpdatedAt:       note.UpdatedAt,
+	}
+	if note.Author != nil {
+		noteResponse.AuthorName = note.Author.Name
+	}
+	return noteResponse
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ClientNotificationService lets clients read in the app the notifications they were sent.
+type ClientNotificationService interface {
+	GetClientNotifications(clientID, establishmentID uint, unreadOnly bool, page, pageSize int) ([]response.ClientNotificationResponse, int, error)
+	MarkNotificationRead(clientID, notificationID uint) error
+	MarkAllNotificationsRead(clientID uint) (int, error)
+}
+
+type clientNotificationService struct {
+	notificationRepo repository.ClientNotificationRepository
+	clock            util.Clock
+}
+
+// NewClientNotificationService creates a new instance of ClientNotificationService.
+func NewClientNotificationService(notificationRepo repository.ClientNotificationRepository, clock util.Clock) ClientNotificationService {
+	return &clientNotificationService{notificationRepo: notificationRepo, clock: clock}
+}
+
+// GetClientNotifications retrieves a page of the client's notifications, newest first, and the
+// number of them across all pages. A non-zero establishmentID keeps only those of the client's
+// account at that establishment.
+func (s *clientNotificationService) GetClientNotifications(clientID, establishmentID uint, unreadOnly bool, page, pageSize int) ([]response.ClientNotificationResponse, int, error) {
+	notifications, total, err := s.notificationRepo.GetClientNotifications(clientID, establishmentID, unreadOnly, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error retrieving notifications: %w", err)
+	}
+
+	responses := make([]response.ClientNotificationResponse, len(notifications))
+	for i := range notifications {
+		responses[i] = clientNotificationToResponse(&notifications[i])
+	}
+	return responses, int(total), nil
+}
+
+// MarkNotificationRead marks one of the client's notifications read.
+func (s *clientNotificationService) MarkNotificationRead(clientID, notificationID uint) error {
+	err := s.notificationRepo.MarkClientNotificationRead(clientID, notificationID, s.clock.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotificationNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error marking notification read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of the client read, and returns how many
+// were.
+func (s *clientNotificationService) MarkAllNotificationsRead(clientID uint) (int, error) {
+	marked, err := s.notificationRepo.MarkClientNotificationsRead(clientID, s.clock.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error marking notifications read: %w", err)
+	}
+	return int(marked), nil
+}
+
+func clientNotificationToResponse(notification *entities.ClientNotification) response.ClientNotificationResponse {
+	return response.ClientNotificationResponse{
+		ID:              notification.ID,
+		EstablishmentID: notification.EstablishmentID,
+		CreditAccountID: notification.CreditAccountID,
+		Kind:            notification.Kind,
+		Message:         notification.Message,
+		Read:            notification.ReadAt != nil,
+		ReadAt:          notification.ReadAt,
+		CreatedAt:       notification.CreatedAt,
+	}
+}
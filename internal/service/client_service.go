@@ -6,6 +6,7 @@ import (
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -50,6 +51,7 @@ func (s *clientService) CreateClient(req request.CreateClientRequest) (*response
 		MonthlyDueDate:          req.MonthlyDueDate,
 		InterestRate:            req.InterestRate,
 		InterestType:            req.InterestType,
+		CompoundingPeriod:       compoundingPeriodOrDefault(req.CompoundingPeriod),
 		CreditType:              req.CreditType,
 		GracePeriod:             req.GracePeriod,
 		IsBlocked:               false,
@@ -83,7 +85,7 @@ func (s *clientService) GetClientByID(userID uint) (*response.ClientResponse, er
 
 // GetClientsByEstablishmentID retrieves all clients associated with an establishment.
 func (s *clientService) GetClientsByEstablishmentID(establishmentID uint) ([]response.ClientResponse, error) {
-	users, err := s.userRepo.GetClientsByEstablishmentID(establishmentID)
+	users, err := s.userRepo.GetClientsByEstablishmentID(establishmentID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving clients: %w", err)
 	}
@@ -122,9 +124,9 @@ func (s *clientService) UpdateClient(userID uint, req request.UpdateClientReques
 	if req.CreditLimit != 0 || req.MonthlyDueDate != 0 || req.InterestRate != 0 ||
 		req.InterestType != "" || req.CreditType != "" || req.GracePeriod != 0 {
 
-		creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(userID) // Use userID here
+		creditAccount, err := findClientCreditAccount(s.creditAccountRepo, userID, 0)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving credit account: %w", err)
+			return nil, err
 		}
 
 		if req.CreditLimit > 0 {
@@ -206,7 +208,7 @@ func NewUserResponse(user *entities.User) *response.UserResponse {
 		Name:      user.Name,
 		Address:   user.Address,
 		Phone:     user.Phone,
-		PhotoUrl:  user.PhotoUrl,
+		PhotoUrl:  util.UserPhotoURL(user.PhotoUrl, user.Name),
 		Rol:       user.Rol,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
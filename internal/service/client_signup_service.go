@@ -0,0 +1,360 @@
+package service
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	inviteCodeLength = 8
+	// inviteCodeAlphabet leaves out characters that are easily mistaken for others (0/O, 1/I/L)
+	inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+)
+
+// ClientSignupService lets clients register themselves with an establishment using its invite code.
+// Their credit account is opened once an admin approves the signup and sets its terms.
+type ClientSignupService interface {
+	GetInviteCode(adminID, branchID uint) (*response.InviteCodeResponse, error)
+	RotateInviteCode(adminID, branchID uint) (*response.InviteCodeResponse, error)
+	SignUpClient(inviteCode string, req request.ClientSignupRequest) (*response.ClientSignupResponse, error)
+	GetClientSignups(adminID, branchID uint, status enums.SignupStatus) ([]response.ClientSignupResponse, error)
+	ApproveClientSignup(adminID, branchID, signupID uint, req request.ApproveClientSignupRequest) (*response.ClientSignupResponse, error)
+	RejectClientSignup(adminID, branchID, signupID uint, reason string) (*response.ClientSignupResponse, error)
+}
+
+type clientSignupService struct {
+	signupRepo          repository.ClientSignupRepository
+	establishmentRepo   repository.EstablishmentRepository
+	userRepo            repository.UserRepository
+	creditAccountRepo   repository.CreditAccountRepository
+	settingsRepo        repository.EstablishmentSettingsRepository
+	verificationService ContactVerificationService
+	mailer              mail.Sender
+	clock               util.Clock
+}
+
+// NewClientSignupService creates a new instance of ClientSignupService. The contact details of
+// approved clients are verified by verificationService.
+func NewClientSignupService(signupRepo repository.ClientSignupRepository, establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository, creditAccountRepo repository.CreditAccountRepository, settingsRepo repository.EstablishmentSettingsRepository, verificationService ContactVerificationService, mailer mail.Sender, clock util.Clock) ClientSignupService {
+	return &clientSignupService{
+		signupRepo:          signupRepo,
+		establishmentRepo:   establishmentRepo,
+		userRepo:            userRepo,
+		creditAccountRepo:   creditAccountRepo,
+		settingsRepo:        settingsRepo,
+		verificationService: verificationService,
+		mailer:              mailer,
+		clock:               clock,
+	}
+}
+
+// GetInviteCode retrieves the invite code of the admin's establishment, or the selected branch,
+// generating one the first time.
+func (s *clientSignupService) GetInviteCode(adminID, branchID uint) (*response.InviteCodeResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if establishment.InviteCode != nil {
+		return inviteCodeToResponse(establishment.ID, *establishment.InviteCode), nil
+	}
+	return s.newInviteCode(establishment.ID)
+}
+
+// RotateInviteCode replaces the invite code of the admin's establishment, or the selected branch,
+// so a leaked code stops working. Signups already made are left alone.
+func (s *clientSignupService) RotateInviteCode(adminID, branchID uint) (*response.InviteCodeResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	return s.newInviteCode(establishment.ID)
+}
+
+func (s *clientSignupService) newInviteCode(establishmentID uint) (*response.InviteCodeResponse, error) {
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.establishmentRepo.UpdateInviteCode(establishmentID, code); err != nil {
+		return nil, fmt.Errorf("error saving invite code: %w", err)
+	}
+	return inviteCodeToResponse(establishmentID, code), nil
+}
+
+// SignUpClient registers a client with the establishment of an invite code, pending the approval of
+// its admin, who is notified. Clients that already have an account in another establishment sign up
+// with the same email and DNI, and keep their password.
+func (s *clientSignupService) SignUpClient(inviteCode string, req request.ClientSignupRequest) (*response.ClientSignupResponse, error) {
+	establishment, err := s.establishmentRepo.GetEstablishmentByInviteCode(strings.ToUpper(strings.TrimSpace(inviteCode)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInviteCodeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	if err := checkEstablishmentActive(s.establishmentRepo, establishment.ID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.signupRepo.GetPendingClientSignup(establishment.ID, req.DNI); err == nil {
+		return nil, ErrClientSignupPending
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error retrieving client signups: %w", err)
+	}
+	if _, err := s.existingClient(req.Email, req.DNI, establishment.ID); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	signup := entities.ClientSignup{
+		EstablishmentID: establishment.ID,
+		DNI:             req.DNI,
+		Email:           req.Email,
+		Name:            req.Name,
+		Address:         req.Address,
+		Phone:           req.Phone,
+		Password:        string(hashedPassword),
+		Status:          enums.SignupPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.signupRepo.CreateClientSignup(&signup); err != nil {
+		return nil, fmt.Errorf("error creating client signup: %w", err)
+	}
+
+	lang := establishmentLanguage(s.settingsRepo, establishment.ID)
+	admin, err := s.userRepo.GetUserByID(establishment.AdminID)
+	if err == nil {
+		err = s.mailer.Send(mail.Message{
+			To:      admin.Email,
+			Subject: i18n.T(lang, "mail.client_signup.subject", establishment.Name, signup.Name),
+			Body:    mailBody(lang, admin.Name, "mail.client_signup.body", signup.Name, signup.DNI, establishment.Name),
+		})
+	}
+	if err != nil {
+		log.Printf("admin could not be notified of client signup %d: %v", signup.ID, err)
+	}
+	s.notifyClient(&signup, lang, i18n.T(lang, "mail.signup_received.subject", establishment.Name),
+		i18n.T(lang, "mail.signup_received.body", establishment.Name))
+	return clientSignupToResponse(&signup), nil
+}
+
+// GetClientSignups lists the client signups of the admin's establishment, only those in a status
+// unless status is empty.
+func (s *clientSignupService) GetClientSignups(adminID, branchID uint, status enums.SignupStatus) ([]response.ClientSignupResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	signups, err := s.signupRepo.GetClientSignupsByEstablishmentID(establishment.ID, status)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client signups: %w", err)
+	}
+
+	signupResponses := make([]response.ClientSignupResponse, len(signups))
+	for i := range signups {
+		signupResponses[i] = *clientSignupToResponse(&signups[i])
+	}
+	return signupResponses, nil
+}
+
+// ApproveClientSignup approves a pending client signup of the admin's establishment, opening the
+// client's credit account with the given terms, and lets the client know.
+func (s *clientSignupService) ApproveClientSignup(adminID, branchID, signupID uint, req request.ApproveClientSignupRequest) (*response.ClientSignupResponse, error) {
+	signup, err := s.findClientSignup(adminID, branchID, signupID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEstablishmentActive(s.establishmentRepo, signup.EstablishmentID); err != nil {
+		return nil, err
+	}
+	interestRate, err := interestRateOrDefault(s.settingsRepo, signup.EstablishmentID, req.InterestRate)
+	if err != nil {
+		return nil, err
+	}
+	// The client may have been registered by an admin since signing up
+	existing, err := s.existingClient(signup.Email, signup.DNI, signup.EstablishmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	creditAccount := &entities.CreditAccount{
+		EstablishmentID:         signup.EstablishmentID,
+		CreditLimit:             req.CreditLimit,
+		MonthlyDueDate:          req.MonthlyDueDate,
+		InterestRate:            interestRate,
+		InterestType:            req.InterestType,
+		CompoundingPeriod:       compoundingPeriodOrDefault(req.CompoundingPeriod),
+		CreditType:              req.CreditType,
+		GracePeriod:             req.GracePeriod,
+		LastInterestAccrualDate: now,
+		LateFeePercentage:       req.LateFeePercentage,
+	}
+	var user *entities.User
+	if existing != nil {
+		creditAccount.ClientID = existing.ID
+	} else {
+		user = &entities.User{
+			DNI:       signup.DNI,
+			Email:     signup.Email,
+			Password:  signup.Password,
+			Name:      signup.Name,
+			Address:   signup.Address,
+			Phone:     signup.Phone,
+			Rol:       enums.CLIENT,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	signup.DecidedByID, signup.DecidedAt, signup.UpdatedAt = &adminID, &now, now
+	if err := s.signupRepo.ApproveClientSignup(signup, user, creditAccount); err != nil {
+		if errors.Is(err, repository.ErrSignupDecided) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error approving client signup: %w", err)
+	}
+
+	lang := establishmentLanguage(s.settingsRepo, signup.EstablishmentID)
+	s.notifyClient(signup, lang, i18n.T(lang, "mail.signup_approved.subject"),
+		i18n.T(lang, "mail.signup_approved.body", creditAccount.CreditLimit, creditAccount.MonthlyDueDate))
+	if user != nil {
+		s.verificationService.StartVerification(user, enums.ContactEmail, enums.ContactPhone)
+	}
+	return clientSignupToResponse(signup), nil
+}
+
+// RejectClientSignup rejects a pending client signup of the admin's establishment, and lets the
+// client know why.
+func (s *clientSignupService) RejectClientSignup(adminID, branchID, signupID uint, reason string) (*response.ClientSignupResponse, error) {
+	signup, err := s.findClientSignup(adminID, branchID, signupID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	signup.Status, signup.DecidedByID, signup.DecidedAt, signup.Reason, signup.UpdatedAt = enums.SignupRejected, &adminID, &now, reason, now
+	rejected, err := s.signupRepo.RejectClientSignup(signup)
+	if err != nil {
+		return nil, fmt.Errorf("error rejecting client signup: %w", err)
+	}
+	if !rejected {
+		return nil, ErrClientSignupDecided
+	}
+
+	lang := establishmentLanguage(s.settingsRepo, signup.EstablishmentID)
+	s.notifyClient(signup, lang, i18n.T(lang, "mail.signup_rejected.subject"), i18n.T(lang, "mail.signup_rejected.body", reason))
+	return clientSignupToResponse(signup), nil
+}
+
+// existingClient retrieves the client user already registered with an email, nil if there is none.
+// It fails if the email belongs to someone else, or the client already has an account in the establishment.
+func (s *clientSignupService) existingClient(email, dni string, establishmentID uint) (*entities.User, error) {
+	existing, err := s.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	if existing.Rol != enums.CLIENT || existing.DNI != dni {
+		return nil, ErrEmailInUse
+	}
+	if _, err := s.creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(existing.ID, establishmentID); err == nil {
+		return nil, ErrCreditAccountExists
+	}
+	return existing, nil
+}
+
+// findClientSignup retrieves a pending client signup of the admin's establishment.
+func (s *clientSignupService) findClientSignup(adminID, branchID, signupID uint) (*entities.ClientSignup, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	signup, err := s.signupRepo.GetClientSignupByID(signupID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && signup.EstablishmentID != establishment.ID) {
+		return nil, ErrClientSignupNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client signup: %w", err)
+	}
+	if signup.Status != enums.SignupPending {
+		return nil, ErrClientSignupDecided
+	}
+	return signup, nil
+}
+
+// notifyClient emails the client who signed up, greeting them in lang. Failures are only logged, the
+// signup stands either way.
+func (s *clientSignupService) notifyClient(signup *entities.ClientSignup, lang i18n.Language, subject, details string) {
+	err := s.mailer.Send(mail.Message{
+		To:      signup.Email,
+		Subject: subject,
+		Body:    i18n.T(lang, "mail.greeting", signup.Name) + "\n\n" + details + "\n",
+	})
+	if err != nil {
+		log.Printf("client could not be notified of client signup %d: %v", signup.ID, err)
+	}
+}
+
+// generateInviteCode returns a new random invite code, in capitals to be easy to type.
+func generateInviteCode() (string, error) {
+	var code strings.Builder
+	max := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for i := 0; i < inviteCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("error generating invite code: %w", err)
+		}
+		code.WriteByte(inviteCodeAlphabet[n.Int64()])
+	}
+	return code.String(), nil
+}
+
+func inviteCodeToResponse(establishmentID uint, code string) *response.InviteCodeResponse {
+	return &response.InviteCodeResponse{
+		EstablishmentID: establishmentID,
+		InviteCode:      code,
+		SignupPath:      fmt.Sprintf("/establishments/%s/client-signup", code),
+	}
+}
+
+func clientSignupToResponse(signup *entities.ClientSignup) *response.ClientSignupResponse {
+	return &response.ClientSignupResponse{
+		ID:              signup.ID,
+		EstablishmentID: signup.EstablishmentID,
+		DNI:             signup.DNI,
+		Email:           signup.Email,
+		Name:            signup.Name,
+		Address:         signup.Address,
+		Phone:           signup.Phone,
+		Status:          signup.Status,
+		DecidedByID:     signup.DecidedByID,
+		DecidedAt:       signup.DecidedAt,
+		Reason:          signup.Reason,
+		CreditAccountID: signup.CreditAccountID,
+		CreatedAt:       signup.CreatedAt,
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ClientTagService handles the tags establishments label their clients with, e.g. VIP or wholesale.
+type ClientTagService interface {
+	GetClientTags(adminID, branchID uint) ([]response.ClientTagResponse, error)
+	CreateClientTag(adminID, branchID uint, req request.ClientTagRequest) (*response.ClientTagResponse, error)
+	UpdateClientTag(adminID, branchID, tagID uint, req request.ClientTagRequest) (*response.ClientTagResponse, error)
+	DeleteClientTag(adminID, branchID, tagID uint) error
+	GetTagsOfClient(adminID, branchID, clientID uint) ([]response.ClientTagResponse, error)
+	SetClientTags(adminID, branchID, clientID uint, req request.SetClientTagsRequest) ([]response.ClientTagResponse, error)
+}
+
+type clientTagService struct {
+	tagRepo           repository.ClientTagRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+}
+
+// NewClientTagService creates a new instance of ClientTagService.
+func NewClientTagService(tagRepo repository.ClientTagRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock) ClientTagService {
+	return &clientTagService{tagRepo: tagRepo, creditAccountRepo: creditAccountRepo, establishmentRepo: establishmentRepo, clock: clock}
+}
+
+// GetClientTags retrieves the client tags of the admin's establishment.
+func (s *clientTagService) GetClientTags(adminID, branchID uint) ([]response.ClientTagResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.tagRepo.GetClientTagsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client tags: %w", err)
+	}
+	return clientTagsToResponse(tags), nil
+}
+
+// CreateClientTag adds a client tag to the admin's establishment.
+func (s *clientTagService) CreateClientTag(adminID, branchID uint, req request.ClientTagRequest) (*response.ClientTagResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkNameAvailable(establishment.ID, 0, name); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	tag := entities.ClientTag{EstablishmentID: establishment.ID, Name: name, CreatedAt: now, UpdatedAt: now}
+	if err := s.tagRepo.CreateClientTag(&tag); err != nil {
+		return nil, fmt.Errorf("error creating client tag: %w", err)
+	}
+	return clientTagToResponse(&tag), nil
+}
+
+// UpdateClientTag renames a client tag of the admin's establishment.
+func (s *clientTagService) UpdateClientTag(adminID, branchID, tagID uint, req request.ClientTagRequest) (*response.ClientTagResponse, error) {
+	tag, err := s.findClientTag(adminID, branchID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkNameAvailable(tag.EstablishmentID, tag.ID, name); err != nil {
+		return nil, err
+	}
+
+	tag.Name, tag.UpdatedAt = name, s.clock.Now()
+	if err := s.tagRepo.UpdateClientTag(tag); err != nil {
+		return nil, fmt.Errorf("error updating client tag: %w", err)
+	}
+	return clientTagToResponse(tag), nil
+}
+
+// DeleteClientTag deletes a client tag of the admin's establishment, untagging its clients.
+func (s *clientTagService) DeleteClientTag(adminID, branchID, tagID uint) error {
+	tag, err := s.findClientTag(adminID, branchID, tagID)
+	if err != nil {
+		return err
+	}
+	if err := s.tagRepo.DeleteClientTag(tag.ID); err != nil {
+		return fmt.Errorf("error deleting client tag: %w", err)
+	}
+	return nil
+}
+
+// GetTagsOfClient retrieves the tags a client of the admin's establishment has in it.
+func (s *clientTagService) GetTagsOfClient(adminID, branchID, clientID uint) ([]response.ClientTagResponse, error) {
+	establishment, err := establishmentClient(s.establishmentRepo, s.creditAccountRepo, adminID, branchID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.tagRepo.GetTagsOfClient(establishment.ID, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client tags: %w", err)
+	}
+	return clientTagsToResponse(tags), nil
+}
+
+// SetClientTags replaces the tags a client of the admin's establishment has in it, which must all be
+// tags of the establishment.
+func (s *clientTagService) SetClientTags(adminID, branchID, clientID uint, req request.SetClientTagsRequest) ([]response.ClientTagResponse, error) {
+	establishment, err := establishmentClient(s.establishmentRepo, s.creditAccountRepo, adminID, branchID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.tagRepo.GetClientTagsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client tags: %w", err)
+	}
+	establishmentTags := make(map[uint]bool, len(tags))
+	for _, tag := range tags {
+		establishmentTags[tag.ID] = true
+	}
+	for _, tagID := range req.TagIDs {
+		if !establishmentTags[tagID] {
+			return nil, ErrClientTagNotFound
+		}
+	}
+
+	if err := s.tagRepo.SetClientTags(establishment.ID, clientID, req.TagIDs, s.clock.Now()); err != nil {
+		return nil, fmt.Errorf("error tagging client: %w", err)
+	}
+	clientTags, err := s.tagRepo.GetTagsOfClient(establishment.ID, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client tags: %w", err)
+	}
+	return clientTagsToResponse(clientTags), nil
+}
+
+// findClientTag retrieves a client tag of the admin's establishment.
+func (s *clientTagService) findClientTag(adminID, branchID, tagID uint) (*entities.ClientTag, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := s.tagRepo.GetClientTagByID(tagID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && tag.EstablishmentID != establishment.ID) {
+		return nil, ErrClientTagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client tag: %w", err)
+	}
+	return tag, nil
+}
+
+// checkNameAvailable rejects a blank name, or one another tag of the establishment than the one being
+// renamed already has. Names are compared ignoring case.
+func (s *clientTagService) checkNameAvailable(establishmentID, tagID uint, name string) error {
+	if name == "" {
+		return ErrInvalidClientTagName
+	}
+	tags, err := s.tagRepo.GetClientTagsByEstablishmentID(establishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving client tags: %w", err)
+	}
+	for _, tag := range tags {
+		if tag.ID != tagID && strings.EqualFold(tag.Name, name) {
+			return ErrClientTagExists
+		}
+	}
+	return nil
+}
+
+// establishmentClient retrieves the admin's establishment, or the selected branch, checking the client
+// holds a credit account in it, which makes them a client of it.
+func establishmentClient(establishmentRepo repository.EstablishmentRepository, creditAccountRepo repository.CreditAccountRepository, adminID, branchID, clientID uint) (*entities.Establishment, error) {
+	establishment, err := adminEstablishment(establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(clientID, establishment.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrClientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	return establishment, nil
+}
+
+func clientTagsToResponse(tags []entities.ClientTag) []response.ClientTagResponse {
+	tagResponses := make([]response.ClientTagResponse, len(tags))
+	for i := range tags {
+		tagResponses[i] = *clientTagToResponse(&tags[i])
+	}
+	return tagResponses
+}
+
+func clientTagToResponse(tag *entities.ClientTag) *response.ClientTagResponse {
+	return &response.ClientTagResponse{
+		ID:              tag.ID,
+		EstablishmentID: tag.EstablishmentID,
+		Name:            tag.Name,
+		CreatedAt:       tag.CreatedAt,
+		UpdatedAt:       tag.UpdatedAt,
+	}
+}
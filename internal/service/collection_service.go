@@ -0,0 +1,196 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// CollectionService gives admins the worklist of overdue clients to collect from, and the log of the
+// contacts made to them.
+type CollectionService interface {
+	GetCollectionsWorklist(adminID, branchID uint) ([]response.CollectionWorklistItem, error)
+	LogCollectionContact(adminID, branchID, clientID uint, req request.LogCollectionContactRequest) (*response.CollectionContactResponse, error)
+}
+
+type collectionService struct {
+	contactRepo       repository.CollectionContactRepository
+	creditAccountRepo repository.CreditAccountRepository
+	installmentRepo   repository.InstallmentRepository
+	promiseRepo       repository.PaymentPromiseRepository
+	establishmentRepo repository.EstablishmentRepository
+	holidayRepo       repository.HolidayRepository
+	clock             util.Clock
+}
+
+// NewCollectionService creates a new instance of CollectionService.
+func NewCollectionService(contactRepo repository.CollectionContactRepository, creditAccountRepo repository.CreditAccountRepository, installmentRepo repository.InstallmentRepository, promiseRepo repository.PaymentPromiseRepository, establishmentRepo repository.EstablishmentRepository, holidayRepo repository.HolidayRepository, clock util.Clock) CollectionService {
+	return &collectionService{
+		contactRepo:       contactRepo,
+		creditAccountRepo: creditAccountRepo,
+		installmentRepo:   installmentRepo,
+		promiseRepo:       promiseRepo,
+		establishmentRepo: establishmentRepo,
+		holidayRepo:       holidayRepo,
+		clock:             clock,
+	}
+}
+
+// GetCollectionsWorklist lists the overdue clients of the admin's establishment, or the selected branch,
+// in the order to work them: the most overdue first, then those owing the most, then those who broke
+// the most payment promises. Written-off accounts are collected from the bad-debt report instead.
+func (s *collectionService) GetCollectionsWorklist(adminID, branchID uint) ([]response.CollectionWorklistItem, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+
+	var longTermIDs []uint
+	for i := range accounts {
+		if accounts[i].CreditType == enums.LongTerm {
+			longTermIDs = append(longTermIDs, accounts[i].ID)
+		}
+	}
+	installmentsByAccount := make(map[uint][]entities.Installment, len(longTermIDs))
+	if len(longTermIDs) > 0 {
+		installments, err := s.installmentRepo.GetInstallmentsByCreditAccountIDs(longTermIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving installments: %w", err)
+		}
+		for _, installment := range installments {
+			installmentsByAccount[installment.CreditAccountID] = append(installmentsByAccount[installment.CreditAccountID], installment)
+		}
+	}
+
+	days, err := businessDays(s.holidayRepo, establishment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	var overdueIDs []uint
+	items := make([]response.CollectionWorklistItem, 0)
+	for i := range accounts {
+		account := &accounts[i]
+		if account.WrittenOffAt != nil {
+			continue
+		}
+		daysOverdue := daysOverdueWithInstallments(account, installmentsByAccount[account.ID], days, now)
+		if daysOverdue == 0 {
+			continue
+		}
+
+		item := response.CollectionWorklistItem{
+			ClientID:        account.ClientID,
+			CreditAccountID: account.ID,
+			DaysOverdue:     daysOverdue,
+			Balance:         roundCurrency(account.CurrentBalance - account.AccountCredit),
+			IsBlocked:       account.IsBlocked,
+		}
+		if account.Client != nil {
+			item.Name = account.Client.Name
+			item.DNI = account.Client.DNI
+			item.Phone = account.Client.Phone
+			item.Email = account.Client.Email
+			item.Address = account.Client.Address
+		}
+		items = append(items, item)
+		overdueIDs = append(overdueIDs, account.ID)
+	}
+
+	brokenPromises, err := s.promiseRepo.CountBrokenPaymentPromisesByCreditAccountIDs(overdueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error counting broken payment promises: %w", err)
+	}
+	lastContacts, err := s.contactRepo.GetLastCollectionContacts(overdueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving collection contacts: %w", err)
+	}
+	for i := range items {
+		items[i].BrokenPromises = brokenPromises[items[i].CreditAccountID]
+		if contact, ok := lastContacts[items[i].CreditAccountID]; ok {
+			contactedAt, outcome := contact.ContactedAt, contact.Outcome
+			items[i].LastContactAt, items[i].LastContactOutcome = &contactedAt, &outcome
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].DaysOverdue != items[j].DaysOverdue {
+			return items[i].DaysOverdue > items[j].DaysOverdue
+		}
+		if items[i].Balance != items[j].Balance {
+			return items[i].Balance > items[j].Balance
+		}
+		return items[i].BrokenPromises > items[j].BrokenPromises
+	})
+	for i := range items {
+		items[i].Priority = i + 1
+	}
+	return items, nil
+}
+
+// LogCollectionContact records a call, visit or message to a client of the admin's establishment, or
+// the selected branch, and its outcome. Contacts are logged as they happen or afterwards, never ahead.
+func (s *collectionService) LogCollectionContact(adminID, branchID, clientID uint, req request.LogCollectionContactRequest) (*response.CollectionContactResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(clientID, establishment.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+
+	now := s.clock.Now()
+	contactedAt := now
+	if req.ContactedAt != nil {
+		if req.ContactedAt.After(now) {
+			return nil, ErrInvalidContactDate
+		}
+		contactedAt = *req.ContactedAt
+	}
+	contact := entities.CollectionContact{
+		CreditAccountID: creditAccount.ID,
+		EstablishmentID: establishment.ID,
+		ClientID:        creditAccount.ClientID,
+		AdminID:         adminID,
+		Type:            req.Type,
+		Outcome:         req.Outcome,
+		Notes:           strings.TrimSpace(req.Notes),
+		ContactedAt:     contactedAt,
+		CreatedAt:       now,
+	}
+	if err := s.contactRepo.CreateCollectionContact(&contact); err != nil {
+		return nil, fmt.Errorf("error logging collection contact: %w", err)
+	}
+	return collectionContactToResponse(&contact), nil
+}
+
+func collectionContactToResponse(contact *entities.CollectionContact) *response.CollectionContactResponse {
+	return &response.CollectionContactResponse{
+		ID:              contact.ID,
+		ClientID:        contact.ClientID,
+		CreditAccountID: contact.CreditAccountID,
+		AdminID:         contact.AdminID,
+		Type:            contact.Type,
+		Outcome:         contact.Outcome,
+		Notes:           contact.Notes,
+		ContactedAt:     contact.ContactedAt,
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/sms"
+	"ApiRestFinance/internal/util"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	verificationCodeDigits = 6
+	verificationCodeTTL    = 15 * time.Minute
+	// verificationResendCooldown is how long a user waits before asking for another code
+	verificationResendCooldown = time.Minute
+	maxVerificationAttempts    = 5
+)
+
+// ContactVerificationService verifies that the email and phone of users are theirs, with codes sent
+// to them. Statements are only emailed to verified emails.
+type ContactVerificationService interface {
+	GetContactVerification(userID uint) (*response.ContactVerificationResponse, error)
+	SendVerificationCode(userID uint, channel enums.ContactChannel, lang i18n.Language) (*response.ContactVerificationResponse, error)
+	VerifyContact(userID uint, channel enums.ContactChannel, code string) (*response.ContactVerificationResponse, error)
+	StartVerification(user *entities.User, channels ...enums.ContactChannel)
+}
+
+type contactVerificationService struct {
+	userRepo         repository.UserRepository
+	verificationRepo repository.ContactVerificationRepository
+	settingsService  EstablishmentSettingsService
+	mailer           mail.Sender
+	texter           sms.Sender
+	clock            util.Clock
+}
+
+// NewContactVerificationService creates a new instance of ContactVerificationService. Email codes
+// are sent by mailer and phone codes by texter.
+func NewContactVerificationService(userRepo repository.UserRepository, verificationRepo repository.ContactVerificationRepository, settingsService EstablishmentSettingsService, mailer mail.Sender, texter sms.Sender, clock util.Clock) ContactVerificationService {
+	return &contactVerificationService{userRepo: userRepo, verificationRepo: verificationRepo, settingsService: settingsService, mailer: mailer, texter: texter, clock: clock}
+}
+
+// GetContactVerification tells which of the email and phone of a user are verified.
+func (s *contactVerificationService) GetContactVerification(userID uint) (*response.ContactVerificationResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	return contactVerificationToResponse(user), nil
+}
+
+// SendVerificationCode sends a new code to the email or phone of a user, replacing the previous one.
+// It fails if the contact is already verified or a code was sent less than a minute ago.
+func (s *contactVerificationService) SendVerificationCode(userID uint, channel enums.ContactChannel, lang i18n.Language) (*response.ContactVerificationResponse, error) {
+	if !validContactChannel(channel) {
+		return nil, ErrInvalidContactChannel
+	}
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	if contactVerified(user, channel) {
+		return nil, ErrContactAlreadyVerified
+	}
+	if channel == enums.ContactPhone && strings.TrimSpace(user.Phone) == "" {
+		return nil, ErrNoPhone
+	}
+
+	verification, err := s.verificationRepo.GetContactVerification(userID, channel)
+	switch {
+	case err == nil && verification.Destination == contactDestination(user, channel) && s.clock.Now().Sub(verification.SentAt) < verificationResendCooldown:
+		return nil, ErrVerificationCodeTooSoon
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("error retrieving verification code: %w", err)
+	}
+
+	if err := s.sendCode(user, channel, lang); err != nil {
+		return nil, err
+	}
+	return contactVerificationToResponse(user), nil
+}
+
+// VerifyContact marks the email or phone of a user as verified with the code last sent to it. Codes
+// expire after 15 minutes or 5 wrong attempts.
+func (s *contactVerificationService) VerifyContact(userID uint, channel enums.ContactChannel, code string) (*response.ContactVerificationResponse, error) {
+	if !validContactChannel(channel) {
+		return nil, ErrInvalidContactChannel
+	}
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	if contactVerified(user, channel) {
+		return nil, ErrContactAlreadyVerified
+	}
+
+	verification, err := s.verificationRepo.GetContactVerification(userID, channel)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVerificationCodeExpired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving verification code: %w", err)
+	}
+	// A code sent to an email or phone the user changed since doesn't verify the new one
+	if verification.Destination != contactDestination(user, channel) || verification.Attempts >= maxVerificationAttempts || !s.clock.Now().Before(verification.ExpiresAt) {
+		return nil, ErrVerificationCodeExpired
+	}
+	if subtle.ConstantTimeCompare([]byte(hashVerificationCode(userID, channel, strings.TrimSpace(code))), []byte(verification.CodeHash)) != 1 {
+		if err := s.verificationRepo.AddFailedAttempt(verification.ID); err != nil {
+			return nil, fmt.Errorf("error recording verification attempt: %w", err)
+		}
+		return nil, ErrInvalidVerificationCode
+	}
+
+	now := s.clock.Now()
+	confirmed, err := s.verificationRepo.ConfirmContact(verification, now)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying contact: %w", err)
+	}
+	if !confirmed {
+		return nil, ErrVerificationCodeExpired
+	}
+	if channel == enums.ContactPhone {
+		user.PhoneVerifiedAt = &now
+	} else {
+		user.EmailVerifiedAt = &now
+	}
+	return contactVerificationToResponse(user), nil
+}
+
+// StartVerification sends codes to the channels of a user whose email or phone were just created
+// or changed, and so are unverified, in the language of the user's establishment. Failures are only
+// logged: the user can ask for another code.
+func (s *contactVerificationService) StartVerification(user *entities.User, channels ...enums.ContactChannel) {
+	lang := s.settingsService.UserLanguage(user.ID, user.Rol, 0, 0)
+	for _, channel := range channels {
+		if contactVerified(user, channel) || contactDestination(user, channel) == "" {
+			continue
+		}
+		if err := s.sendCode(user, channel, lang); err != nil {
+			log.Printf("verification code for the %s of user %d could not be sent: %v", channel, user.ID, err)
+		}
+	}
+}
+
+// sendCode stores a new code for a channel of user and sends it.
+func (s *contactVerificationService) sendCode(user *entities.User, channel enums.ContactChannel, lang i18n.Language) error {
+	code, err := generateVerificationCode()
+	if err != nil {
+		return err
+	}
+	now := s.clock.Now()
+	verification := entities.ContactVerification{
+		UserID:      user.ID,
+		Channel:     channel,
+		Destination: contactDestination(user, channel),
+		CodeHash:    hashVerificationCode(user.ID, channel, code),
+		ExpiresAt:   now.Add(verificationCodeTTL),
+		SentAt:      now,
+		CreatedAt:   now,
+	}
+	if err := s.verificationRepo.SaveContactVerification(&verification); err != nil {
+		return fmt.Errorf("error saving verification code: %w", err)
+	}
+
+	minutes := int(verificationCodeTTL / time.Minute)
+	if channel == enums.ContactPhone {
+		_, err = s.texter.Send(sms.Message{To: verification.Destination, Body: i18n.T(lang, "sms.contact_verification", code, minutes)})
+	} else {
+		err = s.mailer.Send(mail.Message{
+			To:      verification.Destination,
+			Subject: i18n.T(lang, "mail.contact_verification.subject", code),
+			Body:    mailBody(lang, user.Name, "mail.contact_verification.body", code, minutes),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("error sending verification code: %w", err)
+	}
+	return nil
+}
+
+func validContactChannel(channel enums.ContactChannel) bool {
+	return channel == enums.ContactEmail || channel == enums.ContactPhone
+}
+
+// contactDestination returns the email or phone of user a channel sends to.
+func contactDestination(user *entities.User, channel enums.ContactChannel) string {
+	if channel == enums.ContactPhone {
+		return strings.TrimSpace(user.Phone)
+	}
+	return user.Email
+}
+
+func contactVerified(user *entities.User, channel enums.ContactChannel) bool {
+	if channel == enums.ContactPhone {
+		return user.PhoneVerifiedAt != nil
+	}
+	return user.EmailVerifiedAt != nil
+}
+
+// generateVerificationCode returns a random numeric code, easy to type on a phone.
+func generateVerificationCode() (string, error) {
+	var code strings.Builder
+	for i := 0; i < verificationCodeDigits; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("error generating verification code: %w", err)
+		}
+		code.WriteString(digit.String())
+	}
+	return code.String(), nil
+}
+
+// hashVerificationCode hashes a code with the user and channel it was sent for, so a code only
+// verifies what it was sent to.
+func hashVerificationCode(userID uint, channel enums.ContactChannel, code string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", userID, channel, code)))
+	return hex.EncodeToString(sum[:])
+}
+
+func contactVerificationToResponse(user *entities.User) *response.ContactVerificationResponse {
+	return &response.ContactVerificationResponse{
+		Email:           user.Email,
+		EmailVerified:   user.EmailVerifiedAt != nil,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Phone:           user.Phone,
+		PhoneVerified:   user.PhoneVerifiedAt != nil,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"fmt"
+)
+
+// CreditAccountEventService handles the history of changes to the terms and status of credit accounts.
+type CreditAccountEventService interface {
+	GetCreditAccountEvents(creditAccountID uint, page, pageSize int) ([]response.CreditAccountEventResponse, int, error)
+}
+
+type creditAccountEventService struct {
+	eventRepo repository.CreditAccountEventRepository
+}
+
+// NewCreditAccountEventService creates a new instance of CreditAccountEventService.
+func NewCreditAccountEventService(eventRepo repository.CreditAccountEventRepository) CreditAccountEventService {
+	return &creditAccountEventService{eventRepo: eventRepo}
+}
+
+// GetCreditAccountEvents retrieves a page of the history of a credit account, newest first, and the
+// number of events across all pages.
+func (s *creditAccountEventService) GetCreditAccountEvents(creditAccountID uint, page, pageSize int) ([]response.CreditAccountEventResponse, int, error) {
+	events, total, err := s.eventRepo.GetCreditAccountEvents(creditAccountID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error retrieving credit account events: %w", err)
+	}
+
+	eventResponses := make([]response.CreditAccountEventResponse, len(events))
+	for i := range events {
+		eventResponses[i] = creditAccountEventToResponse(&events[i])
+	}
+	return eventResponses, int(total), nil
+}
+
+func creditAccountEventToResponse(accountEvent *entities.CreditAccountEvent) response.CreditAccountEventResponse {
+	eventResponse := response.CreditAccountEventResponse{
+		ID:              accountEvent.ID,
+		CreditAccountID: accountEvent.CreditAccountID,
+		Type:            accountEvent.Type,
+		OldValue:        accountEvent.OldValue,
+		NewValue:        accountEvent.NewValue,
+		Description:     creditAccountEventDescription(accountEvent),
+		Reason:          accountEvent.Reason,
+		Automatic:       accountEvent.Automatic,
+		ActorID:         accountEvent.ActorID,
+		CreatedAt:       accountEvent.CreatedAt,
+	}
+	if accountEvent.Actor != nil {
+		eventResponse.ActorName = accountEvent.Actor.Name
+	}
+	return eventResponse
+}
+
+// creditAccountEventDescription describes an event for the people reading the history, e.g.
+// "Credit limit changed from 1500.00 to 1000.00".
+func creditAccountEventDescription(accountEvent *entities.CreditAccountEvent) string {
+	changed := func(term, format string) string {
+		if accountEvent.OldValue == nil || accountEvent.NewValue == nil {
+			return term + " changed"
+		}
+		return fmt.Sprintf("%s changed from "+format+" to "+format, term, *accountEvent.OldValue, *accountEvent.NewValue)
+	}
+	switch accountEvent.Type {
+	case enums.CreditAccountLimitChanged:
+		return changed("Credit limit", "%.2f")
+	case enums.CreditAccountRateChanged:
+		return changed("Interest rate", "%.2f%%")
+	case enums.CreditAccountBlocked:
+		return "Account blocked"
+	case enums.CreditAccountUnblocked:
+		return "Account unblocked"
+	case enums.CreditAccountClosed:
+		return "Account closed"
+	case enums.CreditAccountReopened:
+		return "Account reopened"
+	}
+	return string(accountEvent.Type)
+}
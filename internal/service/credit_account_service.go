@@ -1,14 +1,21 @@
 package service
 
 import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/event"
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // CreditAccountService handles credit account-related operations.
@@ -17,36 +24,68 @@ type CreditAccountService interface {
 	GetCreditAccountByID(id uint) (*response.CreditAccountResponse, error)
 	UpdateCreditAccount(id uint, req request.UpdateCreditAccountRequest) (*response.CreditAccountResponse, error)
 	DeleteCreditAccount(id uint) error
-	GetCreditAccountsByEstablishmentID(establishmentID uint) ([]response.CreditAccountResponse, error)
-	GetCreditAccountByClientID(clientID uint) (*response.CreditAccountResponse, error)
+	GetCreditAccountsByEstablishmentID(establishmentID uint, includes CreditAccountIncludes) ([]response.CreditAccountResponse, error)
+	SearchEstablishmentClients(adminID, branchID uint, query string, tags []string, page, pageSize int) ([]response.ClientSearchResponse, int, error)
+	GetCreditAccountByClientID(clientID, establishmentID uint) (*response.CreditAccountResponse, error)
 	ApplyInterestToAccount(creditAccountID uint) error
 	ApplyLateFeeToAccount(creditAccountID uint) error
-	GetOverdueCreditAccounts(establishmentID uint) ([]response.CreditAccountResponse, error)
-	ProcessPurchase(creditAccountID uint, amount float64, description string) error
-	ProcessPayment(creditAccountID uint, amount float64, description string) error
+	GetOverdueCreditAccounts(establishmentID uint, includes CreditAccountIncludes) ([]response.CreditAccountResponse, error)
+	BlockCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error)
+	UnblockCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error)
+	WriteOffCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error)
+	CloseCreditAccount(userID uint, role enums.Role, creditAccountID uint, req request.CloseCreditAccountRequest) (*response.CreditAccountResponse, error)
+	ReopenCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error)
+	BlockOverdueAccounts() error
+	ProcessPurchase(creditAccountID uint, amount float64, description, pin string) error
+	ProcessPayment(creditAccountID uint, amount float64, paymentMethod enums.PaymentMethod, description string) error
 	GetAdminDebtSummary(establishmentID uint) ([]response.AdminDebtSummary, error)
 	CalculateDueDate(account entities.CreditAccount) (time.Time, error)
 	GetNumberOfDues(account entities.CreditAccount) int
-	UpdateCreditAccountByClientID(clientID uint, req request.UpdateCreditAccountRequest) (*response.CreditAccountResponse, error)
+	UpdateCreditAccountByClientID(clientID, establishmentID uint, req request.UpdateCreditAccountRequest) (*response.CreditAccountResponse, error)
+	PatchCreditAccountByClientID(clientID, establishmentID uint, req request.PatchCreditAccountRequest) (*response.CreditAccountResponse, error)
 	NewEstablishmentResponse(establishment *entities.Establishment) *response.EstablishmentResponse
 }
 
+// CreditAccountIncludes tells which nested objects the credit account responses of a list embed.
+// Leaving them out also saves looking them up.
+type CreditAccountIncludes struct {
+	Client        bool
+	Establishment bool // With its admin
+}
+
+// AllCreditAccountIncludes embeds every nested object, as single credit accounts always do.
+var AllCreditAccountIncludes = CreditAccountIncludes{Client: true, Establishment: true}
+
 type creditAccountService struct {
 	creditAccountRepo repository.CreditAccountRepository
 	transactionRepo   repository.TransactionRepository
 	installmentRepo   repository.InstallmentRepository
 	clientRepo        repository.ClientRepository
 	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	promiseRepo       repository.PaymentPromiseRepository
+	templateRepo      repository.CreditTemplateRepository
+	holidayRepo       repository.HolidayRepository
+	pinService        PurchasePinService
+	clock             util.Clock
+	bus               event.Bus
 }
 
 // NewCreditAccountService creates a new instance of CreditAccountService.
-func NewCreditAccountService(creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository, clientRepo repository.ClientRepository, establishmentRepo repository.EstablishmentRepository) CreditAccountService {
+func NewCreditAccountService(creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository, clientRepo repository.ClientRepository, establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository, promiseRepo repository.PaymentPromiseRepository, templateRepo repository.CreditTemplateRepository, holidayRepo repository.HolidayRepository, pinService PurchasePinService, clock util.Clock, bus event.Bus) CreditAccountService {
 	return &creditAccountService{
 		creditAccountRepo: creditAccountRepo,
 		transactionRepo:   transactionRepo,
 		installmentRepo:   installmentRepo,
 		clientRepo:        clientRepo,
 		establishmentRepo: establishmentRepo,
+		settingsRepo:      settingsRepo,
+		promiseRepo:       promiseRepo,
+		templateRepo:      templateRepo,
+		holidayRepo:       holidayRepo,
+		pinService:        pinService,
+		clock:             clock,
+		bus:               bus,
 	}
 }
 
@@ -69,6 +108,11 @@ func (s *creditAccountService) CreateCreditAccount(req request.CreateCreditAccou
 		return nil, fmt.Errorf("establishment with ID %d not found", establishmentID)
 	}
 
+	// A client can hold accounts in several establishments, but only one in each
+	if _, err := s.creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(client.ID, establishment.ID); err == nil {
+		return nil, ErrCreditAccountExists
+	}
+
 	creditAccount := entities.CreditAccount{
 		EstablishmentID:         establishment.ID,
 		ClientID:                client.ID,
@@ -76,13 +120,22 @@ func (s *creditAccountService) CreateCreditAccount(req request.CreateCreditAccou
 		MonthlyDueDate:          req.MonthlyDueDate,
 		InterestRate:            req.InterestRate,
 		InterestType:            req.InterestType,
+		CompoundingPeriod:       req.CompoundingPeriod,
 		CreditType:              req.CreditType,
 		GracePeriod:             req.GracePeriod,
 		IsBlocked:               false,
-		LastInterestAccrualDate: time.Now(),
+		LastInterestAccrualDate: s.clock.Now(),
 		CurrentBalance:          req.CreditLimit,
 		LateFeePercentage:       establishment.LateFeePercentage,
 	}
+	if err := applyCreditTemplate(s.templateRepo, req.CreditTemplateID, &creditAccount); err != nil {
+		return nil, err
+	}
+	creditAccount.InterestRate, err = interestRateOrDefault(s.settingsRepo, establishment.ID, creditAccount.InterestRate)
+	if err != nil {
+		return nil, err
+	}
+	creditAccount.CompoundingPeriod = compoundingPeriodOrDefault(creditAccount.CompoundingPeriod)
 
 	err = s.creditAccountRepo.CreateCreditAccount(&creditAccount)
 	if err != nil {
@@ -99,74 +152,330 @@ func (s *creditAccountService) GetCreditAccountByID(id uint) (*response.CreditAc
 		return nil, err
 	}
 
-	return s.creditAccountToResponse(creditAccount), nil
+	return s.accountResponseWithHistory(creditAccount)
 }
 
-// UpdateCreditAccount updates an existing credit account.
+// UpdateCreditAccount updates an existing credit account, if it still is at the version of the request.
 func (s *creditAccountService) UpdateCreditAccount(id uint, req request.UpdateCreditAccountRequest) (*response.CreditAccountResponse, error) {
+	patch := req.Patch()
+	if patch.Version == nil {
+		return nil, ErrVersionRequired
+	}
 	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(id)
 	if err != nil {
 		return nil, err
 	}
+	return s.patchCreditAccount(creditAccount, patch)
+}
 
-	// Update fields only if they are provided in the request
-	if req.CreditLimit > 0 {
-		creditAccount.CreditLimit = req.CreditLimit
+// DeleteCreditAccount deletes a credit account.
+func (s *creditAccountService) DeleteCreditAccount(id uint) error {
+	if err := s.creditAccountRepo.DeleteCreditAccount(id); err != nil {
+		return err
 	}
-	if req.MonthlyDueDate > 0 {
-		creditAccount.MonthlyDueDate = req.MonthlyDueDate
+	publishAccountEvent(s.bus, s.clock, event.CreditAccountDeleted, id)
+	return nil
+}
+
+// GetCreditAccountsByEstablishmentID retrieves all credit accounts for an establishment, embedding
+// the nested objects of includes.
+func (s *creditAccountService) GetCreditAccountsByEstablishmentID(establishmentID uint, includes CreditAccountIncludes) ([]response.CreditAccountResponse, error) {
+	creditAccounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishmentID)
+	if err != nil {
+		return nil, err
 	}
-	if req.InterestRate > 0 {
-		creditAccount.InterestRate = req.InterestRate
+
+	var creditAccountResponses []response.CreditAccountResponse
+	for _, creditAccount := range creditAccounts {
+		creditAccountResponses = append(creditAccountResponses, *creditAccountToResponseWith(s.establishmentRepo, &creditAccount, includes))
 	}
-	if req.InterestType != "" {
-		creditAccount.InterestType = req.InterestType
+	return creditAccountResponses, nil
+}
+
+// GetCreditAccountByClientID retrieves a client's credit account in an establishment. An
+// establishmentID of 0 selects the client's only account.
+func (s *creditAccountService) GetCreditAccountByClientID(clientID, establishmentID uint) (*response.CreditAccountResponse, error) {
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+	if err != nil {
+		return nil, err
 	}
-	if req.CreditType != "" {
-		creditAccount.CreditType = req.CreditType
+	return s.accountResponseWithHistory(creditAccount)
+}
+
+// updateBlockReason is the block history reason of accounts blocked or unblocked through a credit account update.
+const updateBlockReason = "Changed in a credit account update"
+
+// BlockCreditAccount blocks a credit account of one of the admin's establishments, so it can't be
+// used for purchases until it is unblocked.
+func (s *creditAccountService) BlockCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error) {
+	return s.changeBlock(adminID, creditAccountID, true, reason)
+}
+
+// UnblockCreditAccount unblocks a credit account of one of the admin's establishments.
+func (s *creditAccountService) UnblockCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error) {
+	return s.changeBlock(adminID, creditAccountID, false, reason)
+}
+
+func (s *creditAccountService) changeBlock(adminID, creditAccountID uint, blocked bool, reason string) (*response.CreditAccountResponse, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
 	}
-	if req.GracePeriod >= 0 {
-		creditAccount.GracePeriod = req.GracePeriod
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
 	}
-	creditAccount.IsBlocked = req.IsBlocked
-	if req.LateFeePercentage >= 0 {
-		creditAccount.LateFeePercentage = req.LateFeePercentage
+	if !blocked && creditAccount.WrittenOffAt != nil {
+		return nil, ErrCreditAccountWrittenOff
 	}
 
-	err = s.creditAccountRepo.UpdateCreditAccount(creditAccount)
+	changed, err := s.setBlocked(creditAccount, blocked, reason, &adminID, false)
 	if err != nil {
 		return nil, err
 	}
+	switch {
+	case !changed && blocked:
+		return nil, ErrCreditAccountAlreadyBlocked
+	case !changed:
+		return nil, ErrCreditAccountNotBlocked
+	}
+	publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, creditAccount.ID)
+	return s.accountResponseWithHistory(creditAccount)
+}
+
+// WriteOffCreditAccount writes off the balance of a credit account of one of the admin's establishments
+// as bad debt. The account is blocked for good, leaves the receivables and shows in the bad-debt report,
+// and later payments recover the written-off amount.
+func (s *creditAccountService) WriteOffCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
 
-	return s.creditAccountToResponse(creditAccount), nil
+	wasBlocked := creditAccount.IsBlocked
+	err = s.creditAccountRepo.WriteOffCreditAccount(creditAccount, &entities.CreditAccountWriteOff{
+		Reason:         reason,
+		WrittenOffByID: adminID,
+	})
+	if errors.Is(err, repository.ErrBalanceChanged) {
+		return nil, ErrNothingToPayOff
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !wasBlocked {
+		publishAccountEvent(s.bus, s.clock, event.AccountBlocked, creditAccount.ID)
+	}
+	publishAccountEvent(s.bus, s.clock, event.AccountWrittenOff, creditAccount.ID)
+	return s.accountResponseWithHistory(creditAccount)
 }
 
-// DeleteCreditAccount deletes a credit account.
-func (s *creditAccountService) DeleteCreditAccount(id uint) error {
-	return s.creditAccountRepo.DeleteCreditAccount(id)
+// AccountBalanceError is returned when closing a credit account that still owes, with the quote to
+// pay it off. Quote is nil when only written-off debt is owed, which can't be paid off at once.
+type AccountBalanceError struct {
+	Quote *response.PayoffQuoteResponse
 }
 
-// GetCreditAccountsByEstablishmentID retrieves all credit accounts for an establishment.
-func (s *creditAccountService) GetCreditAccountsByEstablishmentID(establishmentID uint) ([]response.CreditAccountResponse, error) {
-	creditAccounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishmentID)
+func (e *AccountBalanceError) Error() string {
+	return ErrCreditAccountHasBalance.Error()
+}
+
+func (e *AccountBalanceError) Unwrap() error {
+	return ErrCreditAccountHasBalance
+}
+
+// CloseCreditAccount closes a credit account to purchases, for good unless an admin reopens it. Its
+// history stays available. Clients close their own accounts and admins those of their
+// establishments. Accounts that still owe are first paid off if req.PayoffAmount matches their
+// payoff quote, and fail with AccountBalanceError otherwise.
+func (s *creditAccountService) CloseCreditAccount(userID uint, role enums.Role, creditAccountID uint, req request.CloseCreditAccountRequest) (*response.CreditAccountResponse, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	reason := req.Reason
+	switch role {
+	case enums.CLIENT:
+		if creditAccount.ClientID != userID {
+			return nil, ErrCreditAccountNotFound
+		}
+		if reason == "" {
+			reason = "Closed by the client"
+		}
+	case enums.ADMIN:
+		if _, err := s.establishmentRepo.GetAdminEstablishment(userID, creditAccount.EstablishmentID); err != nil {
+			return nil, ErrCreditAccountNotFound
+		}
+		if reason == "" {
+			reason = "Closed by an admin"
+		}
+	default:
+		return nil, ErrCreditAccountNotFound
+	}
+	if creditAccount.Status == enums.AccountClosed {
+		return nil, ErrCreditAccountAlreadyClosed
+	}
+	if creditAccount.WrittenOffBalance > 0 {
+		return nil, &AccountBalanceError{}
 	}
 
-	var creditAccountResponses []response.CreditAccountResponse
-	for _, creditAccount := range creditAccounts {
-		creditAccountResponses = append(creditAccountResponses, *s.creditAccountToResponse(&creditAccount))
+	// Settle and close in one request when the client pays off what they owe
+	if creditAccount.CurrentBalance > 0 {
+		days, err := businessDays(s.holidayRepo, creditAccount.EstablishmentID)
+		if err != nil {
+			return nil, err
+		}
+		quote, err := payoffQuote(creditAccount, days, s.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+		if req.PayoffAmount == 0 {
+			return nil, &AccountBalanceError{Quote: quote}
+		}
+		if math.Abs(quote.PayoffAmount-req.PayoffAmount) > 0.005 {
+			return nil, ErrPayoffQuoteChanged
+		}
+		err = s.creditAccountRepo.SettleCreditAccount(creditAccount, quote.OutstandingPrincipal, quote.PayoffAmount, "Account Payoff")
+		if errors.Is(err, repository.ErrBalanceChanged) {
+			return nil, ErrPayoffQuoteChanged
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error settling credit account: %w", err)
+		}
+		publishAccountEvent(s.bus, s.clock, event.PaymentConfirmed, creditAccount.ID)
 	}
-	return creditAccountResponses, nil
+
+	err = s.creditAccountRepo.CloseCreditAccount(creditAccount, reason, userID)
+	switch {
+	case errors.Is(err, repository.ErrAccountClosed):
+		return nil, ErrCreditAccountAlreadyClosed
+	case errors.Is(err, repository.ErrBalanceChanged):
+		// A purchase or charge got in after the payoff
+		return nil, &AccountBalanceError{}
+	case err != nil:
+		return nil, fmt.Errorf("error closing credit account: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.AccountClosed, creditAccount.ID)
+	return s.accountResponseWithHistory(creditAccount)
 }
 
-// GetCreditAccountByClientID retrieves a credit account by client ID.
-func (s *creditAccountService) GetCreditAccountByClientID(clientID uint) (*response.CreditAccountResponse, error) {
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(clientID)
+// ReopenCreditAccount opens a closed credit account of one of the admin's establishments to
+// purchases again, with the terms it had.
+func (s *creditAccountService) ReopenCreditAccount(adminID, creditAccountID uint, reason string) (*response.CreditAccountResponse, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving credit account: %w", err)
 	}
-	return s.creditAccountToResponse(creditAccount), nil
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
+
+	if err := s.creditAccountRepo.ReopenCreditAccount(creditAccount, reason, adminID); err != nil {
+		return nil, err
+	}
+	publishAccountEvent(s.bus, s.clock, event.AccountReopened, creditAccount.ID)
+	return s.accountResponseWithHistory(creditAccount)
+}
+
+// setBlocked blocks or unblocks a credit account, recording why in its block history, and publishes
+// account.blocked or account.unblocked. Accounts already in the requested state are left untouched,
+// and setBlocked reports whether the account changed.
+func (s *creditAccountService) setBlocked(creditAccount *entities.CreditAccount, blocked bool, reason string, actorID *uint, automatic bool) (bool, error) {
+	if creditAccount.IsBlocked == blocked {
+		return false, nil
+	}
+	changed, err := s.creditAccountRepo.SetCreditAccountBlocked(creditAccount.ID, &entities.CreditAccountBlockEvent{
+		Blocked:   blocked,
+		Reason:    reason,
+		Automatic: automatic,
+		ActorID:   actorID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error updating credit account block: %w", err)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	creditAccount.IsBlocked = blocked
+	creditAccount.Version++
+	if blocked {
+		publishAccountEvent(s.bus, s.clock, event.AccountBlocked, creditAccount.ID)
+	} else {
+		publishAccountEvent(s.bus, s.clock, event.AccountUnblocked, creditAccount.ID)
+	}
+	return true, nil
+}
+
+// accountResponseWithHistory builds the response of a single credit account, including its block history.
+func (s *creditAccountService) accountResponseWithHistory(creditAccount *entities.CreditAccount) (*response.CreditAccountResponse, error) {
+	history, err := s.creditAccountRepo.GetBlockHistory(creditAccount.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block history: %w", err)
+	}
+
+	creditAccountResponse := s.creditAccountToResponse(creditAccount)
+	if creditAccountResponse == nil {
+		return nil, nil
+	}
+	creditAccountResponse.BlockHistory = make([]response.CreditAccountBlockEventResponse, 0, len(history))
+	for _, blockEvent := range history {
+		eventResponse := response.CreditAccountBlockEventResponse{
+			Blocked:   blockEvent.Blocked,
+			Reason:    blockEvent.Reason,
+			Automatic: blockEvent.Automatic,
+			ActorID:   blockEvent.ActorID,
+			CreatedAt: blockEvent.CreatedAt,
+		}
+		if blockEvent.Actor != nil {
+			eventResponse.ActorName = blockEvent.Actor.Name
+		}
+		creditAccountResponse.BlockHistory = append(creditAccountResponse.BlockHistory, eventResponse)
+	}
+	return creditAccountResponse, nil
+}
+
+// findClientCreditAccount returns the client's credit account in establishmentID. With an
+// establishmentID of 0 it returns the client's only account, and fails with ErrEstablishmentRequired
+// when the client holds accounts in several establishments.
+func findClientCreditAccount(repo repository.CreditAccountRepository, clientID, establishmentID uint) (*entities.CreditAccount, error) {
+	if establishmentID != 0 {
+		creditAccount, err := repo.GetCreditAccountByClientAndEstablishmentID(clientID, establishmentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCreditAccountNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving credit account: %w", err)
+		}
+		return creditAccount, nil
+	}
+
+	creditAccounts, err := repo.GetCreditAccountsByClientID(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+	switch len(creditAccounts) {
+	case 0:
+		return nil, ErrCreditAccountNotFound
+	case 1:
+		return &creditAccounts[0], nil
+	default:
+		return nil, ErrEstablishmentRequired
+	}
 }
 
 // ApplyInterestToAccount calculates and applies interest to a credit account.
@@ -179,70 +488,362 @@ func (s *creditAccountService) ApplyInterestToAccount(creditAccountID uint) erro
 	if err := s.creditAccountRepo.ApplyInterest(creditAccount); err != nil {
 		return fmt.Errorf("error applying interest to account %d: %w", creditAccountID, err)
 	}
+	publishAccountEvent(s.bus, s.clock, event.InterestAccrued, creditAccountID)
 	return nil
 }
 
-// ApplyLateFeeToAccount applies late fee to a credit account if overdue.
+// ApplyLateFeeToAccount applies late fee to a credit account if overdue, unless the client promised
+// to pay by a date that hasn't passed yet. Each billing cycle is charged its fee once, or what accrued
+// since it was last applied in the DAILY mode, and ErrLateFeeApplied otherwise. The fee is worked out
+// again when a payment or purchase changes the balance while it is being applied.
 func (s *creditAccountService) ApplyLateFeeToAccount(creditAccountID uint) error {
+	for attempt := 1; ; attempt++ {
+		err := s.applyLateFee(creditAccountID)
+		if attempt == maxLateFeeAttempts || !errors.Is(err, ErrBalanceChanged) {
+			return err
+		}
+	}
+}
+
+// maxLateFeeAttempts is how many times a late fee is worked out when the balance keeps changing.
+const maxLateFeeAttempts = 3
+
+func (s *creditAccountService) applyLateFee(creditAccountID uint) error {
 	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
 	if err != nil {
 		return fmt.Errorf("error retrieving credit account: %w", err)
 	}
+	suspended, err := lateFeesSuspended(s.promiseRepo, creditAccount, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	if suspended {
+		return ErrLateFeesSuspended
+	}
 
-	// Calculate days overdue (you can use a helper function for this)
-	daysOverdue := calculateDaysOverdue(creditAccount.MonthlyDueDate)
+	var installments []entities.Installment
+	if creditAccount.CreditType == enums.LongTerm {
+		installments, err = s.installmentRepo.GetInstallmentsByCreditAccountID(creditAccount.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving installments: %w", err)
+		}
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(creditAccount.EstablishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	days, err := businessDays(s.holidayRepo, creditAccount.EstablishmentID)
+	if err != nil {
+		return err
+	}
+	lateFee := lateFeeFor(settings, creditAccount, installments, days, s.clock.Now())
+	if lateFee == nil {
+		return nil
+	}
 
-	if err := s.creditAccountRepo.ApplyLateFee(creditAccount, daysOverdue); err != nil {
+	if err := s.creditAccountRepo.ApplyLateFee(creditAccount, lateFee); err != nil {
+		if errors.Is(err, ErrLateFeeApplied) {
+			return err
+		}
 		return fmt.Errorf("error applying late fee to account %d: %w", creditAccountID, err)
 	}
+	publishAccountEvent(s.bus, s.clock, event.LateFeeApplied, creditAccountID)
 	return nil
 }
 
-// calculateDaysOverdue calculates the number of days a payment is overdue
-func calculateDaysOverdue(dueDate int) int {
-	today := time.Now()
-	thisMonthDueDate := time.Date(today.Year(), today.Month(), dueDate, 0, 0, 0, 0, time.UTC)
+// lateFeeFor returns the late fee the billing cycle a credit account is overdue on owes as of now, per the
+// late fee mode and cap of its establishment, or nil if the account isn't overdue. The cycle is the one of
+// the oldest overdue installment for long-term credit, the current month's, on a business day, for
+// short-term credit.
+func lateFeeFor(settings *entities.EstablishmentSettings, creditAccount *entities.CreditAccount, installments []entities.Installment, days calendar.BusinessDays, now time.Time) *entities.LateFee {
+	if creditAccount.CurrentBalance-creditAccount.AccountCredit <= 0 {
+		return nil
+	}
 
-	if today.Before(thisMonthDueDate) {
+	var cycleDueDate time.Time
+	var overdue float64
+	if creditAccount.CreditType == enums.LongTerm {
+		// Installments of purchases still in their grace period aren't due yet
+		for _, installment := range installments {
+			if !installment.Status.IsOpen() || !installment.DueDate.Before(now) {
+				continue
+			}
+			if cycleDueDate.IsZero() || installment.DueDate.Before(cycleDueDate) {
+				cycleDueDate = installment.DueDate
+			}
+			overdue += installment.Amount
+		}
+		overdue = min(overdue, creditAccount.CurrentBalance-creditAccount.AccountCredit)
+	} else {
+		loc := accountLocation(creditAccount)
+		cycleDueDate = days.Next(util.CurrentDueDate(now.In(loc), creditAccount.MonthlyDueDate, loc))
+		overdue = creditAccount.CurrentBalance - creditAccount.AccountCredit
+	}
+	daysOverdue := int(now.Sub(cycleDueDate).Hours() / 24)
+	if cycleDueDate.IsZero() || daysOverdue <= 0 || overdue <= 0 {
+		return nil
+	}
+
+	rate := creditAccount.Establishment.LateFeePercentage / 100
+	var amount float64
+	switch enums.LateFeeMode(settings.LateFeeMode) {
+	case enums.LateFeeDaily:
+		amount = overdue * rate * float64(daysOverdue)
+	case enums.LateFeeFixed:
+		amount = settings.LateFeeAmount
+	default:
+		amount = overdue * rate
+	}
+	if settings.LateFeeCap > 0 {
+		amount = min(amount, overdue*settings.LateFeeCap/100)
+	}
+	if amount <= 0 {
+		return nil
+	}
+	return &entities.LateFee{
+		Amount:       roundCurrency(amount),
+		AppliedDate:  now,
+		CycleDueDate: cycleDueDate,
+		DaysOverdue:  daysOverdue,
+	}
+}
+
+// calculateDaysOverdue calculates the number of days a payment is overdue in the establishment's time zone,
+// counting from the next business day when this month's due date falls on a Sunday or a holiday
+func calculateDaysOverdue(now time.Time, dueDate int, loc *time.Location, days calendar.BusinessDays) int {
+	now = now.In(loc)
+	cycleDueDate := days.Next(util.CurrentDueDate(now, dueDate, loc))
+	if now.Before(cycleDueDate) {
+		return 0
+	}
+	return int(now.Sub(cycleDueDate).Hours() / 24)
+}
+
+// BlockOverdueAccounts blocks the credit accounts that have been overdue for at least as many days as their
+// establishment allows. Establishments without an auto-block threshold never have accounts blocked.
+func (s *creditAccountService) BlockOverdueAccounts() error {
+	settings, err := s.settingsRepo.GetEstablishmentSettingsWithAutoBlock()
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	now := s.clock.Now()
+	failed := 0
+	for _, rules := range settings {
+		accounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(rules.EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error retrieving credit accounts: %w", err)
+		}
+		days, err := businessDays(s.holidayRepo, rules.EstablishmentID)
+		if err != nil {
+			return err
+		}
+		for i := range accounts {
+			if accounts[i].IsBlocked {
+				continue
+			}
+			daysOverdue, err := s.accountDaysOverdue(&accounts[i], days, now)
+			if err != nil {
+				failed++
+				continue
+			}
+			if daysOverdue < rules.AutoBlockDaysOverdue {
+				continue
+			}
+			reason := fmt.Sprintf("Overdue for %d days", daysOverdue)
+			blocked, err := s.setBlocked(&accounts[i], true, reason, nil, true)
+			if err != nil {
+				failed++
+				continue
+			}
+			if blocked {
+				publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, accounts[i].ID)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d overdue accounts could not be blocked", failed)
+	}
+	return nil
+}
+
+// accountDaysOverdue returns how many days the oldest unpaid amount of an account is overdue: its oldest
+// unpaid installment for long-term credit, this month's due date, on a business day, for short-term credit.
+func (s *creditAccountService) accountDaysOverdue(creditAccount *entities.CreditAccount, days calendar.BusinessDays, now time.Time) (int, error) {
+	var installments []entities.Installment
+	if creditAccount.CreditType == enums.LongTerm && creditAccount.CurrentBalance-creditAccount.AccountCredit > 0 {
+		var err error
+		installments, err = s.installmentRepo.GetInstallmentsByCreditAccountID(creditAccount.ID)
+		if err != nil {
+			return 0, fmt.Errorf("error retrieving installments: %w", err)
+		}
+	}
+	return daysOverdueWithInstallments(creditAccount, installments, days, now), nil
+}
+
+// daysOverdueWithInstallments is accountDaysOverdue for callers that already loaded the installments of
+// the account. They are ignored for short-term credit.
+func daysOverdueWithInstallments(creditAccount *entities.CreditAccount, installments []entities.Installment, days calendar.BusinessDays, now time.Time) int {
+	if creditAccount.CurrentBalance-creditAccount.AccountCredit <= 0 {
 		return 0
 	}
+	if creditAccount.CreditType != enums.LongTerm {
+		return calculateDaysOverdue(now, creditAccount.MonthlyDueDate, accountLocation(creditAccount), days)
+	}
+	return installmentsDaysOverdue(installments, now)
+}
+
+// installmentsDaysOverdue returns how many days the oldest unpaid installment is past its due date, 0 if none is.
+func installmentsDaysOverdue(installments []entities.Installment, now time.Time) int {
+	daysOverdue := 0
+	for _, installment := range installments {
+		if !installment.Status.IsOpen() || !installment.DueDate.Before(now) {
+			continue
+		}
+		daysOverdue = max(daysOverdue, int(now.Sub(installment.DueDate).Hours()/24))
+	}
+	return daysOverdue
+}
+
+// SearchEstablishmentClients retrieves one page of the clients of the admin's establishment whose name,
+// DNI, email or phone contains query, along with the number of matches across all pages. Each client comes
+// with the balance and overdue status of their account, computed with one installment query for the page.
+// With tags, only the clients with every one of them in the establishment are searched.
+func (s *creditAccountService) SearchEstablishmentClients(adminID, branchID uint, query string, tags []string, page, pageSize int) ([]response.ClientSearchResponse, int, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	accounts, total, err := s.creditAccountRepo.SearchCreditAccounts(establishment.ID, strings.TrimSpace(query), tags, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error searching clients: %w", err)
+	}
+
+	var longTermIDs []uint
+	for i := range accounts {
+		if accounts[i].CreditType == enums.LongTerm {
+			longTermIDs = append(longTermIDs, accounts[i].ID)
+		}
+	}
+	installmentsByAccount := make(map[uint][]entities.Installment, len(longTermIDs))
+	if len(longTermIDs) > 0 {
+		installments, err := s.installmentRepo.GetInstallmentsByCreditAccountIDs(longTermIDs)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error retrieving installments: %w", err)
+		}
+		for _, installment := range installments {
+			installmentsByAccount[installment.CreditAccountID] = append(installmentsByAccount[installment.CreditAccountID], installment)
+		}
+	}
 
-	return int(today.Sub(thisMonthDueDate).Hours() / 24)
+	days, err := businessDays(s.holidayRepo, establishment.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := s.clock.Now()
+	results := make([]response.ClientSearchResponse, 0, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+		daysOverdue := daysOverdueWithInstallments(account, installmentsByAccount[account.ID], days, now)
+
+		result := response.ClientSearchResponse{
+			ClientID:        account.ClientID,
+			CreditAccountID: account.ID,
+			CurrentBalance:  account.CurrentBalance,
+			AccountCredit:   account.AccountCredit,
+			CreditLimit:     account.CreditLimit,
+			IsBlocked:       account.IsBlocked,
+			HighRisk:        account.HighRisk,
+			Overdue:         daysOverdue > 0,
+			DaysOverdue:     daysOverdue,
+		}
+		if account.Client != nil {
+			result.Name = account.Client.Name
+			result.DNI = account.Client.DNI
+			result.Email = account.Client.Email
+			result.Phone = account.Client.Phone
+			result.PhotoUrl = account.Client.PhotoUrl
+		}
+		results = append(results, result)
+	}
+	return results, int(total), nil
 }
 
-// GetOverdueCreditAccounts retrieves overdue credit accounts for an establishment.
-func (s *creditAccountService) GetOverdueCreditAccounts(establishmentID uint) ([]response.CreditAccountResponse, error) {
-	overdueAccounts, err := s.creditAccountRepo.GetOverdueCreditAccounts(establishmentID)
+// GetOverdueCreditAccounts retrieves overdue credit accounts for an establishment, embedding the
+// nested objects of includes. Accounts are overdue from the day after their due date, clamped to
+// the month and moved off Sundays and holidays, as accountDaysOverdue counts them.
+func (s *creditAccountService) GetOverdueCreditAccounts(establishmentID uint, includes CreditAccountIncludes) ([]response.CreditAccountResponse, error) {
+	owingAccounts, err := s.creditAccountRepo.GetOwingCreditAccounts(establishmentID)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving overdue credit accounts: %w", err)
 	}
+	days, err := businessDays(s.holidayRepo, establishmentID)
+	if err != nil {
+		return nil, err
+	}
 
+	now := s.clock.Now()
 	var overdueAccountResponses []response.CreditAccountResponse
-	for _, account := range overdueAccounts {
-		overdueAccountResponses = append(overdueAccountResponses, *s.creditAccountToResponse(&account))
+	for i := range owingAccounts {
+		daysOverdue, err := s.accountDaysOverdue(&owingAccounts[i], days, now)
+		if err != nil {
+			return nil, err
+		}
+		if daysOverdue > 0 {
+			overdueAccountResponses = append(overdueAccountResponses, *creditAccountToResponseWith(s.establishmentRepo, &owingAccounts[i], includes))
+		}
 	}
 
 	return overdueAccountResponses, nil
 }
 
-// ProcessPurchase processes a purchase transaction on a credit account.
-func (s *creditAccountService) ProcessPurchase(creditAccountID uint, amount float64, description string) error {
+// ProcessPurchase processes a purchase transaction on a credit account. Purchases above the PIN
+// threshold of the establishment are confirmed with the client's purchase PIN.
+func (s *creditAccountService) ProcessPurchase(creditAccountID uint, amount float64, description, pin string) error {
 	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
 	if err != nil {
 		return fmt.Errorf("error retrieving credit account: %w", err)
 	}
 
-	return s.creditAccountRepo.ProcessPurchase(creditAccount, amount, description)
+	if err := checkHighRiskPurchase(s.settingsRepo, creditAccount, amount); err != nil {
+		return err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(creditAccount.EstablishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	if settings.PinThreshold > 0 && amount > settings.PinThreshold {
+		if err := s.pinService.VerifyPurchasePin(creditAccount.ClientID, pin); err != nil {
+			return err
+		}
+	}
+	if err := s.creditAccountRepo.ProcessPurchase(creditAccount, amount, description); err != nil {
+		return err
+	}
+	publishAccountEvent(s.bus, s.clock, event.PurchaseCreated, creditAccountID)
+	return nil
 }
 
-// ProcessPayment processes a payment transaction on a credit account.
-func (s *creditAccountService) ProcessPayment(creditAccountID uint, amount float64, description string) error {
+// ProcessPayment processes a payment transaction on a credit account. Cash payments are collected
+// in the open till session of the establishment, if any.
+func (s *creditAccountService) ProcessPayment(creditAccountID uint, amount float64, paymentMethod enums.PaymentMethod, description string) error {
 	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
 	if err != nil {
 		return fmt.Errorf("error retrieving credit account: %w", err)
 	}
 
-	return s.creditAccountRepo.ProcessPayment(creditAccount, amount, description)
+	if err := s.creditAccountRepo.ProcessPayment(creditAccount, amount, paymentMethod, description); err != nil {
+		return err
+	}
+	publishAccountEvent(s.bus, s.clock, event.TransactionCreated, creditAccountID)
+	// Payments recorded by the establishment are confirmed as they are recorded
+	publishAccountEvent(s.bus, s.clock, event.PaymentConfirmed, creditAccountID)
+	return nil
 }
 
 // GetAdminDebtSummary retrieves a summary of debts for an establishment.
@@ -254,6 +855,10 @@ func (s *creditAccountService) GetAdminDebtSummary(establishmentID uint) ([]resp
 
 	summary := make([]response.AdminDebtSummary, 0, len(creditAccounts))
 	for _, account := range creditAccounts {
+		// Written-off debt is in the bad-debt report instead
+		if account.WrittenOffAt != nil {
+			continue
+		}
 
 		if account.Client == nil {
 			return nil, fmt.Errorf("error retrieving client: %w", err)
@@ -270,6 +875,11 @@ func (s *creditAccountService) GetAdminDebtSummary(establishmentID uint) ([]resp
 			return nil, fmt.Errorf("error calculating due date: %w", err)
 		}
 
+		brokenPromises, err := s.promiseRepo.CountBrokenPaymentPromises(account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error counting broken payment promises: %w", err)
+		}
+
 		summaryItem := response.AdminDebtSummary{
 			ClientID:       account.ClientID,
 			ClientName:     user.Name,
@@ -278,6 +888,9 @@ func (s *creditAccountService) GetAdminDebtSummary(establishmentID uint) ([]resp
 			NumberOfDues:   s.GetNumberOfDues(account),
 			CurrentBalance: account.CurrentBalance,
 			DueDate:        dueDate,
+			CreditScore:    account.CreditScore,
+			HighRisk:       account.HighRisk,
+			BrokenPromises: int(brokenPromises),
 		}
 
 		summary = append(summary, summaryItem)
@@ -285,34 +898,27 @@ func (s *creditAccountService) GetAdminDebtSummary(establishmentID uint) ([]resp
 	return summary, nil
 }
 
-// CalculateDueDate calculates the next due date for a credit account.
+// CalculateDueDate calculates the next due date for a credit account, on a business day.
 func (s *creditAccountService) CalculateDueDate(account entities.CreditAccount) (time.Time, error) {
-	today := time.Now()
+	loc := accountLocation(&account)
+	today := s.clock.Now().In(loc)
+	days, err := businessDays(s.holidayRepo, account.EstablishmentID)
+	if err != nil {
+		return time.Time{}, err
+	}
 	if account.CreditType == enums.ShortTerm {
-		nextMonth := today.Month() + 1
-		nextYear := today.Year()
-		if nextMonth > time.December {
-			nextMonth = time.January
-			nextYear++
-		}
-		return time.Date(nextYear, nextMonth, account.MonthlyDueDate, 0, 0, 0, 0, time.UTC), nil
+		return days.Next(util.FollowingMonthDueDate(today, account.MonthlyDueDate, loc)), nil
 	} else if account.CreditType == enums.LongTerm {
 		installments, err := s.installmentRepo.GetInstallmentsByCreditAccountID(account.ID)
 		if err != nil {
 			return time.Time{}, fmt.Errorf("error retrieving installments: %w", err)
 		}
 		for _, installment := range installments {
-			if installment.Status == enums.Pending && installment.DueDate.After(today) {
+			if installment.Status.IsOpen() && installment.DueDate.After(today) {
 				return installment.DueDate, nil
 			}
 		}
-		nextMonth := today.Month() + 1
-		nextYear := today.Year()
-		if nextMonth > time.December {
-			nextMonth = time.January
-			nextYear++
-		}
-		return time.Date(nextYear, nextMonth, account.MonthlyDueDate, 0, 0, 0, 0, time.UTC), nil
+		return days.Next(util.FollowingMonthDueDate(today, account.MonthlyDueDate, loc)), nil
 	}
 	return time.Time{}, fmt.Errorf("invalid credit type: %s", account.CreditType)
 }
@@ -330,65 +936,112 @@ func (s *creditAccountService) GetNumberOfDues(account entities.CreditAccount) i
 }
 
 func (s *creditAccountService) creditAccountToResponse(creditAccount *entities.CreditAccount) *response.CreditAccountResponse {
-	establishment, err := s.establishmentRepo.GetEstablishmentByID(creditAccount.EstablishmentID)
-	if err != nil {
-		return nil
-	}
+	return creditAccountToResponse(s.establishmentRepo, creditAccount)
+}
 
-	admin, err := s.establishmentRepo.GetAdminByUserID(establishment.AdminID)
+// creditAccountToResponse builds the response for a credit account, including its establishment and the establishment's admin.
+func creditAccountToResponse(establishmentRepo repository.EstablishmentRepository, creditAccount *entities.CreditAccount) *response.CreditAccountResponse {
+	return creditAccountToResponseWith(establishmentRepo, creditAccount, AllCreditAccountIncludes)
+}
 
-	if err != nil {
-		return nil
+// creditAccountToResponseWith builds the response for a credit account, embedding the nested objects
+// of includes.
+func creditAccountToResponseWith(establishmentRepo repository.EstablishmentRepository, creditAccount *entities.CreditAccount, includes CreditAccountIncludes) *response.CreditAccountResponse {
+	var establishmentResponse *response.EstablishmentResponse
+	if includes.Establishment {
+		establishment, err := establishmentRepo.GetEstablishmentByID(creditAccount.EstablishmentID)
+		if err != nil {
+			return nil
+		}
+		admin, err := establishmentRepo.GetAdminByUserID(establishment.AdminID)
+		if err != nil {
+			return nil
+		}
+		establishmentResponse = establishmentWithAdminToResponse(establishment, admin)
 	}
-
-	adminResponse := &response.UserResponse{
-		ID:        admin.ID,
-		DNI:       admin.DNI,
-		Name:      admin.Name,
-		Email:     admin.Email,
-		Address:   admin.Address,
-		Phone:     admin.Phone,
-		PhotoUrl:  admin.PhotoUrl,
-		Rol:       admin.Rol,
-		CreatedAt: admin.CreatedAt,
-		UpdatedAt: admin.UpdatedAt,
+	var clientResponse *response.UserResponse
+	if includes.Client {
+		clientResponse = NewUserResponse(creditAccount.Client)
 	}
 
-	establishmentResponse := &response.EstablishmentResponse{
-		ID:                establishment.ID,
-		RUC:               establishment.RUC,
-		Name:              establishment.Name,
-		Phone:             establishment.Phone,
-		Address:           establishment.Address,
-		ImageUrl:          establishment.ImageUrl,
-		LateFeePercentage: establishment.LateFeePercentage,
-		IsActive:          establishment.IsActive,
-		CreatedAt:         establishment.CreatedAt,
-		UpdatedAt:         establishment.UpdatedAt,
-		AdminID:           establishment.AdminID,
-		Admin:             adminResponse,
-	}
 	return &response.CreditAccountResponse{
 		ID:                      creditAccount.ID,
 		ClientID:                creditAccount.ClientID,
-		Client:                  NewUserResponse(creditAccount.Client),
+		Client:                  clientResponse,
 		EstablishmentID:         creditAccount.EstablishmentID,
 		Establishment:           establishmentResponse,
 		CreditLimit:             creditAccount.CreditLimit,
 		CurrentBalance:          creditAccount.CurrentBalance,
+		AccountCredit:           creditAccount.AccountCredit,
 		MonthlyDueDate:          creditAccount.MonthlyDueDate,
 		InterestRate:            creditAccount.InterestRate,
 		InterestType:            creditAccount.InterestType,
+		CompoundingPeriod:       compoundingPeriodOrDefault(creditAccount.CompoundingPeriod),
 		CreditType:              creditAccount.CreditType,
 		GracePeriod:             creditAccount.GracePeriod,
 		IsBlocked:               creditAccount.IsBlocked,
+		CreditScore:             creditAccount.CreditScore,
+		HighRisk:                creditAccount.HighRisk,
 		LastInterestAccrualDate: creditAccount.LastInterestAccrualDate,
 		LateFeePercentage:       creditAccount.LateFeePercentage,
+		SpendingLimit:           creditAccount.SpendingLimit,
+		SpendingLimitPeriod:     spendingPeriodOrDefault(creditAccount.SpendingLimitPeriod),
+		WrittenOffBalance:       creditAccount.WrittenOffBalance,
+		WrittenOffAt:            creditAccount.WrittenOffAt,
+		Status:                  accountStatusOrDefault(creditAccount.Status),
+		ClosedAt:                creditAccount.ClosedAt,
+		CreditTemplateID:        creditAccount.CreditTemplateID,
+		Rates:                   creditRatesToResponse(creditAccount),
+		Version:                 creditAccount.Version,
 		CreatedAt:               creditAccount.CreatedAt,
 		UpdatedAt:               creditAccount.UpdatedAt,
 	}
 }
 
+// accountStatusOrDefault returns the status of accounts created before closing them was possible as active.
+func accountStatusOrDefault(status enums.CreditAccountStatus) enums.CreditAccountStatus {
+	if status == "" {
+		return enums.AccountActive
+	}
+	return status
+}
+
+// establishmentWithAdminToResponse builds the response for the establishment of a credit account,
+// including its admin.
+func establishmentWithAdminToResponse(establishment *entities.Establishment, admin *entities.User) *response.EstablishmentResponse {
+	adminResponse := &response.UserResponse{
+		ID:        admin.ID,
+		DNI:       admin.DNI,
+		Name:      admin.Name,
+		Email:     admin.Email,
+		Address:   admin.Address,
+		Phone:     admin.Phone,
+		PhotoUrl:  util.UserPhotoURL(admin.PhotoUrl, admin.Name),
+		Rol:       admin.Rol,
+		CreatedAt: admin.CreatedAt,
+		UpdatedAt: admin.UpdatedAt,
+	}
+
+	return &response.EstablishmentResponse{
+		ID:                             establishment.ID,
+		RUC:                            establishment.RUC,
+		Name:                           establishment.Name,
+		Phone:                          establishment.Phone,
+		Address:                        establishment.Address,
+		ImageUrl:                       establishment.ImageUrl,
+		LateFeePercentage:              establishment.LateFeePercentage,
+		Timezone:                       establishment.Timezone,
+		EarlyPaymentDiscountPercentage: establishment.EarlyPaymentDiscountPercentage,
+		IsActive:                       establishment.IsActive,
+		Version:                        establishment.Version,
+		CreatedAt:                      establishment.CreatedAt,
+		UpdatedAt:                      establishment.UpdatedAt,
+		AdminID:                        establishment.AdminID,
+		ParentID:                       establishment.ParentID,
+		Admin:                          adminResponse,
+	}
+}
+
 func (s *creditAccountService) NewEstablishmentResponse(establishment *entities.Establishment) *response.EstablishmentResponse {
 	admin, err := s.establishmentRepo.GetAdminByUserID(establishment.AdminID)
 	if err != nil {
@@ -405,66 +1058,95 @@ func (s *creditAccountService) NewEstablishmentResponse(establishment *entities.
 		Email:     admin.Email,
 		Address:   admin.Address,
 		Phone:     admin.Phone,
-		PhotoUrl:  admin.PhotoUrl,
+		PhotoUrl:  util.UserPhotoURL(admin.PhotoUrl, admin.Name),
 		Rol:       admin.Rol,
 		CreatedAt: admin.CreatedAt,
 		UpdatedAt: admin.UpdatedAt,
 	}
 
 	return &response.EstablishmentResponse{
-		ID:                establishment.ID,
-		RUC:               establishment.RUC,
-		Name:              establishment.Name,
-		Phone:             establishment.Phone,
-		Address:           establishment.Address,
-		ImageUrl:          establishment.ImageUrl,
-		LateFeePercentage: establishment.LateFeePercentage,
-		IsActive:          establishment.IsActive,
-		CreatedAt:         establishment.CreatedAt,
-		UpdatedAt:         establishment.UpdatedAt,
-		AdminID:           establishment.AdminID,
-		Admin:             userResponse,
+		ID:                             establishment.ID,
+		RUC:                            establishment.RUC,
+		Name:                           establishment.Name,
+		Phone:                          establishment.Phone,
+		Address:                        establishment.Address,
+		ImageUrl:                       establishment.ImageUrl,
+		LateFeePercentage:              establishment.LateFeePercentage,
+		Timezone:                       establishment.Timezone,
+		EarlyPaymentDiscountPercentage: establishment.EarlyPaymentDiscountPercentage,
+		IsActive:                       establishment.IsActive,
+		Version:                        establishment.Version,
+		CreatedAt:                      establishment.CreatedAt,
+		UpdatedAt:                      establishment.UpdatedAt,
+		AdminID:                        establishment.AdminID,
+		ParentID:                       establishment.ParentID,
+		Admin:                          userResponse,
 	}
 }
 
-// UpdateCreditAccountByClientID updates an existing credit account by client ID.
-func (s *creditAccountService) UpdateCreditAccountByClientID(clientID uint, req request.UpdateCreditAccountRequest) (*response.CreditAccountResponse, error) {
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(clientID)
-	if err != nil {
-		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+// UpdateCreditAccountByClientID updates a client's credit account in an establishment, if it still is
+// at the version of the request.
+func (s *creditAccountService) UpdateCreditAccountByClientID(clientID, establishmentID uint, req request.UpdateCreditAccountRequest) (*response.CreditAccountResponse, error) {
+	return s.PatchCreditAccountByClientID(clientID, establishmentID, req.Patch())
+}
+
+// PatchCreditAccountByClientID changes the fields the patch sets of a client's credit account in an
+// establishment, if it still is at the version of the patch.
+func (s *creditAccountService) PatchCreditAccountByClientID(clientID, establishmentID uint, req request.PatchCreditAccountRequest) (*response.CreditAccountResponse, error) {
+	if req.Version == nil {
+		return nil, ErrVersionRequired
 	}
-	if creditAccount == nil {
-		return nil, errors.New("credit account not found for this client")
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+	if err != nil {
+		return nil, err
 	}
+	return s.patchCreditAccount(creditAccount, req)
+}
 
-	// Update the credit account fields based on the request
-	if req.CreditLimit > 0 {
-		creditAccount.CreditLimit = req.CreditLimit
+// patchCreditAccount applies a patch to a credit account, read as of now, and saves it if it still
+// is at the version of the patch. Blocking or unblocking it is recorded in its block history.
+func (s *creditAccountService) patchCreditAccount(creditAccount *entities.CreditAccount, req request.PatchCreditAccountRequest) (*response.CreditAccountResponse, error) {
+	creditAccount.Version = *req.Version
+	if req.CreditLimit != nil {
+		creditAccount.CreditLimit = *req.CreditLimit
+	}
+	if req.MonthlyDueDate != nil {
+		creditAccount.MonthlyDueDate = *req.MonthlyDueDate
+	}
+	if req.InterestRate != nil {
+		creditAccount.InterestRate = *req.InterestRate
 	}
-	if req.MonthlyDueDate > 0 {
-		creditAccount.MonthlyDueDate = req.MonthlyDueDate
+	if req.InterestType != nil {
+		creditAccount.InterestType = *req.InterestType
 	}
-	if req.InterestRate > 0 {
-		creditAccount.InterestRate = req.InterestRate
+	if req.CompoundingPeriod != nil {
+		creditAccount.CompoundingPeriod = *req.CompoundingPeriod
 	}
-	if req.InterestType != "" {
-		creditAccount.InterestType = req.InterestType
+	if req.CreditType != nil {
+		creditAccount.CreditType = *req.CreditType
 	}
-	if req.CreditType != "" {
-		creditAccount.CreditType = req.CreditType
+	if req.GracePeriod != nil {
+		creditAccount.GracePeriod = *req.GracePeriod
 	}
-	if req.GracePeriod >= 0 {
-		creditAccount.GracePeriod = req.GracePeriod
+	if req.LateFeePercentage != nil {
+		creditAccount.LateFeePercentage = *req.LateFeePercentage
 	}
-	creditAccount.IsBlocked = req.IsBlocked
-	if req.LateFeePercentage >= 0 {
-		creditAccount.LateFeePercentage = req.LateFeePercentage
+	if req.SpendingLimit != nil {
+		creditAccount.SpendingLimit = *req.SpendingLimit
+	}
+	if req.SpendingLimitPeriod != nil {
+		creditAccount.SpendingLimitPeriod = *req.SpendingLimitPeriod
 	}
 
-	err = s.creditAccountRepo.UpdateCreditAccount(creditAccount)
-	if err != nil {
+	if err := s.creditAccountRepo.UpdateCreditAccount(creditAccount); err != nil {
 		return nil, fmt.Errorf("error updating credit account: %w", err)
 	}
+	publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, creditAccount.ID)
+	if req.IsBlocked != nil {
+		if _, err := s.setBlocked(creditAccount, *req.IsBlocked, updateBlockReason, nil, false); err != nil {
+			return nil, err
+		}
+	}
 
-	return s.creditAccountToResponse(creditAccount), nil
+	return s.accountResponseWithHistory(creditAccount)
 }
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// fakePinService accepts pin as the purchase PIN of every client.
+type fakePinService struct {
+	pin      string
+	verified []uint // Clients whose PIN was asked for
+}
+
+func (f *fakePinService) GetPurchasePin(userID uint) (*response.PurchasePinResponse, error) {
+	return &response.PurchasePinResponse{}, nil
+}
+
+func (f *fakePinService) SetPurchasePin(userID uint, req *request.SetPurchasePinRequest) (*response.PurchasePinResponse, error) {
+	return &response.PurchasePinResponse{}, nil
+}
+
+func (f *fakePinService) VerifyPurchasePin(userID uint, pin string) error {
+	f.verified = append(f.verified, userID)
+	if pin == "" {
+		return ErrPurchasePinRequired
+	}
+	if pin != f.pin {
+		return ErrInvalidPurchasePin
+	}
+	return nil
+}
+
+// creditAccountServiceMocks are the dependencies of a credit account service under test.
+type creditAccountServiceMocks struct {
+	accounts       *mocks.MockCreditAccountRepository
+	installments   *mocks.MockInstallmentRepository
+	establishments *mocks.MockEstablishmentRepository
+	settings       *mocks.MockEstablishmentSettingsRepository
+	promises       *mocks.MockPaymentPromiseRepository
+	holidays       *mocks.MockHolidayRepository
+	pins           *fakePinService
+	events         []event.Name // Published, in order
+}
+
+func newTestCreditAccountService(t *testing.T) (CreditAccountService, *creditAccountServiceMocks) {
+	ctrl := gomock.NewController(t)
+	m := &creditAccountServiceMocks{
+		accounts:       mocks.NewMockCreditAccountRepository(ctrl),
+		installments:   mocks.NewMockInstallmentRepository(ctrl),
+		establishments: mocks.NewMockEstablishmentRepository(ctrl),
+		settings:       mocks.NewMockEstablishmentSettingsRepository(ctrl),
+		promises:       mocks.NewMockPaymentPromiseRepository(ctrl),
+		holidays:       mocks.NewMockHolidayRepository(ctrl),
+		pins:           &fakePinService{pin: "1234"},
+	}
+	bus := event.NewInMemoryBus()
+	bus.SubscribeAll(func(evt event.Event) { m.events = append(m.events, evt.Name) })
+	s := NewCreditAccountService(m.accounts, mocks.NewMockTransactionRepository(ctrl), m.installments,
+		mocks.NewMockClientRepository(ctrl), m.establishments, m.settings,
+		m.promises, mocks.NewMockCreditTemplateRepository(ctrl), m.holidays,
+		m.pins, util.NewFakeClock(fixture.Now), bus)
+	return s, m
+}
+
+func TestCreditAccountServiceProcessPurchase(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+	tests := []struct {
+		name         string
+		account      *entities.CreditAccount
+		settings     *entities.EstablishmentSettings
+		amount       float64
+		pin          string
+		repoErr      error
+		wantErr      error // Matched with errors.Is, nil for success
+		wantPinAsked bool
+		wantCharged  bool
+	}{
+		{
+			name:        "no PIN threshold",
+			account:     fixture.CreditAccount().Build(),
+			settings:    fixture.Settings().Build(),
+			amount:      5000,
+			wantCharged: true,
+		},
+		{
+			name:        "at the PIN threshold",
+			account:     fixture.CreditAccount().Build(),
+			settings:    fixture.Settings().PinThreshold(200).Build(),
+			amount:      200,
+			wantCharged: true,
+		},
+		{
+			name:         "above the PIN threshold with the PIN",
+			account:      fixture.CreditAccount().Build(),
+			settings:     fixture.Settings().PinThreshold(200).Build(),
+			amount:       250,
+			pin:          "1234",
+			wantPinAsked: true,
+			wantCharged:  true,
+		},
+		{
+			name:         "above the PIN threshold without a PIN",
+			account:      fixture.CreditAccount().Build(),
+			settings:     fixture.Settings().PinThreshold(200).Build(),
+			amount:       250,
+			wantErr:      ErrPurchasePinRequired,
+			wantPinAsked: true,
+		},
+		{
+			name:         "above the PIN threshold with a wrong PIN",
+			account:      fixture.CreditAccount().Build(),
+			settings:     fixture.Settings().PinThreshold(200).Build(),
+			amount:       250,
+			pin:          "0000",
+			wantErr:      ErrInvalidPurchasePin,
+			wantPinAsked: true,
+		},
+		{
+			name:        "high risk within its limit",
+			account:     fixture.CreditAccount().HighRisk(300).Build(),
+			settings:    fixture.Settings().HighRiskMaxPurchase(100).Build(),
+			amount:      100,
+			wantCharged: true,
+		},
+		{
+			name:     "high risk over its limit",
+			account:  fixture.CreditAccount().HighRisk(300).Build(),
+			settings: fixture.Settings().HighRiskMaxPurchase(100).Build(),
+			amount:   101,
+			wantErr:  ErrHighRiskPurchaseLimit,
+		},
+		{
+			name:        "the repository fails",
+			account:     fixture.CreditAccount().Build(),
+			settings:    fixture.Settings().Build(),
+			amount:      50,
+			repoErr:     errDatabase,
+			wantErr:     errDatabase,
+			wantCharged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestCreditAccountService(t)
+			m.accounts.EXPECT().GetCreditAccountByID(tt.account.ID).Return(tt.account, nil)
+			m.settings.EXPECT().GetEstablishmentSettings(tt.account.EstablishmentID).Return(tt.settings, nil).MinTimes(1)
+			if tt.wantCharged {
+				m.accounts.EXPECT().ProcessPurchase(tt.account, tt.amount, "Compra").Return(tt.repoErr)
+			}
+
+			err := s.ProcessPurchase(tt.account.ID, tt.amount, "Compra", tt.pin)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("ProcessPurchase returned %v, want %v", err, tt.wantErr)
+			}
+			if asked := len(m.pins.verified) > 0; asked != tt.wantPinAsked {
+				t.Errorf("PIN asked for = %t, want %t", asked, tt.wantPinAsked)
+			}
+			var want []event.Name
+			if tt.wantErr == nil {
+				want = []event.Name{event.PurchaseCreated}
+			}
+			if !reflect.DeepEqual(m.events, want) {
+				t.Errorf("published %v, want %v", m.events, want)
+			}
+		})
+	}
+}
+
+func TestCreditAccountServiceProcessPurchaseAccountNotFound(t *testing.T) {
+	s, m := newTestCreditAccountService(t)
+	m.accounts.EXPECT().GetCreditAccountByID(uint(404)).Return(nil, errors.New("credit account not found"))
+
+	if err := s.ProcessPurchase(404, 100, "Compra", ""); err == nil {
+		t.Fatal("ProcessPurchase of a missing account succeeded")
+	}
+	if len(m.events) != 0 {
+		t.Errorf("published %v for a missing account", m.events)
+	}
+}
+
+func TestCreditAccountServiceProcessPayment(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+	tests := []struct {
+		name       string
+		repoErr    error
+		wantEvents []event.Name
+	}{
+		{"recorded", nil, []event.Name{event.TransactionCreated, event.PaymentConfirmed}},
+		{"the repository fails", errDatabase, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestCreditAccountService(t)
+			account := fixture.CreditAccount().Balance(300).Build()
+			m.accounts.EXPECT().GetCreditAccountByID(account.ID).Return(account, nil)
+			m.accounts.EXPECT().ProcessPayment(account, 120.0, enums.YAPE, "Pago").Return(tt.repoErr)
+
+			if err := s.ProcessPayment(account.ID, 120, enums.YAPE, "Pago"); !errors.Is(err, tt.repoErr) {
+				t.Fatalf("ProcessPayment returned %v, want %v", err, tt.repoErr)
+			}
+			if !reflect.DeepEqual(m.events, tt.wantEvents) {
+				t.Errorf("published %v, want %v", m.events, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestCreditAccountServiceApplyLateFeeToAccountWhileTheBalanceChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErrs    []error // Of each attempt to apply the fee
+		wantErr     error
+		wantApplied bool
+	}{
+		{"applied", []error{nil}, nil, true},
+		{"worked out again after a payment", []error{ErrBalanceChanged, nil}, nil, true},
+		{"the balance keeps changing", []error{ErrBalanceChanged, ErrBalanceChanged, ErrBalanceChanged}, ErrBalanceChanged, false},
+		{"already charged", []error{ErrLateFeeApplied}, ErrLateFeeApplied, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestCreditAccountService(t)
+			establishment := fixture.Establishment().LateFee(5).Build()
+			balances := []float64{300, 200, 150} // Read by each attempt, lowered by payments in between
+			var fees []float64
+			for i, repoErr := range tt.repoErrs {
+				account := fixture.CreditAccount().DueDay(5).Balance(balances[i]).Establishment(establishment).Build()
+				m.accounts.EXPECT().GetCreditAccountByID(account.ID).Return(account, nil)
+				m.promises.EXPECT().GetActivePaymentPromise(account.ID).Return(nil, gorm.ErrRecordNotFound)
+				m.settings.EXPECT().GetEstablishmentSettings(account.EstablishmentID).Return(fixture.Settings().Build(), nil)
+				m.holidays.EXPECT().GetHolidaysByEstablishmentID(account.EstablishmentID, 0).Return(nil, nil)
+				m.accounts.EXPECT().ApplyLateFee(account, gomock.Any()).DoAndReturn(func(_ *entities.CreditAccount, lateFee *entities.LateFee) error {
+					fees = append(fees, lateFee.Amount)
+					return repoErr
+				})
+			}
+
+			if err := s.ApplyLateFeeToAccount(fixture.CreditAccountID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyLateFeeToAccount returned %v, want %v", err, tt.wantErr)
+			}
+			for i, fee := range fees {
+				if want := roundCurrency(balances[i] * 0.05); fee != want {
+					t.Errorf("attempt %d charged %.2f, want %.2f of the balance it read", i+1, fee, want)
+				}
+			}
+			if applied := reflect.DeepEqual(m.events, []event.Name{event.LateFeeApplied}); applied != tt.wantApplied {
+				t.Errorf("published %v", m.events)
+			}
+		})
+	}
+}
+
+func TestCreditAccountServiceGetOverdueCreditAccounts(t *testing.T) {
+	// fixture.Now is Monday, March 10 2025 in Lima
+	loc := util.LoadLocation("America/Lima")
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, loc)
+	}
+	holiday := func(d int) entities.Holiday {
+		return entities.Holiday{EstablishmentID: fixture.EstablishmentID, Date: time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC)}
+	}
+	establishment := fixture.Establishment().Build()
+	paid := fixture.Installment(100, day(time.March, 5))
+	paid.Status = enums.Paid
+	tests := []struct {
+		name         string
+		account      *entities.CreditAccount
+		holidays     []entities.Holiday
+		installments []entities.Installment // Of long-term accounts
+		want         bool
+	}{
+		{"past its due date", fixture.CreditAccount().DueDay(5).Balance(100).Build(), nil, nil, true},
+		{"due today", fixture.CreditAccount().DueDay(10).Balance(100).Build(), nil, nil, false},
+		{"due later this month", fixture.CreditAccount().DueDay(15).Balance(100).Build(), nil, nil, false},
+		{"due on a Sunday, so today", fixture.CreditAccount().DueDay(9).Balance(100).Build(), nil, nil, false},
+		{"due on a holiday before a Sunday, so today", fixture.CreditAccount().DueDay(8).Balance(100).Build(), []entities.Holiday{holiday(8)}, nil, false},
+		{"due on a holiday, moved to a day already past", fixture.CreditAccount().DueDay(6).Balance(100).Build(), []entities.Holiday{holiday(6)}, nil, true},
+		{"long-term with an installment past due", fixture.CreditAccount().LongTerm(0).DueDay(5).Balance(200).Build(), nil,
+			[]entities.Installment{*fixture.Installment(100, day(time.March, 5)), *fixture.Installment(100, day(time.April, 5))}, true},
+		{"long-term with the installments due so far paid", fixture.CreditAccount().LongTerm(0).DueDay(5).Balance(100).Build(), nil,
+			[]entities.Installment{*paid, *fixture.Installment(100, day(time.April, 5))}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestCreditAccountService(t)
+			tt.account.Establishment = establishment
+			m.accounts.EXPECT().GetOwingCreditAccounts(fixture.EstablishmentID).Return([]entities.CreditAccount{*tt.account}, nil)
+			m.holidays.EXPECT().GetHolidaysByEstablishmentID(fixture.EstablishmentID, 0).Return(tt.holidays, nil)
+			if tt.account.CreditType == enums.LongTerm {
+				m.installments.EXPECT().GetInstallmentsByCreditAccountID(tt.account.ID).Return(tt.installments, nil)
+			}
+
+			overdue, err := s.GetOverdueCreditAccounts(fixture.EstablishmentID, CreditAccountIncludes{})
+			if err != nil {
+				t.Fatalf("GetOverdueCreditAccounts returned %v", err)
+			}
+			if got := len(overdue) == 1; got != tt.want {
+				t.Errorf("listed %d accounts, want overdue %t", len(overdue), tt.want)
+			}
+		})
+	}
+}
+
+func TestLateFeeFor(t *testing.T) {
+	// fixture.Now is Monday, March 10 2025 in Lima, 5 days after a due date on the 5th
+	loc := util.LoadLocation("America/Lima")
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, loc)
+	}
+	establishment := fixture.Establishment().LateFee(5).Build()
+	shortTerm := func(dueDay int, balance float64) *fixture.CreditAccountBuilder {
+		return fixture.CreditAccount().DueDay(dueDay).Balance(balance).Establishment(establishment)
+	}
+	paid := fixture.Installment(100, day(time.February, 5))
+	paid.Status = enums.Paid
+	type fee struct {
+		amount       float64
+		cycleDueDate time.Time
+		daysOverdue  int
+	}
+	tests := []struct {
+		name         string
+		settings     *entities.EstablishmentSettings
+		account      *entities.CreditAccount
+		installments []entities.Installment
+		holidays     []time.Time
+		want         *fee // Nil if no fee is owed
+	}{
+		{"flat", fixture.Settings().Build(), shortTerm(5, 200).Build(), nil, nil, &fee{10, day(time.March, 5), 5}},
+		{"flat on what the account credit doesn't cover", fixture.Settings().Build(), shortTerm(5, 200).AccountCredit(50).Build(), nil, nil, &fee{7.5, day(time.March, 5), 5}},
+		{"daily", fixture.Settings().LateFeeMode(enums.LateFeeDaily, 0).Build(), shortTerm(5, 200).Build(), nil, nil, &fee{50, day(time.March, 5), 5}},
+		{"fixed", fixture.Settings().LateFeeMode(enums.LateFeeFixed, 15).Build(), shortTerm(5, 200).Build(), nil, nil, &fee{15, day(time.March, 5), 5}},
+		{"fixed without an amount", fixture.Settings().LateFeeMode(enums.LateFeeFixed, 0).Build(), shortTerm(5, 200).Build(), nil, nil, nil},
+		{"daily, capped", fixture.Settings().LateFeeMode(enums.LateFeeDaily, 0).LateFeeCap(15).Build(), shortTerm(5, 200).Build(), nil, nil, &fee{30, day(time.March, 5), 5}},
+		{"fixed, capped", fixture.Settings().LateFeeMode(enums.LateFeeFixed, 15).LateFeeCap(5).Build(), shortTerm(5, 200).Build(), nil, nil, &fee{10, day(time.March, 5), 5}},
+		{"flat, under the cap", fixture.Settings().LateFeeCap(10).Build(), shortTerm(5, 200).Build(), nil, nil, &fee{10, day(time.March, 5), 5}},
+		{"due today", fixture.Settings().Build(), shortTerm(10, 200).Build(), nil, nil, nil},
+		{"due later this month", fixture.Settings().Build(), shortTerm(15, 200).Build(), nil, nil, nil},
+		{"paid off", fixture.Settings().Build(), shortTerm(5, 0).Build(), nil, nil, nil},
+		{"covered by account credit", fixture.Settings().Build(), shortTerm(5, 200).AccountCredit(200).Build(), nil, nil, nil},
+		{"due on a holiday, counted from the next business day", fixture.Settings().LateFeeMode(enums.LateFeeDaily, 0).Build(), shortTerm(6, 200).Build(), nil,
+			[]time.Time{time.Date(2025, time.March, 6, 0, 0, 0, 0, time.UTC)}, &fee{30, day(time.March, 7), 3}},
+		{"long-term, on the overdue installments", fixture.Settings().Build(), shortTerm(5, 300).LongTerm(0).Build(),
+			[]entities.Installment{*fixture.Installment(100, day(time.February, 5)), *fixture.Installment(100, day(time.March, 5)), *fixture.Installment(100, day(time.April, 5))},
+			nil, &fee{10, day(time.February, 5), 33}},
+		{"long-term, paid installments aren't overdue", fixture.Settings().Build(), shortTerm(5, 200).LongTerm(0).Build(),
+			[]entities.Installment{*paid, *fixture.Installment(100, day(time.March, 5)), *fixture.Installment(100, day(time.April, 5))},
+			nil, &fee{5, day(time.March, 5), 5}},
+		{"long-term, none due yet", fixture.Settings().Build(), shortTerm(5, 200).LongTerm(0).Build(),
+			[]entities.Installment{*fixture.Installment(100, day(time.April, 5)), *fixture.Installment(100, day(time.May, 5))}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lateFeeFor(tt.settings, tt.account, tt.installments, calendar.NewBusinessDays(tt.holidays), fixture.Now)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("lateFeeFor = %.2f, want no fee", got.Amount)
+			case tt.want != nil && got == nil:
+				t.Errorf("lateFeeFor = no fee, want %.2f", tt.want.amount)
+			case tt.want != nil && (got.Amount != tt.want.amount || !got.CycleDueDate.Equal(tt.want.cycleDueDate) || got.DaysOverdue != tt.want.daysOverdue):
+				t.Errorf("lateFeeFor = %.2f for the cycle due %s, %d days overdue, want %.2f, %s, %d",
+					got.Amount, got.CycleDueDate.Format("2006-01-02"), got.DaysOverdue, tt.want.amount, tt.want.cycleDueDate.Format("2006-01-02"), tt.want.daysOverdue)
+			}
+		})
+	}
+}
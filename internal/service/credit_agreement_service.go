@@ -0,0 +1,303 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jung-kurt/gofpdf"
+	"gorm.io/gorm"
+)
+
+// CreditAgreementService handles the credit agreements clients accept before purchasing on credit.
+type CreditAgreementService interface {
+	GetClientCreditAgreement(clientID, establishmentID uint) (*response.CreditAgreementResponse, error)
+	GetClientCreditAgreementPDF(clientID, establishmentID uint) ([]byte, error)
+	AcceptClientCreditAgreement(clientID, establishmentID uint, ip, userAgent string) (*response.CreditAgreementResponse, error)
+	GetCreditAgreement(adminID, creditAccountID uint) (*response.CreditAgreementResponse, error)
+	GetCreditAgreementPDF(adminID, creditAccountID uint) ([]byte, error)
+}
+
+type creditAgreementService struct {
+	agreementRepo     repository.CreditAgreementRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+	bus               event.Bus
+}
+
+// NewCreditAgreementService creates a new instance of CreditAgreementService.
+func NewCreditAgreementService(agreementRepo repository.CreditAgreementRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock, bus event.Bus) CreditAgreementService {
+	return &creditAgreementService{
+		agreementRepo:     agreementRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		clock:             clock,
+		bus:               bus,
+	}
+}
+
+// agreementTemplate is the text of the credit agreement, populated with the terms of the account.
+var agreementTemplate = template.Must(template.New("agreement").Parse(`PRIMERA - PARTES
+{{.Establishment}}, con RUC {{.RUC}} y domicilio en {{.Address}} (EL ESTABLECIMIENTO), y {{.Client}}, identificado con DNI {{.DNI}} (EL CLIENTE), celebran el presente contrato de credito.
+
+SEGUNDA - LINEA DE CREDITO
+EL ESTABLECIMIENTO otorga a EL CLIENTE una linea de credito {{.CreditType}} de S/ {{printf "%.2f" .CreditLimit}} para compras en sus locales. EL CLIENTE no podra comprar por encima de la linea disponible.
+
+TERCERA - INTERESES Y COSTO DEL CREDITO
+El saldo deudor devenga una tasa de interes {{.Rate}}. La Tasa de Costo Efectivo Anual (TCEA) del credito es {{printf "%.2f" .TCEA}}%.
+
+CUARTA - PAGOS
+EL CLIENTE pagara el saldo deudor el dia {{.DueDay}} de cada mes.{{if .GracePeriod}} El credito tiene un periodo de gracia de {{.GracePeriod}} meses.{{end}}
+
+QUINTA - MORA
+Los pagos vencidos generan un cargo por mora del {{printf "%.2f" .LateFee}}% sobre el saldo vencido, y EL ESTABLECIMIENTO podra bloquear la linea de credito mientras la deuda siga vencida.
+
+SEXTA - ACEPTACION
+EL CLIENTE acepta el presente contrato por medios electronicos. Las compras al credito quedan habilitadas desde su aceptacion.`))
+
+// agreementTerms are the data the agreement template is populated with.
+type agreementTerms struct {
+	Establishment, RUC, Address string
+	Client, DNI                 string
+	CreditType                  string
+	CreditLimit                 float64
+	Rate                        string
+	TCEA                        float64
+	DueDay                      int
+	GracePeriod                 int
+	LateFee                     float64
+}
+
+// GetClientCreditAgreement retrieves the credit agreement of the client's account in an establishment.
+func (s *creditAgreementService) GetClientCreditAgreement(clientID, establishmentID uint) (*response.CreditAgreementResponse, error) {
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	agreement, err := s.findAgreement(creditAccount)
+	if err != nil {
+		return nil, err
+	}
+	return creditAgreementToResponse(agreement), nil
+}
+
+// GetClientCreditAgreementPDF renders the credit agreement of the client's account in an establishment.
+func (s *creditAgreementService) GetClientCreditAgreementPDF(clientID, establishmentID uint) ([]byte, error) {
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.agreementPDF(creditAccount)
+}
+
+// AcceptClientCreditAgreement records the client's acceptance of the credit agreement of their account
+// in an establishment, from ip, along with the hash of the agreement text, and allows purchases on it.
+func (s *creditAgreementService) AcceptClientCreditAgreement(clientID, establishmentID uint, ip, userAgent string) (*response.CreditAgreementResponse, error) {
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	agreement, err := s.findAgreement(creditAccount)
+	if err != nil {
+		return nil, err
+	}
+	if agreement.AcceptedAt != nil {
+		return nil, ErrCreditAgreementAccepted
+	}
+
+	text, err := agreementText(agreement, creditAccount)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256([]byte(text))
+	now := s.clock.Now()
+	agreement.AcceptedAt, agreement.AcceptedIP, agreement.AcceptedUserAgent = &now, ip, userAgent
+	agreement.TermsHash, agreement.UpdatedAt = hex.EncodeToString(hash[:]), now
+	if err := s.agreementRepo.AcceptCreditAgreement(agreement); err != nil {
+		if errors.Is(err, repository.ErrAgreementAccepted) {
+			return nil, ErrCreditAgreementAccepted
+		}
+		return nil, fmt.Errorf("error accepting credit agreement: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.CreditAgreementAccepted, creditAccount.ID)
+	return creditAgreementToResponse(agreement), nil
+}
+
+// GetCreditAgreement retrieves the credit agreement of a credit account of one of the admin's
+// establishments.
+func (s *creditAgreementService) GetCreditAgreement(adminID, creditAccountID uint) (*response.CreditAgreementResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	agreement, err := s.findAgreement(creditAccount)
+	if err != nil {
+		return nil, err
+	}
+	return creditAgreementToResponse(agreement), nil
+}
+
+// GetCreditAgreementPDF renders the credit agreement of a credit account of one of the admin's
+// establishments.
+func (s *creditAgreementService) GetCreditAgreementPDF(adminID, creditAccountID uint) ([]byte, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.agreementPDF(creditAccount)
+}
+
+// findAgreement retrieves the credit agreement of an account. Accounts opened before agreements were
+// introduced have none.
+func (s *creditAgreementService) findAgreement(creditAccount *entities.CreditAccount) (*entities.CreditAgreement, error) {
+	agreement, err := s.agreementRepo.GetCreditAgreementByCreditAccountID(creditAccount.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAgreementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit agreement: %w", err)
+	}
+	return agreement, nil
+}
+
+func (s *creditAgreementService) adminCreditAccount(adminID, creditAccountID uint) (*entities.CreditAccount, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
+	return creditAccount, nil
+}
+
+// agreementText populates the agreement template with the terms of an agreement and the parties of its
+// account.
+func agreementText(agreement *entities.CreditAgreement, creditAccount *entities.CreditAccount) (string, error) {
+	terms := agreementTerms{
+		CreditLimit: agreement.CreditLimit,
+		Rate:        fmt.Sprintf("efectiva anual de %.2f%%", agreement.InterestRate),
+		TCEA:        agreement.TCEA,
+		DueDay:      agreement.MonthlyDueDate,
+		LateFee:     agreement.LateFeePercentage,
+		CreditType:  "de corto plazo",
+	}
+	if agreement.InterestType == enums.Nominal {
+		periods := map[enums.CompoundingPeriod]string{enums.Daily: "diariamente", enums.Monthly: "mensualmente", enums.Quarterly: "trimestralmente"}
+		terms.Rate = fmt.Sprintf("nominal anual de %.2f%% capitalizable %s", agreement.InterestRate, periods[agreement.CompoundingPeriod])
+	}
+	if agreement.CreditType == enums.LongTerm {
+		terms.CreditType, terms.GracePeriod = "de largo plazo", agreement.GracePeriod
+	}
+	if establishment := creditAccount.Establishment; establishment != nil {
+		terms.Establishment, terms.RUC, terms.Address = establishment.Name, establishment.RUC, establishment.Address
+	}
+	if client := creditAccount.Client; client != nil {
+		terms.Client, terms.DNI = client.Name, client.DNI
+	}
+
+	var text bytes.Buffer
+	if err := agreementTemplate.Execute(&text, terms); err != nil {
+		return "", fmt.Errorf("error populating credit agreement: %w", err)
+	}
+	return text.String(), nil
+}
+
+// agreementPDF renders the credit agreement of an account. Once accepted it carries the acceptance: when,
+// from which IP and the hash of the text accepted.
+func (s *creditAgreementService) agreementPDF(creditAccount *entities.CreditAccount) ([]byte, error) {
+	agreement, err := s.findAgreement(creditAccount)
+	if err != nil {
+		return nil, err
+	}
+	text, err := agreementText(agreement, creditAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 10, "CONTRATO DE CREDITO", "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Cuenta de credito N. %d", creditAccount.ID), "", 1, "C", false, 0, "")
+	pdf.Ln(6)
+
+	for _, clause := range strings.Split(text, "\n\n") {
+		title, body, _ := strings.Cut(clause, "\n")
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(0, 6, tr(title), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 5, tr(body), "", "J", false)
+		pdf.Ln(3)
+	}
+	pdf.Ln(6)
+
+	// Digital acceptance
+	pdf.SetFont("Arial", "B", 10)
+	if agreement.AcceptedAt == nil {
+		pdf.CellFormat(0, 8, "PENDIENTE DE ACEPTACION", "1", 1, "C", false, 0, "")
+	} else {
+		acceptedAt := agreement.AcceptedAt.In(accountLocation(creditAccount))
+		pdf.CellFormat(0, 8, "ACEPTADO ELECTRONICAMENTE", "LTR", 1, "C", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(0, 6, tr(fmt.Sprintf("Fecha: %s   IP: %s", acceptedAt.Format("02/01/2006 15:04:05 MST"), agreement.AcceptedIP)), "LR", 1, "C", false, 0, "")
+		pdf.CellFormat(0, 6, "SHA-256: "+agreement.TermsHash, "LBR", 1, "C", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("error generating PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// agreementPending reports whether the credit agreement of an account is still to be accepted, which
+// holds its purchases.
+func agreementPending(agreementRepo repository.CreditAgreementRepository, creditAccount *entities.CreditAccount) (bool, error) {
+	agreement, err := agreementRepo.GetCreditAgreementByCreditAccountID(creditAccount.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error retrieving credit agreement: %w", err)
+	}
+	return agreement.AcceptedAt == nil, nil
+}
+
+func creditAgreementToResponse(agreement *entities.CreditAgreement) *response.CreditAgreementResponse {
+	return &response.CreditAgreementResponse{
+		ID:                agreement.ID,
+		CreditAccountID:   agreement.CreditAccountID,
+		CreditLimit:       agreement.CreditLimit,
+		InterestRate:      agreement.InterestRate,
+		InterestType:      agreement.InterestType,
+		CompoundingPeriod: agreement.CompoundingPeriod,
+		TCEA:              roundRate(agreement.TCEA),
+		CreditType:        agreement.CreditType,
+		MonthlyDueDate:    agreement.MonthlyDueDate,
+		GracePeriod:       agreement.GracePeriod,
+		LateFeePercentage: agreement.LateFeePercentage,
+		Accepted:          agreement.AcceptedAt != nil,
+		AcceptedAt:        agreement.AcceptedAt,
+		AcceptedIP:        agreement.AcceptedIP,
+		TermsHash:         agreement.TermsHash,
+		CreatedAt:         agreement.CreatedAt,
+	}
+}
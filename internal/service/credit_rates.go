@@ -0,0 +1,29 @@
+package service
+
+import (
+	"ApiRestFinance/internal/finance"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+)
+
+// creditRatesToResponse returns the disclosed rates of an account, or nil if its configuration is invalid.
+func creditRatesToResponse(account *entities.CreditAccount) *response.CreditRatesResponse {
+	rates, err := finance.AccountRates(*account)
+	if err != nil {
+		return nil
+	}
+	return &response.CreditRatesResponse{
+		TNA:  roundRate(rates.TNA * 100),
+		TEA:  roundRate(rates.TEA * 100),
+		TCEA: roundRate(rates.TCEA * 100),
+	}
+}
+
+// compoundingPeriodOrDefault returns period, or MONTHLY when none was given.
+func compoundingPeriodOrDefault(period enums.CompoundingPeriod) enums.CompoundingPeriod {
+	if period == "" {
+		return enums.Monthly
+	}
+	return period
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/scoring"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"time"
+)
+
+// CreditScoringService recalculates the credit scores and high-risk flags of credit accounts.
+type CreditScoringService interface {
+	ScoreAllAccounts() error
+}
+
+type creditScoringService struct {
+	creditAccountRepo repository.CreditAccountRepository
+	installmentRepo   repository.InstallmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	promiseRepo       repository.PaymentPromiseRepository
+	holidayRepo       repository.HolidayRepository
+	clock             util.Clock
+}
+
+// NewCreditScoringService creates a new instance of CreditScoringService.
+func NewCreditScoringService(creditAccountRepo repository.CreditAccountRepository, installmentRepo repository.InstallmentRepository, settingsRepo repository.EstablishmentSettingsRepository, promiseRepo repository.PaymentPromiseRepository, holidayRepo repository.HolidayRepository, clock util.Clock) CreditScoringService {
+	return &creditScoringService{
+		creditAccountRepo: creditAccountRepo,
+		installmentRepo:   installmentRepo,
+		settingsRepo:      settingsRepo,
+		promiseRepo:       promiseRepo,
+		holidayRepo:       holidayRepo,
+		clock:             clock,
+	}
+}
+
+// ScoreAllAccounts scores every credit account and flags as high risk the ones scoring below their
+// establishment's threshold.
+func (s *creditScoringService) ScoreAllAccounts() error {
+	accounts, err := s.creditAccountRepo.GetAllCreditAccounts()
+	if err != nil {
+		return fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+
+	now := s.clock.Now()
+	thresholds := make(map[uint]int)
+	calendars := make(map[uint]calendar.BusinessDays)
+	failed := 0
+	for i := range accounts {
+		account := &accounts[i]
+		threshold, ok := thresholds[account.EstablishmentID]
+		if !ok {
+			settings, err := s.settingsRepo.GetEstablishmentSettings(account.EstablishmentID)
+			if err != nil {
+				return fmt.Errorf("error retrieving establishment settings: %w", err)
+			}
+			threshold = settings.HighRiskScore
+			thresholds[account.EstablishmentID] = threshold
+		}
+		days, ok := calendars[account.EstablishmentID]
+		if !ok {
+			days, err = businessDays(s.holidayRepo, account.EstablishmentID)
+			if err != nil {
+				return err
+			}
+			calendars[account.EstablishmentID] = days
+		}
+
+		history, err := s.history(account, days, now)
+		if err != nil {
+			failed++
+			continue
+		}
+		score := scoring.Score(history)
+		if err := s.creditAccountRepo.UpdateCreditScore(account.ID, score, score < threshold, now); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d credit accounts could not be scored", failed)
+	}
+	return nil
+}
+
+// history gathers the repayment history of an account, with the business days of its establishment.
+func (s *creditScoringService) history(account *entities.CreditAccount, days calendar.BusinessDays, now time.Time) (scoring.History, error) {
+	history := scoring.History{}
+	brokenPromises, err := s.promiseRepo.CountBrokenPaymentPromises(account.ID)
+	if err != nil {
+		return history, fmt.Errorf("error counting broken payment promises: %w", err)
+	}
+	history.BrokenPromises = int(brokenPromises)
+
+	owed := account.CurrentBalance - account.AccountCredit
+	if account.CreditLimit > 0 {
+		history.Utilization = owed / account.CreditLimit
+	}
+
+	if account.CreditType != enums.LongTerm {
+		if owed > 0 {
+			history.DaysOverdue = calculateDaysOverdue(now, account.MonthlyDueDate, accountLocation(account), days)
+		}
+		return history, nil
+	}
+
+	installments, err := s.installmentRepo.GetInstallmentsByCreditAccountID(account.ID)
+	if err != nil {
+		return history, fmt.Errorf("error retrieving installments: %w", err)
+	}
+	history.InstallmentsDue, history.InstallmentsOnTime = installmentPunctuality(installments, now)
+	for _, installment := range installments {
+		if installment.DueDate.Before(now) && installment.Status.IsOpen() {
+			history.OverdueInstallments++
+		}
+	}
+	history.DaysOverdue = installmentsDaysOverdue(installments, now)
+	return history, nil
+}
+
+// installmentPunctuality counts the installments whose due date passed by now, and those of them paid
+// on time: paid in full by the end of their due date. Cancelled installments aren't counted.
+func installmentPunctuality(installments []entities.Installment, now time.Time) (due, onTime int) {
+	for _, installment := range installments {
+		if !installment.DueDate.Before(now) || installment.Status == enums.Cancelled {
+			continue
+		}
+		due++
+		if installment.PaidAt != nil && installment.PaidAt.Before(installment.DueDate.AddDate(0, 0, 1)) {
+			onTime++
+		}
+	}
+	return due, onTime
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"ApiRestFinance/internal/finance"
+	"ApiRestFinance/internal/interest"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"math"
+)
+
+// CreditSimulationService builds repayment schedules for prospective credits without persisting anything.
+type CreditSimulationService interface {
+	SimulateCredit(req request.CreateCreditSimulationRequest, adminID uint) (*response.CreditSimulationResponse, error)
+}
+
+type creditSimulationService struct {
+	establishmentRepo repository.EstablishmentRepository
+	holidayRepo       repository.HolidayRepository
+	clock             util.Clock
+}
+
+// NewCreditSimulationService creates a new instance of CreditSimulationService.
+func NewCreditSimulationService(establishmentRepo repository.EstablishmentRepository, holidayRepo repository.HolidayRepository, clock util.Clock) CreditSimulationService {
+	return &creditSimulationService{establishmentRepo: establishmentRepo, holidayRepo: holidayRepo, clock: clock}
+}
+
+// SimulateCredit returns the installment schedule, total interest and effective annual cost of a credit.
+// Installments follow the French (constant payment) system over one-month periods. Long-term credits may
+// start with grace months that neither accrue interest nor are paid, which push the first installment
+// back; short-term credits are repaid in a single payment.
+func (s *creditSimulationService) SimulateCredit(req request.CreateCreditSimulationRequest, adminID uint) (*response.CreditSimulationResponse, error) {
+	switch req.CreditType {
+	case enums.ShortTerm:
+		if req.NumberOfInstallments != 1 || req.GracePeriod != 0 {
+			return nil, fmt.Errorf("%w: short-term credit is repaid in a single installment without grace period", ErrInvalidSimulation)
+		}
+	case enums.LongTerm:
+	default:
+		return nil, fmt.Errorf("%w: invalid credit type: %s", ErrInvalidSimulation, req.CreditType)
+	}
+
+	compoundingPeriod := compoundingPeriodOrDefault(req.CompoundingPeriod)
+	monthlyRate, err := interest.RateForMonths(req.InterestRate/100, req.InterestType, compoundingPeriod, 1)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSimulation, err)
+	}
+
+	establishment, err := s.establishmentRepo.GetEstablishmentByAdminID(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	days, err := businessDays(s.holidayRepo, establishment.ID)
+	if err != nil {
+		return nil, err
+	}
+	loc := establishmentLocation(establishment)
+	today := s.clock.Now().In(loc)
+
+	monthlyDueDate := req.MonthlyDueDate
+	if monthlyDueDate == 0 {
+		monthlyDueDate = today.Day()
+	}
+	firstDueDate := util.FollowingMonthDueDate(today, monthlyDueDate, loc)
+
+	principal := roundCurrency(req.Principal)
+	payment := roundCurrency(finance.FrenchPayment(principal, monthlyRate, req.NumberOfInstallments))
+	totalPeriods := req.GracePeriod + req.NumberOfInstallments
+
+	balance := principal
+	cashFlows := []float64{-principal}
+	schedule := make([]response.SimulatedInstallment, 0, totalPeriods)
+	var totalInterest, totalPayment float64
+
+	for i := 0; i < totalPeriods; i++ {
+		periodInterest := roundCurrency(balance * monthlyRate)
+		if i < req.GracePeriod {
+			periodInterest = 0 // No interest accrues during the grace period
+		}
+		installment := response.SimulatedInstallment{
+			Number:         i + 1,
+			DueDate:        days.Next(util.AddMonthsToDueDate(firstDueDate, i, monthlyDueDate, loc)),
+			OpeningBalance: balance,
+			Interest:       periodInterest,
+			IsGracePeriod:  i < req.GracePeriod,
+		}
+
+		switch {
+		case installment.IsGracePeriod:
+			// Nothing is due until the grace period is over
+		case i == totalPeriods-1:
+			// The last installment settles whatever rounding left over
+			installment.Amortization = balance
+			installment.Payment = roundCurrency(periodInterest + balance)
+		default:
+			installment.Payment = payment
+			installment.Amortization = roundCurrency(payment - periodInterest)
+		}
+
+		balance = roundCurrency(balance - installment.Amortization)
+		installment.ClosingBalance = balance
+
+		totalInterest += installment.Interest
+		totalPayment += installment.Payment
+		cashFlows = append(cashFlows, installment.Payment)
+		schedule = append(schedule, installment)
+	}
+
+	return &response.CreditSimulationResponse{
+		Principal:            principal,
+		InterestRate:         req.InterestRate,
+		InterestType:         req.InterestType,
+		CompoundingPeriod:    compoundingPeriod,
+		CreditType:           req.CreditType,
+		NumberOfInstallments: req.NumberOfInstallments,
+		GracePeriod:          req.GracePeriod,
+		MonthlyRate:          roundRate(monthlyRate * 100),
+		EffectiveAnnualRate:  roundRate(finance.AnnualizePeriodicRate(monthlyRate, finance.MonthsPerYear) * 100),
+		TCEA:                 roundRate(finance.TCEA(cashFlows) * 100),
+		TotalInterest:        roundCurrency(totalInterest),
+		TotalPayment:         roundCurrency(totalPayment),
+		Schedule:             schedule,
+	}, nil
+}
+
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func roundRate(rate float64) float64 {
+	return math.Round(rate*10000) / 10000
+}
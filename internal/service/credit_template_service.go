@@ -0,0 +1,207 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// CreditTemplateService handles the credit templates establishments open credit accounts with.
+type CreditTemplateService interface {
+	GetCreditTemplates(adminID, branchID uint) ([]response.CreditTemplateResponse, error)
+	CreateCreditTemplate(adminID, branchID uint, req request.CreditTemplateRequest) (*response.CreditTemplateResponse, error)
+	UpdateCreditTemplate(adminID, branchID, templateID uint, req request.CreditTemplateRequest) (*response.CreditTemplateResponse, error)
+	DeleteCreditTemplate(adminID, branchID, templateID uint) error
+}
+
+type creditTemplateService struct {
+	templateRepo      repository.CreditTemplateRepository
+	establishmentRepo repository.EstablishmentRepository
+}
+
+// NewCreditTemplateService creates a new instance of CreditTemplateService.
+func NewCreditTemplateService(templateRepo repository.CreditTemplateRepository, establishmentRepo repository.EstablishmentRepository) CreditTemplateService {
+	return &creditTemplateService{templateRepo: templateRepo, establishmentRepo: establishmentRepo}
+}
+
+// GetCreditTemplates retrieves the credit templates of the admin's establishment, or the selected branch.
+func (s *creditTemplateService) GetCreditTemplates(adminID, branchID uint) ([]response.CreditTemplateResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.templateRepo.GetCreditTemplatesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit templates: %w", err)
+	}
+
+	templateResponses := make([]response.CreditTemplateResponse, len(templates))
+	for i := range templates {
+		templateResponses[i] = *creditTemplateToResponse(&templates[i])
+	}
+	return templateResponses, nil
+}
+
+// CreateCreditTemplate adds a credit template to the admin's establishment, or the selected branch.
+func (s *creditTemplateService) CreateCreditTemplate(adminID, branchID uint, req request.CreditTemplateRequest) (*response.CreditTemplateResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	template := entities.CreditTemplate{EstablishmentID: establishment.ID}
+	setCreditTemplateTerms(&template, req)
+	if err := s.checkNameAvailable(establishment.ID, 0, template.Name); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.CreateCreditTemplate(&template); err != nil {
+		if errors.Is(err, repository.ErrCreditTemplateExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error creating credit template: %w", err)
+	}
+	return creditTemplateToResponse(&template), nil
+}
+
+// UpdateCreditTemplate changes the name and terms of a credit template of the admin's establishment,
+// or the selected branch. Accounts already opened with it keep their terms.
+func (s *creditTemplateService) UpdateCreditTemplate(adminID, branchID, templateID uint, req request.CreditTemplateRequest) (*response.CreditTemplateResponse, error) {
+	template, err := s.findCreditTemplate(adminID, branchID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	setCreditTemplateTerms(template, req)
+	if err := s.checkNameAvailable(template.EstablishmentID, template.ID, template.Name); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.UpdateCreditTemplate(template); err != nil {
+		if errors.Is(err, repository.ErrCreditTemplateExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error updating credit template: %w", err)
+	}
+	return creditTemplateToResponse(template), nil
+}
+
+// DeleteCreditTemplate deletes a credit template of the admin's establishment, or the selected
+// branch. Accounts opened with it keep their terms.
+func (s *creditTemplateService) DeleteCreditTemplate(adminID, branchID, templateID uint) error {
+	template, err := s.findCreditTemplate(adminID, branchID, templateID)
+	if err != nil {
+		return err
+	}
+	if err := s.templateRepo.DeleteCreditTemplate(template.ID); err != nil {
+		return fmt.Errorf("error deleting credit template: %w", err)
+	}
+	return nil
+}
+
+// findCreditTemplate retrieves a credit template of the admin's establishment, or the selected branch.
+func (s *creditTemplateService) findCreditTemplate(adminID, branchID, templateID uint) (*entities.CreditTemplate, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	return establishmentCreditTemplate(s.templateRepo, establishment.ID, templateID)
+}
+
+// checkNameAvailable rejects a blank name, or one another credit template of the establishment than
+// the one being renamed already has. Names are compared ignoring case.
+func (s *creditTemplateService) checkNameAvailable(establishmentID, templateID uint, name string) error {
+	if name == "" {
+		return ErrInvalidCreditTemplateName
+	}
+	templates, err := s.templateRepo.GetCreditTemplatesByEstablishmentID(establishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving credit templates: %w", err)
+	}
+	for _, template := range templates {
+		if template.ID != templateID && strings.EqualFold(template.Name, name) {
+			return ErrCreditTemplateExists
+		}
+	}
+	return nil
+}
+
+// establishmentCreditTemplate retrieves a credit template of an establishment.
+func establishmentCreditTemplate(templateRepo repository.CreditTemplateRepository, establishmentID, templateID uint) (*entities.CreditTemplate, error) {
+	template, err := templateRepo.GetCreditTemplateByID(templateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && template.EstablishmentID != establishmentID) {
+		return nil, ErrCreditTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit template: %w", err)
+	}
+	return template, nil
+}
+
+// applyCreditTemplate opens a credit account of an establishment with the credit template templateID,
+// if not nil: the account takes the terms of the template it doesn't set, and remembers the template.
+func applyCreditTemplate(templateRepo repository.CreditTemplateRepository, templateID *uint, creditAccount *entities.CreditAccount) error {
+	if templateID == nil {
+		return nil
+	}
+	template, err := establishmentCreditTemplate(templateRepo, creditAccount.EstablishmentID, *templateID)
+	if err != nil {
+		return err
+	}
+
+	if creditAccount.CreditLimit == 0 {
+		creditAccount.CreditLimit = template.CreditLimit
+	}
+	if creditAccount.MonthlyDueDate == 0 {
+		creditAccount.MonthlyDueDate = template.MonthlyDueDate
+	}
+	if creditAccount.InterestRate == 0 {
+		creditAccount.InterestRate = template.InterestRate
+	}
+	if creditAccount.InterestType == "" {
+		creditAccount.InterestType = template.InterestType
+	}
+	if creditAccount.CompoundingPeriod == "" {
+		creditAccount.CompoundingPeriod = template.CompoundingPeriod
+	}
+	if creditAccount.CreditType == "" {
+		creditAccount.CreditType = template.CreditType
+	}
+	if creditAccount.GracePeriod == 0 {
+		creditAccount.GracePeriod = template.GracePeriod
+	}
+	creditAccount.CreditTemplateID = &template.ID
+	return nil
+}
+
+func setCreditTemplateTerms(template *entities.CreditTemplate, req request.CreditTemplateRequest) {
+	template.Name = strings.TrimSpace(req.Name)
+	template.CreditLimit = req.CreditLimit
+	template.MonthlyDueDate = req.MonthlyDueDate
+	template.InterestRate = req.InterestRate
+	template.InterestType = req.InterestType
+	template.CompoundingPeriod = compoundingPeriodOrDefault(req.CompoundingPeriod)
+	template.CreditType = req.CreditType
+	template.GracePeriod = req.GracePeriod
+}
+
+func creditTemplateToResponse(template *entities.CreditTemplate) *response.CreditTemplateResponse {
+	return &response.CreditTemplateResponse{
+		ID:                template.ID,
+		EstablishmentID:   template.EstablishmentID,
+		Name:              template.Name,
+		CreditLimit:       template.CreditLimit,
+		MonthlyDueDate:    template.MonthlyDueDate,
+		InterestRate:      template.InterestRate,
+		InterestType:      template.InterestType,
+		CompoundingPeriod: template.CompoundingPeriod,
+		CreditType:        template.CreditType,
+		GracePeriod:       template.GracePeriod,
+		CreatedAt:         template.CreatedAt,
+		UpdatedAt:         template.UpdatedAt,
+	}
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CreditTermUpdateService changes the terms of many credit accounts of an establishment at once, e.g.
+// when it changes its rate or late fee policy, and keeps the audit trail of those changes.
+type CreditTermUpdateService interface {
+	BulkUpdateCreditAccounts(adminID, branchID uint, req request.BulkUpdateCreditAccountsRequest) (*response.BulkCreditTermUpdateResponse, error)
+	GetCreditTermUpdates(adminID, branchID uint) ([]response.CreditTermUpdateResponse, error)
+	GetCreditTermUpdate(adminID, branchID, updateID uint) (*response.CreditTermUpdateResponse, error)
+}
+
+type creditTermUpdateService struct {
+	updateRepo        repository.CreditTermUpdateRepository
+	templateRepo      repository.CreditTemplateRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+	bus               event.Bus
+}
+
+// NewCreditTermUpdateService creates a new instance of CreditTermUpdateService.
+func NewCreditTermUpdateService(updateRepo repository.CreditTermUpdateRepository, templateRepo repository.CreditTemplateRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock, bus event.Bus) CreditTermUpdateService {
+	return &creditTermUpdateService{
+		updateRepo:        updateRepo,
+		templateRepo:      templateRepo,
+		establishmentRepo: establishmentRepo,
+		clock:             clock,
+		bus:               bus,
+	}
+}
+
+// BulkUpdateCreditAccounts sets the terms of the request on the credit accounts of the admin's
+// establishment, or the selected branch, its filter matches, reporting what it did with each of them.
+// Each account is updated on its own, so one changed meanwhile fails without holding back the rest;
+// written-off accounts are skipped. The update and every term it changed are kept as its audit trail.
+// In preview nothing is changed or recorded, and the report lists what the update would do.
+func (s *creditTermUpdateService) BulkUpdateCreditAccounts(adminID, branchID uint, req request.BulkUpdateCreditAccountsRequest) (*response.BulkCreditTermUpdateResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Terms.Empty() {
+		return nil, ErrNoCreditTermChanges
+	}
+	if req.Filter.CreditTemplateID != nil {
+		if _, err := establishmentCreditTemplate(s.templateRepo, establishment.ID, *req.Filter.CreditTemplateID); err != nil {
+			return nil, err
+		}
+	}
+	accounts, err := s.updateRepo.GetFilteredCreditAccounts(establishment.ID, creditAccountFilter(req.Filter))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+
+	now := s.clock.Now()
+	report := &response.BulkCreditTermUpdateResponse{Preview: req.Preview, Accounts: make([]response.CreditTermAccountResult, len(accounts))}
+	changesByAccount := make([][]entities.CreditTermChange, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+		result := response.CreditTermAccountResult{
+			CreditAccountID: account.ID,
+			ClientID:        account.ClientID,
+			Changes:         make([]response.CreditTermChangeResponse, 0),
+		}
+		if account.Client != nil {
+			result.ClientName = account.Client.Name
+		}
+		switch {
+		case account.WrittenOffAt != nil:
+			result.Action, result.Reason = enums.CreditTermSkipped, "the account was written off, its terms are frozen"
+		default:
+			changesByAccount[i] = setCreditTerms(account, req.Terms, now)
+			result.Action = enums.CreditTermUpdate
+			if len(changesByAccount[i]) == 0 {
+				result.Action = enums.CreditTermUnchanged
+			}
+			for _, change := range changesByAccount[i] {
+				result.Changes = append(result.Changes, creditTermChangeToResponse(change))
+			}
+		}
+		report.Accounts[i] = result
+	}
+	if req.Preview {
+		report.Summary = summarizeCreditTermUpdate(report.Accounts)
+		return report, nil
+	}
+
+	filter, err := json.Marshal(req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding filter: %w", err)
+	}
+	terms, err := json.Marshal(req.Terms)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding terms: %w", err)
+	}
+	update := entities.CreditTermUpdate{
+		EstablishmentID: establishment.ID,
+		AdminID:         adminID,
+		Reason:          strings.TrimSpace(req.Reason),
+		Filter:          string(filter),
+		Terms:           string(terms),
+		Matched:         len(accounts),
+		CreatedAt:       now,
+	}
+	if err := s.updateRepo.CreateCreditTermUpdate(&update); err != nil {
+		return nil, fmt.Errorf("error recording credit term update: %w", err)
+	}
+
+	for i := range accounts {
+		if report.Accounts[i].Action != enums.CreditTermUpdate {
+			continue
+		}
+		for j := range changesByAccount[i] {
+			changesByAccount[i][j].CreditTermUpdateID = update.ID
+		}
+		err := s.updateRepo.ApplyCreditTermChanges(&accounts[i], &update, changesByAccount[i])
+		switch {
+		case errors.Is(err, repository.ErrVersionConflict):
+			report.Accounts[i].Action, report.Accounts[i].Reason = enums.CreditTermFailed, err.Error()
+		case err != nil:
+			report.Accounts[i].Action, report.Accounts[i].Reason = enums.CreditTermFailed, "error updating credit account"
+		default:
+			publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, accounts[i].ID)
+		}
+	}
+	report.Summary = summarizeCreditTermUpdate(report.Accounts)
+	update.Updated, update.Failed = report.Summary.Updated, report.Summary.Failed
+	if err := s.updateRepo.SaveCreditTermUpdate(&update); err != nil {
+		return nil, fmt.Errorf("error recording credit term update: %w", err)
+	}
+	report.UpdateID = &update.ID
+	return report, nil
+}
+
+// GetCreditTermUpdates retrieves the bulk updates of credit terms of the admin's establishment, or the
+// selected branch, newest first.
+func (s *creditTermUpdateService) GetCreditTermUpdates(adminID, branchID uint) ([]response.CreditTermUpdateResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := s.updateRepo.GetCreditTermUpdatesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit term updates: %w", err)
+	}
+
+	updateResponses := make([]response.CreditTermUpdateResponse, len(updates))
+	for i := range updates {
+		updateResponses[i] = *creditTermUpdateToResponse(&updates[i])
+	}
+	return updateResponses, nil
+}
+
+// GetCreditTermUpdate retrieves a bulk update of credit terms of the admin's establishment, or the
+// selected branch, with every term it changed.
+func (s *creditTermUpdateService) GetCreditTermUpdate(adminID, branchID, updateID uint) (*response.CreditTermUpdateResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	update, err := s.updateRepo.GetCreditTermUpdateByID(updateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && update.EstablishmentID != establishment.ID) {
+		return nil, ErrCreditTermUpdateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit term update: %w", err)
+	}
+
+	updateResponse := creditTermUpdateToResponse(update)
+	updateResponse.Changes = make([]response.CreditTermChangeResponse, len(update.Changes))
+	for i, change := range update.Changes {
+		updateResponse.Changes[i] = creditTermChangeToResponse(change)
+	}
+	return updateResponse, nil
+}
+
+// setCreditTerms sets the terms on a credit account and returns those that changed, as of now.
+func setCreditTerms(creditAccount *entities.CreditAccount, terms request.CreditTermsRequest, now time.Time) []entities.CreditTermChange {
+	var changes []entities.CreditTermChange
+	change := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, entities.CreditTermChange{
+				CreditAccountID: creditAccount.ID,
+				Field:           field,
+				OldValue:        oldValue,
+				NewValue:        newValue,
+				CreatedAt:       now,
+			})
+		}
+	}
+
+	if terms.CreditLimit != nil {
+		change("credit_limit", formatTerm(creditAccount.CreditLimit), formatTerm(*terms.CreditLimit))
+		creditAccount.CreditLimit = *terms.CreditLimit
+	}
+	if terms.MonthlyDueDate != nil {
+		change("monthly_due_date", strconv.Itoa(creditAccount.MonthlyDueDate), strconv.Itoa(*terms.MonthlyDueDate))
+		creditAccount.MonthlyDueDate = *terms.MonthlyDueDate
+	}
+	if terms.InterestRate != nil {
+		change("interest_rate", formatTerm(creditAccount.InterestRate), formatTerm(*terms.InterestRate))
+		creditAccount.InterestRate = *terms.InterestRate
+	}
+	if terms.InterestType != nil {
+		change("interest_type", string(creditAccount.InterestType), string(*terms.InterestType))
+		creditAccount.InterestType = *terms.InterestType
+	}
+	if terms.CompoundingPeriod != nil {
+		change("compounding_period", string(creditAccount.CompoundingPeriod), string(*terms.CompoundingPeriod))
+		creditAccount.CompoundingPeriod = *terms.CompoundingPeriod
+	}
+	if terms.GracePeriod != nil {
+		change("grace_period", strconv.Itoa(creditAccount.GracePeriod), strconv.Itoa(*terms.GracePeriod))
+		creditAccount.GracePeriod = *terms.GracePeriod
+	}
+	if terms.LateFeePercentage != nil {
+		change("late_fee_percentage", formatTerm(creditAccount.LateFeePercentage), formatTerm(*terms.LateFeePercentage))
+		creditAccount.LateFeePercentage = *terms.LateFeePercentage
+	}
+	if terms.SpendingLimit != nil {
+		change("spending_limit", formatTerm(creditAccount.SpendingLimit), formatTerm(*terms.SpendingLimit))
+		creditAccount.SpendingLimit = *terms.SpendingLimit
+	}
+	if terms.SpendingLimitPeriod != nil {
+		change("spending_limit_period", string(creditAccount.SpendingLimitPeriod), string(*terms.SpendingLimitPeriod))
+		creditAccount.SpendingLimitPeriod = *terms.SpendingLimitPeriod
+	}
+	return changes
+}
+
+// formatTerm formats an amount or rate term with as many decimals as it has.
+func formatTerm(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func creditAccountFilter(req request.CreditAccountFilterRequest) repository.CreditAccountFilter {
+	return repository.CreditAccountFilter{
+		CreditAccountIDs: req.CreditAccountIDs,
+		CreditTemplateID: req.CreditTemplateID,
+		CreditType:       req.CreditType,
+		InterestType:     req.InterestType,
+		Status:           req.Status,
+		Tags:             req.Tags,
+	}
+}
+
+func summarizeCreditTermUpdate(results []response.CreditTermAccountResult) response.CreditTermUpdateSummary {
+	summary := response.CreditTermUpdateSummary{Matched: len(results)}
+	for _, result := range results {
+		switch result.Action {
+		case enums.CreditTermUpdate:
+			summary.Updated++
+		case enums.CreditTermUnchanged:
+			summary.Unchanged++
+		case enums.CreditTermSkipped:
+			summary.Skipped++
+		case enums.CreditTermFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+func creditTermUpdateToResponse(update *entities.CreditTermUpdate) *response.CreditTermUpdateResponse {
+	updateResponse := &response.CreditTermUpdateResponse{
+		ID:              update.ID,
+		EstablishmentID: update.EstablishmentID,
+		AdminID:         update.AdminID,
+		Reason:          update.Reason,
+		Matched:         update.Matched,
+		Updated:         update.Updated,
+		Failed:          update.Failed,
+		CreatedAt:       update.CreatedAt,
+	}
+	// The filter and terms were encoded from their requests, which share their JSON names
+	_ = json.Unmarshal([]byte(update.Filter), &updateResponse.Filter)
+	_ = json.Unmarshal([]byte(update.Terms), &updateResponse.Terms)
+	return updateResponse
+}
+
+func creditTermChangeToResponse(change entities.CreditTermChange) response.CreditTermChangeResponse {
+	return response.CreditTermChangeResponse{
+		CreditAccountID: change.CreditAccountID,
+		Field:           change.Field,
+		OldValue:        change.OldValue,
+		NewValue:        change.NewValue,
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreditTermUpdateServiceBulkUpdateCreditAccounts(t *testing.T) {
+	const updateID uint = 7
+	limit := 1500.0
+	// The bulk update raises the limit of every account of the establishment to 1500
+	matched := func() []entities.CreditAccount {
+		writtenOff := fixture.Now
+		return []entities.CreditAccount{
+			*fixture.CreditAccount().Client(fixture.Client().Build()).Build(),
+			*fixture.CreditAccount().ID(101).Limit(limit).Build(),
+			*fixture.CreditAccount().ID(102).With(func(a *entities.CreditAccount) { a.WrittenOffAt = &writtenOff }).Build(),
+		}
+	}
+	tests := []struct {
+		name        string
+		preview     bool
+		applyErr    error // Of updating account 100
+		wantActions []enums.CreditTermAction
+		wantSummary response.CreditTermUpdateSummary
+		wantEvents  []event.Name
+	}{
+		{"preview", true, nil,
+			[]enums.CreditTermAction{enums.CreditTermUpdate, enums.CreditTermUnchanged, enums.CreditTermSkipped},
+			response.CreditTermUpdateSummary{Matched: 3, Updated: 1, Unchanged: 1, Skipped: 1}, nil},
+		{"applied", false, nil,
+			[]enums.CreditTermAction{enums.CreditTermUpdate, enums.CreditTermUnchanged, enums.CreditTermSkipped},
+			response.CreditTermUpdateSummary{Matched: 3, Updated: 1, Unchanged: 1, Skipped: 1}, []event.Name{event.CreditAccountUpdated}},
+		{"account changed meanwhile", false, repository.ErrVersionConflict,
+			[]enums.CreditTermAction{enums.CreditTermFailed, enums.CreditTermUnchanged, enums.CreditTermSkipped},
+			response.CreditTermUpdateSummary{Matched: 3, Unchanged: 1, Skipped: 1, Failed: 1}, nil},
+		{"database error", false, errors.New("connection refused"),
+			[]enums.CreditTermAction{enums.CreditTermFailed, enums.CreditTermUnchanged, enums.CreditTermSkipped},
+			response.CreditTermUpdateSummary{Matched: 3, Unchanged: 1, Skipped: 1, Failed: 1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			updates := mocks.NewMockCreditTermUpdateRepository(ctrl)
+			establishments := mocks.NewMockEstablishmentRepository(ctrl)
+			establishments.EXPECT().GetEstablishmentByAdminID(fixture.AdminID).Return(fixture.Establishment().Build(), nil)
+			updates.EXPECT().GetFilteredCreditAccounts(fixture.EstablishmentID, repository.CreditAccountFilter{}).Return(matched(), nil)
+			if !tt.preview {
+				updates.EXPECT().CreateCreditTermUpdate(gomock.Any()).DoAndReturn(func(update *entities.CreditTermUpdate) error {
+					if update.Matched != 3 || update.AdminID != fixture.AdminID || update.Reason != "Nueva política" {
+						t.Errorf("recorded update %+v", update)
+					}
+					update.ID = updateID
+					return nil
+				})
+				updates.EXPECT().ApplyCreditTermChanges(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(account *entities.CreditAccount, update *entities.CreditTermUpdate, changes []entities.CreditTermChange) error {
+						want := []entities.CreditTermChange{{CreditTermUpdateID: updateID, CreditAccountID: fixture.CreditAccountID, Field: "credit_limit", OldValue: "1000", NewValue: "1500", CreatedAt: fixture.Now}}
+						if account.ID != fixture.CreditAccountID || account.CreditLimit != limit || !reflect.DeepEqual(changes, want) {
+							t.Errorf("applied %+v to account %d with limit %.2f", changes, account.ID, account.CreditLimit)
+						}
+						return tt.applyErr
+					})
+				updates.EXPECT().SaveCreditTermUpdate(gomock.Any()).DoAndReturn(func(update *entities.CreditTermUpdate) error {
+					if update.Updated != tt.wantSummary.Updated || update.Failed != tt.wantSummary.Failed {
+						t.Errorf("saved update with %d updated and %d failed, want %d and %d", update.Updated, update.Failed, tt.wantSummary.Updated, tt.wantSummary.Failed)
+					}
+					return nil
+				})
+			}
+			bus := event.NewInMemoryBus()
+			var events []event.Name
+			bus.SubscribeAll(func(evt event.Event) { events = append(events, evt.Name) })
+			s := NewCreditTermUpdateService(updates, mocks.NewMockCreditTemplateRepository(ctrl), establishments, util.NewFakeClock(fixture.Now), bus)
+
+			report, err := s.BulkUpdateCreditAccounts(fixture.AdminID, 0, request.BulkUpdateCreditAccountsRequest{
+				Terms:   request.CreditTermsRequest{CreditLimit: &limit},
+				Reason:  " Nueva política ",
+				Preview: tt.preview,
+			})
+			if err != nil {
+				t.Fatalf("BulkUpdateCreditAccounts returned %v", err)
+			}
+			var actions []enums.CreditTermAction
+			for _, result := range report.Accounts {
+				actions = append(actions, result.Action)
+				if (result.Action == enums.CreditTermFailed || result.Action == enums.CreditTermSkipped) && result.Reason == "" {
+					t.Errorf("account %d %s without a reason", result.CreditAccountID, result.Action)
+				}
+			}
+			if !reflect.DeepEqual(actions, tt.wantActions) {
+				t.Errorf("actions %v, want %v", actions, tt.wantActions)
+			}
+			if report.Summary != tt.wantSummary {
+				t.Errorf("summary %+v, want %+v", report.Summary, tt.wantSummary)
+			}
+			if (report.UpdateID == nil) != tt.preview || report.Preview != tt.preview {
+				t.Errorf("update ID %v in preview %t", report.UpdateID, report.Preview)
+			}
+			if report.Accounts[0].ClientName != fixture.Client().Build().Name {
+				t.Errorf("client name %q", report.Accounts[0].ClientName)
+			}
+			if !reflect.DeepEqual(events, tt.wantEvents) {
+				t.Errorf("published %v, want %v", events, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestCreditTermUpdateServiceBulkUpdateCreditAccountsWithoutTerms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	establishments := mocks.NewMockEstablishmentRepository(ctrl)
+	establishments.EXPECT().GetEstablishmentByAdminID(fixture.AdminID).Return(fixture.Establishment().Build(), nil)
+	s := NewCreditTermUpdateService(mocks.NewMockCreditTermUpdateRepository(ctrl), mocks.NewMockCreditTemplateRepository(ctrl), establishments,
+		util.NewFakeClock(fixture.Now), event.NewInMemoryBus())
+
+	_, err := s.BulkUpdateCreditAccounts(fixture.AdminID, 0, request.BulkUpdateCreditAccountsRequest{Reason: "Nada"})
+	if !errors.Is(err, ErrNoCreditTermChanges) {
+		t.Errorf("BulkUpdateCreditAccounts = %v, want %v", err, ErrNoCreditTermChanges)
+	}
+}
+
+func TestSetCreditTerms(t *testing.T) {
+	rate, dueDay, grace, fee := 30.5, 28, 2, 7.25
+	nominal, daily, weekly := enums.Nominal, enums.Daily, enums.SpendingWeekly
+	sameLimit, sameDueDay := 1000.0, 15
+	type change struct{ field, oldValue, newValue string }
+	tests := []struct {
+		name  string
+		terms request.CreditTermsRequest
+		want  []change
+	}{
+		{"interest rate", request.CreditTermsRequest{InterestRate: &rate}, []change{{"interest_rate", "24", "30.5"}}},
+		{"due date and grace period", request.CreditTermsRequest{MonthlyDueDate: &dueDay, GracePeriod: &grace},
+			[]change{{"monthly_due_date", "15", "28"}, {"grace_period", "0", "2"}}},
+		{"interest type and compounding", request.CreditTermsRequest{InterestType: &nominal, CompoundingPeriod: &daily},
+			[]change{{"interest_type", "EFFECTIVE", "NOMINAL"}, {"compounding_period", "MONTHLY", "DAILY"}}},
+		{"late fee and spending limit period", request.CreditTermsRequest{LateFeePercentage: &fee, SpendingLimitPeriod: &weekly},
+			[]change{{"late_fee_percentage", "5", "7.25"}, {"spending_limit_period", "MONTHLY", "WEEKLY"}}},
+		{"terms the account already has", request.CreditTermsRequest{CreditLimit: &sameLimit, MonthlyDueDate: &sameDueDay}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := fixture.CreditAccount().Build()
+			var got []change
+			for _, c := range setCreditTerms(account, tt.terms, fixture.Now) {
+				if c.CreditAccountID != account.ID || !c.CreatedAt.Equal(fixture.Now) {
+					t.Errorf("change of account %d at %v", c.CreditAccountID, c.CreatedAt)
+				}
+				got = append(got, change{c.Field, c.OldValue, c.NewValue})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setCreditTerms = %v, want %v", got, tt.want)
+			}
+			if tt.terms.InterestRate != nil && account.InterestRate != *tt.terms.InterestRate {
+				t.Errorf("interest rate %.2f not set", account.InterestRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,605 @@
+package service
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DemoDataService fills a development database with sample establishments, products and clients,
+// and months of their purchases and payments, so reports, overdue flows and PDFs can be tried
+// without setting anything up by hand.
+type DemoDataService interface {
+	SeedDemoData(months int) (*DemoData, error)
+}
+
+// DemoData sums up what SeedDemoData created and how to log in as its users.
+type DemoData struct {
+	AdminEmails    []string
+	ClientEmails   []string
+	Password       string // Of every demo user
+	Establishments int
+	Products       int
+	CreditAccounts int
+	Purchases      int
+	Payments       int
+	Installments   int
+}
+
+// demoPassword is the password of every demo user.
+const demoPassword = "demo1234"
+
+// demoPayer is how a demo client pays what they owe each month.
+type demoPayer int
+
+const (
+	paysOnTime  demoPayer = iota // Pays everything due two days before the due date
+	paysPartly                   // Pays half of the balance, or the oldest installment, on the due date
+	paysLate                     // Is charged a late fee and pays everything due ten days late
+	stopsPaying                  // Pays the first two months and then falls behind for good
+)
+
+type demoPerson struct {
+	name, dni, email, phone, address string
+}
+
+type demoAccount struct {
+	client      demoPerson
+	payer       demoPayer
+	creditType  enums.CreditType
+	creditLimit float64
+	dueDay      int
+	recent      bool // Opened a month ago instead of at the start of the history
+}
+
+type demoProduct struct {
+	sku      string
+	name     string
+	category enums.ProductCategory
+	price    float64
+}
+
+type demoEstablishment struct {
+	name, ruc, phone, address string
+	admin                     demoPerson // Branches share the admin and RUC of their main establishment
+	lateFee                   float64
+	products                  []demoProduct
+	accounts                  []demoAccount
+	branches                  []demoEstablishment
+}
+
+// carmenFlores buys in two establishments, so she has a credit account in each.
+var carmenFlores = demoPerson{"Carmen Flores Ríos", "70123456", "carmen.flores@example.com", "912345678", "Jr. Los Pinos 452, San Juan de Lurigancho, Lima"}
+
+var demoEstablishments = []demoEstablishment{
+	{
+		name: "Bodega Doña Rosa", ruc: "20601234561", phone: "014567890", address: "Av. Los Próceres 245, San Juan de Lurigancho, Lima",
+		admin:   demoPerson{"Rosa Quispe Mamani", "40512367", "rosa.quispe@example.com", "987654321", "Av. Los Próceres 245, San Juan de Lurigancho, Lima"},
+		lateFee: 2,
+		products: []demoProduct{
+			{"DR-001", "Arroz Costeño 5 kg", enums.ProductCategoryGrocery, 24.90},
+			{"DR-002", "Aceite Primor 1 L", enums.ProductCategoryGrocery, 11.50},
+			{"DR-003", "Azúcar rubia 1 kg", enums.ProductCategoryGrocery, 4.20},
+			{"DR-004", "Leche Gloria 400 g x6", enums.ProductCategoryGrocery, 22.80},
+			{"DR-005", "Fideos Don Vittorio 500 g", enums.ProductCategoryGrocery, 3.60},
+			{"DR-006", "Huevos x15", enums.ProductCategoryPoultry, 9.50},
+			{"DR-007", "Pollo entero kg", enums.ProductCategoryPoultry, 10.90},
+			{"DR-008", "Pan francés x10", enums.ProductCategoryBakery, 3.00},
+			{"DR-009", "Papa amarilla kg", enums.ProductCategoryFruitAndVeg, 4.50},
+			{"DR-010", "Plátano de seda kg", enums.ProductCategoryFruitAndVeg, 3.80},
+			{"DR-011", "Cerveza Pilsen 630 ml x6", enums.ProductCategoryLiquor, 36.00},
+			{"DR-012", "Detergente Bolívar 2.6 kg", enums.ProductCategoryGeneralStore, 32.90},
+		},
+		accounts: []demoAccount{
+			{client: carmenFlores, payer: paysOnTime, creditType: enums.ShortTerm, creditLimit: 800, dueDay: 15},
+			{client: demoPerson{"Luis Ramírez Chávez", "70234567", "luis.ramirez@example.com", "923456789", "Av. Próceres de la Independencia 1830, San Juan de Lurigancho, Lima"}, payer: paysPartly, creditType: enums.ShortTerm, creditLimit: 600, dueDay: 10},
+			{client: demoPerson{"María Torres Vega", "70345678", "maria.torres@example.com", "934567890", "Jr. Los Jazmines 127, San Juan de Lurigancho, Lima"}, payer: paysLate, creditType: enums.ShortTerm, creditLimit: 500, dueDay: 20},
+			{client: demoPerson{"Pedro Castillo Huamán", "70456789", "pedro.castillo@example.com", "945678901", "Calle Las Retamas 310, San Juan de Lurigancho, Lima"}, payer: stopsPaying, creditType: enums.LongTerm, creditLimit: 1500, dueDay: 5},
+			{client: demoPerson{"Ana Sánchez Paredes", "70567890", "ana.sanchez@example.com", "956789012", "Av. Canto Grande 998, San Juan de Lurigancho, Lima"}, payer: paysOnTime, creditType: enums.LongTerm, creditLimit: 2000, dueDay: 28},
+		},
+		branches: []demoEstablishment{
+			{
+				name: "Bodega Doña Rosa - Zárate", phone: "014561234", address: "Jr. Las Gardenias 118, San Juan de Lurigancho, Lima",
+				lateFee: 2,
+				products: []demoProduct{
+					{"DZ-001", "Arroz Costeño 5 kg", enums.ProductCategoryGrocery, 24.90},
+					{"DZ-002", "Aceite Primor 1 L", enums.ProductCategoryGrocery, 11.50},
+					{"DZ-003", "Huevos x15", enums.ProductCategoryPoultry, 9.50},
+					{"DZ-004", "Pan francés x10", enums.ProductCategoryBakery, 3.00},
+					{"DZ-005", "Gaseosa Inca Kola 3 L", enums.ProductCategoryGeneralStore, 12.50},
+				},
+				accounts: []demoAccount{
+					{client: demoPerson{"Jorge Mendoza Salas", "70678901", "jorge.mendoza@example.com", "967890123", "Jr. Las Gardenias 240, San Juan de Lurigancho, Lima"}, payer: paysOnTime, creditType: enums.ShortTerm, creditLimit: 700, dueDay: 25},
+					{client: demoPerson{"Rocío Gutiérrez León", "70789012", "rocio.gutierrez@example.com", "978901234", "Av. Gran Chimú 655, San Juan de Lurigancho, Lima"}, payer: stopsPaying, creditType: enums.ShortTerm, creditLimit: 400, dueDay: 15},
+				},
+			},
+		},
+	},
+	{
+		name: "Minimarket El Sol", ruc: "20609876542", phone: "054223344", address: "Calle Los Olivos 310, Cayma, Arequipa",
+		admin:   demoPerson{"Jorge Huamán Torres", "41638290", "jorge.huaman@example.com", "976543210", "Calle Los Olivos 310, Cayma, Arequipa"},
+		lateFee: 3,
+		products: []demoProduct{
+			{"ES-001", "Quinua 1 kg", enums.ProductCategoryGrocery, 12.50},
+			{"ES-002", "Café Altomayo 180 g", enums.ProductCategoryGrocery, 18.90},
+			{"ES-003", "Queso fresco kg", enums.ProductCategoryGrocery, 24.00},
+			{"ES-004", "Carne molida kg", enums.ProductCategoryMeat, 22.00},
+			{"ES-005", "Lomo fino kg", enums.ProductCategoryMeat, 45.00},
+			{"ES-006", "Trucha kg", enums.ProductCategorySeafood, 19.50},
+			{"ES-007", "Manzana kg", enums.ProductCategoryFruitAndVeg, 6.50},
+			{"ES-008", "Pisco Quebranta 700 ml", enums.ProductCategoryLiquor, 48.00},
+			{"ES-009", "Papel higiénico x12", enums.ProductCategoryGeneralStore, 21.90},
+		},
+		accounts: []demoAccount{
+			{client: carmenFlores, payer: paysOnTime, creditType: enums.LongTerm, creditLimit: 1200, dueDay: 15},
+			{client: demoPerson{"Miguel Rojas Cárdenas", "70890123", "miguel.rojas@example.com", "989012345", "Av. Ejército 720, Cayma, Arequipa"}, payer: paysLate, creditType: enums.LongTerm, creditLimit: 1800, dueDay: 10},
+			{client: demoPerson{"Lucía Vargas Núñez", "70901234", "lucia.vargas@example.com", "990123456", "Calle Mercaderes 215, Arequipa"}, payer: paysPartly, creditType: enums.ShortTerm, creditLimit: 900, dueDay: 30},
+			{client: demoPerson{"Diego Álvarez Poma", "71012345", "diego.alvarez@example.com", "901234567", "Av. Cayma 402, Cayma, Arequipa"}, payer: paysOnTime, creditType: enums.ShortTerm, creditLimit: 1000, dueDay: 5, recent: true},
+		},
+	},
+}
+
+type demoDataService struct {
+	userRepo          repository.UserRepository
+	establishmentRepo repository.EstablishmentRepository
+	categoryRepo      repository.CategoryRepository
+	productRepo       repository.ProductRepository
+	creditAccountRepo repository.CreditAccountRepository
+	agreementRepo     repository.CreditAgreementRepository
+	installmentRepo   repository.InstallmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	holidayRepo       repository.HolidayRepository
+	clock             *util.FakeClock
+}
+
+// NewDemoDataService creates a new instance of DemoDataService. The history is recorded by moving
+// clock through it, so the repositories must use the same clock and nothing else may.
+func NewDemoDataService(userRepo repository.UserRepository, establishmentRepo repository.EstablishmentRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository, creditAccountRepo repository.CreditAccountRepository, agreementRepo repository.CreditAgreementRepository, installmentRepo repository.InstallmentRepository, settingsRepo repository.EstablishmentSettingsRepository, holidayRepo repository.HolidayRepository, clock *util.FakeClock) DemoDataService {
+	return &demoDataService{
+		userRepo:          userRepo,
+		establishmentRepo: establishmentRepo,
+		categoryRepo:      categoryRepo,
+		productRepo:       productRepo,
+		creditAccountRepo: creditAccountRepo,
+		agreementRepo:     agreementRepo,
+		installmentRepo:   installmentRepo,
+		settingsRepo:      settingsRepo,
+		holidayRepo:       holidayRepo,
+		clock:             clock,
+	}
+}
+
+// demoRun is the state of one SeedDemoData.
+type demoRun struct {
+	data      *DemoData
+	password  string                    // Hashed demoPassword
+	clients   map[string]*entities.User // By DNI
+	histories []*demoHistory
+	rng       *rand.Rand // Seeded, so every run creates the same data
+}
+
+// demoHistory is a demo credit account whose purchases and payments are being played.
+type demoHistory struct {
+	account  *entities.CreditAccount
+	payer    demoPayer
+	products []entities.Product
+	settings *entities.EstablishmentSettings
+	days     calendar.BusinessDays
+	opened   time.Time
+	due      time.Time // Due date of the cycle being played, before moving it to a business day
+	cycles   int       // Cycles played
+}
+
+// SeedDemoData creates the demo establishments with their products and clients, and plays the given
+// months of the clients' purchases and payments up to the clock's time. It fails with
+// ErrDemoDataSeeded if the database already has the demo users.
+func (s *demoDataService) SeedDemoData(months int) (*DemoData, error) {
+	if months < 1 {
+		return nil, errors.New("the demo data needs at least a month of history")
+	}
+	if _, err := s.userRepo.GetUserByEmail(demoEstablishments[0].admin.email); err == nil {
+		return nil, ErrDemoDataSeeded
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(demoPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+	run := &demoRun{
+		data:     &DemoData{Password: demoPassword},
+		password: string(hashedPassword),
+		clients:  make(map[string]*entities.User),
+		rng:      rand.New(rand.NewSource(1)),
+	}
+
+	now := s.clock.Now()
+	start := now.AddDate(0, -months, 0)
+	for _, demo := range demoEstablishments {
+		if err := s.seedEstablishment(run, demo, nil, start, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.playHistory(run, start, now); err != nil {
+		return nil, err
+	}
+	s.clock.Set(now)
+	return run.data, nil
+}
+
+// seedEstablishment creates a demo establishment, as a branch of main unless it's nil, with its
+// products, clients and branches.
+func (s *demoDataService) seedEstablishment(run *demoRun, demo demoEstablishment, main *entities.Establishment, start, now time.Time) error {
+	s.clock.Set(start)
+	establishment := &entities.Establishment{
+		RUC:               demo.ruc,
+		Name:              demo.name,
+		Phone:             demo.phone,
+		Address:           demo.address,
+		LateFeePercentage: demo.lateFee,
+		Timezone:          util.DefaultTimezone,
+		IsActive:          true,
+		Plan:              enums.PlanPremium, // So the demo shows every feature
+		CreatedAt:         start,
+		UpdatedAt:         start,
+	}
+	if main == nil {
+		admin := run.newUser(demo.admin, enums.ADMIN, start)
+		if err := s.establishmentRepo.CreateAdminAndEstablishment(admin, establishment); err != nil {
+			return fmt.Errorf("error creating establishment %s: %w", demo.name, err)
+		}
+		run.data.AdminEmails = append(run.data.AdminEmails, admin.Email)
+	} else {
+		establishment.RUC, establishment.AdminID, establishment.ParentID = main.RUC, main.AdminID, &main.ID
+		if err := s.establishmentRepo.CreateEstablishment(establishment); err != nil {
+			return fmt.Errorf("error creating branch %s: %w", demo.name, err)
+		}
+	}
+	run.data.Establishments++
+
+	// Long-term purchases are split in three, so whole installment plans fit in the history
+	settings := repository.DefaultEstablishmentSettings(establishment.ID)
+	settings.MaxInstallments = 3
+	if err := s.settingsRepo.SaveEstablishmentSettings(settings); err != nil {
+		return fmt.Errorf("error saving the settings of %s: %w", demo.name, err)
+	}
+	days, err := businessDays(s.holidayRepo, establishment.ID)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.categoryRepo.GetCategoriesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving categories: %w", err)
+	}
+	categoryIDs := make(map[string]uint, len(categories))
+	for _, category := range categories {
+		categoryIDs[category.Name] = category.ID
+	}
+	products := make([]entities.Product, 0, len(demo.products))
+	for _, demoProduct := range demo.products {
+		sku, categoryID := demoProduct.sku, categoryIDs[string(demoProduct.category)]
+		product := entities.Product{
+			EstablishmentID: establishment.ID,
+			Name:            demoProduct.name,
+			SKU:             &sku,
+			CategoryID:      &categoryID,
+			Description:     demoProduct.name,
+			Price:           demoProduct.price,
+			Stock:           200,
+			IsActive:        true,
+			CreatedAt:       start,
+			UpdatedAt:       start,
+		}
+		if err := s.productRepo.CreateProduct(&product); err != nil {
+			return fmt.Errorf("error creating product %s: %w", demoProduct.name, err)
+		}
+		products = append(products, product)
+	}
+	run.data.Products += len(products)
+
+	for _, demoAccount := range demo.accounts {
+		if err := s.openAccount(run, establishment, demoAccount, products, settings, days, start, now); err != nil {
+			return err
+		}
+	}
+	for _, branch := range demo.branches {
+		if err := s.seedEstablishment(run, branch, establishment, start, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openAccount opens the credit account of a demo client in an establishment, creating the client
+// unless they already have an account elsewhere, and accepts its credit agreement.
+func (s *demoDataService) openAccount(run *demoRun, establishment *entities.Establishment, demo demoAccount, products []entities.Product, settings *entities.EstablishmentSettings, days calendar.BusinessDays, start, now time.Time) error {
+	opened := start
+	if demo.recent {
+		opened = now.AddDate(0, -1, 0)
+	}
+	s.clock.Set(opened)
+
+	creditAccount := &entities.CreditAccount{
+		EstablishmentID:         establishment.ID,
+		CreditLimit:             demo.creditLimit,
+		MonthlyDueDate:          demo.dueDay,
+		InterestRate:            36,
+		InterestType:            enums.Effective,
+		CompoundingPeriod:       enums.Monthly,
+		CreditType:              demo.creditType,
+		LastInterestAccrualDate: opened,
+		LateFeePercentage:       establishment.LateFeePercentage,
+		CreatedAt:               opened,
+		UpdatedAt:               opened,
+	}
+	if demo.creditType == enums.LongTerm {
+		creditAccount.InterestRate, creditAccount.InterestType = 48, enums.Nominal
+	}
+
+	var err error
+	if client, ok := run.clients[demo.client.dni]; ok {
+		creditAccount.ClientID = client.ID
+		err = s.creditAccountRepo.CreateCreditAccount(creditAccount)
+	} else {
+		client = run.newUser(demo.client, enums.CLIENT, opened)
+		if err = s.creditAccountRepo.CreateClientAndCreditAccount(client, creditAccount); err == nil {
+			run.clients[client.DNI] = client
+			run.data.ClientEmails = append(run.data.ClientEmails, client.Email)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error creating the credit account of %s: %w", demo.client.name, err)
+	}
+	run.data.CreditAccounts++
+
+	// The agreement text and late fees need the client and establishment of the account
+	creditAccount, err = s.creditAccountRepo.GetCreditAccountByID(creditAccount.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	agreement, err := s.agreementRepo.GetCreditAgreementByCreditAccountID(creditAccount.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving credit agreement: %w", err)
+	}
+	text, err := agreementText(agreement, creditAccount)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256([]byte(text))
+	agreement.AcceptedAt, agreement.AcceptedIP, agreement.AcceptedUserAgent = &opened, "127.0.0.1", "demo data seed"
+	agreement.TermsHash, agreement.UpdatedAt = hex.EncodeToString(hash[:]), opened
+	if err := s.agreementRepo.AcceptCreditAgreement(agreement); err != nil {
+		return fmt.Errorf("error accepting credit agreement: %w", err)
+	}
+
+	run.histories = append(run.histories, &demoHistory{
+		account:  creditAccount,
+		payer:    demo.payer,
+		products: products,
+		settings: settings,
+		days:     days,
+		opened:   opened,
+		due:      util.NextDueDate(opened, demo.dueDay, accountLocation(creditAccount)),
+	})
+	return nil
+}
+
+// newUser creates the user of a demo person, with their contact details already verified.
+func (r *demoRun) newUser(person demoPerson, role enums.Role, now time.Time) *entities.User {
+	return &entities.User{
+		DNI:             person.dni,
+		Email:           person.email,
+		Password:        r.password,
+		Name:            person.name,
+		Address:         person.address,
+		Phone:           person.phone,
+		Rol:             role,
+		EmailVerifiedAt: &now,
+		PhoneVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// playHistory plays the purchases and payments of every demo account day by day from start to now.
+// Installments left unpaid past their due date are then marked overdue.
+func (s *demoDataService) playHistory(run *demoRun, start, now time.Time) error {
+	loc := util.LoadLocation(util.DefaultTimezone)
+	for day := startOfDay(start, loc); !day.After(now); day = day.AddDate(0, 0, 1) {
+		for _, history := range run.histories {
+			if err := s.playDay(run, history, day, now); err != nil {
+				return fmt.Errorf("error playing the history of credit account %d: %w", history.account.ID, err)
+			}
+		}
+	}
+
+	s.clock.Set(now)
+	if _, err := s.installmentRepo.MarkOverdueInstallments(now); err != nil {
+		return fmt.Errorf("error marking installments overdue: %w", err)
+	}
+	return nil
+}
+
+// playDay makes the purchases a demo client makes on day, and the payment or late fee of their
+// cycle if it's due that day.
+func (s *demoDataService) playDay(run *demoRun, history *demoHistory, day, now time.Time) error {
+	// About four purchases a month, during opening hours
+	if run.rng.Float64() < 0.14 {
+		at := day.Add(9*time.Hour + time.Duration(run.rng.Intn(12*60))*time.Minute)
+		if !at.Before(history.opened) && !at.After(now) {
+			if err := s.purchase(run, history, at); err != nil {
+				return err
+			}
+		}
+	}
+
+	at := day.Add(18 * time.Hour)
+	if at.After(now) {
+		return nil
+	}
+	daysPastDue := int(math.Round(day.Sub(history.days.Next(history.due)).Hours() / 24))
+	var err error
+	switch history.payer {
+	case paysOnTime:
+		if daysPastDue == -2 {
+			err = s.pay(run, history, at, false)
+		}
+	case paysPartly:
+		if daysPastDue == 0 {
+			err = s.pay(run, history, at, true)
+		}
+	case paysLate:
+		if daysPastDue == 5 {
+			err = s.chargeLateFee(history, at)
+		} else if daysPastDue == 10 {
+			err = s.pay(run, history, at, false)
+		}
+	case stopsPaying:
+		if history.cycles < 2 && daysPastDue == 0 {
+			err = s.pay(run, history, at, false)
+		} else if history.cycles >= 2 && daysPastDue == 5 {
+			err = s.chargeLateFee(history, at)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Every client is done with a cycle ten days after its due date
+	if daysPastDue >= 10 {
+		loc := accountLocation(history.account)
+		history.due = util.AddMonthsToDueDate(history.due, 1, history.account.MonthlyDueDate, loc)
+		history.cycles++
+	}
+	return nil
+}
+
+// purchase makes a credit purchase of a few random products of the establishment at the given time,
+// unless it exceeds the client's credit limit.
+func (s *demoDataService) purchase(run *demoRun, history *demoHistory, at time.Time) error {
+	lines := 1 + run.rng.Intn(3)
+	items := make([]entities.PurchaseItem, 0, lines)
+	amount := 0.0
+	for _, i := range run.rng.Perm(len(history.products))[:lines] {
+		product := history.products[i]
+		quantity := 1 + run.rng.Intn(3)
+		taxable, tax, total := taxBreakdown(product.Price*float64(quantity), history.settings.TaxRate, !history.settings.PricesExcludeTax)
+		items = append(items, entities.PurchaseItem{
+			EstablishmentID: product.EstablishmentID,
+			ProductID:       product.ID,
+			Quantity:        quantity,
+			UnitPrice:       product.Price,
+			Total:           total,
+			TaxRate:         history.settings.TaxRate,
+			TaxableAmount:   taxable,
+			TaxAmount:       tax,
+			IsCredit:        true,
+			SoldAt:          at,
+		})
+		amount += total
+	}
+	amount = roundCurrency(amount)
+
+	account := history.account
+	if account.CurrentBalance+amount-account.AccountCredit > account.CreditLimit {
+		return nil // Clients stop buying on credit once they reach their limit
+	}
+	s.clock.Set(at)
+	if account.CreditType == enums.LongTerm {
+		installments, err := planPurchaseInstallments(s.settingsRepo, account, amount, history.days, at)
+		if err != nil {
+			return err
+		}
+		if err := s.installmentRepo.CreateInstallments(installments); err != nil {
+			return fmt.Errorf("error creating installments: %w", err)
+		}
+		run.data.Installments += len(installments)
+	}
+	if err := s.creditAccountRepo.ProcessPurchaseTransaction(account, amount, "Product Purchase", items); err != nil {
+		return fmt.Errorf("error processing purchase: %w", err)
+	}
+	run.data.Purchases++
+	return nil
+}
+
+// pay records the payment of what's due in the cycle being played: the balance of a short-term
+// account, half of it when partly, or the installments due by then of a long-term one, only the
+// oldest when partly, which the payment pays off.
+func (s *demoDataService) pay(run *demoRun, history *demoHistory, at time.Time, partly bool) error {
+	account := history.account
+	amount := account.CurrentBalance
+	if partly {
+		amount /= 2
+	}
+
+	if account.CreditType == enums.LongTerm {
+		installments, err := s.installmentRepo.GetInstallmentsByCreditAccountID(account.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving installments: %w", err)
+		}
+		amount = 0
+		for _, installment := range installments {
+			if installment.Status == enums.Pending && !installment.DueDate.After(history.days.Next(history.due)) {
+				amount += installment.Amount
+				if partly {
+					break
+				}
+			}
+		}
+	}
+	amount = roundCurrency(math.Min(amount, account.CurrentBalance))
+	if amount <= 0 {
+		return nil
+	}
+
+	s.clock.Set(at)
+	if err := s.creditAccountRepo.ProcessPayment(account, amount, enums.CASH, "Payment"); err != nil {
+		return fmt.Errorf("error processing payment: %w", err)
+	}
+	run.data.Payments++
+	return nil
+}
+
+// chargeLateFee charges the establishment's late fee on what the client owes past its due date.
+func (s *demoDataService) chargeLateFee(history *demoHistory, at time.Time) error {
+	if history.account.CurrentBalance <= 0 {
+		return nil
+	}
+	var installments []entities.Installment
+	if history.account.CreditType == enums.LongTerm {
+		var err error
+		if installments, err = s.installmentRepo.GetInstallmentsByCreditAccountID(history.account.ID); err != nil {
+			return fmt.Errorf("error retrieving installments: %w", err)
+		}
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(history.account.EstablishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	lateFee := lateFeeFor(settings, history.account, installments, history.days, at)
+	if lateFee == nil {
+		return nil
+	}
+	s.clock.Set(at)
+	if err := s.creditAccountRepo.ApplyLateFee(history.account, lateFee); err != nil && !errors.Is(err, ErrLateFeeApplied) {
+		return fmt.Errorf("error applying late fee: %w", err)
+	}
+	return nil
+}
+
+// startOfDay returns midnight of the day t falls on in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
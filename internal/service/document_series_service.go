@@ -0,0 +1,141 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// documentSeriesCodes are the series codes SUNAT accepts for each document type: its letter and three
+// letters or digits.
+var documentSeriesCodes = map[enums.DocumentType]*regexp.Regexp{
+	enums.DocumentReceipt: regexp.MustCompile(`^B[A-Z0-9]{3}$`),
+	enums.DocumentInvoice: regexp.MustCompile(`^F[A-Z0-9]{3}$`),
+}
+
+// DocumentSeriesService handles the series establishments number their fiscal documents from.
+type DocumentSeriesService interface {
+	GetDocumentSeries(adminID, branchID uint) ([]response.DocumentSeriesResponse, error)
+	CreateDocumentSeries(adminID, branchID uint, req request.CreateDocumentSeriesRequest) (*response.DocumentSeriesResponse, error)
+	UpdateDocumentSeries(adminID, branchID, seriesID uint, req request.UpdateDocumentSeriesRequest) (*response.DocumentSeriesResponse, error)
+}
+
+type documentSeriesService struct {
+	seriesRepo        repository.DocumentSeriesRepository
+	establishmentRepo repository.EstablishmentRepository
+}
+
+// NewDocumentSeriesService creates a new instance of DocumentSeriesService.
+func NewDocumentSeriesService(seriesRepo repository.DocumentSeriesRepository, establishmentRepo repository.EstablishmentRepository) DocumentSeriesService {
+	return &documentSeriesService{seriesRepo: seriesRepo, establishmentRepo: establishmentRepo}
+}
+
+// GetDocumentSeries retrieves the series of the admin's establishment.
+func (s *documentSeriesService) GetDocumentSeries(adminID, branchID uint) ([]response.DocumentSeriesResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.seriesRepo.GetDocumentSeriesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving document series: %w", err)
+	}
+
+	seriesResponses := make([]response.DocumentSeriesResponse, len(series))
+	for i := range series {
+		seriesResponses[i] = *documentSeriesToResponse(&series[i])
+	}
+	return seriesResponses, nil
+}
+
+// CreateDocumentSeries adds a series to the admin's establishment.
+func (s *documentSeriesService) CreateDocumentSeries(adminID, branchID uint, req request.CreateDocumentSeriesRequest) (*response.DocumentSeriesResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	code := strings.ToUpper(req.Code)
+	if !documentSeriesCodes[req.DocumentType].MatchString(code) {
+		return nil, ErrInvalidDocumentSeries
+	}
+
+	existing, err := s.seriesRepo.GetDocumentSeriesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving document series: %w", err)
+	}
+	for _, series := range existing {
+		if series.Code == code {
+			return nil, ErrDocumentSeriesExists
+		}
+	}
+
+	series := entities.DocumentSeries{
+		EstablishmentID: establishment.ID,
+		DocumentType:    req.DocumentType,
+		Code:            code,
+		Active:          req.Active,
+	}
+	if req.NextNumber != nil {
+		series.LastNumber = *req.NextNumber - 1
+	}
+	if err := s.seriesRepo.CreateDocumentSeries(&series); err != nil {
+		return nil, fmt.Errorf("error creating document series: %w", err)
+	}
+	return documentSeriesToResponse(&series), nil
+}
+
+// UpdateDocumentSeries activates or deactivates a series of the admin's establishment, or moves its
+// numbering forward. Activating a series deactivates the active one of its type.
+func (s *documentSeriesService) UpdateDocumentSeries(adminID, branchID, seriesID uint, req request.UpdateDocumentSeriesRequest) (*response.DocumentSeriesResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.seriesRepo.GetDocumentSeriesByID(seriesID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && series.EstablishmentID != establishment.ID) {
+		return nil, ErrDocumentSeriesNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving document series: %w", err)
+	}
+
+	if req.Active != nil {
+		series.Active = *req.Active
+	}
+	var lastNumber *int64
+	if req.NextNumber != nil {
+		number := *req.NextNumber - 1
+		lastNumber = &number
+	}
+	updated, err := s.seriesRepo.UpdateDocumentSeries(series, lastNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error updating document series: %w", err)
+	}
+	if !updated {
+		return nil, ErrDocumentNumberIssued
+	}
+	return documentSeriesToResponse(series), nil
+}
+
+func documentSeriesToResponse(series *entities.DocumentSeries) *response.DocumentSeriesResponse {
+	return &response.DocumentSeriesResponse{
+		ID:                 series.ID,
+		EstablishmentID:    series.EstablishmentID,
+		DocumentType:       series.DocumentType,
+		Code:               series.Code,
+		LastNumber:         series.LastNumber,
+		NextDocumentNumber: util.FormatDocumentNumber(series.Code, series.LastNumber+1),
+		Active:             series.Active,
+		CreatedAt:          series.CreatedAt,
+		UpdatedAt:          series.UpdatedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// documentTypes are the documents that may be uploaded, by the extension they are stored with and
+// the content type their bytes must be detected as.
+var documentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+// VirusScanner decides whether an uploaded document may be stored, e.g. by running it through an
+// antivirus. It returns ErrAttachmentInfected to refuse a document.
+type VirusScanner interface {
+	Scan(data []byte, fileName string) error
+}
+
+type noopVirusScanner struct{}
+
+// NewNoopVirusScanner creates a VirusScanner that accepts every document.
+func NewNoopVirusScanner() VirusScanner {
+	return noopVirusScanner{}
+}
+
+func (noopVirusScanner) Scan([]byte, string) error {
+	return nil
+}
+
+type httpVirusScanner struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPVirusScanner creates a VirusScanner that posts the document to an external scanning
+// endpoint. The endpoint must answer with JSON of the form {"infected": bool}.
+func NewHTTPVirusScanner(endpoint string) VirusScanner {
+	return &httpVirusScanner{endpoint: endpoint, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (s *httpVirusScanner) Scan(data []byte, fileName string) error {
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error creating virus scan request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-File-Name", fileName)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling virus scanner: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("virus scanner returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Infected bool `json:"infected"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("error decoding virus scan response: %w", err)
+	}
+	if result.Infected {
+		return ErrAttachmentInfected
+	}
+	return nil
+}
+
+// DocumentUploadSettings are the limit uploaded documents are checked against and the directory
+// they're stored under, the same one images are.
+type DocumentUploadSettings struct {
+	MaxSize     int64 // In bytes
+	StorageRoot string
+}
+
+// DocumentUploader validates uploaded documents, scans them and stores them as they are.
+type DocumentUploader struct {
+	scanner  VirusScanner
+	settings func() DocumentUploadSettings
+}
+
+// NewDocumentUploader creates a DocumentUploader that scans documents with scanner. The settings are
+// read for every upload, so they can change while the API runs.
+func NewDocumentUploader(scanner VirusScanner, settings func() DocumentUploadSettings) *DocumentUploader {
+	if scanner == nil {
+		scanner = NewNoopVirusScanner()
+	}
+	return &DocumentUploader{scanner: scanner, settings: settings}
+}
+
+// storedDocument is a document written to storage.
+type storedDocument struct {
+	Path        string
+	ContentType string
+	Size        int64
+	SHA256      string
+}
+
+// save validates and scans file, then writes it to dir, under the storage root, as baseName with the
+// extension of its type.
+func (u *DocumentUploader) save(file *multipart.FileHeader, dir, baseName string) (*storedDocument, error) {
+	settings := u.settings()
+
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	contentType, ok := documentTypes[ext]
+	if !ok {
+		return nil, ErrInvalidAttachmentType
+	}
+	if file.Size > settings.MaxSize {
+		return nil, ErrFileSizeTooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening uploaded file: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(src, settings.MaxSize+1))
+	src.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading uploaded file: %w", err)
+	}
+	if int64(len(data)) > settings.MaxSize {
+		return nil, ErrFileSizeTooLarge
+	}
+	// The bytes must match the extension, so a renamed executable isn't stored as a PDF
+	if !strings.HasPrefix(http.DetectContentType(data), contentType) {
+		return nil, ErrInvalidAttachmentType
+	}
+
+	if err := u.scanner.Scan(data, file.Filename); err != nil {
+		return nil, err
+	}
+
+	dir = filepath.Join(settings.StorageRoot, dir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, baseName+ext)
+	if err := os.WriteFile(path, data, 0640); err != nil {
+		return nil, fmt.Errorf("error writing document: %w", err)
+	}
+	digest := sha256.Sum256(data)
+	return &storedDocument{Path: path, ContentType: contentType, Size: int64(len(data)), SHA256: hex.EncodeToString(digest[:])}, nil
+}
+
+// read returns the contents of a stored document.
+func (u *DocumentUploader) read(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading document: %w", err)
+	}
+	return data, nil
+}
+
+// remove deletes a stored document. Documents already gone are not an error.
+func (u *DocumentUploader) remove(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting document: %w", err)
+	}
+	return nil
+}
@@ -1,12 +1,167 @@
 package service
 
-import "errors"
+import (
+	"ApiRestFinance/internal/plan"
+	"ApiRestFinance/internal/repository"
+	"errors"
+)
 
 // Define custom errors
 var (
-	ErrCreditAccountNotFound  = errors.New("credit account not found")
-	ErrInvalidTransactionType = errors.New("invalid transaction type")
-	ErrInsufficientBalance    = errors.New("insufficient balance")
-	ErrInvalidFileType        = errors.New("invalid file type. Only images are allowed")
-	ErrFileSizeTooLarge       = errors.New("file size too large")
+	ErrCreditAccountNotFound       = errors.New("credit account not found")
+	ErrCreditAccountExists         = repository.ErrCreditAccountExists
+	ErrEstablishmentRequired       = errors.New("client has credit accounts in several establishments, an establishment must be selected")
+	ErrInvalidTransactionType      = errors.New("invalid transaction type")
+	ErrInsufficientBalance         = errors.New("insufficient balance")
+	ErrInvalidFileType             = errors.New("invalid file type. Only images are allowed")
+	ErrFileSizeTooLarge            = errors.New("file size too large")
+	ErrQueryLimitExceeded          = errors.New("query limit exceeded")
+	ErrInvalidImage                = errors.New("file is not a valid image")
+	ErrInvalidImageDimensions      = errors.New("invalid image dimensions")
+	ErrImageRejected               = errors.New("image rejected by moderation")
+	ErrInvalidSimulation           = errors.New("invalid credit simulation")
+	ErrInvalidReportPeriod         = errors.New("invalid report period")
+	ErrNothingToPayOff             = errors.New("credit account has no outstanding balance")
+	ErrPayoffQuoteChanged          = errors.New("payoff amount changed, request a new quote")
+	ErrBranchNotFound              = errors.New("branch not found")
+	ErrEstablishmentNotFound       = errors.New("establishment not found")
+	ErrInterestRateRequired        = errors.New("interest rate is required, the establishment has no default rate")
+	ErrHighRiskPurchaseLimit       = errors.New("purchase exceeds the limit for high-risk clients")
+	ErrCreditAccountAlreadyBlocked = errors.New("credit account is already blocked")
+	ErrCreditAccountNotBlocked     = errors.New("credit account is not blocked")
+	ErrJobNotFound                 = errors.New("job not found")
+	ErrJobNotFinished              = errors.New("job has not finished yet")
+	ErrJobFailed                   = errors.New("job failed")
+	ErrJobHasNoResult              = errors.New("job has no result to download")
+	ErrTwoFactorAlreadyEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotSetUp           = errors.New("two-factor authentication has not been set up")
+	ErrTwoFactorNotEnabled         = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequired           = errors.New("the establishment requires two-factor authentication")
+	ErrInvalidTwoFactorCode        = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorChallenge   = errors.New("login challenge invalid or expired, login again")
+	ErrTwoFactorLocked             = errors.New("two-factor authentication locked after too many wrong codes, try again later")
+	ErrSessionNotFound             = errors.New("session not found")
+	ErrDocumentSeriesNotFound      = errors.New("document series not found")
+	ErrDocumentSeriesExists        = repository.ErrDocumentSeriesExists
+	ErrInvalidDocumentSeries       = errors.New("invalid series code, receipt series start with B and invoice series with F, followed by three letters or digits")
+	ErrDocumentNumberIssued        = errors.New("the series already issued that number, it can only move forward")
+	ErrInvoiceNotFound             = errors.New("electronic invoice not found")
+	ErrCategoryNotFound            = errors.New("category not found")
+	ErrCategoryExists              = repository.ErrCategoryExists
+	ErrCategoryInUse               = errors.New("category still has products, move them to another category first")
+	ErrInvalidCategoryName         = errors.New("category name can't be blank")
+	ErrProductNotFound             = errors.New("product not found")
+	ErrInvalidBarcode              = errors.New("invalid barcode, it must be an EAN-13 code of 13 digits with a valid check digit")
+	ErrSKUExists                   = repository.ErrSKUExists
+	ErrBarcodeExists               = repository.ErrBarcodeExists
+	ErrCreditLimitExceeded         = errors.New("purchase amount exceeds credit limit")
+	ErrSpendingLimitExceeded       = errors.New("purchase exceeds the spending limit of the period")
+	ErrPurchaseApprovalNotFound    = errors.New("purchase approval not found")
+	ErrPurchaseApprovalDecided     = repository.ErrApprovalDecided
+	ErrPaymentPromiseExists        = errors.New("credit account already has an active payment promise")
+	ErrInvalidPromiseDate          = errors.New("invalid promise date, it must be an ISO-8601 date from today on")
+	ErrLateFeesSuspended           = errors.New("late fees are suspended by an active payment promise")
+	ErrCreditAccountWrittenOff     = repository.ErrAccountWrittenOff
+	ErrAttachmentNotFound          = errors.New("attachment not found")
+	ErrInvalidAttachmentType       = errors.New("invalid file type. Only PDF, JPEG and PNG documents are allowed")
+	ErrInvalidAttachmentKind       = errors.New("invalid attachment kind")
+	ErrAttachmentInfected          = errors.New("document rejected by the virus scan")
+	ErrCreditAgreementNotFound     = errors.New("credit agreement not found")
+	ErrCreditAgreementAccepted     = repository.ErrAgreementAccepted
+	ErrEmailInUse                  = repository.ErrEmailInUse
+	ErrDNIInUse                    = repository.ErrDNIInUse
+	ErrRUCInUse                    = repository.ErrRUCInUse
+	ErrInviteCodeNotFound          = errors.New("invite code not found")
+	ErrClientSignupNotFound        = errors.New("client signup not found")
+	ErrClientSignupPending         = errors.New("a signup with that DNI is already waiting for approval")
+	ErrClientSignupDecided         = repository.ErrSignupDecided
+	ErrVersionRequired             = errors.New("send the version of the record the change is based on, in the body or in If-Match")
+	ErrVersionConflict             = repository.ErrVersionConflict
+	ErrInvalidContactChannel       = errors.New("invalid channel, use email or phone")
+	ErrContactAlreadyVerified      = errors.New("that contact is already verified")
+	ErrNoPhone                     = errors.New("the user has no phone to verify")
+	ErrVerificationCodeTooSoon     = errors.New("a verification code was sent less than a minute ago, wait before asking for another")
+	ErrInvalidVerificationCode     = errors.New("invalid verification code")
+	ErrVerificationCodeExpired     = errors.New("verification code expired or was entered wrong too many times, ask for a new one")
+	ErrInstallmentNotFound         = errors.New("installment not found")
+	ErrInstallmentPaid             = repository.ErrInstallmentPaid
+	ErrInstallmentCancelled        = repository.ErrInstallmentCancelled
+	ErrInvalidPaymentLinkAmount    = errors.New("send the installment to pay or an amount no larger than what the account owes")
+	ErrNoVerifiedContact           = errors.New("the client has no verified email or phone to send the link to")
+	ErrPaymentLinkNotFound         = errors.New("payment link not found")
+	ErrPaymentLinkExpired          = errors.New("payment link expired, ask the establishment for a new one")
+	ErrPaymentLinkUsed             = repository.ErrPaymentLinkUsed
+	ErrEmailNotVerified            = errors.New("verify your email to receive the report digest")
+	ErrUserNotFound                = errors.New("user not found")
+	ErrUserNotClient               = errors.New("only the data of clients can be exported or anonymized")
+	ErrClientHasOtherAccounts      = errors.New("the client has credit accounts in other establishments, only they can erase their data")
+	ErrClientOwesBalance           = errors.New("the client still owes on a credit account, settle it before erasing their data")
+	ErrUserAnonymized              = repository.ErrUserAnonymized
+	ErrDemoDataSeeded              = errors.New("the database already has the demo data")
+	ErrCreditAccountClosed         = repository.ErrAccountClosed
+	ErrCreditAccountNotClosed      = repository.ErrAccountNotClosed
+	ErrCreditAccountAlreadyClosed  = errors.New("credit account is already closed")
+	ErrCreditAccountHasBalance     = errors.New("credit account still owes, pay it off to close it")
+	ErrEstablishmentInactive       = repository.ErrEstablishmentInactive
+	ErrEstablishmentActive         = errors.New("establishment is already active")
+	ErrEstablishmentDeactivated    = errors.New("establishment is already deactivated")
+	ErrIncorrectPassword           = errors.New("current password incorrect")
+	ErrInvalidPurchasePinFormat    = errors.New("purchase PIN must be 4 to 6 digits")
+	ErrPurchasePinRequired         = errors.New("purchases of this amount need the client's purchase PIN")
+	ErrPurchasePinNotSet           = errors.New("the client has not set a purchase PIN, they must set one before purchases of this amount")
+	ErrInvalidPurchasePin          = errors.New("invalid purchase PIN")
+	ErrPurchasePinLocked           = errors.New("purchase PIN locked after too many wrong attempts, try again later or have the client reset it")
+	ErrInvalidRescheduleDate       = errors.New("invalid due date, installments are moved to a day from tomorrow up to a month after the account's last installment")
+	ErrRescheduleLimitReached      = repository.ErrRescheduleLimitReached
+	ErrLateFeeApplied              = repository.ErrLateFeeApplied
+	ErrBalanceChanged              = repository.ErrBalanceChanged
+	ErrAccountAdjustmentNotFound   = errors.New("adjustment not found")
+	ErrAdjustmentApproverRequired  = errors.New("adjustments above the threshold need an approver, designate one in the establishment settings")
+	ErrInvalidAdjustmentApprover   = errors.New("the adjustment approver must be an admin other than the establishment's")
+	ErrAdjustmentDecided           = repository.ErrAdjustmentDecided
+	ErrAdjustmentTransaction       = errors.New("adjustments can't be updated or deleted, make an opposite adjustment instead")
+	ErrTillSessionNotFound         = errors.New("till session not found")
+	ErrTillSessionOpen             = repository.ErrTillSessionOpen
+	ErrTillSessionClosed           = repository.ErrTillSessionClosed
+	ErrDiscrepancyNotesRequired    = repository.ErrDiscrepancyNotesRequired
+	ErrInvalidContactDate          = errors.New("invalid contact date, contacts can't be logged ahead of time")
+	ErrClientNotFound              = errors.New("client not found")
+	ErrClientTagNotFound           = errors.New("client tag not found")
+	ErrClientTagExists             = repository.ErrClientTagExists
+	ErrInvalidClientTagName        = errors.New("client tag name can't be blank")
+	ErrClientNoteNotFound          = errors.New("client note not found")
+	ErrInvalidClientNote           = errors.New("client note can't be blank")
+	ErrInvalidConfigImport         = errors.New("the configuration has items that can't be imported, dry run the import to see them")
+	ErrCreditTemplateNotFound      = errors.New("credit template not found")
+	ErrCreditTemplateExists        = repository.ErrCreditTemplateExists
+	ErrInvalidCreditTemplateName   = errors.New("credit template name can't be blank")
+	ErrConfigImportConflict        = errors.New("the configuration has products that differ from those of the establishment, dry run the import to see them or import with another on_conflict mode")
+	ErrNoCreditTermChanges         = errors.New("the update sets no credit terms")
+	ErrCreditTermUpdateNotFound    = errors.New("credit term update not found")
+	ErrUpgradeRequired             = plan.ErrUpgradeRequired
+	ErrPlanOfBranch                = errors.New("branches are on the plan of their main establishment")
+	ErrEstablishmentSuspended      = errors.New("establishment is suspended by the platform, contact support")
+	ErrAlreadySuspended            = errors.New("establishment is already suspended")
+	ErrNotSuspended                = errors.New("establishment is not suspended")
+	ErrPlatformBranch              = errors.New("branches are managed with their main establishment")
+	ErrAccessDenied                = errors.New("not authorized to access this record")
+	ErrInvalidBankSignature        = errors.New("invalid bank notification signature")
+	ErrStaleBankNotification       = errors.New("bank notification timestamp is outside the allowed window")
+	ErrAccountingEventsUnmapped    = errors.New("map every accounting event to an account before exporting the journal")
+	ErrNotPayment                  = errors.New("transaction is not a payment")
+	ErrNotificationNotFound        = errors.New("notification not found")
+	ErrInvalidInstallmentStatus    = errors.New("an installment's status follows its payments and due date, it can only be changed by hand to CANCELLED while it is owed")
+	ErrHolidayNotFound             = errors.New("holiday not found")
+	ErrHolidayExists               = repository.ErrHolidayExists
+	ErrInvalidHolidayDate          = errors.New("holiday dates must be YYYY-MM-DD")
+	ErrInvalidHolidayYear          = errors.New("year must be between 2000 and 2100")
+	// ErrAgreementNotAccepted is also returned by the repository, which checks it again with the purchase
+	ErrAgreementNotAccepted = repository.ErrAgreementNotAccepted
+	// ErrCreditAccountBlocked is also returned by the repository when the account gets blocked mid-purchase
+	ErrCreditAccountBlocked = repository.ErrCreditAccountBlocked
+	// Errors of confirming pending payments, checked by the repository as it locks the payment
+	ErrPaymentNotPending   = repository.ErrPaymentNotPending
+	ErrInvalidPaymentCode  = repository.ErrInvalidPaymentCode
+	ErrPaymentCodeExpired  = repository.ErrPaymentCodeExpired
+	ErrPaymentCodeAttempts = repository.ErrPaymentCodeAttempts
 )
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EstablishmentConfigService exports the configuration of an establishment, its settings, product
+// categories and products, and imports it into another, so chains can set up new stores like the
+// ones they have.
+type EstablishmentConfigService interface {
+	ExportConfig(adminID, branchID uint) (*response.EstablishmentConfigResponse, error)
+	ImportConfig(adminID, branchID uint, req request.ImportEstablishmentConfigRequest) (*response.EstablishmentImportReport, error)
+}
+
+type establishmentConfigService struct {
+	configRepo        repository.EstablishmentConfigRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	categoryRepo      repository.CategoryRepository
+	productRepo       repository.ProductRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+}
+
+// NewEstablishmentConfigService creates a new instance of EstablishmentConfigService.
+func NewEstablishmentConfigService(configRepo repository.EstablishmentConfigRepository, settingsRepo repository.EstablishmentSettingsRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock) EstablishmentConfigService {
+	return &establishmentConfigService{
+		configRepo:        configRepo,
+		settingsRepo:      settingsRepo,
+		categoryRepo:      categoryRepo,
+		productRepo:       productRepo,
+		establishmentRepo: establishmentRepo,
+		clock:             clock,
+	}
+}
+
+// ExportConfig exports the settings, categories and products of the admin's establishment, or the
+// selected branch, by name. Products are exported without their stock.
+func (s *establishmentConfigService) ExportConfig(adminID, branchID uint) (*response.EstablishmentConfigResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	categories, err := s.categoryRepo.GetCategoriesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving categories: %w", err)
+	}
+	products, err := s.productRepo.GetAllProductsByEstablishmentID(establishment.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving products: %w", err)
+	}
+	sort.SliceStable(products, func(i, j int) bool {
+		if products[i].Name != products[j].Name {
+			return products[i].Name < products[j].Name
+		}
+		return products[i].ID < products[j].ID
+	})
+
+	config := &response.EstablishmentConfigResponse{
+		EstablishmentID: establishment.ID,
+		ExportedAt:      s.clock.Now(),
+		Settings:        *establishmentSettingsToResponse(settings),
+		Categories:      make([]response.ConfigCategoryResponse, 0, len(categories)),
+		Products:        make([]response.ConfigProductResponse, 0, len(products)),
+	}
+	for _, category := range categories {
+		config.Categories = append(config.Categories, response.ConfigCategoryResponse{Name: category.Name})
+	}
+	for i := range products {
+		product := response.ConfigProductResponse{
+			Name:        products[i].Name,
+			Description: products[i].Description,
+			Price:       products[i].Price,
+			ImageUrl:    products[i].ImageUrl,
+			IsActive:    products[i].IsActive,
+		}
+		if products[i].SKU != nil {
+			product.SKU = *products[i].SKU
+		}
+		if products[i].Barcode != nil {
+			product.Barcode = *products[i].Barcode
+		}
+		if products[i].Category != nil {
+			product.Category = products[i].Category.Name
+		}
+		config.Products = append(config.Products, product)
+	}
+	return config, nil
+}
+
+// ImportConfig imports a configuration into the admin's establishment, or the selected branch:
+// its settings replace those the configuration sets, its categories are created unless the
+// establishment has them, and its products are matched with those of the establishment by SKU,
+// barcode or name. New products are created without stock. Imported products that differ from the
+// existing ones they match are resolved by req.OnConflict. It imports all or nothing: any invalid
+// item, or any conflict in the FAIL mode, fails the import. Dry runs only report what the import
+// would do.
+func (s *establishmentConfigService) ImportConfig(adminID, branchID uint, req request.ImportEstablishmentConfigRequest) (*response.EstablishmentImportReport, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	mode := enums.ImportConflictMode(req.OnConflict)
+	if mode == "" {
+		mode = enums.ImportSkip
+	}
+
+	report := &response.EstablishmentImportReport{
+		DryRun:     req.DryRun,
+		OnConflict: mode,
+		Categories: []response.ImportItemResult{},
+		Products:   []response.ImportItemResult{},
+	}
+	establishmentImport := repository.EstablishmentImport{EstablishmentID: establishment.ID}
+	if req.Config.Settings != nil {
+		settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+		}
+		original := *settings
+		applySettings(settings, *req.Config.Settings)
+
+		action := enums.ImportUnchanged
+		if *settings != original {
+			action = enums.ImportUpdate
+			establishmentImport.Settings = settings
+		}
+		report.Settings = &action
+	}
+
+	categoryNames, err := s.planCategories(establishment.ID, req.Config.Categories, report, &establishmentImport)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.planProducts(establishment.ID, req.Config.Products, categoryNames, mode, report, &establishmentImport); err != nil {
+		return nil, err
+	}
+
+	if report.Summary.Invalid > 0 {
+		return failedImport(report, ErrInvalidConfigImport)
+	}
+	if report.Summary.Conflicts > 0 {
+		return failedImport(report, ErrConfigImportConflict)
+	}
+	if req.DryRun {
+		return report, nil
+	}
+	if establishmentImport.Settings != nil || len(establishmentImport.Categories) > 0 || len(establishmentImport.Products) > 0 {
+		if err := s.configRepo.ImportEstablishmentConfig(&establishmentImport); err != nil {
+			if errors.Is(err, ErrVersionConflict) || errors.Is(err, ErrCategoryExists) || errors.Is(err, ErrSKUExists) || errors.Is(err, ErrBarcodeExists) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("error importing establishment configuration: %w", err)
+		}
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// failedImport returns the report of an import that can't be applied, or err unless it is a dry run.
+func failedImport(report *response.EstablishmentImportReport, err error) (*response.EstablishmentImportReport, error) {
+	if report.DryRun {
+		return report, nil
+	}
+	return nil, err
+}
+
+// planCategories reports on the categories of an import, adding those the establishment doesn't have
+// to it. It returns the names, lowercased, of the categories the establishment will have.
+func (s *establishmentConfigService) planCategories(establishmentID uint, categories []request.ConfigCategoryRequest, report *response.EstablishmentImportReport, establishmentImport *repository.EstablishmentImport) (map[string]bool, error) {
+	existing, err := s.categoryRepo.GetCategoriesByEstablishmentID(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving categories: %w", err)
+	}
+	categoryIDs := make(map[string]uint, len(existing))
+	for _, category := range existing {
+		categoryIDs[strings.ToLower(category.Name)] = category.ID
+	}
+
+	names := make(map[string]bool, len(existing)+len(categories))
+	for key := range categoryIDs {
+		names[key] = true
+	}
+	imported := make(map[string]bool, len(categories))
+	for i, category := range categories {
+		name := strings.TrimSpace(category.Name)
+		key := strings.ToLower(name)
+		item := response.ImportItemResult{Index: i, Name: name}
+		if id, ok := categoryIDs[key]; ok {
+			item.ExistingID = &id
+		}
+		switch {
+		case name == "":
+			item.Action, item.Reason = enums.ImportInvalid, ErrInvalidCategoryName.Error()
+		case imported[key]:
+			item.Action, item.Reason = enums.ImportInvalid, "the configuration has the category more than once"
+		case item.ExistingID != nil:
+			item.Action = enums.ImportUnchanged
+		default:
+			item.Action = enums.ImportCreate
+			establishmentImport.Categories = append(establishmentImport.Categories, entities.Category{EstablishmentID: establishmentID, Name: name})
+			names[key] = true
+		}
+		if name != "" {
+			imported[key] = true
+		}
+		report.Categories = append(report.Categories, item)
+		countImportAction(&report.Summary, item.Action)
+	}
+	return names, nil
+}
+
+// planProducts reports on the products of an import, adding those to create, and those to update in
+// the OVERWRITE mode, to it.
+func (s *establishmentConfigService) planProducts(establishmentID uint, products []request.ConfigProductRequest, categoryNames map[string]bool, mode enums.ImportConflictMode, report *response.EstablishmentImportReport, establishmentImport *repository.EstablishmentImport) error {
+	existing, err := s.productRepo.GetAllProductsByEstablishmentID(establishmentID, 0)
+	if err != nil {
+		return fmt.Errorf("error retrieving products: %w", err)
+	}
+	sort.SliceStable(existing, func(i, j int) bool { return existing[i].ID < existing[j].ID })
+	bySKU := make(map[string]*entities.Product)
+	byBarcode := make(map[string]*entities.Product)
+	byName := make(map[string]*entities.Product)
+	for i := range existing {
+		if existing[i].SKU != nil {
+			bySKU[*existing[i].SKU] = &existing[i]
+		}
+		if existing[i].Barcode != nil {
+			byBarcode[*existing[i].Barcode] = &existing[i]
+		}
+		if key := strings.ToLower(existing[i].Name); byName[key] == nil {
+			byName[key] = &existing[i]
+		}
+	}
+
+	importedSKUs := make(map[string]bool, len(products))
+	importedBarcodes := make(map[string]bool, len(products))
+	for i, imported := range products {
+		name, category := strings.TrimSpace(imported.Name), strings.TrimSpace(imported.Category)
+		sku, barcode := optionalCode(imported.SKU), optionalCode(imported.Barcode)
+		item := response.ImportItemResult{Index: i, Name: name, SKU: strings.TrimSpace(imported.SKU)}
+
+		// Products are matched by SKU, or by barcode without one, or by name without either
+		var match *entities.Product
+		switch {
+		case sku != nil:
+			match = bySKU[*sku]
+		case barcode != nil:
+			match = byBarcode[*barcode]
+		default:
+			match = byName[strings.ToLower(name)]
+		}
+		if match != nil {
+			item.ExistingID = &match.ID
+		}
+
+		switch {
+		case name == "":
+			item.Reason = "the product name can't be blank"
+		case barcode != nil && !validEAN13(*barcode):
+			item.Reason = ErrInvalidBarcode.Error()
+		case sku != nil && importedSKUs[*sku]:
+			item.Reason = "another product of the configuration has the same SKU"
+		case barcode != nil && importedBarcodes[*barcode]:
+			item.Reason = "another product of the configuration has the same barcode"
+		case barcode != nil && byBarcode[*barcode] != nil && byBarcode[*barcode] != match:
+			item.Reason = ErrBarcodeExists.Error()
+		case !categoryNames[strings.ToLower(category)]:
+			item.Reason = fmt.Sprintf("category %q is neither in the configuration nor in the establishment", category)
+		}
+		if sku != nil {
+			importedSKUs[*sku] = true
+		}
+		if barcode != nil {
+			importedBarcodes[*barcode] = true
+		}
+
+		product := entities.Product{
+			EstablishmentID: establishmentID,
+			Name:            name,
+			SKU:             sku,
+			Barcode:         barcode,
+			Description:     imported.Description,
+			Price:           imported.Price,
+			ImageUrl:        strings.TrimSpace(imported.ImageUrl),
+			IsActive:        imported.IsActive,
+		}
+		switch {
+		case item.Reason != "":
+			item.Action = enums.ImportInvalid
+		case match == nil:
+			item.Action = enums.ImportCreate
+			establishmentImport.Products = append(establishmentImport.Products, repository.ImportedProduct{Product: product, CategoryName: category})
+		case sameImportedProduct(match, &product, category):
+			item.Action = enums.ImportUnchanged
+		case mode == enums.ImportOverwrite:
+			item.Action = enums.ImportUpdate
+			updated := *match
+			updated.Name, updated.SKU, updated.Barcode = product.Name, product.SKU, product.Barcode
+			updated.Description, updated.Price, updated.IsActive = product.Description, product.Price, product.IsActive
+			if product.ImageUrl != "" {
+				updated.ImageUrl = product.ImageUrl
+			}
+			establishmentImport.Products = append(establishmentImport.Products, repository.ImportedProduct{Product: updated, CategoryName: category})
+		case mode == enums.ImportFail:
+			item.Action, item.Reason = enums.ImportConflict, "differs from the existing product"
+		default:
+			item.Action, item.Reason = enums.ImportSkipped, "differs from the existing product, which is kept"
+		}
+		report.Products = append(report.Products, item)
+		countImportAction(&report.Summary, item.Action)
+	}
+	return nil
+}
+
+// sameImportedProduct reports whether an imported product would leave an existing one as it is. An
+// imported product without an image keeps the image of the existing one.
+func sameImportedProduct(existing, imported *entities.Product, category string) bool {
+	existingCategory := ""
+	if existing.Category != nil {
+		existingCategory = existing.Category.Name
+	}
+	return existing.Name == imported.Name &&
+		equalCode(existing.SKU, imported.SKU) && equalCode(existing.Barcode, imported.Barcode) &&
+		strings.EqualFold(existingCategory, category) &&
+		existing.Description == imported.Description && existing.Price == imported.Price &&
+		existing.IsActive == imported.IsActive &&
+		(imported.ImageUrl == "" || existing.ImageUrl == imported.ImageUrl)
+}
+
+// equalCode reports whether two optional SKUs or barcodes are the same.
+func equalCode(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func countImportAction(summary *response.ImportSummary, action enums.ImportAction) {
+	switch action {
+	case enums.ImportCreate:
+		summary.Created++
+	case enums.ImportUpdate:
+		summary.Updated++
+	case enums.ImportUnchanged:
+		summary.Unchanged++
+	case enums.ImportSkipped:
+		summary.Skipped++
+	case enums.ImportConflict:
+		summary.Conflicts++
+	case enums.ImportInvalid:
+		summary.Invalid++
+	}
+}
@@ -4,31 +4,38 @@ import (
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
 	"fmt"
-	"io"
 	"mime/multipart"
-	"os"
-	"path/filepath"
-	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // EstablishmentService handles establishment-related operations.
 type EstablishmentService interface {
 	CreateEstablishment(req *request.CreateEstablishmentRequest, adminID uint) (*response.EstablishmentResponse, error)
-	GetEstablishmentByAdminID(adminID uint) (*response.EstablishmentResponse, error)
-	UpdateEstablishmentByAdminID(adminID uint, req request.UpdateEstablishmentRequest) (*response.EstablishmentResponse, error)
+	GetEstablishmentByAdminID(adminID, branchID uint) (*response.EstablishmentResponse, error)
+	UpdateEstablishmentByAdminID(adminID, branchID uint, req request.UpdateEstablishmentRequest) (*response.EstablishmentResponse, error)
+	PatchEstablishmentByAdminID(adminID, branchID uint, req request.PatchEstablishmentRequest) (*response.EstablishmentResponse, error)
+	CreateBranch(adminID uint, req request.CreateBranchRequest) (*response.EstablishmentResponse, error)
+	GetBranches(adminID uint) ([]response.EstablishmentResponse, error)
+	DeactivateEstablishment(adminID, branchID uint) (*response.EstablishmentResponse, error)
+	ReactivateEstablishment(adminID, branchID uint) (*response.EstablishmentResponse, error)
 }
 
 type establishmentService struct {
 	establishmentRepo repository.EstablishmentRepository
 	userRepo          repository.UserRepository
+	imageUploader     *ImageUploader
 }
 
 // NewEstablishmentService creates a new instance of establishmentService.
-func NewEstablishmentService(establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository) EstablishmentService {
-	return &establishmentService{establishmentRepo: establishmentRepo, userRepo: userRepo}
+func NewEstablishmentService(establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository, imageUploader *ImageUploader) EstablishmentService {
+	return &establishmentService{establishmentRepo: establishmentRepo, userRepo: userRepo, imageUploader: imageUploader}
 }
 
 // CreateEstablishment creates a new establishment for an admin user.
@@ -41,14 +48,23 @@ func (s *establishmentService) CreateEstablishment(req *request.CreateEstablishm
 
 	// Create the Establishment entity
 	establishment := &entities.Establishment{
-		RUC:               req.RUC,
-		Name:              req.Name,
-		Phone:             req.Phone,
-		Address:           req.Address,
-		ImageUrl:          req.ImageUrl,
-		LateFeePercentage: req.LateFeePercentage,
-		IsActive:          true,
-		AdminID:           adminID,
+		RUC:                            req.RUC,
+		Name:                           req.Name,
+		Phone:                          req.Phone,
+		Address:                        req.Address,
+		ImageUrl:                       req.ImageUrl,
+		LateFeePercentage:              req.LateFeePercentage,
+		Timezone:                       req.Timezone,
+		EarlyPaymentDiscountPercentage: req.EarlyPaymentDiscountPercentage,
+		IsActive:                       true,
+		Plan:                           enums.PlanFree,
+		AdminID:                        adminID,
+	}
+	if establishment.Timezone == "" {
+		establishment.Timezone = util.DefaultTimezone
+	}
+	if err := util.ValidateTimezone(establishment.Timezone); err != nil {
+		return nil, err
 	}
 
 	admin, err := s.userRepo.GetUserByID(adminID)
@@ -58,13 +74,14 @@ func (s *establishmentService) CreateEstablishment(req *request.CreateEstablishm
 	}
 
 	adminResponse := &response.UserResponse{
-		ID:      admin.ID,
-		DNI:     admin.DNI,
-		Email:   admin.Email,
-		Name:    admin.Name,
-		Rol:     admin.Rol,
-		Address: admin.Address,
-		Phone:   admin.Phone,
+		ID:       admin.ID,
+		DNI:      admin.DNI,
+		Email:    admin.Email,
+		Name:     admin.Name,
+		Rol:      admin.Rol,
+		Address:  admin.Address,
+		Phone:    admin.Phone,
+		PhotoUrl: util.UserPhotoURL(admin.PhotoUrl, admin.Name),
 	}
 
 	if err := s.establishmentRepo.CreateEstablishment(establishment); err != nil {
@@ -74,9 +91,9 @@ func (s *establishmentService) CreateEstablishment(req *request.CreateEstablishm
 	return establishmentToResponse(establishment, adminResponse), nil // Return the EstablishmentResponse here
 }
 
-// GetEstablishmentByAdminID retrieves the establishment associated with a specific admin.
-func (s *establishmentService) GetEstablishmentByAdminID(adminID uint) (*response.EstablishmentResponse, error) {
-	establishment, err := s.establishmentRepo.GetEstablishmentByAdminID(adminID)
+// GetEstablishmentByAdminID retrieves the admin's main establishment, or the branch branchID when it is not 0.
+func (s *establishmentService) GetEstablishmentByAdminID(adminID, branchID uint) (*response.EstablishmentResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
 	if err != nil {
 		return nil, err
 	}
@@ -94,40 +111,78 @@ func (s *establishmentService) GetEstablishmentByAdminID(adminID uint) (*respons
 		Rol:      admin.Rol,
 		Address:  admin.Address,
 		Phone:    admin.Phone,
-		PhotoUrl: admin.PhotoUrl,
+		PhotoUrl: util.UserPhotoURL(admin.PhotoUrl, admin.Name),
 	}
 
 	// Convert to Response Type
 	establishmentResponse := &response.EstablishmentResponse{
-		ID:       establishment.ID,
-		RUC:      establishment.RUC,
-		Name:     establishment.Name,
-		Phone:    establishment.Phone,
-		Address:  establishment.Address,
-		ImageUrl: establishment.ImageUrl,
-		IsActive: establishment.IsActive,
-		Admin:    adminResponse,
-		AdminID:  establishment.AdminID,
+		ID:                             establishment.ID,
+		RUC:                            establishment.RUC,
+		Name:                           establishment.Name,
+		Phone:                          establishment.Phone,
+		Address:                        establishment.Address,
+		ImageUrl:                       establishment.ImageUrl,
+		Timezone:                       establishment.Timezone,
+		EarlyPaymentDiscountPercentage: establishment.EarlyPaymentDiscountPercentage,
+		IsActive:                       establishment.IsActive,
+		Version:                        establishment.Version,
+		Admin:                          adminResponse,
+		AdminID:                        establishment.AdminID,
+		ParentID:                       establishment.ParentID,
 	}
 
 	return establishmentResponse, nil
 }
 
-// UpdateEstablishmentByAdminID updates the establishment associated with the admin.
-func (s *establishmentService) UpdateEstablishmentByAdminID(adminID uint, req request.UpdateEstablishmentRequest) (*response.EstablishmentResponse, error) {
-	establishment, err := s.establishmentRepo.GetEstablishmentByAdminID(adminID)
+// UpdateEstablishmentByAdminID updates the admin's main establishment, or the branch branchID when it is
+// not 0, if it still is at the version of the request.
+func (s *establishmentService) UpdateEstablishmentByAdminID(adminID, branchID uint, req request.UpdateEstablishmentRequest) (*response.EstablishmentResponse, error) {
+	return s.PatchEstablishmentByAdminID(adminID, branchID, req.Patch())
+}
+
+// PatchEstablishmentByAdminID changes the fields the patch sets of the admin's main establishment, or
+// the branch branchID when it is not 0, if it still is at the version of the patch.
+func (s *establishmentService) PatchEstablishmentByAdminID(adminID, branchID uint, req request.PatchEstablishmentRequest) (*response.EstablishmentResponse, error) {
+	if req.Version == nil {
+		return nil, ErrVersionRequired
+	}
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
 	if err != nil {
 		return nil, err
 	}
+	establishment.Version = *req.Version
 
-	// Update fields from the request
-	establishment.RUC = req.RUC
-	establishment.Name = req.Name
-	establishment.Phone = req.Phone
-	establishment.Address = req.Address
-	establishment.ImageUrl = req.ImageUrl
-	establishment.IsActive = req.IsActive
-	establishment.LateFeePercentage = req.LateFeePercentage
+	// A branch keeps the RUC of its main establishment
+	if req.RUC != nil && establishment.ParentID == nil {
+		establishment.RUC = *req.RUC
+	}
+	if req.Name != nil {
+		establishment.Name = *req.Name
+	}
+	if req.Phone != nil {
+		establishment.Phone = *req.Phone
+	}
+	if req.Address != nil {
+		establishment.Address = *req.Address
+	}
+	if req.ImageUrl != nil {
+		establishment.ImageUrl = *req.ImageUrl
+	}
+	if req.IsActive != nil {
+		establishment.IsActive = *req.IsActive
+	}
+	if req.LateFeePercentage != nil {
+		establishment.LateFeePercentage = *req.LateFeePercentage
+	}
+	if req.EarlyPaymentDiscountPercentage != nil {
+		establishment.EarlyPaymentDiscountPercentage = *req.EarlyPaymentDiscountPercentage
+	}
+	if req.Timezone != nil {
+		if err := util.ValidateTimezone(*req.Timezone); err != nil {
+			return nil, err
+		}
+		establishment.Timezone = *req.Timezone
+	}
 
 	if err := s.establishmentRepo.UpdateEstablishment(establishment); err != nil {
 		return nil, err
@@ -140,83 +195,144 @@ func (s *establishmentService) UpdateEstablishmentByAdminID(adminID uint, req re
 	}
 
 	adminResponse := &response.UserResponse{
-		ID:      admin.ID,
-		DNI:     admin.DNI,
-		Email:   admin.Email,
-		Name:    admin.Name,
-		Rol:     admin.Rol,
-		Address: admin.Address,
-		Phone:   admin.Phone,
+		ID:       admin.ID,
+		DNI:      admin.DNI,
+		Email:    admin.Email,
+		Name:     admin.Name,
+		Rol:      admin.Rol,
+		Address:  admin.Address,
+		Phone:    admin.Phone,
+		PhotoUrl: util.UserPhotoURL(admin.PhotoUrl, admin.Name),
 	}
 
 	return establishmentToResponse(establishment, adminResponse), nil
 }
 
-// UploadEstablishmentLogo uploads an establishment logo and returns the URL.
-func (s *establishmentService) UploadEstablishmentLogo(file *multipart.FileHeader) (string, error) {
-	// 1. File Type Validation
-	allowedFileTypes := []string{".jpg", ".jpeg", ".png", ".gif"}
-	fileExt := strings.ToLower(filepath.Ext(file.Filename))
-	isValidFileType := false
-	for _, allowedType := range allowedFileTypes {
-		if fileExt == allowedType {
-			isValidFileType = true
-			break
-		}
+// CreateBranch creates a branch of the admin's main establishment. Settings that are not given are
+// copied from the main establishment.
+func (s *establishmentService) CreateBranch(adminID uint, req request.CreateBranchRequest) (*response.EstablishmentResponse, error) {
+	main, err := s.establishmentRepo.GetEstablishmentByAdminID(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving main establishment: %w", err)
+	}
+
+	branch := &entities.Establishment{
+		RUC:                            main.RUC,
+		Name:                           req.Name,
+		Phone:                          req.Phone,
+		Address:                        req.Address,
+		ImageUrl:                       req.ImageUrl,
+		LateFeePercentage:              req.LateFeePercentage,
+		Timezone:                       req.Timezone,
+		EarlyPaymentDiscountPercentage: req.EarlyPaymentDiscountPercentage,
+		IsActive:                       true,
+		AdminID:                        adminID,
+		ParentID:                       &main.ID,
+	}
+	if branch.ImageUrl == "" {
+		branch.ImageUrl = main.ImageUrl
+	}
+	if branch.LateFeePercentage == 0 {
+		branch.LateFeePercentage = main.LateFeePercentage
 	}
-	if !isValidFileType {
-		return "", ErrInvalidFileType
+	if branch.Timezone == "" {
+		branch.Timezone = main.Timezone
+	}
+	if err := util.ValidateTimezone(branch.Timezone); err != nil {
+		return nil, err
 	}
 
-	// 2. File Size Validation (Example: 2MB limit)
-	if file.Size > 2*1024*1024 {
-		return "", ErrFileSizeTooLarge
+	if err := s.establishmentRepo.CreateEstablishment(branch); err != nil {
+		return nil, fmt.Errorf("error creating branch: %w", err)
 	}
 
-	// 3. Create the "establishments_images" directory if it doesn't exist
-	imagesDir := "establishments_images"
-	if _, err := os.Stat(imagesDir); os.IsNotExist(err) {
-		err := os.Mkdir(imagesDir, 0755)
-		if err != nil {
-			return "", err
-		}
+	admin, err := s.userRepo.GetUserByID(adminID)
+	if err != nil {
+		return nil, err
+	}
+	return establishmentToResponse(branch, NewUserResponse(admin)), nil
+}
+
+// GetBranches lists the admin's main establishment followed by its branches.
+func (s *establishmentService) GetBranches(adminID uint) ([]response.EstablishmentResponse, error) {
+	establishments, err := s.establishmentRepo.GetEstablishmentsByAdminID(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving branches: %w", err)
+	}
+
+	admin, err := s.userRepo.GetUserByID(adminID)
+	if err != nil {
+		return nil, err
 	}
+	adminResponse := NewUserResponse(admin)
 
-	// 4. Generate a unique filename (you can use UUIDs or a timestamp)
-	newFilename := fmt.Sprintf("%d%s", time.Now().UnixNano(), fileExt) // Example using timestamp
+	branches := make([]response.EstablishmentResponse, 0, len(establishments))
+	for i := range establishments {
+		branches = append(branches, *establishmentToResponse(&establishments[i], adminResponse))
+	}
+	return branches, nil
+}
 
-	// 5. Create the full image file path
-	imagePath := filepath.Join(imagesDir, newFilename)
+// DeactivateEstablishment deactivates the admin's main establishment, or the branch branchID when it
+// is not 0. Until it is reactivated it takes no purchases or new clients and its catalog is hidden,
+// while its clients can still pay what they owe. Deactivating the main establishment deactivates its
+// branches with it.
+func (s *establishmentService) DeactivateEstablishment(adminID, branchID uint) (*response.EstablishmentResponse, error) {
+	return s.setActive(adminID, branchID, false)
+}
+
+// ReactivateEstablishment reactivates the admin's main establishment, or the branch branchID when it
+// is not 0. A branch of a deactivated main establishment stays inactive until the main
+// establishment is reactivated.
+func (s *establishmentService) ReactivateEstablishment(adminID, branchID uint) (*response.EstablishmentResponse, error) {
+	return s.setActive(adminID, branchID, true)
+}
 
-	// 6. Open the uploaded file
-	src, err := file.Open()
+func (s *establishmentService) setActive(adminID, branchID uint, active bool) (*response.EstablishmentResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
 	if err != nil {
-		return "", fmt.Errorf("error opening uploaded file: %w", err)
+		return nil, err
 	}
-	defer func(src multipart.File) {
-		err := src.Close()
-		if err != nil {
-			fmt.Println("error closing file:", err)
-		}
-	}(src)
+	changed, err := s.establishmentRepo.SetEstablishmentActive(establishment.ID, active)
+	if err != nil {
+		return nil, fmt.Errorf("error updating establishment: %w", err)
+	}
+	switch {
+	case !changed && active:
+		return nil, ErrEstablishmentActive
+	case !changed:
+		return nil, ErrEstablishmentDeactivated
+	}
+	return s.GetEstablishmentByAdminID(adminID, branchID)
+}
 
-	// 7. Create the destination file
-	dst, err := os.Create(imagePath)
+// checkEstablishmentActive fails with ErrEstablishmentInactive unless an establishment takes
+// purchases and new clients: it is active and, for a branch, so is its main establishment.
+func checkEstablishmentActive(establishmentRepo repository.EstablishmentRepository, establishmentID uint) error {
+	active, err := establishmentRepo.IsEstablishmentActive(establishmentID)
 	if err != nil {
-		return "", fmt.Errorf("error creating image file: %w", err)
+		return fmt.Errorf("error checking establishment: %w", err)
 	}
-	defer func(dst *os.File) {
-		err := dst.Close()
-		if err != nil {
-			fmt.Println("error closing file:", err)
-		}
-	}(dst)
+	if !active {
+		return ErrEstablishmentInactive
+	}
+	return nil
+}
 
-	// 8. Copy the uploaded file contents to the destination file
-	if _, err := io.Copy(dst, src); err != nil {
-		return "", fmt.Errorf("error copying image: %w", err)
+// adminEstablishment returns the establishment of an admin selected by branchID: the main
+// establishment when branchID is 0, otherwise the branch with that ID if it belongs to the admin.
+func adminEstablishment(establishmentRepo repository.EstablishmentRepository, adminID, branchID uint) (*entities.Establishment, error) {
+	if branchID == 0 {
+		return establishmentRepo.GetEstablishmentByAdminID(adminID)
 	}
+	establishment, err := establishmentRepo.GetAdminEstablishment(adminID, branchID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBranchNotFound
+	}
+	return establishment, err
+}
 
-	// 9. Return the URL of the uploaded image
-	return imagePath, nil
+// UploadEstablishmentLogo uploads an establishment logo and returns the URL.
+func (s *establishmentService) UploadEstablishmentLogo(file *multipart.FileHeader) (string, error) {
+	return s.imageUploader.save(file, "establishments_images", fmt.Sprintf("%d", time.Now().UnixNano()))
 }
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EstablishmentSettingsService handles the business rules admins configure for their establishments.
+type EstablishmentSettingsService interface {
+	GetSettings(adminID, branchID uint) (*response.EstablishmentSettingsResponse, error)
+	UpdateSettings(adminID, branchID uint, req request.UpdateEstablishmentSettingsRequest) (*response.EstablishmentSettingsResponse, error)
+	UserLanguage(userID uint, role enums.Role, branchID, establishmentID uint) i18n.Language
+	UserLocation(userID uint, role enums.Role, branchID, establishmentID uint) *time.Location
+}
+
+type establishmentSettingsService struct {
+	settingsRepo      repository.EstablishmentSettingsRepository
+	establishmentRepo repository.EstablishmentRepository
+	creditAccountRepo repository.CreditAccountRepository
+	userRepo          repository.UserRepository
+}
+
+// NewEstablishmentSettingsService creates a new instance of EstablishmentSettingsService.
+func NewEstablishmentSettingsService(settingsRepo repository.EstablishmentSettingsRepository, establishmentRepo repository.EstablishmentRepository, creditAccountRepo repository.CreditAccountRepository, userRepo repository.UserRepository) EstablishmentSettingsService {
+	return &establishmentSettingsService{settingsRepo: settingsRepo, establishmentRepo: establishmentRepo, creditAccountRepo: creditAccountRepo, userRepo: userRepo}
+}
+
+// GetSettings retrieves the settings of the admin's establishment.
+func (s *establishmentSettingsService) GetSettings(adminID, branchID uint) (*response.EstablishmentSettingsResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	return establishmentSettingsToResponse(settings), nil
+}
+
+// UpdateSettings changes the settings of the admin's establishment. Fields missing from the request keep their value.
+func (s *establishmentSettingsService) UpdateSettings(adminID, branchID uint, req request.UpdateEstablishmentSettingsRequest) (*response.EstablishmentSettingsResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	if req.AdjustmentApproverID != nil {
+		if err := s.checkAdjustmentApprover(establishment, *req.AdjustmentApproverID); err != nil {
+			return nil, err
+		}
+		settings.AdjustmentApproverID = *req.AdjustmentApproverID
+	}
+	applySettings(settings, req)
+
+	if err := s.settingsRepo.SaveEstablishmentSettings(settings); err != nil {
+		return nil, fmt.Errorf("error updating establishment settings: %w", err)
+	}
+	return establishmentSettingsToResponse(settings), nil
+}
+
+// applySettings changes the settings the request sets, but the adjustment approver, which is checked first.
+func applySettings(settings *entities.EstablishmentSettings, req request.UpdateEstablishmentSettingsRequest) {
+	if req.MaxInstallments != nil {
+		settings.MaxInstallments = *req.MaxInstallments
+	}
+	if req.DefaultInterestRate != nil {
+		settings.DefaultInterestRate = *req.DefaultInterestRate
+	}
+	if req.AutoBlockDaysOverdue != nil {
+		settings.AutoBlockDaysOverdue = *req.AutoBlockDaysOverdue
+	}
+	if req.ReminderSchedule != nil {
+		settings.ReminderSchedule = formatReminderSchedule(req.ReminderSchedule)
+	} else if req.ReminderDaysBefore != nil {
+		settings.ReminderSchedule = ""
+		if *req.ReminderDaysBefore > 0 {
+			settings.ReminderSchedule = formatReminderSchedule([]int{-*req.ReminderDaysBefore})
+		}
+	}
+	if req.HighRiskScore != nil {
+		settings.HighRiskScore = *req.HighRiskScore
+	}
+	if req.HighRiskMaxPurchase != nil {
+		settings.HighRiskMaxPurchase = *req.HighRiskMaxPurchase
+	}
+	if req.RequireAdminTwoFactor != nil {
+		settings.RequireAdminTwoFactor = *req.RequireAdminTwoFactor
+	}
+	if req.TaxRate != nil {
+		settings.TaxRate = *req.TaxRate
+	}
+	if req.PricesExcludeTax != nil {
+		settings.PricesExcludeTax = *req.PricesExcludeTax
+	}
+	if req.ApprovalThreshold != nil {
+		settings.ApprovalThreshold = *req.ApprovalThreshold
+	}
+	if req.ApprovalExpiryDays != nil {
+		settings.ApprovalExpiryDays = *req.ApprovalExpiryDays
+	}
+	if req.PinThreshold != nil {
+		settings.PinThreshold = *req.PinThreshold
+	}
+	if req.PaymentCodeMinutes != nil {
+		settings.PaymentCodeMinutes = *req.PaymentCodeMinutes
+	}
+	if req.MaxReschedules != nil {
+		settings.MaxReschedules = *req.MaxReschedules
+	}
+	if req.RescheduleInterest != nil {
+		settings.RescheduleInterest = *req.RescheduleInterest
+	}
+	if req.LateFeeMode != nil {
+		settings.LateFeeMode = *req.LateFeeMode
+	}
+	if req.LateFeeAmount != nil {
+		settings.LateFeeAmount = *req.LateFeeAmount
+	}
+	if req.LateFeeCap != nil {
+		settings.LateFeeCap = *req.LateFeeCap
+	}
+	if req.AdjustmentThreshold != nil {
+		settings.AdjustmentThreshold = *req.AdjustmentThreshold
+	}
+	if req.Language != nil {
+		settings.Language = *req.Language
+	}
+	if req.SMSNotifications != nil {
+		settings.SMSNotifications = *req.SMSNotifications
+	}
+	if req.SMSSender != nil {
+		settings.SMSSender = strings.TrimSpace(*req.SMSSender)
+	}
+	if req.DigestFrequency != nil {
+		settings.DigestFrequency = *req.DigestFrequency
+	}
+	if req.DigestSections != nil {
+		settings.DigestSections = strings.Join(req.DigestSections, ",")
+	}
+}
+
+// reminderDaysBefore returns how many days before the due date an establishment sends its first payment
+// reminder, 0 if it sends none before it.
+func reminderDaysBefore(settings *entities.EstablishmentSettings) int {
+	if schedule := reminderSchedule(settings); len(schedule) > 0 && schedule[0] < 0 {
+		return -schedule[0]
+	}
+	return 0
+}
+
+// checkAdjustmentApprover rejects the approver of the adjustments of an establishment unless it is an
+// admin other than the establishment's, or 0 for none.
+func (s *establishmentSettingsService) checkAdjustmentApprover(establishment *entities.Establishment, approverID uint) error {
+	if approverID == 0 {
+		return nil
+	}
+	if approverID == establishment.AdminID {
+		return ErrInvalidAdjustmentApprover
+	}
+	approver, err := s.userRepo.GetUserByID(approverID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidAdjustmentApprover
+	}
+	if err != nil {
+		return fmt.Errorf("error retrieving adjustment approver: %w", err)
+	}
+	if approver.Rol != enums.ADMIN {
+		return ErrInvalidAdjustmentApprover
+	}
+	return nil
+}
+
+// UserLanguage returns the language of the establishment a user acts on, see userEstablishment. Users
+// without one get the default language.
+func (s *establishmentSettingsService) UserLanguage(userID uint, role enums.Role, branchID, establishmentID uint) i18n.Language {
+	establishment := s.userEstablishment(userID, role, branchID, establishmentID)
+	if establishment == nil {
+		return i18n.Default
+	}
+	return establishmentLanguage(s.settingsRepo, establishment.ID)
+}
+
+// UserLocation returns the time zone of the establishment a user acts on, see userEstablishment, or
+// nil for users without one.
+func (s *establishmentSettingsService) UserLocation(userID uint, role enums.Role, branchID, establishmentID uint) *time.Location {
+	establishment := s.userEstablishment(userID, role, branchID, establishmentID)
+	if establishment == nil {
+		return nil
+	}
+	return establishmentLocation(establishment)
+}
+
+// userEstablishment returns the establishment a user acts on: the admin's establishment or the
+// selected branch, or the establishment of the client's credit account. It returns nil for users
+// without one.
+func (s *establishmentSettingsService) userEstablishment(userID uint, role enums.Role, branchID, establishmentID uint) *entities.Establishment {
+	switch role {
+	case enums.ADMIN:
+		establishment, err := adminEstablishment(s.establishmentRepo, userID, branchID)
+		if err != nil {
+			return nil
+		}
+		return establishment
+	case enums.CLIENT:
+		creditAccount, err := findClientCreditAccount(s.creditAccountRepo, userID, establishmentID)
+		if err != nil || creditAccount.Establishment == nil {
+			return nil
+		}
+		return creditAccount.Establishment
+	}
+	return nil
+}
+
+// establishmentLanguage returns the language an establishment sends its emails and PDFs in, the
+// default one if its settings can't be read.
+func establishmentLanguage(settingsRepo repository.EstablishmentSettingsRepository, establishmentID uint) i18n.Language {
+	settings, err := settingsRepo.GetEstablishmentSettings(establishmentID)
+	if err != nil {
+		log.Printf("error retrieving the language of establishment %d: %v", establishmentID, err)
+		return i18n.Default
+	}
+	if lang, ok := i18n.Parse(settings.Language); ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+// mailBody greets a person and follows with the text of key, in a language.
+func mailBody(lang i18n.Language, name, key string, args ...interface{}) string {
+	return i18n.T(lang, "mail.greeting", name) + "\n\n" + i18n.T(lang, key, args...) + "\n"
+}
+
+// interestRateOrDefault returns rate, or the establishment's default rate when rate is not set.
+func interestRateOrDefault(settingsRepo repository.EstablishmentSettingsRepository, establishmentID uint, rate float64) (float64, error) {
+	if rate > 0 {
+		return rate, nil
+	}
+	settings, err := settingsRepo.GetEstablishmentSettings(establishmentID)
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	if settings.DefaultInterestRate <= 0 {
+		return 0, ErrInterestRateRequired
+	}
+	return settings.DefaultInterestRate, nil
+}
+
+// checkHighRiskPurchase rejects a purchase by a high-risk client above the establishment's cap.
+func checkHighRiskPurchase(settingsRepo repository.EstablishmentSettingsRepository, creditAccount *entities.CreditAccount, amount float64) error {
+	if !creditAccount.HighRisk {
+		return nil
+	}
+	settings, err := settingsRepo.GetEstablishmentSettings(creditAccount.EstablishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	if settings.HighRiskMaxPurchase > 0 && amount > settings.HighRiskMaxPurchase {
+		return fmt.Errorf("purchases of high-risk clients are limited to %.2f: %w", settings.HighRiskMaxPurchase, ErrHighRiskPurchaseLimit)
+	}
+	return nil
+}
+
+// taxBreakdown splits an amount taxed at rate (%) into its taxable base and its tax, and returns the
+// amount with tax. inclusive tells whether amount already includes the tax or is the taxable base.
+func taxBreakdown(amount, rate float64, inclusive bool) (taxable, tax, total float64) {
+	if inclusive {
+		total = roundCurrency(amount)
+		taxable = roundCurrency(total / (1 + rate/100))
+		return taxable, roundCurrency(total - taxable), total
+	}
+	taxable = roundCurrency(amount)
+	tax = roundCurrency(taxable * rate / 100)
+	return taxable, tax, roundCurrency(taxable + tax)
+}
+
+func establishmentSettingsToResponse(settings *entities.EstablishmentSettings) *response.EstablishmentSettingsResponse {
+	return &response.EstablishmentSettingsResponse{
+		EstablishmentID:       settings.EstablishmentID,
+		MaxInstallments:       settings.MaxInstallments,
+		DefaultInterestRate:   settings.DefaultInterestRate,
+		AutoBlockDaysOverdue:  settings.AutoBlockDaysOverdue,
+		ReminderSchedule:      reminderSchedule(settings),
+		ReminderDaysBefore:    reminderDaysBefore(settings),
+		HighRiskScore:         settings.HighRiskScore,
+		HighRiskMaxPurchase:   settings.HighRiskMaxPurchase,
+		RequireAdminTwoFactor: settings.RequireAdminTwoFactor,
+		TaxRate:               settings.TaxRate,
+		PricesExcludeTax:      settings.PricesExcludeTax,
+		ApprovalThreshold:     settings.ApprovalThreshold,
+		ApprovalExpiryDays:    settings.ApprovalExpiryDays,
+		PinThreshold:          settings.PinThreshold,
+		PaymentCodeMinutes:    settings.PaymentCodeMinutes,
+		MaxReschedules:        settings.MaxReschedules,
+		RescheduleInterest:    settings.RescheduleInterest,
+		LateFeeMode:           settings.LateFeeMode,
+		LateFeeAmount:         settings.LateFeeAmount,
+		LateFeeCap:            settings.LateFeeCap,
+		AdjustmentThreshold:   settings.AdjustmentThreshold,
+		AdjustmentApproverID:  settings.AdjustmentApproverID,
+		Language:              settings.Language,
+		SMSNotifications:      settings.SMSNotifications,
+		SMSSender:             settings.SMSSender,
+		DigestFrequency:       settings.DigestFrequency,
+		DigestSections:        digestSectionNames(settings),
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HolidayService handles the holiday calendars establishments count business days with.
+type HolidayService interface {
+	GetHolidays(adminID, branchID uint, year int) ([]response.HolidayResponse, error)
+	CreateHoliday(adminID, branchID uint, req request.HolidayRequest) (*response.HolidayResponse, error)
+	UpdateHoliday(adminID, branchID, holidayID uint, req request.HolidayRequest) (*response.HolidayResponse, error)
+	DeleteHoliday(adminID, branchID, holidayID uint) error
+	AddNationalHolidays(adminID, branchID uint, year int) ([]response.HolidayResponse, error)
+}
+
+type holidayService struct {
+	holidayRepo       repository.HolidayRepository
+	establishmentRepo repository.EstablishmentRepository
+}
+
+// NewHolidayService creates a new instance of HolidayService.
+func NewHolidayService(holidayRepo repository.HolidayRepository, establishmentRepo repository.EstablishmentRepository) HolidayService {
+	return &holidayService{holidayRepo: holidayRepo, establishmentRepo: establishmentRepo}
+}
+
+// GetHolidays retrieves the holidays of the admin's establishment in a year, or in every year when it is 0.
+func (s *holidayService) GetHolidays(adminID, branchID uint, year int) ([]response.HolidayResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if year != 0 {
+		if err := checkHolidayYear(year); err != nil {
+			return nil, err
+		}
+	}
+	return s.holidayResponses(establishment.ID, year)
+}
+
+// CreateHoliday adds a holiday to the calendar of the admin's establishment. The installments still to
+// be paid that are due on it move to the next business day.
+func (s *holidayService) CreateHoliday(adminID, branchID uint, req request.HolidayRequest) (*response.HolidayResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseHolidayDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	holiday := entities.Holiday{EstablishmentID: establishment.ID, Date: date, Name: strings.TrimSpace(req.Name)}
+	shifts, err := s.dueDateShifts(establishment, []entities.Holiday{holiday})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.holidayRepo.CreateHoliday(&holiday, shifts[0]); err != nil {
+		if errors.Is(err, ErrHolidayExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error creating holiday: %w", err)
+	}
+	return holidayToResponse(&holiday), nil
+}
+
+// UpdateHoliday changes the date and name of a holiday of the admin's establishment. The installments
+// still to be paid that are due on its new date move to the next business day; those moved off its
+// previous date stay where they are.
+func (s *holidayService) UpdateHoliday(adminID, branchID, holidayID uint, req request.HolidayRequest) (*response.HolidayResponse, error) {
+	establishment, holiday, err := s.findHoliday(adminID, branchID, holidayID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseHolidayDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	holiday.Name = strings.TrimSpace(req.Name)
+	if !holiday.Date.Equal(date) {
+		holiday.Date, holiday.National = date, false
+	}
+	shifts, err := s.dueDateShifts(establishment, []entities.Holiday{*holiday})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.holidayRepo.UpdateHoliday(holiday, shifts[0]); err != nil {
+		if errors.Is(err, ErrHolidayExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error updating holiday: %w", err)
+	}
+	return holidayToResponse(holiday), nil
+}
+
+// DeleteHoliday deletes a holiday of the admin's establishment. Installments moved off it stay where they are.
+func (s *holidayService) DeleteHoliday(adminID, branchID, holidayID uint) error {
+	_, holiday, err := s.findHoliday(adminID, branchID, holidayID)
+	if err != nil {
+		return err
+	}
+	err = s.holidayRepo.DeleteHoliday(holiday.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrHolidayNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting holiday: %w", err)
+	}
+	return nil
+}
+
+// AddNationalHolidays adds the national holidays of Peru of a year to the calendar of the admin's
+// establishment, keeping the holidays it already has on their dates, and returns the holidays of that
+// year. The installments still to be paid that are due on them move to the next business day.
+func (s *holidayService) AddNationalHolidays(adminID, branchID uint, year int) ([]response.HolidayResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHolidayYear(year); err != nil {
+		return nil, err
+	}
+
+	national := calendar.PeruvianHolidays(year)
+	holidays := make([]entities.Holiday, len(national))
+	for i, holiday := range national {
+		holidays[i] = entities.Holiday{EstablishmentID: establishment.ID, Date: holiday.Date, Name: holiday.Name, National: true}
+	}
+	shifts, err := s.dueDateShifts(establishment, holidays)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.holidayRepo.CreateHolidays(holidays, shifts); err != nil {
+		return nil, fmt.Errorf("error adding national holidays: %w", err)
+	}
+	return s.holidayResponses(establishment.ID, year)
+}
+
+// dueDateShifts returns where the installments due on each of the given holidays of an establishment
+// move to: the next business day, counting them along with the other holidays it has.
+func (s *holidayService) dueDateShifts(establishment *entities.Establishment, holidays []entities.Holiday) ([]repository.DueDateShift, error) {
+	existing, err := s.holidayRepo.GetHolidaysByEstablishmentID(establishment.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving holidays: %w", err)
+	}
+	dates := make([]time.Time, 0, len(existing)+len(holidays))
+	for _, holiday := range holidays {
+		dates = append(dates, holiday.Date)
+	}
+	for _, holiday := range existing {
+		// A holiday being moved no longer is on its previous date
+		if holidays[0].ID == 0 || holiday.ID != holidays[0].ID {
+			dates = append(dates, holiday.Date)
+		}
+	}
+	days := calendar.NewBusinessDays(dates)
+
+	loc := establishmentLocation(establishment)
+	shifts := make([]repository.DueDateShift, len(holidays))
+	for i, holiday := range holidays {
+		from := time.Date(holiday.Date.Year(), holiday.Date.Month(), holiday.Date.Day(), 0, 0, 0, 0, loc)
+		shifts[i] = repository.DueDateShift{From: from, To: from.AddDate(0, 0, 1), DueDate: days.Next(from)}
+	}
+	return shifts, nil
+}
+
+// findHoliday retrieves a holiday of the admin's establishment, with the establishment.
+func (s *holidayService) findHoliday(adminID, branchID, holidayID uint) (*entities.Establishment, *entities.Holiday, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	holiday, err := s.holidayRepo.GetHolidayByID(holidayID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && holiday.EstablishmentID != establishment.ID) {
+		return nil, nil, ErrHolidayNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving holiday: %w", err)
+	}
+	return establishment, holiday, nil
+}
+
+func (s *holidayService) holidayResponses(establishmentID uint, year int) ([]response.HolidayResponse, error) {
+	holidays, err := s.holidayRepo.GetHolidaysByEstablishmentID(establishmentID, year)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving holidays: %w", err)
+	}
+	holidayResponses := make([]response.HolidayResponse, len(holidays))
+	for i := range holidays {
+		holidayResponses[i] = *holidayToResponse(&holidays[i])
+	}
+	return holidayResponses, nil
+}
+
+// businessDays returns the days the due dates of the credit accounts of an establishment can fall on:
+// every day but Sundays and its holidays.
+func businessDays(holidayRepo repository.HolidayRepository, establishmentID uint) (calendar.BusinessDays, error) {
+	holidays, err := holidayRepo.GetHolidaysByEstablishmentID(establishmentID, 0)
+	if err != nil {
+		return calendar.BusinessDays{}, fmt.Errorf("error retrieving holidays: %w", err)
+	}
+	dates := make([]time.Time, len(holidays))
+	for i := range holidays {
+		dates[i] = holidays[i].Date
+	}
+	return calendar.NewBusinessDays(dates), nil
+}
+
+// nextBusinessDueDate returns the first monthly due date, moved to the next business day when it falls
+// on a Sunday or a holiday, that isn't before now.
+func nextBusinessDueDate(days calendar.BusinessDays, now time.Time, monthlyDueDate int, loc *time.Location) time.Time {
+	dueDate := days.Next(util.CurrentDueDate(now, monthlyDueDate, loc))
+	if dueDate.Before(now) {
+		dueDate = days.Next(util.FollowingMonthDueDate(now, monthlyDueDate, loc))
+	}
+	return dueDate
+}
+
+// parseHolidayDate reads the date of a holiday, as midnight UTC.
+func parseHolidayDate(value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, ErrInvalidHolidayDate
+	}
+	return date, checkHolidayYear(date.Year())
+}
+
+func checkHolidayYear(year int) error {
+	if year < 2000 || year > 2100 {
+		return ErrInvalidHolidayYear
+	}
+	return nil
+}
+
+func holidayToResponse(holiday *entities.Holiday) *response.HolidayResponse {
+	return &response.HolidayResponse{
+		ID:              holiday.ID,
+		EstablishmentID: holiday.EstablishmentID,
+		Date:            holiday.Date.UTC().Format("2006-01-02"),
+		Name:            holiday.Name,
+		National:        holiday.National,
+		CreatedAt:       holiday.CreatedAt,
+		UpdatedAt:       holiday.UpdatedAt,
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"ApiRestFinance/internal/util"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Standard sizes every uploaded image is stored in, keyed by file name suffix.
+// The unsuffixed file is the "large" rendition and is the one whose path is returned.
+var standardImageSizes = []struct {
+	suffix  string
+	maxSide int
+}{
+	{suffix: "", maxSide: 1024},
+	{suffix: "_medium", maxSide: 512},
+	{suffix: "_thumb", maxSide: 128},
+}
+
+// ImageModerator decides whether an uploaded image may be stored, e.g. by
+// running it through an NSFW classifier. It returns ErrImageRejected to refuse an image.
+type ImageModerator interface {
+	Moderate(data []byte, contentType string) error
+}
+
+type noopImageModerator struct{}
+
+// NewNoopImageModerator creates an ImageModerator that accepts every image.
+func NewNoopImageModerator() ImageModerator {
+	return noopImageModerator{}
+}
+
+func (noopImageModerator) Moderate([]byte, string) error {
+	return nil
+}
+
+type httpImageModerator struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPImageModerator creates an ImageModerator that posts the image to an external
+// moderation endpoint. The endpoint must answer with JSON of the form {"flagged": bool}.
+func NewHTTPImageModerator(endpoint string) ImageModerator {
+	return &httpImageModerator{endpoint: endpoint, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (m *httpImageModerator) Moderate(data []byte, contentType string) error {
+	resp, err := m.client.Post(m.endpoint, contentType, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error calling image moderation provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("image moderation provider returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Flagged bool `json:"flagged"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("error decoding image moderation response: %w", err)
+	}
+	if result.Flagged {
+		return ErrImageRejected
+	}
+	return nil
+}
+
+// ImageUploadSettings are the limits uploaded images are checked against and the directory they're stored under.
+type ImageUploadSettings struct {
+	MaxSize     int64 // In bytes
+	MinSide     int   // In pixels
+	MaxSide     int   // In pixels
+	StorageRoot string
+}
+
+// ImageUploader validates uploaded images and stores them in the standard sizes.
+type ImageUploader struct {
+	moderator ImageModerator
+	settings  func() ImageUploadSettings
+}
+
+// NewImageUploader creates an ImageUploader that checks images with moderator. The settings are
+// read for every upload, so they can change while the API runs.
+func NewImageUploader(moderator ImageModerator, settings func() ImageUploadSettings) *ImageUploader {
+	if moderator == nil {
+		moderator = NewNoopImageModerator()
+	}
+	return &ImageUploader{moderator: moderator, settings: settings}
+}
+
+// save validates file and writes it to dir, under the storage root, as baseName plus one file per
+// standard size. It returns the path of the large rendition.
+func (u *ImageUploader) save(file *multipart.FileHeader, dir, baseName string) (string, error) {
+	settings := u.settings()
+
+	// 1. File Type Validation (Only allow images)
+	fileExt := strings.ToLower(filepath.Ext(file.Filename))
+	switch fileExt {
+	case ".jpg", ".jpeg", ".png", ".gif":
+	default:
+		return "", ErrInvalidFileType
+	}
+
+	// 2. File Size Validation
+	if file.Size > settings.MaxSize {
+		return "", ErrFileSizeTooLarge
+	}
+
+	// 3. Read and decode the image, rejecting anything that isn't really an image
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("error opening uploaded file: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(src, settings.MaxSize+1))
+	src.Close()
+	if err != nil {
+		return "", fmt.Errorf("error reading uploaded file: %w", err)
+	}
+	if int64(len(data)) > settings.MaxSize {
+		return "", ErrFileSizeTooLarge
+	}
+
+	img, format, err := util.DecodeImage(bytes.NewReader(data))
+	if err != nil {
+		return "", ErrInvalidImage
+	}
+
+	// 4. Dimension Validation
+	bounds := img.Bounds()
+	if bounds.Dx() < settings.MinSide || bounds.Dy() < settings.MinSide ||
+		bounds.Dx() > settings.MaxSide || bounds.Dy() > settings.MaxSide {
+		return "", fmt.Errorf("%w: must be between %dx%d and %dx%d pixels, got %dx%d", ErrInvalidImageDimensions,
+			settings.MinSide, settings.MinSide, settings.MaxSide, settings.MaxSide, bounds.Dx(), bounds.Dy())
+	}
+
+	// 5. Moderation
+	if err := u.moderator.Moderate(data, http.DetectContentType(data)); err != nil {
+		return "", err
+	}
+
+	// 6. Resize and store every standard size. PNG keeps transparency; everything else becomes JPEG.
+	dir = filepath.Join(settings.StorageRoot, dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	outExt := ".jpg"
+	if format == "png" {
+		outExt = ".png"
+	}
+
+	var mainPath string
+	for _, size := range standardImageSizes {
+		path := filepath.Join(dir, baseName+size.suffix+outExt)
+		if err := writeImage(path, util.ResizeImage(img, size.maxSide), outExt); err != nil {
+			return "", err
+		}
+		if size.suffix == "" {
+			mainPath = path
+		}
+	}
+	return mainPath, nil
+}
+
+// remove deletes every rendition of the image save stored at path. Images already gone are not an error.
+func (u *ImageUploader) remove(path string) error {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for _, size := range standardImageSizes {
+		if err := os.Remove(base + size.suffix + ext); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error deleting image: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeImage encodes img to a new file at path. Errors closing it are returned too, as they can
+// mean the image wasn't fully written.
+func writeImage(path string, img image.Image, ext string) (err error) {
+	dst, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating image file: %w", err)
+	}
+	defer func() {
+		if closeErr := dst.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing image file: %w", closeErr)
+		}
+	}()
+
+	if ext == ".png" {
+		err = png.Encode(dst, img)
+	} else {
+		err = jpeg.Encode(dst, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return fmt.Errorf("error encoding image: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImpersonationService lets admins see the API as one of their clients, for support, and audits what
+// they see. It also checks the impersonation tokens of requests, see middleware.ImpersonationGuard.
+type ImpersonationService interface {
+	StartImpersonation(adminID, branchID, clientID uint, reason string, client ClientInfo) (*response.ImpersonationTokenResponse, error)
+	StopImpersonation(impersonationID uint) error
+	StopAdminImpersonations(adminID uint) error
+	GetImpersonations(adminID uint) ([]response.ImpersonationResponse, error)
+	ImpersonationActive(impersonationID uint) bool
+	AuditImpersonatedRequest(impersonationID uint, method, path string, status int, ip string)
+}
+
+type impersonationService struct {
+	impersonationRepo repository.ImpersonationRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	tokens            *util.TokenIssuer
+	clock             util.Clock
+}
+
+// NewImpersonationService creates a new instance of ImpersonationService. Impersonation tokens are
+// issued by tokens.
+func NewImpersonationService(impersonationRepo repository.ImpersonationRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, tokens *util.TokenIssuer, clock util.Clock) ImpersonationService {
+	return &impersonationService{
+		impersonationRepo: impersonationRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		tokens:            tokens,
+		clock:             clock,
+	}
+}
+
+// StartImpersonation issues an impersonation token for a client with a credit account in the admin's
+// establishment, or the selected branch. The token acts as the client's on that establishment only.
+func (s *impersonationService) StartImpersonation(adminID, branchID, clientID uint, reason string, client ClientInfo) (*response.ImpersonationTokenResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(clientID, establishment.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+
+	now := s.clock.Now()
+	impersonation := entities.Impersonation{
+		AdminID:         adminID,
+		ClientID:        clientID,
+		EstablishmentID: establishment.ID,
+		Reason:          reason,
+		IP:              client.IP,
+		UserAgent:       client.UserAgent,
+		ExpiresAt:       now.Add(s.tokens.ImpersonationTTL()),
+		CreatedAt:       now,
+	}
+	if err := s.impersonationRepo.CreateImpersonation(&impersonation); err != nil {
+		return nil, fmt.Errorf("error creating impersonation: %w", err)
+	}
+
+	accessToken, err := s.tokens.Issue(util.ImpersonationToken, util.TokenClaims{
+		UserID:          clientID,
+		Role:            string(enums.CLIENT),
+		EstablishmentID: establishment.ID,
+		ImpersonatorID:  adminID,
+		ImpersonationID: impersonation.ID,
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Admin %d started impersonation %d of client %d in establishment %d", adminID, impersonation.ID, clientID, establishment.ID)
+
+	return &response.ImpersonationTokenResponse{
+		ImpersonationID: impersonation.ID,
+		ClientID:        clientID,
+		EstablishmentID: establishment.ID,
+		AccessToken:     accessToken,
+		Impersonation:   true,
+		ExpiresAt:       impersonation.ExpiresAt,
+	}, nil
+}
+
+// StopImpersonation ends an impersonation, so its token stops working right away. Impersonations
+// already over are left alone.
+func (s *impersonationService) StopImpersonation(impersonationID uint) error {
+	if _, err := s.impersonationRepo.EndImpersonation(impersonationID, s.clock.Now()); err != nil {
+		return fmt.Errorf("error stopping impersonation: %w", err)
+	}
+	return nil
+}
+
+// StopAdminImpersonations ends every impersonation of an admin still going on.
+func (s *impersonationService) StopAdminImpersonations(adminID uint) error {
+	if err := s.impersonationRepo.EndAdminImpersonations(adminID, s.clock.Now()); err != nil {
+		return fmt.Errorf("error stopping impersonations: %w", err)
+	}
+	return nil
+}
+
+// GetImpersonations retrieves the latest impersonations of an admin, newest first, with the requests
+// made in each.
+func (s *impersonationService) GetImpersonations(adminID uint) ([]response.ImpersonationResponse, error) {
+	impersonations, err := s.impersonationRepo.GetImpersonationsByAdminID(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving impersonations: %w", err)
+	}
+
+	return impersonationsToResponse(impersonations, s.clock.Now()), nil
+}
+
+// ImpersonationActive reports whether an impersonation was neither stopped nor expired. It reports
+// false when it can't be read, so requests aren't let through unchecked.
+func (s *impersonationService) ImpersonationActive(impersonationID uint) bool {
+	impersonation, err := s.impersonationRepo.GetImpersonationByID(impersonationID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Error retrieving impersonation %d: %v", impersonationID, err)
+		}
+		return false
+	}
+	return impersonation.EndedAt == nil && s.clock.Now().Before(impersonation.ExpiresAt)
+}
+
+// AuditImpersonatedRequest records a request made with the token of an impersonation.
+func (s *impersonationService) AuditImpersonatedRequest(impersonationID uint, method, path string, status int, ip string) {
+	err := s.impersonationRepo.CreateImpersonationRequest(&entities.ImpersonationRequest{
+		ImpersonationID: impersonationID,
+		Method:          method,
+		Path:            path,
+		Status:          status,
+		IP:              ip,
+		CreatedAt:       s.clock.Now(),
+	})
+	if err != nil {
+		log.Printf("Error auditing request %s %s of impersonation %d: %v", method, path, impersonationID, err)
+	}
+}
+
+// impersonationsToResponse builds the responses of impersonations, active if they are still going on
+// at now.
+func impersonationsToResponse(impersonations []entities.Impersonation, now time.Time) []response.ImpersonationResponse {
+	impersonationResponses := make([]response.ImpersonationResponse, 0, len(impersonations))
+	for _, impersonation := range impersonations {
+		requests := make([]response.ImpersonationRequestResponse, 0, len(impersonation.Requests))
+		for _, request := range impersonation.Requests {
+			requests = append(requests, response.ImpersonationRequestResponse{
+				Method:    request.Method,
+				Path:      request.Path,
+				Status:    request.Status,
+				IP:        request.IP,
+				CreatedAt: request.CreatedAt,
+			})
+		}
+		impersonationResponses = append(impersonationResponses, response.ImpersonationResponse{
+			ID:              impersonation.ID,
+			ClientID:        impersonation.ClientID,
+			EstablishmentID: impersonation.EstablishmentID,
+			Reason:          impersonation.Reason,
+			IP:              impersonation.IP,
+			Active:          impersonation.EndedAt == nil && now.Before(impersonation.ExpiresAt),
+			CreatedAt:       impersonation.CreatedAt,
+			ExpiresAt:       impersonation.ExpiresAt,
+			EndedAt:         impersonation.EndedAt,
+			Requests:        requests,
+		})
+	}
+	return impersonationResponses
+}
@@ -1,12 +1,20 @@
 package service
 
 import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/interest"
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // InstallmentService handles installment-related operations.
@@ -16,16 +24,32 @@ type InstallmentService interface {
 	UpdateInstallment(id uint, req request.UpdateInstallmentRequest) (*response.InstallmentResponse, error)
 	DeleteInstallment(id uint) error
 	GetInstallmentsByCreditAccountID(creditAccountID uint) ([]response.InstallmentResponse, error)
+	GetInstallmentsByCreditAccountIDs(creditAccountIDs []uint) (map[uint][]response.InstallmentResponse, error)
 	GetOverdueInstallments(creditAccountID uint) ([]response.InstallmentResponse, error)
+	MarkOverdueInstallments() error
+	RescheduleInstallment(adminID, installmentID uint, req request.RescheduleInstallmentRequest) (*response.InstallmentResponse, error)
+	GetInstallmentReschedules(adminID, installmentID uint) ([]response.InstallmentRescheduleResponse, error)
 }
 
 type installmentService struct {
-	installmentRepo repository.InstallmentRepository
+	installmentRepo   repository.InstallmentRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	clock             util.Clock
+	bus               event.Bus
 }
 
 // NewInstallmentService creates a new instance of InstallmentService.
-func NewInstallmentService(installmentRepo repository.InstallmentRepository) InstallmentService {
-	return &installmentService{installmentRepo: installmentRepo}
+func NewInstallmentService(installmentRepo repository.InstallmentRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository, clock util.Clock, bus event.Bus) InstallmentService {
+	return &installmentService{
+		installmentRepo:   installmentRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		settingsRepo:      settingsRepo,
+		clock:             clock,
+		bus:               bus,
+	}
 }
 
 // CreateInstallment creates a new installment.
@@ -41,6 +65,7 @@ func (s *installmentService) CreateInstallment(req request.CreateInstallmentRequ
 	if err != nil {
 		return nil, fmt.Errorf("error creating installment: %w", err)
 	}
+	publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, installment.CreditAccountID)
 	return installmentToResponse(&installment), nil
 }
 
@@ -53,12 +78,26 @@ func (s *installmentService) GetInstallmentByID(id uint) (*response.InstallmentR
 	return installmentToResponse(installment), nil
 }
 
-// UpdateInstallment updates an existing installment.
+// UpdateInstallment updates an existing installment, if it still is at the version of the request.
+// Paid and cancelled installments can't be changed. The status follows the installment's payments
+// and due date, so it can only be set to CANCELLED, or to the one it already has.
 func (s *installmentService) UpdateInstallment(id uint, req request.UpdateInstallmentRequest) (*response.InstallmentResponse, error) {
+	if req.Version == nil {
+		return nil, ErrVersionRequired
+	}
 	installment, err := s.installmentRepo.GetInstallmentByID(id)
 	if err != nil {
 		return nil, err
 	}
+	switch {
+	case installment.Status == enums.Paid:
+		return nil, ErrInstallmentPaid
+	case installment.Status == enums.Cancelled:
+		return nil, ErrInstallmentCancelled
+	case req.Status != "" && req.Status != installment.Status && req.Status != enums.Cancelled:
+		return nil, ErrInvalidInstallmentStatus
+	}
+	installment.Version = *req.Version
 
 	if !req.DueDate.IsZero() {
 		installment.DueDate = req.DueDate
@@ -66,20 +105,31 @@ func (s *installmentService) UpdateInstallment(id uint, req request.UpdateInstal
 	if req.Amount > 0 {
 		installment.Amount = req.Amount
 	}
-	if req.Status != "" {
-		installment.Status = req.Status
+	if req.Status == enums.Cancelled {
+		now := s.clock.Now()
+		installment.Status = enums.Cancelled
+		installment.CancelledAt = &now
 	}
 
 	err = s.installmentRepo.UpdateInstallment(installment)
 	if err != nil {
 		return nil, err
 	}
+	publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, installment.CreditAccountID)
 	return installmentToResponse(installment), nil
 }
 
 // DeleteInstallment deletes an installment.
 func (s *installmentService) DeleteInstallment(id uint) error {
-	return s.installmentRepo.DeleteInstallment(id)
+	installment, err := s.installmentRepo.GetInstallmentByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.installmentRepo.DeleteInstallment(id); err != nil {
+		return err
+	}
+	publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, installment.CreditAccountID)
+	return nil
 }
 
 // GetInstallmentsByCreditAccountID retrieves all installments for a specific credit account.
@@ -97,6 +147,20 @@ func (s *installmentService) GetInstallmentsByCreditAccountID(creditAccountID ui
 	return installmentResponses, nil
 }
 
+// GetInstallmentsByCreditAccountIDs retrieves the installments of several credit accounts at once, grouped by account.
+func (s *installmentService) GetInstallmentsByCreditAccountIDs(creditAccountIDs []uint) (map[uint][]response.InstallmentResponse, error) {
+	installments, err := s.installmentRepo.GetInstallmentsByCreditAccountIDs(creditAccountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving installments: %w", err)
+	}
+
+	byAccount := make(map[uint][]response.InstallmentResponse, len(creditAccountIDs))
+	for i := range installments {
+		byAccount[installments[i].CreditAccountID] = append(byAccount[installments[i].CreditAccountID], *installmentToResponse(&installments[i]))
+	}
+	return byAccount, nil
+}
+
 // GetOverdueInstallments retrieves all overdue installments for a specific credit account.
 func (s *installmentService) GetOverdueInstallments(creditAccountID uint) ([]response.InstallmentResponse, error) {
 	installments, err := s.installmentRepo.GetOverdueInstallments(creditAccountID)
@@ -112,6 +176,158 @@ func (s *installmentService) GetOverdueInstallments(creditAccountID uint) ([]res
 	return installmentResponses, nil
 }
 
+// MarkOverdueInstallments marks overdue the installments still owed whose due date passed.
+func (s *installmentService) MarkOverdueInstallments() error {
+	installments, err := s.installmentRepo.MarkOverdueInstallments(s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error marking installments overdue: %w", err)
+	}
+	accounts := make(map[uint]bool)
+	for i := range installments {
+		if !accounts[installments[i].CreditAccountID] {
+			accounts[installments[i].CreditAccountID] = true
+			publishAccountEvent(s.bus, s.clock, event.CreditAccountUpdated, installments[i].CreditAccountID)
+		}
+	}
+	return nil
+}
+
+// RescheduleInstallment moves the due date of an unpaid installment of a credit account of one of
+// the admin's establishments, keeping the date it had in its reschedule history. The new date goes
+// from tomorrow up to a month after the account's last unpaid installment, so the credit isn't
+// stretched past its term by more than a month, and each account has at most the reschedules its
+// establishment allows. Establishments that charge for it add the account's interest for the days
+// an installment is postponed.
+func (s *installmentService) RescheduleInstallment(adminID, installmentID uint, req request.RescheduleInstallmentRequest) (*response.InstallmentResponse, error) {
+	installment, creditAccount, err := s.adminInstallment(adminID, installmentID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case installment.Status == enums.Paid:
+		return nil, ErrInstallmentPaid
+	case installment.Status == enums.Cancelled:
+		return nil, ErrInstallmentCancelled
+	case creditAccount.Status == enums.AccountClosed:
+		return nil, ErrCreditAccountClosed
+	case creditAccount.WrittenOffAt != nil:
+		return nil, ErrCreditAccountWrittenOff
+	}
+
+	loc := accountLocation(creditAccount)
+	dueDate, _, err := util.ParseISODate(req.DueDate, loc)
+	if err != nil {
+		return nil, ErrInvalidRescheduleDate
+	}
+	dueDate = util.StartOfDayIn(dueDate.In(loc), loc)
+	currentDueDate := util.StartOfDayIn(installment.DueDate.In(loc), loc)
+	lastDueDate, err := s.lastDueDate(creditAccount, loc)
+	if err != nil {
+		return nil, err
+	}
+	if !dueDate.After(util.StartOfDayIn(s.clock.Now().In(loc), loc)) || dueDate.Equal(currentDueDate) ||
+		dueDate.After(util.AddMonthsToDueDate(lastDueDate, 1, creditAccount.MonthlyDueDate, loc)) {
+		return nil, ErrInvalidRescheduleDate
+	}
+
+	settings, err := s.settingsRepo.GetEstablishmentSettings(creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	reschedule := entities.InstallmentReschedule{
+		NewDueDate:      dueDate,
+		Reason:          strings.TrimSpace(req.Reason),
+		RescheduledByID: adminID,
+	}
+	if settings.RescheduleInterest && dueDate.After(currentDueDate) {
+		days := int(dueDate.Sub(currentDueDate).Hours()/24 + 0.5)
+		charged, err := interest.ForDays(installment.Amount, creditAccount.InterestRate/100,
+			creditAccount.InterestType, creditAccount.CompoundingPeriod, days)
+		if err != nil {
+			return nil, fmt.Errorf("error calculating interest: %w", err)
+		}
+		reschedule.Interest = roundCurrency(charged)
+	}
+
+	if err := s.installmentRepo.RescheduleInstallment(installment, &reschedule, settings.MaxReschedules); err != nil {
+		return nil, err
+	}
+	publishAccountEvent(s.bus, s.clock, event.InstallmentRescheduled, creditAccount.ID)
+	return installmentToResponse(installment), nil
+}
+
+// GetInstallmentReschedules retrieves the reschedule history of an installment of a credit account
+// of one of the admin's establishments, oldest first.
+func (s *installmentService) GetInstallmentReschedules(adminID, installmentID uint) ([]response.InstallmentRescheduleResponse, error) {
+	installment, creditAccount, err := s.adminInstallment(adminID, installmentID)
+	if err != nil {
+		return nil, err
+	}
+	reschedules, err := s.installmentRepo.GetInstallmentReschedules(installment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving reschedules: %w", err)
+	}
+
+	loc := accountLocation(creditAccount)
+	rescheduleResponses := make([]response.InstallmentRescheduleResponse, 0, len(reschedules))
+	for i := range reschedules {
+		rescheduleResponses = append(rescheduleResponses, *installmentRescheduleToResponse(&reschedules[i], loc))
+	}
+	return rescheduleResponses, nil
+}
+
+// adminInstallment retrieves an installment of a credit account of one of the admin's
+// establishments, with the account.
+func (s *installmentService) adminInstallment(adminID, installmentID uint) (*entities.Installment, *entities.CreditAccount, error) {
+	installment, err := s.installmentRepo.GetInstallmentByID(installmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInstallmentNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving installment: %w", err)
+	}
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(installment.CreditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInstallmentNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, nil, ErrInstallmentNotFound
+	}
+	return installment, creditAccount, nil
+}
+
+// lastDueDate returns the start of the day the last unpaid installment of a credit account is due.
+func (s *installmentService) lastDueDate(creditAccount *entities.CreditAccount, loc *time.Location) (time.Time, error) {
+	installments, err := s.installmentRepo.GetInstallmentsByCreditAccountID(creditAccount.ID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error retrieving installments: %w", err)
+	}
+	var last time.Time
+	for _, installment := range installments {
+		if installment.Status.IsOpen() && installment.DueDate.After(last) {
+			last = installment.DueDate
+		}
+	}
+	return util.StartOfDayIn(last.In(loc), loc), nil
+}
+
+func installmentRescheduleToResponse(reschedule *entities.InstallmentReschedule, loc *time.Location) *response.InstallmentRescheduleResponse {
+	return &response.InstallmentRescheduleResponse{
+		ID:              reschedule.ID,
+		InstallmentID:   reschedule.InstallmentID,
+		CreditAccountID: reschedule.CreditAccountID,
+		OriginalDueDate: reschedule.OriginalDueDate.In(loc).Format("2006-01-02"),
+		NewDueDate:      reschedule.NewDueDate.In(loc).Format("2006-01-02"),
+		Interest:        reschedule.Interest,
+		Reason:          reschedule.Reason,
+		RescheduledByID: reschedule.RescheduledByID,
+		CreatedAt:       reschedule.CreatedAt,
+	}
+}
+
 func installmentToResponse(installment *entities.Installment) *response.InstallmentResponse {
 	return &response.InstallmentResponse{
 		ID:              installment.ID,
@@ -119,6 +335,10 @@ func installmentToResponse(installment *entities.Installment) *response.Installm
 		DueDate:         installment.DueDate,
 		Amount:          installment.Amount,
 		Status:          installment.Status,
+		OverdueAt:       installment.OverdueAt,
+		PaidAt:          installment.PaidAt,
+		CancelledAt:     installment.CancelledAt,
+		Version:         installment.Version,
 		CreatedAt:       installment.CreatedAt,
 		UpdatedAt:       installment.UpdatedAt,
 	}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/invoicing"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"gorm.io/gorm"
+)
+
+const (
+	invoiceBatchSize     = 20
+	invoiceMaxAttempts   = 10
+	invoiceRetryDelay    = time.Minute
+	invoiceMaxRetryDelay = 6 * time.Hour
+	invoiceCurrency      = "PEN"
+)
+
+// InvoiceSender submits signed electronic receipts to SUNAT or an OSE. *invoicing.Client implements it.
+type InvoiceSender interface {
+	SendBill(fileName string, signedXML []byte) (*invoicing.CDR, error)
+}
+
+// InvoicingService issues the electronic receipts of credit purchases. It is an EventPublisher: the
+// receipt of a purchase is queued when its purchase.created event is relayed from the outbox, and
+// submitted by SubmitDueInvoices.
+type InvoicingService interface {
+	PublishEvent(id uint, evt event.Event) error
+	SubmitDueInvoices() error
+	GetInvoice(userID uint, role enums.Role, transactionID uint) (*response.ElectronicInvoiceResponse, error)
+	GetInvoicePDF(userID uint, role enums.Role, transactionID uint) ([]byte, error)
+}
+
+type invoicingService struct {
+	invoiceRepo       repository.ElectronicInvoiceRepository
+	purchaseItemRepo  repository.PurchaseItemRepository
+	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	signer            *invoicing.Signer
+	sender            InvoiceSender
+	clock             util.Clock
+}
+
+// NewInvoicingService creates a new instance of InvoicingService. signer and sender may be nil when
+// electronic invoicing is not configured, in which case only the receipts issued so far can be read.
+func NewInvoicingService(invoiceRepo repository.ElectronicInvoiceRepository, purchaseItemRepo repository.PurchaseItemRepository, establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository, signer *invoicing.Signer, sender InvoiceSender, clock util.Clock) InvoicingService {
+	return &invoicingService{
+		invoiceRepo:       invoiceRepo,
+		purchaseItemRepo:  purchaseItemRepo,
+		establishmentRepo: establishmentRepo,
+		settingsRepo:      settingsRepo,
+		signer:            signer,
+		sender:            sender,
+		clock:             clock,
+	}
+}
+
+// PublishEvent queues the receipt of the purchase of a purchase.created event and ignores any other
+// event. Relaying an event again doesn't queue a second receipt.
+func (s *invoicingService) PublishEvent(id uint, evt event.Event) error {
+	if evt.Name != event.PurchaseCreated {
+		return nil
+	}
+	payload, ok := evt.Payload.(json.RawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T of event %d", evt.Payload, id)
+	}
+	var purchase struct {
+		TransactionID uint `json:"transaction_id"`
+	}
+	if err := json.Unmarshal(payload, &purchase); err != nil {
+		return fmt.Errorf("error decoding event %d: %w", id, err)
+	}
+
+	if _, err := s.invoiceRepo.CreateInvoiceForTransaction(purchase.TransactionID, s.clock.Now()); err != nil {
+		return fmt.Errorf("error queuing electronic invoice: %w", err)
+	}
+	return nil
+}
+
+// SubmitDueInvoices signs and submits the queued receipts until none is due. Receipts that couldn't
+// be submitted are retried with exponential backoff and marked FAILED after invoiceMaxAttempts;
+// receipts SUNAT rejected are not retried, they must be corrected with a new document.
+func (s *invoicingService) SubmitDueInvoices() error {
+	for {
+		count, err := s.invoiceRepo.ProcessDueInvoices(s.clock.Now(), invoiceBatchSize, s.submit)
+		if err != nil {
+			return fmt.Errorf("error submitting electronic invoices: %w", err)
+		}
+		if count < invoiceBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *invoicingService) submit(invoices []entities.ElectronicInvoice) {
+	for i := range invoices {
+		invoice := &invoices[i]
+		invoice.Attempts++
+
+		cdr, err := s.send(invoice)
+		now := s.clock.Now()
+		var fault *invoicing.Fault
+		switch {
+		case err == nil:
+			invoice.Status = enums.InvoiceRejected
+			if cdr.Accepted() {
+				invoice.Status = enums.InvoiceAccepted
+			}
+			invoice.CDR = cdr.Data
+			invoice.ResponseCode = cdr.ResponseCode
+			invoice.ResponseDescription = cdr.Description
+			for _, note := range cdr.Notes {
+				invoice.ResponseDescription += "\n" + note
+			}
+			invoice.LastError = ""
+			invoice.SubmittedAt = &now
+		case errors.As(err, &fault) && fault.Rejected():
+			invoice.Status = enums.InvoiceRejected
+			invoice.ResponseCode = fault.Code
+			invoice.ResponseDescription = fault.Message
+			invoice.LastError = ""
+			invoice.SubmittedAt = &now
+		default:
+			invoice.LastError = err.Error()
+			invoice.NextAttemptAt = now.Add(retryBackoff(invoice.Attempts, invoiceRetryDelay, invoiceMaxRetryDelay))
+			if invoice.Attempts >= invoiceMaxAttempts {
+				invoice.Status = enums.InvoiceFailed
+				log.Printf("giving up on electronic invoice %s after %d attempts: %v", invoice.DocumentNumber, invoice.Attempts, err)
+			}
+		}
+	}
+}
+
+// send submits the receipt, signing it first if this is its first attempt.
+func (s *invoicingService) send(invoice *entities.ElectronicInvoice) (*invoicing.CDR, error) {
+	if s.signer == nil || s.sender == nil {
+		return nil, errors.New("electronic invoicing is not configured")
+	}
+	doc, err := s.invoiceDocument(invoice)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.SignedXML == "" {
+		signedXML, digest, err := s.signer.Sign(doc)
+		if err != nil {
+			return nil, fmt.Errorf("error signing electronic invoice: %w", err)
+		}
+		invoice.SignedXML, invoice.DigestValue = string(signedXML), digest
+	}
+	return s.sender.SendBill(doc.FileName(), []byte(invoice.SignedXML))
+}
+
+// invoiceDocument builds the receipt of the purchase of invoice. Its lines are the items of the
+// purchase, as they were taxed, when they add up to the amount charged, or otherwise a single line
+// with the purchase taxed at the establishment's current rate.
+func (s *invoicingService) invoiceDocument(invoice *entities.ElectronicInvoice) (*invoicing.Document, error) {
+	transaction := invoice.Transaction
+	if transaction == nil || transaction.CreditAccount == nil || transaction.CreditAccount.Establishment == nil || transaction.CreditAccount.Client == nil {
+		return nil, fmt.Errorf("purchase %d of electronic invoice %s not found", invoice.TransactionID, invoice.DocumentNumber)
+	}
+	establishment := transaction.CreditAccount.Establishment
+	client := transaction.CreditAccount.Client
+
+	items, err := s.purchaseItemRepo.GetPurchaseItemsByTransactionID(transaction.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving purchase items: %w", err)
+	}
+	var lines []invoicing.Line
+	itemsTotal := 0.0
+	for _, item := range items {
+		description := fmt.Sprintf("Product %d", item.ProductID)
+		if item.Product != nil {
+			description = item.Product.Name
+		}
+		lines = append(lines, invoicing.Line{
+			Description: description,
+			Quantity:    float64(item.Quantity),
+			TaxRate:     item.TaxRate,
+			Amounts:     invoicing.Amounts{Taxable: item.TaxableAmount, Tax: item.TaxAmount, Total: item.Total},
+		})
+		itemsTotal += item.Total
+	}
+	if len(lines) == 0 || math.Abs(itemsTotal-transaction.Amount) >= 0.005 {
+		settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+		}
+		description := transaction.Description
+		if description == "" {
+			description = "Purchase"
+		}
+		taxable, tax, total := taxBreakdown(transaction.Amount, settings.TaxRate, true)
+		lines = []invoicing.Line{{
+			Description: description,
+			Quantity:    1,
+			TaxRate:     settings.TaxRate,
+			Amounts:     invoicing.Amounts{Taxable: taxable, Tax: tax, Total: total},
+		}}
+	}
+
+	return &invoicing.Document{
+		Type:     invoicing.Receipt,
+		Number:   invoice.DocumentNumber,
+		IssuedAt: transaction.TransactionDate.In(establishmentLocation(establishment)),
+		Currency: invoiceCurrency,
+		Issuer:   invoicing.Party{IDType: invoicing.RUC, ID: establishment.RUC, Name: establishment.Name, Address: establishment.Address},
+		Customer: invoicing.Party{IDType: invoicing.DNI, ID: client.DNI, Name: client.Name, Address: client.Address},
+		Lines:    lines,
+	}, nil
+}
+
+// GetInvoice retrieves the receipt of a purchase, for the admin of its establishment or the client
+// who made it.
+func (s *invoicingService) GetInvoice(userID uint, role enums.Role, transactionID uint) (*response.ElectronicInvoiceResponse, error) {
+	invoice, err := s.findInvoice(userID, role, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ElectronicInvoiceResponse{
+		TransactionID:       invoice.TransactionID,
+		DocumentNumber:      invoice.DocumentNumber,
+		Status:              invoice.Status,
+		ResponseCode:        invoice.ResponseCode,
+		ResponseDescription: invoice.ResponseDescription,
+		DigestValue:         invoice.DigestValue,
+		Attempts:            invoice.Attempts,
+		LastError:           invoice.LastError,
+		SubmittedAt:         invoice.SubmittedAt,
+		CreatedAt:           invoice.CreatedAt,
+	}, nil
+}
+
+// findInvoice retrieves the receipt of a purchase if the user may see it. Receipts of other
+// establishments or clients are reported as not found.
+func (s *invoicingService) findInvoice(userID uint, role enums.Role, transactionID uint) (*entities.ElectronicInvoice, error) {
+	invoice, err := s.invoiceRepo.GetInvoiceByTransactionID(transactionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving electronic invoice: %w", err)
+	}
+	switch role {
+	case enums.ADMIN:
+		_, err := s.establishmentRepo.GetAdminEstablishment(userID, invoice.EstablishmentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving establishment: %w", err)
+		}
+	case enums.CLIENT:
+		if invoice.Transaction == nil || invoice.Transaction.CreditAccount == nil || invoice.Transaction.CreditAccount.ClientID != userID {
+			return nil, ErrInvoiceNotFound
+		}
+	default:
+		return nil, ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
+
+// GetInvoicePDF renders the printed representation of the receipt of a purchase, which SUNAT
+// requires to show the hash of the signed XML. Receipts not signed yet are marked as such.
+func (s *invoicingService) GetInvoicePDF(userID uint, role enums.Role, transactionID uint) ([]byte, error) {
+	invoice, err := s.findInvoice(userID, role, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := s.invoiceDocument(invoice)
+	if err != nil {
+		return nil, err
+	}
+	amounts := doc.Amounts()
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	// Issuer and document number
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(120, 8, tr(doc.Issuer.Name), "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 8, "BOLETA DE VENTA ELECTRONICA", "LTR", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(120, 8, tr(doc.Issuer.Address), "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 8, "RUC "+doc.Issuer.ID, "LR", 1, "C", false, 0, "")
+	pdf.CellFormat(120, 8, "", "", 0, "L", false, 0, "")
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(70, 8, doc.Number, "LBR", 1, "C", false, 0, "")
+	pdf.Ln(6)
+
+	// Customer
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, "Fecha de emision: "+doc.IssuedAt.Format("02/01/2006 15:04"), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, tr("Cliente: "+doc.Customer.Name), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, "DNI: "+doc.Customer.ID, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, "Moneda: "+doc.Currency, "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	// Lines
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(20, 8, "Cant.", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(110, 8, "Descripcion", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 8, "P. Unit.", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 8, "Importe", "1", 1, "R", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	for _, line := range doc.Lines {
+		pdf.CellFormat(20, 8, fmt.Sprintf("%g", line.Quantity), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(110, 8, tr(line.Description), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 8, fmt.Sprintf("%.2f", line.UnitPrice()), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 8, fmt.Sprintf("%.2f", line.Amounts.Total), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	// Totals
+	for _, total := range []struct {
+		label  string
+		amount float64
+	}{
+		{"Op. gravada", amounts.Taxable},
+		{"IGV (" + strconv.FormatFloat(doc.Lines[0].TaxRate, 'f', -1, 64) + "%)", amounts.Tax},
+		{"Importe total", amounts.Total},
+	} {
+		pdf.CellFormat(160, 7, total.label, "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, fmt.Sprintf("%.2f", total.amount), "", 1, "R", false, 0, "")
+	}
+	pdf.Ln(2)
+	pdf.MultiCell(0, 6, tr(invoicing.AmountInWords(amounts.Total, doc.Currency)), "", "L", false)
+	pdf.Ln(4)
+
+	// Hash and status
+	hash := invoice.DigestValue
+	if hash == "" {
+		hash = "Pendiente de firma"
+	}
+	pdf.SetFont("Arial", "", 9)
+	pdf.CellFormat(0, 5, "Valor resumen: "+hash, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, "Estado en SUNAT: "+string(invoice.Status), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, "Representacion impresa de la boleta de venta electronica", "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("error rendering electronic invoice PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
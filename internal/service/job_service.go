@@ -0,0 +1,263 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/queue"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxJobAttempts = 3
+	// jobTimeout is how long a job may run before it is considered abandoned by its worker and queued again
+	jobTimeout = 30 * time.Minute
+	// jobRequeueDelay is how long a job may wait in the queue before it is enqueued again, in case its task was lost
+	jobRequeueDelay = 5 * time.Minute
+	jobRetention    = 24 * time.Hour
+)
+
+// JobResult is the file a job produced.
+type JobResult struct {
+	Data        []byte
+	ContentType string
+	Filename    string
+}
+
+// JobHandler runs a job of one type with its payload. The result is nil for jobs that don't produce a file.
+type JobHandler func(payload json.RawMessage) (*JobResult, error)
+
+// JobService queues background jobs and runs them as their tasks are delivered by the queue.
+type JobService interface {
+	// RegisterHandler sets the handler that runs the jobs of a type. Handlers are registered at startup,
+	// by the services owning the job types.
+	RegisterHandler(jobType string, handler JobHandler)
+	// EnqueueJob stores a job and queues it. A job with a dedupKey is only queued if no unfinished
+	// job has the same key; the existing job is returned otherwise.
+	EnqueueJob(jobType string, ownerID uint, dedupKey string, payload any) (*response.JobResponse, error)
+	GetJob(id, ownerID uint) (*response.JobResponse, error)
+	GetJobResult(id, ownerID uint) (*JobResult, error)
+	RunJob(task queue.Task)
+	RequeueStaleJobs() error
+	PurgeFinishedJobs() error
+}
+
+type jobService struct {
+	jobRepo repository.JobRepository
+	queue   queue.Driver
+	clock   util.Clock
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+}
+
+// NewJobService creates a new instance of JobService that queues jobs in driver.
+func NewJobService(jobRepo repository.JobRepository, driver queue.Driver, clock util.Clock) JobService {
+	return &jobService{jobRepo: jobRepo, queue: driver, clock: clock, handlers: make(map[string]JobHandler)}
+}
+
+func (s *jobService) RegisterHandler(jobType string, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+func (s *jobService) handler(jobType string) (JobHandler, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	handler, ok := s.handlers[jobType]
+	return handler, ok
+}
+
+func (s *jobService) EnqueueJob(jobType string, ownerID uint, dedupKey string, payload any) (*response.JobResponse, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding job payload: %w", err)
+	}
+	job := &entities.Job{Type: jobType, OwnerID: ownerID, Status: enums.JobQueued, Payload: string(data)}
+	if dedupKey != "" {
+		job.DedupKey = &dedupKey
+	}
+
+	created, err := s.jobRepo.CreateJob(job)
+	if err != nil {
+		return nil, fmt.Errorf("error creating job: %w", err)
+	}
+	if created {
+		// A job that doesn't fit in the queue stays queued in the database and is picked up by RequeueStaleJobs
+		if err := s.queue.Enqueue(queue.Task{JobID: job.ID, Type: job.Type}); err != nil {
+			log.Printf("job %d (%s) could not be queued, will retry: %v", job.ID, job.Type, err)
+		}
+	}
+	return jobToResponse(job), nil
+}
+
+// GetJob returns the status of a job the user requested.
+func (s *jobService) GetJob(id, ownerID uint) (*response.JobResponse, error) {
+	job, err := s.ownedJob(id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return jobToResponse(job), nil
+}
+
+// GetJobResult returns the file produced by a job the user requested, once it succeeded.
+func (s *jobService) GetJobResult(id, ownerID uint) (*JobResult, error) {
+	job, err := s.ownedJob(id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	switch job.Status {
+	case enums.JobSucceeded:
+	case enums.JobFailed:
+		return nil, fmt.Errorf("%s: %w", job.Error, ErrJobFailed)
+	default:
+		return nil, ErrJobNotFinished
+	}
+	if job.Result == nil {
+		return nil, ErrJobHasNoResult
+	}
+	return &JobResult{Data: job.Result, ContentType: job.ResultContentType, Filename: job.ResultFilename}, nil
+}
+
+func (s *jobService) ownedJob(id, ownerID uint) (*entities.Job, error) {
+	job, err := s.jobRepo.GetJobByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving job: %w", err)
+	}
+	// Jobs of other users are reported missing rather than forbidden, so their IDs can't be probed
+	if job.OwnerID == 0 || job.OwnerID != ownerID {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// RunJob runs the job of a task delivered by the queue. Tasks for jobs that are no longer queued,
+// e.g. delivered twice, are ignored. A job that fails is retried a few minutes later, up to maxJobAttempts times.
+func (s *jobService) RunJob(task queue.Task) {
+	job, err := s.jobRepo.ClaimJob(task.JobID, s.clock.Now())
+	if err != nil {
+		log.Printf("error claiming job %d: %v", task.JobID, err)
+		return
+	}
+	if job == nil {
+		return
+	}
+
+	result, runErr := s.run(job)
+	if runErr != nil && job.Attempts < maxJobAttempts && !errors.Is(runErr, errUnknownJobType) {
+		// Put back in the queue without a task, so RequeueStaleJobs retries it after jobRequeueDelay
+		log.Printf("job %d (%s) failed, will retry: %v", job.ID, job.Type, runErr)
+		if err := s.jobRepo.RequeueJob(job.ID); err != nil {
+			log.Printf("error requeuing job %d: %v", job.ID, err)
+		}
+		return
+	}
+
+	now := s.clock.Now()
+	job.FinishedAt = &now
+	if runErr != nil {
+		log.Printf("job %d (%s) failed: %v", job.ID, job.Type, runErr)
+		job.Status = enums.JobFailed
+		job.Error = runErr.Error()
+	} else {
+		job.Status = enums.JobSucceeded
+		job.Error = ""
+		if result != nil {
+			job.Result, job.ResultContentType, job.ResultFilename = result.Data, result.ContentType, result.Filename
+		}
+	}
+	if err := s.jobRepo.FinishJob(job); err != nil {
+		log.Printf("error saving job %d: %v", job.ID, err)
+	}
+}
+
+var errUnknownJobType = errors.New("unknown job type")
+
+// run calls the handler of the job, turning a panic into an error so the job is marked failed.
+func (s *jobService) run(job *entities.Job) (result *JobResult, err error) {
+	handler, ok := s.handler(job.Type)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", job.Type, errUnknownJobType)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(json.RawMessage(job.Payload))
+}
+
+func (s *jobService) requeue(job *entities.Job) {
+	if err := s.jobRepo.RequeueJob(job.ID); err != nil {
+		log.Printf("error requeuing job %d: %v", job.ID, err)
+		return
+	}
+	if err := s.queue.Enqueue(queue.Task{JobID: job.ID, Type: job.Type}); err != nil {
+		log.Printf("job %d (%s) could not be queued, will retry: %v", job.ID, job.Type, err)
+	}
+}
+
+// RequeueStaleJobs queues again the jobs whose task was lost, e.g. because the queue was full or
+// the API restarted before a worker got to them, and the ones whose worker stopped while running
+// them. Jobs that used up their attempts are marked failed instead.
+func (s *jobService) RequeueStaleJobs() error {
+	now := s.clock.Now()
+	jobs, err := s.jobRepo.GetStaleJobs(now.Add(-jobRequeueDelay), now.Add(-jobTimeout))
+	if err != nil {
+		return fmt.Errorf("error retrieving stale jobs: %w", err)
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		switch {
+		case job.Status == enums.JobQueued:
+			if err := s.queue.Enqueue(queue.Task{JobID: job.ID, Type: job.Type}); err != nil {
+				return fmt.Errorf("error queuing job %d: %w", job.ID, err)
+			}
+		case job.Attempts < maxJobAttempts:
+			s.requeue(job)
+		default:
+			job.Status = enums.JobFailed
+			job.Error = "job timed out"
+			job.FinishedAt = &now
+			if err := s.jobRepo.FinishJob(job); err != nil {
+				return fmt.Errorf("error saving job %d: %w", job.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// PurgeFinishedJobs deletes the jobs that finished more than jobRetention ago, along with their results.
+func (s *jobService) PurgeFinishedJobs() error {
+	if _, err := s.jobRepo.DeleteFinishedJobs(s.clock.Now().Add(-jobRetention)); err != nil {
+		return fmt.Errorf("error purging jobs: %w", err)
+	}
+	return nil
+}
+
+func jobToResponse(job *entities.Job) *response.JobResponse {
+	return &response.JobResponse{
+		ID:         job.ID,
+		Type:       job.Type,
+		Status:     job.Status,
+		Error:      job.Error,
+		Attempts:   job.Attempts,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
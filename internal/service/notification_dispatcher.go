@@ -0,0 +1,330 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/sms"
+	"ApiRestFinance/internal/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Notification is a message about a credit account to its client, rendered from the templates of
+// its kind with Args.
+type Notification struct {
+	Kind    enums.NotificationKind
+	Account *entities.CreditAccount // With its Client and Establishment
+	Args    []interface{}
+}
+
+// notificationTemplates are the i18n keys of the email and the text of each kind of notification.
+// The subject and body of an email are formatted with the same arguments as its text. Kinds
+// without an email are only texted, and kinds without a text only emailed.
+var notificationTemplates = map[enums.NotificationKind]struct{ mail, sms string }{
+	enums.PaymentReminderNotification:     {"mail.payment_reminder", "sms.payment_reminder"},
+	enums.PaymentConfirmationNotification: {"", "sms.payment_confirmation"},
+	enums.OverdueNotification:             {"mail.overdue_notice", "sms.overdue_notice"},
+	enums.PaymentLinkNotification:         {"mail.payment_link", "sms.payment_link"},
+	enums.PaymentCodeNotification:         {"mail.payment_code", "sms.payment_code"},
+	enums.BankTransferNotification:        {"mail.bank_transfer", ""}, // Texted as a payment confirmation
+}
+
+// verifiedNotifications are the kinds only emailed to verified emails, as whoever reads them can act
+// on the client's account.
+var verifiedNotifications = map[enums.NotificationKind]bool{
+	enums.PaymentLinkNotification: true,
+}
+
+// NotificationDispatcher sends notifications to clients by email and, in the establishments that
+// turned SMS notifications on, by text to their verified phone. Texts are tracked until the SMS
+// provider reports them delivered or undelivered. Notifications are also kept for the clients to
+// read in the app.
+type NotificationDispatcher interface {
+	Dispatch(notification Notification) ([]enums.ContactChannel, error)
+	Channels(kind enums.NotificationKind, account *entities.CreditAccount) ([]enums.ContactChannel, error)
+	UpdateSMSStatus(report sms.StatusReport) error
+	GetSMSDeliveries(adminID, branchID uint) ([]response.SMSDeliveryResponse, error)
+}
+
+type notificationDispatcher struct {
+	establishmentRepo repository.EstablishmentRepository
+	creditAccountRepo repository.CreditAccountRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	deliveryRepo      repository.SMSDeliveryRepository
+	notificationRepo  repository.ClientNotificationRepository
+	mailer            mail.Sender
+	texter            sms.Sender
+	jobService        JobService
+	clock             util.Clock
+}
+
+// NewNotificationDispatcher creates a new instance of NotificationDispatcher. Clients are texted
+// a confirmation of the payments published on bus, by jobService's workers.
+func NewNotificationDispatcher(establishmentRepo repository.EstablishmentRepository, creditAccountRepo repository.CreditAccountRepository, settingsRepo repository.EstablishmentSettingsRepository, deliveryRepo repository.SMSDeliveryRepository, notificationRepo repository.ClientNotificationRepository, mailer mail.Sender, texter sms.Sender, jobService JobService, clock util.Clock, bus event.Bus) NotificationDispatcher {
+	d := &notificationDispatcher{
+		establishmentRepo: establishmentRepo,
+		creditAccountRepo: creditAccountRepo,
+		settingsRepo:      settingsRepo,
+		deliveryRepo:      deliveryRepo,
+		notificationRepo:  notificationRepo,
+		mailer:            mailer,
+		texter:            texter,
+		jobService:        jobService,
+		clock:             clock,
+	}
+	jobService.RegisterHandler(JobPaymentConfirmation, d.runPaymentConfirmationJob)
+	bus.Subscribe(event.PaymentConfirmed, d.queuePaymentConfirmation)
+	return d
+}
+
+// JobPaymentConfirmation texts the client of a credit account that their payment was received.
+const JobPaymentConfirmation = "payment_confirmation"
+
+// paymentConfirmationPayload is the input of a JobPaymentConfirmation job.
+type paymentConfirmationPayload struct {
+	CreditAccountID uint `json:"credit_account_id"`
+}
+
+// Dispatch sends a notification on every channel its kind and client allow, and returns those that
+// reached the client. It only fails when every channel tried failed, and then isn't kept, as it is
+// dispatched again; the failures of the others are logged.
+func (d *notificationDispatcher) Dispatch(notification Notification) ([]enums.ContactChannel, error) {
+	client := notification.Account.Client
+	templates := notificationTemplates[notification.Kind]
+	settings, err := d.settingsRepo.GetEstablishmentSettings(notification.Account.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	lang, ok := i18n.Parse(settings.Language)
+	if !ok {
+		lang = i18n.Default
+	}
+
+	var channels []enums.ContactChannel
+	var errs []error
+	for _, channel := range recipientChannels(notification.Kind, client, settings) {
+		switch channel {
+		case enums.ContactEmail:
+			err := d.mailer.Send(mail.Message{
+				To:      client.Email,
+				Subject: i18n.T(lang, templates.mail+".subject", notification.Args...),
+				Body:    mailBody(lang, client.Name, templates.mail+".body", notification.Args...),
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error emailing %s: %w", notification.Kind, err))
+				continue
+			}
+		case enums.ContactPhone:
+			if err := d.text(notification, settings, i18n.T(lang, templates.sms, notification.Args...)); err != nil {
+				errs = append(errs, fmt.Errorf("error texting %s: %w", notification.Kind, err))
+				continue
+			}
+		}
+		channels = append(channels, channel)
+	}
+
+	if len(channels) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		log.Printf("credit account %d: %v", notification.Account.ID, err)
+	}
+	d.keep(notification, lang)
+	return channels, nil
+}
+
+// keep keeps a notification for the client to read in the app, in the text it was texted in or the
+// subject it was emailed with. Failing to keep it doesn't fail the notification.
+func (d *notificationDispatcher) keep(notification Notification, lang i18n.Language) {
+	if notification.Account.Client == nil {
+		return
+	}
+	templates := notificationTemplates[notification.Kind]
+	message := i18n.T(lang, templates.mail+".subject", notification.Args...)
+	if templates.sms != "" {
+		message = i18n.T(lang, templates.sms, notification.Args...)
+	}
+	err := d.notificationRepo.CreateClientNotification(&entities.ClientNotification{
+		ClientID:        notification.Account.ClientID,
+		EstablishmentID: notification.Account.EstablishmentID,
+		CreditAccountID: notification.Account.ID,
+		Kind:            notification.Kind,
+		Message:         message,
+		CreatedAt:       d.clock.Now(),
+	})
+	if err != nil {
+		log.Printf("credit account %d: error keeping %s notification: %v", notification.Account.ID, notification.Kind, err)
+	}
+}
+
+// Channels returns the channels a notification of kind would be sent on to the client of account.
+func (d *notificationDispatcher) Channels(kind enums.NotificationKind, account *entities.CreditAccount) ([]enums.ContactChannel, error) {
+	settings, err := d.settingsRepo.GetEstablishmentSettings(account.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	return recipientChannels(kind, account.Client, settings), nil
+}
+
+// recipientChannels returns the channels a notification of kind reaches client on: their email,
+// if verified for the kinds that require it, and their verified phone in the establishments that
+// text notifications. Kinds only reach the channels they have a template for.
+func recipientChannels(kind enums.NotificationKind, client *entities.User, settings *entities.EstablishmentSettings) []enums.ContactChannel {
+	if client == nil {
+		return nil
+	}
+	var channels []enums.ContactChannel
+	if notificationTemplates[kind].mail != "" && client.Email != "" && (client.EmailVerifiedAt != nil || !verifiedNotifications[kind]) {
+		channels = append(channels, enums.ContactEmail)
+	}
+	if notificationTemplates[kind].sms != "" && settings.SMSNotifications && client.PhoneVerifiedAt != nil && client.Phone != "" {
+		channels = append(channels, enums.ContactPhone)
+	}
+	return channels
+}
+
+// text sends the text of a notification from the establishment's sender, recording its delivery.
+func (d *notificationDispatcher) text(notification Notification, settings *entities.EstablishmentSettings, body string) error {
+	now := d.clock.Now()
+	delivery := entities.SMSDelivery{
+		EstablishmentID: notification.Account.EstablishmentID,
+		ClientID:        notification.Account.ClientID,
+		CreditAccountID: notification.Account.ID,
+		Kind:            notification.Kind,
+		Phone:           notification.Account.Client.Phone,
+		Status:          enums.SMSQueued,
+		SentAt:          now,
+		UpdatedAt:       now,
+	}
+	// The delivery is recorded first so the provider's status reports always find it
+	if err := d.deliveryRepo.CreateSMSDelivery(&delivery); err != nil {
+		return fmt.Errorf("error recording SMS delivery: %w", err)
+	}
+
+	providerID, sendErr := d.texter.Send(sms.Message{From: settings.SMSSender, To: delivery.Phone, Body: body})
+	delivery.ProviderID = providerID
+	switch {
+	case sendErr != nil:
+		delivery.Status = enums.SMSFailed
+		delivery.Error = sendErr.Error()
+	case providerID == "":
+		// Senders without status reports, like the log, are done with the text once they take it
+		delivery.Status = enums.SMSSent
+	}
+	delivery.UpdatedAt = d.clock.Now()
+	if err := d.deliveryRepo.UpdateSMSDelivery(&delivery); err != nil {
+		log.Printf("SMS delivery %d could not be updated: %v", delivery.ID, err)
+	}
+	return sendErr
+}
+
+// queuePaymentConfirmation queues the confirmation of a payment to the client, when the account's
+// establishment texts its notifications.
+func (d *notificationDispatcher) queuePaymentConfirmation(evt event.Event) {
+	account, err := d.creditAccountRepo.GetCreditAccountByID(evt.CreditAccountID)
+	if err == nil {
+		var settings *entities.EstablishmentSettings
+		settings, err = d.settingsRepo.GetEstablishmentSettings(account.EstablishmentID)
+		if err != nil || !settings.SMSNotifications {
+			return
+		}
+		_, err = d.jobService.EnqueueJob(JobPaymentConfirmation, 0, "", paymentConfirmationPayload{CreditAccountID: account.ID})
+	}
+	if err != nil {
+		log.Printf("payment confirmation of credit account %d could not be queued: %v", evt.CreditAccountID, err)
+	}
+}
+
+func (d *notificationDispatcher) runPaymentConfirmationJob(data json.RawMessage) (*JobResult, error) {
+	var payload paymentConfirmationPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("error decoding job payload: %w", err)
+	}
+	account, err := d.creditAccountRepo.GetCreditAccountByID(payload.CreditAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+
+	establishmentName := ""
+	if account.Establishment != nil {
+		establishmentName = account.Establishment.Name
+	}
+	_, err = d.Dispatch(Notification{
+		Kind:    enums.PaymentConfirmationNotification,
+		Account: account,
+		Args:    []interface{}{establishmentName, roundCurrency(account.CurrentBalance - account.AccountCredit)},
+	})
+	return nil, err
+}
+
+// UpdateSMSStatus records the delivery status the SMS provider reported for a text. Reports of
+// texts the API didn't send, or with statuses it doesn't track, are ignored.
+func (d *notificationDispatcher) UpdateSMSStatus(report sms.StatusReport) error {
+	status, ok := smsStatuses[report.Status]
+	if !ok || report.MessageID == "" {
+		return nil
+	}
+	deliveryError := ""
+	if status == enums.SMSUndelivered || status == enums.SMSFailed {
+		deliveryError = report.ErrorCode
+	}
+	if err := d.deliveryRepo.UpdateSMSDeliveryStatus(report.MessageID, status, deliveryError, d.clock.Now()); err != nil {
+		return fmt.Errorf("error updating SMS delivery: %w", err)
+	}
+	return nil
+}
+
+// smsStatuses maps the message statuses of Twilio to the statuses of SMS deliveries.
+var smsStatuses = map[string]enums.SMSStatus{
+	"accepted":    enums.SMSQueued,
+	"scheduled":   enums.SMSQueued,
+	"queued":      enums.SMSQueued,
+	"sending":     enums.SMSQueued,
+	"sent":        enums.SMSSent,
+	"delivered":   enums.SMSDelivered,
+	"read":        enums.SMSDelivered,
+	"undelivered": enums.SMSUndelivered,
+	"failed":      enums.SMSFailed,
+	"canceled":    enums.SMSFailed,
+}
+
+// GetSMSDeliveries lists the notifications texted to the clients of the admin's establishment,
+// newest first, including failed attempts.
+func (d *notificationDispatcher) GetSMSDeliveries(adminID, branchID uint) ([]response.SMSDeliveryResponse, error) {
+	establishment, err := adminEstablishment(d.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := d.deliveryRepo.GetSMSDeliveriesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving SMS deliveries: %w", err)
+	}
+
+	return smsDeliveriesToResponse(deliveries), nil
+}
+
+func smsDeliveriesToResponse(deliveries []entities.SMSDelivery) []response.SMSDeliveryResponse {
+	responses := make([]response.SMSDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, response.SMSDeliveryResponse{
+			ID:              delivery.ID,
+			CreditAccountID: delivery.CreditAccountID,
+			ClientID:        delivery.ClientID,
+			EstablishmentID: delivery.EstablishmentID,
+			Kind:            delivery.Kind,
+			Phone:           delivery.Phone,
+			Status:          delivery.Status,
+			Error:           delivery.Error,
+			SentAt:          delivery.SentAt,
+			UpdatedAt:       delivery.UpdatedAt,
+		})
+	}
+	return responses
+}
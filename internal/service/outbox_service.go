@@ -0,0 +1,110 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	outboxBatchSize     = 100
+	outboxMaxAttempts   = 10
+	outboxRetryDelay    = 30 * time.Second
+	outboxMaxRetryDelay = 6 * time.Hour
+	outboxRetention     = 7 * 24 * time.Hour
+)
+
+// EventPublisher delivers the events relayed from the outbox outside the API, e.g. to webhooks or a
+// queue. The payload of the events it receives is a json.RawMessage.
+type EventPublisher interface {
+	PublishEvent(id uint, evt event.Event) error
+}
+
+// OutboxService relays the events written to the outbox to the publishers.
+type OutboxService interface {
+	RelayEvents() error
+	PurgeDeliveredEvents() error
+}
+
+type outboxService struct {
+	outboxRepo repository.OutboxRepository
+	publishers []EventPublisher
+	clock      util.Clock
+}
+
+// NewOutboxService creates a new instance of OutboxService. Without publishers, events are marked
+// delivered as nothing is waiting for them.
+func NewOutboxService(outboxRepo repository.OutboxRepository, publishers []EventPublisher, clock util.Clock) OutboxService {
+	return &outboxService{outboxRepo: outboxRepo, publishers: publishers, clock: clock}
+}
+
+// RelayEvents publishes the pending events, oldest first, until none is left. Events a publisher
+// rejects are retried with exponential backoff and given up on after outboxMaxAttempts; delivery is
+// at least once, so a retried event may reach a publisher that already accepted it.
+func (s *outboxService) RelayEvents() error {
+	for {
+		count, err := s.outboxRepo.RelayPendingEvents(s.clock.Now(), outboxMaxAttempts, outboxBatchSize, s.relay)
+		if err != nil {
+			return fmt.Errorf("error relaying outbox events: %w", err)
+		}
+		if count < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *outboxService) relay(events []entities.OutboxEvent) {
+	for i := range events {
+		outboxEvent := &events[i]
+		now := s.clock.Now()
+		outboxEvent.Attempts++
+
+		if err := s.publish(outboxEvent); err != nil {
+			outboxEvent.LastError = err.Error()
+			outboxEvent.NextAttemptAt = now.Add(retryBackoff(outboxEvent.Attempts, outboxRetryDelay, outboxMaxRetryDelay))
+			if outboxEvent.Attempts >= outboxMaxAttempts {
+				log.Printf("giving up on outbox event %d (%s) after %d attempts: %v", outboxEvent.ID, outboxEvent.Name, outboxEvent.Attempts, err)
+			}
+			continue
+		}
+		outboxEvent.DeliveredAt = &now
+		outboxEvent.LastError = ""
+	}
+}
+
+func (s *outboxService) publish(outboxEvent *entities.OutboxEvent) error {
+	evt := event.Event{
+		Name:            event.Name(outboxEvent.Name),
+		CreditAccountID: outboxEvent.CreditAccountID,
+		OccurredAt:      outboxEvent.OccurredAt,
+		Payload:         json.RawMessage(outboxEvent.Payload),
+	}
+	for _, publisher := range s.publishers {
+		if err := publisher.PublishEvent(outboxEvent.ID, evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retryBackoff returns how long to wait before the attempt after the given one, doubling delay after
+// every attempt up to maxDelay.
+func retryBackoff(attempts int, delay, maxDelay time.Duration) time.Duration {
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// PurgeDeliveredEvents deletes the events delivered more than outboxRetention ago.
+func (s *outboxService) PurgeDeliveredEvents() error {
+	if _, err := s.outboxRepo.DeleteDeliveredEvents(s.clock.Now().Add(-outboxRetention)); err != nil {
+		return fmt.Errorf("error purging outbox events: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultPaymentLinkTTL is how long payment links last unless the admin asks for another time.
+const defaultPaymentLinkTTL = 24 * time.Hour
+
+// statementPaymentLinkTTL is how long the links printed on statements last. Statements reuse the
+// link of an earlier one for the same balance while it has statementPaymentLinkMinTTL left.
+const (
+	statementPaymentLinkTTL    = 30 * 24 * time.Hour
+	statementPaymentLinkMinTTL = 7 * 24 * time.Hour
+)
+
+// PaymentLinkService handles the links admins send to clients to pay their credit accounts. Links
+// are opened by their signed token, without logging in.
+type PaymentLinkService interface {
+	CreatePaymentLink(adminID, creditAccountID uint, req request.CreatePaymentLinkRequest, baseURL string) (*response.PaymentLinkResponse, error)
+	GetPaymentLinks(adminID, creditAccountID uint, baseURL string) ([]response.PaymentLinkResponse, error)
+	GetPaymentLink(adminID, creditAccountID, linkID uint, baseURL string) (*response.PaymentLinkResponse, error)
+	StatementPaymentLink(creditAccount *entities.CreditAccount) (*response.PaymentLinkResponse, error)
+	ViewPaymentLink(token string) (*response.PaymentLinkPageResponse, error)
+	PayPaymentLink(token string, req request.PayPaymentLinkRequest) (*response.TransactionResponse, error)
+	ExpirePaymentLinks() error
+}
+
+type paymentLinkService struct {
+	linkRepo          repository.PaymentLinkRepository
+	creditAccountRepo repository.CreditAccountRepository
+	installmentRepo   repository.InstallmentRepository
+	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	dispatcher        NotificationDispatcher
+	clock             util.Clock
+	bus               event.Bus
+	secret            []byte
+	baseURL           string
+}
+
+// NewPaymentLinkService creates a new instance of PaymentLinkService. Tokens are signed with secret,
+// and links are marked paid when the payments made through them are confirmed on bus. baseURL is
+// what the URLs of the links printed on statements start with; statements print none without it.
+func NewPaymentLinkService(linkRepo repository.PaymentLinkRepository, creditAccountRepo repository.CreditAccountRepository, installmentRepo repository.InstallmentRepository, establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository, dispatcher NotificationDispatcher, clock util.Clock, bus event.Bus, secret, baseURL string) PaymentLinkService {
+	s := &paymentLinkService{
+		linkRepo:          linkRepo,
+		creditAccountRepo: creditAccountRepo,
+		installmentRepo:   installmentRepo,
+		establishmentRepo: establishmentRepo,
+		settingsRepo:      settingsRepo,
+		dispatcher:        dispatcher,
+		clock:             clock,
+		bus:               bus,
+		secret:            []byte(secret),
+		baseURL:           baseURL,
+	}
+	bus.Subscribe(event.PaymentConfirmed, s.markPaid)
+	return s
+}
+
+// CreatePaymentLink sends the client of a credit account of one of the admin's establishments a link
+// to pay one of its unpaid installments or an amount no larger than what the account owes. Links are
+// only sent to verified contacts, so the client must have verified their email or phone.
+func (s *paymentLinkService) CreatePaymentLink(adminID, creditAccountID uint, req request.CreatePaymentLinkRequest, baseURL string) (*response.PaymentLinkResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	owed := roundCurrency(creditAccount.CurrentBalance - creditAccount.AccountCredit)
+	if owed <= 0 {
+		return nil, ErrNothingToPayOff
+	}
+
+	amount := roundCurrency(req.Amount)
+	if req.InstallmentID != nil {
+		installment, err := s.installmentRepo.GetInstallmentByID(*req.InstallmentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && installment.CreditAccountID != creditAccount.ID) {
+			return nil, ErrInstallmentNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving installment: %w", err)
+		}
+		switch installment.Status {
+		case enums.Paid:
+			return nil, ErrInstallmentPaid
+		case enums.Cancelled:
+			return nil, ErrInstallmentCancelled
+		}
+		amount = math.Min(roundCurrency(installment.Amount), owed)
+	} else if amount <= 0 || amount > owed {
+		return nil, ErrInvalidPaymentLinkAmount
+	}
+
+	channels, err := s.dispatcher.Channels(enums.PaymentLinkNotification, creditAccount)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return nil, ErrNoVerifiedContact
+	}
+
+	ttl := defaultPaymentLinkTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	now := s.clock.Now()
+	link := entities.PaymentLink{
+		CreditAccountID: creditAccount.ID,
+		EstablishmentID: creditAccount.EstablishmentID,
+		InstallmentID:   req.InstallmentID,
+		Amount:          amount,
+		Status:          enums.PaymentLinkCreated,
+		// Tokens carry the expiry in seconds, so it is stored the same way
+		ExpiresAt:   now.Add(ttl).Truncate(time.Second),
+		CreatedByID: adminID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.linkRepo.CreatePaymentLink(&link); err != nil {
+		return nil, fmt.Errorf("error creating payment link: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.PaymentLinkCreated, creditAccount.ID)
+
+	linkResponse := s.linkToResponse(&link, creditAccount.ClientID, baseURL)
+	establishmentName := ""
+	if creditAccount.Establishment != nil {
+		establishmentName = creditAccount.Establishment.Name
+	}
+	// The link is kept when it can't be sent, the admin can share its URL
+	linkResponse.SentTo, err = s.dispatcher.Dispatch(Notification{
+		Kind:    enums.PaymentLinkNotification,
+		Account: creditAccount,
+		Args:    []interface{}{amount, establishmentName, linkResponse.URL, link.ExpiresAt.In(accountLocation(creditAccount)).Format("2006-01-02 15:04")},
+	})
+	if err != nil {
+		log.Printf("payment link %d could not be sent: %v", link.ID, err)
+	}
+	return linkResponse, nil
+}
+
+// GetPaymentLinks retrieves the payment links of a credit account of one of the admin's
+// establishments, newest first.
+func (s *paymentLinkService) GetPaymentLinks(adminID, creditAccountID uint, baseURL string) ([]response.PaymentLinkResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.linkRepo.GetPaymentLinksByCreditAccountID(creditAccount.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving payment links: %w", err)
+	}
+
+	linkResponses := make([]response.PaymentLinkResponse, 0, len(links))
+	for i := range links {
+		linkResponses = append(linkResponses, *s.linkToResponse(&links[i], creditAccount.ClientID, baseURL))
+	}
+	return linkResponses, nil
+}
+
+// GetPaymentLink retrieves a payment link of a credit account of one of the admin's establishments.
+func (s *paymentLinkService) GetPaymentLink(adminID, creditAccountID, linkID uint, baseURL string) (*response.PaymentLinkResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.linkRepo.GetPaymentLinkByID(linkID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && link.CreditAccountID != creditAccount.ID) {
+		return nil, ErrPaymentLinkNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving payment link: %w", err)
+	}
+	return s.linkToResponse(link, creditAccount.ClientID, baseURL), nil
+}
+
+// StatementPaymentLink returns a link to pay the balance of a credit account, to be printed on its
+// statements, creating it unless an earlier statement's still lasts. It is nil when the account
+// owes nothing or no base URL is configured.
+func (s *paymentLinkService) StatementPaymentLink(creditAccount *entities.CreditAccount) (*response.PaymentLinkResponse, error) {
+	owed := roundCurrency(creditAccount.CurrentBalance - creditAccount.AccountCredit)
+	if s.baseURL == "" || owed <= 0 {
+		return nil, nil
+	}
+
+	now := s.clock.Now()
+	link, err := s.linkRepo.GetOpenStatementPaymentLink(creditAccount.ID, owed, now.Add(statementPaymentLinkMinTTL))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		link = &entities.PaymentLink{
+			CreditAccountID: creditAccount.ID,
+			EstablishmentID: creditAccount.EstablishmentID,
+			Amount:          owed,
+			Status:          enums.PaymentLinkCreated,
+			ExpiresAt:       now.Add(statementPaymentLinkTTL).Truncate(time.Second),
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := s.linkRepo.CreatePaymentLink(link); err != nil {
+			return nil, fmt.Errorf("error creating payment link: %w", err)
+		}
+		publishAccountEvent(s.bus, s.clock, event.PaymentLinkCreated, creditAccount.ID)
+	} else if err != nil {
+		return nil, fmt.Errorf("error retrieving payment link: %w", err)
+	}
+	return s.linkToResponse(link, creditAccount.ClientID, s.baseURL), nil
+}
+
+// ViewPaymentLink shows the client what a payment link asks them to pay, and the payment they made
+// through it if any. The first view marks the link viewed.
+func (s *paymentLinkService) ViewPaymentLink(token string) (*response.PaymentLinkPageResponse, error) {
+	link, err := s.openLink(token)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	status := s.status(link, now)
+	if status == enums.PaymentLinkCreated {
+		if err := s.linkRepo.MarkPaymentLinkViewed(link.ID, now); err != nil {
+			return nil, fmt.Errorf("error updating payment link: %w", err)
+		}
+		status = enums.PaymentLinkViewed
+	}
+
+	page := &response.PaymentLinkPageResponse{
+		InstallmentID: link.InstallmentID,
+		Amount:        link.Amount,
+		Status:        status,
+		ExpiresAt:     link.ExpiresAt,
+	}
+	if account := link.CreditAccount; account != nil {
+		if account.Establishment != nil {
+			page.EstablishmentName = account.Establishment.Name
+		}
+		if account.Client != nil {
+			page.ClientName = account.Client.Name
+		}
+	}
+	if link.Transaction != nil {
+		page.Payment = transactionToResponse(link.Transaction)
+	}
+	return page, nil
+}
+
+// PayPaymentLink makes the payment a link asks for on its credit account, pending until an admin
+// confirms it with the code in the response. The code is only returned here, to whoever paid, and
+// expires like those of the other non-cash payments. A link is only paid through once.
+func (s *paymentLinkService) PayPaymentLink(token string, req request.PayPaymentLinkRequest) (*response.TransactionResponse, error) {
+	link, err := s.openLink(token)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	switch s.status(link, now) {
+	case enums.PaymentLinkExpired:
+		return nil, ErrPaymentLinkExpired
+	case enums.PaymentLinkPaid:
+		return nil, ErrPaymentLinkUsed
+	}
+
+	establishmentID := uint(0)
+	if link.CreditAccount != nil {
+		establishmentID = link.CreditAccount.EstablishmentID
+	}
+	paymentCode, codeExpiresAt, err := newPaymentCode(s.settingsRepo, establishmentID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := entities.Transaction{
+		TransactionType: enums.Payment,
+		Amount:          link.Amount,
+		Description:     fmt.Sprintf("Payment link #%d", link.ID),
+		TransactionDate: now,
+		PaymentMethod:   req.PaymentMethod,
+		PaymentCode:     paymentCode,
+		PaymentStatus:   enums.PENDING,
+
+		PaymentCodeExpiresAt: codeExpiresAt,
+	}
+	if err := s.linkRepo.PayPaymentLink(link, &transaction); err != nil {
+		if errors.Is(err, repository.ErrPaymentLinkUsed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error processing payment: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.TransactionCreated, link.CreditAccountID)
+
+	resp := transactionToResponse(&transaction)
+	resp.PaymentCode = transaction.PaymentCode
+	return resp, nil
+}
+
+// ExpirePaymentLinks expires the payment links that weren't paid through in time.
+func (s *paymentLinkService) ExpirePaymentLinks() error {
+	links, err := s.linkRepo.ExpirePaymentLinks(s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error expiring payment links: %w", err)
+	}
+	for i := range links {
+		publishAccountEvent(s.bus, s.clock, event.PaymentLinkExpired, links[i].CreditAccountID)
+	}
+	return nil
+}
+
+// markPaid marks paid the links of the account whose payment was just confirmed.
+func (s *paymentLinkService) markPaid(evt event.Event) {
+	links, err := s.linkRepo.MarkPaymentLinksPaid(evt.CreditAccountID, s.clock.Now())
+	if err != nil {
+		log.Printf("payment links of credit account %d could not be marked paid: %v", evt.CreditAccountID, err)
+		return
+	}
+	for i := range links {
+		publishAccountEvent(s.bus, s.clock, event.PaymentLinkPaid, links[i].CreditAccountID)
+	}
+}
+
+// status returns the status of a link at now: links past their expiry are expired even before
+// ExpirePaymentLinks marks them, unless their payment is waiting for confirmation.
+func (s *paymentLinkService) status(link *entities.PaymentLink, now time.Time) enums.PaymentLinkStatus {
+	open := link.Status == enums.PaymentLinkCreated || link.Status == enums.PaymentLinkViewed
+	paymentPending := link.Transaction != nil && link.Transaction.PaymentStatus != enums.FAILED
+	if open && !paymentPending && !now.Before(link.ExpiresAt) {
+		return enums.PaymentLinkExpired
+	}
+	return link.Status
+}
+
+// token signs the ID and expiry of a link, so links can't be guessed or made to last longer.
+func (s *paymentLinkService) token(link *entities.PaymentLink) string {
+	payload := fmt.Sprintf("%d.%d", link.ID, link.ExpiresAt.Unix())
+	return payload + "." + s.sign(payload)
+}
+
+func (s *paymentLinkService) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("payment-link:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// openLink retrieves the link of a token. Tokens that weren't signed by the API, or that don't
+// match their link, are not found.
+func (s *paymentLinkService) openLink(token string) (*entities.PaymentLink, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || !hmac.Equal([]byte(parts[2]), []byte(s.sign(parts[0]+"."+parts[1]))) {
+		return nil, ErrPaymentLinkNotFound
+	}
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrPaymentLinkNotFound
+	}
+
+	link, err := s.linkRepo.GetPaymentLinkByID(uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPaymentLinkNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving payment link: %w", err)
+	}
+	if strconv.FormatInt(link.ExpiresAt.Unix(), 10) != parts[1] {
+		return nil, ErrPaymentLinkNotFound
+	}
+	return link, nil
+}
+
+func (s *paymentLinkService) adminCreditAccount(adminID, creditAccountID uint) (*entities.CreditAccount, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
+	return creditAccount, nil
+}
+
+func (s *paymentLinkService) linkToResponse(link *entities.PaymentLink, clientID uint, baseURL string) *response.PaymentLinkResponse {
+	return &response.PaymentLinkResponse{
+		ID:              link.ID,
+		CreditAccountID: link.CreditAccountID,
+		ClientID:        clientID,
+		InstallmentID:   link.InstallmentID,
+		Amount:          link.Amount,
+		Status:          link.Status,
+		URL:             baseURL + s.token(link),
+		ExpiresAt:       link.ExpiresAt,
+		ViewedAt:        link.ViewedAt,
+		TransactionID:   link.TransactionID,
+		PaidAt:          link.PaidAt,
+		CreatedByID:     link.CreatedByID,
+		CreatedAt:       link.CreatedAt,
+	}
+}
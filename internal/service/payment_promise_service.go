@@ -0,0 +1,194 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PaymentPromiseService handles the promises to pay admins negotiate with overdue clients.
+type PaymentPromiseService interface {
+	CreatePaymentPromise(adminID, creditAccountID uint, req request.CreatePaymentPromiseRequest) (*response.PaymentPromiseResponse, error)
+	GetPaymentPromises(adminID, creditAccountID uint) ([]response.PaymentPromiseResponse, error)
+	ResolveDuePaymentPromises() error
+}
+
+type paymentPromiseService struct {
+	promiseRepo       repository.PaymentPromiseRepository
+	creditAccountRepo repository.CreditAccountRepository
+	transactionRepo   repository.TransactionRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+	bus               event.Bus
+}
+
+// NewPaymentPromiseService creates a new instance of PaymentPromiseService.
+func NewPaymentPromiseService(promiseRepo repository.PaymentPromiseRepository, creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock, bus event.Bus) PaymentPromiseService {
+	return &paymentPromiseService{
+		promiseRepo:       promiseRepo,
+		creditAccountRepo: creditAccountRepo,
+		transactionRepo:   transactionRepo,
+		establishmentRepo: establishmentRepo,
+		clock:             clock,
+		bus:               bus,
+	}
+}
+
+// CreatePaymentPromise records a promise to pay of a client of one of the admin's establishments. The
+// account must owe something, the promised date can't be in the past and an account only has one
+// active promise at a time.
+func (s *paymentPromiseService) CreatePaymentPromise(adminID, creditAccountID uint, req request.CreatePaymentPromiseRequest) (*response.PaymentPromiseResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if creditAccount.CurrentBalance-creditAccount.AccountCredit <= 0 {
+		return nil, ErrNothingToPayOff
+	}
+
+	loc := accountLocation(creditAccount)
+	promisedAt, _, err := util.ParseISODate(req.Date, loc)
+	if err != nil {
+		return nil, ErrInvalidPromiseDate
+	}
+	// Promises are for a day, the one the instant falls on in the establishment
+	promisedDate := util.StartOfDayIn(promisedAt.In(loc), loc)
+	now := s.clock.Now()
+	if promisedDate.Before(util.StartOfDayIn(now.In(loc), loc)) {
+		return nil, ErrInvalidPromiseDate
+	}
+
+	if _, err := s.promiseRepo.GetActivePaymentPromise(creditAccount.ID); err == nil {
+		return nil, ErrPaymentPromiseExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error retrieving payment promises: %w", err)
+	}
+
+	promise := entities.PaymentPromise{
+		CreditAccountID: creditAccount.ID,
+		Amount:          roundCurrency(req.Amount),
+		PromisedDate:    promisedDate,
+		Status:          enums.PromiseActive,
+		CreatedByID:     adminID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.promiseRepo.CreatePaymentPromise(&promise); err != nil {
+		return nil, fmt.Errorf("error creating payment promise: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.PaymentPromiseCreated, creditAccount.ID)
+	return paymentPromiseToResponse(&promise, loc), nil
+}
+
+// GetPaymentPromises retrieves the payment promises of a credit account of one of the admin's
+// establishments, newest first.
+func (s *paymentPromiseService) GetPaymentPromises(adminID, creditAccountID uint) ([]response.PaymentPromiseResponse, error) {
+	creditAccount, err := s.adminCreditAccount(adminID, creditAccountID)
+	if err != nil {
+		return nil, err
+	}
+	promises, err := s.promiseRepo.GetPaymentPromisesByCreditAccountID(creditAccount.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving payment promises: %w", err)
+	}
+
+	loc := accountLocation(creditAccount)
+	promiseResponses := make([]response.PaymentPromiseResponse, 0, len(promises))
+	for i := range promises {
+		promiseResponses = append(promiseResponses, *paymentPromiseToResponse(&promises[i], loc))
+	}
+	return promiseResponses, nil
+}
+
+// ResolveDuePaymentPromises marks kept the active promises whose date is over when the payments made
+// from the promise through the promised date add up to the promised amount, and broken otherwise.
+func (s *paymentPromiseService) ResolveDuePaymentPromises() error {
+	now := s.clock.Now()
+	promises, err := s.promiseRepo.GetDuePaymentPromises(now)
+	if err != nil {
+		return fmt.Errorf("error retrieving payment promises: %w", err)
+	}
+
+	failed := 0
+	for i := range promises {
+		promise := &promises[i]
+		totals, err := s.transactionRepo.GetTransactionTotals(promise.CreditAccountID, promise.CreatedAt, promise.PromisedDate.AddDate(0, 0, 1))
+		if err != nil {
+			failed++
+			continue
+		}
+
+		promise.Paid = roundCurrency(totals.Payments)
+		promise.Status = enums.PromiseBroken
+		name := event.PaymentPromiseBroken
+		if promise.Paid >= promise.Amount {
+			promise.Status = enums.PromiseKept
+			name = event.PaymentPromiseKept
+		}
+		promise.ResolvedAt = &now
+		promise.UpdatedAt = now
+		resolved, err := s.promiseRepo.ResolvePaymentPromise(promise)
+		if err != nil {
+			failed++
+			continue
+		}
+		if resolved {
+			publishAccountEvent(s.bus, s.clock, name, promise.CreditAccountID)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d payment promises could not be resolved", failed)
+	}
+	return nil
+}
+
+// adminCreditAccount retrieves a credit account of one of the admin's establishments.
+func (s *paymentPromiseService) adminCreditAccount(adminID, creditAccountID uint) (*entities.CreditAccount, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
+	return creditAccount, nil
+}
+
+// lateFeesSuspended reports whether a credit account has an active promise to pay whose date hasn't
+// passed, which suspends its late fees.
+func lateFeesSuspended(promiseRepo repository.PaymentPromiseRepository, creditAccount *entities.CreditAccount, now time.Time) (bool, error) {
+	promise, err := promiseRepo.GetActivePaymentPromise(creditAccount.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error retrieving payment promises: %w", err)
+	}
+	return now.Before(promise.PromisedDate.AddDate(0, 0, 1)), nil
+}
+
+func paymentPromiseToResponse(promise *entities.PaymentPromise, loc *time.Location) *response.PaymentPromiseResponse {
+	return &response.PaymentPromiseResponse{
+		ID:              promise.ID,
+		CreditAccountID: promise.CreditAccountID,
+		Amount:          promise.Amount,
+		PromisedDate:    promise.PromisedDate.In(loc).Format("2006-01-02"),
+		Status:          promise.Status,
+		Paid:            promise.Paid,
+		CreatedByID:     promise.CreatedByID,
+		ResolvedAt:      promise.ResolvedAt,
+		CreatedAt:       promise.CreatedAt,
+	}
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentReminderService reminds clients of their due dates and notifies them of the amounts left unpaid
+// after them, on the days of the reminder schedule of their establishment.
+type PaymentReminderService interface {
+	SendReminders() error
+}
+
+type paymentReminderService struct {
+	settingsRepo      repository.EstablishmentSettingsRepository
+	creditAccountRepo repository.CreditAccountRepository
+	installmentRepo   repository.InstallmentRepository
+	reminderRepo      repository.PaymentReminderRepository
+	holidayRepo       repository.HolidayRepository
+	dispatcher        NotificationDispatcher
+	clock             util.Clock
+}
+
+// NewPaymentReminderService creates a new instance of PaymentReminderService.
+func NewPaymentReminderService(settingsRepo repository.EstablishmentSettingsRepository, creditAccountRepo repository.CreditAccountRepository, installmentRepo repository.InstallmentRepository, reminderRepo repository.PaymentReminderRepository, holidayRepo repository.HolidayRepository, dispatcher NotificationDispatcher, clock util.Clock) PaymentReminderService {
+	return &paymentReminderService{
+		settingsRepo:      settingsRepo,
+		creditAccountRepo: creditAccountRepo,
+		installmentRepo:   installmentRepo,
+		reminderRepo:      reminderRepo,
+		holidayRepo:       holidayRepo,
+		dispatcher:        dispatcher,
+		clock:             clock,
+	}
+}
+
+// SendReminders sends every client the reminders their establishment's schedule has for today: what
+// is due on a due date, on the days before it and on it, and what was left unpaid, on the days after
+// it. Due dates are those of the installments of long-term accounts and the monthly due date of
+// short-term ones, moved to a business day. It is safe to run repeatedly: each installment, or
+// monthly due date, is only reminded of once on each day of the schedule.
+func (s *paymentReminderService) SendReminders() error {
+	settings, err := s.settingsRepo.GetEstablishmentSettingsWithReminders()
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	now := s.clock.Now()
+	failed := 0
+	for i := range settings {
+		schedule := reminderSchedule(&settings[i])
+		accounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(settings[i].EstablishmentID)
+		if err != nil {
+			return fmt.Errorf("error retrieving credit accounts: %w", err)
+		}
+		days, err := businessDays(s.holidayRepo, settings[i].EstablishmentID)
+		if err != nil {
+			return err
+		}
+		for j := range accounts {
+			if err := s.remind(&accounts[j], schedule, days, now); err != nil {
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d payment reminders could not be sent", failed)
+	}
+	return nil
+}
+
+// remind sends the client of an account the reminders of the schedule for today, comparing due dates
+// by calendar day in the account's time zone.
+func (s *paymentReminderService) remind(account *entities.CreditAccount, schedule []int, days calendar.BusinessDays, now time.Time) error {
+	loc := accountLocation(account)
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	var installments []entities.Installment
+	if account.CreditType == enums.LongTerm {
+		var err error
+		installments, err = s.installmentRepo.GetInstallmentsByCreditAccountID(account.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving installments: %w", err)
+		}
+	}
+	for _, daysFromDue := range schedule {
+		dueDate := today.AddDate(0, 0, -daysFromDue)
+		if err := s.remindOf(account, installments, days, dueDate, daysFromDue, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remindOf sends the client of an account the reminder of a due date for a day of the schedule: a
+// payment reminder up to the due date and an overdue notice after it. Long-term accounts are reminded
+// of their installments due then that are still open and weren't yet on that day.
+func (s *paymentReminderService) remindOf(account *entities.CreditAccount, installments []entities.Installment, days calendar.BusinessDays, dueDate time.Time, daysFromDue int, now time.Time) error {
+	kind := enums.PaymentReminderNotification
+	if daysFromDue > 0 {
+		kind = enums.OverdueNotification
+	}
+
+	var reminders []entities.PaymentReminder
+	if account.CreditType != enums.LongTerm {
+		if !isMonthlyDueDate(days, dueDate, account.MonthlyDueDate) {
+			return nil
+		}
+		reminders = []entities.PaymentReminder{{Amount: roundCurrency(account.CurrentBalance - account.AccountCredit)}}
+	} else {
+		for _, installment := range installments {
+			if installment.Status.IsOpen() && util.StartOfDayIn(installment.DueDate.In(dueDate.Location()), dueDate.Location()).Equal(dueDate) {
+				reminders = append(reminders, entities.PaymentReminder{InstallmentID: installment.ID, Amount: installment.Amount})
+			}
+		}
+	}
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	sent, err := s.reminderRepo.GetReminders(account.ID, dueDate, daysFromDue)
+	if err != nil {
+		return fmt.Errorf("error retrieving payment reminders: %w", err)
+	}
+	reminded := make(map[uint]bool, len(sent))
+	for _, reminder := range sent {
+		if reminder.Kind == kind {
+			reminded[reminder.InstallmentID] = true
+		}
+	}
+	if reminded[0] {
+		return nil // Short-term due dates, and installments reminded of before they were recorded one by one
+	}
+	pending := reminders[:0]
+	for _, reminder := range reminders {
+		if !reminded[reminder.InstallmentID] {
+			pending = append(pending, reminder)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Reminders are of what has to be paid by the due date, notices of what was left unpaid on it
+	amount := 0.0
+	switch {
+	case account.CreditType != enums.LongTerm:
+		amount = pending[0].Amount
+	case kind == enums.PaymentReminderNotification:
+		amount = amountDue(installments, dueDate)
+	default:
+		for _, reminder := range pending {
+			amount += reminder.Amount
+		}
+		amount = roundCurrency(amount)
+	}
+	if amount <= 0 {
+		return nil
+	}
+	return s.notify(account, kind, dueDate, daysFromDue, amount, pending, now)
+}
+
+// notify sends the client a notification of a kind about the amount due on dueDate, and records it
+// for the installments, or monthly due date, it reminded of.
+func (s *paymentReminderService) notify(account *entities.CreditAccount, kind enums.NotificationKind, dueDate time.Time, daysFromDue int, amount float64, reminders []entities.PaymentReminder, now time.Time) error {
+	establishmentName := ""
+	if account.Establishment != nil {
+		establishmentName = account.Establishment.Name
+	}
+	channels, err := s.dispatcher.Dispatch(Notification{
+		Kind:    kind,
+		Account: account,
+		Args:    []interface{}{amount, establishmentName, dueDate.Format("2006-01-02")},
+	})
+	if err != nil || len(channels) == 0 {
+		return err
+	}
+
+	email := ""
+	for _, channel := range channels {
+		if channel == enums.ContactEmail {
+			email = account.Client.Email
+		}
+	}
+	for i := range reminders {
+		reminders[i].CreditAccountID, reminders[i].DueDate, reminders[i].Kind = account.ID, dueDate, kind
+		reminders[i].DaysFromDue, reminders[i].Email, reminders[i].SentAt = daysFromDue, email, now
+	}
+	return s.reminderRepo.CreateReminders(reminders)
+}
+
+// amountDue returns what the client of a long-term account has to pay by dueDate: the installments
+// still open due up to then.
+func amountDue(installments []entities.Installment, dueDate time.Time) float64 {
+	amount := 0.0
+	for _, installment := range installments {
+		if installment.Status.IsOpen() && installment.DueDate.Before(dueDate.AddDate(0, 0, 1)) {
+			amount += installment.Amount
+		}
+	}
+	return roundCurrency(amount)
+}
+
+// isMonthlyDueDate reports whether day is a monthly due date, moved to the next business day, which
+// may be in the month after it.
+func isMonthlyDueDate(days calendar.BusinessDays, day time.Time, monthlyDueDate int) bool {
+	for _, months := range []int{0, -1} {
+		if days.Next(util.AddMonthsToDueDate(day, months, monthlyDueDate, day.Location())).Equal(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// reminderSchedule returns the days from the due date an establishment sends reminders on, earliest first.
+func reminderSchedule(settings *entities.EstablishmentSettings) []int {
+	schedule := make([]int, 0)
+	if settings.ReminderSchedule == "" {
+		return schedule
+	}
+	for _, day := range strings.Split(settings.ReminderSchedule, ",") {
+		if daysFromDue, err := strconv.Atoi(day); err == nil {
+			schedule = append(schedule, daysFromDue)
+		}
+	}
+	return schedule
+}
+
+// formatReminderSchedule returns how a reminder schedule is stored: its days earliest first, once each.
+func formatReminderSchedule(schedule []int) string {
+	sorted := append([]int(nil), schedule...)
+	sort.Ints(sorted)
+	days := make([]string, 0, len(sorted))
+	for i, daysFromDue := range sorted {
+		if i == 0 || daysFromDue != sorted[i-1] {
+			days = append(days, strconv.Itoa(daysFromDue))
+		}
+	}
+	return strings.Join(days, ",")
+}
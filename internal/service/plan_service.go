@@ -0,0 +1,144 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/plan"
+	"ApiRestFinance/internal/repository"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// planNearLimit is the share of a quota from which an establishment is told it is near the limit.
+const planNearLimit = 0.8
+
+// PlanService handles the plans establishments are on and the limits that come with them.
+type PlanService interface {
+	GetPlans() []response.PlanResponse
+	GetEstablishmentPlan(adminID uint) (*response.EstablishmentPlanResponse, error)
+	AssignPlan(establishmentID uint, req request.AssignPlanRequest) (*response.EstablishmentPlanResponse, error)
+}
+
+type planService struct {
+	establishmentRepo repository.EstablishmentRepository
+}
+
+// NewPlanService creates a new instance of PlanService.
+func NewPlanService(establishmentRepo repository.EstablishmentRepository) PlanService {
+	return &planService{establishmentRepo: establishmentRepo}
+}
+
+// GetPlans lists the plans establishments can be on, from the smallest to the largest.
+func (s *planService) GetPlans() []response.PlanResponse {
+	plans := make([]response.PlanResponse, len(plan.Plans))
+	for i, limits := range plan.Plans {
+		plans[i] = planToResponse(limits)
+	}
+	return plans
+}
+
+// GetEstablishmentPlan retrieves the plan of the admin's main establishment, which its branches are
+// on too, and how much of its quotas they use.
+func (s *planService) GetEstablishmentPlan(adminID uint) (*response.EstablishmentPlanResponse, error) {
+	establishment, err := s.establishmentRepo.GetEstablishmentByAdminID(adminID)
+	if err != nil {
+		return nil, err
+	}
+	return s.establishmentPlan(establishment)
+}
+
+// AssignPlan moves a main establishment, with its branches, to a plan. Moving to a smaller plan
+// keeps what the establishment already has past the new limits, but nothing new can be added.
+func (s *planService) AssignPlan(establishmentID uint, req request.AssignPlanRequest) (*response.EstablishmentPlanResponse, error) {
+	establishment, err := s.establishmentRepo.GetEstablishmentByID(establishmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrEstablishmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	if establishment.ParentID != nil {
+		return nil, ErrPlanOfBranch
+	}
+
+	if establishment.Plan != req.Plan {
+		if err := s.establishmentRepo.SetEstablishmentPlan(establishment.ID, req.Plan); err != nil {
+			return nil, fmt.Errorf("error updating establishment: %w", err)
+		}
+		if establishment, err = s.establishmentRepo.GetEstablishmentByID(establishment.ID); err != nil {
+			return nil, fmt.Errorf("error retrieving establishment: %w", err)
+		}
+	}
+	return s.establishmentPlan(establishment)
+}
+
+func (s *planService) establishmentPlan(establishment *entities.Establishment) (*response.EstablishmentPlanResponse, error) {
+	usage, err := s.establishmentRepo.GetPlanUsage(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving plan usage: %w", err)
+	}
+
+	limits := plan.Of(establishment.Plan)
+	planResponse := &response.EstablishmentPlanResponse{
+		EstablishmentID: establishment.ID,
+		Name:            establishment.Name,
+		Plan:            planToResponse(limits),
+		Usage:           make([]response.PlanUsageResponse, 0, len(plan.Quotas)),
+		Version:         establishment.Version,
+	}
+	for _, quota := range plan.Quotas {
+		max := limits.Max(quota)
+		planResponse.Usage = append(planResponse.Usage, response.PlanUsageResponse{
+			Quota:     string(quota),
+			Used:      usage[quota],
+			Max:       max,
+			NearLimit: max > 0 && float64(usage[quota]) >= planNearLimit*float64(max),
+			AtLimit:   limits.CheckQuota(quota, usage[quota], 1) != nil,
+		})
+	}
+	return planResponse, nil
+}
+
+// establishmentLimits returns the limits of the plan of an establishment, or of its main
+// establishment for a branch.
+func establishmentLimits(establishmentRepo repository.EstablishmentRepository, establishment *entities.Establishment) (plan.Limits, error) {
+	if establishment.ParentID == nil {
+		return plan.Of(establishment.Plan), nil
+	}
+	main, err := establishmentRepo.GetEstablishmentByID(*establishment.ParentID)
+	if err != nil {
+		return plan.Limits{}, fmt.Errorf("error retrieving main establishment: %w", err)
+	}
+	return plan.Of(main.Plan), nil
+}
+
+// checkPlanFeature fails with ErrUpgradeRequired unless the plan of an establishment includes a feature.
+func checkPlanFeature(establishmentRepo repository.EstablishmentRepository, establishmentID uint, feature enums.PlanFeature) error {
+	establishment, err := establishmentRepo.GetEstablishmentByID(establishmentID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	limits, err := establishmentLimits(establishmentRepo, establishment)
+	if err != nil {
+		return err
+	}
+	return limits.CheckFeature(feature)
+}
+
+func planToResponse(limits plan.Limits) response.PlanResponse {
+	features := limits.Features
+	if features == nil {
+		features = []enums.PlanFeature{}
+	}
+	return response.PlanResponse{
+		Plan:        limits.Plan,
+		MaxClients:  limits.MaxClients,
+		MaxProducts: limits.MaxProducts,
+		MaxBranches: limits.MaxBranches,
+		Features:    features,
+	}
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// platformMetricsDays is the period, up to now, the activity of the platform metrics covers.
+	platformMetricsDays = 30
+	// temporaryPasswordLength is the length of the passwords generated for admins and super-admins.
+	temporaryPasswordLength = 16
+	// temporaryPasswordAlphabet leaves out characters that are easily mistaken for one another.
+	temporaryPasswordAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// PlatformService handles what super-admins do across the establishments of the platform, and audits
+// the requests made to the platform endpoints.
+type PlatformService interface {
+	StartPlatformRequest(actorID uint, role, method, route, path, ip, userAgent string) (uint, error)
+	CompletePlatformRequest(entryID uint, status int)
+	GetEstablishments(req request.SearchPlatformEstablishmentsRequest) ([]response.PlatformEstablishmentResponse, error)
+	SuspendEstablishment(establishmentID uint, req request.SuspendEstablishmentRequest) (*response.PlatformEstablishmentResponse, error)
+	ReinstateEstablishment(establishmentID uint) (*response.PlatformEstablishmentResponse, error)
+	ResetAdminPassword(establishmentID uint) error
+	GetMetrics() (*response.PlatformMetricsResponse, error)
+	GetAuditLog(req request.SearchPlatformAuditLogRequest) ([]response.PlatformAuditEntryResponse, error)
+	CreateSuperAdmin(dni, name, email string) (string, error)
+}
+
+type platformService struct {
+	platformRepo      repository.PlatformRepository
+	establishmentRepo repository.EstablishmentRepository
+	userRepo          repository.UserRepository
+	sessionRepo       repository.SessionRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	mailer            mail.Sender
+	clock             util.Clock
+}
+
+// NewPlatformService creates a new instance of PlatformService. Temporary passwords of admins are
+// emailed with mailer.
+func NewPlatformService(platformRepo repository.PlatformRepository, establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository, sessionRepo repository.SessionRepository, settingsRepo repository.EstablishmentSettingsRepository, mailer mail.Sender, clock util.Clock) PlatformService {
+	return &platformService{
+		platformRepo:      platformRepo,
+		establishmentRepo: establishmentRepo,
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		settingsRepo:      settingsRepo,
+		mailer:            mailer,
+		clock:             clock,
+	}
+}
+
+// StartPlatformRequest records the audit entry of a request to the platform endpoints, before it is
+// handled, and returns the entry to complete.
+func (s *platformService) StartPlatformRequest(actorID uint, role, method, route, path, ip, userAgent string) (uint, error) {
+	entry := &entities.PlatformAuditEntry{
+		ActorID:   actorID,
+		ActorRole: role,
+		Method:    method,
+		Route:     route,
+		Path:      path,
+		IP:        ip,
+		UserAgent: userAgent,
+		CreatedAt: s.clock.Now(),
+	}
+	if err := s.platformRepo.CreatePlatformAuditEntry(entry); err != nil {
+		return 0, fmt.Errorf("error creating platform audit entry: %w", err)
+	}
+	return entry.ID, nil
+}
+
+// CompletePlatformRequest records the status a request to the platform endpoints was answered with.
+// The request was already answered, so failures are only logged and the entry stays incomplete.
+func (s *platformService) CompletePlatformRequest(entryID uint, status int) {
+	if err := s.platformRepo.CompletePlatformAuditEntry(entryID, status, s.clock.Now()); err != nil {
+		log.Printf("Error completing platform audit entry %d with status %d: %v", entryID, status, err)
+	}
+}
+
+// GetEstablishments lists the main establishments with their admins, oldest first, optionally
+// searched and filtered by status.
+func (s *platformService) GetEstablishments(req request.SearchPlatformEstablishmentsRequest) ([]response.PlatformEstablishmentResponse, error) {
+	establishments, err := s.platformRepo.GetPlatformEstablishments(strings.TrimSpace(req.Query))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishments: %w", err)
+	}
+	establishmentResponses := make([]response.PlatformEstablishmentResponse, 0, len(establishments))
+	for i := range establishments {
+		establishmentResponse := platformEstablishmentToResponse(&establishments[i])
+		if req.Status == "" || establishmentResponse.Status == req.Status {
+			establishmentResponses = append(establishmentResponses, establishmentResponse)
+		}
+	}
+	return establishmentResponses, nil
+}
+
+// SuspendEstablishment suspends a main establishment, with its branches, and logs its admin out of
+// every session. Until it is reinstated, the admin can't log in and the establishment takes no
+// purchases or new clients.
+func (s *platformService) SuspendEstablishment(establishmentID uint, req request.SuspendEstablishmentRequest) (*response.PlatformEstablishmentResponse, error) {
+	establishment, err := s.mainEstablishment(establishmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	changed, err := s.establishmentRepo.SetEstablishmentSuspended(establishment.ID, &now, strings.TrimSpace(req.Reason))
+	if err != nil {
+		return nil, fmt.Errorf("error suspending establishment: %w", err)
+	}
+	if !changed {
+		return nil, ErrAlreadySuspended
+	}
+	if err := s.sessionRepo.RevokeUserSessions(establishment.AdminID, 0, now); err != nil {
+		return nil, fmt.Errorf("error revoking sessions: %w", err)
+	}
+	return s.platformEstablishment(establishment.ID)
+}
+
+// ReinstateEstablishment lifts the suspension of a main establishment, with its branches.
+func (s *platformService) ReinstateEstablishment(establishmentID uint) (*response.PlatformEstablishmentResponse, error) {
+	establishment, err := s.mainEstablishment(establishmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := s.establishmentRepo.SetEstablishmentSuspended(establishment.ID, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("error reinstating establishment: %w", err)
+	}
+	if !changed {
+		return nil, ErrNotSuspended
+	}
+	return s.platformEstablishment(establishment.ID)
+}
+
+// ResetAdminPassword gives the admin of a main establishment a new temporary password, emailed to them
+// and never returned, and logs them out of every session.
+func (s *platformService) ResetAdminPassword(establishmentID uint) error {
+	establishment, err := s.mainEstablishment(establishmentID)
+	if err != nil {
+		return err
+	}
+	admin, err := s.userRepo.GetUserByID(establishment.AdminID)
+	if err != nil {
+		return fmt.Errorf("error retrieving admin: %w", err)
+	}
+
+	password, hash, err := generateTemporaryPassword()
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdatePassword(admin.ID, hash); err != nil {
+		return fmt.Errorf("error updating password: %w", err)
+	}
+	if err := s.sessionRepo.RevokeUserSessions(admin.ID, 0, s.clock.Now()); err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+
+	lang := establishmentLanguage(s.settingsRepo, establishment.ID)
+	err = s.mailer.Send(mail.Message{
+		To:      admin.Email,
+		Subject: i18n.T(lang, "mail.admin_password_reset.subject", establishment.Name),
+		Body:    mailBody(lang, admin.Name, "mail.admin_password_reset.body", establishment.Name, password),
+	})
+	if err != nil {
+		// The password was changed all the same, resetting it again sends a new one
+		return fmt.Errorf("error emailing temporary password: %w", err)
+	}
+	return nil
+}
+
+// GetMetrics adds up the establishments, users and credit accounts of the platform, and their
+// activity over the last platformMetricsDays days.
+func (s *platformService) GetMetrics() (*response.PlatformMetricsResponse, error) {
+	now := s.clock.Now()
+	metrics, err := s.platformRepo.GetPlatformMetrics(now.AddDate(0, 0, -platformMetricsDays))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving platform metrics: %w", err)
+	}
+	return &response.PlatformMetricsResponse{
+		Establishments:          metrics.Establishments,
+		ActiveEstablishments:    metrics.ActiveEstablishments,
+		InactiveEstablishments:  metrics.Establishments - metrics.ActiveEstablishments - metrics.SuspendedEstablishments,
+		SuspendedEstablishments: metrics.SuspendedEstablishments,
+		Branches:                metrics.Branches,
+		EstablishmentsByPlan:    metrics.EstablishmentsByPlan,
+		Admins:                  metrics.Admins,
+		Clients:                 metrics.Clients,
+		OpenCreditAccounts:      metrics.OpenCreditAccounts,
+		Receivables:             roundCurrency(metrics.Receivables),
+		WrittenOffBalance:       roundCurrency(metrics.WrittenOffBalance),
+		PeriodDays:              platformMetricsDays,
+		Purchases:               metrics.Purchases,
+		PurchasesAmount:         roundCurrency(metrics.PurchasesAmount),
+		Payments:                metrics.Payments,
+		PaymentsAmount:          roundCurrency(metrics.PaymentsAmount),
+		NewCreditAccounts:       metrics.NewCreditAccounts,
+		GeneratedAt:             now,
+	}, nil
+}
+
+// GetAuditLog lists the latest requests made to the platform endpoints, newest first.
+func (s *platformService) GetAuditLog(req request.SearchPlatformAuditLogRequest) ([]response.PlatformAuditEntryResponse, error) {
+	startDate, endDate, err := util.ParseDateRange(req.StartDate, req.EndDate, "", s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.platformRepo.GetPlatformAuditEntries(repository.PlatformAuditFilter{ActorID: req.ActorID, StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving platform audit log: %w", err)
+	}
+	entryResponses := make([]response.PlatformAuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		entryResponses[i] = response.PlatformAuditEntryResponse{
+			ID:          entry.ID,
+			ActorID:     entry.ActorID,
+			ActorRole:   entry.ActorRole,
+			Method:      entry.Method,
+			Route:       entry.Route,
+			Path:        entry.Path,
+			Status:      entry.Status,
+			IP:          entry.IP,
+			UserAgent:   entry.UserAgent,
+			CreatedAt:   entry.CreatedAt,
+			CompletedAt: entry.CompletedAt,
+		}
+	}
+	return entryResponses, nil
+}
+
+// CreateSuperAdmin creates a super-admin with a generated password, which it returns to be handed over
+// once. Super-admins have to set up two-factor authentication the first time they log in.
+func (s *platformService) CreateSuperAdmin(dni, name, email string) (string, error) {
+	password, hash, err := generateTemporaryPassword()
+	if err != nil {
+		return "", err
+	}
+	now := s.clock.Now()
+	user := &entities.User{
+		DNI:             dni,
+		Email:           strings.TrimSpace(email),
+		Password:        hash,
+		Name:            name,
+		Rol:             enums.SUPERADMIN,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.userRepo.CreateUser(user); err != nil {
+		return "", fmt.Errorf("error creating super-admin: %w", err)
+	}
+	return password, nil
+}
+
+// mainEstablishment retrieves an establishment the platform acts on, which must be a main establishment.
+func (s *platformService) mainEstablishment(establishmentID uint) (*entities.Establishment, error) {
+	establishment, err := s.establishmentRepo.GetEstablishmentByID(establishmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrEstablishmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	if establishment.ParentID != nil {
+		return nil, ErrPlatformBranch
+	}
+	return establishment, nil
+}
+
+// platformEstablishment retrieves a main establishment as super-admins see it.
+func (s *platformService) platformEstablishment(establishmentID uint) (*response.PlatformEstablishmentResponse, error) {
+	establishment, err := s.platformRepo.GetPlatformEstablishment(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	establishmentResponse := platformEstablishmentToResponse(establishment)
+	return &establishmentResponse, nil
+}
+
+func platformEstablishmentToResponse(establishment *repository.PlatformEstablishment) response.PlatformEstablishmentResponse {
+	status := "ACTIVE"
+	if establishment.SuspendedAt != nil {
+		status = "SUSPENDED"
+	} else if !establishment.IsActive {
+		status = "INACTIVE"
+	}
+	establishmentResponse := response.PlatformEstablishmentResponse{
+		ID:                 establishment.ID,
+		Name:               establishment.Name,
+		RUC:                establishment.RUC,
+		Status:             status,
+		Plan:               establishment.Plan,
+		AdminID:            establishment.AdminID,
+		Branches:           establishment.Branches,
+		OpenCreditAccounts: establishment.Clients,
+		SuspendedAt:        establishment.SuspendedAt,
+		SuspensionReason:   establishment.SuspensionReason,
+		CreatedAt:          establishment.CreatedAt,
+		Version:            establishment.Version,
+	}
+	if establishment.Admin != nil {
+		establishmentResponse.AdminName = establishment.Admin.Name
+		establishmentResponse.AdminEmail = establishment.Admin.Email
+	}
+	return establishmentResponse
+}
+
+// generateTemporaryPassword returns a new random password and its bcrypt hash.
+func generateTemporaryPassword() (string, string, error) {
+	var password strings.Builder
+	max := big.NewInt(int64(len(temporaryPasswordAlphabet)))
+	for i := 0; i < temporaryPasswordLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", "", fmt.Errorf("error generating password: %w", err)
+		}
+		password.WriteByte(temporaryPasswordAlphabet[n.Int64()])
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password.String()), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", fmt.Errorf("error hashing password: %w", err)
+	}
+	return password.String(), string(hash), nil
+}
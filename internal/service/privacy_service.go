@@ -0,0 +1,374 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// anonymizedName replaces the name of anonymized clients, so the records kept about them still read
+// sensibly in reports.
+const anonymizedName = "Anonymized client"
+
+// RetentionPolicy is how long the records holding personal data are kept once they served their
+// purpose, before PurgeExpiredData deletes them. Zero keeps them forever.
+type RetentionPolicy struct {
+	Deliveries     time.Duration // Logs of the statements, reminders, texts, notifications and digests sent
+	Signups        time.Duration // Self-registrations, from their approval or rejection
+	Impersonations time.Duration // Audit trail of admins impersonating clients, from its end
+	Verifications  time.Duration // Contact verification codes, from their expiry
+}
+
+// PrivacyService exports and erases the personal data held about clients, on their request, and
+// deletes what is no longer needed.
+type PrivacyService interface {
+	AnonymizeUser(userID uint, role enums.Role, targetID uint) (*response.UserResponse, error)
+	ExportUserData(userID uint, role enums.Role, branchID, targetID uint) (*response.UserDataExportResponse, error)
+	ExportUserArchive(userID uint, role enums.Role, branchID, targetID uint) ([]byte, error)
+	PurgeExpiredData() error
+}
+
+type privacyService struct {
+	privacyRepo       repository.PrivacyRepository
+	userRepo          repository.UserRepository
+	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	attachmentRepo    repository.AttachmentRepository
+	documentUploader  *DocumentUploader
+	imageUploader     *ImageUploader
+	retention         RetentionPolicy
+	clock             util.Clock
+}
+
+// NewPrivacyService creates a new instance of PrivacyService. The files of erased clients are deleted
+// through the uploaders that stored them.
+func NewPrivacyService(privacyRepo repository.PrivacyRepository, userRepo repository.UserRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, attachmentRepo repository.AttachmentRepository, documentUploader *DocumentUploader, imageUploader *ImageUploader, retention RetentionPolicy, clock util.Clock) PrivacyService {
+	return &privacyService{
+		privacyRepo:       privacyRepo,
+		userRepo:          userRepo,
+		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		attachmentRepo:    attachmentRepo,
+		documentUploader:  documentUploader,
+		imageUploader:     imageUploader,
+		retention:         retention,
+		clock:             clock,
+	}
+}
+
+// AnonymizeUser irreversibly erases the personal data of a client, on their own request or through an
+// admin of every establishment they hold an account in. Their credit accounts, transactions and
+// signed agreements are kept for the establishments' books, tied to the anonymized client, so they
+// must have settled what they owe first. Their photo and documents are deleted, but for the signed
+// agreements, which are kept until their retention ends.
+func (s *privacyService) AnonymizeUser(userID uint, role enums.Role, targetID uint) (*response.UserResponse, error) {
+	user, err := s.client(targetID)
+	if err != nil {
+		return nil, err
+	}
+	creditAccounts, err := s.creditAccountRepo.GetCreditAccountsByClientID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+
+	if role == enums.ADMIN {
+		managed := 0
+		for _, creditAccount := range creditAccounts {
+			if _, err := s.establishmentRepo.GetAdminEstablishment(userID, creditAccount.EstablishmentID); err == nil {
+				managed++
+			}
+		}
+		if managed == 0 {
+			return nil, ErrUserNotFound
+		}
+		if managed < len(creditAccounts) {
+			return nil, ErrClientHasOtherAccounts
+		}
+	} else if userID != user.ID {
+		return nil, ErrUserNotFound
+	}
+	for _, creditAccount := range creditAccounts {
+		if creditAccount.CurrentBalance > 0 || creditAccount.WrittenOffBalance > 0 {
+			return nil, ErrClientOwesBalance
+		}
+	}
+
+	anonymized := &entities.User{
+		DNI:   fmt.Sprintf("anonymized-%d", user.ID),
+		Email: fmt.Sprintf("anonymized-%d@anonymized.invalid", user.ID),
+		Name:  anonymizedName,
+	}
+	if err := s.privacyRepo.AnonymizeUser(user.ID, anonymized, s.clock.Now()); err != nil {
+		if errors.Is(err, repository.ErrUserAnonymized) {
+			return nil, ErrUserAnonymized
+		}
+		return nil, fmt.Errorf("error anonymizing user: %w", err)
+	}
+
+	// The files go once the records no longer point at them. Those that can't be deleted are logged,
+	// the attachments are purged again when their retention ends.
+	if user.PhotoUrl != "" && !strings.Contains(user.PhotoUrl, "://") {
+		if err := s.imageUploader.remove(user.PhotoUrl); err != nil {
+			log.Printf("Error deleting the photo of anonymized user %d: %v", user.ID, err)
+		}
+	}
+	for _, creditAccount := range creditAccounts {
+		attachments, err := s.attachmentRepo.GetClientAttachments(creditAccount.EstablishmentID, user.ID)
+		if err != nil {
+			log.Printf("Error retrieving the attachments of anonymized user %d: %v", user.ID, err)
+			continue
+		}
+		for _, attachment := range attachments {
+			if attachment.Kind == enums.AttachmentCreditAgreement {
+				continue
+			}
+			if err := s.documentUploader.remove(attachment.Path); err != nil {
+				log.Printf("Error deleting attachment %d of anonymized user %d: %v", attachment.ID, user.ID, err)
+				continue
+			}
+			if err := s.attachmentRepo.DeleteAttachment(attachment.ID); err != nil {
+				log.Printf("Error deleting attachment %d of anonymized user %d: %v", attachment.ID, user.ID, err)
+			}
+		}
+	}
+
+	user, err = s.userRepo.GetUserByID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	return _NewUserResponse(user), nil
+}
+
+// ExportUserData returns all the data held about a client. Clients export their own data across every
+// establishment; admins export the data of a client of their establishment (or of branch branchID)
+// concerning it.
+func (s *privacyService) ExportUserData(userID uint, role enums.Role, branchID, targetID uint) (*response.UserDataExportResponse, error) {
+	data, err := s.userData(userID, role, branchID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return s.userDataToResponse(data)
+}
+
+// ExportUserArchive returns the export of ExportUserData as a ZIP archive of data.json and the files
+// of the client's documents.
+func (s *privacyService) ExportUserArchive(userID uint, role enums.Role, branchID, targetID uint) ([]byte, error) {
+	data, err := s.userData(userID, role, branchID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	export, err := s.userDataToResponse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	file, err := archive.Create("data.json")
+	if err != nil {
+		return nil, fmt.Errorf("error creating archive: %w", err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return nil, fmt.Errorf("error encoding data: %w", err)
+	}
+	for _, attachment := range data.Attachments {
+		contents, err := s.documentUploader.read(attachment.Path)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("attachments/%d-%s", attachment.ID, filepath.Base(attachment.FileName))
+		file, err := archive.Create(name)
+		if err != nil {
+			return nil, fmt.Errorf("error creating archive: %w", err)
+		}
+		if _, err := file.Write(contents); err != nil {
+			return nil, fmt.Errorf("error creating archive: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("error creating archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// PurgeExpiredData deletes the records holding personal data whose retention period ended. Every
+// kind of record is purged even if another fails.
+func (s *privacyService) PurgeExpiredData() error {
+	now := s.clock.Now()
+	purges := []struct {
+		name      string
+		retention time.Duration
+		purge     func(before time.Time) (int64, error)
+	}{
+		{"delivery logs", s.retention.Deliveries, s.privacyRepo.DeleteDeliveryLogs},
+		{"client signups", s.retention.Signups, s.privacyRepo.DeleteDecidedSignups},
+		{"impersonations", s.retention.Impersonations, s.privacyRepo.DeleteImpersonations},
+		{"contact verifications", s.retention.Verifications, s.privacyRepo.DeleteContactVerifications},
+	}
+	var errs []error
+	for _, purge := range purges {
+		if purge.retention <= 0 {
+			continue
+		}
+		if _, err := purge.purge(now.Add(-purge.retention)); err != nil {
+			errs = append(errs, fmt.Errorf("error purging %s: %w", purge.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// client retrieves the user a privacy request is about, who must be a client.
+func (s *privacyService) client(userID uint) (*entities.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	if user.Rol != enums.CLIENT {
+		return nil, ErrUserNotClient
+	}
+	return user, nil
+}
+
+// userData gathers the data of the client targetID the user may export.
+func (s *privacyService) userData(userID uint, role enums.Role, branchID, targetID uint) (*repository.UserData, error) {
+	user, err := s.client(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var establishmentID uint
+	if role == enums.ADMIN {
+		establishment, err := adminEstablishment(s.establishmentRepo, userID, branchID)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(user.ID, establishment.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving credit account: %w", err)
+		}
+		establishmentID = establishment.ID
+	} else if userID != user.ID {
+		return nil, ErrUserNotFound
+	}
+
+	data, err := s.privacyRepo.GetUserData(user.ID, establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user data: %w", err)
+	}
+	return data, nil
+}
+
+func (s *privacyService) userDataToResponse(data *repository.UserData) (*response.UserDataExportResponse, error) {
+	export := &response.UserDataExportResponse{
+		ExportedAt:          s.clock.Now(),
+		User:                *_NewUserResponse(data.User),
+		Contact:             *contactVerificationToResponse(data.User),
+		AnonymizedAt:        data.User.AnonymizedAt,
+		CreditAccounts:      make([]response.UserDataAccountResponse, 0, len(data.CreditAccounts)),
+		ClientSignups:       make([]response.ClientSignupResponse, 0, len(data.Signups)),
+		Attachments:         attachmentsToResponse(data.Attachments),
+		StatementDeliveries: statementDeliveriesToResponse(data.StatementDeliveries),
+		PaymentReminders:    make([]response.PaymentReminderResponse, 0, len(data.PaymentReminders)),
+		SMSDeliveries:       smsDeliveriesToResponse(data.SMSDeliveries),
+		Notifications:       make([]response.ClientNotificationResponse, len(data.Notifications)),
+		Impersonations:      impersonationsToResponse(data.Impersonations, s.clock.Now()),
+	}
+
+	locations := make(map[uint]*time.Location)
+	for i := range data.CreditAccounts {
+		creditAccount := &data.CreditAccounts[i]
+		loc, ok := locations[creditAccount.EstablishmentID]
+		if !ok {
+			establishment, err := s.establishmentRepo.GetEstablishmentByID(creditAccount.EstablishmentID)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving establishment: %w", err)
+			}
+			loc = establishmentLocation(establishment)
+			locations[creditAccount.EstablishmentID] = loc
+		}
+
+		account := response.UserDataAccountResponse{
+			CreditAccount:      *creditAccountToResponseWith(s.establishmentRepo, creditAccount, CreditAccountIncludes{}),
+			Transactions:       []response.TransactionResponse{},
+			Installments:       []response.InstallmentResponse{},
+			PaymentPromises:    []response.PaymentPromiseResponse{},
+			CollectionContacts: []response.CollectionContactResponse{},
+			Activity:           []response.ActivityResponse{},
+		}
+		for j := range data.Agreements {
+			if data.Agreements[j].CreditAccountID == creditAccount.ID {
+				account.Agreement = creditAgreementToResponse(&data.Agreements[j])
+			}
+		}
+		for j := range data.Transactions {
+			if data.Transactions[j].CreditAccountID == creditAccount.ID {
+				account.Transactions = append(account.Transactions, *transactionToResponse(&data.Transactions[j]))
+			}
+		}
+		for j := range data.Installments {
+			if data.Installments[j].CreditAccountID == creditAccount.ID {
+				account.Installments = append(account.Installments, *installmentToResponse(&data.Installments[j]))
+			}
+		}
+		for j := range data.PaymentPromises {
+			if data.PaymentPromises[j].CreditAccountID == creditAccount.ID {
+				account.PaymentPromises = append(account.PaymentPromises, *paymentPromiseToResponse(&data.PaymentPromises[j], loc))
+			}
+		}
+		for j := range data.CollectionContacts {
+			if data.CollectionContacts[j].CreditAccountID == creditAccount.ID {
+				account.CollectionContacts = append(account.CollectionContacts, *collectionContactToResponse(&data.CollectionContacts[j]))
+			}
+		}
+		for j := range data.Activities {
+			if data.Activities[j].CreditAccountID == creditAccount.ID {
+				account.Activity = append(account.Activity, activityToResponse(&data.Activities[j]))
+			}
+		}
+		export.CreditAccounts = append(export.CreditAccounts, account)
+	}
+
+	for i := range data.Signups {
+		export.ClientSignups = append(export.ClientSignups, *clientSignupToResponse(&data.Signups[i]))
+	}
+	for _, reminder := range data.PaymentReminders {
+		export.PaymentReminders = append(export.PaymentReminders, response.PaymentReminderResponse{
+			CreditAccountID: reminder.CreditAccountID,
+			Kind:            reminder.Kind,
+			DueDate:         reminder.DueDate,
+			InstallmentID:   reminder.InstallmentID,
+			DaysFromDue:     reminder.DaysFromDue,
+			Email:           reminder.Email,
+			Amount:          reminder.Amount,
+			SentAt:          reminder.SentAt,
+		})
+	}
+	for i := range data.Notifications {
+		export.Notifications[i] = clientNotificationToResponse(&data.Notifications[i])
+	}
+	for i := range data.Sessions {
+		export.Sessions = append(export.Sessions, sessionToResponse(&data.Sessions[i], 0))
+	}
+	return export, nil
+}
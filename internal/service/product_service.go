@@ -4,40 +4,46 @@ import (
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities"
-	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
 	"errors"
 	"fmt"
-	"io"
 	"mime/multipart"
-	"os"
-	"path/filepath"
 	"strings"
+
+	"gorm.io/gorm"
 )
 
 // ProductService handles product-related operations.
 type ProductService interface {
 	CreateProduct(req request.CreateProductRequest) (*response.ProductResponse, error)
 	GetProductByID(id uint) (*response.ProductResponse, error)
-	GetAllProductsByEstablishmentID(establishmentID uint) ([]response.ProductResponse, error)
+	GetAllProductsByEstablishmentID(establishmentID, categoryID uint) ([]response.ProductResponse, error)
+	LookupProductByBarcode(adminID, branchID uint, barcode string) (*response.ProductResponse, error)
 	UpdateProduct(id uint, req request.UpdateProductRequest) (*response.ProductResponse, error)
+	PatchProduct(id uint, req request.PatchProductRequest) (*response.ProductResponse, error)
 	DeleteProduct(id uint) error
+	UploadProductImage(file *multipart.FileHeader, productID uint) (string, error)
 	productToResponse(product *entities.Product) *response.ProductResponse
 	NewEstablishmentResponseW(establishment *entities.Establishment) response.EstablishmentResponse
 }
 
 type productService struct {
 	productRepo       repository.ProductRepository
+	categoryRepo      repository.CategoryRepository
 	establishmentRepo repository.EstablishmentRepository
 	userRepo          repository.UserRepository
+	imageUploader     *ImageUploader
 }
 
 // NewProductService creates a new ProductService instance.
-func NewProductService(productRepo repository.ProductRepository, establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository) ProductService {
+func NewProductService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository, establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository, imageUploader *ImageUploader) ProductService {
 	return &productService{
 		productRepo:       productRepo,
+		categoryRepo:      categoryRepo,
 		establishmentRepo: establishmentRepo,
 		userRepo:          userRepo,
+		imageUploader:     imageUploader,
 	}
 }
 
@@ -51,32 +57,22 @@ func (s *productService) CreateProduct(req request.CreateProductRequest) (*respo
 		return nil, fmt.Errorf("establishment with ID %d not found", req.EstablishmentID)
 	}
 
-	// Validate Category
-	var validCategory bool
-	for _, categoryValue := range []enums.ProductCategory{
-		enums.ProductCategoryGrocery,
-		enums.ProductCategoryFruitAndVeg,
-		enums.ProductCategoryMeat,
-		enums.ProductCategoryPoultry,
-		enums.ProductCategorySeafood,
-		enums.ProductCategoryBakery,
-		enums.ProductCategoryLiquor,
-		enums.ProductCategoryGeneralStore,
-	} {
-		if categoryValue == enums.ProductCategory(req.Category) {
-			validCategory = true
-			break
-		}
+	category, err := s.establishmentCategory(establishment.ID, req.CategoryID)
+	if err != nil {
+		return nil, err
 	}
-
-	if !validCategory {
-		return nil, fmt.Errorf("invalid product category: %s", req.Category)
+	sku, barcode, err := s.productCodes(establishment.ID, 0, optionalCode(req.SKU), optionalCode(req.Barcode))
+	if err != nil {
+		return nil, err
 	}
 
 	product := entities.Product{
 		EstablishmentID: establishment.ID,
 		Name:            req.Name,
-		Category:        enums.ProductCategory(req.Category),
+		CategoryID:      &category.ID,
+		Category:        category,
+		SKU:             sku,
+		Barcode:         barcode,
 		Description:     req.Description,
 		Price:           req.Price,
 		Stock:           req.Stock,
@@ -103,9 +99,10 @@ func (s *productService) GetProductByID(id uint) (*response.ProductResponse, err
 	return s.productToResponse(product), nil
 }
 
-// GetAllProductsByEstablishmentID retrieves all products for a specific establishment.
-func (s *productService) GetAllProductsByEstablishmentID(establishmentID uint) ([]response.ProductResponse, error) {
-	products, err := s.productRepo.GetAllProductsByEstablishmentID(establishmentID)
+// GetAllProductsByEstablishmentID retrieves all products for a specific establishment, or those of
+// one of its categories unless categoryID is 0.
+func (s *productService) GetAllProductsByEstablishmentID(establishmentID, categoryID uint) ([]response.ProductResponse, error) {
+	products, err := s.productRepo.GetAllProductsByEstablishmentID(establishmentID, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -118,30 +115,59 @@ func (s *productService) GetAllProductsByEstablishmentID(establishmentID uint) (
 	return productResponses, nil
 }
 
-// UpdateProduct updates an existing product.
+// UpdateProduct updates an existing product, if it still is at the version of the request.
 func (s *productService) UpdateProduct(id uint, req request.UpdateProductRequest) (*response.ProductResponse, error) {
+	return s.PatchProduct(id, req.Patch())
+}
+
+// PatchProduct changes the fields of a product the patch sets, if it still is at the version of the patch.
+func (s *productService) PatchProduct(id uint, req request.PatchProductRequest) (*response.ProductResponse, error) {
+	if req.Version == nil {
+		return nil, ErrVersionRequired
+	}
 	product, err := s.productRepo.GetProductByID(id)
 	if err != nil {
-		return nil, errors.New("product not found")
+		return nil, ErrProductNotFound
 	}
+	product.Version = *req.Version
 
-	// Update the product fields from the request
-	if req.Name != "" {
-		product.Name = req.Name
+	if req.Name != nil {
+		product.Name = *req.Name
 	}
-	if req.Description != "" {
-		product.Description = req.Description
+	if req.CategoryID != nil {
+		category, err := s.establishmentCategory(product.EstablishmentID, *req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		product.CategoryID, product.Category = &category.ID, category
 	}
-	if req.Price > 0 {
-		product.Price = req.Price
+	if req.SKU != nil || req.Barcode != nil {
+		sku, barcode := product.SKU, product.Barcode
+		if req.SKU != nil {
+			sku = optionalCode(*req.SKU)
+		}
+		if req.Barcode != nil {
+			barcode = optionalCode(*req.Barcode)
+		}
+		if product.SKU, product.Barcode, err = s.productCodes(product.EstablishmentID, product.ID, sku, barcode); err != nil {
+			return nil, err
+		}
 	}
-	if req.Stock >= 0 {
-		product.Stock = req.Stock
+	if req.Description != nil {
+		product.Description = *req.Description
 	}
-	if req.ImageUrl != "" {
-		product.ImageUrl = req.ImageUrl
+	if req.Price != nil {
+		product.Price = *req.Price
+	}
+	if req.Stock != nil {
+		product.Stock = *req.Stock
+	}
+	if req.ImageUrl != nil {
+		product.ImageUrl = *req.ImageUrl
+	}
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
 	}
-	product.IsActive = req.IsActive
 
 	err = s.productRepo.UpdateProduct(product)
 	if err != nil {
@@ -151,80 +177,118 @@ func (s *productService) UpdateProduct(id uint, req request.UpdateProductRequest
 	return s.productToResponse(product), nil
 }
 
+// LookupProductByBarcode retrieves the product of the admin's establishment with a barcode, as
+// scanned at the point of sale.
+func (s *productService) LookupProductByBarcode(adminID, branchID uint, barcode string) (*response.ProductResponse, error) {
+	barcode = strings.TrimSpace(barcode)
+	if !validEAN13(barcode) {
+		return nil, ErrInvalidBarcode
+	}
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetProductByBarcode(establishment.ID, barcode)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving product: %w", err)
+	}
+	return s.productToResponse(product), nil
+}
+
 // DeleteProduct deletes a product.
 func (s *productService) DeleteProduct(id uint) error {
 	return s.productRepo.DeleteProduct(id)
 }
 
-// UploadProductImage uploads a product image and returns the URL.
+// UploadProductImage validates, resizes and stores a product image, then saves its URL on the product.
 func (s *productService) UploadProductImage(file *multipart.FileHeader, productID uint) (string, error) {
-	// 1. File Type Validation
-	allowedFileTypes := []string{".jpg", ".jpeg", ".png", ".gif"}
-	fileExt := strings.ToLower(filepath.Ext(file.Filename))
-	isValidFileType := false
-
-	for _, allowedType := range allowedFileTypes {
-		if fileExt == allowedType {
-			isValidFileType = true
-			break
-		}
+	product, err := s.productRepo.GetProductByID(productID)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving product: %w", err)
 	}
 
-	if !isValidFileType {
-		return "", ErrInvalidFileType
+	imagePath, err := s.imageUploader.save(file, "images_products", fmt.Sprintf("%d", productID))
+	if err != nil {
+		return "", err
 	}
 
-	// 2. File Size Validation (Example: Limit to 2MB)
-	if file.Size > 2*1024*1024 {
-		return "", ErrFileSizeTooLarge
+	product.ImageUrl = imagePath
+	if err := s.productRepo.UpdateProduct(product); err != nil {
+		return "", fmt.Errorf("error saving image URL: %w", err)
 	}
+	return imagePath, nil
+}
 
-	// 3. Create the "images_products" directory if it doesn't exist
-	imagesDir := "images_products"
-	if _, err := os.Stat(imagesDir); os.IsNotExist(err) {
-		err := os.Mkdir(imagesDir, 0755)
-		if err != nil {
-			return "", err
-		}
+// establishmentCategory retrieves a category of an establishment.
+func (s *productService) establishmentCategory(establishmentID, categoryID uint) (*entities.Category, error) {
+	category, err := s.categoryRepo.GetCategoryByID(categoryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && category.EstablishmentID != establishmentID) {
+		return nil, ErrCategoryNotFound
 	}
-
-	// 4. Generate a new filename
-	newFilename := fmt.Sprintf("%d%s", productID, fileExt)
-
-	// 5. Create the full file path
-	imagePath := filepath.Join(imagesDir, newFilename)
-
-	// 6. Open the uploaded file
-	src, err := file.Open()
 	if err != nil {
-		return "", fmt.Errorf("error opening uploaded file: %w", err)
+		return nil, fmt.Errorf("error retrieving category: %w", err)
 	}
-	defer func(src multipart.File) {
-		err := src.Close()
-		if err != nil {
-			fmt.Println("error closing uploaded file:", err)
-		}
-	}(src)
+	return category, nil
+}
 
-	// 7. Create the destination file
-	dst, err := os.Create(imagePath)
+// productCodes validates the SKU and barcode a product of an establishment will have, rejecting
+// those another of its products than productID already has.
+func (s *productService) productCodes(establishmentID, productID uint, sku, barcode *string) (*string, *string, error) {
+	if barcode != nil && !validEAN13(*barcode) {
+		return nil, nil, ErrInvalidBarcode
+	}
+	if sku == nil && barcode == nil {
+		return nil, nil, nil
+	}
+	products, err := s.productRepo.GetProductsByCodes(establishmentID, sku, barcode)
 	if err != nil {
-		return "", fmt.Errorf("error creating image file: %w", err)
+		return nil, nil, fmt.Errorf("error retrieving products: %w", err)
 	}
-	defer func(dst *os.File) {
-		err := dst.Close()
-		if err != nil {
-			fmt.Println("error closing destination file:", err)
+	for _, product := range products {
+		if product.ID == productID {
+			continue
 		}
-	}(dst)
+		if sku != nil && product.SKU != nil && *product.SKU == *sku {
+			return nil, nil, ErrSKUExists
+		}
+		return nil, nil, ErrBarcodeExists
+	}
+	return sku, barcode, nil
+}
 
-	// 8. Copy the uploaded file contents to the destination file
-	if _, err := io.Copy(dst, src); err != nil {
-		return "", fmt.Errorf("error copying image: %w", err)
+// optionalCode returns nil for a blank SKU or barcode, which products don't need to have.
+func optionalCode(code string) *string {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil
 	}
+	return &code
+}
 
-	// 9. Return the URL of the uploaded image
-	return imagePath, nil
+// validEAN13 reports whether code is an EAN-13 barcode: 13 digits, the last one being the check
+// digit of the others weighted alternately by 1 and 3.
+func validEAN13(code string) bool {
+	if len(code) != 13 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(code); i++ {
+		if code[i] < '0' || code[i] > '9' {
+			return false
+		}
+		digit := int(code[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		if i < 12 {
+			sum += digit
+		}
+	}
+	return (10-sum%10)%10 == int(code[12]-'0')
 }
 
 func (s *productService) productToResponse(product *entities.Product) *response.ProductResponse {
@@ -232,17 +296,25 @@ func (s *productService) productToResponse(product *entities.Product) *response.
 	if err != nil {
 		return nil
 	}
+	categoryName := ""
+	if product.Category != nil {
+		categoryName = product.Category.Name
+	}
 	return &response.ProductResponse{
 		ID:              product.ID,
 		EstablishmentID: product.EstablishmentID,
 		Establishment:   s.NewEstablishmentResponseW(establishment),
 		Name:            product.Name,
-		Category:        product.Category,
+		SKU:             product.SKU,
+		Barcode:         product.Barcode,
+		CategoryID:      product.CategoryID,
+		Category:        categoryName,
 		Description:     product.Description,
 		Price:           product.Price,
 		Stock:           product.Stock,
-		ImageUrl:        product.ImageUrl,
+		ImageUrl:        util.ProductImageURL(product.ImageUrl, product.Name),
 		IsActive:        product.IsActive,
+		Version:         product.Version,
 		CreatedAt:       product.CreatedAt,
 		UpdatedAt:       product.UpdatedAt,
 	}
@@ -259,37 +331,45 @@ func (s *productService) NewEstablishmentResponseW(establishment *entities.Estab
 		Email:     admin.Email,
 		Name:      admin.Name,
 		Phone:     admin.Phone,
+		PhotoUrl:  util.UserPhotoURL(admin.PhotoUrl, admin.Name),
 		Rol:       admin.Rol,
 		CreatedAt: admin.CreatedAt,
 		UpdatedAt: admin.UpdatedAt,
 	}
 
 	return response.EstablishmentResponse{
-		ID:                establishment.ID,
-		RUC:               establishment.RUC,
-		Name:              establishment.Name,
-		Phone:             establishment.Phone,
-		Address:           establishment.Address,
-		ImageUrl:          establishment.ImageUrl,
-		LateFeePercentage: establishment.LateFeePercentage,
-		IsActive:          establishment.IsActive,
-		CreatedAt:         establishment.CreatedAt,
-		UpdatedAt:         establishment.UpdatedAt,
-		Admin:             adminResponse,
-		AdminID:           adminResponse.ID,
+		ID:                             establishment.ID,
+		RUC:                            establishment.RUC,
+		Name:                           establishment.Name,
+		Phone:                          establishment.Phone,
+		Address:                        establishment.Address,
+		ImageUrl:                       establishment.ImageUrl,
+		LateFeePercentage:              establishment.LateFeePercentage,
+		Timezone:                       establishment.Timezone,
+		EarlyPaymentDiscountPercentage: establishment.EarlyPaymentDiscountPercentage,
+		IsActive:                       establishment.IsActive,
+		Version:                        establishment.Version,
+		CreatedAt:                      establishment.CreatedAt,
+		UpdatedAt:                      establishment.UpdatedAt,
+		Admin:                          adminResponse,
+		AdminID:                        adminResponse.ID,
+		ParentID:                       establishment.ParentID,
 	}
 }
 
 func NewEstablishment(establishment *entities.Establishment) entities.Establishment {
 	return entities.Establishment{
-		RUC:               establishment.RUC,
-		Name:              establishment.Name,
-		Phone:             establishment.Phone,
-		Address:           establishment.Address,
-		ImageUrl:          establishment.ImageUrl,
-		LateFeePercentage: establishment.LateFeePercentage,
-		IsActive:          establishment.IsActive,
-		CreatedAt:         establishment.CreatedAt,
-		UpdatedAt:         establishment.UpdatedAt,
+		RUC:                            establishment.RUC,
+		Name:                           establishment.Name,
+		Phone:                          establishment.Phone,
+		Address:                        establishment.Address,
+		ImageUrl:                       establishment.ImageUrl,
+		LateFeePercentage:              establishment.LateFeePercentage,
+		Timezone:                       establishment.Timezone,
+		EarlyPaymentDiscountPercentage: establishment.EarlyPaymentDiscountPercentage,
+		IsActive:                       establishment.IsActive,
+		Version:                        establishment.Version,
+		CreatedAt:                      establishment.CreatedAt,
+		UpdatedAt:                      establishment.UpdatedAt,
 	}
 }
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxPurchasePinAttempts is how many wrong PINs in a row lock the PIN
+	maxPurchasePinAttempts = 5
+	purchasePinLockout     = 30 * time.Minute
+)
+
+var purchasePinFormat = regexp.MustCompile(`^[0-9]{4,6}$`)
+
+// PurchasePinService handles the PINs clients confirm the purchases admins charge to their credit
+// accounts with, above the PIN threshold of the establishment.
+type PurchasePinService interface {
+	GetPurchasePin(userID uint) (*response.PurchasePinResponse, error)
+	SetPurchasePin(userID uint, req *request.SetPurchasePinRequest) (*response.PurchasePinResponse, error)
+	VerifyPurchasePin(userID uint, pin string) error
+}
+
+type purchasePinService struct {
+	userRepo repository.UserRepository
+	pinRepo  repository.PurchasePinRepository
+	clock    util.Clock
+}
+
+// NewPurchasePinService creates a new instance of PurchasePinService.
+func NewPurchasePinService(userRepo repository.UserRepository, pinRepo repository.PurchasePinRepository, clock util.Clock) PurchasePinService {
+	return &purchasePinService{userRepo: userRepo, pinRepo: pinRepo, clock: clock}
+}
+
+// GetPurchasePin tells whether a client set a purchase PIN and whether it is locked.
+func (s *purchasePinService) GetPurchasePin(userID uint) (*response.PurchasePinResponse, error) {
+	pin, err := s.pinRepo.GetPurchasePin(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &response.PurchasePinResponse{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving purchase PIN: %w", err)
+	}
+	return s.purchasePinToResponse(pin), nil
+}
+
+// SetPurchasePin sets the purchase PIN of a client, confirmed with their password. Setting it again
+// resets a forgotten PIN and lifts its lockout.
+func (s *purchasePinService) SetPurchasePin(userID uint, req *request.SetPurchasePinRequest) (*response.PurchasePinResponse, error) {
+	pinCode := strings.TrimSpace(req.Pin)
+	if !purchasePinFormat.MatchString(pinCode) {
+		return nil, ErrInvalidPurchasePinFormat
+	}
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, ErrIncorrectPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pinCode), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing purchase PIN: %w", err)
+	}
+	now := s.clock.Now()
+	pin := entities.PurchasePin{UserID: userID, PinHash: string(hash), CreatedAt: now, UpdatedAt: now}
+	if err := s.pinRepo.SavePurchasePin(&pin); err != nil {
+		return nil, fmt.Errorf("error saving purchase PIN: %w", err)
+	}
+	return s.purchasePinToResponse(&pin), nil
+}
+
+// VerifyPurchasePin checks the PIN a client entered to confirm a purchase. Five wrong PINs in a row
+// lock it for 30 minutes, unless the client resets it meanwhile.
+func (s *purchasePinService) VerifyPurchasePin(userID uint, pinCode string) error {
+	pin, err := s.pinRepo.GetPurchasePin(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPurchasePinNotSet
+	}
+	if err != nil {
+		return fmt.Errorf("error retrieving purchase PIN: %w", err)
+	}
+	now := s.clock.Now()
+	if pin.LockedUntil != nil && now.Before(*pin.LockedUntil) {
+		return ErrPurchasePinLocked
+	}
+	if strings.TrimSpace(pinCode) == "" {
+		return ErrPurchasePinRequired
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(pin.PinHash), []byte(strings.TrimSpace(pinCode))); err != nil {
+		if err := s.pinRepo.AddFailedPinAttempt(pin.ID, maxPurchasePinAttempts, now.Add(purchasePinLockout)); err != nil {
+			return fmt.Errorf("error recording purchase PIN attempt: %w", err)
+		}
+		return ErrInvalidPurchasePin
+	}
+	if pin.FailedAttempts > 0 {
+		if err := s.pinRepo.ClearFailedPinAttempts(pin.ID); err != nil {
+			return fmt.Errorf("error recording purchase PIN attempt: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *purchasePinService) purchasePinToResponse(pin *entities.PurchasePin) *response.PurchasePinResponse {
+	pinResponse := &response.PurchasePinResponse{IsSet: true, UpdatedAt: &pin.UpdatedAt}
+	if pin.LockedUntil != nil && s.clock.Now().Before(*pin.LockedUntil) {
+		pinResponse.LockedUntil = pin.LockedUntil
+	}
+	return pinResponse
+}
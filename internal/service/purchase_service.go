@@ -1,30 +1,53 @@
 package service
 
 import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/interest"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/qrcode"
 	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jung-kurt/gofpdf"
+	"log"
 	"math"
 	"os"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // PurchaseService handles purchase logic.
 type PurchaseService interface {
-	ProcessPurchase(userID uint, establishmentID uint, productIDs []uint, creditType enums.CreditType, amount float64) error
-	GetClientBalance(clientID uint) (float64, error)
-	GetClientOverdueBalance(clientID uint) (float64, error)
-	GetClientInstallments(clientID uint) ([]response.InstallmentResponse, error)
-	GetClientTransactions(clientID uint) ([]response.TransactionResponse, error)
-	GetClientCreditAccount(clientID uint) (*entities.CreditAccount, error)
-	GetClientAccountSummary(clientID uint) (*response.AccountSummaryResponse, error)
+	ProcessPurchase(userID uint, establishmentID uint, items []request.PurchaseItemRequest, creditType enums.CreditType, amount float64) (*response.PurchaseApprovalResponse, error)
+	GetPurchaseApprovals(adminID, branchID uint, status enums.ApprovalStatus) ([]response.PurchaseApprovalResponse, error)
+	ApprovePurchase(adminID, branchID, approvalID uint) (*response.PurchaseApprovalResponse, error)
+	RejectPurchase(adminID, branchID, approvalID uint, reason string) (*response.PurchaseApprovalResponse, error)
+	ExpirePurchaseApprovals() error
+	QuotePurchase(userID uint, establishmentID uint, items []request.PurchaseItemRequest, creditType enums.CreditType) (*response.PurchaseQuoteResponse, error)
+	RecordCashSale(adminID, branchID uint, items []request.PurchaseItemRequest) error
+	GetClientBalance(clientID, establishmentID uint) (*response.ClientBalanceResponse, error)
+	GetClientOverdueBalance(clientID, establishmentID uint) (float64, error)
+	GetClientInstallments(clientID, establishmentID uint) ([]response.InstallmentResponse, error)
+	GetClientTransactions(clientID, establishmentID uint) ([]response.TransactionResponse, error)
+	GetClientCreditAccount(clientID, establishmentID uint) (*entities.CreditAccount, error)
+	GetClientCreditAccounts(clientID uint, includes CreditAccountIncludes) ([]response.CreditAccountResponse, error)
+	GetClientAccountSummary(clientID, establishmentID uint) (*response.AccountSummaryResponse, error)
 	CalculateDueDate(account entities.CreditAccount) (time.Time, error)
-	GetClientAccountStatement(clientID uint, startDate, endDate time.Time) (*response.AccountStatementResponse, error)
-	GenerateClientAccountStatementPDF(clientID uint, startDate, endDate time.Time) ([]byte, error)
+	GetClientAccountStatement(clientID, establishmentID uint, startDate, endDate time.Time) (*response.AccountStatementResponse, error)
+	GenerateClientAccountStatementPDF(clientID, establishmentID uint, startDate, endDate time.Time, lang i18n.Language) ([]byte, error)
+	EnqueueClientAccountStatementPDF(clientID, establishmentID uint, startDate, endDate time.Time, lang i18n.Language) (*response.JobResponse, error)
+	GetPayoffQuote(clientID, establishmentID uint) (*response.PayoffQuoteResponse, error)
+	PayOff(clientID, establishmentID uint, amount float64) (*response.PayoffQuoteResponse, error)
 }
 
 type purchaseService struct {
@@ -34,105 +57,629 @@ type purchaseService struct {
 	creditAccountRepo repository.CreditAccountRepository
 	transactionRepo   repository.TransactionRepository
 	installmentRepo   repository.InstallmentRepository
+	purchaseItemRepo  repository.PurchaseItemRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	approvalRepo      repository.PurchaseApprovalRepository
+	agreementRepo     repository.CreditAgreementRepository
+	holidayRepo       repository.HolidayRepository
+	mailer            mail.Sender
+	clock             util.Clock
+	bus               event.Bus
+	summaryCache      *AccountSummaryCache
+	jobService        JobService
+	paymentLinks      PaymentLinkService
 }
 
-func NewPurchaseService(userRepo repository.UserRepository, establishmentRepo repository.EstablishmentRepository, productRepo repository.ProductRepository, creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository) PurchaseService {
-	return &purchaseService{
+func NewPurchaseService(userRepo repository.UserRepository, establishmentRepo repository.EstablishmentRepository, productRepo repository.ProductRepository, creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository, purchaseItemRepo repository.PurchaseItemRepository, settingsRepo repository.EstablishmentSettingsRepository, approvalRepo repository.PurchaseApprovalRepository, agreementRepo repository.CreditAgreementRepository, holidayRepo repository.HolidayRepository, mailer mail.Sender, clock util.Clock, bus event.Bus, summaryCache *AccountSummaryCache, jobService JobService, paymentLinks PaymentLinkService) PurchaseService {
+	s := &purchaseService{
 		userRepo:          userRepo,
 		establishmentRepo: establishmentRepo,
 		productRepo:       productRepo,
 		creditAccountRepo: creditAccountRepo,
 		transactionRepo:   transactionRepo,
 		installmentRepo:   installmentRepo,
+		purchaseItemRepo:  purchaseItemRepo,
+		settingsRepo:      settingsRepo,
+		approvalRepo:      approvalRepo,
+		agreementRepo:     agreementRepo,
+		holidayRepo:       holidayRepo,
+		mailer:            mailer,
+		clock:             clock,
+		bus:               bus,
+		summaryCache:      summaryCache,
+		jobService:        jobService,
+		paymentLinks:      paymentLinks,
 	}
+	jobService.RegisterHandler(JobAccountStatementPDF, s.runAccountStatementPDFJob)
+	return s
 }
 
-func (s *purchaseService) ProcessPurchase(userID uint, establishmentID uint, productIDs []uint, creditType enums.CreditType, amount float64) error {
-	if userID == 0 || establishmentID == 0 || len(productIDs) == 0 || amount <= 0 {
-		return errors.New("invalid input data")
+// ProcessPurchase makes a credit purchase of the client. Purchases above the approval threshold of
+// the establishment are not made but wait for an admin's approval, which is returned.
+func (s *purchaseService) ProcessPurchase(userID uint, establishmentID uint, items []request.PurchaseItemRequest, creditType enums.CreditType, amount float64) (*response.PurchaseApprovalResponse, error) {
+	if userID == 0 || establishmentID == 0 || len(items) == 0 || amount <= 0 {
+		return nil, errors.New("invalid input data")
 	}
 
 	if creditType != enums.ShortTerm && creditType != enums.LongTerm {
-		return errors.New("invalid credit type")
+		return nil, errors.New("invalid credit type")
 	}
 
 	// Get the client's credit account
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(userID)
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, userID, establishmentID)
 	if err != nil {
-		return fmt.Errorf("error retrieving credit account: %w", err)
-	}
-	if creditAccount == nil {
-		return errors.New("client does not have a credit account")
+		return nil, err
 	}
 
 	// Check if the account is blocked
 	if creditAccount.IsBlocked {
-		return errors.New("client's credit account is blocked")
+		return nil, ErrCreditAccountBlocked
+	}
+	if creditAccount.Status == enums.AccountClosed {
+		return nil, ErrCreditAccountClosed
+	}
+	if err := checkEstablishmentActive(s.establishmentRepo, creditAccount.EstablishmentID); err != nil {
+		return nil, err
+	}
+	if pending, err := agreementPending(s.agreementRepo, creditAccount); err != nil {
+		return nil, err
+	} else if pending {
+		return nil, ErrAgreementNotAccepted
+	}
+
+	if err := checkHighRiskPurchase(s.settingsRepo, creditAccount, amount); err != nil {
+		return nil, err
 	}
 
 	// Check if the purchase exceeds the credit limit
-	if creditAccount.CurrentBalance+amount > creditAccount.CreditLimit {
-		return fmt.Errorf("purchase amount exceeds credit limit (Current Balance: %.2f, Credit Limit: %.2f)", creditAccount.CurrentBalance, creditAccount.CreditLimit)
+	if creditAccount.CurrentBalance+amount-creditAccount.AccountCredit > creditAccount.CreditLimit {
+		return nil, fmt.Errorf("purchase amount exceeds credit limit (Current Balance: %.2f, Credit Limit: %.2f)", creditAccount.CurrentBalance, creditAccount.CreditLimit)
+	}
+
+	if err := s.checkSpendingLimit(creditAccount, amount); err != nil {
+		return nil, err
+	}
+
+	purchaseItems, _, err := s.buildPurchaseItems(creditAccount.EstablishmentID, items, true)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settingsRepo.GetEstablishmentSettings(creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	if settings.ApprovalThreshold > 0 && amount > settings.ApprovalThreshold {
+		return s.requestPurchaseApproval(creditAccount, items, creditType, amount, settings.ApprovalExpiryDays)
 	}
 
 	// If long-term credit, calculate and create installments
 	if creditType == enums.LongTerm {
 		err = s.createInstallments(creditAccount, amount)
 		if err != nil {
-			return fmt.Errorf("error creating installments: %w", err)
+			return nil, fmt.Errorf("error creating installments: %w", err)
 		}
 	}
 
 	// Start a transaction to ensure data consistency
-	if err := s.creditAccountRepo.ProcessPurchaseTransaction(creditAccount, amount, "Product Purchase"); err != nil {
-		return fmt.Errorf("error processing purchase: %w", err)
+	if err := s.creditAccountRepo.ProcessPurchaseTransaction(creditAccount, amount, "Product Purchase", purchaseItems); err != nil {
+		return nil, fmt.Errorf("error processing purchase: %w", err)
 	}
+	publishAccountEvent(s.bus, s.clock, event.PurchaseCreated, creditAccount.ID)
 
+	return nil, nil
+}
+
+// requestPurchaseApproval holds a purchase of the client for an admin of the establishment to
+// approve within expiryDays, and lets the admin know.
+func (s *purchaseService) requestPurchaseApproval(creditAccount *entities.CreditAccount, items []request.PurchaseItemRequest, creditType enums.CreditType, amount float64, expiryDays int) (*response.PurchaseApprovalResponse, error) {
+	lines, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding purchase items: %w", err)
+	}
+	now := s.clock.Now()
+	approval := entities.PurchaseApproval{
+		CreditAccountID: creditAccount.ID,
+		CreditAccount:   creditAccount,
+		EstablishmentID: creditAccount.EstablishmentID,
+		Amount:          amount,
+		CreditType:      creditType,
+		Items:           string(lines),
+		Status:          enums.ApprovalPending,
+		ExpiresAt:       now.AddDate(0, 0, expiryDays),
+	}
+	if err := s.approvalRepo.CreatePurchaseApproval(&approval); err != nil {
+		return nil, fmt.Errorf("error creating purchase approval: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.PurchaseApprovalRequested, creditAccount.ID)
+
+	clientName := ""
+	if creditAccount.Client != nil {
+		clientName = creditAccount.Client.Name
+	}
+	establishment, err := s.establishmentRepo.GetEstablishmentByID(creditAccount.EstablishmentID)
+	if err == nil {
+		var admin *entities.User
+		if admin, err = s.userRepo.GetUserByID(establishment.AdminID); err == nil {
+			lang := establishmentLanguage(s.settingsRepo, establishment.ID)
+			err = s.mailer.Send(mail.Message{
+				To:      admin.Email,
+				Subject: i18n.T(lang, "mail.purchase_approval.subject", establishment.Name, amount),
+				Body: mailBody(lang, admin.Name, "mail.purchase_approval.body",
+					clientName, amount, approval.ExpiresAt.In(accountLocation(creditAccount)).Format("2006-01-02 15:04")),
+			})
+		}
+	}
+	if err != nil {
+		log.Printf("admin could not be notified of purchase approval %d: %v", approval.ID, err)
+	}
+	return purchaseApprovalToResponse(&approval), nil
+}
+
+// GetPurchaseApprovals lists the purchases of the admin's establishment that needed approval, only
+// those in a status unless status is empty.
+func (s *purchaseService) GetPurchaseApprovals(adminID, branchID uint, status enums.ApprovalStatus) ([]response.PurchaseApprovalResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	approvals, err := s.approvalRepo.GetPurchaseApprovalsByEstablishmentID(establishment.ID, status)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving purchase approvals: %w", err)
+	}
+
+	approvalResponses := make([]response.PurchaseApprovalResponse, len(approvals))
+	for i := range approvals {
+		approvalResponses[i] = *purchaseApprovalToResponse(&approvals[i])
+	}
+	return approvalResponses, nil
+}
+
+// ApprovePurchase makes a purchase waiting for approval in the admin's establishment, if the account
+// can still afford it, and lets the client know.
+func (s *purchaseService) ApprovePurchase(adminID, branchID, approvalID uint) (*response.PurchaseApprovalResponse, error) {
+	approval, err := s.findPurchaseApproval(adminID, branchID, approvalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The account may have changed since the purchase was requested
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(approval.CreditAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if creditAccount.IsBlocked {
+		return nil, ErrCreditAccountBlocked
+	}
+	if creditAccount.Status == enums.AccountClosed {
+		return nil, ErrCreditAccountClosed
+	}
+	if err := checkEstablishmentActive(s.establishmentRepo, creditAccount.EstablishmentID); err != nil {
+		return nil, err
+	}
+	if err := checkHighRiskPurchase(s.settingsRepo, creditAccount, approval.Amount); err != nil {
+		return nil, err
+	}
+	if creditAccount.CurrentBalance+approval.Amount-creditAccount.AccountCredit > creditAccount.CreditLimit {
+		return nil, fmt.Errorf("current balance %.2f, credit limit %.2f: %w", creditAccount.CurrentBalance, creditAccount.CreditLimit, ErrCreditLimitExceeded)
+	}
+
+	var items []request.PurchaseItemRequest
+	if err := json.Unmarshal([]byte(approval.Items), &items); err != nil {
+		return nil, fmt.Errorf("error decoding purchase items: %w", err)
+	}
+	purchaseItems, _, err := s.buildPurchaseItems(approval.EstablishmentID, items, true)
+	if err != nil {
+		return nil, err
+	}
+	// Installments are planned before the purchase uses up any account credit, as in ProcessPurchase
+	var installments []entities.Installment
+	if approval.CreditType == enums.LongTerm {
+		if installments, err = s.planInstallments(creditAccount, approval.Amount); err != nil {
+			return nil, fmt.Errorf("error planning installments: %w", err)
+		}
+	}
+
+	if err := s.creditAccountRepo.ApprovePurchaseTransaction(approval, adminID, "Product Purchase", purchaseItems); err != nil {
+		if errors.Is(err, repository.ErrApprovalDecided) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error processing purchase: %w", err)
+	}
+	if len(installments) > 0 {
+		if err := s.installmentRepo.CreateInstallments(installments); err != nil {
+			return nil, fmt.Errorf("error creating installments: %w", err)
+		}
+	}
+	publishAccountEvent(s.bus, s.clock, event.PurchaseCreated, approval.CreditAccountID)
+	publishAccountEvent(s.bus, s.clock, event.PurchaseApproved, approval.CreditAccountID)
+
+	s.notifyPurchaseDecision(approval, "mail.purchase_approved")
+	return purchaseApprovalToResponse(approval), nil
+}
+
+// RejectPurchase rejects a purchase waiting for approval in the admin's establishment, and lets the
+// client know why.
+func (s *purchaseService) RejectPurchase(adminID, branchID, approvalID uint, reason string) (*response.PurchaseApprovalResponse, error) {
+	approval, err := s.findPurchaseApproval(adminID, branchID, approvalID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	approval.Status, approval.DecidedByID, approval.DecidedAt, approval.Reason, approval.UpdatedAt = enums.ApprovalRejected, &adminID, &now, reason, now
+	rejected, err := s.approvalRepo.RejectPurchaseApproval(approval)
+	if err != nil {
+		return nil, fmt.Errorf("error rejecting purchase: %w", err)
+	}
+	if !rejected {
+		return nil, ErrPurchaseApprovalDecided
+	}
+	publishAccountEvent(s.bus, s.clock, event.PurchaseRejected, approval.CreditAccountID)
+
+	s.notifyPurchaseDecision(approval, "mail.purchase_rejected", reason)
+	return purchaseApprovalToResponse(approval), nil
+}
+
+// ExpirePurchaseApprovals expires the purchases nobody approved or rejected in time, and lets their
+// clients know. It is run periodically.
+func (s *purchaseService) ExpirePurchaseApprovals() error {
+	approvals, err := s.approvalRepo.ExpirePurchaseApprovals(s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error expiring purchase approvals: %w", err)
+	}
+	for i := range approvals {
+		publishAccountEvent(s.bus, s.clock, event.PurchaseApprovalExpired, approvals[i].CreditAccountID)
+		s.notifyPurchaseDecision(&approvals[i], "mail.purchase_expired")
+	}
 	return nil
+}
 
+// findPurchaseApproval retrieves a pending purchase approval of the admin's establishment.
+func (s *purchaseService) findPurchaseApproval(adminID, branchID, approvalID uint) (*entities.PurchaseApproval, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	approval, err := s.approvalRepo.GetPurchaseApprovalByID(approvalID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && approval.EstablishmentID != establishment.ID) {
+		return nil, ErrPurchaseApprovalNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving purchase approval: %w", err)
+	}
+	if approval.Status != enums.ApprovalPending {
+		return nil, ErrPurchaseApprovalDecided
+	}
+	return approval, nil
 }
 
-func (s *purchaseService) GetClientBalance(clientID uint) (float64, error) {
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(clientID)
+// notifyPurchaseDecision emails the client what became of their purchase, with the texts of key
+// formatted with the amount and details. Failures are only logged, the decision stands either way.
+func (s *purchaseService) notifyPurchaseDecision(approval *entities.PurchaseApproval, key string, details ...interface{}) {
+	if approval.CreditAccount == nil || approval.CreditAccount.Client == nil || approval.CreditAccount.Client.Email == "" {
+		return
+	}
+	client := approval.CreditAccount.Client
+	lang := establishmentLanguage(s.settingsRepo, approval.EstablishmentID)
+	err := s.mailer.Send(mail.Message{
+		To:      client.Email,
+		Subject: i18n.T(lang, key+".subject", approval.Amount),
+		Body:    mailBody(lang, client.Name, key+".body", append([]interface{}{approval.Amount}, details...)...),
+	})
 	if err != nil {
-		return 0, fmt.Errorf("error retrieving credit account: %w", err)
+		log.Printf("client could not be notified of purchase approval %d: %v", approval.ID, err)
 	}
-	if creditAccount == nil {
-		return 0, errors.New("client does not have a credit account")
+}
+
+func purchaseApprovalToResponse(approval *entities.PurchaseApproval) *response.PurchaseApprovalResponse {
+	approvalResponse := &response.PurchaseApprovalResponse{
+		ID:              approval.ID,
+		CreditAccountID: approval.CreditAccountID,
+		EstablishmentID: approval.EstablishmentID,
+		Amount:          approval.Amount,
+		CreditType:      approval.CreditType,
+		Items:           []response.PurchaseApprovalItemResponse{},
+		Status:          approval.Status,
+		ExpiresAt:       approval.ExpiresAt,
+		DecidedByID:     approval.DecidedByID,
+		DecidedAt:       approval.DecidedAt,
+		Reason:          approval.Reason,
+		TransactionID:   approval.TransactionID,
+		CreatedAt:       approval.CreatedAt,
 	}
-	return creditAccount.CurrentBalance, nil
+	if approval.CreditAccount != nil {
+		approvalResponse.ClientID = approval.CreditAccount.ClientID
+		if approval.CreditAccount.Client != nil {
+			approvalResponse.ClientName = approval.CreditAccount.Client.Name
+		}
+	}
+	var items []request.PurchaseItemRequest
+	if json.Unmarshal([]byte(approval.Items), &items) == nil {
+		for _, item := range items {
+			approvalResponse.Items = append(approvalResponse.Items, response.PurchaseApprovalItemResponse{ProductID: item.ProductID, Quantity: item.Quantity})
+		}
+	}
+	return approvalResponse
+}
+
+// QuotePurchase prices a credit purchase of the client as ProcessPurchase would make it, and lists
+// the conditions that would keep it from going through, without making it.
+func (s *purchaseService) QuotePurchase(userID uint, establishmentID uint, items []request.PurchaseItemRequest, creditType enums.CreditType) (*response.PurchaseQuoteResponse, error) {
+	if userID == 0 || establishmentID == 0 || len(items) == 0 {
+		return nil, errors.New("invalid input data")
+	}
+
+	if creditType != enums.ShortTerm && creditType != enums.LongTerm {
+		return nil, errors.New("invalid credit type")
+	}
+
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, userID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseItems, products, err := s.buildPurchaseItems(creditAccount.EstablishmentID, items, true)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := response.PurchaseQuoteResponse{
+		CreditAccountID:    creditAccount.ID,
+		CreditType:         creditType,
+		Lines:              make([]response.PurchaseQuoteLineResponse, len(purchaseItems)),
+		AvailableCredit:    roundCurrency(creditAccount.CreditLimit - creditAccount.CurrentBalance + creditAccount.AccountCredit),
+		BlockingConditions: []response.PurchaseBlockingCondition{},
+	}
+	for i, item := range purchaseItems {
+		quote.Lines[i] = response.PurchaseQuoteLineResponse{
+			ProductID:     item.ProductID,
+			Name:          products[i].Name,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			TaxRate:       item.TaxRate,
+			TaxableAmount: item.TaxableAmount,
+			TaxAmount:     item.TaxAmount,
+			Total:         item.Total,
+		}
+		quote.TaxableAmount += item.TaxableAmount
+		quote.TaxAmount += item.TaxAmount
+		quote.Total += item.Total
+	}
+	quote.TaxableAmount = roundCurrency(quote.TaxableAmount)
+	quote.TaxAmount = roundCurrency(quote.TaxAmount)
+	quote.Total = roundCurrency(quote.Total)
+	quote.AvailableCreditAfter = roundCurrency(quote.AvailableCredit - quote.Total)
+
+	// The same checks as ProcessPurchase, in the same order
+	if creditAccount.IsBlocked {
+		quote.BlockingConditions = append(quote.BlockingConditions, response.PurchaseBlockingCondition{
+			Code: enums.BlockAccountBlocked, Message: ErrCreditAccountBlocked.Error(),
+		})
+	}
+	if creditAccount.Status == enums.AccountClosed {
+		quote.BlockingConditions = append(quote.BlockingConditions, response.PurchaseBlockingCondition{
+			Code: enums.BlockAccountClosed, Message: ErrCreditAccountClosed.Error(),
+		})
+	}
+	if err := checkEstablishmentActive(s.establishmentRepo, creditAccount.EstablishmentID); errors.Is(err, ErrEstablishmentInactive) {
+		quote.BlockingConditions = append(quote.BlockingConditions, response.PurchaseBlockingCondition{
+			Code: enums.BlockEstablishmentInactive, Message: err.Error(),
+		})
+	} else if err != nil {
+		return nil, err
+	}
+	if pending, err := agreementPending(s.agreementRepo, creditAccount); err != nil {
+		return nil, err
+	} else if pending {
+		quote.BlockingConditions = append(quote.BlockingConditions, response.PurchaseBlockingCondition{
+			Code: enums.BlockAgreementPending, Message: ErrAgreementNotAccepted.Error(),
+		})
+	}
+	if err := checkHighRiskPurchase(s.settingsRepo, creditAccount, quote.Total); errors.Is(err, ErrHighRiskPurchaseLimit) {
+		quote.BlockingConditions = append(quote.BlockingConditions, response.PurchaseBlockingCondition{
+			Code: enums.BlockHighRiskLimit, Message: err.Error(),
+		})
+	} else if err != nil {
+		return nil, err
+	}
+	if quote.AvailableCreditAfter < 0 {
+		quote.BlockingConditions = append(quote.BlockingConditions, response.PurchaseBlockingCondition{
+			Code:    enums.BlockCreditLimitExceeded,
+			Message: fmt.Sprintf("purchase amount exceeds credit limit (Current Balance: %.2f, Credit Limit: %.2f)", creditAccount.CurrentBalance, creditAccount.CreditLimit),
+		})
+	}
+	if err := s.checkSpendingLimit(creditAccount, quote.Total); errors.Is(err, ErrSpendingLimitExceeded) {
+		quote.BlockingConditions = append(quote.BlockingConditions, response.PurchaseBlockingCondition{
+			Code: enums.BlockSpendingLimit, Message: err.Error(),
+		})
+	} else if err != nil {
+		return nil, err
+	}
+	quote.CanPurchase = len(quote.BlockingConditions) == 0
+
+	if creditType == enums.LongTerm {
+		installments, err := s.planInstallments(creditAccount, quote.Total)
+		if err != nil {
+			return nil, fmt.Errorf("error planning installments: %w", err)
+		}
+		for _, installment := range installments {
+			quote.Installments = append(quote.Installments, response.QuotedInstallmentResponse{
+				DueDate: installment.DueDate,
+				Amount:  roundCurrency(installment.Amount),
+				Status:  installment.Status,
+			})
+		}
+	}
+	return &quote, nil
+}
+
+// RecordCashSale records the products an establishment (or branch branchID) sold for cash, so they
+// count in its sales reports.
+func (s *purchaseService) RecordCashSale(adminID, branchID uint, items []request.PurchaseItemRequest) error {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	if err := checkEstablishmentActive(s.establishmentRepo, establishment.ID); err != nil {
+		return err
+	}
+
+	purchaseItems, _, err := s.buildPurchaseItems(establishment.ID, items, false)
+	if err != nil {
+		return err
+	}
+	if err := s.purchaseItemRepo.CreatePurchaseItems(purchaseItems); err != nil {
+		return fmt.Errorf("error recording cash sale: %w", err)
+	}
+	return nil
+}
+
+// buildPurchaseItems prices the requested products of an establishment at their current price,
+// broken down with the establishment's tax settings. It also returns the product of each item.
+func (s *purchaseService) buildPurchaseItems(establishmentID uint, items []request.PurchaseItemRequest, isCredit bool) ([]entities.PurchaseItem, []*entities.Product, error) {
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	soldAt := s.clock.Now()
+	purchaseItems := make([]entities.PurchaseItem, 0, len(items))
+	products := make([]*entities.Product, 0, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, nil, errors.New("invalid input data")
+		}
+		product, err := s.productRepo.GetProductByID(item.ProductID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error retrieving product %d: %w", item.ProductID, err)
+		}
+		if product.EstablishmentID != establishmentID {
+			return nil, nil, fmt.Errorf("product %d does not belong to the establishment", item.ProductID)
+		}
+		products = append(products, product)
+
+		taxable, tax, total := taxBreakdown(product.Price*float64(item.Quantity), settings.TaxRate, !settings.PricesExcludeTax)
+		purchaseItems = append(purchaseItems, entities.PurchaseItem{
+			EstablishmentID: establishmentID,
+			ProductID:       product.ID,
+			Quantity:        item.Quantity,
+			UnitPrice:       product.Price,
+			Total:           total,
+			TaxRate:         settings.TaxRate,
+			TaxableAmount:   taxable,
+			TaxAmount:       tax,
+			IsCredit:        isCredit,
+			SoldAt:          soldAt,
+		})
+	}
+	return purchaseItems, products, nil
 }
 
-func (s *purchaseService) GetClientOverdueBalance(clientID uint) (float64, error) {
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(clientID)
+func (s *purchaseService) GetClientBalance(clientID, establishmentID uint) (*response.ClientBalanceResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := s.spendingAllowance(creditAccount)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ClientBalanceResponse{
+		ClientID:          clientID,
+		CurrentBalance:    creditAccount.CurrentBalance,
+		AccountCredit:     creditAccount.AccountCredit,
+		SpendingAllowance: allowance,
+		UpdatedAt:         creditAccount.UpdatedAt,
+	}, nil
+}
+
+// spendingAllowance returns how much of its spending limit a credit account has left in the current
+// period, or nil if it has no spending limit.
+func (s *purchaseService) spendingAllowance(creditAccount *entities.CreditAccount) (*response.SpendingAllowanceResponse, error) {
+	if creditAccount.SpendingLimit <= 0 {
+		return nil, nil
+	}
+	period := spendingPeriodOrDefault(creditAccount.SpendingLimitPeriod)
+	start, end := spendingPeriodBounds(period, s.clock.Now().In(accountLocation(creditAccount)))
+	totals, err := s.transactionRepo.GetTransactionTotals(creditAccount.ID, start, time.Time{})
 	if err != nil {
-		return 0, fmt.Errorf("error retrieving credit account: %w", err)
+		return nil, err
+	}
+	return &response.SpendingAllowanceResponse{
+		Limit:       creditAccount.SpendingLimit,
+		Period:      period,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Spent:       roundCurrency(totals.Purchases),
+		Remaining:   roundCurrency(max(creditAccount.SpendingLimit-totals.Purchases, 0)),
+	}, nil
+}
+
+// checkSpendingLimit rejects a purchase of amount beyond what the credit account may still spend this period.
+func (s *purchaseService) checkSpendingLimit(creditAccount *entities.CreditAccount, amount float64) error {
+	allowance, err := s.spendingAllowance(creditAccount)
+	if err != nil || allowance == nil {
+		return err
+	}
+	if amount > allowance.Remaining+0.005 {
+		return fmt.Errorf("%.2f left of the %s spending limit of %.2f until %s: %w", allowance.Remaining,
+			strings.ToLower(string(allowance.Period)), allowance.Limit, allowance.PeriodEnd.Format("2006-01-02"), ErrSpendingLimitExceeded)
 	}
-	if creditAccount == nil {
+	return nil
+}
+
+// spendingPeriodBounds returns the start of the spending period containing now, in its location,
+// and the start of the next one.
+func spendingPeriodBounds(period enums.SpendingPeriod, now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if period == enums.SpendingWeekly {
+		start := today.AddDate(0, 0, -(int(today.Weekday())+6)%7) // Back to Monday
+		return start, start.AddDate(0, 0, 7)
+	}
+	start := today.AddDate(0, 0, 1-today.Day())
+	return start, start.AddDate(0, 1, 0)
+}
+
+func spendingPeriodOrDefault(period enums.SpendingPeriod) enums.SpendingPeriod {
+	if period == "" {
+		return enums.SpendingMonthly
+	}
+	return period
+}
+
+func (s *purchaseService) GetClientOverdueBalance(clientID, establishmentID uint) (float64, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
+	if errors.Is(err, ErrCreditAccountNotFound) {
 		return 0, nil // No credit account, no overdue balance
 	}
+	if err != nil {
+		return 0, err
+	}
 
-	if !isAccountOverdue(*creditAccount) {
+	days, err := businessDays(s.holidayRepo, creditAccount.EstablishmentID)
+	if err != nil {
+		return 0, err
+	}
+	if !isAccountOverdue(*creditAccount, days, s.clock.Now()) {
 		return 0, nil // Account is not overdue
 	}
 
 	return creditAccount.CurrentBalance, nil
 }
 
-// isAccountOverdue checks if the account is overdue based on the monthly due date
-func isAccountOverdue(creditAccount entities.CreditAccount) bool {
-	today := time.Now()
-	dueDate := time.Date(today.Year(), today.Month(), creditAccount.MonthlyDueDate, 0, 0, 0, 0, time.UTC)
-	return today.After(dueDate) && creditAccount.CurrentBalance > 0
+// isAccountOverdue checks if the account is overdue based on the monthly due date, moved to the next
+// business day when it falls on a Sunday or a holiday
+func isAccountOverdue(creditAccount entities.CreditAccount, days calendar.BusinessDays, now time.Time) bool {
+	loc := accountLocation(&creditAccount)
+	dueDate := days.Next(util.CurrentDueDate(now.In(loc), creditAccount.MonthlyDueDate, loc))
+	return now.After(dueDate) && creditAccount.CurrentBalance > 0
 }
 
-func (s *purchaseService) GetClientInstallments(clientID uint) ([]response.InstallmentResponse, error) {
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(clientID)
+func (s *purchaseService) GetClientInstallments(clientID, establishmentID uint) ([]response.InstallmentResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving credit account: %w", err)
-	}
-	if creditAccount == nil {
-		return nil, errors.New("client does not have a credit account")
+		return nil, err
 	}
 
 	installments, err := s.installmentRepo.GetInstallmentsByCreditAccountID(creditAccount.ID)
@@ -148,6 +695,7 @@ func (s *purchaseService) GetClientInstallments(clientID uint) ([]response.Insta
 			DueDate:         installment.DueDate,
 			Amount:          installment.Amount,
 			Status:          installment.Status,
+			Version:         installment.Version,
 			CreatedAt:       installment.CreatedAt,
 			UpdatedAt:       installment.UpdatedAt,
 		})
@@ -155,13 +703,10 @@ func (s *purchaseService) GetClientInstallments(clientID uint) ([]response.Insta
 	return installmentResponses, nil
 }
 
-func (s *purchaseService) GetClientTransactions(clientID uint) ([]response.TransactionResponse, error) {
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(clientID)
+func (s *purchaseService) GetClientTransactions(clientID, establishmentID uint) ([]response.TransactionResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving credit account: %w", err)
-	}
-	if creditAccount == nil {
-		return nil, errors.New("client does not have a credit account")
+		return nil, err
 	}
 
 	transactions, err := s.transactionRepo.GetTransactionsByCreditAccountID(creditAccount.ID)
@@ -178,6 +723,7 @@ func (s *purchaseService) GetClientTransactions(clientID uint) ([]response.Trans
 			Amount:          transaction.Amount,
 			Description:     transaction.Description,
 			TransactionDate: transaction.TransactionDate,
+			DocumentNumber:  transaction.DocumentNumber,
 			CreatedAt:       transaction.CreatedAt,
 			UpdatedAt:       transaction.UpdatedAt,
 		})
@@ -186,66 +732,113 @@ func (s *purchaseService) GetClientTransactions(clientID uint) ([]response.Trans
 	return transactionResponses, nil
 }
 
-func (s *purchaseService) GetClientCreditAccount(clientID uint) (*entities.CreditAccount, error) {
-	creditAccount, err := s.creditAccountRepo.GetCreditAccountByClientID(clientID)
+// GetClientCreditAccount returns the client's credit account in establishmentID, or their only
+// account when establishmentID is 0.
+func (s *purchaseService) GetClientCreditAccount(clientID, establishmentID uint) (*entities.CreditAccount, error) {
+	return findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+}
+
+// GetClientCreditAccounts returns all of the client's credit accounts, one per establishment, embedding
+// the nested objects of includes.
+func (s *purchaseService) GetClientCreditAccounts(clientID uint, includes CreditAccountIncludes) ([]response.CreditAccountResponse, error) {
+	creditAccounts, err := s.creditAccountRepo.GetCreditAccountsByClientID(clientID)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
 	}
-	if creditAccount == nil {
-		return nil, errors.New("client does not have a credit account")
+
+	creditAccountResponses := make([]response.CreditAccountResponse, 0, len(creditAccounts))
+	for i := range creditAccounts {
+		creditAccountResponses = append(creditAccountResponses, *creditAccountToResponseWith(s.establishmentRepo, &creditAccounts[i], includes))
 	}
-	return creditAccount, nil
+	return creditAccountResponses, nil
 }
 
 func (s *purchaseService) createInstallments(creditAccount *entities.CreditAccount, purchaseAmount float64) error {
+	installments, err := s.planInstallments(creditAccount, purchaseAmount)
+	if err != nil || len(installments) == 0 {
+		return err
+	}
+	return s.installmentRepo.CreateInstallments(installments)
+}
+
+// planInstallments splits a purchase on a long-term credit account into its monthly installments,
+// without creating them.
+func (s *purchaseService) planInstallments(creditAccount *entities.CreditAccount, purchaseAmount float64) ([]entities.Installment, error) {
 	if creditAccount.CreditType != enums.LongTerm {
-		return nil // Installments are not applicable for short-term credit
+		return nil, nil // Installments are not applicable for short-term credit
 	}
+	days, err := businessDays(s.holidayRepo, creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, err
+	}
+	return planPurchaseInstallments(s.settingsRepo, creditAccount, purchaseAmount, days, s.clock.Now())
+}
 
-	// Assuming 12-month installment plan for simplicity
-	numInstallments := 12
+// planPurchaseInstallments splits a purchase made at now on a long-term credit account into its
+// monthly installments, each due on the next business day from its monthly due date.
+func planPurchaseInstallments(settingsRepo repository.EstablishmentSettingsRepository, creditAccount *entities.CreditAccount, purchaseAmount float64, days calendar.BusinessDays, now time.Time) ([]entities.Installment, error) {
+	if creditAccount.CreditType != enums.LongTerm {
+		return nil, nil // Installments are not applicable for short-term credit
+	}
+
+	// Purchases are split into as many monthly installments as the establishment allows
+	settings, err := settingsRepo.GetEstablishmentSettings(creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+	numInstallments := settings.MaxInstallments
 	installmentAmount := purchaseAmount / float64(numInstallments)
 
-	// Calculate the first installment due date based on credit account's due date
-	firstDueDate := calculateNextDueDate(creditAccount.MonthlyDueDate)
+	// Calculate the first installment due date based on credit account's due date, pushed back
+	// past the grace period during which the purchase is neither due nor accrues interest
+	loc := accountLocation(creditAccount)
+	firstDueDate := calculateNextDueDate(now, creditAccount.MonthlyDueDate, loc)
+	firstDueDate = util.AddMonthsToDueDate(firstDueDate, creditAccount.GracePeriod, creditAccount.MonthlyDueDate, loc)
+
+	// Account credit left over from an overpayment covers the earliest installments first
+	remainingCredit := min(creditAccount.AccountCredit, purchaseAmount)
 
 	var installments []entities.Installment
 	for i := 0; i < numInstallments; i++ {
-		installmentDueDate := firstDueDate.AddDate(0, i, 0)
+		// Clamp each month separately so a due day of 31 doesn't drift into the next month
+		installmentDueDate := util.AddMonthsToDueDate(firstDueDate, i, creditAccount.MonthlyDueDate, loc)
 		installment := entities.Installment{
 			CreditAccountID: creditAccount.ID,
-			DueDate:         installmentDueDate,
+			DueDate:         days.Next(installmentDueDate),
 			Amount:          installmentAmount,
 			Status:          enums.Pending,
 		}
+		if remainingCredit > 0 {
+			covered := min(remainingCredit, installment.Amount)
+			installment.Amount = roundCurrency(installment.Amount - covered)
+			remainingCredit -= covered
+			if installment.Amount <= 0 {
+				installment.Amount = installmentAmount
+				installment.Status = enums.Paid
+				installment.PaidAt = &now
+			}
+		}
 		installments = append(installments, installment)
 	}
-
-	return s.installmentRepo.CreateInstallments(installments)
+	return installments, nil
 }
 
 // calculateNextDueDate calculates the next due date for an installment
-func calculateNextDueDate(monthlyDueDate int) time.Time {
-	today := time.Now()
-	dueDate := time.Date(today.Year(), today.Month(), monthlyDueDate, 0, 0, 0, 0, time.UTC)
-	if dueDate.Before(today) {
-		dueDate = dueDate.AddDate(0, 1, 0)
-	}
-	return dueDate
+func calculateNextDueDate(now time.Time, monthlyDueDate int, loc *time.Location) time.Time {
+	return util.NextDueDate(now.In(loc), monthlyDueDate, loc)
 }
 
-// CalculateDueDate calculates the next due date for a credit account.
+// CalculateDueDate calculates the next due date for a credit account, on a business day.
 func (s *purchaseService) CalculateDueDate(account entities.CreditAccount) (time.Time, error) {
-	today := time.Now()
+	loc := accountLocation(&account)
+	today := s.clock.Now().In(loc)
+	days, err := businessDays(s.holidayRepo, account.EstablishmentID)
+	if err != nil {
+		return time.Time{}, err
+	}
 	if account.CreditType == enums.ShortTerm {
 		// For short-term credit, the due date is the next month's due date
-		nextMonth := today.Month() + 1
-		nextYear := today.Year()
-		if nextMonth > time.December {
-			nextMonth = time.January
-			nextYear++
-		}
-		return time.Date(nextYear, nextMonth, account.MonthlyDueDate, 0, 0, 0, 0, time.UTC), nil
+		return days.Next(util.FollowingMonthDueDate(today, account.MonthlyDueDate, loc)), nil
 	} else if account.CreditType == enums.LongTerm {
 		// For long-term credit, find the next pending installment's due date
 		installments, err := s.installmentRepo.GetInstallmentsByCreditAccountID(account.ID)
@@ -253,30 +846,29 @@ func (s *purchaseService) CalculateDueDate(account entities.CreditAccount) (time
 			return time.Time{}, fmt.Errorf("error retrieving installments: %w", err)
 		}
 		for _, installment := range installments {
-			if installment.Status == enums.Pending && installment.DueDate.After(today) {
+			if installment.Status.IsOpen() && installment.DueDate.After(today) {
 				return installment.DueDate, nil
 			}
 		}
 
 		// If no pending installments, calculate the next due date based on MonthlyDueDate
-		nextMonth := today.Month() + 1
-		nextYear := today.Year()
-		if nextMonth > time.December {
-			nextMonth = time.January
-			nextYear++
-		}
-		return time.Date(nextYear, nextMonth, account.MonthlyDueDate, 0, 0, 0, 0, time.UTC), nil
+		return days.Next(util.FollowingMonthDueDate(today, account.MonthlyDueDate, loc)), nil
 	}
 	return time.Time{}, fmt.Errorf("invalid credit type: %s", account.CreditType)
 }
 
 // GetClientAccountSummary retrieves a summary of the client's account.
-func (s *purchaseService) GetClientAccountSummary(clientID uint) (*response.AccountSummaryResponse, error) {
-	creditAccount, err := s.GetClientCreditAccount(clientID)
+func (s *purchaseService) GetClientAccountSummary(clientID, establishmentID uint) (*response.AccountSummaryResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
 	if err != nil {
 		return nil, err
 	}
 
+	cacheKey := summaryCacheKey(creditAccount.ID, s.clock.Now().In(accountLocation(creditAccount)))
+	if summary, ok := s.summaryCache.summaries.Get(cacheKey); ok {
+		return summary, nil
+	}
+
 	// Get transactions up to the current due date
 	dueDate, err := s.CalculateDueDate(*creditAccount)
 	if err != nil {
@@ -294,8 +886,10 @@ func (s *purchaseService) GetClientAccountSummary(clientID uint) (*response.Acco
 	// Prepare the response
 	summary := &response.AccountSummaryResponse{
 		CurrentBalance: creditAccount.CurrentBalance,
+		AccountCredit:  creditAccount.AccountCredit,
 		DueDate:        dueDate,
 		TotalInterest:  totalInterest,
+		Rates:          creditRatesToResponse(creditAccount),
 		Transactions:   make([]response.TransactionResponse, len(transactions)),
 	}
 
@@ -304,6 +898,7 @@ func (s *purchaseService) GetClientAccountSummary(clientID uint) (*response.Acco
 		summary.Transactions[i] = *transactionToResponse(&transaction)
 	}
 
+	s.summaryCache.summaries.Set(cacheKey, summary)
 	return summary, nil
 }
 
@@ -326,30 +921,35 @@ func calculateInterestForPurchase(transaction entities.Transaction, account enti
 	// Calculate the number of days from the purchase date to the due date
 	days := int(dueDate.Sub(transaction.TransactionDate).Hours() / 24)
 
-	// Calculate the interest based on the interest type (Nominal or Effective)
-	var interest float64
-	principal := transaction.Amount
-	annualRate := account.InterestRate / 100
-
-	if account.InterestType == enums.Nominal {
-		// Nominal interest calculation
-		interest = principal * annualRate * float64(days) / 365
-	} else if account.InterestType == enums.Effective {
-		// Effective interest calculation
-		dailyRate := math.Pow(1+annualRate, 1.0/365) - 1
-		interest = principal * (math.Pow(1+dailyRate, float64(days)) - 1)
+	// Calculate the interest based on the interest type (Nominal or Effective) and its compounding
+	accrued, err := interest.ForDays(transaction.Amount, account.InterestRate/100, account.InterestType, account.CompoundingPeriod, days)
+	if err != nil {
+		return 0
 	}
-
-	return interest
+	return accrued
 }
 
 // GetClientAccountStatement retrieves a client's account statement for a date range.
-func (s *purchaseService) GetClientAccountStatement(clientID uint, startDate, endDate time.Time) (*response.AccountStatementResponse, error) {
-	creditAccount, err := s.GetClientCreditAccount(clientID)
+func (s *purchaseService) GetClientAccountStatement(clientID, establishmentID uint, startDate, endDate time.Time) (*response.AccountStatementResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Statement dates are calendar days in the establishment's time zone
+	loc := accountLocation(creditAccount)
+	if !startDate.IsZero() {
+		startDate = util.StartOfDayIn(startDate, loc)
+	}
+	if !endDate.IsZero() {
+		endDate = util.EndOfDayIn(endDate, loc)
+	}
+
+	cacheKey := statementCacheKey(creditAccount.ID, startDate, endDate)
+	if statement, ok := s.summaryCache.statements.Get(cacheKey); ok {
+		return statement, nil
+	}
+
 	transactions, err := s.transactionRepo.GetTransactionsByCreditAccountIDAndDateRange(creditAccount.ID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving transactions: %w", err)
@@ -374,68 +974,173 @@ func (s *purchaseService) GetClientAccountStatement(clientID uint, startDate, en
 	}
 
 	// Populate transactions in the response
+	purchaseIDs := make([]uint, 0, len(transactions))
 	for i, transaction := range transactions {
 		statement.Transactions[i] = *transactionToResponse(&transaction)
+		switch transaction.TransactionType {
+		case enums.Purchase:
+			purchaseIDs = append(purchaseIDs, transaction.ID)
+		case enums.InterestCharge:
+			statement.InterestCharged += transaction.Amount
+		case enums.LateFeeCharge:
+			statement.LateFeesCharged += transaction.Amount
+		case enums.AdjustmentDebit:
+			statement.Adjustments += transaction.Amount
+		case enums.AdjustmentCredit:
+			statement.Adjustments -= transaction.Amount
+		}
 	}
+	statement.InterestCharged = roundCurrency(statement.InterestCharged)
+	statement.LateFeesCharged = roundCurrency(statement.LateFeesCharged)
+	statement.Adjustments = roundCurrency(statement.Adjustments)
 
+	// Purchases of products show the tax their items were charged
+	taxes, err := s.purchaseItemRepo.GetPurchaseTaxes(purchaseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving purchase taxes: %w", err)
+	}
+	taxesByID := make(map[uint]repository.PurchaseTax, len(taxes))
+	for _, tax := range taxes {
+		taxesByID[tax.TransactionID] = tax
+	}
+	for i := range statement.Transactions {
+		if tax, ok := taxesByID[statement.Transactions[i].ID]; ok {
+			taxable, taxAmount := roundCurrency(tax.TaxableAmount), roundCurrency(tax.TaxAmount)
+			statement.Transactions[i].TaxableAmount = &taxable
+			statement.Transactions[i].TaxAmount = &taxAmount
+		}
+	}
+
+	s.summaryCache.statements.Set(cacheKey, statement)
 	return statement, nil
 }
 
-// GenerateClientAccountStatementPDF generates a PDF account statement for the client.
-func (s *purchaseService) GenerateClientAccountStatementPDF(clientID uint, startDate, endDate time.Time) ([]byte, error) {
+// GenerateClientAccountStatementPDF generates a PDF account statement for the client, in lang or, if
+// it is empty, in the language of the account's establishment. The establishment's plan must include
+// PDF statements.
+func (s *purchaseService) GenerateClientAccountStatementPDF(clientID, establishmentID uint, startDate, endDate time.Time, lang i18n.Language) ([]byte, error) {
 	// 1. Get account statement data
-	statement, err := s.GetClientAccountStatement(clientID, startDate, endDate)
+	statement, err := s.GetClientAccountStatement(clientID, establishmentID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("error getting account statement: %w", err)
 	}
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlanFeature(s.establishmentRepo, creditAccount.EstablishmentID, enums.FeaturePDFStatements); err != nil {
+		return nil, err
+	}
+	if lang == "" {
+		lang = establishmentLanguage(s.settingsRepo, creditAccount.EstablishmentID)
+	}
 
 	// 2. Generate PDF using the statement data
 	pdf := gofpdf.New("P", "mm", "A4", "") // Create a new PDF document
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	label := func(key string, args ...interface{}) string { return tr(i18n.T(lang, key, args...)) }
+
+	// The footer of the last page carries a QR code of a link to pay the balance, when it is owed
+	lastPage := false
+	if link, code := s.statementPaymentCode(creditAccount); code != nil {
+		pdf.SetAutoPageBreak(true, statementFooterHeight)
+		pdf.SetFooterFunc(func() {
+			if !lastPage {
+				return
+			}
+			pageWidth, pageHeight := pdf.GetPageSize()
+			left, _, right, _ := pdf.GetMargins()
+			top := pageHeight - statementFooterHeight + 5
+			drawQRCode(pdf, code, pageWidth-right-statementQRCodeSide, top, statementQRCodeSide)
+			pdf.SetFont("Arial", "", 10)
+			pdf.SetXY(left, top+statementQRCodeSide/2-5)
+			pdf.CellFormat(pageWidth-left-right-statementQRCodeSide-5, 10, label("pdf.statement.scan_to_pay", link.Amount), "", 0, "R", false, 0, "")
+		})
+	}
 	pdf.AddPage()
 
 	// Header
 	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(40, 10, fmt.Sprintf("Account Statement - Client ID: %d", clientID))
+	pdf.Cell(40, 10, label("pdf.statement.title", clientID))
 	pdf.Ln(10)
 
 	// Date Range
 	pdf.SetFont("Arial", "", 12)
-	pdf.CellFormat(40, 10, fmt.Sprintf("Start Date: %s", startDate.Format("2006-01-02")), "", 0, "L", false, 0, "")
-	pdf.CellFormat(40, 10, fmt.Sprintf("End Date: %s", endDate.Format("2006-01-02")), "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 10, label("pdf.statement.start_date", startDate.Format("2006-01-02")), "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 10, label("pdf.statement.end_date", endDate.Format("2006-01-02")), "", 0, "L", false, 0, "")
 	pdf.Ln(10)
 
+	// Cost of credit, which must be disclosed on every statement
+	if rates := creditRatesToResponse(creditAccount); rates != nil {
+		pdf.CellFormat(40, 10, fmt.Sprintf("TNA: %.2f%%", rates.TNA), "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 10, fmt.Sprintf("TEA: %.2f%%", rates.TEA), "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 10, fmt.Sprintf("TCEA: %.2f%%", rates.TCEA), "", 0, "L", false, 0, "")
+		pdf.Ln(10)
+	}
+
 	// Starting Balance
-	pdf.CellFormat(40, 10, fmt.Sprintf("Starting Balance: %.2f", statement.StartingBalance), "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 10, label("pdf.statement.starting_balance", statement.StartingBalance), "", 0, "L", false, 0, "")
 	pdf.Ln(10)
 
 	// Transactions Table Header (Corrected)
 	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(30, 10, "Date")
-	pdf.Cell(40, 10, "Description")
-	pdf.Cell(30, 10, "Type")
-	pdf.Cell(30, 10, "Payment Method")
-	pdf.Cell(30, 10, "Amount")
-	pdf.Cell(30, 10, "Status")
+	pdf.Cell(22, 10, label("pdf.statement.date"))
+	pdf.Cell(28, 10, label("pdf.statement.receipt"))
+	pdf.Cell(40, 10, label("pdf.statement.description"))
+	pdf.Cell(25, 10, label("pdf.statement.type"))
+	pdf.Cell(30, 10, label("pdf.statement.payment_method"))
+	pdf.Cell(22, 10, label("pdf.statement.amount"))
+	pdf.Cell(23, 10, label("pdf.statement.status"))
 	pdf.Ln(10)
 
 	// Transactions Table Data
 	pdf.SetFont("Arial", "", 10)
 	for _, transaction := range statement.Transactions {
-		pdf.CellFormat(30, 10, transaction.TransactionDate.Format("2006-01-02"), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(40, 10, transaction.Description, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(30, 10, string(transaction.TransactionType), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(30, 10, string(transaction.PaymentMethod), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(30, 10, fmt.Sprintf("%.2f", transaction.Amount), "1", 0, "R", false, 0, "")
-		pdf.CellFormat(30, 10, string(transaction.PaymentStatus), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(22, 10, transaction.TransactionDate.Format("2006-01-02"), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(28, 10, transaction.DocumentNumber, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 10, tr(transaction.Description), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 10, tr(i18n.Label(lang, "transaction_type", string(transaction.TransactionType))), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 10, tr(i18n.Label(lang, "payment_method", string(transaction.PaymentMethod))), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(22, 10, fmt.Sprintf("%.2f", transaction.Amount), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(23, 10, tr(i18n.Label(lang, "payment_status", string(transaction.PaymentStatus))), "1", 0, "L", false, 0, "")
 		pdf.Ln(8)
 	}
 
+	// Tax charged on the purchases of products
+	var taxable, tax float64
+	for _, transaction := range statement.Transactions {
+		if transaction.TaxableAmount != nil && transaction.TaxAmount != nil {
+			taxable += *transaction.TaxableAmount
+			tax += *transaction.TaxAmount
+		}
+	}
+	if tax > 0 {
+		pdf.Ln(10)
+		pdf.SetFont("Arial", "", 12)
+		pdf.CellFormat(60, 10, label("pdf.statement.taxable_purchases", taxable), "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 10, fmt.Sprintf("IGV: %.2f", tax), "", 0, "L", false, 0, "")
+	}
+
+	// Interest and late fees charged over the period
+	if statement.InterestCharged > 0 || statement.LateFeesCharged > 0 {
+		pdf.Ln(10)
+		pdf.SetFont("Arial", "", 12)
+		pdf.CellFormat(60, 10, label("pdf.statement.interest_charged", statement.InterestCharged), "", 0, "L", false, 0, "")
+		pdf.CellFormat(60, 10, label("pdf.statement.late_fees_charged", statement.LateFeesCharged), "", 0, "L", false, 0, "")
+	}
+	if statement.Adjustments != 0 {
+		pdf.Ln(10)
+		pdf.SetFont("Arial", "", 12)
+		pdf.CellFormat(60, 10, label("pdf.statement.adjustments", statement.Adjustments), "", 0, "L", false, 0, "")
+	}
+
 	// Ending Balance
 	pdf.Ln(10)
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(40, 10, fmt.Sprintf("Ending Balance: %.2f", statement.StartingBalance+calculateTotalTransactionAmount(statement.Transactions)), "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 10, label("pdf.statement.ending_balance", statement.StartingBalance+calculateTotalTransactionAmount(statement.Transactions)), "", 0, "L", false, 0, "")
 
 	// 3. Output PDF as byte array
+	lastPage = true
 	err = pdf.OutputFileAndClose("account_statement.pdf") // Correct way to output to file
 	if err != nil {
 		return nil, fmt.Errorf("error outputting PDF to file: %w", err)
@@ -453,15 +1158,183 @@ func (s *purchaseService) GenerateClientAccountStatementPDF(clientID uint, start
 	return pdfBytes, nil
 }
 
+// Layout of the QR code in the footer of statements, in millimeters.
+const (
+	statementFooterHeight = 40
+	statementQRCodeSide   = 30
+)
+
+// statementPaymentCode returns the link to pay the balance of a credit account printed on its
+// statements, and its QR code. Statements are still printed without one when it can't be made.
+func (s *purchaseService) statementPaymentCode(creditAccount *entities.CreditAccount) (*response.PaymentLinkResponse, *qrcode.Code) {
+	link, err := s.paymentLinks.StatementPaymentLink(creditAccount)
+	if err != nil {
+		log.Printf("statement of credit account %d is printed without a payment link: %v", creditAccount.ID, err)
+		return nil, nil
+	}
+	if link == nil {
+		return nil, nil
+	}
+	code, err := qrcode.Encode(link.URL)
+	if err != nil {
+		log.Printf("statement of credit account %d is printed without a payment link: %v", creditAccount.ID, err)
+		return nil, nil
+	}
+	return link, code
+}
+
+// drawQRCode draws code on the PDF as a square of side at x, y, its quiet zone included.
+func drawQRCode(pdf *gofpdf.Fpdf, code *qrcode.Code, x, y, side float64) {
+	module := side / float64(code.Size()+2*qrcode.QuietZone)
+	x += qrcode.QuietZone * module
+	y += qrcode.QuietZone * module
+	pdf.SetFillColor(0, 0, 0)
+	for row := 0; row < code.Size(); row++ {
+		for column := 0; column < code.Size(); column++ {
+			// Runs of dark modules in a row are drawn as one rectangle, without seams between them
+			if !code.Dark(column, row) || (column > 0 && code.Dark(column-1, row)) {
+				continue
+			}
+			run := 1
+			for column+run < code.Size() && code.Dark(column+run, row) {
+				run++
+			}
+			pdf.Rect(x+float64(column)*module, y+float64(row)*module, float64(run)*module, module, "F")
+		}
+	}
+}
+
 // calculateTotalTransactionAmount calculates the total amount from a list of transactions
 func calculateTotalTransactionAmount(transactions []response.TransactionResponse) float64 {
 	total := 0.0
 	for _, transaction := range transactions {
-		if transaction.TransactionType == enums.Purchase {
+		if transaction.TransactionType.IsCharge() {
 			total += transaction.Amount
-		} else if transaction.TransactionType == enums.Payment {
+		} else if transaction.TransactionType.IsCredit() {
 			total -= transaction.Amount
 		}
 	}
 	return total
 }
+
+// GetPayoffQuote returns how much the client has to pay today to settle their account: the
+// outstanding balance plus the interest accrued since the last accrual, minus the establishment's
+// early-payment discount. Interest is counted in whole days, so the quote expires at the end of the
+// establishment's current day.
+// JobAccountStatementPDF renders a client's account statement in the background.
+const JobAccountStatementPDF = "account_statement_pdf"
+
+// accountStatementPDFPayload is the input of a JobAccountStatementPDF job.
+type accountStatementPDFPayload struct {
+	ClientID        uint          `json:"client_id"`
+	EstablishmentID uint          `json:"establishment_id"`
+	StartDate       time.Time     `json:"start_date"`
+	EndDate         time.Time     `json:"end_date"`
+	Language        i18n.Language `json:"language,omitempty"`
+}
+
+// EnqueueClientAccountStatementPDF queues the rendering of the PDF statement of a client's account,
+// to be downloaded from the job once it is done. The account is looked up right away, so a missing
+// account is reported here rather than by the job.
+func (s *purchaseService) EnqueueClientAccountStatementPDF(clientID, establishmentID uint, startDate, endDate time.Time, lang i18n.Language) (*response.JobResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlanFeature(s.establishmentRepo, creditAccount.EstablishmentID, enums.FeaturePDFStatements); err != nil {
+		return nil, err
+	}
+	return s.jobService.EnqueueJob(JobAccountStatementPDF, clientID, "", accountStatementPDFPayload{
+		ClientID:        clientID,
+		EstablishmentID: creditAccount.EstablishmentID,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Language:        lang,
+	})
+}
+
+func (s *purchaseService) runAccountStatementPDFJob(data json.RawMessage) (*JobResult, error) {
+	var payload accountStatementPDFPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("error decoding job payload: %w", err)
+	}
+	pdf, err := s.GenerateClientAccountStatementPDF(payload.ClientID, payload.EstablishmentID, payload.StartDate, payload.EndDate, payload.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &JobResult{Data: pdf, ContentType: "application/pdf", Filename: "account_statement.pdf"}, nil
+}
+
+func (s *purchaseService) GetPayoffQuote(clientID, establishmentID uint) (*response.PayoffQuoteResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	days, err := businessDays(s.holidayRepo, creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, err
+	}
+	return payoffQuote(creditAccount, days, s.clock.Now())
+}
+
+// PayOff settles the client's account if amount still matches its payoff quote.
+func (s *purchaseService) PayOff(clientID, establishmentID uint, amount float64) (*response.PayoffQuoteResponse, error) {
+	creditAccount, err := s.GetClientCreditAccount(clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := businessDays(s.holidayRepo, creditAccount.EstablishmentID)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := payoffQuote(creditAccount, days, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if math.Abs(quote.PayoffAmount-amount) > 0.005 {
+		return nil, ErrPayoffQuoteChanged
+	}
+
+	err = s.creditAccountRepo.SettleCreditAccount(creditAccount, quote.OutstandingPrincipal, quote.PayoffAmount, "Account Payoff")
+	if errors.Is(err, repository.ErrBalanceChanged) {
+		return nil, ErrPayoffQuoteChanged
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error settling credit account: %w", err)
+	}
+	publishAccountEvent(s.bus, s.clock, event.PaymentConfirmed, creditAccount.ID)
+
+	return quote, nil
+}
+
+// payoffQuote quotes paying off a credit account at now, see GetPayoffQuote. Whether it is overdue
+// depends on the business days of its establishment.
+func payoffQuote(creditAccount *entities.CreditAccount, days calendar.BusinessDays, now time.Time) (*response.PayoffQuoteResponse, error) {
+	if creditAccount.CurrentBalance <= 0 {
+		return nil, ErrNothingToPayOff
+	}
+
+	loc := accountLocation(creditAccount)
+	accrualDays := int(now.Sub(creditAccount.LastInterestAccrualDate).Hours() / 24)
+	accrued, err := interest.ForDays(creditAccount.CurrentBalance, creditAccount.InterestRate/100,
+		creditAccount.InterestType, creditAccount.CompoundingPeriod, accrualDays)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating accrued interest: %w", err)
+	}
+
+	quote := &response.PayoffQuoteResponse{
+		CreditAccountID:      creditAccount.ID,
+		OutstandingPrincipal: creditAccount.CurrentBalance,
+		AccruedInterest:      roundCurrency(accrued),
+		QuotedAt:             now,
+		ExpiresAt:            util.EndOfDayIn(now, loc),
+	}
+	if creditAccount.Establishment != nil && !isAccountOverdue(*creditAccount, days, now) {
+		quote.DiscountPercentage = creditAccount.Establishment.EarlyPaymentDiscountPercentage
+		quote.Discount = roundCurrency(creditAccount.CurrentBalance * quote.DiscountPercentage / 100)
+	}
+	quote.PayoffAmount = roundCurrency(quote.OutstandingPrincipal + quote.AccruedInterest - quote.Discount)
+
+	return quote, nil
+}
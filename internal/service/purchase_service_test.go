@@ -0,0 +1,154 @@
+package service
+
+import (
+	"ApiRestFinance/internal/calendar"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"math"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlanPurchaseInstallments(t *testing.T) {
+	// Accounts without an establishment loaded have their due dates in the default time zone
+	loc := util.LoadLocation("")
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, loc)
+	}
+	type planned struct {
+		dueDate time.Time
+		amount  float64
+		status  enums.InstallmentStatus
+	}
+	tests := []struct {
+		name     string
+		account  *entities.CreditAccount
+		amount   float64
+		holidays []time.Time
+		want     []planned
+	}{
+		{
+			name:    "short-term credit has no installments",
+			account: fixture.CreditAccount().ShortTerm().Build(),
+			amount:  300,
+		},
+		{
+			name:    "from the next due date",
+			account: fixture.CreditAccount().LongTerm(0).Build(),
+			amount:  300,
+			want: []planned{
+				{day(time.March, 15), 100, enums.Pending},
+				{day(time.April, 15), 100, enums.Pending},
+				{day(time.May, 15), 100, enums.Pending},
+			},
+		},
+		{
+			name:    "after the grace period, off Sundays",
+			account: fixture.CreditAccount().LongTerm(1).Build(),
+			amount:  300,
+			want: []planned{
+				{day(time.April, 15), 100, enums.Pending},
+				{day(time.May, 15), 100, enums.Pending},
+				{day(time.June, 16), 100, enums.Pending}, // June 15 is a Sunday
+			},
+		},
+		{
+			name:     "off holidays",
+			account:  fixture.CreditAccount().LongTerm(0).Build(),
+			amount:   300,
+			holidays: []time.Time{day(time.April, 15)},
+			want: []planned{
+				{day(time.March, 15), 100, enums.Pending},
+				{day(time.April, 16), 100, enums.Pending},
+				{day(time.May, 15), 100, enums.Pending},
+			},
+		},
+		{
+			name:    "due day 31 is clamped each month",
+			account: fixture.CreditAccount().LongTerm(0).DueDay(31).Build(),
+			amount:  300,
+			want: []planned{
+				{day(time.March, 31), 100, enums.Pending},
+				{day(time.April, 30), 100, enums.Pending},
+				{day(time.May, 31), 100, enums.Pending},
+			},
+		},
+		{
+			name:    "account credit covers the earliest installments",
+			account: fixture.CreditAccount().LongTerm(0).AccountCredit(150).Build(),
+			amount:  300,
+			want: []planned{
+				{day(time.March, 15), 100, enums.Paid},
+				{day(time.April, 15), 50, enums.Pending},
+				{day(time.May, 15), 100, enums.Pending},
+			},
+		},
+		{
+			name:    "account credit above the purchase pays it all",
+			account: fixture.CreditAccount().LongTerm(0).AccountCredit(500).Build(),
+			amount:  300,
+			want: []planned{
+				{day(time.March, 15), 100, enums.Paid},
+				{day(time.April, 15), 100, enums.Paid},
+				{day(time.May, 15), 100, enums.Paid},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingsRepo := mocks.NewMockEstablishmentSettingsRepository(gomock.NewController(t))
+			settingsRepo.EXPECT().GetEstablishmentSettings(fixture.EstablishmentID).
+				Return(fixture.Settings().MaxInstallments(3).Build(), nil).AnyTimes()
+
+			installments, err := planPurchaseInstallments(settingsRepo, tt.account, tt.amount, calendar.NewBusinessDays(tt.holidays), fixture.Now)
+			if err != nil {
+				t.Fatalf("planPurchaseInstallments returned %v", err)
+			}
+			if len(installments) != len(tt.want) {
+				t.Fatalf("planned %d installments, want %d", len(installments), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := installments[i]
+				if !got.DueDate.Equal(want.dueDate) || math.Abs(got.Amount-want.amount) > 0.005 || got.Status != want.status {
+					t.Errorf("installment %d = %s %.2f %s, want %s %.2f %s", i+1,
+						got.DueDate.Format("2006-01-02"), got.Amount, got.Status, want.dueDate.Format("2006-01-02"), want.amount, want.status)
+				}
+				if (got.PaidAt != nil) != (want.status == enums.Paid) {
+					t.Errorf("installment %d paid at %v with status %s", i+1, got.PaidAt, got.Status)
+				}
+				if got.CreditAccountID != tt.account.ID {
+					t.Errorf("installment %d is of account %d, want %d", i+1, got.CreditAccountID, tt.account.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateInterestForPurchase(t *testing.T) {
+	purchase := *fixture.Purchase(1000)
+	tests := []struct {
+		name    string
+		account *entities.CreditAccount
+		dueDate time.Time
+		want    float64
+	}{
+		{"a year at the TEA", fixture.CreditAccount().Build(), purchase.TransactionDate.AddDate(0, 0, 365), 240},
+		{"30 days", fixture.CreditAccount().Build(), purchase.TransactionDate.AddDate(0, 0, 30), 17.8376106371132},
+		{"a nominal rate", fixture.CreditAccount().Rate(12, enums.Nominal).Build(), purchase.TransactionDate.AddDate(0, 0, 365), 126.82503013196977},
+		{"partial days are not charged", fixture.CreditAccount().Build(), purchase.TransactionDate.Add(23 * time.Hour), 0},
+		{"a due date before the purchase", fixture.CreditAccount().Build(), purchase.TransactionDate.AddDate(0, 0, -1), 0},
+		{"an invalid interest type", fixture.CreditAccount().Rate(24, "SIMPLE").Build(), purchase.TransactionDate.AddDate(0, 0, 30), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateInterestForPurchase(purchase, *tt.account, tt.dueDate); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("calculateInterestForPurchase = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QueryLimits bounds how much data a single request may ask for.
+type QueryLimits struct {
+	MaxStatementMonths int // Longest date range allowed for account statements
+	MaxExportMonths    int // Longest date range allowed for file exports (PDF)
+	DefaultPageSize    int // Page size used when the client doesn't send one
+	MaxPageSize        int // Largest page size a client may request
+}
+
+var defaultQueryLimits = QueryLimits{
+	MaxStatementMonths: 3,
+	MaxExportMonths:    3,
+	DefaultPageSize:    20,
+	MaxPageSize:        20,
+}
+
+var roleQueryLimits = map[enums.Role]QueryLimits{
+	enums.ADMIN: {
+		MaxStatementMonths: 12,
+		MaxExportMonths:    12,
+		DefaultPageSize:    20,
+		MaxPageSize:        100,
+	},
+	enums.CLIENT: {
+		MaxStatementMonths: 12,
+		MaxExportMonths:    6,
+		DefaultPageSize:    20,
+		MaxPageSize:        50,
+	},
+}
+
+// QueryLimitsForRole returns the query limits that apply to the given role.
+func QueryLimitsForRole(role enums.Role) QueryLimits {
+	if limits, ok := roleQueryLimits[role]; ok {
+		return limits
+	}
+	return defaultQueryLimits
+}
+
+// ResolveStatementRange fills in missing bounds and checks the range against MaxStatementMonths.
+func (l QueryLimits) ResolveStatementRange(startDate, endDate time.Time) (time.Time, time.Time, error) {
+	return resolveDateRange(startDate, endDate, l.MaxStatementMonths)
+}
+
+// ResolveExportRange fills in missing bounds and checks the range against MaxExportMonths.
+func (l QueryLimits) ResolveExportRange(startDate, endDate time.Time) (time.Time, time.Time, error) {
+	return resolveDateRange(startDate, endDate, l.MaxExportMonths)
+}
+
+// ResolvePageSize returns the page size to use, or an error if the requested one is too large.
+func (l QueryLimits) ResolvePageSize(pageSize int) (int, error) {
+	if pageSize <= 0 {
+		return l.DefaultPageSize, nil
+	}
+	if pageSize > l.MaxPageSize {
+		return 0, &PageSizeLimitError{Requested: pageSize, Max: l.MaxPageSize}
+	}
+	return pageSize, nil
+}
+
+// resolveDateRange defaults a missing end date to now and a missing start date
+// to maxMonths before the end date, so omitting the range never scans the full history.
+func resolveDateRange(startDate, endDate time.Time, maxMonths int) (time.Time, time.Time, error) {
+	if endDate.IsZero() {
+		endDate = time.Now()
+	}
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, -maxMonths, 0)
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: end date is before start date", ErrQueryLimitExceeded)
+	}
+	if endDate.After(startDate.AddDate(0, maxMonths, 0)) {
+		return time.Time{}, time.Time{}, &DateRangeLimitError{
+			MaxMonths: maxMonths,
+			Chunks:    splitDateRange(startDate, endDate, maxMonths),
+		}
+	}
+	return startDate, endDate, nil
+}
+
+// splitDateRange splits [startDate, endDate] into consecutive windows of at most maxMonths.
+func splitDateRange(startDate, endDate time.Time, maxMonths int) []DateRange {
+	var chunks []DateRange
+	for chunkStart := startDate; !chunkStart.After(endDate); {
+		next := chunkStart.AddDate(0, maxMonths, 0)
+		chunkEnd := next.AddDate(0, 0, -1)
+		if chunkEnd.After(endDate) {
+			chunkEnd = endDate
+		}
+		chunks = append(chunks, DateRange{StartDate: chunkStart, EndDate: chunkEnd})
+		chunkStart = next
+	}
+	return chunks
+}
+
+// DateRange is an inclusive range of dates.
+type DateRange struct {
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// DateRangeLimitError is returned when a request asks for a longer date range than allowed.
+type DateRangeLimitError struct {
+	MaxMonths int
+	Chunks    []DateRange // Suggested ranges that together cover the original request
+}
+
+func (e *DateRangeLimitError) Error() string {
+	parts := make([]string, 0, len(e.Chunks))
+	for _, chunk := range e.Chunks {
+		parts = append(parts, chunk.StartDate.Format("2006-01-02")+" to "+chunk.EndDate.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("date range exceeds the maximum of %d months per request; split it into: %s",
+		e.MaxMonths, strings.Join(parts, ", "))
+}
+
+func (e *DateRangeLimitError) Unwrap() error {
+	return ErrQueryLimitExceeded
+}
+
+// PageSizeLimitError is returned when a request asks for a larger page than allowed.
+type PageSizeLimitError struct {
+	Requested int
+	Max       int
+}
+
+func (e *PageSizeLimitError) Error() string {
+	return fmt.Sprintf("page_size %d exceeds the maximum of %d; request page_size=%d and use the page parameter to fetch the rest",
+		e.Requested, e.Max, e.Max)
+}
+
+func (e *PageSizeLimitError) Unwrap() error {
+	return ErrQueryLimitExceeded
+}
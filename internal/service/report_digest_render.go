@@ -0,0 +1,179 @@
+package service
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/model/dto/response"
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// reportDigestDocument is a report digest laid out in sections of lines and tables, so it reads
+// the same as plain text, HTML and PDF.
+type reportDigestDocument struct {
+	Title    string
+	Period   string
+	Sections []reportDigestSection
+}
+
+type reportDigestSection struct {
+	Title  string
+	Lines  []string
+	Header []string   // Columns of Rows, if the section has a table
+	Rows   [][]string // Empty tables show Empty instead
+	Empty  string
+}
+
+// digestContent lays out digest in lang, with its dates in loc.
+func digestContent(lang i18n.Language, digest *response.ReportDigestResponse, loc *time.Location) *reportDigestDocument {
+	document := &reportDigestDocument{
+		Title:  i18n.T(lang, "digest.title", digest.Name),
+		Period: i18n.T(lang, "digest.period", digest.StartDate.In(loc).Format("2006-01-02"), digest.EndDate.In(loc).Format("2006-01-02")),
+	}
+	if c := digest.Collections; c != nil {
+		document.Sections = append(document.Sections, reportDigestSection{
+			Title: i18n.T(lang, "digest.collections"),
+			Lines: []string{
+				i18n.T(lang, "digest.collections.payments", c.Payments),
+				i18n.T(lang, "digest.collections.collected", c.Collected),
+				i18n.T(lang, "digest.collections.pending", c.Pending),
+			},
+		})
+	}
+	if d := digest.NewDebt; d != nil {
+		document.Sections = append(document.Sections, reportDigestSection{
+			Title: i18n.T(lang, "digest.new_debt"),
+			Lines: []string{
+				i18n.T(lang, "digest.new_debt.purchases", d.Purchases, d.Amount),
+				i18n.T(lang, "digest.new_debt.accounts", d.NewAccounts),
+				i18n.T(lang, "digest.new_debt.charges", d.Charges),
+			},
+		})
+	}
+	if o := digest.Overdue; o != nil {
+		document.Sections = append(document.Sections, reportDigestSection{
+			Title: i18n.T(lang, "digest.overdue"),
+			Lines: []string{
+				i18n.T(lang, "digest.overdue.accounts", o.OverdueAccounts, o.OverdueAmount),
+				i18n.T(lang, "digest.overdue.new", o.NewlyOverdue, o.NewlyOverdueAmount),
+				i18n.T(lang, "digest.overdue.settled", o.SettledOverdue, o.SettledOverdueAmount),
+			},
+		})
+	}
+	if digest.TopDebtors != nil {
+		section := reportDigestSection{
+			Title:  i18n.T(lang, "digest.top_debtors"),
+			Header: []string{i18n.T(lang, "digest.top_debtors.client"), i18n.T(lang, "digest.top_debtors.balance"), i18n.T(lang, "digest.top_debtors.overdue")},
+			Empty:  i18n.T(lang, "digest.top_debtors.none"),
+		}
+		for _, debtor := range digest.TopDebtors {
+			overdue := i18n.T(lang, "digest.no")
+			if debtor.Overdue {
+				overdue = i18n.T(lang, "digest.yes")
+			}
+			section.Rows = append(section.Rows, []string{debtor.ClientName, fmt.Sprintf("%.2f", debtor.Balance), overdue})
+		}
+		document.Sections = append(document.Sections, section)
+	}
+	return document
+}
+
+// text renders the sections of the document as plain text, for the body of the email.
+func (d *reportDigestDocument) text() string {
+	var b strings.Builder
+	for _, section := range d.Sections {
+		b.WriteString("\n" + section.Title + "\n")
+		for _, line := range section.Lines {
+			b.WriteString("- " + line + "\n")
+		}
+		if section.Header != nil && len(section.Rows) == 0 {
+			b.WriteString("- " + section.Empty + "\n")
+		}
+		for _, row := range section.Rows {
+			b.WriteString("- " + strings.Join(row, " | ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+var reportDigestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html><body style="font-family: Arial, sans-serif; color: #222;">
+<h2>{{.Title}}</h2>
+<p>{{.Period}}</p>
+{{range .Sections}}<h3>{{.Title}}</h3>
+{{if .Lines}}<ul>{{range .Lines}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .Header}}{{if .Rows}}<table cellpadding="6" style="border-collapse: collapse;">
+<tr>{{range .Header}}<th align="left" style="border-bottom: 1px solid #ccc;">{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>{{else}}<p>{{.Empty}}</p>{{end}}{{end}}
+{{end}}</body></html>
+`))
+
+// html renders the document as the HTML body of the email.
+func (d *reportDigestDocument) html() (string, error) {
+	var buf bytes.Buffer
+	if err := reportDigestTemplate.Execute(&buf, d); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// pdf renders the document as a PDF, attached to the email.
+func (d *reportDigestDocument) pdf() ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, tr(d.Title), "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 12)
+	pdf.CellFormat(0, 8, tr(d.Period), "", 1, "L", false, 0, "")
+
+	widths := []float64{100, 40, 30}
+	for _, section := range d.Sections {
+		pdf.Ln(6)
+		pdf.SetFont("Arial", "B", 14)
+		pdf.CellFormat(0, 10, tr(section.Title), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 11)
+		for _, line := range section.Lines {
+			pdf.CellFormat(0, 7, tr(line), "", 1, "L", false, 0, "")
+		}
+		if section.Header == nil {
+			continue
+		}
+		if len(section.Rows) == 0 {
+			pdf.CellFormat(0, 7, tr(section.Empty), "", 1, "L", false, 0, "")
+			continue
+		}
+		pdf.SetFont("Arial", "B", 11)
+		for i, column := range section.Header {
+			pdf.CellFormat(widths[i], 8, tr(column), "B", 0, digestColumnAlign(i), false, 0, "")
+		}
+		pdf.Ln(8)
+		pdf.SetFont("Arial", "", 11)
+		for _, row := range section.Rows {
+			for i, cell := range row {
+				pdf.CellFormat(widths[i], 7, tr(cell), "", 0, digestColumnAlign(i), false, 0, "")
+			}
+			pdf.Ln(7)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("error generating PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// digestColumnAlign aligns the amounts of the second column of a table to the right.
+func digestColumnAlign(column int) string {
+	if column == 1 {
+		return "R"
+	}
+	return "L"
+}
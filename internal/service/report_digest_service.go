@@ -0,0 +1,497 @@
+package service
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// digestSendWindow is how long after its period ends a scheduled digest is still sent, so a
+	// digest that couldn't be sent on time is retried but old periods aren't sent when an
+	// establishment turns digests on.
+	digestSendWindow  = 2 * 24 * time.Hour
+	maxDigestAttempts = 3
+	// digestTopDebtors is how many accounts the top debtors section lists.
+	digestTopDebtors = 5
+	// defaultDigestPeriod is the period of the digests admins ask for without naming one.
+	defaultDigestPeriod = "last_week"
+)
+
+// ReportDigestService emails establishment admins a digest of their reports: collections, new debt,
+// overdue changes and top debtors, weekly or monthly as their settings say, or when they ask for it.
+type ReportDigestService interface {
+	SendDueDigests() error
+	SendDigest(adminID, branchID uint, req request.SendReportDigestRequest) (*response.ReportDigestResponse, error)
+	GetDigestDeliveries(adminID, branchID uint) ([]response.ReportDigestDeliveryResponse, error)
+}
+
+type reportDigestService struct {
+	establishmentRepo repository.EstablishmentRepository
+	userRepo          repository.UserRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	creditAccountRepo repository.CreditAccountRepository
+	transactionRepo   repository.TransactionRepository
+	installmentRepo   repository.InstallmentRepository
+	digestRepo        repository.ReportDigestRepository
+	mailer            mail.Sender
+	jobService        JobService
+	clock             util.Clock
+}
+
+// NewReportDigestService creates a new instance of ReportDigestService. Scheduled digests are
+// compiled and emailed by jobService's workers.
+func NewReportDigestService(establishmentRepo repository.EstablishmentRepository, userRepo repository.UserRepository, settingsRepo repository.EstablishmentSettingsRepository, creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository, digestRepo repository.ReportDigestRepository, mailer mail.Sender, jobService JobService, clock util.Clock) ReportDigestService {
+	s := &reportDigestService{
+		establishmentRepo: establishmentRepo,
+		userRepo:          userRepo,
+		settingsRepo:      settingsRepo,
+		creditAccountRepo: creditAccountRepo,
+		transactionRepo:   transactionRepo,
+		installmentRepo:   installmentRepo,
+		digestRepo:        digestRepo,
+		mailer:            mailer,
+		jobService:        jobService,
+		clock:             clock,
+	}
+	jobService.RegisterHandler(JobReportDigest, s.runReportDigestJob)
+	return s
+}
+
+// JobReportDigest compiles and emails the scheduled digest of one establishment for one period.
+const JobReportDigest = "report_digest"
+
+// reportDigestPayload is the input of a JobReportDigest job.
+type reportDigestPayload struct {
+	EstablishmentID uint                  `json:"establishment_id"`
+	Frequency       enums.DigestFrequency `json:"frequency"`
+	PeriodStart     time.Time             `json:"period_start"`
+	PeriodEnd       time.Time             `json:"period_end"`
+}
+
+// SendDueDigests queues the digest of every establishment whose weekly or monthly period just
+// ended, if its plan includes report digests. Admins without a verified email are skipped. It is safe to run repeatedly: each digest is
+// only queued once at a time and only sent once.
+func (s *reportDigestService) SendDueDigests() error {
+	settings, err := s.settingsRepo.GetEstablishmentSettingsWithDigests()
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	now := s.clock.Now()
+	failed := 0
+	for i := range settings {
+		establishment, err := s.establishmentRepo.GetEstablishmentByID(settings[i].EstablishmentID)
+		if err != nil {
+			failed++
+			continue
+		}
+		limits, err := establishmentLimits(s.establishmentRepo, establishment)
+		if err != nil {
+			failed++
+			continue
+		}
+		if !limits.Allows(enums.FeatureReportDigests) {
+			continue
+		}
+		frequency := enums.DigestFrequency(settings[i].DigestFrequency)
+		start, end, due, err := s.digestDue(establishment, frequency, now)
+		if err == nil && due {
+			_, err = s.jobService.EnqueueJob(JobReportDigest, 0, fmt.Sprintf("%s:%d:%s:%s", JobReportDigest, establishment.ID, frequency, start.Format(time.RFC3339)), reportDigestPayload{
+				EstablishmentID: establishment.ID,
+				Frequency:       frequency,
+				PeriodStart:     start,
+				PeriodEnd:       end,
+			})
+		}
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d report digests could not be queued", failed)
+	}
+	return nil
+}
+
+// digestDue returns the last complete period of an establishment's digests at frequency and
+// whether its digest still has to be sent.
+func (s *reportDigestService) digestDue(establishment *entities.Establishment, frequency enums.DigestFrequency, now time.Time) (time.Time, time.Time, bool, error) {
+	period := "last_week"
+	if frequency == enums.DigestMonthly {
+		period = "last_month"
+	}
+	start, end, err := util.NamedDateRange(period, now.In(establishmentLocation(establishment)))
+	if err != nil {
+		return start, end, false, err
+	}
+	if now.Sub(end) > digestSendWindow {
+		return start, end, false, nil
+	}
+
+	digest, err := s.digestRepo.GetDigest(establishment.ID, frequency, start)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return start, end, true, nil
+	case err != nil:
+		return start, end, false, fmt.Errorf("error retrieving report digest: %w", err)
+	}
+	return start, end, digest.Status != enums.DigestSent && digest.Attempts < maxDigestAttempts, nil
+}
+
+func (s *reportDigestService) runReportDigestJob(data json.RawMessage) (*JobResult, error) {
+	var payload reportDigestPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("error decoding job payload: %w", err)
+	}
+	establishment, err := s.establishmentRepo.GetEstablishmentByID(payload.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	return nil, s.sendScheduledDigest(establishment, payload.Frequency, payload.PeriodStart, payload.PeriodEnd)
+}
+
+// sendScheduledDigest emails the digest of establishment for the period and records the attempt. A
+// failed email is only recorded in the digest: SendDueDigests queues it again on its next run.
+func (s *reportDigestService) sendScheduledDigest(establishment *entities.Establishment, frequency enums.DigestFrequency, start, end time.Time) error {
+	admin, err := s.userRepo.GetUserByID(establishment.AdminID)
+	if err != nil {
+		return fmt.Errorf("error retrieving admin: %w", err)
+	}
+	if admin.EmailVerifiedAt == nil {
+		return nil
+	}
+	settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving establishment settings: %w", err)
+	}
+
+	record, err := s.digestRepo.GetDigest(establishment.ID, frequency, start)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		record = &entities.ReportDigest{
+			EstablishmentID: establishment.ID,
+			Frequency:       frequency,
+			PeriodStart:     start,
+			PeriodEnd:       end,
+		}
+	case err != nil:
+		return fmt.Errorf("error retrieving report digest: %w", err)
+	case record.Status == enums.DigestSent || record.Attempts >= maxDigestAttempts:
+		return nil
+	}
+
+	record.Email = admin.Email
+	record.Attempts++
+	sendErr := s.compileAndSend(establishment, admin, digestSections(settings), start, end)
+	if sendErr != nil {
+		log.Printf("report digest of establishment %d could not be sent: %v", establishment.ID, sendErr)
+		record.Status = enums.DigestFailed
+		record.Error = sendErr.Error()
+	} else {
+		now := s.clock.Now()
+		record.Status = enums.DigestSent
+		record.Error = ""
+		record.SentAt = &now
+	}
+	if err := s.digestRepo.SaveDigest(record); err != nil {
+		return fmt.Errorf("error saving report digest: %w", err)
+	}
+	return nil
+}
+
+func (s *reportDigestService) compileAndSend(establishment *entities.Establishment, admin *entities.User, sections []enums.DigestSection, start, end time.Time) error {
+	digest, err := s.compile(establishment, sections, start, end)
+	if err != nil {
+		return err
+	}
+	return s.send(establishment, admin, digest)
+}
+
+// SendDigest compiles the digest of the admin's establishment, or the selected branch, over the
+// period asked for and emails it to the admin, unless it is only a preview. The admin's email must
+// be verified, and the establishment's plan must include report digests.
+func (s *reportDigestService) SendDigest(adminID, branchID uint, req request.SendReportDigestRequest) (*response.ReportDigestResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := s.userRepo.GetUserByID(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving admin: %w", err)
+	}
+	if !req.Preview && admin.EmailVerifiedAt == nil {
+		return nil, ErrEmailNotVerified
+	}
+	if !req.Preview {
+		limits, err := establishmentLimits(s.establishmentRepo, establishment)
+		if err != nil {
+			return nil, err
+		}
+		if err := limits.CheckFeature(enums.FeatureReportDigests); err != nil {
+			return nil, err
+		}
+	}
+
+	period := req.Period
+	if period == "" {
+		period = defaultDigestPeriod
+	}
+	start, end, err := reportPeriodRange(period, s.clock.Now().In(establishmentLocation(establishment)))
+	if err != nil {
+		return nil, err
+	}
+	sections := make([]enums.DigestSection, 0, len(req.Sections))
+	for _, section := range req.Sections {
+		sections = append(sections, enums.DigestSection(section))
+	}
+	if len(sections) == 0 {
+		settings, err := s.settingsRepo.GetEstablishmentSettings(establishment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving establishment settings: %w", err)
+		}
+		sections = digestSections(settings)
+	}
+
+	digest, err := s.compile(establishment, sections, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if req.Preview {
+		return digest, nil
+	}
+	if err := s.send(establishment, admin, digest); err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// GetDigestDeliveries lists the scheduled digests of the admin's establishment, or the selected
+// branch, newest first.
+func (s *reportDigestService) GetDigestDeliveries(adminID, branchID uint) ([]response.ReportDigestDeliveryResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	digests, err := s.digestRepo.GetDigestsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving report digests: %w", err)
+	}
+
+	deliveries := make([]response.ReportDigestDeliveryResponse, 0, len(digests))
+	for _, digest := range digests {
+		deliveries = append(deliveries, response.ReportDigestDeliveryResponse{
+			ID:          digest.ID,
+			Frequency:   digest.Frequency,
+			PeriodStart: digest.PeriodStart,
+			PeriodEnd:   digest.PeriodEnd,
+			Email:       digest.Email,
+			Status:      digest.Status,
+			Attempts:    digest.Attempts,
+			Error:       digest.Error,
+			SentAt:      digest.SentAt,
+		})
+	}
+	return deliveries, nil
+}
+
+// compile gathers the sections of the digest of establishment from start to end. Accounts written
+// off as bad debt are left out, they are in the bad-debt report instead.
+func (s *reportDigestService) compile(establishment *entities.Establishment, sections []enums.DigestSection, start, end time.Time) (*response.ReportDigestResponse, error) {
+	digest := &response.ReportDigestResponse{
+		EstablishmentID: establishment.ID,
+		Name:            establishment.Name,
+		StartDate:       start,
+		EndDate:         end,
+	}
+	wanted := make(map[enums.DigestSection]bool, len(sections))
+	for _, section := range sections {
+		wanted[section] = true
+	}
+
+	if wanted[enums.DigestCollections] || wanted[enums.DigestNewDebt] {
+		transactions, err := s.transactionRepo.GetTransactionsByEstablishmentID(establishment.ID, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving transactions: %w", err)
+		}
+		collections := &response.DigestCollectionsResponse{}
+		newDebt := &response.DigestNewDebtResponse{}
+		for _, transaction := range transactions {
+			if transaction.PaymentStatus == enums.FAILED {
+				continue
+			}
+			switch transaction.TransactionType {
+			case enums.Payment:
+				collections.Payments++
+				if transaction.PaymentStatus == enums.PENDING {
+					collections.Pending += transaction.Amount
+				} else {
+					collections.Collected += transaction.Amount
+				}
+			case enums.Purchase:
+				newDebt.Purchases++
+				newDebt.Amount += transaction.Amount
+			case enums.InterestCharge, enums.LateFeeCharge:
+				newDebt.Charges += transaction.Amount
+			}
+		}
+		if wanted[enums.DigestCollections] {
+			collections.Collected = roundCurrency(collections.Collected)
+			collections.Pending = roundCurrency(collections.Pending)
+			digest.Collections = collections
+		}
+		if wanted[enums.DigestNewDebt] {
+			newDebt.Amount = roundCurrency(newDebt.Amount)
+			newDebt.Charges = roundCurrency(newDebt.Charges)
+			digest.NewDebt = newDebt
+		}
+	}
+
+	if !wanted[enums.DigestNewDebt] && !wanted[enums.DigestOverdue] && !wanted[enums.DigestTopDebtors] {
+		return digest, nil
+	}
+	allAccounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+	accounts := make([]entities.CreditAccount, 0, len(allAccounts))
+	ids := make([]uint, 0, len(allAccounts))
+	for _, account := range allAccounts {
+		if account.WrittenOffAt != nil {
+			continue
+		}
+		accounts = append(accounts, account)
+		ids = append(ids, account.ID)
+		if digest.NewDebt != nil && !account.CreatedAt.Before(start) && !account.CreatedAt.After(end) {
+			digest.NewDebt.NewAccounts++
+		}
+	}
+	if !wanted[enums.DigestOverdue] && !wanted[enums.DigestTopDebtors] {
+		return digest, nil
+	}
+
+	installments, err := s.installmentRepo.GetInstallmentsByCreditAccountIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving installments: %w", err)
+	}
+	overdue := &response.DigestOverdueResponse{}
+	overdueAccounts := make(map[uint]bool)
+	for _, installment := range installments {
+		open := installment.Status.IsOpen()
+		if open && installment.DueDate.Before(end) {
+			overdueAccounts[installment.CreditAccountID] = true
+			overdue.OverdueAmount += installment.Amount
+		}
+		switch {
+		case open && !installment.DueDate.Before(start) && installment.DueDate.Before(end):
+			overdue.NewlyOverdue++
+			overdue.NewlyOverdueAmount += installment.Amount
+		case installment.PaidAt != nil && installment.DueDate.Before(start) && !installment.PaidAt.Before(start) && !installment.PaidAt.After(end):
+			overdue.SettledOverdue++
+			overdue.SettledOverdueAmount += installment.Amount
+		}
+	}
+	if wanted[enums.DigestOverdue] {
+		overdue.OverdueAccounts = len(overdueAccounts)
+		overdue.OverdueAmount = roundCurrency(overdue.OverdueAmount)
+		overdue.NewlyOverdueAmount = roundCurrency(overdue.NewlyOverdueAmount)
+		overdue.SettledOverdueAmount = roundCurrency(overdue.SettledOverdueAmount)
+		digest.Overdue = overdue
+	}
+
+	if wanted[enums.DigestTopDebtors] {
+		debtors := make([]response.DigestDebtorResponse, 0, len(accounts))
+		for _, account := range accounts {
+			balance := roundCurrency(account.CurrentBalance - account.AccountCredit)
+			if balance <= 0 {
+				continue
+			}
+			debtor := response.DigestDebtorResponse{
+				CreditAccountID: account.ID,
+				ClientID:        account.ClientID,
+				Balance:         balance,
+				Overdue:         overdueAccounts[account.ID],
+			}
+			if account.Client != nil {
+				debtor.ClientName = account.Client.Name
+			}
+			debtors = append(debtors, debtor)
+		}
+		sort.SliceStable(debtors, func(i, j int) bool { return debtors[i].Balance > debtors[j].Balance })
+		if len(debtors) > digestTopDebtors {
+			debtors = debtors[:digestTopDebtors]
+		}
+		digest.TopDebtors = debtors
+	}
+	return digest, nil
+}
+
+// send emails digest to admin, in HTML with a plain text version and the digest attached as a PDF.
+func (s *reportDigestService) send(establishment *entities.Establishment, admin *entities.User, digest *response.ReportDigestResponse) error {
+	lang := establishmentLanguage(s.settingsRepo, establishment.ID)
+	loc := establishmentLocation(establishment)
+	from, to := digest.StartDate.In(loc).Format("2006-01-02"), digest.EndDate.In(loc).Format("2006-01-02")
+	content := digestContent(lang, digest, loc)
+
+	html, err := content.html()
+	if err != nil {
+		return fmt.Errorf("error rendering report digest: %w", err)
+	}
+	pdf, err := content.pdf()
+	if err != nil {
+		return fmt.Errorf("error rendering report digest: %w", err)
+	}
+	err = s.mailer.Send(mail.Message{
+		To:      admin.Email,
+		Subject: i18n.T(lang, "mail.report_digest.subject", establishment.Name, from, to),
+		Body:    mailBody(lang, admin.Name, "mail.report_digest.body", establishment.Name, from, to) + "\n" + content.text(),
+		HTML:    html,
+		Attachments: []mail.Attachment{{
+			Filename:    fmt.Sprintf("digest-%s.pdf", to),
+			ContentType: "application/pdf",
+			Data:        pdf,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	digest.SentTo = admin.Email
+	return nil
+}
+
+// digestSections returns the sections of the digests of an establishment, all of them unless its
+// settings chose some.
+func digestSections(settings *entities.EstablishmentSettings) []enums.DigestSection {
+	if settings.DigestSections == "" {
+		return enums.DigestSections
+	}
+	var sections []enums.DigestSection
+	for _, section := range strings.Split(settings.DigestSections, ",") {
+		sections = append(sections, enums.DigestSection(section))
+	}
+	return sections
+}
+
+// digestSectionNames returns the sections of the digests of an establishment, for its settings.
+func digestSectionNames(settings *entities.EstablishmentSettings) []string {
+	sections := digestSections(settings)
+	names := make([]string, 0, len(sections))
+	for _, section := range sections {
+		names = append(names, string(section))
+	}
+	return names
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultForecastWeeks and DefaultForecastMonths are how many periods the forecast covers by
+	// default, MaxForecastWeeks and MaxForecastMonths at most.
+	DefaultForecastWeeks  = 8
+	MaxForecastWeeks      = 26
+	DefaultForecastMonths = 3
+	MaxForecastMonths     = 12
+	// forecastNeutralPunctuality is assumed when the establishment has no installments due yet.
+	forecastNeutralPunctuality = 0.8
+	// forecastMinHistory is how many installments an account must have had due for its own
+	// punctuality to be used, rather than that of its establishment.
+	forecastMinHistory = 3
+	// forecastBandZ is the z-score of the 10th and 90th percentiles the forecast band spans.
+	forecastBandZ = 1.2816
+)
+
+// forecastPeriod accumulates what is due in a forecast period.
+type forecastPeriod struct {
+	start, end time.Time
+	accounts   map[uint]bool
+	scheduled  float64
+	expected   float64
+	variance   float64
+}
+
+// GetForecastReport forecasts the collections of the admin's establishment, or the selected branch,
+// for each of the next periods weeks or months (granularity week or month), in its time zone. The first
+// period runs from today to the end of the current week or month. What falls due in a period is the
+// pending installments of long-term accounts and the balance owed of short-term accounts on their next
+// due date. Each amount counts as collected with the probability its account paid installments on time
+// before, or the establishment's when the account has too short a history. The band around the expected
+// amount spans its 10th to 90th percentiles. Past-due installments and written-off accounts are left out.
+func (s *reportService) GetForecastReport(adminID, branchID uint, granularity string, periods int) (*response.ForecastReportResponse, error) {
+	if granularity == "" {
+		granularity = "week"
+	}
+	maxPeriods := MaxForecastWeeks
+	switch granularity {
+	case "week":
+		if periods == 0 {
+			periods = DefaultForecastWeeks
+		}
+	case "month":
+		maxPeriods = MaxForecastMonths
+		if periods == 0 {
+			periods = DefaultForecastMonths
+		}
+	default:
+		return nil, fmt.Errorf("%w: granularity must be week or month", ErrInvalidReportPeriod)
+	}
+	if periods < 1 || periods > maxPeriods {
+		return nil, fmt.Errorf("%w: periods must be between 1 and %d", ErrInvalidReportPeriod, maxPeriods)
+	}
+
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	accounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+	var longTermIDs []uint
+	for i := range accounts {
+		if accounts[i].CreditType == enums.LongTerm && accounts[i].WrittenOffAt == nil {
+			longTermIDs = append(longTermIDs, accounts[i].ID)
+		}
+	}
+	installmentsByAccount := make(map[uint][]entities.Installment, len(longTermIDs))
+	if len(longTermIDs) > 0 {
+		installments, err := s.installmentRepo.GetInstallmentsByCreditAccountIDs(longTermIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving installments: %w", err)
+		}
+		for _, installment := range installments {
+			installmentsByAccount[installment.CreditAccountID] = append(installmentsByAccount[installment.CreditAccountID], installment)
+		}
+	}
+
+	days, err := businessDays(s.holidayRepo, establishment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := establishmentLocation(establishment)
+	now := s.clock.Now().In(loc)
+	buckets := forecastPeriods(granularity, periods, now)
+
+	// The establishment's punctuality stands in for accounts without enough history
+	due, onTime := 0, 0
+	for _, installments := range installmentsByAccount {
+		accountDue, accountOnTime := installmentPunctuality(installments, now)
+		due, onTime = due+accountDue, onTime+accountOnTime
+	}
+	punctuality := forecastNeutralPunctuality
+	if due > 0 {
+		punctuality = float64(onTime) / float64(due)
+	}
+
+	report := &response.ForecastReportResponse{
+		EstablishmentID: establishment.ID,
+		Granularity:     granularity,
+		GeneratedAt:     now,
+		Punctuality:     math.Round(punctuality*1000) / 1000,
+		Periods:         make([]response.ForecastPeriodResponse, len(buckets)),
+	}
+	for i := range accounts {
+		account := &accounts[i]
+		if account.WrittenOffAt != nil {
+			continue
+		}
+		if account.CreditType != enums.LongTerm {
+			owed := account.CurrentBalance - account.AccountCredit
+			if owed > 0 {
+				addForecastAmount(buckets, account.ID, nextBusinessDueDate(days, now, account.MonthlyDueDate, loc), owed, punctuality)
+			}
+			continue
+		}
+
+		installments := installmentsByAccount[account.ID]
+		accountPunctuality := punctuality
+		if accountDue, accountOnTime := installmentPunctuality(installments, now); accountDue >= forecastMinHistory {
+			accountPunctuality = float64(accountOnTime) / float64(accountDue)
+		}
+		for _, installment := range installments {
+			if !installment.Status.IsOpen() {
+				continue
+			}
+			// Installments due today can still be paid on time
+			if installment.DueDate.Before(util.StartOfDayIn(now, loc)) {
+				report.OverdueBalance += installment.Amount
+				continue
+			}
+			addForecastAmount(buckets, account.ID, installment.DueDate, installment.Amount, accountPunctuality)
+		}
+	}
+	report.OverdueBalance = roundCurrency(report.OverdueBalance)
+
+	var totalVariance float64
+	for i, bucket := range buckets {
+		report.Periods[i] = response.ForecastPeriodResponse{
+			Start:           bucket.start,
+			End:             bucket.end.Add(-time.Nanosecond),
+			Accounts:        len(bucket.accounts),
+			ForecastAmounts: forecastBand(bucket.scheduled, bucket.expected, bucket.variance),
+		}
+		report.Total.Scheduled += bucket.scheduled
+		report.Total.Expected += bucket.expected
+		totalVariance += bucket.variance
+	}
+	report.Total = forecastBand(report.Total.Scheduled, report.Total.Expected, totalVariance)
+	return report, nil
+}
+
+// forecastPeriods returns the periods a forecast covers from now: the rest of the current week
+// (starting on Monday) or month, then the following ones.
+func forecastPeriods(granularity string, periods int, now time.Time) []forecastPeriod {
+	loc := now.Location()
+	today := util.StartOfDayIn(now, loc)
+	next := func(start time.Time) time.Time {
+		if granularity == "month" {
+			return time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, loc)
+		}
+		return time.Date(start.Year(), start.Month(), start.Day()+7-(int(start.Weekday())+6)%7, 0, 0, 0, 0, loc)
+	}
+
+	buckets := make([]forecastPeriod, periods)
+	start := today
+	for i := range buckets {
+		end := next(start)
+		buckets[i] = forecastPeriod{start: start, end: end, accounts: make(map[uint]bool)}
+		start = end
+	}
+	return buckets
+}
+
+// addForecastAmount adds an amount of an account due at dueDate to the period it falls in, if any, as
+// collected with probability punctuality.
+func addForecastAmount(buckets []forecastPeriod, creditAccountID uint, dueDate time.Time, amount, punctuality float64) {
+	for i := range buckets {
+		if dueDate.Before(buckets[i].start) || !dueDate.Before(buckets[i].end) {
+			continue
+		}
+		buckets[i].accounts[creditAccountID] = true
+		buckets[i].scheduled += amount
+		buckets[i].expected += amount * punctuality
+		buckets[i].variance += amount * amount * punctuality * (1 - punctuality)
+		return
+	}
+}
+
+// forecastBand returns the amounts of a period: each due amount collected or not on its own, the
+// collections are roughly normal around expected with the given variance, and never above what's due.
+func forecastBand(scheduled, expected, variance float64) response.ForecastAmounts {
+	spread := forecastBandZ * math.Sqrt(variance)
+	return response.ForecastAmounts{
+		Scheduled:   roundCurrency(scheduled),
+		Optimistic:  roundCurrency(math.Min(expected+spread, scheduled)),
+		Expected:    roundCurrency(expected),
+		Pessimistic: roundCurrency(math.Max(expected-spread, 0)),
+	}
+}
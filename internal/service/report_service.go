@@ -0,0 +1,472 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultReportPeriod is the period reports cover when none is requested.
+	DefaultReportPeriod = "month"
+	// DefaultCohortMonths and MaxCohortMonths bound how many onboarding months the cohort report covers.
+	DefaultCohortMonths = 12
+	MaxCohortMonths     = 24
+	// cohortDefaultDays is how long after a due date a client may still pay before counting as defaulted.
+	cohortDefaultDays = 30
+)
+
+// ReportService builds the business reports of an establishment.
+type ReportService interface {
+	GetProductReport(adminID, branchID uint, period string) (*response.ProductReportResponse, error)
+	ExportProductReportCSV(adminID, branchID uint, period string) ([]byte, error)
+	GetCohortReport(adminID, branchID uint, months int) (*response.CohortReportResponse, error)
+	GetBranchReport(adminID uint, period string) (*response.BranchReportResponse, error)
+	GetBadDebtReport(adminID, branchID uint) (*response.BadDebtReportResponse, error)
+	GetForecastReport(adminID, branchID uint, granularity string, periods int) (*response.ForecastReportResponse, error)
+}
+
+type reportService struct {
+	establishmentRepo repository.EstablishmentRepository
+	purchaseItemRepo  repository.PurchaseItemRepository
+	creditAccountRepo repository.CreditAccountRepository
+	transactionRepo   repository.TransactionRepository
+	installmentRepo   repository.InstallmentRepository
+	holidayRepo       repository.HolidayRepository
+	clock             util.Clock
+}
+
+// NewReportService creates a new instance of ReportService.
+func NewReportService(establishmentRepo repository.EstablishmentRepository, purchaseItemRepo repository.PurchaseItemRepository, creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository, holidayRepo repository.HolidayRepository, clock util.Clock) ReportService {
+	return &reportService{
+		establishmentRepo: establishmentRepo,
+		purchaseItemRepo:  purchaseItemRepo,
+		creditAccountRepo: creditAccountRepo,
+		transactionRepo:   transactionRepo,
+		installmentRepo:   installmentRepo,
+		holidayRepo:       holidayRepo,
+		clock:             clock,
+	}
+}
+
+// GetProductReport returns units sold, revenue, credit vs cash split and stock turnover of every
+// product of the admin's establishment (or of branch branchID) over period.
+func (s *reportService) GetProductReport(adminID, branchID uint, period string) (*response.ProductReportResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+
+	if period == "" {
+		period = DefaultReportPeriod
+	}
+	startDate, endDate, err := reportPeriodRange(period, s.clock.Now().In(establishmentLocation(establishment)))
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := s.purchaseItemRepo.GetProductSales(establishment.ID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating product sales: %w", err)
+	}
+
+	report := &response.ProductReportResponse{
+		EstablishmentID: establishment.ID,
+		Period:          period,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Products:        make([]response.ProductPerformanceResponse, len(sales)),
+	}
+	for i, sale := range sales {
+		performance := response.ProductPerformanceResponse{
+			ProductID:      sale.ProductID,
+			Name:           sale.Name,
+			Category:       sale.Category,
+			UnitsSold:      sale.UnitsSold,
+			Revenue:        roundCurrency(sale.Revenue),
+			CreditRevenue:  roundCurrency(sale.CreditRevenue),
+			CashRevenue:    roundCurrency(sale.CashRevenue),
+			TaxableRevenue: roundCurrency(sale.TaxableRevenue),
+			Tax:            roundCurrency(sale.TaxRevenue),
+			Stock:          sale.Stock,
+		}
+		if sale.Revenue > 0 {
+			performance.CreditPercentage = roundRate(sale.CreditRevenue / sale.Revenue * 100)
+		}
+		if sale.Stock > 0 {
+			performance.StockTurnover = roundRate(float64(sale.UnitsSold) / float64(sale.Stock))
+		}
+		report.Products[i] = performance
+		report.TotalRevenue += sale.Revenue
+		report.TotalTaxable += sale.TaxableRevenue
+		report.TotalTax += sale.TaxRevenue
+	}
+	report.TotalRevenue = roundCurrency(report.TotalRevenue)
+	report.TotalTaxable = roundCurrency(report.TotalTaxable)
+	report.TotalTax = roundCurrency(report.TotalTax)
+
+	return report, nil
+}
+
+// ExportProductReportCSV returns the product report as a CSV file, one row per product.
+func (s *reportService) ExportProductReportCSV(adminID, branchID uint, period string) ([]byte, error) {
+	report, err := s.GetProductReport(adminID, branchID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"product_id", "name", "category", "units_sold", "revenue", "credit_revenue", "cash_revenue", "taxable_revenue", "tax", "credit_percentage", "stock", "stock_turnover"})
+	for _, product := range report.Products {
+		_ = writer.Write([]string{
+			strconv.FormatUint(uint64(product.ProductID), 10),
+			product.Name,
+			product.Category,
+			strconv.Itoa(product.UnitsSold),
+			strconv.FormatFloat(product.Revenue, 'f', 2, 64),
+			strconv.FormatFloat(product.CreditRevenue, 'f', 2, 64),
+			strconv.FormatFloat(product.CashRevenue, 'f', 2, 64),
+			strconv.FormatFloat(product.TaxableRevenue, 'f', 2, 64),
+			strconv.FormatFloat(product.Tax, 'f', 2, 64),
+			strconv.FormatFloat(product.CreditPercentage, 'f', 2, 64),
+			strconv.Itoa(product.Stock),
+			strconv.FormatFloat(product.StockTurnover, 'f', 4, 64),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("error writing CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GetCohortReport groups the clients of the admin's establishment by the month their credit account
+// was opened and, for every due date since, counts how many of those who owed something paid on time
+// (during the billing cycle), late (within cohortDefaultDays after the due date) or not at all.
+// Failed payments don't count. months is the number of onboarding months covered, the current one included.
+func (s *reportService) GetCohortReport(adminID, branchID uint, months int) (*response.CohortReportResponse, error) {
+	if months == 0 {
+		months = DefaultCohortMonths
+	}
+	if months < 1 || months > MaxCohortMonths {
+		return nil, fmt.Errorf("%w: months must be between 1 and %d", ErrInvalidReportPeriod, MaxCohortMonths)
+	}
+
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	loc := establishmentLocation(establishment)
+	now := s.clock.Now().In(loc)
+	windowStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc).AddDate(0, -(months - 1), 0)
+
+	accounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+	transactions, err := s.transactionRepo.GetTransactionsByEstablishmentID(establishment.ID, windowStart, now)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving transactions: %w", err)
+	}
+	transactionsByAccount := make(map[uint][]entities.Transaction)
+	for _, transaction := range transactions {
+		if transaction.TransactionType == enums.Payment && transaction.PaymentStatus == enums.FAILED {
+			continue
+		}
+		transactionsByAccount[transaction.CreditAccountID] = append(transactionsByAccount[transaction.CreditAccountID], transaction)
+	}
+
+	cohorts := make(map[string]*response.CohortResponse)
+	for _, account := range accounts {
+		onboardedAt := account.CreatedAt.In(loc)
+		if onboardedAt.Before(windowStart) {
+			continue
+		}
+
+		key := onboardedAt.Format("2006-01")
+		cohort, ok := cohorts[key]
+		if !ok {
+			cohort = &response.CohortResponse{Cohort: key}
+			cohorts[key] = cohort
+		}
+		cohort.Clients++
+
+		accountTransactions := transactionsByAccount[account.ID]
+		cycleStart := onboardedAt
+		for k := 1; ; k++ {
+			monthStart := time.Date(onboardedAt.Year(), onboardedAt.Month()+time.Month(k), 1, 0, 0, 0, 0, loc)
+			dueDate := util.EndOfDayIn(util.DueDateInMonth(monthStart.Year(), monthStart.Month(), account.MonthlyDueDate, loc), loc)
+			if dueDate.After(now) {
+				break
+			}
+
+			for len(cohort.Periods) < k {
+				cohort.Periods = append(cohort.Periods, response.CohortPeriodResponse{MonthsSinceOnboarding: len(cohort.Periods) + 1})
+			}
+			recordCohortPeriod(&cohort.Periods[k-1], accountTransactions, cycleStart, dueDate, now)
+			cohort.Periods[k-1].Month = monthStart.Format("2006-01")
+			cycleStart = dueDate
+		}
+	}
+
+	report := &response.CohortReportResponse{
+		EstablishmentID: establishment.ID,
+		GeneratedAt:     now,
+		Cohorts:         make([]response.CohortResponse, 0, len(cohorts)),
+	}
+	for month := windowStart; !month.After(now); month = month.AddDate(0, 1, 0) {
+		cohort, ok := cohorts[month.Format("2006-01")]
+		if !ok {
+			continue
+		}
+		for i := range cohort.Periods {
+			period := &cohort.Periods[i]
+			if period.Eligible > 0 {
+				period.OnTimePercentage = roundRate(float64(period.OnTime) / float64(period.Eligible) * 100)
+				period.DefaultPercentage = roundRate(float64(period.Defaulted) / float64(period.Eligible) * 100)
+			}
+		}
+		report.Cohorts = append(report.Cohorts, *cohort)
+	}
+
+	return report, nil
+}
+
+// recordCohortPeriod classifies one account at the due date closing the billing cycle that started
+// at cycleStart. Accounts that owed nothing when the cycle started aren't eligible.
+func recordCohortPeriod(period *response.CohortPeriodResponse, transactions []entities.Transaction, cycleStart, dueDate, now time.Time) {
+	var owed float64
+	var paidInCycle, paidLate bool
+	defaultDate := dueDate.AddDate(0, 0, cohortDefaultDays)
+
+	for _, transaction := range transactions {
+		date := transaction.TransactionDate
+		switch transaction.TransactionType {
+		case enums.Purchase, enums.InterestCharge, enums.LateFeeCharge, enums.AdjustmentDebit:
+			if !date.After(cycleStart) {
+				owed += transaction.Amount
+			}
+		case enums.AdjustmentCredit:
+			if !date.After(cycleStart) {
+				owed -= transaction.Amount
+			}
+		case enums.Payment:
+			switch {
+			case !date.After(cycleStart):
+				owed -= transaction.Amount
+			case !date.After(dueDate):
+				paidInCycle = true
+			case !date.After(defaultDate):
+				paidLate = true
+			}
+		}
+	}
+
+	if owed <= 0.005 {
+		return
+	}
+	period.Eligible++
+	switch {
+	case paidInCycle:
+		period.OnTime++
+	case paidLate || defaultDate.After(now):
+		// Still within the default threshold, so not (yet) a default
+		period.Late++
+	default:
+		period.Defaulted++
+	}
+}
+
+// GetBranchReport puts the sales, payments and outstanding debt of the admin's main establishment and
+// each of its branches side by side, with the totals across all of them. The period is resolved in
+// the main establishment's time zone so every branch covers the same range.
+func (s *reportService) GetBranchReport(adminID uint, period string) (*response.BranchReportResponse, error) {
+	establishments, err := s.establishmentRepo.GetEstablishmentsByAdminID(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving branches: %w", err)
+	}
+	if len(establishments) == 0 {
+		return nil, fmt.Errorf("error retrieving establishment: admin %d has no establishment", adminID)
+	}
+
+	if period == "" {
+		period = DefaultReportPeriod
+	}
+	now := s.clock.Now()
+	startDate, endDate, err := reportPeriodRange(period, now.In(establishmentLocation(&establishments[0])))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &response.BranchReportResponse{
+		Period:    period,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Branches:  make([]response.BranchSummaryResponse, 0, len(establishments)),
+	}
+	for i := range establishments {
+		establishment := &establishments[i]
+		summary := response.BranchSummaryResponse{
+			EstablishmentID: establishment.ID,
+			Name:            establishment.Name,
+			IsMain:          establishment.ParentID == nil,
+		}
+
+		sales, err := s.purchaseItemRepo.GetProductSales(establishment.ID, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("error aggregating product sales: %w", err)
+		}
+		for _, sale := range sales {
+			summary.CreditSales += sale.CreditRevenue
+			summary.CashSales += sale.CashRevenue
+		}
+
+		transactions, err := s.transactionRepo.GetTransactionsByEstablishmentID(establishment.ID, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving transactions: %w", err)
+		}
+		for _, transaction := range transactions {
+			switch {
+			case transaction.TransactionType == enums.Payment && transaction.PaymentStatus != enums.FAILED:
+				summary.Payments += transaction.Amount
+			case transaction.TransactionType == enums.InterestCharge || transaction.TransactionType == enums.LateFeeCharge:
+				summary.InterestAndFees += transaction.Amount
+			case transaction.TransactionType == enums.AdjustmentDebit:
+				summary.Adjustments += transaction.Amount
+			case transaction.TransactionType == enums.AdjustmentCredit:
+				summary.Adjustments -= transaction.Amount
+			}
+		}
+
+		accounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving credit accounts: %w", err)
+		}
+		days, err := businessDays(s.holidayRepo, establishment.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range accounts {
+			// Written-off debt is in the bad-debt report instead
+			if account.WrittenOffAt != nil {
+				continue
+			}
+			summary.CreditAccounts++
+			summary.OutstandingBalance += account.CurrentBalance
+			if isAccountOverdue(account, days, now) {
+				summary.OverdueAccounts++
+			}
+		}
+
+		summary.CreditSales = roundCurrency(summary.CreditSales)
+		summary.CashSales = roundCurrency(summary.CashSales)
+		summary.Payments = roundCurrency(summary.Payments)
+		summary.InterestAndFees = roundCurrency(summary.InterestAndFees)
+		summary.Adjustments = roundCurrency(summary.Adjustments)
+		summary.OutstandingBalance = roundCurrency(summary.OutstandingBalance)
+		report.Branches = append(report.Branches, summary)
+
+		report.Totals.CreditSales += summary.CreditSales
+		report.Totals.CashSales += summary.CashSales
+		report.Totals.Payments += summary.Payments
+		report.Totals.InterestAndFees += summary.InterestAndFees
+		report.Totals.Adjustments += summary.Adjustments
+		report.Totals.OutstandingBalance += summary.OutstandingBalance
+		report.Totals.CreditAccounts += summary.CreditAccounts
+		report.Totals.OverdueAccounts += summary.OverdueAccounts
+	}
+	report.Totals.CreditSales = roundCurrency(report.Totals.CreditSales)
+	report.Totals.CashSales = roundCurrency(report.Totals.CashSales)
+	report.Totals.Payments = roundCurrency(report.Totals.Payments)
+	report.Totals.InterestAndFees = roundCurrency(report.Totals.InterestAndFees)
+	report.Totals.Adjustments = roundCurrency(report.Totals.Adjustments)
+	report.Totals.OutstandingBalance = roundCurrency(report.Totals.OutstandingBalance)
+
+	return report, nil
+}
+
+// GetBadDebtReport lists the credit accounts of the admin's establishment (or of branch branchID)
+// written off as bad debt, with what was recovered from each since.
+func (s *reportService) GetBadDebtReport(adminID, branchID uint) (*response.BadDebtReportResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	writeOffs, err := s.creditAccountRepo.GetWriteOffsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving write-offs: %w", err)
+	}
+
+	report := &response.BadDebtReportResponse{
+		EstablishmentID: establishment.ID,
+		Accounts:        make([]response.BadDebtAccountResponse, 0, len(writeOffs)),
+	}
+	for _, writeOff := range writeOffs {
+		account := response.BadDebtAccountResponse{
+			CreditAccountID: writeOff.CreditAccountID,
+			WrittenOffAt:    writeOff.CreatedAt,
+			Reason:          writeOff.Reason,
+			WrittenOffByID:  writeOff.WrittenOffByID,
+			WrittenOff:      writeOff.Amount,
+		}
+		if writeOff.CreditAccount != nil {
+			account.ClientID = writeOff.CreditAccount.ClientID
+			if writeOff.CreditAccount.Client != nil {
+				account.ClientName = writeOff.CreditAccount.Client.Name
+			}
+			account.Outstanding = roundCurrency(writeOff.CreditAccount.WrittenOffBalance)
+		}
+		account.Recovered = roundCurrency(account.WrittenOff - account.Outstanding)
+		report.Accounts = append(report.Accounts, account)
+
+		report.WrittenOff += account.WrittenOff
+		report.Recovered += account.Recovered
+		report.Outstanding += account.Outstanding
+	}
+	report.WrittenOff = roundCurrency(report.WrittenOff)
+	report.Recovered = roundCurrency(report.Recovered)
+	report.Outstanding = roundCurrency(report.Outstanding)
+	return report, nil
+}
+
+// reportPeriodRange returns the local time range a report period covers. "week", "month",
+// "quarter" and "year" run from the start of the current calendar period to now, named ranges
+// such as last_month or last_30_days cover what util.NamedDateRange says, and "YYYY-MM" and
+// "YYYY" cover that whole month or year.
+func reportPeriodRange(period string, now time.Time) (time.Time, time.Time, error) {
+	loc := now.Location()
+	today := util.StartOfDayIn(now, loc)
+
+	switch period {
+	case "week":
+		// Weeks start on Monday
+		offset := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -offset), now, nil
+	case "month":
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc), now, nil
+	case "quarter":
+		firstMonth := time.Month((int(now.Month())-1)/3*3 + 1)
+		return time.Date(now.Year(), firstMonth, 1, 0, 0, 0, 0, loc), now, nil
+	case "year":
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc), now, nil
+	}
+
+	if start, end, err := util.NamedDateRange(period, now); err == nil {
+		return start, end, nil
+	}
+	if month, err := time.ParseInLocation("2006-01", period, loc); err == nil {
+		return month, month.AddDate(0, 1, 0).Add(-time.Nanosecond), nil
+	}
+	if year, err := time.ParseInLocation("2006", period, loc); err == nil {
+		return year, year.AddDate(1, 0, 0).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("%w: %q, use week, month, quarter, year, a named range such as last_month or last_30_days, YYYY-MM or YYYY", ErrInvalidReportPeriod, period)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"fmt"
+	"time"
+)
+
+// sessionRetention is how long sessions are kept after they expire or are revoked.
+const sessionRetention = 7 * 24 * time.Hour
+
+// ClientInfo describes the device a login or refresh comes from.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// SessionService lets users see the devices they are logged in on and log them out.
+type SessionService interface {
+	GetSessions(userID, currentSessionID uint) ([]response.SessionResponse, error)
+	RevokeSession(userID, sessionID uint) error
+	PurgeEndedSessions() error
+}
+
+type sessionService struct {
+	sessionRepo repository.SessionRepository
+	clock       util.Clock
+}
+
+// NewSessionService creates a new instance of SessionService.
+func NewSessionService(sessionRepo repository.SessionRepository, clock util.Clock) SessionService {
+	return &sessionService{sessionRepo: sessionRepo, clock: clock}
+}
+
+// GetSessions retrieves the active sessions of a user, flagging the one of the request.
+func (s *sessionService) GetSessions(userID, currentSessionID uint) ([]response.SessionResponse, error) {
+	sessions, err := s.sessionRepo.GetActiveSessionsByUserID(userID, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving sessions: %w", err)
+	}
+
+	sessionResponses := make([]response.SessionResponse, len(sessions))
+	for i := range sessions {
+		sessionResponses[i] = sessionToResponse(&sessions[i], currentSessionID)
+	}
+	return sessionResponses, nil
+}
+
+// RevokeSession revokes an active session of a user. Its refresh token stops working right away;
+// access tokens already issued to it last until they expire.
+func (s *sessionService) RevokeSession(userID, sessionID uint) error {
+	revoked, err := s.sessionRepo.RevokeSession(userID, sessionID, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking session: %w", err)
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// PurgeEndedSessions deletes the sessions that expired or were revoked more than sessionRetention ago.
+func (s *sessionService) PurgeEndedSessions() error {
+	if _, err := s.sessionRepo.DeleteEndedSessions(s.clock.Now().Add(-sessionRetention)); err != nil {
+		return fmt.Errorf("error purging sessions: %w", err)
+	}
+	return nil
+}
+
+func sessionToResponse(session *entities.Session, currentSessionID uint) response.SessionResponse {
+	return response.SessionResponse{
+		ID:           session.ID,
+		Device:       util.DeviceName(session.UserAgent),
+		UserAgent:    session.UserAgent,
+		IP:           session.IP,
+		Current:      session.ID == currentSessionID,
+		CreatedAt:    session.CreatedAt,
+		LastActiveAt: session.LastActiveAt,
+		ExpiresAt:    session.ExpiresAt,
+	}
+}
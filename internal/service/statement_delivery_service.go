@@ -0,0 +1,313 @@
+package service
+
+import (
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/mail"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// statementSendWindow is how long after the closing date a statement is still sent, so a
+	// statement that couldn't be sent on time is retried but old periods aren't sent when
+	// an establishment turns the emails on.
+	statementSendWindow  = 7 * 24 * time.Hour
+	maxStatementAttempts = 3
+)
+
+// StatementDeliveryService emails clients their monthly account statement.
+type StatementDeliveryService interface {
+	SendDueStatements() error
+	GetStatementEmailSettings(adminID, branchID uint) (*response.StatementEmailSettingsResponse, error)
+	UpdateStatementEmailSettings(adminID, branchID uint, enabled bool) (*response.StatementEmailSettingsResponse, error)
+	UpdateClientStatementEmails(clientID uint, enabled bool) (*response.StatementEmailSettingsResponse, error)
+	GetEstablishmentDeliveries(adminID, branchID uint) ([]response.StatementDeliveryResponse, error)
+	GetClientDeliveries(clientID uint) ([]response.StatementDeliveryResponse, error)
+}
+
+type statementDeliveryService struct {
+	establishmentRepo repository.EstablishmentRepository
+	creditAccountRepo repository.CreditAccountRepository
+	userRepo          repository.UserRepository
+	deliveryRepo      repository.StatementDeliveryRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	purchaseService   PurchaseService
+	mailer            mail.Sender
+	jobService        JobService
+	clock             util.Clock
+}
+
+// NewStatementDeliveryService creates a new instance of StatementDeliveryService. Statements are
+// rendered and emailed by jobService's workers.
+func NewStatementDeliveryService(establishmentRepo repository.EstablishmentRepository, creditAccountRepo repository.CreditAccountRepository, userRepo repository.UserRepository, deliveryRepo repository.StatementDeliveryRepository, settingsRepo repository.EstablishmentSettingsRepository, purchaseService PurchaseService, mailer mail.Sender, jobService JobService, clock util.Clock) StatementDeliveryService {
+	s := &statementDeliveryService{
+		establishmentRepo: establishmentRepo,
+		creditAccountRepo: creditAccountRepo,
+		userRepo:          userRepo,
+		deliveryRepo:      deliveryRepo,
+		settingsRepo:      settingsRepo,
+		purchaseService:   purchaseService,
+		mailer:            mailer,
+		jobService:        jobService,
+		clock:             clock,
+	}
+	jobService.RegisterHandler(JobStatementEmail, s.runStatementEmailJob)
+	return s
+}
+
+// JobStatementEmail renders and emails the statement of one credit account for one period.
+const JobStatementEmail = "statement_email"
+
+// statementEmailPayload is the input of a JobStatementEmail job.
+type statementEmailPayload struct {
+	EstablishmentID uint      `json:"establishment_id"`
+	CreditAccountID uint      `json:"credit_account_id"`
+	PeriodEnd       time.Time `json:"period_end"`
+}
+
+// SendDueStatements queues the email of the statement of every credit account whose billing cycle
+// just closed, in the establishments that turned statement emails on and whose plan still includes
+// them. Clients who unsubscribed or
+// haven't verified their email are skipped. It is safe to run repeatedly: each statement is only queued once at a time and only sent once.
+func (s *statementDeliveryService) SendDueStatements() error {
+	establishments, err := s.establishmentRepo.GetEstablishmentsWithStatementEmails()
+	if err != nil {
+		return fmt.Errorf("error retrieving establishments: %w", err)
+	}
+
+	now := s.clock.Now()
+	failed := 0
+	for i := range establishments {
+		limits, err := establishmentLimits(s.establishmentRepo, &establishments[i])
+		if err != nil {
+			return err
+		}
+		if !limits.Allows(enums.FeatureStatementEmails) {
+			continue
+		}
+		accounts, err := s.creditAccountRepo.GetCreditAccountsByEstablishmentID(establishments[i].ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving credit accounts: %w", err)
+		}
+		for j := range accounts {
+			periodEnd, due, err := s.statementDue(&establishments[i], &accounts[j], now)
+			if err == nil && due {
+				_, err = s.jobService.EnqueueJob(JobStatementEmail, 0, fmt.Sprintf("%s:%d:%s", JobStatementEmail, accounts[j].ID, periodEnd.Format(time.RFC3339)), statementEmailPayload{
+					EstablishmentID: establishments[i].ID,
+					CreditAccountID: accounts[j].ID,
+					PeriodEnd:       periodEnd,
+				})
+			}
+			if err != nil {
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d statements could not be queued", failed)
+	}
+	return nil
+}
+
+// statementDue returns the closing date of the last statement of account and whether it still has to be sent.
+func (s *statementDeliveryService) statementDue(establishment *entities.Establishment, account *entities.CreditAccount, now time.Time) (time.Time, bool, error) {
+	if !statementRecipient(account.Client) {
+		return time.Time{}, false, nil
+	}
+
+	periodEnd := lastClosingDate(now, account.MonthlyDueDate, establishmentLocation(establishment))
+	if now.Sub(periodEnd) > statementSendWindow {
+		return periodEnd, false, nil
+	}
+
+	delivery, err := s.deliveryRepo.GetDelivery(account.ID, periodEnd)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return periodEnd, true, nil
+	case err != nil:
+		return periodEnd, false, fmt.Errorf("error retrieving statement delivery: %w", err)
+	}
+	return periodEnd, delivery.Status != enums.StatementSent && delivery.Attempts < maxStatementAttempts, nil
+}
+
+func (s *statementDeliveryService) runStatementEmailJob(data json.RawMessage) (*JobResult, error) {
+	var payload statementEmailPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("error decoding job payload: %w", err)
+	}
+	establishment, err := s.establishmentRepo.GetEstablishmentByID(payload.EstablishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving establishment: %w", err)
+	}
+	account, err := s.creditAccountRepo.GetCreditAccountByID(payload.CreditAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	return nil, s.sendStatement(establishment, account, payload.PeriodEnd)
+}
+
+// sendStatement emails the statement of account closing at periodEnd and records the attempt. It
+// checks again that the statement is due, as the job may run a while after being queued. A failed
+// email is only recorded in the delivery: SendDueStatements queues it again on its next run.
+func (s *statementDeliveryService) sendStatement(establishment *entities.Establishment, account *entities.CreditAccount, periodEnd time.Time) error {
+	if !statementRecipient(account.Client) {
+		return nil
+	}
+
+	delivery, err := s.deliveryRepo.GetDelivery(account.ID, periodEnd)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		delivery = &entities.StatementDelivery{
+			CreditAccountID: account.ID,
+			ClientID:        account.ClientID,
+			EstablishmentID: establishment.ID,
+			PeriodStart:     util.AddMonthsToDueDate(periodEnd, -1, account.MonthlyDueDate, establishmentLocation(establishment)),
+			PeriodEnd:       periodEnd,
+		}
+	case err != nil:
+		return fmt.Errorf("error retrieving statement delivery: %w", err)
+	case delivery.Status == enums.StatementSent || delivery.Attempts >= maxStatementAttempts:
+		return nil
+	}
+
+	delivery.Email = account.Client.Email
+	delivery.Attempts++
+	sendErr := s.deliver(establishment, account, delivery)
+	if sendErr != nil {
+		log.Printf("statement of credit account %d could not be sent: %v", account.ID, sendErr)
+		delivery.Status = enums.StatementFailed
+		delivery.Error = sendErr.Error()
+	} else {
+		now := s.clock.Now()
+		delivery.Status = enums.StatementSent
+		delivery.Error = ""
+		delivery.SentAt = &now
+	}
+	if err := s.deliveryRepo.SaveDelivery(delivery); err != nil {
+		return fmt.Errorf("error saving statement delivery: %w", err)
+	}
+	return nil
+}
+
+// statementRecipient reports whether statements are emailed to client: only to a verified email, so
+// they don't reach someone else's inbox, and not after the client unsubscribed.
+func statementRecipient(client *entities.User) bool {
+	return client != nil && client.EmailVerifiedAt != nil && !client.StatementEmailsOptOut
+}
+
+func (s *statementDeliveryService) deliver(establishment *entities.Establishment, account *entities.CreditAccount, delivery *entities.StatementDelivery) error {
+	lang := establishmentLanguage(s.settingsRepo, establishment.ID)
+	pdf, err := s.purchaseService.GenerateClientAccountStatementPDF(account.ClientID, establishment.ID, delivery.PeriodStart, delivery.PeriodEnd, lang)
+	if err != nil {
+		return fmt.Errorf("error generating statement: %w", err)
+	}
+
+	closing := delivery.PeriodEnd.Format("2006-01-02")
+	return s.mailer.Send(mail.Message{
+		To:      delivery.Email,
+		Subject: i18n.T(lang, "mail.statement.subject", establishment.Name, closing),
+		Body:    mailBody(lang, account.Client.Name, "mail.statement.body", establishment.Name, delivery.PeriodStart.Format("2006-01-02"), closing),
+		Attachments: []mail.Attachment{{
+			Filename:    fmt.Sprintf("statement-%s.pdf", closing),
+			ContentType: "application/pdf",
+			Data:        pdf,
+		}},
+	})
+}
+
+// GetStatementEmailSettings tells whether the admin's establishment emails monthly statements.
+func (s *statementDeliveryService) GetStatementEmailSettings(adminID, branchID uint) (*response.StatementEmailSettingsResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.StatementEmailSettingsResponse{Enabled: establishment.StatementEmailsEnabled}, nil
+}
+
+// UpdateStatementEmailSettings turns the monthly statement emails of the admin's establishment on or
+// off. They can only be turned on if the establishment's plan includes them.
+func (s *statementDeliveryService) UpdateStatementEmailSettings(adminID, branchID uint, enabled bool) (*response.StatementEmailSettingsResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		limits, err := establishmentLimits(s.establishmentRepo, establishment)
+		if err != nil {
+			return nil, err
+		}
+		if err := limits.CheckFeature(enums.FeatureStatementEmails); err != nil {
+			return nil, err
+		}
+	}
+	establishment.StatementEmailsEnabled = enabled
+	if err := s.establishmentRepo.UpdateEstablishment(establishment); err != nil {
+		return nil, fmt.Errorf("error updating establishment: %w", err)
+	}
+	return &response.StatementEmailSettingsResponse{Enabled: enabled}, nil
+}
+
+// UpdateClientStatementEmails unsubscribes a client from statement emails, or subscribes them again.
+func (s *statementDeliveryService) UpdateClientStatementEmails(clientID uint, enabled bool) (*response.StatementEmailSettingsResponse, error) {
+	client, err := s.userRepo.GetUserByID(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving client: %w", err)
+	}
+	client.StatementEmailsOptOut = !enabled
+	if err := s.userRepo.UpdateUser(client); err != nil {
+		return nil, fmt.Errorf("error updating client: %w", err)
+	}
+	return &response.StatementEmailSettingsResponse{Enabled: enabled}, nil
+}
+
+// GetEstablishmentDeliveries lists the statements sent to the clients of the admin's establishment, newest first.
+func (s *statementDeliveryService) GetEstablishmentDeliveries(adminID, branchID uint) ([]response.StatementDeliveryResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.deliveryRepo.GetDeliveriesByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving statement deliveries: %w", err)
+	}
+	return statementDeliveriesToResponse(deliveries), nil
+}
+
+// GetClientDeliveries lists the statements sent to a client, newest first.
+func (s *statementDeliveryService) GetClientDeliveries(clientID uint) ([]response.StatementDeliveryResponse, error) {
+	deliveries, err := s.deliveryRepo.GetDeliveriesByClientID(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving statement deliveries: %w", err)
+	}
+	return statementDeliveriesToResponse(deliveries), nil
+}
+
+func statementDeliveriesToResponse(deliveries []entities.StatementDelivery) []response.StatementDeliveryResponse {
+	responses := make([]response.StatementDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, response.StatementDeliveryResponse{
+			ID:              delivery.ID,
+			CreditAccountID: delivery.CreditAccountID,
+			ClientID:        delivery.ClientID,
+			EstablishmentID: delivery.EstablishmentID,
+			PeriodStart:     delivery.PeriodStart,
+			PeriodEnd:       delivery.PeriodEnd,
+			Email:           delivery.Email,
+			Status:          delivery.Status,
+			Attempts:        delivery.Attempts,
+			Error:           delivery.Error,
+			SentAt:          delivery.SentAt,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatementPeriodService closes the monthly billing cycles of credit accounts into immutable statements.
+type StatementPeriodService interface {
+	CloseDueStatementPeriods() error
+	GetClientStatements(clientID, establishmentID uint) ([]response.StatementPeriodResponse, error)
+	GetCreditAccountStatements(adminID, creditAccountID uint) ([]response.StatementPeriodResponse, error)
+}
+
+type statementPeriodService struct {
+	periodRepo        repository.StatementPeriodRepository
+	creditAccountRepo repository.CreditAccountRepository
+	transactionRepo   repository.TransactionRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+}
+
+// NewStatementPeriodService creates a new instance of StatementPeriodService.
+func NewStatementPeriodService(periodRepo repository.StatementPeriodRepository, creditAccountRepo repository.CreditAccountRepository, transactionRepo repository.TransactionRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock) StatementPeriodService {
+	return &statementPeriodService{
+		periodRepo:        periodRepo,
+		creditAccountRepo: creditAccountRepo,
+		transactionRepo:   transactionRepo,
+		establishmentRepo: establishmentRepo,
+		clock:             clock,
+	}
+}
+
+// lastClosingDate returns the most recent closing date of a billing cycle, at or before now.
+// Cycles close on the account's monthly due date.
+func lastClosingDate(now time.Time, monthlyDueDate int, loc *time.Location) time.Time {
+	closing := util.CurrentDueDate(now, monthlyDueDate, loc)
+	if closing.After(now) {
+		closing = util.AddMonthsToDueDate(now, -1, monthlyDueDate, loc)
+	}
+	return closing
+}
+
+// CloseDueStatementPeriods closes the billing cycle of every credit account whose closing date
+// has passed since its last closed period. It is safe to run repeatedly.
+func (s *statementPeriodService) CloseDueStatementPeriods() error {
+	accounts, err := s.creditAccountRepo.GetAllCreditAccounts()
+	if err != nil {
+		return fmt.Errorf("error retrieving credit accounts: %w", err)
+	}
+
+	now := s.clock.Now()
+	failed := 0
+	for i := range accounts {
+		if err := s.closePeriod(&accounts[i], now); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d statement periods could not be closed", failed)
+	}
+	return nil
+}
+
+func (s *statementPeriodService) closePeriod(account *entities.CreditAccount, now time.Time) error {
+	loc := accountLocation(account)
+	periodEnd := lastClosingDate(now, account.MonthlyDueDate, loc)
+	if !periodEnd.After(account.CreatedAt) {
+		return nil
+	}
+
+	// A period starts where the previous one ended. If closings were missed, the next period
+	// covers every cycle since the last closed one rather than guessing past balances.
+	var periodStart time.Time
+	var openingBalance float64
+	latest, err := s.periodRepo.GetLatestStatementPeriod(account.ID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		periodStart = util.AddMonthsToDueDate(periodEnd, -1, account.MonthlyDueDate, loc)
+		if periodStart.Before(account.CreatedAt) {
+			periodStart = account.CreatedAt
+		}
+		openingBalance, err = s.transactionRepo.GetBalanceBeforeDate(account.ID, periodStart)
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("error retrieving last statement period: %w", err)
+	case !latest.PeriodEnd.Before(periodEnd):
+		return nil
+	default:
+		periodStart = latest.PeriodEnd
+		openingBalance = latest.ClosingBalance
+	}
+
+	totals, err := s.transactionRepo.GetTransactionTotals(account.ID, periodStart, periodEnd)
+	if err != nil {
+		return err
+	}
+	// The closing balance is the current one without what happened since the closing date
+	since, err := s.transactionRepo.GetTransactionTotals(account.ID, periodEnd, time.Time{})
+	if err != nil {
+		return err
+	}
+	closingBalance := roundCurrency(account.CurrentBalance - account.AccountCredit - since.Purchases - since.Interest - since.LateFees - since.Adjustments + since.Payments)
+
+	_, err = s.periodRepo.CreateStatementPeriod(&entities.StatementPeriod{
+		CreditAccountID: account.ID,
+		PeriodStart:     periodStart,
+		PeriodEnd:       periodEnd,
+		OpeningBalance:  roundCurrency(openingBalance),
+		Purchases:       roundCurrency(totals.Purchases),
+		Payments:        roundCurrency(totals.Payments),
+		InterestAndFees: roundCurrency(closingBalance - openingBalance - totals.Purchases - totals.Adjustments + totals.Payments),
+		Adjustments:     roundCurrency(totals.Adjustments),
+		ClosingBalance:  closingBalance,
+		PurchaseCount:   totals.PurchaseCount,
+		PaymentCount:    totals.PaymentCount,
+		ClosedAt:        now,
+	})
+	if err != nil {
+		return fmt.Errorf("error closing statement period: %w", err)
+	}
+	return nil
+}
+
+// GetClientStatements lists the closed statements of the client's credit account, newest first.
+func (s *statementPeriodService) GetClientStatements(clientID, establishmentID uint) ([]response.StatementPeriodResponse, error) {
+	creditAccount, err := findClientCreditAccount(s.creditAccountRepo, clientID, establishmentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.statements(creditAccount.ID)
+}
+
+// GetCreditAccountStatements lists the closed statements of a credit account in one of the admin's establishments.
+func (s *statementPeriodService) GetCreditAccountStatements(adminID, creditAccountID uint) ([]response.StatementPeriodResponse, error) {
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(creditAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCreditAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if _, err := s.establishmentRepo.GetAdminEstablishment(adminID, creditAccount.EstablishmentID); err != nil {
+		return nil, ErrCreditAccountNotFound
+	}
+	return s.statements(creditAccount.ID)
+}
+
+func (s *statementPeriodService) statements(creditAccountID uint) ([]response.StatementPeriodResponse, error) {
+	periods, err := s.periodRepo.GetStatementPeriodsByCreditAccountID(creditAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving statement periods: %w", err)
+	}
+
+	responses := make([]response.StatementPeriodResponse, 0, len(periods))
+	for _, period := range periods {
+		responses = append(responses, response.StatementPeriodResponse{
+			ID:              period.ID,
+			CreditAccountID: period.CreditAccountID,
+			PeriodStart:     period.PeriodStart,
+			PeriodEnd:       period.PeriodEnd,
+			OpeningBalance:  period.OpeningBalance,
+			Purchases:       period.Purchases,
+			Payments:        period.Payments,
+			InterestAndFees: period.InterestAndFees,
+			Adjustments:     period.Adjustments,
+			ClosingBalance:  period.ClosingBalance,
+			PurchaseCount:   period.PurchaseCount,
+			PaymentCount:    period.PaymentCount,
+			ClosedAt:        period.ClosedAt,
+		})
+	}
+	return responses, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/tenant"
+	"fmt"
+)
+
+// TenantService resolves the tenant requests act in, see tenant.Scope.
+type TenantService interface {
+	ResolveTenant(userID uint, role enums.Role, impersonatedEstablishmentID uint) (tenant.Scope, error)
+}
+
+type tenantService struct {
+	establishmentRepo repository.EstablishmentRepository
+	creditAccountRepo repository.CreditAccountRepository
+}
+
+// NewTenantService creates a new instance of TenantService.
+func NewTenantService(establishmentRepo repository.EstablishmentRepository, creditAccountRepo repository.CreditAccountRepository) TenantService {
+	return &tenantService{establishmentRepo: establishmentRepo, creditAccountRepo: creditAccountRepo}
+}
+
+// ResolveTenant returns the tenant of a user: an admin's main establishment and its branches, or the
+// establishments where a client holds credit accounts, narrowed to the one an impersonation was
+// started from. Super-admins, who act on the platform, reach no tenant data through it.
+func (s *tenantService) ResolveTenant(userID uint, role enums.Role, impersonatedEstablishmentID uint) (tenant.Scope, error) {
+	switch role {
+	case enums.ADMIN:
+		establishments, err := s.establishmentRepo.GetEstablishmentsByAdminID(userID)
+		if err != nil {
+			return tenant.Scope{}, fmt.Errorf("error retrieving establishments: %w", err)
+		}
+		scope := tenant.Scope{EstablishmentIDs: make([]uint, len(establishments))}
+		for i, establishment := range establishments {
+			scope.EstablishmentIDs[i] = establishment.ID
+		}
+		return scope, nil
+	case enums.CLIENT:
+		establishmentIDs, err := s.creditAccountRepo.GetClientEstablishmentIDs(userID)
+		if err != nil {
+			return tenant.Scope{}, fmt.Errorf("error retrieving credit accounts: %w", err)
+		}
+		scope := tenant.Scope{EstablishmentIDs: establishmentIDs, ClientID: userID}
+		if impersonatedEstablishmentID != 0 {
+			scope.EstablishmentIDs = nil
+			if scope.Allows(impersonatedEstablishmentID) {
+				scope.EstablishmentIDs = []uint{impersonatedEstablishmentID}
+			}
+		}
+		return scope, nil
+	}
+	return tenant.Scope{}, nil
+}
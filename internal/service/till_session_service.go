@@ -0,0 +1,203 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/request"
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TillSessionService lets admins open and close the cash register of an establishment, reconciling
+// the cash counted in the drawer with the cash payments and sales recorded meanwhile.
+type TillSessionService interface {
+	OpenTillSession(adminID, branchID uint, req request.OpenTillSessionRequest) (*response.TillSessionResponse, error)
+	GetCurrentTillSession(adminID, branchID uint) (*response.TillSessionResponse, error)
+	GetTillSessions(adminID, branchID uint) ([]response.TillSessionResponse, error)
+	GetTillSessionReport(adminID, branchID, sessionID uint) (*response.TillSessionResponse, error)
+	CloseTillSession(adminID, branchID, sessionID uint, req request.CloseTillSessionRequest) (*response.TillSessionResponse, error)
+}
+
+type tillSessionService struct {
+	sessionRepo       repository.TillSessionRepository
+	establishmentRepo repository.EstablishmentRepository
+	clock             util.Clock
+}
+
+// NewTillSessionService creates a new instance of TillSessionService.
+func NewTillSessionService(sessionRepo repository.TillSessionRepository, establishmentRepo repository.EstablishmentRepository, clock util.Clock) TillSessionService {
+	return &tillSessionService{sessionRepo: sessionRepo, establishmentRepo: establishmentRepo, clock: clock}
+}
+
+// OpenTillSession opens the till of the admin's establishment, or the selected branch, with the cash
+// in the drawer. An establishment has one open till session at a time.
+func (s *tillSessionService) OpenTillSession(adminID, branchID uint, req request.OpenTillSessionRequest) (*response.TillSessionResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEstablishmentActive(s.establishmentRepo, establishment.ID); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	session := entities.TillSession{
+		EstablishmentID: establishment.ID,
+		OpenedByID:      adminID,
+		OpenedAt:        now,
+		OpeningCash:     roundCurrency(*req.OpeningCash),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.sessionRepo.OpenTillSession(&session); err != nil {
+		if errors.Is(err, repository.ErrTillSessionOpen) {
+			return nil, ErrTillSessionOpen
+		}
+		return nil, fmt.Errorf("error opening till session: %w", err)
+	}
+	return s.report(&session)
+}
+
+// GetCurrentTillSession reports on the open till session of the admin's establishment, or the selected branch.
+func (s *tillSessionService) GetCurrentTillSession(adminID, branchID uint) (*response.TillSessionResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.sessionRepo.GetOpenTillSession(establishment.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTillSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving till session: %w", err)
+	}
+	return s.report(session)
+}
+
+// GetTillSessions lists the till sessions of the admin's establishment, or the selected branch, newest
+// first, without their payments.
+func (s *tillSessionService) GetTillSessions(adminID, branchID uint) ([]response.TillSessionResponse, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionRepo.GetTillSessionsByEstablishmentID(establishment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving till sessions: %w", err)
+	}
+
+	responses := make([]response.TillSessionResponse, 0, len(sessions))
+	for i := range sessions {
+		totals := repository.TillSessionTotals{CashPayments: sessions[i].CashPayments, PaymentCount: sessions[i].PaymentCount, CashSales: sessions[i].CashSales}
+		if sessions[i].ClosedAt == nil {
+			if totals, err = s.sessionRepo.GetTillSessionTotals(sessions[i].ID); err != nil {
+				return nil, err
+			}
+		}
+		responses = append(responses, *tillSessionToResponse(&sessions[i], totals))
+	}
+	return responses, nil
+}
+
+// GetTillSessionReport reports on a till session of the admin's establishment, or the selected branch:
+// the cash expected in the drawer against the cash counted, and the cash payments collected.
+func (s *tillSessionService) GetTillSessionReport(adminID, branchID, sessionID uint) (*response.TillSessionResponse, error) {
+	session, err := s.findTillSession(adminID, branchID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(session)
+}
+
+// CloseTillSession closes an open till session with the cash counted in the drawer. Notes explaining
+// the discrepancy are required when the counted cash isn't the expected one.
+func (s *tillSessionService) CloseTillSession(adminID, branchID, sessionID uint, req request.CloseTillSessionRequest) (*response.TillSessionResponse, error) {
+	session, err := s.findTillSession(adminID, branchID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.ClosedAt != nil {
+		return nil, ErrTillSessionClosed
+	}
+
+	now := s.clock.Now()
+	session.ClosedByID, session.ClosedAt, session.UpdatedAt = &adminID, &now, now
+	session.CountedCash, session.Notes = roundCurrency(*req.CountedCash), strings.TrimSpace(req.Notes)
+	if err := s.sessionRepo.CloseTillSession(session); err != nil {
+		if errors.Is(err, repository.ErrTillSessionClosed) || errors.Is(err, repository.ErrDiscrepancyNotesRequired) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error closing till session: %w", err)
+	}
+	return s.report(session)
+}
+
+// findTillSession retrieves a till session of the admin's establishment, or the selected branch.
+func (s *tillSessionService) findTillSession(adminID, branchID, sessionID uint) (*entities.TillSession, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.sessionRepo.GetTillSessionByID(sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTillSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving till session: %w", err)
+	}
+	if session.EstablishmentID != establishment.ID {
+		return nil, ErrTillSessionNotFound
+	}
+	return session, nil
+}
+
+// report builds the report of a till session with its cash payments. Open sessions are summed as of now.
+func (s *tillSessionService) report(session *entities.TillSession) (*response.TillSessionResponse, error) {
+	totals := repository.TillSessionTotals{CashPayments: session.CashPayments, PaymentCount: session.PaymentCount, CashSales: session.CashSales}
+	if session.ClosedAt == nil {
+		var err error
+		if totals, err = s.sessionRepo.GetTillSessionTotals(session.ID); err != nil {
+			return nil, err
+		}
+	}
+	payments, err := s.sessionRepo.GetTillSessionPayments(session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving till session payments: %w", err)
+	}
+
+	report := tillSessionToResponse(session, totals)
+	report.Payments = make([]response.TransactionResponse, 0, len(payments))
+	for i := range payments {
+		report.Payments = append(report.Payments, *transactionToResponse(&payments[i]))
+	}
+	return report, nil
+}
+
+func tillSessionToResponse(session *entities.TillSession, totals repository.TillSessionTotals) *response.TillSessionResponse {
+	sessionResponse := &response.TillSessionResponse{
+		ID:              session.ID,
+		EstablishmentID: session.EstablishmentID,
+		Open:            session.ClosedAt == nil,
+		OpenedByID:      session.OpenedByID,
+		OpenedAt:        session.OpenedAt,
+		ClosedByID:      session.ClosedByID,
+		ClosedAt:        session.ClosedAt,
+		OpeningCash:     session.OpeningCash,
+		CashPayments:    roundCurrency(totals.CashPayments),
+		PaymentCount:    totals.PaymentCount,
+		CashSales:       roundCurrency(totals.CashSales),
+		ExpectedCash:    roundCurrency(session.OpeningCash + totals.CashPayments + totals.CashSales),
+		Notes:           session.Notes,
+	}
+	if session.ClosedAt != nil {
+		counted, discrepancy := session.CountedCash, roundCurrency(session.CountedCash-session.ExpectedCash)
+		sessionResponse.ExpectedCash = session.ExpectedCash
+		sessionResponse.CountedCash, sessionResponse.Discrepancy = &counted, &discrepancy
+	}
+	return sessionResponse
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/util"
+	"time"
+)
+
+// establishmentLocation returns the time zone of an establishment.
+func establishmentLocation(establishment *entities.Establishment) *time.Location {
+	if establishment == nil {
+		return util.LoadLocation("")
+	}
+	return util.LoadLocation(establishment.Timezone)
+}
+
+// accountLocation returns the time zone that due dates of a credit account are computed in.
+func accountLocation(account *entities.CreditAccount) *time.Location {
+	return establishmentLocation(account.Establishment)
+}
@@ -1,37 +1,68 @@
 package service
 
 import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/i18n"
 	"ApiRestFinance/internal/model/dto/request"
 	"ApiRestFinance/internal/model/dto/response"
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/repository"
 	"ApiRestFinance/internal/util"
+	"bytes"
 	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/jung-kurt/gofpdf"
 )
 
 // TransactionService handles transaction-related operations.
 type TransactionService interface {
 	CreateTransaction(req request.CreateTransactionRequest) (*response.TransactionResponse, error)
 	GetTransactionByID(id uint) (*response.TransactionResponse, error)
-	GetTransactionsByCreditAccountID(creditAccountID uint) ([]response.TransactionResponse, error)
+	GetTransactionsByCreditAccountID(creditAccountID uint, page, pageSize int) ([]response.TransactionResponse, error)
+	GetTransactionsByCreditAccountIDs(creditAccountIDs []uint) (map[uint][]response.TransactionResponse, error)
 	UpdateTransaction(id uint, req request.UpdateTransactionRequest) (*response.TransactionResponse, error)
 	DeleteTransaction(id uint) error
 	ConfirmPayment(transactionID uint, confirmationCode string) error
+	ExpirePendingPayments() error
+	SearchEstablishmentTransactions(adminID, branchID uint, req request.SearchTransactionsRequest, page, pageSize int) ([]response.EstablishmentTransactionResponse, int, error)
+	GetPaymentAllocation(transactionID uint) (*response.PaymentAllocationResponse, error)
+	GetPaymentReceiptPDF(transactionID uint, lang i18n.Language) ([]byte, error)
 }
 
+// maxPaymentCodeAttempts is how many wrong confirmation codes fail a pending payment.
+const maxPaymentCodeAttempts = 5
+
 type transactionService struct {
 	transactionRepo   repository.TransactionRepository
 	creditAccountRepo repository.CreditAccountRepository
+	establishmentRepo repository.EstablishmentRepository
+	settingsRepo      repository.EstablishmentSettingsRepository
+	installmentRepo   repository.InstallmentRepository
+	allocationRepo    repository.PaymentAllocationRepository
+	dispatcher        NotificationDispatcher
+	clock             util.Clock
+	bus               event.Bus
 }
 
-// NewTransactionService creates a new TransactionService instance.
-func NewTransactionService(transactionRepo repository.TransactionRepository, creditAccountRepo repository.CreditAccountRepository) TransactionService {
+// NewTransactionService creates a new TransactionService instance. The confirmation codes of
+// non-cash payments are sent to the clients through dispatcher.
+func NewTransactionService(transactionRepo repository.TransactionRepository, creditAccountRepo repository.CreditAccountRepository, establishmentRepo repository.EstablishmentRepository, settingsRepo repository.EstablishmentSettingsRepository, installmentRepo repository.InstallmentRepository, allocationRepo repository.PaymentAllocationRepository, dispatcher NotificationDispatcher, clock util.Clock, bus event.Bus) TransactionService {
 	return &transactionService{
 		transactionRepo:   transactionRepo,
 		creditAccountRepo: creditAccountRepo,
+		establishmentRepo: establishmentRepo,
+		settingsRepo:      settingsRepo,
+		installmentRepo:   installmentRepo,
+		allocationRepo:    allocationRepo,
+		dispatcher:        dispatcher,
+		clock:             clock,
+		bus:               bus,
 	}
 }
 
@@ -48,9 +79,14 @@ func (s *transactionService) CreateTransaction(req request.CreateTransactionRequ
 		return nil, errors.New("transaction amount must be greater than zero")
 	}
 
+	now := s.clock.Now()
 	var paymentCode string
+	var codeExpiresAt *time.Time
 	if req.PaymentMethod != enums.CASH {
-		paymentCode = util.GeneratePaymentCode()
+		paymentCode, codeExpiresAt, err = newPaymentCode(s.settingsRepo, creditAccount.EstablishmentID, now)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	transaction := entities.Transaction{
@@ -58,50 +94,84 @@ func (s *transactionService) CreateTransaction(req request.CreateTransactionRequ
 		TransactionType: req.TransactionType,
 		Amount:          req.Amount,
 		Description:     req.Description,
-		TransactionDate: time.Now(),
+		TransactionDate: now,
 		PaymentMethod:   req.PaymentMethod,
 		PaymentCode:     paymentCode,
 		PaymentStatus:   enums.PENDING,
+
+		PaymentCodeExpiresAt: codeExpiresAt,
 	}
 
 	if err := s.transactionRepo.CreateTransaction(&transaction, creditAccount); err != nil {
 		return nil, fmt.Errorf("error processing transaction: %w", err)
 	}
-	return transactionToResponse(&transaction), nil
+	publishAccountEvent(s.bus, s.clock, event.TransactionCreated, creditAccount.ID)
+
+	resp := transactionToResponse(&transaction)
+	if paymentCode != "" {
+		resp.CodeSentTo = sendPaymentCode(s.dispatcher, creditAccount, &transaction)
+	}
+	return resp, nil
 }
 
+// ConfirmPayment confirms a pending non-cash payment with the code sent to the client, see
+// repository.TransactionRepository.ConfirmPendingPayment. The code can't be tried again once the payment
+// failed.
 func (s *transactionService) ConfirmPayment(transactionID uint, confirmationCode string) error {
-	transaction, err := s.transactionRepo.GetTransactionByID(transactionID)
+	transaction, err := s.transactionRepo.ConfirmPendingPayment(transactionID, strings.TrimSpace(confirmationCode), maxPaymentCodeAttempts, s.clock.Now())
+	switch {
+	case errors.Is(err, ErrPaymentCodeExpired), errors.Is(err, ErrPaymentCodeAttempts):
+		publishAccountEvent(s.bus, s.clock, event.PaymentFailed, transaction.CreditAccountID)
+		return err
+	case err != nil:
+		return err
+	}
+	publishAccountEvent(s.bus, s.clock, event.PaymentConfirmed, transaction.CreditAccountID)
+	return nil
+}
+
+// ExpirePendingPayments fails the non-cash payments that weren't confirmed before their code
+// expired, so what they paid is owed again. It is run periodically.
+func (s *transactionService) ExpirePendingPayments() error {
+	transactions, err := s.transactionRepo.ExpirePendingPayments(s.clock.Now())
 	if err != nil {
-		return fmt.Errorf("error retrieving transaction: %w", err)
+		return fmt.Errorf("error expiring pending payments: %w", err)
 	}
-	if transaction == nil {
-		return errors.New("transaction not found")
+	for i := range transactions {
+		publishAccountEvent(s.bus, s.clock, event.PaymentFailed, transactions[i].CreditAccountID)
 	}
+	return nil
+}
 
-	// Check if the transaction is pending and the payment method is not cash
-	if transaction.PaymentStatus != enums.PENDING || transaction.PaymentMethod == enums.CASH {
-		return errors.New("transaction cannot be confirmed")
+// newPaymentCode returns the confirmation code of a non-cash payment on a credit account of an
+// establishment made at now, and when the payment expires unless confirmed with it.
+func newPaymentCode(settingsRepo repository.EstablishmentSettingsRepository, establishmentID uint, now time.Time) (string, *time.Time, error) {
+	settings, err := settingsRepo.GetEstablishmentSettings(establishmentID)
+	if err != nil {
+		return "", nil, fmt.Errorf("error retrieving establishment settings: %w", err)
 	}
+	expiresAt := now.Add(time.Duration(settings.PaymentCodeMinutes) * time.Minute)
+	return util.GeneratePaymentCode(), &expiresAt, nil
+}
 
-	// Validate the confirmation code against the generated PaymentCode
-	if transaction.PaymentCode != confirmationCode {
-		transaction.PaymentStatus = enums.FAILED
-		if err := s.transactionRepo.UpdateTransaction(transaction, nil); err != nil {
-			return fmt.Errorf("error updating transaction: %w", err)
-		}
-
-		if transaction.PaymentCode == "" {
-			transaction.PaymentCode = util.GeneratePaymentCode()
-		}
-		return errors.New("invalid confirmation code")
+// sendPaymentCode sends the client of a credit account the code to confirm a payment with, and
+// returns the channels it reached them on. The payment is kept when the code can't be sent; it
+// expires unconfirmed.
+func sendPaymentCode(dispatcher NotificationDispatcher, creditAccount *entities.CreditAccount, transaction *entities.Transaction) []enums.ContactChannel {
+	establishmentName := ""
+	if creditAccount.Establishment != nil {
+		establishmentName = creditAccount.Establishment.Name
 	}
-
-	// Update the transaction status to SUCCESS
-	transaction.PaymentStatus = enums.SUCCESS
-	transaction.ConfirmationCode = confirmationCode
-
-	return s.transactionRepo.UpdateTransaction(transaction, nil)
+	channels, err := dispatcher.Dispatch(Notification{
+		Kind:    enums.PaymentCodeNotification,
+		Account: creditAccount,
+		Args: []interface{}{transaction.Amount, establishmentName, transaction.PaymentCode,
+			transaction.PaymentCodeExpiresAt.In(accountLocation(creditAccount)).Format("15:04")},
+	})
+	if err != nil {
+		log.Printf("confirmation code of transaction %d could not be sent: %v", transaction.ID, err)
+	}
+	return channels
 }
 
 func (s *transactionService) GetTransactionByID(id uint) (*response.TransactionResponse, error) {
@@ -116,8 +186,11 @@ func (s *transactionService) GetTransactionByID(id uint) (*response.TransactionR
 	return transactionToResponse(transaction), nil
 }
 
-func (s *transactionService) GetTransactionsByCreditAccountID(creditAccountID uint) ([]response.TransactionResponse, error) {
-	transactions, err := s.transactionRepo.GetTransactionsByCreditAccountID(creditAccountID)
+func (s *transactionService) GetTransactionsByCreditAccountID(creditAccountID uint, page, pageSize int) ([]response.TransactionResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	transactions, err := s.transactionRepo.GetTransactionsByCreditAccountIDPaged(creditAccountID, (page-1)*pageSize, pageSize)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving transactions: %w", err)
 	}
@@ -130,6 +203,20 @@ func (s *transactionService) GetTransactionsByCreditAccountID(creditAccountID ui
 	return transactionResponses, nil
 }
 
+// GetTransactionsByCreditAccountIDs retrieves the transactions of several credit accounts at once, grouped by account and newest first.
+func (s *transactionService) GetTransactionsByCreditAccountIDs(creditAccountIDs []uint) (map[uint][]response.TransactionResponse, error) {
+	transactions, err := s.transactionRepo.GetTransactionsByCreditAccountIDs(creditAccountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving transactions: %w", err)
+	}
+
+	byAccount := make(map[uint][]response.TransactionResponse, len(creditAccountIDs))
+	for i := range transactions {
+		byAccount[transactions[i].CreditAccountID] = append(byAccount[transactions[i].CreditAccountID], *transactionToResponse(&transactions[i]))
+	}
+	return byAccount, nil
+}
+
 func (s *transactionService) UpdateTransaction(id uint, req request.UpdateTransactionRequest) (*response.TransactionResponse, error) {
 	transaction, err := s.transactionRepo.GetTransactionByID(id)
 	if err != nil {
@@ -138,6 +225,9 @@ func (s *transactionService) UpdateTransaction(id uint, req request.UpdateTransa
 	if transaction == nil {
 		return nil, errors.New("transaction not found")
 	}
+	if transaction.TransactionType.IsAdjustment() || req.TransactionType.IsAdjustment() {
+		return nil, ErrAdjustmentTransaction
+	}
 
 	// Retrieve the credit account
 	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(transaction.CreditAccountID)
@@ -163,6 +253,7 @@ func (s *transactionService) UpdateTransaction(id uint, req request.UpdateTransa
 	if err := s.transactionRepo.UpdateTransaction(transaction, creditAccount); err != nil {
 		return nil, fmt.Errorf("error updating transaction: %w", err)
 	}
+	publishAccountEvent(s.bus, s.clock, event.TransactionUpdated, creditAccount.ID)
 
 	return transactionToResponse(transaction), nil
 }
@@ -175,6 +266,9 @@ func (s *transactionService) DeleteTransaction(id uint) error {
 	if transaction == nil {
 		return errors.New("transaction not found")
 	}
+	if transaction.TransactionType.IsAdjustment() {
+		return ErrAdjustmentTransaction
+	}
 
 	// Retrieve the credit account to adjust the balance
 	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(transaction.CreditAccountID)
@@ -189,10 +283,255 @@ func (s *transactionService) DeleteTransaction(id uint) error {
 	if err := s.transactionRepo.DeleteTransaction(id, creditAccount); err != nil {
 		return fmt.Errorf("error deleting transaction: %w", err)
 	}
+	publishAccountEvent(s.bus, s.clock, event.TransactionDeleted, creditAccount.ID)
 
 	return nil
 }
 
+// SearchEstablishmentTransactions retrieves one page of the transactions of the admin's establishment
+// that match req, along with the number of matches across all pages. Newest transactions come first
+// unless req asks for another order.
+func (s *transactionService) SearchEstablishmentTransactions(adminID, branchID uint, req request.SearchTransactionsRequest, page, pageSize int) ([]response.EstablishmentTransactionResponse, int, error) {
+	establishment, err := adminEstablishment(s.establishmentRepo, adminID, branchID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	// Dates without an offset and named ranges are in the establishment's time zone
+	startDate, endDate, err := util.ParseDateRange(req.StartDate, req.EndDate, req.Range, s.clock.Now().In(establishmentLocation(establishment)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	search := repository.TransactionSearch{
+		EstablishmentID: establishment.ID,
+		Client:          strings.TrimSpace(req.Client),
+		TransactionType: req.TransactionType,
+		PaymentMethod:   req.PaymentMethod,
+		PaymentStatus:   req.PaymentStatus,
+		MinAmount:       req.MinAmount,
+		MaxAmount:       req.MaxAmount,
+		StartDate:       startDate,
+		Text:            strings.TrimSpace(req.Query),
+		SortBy:          req.Sort,
+		Descending:      req.Order != "asc",
+		Offset:          (page - 1) * pageSize,
+		Limit:           pageSize,
+	}
+	if !endDate.IsZero() {
+		// The search excludes its end
+		search.EndDate = endDate.Add(time.Nanosecond)
+	}
+
+	transactions, total, err := s.transactionRepo.SearchTransactions(search)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error searching transactions: %w", err)
+	}
+
+	results := make([]response.EstablishmentTransactionResponse, 0, len(transactions))
+	for i := range transactions {
+		result := response.EstablishmentTransactionResponse{TransactionResponse: *transactionToResponse(&transactions[i])}
+		if account := transactions[i].CreditAccount; account != nil && account.Client != nil {
+			result.ClientID = account.Client.ID
+			result.ClientName = account.Client.Name
+			result.ClientDNI = account.Client.DNI
+		}
+		results = append(results, result)
+	}
+	return results, int(total), nil
+}
+
+// GetPaymentAllocation returns the installments a payment paid and those left to pay after it, as
+// they stood when it was made: later payments and installments are not counted.
+func (s *transactionService) GetPaymentAllocation(transactionID uint) (*response.PaymentAllocationResponse, error) {
+	transaction, err := s.transactionRepo.GetTransactionByID(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving transaction: %w", err)
+	}
+	if transaction.TransactionType != enums.Payment {
+		return nil, ErrNotPayment
+	}
+
+	allocations, err := s.allocationRepo.GetAllocationsByTransactionID(transaction.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving payment allocations: %w", err)
+	}
+	allocated, err := s.allocationRepo.GetAllocatedAmounts(transaction.CreditAccountID, transaction.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving payment allocations: %w", err)
+	}
+	installments, err := s.installmentRepo.GetInstallmentsByCreditAccountID(transaction.CreditAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving installments: %w", err)
+	}
+	sort.SliceStable(installments, func(i, j int) bool {
+		if !installments[i].DueDate.Equal(installments[j].DueDate) {
+			return installments[i].DueDate.Before(installments[j].DueDate)
+		}
+		return installments[i].ID < installments[j].ID
+	})
+	byID := make(map[uint]*entities.Installment, len(installments))
+	for i := range installments {
+		byID[installments[i].ID] = &installments[i]
+	}
+
+	resp := &response.PaymentAllocationResponse{
+		TransactionID:     transaction.ID,
+		CreditAccountID:   transaction.CreditAccountID,
+		DocumentNumber:    transaction.DocumentNumber,
+		TransactionDate:   transaction.TransactionDate,
+		PaymentMethod:     transaction.PaymentMethod,
+		PaymentStatus:     transaction.PaymentStatus,
+		Amount:            transaction.Amount,
+		PaidInstallments:  []response.AllocatedInstallmentResponse{},
+		RemainingSchedule: []response.RemainingInstallmentResponse{},
+	}
+	for _, allocation := range allocations {
+		installment, ok := byID[allocation.InstallmentID]
+		if !ok {
+			// Deleted since
+			continue
+		}
+		outstanding := roundCurrency(installment.Amount - allocated[installment.ID])
+		resp.AllocatedAmount += allocation.Amount
+		resp.PaidInstallments = append(resp.PaidInstallments, response.AllocatedInstallmentResponse{
+			InstallmentID: installment.ID,
+			DueDate:       installment.DueDate,
+			Amount:        installment.Amount,
+			Allocated:     allocation.Amount,
+			Outstanding:   outstanding,
+			Paid:          outstanding <= 0,
+		})
+	}
+	resp.AllocatedAmount = roundCurrency(resp.AllocatedAmount)
+	resp.UnallocatedAmount = roundCurrency(transaction.Amount - resp.AllocatedAmount)
+
+	for _, installment := range installments {
+		if !installment.Status.IsOpen() || installment.CreatedAt.After(transaction.CreatedAt) {
+			continue
+		}
+		outstanding := roundCurrency(installment.Amount - allocated[installment.ID])
+		if outstanding <= 0 {
+			continue
+		}
+		resp.RemainingAmount += outstanding
+		resp.RemainingSchedule = append(resp.RemainingSchedule, response.RemainingInstallmentResponse{
+			InstallmentID: installment.ID,
+			DueDate:       installment.DueDate,
+			Amount:        installment.Amount,
+			Outstanding:   outstanding,
+			Status:        installment.Status,
+		})
+	}
+	resp.RemainingAmount = roundCurrency(resp.RemainingAmount)
+	if len(resp.RemainingSchedule) > 0 {
+		next := resp.RemainingSchedule[0]
+		resp.NextDueDate = &next.DueDate
+		resp.NextDueAmount = next.Outstanding
+	}
+	return resp, nil
+}
+
+// GetPaymentReceiptPDF renders the receipt of a payment, with the installments it paid and those
+// left to pay after it, in lang or, if it is empty, in the language of the account's establishment.
+func (s *transactionService) GetPaymentReceiptPDF(transactionID uint, lang i18n.Language) ([]byte, error) {
+	allocation, err := s.GetPaymentAllocation(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	creditAccount, err := s.creditAccountRepo.GetCreditAccountByID(allocation.CreditAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving credit account: %w", err)
+	}
+	if lang == "" {
+		lang = establishmentLanguage(s.settingsRepo, creditAccount.EstablishmentID)
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	label := func(key string, args ...interface{}) string { return tr(i18n.T(lang, key, args...)) }
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(40, 10, label("pdf.receipt.title"))
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "", 12)
+	if creditAccount.Establishment != nil {
+		pdf.CellFormat(0, 8, tr(creditAccount.Establishment.Name), "", 1, "L", false, 0, "")
+	}
+	if creditAccount.Client != nil {
+		pdf.CellFormat(0, 8, label("pdf.receipt.client", creditAccount.Client.Name), "", 1, "L", false, 0, "")
+	}
+	if allocation.DocumentNumber != "" {
+		pdf.CellFormat(0, 8, label("pdf.receipt.number", allocation.DocumentNumber), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 8, label("pdf.receipt.date", allocation.TransactionDate.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	pdf.CellFormat(90, 8, label("pdf.receipt.payment_method", i18n.Label(lang, "payment_method", string(allocation.PaymentMethod))), "", 0, "L", false, 0, "")
+	pdf.CellFormat(90, 8, label("pdf.receipt.status", i18n.Label(lang, "payment_status", string(allocation.PaymentStatus))), "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 10, label("pdf.receipt.amount", allocation.Amount), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	// Installments paid
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, label("pdf.receipt.paid_installments"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	if len(allocation.PaidInstallments) == 0 {
+		pdf.CellFormat(0, 8, label("pdf.receipt.no_installments_paid"), "", 1, "L", false, 0, "")
+	} else {
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(40, 8, label("pdf.receipt.due_date"), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 8, label("pdf.receipt.installment"), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 8, label("pdf.receipt.paid"), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 8, label("pdf.receipt.outstanding"), "1", 1, "R", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		for _, installment := range allocation.PaidInstallments {
+			pdf.CellFormat(40, 8, installment.DueDate.Format("2006-01-02"), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(40, 8, fmt.Sprintf("%.2f", installment.Amount), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(40, 8, fmt.Sprintf("%.2f", installment.Allocated), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(40, 8, fmt.Sprintf("%.2f", installment.Outstanding), "1", 1, "R", false, 0, "")
+		}
+	}
+	if allocation.UnallocatedAmount > 0 {
+		pdf.CellFormat(0, 8, label("pdf.receipt.unallocated", allocation.UnallocatedAmount), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Installments left to pay
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, label("pdf.receipt.remaining_schedule"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	if len(allocation.RemainingSchedule) == 0 {
+		pdf.CellFormat(0, 8, label("pdf.receipt.no_installments_left"), "", 1, "L", false, 0, "")
+	} else {
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(40, 8, label("pdf.receipt.due_date"), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 8, label("pdf.receipt.installment"), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 8, label("pdf.receipt.outstanding"), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 8, label("pdf.receipt.installment_status"), "1", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		for _, installment := range allocation.RemainingSchedule {
+			pdf.CellFormat(40, 8, installment.DueDate.Format("2006-01-02"), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(40, 8, fmt.Sprintf("%.2f", installment.Amount), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(40, 8, fmt.Sprintf("%.2f", installment.Outstanding), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(40, 8, tr(i18n.Label(lang, "installment_status", string(installment.Status))), "1", 1, "L", false, 0, "")
+		}
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 8, label("pdf.receipt.remaining_amount", allocation.RemainingAmount), "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, label("pdf.receipt.next_due", allocation.NextDueAmount, allocation.NextDueDate.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("error rendering payment receipt PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 func transactionToResponse(transaction *entities.Transaction) *response.TransactionResponse {
 	return &response.TransactionResponse{
 		ID:              transaction.ID,
@@ -202,9 +541,19 @@ func transactionToResponse(transaction *entities.Transaction) *response.Transact
 		Description:     transaction.Description,
 		TransactionDate: transaction.TransactionDate,
 		PaymentMethod:   transaction.PaymentMethod,
-		PaymentCode:     transaction.PaymentCode,
 		PaymentStatus:   transaction.PaymentStatus,
+		DocumentNumber:  transaction.DocumentNumber,
 		CreatedAt:       transaction.CreatedAt,
 		UpdatedAt:       transaction.UpdatedAt,
+
+		PaymentCodeExpiresAt: pendingPaymentExpiry(transaction),
+	}
+}
+
+// pendingPaymentExpiry returns when a transaction expires unless confirmed, nil once it isn't pending.
+func pendingPaymentExpiry(transaction *entities.Transaction) *time.Time {
+	if transaction.PaymentStatus != enums.PENDING {
+		return nil
 	}
+	return transaction.PaymentCodeExpiresAt
 }
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"ApiRestFinance/internal/event"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestTransactionServiceGetPaymentAllocation(t *testing.T) {
+	loc := util.LoadLocation("America/Lima")
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, loc)
+	}
+	installment := func(id uint, dueDate time.Time, status enums.InstallmentStatus) entities.Installment {
+		installment := *fixture.Installment(100, dueDate)
+		installment.Model = gorm.Model{ID: id, CreatedAt: fixture.Now.AddDate(0, 0, -1)}
+		installment.Status = status
+		return installment
+	}
+	const paymentID uint = 50
+	type allocation struct {
+		installmentID uint
+		amount        float64
+	}
+	type remaining struct {
+		installmentID uint
+		outstanding   float64
+	}
+	tests := []struct {
+		name            string
+		amount          float64
+		installments    []entities.Installment // As they are now, in no particular order
+		allocations     []allocation           // Of the payment
+		allocated       map[uint]float64       // By it and the payments before
+		wantPaid        []allocation
+		wantUnallocated float64
+		wantRemaining   []remaining
+	}{
+		{
+			name:   "into the next installment",
+			amount: 150,
+			installments: []entities.Installment{
+				installment(3, day(time.May, 15), enums.Pending),
+				installment(1, day(time.March, 15), enums.Paid),
+				installment(2, day(time.April, 15), enums.PartiallyPaid),
+			},
+			allocations:   []allocation{{1, 100}, {2, 50}},
+			allocated:     map[uint]float64{1: 100, 2: 50},
+			wantPaid:      []allocation{{1, 100}, {2, 50}},
+			wantRemaining: []remaining{{2, 50}, {3, 100}},
+		},
+		{
+			name:   "the rest of an installment paid before",
+			amount: 60,
+			installments: []entities.Installment{
+				installment(1, day(time.March, 15), enums.Paid),
+				installment(2, day(time.April, 15), enums.Pending),
+			},
+			allocations:   []allocation{{1, 60}},
+			allocated:     map[uint]float64{1: 100},
+			wantPaid:      []allocation{{1, 60}},
+			wantRemaining: []remaining{{2, 100}},
+		},
+		{
+			name:   "beyond every installment",
+			amount: 250,
+			installments: []entities.Installment{
+				installment(1, day(time.March, 15), enums.Paid),
+				installment(2, day(time.April, 15), enums.Paid),
+			},
+			allocations:     []allocation{{1, 100}, {2, 100}},
+			allocated:       map[uint]float64{1: 100, 2: 100},
+			wantPaid:        []allocation{{1, 100}, {2, 100}},
+			wantUnallocated: 50,
+		},
+		{
+			name:   "cancelled installments and those of later purchases aren't left to pay",
+			amount: 100,
+			installments: []entities.Installment{
+				installment(1, day(time.March, 15), enums.Paid),
+				installment(2, day(time.April, 15), enums.Cancelled),
+				func() entities.Installment {
+					later := installment(3, day(time.May, 15), enums.Pending)
+					later.CreatedAt = fixture.Now.Add(time.Hour)
+					return later
+				}(),
+			},
+			allocations: []allocation{{1, 100}},
+			allocated:   map[uint]float64{1: 100},
+			wantPaid:    []allocation{{1, 100}},
+		},
+		{
+			name:   "deleted installments are left out",
+			amount: 100,
+			installments: []entities.Installment{
+				installment(2, day(time.April, 15), enums.Pending),
+			},
+			allocations:     []allocation{{1, 100}},
+			allocated:       map[uint]float64{1: 100},
+			wantUnallocated: 100,
+			wantRemaining:   []remaining{{2, 100}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			transactions := mocks.NewMockTransactionRepository(ctrl)
+			installments := mocks.NewMockInstallmentRepository(ctrl)
+			allocations := mocks.NewMockPaymentAllocationRepository(ctrl)
+			payment := fixture.Payment(tt.amount)
+			payment.Model = gorm.Model{ID: paymentID, CreatedAt: fixture.Now}
+			transactions.EXPECT().GetTransactionByID(paymentID).Return(payment, nil)
+			var rows []entities.PaymentAllocation
+			for _, a := range tt.allocations {
+				rows = append(rows, entities.PaymentAllocation{TransactionID: paymentID, InstallmentID: a.installmentID, CreditAccountID: fixture.CreditAccountID, Amount: a.amount})
+			}
+			allocations.EXPECT().GetAllocationsByTransactionID(paymentID).Return(rows, nil)
+			allocations.EXPECT().GetAllocatedAmounts(fixture.CreditAccountID, paymentID).Return(tt.allocated, nil)
+			installments.EXPECT().GetInstallmentsByCreditAccountID(fixture.CreditAccountID).Return(tt.installments, nil)
+			s := NewTransactionService(transactions, mocks.NewMockCreditAccountRepository(ctrl), mocks.NewMockEstablishmentRepository(ctrl),
+				mocks.NewMockEstablishmentSettingsRepository(ctrl), installments, allocations, nil, util.NewFakeClock(fixture.Now), event.NewInMemoryBus())
+
+			got, err := s.GetPaymentAllocation(paymentID)
+			if err != nil {
+				t.Fatalf("GetPaymentAllocation returned %v", err)
+			}
+			var paid []allocation
+			for _, installment := range got.PaidInstallments {
+				paid = append(paid, allocation{installment.InstallmentID, installment.Allocated})
+				if installment.Paid != (installment.Outstanding <= 0) {
+					t.Errorf("installment %d paid %t with %.2f outstanding", installment.InstallmentID, installment.Paid, installment.Outstanding)
+				}
+			}
+			var left []remaining
+			for _, installment := range got.RemainingSchedule {
+				left = append(left, remaining{installment.InstallmentID, installment.Outstanding})
+			}
+			if !reflect.DeepEqual(paid, tt.wantPaid) {
+				t.Errorf("paid %v, want %v", paid, tt.wantPaid)
+			}
+			if !reflect.DeepEqual(left, tt.wantRemaining) {
+				t.Errorf("left to pay %v, want %v", left, tt.wantRemaining)
+			}
+			if got.UnallocatedAmount != tt.wantUnallocated || roundCurrency(got.AllocatedAmount+got.UnallocatedAmount) != tt.amount {
+				t.Errorf("allocated %.2f and unallocated %.2f of %.2f, want %.2f unallocated", got.AllocatedAmount, got.UnallocatedAmount, tt.amount, tt.wantUnallocated)
+			}
+			if len(tt.wantRemaining) == 0 {
+				if got.NextDueDate != nil || got.RemainingAmount != 0 {
+					t.Errorf("next due %v with %.2f left, want nothing left", got.NextDueDate, got.RemainingAmount)
+				}
+			} else if next := tt.wantRemaining[0]; got.NextDueAmount != next.outstanding || got.NextDueDate == nil {
+				t.Errorf("next due %v of %.2f, want %.2f", got.NextDueDate, got.NextDueAmount, next.outstanding)
+			}
+		})
+	}
+}
+
+func TestTransactionServiceGetPaymentAllocationOfAPurchase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	transactions := mocks.NewMockTransactionRepository(ctrl)
+	transactions.EXPECT().GetTransactionByID(uint(50)).Return(fixture.Purchase(100), nil)
+	s := NewTransactionService(transactions, mocks.NewMockCreditAccountRepository(ctrl), mocks.NewMockEstablishmentRepository(ctrl),
+		mocks.NewMockEstablishmentSettingsRepository(ctrl), mocks.NewMockInstallmentRepository(ctrl), mocks.NewMockPaymentAllocationRepository(ctrl),
+		nil, util.NewFakeClock(fixture.Now), event.NewInMemoryBus())
+
+	if _, err := s.GetPaymentAllocation(50); !errors.Is(err, ErrNotPayment) {
+		t.Errorf("GetPaymentAllocation = %v, want %v", err, ErrNotPayment)
+	}
+}
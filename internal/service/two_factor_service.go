@@ -0,0 +1,247 @@
+package service
+
+import (
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// twoFactorIssuer names the account in authenticator apps
+	twoFactorIssuer    = "ApiRestFinance"
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10 // Characters, written as two groups of five
+	// recoveryCodeAlphabet leaves out characters that are easily mistaken for others (0/o, 1/l/i)
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	// maxTwoFactorAttempts is how many wrong codes in a row lock the second factor of a user
+	maxTwoFactorAttempts = 5
+	// twoFactorLockout is longer than login challenges last, so no challenge outlives a lockout
+	twoFactorLockout = 15 * time.Minute
+)
+
+// TwoFactorService handles the TOTP two-factor authentication of users.
+type TwoFactorService interface {
+	SetupTwoFactor(userID uint) (*response.TwoFactorSetupResponse, error)
+	EnableTwoFactor(userID uint, code string) (*response.RecoveryCodesResponse, error)
+	DisableTwoFactor(userID uint, code string) error
+	RegenerateRecoveryCodes(userID uint, code string) (*response.RecoveryCodesResponse, error)
+	VerifyTwoFactor(user *entities.User, code string) error
+	TwoFactorRequired(user *entities.User) (bool, error)
+}
+
+type twoFactorService struct {
+	userRepo      repository.UserRepository
+	twoFactorRepo repository.TwoFactorRepository
+	clock         util.Clock
+}
+
+// NewTwoFactorService creates a new instance of TwoFactorService.
+func NewTwoFactorService(userRepo repository.UserRepository, twoFactorRepo repository.TwoFactorRepository, clock util.Clock) TwoFactorService {
+	return &twoFactorService{userRepo: userRepo, twoFactorRepo: twoFactorRepo, clock: clock}
+}
+
+// SetupTwoFactor generates a new secret for a user who hasn't enabled two-factor authentication.
+// It is only used once EnableTwoFactor confirms the user's app generates the right codes.
+func (s *twoFactorService) SetupTwoFactor(userID uint) (*response.TwoFactorSetupResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.SetTwoFactorSecret(user.ID, secret); err != nil {
+		return nil, fmt.Errorf("error setting up two-factor authentication: %w", err)
+	}
+	return &response.TwoFactorSetupResponse{
+		Secret:     secret,
+		OtpauthURL: util.TOTPURL(twoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor turns on two-factor authentication once code shows the user's app was set up
+// with their secret, and returns their recovery codes.
+func (s *twoFactorService) EnableTwoFactor(userID uint, code string) (*response.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	switch {
+	case user.TwoFactorEnabled:
+		return nil, ErrTwoFactorAlreadyEnabled
+	case user.TwoFactorSecret == "":
+		return nil, ErrTwoFactorNotSetUp
+	}
+
+	step, ok := util.VerifyTOTP(user.TwoFactorSecret, strings.TrimSpace(code), s.clock.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.EnableTwoFactor(user.ID, step, hashes); err != nil {
+		return nil, fmt.Errorf("error enabling two-factor authentication: %w", err)
+	}
+	return &response.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication, unless an establishment of the user requires it.
+func (s *twoFactorService) DisableTwoFactor(userID uint, code string) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("error retrieving user: %w", err)
+	}
+	if err := s.VerifyTwoFactor(user, code); err != nil {
+		return err
+	}
+	required, err := s.TwoFactorRequired(user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrTwoFactorRequired
+	}
+
+	if err := s.twoFactorRepo.DisableTwoFactor(user.ID); err != nil {
+		return fmt.Errorf("error disabling two-factor authentication: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user, used or not, with new ones.
+func (s *twoFactorService) RegenerateRecoveryCodes(userID uint, code string) (*response.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	if err := s.VerifyTwoFactor(user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		return nil, fmt.Errorf("error replacing recovery codes: %w", err)
+	}
+	return &response.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// VerifyTwoFactor checks a code of the user's authenticator app or one of their unused recovery
+// codes. Either can only be used once. Five wrong codes in a row lock the second factor for 15
+// minutes, and ErrTwoFactorLocked is returned meanwhile.
+func (s *twoFactorService) VerifyTwoFactor(user *entities.User, code string) error {
+	if !user.TwoFactorEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	now := s.clock.Now()
+	if twoFactorLocked(user, now) {
+		return ErrTwoFactorLocked
+	}
+	started, err := s.twoFactorRepo.StartTwoFactorAttempt(user.ID, maxTwoFactorAttempts, now, now.Add(twoFactorLockout))
+	if err != nil {
+		return fmt.Errorf("error recording two-factor attempt: %w", err)
+	}
+	if !started {
+		return ErrTwoFactorLocked // By guesses made since the user was read
+	}
+
+	ok, err := s.checkTwoFactorCode(user, strings.TrimSpace(code), now)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	if err := s.twoFactorRepo.ClearTwoFactorAttempts(user.ID); err != nil {
+		return fmt.Errorf("error recording two-factor attempt: %w", err)
+	}
+	return nil
+}
+
+// checkTwoFactorCode reports whether code is a TOTP code of a period after the last one accepted,
+// which it then accepts, or an unused recovery code, which it marks used.
+func (s *twoFactorService) checkTwoFactorCode(user *entities.User, code string, now time.Time) (bool, error) {
+	if step, ok := util.VerifyTOTP(user.TwoFactorSecret, code, now); ok {
+		advanced, err := s.twoFactorRepo.AdvanceTwoFactorStep(user.ID, step)
+		if err != nil {
+			return false, fmt.Errorf("error verifying two-factor code: %w", err)
+		}
+		return advanced, nil
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(user.ID, hashRecoveryCode(code), now)
+	if err != nil {
+		return false, fmt.Errorf("error verifying recovery code: %w", err)
+	}
+	return used, nil
+}
+
+// twoFactorLocked reports whether too many wrong codes lock the second factor of user at now.
+func twoFactorLocked(user *entities.User, now time.Time) bool {
+	return user.TwoFactorLockedUntil != nil && now.Before(*user.TwoFactorLockedUntil)
+}
+
+// TwoFactorRequired reports whether an establishment requires the user to use two-factor authentication.
+// Super-admins always have to.
+func (s *twoFactorService) TwoFactorRequired(user *entities.User) (bool, error) {
+	if user.Rol == enums.SUPERADMIN {
+		return true, nil
+	}
+	if user.Rol != enums.ADMIN {
+		return false, nil
+	}
+	required, err := s.twoFactorRepo.AdminRequiresTwoFactor(user.ID)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving two-factor policy: %w", err)
+	}
+	return required, nil
+}
+
+// generateRecoveryCodes returns new recovery codes, formatted to be shown to the user, and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := range codes {
+		var code strings.Builder
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				code.WriteByte('-')
+			}
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error generating recovery codes: %w", err)
+			}
+			code.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+		codes[i] = code.String()
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hashes a recovery code as entered, ignoring case, spaces and dashes.
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"ApiRestFinance/internal/repository/mocks"
+	"ApiRestFinance/internal/testutil/fixture"
+	"ApiRestFinance/internal/util"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTwoFactorServiceVerifyTwoFactor(t *testing.T) {
+	currentCode, err := util.TOTPCode(testTOTPSecret, fixture.Now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentStep := fixture.Now.Unix() / 30
+	const recoveryCode = "abcde-fghjk"
+	lockedUntil := fixture.Now.Add(time.Minute)
+	lockExpired := fixture.Now.Add(-time.Minute)
+
+	type outcome struct {
+		started  bool // What StartTwoFactorAttempt reports, if it is called
+		advance  *bool
+		recovery *bool
+		cleared  bool
+	}
+	yes, no := true, false
+	tests := []struct {
+		name        string
+		lockedUntil *time.Time
+		code        string
+		expect      *outcome // Nil if the repository isn't called at all
+		want        error
+	}{
+		{"current TOTP code", nil, currentCode, &outcome{started: true, advance: &yes, cleared: true}, nil},
+		{"TOTP code with spaces around", nil, " " + currentCode + " ", &outcome{started: true, advance: &yes, cleared: true}, nil},
+		{"TOTP code of a period already used", nil, currentCode, &outcome{started: true, advance: &no}, ErrInvalidTwoFactorCode},
+		{"unused recovery code", nil, recoveryCode, &outcome{started: true, recovery: &yes, cleared: true}, nil},
+		{"used recovery code", nil, recoveryCode, &outcome{started: true, recovery: &no}, ErrInvalidTwoFactorCode},
+		{"wrong code", nil, "000000", &outcome{started: true, recovery: &no}, ErrInvalidTwoFactorCode},
+		{"locked", &lockedUntil, currentCode, nil, ErrTwoFactorLocked},
+		{"locked by guesses since the user was read", nil, currentCode, &outcome{started: false}, ErrTwoFactorLocked},
+		{"lockout over", &lockExpired, currentCode, &outcome{started: true, advance: &yes, cleared: true}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockTwoFactorRepository(ctrl)
+			if e := tt.expect; e != nil {
+				repo.EXPECT().StartTwoFactorAttempt(fixture.ClientID, maxTwoFactorAttempts, fixture.Now, fixture.Now.Add(twoFactorLockout)).Return(e.started, nil)
+				if e.advance != nil {
+					repo.EXPECT().AdvanceTwoFactorStep(fixture.ClientID, currentStep).Return(*e.advance, nil)
+				}
+				if e.recovery != nil {
+					repo.EXPECT().UseRecoveryCode(fixture.ClientID, hashRecoveryCode(tt.code), fixture.Now).Return(*e.recovery, nil)
+				}
+				if e.cleared {
+					repo.EXPECT().ClearTwoFactorAttempts(fixture.ClientID).Return(nil)
+				}
+			}
+			user := fixture.Client().TwoFactor(testTOTPSecret).Build()
+			user.TwoFactorLockedUntil = tt.lockedUntil
+			s := NewTwoFactorService(mocks.NewMockUserRepository(ctrl), repo, util.NewFakeClock(fixture.Now))
+
+			if err := s.VerifyTwoFactor(user, tt.code); !errors.Is(err, tt.want) {
+				t.Errorf("VerifyTwoFactor = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTwoFactorServiceVerifyTwoFactorWithoutTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewTwoFactorService(mocks.NewMockUserRepository(ctrl), mocks.NewMockTwoFactorRepository(ctrl), util.NewFakeClock(fixture.Now))
+
+	if err := s.VerifyTwoFactor(fixture.Client().Build(), "000000"); !errors.Is(err, ErrTwoFactorNotEnabled) {
+		t.Errorf("VerifyTwoFactor = %v, want %v", err, ErrTwoFactorNotEnabled)
+	}
+}
+
+func TestTwoFactorServiceLocksAfterTooManyWrongCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockTwoFactorRepository(ctrl)
+	clock := util.NewFakeClock(fixture.Now)
+	user := fixture.Client().TwoFactor(testTOTPSecret).Build()
+	// Stands in for the counting the repository does in SQL
+	repo.EXPECT().StartTwoFactorAttempt(fixture.ClientID, maxTwoFactorAttempts, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ uint, maxAttempts int, now, lockUntil time.Time) (bool, error) {
+			if twoFactorLocked(user, now) {
+				return false, nil
+			}
+			user.TwoFactorFailedAttempts++
+			if user.TwoFactorFailedAttempts >= maxAttempts {
+				user.TwoFactorFailedAttempts = 0
+				user.TwoFactorLockedUntil = &lockUntil
+			}
+			return true, nil
+		}).AnyTimes()
+	repo.EXPECT().UseRecoveryCode(fixture.ClientID, gomock.Any(), gomock.Any()).Return(false, nil).Times(maxTwoFactorAttempts)
+	s := NewTwoFactorService(mocks.NewMockUserRepository(ctrl), repo, clock)
+	stale := *user // As read by a request before the guesses, so only the repository knows of the lock
+
+	for i := 0; i < maxTwoFactorAttempts; i++ {
+		if err := s.VerifyTwoFactor(&stale, "000000"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Fatalf("wrong code %d: VerifyTwoFactor = %v, want %v", i+1, err, ErrInvalidTwoFactorCode)
+		}
+	}
+	right, err := util.TOTPCode(testTOTPSecret, clock.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VerifyTwoFactor(&stale, right); !errors.Is(err, ErrTwoFactorLocked) {
+		t.Errorf("right code after %d wrong ones: VerifyTwoFactor = %v, want %v", maxTwoFactorAttempts, err, ErrTwoFactorLocked)
+	}
+	if err := s.VerifyTwoFactor(user, right); !errors.Is(err, ErrTwoFactorLocked) {
+		t.Errorf("right code for the user read again: VerifyTwoFactor = %v, want %v", err, ErrTwoFactorLocked)
+	}
+
+	clock.Advance(twoFactorLockout)
+	right, err = util.TOTPCode(testTOTPSecret, clock.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.EXPECT().AdvanceTwoFactorStep(fixture.ClientID, clock.Now().Unix()/30).Return(true, nil)
+	repo.EXPECT().ClearTwoFactorAttempts(fixture.ClientID).Return(nil)
+	if err := s.VerifyTwoFactor(user, right); err != nil {
+		t.Errorf("right code once the lockout is over: VerifyTwoFactor = %v", err)
+	}
+}
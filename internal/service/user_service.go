@@ -6,16 +6,12 @@ import (
 	"ApiRestFinance/internal/model/entities"
 	"ApiRestFinance/internal/model/entities/enums"
 	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/util"
 	"errors"
 	"fmt"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
-	"io"
 	"mime/multipart"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
 )
 
 // UserService handles user-related operations.
@@ -23,21 +19,29 @@ type UserService interface {
 	CreateClient(req request.CreateClientRequest) (*response.UserResponse, error)
 	GetUserByID(userID uint) (*response.UserResponse, error)
 	UpdateUser(userID uint, req request.UpdateUserRequest) (*response.UserResponse, error)
+	PatchUser(userID uint, req request.PatchUserRequest) (*response.UserResponse, error)
 	DeleteUser(userID uint) error
-	GetClientsByEstablishmentID(establishmentID uint) ([]entities.User, error)
+	GetClientsByEstablishmentID(establishmentID uint, tags []string) ([]entities.User, error)
 	UploadUserPhoto(photo *multipart.FileHeader, userID uint) (string, error)
-	UpdatePassword(userID uint, newPassword string) error
+	UpdatePassword(userID, sessionID uint, newPassword string) error
 	GetUserIDByEmail(email string) (uint, error)
 }
 
 type userService struct {
-	userRepo          repository.UserRepository
-	creditAccountRepo repository.CreditAccountRepository
+	userRepo            repository.UserRepository
+	creditAccountRepo   repository.CreditAccountRepository
+	settingsRepo        repository.EstablishmentSettingsRepository
+	templateRepo        repository.CreditTemplateRepository
+	sessionRepo         repository.SessionRepository
+	verificationService ContactVerificationService
+	clock               util.Clock
+	imageUploader       *ImageUploader
 }
 
-// NewUserService creates a new instance of UserService.
-func NewUserService(userRepo repository.UserRepository, creditAccountRepo repository.CreditAccountRepository) UserService {
-	return &userService{userRepo: userRepo, creditAccountRepo: creditAccountRepo}
+// NewUserService creates a new instance of UserService. New and changed contact details are
+// verified by verificationService.
+func NewUserService(userRepo repository.UserRepository, creditAccountRepo repository.CreditAccountRepository, settingsRepo repository.EstablishmentSettingsRepository, templateRepo repository.CreditTemplateRepository, sessionRepo repository.SessionRepository, verificationService ContactVerificationService, clock util.Clock, imageUploader *ImageUploader) UserService {
+	return &userService{userRepo: userRepo, creditAccountRepo: creditAccountRepo, settingsRepo: settingsRepo, templateRepo: templateRepo, sessionRepo: sessionRepo, verificationService: verificationService, clock: clock, imageUploader: imageUploader}
 }
 
 // GetUserIDByEmail retrieves a user ID by their email address.
@@ -73,24 +77,51 @@ func (s *userService) CreateClient(req request.CreateClientRequest) (*response.U
 		MonthlyDueDate:          req.MonthlyDueDate,
 		InterestRate:            req.InterestRate,
 		InterestType:            req.InterestType,
+		CompoundingPeriod:       req.CompoundingPeriod,
 		CreditType:              req.CreditType,
 		GracePeriod:             req.GracePeriod,
 		IsBlocked:               false,
-		LastInterestAccrualDate: time.Now(),
+		LastInterestAccrualDate: s.clock.Now(),
 		CurrentBalance:          0.0,
 		LateFeePercentage:       req.LateFeePercentage,
 	}
+	if err := applyCreditTemplate(s.templateRepo, req.CreditTemplateID, creditAccount); err != nil {
+		return nil, err
+	}
+	interestRate, err := interestRateOrDefault(s.settingsRepo, req.EstablishmentID, creditAccount.InterestRate)
+	if err != nil {
+		return nil, err
+	}
+	creditAccount.InterestRate = interestRate
+	creditAccount.CompoundingPeriod = compoundingPeriodOrDefault(creditAccount.CompoundingPeriod)
+
+	// A client that already has an account in another establishment only gets a new credit account
+	existing, err := s.userRepo.GetUserByEmail(req.Email)
+	if err == nil {
+		if existing.Rol != enums.CLIENT || existing.DNI != req.DNI {
+			return nil, ErrEmailInUse
+		}
+		if _, err := s.creditAccountRepo.GetCreditAccountByClientAndEstablishmentID(existing.ID, req.EstablishmentID); err == nil {
+			return nil, ErrCreditAccountExists
+		}
+		creditAccount.ClientID = existing.ID
+		if err := s.creditAccountRepo.CreateCreditAccount(creditAccount); err != nil {
+			return nil, fmt.Errorf("error creating credit account: %w", err)
+		}
+		return _NewUserResponse(existing), nil
+	}
 
 	// Use the CreditAccountRepository to handle the creation in a transaction
 	if err := s.creditAccountRepo.CreateClientAndCreditAccount(user, creditAccount); err != nil {
 		return nil, fmt.Errorf("error during client creation: %w", err)
 	}
+	s.verificationService.StartVerification(user, enums.ContactEmail, enums.ContactPhone)
 
 	return _NewUserResponse(user), nil
 }
 
-// UpdatePassword updates the user's password.
-func (s *userService) UpdatePassword(userID uint, newPassword string) error {
+// UpdatePassword updates the user's password and logs them out of their other sessions, keeping sessionID.
+func (s *userService) UpdatePassword(userID, sessionID uint, newPassword string) error {
 	// Hash the new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
@@ -101,6 +132,9 @@ func (s *userService) UpdatePassword(userID uint, newPassword string) error {
 	if err := s.userRepo.UpdatePassword(userID, string(hashedPassword)); err != nil {
 		return fmt.Errorf("error updating password: %w", err)
 	}
+	if err := s.sessionRepo.RevokeUserSessions(userID, sessionID, s.clock.Now()); err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
 	return nil
 }
 
@@ -115,29 +149,38 @@ func (s *userService) GetUserByID(userID uint) (*response.UserResponse, error) {
 
 // UpdateUser updates an existing user.
 func (s *userService) UpdateUser(userID uint, req request.UpdateUserRequest) (*response.UserResponse, error) {
+	return s.PatchUser(userID, req.Patch())
+}
+
+// PatchUser changes the fields of a user the patch sets. A new phone has to be verified again, with
+// the code sent to it.
+func (s *userService) PatchUser(userID uint, req request.PatchUserRequest) (*response.UserResponse, error) {
 	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving user: %w", err)
 	}
 
-	// Update user fields
-	if req.Name != "" {
-		user.Name = req.Name
+	if req.Name != nil {
+		user.Name = *req.Name
 	}
-	if req.Address != "" {
-		user.Address = req.Address
+	if req.Address != nil {
+		user.Address = *req.Address
 	}
-	if req.Phone != "" {
-		user.Phone = req.Phone
+	phoneChanged := req.Phone != nil && *req.Phone != user.Phone
+	if phoneChanged {
+		user.Phone = *req.Phone
+		user.PhoneVerifiedAt = nil
 	}
-	// Update the PhotoUrl if provided
-	if req.PhotoUrl != "" {
-		user.PhotoUrl = req.PhotoUrl
+	if req.PhotoUrl != nil {
+		user.PhotoUrl = *req.PhotoUrl
 	}
 
 	if err := s.userRepo.UpdateUser(user); err != nil {
 		return nil, fmt.Errorf("error updating user: %w", err)
 	}
+	if phoneChanged {
+		s.verificationService.StartVerification(user, enums.ContactPhone)
+	}
 
 	return NewUserResponse(user), nil
 }
@@ -150,77 +193,26 @@ func (s *userService) DeleteUser(userID uint) error {
 
 // GetClientsByEstablishmentID retrieves all users with the CLIENT role
 // associated with a specific establishment.
-func (s *userService) GetClientsByEstablishmentID(establishmentID uint) ([]entities.User, error) {
-	return s.userRepo.GetClientsByEstablishmentID(establishmentID)
+func (s *userService) GetClientsByEstablishmentID(establishmentID uint, tags []string) ([]entities.User, error) {
+	return s.userRepo.GetClientsByEstablishmentID(establishmentID, tags)
 }
 
-// UploadUserPhoto handles the actual photo upload to the server.
+// UploadUserPhoto validates, resizes and stores a user's profile photo, then saves its URL on the user.
 func (s *userService) UploadUserPhoto(photo *multipart.FileHeader, userID uint) (string, error) {
-	// 1. File Type Validation (Only allow images)
-	allowedFileTypes := []string{".jpg", ".jpeg", ".png", ".gif"}
-
-	fileExt := strings.ToLower(filepath.Ext(photo.Filename))
-	isValidFileType := false
-	for _, allowedType := range allowedFileTypes {
-		if fileExt == allowedType {
-			isValidFileType = true
-			break
-		}
-	}
-	if !isValidFileType {
-		return "", ErrInvalidFileType
-	}
-
-	// 2. File Size Validation (Example: Limit to 2MB)
-	if photo.Size > 2*1024*1024 {
-		return "", ErrFileSizeTooLarge
-	}
-
-	// 3. Create the images directory if it doesn't exist
-	imagesDir := "images_user"
-	if _, err := os.Stat(imagesDir); os.IsNotExist(err) {
-		err := os.Mkdir(imagesDir, 0755)
-		if err != nil {
-			return "", err
-		}
-	}
-
-	// 4. Generate a unique filename for the image (you can use UUIDs or any other method)
-	newFilename := fmt.Sprintf("%d%s", userID, fileExt)
-
-	// 5. Create the full path to the image file
-	imagePath := filepath.Join(imagesDir, newFilename)
-
-	// 6. Open the uploaded photo file
-	file, err := photo.Open()
+	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
-		return "", fmt.Errorf("error opening photo file: %w", err)
+		return "", fmt.Errorf("error retrieving user: %w", err)
 	}
-	defer func(file multipart.File) {
-		err := file.Close()
-		if err != nil {
-			fmt.Println("error closing file:", err)
-		}
-	}(file)
 
-	// 7. Create the destination file
-	dst, err := os.Create(imagePath)
+	imagePath, err := s.imageUploader.save(photo, "images_user", fmt.Sprintf("%d", userID))
 	if err != nil {
-		return "", fmt.Errorf("error creating image file: %w", err)
+		return "", err
 	}
-	defer func(dst *os.File) {
-		err := dst.Close()
-		if err != nil {
-			fmt.Println("error closing destination file:", err)
-		}
-	}(dst)
 
-	// 8. Copy the uploaded file contents to the destination file
-	if _, err := io.Copy(dst, file); err != nil {
-		return "", fmt.Errorf("error copying photo: %w", err)
+	user.PhotoUrl = imagePath
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return "", fmt.Errorf("error saving photo URL: %w", err)
 	}
-
-	// 9. Return the relative URL of the uploaded image
 	return imagePath, nil
 }
 
@@ -236,7 +228,7 @@ func _NewUserResponse(user *entities.User) *response.UserResponse {
 		Name:      user.Name,
 		Address:   user.Address,
 		Phone:     user.Phone,
-		PhotoUrl:  user.PhotoUrl,
+		PhotoUrl:  util.UserPhotoURL(user.PhotoUrl, user.Name),
 		Rol:       user.Rol,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
@@ -0,0 +1,47 @@
+// Package sms sends text messages, through Twilio or to the log in development.
+package sms
+
+import (
+	"log"
+	"net/url"
+)
+
+// Message is a text message to a phone number.
+type Message struct {
+	// From is the number or sender ID the message comes from. Empty uses the sender's default.
+	From string
+	To   string
+	Body string
+}
+
+// Sender delivers messages.
+type Sender interface {
+	// Send hands msg to the provider and returns the ID it gave the message, which its status
+	// reports refer to. Senders without status reports return an empty ID.
+	Send(msg Message) (string, error)
+}
+
+// StatusReport is the delivery status of a sent message, as reported by the provider.
+type StatusReport struct {
+	MessageID string
+	Status    string // The provider's name of the status, e.g. Twilio's delivered or undelivered
+	ErrorCode string // Why the message wasn't delivered, if it wasn't
+}
+
+// StatusReports reads the status reports the provider posts to the API.
+type StatusReports interface {
+	// ParseStatusReport authenticates the form of a status report with the signature sent along.
+	ParseStatusReport(signature string, form url.Values) (*StatusReport, error)
+}
+
+type logSender struct{}
+
+// NewLogSender creates a Sender that only logs the messages, for environments without an SMS provider.
+func NewLogSender() Sender {
+	return logSender{}
+}
+
+func (logSender) Send(msg Message) (string, error) {
+	log.Printf("SMS to %s: %d characters", msg.To, len(msg.Body))
+	return "", nil
+}
@@ -0,0 +1,120 @@
+package sms
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const twilioAPI = "https://api.twilio.com/2010-04-01/Accounts/"
+
+// ErrInvalidSignature is returned for status reports that weren't signed by the provider.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// TwilioSender sends messages through Twilio's Programmable Messaging API and reads the status
+// reports Twilio posts to the status callback of each message.
+type TwilioSender struct {
+	accountSID     string
+	authToken      string
+	from           string
+	statusCallback string
+	http           *http.Client
+}
+
+// NewTwilioSender creates a TwilioSender for an account. Messages without a From are sent from
+// from, a number, alphanumeric sender ID or messaging service SID (MG...). Twilio posts the status
+// of each message to statusCallback, the public URL of the API's status webhook, unless it's empty.
+func NewTwilioSender(accountSID, authToken, from, statusCallback string, timeout time.Duration) *TwilioSender {
+	return &TwilioSender{
+		accountSID:     accountSID,
+		authToken:      authToken,
+		from:           from,
+		statusCallback: statusCallback,
+		http:           &http.Client{Timeout: timeout},
+	}
+}
+
+// Send queues msg in Twilio and returns its message SID.
+func (s *TwilioSender) Send(msg Message) (string, error) {
+	form := url.Values{"To": {msg.To}, "Body": {msg.Body}}
+	from := msg.From
+	if from == "" {
+		from = s.from
+	}
+	if strings.HasPrefix(from, "MG") {
+		form.Set("MessagingServiceSid", from)
+	} else {
+		form.Set("From", from)
+	}
+	if s.statusCallback != "" {
+		form.Set("StatusCallback", s.statusCallback)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, twilioAPI+url.PathEscape(s.accountSID)+"/Messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error creating Twilio request: %w", err)
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error sending SMS: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("error reading Twilio response: %w", err)
+	}
+
+	var answer struct {
+		SID     string `json:"sid"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &answer); err != nil {
+		return "", fmt.Errorf("Twilio answered %s with an invalid response: %w", resp.Status, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("Twilio answered %s with error %d: %s", resp.Status, answer.Code, answer.Message)
+	}
+	return answer.SID, nil
+}
+
+// ParseStatusReport reads the status report Twilio posted to the status callback, checking its
+// X-Twilio-Signature: the base64 HMAC-SHA1, keyed with the auth token, of the callback URL followed
+// by every parameter name and value, sorted by name.
+func (s *TwilioSender) ParseStatusReport(signature string, form url.Values) (*StatusReport, error) {
+	names := make([]string, 0, len(form))
+	for name := range form {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mac := hmac.New(sha1.New, []byte(s.authToken))
+	io.WriteString(mac, s.statusCallback)
+	for _, name := range names {
+		for _, value := range form[name] {
+			io.WriteString(mac, name+value)
+		}
+	}
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if s.statusCallback == "" || !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	return &StatusReport{
+		MessageID: form.Get("MessageSid"),
+		Status:    form.Get("MessageStatus"),
+		ErrorCode: form.Get("ErrorCode"),
+	}, nil
+}
@@ -0,0 +1,367 @@
+// Package tenant isolates the data of the establishments sharing the API. A Scope is the tenant a
+// request acts in, resolved once per request; a database session carrying one in its context only
+// reaches the rows of that tenant, whichever query a repository runs on it, so data of another
+// establishment can't be read or written by construction. Work no tenant is behind, like the
+// scheduled jobs, runs on a System session instead; sessions that are neither can't reach tenant
+// data at all, so a repository built on the bare database fails rather than leaking it.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrOutsideTenant is returned when a record of another establishment is created, or a record
+	// moved to another establishment, through a tenant-scoped session.
+	ErrOutsideTenant = errors.New("record belongs to another establishment")
+	// ErrUnscopedQuery is returned for raw SQL run through a tenant-scoped session, which can't be
+	// scoped to the tenant.
+	ErrUnscopedQuery = errors.New("raw SQL can't be scoped to a tenant")
+	// ErrNoTenant is returned for statements on tenant data, and raw SQL, run through a session that
+	// is neither tenant-scoped nor a System session.
+	ErrNoTenant = errors.New("tenant data queried without a tenant")
+)
+
+// Scope is the tenant a request acts in. Admins act in their main establishment and its branches;
+// clients in the establishments where they hold credit accounts, and only on their own records. The
+// zero Scope reaches no tenant data at all.
+type Scope struct {
+	EstablishmentIDs []uint // Establishments whose records are reachable
+	ClientID         uint   // Set for clients, whose records are the only ones reachable
+}
+
+// Allows reports whether the scope reaches an establishment.
+func (s Scope) Allows(establishmentID uint) bool {
+	for _, id := range s.EstablishmentIDs {
+		if id == establishmentID {
+			return true
+		}
+	}
+	return false
+}
+
+type (
+	contextKey struct{}
+	systemKey  struct{}
+)
+
+// NewContext returns a copy of ctx carrying scope.
+func NewContext(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, contextKey{}, scope)
+}
+
+// FromContext returns the scope ctx carries, if any.
+func FromContext(ctx context.Context) (Scope, bool) {
+	if ctx == nil {
+		return Scope{}, false
+	}
+	scope, ok := ctx.Value(contextKey{}).(Scope)
+	return scope, ok
+}
+
+// Session returns a session of db scoped to scope, for a repository to run every query on.
+func Session(db *gorm.DB, scope Scope) *gorm.DB {
+	return db.WithContext(NewContext(db.Statement.Context, scope))
+}
+
+// System returns a session of db reaching the data of every tenant, for the work no tenant is behind:
+// the scheduled jobs and their workers, the resolution of the tenant itself, the public routes, which
+// are authenticated otherwise, and the platform routes of super-admins. A Session of it is scoped.
+func System(db *gorm.DB) *gorm.DB {
+	return db.WithContext(systemContext(db.Statement.Context))
+}
+
+func systemContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+func isSystem(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
+
+// statementScope returns the scope a statement runs in, and whether it has one. Statements of System
+// sessions have none and are left as they are; those of other sessions fail with ErrNoTenant when
+// they could reach tenant data: raw SQL, statements on no known table, and those on tenant tables.
+func statementScope(db *gorm.DB) (Scope, bool) {
+	stmt := db.Statement
+	if db.Error != nil {
+		return Scope{}, false
+	}
+	if scope, ok := FromContext(stmt.Context); ok {
+		return scope, true
+	}
+	if !isSystem(stmt.Context) && (stmt.SQL.Len() > 0 || stmt.Schema == nil || owned(stmt.Schema)) {
+		_ = db.AddError(ErrNoTenant)
+	}
+	return Scope{}, false
+}
+
+// owned reports whether the rows of a table belong to a tenant: establishments, and the tables with
+// an establishment, a credit account or a client.
+func owned(s *schema.Schema) bool {
+	return s.Table == "establishments" || s.LookUpField("EstablishmentID") != nil || s.LookUpField("CreditAccountID") != nil ||
+		s.LookUpField("ClientID") != nil
+}
+
+// Register installs on db the callbacks that scope the statements of tenant-scoped sessions. Reads,
+// updates and deletes only match the rows of the tenant: establishments by ID, tables with an
+// establishment by it, tables with a client by it for clients, and tables of credit accounts by
+// their account's establishment and client. Creates of records outside the tenant fail with
+// ErrOutsideTenant, as do updates moving records out of it, and raw SQL with ErrUnscopedQuery.
+// Statements of System sessions are left as they are, and those of sessions with neither a scope nor
+// the System mark fail with ErrNoTenant when they could reach tenant data.
+func Register(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:scope_query", scopeStatement); err != nil {
+		return fmt.Errorf("error registering tenant scope: %w", err)
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant:scope_row", scopeStatement); err != nil {
+		return fmt.Errorf("error registering tenant scope: %w", err)
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:scope_update", scopeStatement); err != nil {
+		return fmt.Errorf("error registering tenant scope: %w", err)
+	}
+	if err := callbacks.Update().Before("gorm:begin_transaction").Register("tenant:check_update", checkUpdate); err != nil {
+		return fmt.Errorf("error registering tenant scope: %w", err)
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenant:scope_delete", scopeStatement); err != nil {
+		return fmt.Errorf("error registering tenant scope: %w", err)
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("tenant:refuse_raw", refuseRaw); err != nil {
+		return fmt.Errorf("error registering tenant scope: %w", err)
+	}
+	if err := callbacks.Create().Before("gorm:begin_transaction").Register("tenant:check_create", checkCreate); err != nil {
+		return fmt.Errorf("error registering tenant scope: %w", err)
+	}
+	return nil
+}
+
+// scopeStatement restricts a statement of a tenant-scoped session to the rows of the tenant.
+func scopeStatement(db *gorm.DB) {
+	stmt := db.Statement
+	scope, ok := statementScope(db)
+	if !ok {
+		return
+	}
+	if stmt.SQL.Len() > 0 {
+		_ = db.AddError(ErrUnscopedQuery)
+		return
+	}
+	if stmt.Schema == nil {
+		return
+	}
+	if condition := scopeCondition(stmt.Schema, scope); condition != nil {
+		addCondition(stmt, condition)
+	}
+}
+
+// refuseRaw fails raw SQL run through a tenant-scoped session, or one without the System mark. The
+// savepoints of nested transactions, which GORM runs as raw SQL, reach no data and are let through.
+func refuseRaw(db *gorm.DB) {
+	if savepoint.MatchString(db.Statement.SQL.String()) {
+		return
+	}
+	if _, ok := statementScope(db); ok {
+		_ = db.AddError(ErrUnscopedQuery)
+	}
+}
+
+var savepoint = regexp.MustCompile(`^(SAVEPOINT|ROLLBACK TO SAVEPOINT|RELEASE SAVEPOINT) \w+$`)
+
+// scopeCondition returns the condition matching the rows of a table within scope, or nil for tables
+// that don't belong to a tenant, like users, which clients share across establishments.
+func scopeCondition(s *schema.Schema, scope Scope) clause.Expression {
+	// An empty list of establishments is rendered as IN (NULL), which matches no row
+	var conditions []clause.Expression
+	switch {
+	case s.Table == "establishments":
+		conditions = append(conditions, clause.IN{Column: clause.PrimaryColumn, Values: values(scope.EstablishmentIDs)})
+	case s.LookUpField("EstablishmentID") != nil:
+		conditions = append(conditions, clause.IN{Column: column("establishment_id"), Values: values(scope.EstablishmentIDs)})
+	case s.LookUpField("CreditAccountID") != nil:
+		accounts := "SELECT id FROM credit_accounts WHERE establishment_id IN ?"
+		vars := []interface{}{column("credit_account_id"), scope.EstablishmentIDs}
+		if scope.ClientID != 0 {
+			accounts += " AND client_id = ?"
+			vars = append(vars, scope.ClientID)
+		}
+		conditions = append(conditions, clause.Expr{SQL: "? IN (" + accounts + ")", Vars: vars})
+	}
+	if scope.ClientID != 0 && s.LookUpField("ClientID") != nil {
+		conditions = append(conditions, clause.Eq{Column: column("client_id"), Value: scope.ClientID})
+	}
+	if len(conditions) == 0 {
+		return nil
+	}
+	return clause.And(conditions...)
+}
+
+// addCondition adds condition to the WHERE clause of stmt, grouping the conditions already there so
+// an OR among them can't reach past it.
+func addCondition(stmt *gorm.Statement, condition clause.Expression) {
+	where, _ := stmt.Clauses["WHERE"].Expression.(clause.Where)
+	exprs := []clause.Expression{condition}
+	if len(where.Exprs) > 0 {
+		exprs = []clause.Expression{clause.And(where.Exprs...), condition}
+	}
+	c := stmt.Clauses["WHERE"]
+	c.Name = "WHERE"
+	c.Expression = clause.Where{Exprs: exprs}
+	stmt.Clauses["WHERE"] = c
+}
+
+// checkCreate fails the creation, through a tenant-scoped session, of records of another
+// establishment, of another client, or of a credit account outside the tenant.
+func checkCreate(db *gorm.DB) {
+	checkOwners(db, false)
+}
+
+// checkUpdate fails updates, through a tenant-scoped session, that would move records to another
+// establishment, client or credit account outside the tenant.
+func checkUpdate(db *gorm.DB) {
+	checkOwners(db, true)
+}
+
+// checkOwners checks the establishment, client and credit account the records written by a statement
+// belong to. Updates only check those they set.
+func checkOwners(db *gorm.DB, update bool) {
+	stmt := db.Statement
+	scope, ok := statementScope(db)
+	if !ok || stmt.Schema == nil {
+		return
+	}
+
+	establishmentField := stmt.Schema.LookUpField("EstablishmentID")
+	clientField := stmt.Schema.LookUpField("ClientID")
+	accountField := stmt.Schema.LookUpField("CreditAccountID")
+	if stmt.Schema.Table == "credit_accounts" {
+		accountField = nil // Checked by its establishment
+	}
+	var accountIDs []uint
+	check := func(owner func(field *schema.Field) (uint, bool)) error {
+		if id, ok := owner(establishmentField); ok && !scope.Allows(id) {
+			return ErrOutsideTenant
+		}
+		if id, ok := owner(clientField); ok && scope.ClientID != 0 && id != scope.ClientID {
+			return ErrOutsideTenant
+		}
+		if id, ok := owner(accountField); ok {
+			accountIDs = append(accountIDs, id)
+		}
+		return nil
+	}
+
+	var err error
+	if values, ok := stmt.Dest.(map[string]interface{}); ok && update {
+		err = check(func(field *schema.Field) (uint, bool) {
+			if field == nil {
+				return 0, false
+			}
+			value, ok := values[field.DBName]
+			if !ok {
+				value, ok = values[field.Name]
+			}
+			id, known := toUint(value)
+			return id, ok && known
+		})
+	} else {
+		records := stmt.ReflectValue
+		if update {
+			records = reflect.ValueOf(stmt.Dest)
+		}
+		err = eachRecord(records, func(record reflect.Value) error {
+			return check(func(field *schema.Field) (uint, bool) {
+				if field == nil {
+					return 0, false
+				}
+				value, _ := field.ValueOf(stmt.Context, record)
+				id, _ := toUint(value)
+				return id, !update || id != 0
+			})
+		})
+	}
+	if err == nil && len(accountIDs) > 0 {
+		err = checkCreditAccounts(db, scope, accountIDs)
+	}
+	if err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// checkCreditAccounts fails with ErrOutsideTenant unless every credit account is within scope.
+func checkCreditAccounts(db *gorm.DB, scope Scope, accountIDs []uint) error {
+	distinct := make(map[uint]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		distinct[id] = true
+	}
+	query := db.Session(&gorm.Session{NewDB: true, Context: systemContext(context.Background())}).Table("credit_accounts").
+		Where("id IN ? AND establishment_id IN ? AND deleted_at IS NULL", accountIDs, scope.EstablishmentIDs)
+	if scope.ClientID != 0 {
+		query = query.Where("client_id = ?", scope.ClientID)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return fmt.Errorf("error checking tenant: %w", err)
+	}
+	if int(count) != len(distinct) {
+		return ErrOutsideTenant
+	}
+	return nil
+}
+
+// eachRecord calls fn with each record of a create, one or a slice of them.
+func eachRecord(value reflect.Value, fn func(record reflect.Value) error) error {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := fn(reflect.Indirect(value.Index(i))); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		return fn(value)
+	}
+	return nil
+}
+
+// toUint returns an ID set as a value, if it is one.
+func toUint(value interface{}) (uint, bool) {
+	switch v := value.(type) {
+	case uint:
+		return v, true
+	case *uint:
+		if v != nil {
+			return *v, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func column(name string) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: name}
+}
+
+func values(ids []uint) []interface{} {
+	vals := make([]interface{}, len(ids))
+	for i, id := range ids {
+		vals[i] = id
+	}
+	return vals
+}
@@ -0,0 +1,148 @@
+package tenant
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// dryRunDB returns a database with the tenant callbacks that only builds its statements, so they can
+// be checked without a connection.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=tenant_test sslmode=disable"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("error opening dry run database: %v", err)
+	}
+	if err := Register(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestSessionScopesStatements(t *testing.T) {
+	admin := Scope{EstablishmentIDs: []uint{1, 2}}
+	client := Scope{EstablishmentIDs: []uint{1}, ClientID: 20}
+	tests := []struct {
+		name  string
+		scope Scope
+		query func(db *gorm.DB) *gorm.DB
+		want  []string // In the SQL of the statement
+	}{
+		{"establishments", admin, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.Establishment{}) },
+			[]string{`"establishments"."id" IN ($1,$2)`}},
+		{"table with an establishment", admin, func(db *gorm.DB) *gorm.DB { return db.Where("sku = ?", "A1").Find(&[]entities.Product{}) },
+			[]string{`"products"."establishment_id" IN ($2,$3)`}},
+		{"conditions with an OR", admin, func(db *gorm.DB) *gorm.DB {
+			return db.Where("sku = ?", "A1").Or("sku = ?", "B2").Find(&[]entities.Product{})
+		}, []string{`(sku = $1 OR sku = $2) AND "products"."establishment_id" IN ($3,$4)`}},
+		{"table of credit accounts", admin, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.Installment{}) },
+			[]string{`"installments"."credit_account_id" IN (SELECT id FROM credit_accounts WHERE establishment_id IN ($1,$2))`}},
+		{"table of credit accounts for a client", client, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.Installment{}) },
+			[]string{`IN (SELECT id FROM credit_accounts WHERE establishment_id IN ($1) AND client_id = $2)`}},
+		{"table with a client for a client", client, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.CreditAccount{}) },
+			[]string{`"credit_accounts"."establishment_id" = $1`, `"credit_accounts"."client_id" = $2`}},
+		{"deletes", admin, func(db *gorm.DB) *gorm.DB { return db.Delete(&entities.Product{}, 5) },
+			[]string{`"products"."establishment_id" IN ($3,$4)`}},
+		{"no tenant", Scope{}, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.Product{}) },
+			[]string{`"products"."establishment_id" IN (NULL)`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := tt.query(Session(dryRunDB(t), tt.scope))
+			if tx.Error != nil {
+				t.Fatalf("statement failed: %v", tx.Error)
+			}
+			for _, want := range tt.want {
+				if sql := tx.Statement.SQL.String(); !strings.Contains(sql, want) {
+					t.Errorf("SQL %s, want it to contain %s", sql, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStatementsWithoutATenant(t *testing.T) {
+	tests := []struct {
+		name    string
+		session func(db *gorm.DB) *gorm.DB
+		query   func(db *gorm.DB) *gorm.DB
+		want    error
+	}{
+		{"tenant table", nil, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.Product{}) }, ErrNoTenant},
+		{"table of credit accounts", nil, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.Installment{}) }, ErrNoTenant},
+		{"establishments", nil, func(db *gorm.DB) *gorm.DB { return db.First(&entities.Establishment{}, 1) }, ErrNoTenant},
+		{"create on a tenant table", nil, func(db *gorm.DB) *gorm.DB { return db.Create(&entities.Product{EstablishmentID: 1}) }, ErrNoTenant},
+		{"update on a tenant table", nil, func(db *gorm.DB) *gorm.DB {
+			return db.Model(&entities.Product{}).Where("id = ?", 5).Update("name", "Arroz")
+		}, ErrNoTenant},
+		{"table without a tenant", nil, func(db *gorm.DB) *gorm.DB { return db.First(&entities.User{}, 1) }, nil},
+		{"raw SQL", nil, func(db *gorm.DB) *gorm.DB { return db.Exec("DELETE FROM products") }, ErrNoTenant},
+		{"table of no model", nil, func(db *gorm.DB) *gorm.DB { return db.Table("products").Count(new(int64)) }, ErrNoTenant},
+		{"savepoint", nil, func(db *gorm.DB) *gorm.DB { return db.Exec("SAVEPOINT sp1") }, nil},
+		{"system session", System, func(db *gorm.DB) *gorm.DB { return db.Find(&[]entities.Product{}) }, nil},
+		{"system session running raw SQL", System, func(db *gorm.DB) *gorm.DB { return db.Exec("DELETE FROM products") }, nil},
+		{"context replaced by a repository", System, func(db *gorm.DB) *gorm.DB {
+			return db.WithContext(context.Background()).Find(&[]entities.Product{})
+		}, ErrNoTenant},
+		{"raw SQL in a tenant session of a system session", func(db *gorm.DB) *gorm.DB { return Session(System(db), Scope{EstablishmentIDs: []uint{1}}) },
+			func(db *gorm.DB) *gorm.DB { return db.Exec("DELETE FROM products") }, ErrUnscopedQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dryRunDB(t)
+			if tt.session != nil {
+				db = tt.session(db)
+			}
+			if err := tt.query(db).Error; !errors.Is(err, tt.want) {
+				t.Errorf("statement returned %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionOfASystemSessionIsScoped(t *testing.T) {
+	tx := Session(System(dryRunDB(t)), Scope{EstablishmentIDs: []uint{1}}).Find(&[]entities.Product{})
+	if tx.Error != nil {
+		t.Fatalf("statement failed: %v", tx.Error)
+	}
+	if sql := tx.Statement.SQL.String(); !strings.Contains(sql, `"products"."establishment_id" = $1`) {
+		t.Errorf("SQL %s, want it scoped to the establishment", sql)
+	}
+}
+
+func TestSessionRefusesRecordsOutsideTheTenant(t *testing.T) {
+	scope := Scope{EstablishmentIDs: []uint{1}, ClientID: 20}
+	tests := []struct {
+		name  string
+		query func(db *gorm.DB) *gorm.DB
+		want  error
+	}{
+		{"create in the tenant", func(db *gorm.DB) *gorm.DB { return db.Create(&entities.Product{EstablishmentID: 1}) }, nil},
+		{"create in another establishment", func(db *gorm.DB) *gorm.DB { return db.Create(&entities.Product{EstablishmentID: 2}) }, ErrOutsideTenant},
+		{"create for another client", func(db *gorm.DB) *gorm.DB {
+			return db.Create(&entities.CreditAccount{EstablishmentID: 1, ClientID: 21})
+		}, ErrOutsideTenant},
+		{"move to another establishment", func(db *gorm.DB) *gorm.DB {
+			return db.Model(&entities.Product{}).Where("id = ?", 5).Updates(map[string]interface{}{"establishment_id": uint(2)})
+		}, ErrOutsideTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query(Session(dryRunDB(t), scope)).Error; !errors.Is(err, tt.want) {
+				t.Errorf("statement returned %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
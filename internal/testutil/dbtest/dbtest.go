@@ -0,0 +1,164 @@
+// Package dbtest connects integration tests to the disposable PostgreSQL database of the test
+// environment, migrated to the current schema. Start it and run the tests with:
+//
+//	docker compose --profile test up -d test-db
+//	go test -tags integration ./...
+//
+// The connection settings are those of APP_ENV=test, from .env.test at the module root unless the
+// process environment overrides them. Tests are skipped when the database can't be reached, so
+// the integration tag alone never fails a build without Docker.
+package dbtest
+
+import (
+	"ApiRestFinance/internal/config"
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/migration"
+	"ApiRestFinance/internal/tenant"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+var (
+	once     sync.Once
+	shared   *gorm.DB
+	setupErr error
+)
+
+// Open returns a transaction on the test database that is rolled back when the test ends, so tests
+// see none of each other's rows. Repositories nest their own transactions in it as savepoints. Like
+// the jobs, it is a tenant.System session; tests of tenant-scoped repositories take a tenant.Session
+// of it.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+	tx := connect(t).Begin()
+	if tx.Error != nil {
+		t.Fatalf("error starting test transaction: %v", tx.Error)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+	return tenant.System(tx)
+}
+
+// Shared returns the test database itself, for tests that need several connections, e.g. to race
+// two transactions for a row lock. Every table is emptied when the test ends, so such tests must
+// not run in parallel with others. It is a tenant.System session too.
+func Shared(t testing.TB) *gorm.DB {
+	t.Helper()
+	db := tenant.System(connect(t))
+	t.Cleanup(func() {
+		if err := truncate(db); err != nil {
+			t.Errorf("error emptying test database: %v", err)
+		}
+	})
+	return db
+}
+
+// Create inserts the given rows in order, failing the test if any can't be.
+func Create(t testing.TB, db *gorm.DB, rows ...interface{}) {
+	t.Helper()
+	for _, row := range rows {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("error creating %T: %v", row, err)
+		}
+	}
+}
+
+func connect(t testing.TB) *gorm.DB {
+	t.Helper()
+	once.Do(func() { shared, setupErr = setup() })
+	if errors.Is(setupErr, errUnreachable) {
+		t.Skipf("test database unavailable, start it with docker compose --profile test up -d test-db: %v", setupErr)
+	}
+	if setupErr != nil {
+		t.Fatalf("error setting up test database: %v", setupErr)
+	}
+	return shared
+}
+
+var errUnreachable = errors.New("test database unreachable")
+
+func setup() (*gorm.DB, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Environment != config.Test {
+		return nil, fmt.Errorf("APP_ENV is %s, integration tests only run against the test database", cfg.Environment)
+	}
+
+	db, err := database.Open(cfg.Database.DSN(), database.Pool{MaxOpenConns: 4})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnreachable, err)
+	}
+	migrator, err := migration.New(db, migration.All())
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.Migrate(); err != nil {
+		return nil, err
+	}
+	if err := tenant.Register(db); err != nil {
+		return nil, err
+	}
+	return db, truncate(tenant.System(db))
+}
+
+// loadConfig loads the settings of the test environment from the module root, where the .env
+// files are, whichever package directory the test runs in.
+func loadConfig() (*config.Config, error) {
+	root, err := moduleRoot()
+	if err != nil {
+		return nil, err
+	}
+	if _, set := os.LookupEnv("APP_ENV"); !set {
+		os.Setenv("APP_ENV", config.Test)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chdir(root); err != nil {
+		return nil, err
+	}
+	defer os.Chdir(dir)
+	return config.Load()
+}
+
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found above the test directory")
+		}
+		dir = parent
+	}
+}
+
+// truncate empties every table but the record of applied migrations.
+func truncate(db *gorm.DB) error {
+	var tables []string
+	err := db.Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'").
+		Scan(&tables).Error
+	if err != nil || len(tables) == 0 {
+		return err
+	}
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		quoted[i] = `"` + table + `"`
+	}
+	return db.Exec("TRUNCATE " + strings.Join(quoted, ", ") + " RESTART IDENTITY CASCADE").Error
+}
@@ -0,0 +1,387 @@
+// Package fixture builds entities for tests with sensible defaults, so a test only spells out the
+// fields it is about:
+//
+//	account := fixture.CreditAccount().LongTerm(1).Balance(300).Build()
+//
+// Builders return pointers that tests may change freely; each Build returns a new entity.
+package fixture
+
+import (
+	"ApiRestFinance/internal/model/entities"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Default IDs of the entities built, which relate the fixtures to each other unless a test
+// overrides them.
+const (
+	EstablishmentID uint = 1
+	AdminID         uint = 10
+	ClientID        uint = 20
+	CreditAccountID uint = 100
+)
+
+// Now is the instant fixtures are built at, so dates in tests don't depend on when they run.
+var Now = time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+func model(id uint) gorm.Model {
+	return gorm.Model{ID: id, CreatedAt: Now, UpdatedAt: Now}
+}
+
+// CreditAccountBuilder builds a CreditAccount.
+type CreditAccountBuilder struct {
+	account entities.CreditAccount
+}
+
+// CreditAccount starts an active, unblocked short-term account of ClientID at EstablishmentID, with
+// a 1000 credit limit, no balance, a 24% effective annual rate and its due date on the 15th.
+func CreditAccount() *CreditAccountBuilder {
+	return &CreditAccountBuilder{account: entities.CreditAccount{
+		Model:                   model(CreditAccountID),
+		ClientID:                ClientID,
+		EstablishmentID:         EstablishmentID,
+		CreditLimit:             1000,
+		MonthlyDueDate:          15,
+		InterestRate:            24,
+		InterestType:            enums.Effective,
+		CompoundingPeriod:       enums.Monthly,
+		CreditType:              enums.ShortTerm,
+		LastInterestAccrualDate: Now,
+		LateFeePercentage:       5,
+		SpendingLimitPeriod:     enums.SpendingMonthly,
+		Status:                  enums.AccountActive,
+		Version:                 1,
+		CreatedAt:               Now,
+		UpdatedAt:               Now,
+	}}
+}
+
+// ID sets the account ID.
+func (b *CreditAccountBuilder) ID(id uint) *CreditAccountBuilder {
+	b.account.ID = id
+	return b
+}
+
+// Client sets the client holding the account.
+func (b *CreditAccountBuilder) Client(client *entities.User) *CreditAccountBuilder {
+	b.account.ClientID = client.ID
+	b.account.Client = client
+	return b
+}
+
+// Establishment sets the establishment the account is at.
+func (b *CreditAccountBuilder) Establishment(establishment *entities.Establishment) *CreditAccountBuilder {
+	b.account.EstablishmentID = establishment.ID
+	b.account.Establishment = establishment
+	return b
+}
+
+// Limit sets the credit limit.
+func (b *CreditAccountBuilder) Limit(limit float64) *CreditAccountBuilder {
+	b.account.CreditLimit = limit
+	return b
+}
+
+// Balance sets what the client owes.
+func (b *CreditAccountBuilder) Balance(balance float64) *CreditAccountBuilder {
+	b.account.CurrentBalance = balance
+	return b
+}
+
+// AccountCredit sets the overpaid amount in the client's favor.
+func (b *CreditAccountBuilder) AccountCredit(credit float64) *CreditAccountBuilder {
+	b.account.AccountCredit = credit
+	return b
+}
+
+// DueDay sets the day of the month payments are due.
+func (b *CreditAccountBuilder) DueDay(day int) *CreditAccountBuilder {
+	b.account.MonthlyDueDate = day
+	return b
+}
+
+// Rate sets the annual interest rate, in percent, and its type.
+func (b *CreditAccountBuilder) Rate(rate float64, interestType enums.InterestType) *CreditAccountBuilder {
+	b.account.InterestRate = rate
+	b.account.InterestType = interestType
+	return b
+}
+
+// Compounding sets how often a nominal rate is capitalized.
+func (b *CreditAccountBuilder) Compounding(period enums.CompoundingPeriod) *CreditAccountBuilder {
+	b.account.CompoundingPeriod = period
+	return b
+}
+
+// ShortTerm makes the account short-term credit.
+func (b *CreditAccountBuilder) ShortTerm() *CreditAccountBuilder {
+	b.account.CreditType = enums.ShortTerm
+	b.account.GracePeriod = 0
+	return b
+}
+
+// LongTerm makes the account long-term credit with a grace period in months.
+func (b *CreditAccountBuilder) LongTerm(gracePeriod int) *CreditAccountBuilder {
+	b.account.CreditType = enums.LongTerm
+	b.account.GracePeriod = gracePeriod
+	return b
+}
+
+// Blocked blocks the account.
+func (b *CreditAccountBuilder) Blocked() *CreditAccountBuilder {
+	b.account.IsBlocked = true
+	return b
+}
+
+// HighRisk marks the account high risk with the given score.
+func (b *CreditAccountBuilder) HighRisk(score int) *CreditAccountBuilder {
+	b.account.HighRisk = true
+	b.account.CreditScore = &score
+	return b
+}
+
+// LastAccrual sets when interest was last applied.
+func (b *CreditAccountBuilder) LastAccrual(at time.Time) *CreditAccountBuilder {
+	b.account.LastInterestAccrualDate = at
+	return b
+}
+
+// With changes any other field of the account.
+func (b *CreditAccountBuilder) With(change func(*entities.CreditAccount)) *CreditAccountBuilder {
+	change(&b.account)
+	return b
+}
+
+// Build returns the account.
+func (b *CreditAccountBuilder) Build() *entities.CreditAccount {
+	account := b.account
+	return &account
+}
+
+// UserBuilder builds a User.
+type UserBuilder struct {
+	user entities.User
+}
+
+// Client starts a client with ClientID and verified contact details.
+func Client() *UserBuilder {
+	return user(ClientID, enums.CLIENT, "Cliente Prueba", "12345678")
+}
+
+// Admin starts the admin of EstablishmentID, with AdminID.
+func Admin() *UserBuilder {
+	return user(AdminID, enums.ADMIN, "Admin Prueba", "87654321")
+}
+
+func user(id uint, role enums.Role, name, dni string) *UserBuilder {
+	verified := Now
+	return &UserBuilder{user: entities.User{
+		Model:           model(id),
+		DNI:             dni,
+		Email:           dni + "@example.com",
+		Password:        "not-a-bcrypt-hash", // Tests that log in set a hash with With
+		Name:            name,
+		Address:         "Av. Prueba 123",
+		Phone:           "9" + dni,
+		Rol:             role,
+		EmailVerifiedAt: &verified,
+		PhoneVerifiedAt: &verified,
+		CreatedAt:       Now,
+		UpdatedAt:       Now,
+	}}
+}
+
+// ID sets the user ID.
+func (b *UserBuilder) ID(id uint) *UserBuilder {
+	b.user.ID = id
+	return b
+}
+
+// DNI sets the DNI, and the email and phone derived from it so they stay unique.
+func (b *UserBuilder) DNI(dni string) *UserBuilder {
+	b.user.DNI = dni
+	b.user.Email = dni + "@example.com"
+	b.user.Phone = "9" + dni
+	return b
+}
+
+// TwoFactor enables two-factor authentication with secret, no code of which was used yet.
+func (b *UserBuilder) TwoFactor(secret string) *UserBuilder {
+	b.user.TwoFactorSecret = secret
+	b.user.TwoFactorEnabled = true
+	return b
+}
+
+// With changes any other field of the user.
+func (b *UserBuilder) With(change func(*entities.User)) *UserBuilder {
+	change(&b.user)
+	return b
+}
+
+// Build returns the user.
+func (b *UserBuilder) Build() *entities.User {
+	user := b.user
+	return &user
+}
+
+// EstablishmentBuilder builds an Establishment.
+type EstablishmentBuilder struct {
+	establishment entities.Establishment
+}
+
+// Establishment starts an active main establishment with EstablishmentID, administered by AdminID,
+// in Lima.
+func Establishment() *EstablishmentBuilder {
+	return &EstablishmentBuilder{establishment: entities.Establishment{
+		Model:     model(EstablishmentID),
+		RUC:       "20123456789",
+		Name:      "Bodega Prueba",
+		Phone:     "014567890",
+		Address:   "Jr. Prueba 456",
+		AdminID:   AdminID,
+		IsActive:  true,
+		Timezone:  "America/Lima",
+		Plan:      enums.PlanFree,
+		Version:   1,
+		CreatedAt: Now,
+		UpdatedAt: Now,
+	}}
+}
+
+// ID sets the establishment ID.
+func (b *EstablishmentBuilder) ID(id uint) *EstablishmentBuilder {
+	b.establishment.ID = id
+	return b
+}
+
+// RUC sets the RUC, which has to be unique among main establishments.
+func (b *EstablishmentBuilder) RUC(ruc string) *EstablishmentBuilder {
+	b.establishment.RUC = ruc
+	return b
+}
+
+// Admin sets the admin of the establishment.
+func (b *EstablishmentBuilder) Admin(admin *entities.User) *EstablishmentBuilder {
+	b.establishment.AdminID = admin.ID
+	b.establishment.Admin = admin
+	return b
+}
+
+// LateFee sets the late fee percentage the establishment charges on overdue amounts.
+func (b *EstablishmentBuilder) LateFee(percentage float64) *EstablishmentBuilder {
+	b.establishment.LateFeePercentage = percentage
+	return b
+}
+
+// Inactive deactivates the establishment.
+func (b *EstablishmentBuilder) Inactive() *EstablishmentBuilder {
+	b.establishment.IsActive = false
+	return b
+}
+
+// With changes any other field of the establishment.
+func (b *EstablishmentBuilder) With(change func(*entities.Establishment)) *EstablishmentBuilder {
+	change(&b.establishment)
+	return b
+}
+
+// Build returns the establishment.
+func (b *EstablishmentBuilder) Build() *entities.Establishment {
+	establishment := b.establishment
+	return &establishment
+}
+
+// SettingsBuilder builds EstablishmentSettings.
+type SettingsBuilder struct {
+	settings entities.EstablishmentSettings
+}
+
+// Settings starts the default settings of EstablishmentID.
+func Settings() *SettingsBuilder {
+	return &SettingsBuilder{settings: *repository.DefaultEstablishmentSettings(EstablishmentID)}
+}
+
+// MaxInstallments sets how many installments long-term purchases are split into.
+func (b *SettingsBuilder) MaxInstallments(n int) *SettingsBuilder {
+	b.settings.MaxInstallments = n
+	return b
+}
+
+// PinThreshold sets the purchase amount above which the client's PIN is asked for.
+func (b *SettingsBuilder) PinThreshold(amount float64) *SettingsBuilder {
+	b.settings.PinThreshold = amount
+	return b
+}
+
+// ApprovalThreshold sets the purchase amount above which an admin has to approve it.
+func (b *SettingsBuilder) ApprovalThreshold(amount float64) *SettingsBuilder {
+	b.settings.ApprovalThreshold = amount
+	return b
+}
+
+// HighRiskMaxPurchase sets the largest purchase a high-risk account may make.
+func (b *SettingsBuilder) HighRiskMaxPurchase(amount float64) *SettingsBuilder {
+	b.settings.HighRiskMaxPurchase = amount
+	return b
+}
+
+// LateFeeMode sets how late fees are charged, with the fee per cycle of the FIXED mode.
+func (b *SettingsBuilder) LateFeeMode(mode enums.LateFeeMode, amount float64) *SettingsBuilder {
+	b.settings.LateFeeMode, b.settings.LateFeeAmount = string(mode), amount
+	return b
+}
+
+// LateFeeCap sets the most a billing cycle is charged in late fees, as a percentage of its overdue amount.
+func (b *SettingsBuilder) LateFeeCap(percentage float64) *SettingsBuilder {
+	b.settings.LateFeeCap = percentage
+	return b
+}
+
+// With changes any other field of the settings.
+func (b *SettingsBuilder) With(change func(*entities.EstablishmentSettings)) *SettingsBuilder {
+	change(&b.settings)
+	return b
+}
+
+// Build returns the settings.
+func (b *SettingsBuilder) Build() *entities.EstablishmentSettings {
+	settings := b.settings
+	return &settings
+}
+
+// Installment returns a pending installment of CreditAccountID.
+func Installment(amount float64, dueDate time.Time) *entities.Installment {
+	return &entities.Installment{
+		Model:           model(0),
+		CreditAccountID: CreditAccountID,
+		DueDate:         dueDate,
+		Amount:          amount,
+		Status:          enums.Pending,
+		Version:         1,
+	}
+}
+
+// Purchase returns a purchase made with CreditAccountID at Now.
+func Purchase(amount float64) *entities.Transaction {
+	return transaction(enums.Purchase, amount)
+}
+
+// Payment returns a confirmed cash payment to CreditAccountID made at Now.
+func Payment(amount float64) *entities.Transaction {
+	return transaction(enums.Payment, amount)
+}
+
+func transaction(transactionType enums.TransactionType, amount float64) *entities.Transaction {
+	return &entities.Transaction{
+		Model:           model(0),
+		CreditAccountID: CreditAccountID,
+		TransactionType: transactionType,
+		Amount:          amount,
+		TransactionDate: Now,
+		PaymentMethod:   enums.CASH,
+		PaymentStatus:   enums.SUCCESS,
+	}
+}
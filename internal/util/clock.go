@@ -0,0 +1,98 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Services and repositories depend on it instead
+// of calling time.Now directly so time-dependent logic can be controlled.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by the system time.
+type SystemClock struct{}
+
+// NewSystemClock creates a Clock that reports the system time.
+func NewSystemClock() Clock {
+	return SystemClock{}
+}
+
+// Now returns the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a Clock frozen at a fixed instant until it is moved explicitly.
+type FakeClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's current instant.
+func (c *FakeClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Set moves the clock to now.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// SimulatedClock follows the system time shifted by an adjustable offset.
+// It backs the sandbox "simulate date" mode: once set to a date, time keeps
+// running from there instead of standing still.
+type SimulatedClock struct {
+	mu     sync.RWMutex
+	offset time.Duration
+}
+
+// NewSimulatedClock creates a SimulatedClock with no offset.
+func NewSimulatedClock() *SimulatedClock {
+	return &SimulatedClock{}
+}
+
+// Now returns the simulated current time.
+func (c *SimulatedClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Add(c.offset)
+}
+
+// SetNow shifts the clock so that it currently reports now.
+func (c *SimulatedClock) SetNow(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset = time.Until(now)
+}
+
+// Reset removes any offset so the clock follows the system time again.
+func (c *SimulatedClock) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset = 0
+}
+
+// IsSimulated reports whether the clock is currently shifted from the system time.
+func (c *SimulatedClock) IsSimulated() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset != 0
+}
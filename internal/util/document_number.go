@@ -0,0 +1,9 @@
+package util
+
+import "fmt"
+
+// FormatDocumentNumber returns the printed number of a fiscal document, its series and zero-padded
+// correlative, e.g. B001-000123.
+func FormatDocumentNumber(series string, number int64) string {
+	return fmt.Sprintf("%s-%06d", series, number)
+}
@@ -0,0 +1,74 @@
+package util
+
+import "time"
+
+// DaysInMonth returns the number of days in the given month of the given year.
+func DaysInMonth(year int, month time.Month) int {
+	// Day 0 of the following month normalizes to the last day of this one.
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// DueDateInMonth returns the due date for the given month at midnight in loc.
+// Days past the end of the month (e.g. 31 in April, 29 in a non-leap February)
+// are clamped to the month's last day instead of overflowing into the next one.
+func DueDateInMonth(year int, month time.Month, monthlyDueDate int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	// Normalize month overflow (e.g. month 13) before clamping the day.
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	year, month = first.Year(), first.Month()
+
+	day := monthlyDueDate
+	if day < 1 {
+		day = 1
+	}
+	if last := DaysInMonth(year, month); day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// AddMonthsToDueDate returns the due date that falls the given number of months
+// after the month of base, keeping the original monthly due day where possible.
+func AddMonthsToDueDate(base time.Time, months int, monthlyDueDate int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	base = base.In(loc)
+	return DueDateInMonth(base.Year(), base.Month()+time.Month(months), monthlyDueDate, loc)
+}
+
+// CurrentDueDate returns the due date of the billing cycle that now falls in.
+func CurrentDueDate(now time.Time, monthlyDueDate int, loc *time.Location) time.Time {
+	return AddMonthsToDueDate(now, 0, monthlyDueDate, loc)
+}
+
+// NextDueDate returns the first due date that falls after now.
+func NextDueDate(now time.Time, monthlyDueDate int, loc *time.Location) time.Time {
+	dueDate := CurrentDueDate(now, monthlyDueDate, loc)
+	if dueDate.Before(now) {
+		dueDate = AddMonthsToDueDate(now, 1, monthlyDueDate, loc)
+	}
+	return dueDate
+}
+
+// FollowingMonthDueDate returns the due date in the month after now.
+func FollowingMonthDueDate(now time.Time, monthlyDueDate int, loc *time.Location) time.Time {
+	return AddMonthsToDueDate(now, 1, monthlyDueDate, loc)
+}
+
+// DaysOverdue returns how many whole days now is past this month's due date,
+// or 0 if the due date has not been reached yet.
+func DaysOverdue(now time.Time, monthlyDueDate int, loc *time.Location) int {
+	dueDate := CurrentDueDate(now, monthlyDueDate, loc)
+	if now.Before(dueDate) {
+		return 0
+	}
+	return int(now.Sub(dueDate).Hours() / 24)
+}
+
+// IsPastDueDate reports whether now is after this month's due date.
+func IsPastDueDate(now time.Time, monthlyDueDate int, loc *time.Location) bool {
+	return now.After(CurrentDueDate(now, monthlyDueDate, loc))
+}
@@ -0,0 +1,205 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		want  int
+	}{
+		{2024, time.February, 29},
+		{2025, time.February, 28},
+		{2000, time.February, 29}, // Divisible by 400
+		{2100, time.February, 28}, // Divisible by 100 but not 400
+		{2025, time.April, 30},
+		{2025, time.June, 30},
+		{2025, time.September, 30},
+		{2025, time.November, 30},
+		{2025, time.January, 31},
+		{2025, time.December, 31},
+	}
+	for _, tt := range tests {
+		if got := DaysInMonth(tt.year, tt.month); got != tt.want {
+			t.Errorf("DaysInMonth(%d, %s) = %d, want %d", tt.year, tt.month, got, tt.want)
+		}
+	}
+}
+
+func TestDueDateInMonth(t *testing.T) {
+	tests := []struct {
+		name           string
+		year           int
+		month          time.Month
+		monthlyDueDate int
+		want           time.Time
+	}{
+		{"day 28 in leap February", 2024, time.February, 28, date(2024, time.February, 28)},
+		{"day 29 in leap February", 2024, time.February, 29, date(2024, time.February, 29)},
+		{"day 30 in leap February", 2024, time.February, 30, date(2024, time.February, 29)},
+		{"day 31 in leap February", 2024, time.February, 31, date(2024, time.February, 29)},
+		{"day 28 in February", 2025, time.February, 28, date(2025, time.February, 28)},
+		{"day 29 in February", 2025, time.February, 29, date(2025, time.February, 28)},
+		{"day 30 in February", 2025, time.February, 30, date(2025, time.February, 28)},
+		{"day 31 in February", 2025, time.February, 31, date(2025, time.February, 28)},
+		{"day 29 in February of 2100", 2100, time.February, 29, date(2100, time.February, 28)},
+		{"day 30 in April", 2025, time.April, 30, date(2025, time.April, 30)},
+		{"day 31 in April", 2025, time.April, 31, date(2025, time.April, 30)},
+		{"day 31 in June", 2025, time.June, 31, date(2025, time.June, 30)},
+		{"day 31 in September", 2025, time.September, 31, date(2025, time.September, 30)},
+		{"day 31 in November", 2025, time.November, 31, date(2025, time.November, 30)},
+		{"day 31 in a 31-day month", 2025, time.January, 31, date(2025, time.January, 31)},
+		{"day below 1", 2025, time.March, 0, date(2025, time.March, 1)},
+		{"month 13 is January of the next year", 2025, 13, 31, date(2026, time.January, 31)},
+		{"month 14 is February of the next year", 2025, 14, 31, date(2026, time.February, 28)},
+		{"month 0 is December of the year before", 2025, 0, 31, date(2024, time.December, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DueDateInMonth(tt.year, tt.month, tt.monthlyDueDate, time.UTC); !got.Equal(tt.want) {
+				t.Errorf("DueDateInMonth(%d, %d, %d) = %s, want %s", tt.year, tt.month, tt.monthlyDueDate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDueDateInMonthLocation(t *testing.T) {
+	lima := time.FixedZone("America/Lima", -5*60*60)
+	got := DueDateInMonth(2025, time.February, 31, lima)
+	if want := time.Date(2025, time.February, 28, 0, 0, 0, 0, lima); !got.Equal(want) || got.Location() != lima {
+		t.Errorf("DueDateInMonth in Lima = %s, want %s", got, want)
+	}
+	if got := DueDateInMonth(2025, time.February, 31, nil); got.Location() != time.UTC {
+		t.Errorf("DueDateInMonth with a nil location is in %s, want UTC", got.Location())
+	}
+}
+
+func TestAddMonthsToDueDate(t *testing.T) {
+	tests := []struct {
+		name           string
+		base           time.Time
+		months         int
+		monthlyDueDate int
+		want           time.Time
+	}{
+		{"January 31 to leap February", date(2024, time.January, 31), 1, 31, date(2024, time.February, 29)},
+		{"January 31 to February", date(2025, time.January, 31), 1, 31, date(2025, time.February, 28)},
+		{"back to day 31 after February", date(2025, time.February, 28), 1, 31, date(2025, time.March, 31)},
+		{"day 30 after February", date(2025, time.February, 28), 1, 30, date(2025, time.March, 30)},
+		{"day 31 into April", date(2025, time.March, 31), 1, 31, date(2025, time.April, 30)},
+		{"day 29 a year ahead from leap February", date(2024, time.February, 29), 12, 29, date(2025, time.February, 28)},
+		{"day 29 four years ahead from leap February", date(2024, time.February, 29), 48, 29, date(2028, time.February, 29)},
+		{"across the year", date(2025, time.November, 30), 3, 31, date(2026, time.February, 28)},
+		{"months before", date(2025, time.March, 31), -1, 31, date(2025, time.February, 28)},
+		{"same month", date(2025, time.April, 10), 0, 31, date(2025, time.April, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddMonthsToDueDate(tt.base, tt.months, tt.monthlyDueDate, time.UTC); !got.Equal(tt.want) {
+				t.Errorf("AddMonthsToDueDate(%s, %d, %d) = %s, want %s", tt.base.Format("2006-01-02"), tt.months, tt.monthlyDueDate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddMonthsToDueDateUsesLocation(t *testing.T) {
+	// March 1 at 02:00 UTC is still February 28 in Lima, so the month is February there
+	lima := time.FixedZone("America/Lima", -5*60*60)
+	base := time.Date(2025, time.March, 1, 2, 0, 0, 0, time.UTC)
+	got := AddMonthsToDueDate(base, 0, 31, lima)
+	if want := time.Date(2025, time.February, 28, 0, 0, 0, 0, lima); !got.Equal(want) {
+		t.Errorf("AddMonthsToDueDate in Lima = %s, want %s", got, want)
+	}
+}
+
+func TestNextDueDate(t *testing.T) {
+	tests := []struct {
+		name           string
+		now            time.Time
+		monthlyDueDate int
+		want           time.Time
+	}{
+		{"before the due date", date(2025, time.February, 10), 15, date(2025, time.February, 15)},
+		{"on the due date", date(2025, time.February, 15), 15, date(2025, time.February, 15)},
+		{"after the due date", date(2025, time.February, 15).Add(time.Hour), 15, date(2025, time.March, 15)},
+		{"day 31 in February", date(2025, time.February, 1), 31, date(2025, time.February, 28)},
+		{"day 31 in leap February", date(2024, time.February, 1), 31, date(2024, time.February, 29)},
+		{"after clamped February", date(2025, time.February, 28).Add(time.Hour), 31, date(2025, time.March, 31)},
+		{"day 31 after March", date(2025, time.March, 31).Add(time.Hour), 31, date(2025, time.April, 30)},
+		{"day 30 in a 30-day month", date(2025, time.April, 20), 30, date(2025, time.April, 30)},
+		{"after the due date in December", date(2025, time.December, 31).Add(time.Hour), 31, date(2026, time.January, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextDueDate(tt.now, tt.monthlyDueDate, time.UTC); !got.Equal(tt.want) {
+				t.Errorf("NextDueDate(%s, %d) = %s, want %s", tt.now, tt.monthlyDueDate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollowingMonthDueDate(t *testing.T) {
+	got := FollowingMonthDueDate(date(2025, time.January, 31), 31, time.UTC)
+	if want := date(2025, time.February, 28); !got.Equal(want) {
+		t.Errorf("FollowingMonthDueDate = %s, want %s", got, want)
+	}
+}
+
+func TestDaysOverdue(t *testing.T) {
+	tests := []struct {
+		name           string
+		now            time.Time
+		monthlyDueDate int
+		want           int
+	}{
+		{"before the due date", date(2025, time.March, 14).Add(23 * time.Hour), 15, 0},
+		{"at the start of the due date", date(2025, time.March, 15), 15, 0},
+		{"at the end of the due date", date(2025, time.March, 15).Add(24*time.Hour - time.Nanosecond), 15, 0},
+		{"a day after the due date", date(2025, time.March, 16), 15, 1},
+		{"ten days after the due date", date(2025, time.March, 25).Add(12 * time.Hour), 15, 10},
+		{"on clamped February", date(2025, time.February, 28), 31, 0},
+		{"a day after clamped February", date(2025, time.March, 1), 31, 0}, // March's due date is the 31st
+		{"on clamped leap February", date(2024, time.February, 29).Add(12 * time.Hour), 30, 0},
+		{"on clamped April", date(2025, time.April, 30).Add(23 * time.Hour), 31, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysOverdue(tt.now, tt.monthlyDueDate, time.UTC); got != tt.want {
+				t.Errorf("DaysOverdue(%s, %d) = %d, want %d", tt.now, tt.monthlyDueDate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPastDueDate(t *testing.T) {
+	tests := []struct {
+		name           string
+		now            time.Time
+		monthlyDueDate int
+		want           bool
+	}{
+		{"the day before the due date", date(2025, time.March, 14), 15, false},
+		{"at the start of the due date", date(2025, time.March, 15), 15, false},
+		{"just after the start of the due date", date(2025, time.March, 15).Add(time.Nanosecond), 15, true},
+		{"the day after the due date", date(2025, time.March, 16), 15, true},
+		{"at the start of clamped February", date(2025, time.February, 28), 31, false},
+		{"during clamped February", date(2025, time.February, 28).Add(time.Hour), 31, true},
+		{"at the start of clamped leap February", date(2024, time.February, 29), 31, false},
+		{"the day before clamped leap February", date(2024, time.February, 28).Add(time.Hour), 31, false},
+		{"at the start of clamped April", date(2025, time.April, 30), 31, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPastDueDate(tt.now, tt.monthlyDueDate, time.UTC); got != tt.want {
+				t.Errorf("IsPastDueDate(%s, %d) = %t, want %t", tt.now, tt.monthlyDueDate, got, tt.want)
+			}
+		})
+	}
+}
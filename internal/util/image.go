@@ -0,0 +1,123 @@
+package util
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	_ "image/gif" // Register GIF decoding
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Placeholder URLs that older records were created with before generated avatars existed.
+const (
+	legacyUserPhotoPlaceholder    = "https://cdn.pixabay.com/photo/2015/10/05/22/37/blank-profile-picture-973460_1280.png"
+	legacyProductImagePlaceholder = "https://rahulindesign.websites.co.in/twenty-nineteen/img/defaults/product-default.png"
+)
+
+// avatarColors is the palette initials avatars pick their background from.
+var avatarColors = []string{"#1abc9c", "#2ecc71", "#3498db", "#9b59b6", "#e67e22", "#e74c3c", "#34495e", "#16a085"}
+
+// DecodeImage decodes a JPEG, PNG or GIF image and returns it with its format name.
+func DecodeImage(r io.Reader) (image.Image, string, error) {
+	return image.Decode(r)
+}
+
+// ResizeImage scales src down so that neither side exceeds maxSide, keeping the
+// aspect ratio. Images that already fit are copied unchanged.
+func ResizeImage(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return src
+	}
+
+	newWidth, newHeight := maxSide, maxSide
+	if width > height {
+		newHeight = max(1, height*maxSide/width)
+	} else {
+		newWidth = max(1, width*maxSide/height)
+	}
+
+	// Box filter: each destination pixel averages the source pixels it covers.
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		y0 := bounds.Min.Y + y*height/newHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/newHeight)
+		for x := 0; x < newWidth; x++ {
+			x0 := bounds.Min.X + x*width/newWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/newWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// Initials returns up to two upper-case initials for a name ("Ana María Pérez" -> "AM").
+func Initials(name string) string {
+	var initials []rune
+	for _, word := range strings.Fields(name) {
+		for _, r := range word {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				initials = append(initials, unicode.ToUpper(r))
+				break
+			}
+		}
+		if len(initials) == 2 {
+			break
+		}
+	}
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// InitialsAvatarDataURI returns an SVG avatar showing the initials of name as a data URI.
+// The background color is derived from the name so the same name always gets the same avatar.
+func InitialsAvatarDataURI(name string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	background := avatarColors[hash.Sum32()%uint32(len(avatarColors))]
+
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="128" height="128" viewBox="0 0 128 128">`+
+		`<rect width="128" height="128" fill="%s"/>`+
+		`<text x="50%%" y="50%%" dy=".35em" text-anchor="middle" font-family="Arial, sans-serif" font-size="52" fill="#ffffff">%s</text>`+
+		`</svg>`, background, Initials(name))
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svg))
+}
+
+// UserPhotoURL returns photoURL, or a generated initials avatar when the user has no photo.
+func UserPhotoURL(photoURL, name string) string {
+	if photoURL == "" || photoURL == legacyUserPhotoPlaceholder {
+		return InitialsAvatarDataURI(name)
+	}
+	return photoURL
+}
+
+// ProductImageURL returns imageURL, or a generated initials placeholder when the product has no image.
+func ProductImageURL(imageURL, name string) string {
+	if imageURL == "" || imageURL == legacyProductImagePlaceholder {
+		return InitialsAvatarDataURI(name)
+	}
+	return imageURL
+}
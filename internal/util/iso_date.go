@@ -0,0 +1,129 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDate is returned for dates that aren't ISO-8601.
+var ErrInvalidDate = errors.New("dates must be ISO-8601, e.g. 2026-10-14 or 2026-10-14T09:30:00-05:00")
+
+// ErrInvalidDateRange is returned for unknown named ranges and ranges that end before they start.
+var ErrInvalidDateRange = errors.New("invalid date range, use start and end dates or one of today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year or last_N_days")
+
+// isoLayouts are the ISO-8601 forms ParseISODate accepts. Fractional seconds are accepted after
+// the seconds of any of them.
+var isoLayouts = []struct {
+	layout string
+	zoned  bool
+}{
+	{time.RFC3339, true},
+	{"2006-01-02T15:04:05Z0700", true},
+	{"2006-01-02T15:04Z07:00", true},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02T15:04", false},
+}
+
+// ParseISODate parses an ISO-8601 date (2026-10-14) or date and time (2026-10-14T09:30:00-05:00).
+// Dates and times without an offset are in loc. dateOnly tells a date alone, returned as its
+// midnight in loc, so the end of a range can cover the whole day.
+func ParseISODate(value string, loc *time.Location) (t time.Time, dateOnly bool, err error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return date, true, nil
+	}
+	for _, iso := range isoLayouts {
+		if iso.zoned {
+			if t, err := time.Parse(iso.layout, value); err == nil {
+				return t, false, nil
+			}
+		} else if t, err := time.ParseInLocation(iso.layout, value, loc); err == nil {
+			return t, false, nil
+		}
+	}
+	return time.Time{}, false, ErrInvalidDate
+}
+
+// ParseDateRange reads a range from ISO-8601 start and end values, either of them optional, or from
+// a named range (see NamedDateRange), which can't be combined with them. A start date alone starts
+// at its midnight and an end date alone ends at its last instant. Missing ends are returned as zero
+// times. Dates without an offset and named ranges are in now's location.
+func ParseDateRange(start, end, name string, now time.Time) (time.Time, time.Time, error) {
+	if name != "" {
+		if start != "" || end != "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("a named range can't be combined with start and end dates: %w", ErrInvalidDateRange)
+		}
+		return NamedDateRange(name, now)
+	}
+
+	loc := now.Location()
+	var startTime, endTime time.Time
+	if start != "" {
+		t, _, err := ParseISODate(start, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %q: %w", start, err)
+		}
+		startTime = t
+	}
+	if end != "" {
+		t, dateOnly, err := ParseISODate(end, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %q: %w", end, err)
+		}
+		if dateOnly {
+			t = EndOfDayIn(t, loc)
+		}
+		endTime = t
+	}
+	if !startTime.IsZero() && !endTime.IsZero() && endTime.Before(startTime) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date is before start date: %w", ErrInvalidDateRange)
+	}
+	return startTime, endTime, nil
+}
+
+// NamedDateRange returns the range a name covers at now, in now's location: today, yesterday,
+// this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year,
+// or last_N_days for the N days up to today (e.g. last_30_days). Ranges of the current period run
+// up to now, past periods to their last instant. Weeks start on Monday.
+func NamedDateRange(name string, now time.Time) (time.Time, time.Time, error) {
+	loc := now.Location()
+	today := StartOfDayIn(now, loc)
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	quarterStart := time.Date(now.Year(), time.Month((int(now.Month())-1)/3*3+1), 1, 0, 0, 0, 0, loc)
+	yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc)
+	before := func(t time.Time) time.Time { return t.Add(-time.Nanosecond) }
+
+	switch name {
+	case "today":
+		return today, now, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), before(today), nil
+	case "this_week":
+		return weekStart, now, nil
+	case "last_week":
+		return weekStart.AddDate(0, 0, -7), before(weekStart), nil
+	case "this_month":
+		return monthStart, now, nil
+	case "last_month":
+		return monthStart.AddDate(0, -1, 0), before(monthStart), nil
+	case "this_quarter":
+		return quarterStart, now, nil
+	case "last_quarter":
+		return quarterStart.AddDate(0, -3, 0), before(quarterStart), nil
+	case "this_year":
+		return yearStart, now, nil
+	case "last_year":
+		return yearStart.AddDate(-1, 0, 0), before(yearStart), nil
+	}
+
+	if days, ok := strings.CutSuffix(strings.TrimPrefix(name, "last_"), "_days"); ok && strings.HasPrefix(name, "last_") {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 && n <= 3660 {
+			return today.AddDate(0, 0, 1-n), now, nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown range %q: %w", name, ErrInvalidDateRange)
+}
@@ -1,63 +1,169 @@
 package util
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
-// AccessTokenClaims for access tokens
-type AccessTokenClaims struct {
+// TokenType tells access tokens, sent with every request, from refresh tokens, only accepted to get new tokens.
+type TokenType string
+
+const (
+	AccessToken  TokenType = "access"
+	RefreshToken TokenType = "refresh"
+	// TwoFactorChallenge tokens are issued when the password is right but a second factor is still
+	// needed. They are only accepted to complete the login.
+	TwoFactorChallenge TokenType = "2fa_challenge"
+	// ImpersonationToken tokens let an admin see the API as one of their clients. They act as the
+	// client's access token, only on read-only client endpoints, and can't be refreshed.
+	ImpersonationToken TokenType = "impersonation"
+)
+
+// twoFactorChallengeTTL is how long users have to enter their code after their password.
+const twoFactorChallengeTTL = 5 * time.Minute
+
+// impersonationTTL is how long an impersonation lasts unless the admin stops it first.
+const impersonationTTL = 15 * time.Minute
+
+// ErrInvalidToken is returned for tokens that are malformed, expired, signed with an unknown key or
+// meant for another issuer, audience or use.
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// TokenClaims are the claims of the tokens the API issues. Both token types carry the same claims.
+type TokenClaims struct {
 	UserID uint   `json:"user_id"`
 	Role   string `json:"rol"`
+	// EstablishmentID is the main establishment of admins. Clients choose an establishment per request.
+	EstablishmentID uint `json:"establishment_id,omitempty"`
+	// SessionID is the server-side session the token belongs to. Refresh tokens are only accepted
+	// while their session is active and they are its latest token, by their ID (jti).
+	SessionID uint `json:"sid,omitempty"`
+	// ImpersonatorID is the admin an impersonation token was issued to, and ImpersonationID the
+	// impersonation it belongs to. Both are 0 in every other token.
+	ImpersonatorID  uint      `json:"impersonator_id,omitempty"`
+	ImpersonationID uint      `json:"imp,omitempty"`
+	Type            TokenType `json:"typ"`
 	jwt.RegisteredClaims
 }
 
-// RefreshTokenClaims for refresh tokens
-type RefreshTokenClaims struct {
-	UserID uint   `json:"user_id"`
-	Role   string `json:"rol"`
-	jwt.RegisteredClaims
+// TokenSettings configure a TokenIssuer.
+type TokenSettings struct {
+	Issuer   string
+	Audience string
+	// KeyID names Secret, the key new tokens are signed with
+	KeyID  string
+	Secret string
+	// PreviousKeys are retired keys by ID. Tokens signed with them are still accepted until they
+	// expire, so the key can be rotated without ending every session.
+	PreviousKeys    map[string]string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// TokenIssuer signs the API's tokens with HS256 and validates them. Tokens name the key they're
+// signed with in their kid header.
+type TokenIssuer struct {
+	settings TokenSettings
+	keys     map[string][]byte
+}
+
+// NewTokenIssuer creates a TokenIssuer.
+func NewTokenIssuer(settings TokenSettings) *TokenIssuer {
+	keys := make(map[string][]byte, len(settings.PreviousKeys)+1)
+	for id, secret := range settings.PreviousKeys {
+		keys[id] = []byte(secret)
+	}
+	keys[settings.KeyID] = []byte(settings.Secret)
+	return &TokenIssuer{settings: settings, keys: keys}
+}
+
+// AccessTokenTTL returns how long access tokens are valid.
+func (i *TokenIssuer) AccessTokenTTL() time.Duration {
+	return i.settings.AccessTokenTTL
+}
+
+// RefreshTokenTTL returns how long refresh tokens are valid.
+func (i *TokenIssuer) RefreshTokenTTL() time.Duration {
+	return i.settings.RefreshTokenTTL
+}
+
+// ImpersonationTTL returns how long impersonation tokens are valid.
+func (i *TokenIssuer) ImpersonationTTL() time.Duration {
+	return impersonationTTL
 }
 
-// GenerateAccessToken generates a new JWT access token
-func GenerateAccessToken(userID uint, userRole string, jwtSecret string) (string, error) {
-	expirationTime := time.Now().Add(7 * 24 * time.Hour) // 7 days expiration
-	claims := &AccessTokenClaims{
-		UserID: userID,
-		Role:   userRole,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// Issue signs a new token of the given type, issued at now. claims sets the user, their role,
+// establishment and session, and the token ID; the type, issuer, audience, subject and times are
+// filled in.
+func (i *TokenIssuer) Issue(tokenType TokenType, claims TokenClaims, now time.Time) (string, error) {
+	ttl := i.settings.AccessTokenTTL
+	switch tokenType {
+	case RefreshToken:
+		ttl = i.settings.RefreshTokenTTL
+	case TwoFactorChallenge:
+		ttl = twoFactorChallengeTTL
+	case ImpersonationToken:
+		ttl = impersonationTTL
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(jwtSecret))
-}
-
-// GenerateRefreshToken generates a new JWT refresh token
-func GenerateRefreshToken(userID uint, userRole string, jwtSecret string) (string, error) {
-	expirationTime := time.Now().Add(7 * 24 * time.Hour) // 7 days expiration
-	claims := &RefreshTokenClaims{
-		UserID: userID,
-		Role:   userRole,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	claims.Type = tokenType
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        claims.ID,
+		Issuer:    i.settings.Issuer,
+		Subject:   strconv.FormatUint(uint64(claims.UserID), 10),
+		Audience:  jwt.ClaimStrings{i.settings.Audience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
 	}
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return refreshToken.SignedString([]byte(jwtSecret))
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
+	token.Header["kid"] = i.settings.KeyID
+	return token.SignedString(i.keys[i.settings.KeyID])
 }
 
-// ValidateToken validates a JWT token (both access and refresh)
-func ValidateToken(tokenString string, jwtSecret string) (*jwt.Token, error) {
-	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("invalid signing method: %v", token.Header["alg"])
+// Validate checks the signature, expiry, issuer, audience and type of a token and returns its claims.
+func (i *TokenIssuer) Validate(tokenString string, tokenType TokenType) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		keyID, _ := token.Header["kid"].(string)
+		key, ok := i.keys[keyID]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", keyID)
 		}
-		return []byte(jwtSecret), nil
+		return key, nil
 	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if !claims.VerifyIssuer(i.settings.Issuer, true) || !claims.VerifyAudience(i.settings.Audience, true) ||
+		claims.Type != tokenType || claims.UserID == 0 || claims.Role == "" {
+		return nil, ErrInvalidToken
+	}
+	if tokenType == ImpersonationToken && (claims.ImpersonatorID == 0 || claims.ImpersonationID == 0) {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// NewTokenID generates a random token ID (jti).
+func NewTokenID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("error generating token ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// UseTokenClock makes token expiry checks use the given clock instead of the system time.
+func UseTokenClock(clock Clock) {
+	jwt.TimeFunc = clock.Now
 }
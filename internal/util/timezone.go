@@ -0,0 +1,52 @@
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	_ "time/tzdata" // Embed the zone database so containers without tzdata still resolve zones
+)
+
+// DefaultTimezone is used for establishments that haven't configured a time zone.
+const DefaultTimezone = "America/Lima"
+
+var locationCache sync.Map // map[string]*time.Location
+
+// ValidateTimezone checks that name is a known IANA time zone.
+func ValidateTimezone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil || name == "" {
+		return fmt.Errorf("invalid time zone %q", name)
+	}
+	return nil
+}
+
+// LoadLocation returns the location for an IANA time zone name, falling back
+// to DefaultTimezone when the name is empty or unknown.
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		name = DefaultTimezone
+	}
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if name == DefaultTimezone {
+			return time.UTC
+		}
+		return LoadLocation(DefaultTimezone)
+	}
+	locationCache.Store(name, loc)
+	return loc
+}
+
+// StartOfDayIn returns midnight of t's calendar date in loc.
+func StartOfDayIn(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// EndOfDayIn returns the last instant of t's calendar date in loc.
+func EndOfDayIn(t time.Time, loc *time.Location) time.Time {
+	return StartOfDayIn(t, loc).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
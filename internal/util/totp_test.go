@@ -0,0 +1,110 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors, "12345678901234567890", base32-encoded.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeMatchesTheRFC6238Vectors(t *testing.T) {
+	// RFC 6238 Appendix B, truncated to six digits as RFC 4226 does
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := TOTPCode(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("TOTPCode at %d: %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPCodeAcceptsLowercaseSecrets(t *testing.T) {
+	got, err := TOTPCode(strings.ToLower(rfcSecret), time.Unix(59, 0))
+	if err != nil || got != "287082" {
+		t.Errorf("TOTPCode with a lowercase secret = %s, %v, want 287082", got, err)
+	}
+}
+
+func TestTOTPCodeRejectsInvalidSecrets(t *testing.T) {
+	if _, err := TOTPCode("not base32!", time.Unix(59, 0)); err == nil {
+		t.Error("TOTPCode with an invalid secret returned no error")
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0) // Period 37037037
+	code := func(t0 time.Time) string {
+		c, err := TOTPCode(rfcSecret, t0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current period", rfcSecret, code(now), 37037037, true},
+		{"previous period, for clock drift", rfcSecret, code(now.Add(-totpPeriod * time.Second)), 37037036, true},
+		{"next period, for clock drift", rfcSecret, code(now.Add(totpPeriod * time.Second)), 37037038, true},
+		{"two periods ago", rfcSecret, code(now.Add(-2 * totpPeriod * time.Second)), 0, false},
+		{"two periods ahead", rfcSecret, code(now.Add(2 * totpPeriod * time.Second)), 0, false},
+		{"wrong code", rfcSecret, "000000", 0, false},
+		{"too short", rfcSecret, code(now)[:5], 0, false},
+		{"too long", rfcSecret, code(now) + "0", 0, false},
+		{"empty", rfcSecret, "", 0, false},
+		{"invalid secret", "not base32!", code(now), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := VerifyTOTP(tt.secret, tt.code, now)
+			if step != tt.wantStep || ok != tt.wantOK {
+				t.Errorf("VerifyTOTP = %d, %t, want %d, %t", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestVerifyTOTPWithGeneratedSecrets(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("GenerateTOTPSecret returned %d characters, want 32 for 160 bits", len(secret))
+	}
+	now := time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)
+	code, err := TOTPCode(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step, ok := VerifyTOTP(secret, code, now); !ok || step != now.Unix()/totpPeriod {
+		t.Errorf("VerifyTOTP of its own code = %d, %t, want %d, true", step, ok, now.Unix()/totpPeriod)
+	}
+}
+
+func TestTOTPURL(t *testing.T) {
+	got := TOTPURL("ApiRestFinance", "ana@example.com", rfcSecret)
+	want := "otpauth://totp/ApiRestFinance:ana@example.com?digits=6&issuer=ApiRestFinance&period=30&secret=" + rfcSecret
+	if got != want {
+		t.Errorf("TOTPURL = %s, want %s", got, want)
+	}
+}
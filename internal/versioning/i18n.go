@@ -0,0 +1,63 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"ApiRestFinance/internal/i18n"
+	"ApiRestFinance/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// localizeError translates the message of an error body, {"error": "..."} or the coded one of v2,
+// to the language of the request. Errors with a known code and the validation errors of the request
+// binding are translated; the others are left in English, the language the code writes them in.
+// Other fields of the body are kept as they are.
+func localizeError(ctx *gin.Context, body []byte) []byte {
+	lang := middleware.GetLanguageFromContext(ctx)
+	if lang == i18n.English {
+		return body
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes.TrimSpace(body), &fields); err != nil {
+		return body
+	}
+	var message string
+	if err := json.Unmarshal(fields["error"], &message); err == nil {
+		translated, ok := localizeMessage(lang, message)
+		if !ok {
+			return body
+		}
+		fields["error"], _ = json.Marshal(translated)
+		data, _ := json.Marshal(fields)
+		return data
+	}
+
+	var detail map[string]json.RawMessage
+	if err := json.Unmarshal(fields["error"], &detail); err != nil {
+		return body
+	}
+	if err := json.Unmarshal(detail["message"], &message); err != nil {
+		return body
+	}
+	translated, ok := localizeMessage(lang, message)
+	if !ok {
+		return body
+	}
+	detail["message"], _ = json.Marshal(translated)
+	fields["error"], _ = json.Marshal(detail)
+	data, _ := json.Marshal(fields)
+	return data
+}
+
+// localizeMessage translates an error message, telling whether it knew how.
+func localizeMessage(lang i18n.Language, message string) (string, bool) {
+	if code, ok := knownErrorCode(message); ok {
+		if text, ok := i18n.Lookup(lang, "error."+code); ok {
+			return text, true
+		}
+	}
+	return i18n.TranslateValidation(lang, message)
+}
@@ -0,0 +1,62 @@
+package versioning
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Links are the links of a response to related resources, keyed by relation, e.g. "transactions".
+// Paths are relative to the base path of the version, e.g. /credit-accounts/3/transactions.
+type Links map[string]string
+
+// SetLinks adds links to related resources to the response. Versions with a response envelope list
+// them with the response's own links; the others ignore them.
+func SetLinks(ctx *gin.Context, links Links) {
+	value, _ := ctx.Get("api_links")
+	merged, _ := value.(Links)
+	if merged == nil {
+		merged = Links{}
+	}
+	for rel, path := range links {
+		merged[rel] = path
+	}
+	ctx.Set("api_links", merged)
+}
+
+// responseLinks returns the links of a response: its own URL as "self" and those the handler set,
+// made absolute paths of the version.
+func responseLinks(ctx *gin.Context) map[string]string {
+	links := map[string]string{"self": ctx.Request.URL.RequestURI()}
+	value, _ := ctx.Get("api_links")
+	related, _ := value.(Links)
+	base := FromContext(ctx).BasePath()
+	for rel, path := range related {
+		links[rel] = base + path
+	}
+	return links
+}
+
+// pageLinks adds the links to the first, previous, next and last pages of a list to links. The last
+// page is only known when the list has a total.
+func pageLinks(ctx *gin.Context, links map[string]string, page, pageSize int, hasMore bool, totalPages *int) {
+	pageURL := func(page int) string {
+		u := *ctx.Request.URL
+		query := u.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("page_size", strconv.Itoa(pageSize))
+		u.RawQuery = query.Encode()
+		return u.RequestURI()
+	}
+
+	links["first"] = pageURL(1)
+	if page > 1 {
+		links["prev"] = pageURL(page - 1)
+	}
+	if hasMore {
+		links["next"] = pageURL(page + 1)
+	}
+	if totalPages != nil {
+		links["last"] = pageURL(max(*totalPages, 1))
+	}
+}
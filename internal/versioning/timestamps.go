@@ -0,0 +1,79 @@
+package versioning
+
+import (
+	"bytes"
+	"time"
+
+	"ApiRestFinance/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timestamps are shown as RFC 3339 with the offset of the establishment's time zone, e.g.
+// 2026-10-14T09:30:00-05:00, whatever zone they were read from the database in.
+const timestampLayout = time.RFC3339
+
+// localizeTimestamps rewrites the timestamps of a JSON body in the time zone of the request, as
+// told by middleware.GetLocationFromContext. Bodies of requests without one are left as they are,
+// as are strings that aren't RFC 3339 timestamps, e.g. dates alone. The body isn't decoded, so
+// field order and numbers are kept.
+func localizeTimestamps(ctx *gin.Context, body []byte) []byte {
+	var out bytes.Buffer
+	var loc *time.Location
+	resolved := false
+	rewritten := false
+
+	last := 0
+	for i := 0; i < len(body); i++ {
+		if body[i] != '"' {
+			continue
+		}
+		end, escaped := stringEnd(body, i+1)
+		if end < 0 {
+			break
+		}
+		value := body[i+1 : end]
+		if !escaped && looksLikeTimestamp(value) {
+			if t, err := time.Parse(time.RFC3339Nano, string(value)); err == nil {
+				if !resolved {
+					loc, resolved = middleware.GetLocationFromContext(ctx), true
+				}
+				if loc == nil {
+					return body
+				}
+				out.Write(body[last : i+1])
+				out.WriteString(t.In(loc).Format(timestampLayout))
+				last = end
+				rewritten = true
+			}
+		}
+		i = end
+	}
+	if !rewritten {
+		return body
+	}
+	out.Write(body[last:])
+	return out.Bytes()
+}
+
+// stringEnd returns the index of the quote closing the JSON string starting at start, -1 if it's
+// unterminated, and whether the string has escapes.
+func stringEnd(body []byte, start int) (int, bool) {
+	escaped := false
+	for i := start; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			escaped = true
+			i++
+		case '"':
+			return i, escaped
+		}
+	}
+	return -1, escaped
+}
+
+// looksLikeTimestamp is a cheap check for 2006-01-02T15:04:05..., before parsing.
+func looksLikeTimestamp(value []byte) bool {
+	return len(value) >= len("2006-01-02T15:04:05Z") && len(value) <= len("2006-01-02T15:04:05.999999999-07:00") &&
+		value[4] == '-' && value[7] == '-' && value[10] == 'T' && value[13] == ':'
+}
@@ -0,0 +1,325 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ApiRestFinance/internal/model/dto/response"
+	"ApiRestFinance/internal/model/entities/enums"
+	"ApiRestFinance/internal/repository"
+	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// v2Mapper wraps responses in an envelope with their links, and lists with their pagination, and
+// turns {"error": "..."} bodies into coded errors.
+type v2Mapper struct{}
+
+var (
+	errInvalidPage     = errors.New("Invalid page")
+	errInvalidPageSize = errors.New("Invalid page_size")
+)
+
+// errorCodes gives the errors clients are expected to handle a stable code. Handlers put the
+// error message at the end of the one they send, so errors are recognised by suffix.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{service.ErrCreditAccountNotFound, "credit_account_not_found"},
+	{service.ErrCreditAccountExists, "credit_account_exists"},
+	{service.ErrEstablishmentRequired, "establishment_required"},
+	{service.ErrInvalidTransactionType, "invalid_transaction_type"},
+	{service.ErrInsufficientBalance, "insufficient_balance"},
+	{service.ErrInvalidFileType, "invalid_file_type"},
+	{service.ErrFileSizeTooLarge, "file_too_large"},
+	{service.ErrQueryLimitExceeded, "query_limit_exceeded"},
+	{service.ErrInvalidImage, "invalid_image"},
+	{service.ErrInvalidImageDimensions, "invalid_image_dimensions"},
+	{service.ErrImageRejected, "image_rejected"},
+	{service.ErrInvalidSimulation, "invalid_simulation"},
+	{service.ErrInvalidReportPeriod, "invalid_report_period"},
+	{service.ErrNothingToPayOff, "nothing_to_pay_off"},
+	{service.ErrPayoffQuoteChanged, "payoff_quote_changed"},
+	{service.ErrBranchNotFound, "branch_not_found"},
+	{service.ErrEstablishmentNotFound, "establishment_not_found"},
+	{service.ErrInterestRateRequired, "interest_rate_required"},
+	{service.ErrHighRiskPurchaseLimit, "high_risk_purchase_limit"},
+	{service.ErrCreditAccountBlocked, "credit_account_blocked"},
+	{service.ErrCreditAccountAlreadyBlocked, "credit_account_already_blocked"},
+	{service.ErrCreditAccountNotBlocked, "credit_account_not_blocked"},
+	{service.ErrJobNotFound, "job_not_found"},
+	{service.ErrJobNotFinished, "job_not_finished"},
+	{service.ErrJobFailed, "job_failed"},
+	{service.ErrJobHasNoResult, "job_has_no_result"},
+	{service.ErrTwoFactorAlreadyEnabled, "two_factor_already_enabled"},
+	{service.ErrTwoFactorNotSetUp, "two_factor_not_set_up"},
+	{service.ErrTwoFactorNotEnabled, "two_factor_not_enabled"},
+	{service.ErrTwoFactorRequired, "two_factor_required"},
+	{service.ErrInvalidTwoFactorCode, "invalid_two_factor_code"},
+	{service.ErrInvalidTwoFactorChallenge, "invalid_two_factor_challenge"},
+	{service.ErrTwoFactorLocked, "two_factor_locked"},
+	{service.ErrSessionNotFound, "session_not_found"},
+	{service.ErrDocumentSeriesNotFound, "document_series_not_found"},
+	{service.ErrDocumentSeriesExists, "document_series_exists"},
+	{service.ErrInvalidDocumentSeries, "invalid_document_series"},
+	{service.ErrDocumentNumberIssued, "document_number_issued"},
+	{service.ErrInvoiceNotFound, "invoice_not_found"},
+	{service.ErrCategoryNotFound, "category_not_found"},
+	{service.ErrCategoryExists, "category_exists"},
+	{service.ErrCategoryInUse, "category_in_use"},
+	{service.ErrInvalidCategoryName, "invalid_category_name"},
+	{service.ErrProductNotFound, "product_not_found"},
+	{service.ErrInvalidBarcode, "invalid_barcode"},
+	{service.ErrSKUExists, "sku_exists"},
+	{service.ErrBarcodeExists, "barcode_exists"},
+	{service.ErrCreditLimitExceeded, "credit_limit_exceeded"},
+	{service.ErrSpendingLimitExceeded, "spending_limit_exceeded"},
+	{service.ErrPurchaseApprovalNotFound, "purchase_approval_not_found"},
+	{service.ErrPurchaseApprovalDecided, "purchase_approval_decided"},
+	{service.ErrPaymentPromiseExists, "payment_promise_exists"},
+	{service.ErrInvalidPromiseDate, "invalid_promise_date"},
+	{service.ErrLateFeesSuspended, "late_fees_suspended"},
+	{service.ErrCreditAccountWrittenOff, "credit_account_written_off"},
+	{service.ErrAttachmentNotFound, "attachment_not_found"},
+	{service.ErrInvalidAttachmentType, "invalid_attachment_type"},
+	{service.ErrInvalidAttachmentKind, "invalid_attachment_kind"},
+	{service.ErrAttachmentInfected, "attachment_infected"},
+	{service.ErrCreditAgreementNotFound, "credit_agreement_not_found"},
+	{service.ErrCreditAgreementAccepted, "credit_agreement_accepted"},
+	{service.ErrAgreementNotAccepted, "credit_agreement_not_accepted"},
+	{service.ErrEmailInUse, "email_in_use"},
+	{service.ErrDNIInUse, "dni_in_use"},
+	{service.ErrRUCInUse, "ruc_in_use"},
+	{service.ErrInviteCodeNotFound, "invite_code_not_found"},
+	{service.ErrClientSignupNotFound, "client_signup_not_found"},
+	{service.ErrClientSignupPending, "client_signup_pending"},
+	{service.ErrClientSignupDecided, "client_signup_decided"},
+	{repository.ErrBalanceChanged, "balance_changed"},
+	{util.ErrInvalidDate, "invalid_date"},
+	{util.ErrInvalidDateRange, "invalid_date_range"},
+	{service.ErrVersionRequired, "version_required"},
+	{service.ErrVersionConflict, "version_conflict"},
+	{service.ErrInvalidContactChannel, "invalid_contact_channel"},
+	{service.ErrContactAlreadyVerified, "contact_already_verified"},
+	{service.ErrNoPhone, "no_phone"},
+	{service.ErrVerificationCodeTooSoon, "verification_code_too_soon"},
+	{service.ErrInvalidVerificationCode, "invalid_verification_code"},
+	{service.ErrVerificationCodeExpired, "verification_code_expired"},
+	{service.ErrInstallmentNotFound, "installment_not_found"},
+	{service.ErrInstallmentPaid, "installment_paid"},
+	{service.ErrInstallmentCancelled, "installment_cancelled"},
+	{service.ErrInvalidPaymentLinkAmount, "invalid_payment_link_amount"},
+	{service.ErrNoVerifiedContact, "no_verified_contact"},
+	{service.ErrPaymentLinkNotFound, "payment_link_not_found"},
+	{service.ErrPaymentLinkExpired, "payment_link_expired"},
+	{service.ErrPaymentLinkUsed, "payment_link_used"},
+	{service.ErrEmailNotVerified, "email_not_verified"},
+	{service.ErrUserNotFound, "user_not_found"},
+	{service.ErrUserNotClient, "user_not_client"},
+	{service.ErrClientHasOtherAccounts, "client_has_other_accounts"},
+	{service.ErrClientOwesBalance, "client_owes_balance"},
+	{service.ErrUserAnonymized, "user_anonymized"},
+	{service.ErrCreditAccountClosed, "credit_account_closed"},
+	{service.ErrCreditAccountNotClosed, "credit_account_not_closed"},
+	{service.ErrCreditAccountAlreadyClosed, "credit_account_already_closed"},
+	{service.ErrCreditAccountHasBalance, "credit_account_has_balance"},
+	{service.ErrEstablishmentInactive, "establishment_inactive"},
+	{service.ErrEstablishmentActive, "establishment_active"},
+	{service.ErrEstablishmentDeactivated, "establishment_deactivated"},
+	{service.ErrIncorrectPassword, "incorrect_password"},
+	{service.ErrInvalidPurchasePinFormat, "invalid_purchase_pin_format"},
+	{service.ErrPurchasePinRequired, "purchase_pin_required"},
+	{service.ErrPurchasePinNotSet, "purchase_pin_not_set"},
+	{service.ErrInvalidPurchasePin, "invalid_purchase_pin"},
+	{service.ErrPurchasePinLocked, "purchase_pin_locked"},
+	{service.ErrInvalidRescheduleDate, "invalid_reschedule_date"},
+	{service.ErrRescheduleLimitReached, "reschedule_limit_reached"},
+	{service.ErrLateFeeApplied, "late_fee_applied"},
+	{service.ErrAccountAdjustmentNotFound, "account_adjustment_not_found"},
+	{service.ErrAdjustmentApproverRequired, "adjustment_approver_required"},
+	{service.ErrInvalidAdjustmentApprover, "invalid_adjustment_approver"},
+	{service.ErrAdjustmentDecided, "adjustment_decided"},
+	{service.ErrAdjustmentTransaction, "adjustment_transaction"},
+	{service.ErrTillSessionNotFound, "till_session_not_found"},
+	{service.ErrTillSessionOpen, "till_session_open"},
+	{service.ErrTillSessionClosed, "till_session_closed"},
+	{service.ErrDiscrepancyNotesRequired, "discrepancy_notes_required"},
+	{service.ErrInvalidContactDate, "invalid_contact_date"},
+	{service.ErrClientNotFound, "client_not_found"},
+	{service.ErrClientTagNotFound, "client_tag_not_found"},
+	{service.ErrClientTagExists, "client_tag_exists"},
+	{service.ErrInvalidClientTagName, "invalid_client_tag_name"},
+	{service.ErrClientNoteNotFound, "client_note_not_found"},
+	{service.ErrInvalidClientNote, "invalid_client_note"},
+	{service.ErrInvalidConfigImport, "invalid_config_import"},
+	{service.ErrConfigImportConflict, "config_import_conflict"},
+	{service.ErrCreditTemplateNotFound, "credit_template_not_found"},
+	{service.ErrCreditTemplateExists, "credit_template_exists"},
+	{service.ErrInvalidCreditTemplateName, "invalid_credit_template_name"},
+	{service.ErrNoCreditTermChanges, "no_credit_term_changes"},
+	{service.ErrCreditTermUpdateNotFound, "credit_term_update_not_found"},
+	{service.ErrUpgradeRequired, "upgrade_required"},
+	{service.ErrPlanOfBranch, "plan_of_branch"},
+	{service.ErrEstablishmentSuspended, "establishment_suspended"},
+	{service.ErrAlreadySuspended, "already_suspended"},
+	{service.ErrNotSuspended, "not_suspended"},
+	{service.ErrPlatformBranch, "platform_branch"},
+	{service.ErrAccessDenied, "access_denied"},
+	{service.ErrPaymentNotPending, "payment_not_pending"},
+	{service.ErrInvalidPaymentCode, "invalid_payment_code"},
+	{service.ErrPaymentCodeExpired, "payment_code_expired"},
+	{service.ErrPaymentCodeAttempts, "payment_code_attempts"},
+	{service.ErrInvalidBankSignature, "invalid_bank_signature"},
+	{service.ErrStaleBankNotification, "stale_bank_notification"},
+	{service.ErrAccountingEventsUnmapped, "accounting_events_unmapped"},
+	{service.ErrNotPayment, "not_payment"},
+	{service.ErrNotificationNotFound, "notification_not_found"},
+	{service.ErrInvalidInstallmentStatus, "invalid_installment_status"},
+	{service.ErrHolidayNotFound, "holiday_not_found"},
+	{service.ErrHolidayExists, "holiday_exists"},
+	{service.ErrInvalidHolidayDate, "invalid_holiday_date"},
+	{service.ErrInvalidHolidayYear, "invalid_holiday_year"},
+}
+
+func (v2Mapper) MapResponse(ctx *gin.Context, status int, body []byte) (int, []byte) {
+	trimmed := bytes.TrimSpace(body)
+	switch {
+	case status >= http.StatusBadRequest:
+		return status, mapError(status, trimmed)
+	case len(trimmed) == 0:
+		return status, body
+	case trimmed[0] == '[':
+		return mapList(ctx, status, trimmed)
+	}
+	return status, encodeResponse(json.RawMessage(trimmed), nil, responseLinks(ctx))
+}
+
+// mapError gives the message of a v1 error body its code. Other fields, like the current record of
+// a VersionConflictResponse, are kept.
+func mapError(status int, body []byte) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+	var message string
+	if err := json.Unmarshal(fields["error"], &message); err != nil || message == "" {
+		return body
+	}
+	if len(fields) == 1 {
+		return encodeError(errorCode(status, message), message)
+	}
+	fields["error"], _ = json.Marshal(response.ErrorDetail{Code: errorCode(status, message), Message: message})
+	data, _ := json.Marshal(fields)
+	return data
+}
+
+func errorCode(status int, message string) string {
+	if code, ok := knownErrorCode(message); ok {
+		return code
+	}
+	// Fall back to the status, e.g. not_found or internal_server_error
+	return strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+// knownErrorCode returns the code of the errorCodes entry a message ends with.
+func knownErrorCode(message string) (string, bool) {
+	for _, known := range errorCodes {
+		if strings.HasSuffix(message, known.err.Error()) {
+			return known.code, true
+		}
+	}
+	return "", false
+}
+
+func encodeError(code, message string) []byte {
+	data, _ := json.Marshal(response.ErrorResponseV2{Error: response.ErrorDetail{Code: code, Message: message}})
+	return data
+}
+
+// mapList wraps a list in a ResponseV2 with its pagination and the links to the other pages. Lists
+// the handler already paginated are wrapped as they are; the others are paginated here with the
+// page and page_size query parameters.
+func mapList(ctx *gin.Context, status int, body []byte) (int, []byte) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return status, body
+	}
+
+	if value, ok := ctx.Get("api_pagination"); ok {
+		page := value.(pagination)
+		meta := response.PaginationMeta{
+			Page:     page.page,
+			PageSize: page.pageSize,
+			HasMore:  len(items) == page.pageSize,
+		}
+		if page.total != nil {
+			totalPages := (*page.total + page.pageSize - 1) / page.pageSize
+			meta.TotalItems = page.total
+			meta.TotalPages = &totalPages
+			meta.HasMore = page.page < totalPages
+		}
+		return status, encodeList(ctx, items, meta)
+	}
+
+	page, pageSize, err := pageFromQuery(ctx)
+	if err != nil {
+		return http.StatusBadRequest, encodeError("invalid_pagination", err.Error())
+	}
+
+	total := len(items)
+	totalPages := (total + pageSize - 1) / pageSize
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	return status, encodeList(ctx, items[start:end], response.PaginationMeta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: &total,
+		TotalPages: &totalPages,
+		HasMore:    end < total,
+	})
+}
+
+func encodeList(ctx *gin.Context, items []json.RawMessage, meta response.PaginationMeta) []byte {
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	links := responseLinks(ctx)
+	pageLinks(ctx, links, meta.Page, meta.PageSize, meta.HasMore, meta.TotalPages)
+	return encodeResponse(items, &response.MetaV2{Pagination: &meta}, links)
+}
+
+func encodeResponse(data interface{}, meta *response.MetaV2, links map[string]string) []byte {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	// Keep the & of link queries readable
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(response.ResponseV2{Data: data, Meta: meta, Links: links})
+	return bytes.TrimSuffix(body.Bytes(), []byte("\n"))
+}
+
+// pageFromQuery reads the page the same way the paginated v1 endpoints do, within the caller's query limits.
+func pageFromQuery(ctx *gin.Context) (int, int, error) {
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		return 0, 0, errInvalidPage
+	}
+	requestedPageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "0"))
+	if err != nil || requestedPageSize < 0 {
+		return 0, 0, errInvalidPageSize
+	}
+
+	role, _ := ctx.Get("rol")
+	userRole, _ := role.(enums.Role)
+	pageSize, err := service.QueryLimitsForRole(userRole).ResolvePageSize(requestedPageSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	return page, pageSize, nil
+}
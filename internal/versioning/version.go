@@ -0,0 +1,87 @@
+package versioning
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version identifies a public contract of the API. All versions share the same handlers and
+// services; each one maps the JSON the handlers write to its own contract.
+type Version string
+
+const (
+	V1 Version = "v1" // The original contract: bare lists and {"error": "..."} bodies
+	V2 Version = "v2" // {data, meta, links} envelopes, paginated lists and coded errors
+)
+
+// Header tells clients which version served the response.
+const Header = "API-Version"
+
+// Versions lists the versions the router serves, oldest first.
+var Versions = []Version{V1, V2}
+
+// ResponseMapper turns the status and JSON body written by a handler into the ones of an API version.
+type ResponseMapper interface {
+	MapResponse(ctx *gin.Context, status int, body []byte) (int, []byte)
+}
+
+var mappers = map[Version]ResponseMapper{
+	V1: v1Mapper{},
+	V2: v2Mapper{},
+}
+
+// BasePath returns the path prefix of the version's routes, e.g. /api/v2.
+func (v Version) BasePath() string {
+	return "/api/" + string(v)
+}
+
+// Middleware stores the version in the context and passes every JSON response through the
+// version's mapper. Other responses (files, streams) are written untouched.
+func Middleware(version Version) gin.HandlerFunc {
+	mapper := mappers[version]
+	return func(c *gin.Context) {
+		c.Set("api_version", version)
+		c.Header(Header, string(version))
+
+		writer := &mappedResponseWriter{ResponseWriter: c.Writer, status: c.Writer.Status()}
+		c.Writer = writer
+		defer func() {
+			// Let the recovery middleware answer a panic on the real writer
+			if r := recover(); r != nil {
+				c.Writer = writer.ResponseWriter
+				panic(r)
+			}
+		}()
+		c.Next()
+		writer.finish(c, mapper)
+	}
+}
+
+// FromContext returns the version the request was routed to, V1 outside a versioned group.
+func FromContext(ctx *gin.Context) Version {
+	value, _ := ctx.Get("api_version")
+	if version, ok := value.(Version); ok {
+		return version
+	}
+	return V1
+}
+
+// SetPagination records that the handler already returned a single page of its list, so
+// versions that paginate lists describe that page instead of paginating it again.
+func SetPagination(ctx *gin.Context, page, pageSize int) {
+	ctx.Set("api_pagination", pagination{page: page, pageSize: pageSize})
+}
+
+// SetPaginationTotal is SetPagination for handlers that also counted the matches across all pages.
+// The count is sent in the X-Total-Count header, and versions that paginate lists add it to their metadata.
+func SetPaginationTotal(ctx *gin.Context, page, pageSize, total int) {
+	ctx.Header("X-Total-Count", strconv.Itoa(total))
+	ctx.Set("api_pagination", pagination{page: page, pageSize: pageSize, total: &total})
+}
+
+type pagination struct {
+	page     int
+	pageSize int
+	total    *int // nil when the handler didn't count the matches
+}
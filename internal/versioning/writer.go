@@ -0,0 +1,110 @@
+package versioning
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mappedResponseWriter holds back JSON responses until the handler is done so the version's
+// mapper can rewrite them. As soon as anything else is written it gets out of the way.
+type mappedResponseWriter struct {
+	gin.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	buffering   bool
+	passthrough bool
+}
+
+func (w *mappedResponseWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+// WriteHeaderNow is deferred to finish unless the response is passed through.
+func (w *mappedResponseWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *mappedResponseWriter) Write(data []byte) (int, error) {
+	if !w.buffering && !w.passthrough {
+		if strings.Contains(w.Header().Get("Content-Type"), "application/json") {
+			w.buffering = true
+		} else {
+			w.startPassthrough()
+		}
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *mappedResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush means the handler is streaming, so nothing can be rewritten anymore.
+func (w *mappedResponseWriter) Flush() {
+	if w.buffering {
+		return
+	}
+	if !w.passthrough {
+		w.startPassthrough()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *mappedResponseWriter) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *mappedResponseWriter) Size() int {
+	if w.buffering {
+		return w.body.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+func (w *mappedResponseWriter) Written() bool {
+	return w.buffering || w.ResponseWriter.Written()
+}
+
+func (w *mappedResponseWriter) startPassthrough() {
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// finish writes the buffered response, mapped for the version, to the client.
+func (w *mappedResponseWriter) finish(ctx *gin.Context, mapper ResponseMapper) {
+	ctx.Writer = w.ResponseWriter
+	if w.passthrough {
+		return
+	}
+
+	status, body := w.status, w.body.Bytes()
+	if w.buffering {
+		status, body = mapper.MapResponse(ctx, status, body)
+		if status >= http.StatusBadRequest {
+			body = localizeError(ctx, body)
+		} else {
+			body = localizeTimestamps(ctx, body)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+	if len(body) > 0 {
+		_, _ = w.ResponseWriter.Write(body)
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
@@ -0,0 +1,92 @@
+// Package webhook delivers domain events to HTTP endpoints configured by the operator.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ApiRestFinance/internal/event"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Message is the JSON body a webhook receives for an event. The ID is the same on every delivery
+// attempt, so receivers can discard duplicates.
+type Message struct {
+	ID              uint            `json:"id"`
+	Event           event.Name      `json:"event"`
+	CreditAccountID uint            `json:"credit_account_id"`
+	OccurredAt      time.Time       `json:"occurred_at"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// Publisher posts events to a list of endpoints. When a secret is set, each request is signed with
+// an HMAC-SHA256 of its body in the X-Webhook-Signature header, as "sha256=<hex>".
+type Publisher struct {
+	urls   []string
+	secret string
+	client *http.Client
+}
+
+// NewPublisher creates a Publisher for the given endpoints.
+func NewPublisher(urls []string, secret string) *Publisher {
+	return &Publisher{urls: urls, secret: secret, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// PublishEvent posts evt, whose payload must already be JSON, to every endpoint. It fails if any
+// endpoint doesn't answer with a 2xx status; the event is then posted to all of them again later.
+func (p *Publisher) PublishEvent(id uint, evt event.Event) error {
+	data, _ := evt.Payload.(json.RawMessage)
+	if data == nil {
+		data = json.RawMessage("{}")
+	}
+	body, err := json.Marshal(Message{
+		ID:              id,
+		Event:           evt.Name,
+		CreditAccountID: evt.CreditAccountID,
+		OccurredAt:      evt.OccurredAt,
+		Data:            data,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding webhook: %w", err)
+	}
+
+	for _, url := range p.urls {
+		if err := p.post(url, evt.Name, id, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) post(url string, name event.Name, id uint, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(name))
+	req.Header.Set("X-Webhook-ID", strconv.FormatUint(uint64(id), 10))
+	if p.secret != "" {
+		mac := hmac.New(sha256.New, []byte(p.secret))
+		mac.Write(body)
+		req.Header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting webhook to %s: %w", url, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s answered %s", url, resp.Status)
+	}
+	return nil
+}
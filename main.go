@@ -1,28 +1,27 @@
 package main
 
 import (
+	"ApiRestFinance/internal/app"
 	"ApiRestFinance/internal/config"
-	"ApiRestFinance/internal/controller"
-	"ApiRestFinance/internal/middleware"
-	"ApiRestFinance/internal/model/entities"
-	"ApiRestFinance/internal/repository"
-	"ApiRestFinance/internal/service"
+	"ApiRestFinance/internal/database"
+	"ApiRestFinance/internal/migration"
 
+	"context"
+	"flag"
 	"fmt"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 	"log"
-	"os"
+	"net/http"
+	"strings"
+	"time"
 
 	_ "ApiRestFinance/docs" // Import swagger docs for documentation
 
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // @title Final Assignment Finance API Rest
 // @version 1.0
-// @description API for managing finances in small businesses.
+// @description API for managing finances in small businesses. Every endpoint is also served under /api/v2, which wraps responses in a {data, meta, links} envelope (response.ResponseV2) with links to the response itself and related resources, paginates lists (page and page_size query parameters, with links to the other pages) and returns errors as response.ErrorResponseV2 with a stable code.
 // @termsOfService http://swagger.io/terms/
 
 // @contact.name API Support
@@ -35,157 +34,138 @@ import (
 // @BasePath /api/v1
 
 func main() {
-	// Load configuration
-	cfg, err := config.LoadConfig()
+	rollback := flag.Bool("migrate-rollback", false, "Roll back the last database migration and exit")
+	rollbackTo := flag.String("migrate-rollback-to", "", "Roll back every database migration applied after the given one and exit")
+	seed := flag.Bool("seed", false, "Fill the database with demo establishments, clients and months of their history and exit")
+	superAdminEmail := flag.String("create-superadmin", "", "Create a super-admin with the given email, print their generated password and exit")
+	superAdminName := flag.String("superadmin-name", "Platform operator", "Name of the super-admin created with -create-superadmin")
+	superAdminDNI := flag.String("superadmin-dni", "", "DNI of the super-admin created with -create-superadmin")
+	flag.Parse()
+
+	// Load configuration. Upload limits are reloaded while the API runs, everything else needs a restart.
+	cfg, err := config.Load()
 	if err != nil {
 		log.Fatal("Error loading configuration: ", err)
 	}
-
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = cfg.ServerPort
+	configWatcher := config.NewWatcher(cfg)
+	configWatcher.Start(context.Background())
+
+	db, err := database.Open(cfg.Database.DSN(), database.Pool{
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+	})
+	if err != nil {
+		log.Fatal("Error opening database: ", err)
 	}
 
-	db := cfg.DB
-
-	// Migrate the database
+	// Roll back migrations and exit when asked to, migrate the database otherwise
+	if *rollback || *rollbackTo != "" {
+		if err := rollbackDB(db, *rollbackTo); err != nil {
+			log.Fatal("Error rolling back migrations: ", err)
+		}
+		return
+	}
 	if err := migrateDB(db); err != nil {
 		log.Fatal("Error migrating database: ", err)
 	}
 
-	// Initialize repositories
-	userRepo := repository.NewUserRepository(db)
-	clientRepo := repository.NewClientRepository(db)
-	establishmentRepo := repository.NewEstablishmentRepository(db)
-	productRepo := repository.NewProductRepository(db)
-	creditAccountRepo := repository.NewCreditAccountRepository(db, userRepo)
-	transactionRepo := repository.NewTransactionRepository(db)
-	installmentRepo := repository.NewInstallmentRepository(db)
-
-	// Initialize services
-	authService := service.NewAuthService(userRepo, establishmentRepo, cfg.JwtSecret)
-	userService := service.NewUserService(userRepo, creditAccountRepo)
-	adminService := service.NewAdminService(establishmentRepo, userRepo)
-	establishmentService := service.NewEstablishmentService(establishmentRepo, userRepo)
-	productService := service.NewProductService(productRepo, establishmentRepo, userRepo)
-	creditAccountService := service.NewCreditAccountService(creditAccountRepo, transactionRepo, installmentRepo, clientRepo, establishmentRepo) // Update to use userRepo
-	transactionService := service.NewTransactionService(transactionRepo, creditAccountRepo)
-	installmentService := service.NewInstallmentService(installmentRepo)
-	purchaseService := service.NewPurchaseService(userRepo, establishmentRepo, productRepo, creditAccountRepo, transactionRepo, installmentRepo)
-
-	// Initialize controllers
-	authController := controller.NewAuthController(authService)
-	userController := controller.NewUserController(userService, adminService, creditAccountService, establishmentService) // Use the new UserController
-	establishmentController := controller.NewEstablishmentController(establishmentService)
-	productController := controller.NewProductController(productService, establishmentService)
-	creditAccountController := controller.NewCreditAccountController(creditAccountService, establishmentService)
-	transactionController := controller.NewTransactionController(transactionService)
-	installmentController := controller.NewInstallmentController(installmentService)
-	purchaseController := controller.NewPurchaseController(purchaseService)
-
-	router := gin.Default()
-	gin.SetMode(gin.ReleaseMode)
-	router.Use(gin.Recovery())
-	router.Use(middleware.CorsMiddleware())
-
-	// Swagger documentation
-	url := ginSwagger.URL("/swagger/doc.json")
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
-
-	// Public routes
-	publicRoutes := router.Group("/api/v1")
-	{
-		publicRoutes.POST("/register", authController.RegisterAdmin)
-		publicRoutes.POST("/login", authController.Login)
-		publicRoutes.POST("/refresh", authController.RefreshToken)
-	}
-
-	// Protected routes (require authentication)
-	protectedRoutes := router.Group("/api/v1", middleware.AuthMiddleware(cfg.JwtSecret))
-	{
-		// User routes
-		protectedRoutes.POST("/clients", userController.CreateClient)
-		protectedRoutes.GET("/users/:id", userController.GetUserByID)
-		protectedRoutes.PUT("/users/:id", userController.UpdateUser)
-		protectedRoutes.DELETE("/users/:id", userController.DeleteUser)
-		protectedRoutes.GET("/admins/me", userController.GetAdminProfile)
-		protectedRoutes.PUT("/admins/me", userController.UpdateAdminProfile)
-		protectedRoutes.GET("/establishments/:establishmentID/clients", userController.GetClientsByEstablishmentID)
-		protectedRoutes.POST("/users/:id/photo", userController.UploadUserPhoto)
-		protectedRoutes.PUT("/users/:id/password", userController.UpdatePassword)
-		protectedRoutes.GET("/users/email-to-id", userController.GetUserIDByEmail)
-
-		// Establishment routes
-		protectedRoutes.GET("/establishments/me", establishmentController.GetEstablishment)
-		protectedRoutes.PUT("/establishments/me", establishmentController.UpdateEstablishment)
-		protectedRoutes.GET("/establishments/:establishmentID", establishmentController.GetEstablishmentByID)
-
-		// Product routes
-		protectedRoutes.POST("/products", productController.CreateProduct)
-		protectedRoutes.GET("/products/:id", productController.GetProductByID)
-		protectedRoutes.GET("/establishments/:establishmentID/products", productController.GetAllProductsByEstablishmentID)
-		protectedRoutes.PUT("/products/:id", productController.UpdateProduct)
-		protectedRoutes.DELETE("/products/:id", productController.DeleteProduct)
-
-		// Credit Account Routes
-		protectedRoutes.POST("/credit-accounts", creditAccountController.CreateCreditAccount)
-		protectedRoutes.GET("/credit-accounts/:id", creditAccountController.GetCreditAccountByID)
-		protectedRoutes.PUT("/clients/:clientID/credit-account", userController.UpdateClientCreditAccount)
-		protectedRoutes.DELETE("/credit-accounts/:id", creditAccountController.DeleteCreditAccount)
-		protectedRoutes.GET("/establishments/:establishmentID/credit-accounts", creditAccountController.GetCreditAccountsByEstablishmentID)
-		protectedRoutes.GET("/clients/:clientID/credit-account", creditAccountController.GetCreditAccountByClientID)
-		protectedRoutes.POST("/credit-accounts/:id/apply-interest", creditAccountController.ApplyInterestToAccount)
-		protectedRoutes.POST("/credit-accounts/:id/apply-late-fee", creditAccountController.ApplyLateFeeToAccount)
-		protectedRoutes.GET("/credit-accounts/overdue", creditAccountController.GetOverdueCreditAccounts)
-		protectedRoutes.POST("/credit-accounts/:id/purchases", creditAccountController.ProcessPurchase)
-		protectedRoutes.POST("/credit-accounts/:id/payments", creditAccountController.ProcessPayment)
-		protectedRoutes.GET("/credit-accounts/debt-summary", creditAccountController.GetAdminDebtSummary)
-
-		// Transaction Routes
-		protectedRoutes.POST("/transactions", transactionController.CreateTransaction)
-		protectedRoutes.GET("/transactions/:id", transactionController.GetTransactionByID)
-		protectedRoutes.PUT("/transactions/:id", transactionController.UpdateTransaction)
-		protectedRoutes.DELETE("/transactions/:id", transactionController.DeleteTransaction)
-		protectedRoutes.GET("/credit-accounts/:id/transactions", transactionController.GetTransactionsByCreditAccountID)
-		protectedRoutes.POST("/transactions/:id/confirm", transactionController.ConfirmPayment)
-
-		// Purchase Routes
-		protectedRoutes.POST("/purchases", purchaseController.CreatePurchase)
-		protectedRoutes.GET("/clients/me/balance", purchaseController.GetClientBalance)
-		protectedRoutes.GET("/clients/me/transactions", purchaseController.GetClientTransactions)
-		protectedRoutes.GET("/clients/me/overdue-balance", purchaseController.GetClientOverdueBalance)
-		protectedRoutes.GET("/clients/me/installments", purchaseController.GetClientInstallments)
-		protectedRoutes.GET("/clients/me/credit-account", purchaseController.GetClientCreditAccount)
-		protectedRoutes.GET("/clients/me/account-summary", purchaseController.GetClientAccountSummary)     // New endpoint
-		protectedRoutes.GET("/clients/me/account-statement", purchaseController.GetClientAccountStatement) // New endpoint
-		protectedRoutes.GET("/clients/me/account-statement/pdf", purchaseController.GetClientAccountStatementPDF)
-
-		// Installment Routes
-		protectedRoutes.POST("/installments", installmentController.CreateInstallment)
-		protectedRoutes.GET("/installments/:id", installmentController.GetInstallmentByID)
-		protectedRoutes.PUT("/installments/:id", installmentController.UpdateInstallment)
-		protectedRoutes.DELETE("/installments/:id", installmentController.DeleteInstallment)
-		protectedRoutes.GET("/credit-accounts/:id/installments", installmentController.GetInstallmentsByCreditAccountID)
-		protectedRoutes.GET("/credit-accounts/:id/installments/overdue", installmentController.GetOverdueInstallments)
-
-		// Authentication route (reset password)
-		protectedRoutes.POST("/reset-password", authController.ResetPassword)
-	}
-
-	fmt.Printf("Starting server on port %s...\n", port)
-	if err := router.Run(":" + port); err != nil {
+	// Demo data for frontend development and QA, never in production
+	if *seed {
+		if cfg.IsProduction() {
+			log.Fatal("Refusing to seed demo data in production")
+		}
+		if err := seedDB(db); err != nil {
+			log.Fatal("Error seeding demo data: ", err)
+		}
+		return
+	}
+
+	// Reports and statements read from the replicas that keep up with the primary, if any are configured
+	if len(cfg.Database.ReplicaHosts) > 0 {
+		replicas, err := database.UseReplicas(db, cfg.Database.ReplicaDSNs(), cfg.Database.ReplicaMaxLag, 5*time.Second)
+		if err != nil {
+			log.Fatal("Error connecting to read replicas: ", err)
+		}
+		replicas.Start(context.Background())
+	}
+
+	// Repositories, services and controllers; the workers and scheduled jobs run next to the server
+	application, err := app.Build(cfg, configWatcher, db)
+	if err != nil {
+		log.Fatal("Error building the API: ", err)
+	}
+
+	// Super-admins are only created from the command line, never through the API
+	if *superAdminEmail != "" {
+		if err := createSuperAdmin(application, *superAdminDNI, *superAdminName, *superAdminEmail); err != nil {
+			log.Fatal("Error creating super-admin: ", err)
+		}
+		return
+	}
+	application.Start(context.Background())
+	application.ScheduleJobs(context.Background())
+
+	server := &http.Server{
+		Addr:              ":" + cfg.Server.Port,
+		Handler:           application.Router(),
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+	fmt.Printf("Starting server on port %s...\n", cfg.Server.Port)
+	if err := server.ListenAndServe(); err != nil {
 		log.Fatal("Error starting server: ", err)
 	}
 }
 
 // Migrate the database tables
 func migrateDB(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&entities.User{},
-		&entities.Establishment{},
-		&entities.Product{},
-		&entities.CreditAccount{},
-		&entities.Transaction{},
-		&entities.Installment{},
-	)
+	migrator, err := migration.New(db, migration.All())
+	if err != nil {
+		return err
+	}
+	return migrator.Migrate()
+}
+
+// rollbackDB rolls back the last migration, or every migration after rollbackTo when it is set.
+func rollbackDB(db *gorm.DB, rollbackTo string) error {
+	migrator, err := migration.New(db, migration.All())
+	if err != nil {
+		return err
+	}
+	if rollbackTo != "" {
+		return migrator.RollbackTo(rollbackTo)
+	}
+	return migrator.RollbackLast()
+}
+
+// seedDB creates six months of demo data and prints how to log in with it.
+func seedDB(db *gorm.DB) error {
+	data, err := app.SeedDemoData(db, 6)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Seeded %d establishments, %d products, %d credit accounts, %d purchases, %d payments and %d installments\n",
+		data.Establishments, data.Products, data.CreditAccounts, data.Purchases, data.Payments, data.Installments)
+	fmt.Printf("Admins: %s\n", strings.Join(data.AdminEmails, ", "))
+	fmt.Printf("Clients: %s\n", strings.Join(data.ClientEmails, ", "))
+	fmt.Printf("Password of every demo user: %s\n", data.Password)
+	return nil
+}
+
+// createSuperAdmin creates a super-admin and prints their generated password, which is shown only this once.
+func createSuperAdmin(application *app.App, dni, name, email string) error {
+	if dni == "" {
+		return fmt.Errorf("-superadmin-dni is required")
+	}
+	password, err := application.Services.Platform.CreateSuperAdmin(dni, name, email)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created super-admin %s\n", email)
+	fmt.Printf("Password, shown only once: %s\n", password)
+	fmt.Println("Two-factor authentication has to be set up on the first login")
+	return nil
 }